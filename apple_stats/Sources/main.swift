@@ -109,6 +109,9 @@ public func getGPUStats() -> [String: Any] {
         GPUStats["PC20"] = PC20 ?? 0
         GPUStats["PC22"] = PC22 ?? 0
         GPUStats["PC40"] = PC40 ?? 0
+
+        // Thermal pressure (0: nominal, 1: fair, 2: serious, 3: critical)
+        GPUStats["thermalState"] = ProcessInfo.processInfo.thermalState.rawValue
     }
 
     return GPUStats
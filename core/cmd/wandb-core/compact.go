@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/wandb/wandb/core/pkg/server"
+)
+
+// compact writes a compacted copy of a transaction log and prints how much
+// smaller it is as JSON, and returns the exit code.
+func compact(path string, to string) int {
+	if to == "" {
+		fmt.Fprintln(os.Stderr, "compact: -compact-to is required")
+		return 2
+	}
+
+	result, err := server.Compact(path, to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "compact: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		fmt.Fprintf(os.Stderr, "compact: failed to print result: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/loadgen"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+// loadgenReport is what a load generation prints.
+type loadgenReport struct {
+	Mix              string  `json:"mix"`
+	RunID            string  `json:"run_id"`
+	Dir              string  `json:"dir"`
+	Records          int     `json:"records"`
+	Seconds          float64 `json:"seconds"`
+	RecordsPerSecond float64 `json:"records_per_second"`
+	P50Ms            float64 `json:"p50_ms"`
+	P95Ms            float64 `json:"p95_ms"`
+}
+
+// generateLoad sends a run with the named mix of records to the backend
+// at WANDB_BASE_URL, for soak tests, prints how the stream kept up as
+// JSON, and returns the exit code.
+func generateLoad(mixName string, records int, project string) int {
+	mix, ok := loadgen.Mixes[mixName]
+	if !ok {
+		var names []string
+		for name := range loadgen.Mixes {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		fmt.Fprintf(os.Stderr,
+			"loadgen: unknown mix %q, expected one of %s\n",
+			mixName, strings.Join(names, ", "))
+		return 2
+	}
+	baseURL := os.Getenv("WANDB_BASE_URL")
+	apiKey := os.Getenv("WANDB_API_KEY")
+	if baseURL == "" || apiKey == "" {
+		fmt.Fprintln(os.Stderr, "loadgen: WANDB_BASE_URL and WANDB_API_KEY are required")
+		return 2
+	}
+
+	dir, err := os.MkdirTemp("", "wandb-loadgen-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		return 1
+	}
+	runID := utils.ShortID(8)
+	session, err := loadgen.Start(loadgen.Settings(dir, runID, baseURL, apiKey), project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		return 1
+	}
+	generator, err := session.Generator(mix)
+	if err != nil {
+		session.Finish(1)
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		return 1
+	}
+	result, err := session.Send(generator, records)
+	if err != nil {
+		session.Finish(1)
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		return 1
+	}
+	session.Finish(0)
+
+	p50, _ := result.Percentile(0.5)
+	p95, _ := result.Percentile(0.95)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(loadgenReport{
+		Mix:              mixName,
+		RunID:            runID,
+		Dir:              dir,
+		Records:          result.Records,
+		Seconds:          result.Duration.Seconds(),
+		RecordsPerSecond: result.RecordsPerSecond(),
+		P50Ms:            float64(p50) / float64(time.Millisecond),
+		P95Ms:            float64(p95) / float64(time.Millisecond),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: failed to print result: %v\n", err)
+		return 1
+	}
+	return 0
+}
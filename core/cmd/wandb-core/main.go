@@ -10,6 +10,7 @@ import (
 	"runtime/trace"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/wandb/wandb/core/internal/redact"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/server"
 )
@@ -28,11 +29,31 @@ func main() {
 	enableDebugLogging := flag.Bool("debug", false, "enable debug logging")
 	disableAnalytics := flag.Bool("no-observability", false, "turn off observability")
 	traceFile := flag.String("trace", "", "file name to write trace output to")
+	maxConnections := flag.Int("max-connections", 0, "the most client connections handled at once, or 0 for no limit")
+	maxStreams := flag.Int("max-streams", 0, "the most runs open at once, or 0 for no limit")
+	replayPath := flag.String("replay", "", "replay a .wandb file through a new handler and print the derived state")
+	untilOffset := flag.Int64("until-offset", -1, "with -replay, stop after the record at this offset")
+	replayFull := flag.Bool("replay-full", false, "with -replay, start from the first record even if the log has a checkpoint")
+	compactPath := flag.String("compact", "", "write a compacted copy of a finished run's .wandb file to the path given by -compact-to")
+	compactTo := flag.String("compact-to", "", "with -compact, the path of the compacted copy, which mustn't exist")
+	loadgenMix := flag.String("loadgen", "", "send a run with this mix of records (history, console or files) to WANDB_BASE_URL and print how the stream kept up")
+	loadgenRecords := flag.Int("loadgen-records", 100000, "with -loadgen, the number of records to send")
+	loadgenProject := flag.String("loadgen-project", "loadgen", "with -loadgen, the project of the run")
 	// TODO: remove these flags, they are here for backward compatibility
 	_ = flag.Bool("serve-sock", false, "use sockets")
 
 	flag.Parse()
 
+	if *replayPath != "" {
+		os.Exit(replay(*replayPath, *untilOffset, !*replayFull))
+	}
+	if *compactPath != "" {
+		os.Exit(compact(*compactPath, *compactTo))
+	}
+	if *loadgenMix != "" {
+		os.Exit(generateLoad(*loadgenMix, *loadgenRecords, *loadgenProject))
+	}
+
 	// set up sentry reporting
 	observability.InitSentry(*disableAnalytics, commit)
 	defer sentry.Flush(2)
@@ -48,8 +69,9 @@ func main() {
 			level = slog.LevelDebug
 		}
 		opts := &slog.HandlerOptions{
-			Level:     level,
-			AddSource: false,
+			Level:       level,
+			AddSource:   false,
+			ReplaceAttr: redact.Attr,
 		}
 		logger := slog.New(slog.NewJSONHandler(file, opts))
 		slog.SetDefault(logger)
@@ -61,6 +83,8 @@ func main() {
 			slog.Int("pid", *pid),
 			slog.Bool("debug", *enableDebugLogging),
 			slog.Bool("disable-analytics", *disableAnalytics),
+			slog.Int("max-connections", *maxConnections),
+			slog.Int("max-streams", *maxStreams),
 		)
 		loggerPath = file.Name()
 		defer file.Close()
@@ -91,6 +115,8 @@ func main() {
 		return
 	}
 	srv.SetDefaultLoggerPath(loggerPath)
+	srv.SetMaxConnections(*maxConnections)
+	srv.SetMaxStreams(*maxStreams)
 	srv.Start()
 	srv.Wait()
 	srv.Close()
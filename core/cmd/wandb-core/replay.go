@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/wandb/wandb/core/pkg/server"
+)
+
+// replay prints the state derived from replaying a transaction log as
+// JSON, and returns the exit code.
+func replay(path string, untilOffset int64, fromCheckpoint bool) int {
+	state, err := server.Replay(path, untilOffset, fromCheckpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		fmt.Fprintf(os.Stderr, "replay: failed to print state: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -38,8 +39,32 @@ type Backend struct {
 	// user. There's no guarantee that all logs are made at the Debug level.
 	logger *slog.Logger
 
+	// mu guards apiKey and unauthorizedReported.
+	mu sync.Mutex
+
 	// API key for backend requests.
+	//
+	// It can be replaced while requests are being made using UpdateAPIKey.
 	apiKey string
+
+	// Whether onUnauthorized was called for the current API key.
+	unauthorizedReported bool
+
+	// Called the first time the backend rejects the current API key.
+	onUnauthorized func()
+
+	// Tracks whether the backend is reachable, or nil if requests are
+	// retried regardless.
+	connectivity *connectivity
+
+	// The client used by Probe.
+	probeClient *http.Client
+
+	// Measures the local clock's skew, or nil if it isn't measured.
+	clockSkew *ClockSkew
+
+	// Notices requests rejected for exceeding a limit.
+	quota *QuotaWatcher
 }
 
 // An HTTP client for interacting with the W&B backend.
@@ -116,6 +141,36 @@ type BackendOptions struct {
 
 	// W&B API key.
 	APIKey string
+
+	// Optional callback for when the backend rejects the API key.
+	//
+	// It is called at most once per API key, the first time a request
+	// made with that key fails with a 401 status after all retries. This
+	// usually means that the credentials expired.
+	OnUnauthorized func()
+
+	// How long requests must fail with network errors before the backend
+	// is considered unreachable, or 0 to never consider it unreachable.
+	//
+	// Requests to an unreachable backend fail with ErrNetworkUnavailable
+	// without being sent, until a call to Probe succeeds.
+	OfflineAfter time.Duration
+
+	// Optional callback for the first time the backend becomes unreachable.
+	OnOffline func()
+
+	// The transport used by Probe, if not nil.
+	ProbeTransport http.RoundTripper
+
+	// The smallest skew of the local clock from the backend's to correct
+	// timestamps for, or 0 to not measure the skew.
+	ClockSkewThreshold time.Duration
+
+	// Optional callback for when a request is rejected because the user's
+	// organization exceeded one of its limits.
+	//
+	// It is called at most once per limit. Such requests aren't retried.
+	OnQuotaExceeded func(*QuotaExceededError)
 }
 
 // Creates a [Backend].
@@ -123,10 +178,65 @@ type BackendOptions struct {
 // The `baseURL` is the scheme and hostname for contacting the server, not
 // including a final slash. Example "http://localhost:8080".
 func New(opts BackendOptions) *Backend {
-	return &Backend{
-		baseURL: opts.BaseURL,
-		logger:  opts.Logger,
-		apiKey:  opts.APIKey,
+	backend := &Backend{
+		baseURL:        opts.BaseURL,
+		logger:         opts.Logger,
+		apiKey:         opts.APIKey,
+		onUnauthorized: opts.OnUnauthorized,
+		probeClient: &http.Client{
+			Transport: opts.ProbeTransport,
+			Timeout:   probeTimeout,
+		},
+		clockSkew: NewClockSkew(opts.ClockSkewThreshold, opts.Logger),
+		quota:     NewQuotaWatcher(opts.OnQuotaExceeded),
+	}
+
+	if opts.OfflineAfter > 0 {
+		backend.connectivity = &connectivity{
+			offlineAfter: opts.OfflineAfter,
+			logger:       opts.Logger,
+			onOffline:    opts.OnOffline,
+		}
+	}
+
+	return backend
+}
+
+// UpdateAPIKey replaces the API key used by all clients of the backend.
+//
+// Requests that are already in flight, including their retries, finish
+// with the old key; requests made afterward use the new one.
+func (backend *Backend) UpdateAPIKey(apiKey string) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.apiKey = apiKey
+	backend.unauthorizedReported = false
+}
+
+// Returns the API key to use for a new request.
+func (backend *Backend) currentAPIKey() string {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	return backend.apiKey
+}
+
+// Reports that the backend rejected a request made with the API key.
+//
+// This does nothing if the key was replaced since the request was made.
+func (backend *Backend) reportUnauthorized(apiKey string) {
+	backend.mu.Lock()
+	if backend.apiKey != apiKey || backend.unauthorizedReported {
+		backend.mu.Unlock()
+		return
+	}
+	backend.unauthorizedReported = true
+	backend.mu.Unlock()
+
+	if backend.logger != nil {
+		backend.logger.Warn("api: the API key was rejected")
+	}
+	if backend.onUnauthorized != nil {
+		backend.onUnauthorized()
 	}
 }
 
@@ -142,10 +252,16 @@ type ClientOptions struct {
 
 	// Function that determines whether to retry based on the response.
 	//
-	// If nil, then retries are made on connection errors and server errors
-	// (HTTP status code >=500).
+	// If nil, then RetryPolicy(nil) is used, which retries the failures
+	// that ClassifyResponse considers Retryable.
 	RetryPolicy retryablehttp.CheckRetry
 
+	// Called for each failed attempt that the retry policy retries, if
+	// not nil.
+	//
+	// This is for counting retries; it can't change the retry decision.
+	OnRetry func(resp *http.Response, err error)
+
 	// Timeout for HTTP requests.
 	//
 	// This is the time to wait for an individual HTTP request to complete
@@ -164,6 +280,16 @@ type ClientOptions struct {
 	// on the request and response. Need to make sure that the response body is
 	// available to read by later stages.
 	NetworkPeeker Peeker
+
+	// The transport that makes the requests, if not nil.
+	//
+	// By default, the client has a transport of its own.
+	Transport http.RoundTripper
+
+	// Wraps the transport that makes the requests, if not nil.
+	//
+	// This is used to inject faults.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // Creates a new [Client] for making requests to the [Backend].
@@ -178,11 +304,14 @@ func (backend *Backend) NewClient(opts ClientOptions) Client {
 	// Set the retry policy with debug logging if possible.
 	retryPolicy := opts.RetryPolicy
 	if retryPolicy == nil {
-		retryPolicy = retryablehttp.DefaultRetryPolicy
+		retryPolicy = RetryPolicy(nil)
 	}
 	if backend.logger != nil {
 		retryPolicy = withRetryLogging(retryPolicy, backend.logger)
 	}
+	if opts.OnRetry != nil {
+		retryPolicy = ObserveRetries(retryPolicy, opts.OnRetry)
+	}
 	retryableHTTP.CheckRetry = retryPolicy
 
 	// Let the client log debug messages.
@@ -193,10 +322,31 @@ func (backend *Backend) NewClient(opts ClientOptions) Client {
 		)
 	}
 
+	transport := retryableHTTP.HTTPClient.Transport
+	if opts.Transport != nil {
+		transport = opts.Transport
+	}
+	if opts.WrapTransport != nil {
+		transport = opts.WrapTransport(transport)
+	}
+	transport = backend.quota.Transport(transport)
+	if backend.connectivity != nil {
+		transport = &connectivityTransport{
+			delegate:     transport,
+			connectivity: backend.connectivity,
+		}
+	}
+	if backend.clockSkew != nil && backend.baseURL != nil {
+		transport = &clockSkewTransport{
+			delegate:  transport,
+			clockSkew: backend.clockSkew,
+			host:      backend.baseURL.Host,
+		}
+	}
 	retryableHTTP.HTTPClient.Transport =
 		NewPeekingTransport(
 			opts.NetworkPeeker,
-			NewRateLimitedTransport(retryableHTTP.HTTPClient.Transport),
+			NewRateLimitedTransport(transport),
 		)
 
 	return &clientImpl{
@@ -2,13 +2,16 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,6 +96,50 @@ func TestDo_NotToWandb_NoAuth(t *testing.T) {
 	assert.Empty(t, server.Requests()[0].Header.Get("Authorization"))
 }
 
+func TestUpdateAPIKey(t *testing.T) {
+	newAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("api:new-key"))
+	var unauthorized atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != newAuth {
+				unauthorized.Add(1)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte("OK"))
+		}),
+	)
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	notices := 0
+	backend := api.New(api.BackendOptions{
+		BaseURL:        baseURL,
+		APIKey:         "old-key",
+		OnUnauthorized: func() { notices++ },
+	})
+	client := backend.NewClient(api.ClientOptions{
+		RetryMax:     3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+	send := func() int {
+		resp, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, send())
+	assert.Equal(t, http.StatusUnauthorized, send())
+	backend.UpdateAPIKey("new-key")
+	assert.Equal(t, http.StatusOK, send())
+
+	// rejected requests are not retried, and are reported once
+	assert.EqualValues(t, 2, unauthorized.Load())
+	assert.Equal(t, 1, notices)
+}
+
 func newClient(
 	t *testing.T,
 	baseURLString string,
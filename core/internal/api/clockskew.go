@@ -0,0 +1,131 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ClockSkew measures how far the local clock is off the backend's.
+//
+// The skew is measured once, from the Date header of the first successful
+// response from the backend. A skew beyond a threshold is an offset to
+// correct local timestamps by; smaller ones are left alone, since the Date
+// header is only precise to the second.
+//
+// The methods of a nil ClockSkew report no skew.
+type ClockSkew struct {
+	// threshold is the smallest skew that is corrected.
+	threshold time.Duration
+
+	// logger is for the warning about a skewed clock, if not nil.
+	logger *slog.Logger
+
+	// now is the local clock.
+	now func() time.Time
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// measured is whether the skew was measured.
+	measured bool
+
+	// skew is how far the backend's clock is ahead of the local one.
+	skew time.Duration
+}
+
+// NewClockSkew returns a ClockSkew that corrects skews of at least the
+// threshold, or nil if the threshold isn't positive.
+func NewClockSkew(threshold time.Duration, logger *slog.Logger) *ClockSkew {
+	if threshold <= 0 {
+		return nil
+	}
+	return &ClockSkew{threshold: threshold, logger: logger, now: time.Now}
+}
+
+// Measured returns the measured skew, which is how far the backend's clock
+// is ahead of the local one, and whether it was measured yet.
+func (c *ClockSkew) Measured() (time.Duration, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.skew, c.measured
+}
+
+// Offset is how much to add to local timestamps to correct them, which is
+// zero unless the clock is skewed beyond the threshold.
+func (c *ClockSkew) Offset() time.Duration {
+	skew, _ := c.Measured()
+	if c == nil || skew.Abs() < c.threshold {
+		return 0
+	}
+	return skew
+}
+
+// observe measures the skew from a response, if it's the first successful
+// one with a date.
+//
+// The response is taken to be dated halfway between when the request was
+// sent and when its response arrived.
+func (c *ClockSkew) observe(sent time.Time, resp *http.Response) {
+	if resp == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	received := c.now()
+
+	c.mu.Lock()
+	if c.measured {
+		c.mu.Unlock()
+		return
+	}
+	// the date is truncated to the second
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	c.skew = date.Add(time.Second / 2).Sub(midpoint).Round(time.Second)
+	c.measured = true
+	skew := c.skew
+	c.mu.Unlock()
+
+	if skew.Abs() >= c.threshold && c.logger != nil {
+		c.logger.Warn(
+			"api: the local clock is skewed, correcting timestamps",
+			"skew", skew.String(),
+		)
+	}
+}
+
+// clockSkewTransport is an HTTP transport that measures the clock skew from
+// the backend's responses.
+type clockSkewTransport struct {
+	delegate  http.RoundTripper
+	clockSkew *ClockSkew
+
+	// host is the backend's, to not measure the skew of other servers.
+	host string
+}
+
+func (transport *clockSkewTransport) RoundTrip(
+	req *http.Request,
+) (*http.Response, error) {
+	sent := transport.clockSkew.now()
+	resp, err := transport.delegate.RoundTrip(req)
+	if err == nil && req.URL.Host == transport.host {
+		transport.clockSkew.observe(sent, resp)
+	}
+	return resp, err
+}
+
+// ClockSkew returns the measured skew of the local clock, or nil if it
+// isn't measured.
+func (backend *Backend) ClockSkew() *ClockSkew {
+	if backend == nil {
+		return nil
+	}
+	return backend.clockSkew
+}
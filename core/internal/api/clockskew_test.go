@@ -0,0 +1,111 @@
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/api"
+)
+
+// datedServer is a transport whose responses are dated by a clock that is
+// ahead of the local one by the skew.
+type datedServer struct {
+	skew   time.Duration
+	status int
+}
+
+func (s *datedServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	status := s.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := http.Header{}
+	header.Set("Date", time.Now().Add(s.skew).UTC().Format(http.TimeFormat))
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func newDatedBackend(
+	t *testing.T,
+	server http.RoundTripper,
+) (*api.Backend, api.Client) {
+	baseURL, err := url.Parse("https://api.test")
+	require.NoError(t, err)
+
+	backend := api.New(api.BackendOptions{
+		BaseURL:            baseURL,
+		ClockSkewThreshold: time.Minute,
+	})
+	client := backend.NewClient(api.ClientOptions{Transport: server})
+	return backend, client
+}
+
+func TestClockSkew_CorrectsLargeSkew(t *testing.T) {
+	server := &datedServer{skew: -3 * time.Hour}
+	backend, client := newDatedBackend(t, server)
+
+	_, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+
+	// Only the first response is measured.
+	server.skew = time.Hour
+	_, err = client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+
+	skew, ok := backend.ClockSkew().Measured()
+	assert.True(t, ok)
+	assert.InDelta(t, (-3 * time.Hour).Seconds(), skew.Seconds(), 2)
+	assert.Equal(t, skew, backend.ClockSkew().Offset())
+}
+
+func TestClockSkew_IgnoresSmallSkew(t *testing.T) {
+	backend, client := newDatedBackend(t, &datedServer{skew: 10 * time.Second})
+
+	_, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+
+	skew, ok := backend.ClockSkew().Measured()
+	assert.True(t, ok)
+	assert.InDelta(t, 10, skew.Seconds(), 2)
+	assert.Zero(t, backend.ClockSkew().Offset())
+}
+
+func TestClockSkew_OnlyFromSuccessfulBackendResponses(t *testing.T) {
+	server := &datedServer{skew: time.Hour, status: http.StatusBadRequest}
+	backend, client := newDatedBackend(t, server)
+
+	_, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+	server.status = http.StatusOK
+	req, err := http.NewRequest(http.MethodGet, "https://storage.test/file", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.NoError(t, err)
+
+	_, ok := backend.ClockSkew().Measured()
+	assert.False(t, ok)
+	assert.Zero(t, backend.ClockSkew().Offset())
+}
+
+func TestClockSkew_Disabled(t *testing.T) {
+	baseURL, err := url.Parse("https://api.test")
+	require.NoError(t, err)
+	backend := api.New(api.BackendOptions{BaseURL: baseURL})
+
+	_, ok := backend.ClockSkew().Measured()
+
+	assert.Nil(t, backend.ClockSkew())
+	assert.False(t, ok)
+	assert.Zero(t, backend.ClockSkew().Offset())
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNetworkUnavailable is the error of requests made while the backend is
+// unreachable.
+//
+// Such requests fail immediately, without being sent.
+var ErrNetworkUnavailable = errors.New("api: network unavailable")
+
+// probeTimeout is the longest to wait for a probe's response.
+const probeTimeout = 10 * time.Second
+
+// connectivity tracks whether the backend is reachable.
+//
+// The backend becomes unreachable once requests have failed with network
+// errors for a while, and reachable again as soon as a request succeeds.
+type connectivity struct {
+	// offlineAfter is how long requests must keep failing before the
+	// backend is unreachable.
+	offlineAfter time.Duration
+
+	// logger is for logging changes in connectivity, if not nil.
+	logger *slog.Logger
+
+	// onOffline is called the first time the backend becomes unreachable,
+	// if not nil.
+	onOffline func()
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// firstFailure is when requests started failing, or the zero time if
+	// the last request succeeded.
+	firstFailure time.Time
+
+	// offline is whether the backend is unreachable.
+	offline bool
+
+	// offlineReported is whether onOffline was called.
+	offlineReported bool
+}
+
+// isOffline reports whether the backend is unreachable.
+func (c *connectivity) isOffline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offline
+}
+
+// succeeded records that the backend responded to a request.
+func (c *connectivity) succeeded() {
+	c.mu.Lock()
+	wasOffline := c.offline
+	c.firstFailure = time.Time{}
+	c.offline = false
+	c.mu.Unlock()
+
+	if wasOffline && c.logger != nil {
+		c.logger.Info("api: backend reachable again")
+	}
+}
+
+// failed records that a request failed with a network error.
+func (c *connectivity) failed() {
+	now := time.Now()
+
+	c.mu.Lock()
+	if c.firstFailure.IsZero() {
+		c.firstFailure = now
+	}
+	if c.offline || now.Sub(c.firstFailure) < c.offlineAfter {
+		c.mu.Unlock()
+		return
+	}
+	c.offline = true
+	report := !c.offlineReported
+	c.offlineReported = true
+	c.mu.Unlock()
+
+	if c.logger != nil {
+		c.logger.Warn(
+			"api: backend unreachable",
+			"failingFor", now.Sub(c.firstFailure),
+		)
+	}
+	if report && c.onOffline != nil {
+		c.onOffline()
+	}
+}
+
+// connectivityTransport is an HTTP transport that tracks connectivity,
+// and fails requests without sending them while the backend is
+// unreachable.
+type connectivityTransport struct {
+	delegate     http.RoundTripper
+	connectivity *connectivity
+}
+
+func (transport *connectivityTransport) RoundTrip(
+	req *http.Request,
+) (*http.Response, error) {
+	if transport.connectivity.isOffline() {
+		return nil, ErrNetworkUnavailable
+	}
+
+	resp, err := transport.delegate.RoundTrip(req)
+
+	switch {
+	case err == nil:
+		transport.connectivity.succeeded()
+
+	// Canceled requests say nothing about the network, but timeouts do.
+	case errors.Is(req.Context().Err(), context.Canceled):
+
+	default:
+		transport.connectivity.failed()
+	}
+
+	return resp, err
+}
+
+// Reachable reports whether requests are being made to the backend.
+//
+// It is false once requests have failed with network errors for the
+// OfflineAfter window, until Probe succeeds. It is always true if that
+// window is zero.
+func (backend *Backend) Reachable() bool {
+	return backend.connectivity == nil || !backend.connectivity.isOffline()
+}
+
+// Probe makes a request to the backend to check whether it's reachable.
+//
+// Any response counts, including an error status. If the backend
+// responds, it becomes reachable again.
+func (backend *Backend) Probe(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		backend.baseURL.String(),
+		http.NoBody,
+	)
+	if err != nil {
+		return false
+	}
+
+	resp, err := backend.probeClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+
+	if backend.connectivity != nil {
+		backend.connectivity.succeeded()
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/api"
+)
+
+// flakyNetwork is a transport that fails while the network is down.
+type flakyNetwork struct {
+	down     atomic.Bool
+	requests atomic.Int32
+}
+
+func (n *flakyNetwork) RoundTrip(req *http.Request) (*http.Response, error) {
+	n.requests.Add(1)
+	if n.down.Load() {
+		return nil, errors.New("dial tcp: network is unreachable")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func newUnreliableBackend(
+	t *testing.T,
+	network *flakyNetwork,
+	onOffline func(),
+) (*api.Backend, api.Client) {
+	baseURL, err := url.Parse("https://api.test")
+	require.NoError(t, err)
+
+	backend := api.New(api.BackendOptions{
+		BaseURL:        baseURL,
+		OfflineAfter:   20 * time.Millisecond,
+		OnOffline:      onOffline,
+		ProbeTransport: network,
+	})
+	client := backend.NewClient(api.ClientOptions{
+		RetryMax:     1000,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+		Transport:    network,
+	})
+	return backend, client
+}
+
+func TestBackend_UnreachableAfterWindow(t *testing.T) {
+	network := &flakyNetwork{}
+	network.down.Store(true)
+	var offlineCalls atomic.Int32
+	backend, client := newUnreliableBackend(t, network,
+		func() { offlineCalls.Add(1) })
+
+	_, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+
+	assert.ErrorIs(t, err, api.ErrNetworkUnavailable)
+	assert.False(t, backend.Reachable())
+	assert.EqualValues(t, 1, offlineCalls.Load())
+
+	// Requests fail without reaching the network.
+	requestsBefore := network.requests.Load()
+	_, err = client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	assert.ErrorIs(t, err, api.ErrNetworkUnavailable)
+	assert.Equal(t, requestsBefore, network.requests.Load())
+}
+
+func TestBackend_ProbeRestoresConnectivity(t *testing.T) {
+	network := &flakyNetwork{}
+	network.down.Store(true)
+	var offlineCalls atomic.Int32
+	backend, client := newUnreliableBackend(t, network,
+		func() { offlineCalls.Add(1) })
+	_, _ = client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.False(t, backend.Reachable())
+
+	assert.False(t, backend.Probe(context.Background()))
+	network.down.Store(false)
+	assert.True(t, backend.Probe(context.Background()))
+
+	assert.True(t, backend.Reachable())
+	resp, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	// The callback isn't called again for another outage.
+	network.down.Store(true)
+	_, _ = client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	assert.False(t, backend.Reachable())
+	assert.EqualValues(t, 1, offlineCalls.Load())
+}
+
+func TestBackend_SuccessResetsFailureWindow(t *testing.T) {
+	network := &flakyNetwork{}
+	backend, client := newUnreliableBackend(t, network, nil)
+
+	for range 5 {
+		network.down.Store(true)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.test/x", http.NoBody)
+		require.NoError(t, err)
+		_, _ = client.Do(req)
+		cancel()
+
+		network.down.Store(false)
+		resp, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	assert.True(t, backend.Reachable())
+}
+
+func TestBackend_AlwaysReachableWithoutWindow(t *testing.T) {
+	network := &flakyNetwork{}
+	network.down.Store(true)
+	baseURL, err := url.Parse("https://api.test")
+	require.NoError(t, err)
+	backend := api.New(api.BackendOptions{BaseURL: baseURL})
+	client := backend.NewClient(api.ClientOptions{Transport: network})
+
+	_, err = client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, api.ErrNetworkUnavailable)
+	assert.True(t, backend.Reachable())
+}
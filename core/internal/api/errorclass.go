@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// ErrorClass is how a request's failure is handled.
+type ErrorClass int
+
+const (
+	// NoError is the class of requests that succeeded.
+	NoError ErrorClass = iota
+
+	// Retryable failures are transient, and the request is retried with
+	// backoff.
+	Retryable
+
+	// FatalAuth failures are due to a rejected API key or missing
+	// permissions.
+	FatalAuth
+
+	// FatalNotFound failures are for resources that don't exist, such as
+	// a deleted run.
+	FatalNotFound
+
+	// FatalQuota failures are due to the user's organization reaching one
+	// of its limits, like its storage quota.
+	FatalQuota
+
+	// Fatal failures are other errors that retrying won't fix.
+	Fatal
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case NoError:
+		return "no error"
+	case Retryable:
+		return "retryable error"
+	case FatalAuth:
+		return "authentication error"
+	case FatalNotFound:
+		return "not found"
+	case FatalQuota:
+		return "quota exceeded"
+	default:
+		return "fatal error"
+	}
+}
+
+// ErrorClassOverrides reclassifies responses by their status code.
+//
+// They're for call sites with known exceptions, such as a conflict that
+// resolves itself when retried.
+type ErrorClassOverrides map[int]ErrorClass
+
+type errorClassOverridesKey struct{}
+
+// WithErrorClassOverrides returns a context whose requests classify
+// responses with the overrides.
+//
+// They take precedence over any overrides of the client's retry policy.
+func WithErrorClassOverrides(
+	ctx context.Context,
+	overrides ErrorClassOverrides,
+) context.Context {
+	return context.WithValue(ctx, errorClassOverridesKey{}, overrides)
+}
+
+// ClassifyResponse classifies the result of an HTTP request.
+//
+// Network errors, timeouts, rate limits and server errors are retryable.
+// Other client errors are fatal, as are responses that report an exceeded
+// limit, whatever their status.
+func ClassifyResponse(resp *http.Response, err error) ErrorClass {
+	if err != nil {
+		return classifyTransportError(err)
+	}
+
+	if quotaOfResponse(resp) != nil {
+		return FatalQuota
+	}
+
+	switch code := resp.StatusCode; {
+	case code == 0, code >= 500:
+		return Retryable
+	case code == http.StatusRequestTimeout,
+		code == http.StatusTooManyRequests:
+		return Retryable
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return FatalAuth
+	case code == http.StatusNotFound:
+		return FatalNotFound
+	case code >= 400:
+		return Fatal
+	default:
+		return NoError
+	}
+}
+
+// classifyTransportError classifies an error from an HTTP transport.
+//
+// Requests that weren't sent because the backend is unreachable are not
+// retried.
+func classifyTransportError(err error) ErrorClass {
+	if errors.Is(err, ErrNetworkUnavailable) {
+		return Fatal
+	}
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return Retryable
+	}
+
+	// These are the errors that retryablehttp doesn't retry by default.
+	var unknownAuthority x509.UnknownAuthorityError
+	switch msg := urlErr.Error(); {
+	case strings.Contains(msg, "stopped after") &&
+		strings.Contains(msg, "redirects"),
+		strings.Contains(msg, "unsupported protocol scheme"),
+		strings.Contains(msg, "certificate is not trusted"),
+		errors.As(urlErr.Err, &unknownAuthority):
+		return Fatal
+	default:
+		return Retryable
+	}
+}
+
+// RetryPolicy returns a policy for retryablehttp that retries requests
+// whose failures are Retryable.
+//
+// Responses with fatal errors are returned to the caller, which can use
+// ResponseError to describe them. The overrides, if any, reclassify
+// responses for all requests made with the policy.
+func RetryPolicy(overrides ErrorClassOverrides) retryablehttp.CheckRetry {
+	return func(
+		ctx context.Context,
+		resp *http.Response,
+		err error,
+	) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		class := ClassifyResponse(resp, err)
+		if err == nil {
+			ctxOverrides, _ := ctx.Value(errorClassOverridesKey{}).(ErrorClassOverrides)
+			if override, ok := ctxOverrides[resp.StatusCode]; ok {
+				class = override
+			} else if override, ok := overrides[resp.StatusCode]; ok {
+				class = override
+			}
+		}
+
+		switch {
+		case class != Retryable:
+			return false, nil
+		case err != nil:
+			return true, nil
+		default:
+			return true, fmt.Errorf("api: retryable HTTP status %s", resp.Status)
+		}
+	}
+}
+
+// maxErrorBodyBytes is the most of a response's body to include in its
+// error.
+const maxErrorBodyBytes = 1024
+
+// HTTPError is a response with an error status.
+type HTTPError struct {
+	// Class is how the response was classified.
+	Class ErrorClass
+
+	// StatusCode and Status are the response's status.
+	StatusCode int
+	Status     string
+
+	// Body is the start of the response's body.
+	Body string
+
+	// Quota is the limit that the response reports as exceeded, if the
+	// Class is FatalQuota.
+	Quota *QuotaExceededError
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%v: %s", e.Class, e.Status)
+	}
+	return fmt.Sprintf("%v: %s: %s", e.Class, e.Status, e.Body)
+}
+
+// Unwrap returns the exceeded limit, if any, so that it can be found with
+// errors.As.
+func (e *HTTPError) Unwrap() error {
+	if e.Quota == nil {
+		return nil
+	}
+	return e.Quota
+}
+
+// ResponseError returns an *HTTPError if the response has an error status,
+// and nil otherwise.
+//
+// It reads the start of the response's body, but doesn't close it.
+func ResponseError(resp *http.Response) error {
+	class := ClassifyResponse(resp, nil)
+	if class == NoError {
+		return nil
+	}
+
+	var body string
+	if resp.Body != nil {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		body = strings.TrimSpace(string(snippet))
+	}
+
+	httpErr := &HTTPError{
+		Class:      class,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+	if class == FatalQuota {
+		httpErr.Quota = ParseQuotaExceeded(resp.StatusCode, []byte(body))
+	}
+	return httpErr
+}
@@ -0,0 +1,229 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/api"
+)
+
+func TestClassifyResponse(t *testing.T) {
+	testCases := []struct {
+		status   int
+		expected api.ErrorClass
+	}{
+		{http.StatusOK, api.NoError},
+		{http.StatusNoContent, api.NoError},
+		{http.StatusNotModified, api.NoError},
+		{http.StatusRequestTimeout, api.Retryable},
+		{http.StatusTooManyRequests, api.Retryable},
+		{http.StatusInternalServerError, api.Retryable},
+		{http.StatusNotImplemented, api.Retryable},
+		{http.StatusBadGateway, api.Retryable},
+		{http.StatusServiceUnavailable, api.Retryable},
+		{http.StatusGatewayTimeout, api.Retryable},
+		{0, api.Retryable},
+		{http.StatusUnauthorized, api.FatalAuth},
+		{http.StatusForbidden, api.FatalAuth},
+		{http.StatusNotFound, api.FatalNotFound},
+		{http.StatusBadRequest, api.Fatal},
+		{http.StatusConflict, api.Fatal},
+		{http.StatusGone, api.Fatal},
+		{http.StatusRequestEntityTooLarge, api.Fatal},
+		{http.StatusUnprocessableEntity, api.Fatal},
+		{http.StatusTeapot, api.Fatal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.status), func(t *testing.T) {
+			class := api.ClassifyResponse(&http.Response{StatusCode: tc.status}, nil)
+
+			assert.Equal(t, tc.expected, class)
+		})
+	}
+}
+
+func TestClassifyResponse_TransportErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected api.ErrorClass
+	}{
+		{"connection refused", errors.New("connection refused"), api.Retryable},
+		{
+			"timeout",
+			&url.Error{Op: "Post", URL: "u", Err: context.DeadlineExceeded},
+			api.Retryable,
+		},
+		{
+			"too many redirects",
+			&url.Error{Op: "Get", URL: "u", Err: errors.New("stopped after 10 redirects")},
+			api.Fatal,
+		},
+		{
+			"bad scheme",
+			&url.Error{Op: "Get", URL: "u", Err: errors.New(`unsupported protocol scheme "ftp"`)},
+			api.Fatal,
+		},
+		{
+			"network unavailable",
+			&url.Error{Op: "Post", URL: "u", Err: api.ErrNetworkUnavailable},
+			api.Fatal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, api.ClassifyResponse(nil, tc.err))
+		})
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := api.RetryPolicy(api.ErrorClassOverrides{
+		http.StatusConflict:   api.Retryable,
+		http.StatusBadGateway: api.Fatal,
+	})
+	ctx := api.WithErrorClassOverrides(context.Background(),
+		api.ErrorClassOverrides{http.StatusBadGateway: api.Retryable})
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		status      int
+		err         error
+		shouldRetry bool
+	}{
+		{"success", context.Background(), http.StatusOK, nil, false},
+		{"rate limited", context.Background(), http.StatusTooManyRequests, nil, true},
+		{"server error", context.Background(), http.StatusServiceUnavailable, nil, true},
+		{"unauthorized", context.Background(), http.StatusUnauthorized, nil, false},
+		{"forbidden", context.Background(), http.StatusForbidden, nil, false},
+		{"not found", context.Background(), http.StatusNotFound, nil, false},
+		{"bad request", context.Background(), http.StatusBadRequest, nil, false},
+		{"network error", context.Background(), 0, errors.New("reset"), true},
+		{"policy override", context.Background(), http.StatusConflict, nil, true},
+		{"policy override fatal", context.Background(), http.StatusBadGateway, nil, false},
+		{"context override", ctx, http.StatusBadGateway, nil, true},
+		{"context canceled", canceledCtx, http.StatusServiceUnavailable, nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{
+					StatusCode: tc.status,
+					Status:     http.StatusText(tc.status),
+				}
+			}
+
+			shouldRetry, _ := policy(tc.ctx, resp, tc.err)
+
+			assert.Equal(t, tc.shouldRetry, shouldRetry)
+		})
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Status:     "400 Bad Request",
+		Body:       io.NopCloser(strings.NewReader(strings.Repeat("x", 5000))),
+	}
+
+	err := api.ResponseError(resp)
+
+	var httpErr *api.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, api.Fatal, httpErr.Class)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	assert.Equal(t, strings.Repeat("x", 1024), httpErr.Body)
+	assert.Equal(t,
+		"fatal error: 400 Bad Request: "+strings.Repeat("x", 1024),
+		err.Error())
+
+	assert.NoError(t, api.ResponseError(&http.Response{StatusCode: http.StatusOK}))
+}
+
+func TestClient_RetriesByClass(t *testing.T) {
+	testCases := []struct {
+		status           int
+		expectedRequests int32
+	}{
+		{http.StatusOK, 1},
+		{http.StatusUnauthorized, 1},
+		{http.StatusForbidden, 1},
+		{http.StatusNotFound, 1},
+		{http.StatusBadRequest, 1},
+		{http.StatusTooManyRequests, 4},
+		{http.StatusBadGateway, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.status), func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					requests.Add(1)
+					w.WriteHeader(tc.status)
+				}),
+			)
+			defer server.Close()
+			client := newClient(t, server.URL, api.ClientOptions{
+				RetryMax:     3,
+				RetryWaitMin: time.Millisecond,
+				RetryWaitMax: time.Millisecond,
+			})
+
+			resp, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+
+			assert.Equal(t, tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
+func TestClient_OnRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}),
+	)
+	defer server.Close()
+	var statuses []int
+	client := newClient(t, server.URL, api.ClientOptions{
+		RetryMax:     2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+		OnRetry: func(resp *http.Response, err error) {
+			statuses = append(statuses, resp.StatusCode)
+		},
+	})
+
+	resp, err := client.Send(&api.Request{Method: http.MethodGet, Path: "test"})
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+
+	// The last attempt counts too, though there are no retries left.
+	assert.Equal(t,
+		[]int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+		statuses)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// graphqlErrorClasses classifies the codes that the backend puts in the
+// extensions of GraphQL errors.
+var graphqlErrorClasses = map[string]ErrorClass{
+	"UNAUTHENTICATED":       FatalAuth,
+	"UNAUTHORIZED":          FatalAuth,
+	"FORBIDDEN":             FatalAuth,
+	"PERMISSION_ERROR":      FatalAuth,
+	"NOT_FOUND":             FatalNotFound,
+	"RATE_LIMITED":          Retryable,
+	"TOO_MANY_REQUESTS":     Retryable,
+	"SERVICE_UNAVAILABLE":   Retryable,
+	"INTERNAL_SERVER_ERROR": Retryable,
+}
+
+// ClassifyGraphQLError classifies the error of a GraphQL operation.
+//
+// Responses with an error status are classified like other responses, as
+// long as the GraphQL client got them through StatusErrors. GraphQL errors
+// that come with a successful status are classified by the codes in their
+// extensions: an exceeded limit first, then the first known code. Errors
+// without a known code are fatal, since the backend rejected the operation.
+func ClassifyGraphQLError(err error) ErrorClass {
+	if err == nil {
+		return NoError
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Class
+	}
+	if QuotaExceededInGraphQL(err) != nil {
+		return FatalQuota
+	}
+
+	var list gqlerror.List
+	if errors.As(err, &list) {
+		for _, graphqlErr := range list {
+			code, _ := graphqlErr.Extensions["code"].(string)
+			if class, ok := graphqlErrorClasses[code]; ok {
+				return class
+			}
+		}
+		return Fatal
+	}
+
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return Retryable
+	}
+	return classifyTransportError(err)
+}
+
+// Doer sends HTTP requests, like an *http.Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// StatusErrors returns a Doer that returns responses with an error status
+// as an *HTTPError.
+//
+// GraphQL clients describe such responses by their status text alone,
+// which loses how they were classified.
+func StatusErrors(client Doer) Doer {
+	return &statusErrorsDoer{client: client}
+}
+
+type statusErrorsDoer struct {
+	client Doer
+}
+
+func (d *statusErrorsDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if statusErr := ResponseError(resp); statusErr != nil {
+			if resp.Body != nil {
+				_ = resp.Body.Close()
+			}
+			return nil, statusErr
+		}
+	}
+	return resp, nil
+}
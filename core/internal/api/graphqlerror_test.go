@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/wandb/wandb/core/internal/api"
+)
+
+func graphqlErrorWithCode(code string) error {
+	return fmt.Errorf("failed to upsert bucket: %w", gqlerror.List{
+		{Message: "no", Extensions: map[string]any{"code": code}},
+	})
+}
+
+func TestClassifyGraphQLError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected api.ErrorClass
+	}{
+		{"nil", nil, api.NoError},
+		{"permission", graphqlErrorWithCode("PERMISSION_ERROR"), api.FatalAuth},
+		{"unauthenticated", graphqlErrorWithCode("UNAUTHENTICATED"), api.FatalAuth},
+		{"not found", graphqlErrorWithCode("NOT_FOUND"), api.FatalNotFound},
+		{"rate limited", graphqlErrorWithCode("RATE_LIMITED"), api.Retryable},
+		{"quota", graphqlErrorWithCode("STORAGE_LIMIT_EXCEEDED"), api.FatalQuota},
+		{"unknown code", graphqlErrorWithCode("SOMETHING_ELSE"), api.Fatal},
+		{
+			"status",
+			&api.HTTPError{Class: api.FatalAuth, StatusCode: http.StatusUnauthorized},
+			api.FatalAuth,
+		},
+		{"canceled", context.Canceled, api.Retryable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, api.ClassifyGraphQLError(tc.err))
+		})
+	}
+}
+
+type staticDoer struct {
+	status int
+}
+
+func (d staticDoer) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: d.status,
+		Status:     http.StatusText(d.status),
+		Body:       io.NopCloser(strings.NewReader(`{"data":{}}`)),
+	}, nil
+}
+
+func TestStatusErrors(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/graphql", nil)
+	require.NoError(t, err)
+
+	resp, err := api.StatusErrors(staticDoer{http.StatusOK}).Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = api.StatusErrors(staticDoer{http.StatusUnauthorized}).Do(req)
+	var httpErr *api.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	assert.Equal(t, api.FatalAuth, api.ClassifyGraphQLError(err))
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/wandb/wandb/core/internal/redact"
 )
 
 // Logs the final response (after retries) if it's an error.
@@ -24,6 +26,7 @@ func (backend *Backend) logFinalResponseOnError(
 		"status", resp.StatusCode,
 		"method", req.Method,
 		"url", req.URL.String(),
+		"headers", redact.Headers(req.Header),
 	)
 }
 
@@ -50,3 +53,23 @@ func withRetryLogging(
 		return willRetry, err
 	}
 }
+
+// ObserveRetries wraps a retry policy to call onRetry whenever it decides
+// to retry.
+//
+// The last failed attempt counts too if the policy would retry it, even
+// if the client has run out of retries.
+func ObserveRetries(
+	policy retryablehttp.CheckRetry,
+	onRetry func(resp *http.Response, err error),
+) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		willRetry, policyErr := policy(ctx, resp, err)
+
+		if willRetry {
+			onRetry(resp, err)
+		}
+
+		return willRetry, policyErr
+	}
+}
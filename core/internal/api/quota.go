@@ -0,0 +1,235 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// QuotaExceededError is a request rejected because the user's organization
+// reached one of its limits, such as its storage quota.
+//
+// Retrying such requests doesn't help until the limit is raised or usage
+// goes down.
+type QuotaExceededError struct {
+	// Limit names the limit that was reached, like "storage".
+	Limit string
+
+	// Message is the server's explanation, if it gave one.
+	Message string
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("api: %s limit exceeded", e.Limit)
+	}
+	return fmt.Sprintf("api: %s limit exceeded: %s", e.Limit, e.Message)
+}
+
+// quotaErrorCodes maps the GraphQL error codes that the backend uses for
+// exceeded limits to the limits' names.
+var quotaErrorCodes = map[string]string{
+	"STORAGE_LIMIT_EXCEEDED":       "storage",
+	"STORAGE_QUOTA_EXCEEDED":       "storage",
+	"ARTIFACT_STORAGE_EXCEEDED":    "storage",
+	"TRACKED_HOURS_LIMIT_EXCEEDED": "tracked hours",
+	"USAGE_LIMIT_EXCEEDED":         "usage",
+}
+
+// quotaPhrases are what the bodies of rejected uploads say when a limit
+// is exceeded, for responses without an error code, by limit.
+var quotaPhrases = []struct{ phrase, limit string }{
+	{"storage limit", "storage"},
+	{"storage quota", "storage"},
+	{"quotaexceeded", "storage"},
+	{"quota exceeded", "storage"},
+	{"tracked hours", "tracked hours"},
+	{"usage limit", "usage"},
+}
+
+// ParseQuotaExceeded returns the limit that a response with the status and
+// body reports as exceeded, or nil if it doesn't report one.
+//
+// Responses report limits with a GraphQL error code, or with a 402, 403
+// or 507 status whose body mentions the limit.
+func ParseQuotaExceeded(statusCode int, body []byte) *QuotaExceededError {
+	if statusCode < 400 {
+		return nil
+	}
+
+	var graphqlBody struct {
+		Errors gqlerror.List `json:"errors"`
+	}
+	if json.Unmarshal(body, &graphqlBody) == nil {
+		if err := quotaOfGraphQLErrors(graphqlBody.Errors); err != nil {
+			return err
+		}
+	}
+
+	switch statusCode {
+	case http.StatusPaymentRequired,
+		http.StatusForbidden,
+		http.StatusInsufficientStorage:
+	default:
+		return nil
+	}
+
+	message := strings.TrimSpace(string(body))
+	var jsonBody struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &jsonBody) == nil && jsonBody.Error != "" {
+		message = jsonBody.Error
+	}
+
+	lowerBody := strings.ToLower(string(body))
+	for _, quota := range quotaPhrases {
+		if strings.Contains(lowerBody, quota.phrase) {
+			return &QuotaExceededError{Limit: quota.limit, Message: message}
+		}
+	}
+	return nil
+}
+
+// QuotaExceededInGraphQL returns the limit that a GraphQL operation's
+// error reports as exceeded, or nil if it doesn't report one.
+func QuotaExceededInGraphQL(err error) *QuotaExceededError {
+	var quotaErr *QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return quotaErr
+	}
+
+	var list gqlerror.List
+	if errors.As(err, &list) {
+		return quotaOfGraphQLErrors(list)
+	}
+	return nil
+}
+
+func quotaOfGraphQLErrors(list gqlerror.List) *QuotaExceededError {
+	for _, graphqlErr := range list {
+		code, _ := graphqlErr.Extensions["code"].(string)
+		if limit, ok := quotaErrorCodes[code]; ok {
+			return &QuotaExceededError{Limit: limit, Message: graphqlErr.Message}
+		}
+	}
+	return nil
+}
+
+// quotaOfResponse returns the limit that the response reports as exceeded,
+// or nil if it doesn't report one.
+//
+// It reads the start of an error response's body and puts it back, so
+// that the body can still be read in full.
+func quotaOfResponse(resp *http.Response) *QuotaExceededError {
+	if resp.StatusCode < 400 || resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	resp.Body = &peekedBody{
+		Reader: io.MultiReader(bytes.NewReader(snippet), resp.Body),
+		Closer: resp.Body,
+	}
+	return ParseQuotaExceeded(resp.StatusCode, snippet)
+}
+
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// QuotaWatcher notices when requests are rejected because a limit was
+// exceeded, and reports each limit once.
+//
+// It is safe for concurrent use. A nil QuotaWatcher watches nothing.
+type QuotaWatcher struct {
+	mu sync.Mutex
+
+	// first is the first limit that was exceeded
+	first *QuotaExceededError
+
+	// reported are the limits that were reported, by name
+	reported map[string]bool
+
+	// onExceeded is called the first time each limit is exceeded
+	onExceeded func(*QuotaExceededError)
+}
+
+// NewQuotaWatcher returns a QuotaWatcher that calls onExceeded, if it's
+// not nil, the first time each limit is exceeded.
+func NewQuotaWatcher(onExceeded func(*QuotaExceededError)) *QuotaWatcher {
+	return &QuotaWatcher{
+		reported:   make(map[string]bool),
+		onExceeded: onExceeded,
+	}
+}
+
+// Report notes that the limit was exceeded.
+func (w *QuotaWatcher) Report(err *QuotaExceededError) {
+	if w == nil || err == nil {
+		return
+	}
+
+	w.mu.Lock()
+	if w.first == nil {
+		w.first = err
+	}
+	if w.reported[err.Limit] {
+		w.mu.Unlock()
+		return
+	}
+	w.reported[err.Limit] = true
+	w.mu.Unlock()
+
+	if w.onExceeded != nil {
+		w.onExceeded(err)
+	}
+}
+
+// Exceeded returns the first limit that was exceeded, or nil if none was.
+func (w *QuotaWatcher) Exceeded() *QuotaExceededError {
+	if w == nil {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.first
+}
+
+// Transport wraps the transport so that the limits reported by its
+// responses are noticed.
+func (w *QuotaWatcher) Transport(rt http.RoundTripper) http.RoundTripper {
+	if w == nil {
+		return rt
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := rt.RoundTrip(req)
+		if err == nil {
+			w.Report(quotaOfResponse(resp))
+		}
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Quota returns the backend's QuotaWatcher, or nil if it has none.
+func (backend *Backend) Quota() *QuotaWatcher {
+	if backend == nil {
+		return nil
+	}
+	return backend.quota
+}
@@ -0,0 +1,175 @@
+package api_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/wandb/wandb/core/internal/api"
+)
+
+// quotaErrorFixture is a response seen from the backend or from a storage
+// bucket, and the limit that it reports as exceeded, if any.
+type quotaErrorFixture struct {
+	Name   string `json:"name"`
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+	Limit  string `json:"limit"`
+}
+
+func loadQuotaErrorFixtures(t *testing.T) []quotaErrorFixture {
+	content, err := os.ReadFile("testdata/quota_errors.json")
+	require.NoError(t, err)
+
+	var fixtures []quotaErrorFixture
+	require.NoError(t, json.Unmarshal(content, &fixtures))
+	return fixtures
+}
+
+func TestParseQuotaExceeded(t *testing.T) {
+	for _, fixture := range loadQuotaErrorFixtures(t) {
+		t.Run(fixture.Name, func(t *testing.T) {
+			err := api.ParseQuotaExceeded(fixture.Status, []byte(fixture.Body))
+
+			if fixture.Limit == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, fixture.Limit, err.Limit)
+				assert.NotEmpty(t, err.Message)
+			}
+		})
+	}
+}
+
+func TestClassifyResponse_Quota(t *testing.T) {
+	for _, fixture := range loadQuotaErrorFixtures(t) {
+		t.Run(fixture.Name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: fixture.Status,
+				Body:       io.NopCloser(strings.NewReader(fixture.Body)),
+			}
+
+			class := api.ClassifyResponse(resp, nil)
+
+			if fixture.Limit == "" {
+				assert.NotEqual(t, api.FatalQuota, class)
+			} else {
+				assert.Equal(t, api.FatalQuota, class)
+			}
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, fixture.Body, string(body))
+		})
+	}
+}
+
+func TestQuotaExceededInGraphQL(t *testing.T) {
+	err := gqlerror.List{
+		{Message: "not allowed"},
+		{
+			Message:    "storage limit reached",
+			Extensions: map[string]any{"code": "STORAGE_QUOTA_EXCEEDED"},
+		},
+	}
+
+	quotaErr := api.QuotaExceededInGraphQL(err)
+
+	require.NotNil(t, quotaErr)
+	assert.Equal(t, "storage", quotaErr.Limit)
+	assert.Equal(t, "storage limit reached", quotaErr.Message)
+	assert.Nil(t, api.QuotaExceededInGraphQL(gqlerror.List{{Message: "oops"}}))
+	assert.Nil(t, api.QuotaExceededInGraphQL(nil))
+}
+
+func TestResponseError_Quota(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Status:     "403 Forbidden",
+		Body: io.NopCloser(strings.NewReader(
+			`{"error":"Your organization has exceeded its storage limit."}`)),
+	}
+
+	err := api.ResponseError(resp)
+
+	var quotaErr *api.QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, "storage", quotaErr.Limit)
+	assert.Equal(t,
+		"Your organization has exceeded its storage limit.",
+		quotaErr.Message)
+}
+
+func TestBackend_ReportsQuotaOnceWithoutRetrying(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(
+				`{"error":"Your organization has exceeded its storage limit."}`))
+		}),
+	)
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	var reported []*api.QuotaExceededError
+	backend := api.New(api.BackendOptions{
+		BaseURL: baseURL,
+		OnQuotaExceeded: func(err *api.QuotaExceededError) {
+			reported = append(reported, err)
+		},
+	})
+	client := backend.NewClient(api.ClientOptions{
+		RetryMax:     3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	for range 2 {
+		resp, err := client.Send(&api.Request{Method: http.MethodPost, Path: "files"})
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	assert.EqualValues(t, 2, requests.Load())
+	require.Len(t, reported, 1)
+	assert.Equal(t, "storage", reported[0].Limit)
+	assert.Same(t, reported[0], backend.Quota().Exceeded())
+}
+
+func TestQuotaWatcher_ReportsEachLimitOnce(t *testing.T) {
+	var reported []string
+	watcher := api.NewQuotaWatcher(func(err *api.QuotaExceededError) {
+		reported = append(reported, err.Limit)
+	})
+
+	watcher.Report(&api.QuotaExceededError{Limit: "storage"})
+	watcher.Report(&api.QuotaExceededError{Limit: "storage"})
+	watcher.Report(&api.QuotaExceededError{Limit: "tracked hours"})
+	watcher.Report(nil)
+
+	assert.Equal(t, []string{"storage", "tracked hours"}, reported)
+	assert.Equal(t, "storage", watcher.Exceeded().Limit)
+}
+
+func TestQuotaWatcher_Nil(t *testing.T) {
+	var watcher *api.QuotaWatcher
+
+	watcher.Report(&api.QuotaExceededError{Limit: "storage"})
+
+	assert.Nil(t, watcher.Exceeded())
+	assert.Equal(t, http.DefaultTransport, watcher.Transport(http.DefaultTransport))
+}
@@ -22,8 +22,6 @@ func (client *clientImpl) Send(req *Request) (*http.Response, error) {
 	for headerKey, headerValue := range req.Headers {
 		retryableReq.Header.Set(headerKey, headerValue)
 	}
-	client.setClientHeaders(retryableReq)
-	client.setAuthHeaders(retryableReq)
 
 	return client.sendToWandbBackend(retryableReq)
 }
@@ -63,8 +61,11 @@ func (client *clientImpl) isToWandb(req *http.Request) bool {
 func (client *clientImpl) sendToWandbBackend(
 	req *retryablehttp.Request,
 ) (*http.Response, error) {
+	// The key is read once so that all retries of the request use it
+	// even if it is replaced in the meantime.
+	apiKey := client.backend.currentAPIKey()
 	client.setClientHeaders(req)
-	client.setAuthHeaders(req)
+	client.setAuthHeaders(req, apiKey)
 
 	resp, err := client.send(req)
 
@@ -73,6 +74,10 @@ func (client *clientImpl) sendToWandbBackend(
 		return nil, fmt.Errorf("api: nil error and nil response")
 	}
 
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		client.backend.reportUnauthorized(apiKey)
+	}
+
 	return resp, err
 }
 
@@ -83,7 +88,7 @@ func (client *clientImpl) send(
 	resp, err := client.retryableHTTP.Do(req)
 
 	if err != nil {
-		return nil, fmt.Errorf("api: failed sending: %v", err)
+		return nil, fmt.Errorf("api: failed sending: %w", err)
 	}
 	if resp == nil {
 		return nil, fmt.Errorf("api: no response")
@@ -99,11 +104,14 @@ func (client *clientImpl) setClientHeaders(req *retryablehttp.Request) {
 	}
 }
 
-func (client *clientImpl) setAuthHeaders(req *retryablehttp.Request) {
+func (client *clientImpl) setAuthHeaders(
+	req *retryablehttp.Request,
+	apiKey string,
+) {
 	req.Header.Set("User-Agent", "wandb-core")
 	req.Header.Set(
 		"Authorization",
 		"Basic "+base64.StdEncoding.EncodeToString(
-			[]byte("api:"+client.backend.apiKey)),
+			[]byte("api:"+apiKey)),
 	)
 }
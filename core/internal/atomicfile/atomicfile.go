@@ -0,0 +1,139 @@
+// Package atomicfile replaces files so that a crash never leaves them
+// partly written.
+//
+// A file is written under a temporary name in its directory, synced to
+// disk and renamed over the original, so readers find either the old
+// content or the new. A crash may leave a temporary file behind, named
+// like ".<name>.<random>.tmp", but never a truncated original.
+package atomicfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Step is a step of writing a file atomically.
+type Step string
+
+const (
+	// StepWrite writes the content to the temporary file.
+	StepWrite Step = "write"
+
+	// StepSync flushes the temporary file to disk.
+	StepSync Step = "sync"
+
+	// StepRename renames the temporary file over the original.
+	StepRename Step = "rename"
+)
+
+// Hook is called before each step of a write, to inject faults.
+//
+// If it returns an error, the write stops there without cleaning up, as if
+// the process had crashed, and returns the error.
+type Hook func(step Step) error
+
+// Write replaces the file at the path with the data.
+func Write(path string, data []byte, perm os.FileMode) error {
+	return WriteWithHook(path, data, perm, nil)
+}
+
+// WriteWithHook is like Write, calling the hook before each step if it's
+// not nil.
+func WriteWithHook(path string, data []byte, perm os.FileMode, hook Hook) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, fmt.Sprintf(".%s.*.tmp", filepath.Base(path)))
+	if err != nil {
+		return fmt.Errorf("atomicfile: can't create a temporary file: %v", err)
+	}
+	tmpPath := tmp.Name()
+
+	if err := writeTemp(tmp, data, perm, hook); err != nil {
+		_ = tmp.Close()
+		if !errors.Is(err, errInjected) {
+			_ = os.Remove(tmpPath)
+		}
+		return err
+	}
+
+	if err := runHook(hook, StepRename); err != nil {
+		return err
+	}
+	if err := rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("atomicfile: can't replace %s: %v", path, err)
+	}
+
+	// The rename is only durable once the directory is synced, though a
+	// crash before then leaves the old file rather than a partial one.
+	syncDir(dir)
+	return nil
+}
+
+// errInjected marks errors from a hook, after which nothing is cleaned up.
+var errInjected = errors.New("atomicfile: fault injected")
+
+func runHook(hook Hook, step Step) error {
+	if hook == nil {
+		return nil
+	}
+	if err := hook(step); err != nil {
+		return fmt.Errorf("%w before %s: %v", errInjected, step, err)
+	}
+	return nil
+}
+
+// writeTemp writes the data to the temporary file, syncs and closes it.
+func writeTemp(tmp *os.File, data []byte, perm os.FileMode, hook Hook) error {
+	if err := runHook(hook, StepWrite); err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("atomicfile: can't write %s: %v", tmp.Name(), err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("atomicfile: can't set the mode of %s: %v", tmp.Name(), err)
+	}
+
+	if err := runHook(hook, StepSync); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("atomicfile: can't sync %s: %v", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("atomicfile: can't close %s: %v", tmp.Name(), err)
+	}
+	return nil
+}
+
+// ReadJSON decodes the JSON file at the path into v, and returns whether
+// it did.
+//
+// A file that doesn't exist, or that can't be decoded, such as one left
+// truncated by a crash while an older version wrote it in place, is
+// treated as absent: ReadJSON returns false, with a warning in the log if
+// the file was corrupt. Other errors, like a lack of permissions, are
+// returned.
+func ReadJSON(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		slog.Warn(
+			"atomicfile: ignoring a corrupt file",
+			"path", path,
+			"error", err,
+		)
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,103 @@
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/internal/faults"
+)
+
+// tempFiles returns the temporary files left in the directory.
+func tempFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	require.NoError(t, err)
+	return matches
+}
+
+func TestWrite_ReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"old": true}`), 0o644))
+
+	err := atomicfile.Write(path, []byte(`{"new": true}`), 0o600)
+
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"new": true}`, string(content))
+	assert.Empty(t, tempFiles(t, dir))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
+
+func TestWrite_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "state.json")
+
+	err := atomicfile.Write(path, []byte("{}"), 0o644)
+
+	assert.Error(t, err)
+}
+
+// A writer killed at any step leaves the old file whole, and the next
+// write succeeds.
+func TestWrite_CrashBetweenStepsKeepsOldFile(t *testing.T) {
+	for _, step := range []atomicfile.Step{
+		atomicfile.StepWrite,
+		atomicfile.StepSync,
+		atomicfile.StepRename,
+	} {
+		t.Run(string(step), func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "state.json")
+			require.NoError(t, atomicfile.Write(path, []byte(`{"n": 1}`), 0o644))
+			injector, err := faults.Parse("atomic-write-crash=" + string(step))
+			require.NoError(t, err)
+			hook := injector.AtomicWriteHook()
+
+			crashErr := atomicfile.WriteWithHook(path, []byte(`{"n": 2}`), 0o644, hook)
+			var afterCrash struct{ N int }
+			found, readErr := atomicfile.ReadJSON(path, &afterCrash)
+			leftover := tempFiles(t, dir)
+			retryErr := atomicfile.WriteWithHook(path, []byte(`{"n": 3}`), 0o644, hook)
+			var afterRetry struct{ N int }
+			_, _ = atomicfile.ReadJSON(path, &afterRetry)
+
+			assert.ErrorContains(t, crashErr, "before "+string(step))
+			require.NoError(t, readErr)
+			assert.True(t, found)
+			assert.Equal(t, 1, afterCrash.N)
+			assert.Len(t, leftover, 1)
+			assert.NoError(t, retryErr)
+			assert.Equal(t, 3, afterRetry.N)
+		})
+	}
+}
+
+func TestReadJSON_MissingIsAbsent(t *testing.T) {
+	var v map[string]any
+
+	found, err := atomicfile.ReadJSON(filepath.Join(t.TempDir(), "none.json"), &v)
+
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestReadJSON_CorruptIsAbsent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "truncated.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"last_acked": 4`), 0o644))
+	var v map[string]any
+
+	found, err := atomicfile.ReadJSON(path, &v)
+
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
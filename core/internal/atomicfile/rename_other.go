@@ -0,0 +1,20 @@
+//go:build !windows
+
+package atomicfile
+
+import "os"
+
+// rename renames the file at oldPath over the one at newPath.
+func rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+// syncDir flushes the directory's entries to disk, if possible.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
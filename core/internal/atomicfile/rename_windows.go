@@ -0,0 +1,49 @@
+//go:build windows
+
+package atomicfile
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+const (
+	// renameAttempts is how many times to try replacing a file that's
+	// briefly in use.
+	renameAttempts = 10
+
+	// renameRetryWait is how long to wait between attempts to replace a
+	// file, doubled after each one.
+	renameRetryWait = 10 * time.Millisecond
+
+	// errorSharingViolation is ERROR_SHARING_VIOLATION.
+	errorSharingViolation = syscall.Errno(32)
+)
+
+// rename renames the file at oldPath over the one at newPath.
+//
+// Windows refuses to replace a file that another process has open without
+// sharing it for deletion, like a reader, an indexer or an antivirus, so
+// the rename is retried for a little while.
+func rename(oldPath, newPath string) error {
+	wait := renameRetryWait
+	for attempt := 1; ; attempt++ {
+		err := os.Rename(oldPath, newPath)
+		if err == nil || attempt == renameAttempts || !isInUse(err) {
+			return err
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+func isInUse(err error) bool {
+	return errors.Is(err, syscall.ERROR_ACCESS_DENIED) ||
+		errors.Is(err, errorSharingViolation)
+}
+
+// syncDir does nothing: directories can't be synced on Windows, where
+// renames are durable once they return.
+func syncDir(string) {}
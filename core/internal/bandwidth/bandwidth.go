@@ -0,0 +1,138 @@
+// Package bandwidth caps how fast data is sent to the server.
+//
+// A Limiter is a token bucket shared by all the traffic it wraps, so that
+// concurrent uploads add up to at most the cap between them. Its cap can
+// change while traffic flows through it.
+package bandwidth
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// minBurst is the fewest bytes a limited read can return at once.
+const minBurst = 4096
+
+// Limiter caps the rate at which bytes pass through it.
+//
+// The methods of a nil Limiter don't limit anything.
+type Limiter struct {
+	limiter *rate.Limiter
+}
+
+// NewLimiter returns a limiter with the given cap in bytes per second, or
+// no cap if it's 0.
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	l := &Limiter{limiter: rate.NewLimiter(rate.Inf, 0)}
+	l.SetBytesPerSecond(bytesPerSecond)
+	return l
+}
+
+// SetBytesPerSecond changes the cap, or removes it if it's 0.
+//
+// Traffic that is waiting for the limiter uses the new cap from then on.
+func (l *Limiter) SetBytesPerSecond(bytesPerSecond int64) {
+	if l == nil {
+		return
+	}
+
+	if bytesPerSecond <= 0 {
+		l.limiter.SetLimit(rate.Inf)
+		return
+	}
+
+	// A burst of a tenth of a second keeps the rate smooth.
+	l.limiter.SetBurst(max(int(bytesPerSecond/10), minBurst))
+	l.limiter.SetLimit(rate.Limit(bytesPerSecond))
+}
+
+// BytesPerSecond is the cap, or 0 if there's none.
+func (l *Limiter) BytesPerSecond() int64 {
+	if l == nil || l.limiter.Limit() == rate.Inf {
+		return 0
+	}
+	return int64(l.limiter.Limit())
+}
+
+// Reader returns a reader that reads from r no faster than the cap.
+//
+// Reads wait for the limiter until the context is done.
+func (l *Limiter) Reader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &limitedReader{ctx: ctx, reader: r, limiter: l.limiter}
+}
+
+// Transport wraps the transport so that request bodies are sent no faster
+// than the cap.
+func (l *Limiter) Transport(rt http.RoundTripper) http.RoundTripper {
+	if l == nil {
+		return rt
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Body == nil || req.Body == http.NoBody {
+			return rt.RoundTrip(req)
+		}
+
+		limited := req.Clone(req.Context())
+		limited.Body = &limitedReadCloser{
+			Reader: l.Reader(req.Context(), req.Body),
+			Closer: req.Body,
+		}
+		return rt.RoundTrip(limited)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedReader waits for the limiter after each read.
+type limitedReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	// A read can't take more tokens than the bucket holds.
+	if r.limiter.Limit() != rate.Inf {
+		if burst := r.limiter.Burst(); len(p) > burst {
+			p = p[:burst]
+		}
+	}
+
+	n, err := r.reader.Read(p)
+	if waitErr := r.wait(n); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	return n, err
+}
+
+// wait takes n tokens from the limiter, a burst at a time in case the cap
+// changed since the read.
+func (r *limitedReader) wait(n int) error {
+	for n > 0 {
+		chunk := n
+		if r.limiter.Limit() != rate.Inf {
+			chunk = min(chunk, max(r.limiter.Burst(), 1))
+		}
+
+		if err := r.limiter.WaitN(r.ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
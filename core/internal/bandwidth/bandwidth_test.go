@@ -0,0 +1,109 @@
+package bandwidth_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/bandwidth"
+)
+
+// upload sends the bytes to the server through the limiter, and returns
+// how long it took.
+func upload(
+	t *testing.T,
+	limiter *bandwidth.Limiter,
+	server *httptest.Server,
+	size int,
+) time.Duration {
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+
+	start := time.Now()
+	resp, err := client.Post(server.URL, "", bytes.NewReader(make([]byte, size)))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return time.Since(start)
+}
+
+func newServer(t *testing.T, received *int64) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			n, _ := io.Copy(io.Discard, r.Body)
+			*received = n
+		}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLimiter_Transport(t *testing.T) {
+	var received int64
+	server := newServer(t, &received)
+	limiter := bandwidth.NewLimiter(100_000)
+
+	elapsed := upload(t, limiter, server, 250_000)
+
+	// The first burst of a tenth of a second is sent at once.
+	expected := time.Duration(float64(250_000-10_000) / 100_000 * float64(time.Second))
+	assert.EqualValues(t, 250_000, received)
+	assert.InDelta(t, expected.Seconds(), elapsed.Seconds(), 0.2*expected.Seconds())
+}
+
+func TestLimiter_NoCap(t *testing.T) {
+	var received int64
+	server := newServer(t, &received)
+
+	elapsed := upload(t, bandwidth.NewLimiter(0), server, 10_000_000)
+
+	assert.EqualValues(t, 10_000_000, received)
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestLimiter_SetBytesPerSecond(t *testing.T) {
+	limiter := bandwidth.NewLimiter(20_000)
+	reader := limiter.Reader(context.Background(), bytes.NewReader(make([]byte, 1_000_000)))
+
+	// At 20 kB/s, reading everything would take nearly a minute.
+	start := time.Now()
+	_, err := io.ReadFull(reader, make([]byte, 10_000))
+	require.NoError(t, err)
+	limiter.SetBytesPerSecond(0)
+	n, err := io.Copy(io.Discard, reader)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 990_000, n)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Zero(t, limiter.BytesPerSecond())
+
+	limiter.SetBytesPerSecond(50_000)
+	assert.EqualValues(t, 50_000, limiter.BytesPerSecond())
+}
+
+func TestLimiter_ContextCancelled(t *testing.T) {
+	limiter := bandwidth.NewLimiter(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reader := limiter.Reader(ctx, bytes.NewReader(make([]byte, 100_000)))
+	_, err := io.ReadAll(reader)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var limiter *bandwidth.Limiter
+	source := bytes.NewReader(nil)
+
+	limiter.SetBytesPerSecond(100)
+
+	assert.Zero(t, limiter.BytesPerSecond())
+	assert.Same(t, source, limiter.Reader(context.Background(), source))
+	assert.Equal(t, http.DefaultTransport, limiter.Transport(http.DefaultTransport))
+}
@@ -0,0 +1,93 @@
+// Package consolededup collapses repeated console lines.
+package consolededup
+
+import (
+	"fmt"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Deduper collapses runs of identical consecutive console lines.
+//
+// Once a line has been seen as many times in a row as the threshold, its
+// further repeats are held back and counted, then replaced by one
+// "last line repeated N times" marker when a different line arrives or
+// the output is flushed.
+//
+// The methods of a nil Deduper pass every line through.
+type Deduper struct {
+	threshold int
+
+	// last is the last line passed through, or nil after a flush
+	last *service.OutputRawRecord
+
+	// seen is how many times in a row the last line was added
+	seen int
+
+	// lastHeld is the latest of the repeats held back, for its timestamp
+	lastHeld *service.OutputRawRecord
+}
+
+// New returns a Deduper passing through a line at most threshold times in
+// a row, or nil if the threshold isn't positive.
+func New(threshold int) *Deduper {
+	if threshold <= 0 {
+		return nil
+	}
+	return &Deduper{threshold: threshold}
+}
+
+// Add returns the lines to output now that the line was printed.
+func (d *Deduper) Add(line *service.OutputRawRecord) []*service.OutputRawRecord {
+	if d == nil {
+		return []*service.OutputRawRecord{line}
+	}
+
+	if d.last != nil &&
+		d.last.GetOutputType() == line.GetOutputType() &&
+		d.last.GetLine() == line.GetLine() {
+		d.seen++
+		if d.seen > d.threshold {
+			d.lastHeld = line
+			return nil
+		}
+		return []*service.OutputRawRecord{line}
+	}
+
+	lines := d.Flush()
+	d.last = line
+	d.seen = 1
+	return append(lines, line)
+}
+
+// Flush returns the marker for the repeats held back, if any, and forgets
+// the last line, so that no repeats are counted across the flush.
+func (d *Deduper) Flush() []*service.OutputRawRecord {
+	if d == nil || d.last == nil {
+		return nil
+	}
+
+	var lines []*service.OutputRawRecord
+	if held := d.seen - d.threshold; held > 0 {
+		lines = append(lines, marker(d.lastHeld, held))
+	}
+	d.last = nil
+	d.seen = 0
+	d.lastHeld = nil
+	return lines
+}
+
+// marker is the line replacing repeats held back, the last of which is
+// line.
+func marker(line *service.OutputRawRecord, repeats int) *service.OutputRawRecord {
+	text := "last line repeated 1 time"
+	if repeats > 1 {
+		text = fmt.Sprintf("last line repeated %d times", repeats)
+	}
+
+	return &service.OutputRawRecord{
+		OutputType: line.GetOutputType(),
+		Timestamp:  line.GetTimestamp(),
+		Line:       text,
+	}
+}
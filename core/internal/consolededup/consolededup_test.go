@@ -0,0 +1,150 @@
+package consolededup_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/internal/consolededup"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func stdout(line string) *service.OutputRawRecord {
+	return &service.OutputRawRecord{
+		OutputType: service.OutputRawRecord_STDOUT,
+		Line:       line,
+	}
+}
+
+// output adds the lines to the deduper and flushes it, returning the text
+// of the lines it outputs.
+func output(d *consolededup.Deduper, lines ...*service.OutputRawRecord) []string {
+	var out []string
+	collect := func(records []*service.OutputRawRecord) {
+		for _, record := range records {
+			out = append(out, record.Line)
+		}
+	}
+	for _, line := range lines {
+		collect(d.Add(line))
+	}
+	collect(d.Flush())
+	return out
+}
+
+func repeated(line string, n int) []*service.OutputRawRecord {
+	lines := make([]*service.OutputRawRecord, n)
+	for i := range lines {
+		lines[i] = stdout(line)
+	}
+	return lines
+}
+
+func TestDeduper_CollapsesRepeats(t *testing.T) {
+	d := consolededup.New(2)
+
+	lines := append(repeated("loss: nan", 1000), stdout("done"))
+	out := output(d, lines...)
+
+	assert.Equal(t,
+		[]string{
+			"loss: nan",
+			"loss: nan",
+			"last line repeated 998 times",
+			"done",
+		},
+		out)
+}
+
+func TestDeduper_BelowThreshold(t *testing.T) {
+	d := consolededup.New(3)
+
+	out := output(d, stdout("a"), stdout("a"), stdout("b"), stdout("a"))
+
+	assert.Equal(t, []string{"a", "a", "b", "a"}, out)
+}
+
+func TestDeduper_SingleRepeat(t *testing.T) {
+	d := consolededup.New(1)
+
+	out := output(d, stdout("a"), stdout("a"))
+
+	assert.Equal(t, []string{"a", "last line repeated 1 time"}, out)
+}
+
+func TestDeduper_AlternatingLines(t *testing.T) {
+	d := consolededup.New(1)
+
+	var lines []*service.OutputRawRecord
+	for i := range 100 {
+		lines = append(lines, stdout(fmt.Sprint(i%2)))
+	}
+	out := output(d, lines...)
+
+	assert.Len(t, out, 100)
+}
+
+func TestDeduper_StreamsAreDistinct(t *testing.T) {
+	d := consolededup.New(1)
+
+	out := output(d,
+		stdout("x"),
+		&service.OutputRawRecord{
+			OutputType: service.OutputRawRecord_STDERR,
+			Line:       "x",
+		},
+		stdout("x"),
+	)
+
+	assert.Equal(t, []string{"x", "x", "x"}, out)
+}
+
+func TestDeduper_MarkerKeepsStream(t *testing.T) {
+	d := consolededup.New(1)
+	line := &service.OutputRawRecord{
+		OutputType: service.OutputRawRecord_STDERR,
+		Line:       "warning",
+	}
+
+	d.Add(line)
+	d.Add(line)
+	flushed := d.Flush()
+
+	assert.Len(t, flushed, 1)
+	assert.Equal(t, service.OutputRawRecord_STDERR, flushed[0].OutputType)
+}
+
+func TestDeduper_FlushIsBoundary(t *testing.T) {
+	d := consolededup.New(1)
+
+	var out []string
+	for range 2 {
+		for _, line := range repeated("epoch", 5) {
+			for _, record := range d.Add(line) {
+				out = append(out, record.Line)
+			}
+		}
+		for _, record := range d.Flush() {
+			out = append(out, record.Line)
+		}
+	}
+
+	// Repeats after the flush aren't counted with those before it.
+	assert.Equal(t,
+		[]string{
+			"epoch", "last line repeated 4 times",
+			"epoch", "last line repeated 4 times",
+		},
+		out)
+	assert.Empty(t, d.Flush())
+}
+
+func TestDeduper_Disabled(t *testing.T) {
+	d := consolededup.New(0)
+
+	out := output(d, repeated("same", 10)...)
+
+	assert.Nil(t, d)
+	assert.Len(t, out, 10)
+}
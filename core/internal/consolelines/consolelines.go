@@ -0,0 +1,127 @@
+// Package consolelines assembles timestamped lines from console output.
+package consolelines
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// TimeFormat is the ISO-8601 format of the timestamps prefixing lines.
+const TimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// Assembler puts together the lines of console output from the chunks
+// it's written in, stamping each with when it was printed.
+//
+// A line is stamped with the time the first chunk of it arrived. A
+// carriage return rewrites the line, as progress bars do, so the line is
+// then stamped with the time the first chunk of its final state arrived.
+type Assembler struct {
+	// partial are the lines being assembled, by stream
+	partial map[service.OutputRawRecord_OutputType]*partialLine
+}
+
+type partialLine struct {
+	text strings.Builder
+
+	// timestamp is when the line's final state started, or nil if it
+	// hasn't yet
+	timestamp *timestamppb.Timestamp
+
+	// afterCR is whether the last character was a carriage return, which
+	// rewrites the line unless it's followed by a newline
+	afterCR bool
+}
+
+// New returns an Assembler.
+func New() *Assembler {
+	return &Assembler{
+		partial: make(map[service.OutputRawRecord_OutputType]*partialLine),
+	}
+}
+
+// Add returns the lines completed by the chunk of output.
+func (a *Assembler) Add(chunk *service.OutputRawRecord) []*service.OutputRawRecord {
+	timestamp := chunk.GetTimestamp()
+	if timestamp == nil {
+		timestamp = timestamppb.Now()
+	}
+
+	line := a.partial[chunk.GetOutputType()]
+	if line == nil {
+		line = &partialLine{}
+		a.partial[chunk.GetOutputType()] = line
+	}
+
+	var lines []*service.OutputRawRecord
+	for _, c := range chunk.GetLine() {
+		if line.afterCR {
+			line.afterCR = false
+			if c != '\n' {
+				line.text.Reset()
+				line.timestamp = nil
+			}
+		}
+
+		switch c {
+		case '\n':
+			lines = append(lines, line.complete(chunk.GetOutputType(), timestamp))
+		case '\r':
+			line.afterCR = true
+		default:
+			if line.timestamp == nil {
+				line.timestamp = timestamp
+			}
+			line.text.WriteRune(c)
+		}
+	}
+	return lines
+}
+
+// Flush returns the lines that are incomplete, as they are.
+//
+// A carriage return at the end of a line is dropped: the line isn't
+// rewritten after all.
+func (a *Assembler) Flush() []*service.OutputRawRecord {
+	var lines []*service.OutputRawRecord
+	for _, outputType := range []service.OutputRawRecord_OutputType{
+		service.OutputRawRecord_STDOUT,
+		service.OutputRawRecord_STDERR,
+	} {
+		line := a.partial[outputType]
+		if line == nil || line.timestamp == nil {
+			continue
+		}
+		lines = append(lines, line.complete(outputType, nil))
+	}
+	return lines
+}
+
+// complete returns the line and starts the next one.
+//
+// An empty line is stamped with the given time.
+func (line *partialLine) complete(
+	outputType service.OutputRawRecord_OutputType,
+	timestamp *timestamppb.Timestamp,
+) *service.OutputRawRecord {
+	if line.timestamp != nil {
+		timestamp = line.timestamp
+	}
+	record := &service.OutputRawRecord{
+		OutputType: outputType,
+		Timestamp:  timestamp,
+		Line:       line.text.String(),
+	}
+
+	line.text.Reset()
+	line.timestamp = nil
+	line.afterCR = false
+	return record
+}
+
+// Stamped is the line prefixed with its timestamp.
+func Stamped(line *service.OutputRawRecord) string {
+	return line.GetTimestamp().AsTime().UTC().Format(TimeFormat) + " " + line.GetLine()
+}
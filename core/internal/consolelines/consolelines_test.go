@@ -0,0 +1,114 @@
+package consolelines_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wandb/wandb/core/internal/consolelines"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+var start = time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+
+// chunk is output printed the given number of seconds after the start.
+func chunk(seconds int, text string) *service.OutputRawRecord {
+	return &service.OutputRawRecord{
+		OutputType: service.OutputRawRecord_STDOUT,
+		Timestamp:  timestamppb.New(start.Add(time.Duration(seconds) * time.Second)),
+		Line:       text,
+	}
+}
+
+// stamped returns the stamped lines the chunks make up, flushed at the end.
+func stamped(chunks ...*service.OutputRawRecord) []string {
+	a := consolelines.New()
+	var out []string
+	for _, c := range chunks {
+		for _, line := range a.Add(c) {
+			out = append(out, consolelines.Stamped(line))
+		}
+	}
+	for _, line := range a.Flush() {
+		out = append(out, consolelines.Stamped(line))
+	}
+	return out
+}
+
+func TestAssembler_StampsWithFirstChunk(t *testing.T) {
+	out := stamped(chunk(0, "epoch 1"), chunk(1, ": loss"), chunk(2, " 0.5\n"))
+
+	assert.Equal(t,
+		[]string{"2024-05-06T07:08:09.123456Z epoch 1: loss 0.5"},
+		out)
+}
+
+func TestAssembler_SplitsLines(t *testing.T) {
+	out := stamped(chunk(0, "a\nb"), chunk(1, "\n\nc\n"))
+
+	assert.Equal(t,
+		[]string{
+			"2024-05-06T07:08:09.123456Z a",
+			"2024-05-06T07:08:09.123456Z b",
+			"2024-05-06T07:08:10.123456Z ",
+			"2024-05-06T07:08:10.123456Z c",
+		},
+		out)
+}
+
+func TestAssembler_RewrittenLineStampedWithFinalState(t *testing.T) {
+	out := stamped(
+		chunk(0, " 10%|#"),
+		chunk(1, "\r 50%|#####"),
+		chunk(2, "\r"),
+		chunk(3, "100%|"),
+		chunk(4, "##########|\n"),
+		chunk(5, "done\r\n"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"2024-05-06T07:08:12.123456Z 100%|##########|",
+			"2024-05-06T07:08:14.123456Z done",
+		},
+		out)
+}
+
+func TestAssembler_FlushesPartialLines(t *testing.T) {
+	a := consolelines.New()
+
+	assert.Empty(t, a.Add(chunk(0, "Continue? [y/n] ")))
+	assert.Empty(t, a.Add(&service.OutputRawRecord{
+		OutputType: service.OutputRawRecord_STDERR,
+		Timestamp:  timestamppb.New(start),
+		Line:       "warn\r",
+	}))
+	lines := a.Flush()
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Continue? [y/n] ", lines[0].Line)
+	assert.Equal(t, service.OutputRawRecord_STDERR, lines[1].OutputType)
+	assert.Equal(t, "warn", lines[1].Line)
+	assert.Empty(t, a.Flush())
+}
+
+func TestAssembler_StreamsAreSeparate(t *testing.T) {
+	a := consolelines.New()
+
+	a.Add(chunk(0, "out "))
+	errLines := a.Add(&service.OutputRawRecord{
+		OutputType: service.OutputRawRecord_STDERR,
+		Timestamp:  timestamppb.New(start.Add(time.Second)),
+		Line:       "err\n",
+	})
+	outLines := a.Add(chunk(2, "line\n"))
+
+	require.Len(t, errLines, 1)
+	assert.Equal(t, "err", errLines[0].Line)
+	require.Len(t, outLines, 1)
+	assert.Equal(t, "out line", outLines[0].Line)
+	assert.True(t, outLines[0].Timestamp.AsTime().Equal(start))
+}
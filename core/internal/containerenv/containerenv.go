@@ -0,0 +1,348 @@
+// Package containerenv detects whether a process runs in a container
+// or a Kubernetes pod, and collects information about it.
+//
+// All detection is best-effort: missing files, unexpected formats and
+// unreachable services result in less information, never in an error.
+package containerenv
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the default time limit for querying the Kubernetes API.
+const DefaultTimeout = 2 * time.Second
+
+// serviceAccountDir is where Kubernetes mounts the pod's service account.
+const serviceAccountDir = "var/run/secrets/kubernetes.io/serviceaccount"
+
+// containerIDPattern matches the 64 character container IDs used by
+// docker, containerd and CRI-O.
+var containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// runtimeMarkers maps substrings of cgroup paths to the container runtime
+// that creates them, in the order in which they are checked.
+var runtimeMarkers = []struct {
+	marker  string
+	runtime string
+}{
+	{"docker", "docker"},
+	{"containerd", "containerd"},
+	{"crio", "cri-o"},
+	{"libpod", "podman"},
+}
+
+// Info is the container context of a process.
+type Info struct {
+	// Runtime is the container runtime, e.g. "docker" or "containerd".
+	//
+	// It is "unknown" if the process is in a container but the runtime
+	// could not be determined.
+	Runtime string
+
+	// ContainerID is the ID of the container, if found in the cgroups.
+	ContainerID string
+
+	// Image is the container image, if discoverable.
+	Image string
+
+	// PodName is the name of the Kubernetes pod.
+	PodName string
+
+	// Namespace is the Kubernetes namespace of the pod.
+	Namespace string
+
+	// NodeName is the name of the Kubernetes node running the pod.
+	NodeName string
+}
+
+// IsContainer returns whether the process runs in a container.
+func (info *Info) IsContainer() bool {
+	return info.Runtime != ""
+}
+
+// IsKubernetes returns whether the process runs in a Kubernetes pod.
+func (info *Info) IsKubernetes() bool {
+	return info.PodName != "" || info.Namespace != ""
+}
+
+// Detector inspects the filesystem and environment of a process.
+type Detector struct {
+	// Root is the filesystem root; "/" if empty.
+	Root string
+
+	// Environ is the environment in the format returned by os.Environ.
+	Environ []string
+
+	// APIServer is the base URL of the Kubernetes API.
+	//
+	// If empty, it is derived from the KUBERNETES_SERVICE_HOST and
+	// KUBERNETES_SERVICE_PORT variables.
+	APIServer string
+
+	// Client is the HTTP client used to query the Kubernetes API.
+	//
+	// If nil, a client that trusts the service account's CA is used.
+	Client *http.Client
+}
+
+// NewDetector returns a Detector for the current process.
+func NewDetector() *Detector {
+	return &Detector{Environ: os.Environ()}
+}
+
+// Detect returns the container context of the process.
+//
+// Returns nil if the process does not appear to run in a container.
+// The context bounds the time spent querying the Kubernetes API.
+func (d *Detector) Detect(ctx context.Context) *Info {
+	env := make(map[string]string)
+	for _, entry := range d.Environ {
+		if name, value, found := strings.Cut(entry, "="); found {
+			env[name] = value
+		}
+	}
+
+	info := &Info{}
+	d.detectContainer(info)
+	d.detectKubernetes(info, env)
+
+	if info.Runtime == "" && info.IsKubernetes() {
+		info.Runtime = "unknown"
+	}
+	if !info.IsContainer() {
+		return nil
+	}
+
+	info.Image = firstNonEmpty(env["CONTAINER_IMAGE"], env["IMAGE_NAME"])
+	if info.Image == "" && info.IsKubernetes() {
+		info.Image = d.podImage(ctx, env, info)
+	}
+
+	return info
+}
+
+// detectContainer determines the runtime and container ID from the cgroups
+// and marker files of the process.
+func (d *Detector) detectContainer(info *Info) {
+	if content, err := os.ReadFile(d.path("proc/self/cgroup")); err == nil {
+		info.Runtime, info.ContainerID = parseCgroups(string(content))
+	}
+	if info.ContainerID == "" {
+		if content, err := os.ReadFile(d.path("proc/self/mountinfo")); err == nil {
+			runtime, containerID := parseCgroups(hostnameMounts(string(content)))
+			if info.Runtime == "" {
+				info.Runtime = runtime
+			}
+			info.ContainerID = containerID
+		}
+	}
+
+	if info.Runtime == "" && d.exists(".dockerenv") {
+		info.Runtime = "docker"
+	}
+	if info.Runtime == "" && d.exists("run/.containerenv") {
+		info.Runtime = "podman"
+	}
+}
+
+// hostnameMounts returns the lines of /proc/self/mountinfo that describe
+// the mounts of /etc/hostname, /etc/hosts and /etc/resolv.conf.
+//
+// With cgroup v2 and a private cgroup namespace the cgroup is just "/",
+// but container runtimes bind-mount these files from a directory named
+// after the container. Other mounts are ignored, since on a host running
+// containers they also refer to the runtime's directories.
+func hostnameMounts(content string) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		switch fields[4] {
+		case "/etc/hostname", "/etc/hosts", "/etc/resolv.conf":
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseCgroups finds the container runtime and ID in the contents of
+// /proc/self/cgroup, or in the mount info lines returned by hostnameMounts.
+func parseCgroups(content string) (runtime string, containerID string) {
+	for _, line := range strings.Split(content, "\n") {
+		lineRuntime := ""
+		for _, m := range runtimeMarkers {
+			if strings.Contains(line, m.marker) {
+				lineRuntime = m.runtime
+				break
+			}
+		}
+		if lineRuntime == "" && !strings.Contains(line, "kubepods") {
+			continue
+		}
+
+		if runtime == "" {
+			runtime = lineRuntime
+		}
+		if containerID == "" {
+			containerID = containerIDPattern.FindString(line)
+		}
+		if runtime != "" && containerID != "" {
+			break
+		}
+	}
+	if runtime == "" && containerID != "" {
+		runtime = "unknown"
+	}
+	return runtime, containerID
+}
+
+// detectKubernetes fills in the pod info from the downward API variables
+// and the service account files.
+func (d *Detector) detectKubernetes(info *Info, env map[string]string) {
+	inCluster := env["KUBERNETES_SERVICE_HOST"] != "" || d.exists(serviceAccountDir)
+	if !inCluster {
+		return
+	}
+
+	info.PodName = firstNonEmpty(env["POD_NAME"], env["K8S_POD_NAME"])
+	info.Namespace = firstNonEmpty(env["POD_NAMESPACE"], env["K8S_NAMESPACE"])
+	info.NodeName = firstNonEmpty(env["NODE_NAME"], env["K8S_NODE_NAME"])
+
+	if info.Namespace == "" {
+		if content, err := os.ReadFile(d.path(serviceAccountDir, "namespace")); err == nil {
+			info.Namespace = strings.TrimSpace(string(content))
+		}
+	}
+	// the hostname of a pod is its name unless overridden in the pod spec
+	if info.PodName == "" {
+		info.PodName = env["HOSTNAME"]
+	}
+}
+
+// podImage looks up the image of the container in the pod spec.
+//
+// This requires the pod's service account to be allowed to get pods,
+// which is often not the case; an empty string is returned on failure.
+func (d *Detector) podImage(ctx context.Context, env map[string]string, info *Info) string {
+	if info.PodName == "" || info.Namespace == "" {
+		return ""
+	}
+
+	apiServer := d.APIServer
+	if apiServer == "" {
+		host, port := env["KUBERNETES_SERVICE_HOST"], env["KUBERNETES_SERVICE_PORT"]
+		if host == "" || port == "" {
+			return ""
+		}
+		apiServer = "https://" + net.JoinHostPort(host, port)
+	}
+
+	token, err := os.ReadFile(d.path(serviceAccountDir, "token"))
+	if err != nil {
+		return ""
+	}
+
+	client := d.Client
+	if client == nil {
+		client, err = d.serviceAccountClient()
+		if err != nil {
+			return ""
+		}
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s", apiServer, info.Namespace, info.PodName),
+		nil,
+	)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var pod struct {
+		Status struct {
+			ContainerStatuses []struct {
+				Image       string `json:"image"`
+				ContainerID string `json:"containerID"`
+			} `json:"containerStatuses"`
+		} `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pod); err != nil {
+		return ""
+	}
+
+	// container IDs are reported as e.g. "containerd://<id>"
+	statuses := pod.Status.ContainerStatuses
+	for _, status := range statuses {
+		if info.ContainerID != "" && strings.HasSuffix(status.ContainerID, info.ContainerID) {
+			return status.Image
+		}
+	}
+	if len(statuses) == 1 {
+		return statuses[0].Image
+	}
+	return ""
+}
+
+// serviceAccountClient returns an HTTP client that trusts the cluster CA.
+func (d *Detector) serviceAccountClient() (*http.Client, error) {
+	caCert, err := os.ReadFile(d.path(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("containerenv: no certificates in ca.crt")
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}, nil
+}
+
+func (d *Detector) path(elem ...string) string {
+	root := d.Root
+	if root == "" {
+		root = "/"
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+func (d *Detector) exists(elem ...string) bool {
+	_, err := os.Stat(d.path(elem...))
+	return err == nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,190 @@
+package containerenv_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/containerenv"
+)
+
+const containerID = "3f4e2b1a9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f"
+
+// writeFiles creates files relative to a temporary root directory.
+func writeFiles(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return root
+}
+
+func TestDetect_NotInContainer(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"proc/self/cgroup": "0::/user.slice/user-1000.slice/session-2.scope\n",
+		"proc/self/mountinfo": "24 1 259:2 / / rw,relatime - ext4 /dev/nvme0n1p2 rw\n" +
+			"300 24 0:52 / /var/lib/docker/overlay2/abc/merged rw - overlay overlay rw\n",
+	})
+	detector := &containerenv.Detector{Root: root, Environ: []string{"HOSTNAME=laptop"}}
+
+	assert.Nil(t, detector.Detect(context.Background()))
+}
+
+func TestDetect_DockerCgroupV1(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"proc/self/cgroup": "12:pids:/docker/" + containerID + "\n" +
+			"11:memory:/docker/" + containerID + "\n",
+	})
+	detector := &containerenv.Detector{Root: root}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "docker", info.Runtime)
+	assert.Equal(t, containerID, info.ContainerID)
+	assert.False(t, info.IsKubernetes())
+}
+
+func TestDetect_DockerCgroupV2(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"proc/self/cgroup": "0::/\n",
+		"proc/self/mountinfo": "24 1 0:50 / / rw - overlay overlay rw\n" +
+			"520 24 259:2 /var/lib/docker/containers/" + containerID +
+			"/hostname /etc/hostname rw,relatime - ext4 /dev/nvme0n1p2 rw\n",
+		".dockerenv": "",
+	})
+	detector := &containerenv.Detector{Root: root, Environ: []string{"CONTAINER_IMAGE=pytorch/pytorch:2.3.0"}}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "docker", info.Runtime)
+	assert.Equal(t, containerID, info.ContainerID)
+	assert.Equal(t, "pytorch/pytorch:2.3.0", info.Image)
+}
+
+func TestDetect_DockerEnvOnly(t *testing.T) {
+	root := writeFiles(t, map[string]string{".dockerenv": ""})
+	detector := &containerenv.Detector{Root: root}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "docker", info.Runtime)
+	assert.Empty(t, info.ContainerID)
+}
+
+func TestDetect_Kubernetes(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"proc/self/cgroup": "0::/kubepods.slice/kubepods-burstable.slice/" +
+			"kubepods-burstable-pod1234.slice/cri-containerd-" + containerID + ".scope\n",
+		"var/run/secrets/kubernetes.io/serviceaccount/namespace": "training\n",
+	})
+	detector := &containerenv.Detector{
+		Root: root,
+		Environ: []string{
+			"KUBERNETES_SERVICE_HOST=10.0.0.1",
+			"HOSTNAME=trainer-0",
+			"NODE_NAME=gpu-node-3",
+		},
+	}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "containerd", info.Runtime)
+	assert.Equal(t, containerID, info.ContainerID)
+	assert.Equal(t, "trainer-0", info.PodName)
+	assert.Equal(t, "training", info.Namespace)
+	assert.Equal(t, "gpu-node-3", info.NodeName)
+	assert.True(t, info.IsKubernetes())
+	// no service account token, so the API is not queried
+	assert.Empty(t, info.Image)
+}
+
+func TestDetect_KubernetesDownwardAPI(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"var/run/secrets/kubernetes.io/serviceaccount/namespace": "default\n",
+	})
+	detector := &containerenv.Detector{
+		Root: root,
+		Environ: []string{
+			"KUBERNETES_SERVICE_HOST=10.0.0.1",
+			"HOSTNAME=custom-hostname",
+			"POD_NAME=trainer-1",
+			"POD_NAMESPACE=research",
+		},
+	}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "unknown", info.Runtime)
+	assert.Equal(t, "trainer-1", info.PodName)
+	assert.Equal(t, "research", info.Namespace)
+}
+
+func TestDetect_KubernetesPodImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/training/pods/trainer-0" ||
+			r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": {"containerStatuses": [
+			{"image": "busybox:latest", "containerID": "containerd://0000"},
+			{"image": "ghcr.io/org/trainer:v2", "containerID": "containerd://` + containerID + `"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	root := writeFiles(t, map[string]string{
+		"proc/self/cgroup": "0::/kubepods/besteffort/pod1234/" + containerID + "\n",
+		"var/run/secrets/kubernetes.io/serviceaccount/namespace": "training",
+		"var/run/secrets/kubernetes.io/serviceaccount/token":     "secret-token\n",
+	})
+	detector := &containerenv.Detector{
+		Root:      root,
+		Environ:   []string{"HOSTNAME=trainer-0"},
+		APIServer: server.URL,
+		Client:    server.Client(),
+	}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "unknown", info.Runtime)
+	assert.Equal(t, "ghcr.io/org/trainer:v2", info.Image)
+}
+
+func TestDetect_KubernetesPodImageForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	root := writeFiles(t, map[string]string{
+		"var/run/secrets/kubernetes.io/serviceaccount/namespace": "training",
+		"var/run/secrets/kubernetes.io/serviceaccount/token":     "token",
+	})
+	detector := &containerenv.Detector{
+		Root:      root,
+		Environ:   []string{"HOSTNAME=trainer-0"},
+		APIServer: server.URL,
+		Client:    server.Client(),
+	}
+
+	info := detector.Detect(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "trainer-0", info.PodName)
+	assert.Empty(t, info.Image)
+}
@@ -0,0 +1,347 @@
+// Package dryrun records the requests that a run would send to the backend
+// instead of sending them.
+//
+// In a dry run, the core serializes and batches its data as usual, but
+// each HTTP request is appended to a recording in the run's directory and
+// answered with a synthetic success, so that the run completes without
+// contacting the backend:
+//
+//	graphql-requests.jsonl     GraphQL operations and their variables
+//	filestream-requests.jsonl  filestream requests and their bodies
+//	uploads.jsonl              the manifest of file uploads
+//
+// Each line of a recording is a JSON object for one request, in the order
+// the requests were made. Retries don't happen, since requests succeed.
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the recordings in the run's directory.
+const (
+	GraphQLFileName    = "graphql-requests.jsonl"
+	FileStreamFileName = "filestream-requests.jsonl"
+	UploadsFileName    = "uploads.jsonl"
+)
+
+// UploadHost is the host of the upload URLs in synthetic responses.
+//
+// It's reserved, so an upload URL from a dry run never reaches a server
+// even if it leaks out of the recordings.
+const UploadHost = "dry-run.wandb.invalid"
+
+// GraphQLRequest is a line of the GraphQL recording.
+type GraphQLRequest struct {
+	Time          time.Time       `json:"time"`
+	OperationName string          `json:"operationName"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+}
+
+// FileStreamRequest is a line of the filestream recording.
+type FileStreamRequest struct {
+	Time time.Time       `json:"time"`
+	Path string          `json:"path"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Upload is a line of the uploads manifest.
+type Upload struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	URL    string    `json:"url"`
+	Bytes  int64     `json:"bytes"`
+}
+
+// appendMu serializes appends to the recordings, which are shared by the
+// transports of all of a run's clients.
+var appendMu sync.Mutex
+
+// Transport returns a transport that records requests in the directory
+// instead of sending them.
+//
+// Requests to /graphql and to filestream endpoints are recorded as such,
+// and all others as uploads.
+func Transport(dir string) http.RoundTripper {
+	return &transport{dir: dir}
+}
+
+type transport struct {
+	dir string
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("dryrun: failed to read request body: %v", err)
+		}
+	}
+	now := time.Now()
+
+	switch {
+	case req.URL.Path == "/graphql":
+		var request struct {
+			OperationName string          `json:"operationName"`
+			Variables     json.RawMessage `json:"variables"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return nil, fmt.Errorf("dryrun: invalid GraphQL request: %v", err)
+		}
+		err := t.append(GraphQLFileName, GraphQLRequest{
+			Time:          now,
+			OperationName: request.OperationName,
+			Variables:     request.Variables,
+		})
+		if err != nil {
+			return nil, err
+		}
+		data := graphQLResponse(request.OperationName, request.Variables)
+		return respond(req, fmt.Sprintf(`{"data":%s}`, data)), nil
+
+	case strings.HasSuffix(req.URL.Path, "/file_stream"):
+		record := FileStreamRequest{Time: now, Path: req.URL.Path}
+		if json.Valid(body) {
+			record.Body = body
+		}
+		if err := t.append(FileStreamFileName, record); err != nil {
+			return nil, err
+		}
+		return respond(req, "{}"), nil
+
+	default:
+		err := t.append(UploadsFileName, Upload{
+			Time:   now,
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Bytes:  int64(len(body)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return respond(req, ""), nil
+	}
+}
+
+// append adds the value as a line of the named recording.
+func (t *transport) append(name string, value any) error {
+	line, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("dryrun: failed to encode request: %v", err)
+	}
+	line = append(line, '\n')
+
+	appendMu.Lock()
+	defer appendMu.Unlock()
+
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return fmt.Errorf("dryrun: failed to create directory: %v", err)
+	}
+	file, err := os.OpenFile(
+		filepath.Join(t.dir, name),
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0o644,
+	)
+	if err != nil {
+		return fmt.Errorf("dryrun: failed to open recording: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(line); err != nil {
+		return fmt.Errorf("dryrun: failed to write recording: %v", err)
+	}
+	return nil
+}
+
+// respond returns a 200 response to the request with the body.
+func respond(req *http.Request, body string) *http.Response {
+	header := http.Header{}
+	if body != "" {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// UploadURL returns the synthetic upload URL for the file.
+func UploadURL(name string) string {
+	return (&url.URL{
+		Scheme: "https",
+		Host:   UploadHost,
+		Path:   "/" + strings.TrimPrefix(name, "/"),
+	}).String()
+}
+
+// graphQLResponse returns the "data" of a successful response to the
+// operation.
+//
+// Operations whose results the core uses get plausible results built from
+// their variables; others get no data, which the core treats as nothing
+// found.
+func graphQLResponse(operationName string, variablesJSON json.RawMessage) string {
+	var variables struct {
+		Name          string   `json:"name"`
+		Project       string   `json:"project"`
+		Entity        string   `json:"entity"`
+		DisplayName   string   `json:"displayName"`
+		Files         []string `json:"files"`
+		ArtifactFiles []struct {
+			ArtifactID string `json:"artifactID"`
+			Name       string `json:"name"`
+		} `json:"artifactFiles"`
+	}
+	_ = json.Unmarshal(variablesJSON, &variables)
+
+	var response any
+	switch operationName {
+	case "UpsertBucket":
+		response = map[string]any{
+			"upsertBucket": map[string]any{
+				"bucket": map[string]any{
+					"id":          "dry-run",
+					"name":        variables.Name,
+					"displayName": orDefault(variables.DisplayName, variables.Name),
+					"project": map[string]any{
+						"id":   "dry-run",
+						"name": orDefault(variables.Project, "uncategorized"),
+						"entity": map[string]any{
+							"id":   "dry-run",
+							"name": orDefault(variables.Entity, "dry-run"),
+						},
+					},
+				},
+				"inserted": true,
+			},
+		}
+
+	case "CreateRunFiles":
+		files := make([]map[string]string, len(variables.Files))
+		for i, name := range variables.Files {
+			files[i] = map[string]string{"name": name, "uploadUrl": UploadURL(name)}
+		}
+		response = map[string]any{
+			"createRunFiles": map[string]any{
+				"runID":         "dry-run",
+				"uploadHeaders": []string{},
+				"files":         files,
+			},
+		}
+
+	case "CreateArtifact":
+		response = map[string]any{
+			"createArtifact": map[string]any{
+				"artifact": map[string]any{"id": "dry-run", "state": "PENDING"},
+			},
+		}
+
+	case "CreateArtifactManifest":
+		response = map[string]any{
+			"createArtifactManifest": map[string]any{
+				"artifactManifest": map[string]any{
+					"id": "dry-run",
+					"file": map[string]any{
+						"id":            "dry-run",
+						"uploadUrl":     UploadURL("wandb_manifest.json"),
+						"uploadHeaders": []string{},
+					},
+				},
+			},
+		}
+
+	case "CreateArtifactFiles":
+		edges := make([]map[string]any, len(variables.ArtifactFiles))
+		for i, file := range variables.ArtifactFiles {
+			edges[i] = map[string]any{"node": map[string]any{
+				"uploadUrl":     UploadURL(file.Name),
+				"uploadHeaders": []string{},
+				"artifact":      map[string]string{"id": file.ArtifactID},
+			}}
+		}
+		response = map[string]any{
+			"createArtifactFiles": map[string]any{
+				"files": map[string]any{"edges": edges},
+			},
+		}
+
+	case "CommitArtifact":
+		response = map[string]any{
+			"commitArtifact": map[string]any{
+				"artifact": map[string]any{"id": "dry-run"},
+			},
+		}
+
+	case "LinkArtifact":
+		response = map[string]any{
+			"linkArtifact": map[string]any{"versionIndex": 0},
+		}
+
+	default:
+		return "{}"
+	}
+
+	data, _ := json.Marshal(response)
+	return string(data)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// ReadGraphQL returns the GraphQL requests recorded in the directory.
+func ReadGraphQL(dir string) ([]GraphQLRequest, error) {
+	return readLines[GraphQLRequest](filepath.Join(dir, GraphQLFileName))
+}
+
+// ReadFileStream returns the filestream requests recorded in the
+// directory.
+func ReadFileStream(dir string) ([]FileStreamRequest, error) {
+	return readLines[FileStreamRequest](filepath.Join(dir, FileStreamFileName))
+}
+
+// ReadUploads returns the uploads recorded in the directory.
+func ReadUploads(dir string) ([]Upload, error) {
+	return readLines[Upload](filepath.Join(dir, UploadsFileName))
+}
+
+func readLines[T any](path string) ([]T, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []T
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	for decoder.More() {
+		var value T
+		if err := decoder.Decode(&value); err != nil {
+			return values, fmt.Errorf("dryrun: invalid recording %s: %v", path, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -0,0 +1,147 @@
+package dryrun_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/dryrun"
+)
+
+func roundTrip(
+	t *testing.T,
+	transport http.RoundTripper,
+	method, url, body string,
+) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(content)
+}
+
+func TestGraphQL_RecordedAndAnswered(t *testing.T) {
+	dir := t.TempDir()
+	transport := dryrun.Transport(dir)
+
+	status, body := roundTrip(t, transport, http.MethodPost,
+		"https://api.wandb.ai/graphql",
+		`{
+			"query": "mutation UpsertBucket { ... }",
+			"operationName": "UpsertBucket",
+			"variables": {"name": "run-id", "project": "proj"}
+		}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	var response struct {
+		Data struct {
+			UpsertBucket struct {
+				Bucket struct {
+					Name    string
+					Project struct {
+						Name   string
+						Entity struct{ Name string }
+					}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &response))
+	bucket := response.Data.UpsertBucket.Bucket
+	assert.Equal(t, "run-id", bucket.Name)
+	assert.Equal(t, "proj", bucket.Project.Name)
+	assert.Equal(t, "dry-run", bucket.Project.Entity.Name)
+
+	requests, err := dryrun.ReadGraphQL(dir)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "UpsertBucket", requests[0].OperationName)
+	assert.JSONEq(t,
+		`{"name": "run-id", "project": "proj"}`,
+		string(requests[0].Variables))
+}
+
+func TestGraphQL_CreateRunFilesGetsUploadURLs(t *testing.T) {
+	transport := dryrun.Transport(t.TempDir())
+
+	_, body := roundTrip(t, transport, http.MethodPost,
+		"https://api.wandb.ai/graphql",
+		`{
+			"operationName": "CreateRunFiles",
+			"variables": {"files": ["config.yaml", "media/a.png"]}
+		}`)
+
+	assert.JSONEq(t, `{"data": {"createRunFiles": {
+		"runID": "dry-run",
+		"uploadHeaders": [],
+		"files": [
+			{"name": "config.yaml", "uploadUrl": "https://dry-run.wandb.invalid/config.yaml"},
+			{"name": "media/a.png", "uploadUrl": "https://dry-run.wandb.invalid/media/a.png"}
+		]
+	}}}`, body)
+}
+
+func TestGraphQL_OtherOperationsGetNoData(t *testing.T) {
+	transport := dryrun.Transport(t.TempDir())
+
+	status, body := roundTrip(t, transport, http.MethodPost,
+		"https://api.wandb.ai/graphql",
+		`{"operationName": "RunResumeStatus", "variables": {}}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"data": {}}`, body)
+}
+
+func TestFileStream_Recorded(t *testing.T) {
+	dir := t.TempDir()
+	transport := dryrun.Transport(dir)
+
+	status, body := roundTrip(t, transport, http.MethodPost,
+		"https://api.wandb.ai/files/entity/project/run/file_stream",
+		`{"files": {}, "complete": true}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "{}", body)
+	requests, err := dryrun.ReadFileStream(dir)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/files/entity/project/run/file_stream", requests[0].Path)
+	assert.JSONEq(t, `{"files": {}, "complete": true}`, string(requests[0].Body))
+}
+
+func TestUploads_RecordedInOrder(t *testing.T) {
+	dir := t.TempDir()
+	transport := dryrun.Transport(dir)
+
+	roundTrip(t, transport, http.MethodPut, dryrun.UploadURL("a.txt"), "hello")
+	roundTrip(t, dryrun.Transport(dir), http.MethodPut, dryrun.UploadURL("b.txt"), "")
+
+	uploads, err := dryrun.ReadUploads(dir)
+	require.NoError(t, err)
+	require.Len(t, uploads, 2)
+	assert.Equal(t, http.MethodPut, uploads[0].Method)
+	assert.Equal(t, "https://dry-run.wandb.invalid/a.txt", uploads[0].URL)
+	assert.EqualValues(t, 5, uploads[0].Bytes)
+	assert.Equal(t, "https://dry-run.wandb.invalid/b.txt", uploads[1].URL)
+	assert.EqualValues(t, 0, uploads[1].Bytes)
+}
+
+func TestInvalidGraphQLRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost,
+		"https://api.wandb.ai/graphql", strings.NewReader("not json"))
+	require.NoError(t, err)
+
+	_, err = dryrun.Transport(t.TempDir()).RoundTrip(req)
+
+	assert.ErrorContains(t, err, "invalid GraphQL request")
+}
@@ -0,0 +1,292 @@
+// Package faults injects failures into the core, to test its retries and
+// shutdown.
+//
+// Faults are described by a spec, a comma-separated list of:
+//
+//	filestream-fail=N  the Nth filestream request fails with a 503
+//	upload-delay=D     every file upload stalls for the duration D
+//	artifact-delay=D   every artifact stalls for D before it's saved
+//	writer-delay=D     every write to the transaction log takes D longer
+//	sender-stall=D     the sender stalls for D on every record, as if wedged
+//	dispatcher-drop=N  the Nth response is dropped instead of delivered
+//	panic=COMPONENT    the handler, writer or sender panics once
+//	atomic-write-crash=STEP
+//	                   the next atomic file write stops before STEP, one
+//	                   of write, sync or rename, as if the process crashed
+//
+// Requests and responses are counted from 1, and filestream requests
+// include retries. Faults that take a count may be repeated.
+//
+// Injection is disabled unless there is a spec. A nil *Injector injects
+// no faults, so each hook is a nil check when disabled.
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+)
+
+// EnvVar is the environment variable with the fault spec, used if the
+// _fault_injection setting is empty.
+const EnvVar = "WANDB_CORE_FAULTS"
+
+// Components that can be made to panic.
+const (
+	Handler = "handler"
+	Writer  = "writer"
+	Sender  = "sender"
+)
+
+// Injector injects the faults of a spec.
+type Injector struct {
+	mu sync.Mutex
+
+	// failFileStreamRequests are the filestream requests to fail
+	failFileStreamRequests map[int]bool
+	fileStreamRequests     int
+
+	// uploadDelay is how long each file upload stalls
+	uploadDelay time.Duration
+
+	// artifactDelay is how long each artifact stalls before it's saved
+	artifactDelay time.Duration
+
+	// writerDelay is how long each write to the transaction log stalls
+	writerDelay time.Duration
+
+	// senderStall is how long the sender stalls on each record
+	senderStall time.Duration
+
+	// dropDeliveries are the responses to drop
+	dropDeliveries map[int]bool
+	deliveries     int
+
+	// panicComponent is the component to panic, until it does
+	panicComponent string
+
+	// atomicWriteCrash is the step before which to stop an atomic write,
+	// until one is stopped
+	atomicWriteCrash atomicfile.Step
+}
+
+// New returns an injector for the spec, or for the spec in EnvVar if it
+// is empty.
+//
+// It returns nil if both are empty.
+func New(spec string) (*Injector, error) {
+	if spec == "" {
+		spec = os.Getenv(EnvVar)
+	}
+	if spec == "" {
+		return nil, nil
+	}
+	return Parse(spec)
+}
+
+// Parse returns an injector for the spec.
+func Parse(spec string) (*Injector, error) {
+	i := &Injector{
+		failFileStreamRequests: make(map[int]bool),
+		dropDeliveries:         make(map[int]bool),
+	}
+
+	for _, fault := range strings.Split(spec, ",") {
+		fault = strings.TrimSpace(fault)
+		if fault == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(fault, "=")
+		if !ok {
+			return nil, fmt.Errorf("faults: %q has no value", fault)
+		}
+
+		var err error
+		switch name {
+		case "filestream-fail":
+			err = parseCount(value, i.failFileStreamRequests)
+		case "upload-delay":
+			i.uploadDelay, err = time.ParseDuration(value)
+		case "artifact-delay":
+			i.artifactDelay, err = time.ParseDuration(value)
+		case "writer-delay":
+			i.writerDelay, err = time.ParseDuration(value)
+		case "sender-stall":
+			i.senderStall, err = time.ParseDuration(value)
+		case "dispatcher-drop":
+			err = parseCount(value, i.dropDeliveries)
+		case "panic":
+			if value != Handler && value != Writer && value != Sender {
+				err = fmt.Errorf("unknown component %q", value)
+			}
+			i.panicComponent = value
+		case "atomic-write-crash":
+			step := atomicfile.Step(value)
+			if step != atomicfile.StepWrite &&
+				step != atomicfile.StepSync &&
+				step != atomicfile.StepRename {
+				err = fmt.Errorf("unknown step %q", value)
+			}
+			i.atomicWriteCrash = step
+		default:
+			err = fmt.Errorf("unknown fault")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("faults: %q: %v", fault, err)
+		}
+	}
+
+	return i, nil
+}
+
+func parseCount(value string, counts map[int]bool) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	if n < 1 {
+		return fmt.Errorf("count must be positive")
+	}
+	counts[n] = true
+	return nil
+}
+
+// FileStreamTransport wraps the transport of filestream requests.
+func (i *Injector) FileStreamTransport(rt http.RoundTripper) http.RoundTripper {
+	if i == nil || len(i.failFileStreamRequests) == 0 {
+		return rt
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		i.mu.Lock()
+		i.fileStreamRequests++
+		fail := i.failFileStreamRequests[i.fileStreamRequests]
+		i.mu.Unlock()
+
+		if !fail {
+			return rt.RoundTrip(req)
+		}
+		return &http.Response{
+			Status:     "503 Service Unavailable (injected)",
+			StatusCode: http.StatusServiceUnavailable,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	})
+}
+
+// UploadTransport wraps the transport of file uploads.
+//
+// Stalled uploads end early if their request's context is cancelled.
+func (i *Injector) UploadTransport(rt http.RoundTripper) http.RoundTripper {
+	if i == nil || i.uploadDelay == 0 {
+		return rt
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-time.After(i.uploadDelay):
+			return rt.RoundTrip(req)
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	})
+}
+
+// DelayArtifact stalls before an artifact is saved, or until the context
+// is cancelled.
+func (i *Injector) DelayArtifact(ctx context.Context) {
+	if i == nil || i.artifactDelay == 0 {
+		return
+	}
+	select {
+	case <-time.After(i.artifactDelay):
+	case <-ctx.Done():
+	}
+}
+
+// DelayWrite stalls a write to the transaction log.
+func (i *Injector) DelayWrite() {
+	if i == nil || i.writerDelay == 0 {
+		return
+	}
+	time.Sleep(i.writerDelay)
+}
+
+// StallSender stalls the sender on a record, or until the context is
+// cancelled.
+func (i *Injector) StallSender(ctx context.Context) {
+	if i == nil || i.senderStall == 0 {
+		return
+	}
+	select {
+	case <-time.After(i.senderStall):
+	case <-ctx.Done():
+	}
+}
+
+// DropDelivery returns whether to drop the response being delivered.
+func (i *Injector) DropDelivery() bool {
+	if i == nil || len(i.dropDeliveries) == 0 {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.deliveries++
+	return i.dropDeliveries[i.deliveries]
+}
+
+// MaybePanic panics if the component is to panic and hasn't yet.
+func (i *Injector) MaybePanic(component string) {
+	if i == nil {
+		return
+	}
+
+	i.mu.Lock()
+	shouldPanic := i.panicComponent == component
+	if shouldPanic {
+		i.panicComponent = ""
+	}
+	i.mu.Unlock()
+
+	if shouldPanic {
+		panic(fmt.Sprintf("faults: injected panic in %s", component))
+	}
+}
+
+// AtomicWriteHook returns the hook for atomic file writes, or nil if they
+// aren't to crash.
+func (i *Injector) AtomicWriteHook() atomicfile.Hook {
+	if i == nil || i.atomicWriteCrash == "" {
+		return nil
+	}
+	return func(step atomicfile.Step) error {
+		i.mu.Lock()
+		crash := i.atomicWriteCrash == step
+		if crash {
+			i.atomicWriteCrash = ""
+		}
+		i.mu.Unlock()
+
+		if crash {
+			return errors.New("faults: injected crash")
+		}
+		return nil
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
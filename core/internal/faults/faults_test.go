@@ -0,0 +1,207 @@
+package faults_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/internal/faults"
+)
+
+func TestParse_Errors(t *testing.T) {
+	for _, spec := range []string{
+		"filestream-fail",
+		"filestream-fail=0",
+		"filestream-fail=x",
+		"upload-delay=soon",
+		"artifact-delay=soon",
+		"sender-stall=soon",
+		"panic=dispatcher",
+		"atomic-write-crash=flush",
+		"unplug=1",
+	} {
+		_, err := faults.Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNew_EmptyIsNil(t *testing.T) {
+	t.Setenv(faults.EnvVar, "")
+
+	injector, err := faults.New("")
+
+	assert.NoError(t, err)
+	assert.Nil(t, injector)
+}
+
+func TestNew_UsesEnvVar(t *testing.T) {
+	t.Setenv(faults.EnvVar, "dispatcher-drop=1")
+
+	injector, err := faults.New("")
+
+	require.NoError(t, err)
+	assert.True(t, injector.DropDelivery())
+	assert.False(t, injector.DropDelivery())
+}
+
+func TestNil_InjectsNothing(t *testing.T) {
+	var injector *faults.Injector
+	transport := http.DefaultTransport
+
+	assert.Equal(t, transport, injector.FileStreamTransport(transport))
+	assert.Equal(t, transport, injector.UploadTransport(transport))
+	assert.False(t, injector.DropDelivery())
+	assert.NotPanics(t, func() {
+		injector.DelayWrite()
+		injector.DelayArtifact(context.Background())
+		injector.StallSender(context.Background())
+		injector.MaybePanic(faults.Handler)
+	})
+}
+
+func TestDropDelivery_NthResponses(t *testing.T) {
+	injector, err := faults.Parse("dispatcher-drop=2,dispatcher-drop=3")
+	require.NoError(t, err)
+
+	var dropped []bool
+	for i := 0; i < 4; i++ {
+		dropped = append(dropped, injector.DropDelivery())
+	}
+
+	assert.Equal(t, []bool{false, true, true, false}, dropped)
+}
+
+func TestMaybePanic_Once(t *testing.T) {
+	injector, err := faults.Parse("panic=writer")
+	require.NoError(t, err)
+
+	assert.NotPanics(t, func() { injector.MaybePanic(faults.Handler) })
+	assert.Panics(t, func() { injector.MaybePanic(faults.Writer) })
+	assert.NotPanics(t, func() { injector.MaybePanic(faults.Writer) })
+}
+
+func TestUploadTransport_StallEndsWithContext(t *testing.T) {
+	injector, err := faults.Parse("upload-delay=1h")
+	require.NoError(t, err)
+	transport := injector.UploadTransport(http.DefaultTransport)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "http://unused", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDelayArtifact_StallEndsWithContext(t *testing.T) {
+	injector, err := faults.Parse("artifact-delay=1h")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	injector.DelayArtifact(ctx)
+
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestStallSender_StallEndsWithContext(t *testing.T) {
+	injector, err := faults.Parse("sender-stall=1h")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	injector.StallSender(ctx)
+
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+// attemptTimes records when each request is attempted.
+type attemptTimes struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (a *attemptTimes) wrap(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		a.mu.Lock()
+		a.times = append(a.times, time.Now())
+		a.mu.Unlock()
+		return rt.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestFileStreamTransport_RetriesBackOff(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) { received++ }))
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	injector, err := faults.Parse(
+		"filestream-fail=1,filestream-fail=2,filestream-fail=3")
+	require.NoError(t, err)
+	attempts := &attemptTimes{}
+	client := api.New(api.BackendOptions{BaseURL: baseURL}).
+		NewClient(api.ClientOptions{
+			RetryMax:     5,
+			RetryWaitMin: 20 * time.Millisecond,
+			RetryWaitMax: time.Second,
+			WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+				return attempts.wrap(injector.FileStreamTransport(rt))
+			},
+		})
+
+	resp, err := client.Send(&api.Request{
+		Method: http.MethodPost,
+		Path:   "files/entity/project/run/file_stream",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, received)
+	require.Len(t, attempts.times, 4)
+	var gaps []time.Duration
+	for i := 1; i < len(attempts.times); i++ {
+		gaps = append(gaps, attempts.times[i].Sub(attempts.times[i-1]))
+	}
+	assert.Greater(t, gaps[1], gaps[0])
+	assert.Greater(t, gaps[2], gaps[1])
+}
+
+func TestAtomicWriteHook_CrashesOnceBeforeStep(t *testing.T) {
+	injector, err := faults.Parse("atomic-write-crash=sync")
+	require.NoError(t, err)
+	hook := injector.AtomicWriteHook()
+
+	assert.NoError(t, hook(atomicfile.StepWrite))
+	assert.Error(t, hook(atomicfile.StepSync))
+	assert.NoError(t, hook(atomicfile.StepSync))
+}
+
+func TestAtomicWriteHook_NilWithoutFault(t *testing.T) {
+	injector, err := faults.Parse("panic=writer")
+	require.NoError(t, err)
+	var nilInjector *faults.Injector
+
+	assert.Nil(t, injector.AtomicWriteHook())
+	assert.Nil(t, nilInjector.AtomicWriteHook())
+}
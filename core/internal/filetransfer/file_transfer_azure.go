@@ -0,0 +1,276 @@
+package filetransfer
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+const (
+	// azureAPIVersion is the version of the Azure blob storage API used.
+	azureAPIVersion = "2021-08-06"
+
+	defaultAzureBlockSize        = 8 << 20
+	defaultAzureMaxSinglePutSize = 64 << 20
+	defaultAzureConcurrency      = 4
+)
+
+// AzureBlobUploaderParams configures uploads to Azure blob storage.
+type AzureBlobUploaderParams struct {
+	Client *retryablehttp.Client
+	Logger *observability.CoreLogger
+
+	// BlockSize is the size of the blocks a large file is uploaded in, or
+	// 0 for 8 MiB.
+	BlockSize int64
+
+	// MaxSinglePutSize is the size of the largest file to upload in a
+	// single request, or 0 for 64 MiB.
+	MaxSinglePutSize int64
+
+	// Concurrency is how many blocks of a file to upload at once, or 0
+	// for 4.
+	Concurrency int
+}
+
+// azureBlobUploader uploads files to Azure blob storage through its own
+// API, given a URL with a shared access signature.
+//
+// Small files are uploaded in a single request. Larger ones are uploaded
+// as blocks, each retried on its own and several at once, which are then
+// committed as the blob. A single request can't upload blobs as large,
+// and must start over if it fails.
+type azureBlobUploader struct {
+	client           *retryablehttp.Client
+	logger           *observability.CoreLogger
+	blockSize        int64
+	maxSinglePutSize int64
+	concurrency      int
+}
+
+func NewAzureBlobUploader(params AzureBlobUploaderParams) Uploader {
+	u := &azureBlobUploader{
+		client:           params.Client,
+		logger:           params.Logger,
+		blockSize:        params.BlockSize,
+		maxSinglePutSize: params.MaxSinglePutSize,
+		concurrency:      params.Concurrency,
+	}
+	if u.blockSize <= 0 {
+		u.blockSize = defaultAzureBlockSize
+	}
+	if u.maxSinglePutSize <= 0 {
+		u.maxSinglePutSize = defaultAzureMaxSinglePutSize
+	}
+	if u.concurrency <= 0 {
+		u.concurrency = defaultAzureConcurrency
+	}
+	return u
+}
+
+func (u *azureBlobUploader) Upload(
+	ctx context.Context,
+	file UploadFile,
+	destination UploadDestination,
+) error {
+	if file.Size <= u.maxSinglePutSize {
+		return u.putBlob(ctx, file, destination)
+	}
+	return u.putBlocks(ctx, file, destination)
+}
+
+// putBlob uploads the file in a single request.
+func (u *azureBlobUploader) putBlob(
+	ctx context.Context,
+	file UploadFile,
+	destination UploadDestination,
+) error {
+	body := newHookedReader(file)
+	req, err := retryablehttp.NewRequestWithContext(
+		ctx, http.MethodPut, destination.URL, body)
+	if err != nil {
+		return err
+	}
+	destination.setHeaders(req.Request, u.logger)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	setAzureVersion(req, destination)
+
+	if err := u.do(req); err != nil {
+		return fmt.Errorf("file transfer: upload: failed to upload: %w", err)
+	}
+	return file.Hooks.checksum(body.sum())
+}
+
+// putBlocks uploads the file as blocks, and commits them as the blob once
+// they're all uploaded and the checksum hook accepts the contents.
+func (u *azureBlobUploader) putBlocks(
+	ctx context.Context,
+	file UploadFile,
+	destination UploadDestination,
+) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(u.concurrency)
+
+	// blocks are read in order to hash the file as a whole, and uploaded
+	// in any order
+	hash := md5.New()
+	var blockIDs []string
+	var progressMu sync.Mutex
+	var sent int64
+	for offset := int64(0); offset < file.Size && groupCtx.Err() == nil; offset += u.blockSize {
+		block := make([]byte, min(u.blockSize, file.Size-offset))
+		if n, err := file.Contents.ReadAt(block, offset); n < len(block) {
+			_ = group.Wait()
+			return fmt.Errorf("file transfer: upload: failed to read %s: %w", file.Path, err)
+		}
+		hash.Write(block)
+
+		index := len(blockIDs)
+		id := azureBlockID(index)
+		blockIDs = append(blockIDs, id)
+		group.Go(func() error {
+			if err := u.putBlock(groupCtx, destination, id, block); err != nil {
+				return fmt.Errorf(
+					"file transfer: upload: failed to upload block %d: %w",
+					index, err)
+			}
+
+			progressMu.Lock()
+			defer progressMu.Unlock()
+			sent += int64(len(block))
+			file.Hooks.progress(sent, file.Size)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sum := hash.Sum(nil)
+	if err := file.Hooks.checksum(sum); err != nil {
+		return err
+	}
+	if err := u.putBlockList(ctx, destination, blockIDs, sum); err != nil {
+		return fmt.Errorf("file transfer: upload: failed to commit blocks: %w", err)
+	}
+	return nil
+}
+
+// putBlock uploads one block of the blob.
+func (u *azureBlobUploader) putBlock(
+	ctx context.Context,
+	destination UploadDestination,
+	id string,
+	block []byte,
+) error {
+	req, err := retryablehttp.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		withQuery(destination.URL, "comp=block&blockid="+url.QueryEscape(id)),
+		bytes.NewReader(block),
+	)
+	if err != nil {
+		return err
+	}
+	setAzureVersion(req, destination)
+	return u.do(req)
+}
+
+// putBlockList commits the blocks as the blob, in order.
+//
+// The blob's content type and MD5 are set here, as the blocks have none.
+func (u *azureBlobUploader) putBlockList(
+	ctx context.Context,
+	destination UploadDestination,
+	blockIDs []string,
+	sum []byte,
+) error {
+	var body strings.Builder
+	body.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for _, id := range blockIDs {
+		body.WriteString("<Latest>" + id + "</Latest>")
+	}
+	body.WriteString("</BlockList>")
+
+	req, err := retryablehttp.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		withQuery(destination.URL, "comp=blocklist"),
+		strings.NewReader(body.String()),
+	)
+	if err != nil {
+		return err
+	}
+	setAzureVersion(req, destination)
+	req.Header.Set("x-ms-blob-content-md5", base64.StdEncoding.EncodeToString(sum))
+	if contentType := destination.header("Content-Type"); contentType != "" {
+		req.Header.Set("x-ms-blob-content-type", contentType)
+	}
+	for _, header := range destination.Headers {
+		key, value, ok := strings.Cut(header, ":")
+		if ok && strings.HasPrefix(strings.ToLower(key), "x-ms-meta-") {
+			req.Header.Set(key, value)
+		}
+	}
+	return u.do(req)
+}
+
+func (u *azureBlobUploader) do(req *retryablehttp.Request) error {
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	return api.ResponseError(resp)
+}
+
+// setAzureVersion sets the API version of the request, unless the backend
+// chose one.
+func setAzureVersion(req *retryablehttp.Request, destination UploadDestination) {
+	version := destination.header("x-ms-version")
+	if version == "" {
+		version = azureAPIVersion
+	}
+	req.Header.Set("x-ms-version", version)
+}
+
+// azureBlockID is the ID of the block at the index.
+//
+// The IDs of a blob's blocks must all have the same length.
+func azureBlockID(index int) string {
+	return base64.StdEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("wandb-block-%08d", index)))
+}
+
+// withQuery adds the query parameters to the URL, keeping its own, such
+// as its signature, as they are.
+func withQuery(rawURL string, query string) string {
+	base, fragment, _ := strings.Cut(rawURL, "#")
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	if fragment != "" {
+		return base + separator + query + "#" + fragment
+	}
+	return base + separator + query
+}
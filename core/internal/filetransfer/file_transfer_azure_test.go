@@ -0,0 +1,220 @@
+package filetransfer_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// fakeAzureBlob is a blob in a fake Azure blob storage server.
+type fakeAzureBlob struct {
+	sync.Mutex
+
+	// blocks are the uploaded blocks that aren't committed yet, by ID
+	blocks map[string][]byte
+
+	// failBlocks is how many more block uploads fail
+	failBlocks int
+
+	// blob is the committed blob, and headers the headers it was
+	// committed with
+	blob    []byte
+	headers http.Header
+
+	// requests are the values of each request's "comp" query parameter
+	requests []string
+}
+
+func newFakeAzureBlob(t *testing.T) (*fakeAzureBlob, *httptest.Server) {
+	blob := &fakeAzureBlob{blocks: make(map[string][]byte)}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.NotEmpty(t, r.Header.Get("x-ms-version"))
+			assert.Equal(t, "sas", r.URL.Query().Get("sig"))
+
+			blob.Lock()
+			defer blob.Unlock()
+			comp := r.URL.Query().Get("comp")
+			blob.requests = append(blob.requests, comp)
+
+			switch comp {
+			case "block":
+				if blob.failBlocks > 0 {
+					blob.failBlocks--
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				blob.blocks[r.URL.Query().Get("blockid")] = body
+			case "blocklist":
+				var blockList struct {
+					Latest []string
+				}
+				require.NoError(t, xml.Unmarshal(body, &blockList))
+				blob.blob = nil
+				for _, id := range blockList.Latest {
+					block, ok := blob.blocks[id]
+					require.True(t, ok, "block %s wasn't uploaded", id)
+					blob.blob = append(blob.blob, block...)
+				}
+				blob.headers = r.Header.Clone()
+			default:
+				assert.Equal(t, "BlockBlob", r.Header.Get("x-ms-blob-type"))
+				blob.blob = body
+				blob.headers = r.Header.Clone()
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+	t.Cleanup(server.Close)
+	return blob, server
+}
+
+func newTestAzureUploader() filetransfer.Uploader {
+	return filetransfer.NewAzureBlobUploader(filetransfer.AzureBlobUploaderParams{
+		Client:           impatientClient(),
+		Logger:           observability.NewNoOpLogger(),
+		BlockSize:        4,
+		MaxSinglePutSize: 8,
+		Concurrency:      3,
+	})
+}
+
+func uploadFile(contents []byte, hooks filetransfer.UploadHooks) filetransfer.UploadFile {
+	return filetransfer.UploadFile{
+		Path:     "file.txt",
+		Contents: bytes.NewReader(contents),
+		Size:     int64(len(contents)),
+		Hooks:    hooks,
+	}
+}
+
+func TestAzureBlobUploader_SmallFileInOneRequest(t *testing.T) {
+	blob, server := newFakeAzureBlob(t)
+	contents := []byte("12345678")
+
+	err := newTestAzureUploader().Upload(
+		context.Background(),
+		uploadFile(contents, filetransfer.UploadHooks{}),
+		filetransfer.UploadDestination{
+			URL:     server.URL + "/container/file.txt?sig=sas",
+			Headers: []string{"x-ms-version:2020-04-08"},
+		},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, contents, blob.blob)
+	assert.Equal(t, []string{""}, blob.requests)
+	assert.Equal(t, "2020-04-08", blob.headers.Get("x-ms-version"))
+}
+
+func TestAzureBlobUploader_LargeFileInBlocks(t *testing.T) {
+	blob, server := newFakeAzureBlob(t)
+	contents := []byte("the quick brown fox jumps")
+	var progressMu sync.Mutex
+	var progress []int64
+	var checksum []byte
+
+	err := newTestAzureUploader().Upload(
+		context.Background(),
+		uploadFile(contents, filetransfer.UploadHooks{
+			Progress: func(sent, total int64) {
+				progressMu.Lock()
+				defer progressMu.Unlock()
+				assert.EqualValues(t, len(contents), total)
+				progress = append(progress, sent)
+			},
+			Checksum: func(sum []byte) error {
+				checksum = sum
+				return nil
+			},
+		}),
+		filetransfer.UploadDestination{
+			URL: server.URL + "/container/file.txt?sig=sas",
+			Headers: []string{
+				"Content-Type:text/plain",
+				"x-ms-meta-run:abc",
+			},
+		},
+	)
+
+	require.NoError(t, err)
+	expected := md5.Sum(contents)
+	assert.Equal(t, contents, blob.blob)
+	assert.Len(t, blob.blocks, 7)
+	assert.Equal(t, expected[:], checksum)
+	assert.Equal(t,
+		base64.StdEncoding.EncodeToString(expected[:]),
+		blob.headers.Get("x-ms-blob-content-md5"))
+	assert.Equal(t, "text/plain", blob.headers.Get("x-ms-blob-content-type"))
+	assert.Equal(t, "abc", blob.headers.Get("x-ms-meta-run"))
+	assert.Len(t, progress, 7)
+	assert.IsIncreasing(t, progress)
+	assert.EqualValues(t, len(contents), progress[len(progress)-1])
+}
+
+func TestAzureBlobUploader_RetriesFailedBlock(t *testing.T) {
+	blob, server := newFakeAzureBlob(t)
+	blob.failBlocks = 1
+	contents := []byte("the quick brown fox jumps")
+
+	err := newTestAzureUploader().Upload(
+		context.Background(),
+		uploadFile(contents, filetransfer.UploadHooks{}),
+		filetransfer.UploadDestination{URL: server.URL + "/file.txt?sig=sas"},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, contents, blob.blob)
+	// 7 blocks, one of them twice, and the commit
+	assert.Len(t, blob.requests, 9)
+}
+
+func TestAzureBlobUploader_ChecksumErrorPreventsCommit(t *testing.T) {
+	blob, server := newFakeAzureBlob(t)
+
+	err := newTestAzureUploader().Upload(
+		context.Background(),
+		uploadFile([]byte("the quick brown fox jumps"), filetransfer.UploadHooks{
+			Checksum: func(sum []byte) error { return errors.New("file changed") },
+		}),
+		filetransfer.UploadDestination{URL: server.URL + "/file.txt?sig=sas"},
+	)
+
+	assert.ErrorContains(t, err, "file changed")
+	assert.Nil(t, blob.blob)
+	assert.NotContains(t, blob.requests, "blocklist")
+}
+
+func TestAzureBlobUploader_FailedBlockFailsUpload(t *testing.T) {
+	blob, server := newFakeAzureBlob(t)
+	blob.failBlocks = 100
+
+	err := newTestAzureUploader().Upload(
+		context.Background(),
+		uploadFile([]byte("the quick brown fox jumps"), filetransfer.UploadHooks{}),
+		filetransfer.UploadDestination{URL: server.URL + "/file.txt?sig=sas"},
+	)
+
+	assert.ErrorContains(t, err, "failed to upload block")
+	// the other blocks' requests may still be handled
+	blob.Lock()
+	defer blob.Unlock()
+	assert.Nil(t, blob.blob)
+	assert.NotContains(t, blob.requests, "blocklist")
+}
@@ -1,6 +1,8 @@
 package filetransfer
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"math"
@@ -8,6 +10,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/wandb/wandb/core/pkg/observability"
@@ -23,6 +26,9 @@ type DefaultFileTransfer struct {
 
 	// fileTransferStats is used to track upload/download progress
 	fileTransferStats FileTransferStats
+
+	// uploaders upload files to each kind of storage backend
+	uploaders Uploaders
 }
 
 // NewDefaultFileTransfer creates a new fileTransfer
@@ -35,10 +41,41 @@ func NewDefaultFileTransfer(
 		logger:            logger,
 		client:            client,
 		fileTransferStats: fileTransferStats,
+		uploaders: Uploaders{
+			Presigned: NewPresignedUploader(client, logger),
+			AzureBlob: NewAzureBlobUploader(AzureBlobUploaderParams{
+				Client: client,
+				Logger: logger,
+			}),
+		},
 	}
+	countRetries(client)
 	return fileTransfer
 }
 
+// countRetries makes the client count the retries of each upload's
+// requests in the counter in their context.
+//
+// The client calls its request hook before every attempt. An upload's
+// requests may run at once, such as the blocks of a file uploaded to
+// Azure.
+func countRetries(client *retryablehttp.Client) {
+	previous := client.RequestLogHook
+	client.RequestLogHook = func(
+		logger retryablehttp.Logger,
+		req *http.Request,
+		attempt int,
+	) {
+		retries, ok := req.Context().Value(uploadRetriesKey{}).(*atomic.Int32)
+		if ok && attempt > 0 {
+			retries.Add(1)
+		}
+		if previous != nil {
+			previous(logger, req, attempt)
+		}
+	}
+}
+
 // Upload uploads a file to the server
 func (ft *DefaultFileTransfer) Upload(task *Task) error {
 	ft.logger.Debug("default file transfer: uploading file", "path", task.Path, "url", task.Url)
@@ -70,47 +107,66 @@ func (ft *DefaultFileTransfer) Upload(task *Task) error {
 	}
 
 	task.Size = stat.Size()
+	if task.Size > math.MaxInt {
+		return fmt.Errorf("file transfer: upload: file larger than %v", math.MaxInt)
+	}
 
-	progressReader, err := NewProgressReader(
-		file,
-		task.Size,
-		func(processed int, total int) {
+	destination := UploadDestination{URL: task.Url, Headers: task.Headers}
+	hooks := UploadHooks{
+		Progress: func(sent, total int64) {
 			if task.ProgressCallback != nil {
-				task.ProgressCallback(processed, total)
+				task.ProgressCallback(int(sent), int(total))
 			}
 
 			ft.fileTransferStats.UpdateUploadStats(FileUploadInfo{
 				FileKind:      task.FileKind,
 				Path:          task.Path,
-				UploadedBytes: int64(processed),
-				TotalBytes:    int64(total),
+				Digest:        task.Digest,
+				UploadedBytes: sent,
+				TotalBytes:    total,
 			})
 		},
-	)
-	if err != nil {
-		return err
-	}
-	req, err := retryablehttp.NewRequest(http.MethodPut, task.Url, progressReader)
-	if err != nil {
-		return err
-	}
-	for _, header := range task.Headers {
-		parts := strings.SplitN(header, ":", 2)
-		if len(parts) != 2 {
-			ft.logger.Error("file transfer: upload: invalid header", "header", header)
-			continue
-		}
-		req.Header.Set(parts[0], parts[1])
+		Checksum: func(sum []byte) error {
+			return checkContentMD5(task.Path, destination, sum)
+		},
 	}
-	if task.Context != nil {
-		req = req.WithContext(task.Context)
+
+	ctx := task.Context
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	resp, err := ft.client.Do(req)
-	if err != nil {
-		return err
+	var retries atomic.Int32
+	ctx = withRetryCounter(ctx, &retries)
+	defer func() { task.Retries = int(retries.Load()) }()
+
+	return ft.uploaders.For(destination).Upload(
+		ctx,
+		UploadFile{
+			Path:     task.Path,
+			Contents: file,
+			Size:     task.Size,
+			Hooks:    hooks,
+		},
+		destination,
+	)
+}
+
+// checkContentMD5 returns an error if the backend expects the file to have
+// a different MD5 than it was uploaded with, which happens if the file
+// changed after it was scheduled for upload.
+func checkContentMD5(path string, destination UploadDestination, sum []byte) error {
+	expected := strings.TrimSpace(destination.header("Content-MD5"))
+	if expected == "" {
+		return nil
 	}
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return fmt.Errorf("file transfer: upload: failed to upload: %s", resp.Status)
+
+	actual := base64.StdEncoding.EncodeToString(sum)
+	if actual != expected {
+		return fmt.Errorf(
+			"file transfer: upload: %s changed while it was uploaded:"+
+				" its MD5 is %s, but %s was expected",
+			path, actual, expected,
+		)
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package filetransfer
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/wandb/wandb/core/pkg/service"
 
@@ -31,6 +32,9 @@ type FileTransferManager interface {
 
 	// Schedules a file upload operation.
 	AddTask(task *Task)
+
+	// Returns the number of added tasks that haven't finished.
+	PendingTasks() int
 }
 
 // FileTransferManager handles the upload/download of files
@@ -45,6 +49,10 @@ type fileTransferManager struct {
 	// fileTransferStats keeps track of upload/download statistics
 	fileTransferStats FileTransferStats
 
+	// shared shares the uploads of the same content to the same
+	// destination, such as by run files and artifacts
+	shared *sharedUploads
+
 	// semaphore is the semaphore for limiting concurrency
 	semaphore chan struct{}
 
@@ -59,6 +67,9 @@ type fileTransferManager struct {
 
 	// active is true if the file transfer is active
 	active bool
+
+	// pending is the number of added tasks that haven't finished
+	pending *atomic.Int32
 }
 
 type FileTransferManagerOption func(fm *fileTransferManager)
@@ -93,6 +104,8 @@ func NewFileTransferManager(opts ...FileTransferManagerOption) FileTransferManag
 		inChan:    make(chan *Task, bufferSize),
 		wg:        &sync.WaitGroup{},
 		semaphore: make(chan struct{}, defaultConcurrencyLimit),
+		pending:   &atomic.Int32{},
+		shared:    newSharedUploads(),
 	}
 
 	for _, opt := range opts {
@@ -106,40 +119,82 @@ func (fm *fileTransferManager) Start() {
 	if fm.active {
 		return
 	}
+	fm.active = true
 	fm.wg.Add(1)
 	go func() {
-		fm.active = true
 		for task := range fm.inChan {
 			// add a task to the wait group
 			fm.wg.Add(1)
 			fm.logger.Debug("fileTransfer: got task", "task", task)
 			// spin up a goroutine per task
-			go func(task *Task) {
-				// Acquire the semaphore
-				fm.semaphore <- struct{}{}
-				task.Err = fm.transfer(task)
-				// Release the semaphore
-				<-fm.semaphore
-
-				if task.Err != nil {
-					fm.logger.CaptureError(
-						"filetransfer: uploader: error uploading",
-						task.Err,
-						"path", task.Path, "url", task.Url,
-					)
-				}
-
-				// Execute the callback.
-				fm.completeTask(task)
-
-				// mark the task as done
-				fm.wg.Done()
-			}(task)
+			go fm.run(task)
 		}
 		fm.wg.Done()
 	}()
 }
 
+// run makes the task's transfer and finishes the task.
+func (fm *fileTransferManager) run(task *Task) {
+	// a task sharing another's upload finishes with it
+	if fm.shared.share(task, fm.finishTask) {
+		return
+	}
+
+	if err := fm.acquireWorker(task); err != nil {
+		task.Err = err
+	} else {
+		task.Err = fm.transfer(task)
+		<-fm.semaphore
+	}
+
+	if task.Err != nil && !task.Cancelled() {
+		fm.logger.CaptureError(
+			"filetransfer: uploader: error uploading",
+			task.Err,
+			"path", task.Path, "url", task.Url,
+		)
+	}
+
+	fm.finishTask(task)
+	fm.shared.finish(task, fm.finishTask, func(sharer *Task) {
+		go fm.run(sharer)
+	})
+}
+
+// acquireWorker waits until the task may start its transfer, without
+// exceeding the concurrency limit.
+//
+// A task cancelled while it waits stops waiting, and one cancelled before
+// it starts doesn't take up a worker. The caller must release the worker
+// if no error is returned.
+func (fm *fileTransferManager) acquireWorker(task *Task) error {
+	if task.Context == nil {
+		fm.semaphore <- struct{}{}
+		return nil
+	}
+
+	select {
+	case fm.semaphore <- struct{}{}:
+	case <-task.Context.Done():
+		return task.Context.Err()
+	}
+	if err := task.Context.Err(); err != nil {
+		<-fm.semaphore
+		return err
+	}
+	return nil
+}
+
+// finishTask completes a task that was added.
+func (fm *fileTransferManager) finishTask(task *Task) {
+	// Execute the callback.
+	fm.completeTask(task)
+	fm.pending.Add(-1)
+
+	// mark the task as done
+	fm.wg.Done()
+}
+
 // completeTask runs the completion callback and updates statistics.
 func (fm *fileTransferManager) completeTask(task *Task) {
 	task.CompletionCallback(task)
@@ -148,6 +203,7 @@ func (fm *fileTransferManager) completeTask(task *Task) {
 		fm.fileTransferStats.UpdateUploadStats(FileUploadInfo{
 			FileKind:      task.FileKind,
 			Path:          task.Path,
+			Digest:        task.Digest,
 			UploadedBytes: task.Size,
 			TotalBytes:    task.Size,
 		})
@@ -156,9 +212,14 @@ func (fm *fileTransferManager) completeTask(task *Task) {
 
 func (fm *fileTransferManager) AddTask(task *Task) {
 	fm.logger.Debug("fileTransfer: adding upload task", "path", task.Path, "url", task.Url)
+	fm.pending.Add(1)
 	fm.inChan <- task
 }
 
+func (fm *fileTransferManager) PendingTasks() int {
+	return int(fm.pending.Load())
+}
+
 func (fm *fileTransferManager) Close() {
 	if !fm.active {
 		return
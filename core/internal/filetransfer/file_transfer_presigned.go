@@ -0,0 +1,54 @@
+package filetransfer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// presignedUploader uploads files with a single PUT to a presigned URL.
+//
+// This works with any object store the backend presigns URLs for, such
+// as S3-compatible storage and GCS.
+type presignedUploader struct {
+	client *retryablehttp.Client
+	logger *observability.CoreLogger
+}
+
+func NewPresignedUploader(
+	client *retryablehttp.Client,
+	logger *observability.CoreLogger,
+) Uploader {
+	return &presignedUploader{client: client, logger: logger}
+}
+
+func (u *presignedUploader) Upload(
+	ctx context.Context,
+	file UploadFile,
+	destination UploadDestination,
+) error {
+	body := newHookedReader(file)
+	req, err := retryablehttp.NewRequestWithContext(
+		ctx, http.MethodPut, destination.URL, body)
+	if err != nil {
+		return err
+	}
+	destination.setHeaders(req.Request, u.logger)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := api.ResponseError(resp); err != nil {
+		return fmt.Errorf("file transfer: upload: failed to upload: %w", err)
+	}
+
+	// the whole file was sent for the request to succeed
+	return file.Hooks.checksum(body.sum())
+}
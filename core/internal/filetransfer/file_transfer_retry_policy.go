@@ -4,7 +4,7 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/hashicorp/go-retryablehttp"
+	"github.com/wandb/wandb/core/internal/api"
 )
 
 // FileTransferRetryPolicy is the retry policy to be used for file operations.
@@ -22,5 +22,5 @@ func FileTransferRetryPolicy(
 		return false, err
 	}
 
-	return retryablehttp.ErrorPropagatedRetryPolicy(ctx, resp, err)
+	return api.RetryPolicy(nil)(ctx, resp, err)
 }
@@ -2,12 +2,16 @@ package filetransfer
 
 import (
 	"sync"
-	"sync/atomic"
 
 	"github.com/wandb/wandb/core/pkg/service"
 )
 
 // FileTransferStats reports file upload/download progress and totals.
+//
+// The byte counts never decrease, and the uploaded bytes reach the total
+// once every upload has finished. Files with the same content, like a
+// checkpoint both saved with the run and logged in an artifact, count
+// their bytes once.
 type FileTransferStats interface {
 	// GetFilesStats returns byte counts for uploads.
 	GetFilesStats() *service.FilePusherStats
@@ -23,67 +27,79 @@ type FileTransferStats interface {
 
 	// UpdateUploadStats updates the upload stats for a file.
 	UpdateUploadStats(newInfo FileUploadInfo)
+
+	// CountUnchanged records that a file of the given size wasn't uploaded
+	// again because it hadn't changed since its last upload.
+	//
+	// Its bytes count as uploaded and deduped.
+	CountUnchanged(bytes int64)
 }
 
 type fileTransferStats struct {
 	sync.Mutex
 
-	done *atomic.Bool
+	done bool
 
+	// statsByPath is the latest upload of each file.
 	statsByPath map[string]FileUploadInfo
 
-	uploadedBytes *atomic.Int64
-	totalBytes    *atomic.Int64
-	dedupedBytes  *atomic.Int64
+	// pathsByDigest is the file whose bytes count for each content.
+	pathsByDigest map[string]string
+
+	uploadedBytes int64
+	totalBytes    int64
+	dedupedBytes  int64
 
-	wandbCount    *atomic.Int32
-	mediaCount    *atomic.Int32
-	artifactCount *atomic.Int32
-	otherCount    *atomic.Int32
+	wandbCount    int32
+	mediaCount    int32
+	artifactCount int32
+	otherCount    int32
+
+	unchangedCount int32
 }
 
 func NewFileTransferStats() FileTransferStats {
 	return &fileTransferStats{
-		done: &atomic.Bool{},
-
-		statsByPath: make(map[string]FileUploadInfo),
-
-		uploadedBytes: &atomic.Int64{},
-		totalBytes:    &atomic.Int64{},
-		dedupedBytes:  &atomic.Int64{},
-
-		wandbCount:    &atomic.Int32{},
-		mediaCount:    &atomic.Int32{},
-		artifactCount: &atomic.Int32{},
-		otherCount:    &atomic.Int32{},
+		statsByPath:   make(map[string]FileUploadInfo),
+		pathsByDigest: make(map[string]string),
 	}
 }
 
 func (fts *fileTransferStats) GetFilesStats() *service.FilePusherStats {
-	// NOTE: We don't lock, so these could be out of sync. For instance,
-	// TotalBytes could be less than UploadedBytes!
+	fts.Lock()
+	defer fts.Unlock()
+
 	return &service.FilePusherStats{
-		UploadedBytes: fts.uploadedBytes.Load(),
-		TotalBytes:    fts.totalBytes.Load(),
-		DedupedBytes:  fts.dedupedBytes.Load(),
+		UploadedBytes: fts.uploadedBytes,
+		TotalBytes:    fts.totalBytes,
+		DedupedBytes:  fts.dedupedBytes,
 	}
 }
 
 func (fts *fileTransferStats) GetFileCounts() *service.FileCounts {
+	fts.Lock()
+	defer fts.Unlock()
+
 	return &service.FileCounts{
-		WandbCount:    fts.wandbCount.Load(),
-		MediaCount:    fts.mediaCount.Load(),
-		ArtifactCount: fts.artifactCount.Load(),
-		OtherCount:    fts.otherCount.Load(),
+		WandbCount:    fts.wandbCount,
+		MediaCount:    fts.mediaCount,
+		ArtifactCount: fts.artifactCount,
+		OtherCount:    fts.otherCount,
+
+		UnchangedCount: fts.unchangedCount,
 	}
 }
 
 func (fts *fileTransferStats) IsDone() bool {
-	return fts.done.Load()
+	fts.Lock()
+	defer fts.Unlock()
+	return fts.done
 }
 
 func (fts *fileTransferStats) SetDone() {
-	fts.done.Store(true)
+	fts.Lock()
+	defer fts.Unlock()
+	fts.done = true
 }
 
 // FileUploadInfo is information about an in-progress file upload.
@@ -94,6 +110,9 @@ type FileUploadInfo struct {
 	// The kind of file this is.
 	FileKind RunFileKind
 
+	// The base64-encoded MD5 of the file's content, if known.
+	Digest string
+
 	// The number of bytes uploaded so far.
 	UploadedBytes int64
 
@@ -101,30 +120,75 @@ type FileUploadInfo struct {
 	TotalBytes int64
 }
 
+// isFinished returns whether all of the upload's bytes were uploaded.
+func (info FileUploadInfo) isFinished() bool {
+	return info.UploadedBytes >= info.TotalBytes
+}
+
 func (fts *fileTransferStats) UpdateUploadStats(newInfo FileUploadInfo) {
 	fts.Lock()
 	defer fts.Unlock()
 
-	if oldInfo, ok := fts.statsByPath[newInfo.Path]; ok {
-		fts.addStats(oldInfo, -1)
+	// the bytes of content that another file has count only for it
+	if newInfo.Digest != "" {
+		path, counted := fts.pathsByDigest[newInfo.Digest]
+		if !counted {
+			fts.pathsByDigest[newInfo.Digest] = newInfo.Path
+		} else if path != newInfo.Path {
+			newInfo.UploadedBytes = 0
+			newInfo.TotalBytes = 0
+		}
+	}
+
+	oldInfo, ok := fts.statsByPath[newInfo.Path]
+	switch {
+	case !ok:
+		fts.countFile(newInfo.FileKind)
+
+	case oldInfo.isFinished() && !newInfo.isFinished():
+		// the file is being uploaded again, and its earlier upload stays
+		// counted so that the totals don't go back
+		oldInfo = FileUploadInfo{}
+
+	default:
+		// a retried upload starts over, but the progress shown doesn't
+		finished := newInfo.isFinished()
+		newInfo.TotalBytes = max(newInfo.TotalBytes, oldInfo.TotalBytes)
+		if finished {
+			newInfo.UploadedBytes = newInfo.TotalBytes
+		} else {
+			newInfo.UploadedBytes = min(
+				max(newInfo.UploadedBytes, oldInfo.UploadedBytes),
+				newInfo.TotalBytes,
+			)
+		}
 	}
 
 	fts.statsByPath[newInfo.Path] = newInfo
-	fts.addStats(newInfo, 1)
+	fts.uploadedBytes += newInfo.UploadedBytes - oldInfo.UploadedBytes
+	fts.totalBytes += newInfo.TotalBytes - oldInfo.TotalBytes
 }
 
-func (fts *fileTransferStats) addStats(info FileUploadInfo, mult int64) {
-	fts.uploadedBytes.Add(info.UploadedBytes * mult)
-	fts.totalBytes.Add(info.TotalBytes * mult)
-
-	switch info.FileKind {
+// countFile counts a file of the kind that's uploaded for the first time.
+func (fts *fileTransferStats) countFile(kind RunFileKind) {
+	switch kind {
 	default:
-		fts.otherCount.Add(int32(mult))
+		fts.otherCount++
 	case RunFileKindWandb:
-		fts.wandbCount.Add(int32(mult))
+		fts.wandbCount++
 	case RunFileKindArtifact:
-		fts.artifactCount.Add(int32(mult))
+		fts.artifactCount++
 	case RunFileKindMedia:
-		fts.mediaCount.Add(int32(mult))
+		fts.mediaCount++
 	}
 }
+
+func (fts *fileTransferStats) CountUnchanged(bytes int64) {
+	fts.Lock()
+	defer fts.Unlock()
+
+	fts.unchangedCount++
+	fts.uploadedBytes += bytes
+	fts.totalBytes += bytes
+	fts.dedupedBytes += bytes
+}
@@ -0,0 +1,80 @@
+package filetransfer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func upload(path string, uploaded, total int64) filetransfer.FileUploadInfo {
+	return filetransfer.FileUploadInfo{
+		Path:          path,
+		FileKind:      filetransfer.RunFileKindMedia,
+		UploadedBytes: uploaded,
+		TotalBytes:    total,
+	}
+}
+
+func TestFileTransferStats_RetryDoesNotGoBack(t *testing.T) {
+	stats := filetransfer.NewFileTransferStats()
+
+	stats.UpdateUploadStats(upload("a.png", 60, 100))
+	stats.UpdateUploadStats(upload("a.png", 10, 100))
+
+	assert.EqualValues(t, 60, stats.GetFilesStats().UploadedBytes)
+	stats.UpdateUploadStats(upload("a.png", 100, 100))
+	assert.EqualValues(t, 100, stats.GetFilesStats().UploadedBytes)
+}
+
+func TestFileTransferStats_ReuploadAddsToTotals(t *testing.T) {
+	stats := filetransfer.NewFileTransferStats()
+
+	stats.UpdateUploadStats(upload("a.png", 100, 100))
+	stats.UpdateUploadStats(upload("a.png", 100, 100))
+	stats.UpdateUploadStats(upload("a.png", 0, 50))
+
+	assert.EqualValues(t, 100, stats.GetFilesStats().UploadedBytes)
+	assert.EqualValues(t, 150, stats.GetFilesStats().TotalBytes)
+	stats.UpdateUploadStats(upload("a.png", 50, 50))
+	assert.EqualValues(t, 150, stats.GetFilesStats().UploadedBytes)
+	assert.EqualValues(t, 1, stats.GetFileCounts().MediaCount)
+}
+
+func TestFileTransferStats_UnchangedCountsAsDeduped(t *testing.T) {
+	stats := filetransfer.NewFileTransferStats()
+
+	stats.UpdateUploadStats(upload("a.png", 0, 100))
+	stats.CountUnchanged(30)
+
+	assert.Equal(t,
+		&service.FilePusherStats{
+			UploadedBytes: 30,
+			TotalBytes:    130,
+			DedupedBytes:  30,
+		},
+		stats.GetFilesStats())
+	assert.EqualValues(t, 1, stats.GetFileCounts().UnchangedCount)
+}
+
+// A file with the same content as another, such as one both saved with
+// the run and logged in an artifact, counts as a file but not its bytes.
+func TestFileTransferStats_SameContentCountsOnce(t *testing.T) {
+	stats := filetransfer.NewFileTransferStats()
+	saved := upload("files/model.ckpt", 40, 100)
+	saved.Digest = "digest"
+	logged := upload("staging/model.ckpt", 100, 100)
+	logged.Digest = "digest"
+	logged.FileKind = filetransfer.RunFileKindArtifact
+
+	stats.UpdateUploadStats(saved)
+	stats.UpdateUploadStats(logged)
+	saved.UploadedBytes = 100
+	stats.UpdateUploadStats(saved)
+
+	assert.EqualValues(t, 100, stats.GetFilesStats().UploadedBytes)
+	assert.EqualValues(t, 100, stats.GetFilesStats().TotalBytes)
+	assert.EqualValues(t, 1, stats.GetFileCounts().MediaCount)
+	assert.EqualValues(t, 1, stats.GetFileCounts().ArtifactCount)
+}
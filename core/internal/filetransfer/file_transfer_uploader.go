@@ -0,0 +1,202 @@
+package filetransfer
+
+import (
+	"context"
+	"crypto/md5"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// Uploader uploads files to a kind of storage backend.
+//
+// The backend hands out a destination for each file, usually a presigned
+// URL with headers to send. Most object stores accept the file in a
+// single PUT to that URL, but some are better written to with their own
+// protocol, such as Azure blob storage for large files.
+type Uploader interface {
+	// Upload uploads the file to the destination.
+	//
+	// It may retry parts of the upload, and returns once the file is
+	// uploaded, the context is cancelled, or the upload fails.
+	Upload(ctx context.Context, file UploadFile, destination UploadDestination) error
+}
+
+// UploadFile is the contents of a file to upload.
+type UploadFile struct {
+	// Path is the file's local path, for logging.
+	Path string
+
+	// Contents is the file's contents.
+	Contents io.ReaderAt
+
+	// Size is the number of bytes to upload.
+	Size int64
+
+	// Hooks observe the upload, and may be zero.
+	Hooks UploadHooks
+}
+
+// UploadHooks observe an upload as it happens.
+type UploadHooks struct {
+	// Progress is called as bytes are sent with the number sent so far and
+	// the file's size.
+	//
+	// The number goes back down if part of the upload is retried.
+	Progress func(sent, total int64)
+
+	// Checksum is called with the MD5 of the file's contents once they're
+	// all read, before the upload is finished if the uploader can tell.
+	//
+	// An error it returns fails the upload.
+	Checksum func(md5 []byte) error
+}
+
+func (h UploadHooks) progress(sent, total int64) {
+	if h.Progress != nil {
+		h.Progress(sent, total)
+	}
+}
+
+func (h UploadHooks) checksum(md5 []byte) error {
+	if h.Checksum == nil {
+		return nil
+	}
+	return h.Checksum(md5)
+}
+
+// UploadDestination is where to upload a file to.
+type UploadDestination struct {
+	// URL is the address to upload to, usually presigned.
+	URL string
+
+	// Headers are the headers to send with the upload, each as
+	// "Name:Value".
+	Headers []string
+}
+
+// header returns the value of the header with the name, or "".
+func (d UploadDestination) header(name string) string {
+	for _, header := range d.Headers {
+		key, value, ok := strings.Cut(header, ":")
+		if ok && strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// setHeaders sets the destination's headers on the request, skipping
+// invalid ones.
+func (d UploadDestination) setHeaders(
+	req *http.Request,
+	logger *observability.CoreLogger,
+) {
+	for _, header := range d.Headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok {
+			logger.Error("file transfer: upload: invalid header", "header", header)
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+}
+
+// Uploaders are the uploaders for each kind of storage backend.
+type Uploaders struct {
+	// Presigned uploads with a single PUT to a presigned URL, and is used
+	// for any storage without a more specific uploader.
+	Presigned Uploader
+
+	// AzureBlob uploads to Azure blob storage, or is nil to upload to it
+	// like to any other storage.
+	AzureBlob Uploader
+}
+
+// For returns the uploader for the destination.
+//
+// Azure blob storage is recognized by its host name or, for deployments
+// with their own host names, by the blob type header that the backend
+// sends for it.
+func (u Uploaders) For(destination UploadDestination) Uploader {
+	if u.AzureBlob != nil && isAzureBlob(destination) {
+		return u.AzureBlob
+	}
+	return u.Presigned
+}
+
+func isAzureBlob(destination UploadDestination) bool {
+	if destination.header("x-ms-blob-type") != "" {
+		return true
+	}
+
+	parsed, err := url.Parse(destination.URL)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(parsed.Hostname(), ".blob.core.windows.net")
+}
+
+// uploadRetriesKey is the context key of the counter of an upload's
+// retries.
+type uploadRetriesKey struct{}
+
+// withRetryCounter returns a context whose requests count their retries
+// in the counter.
+func withRetryCounter(ctx context.Context, retries *atomic.Int32) context.Context {
+	return context.WithValue(ctx, uploadRetriesKey{}, retries)
+}
+
+// hookedReader reads a range of a file, calling an upload's hooks.
+//
+// Seeking back to the start, as the HTTP client does to retry a request,
+// restarts the progress and checksum.
+type hookedReader struct {
+	*io.SectionReader
+	hooks UploadHooks
+	sent  int64
+	hash  hash.Hash
+}
+
+func newHookedReader(file UploadFile) *hookedReader {
+	return &hookedReader{
+		SectionReader: io.NewSectionReader(file.Contents, 0, file.Size),
+		hooks:         file.Hooks,
+		hash:          md5.New(),
+	}
+}
+
+func (r *hookedReader) Read(p []byte) (int, error) {
+	n, err := r.SectionReader.Read(p)
+	if n > 0 {
+		r.hash.Write(p[:n])
+		r.sent += int64(n)
+		r.hooks.progress(r.sent, r.SectionReader.Size())
+	}
+	return n, err
+}
+
+func (r *hookedReader) Seek(offset int64, whence int) (int64, error) {
+	position, err := r.SectionReader.Seek(offset, whence)
+	if err == nil && position == 0 {
+		r.sent = 0
+		r.hash.Reset()
+	}
+	return position, err
+}
+
+// Len is the number of bytes in the range, which the HTTP client sends
+// as the content length.
+func (r *hookedReader) Len() int {
+	return int(r.SectionReader.Size())
+}
+
+// sum returns the MD5 of the bytes read since the start.
+func (r *hookedReader) sum() []byte {
+	return r.hash.Sum(nil)
+}
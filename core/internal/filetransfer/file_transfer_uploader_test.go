@@ -0,0 +1,193 @@
+package filetransfer_test
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// namedUploader is an uploader that's only told apart from others.
+type namedUploader struct{ name string }
+
+func (u *namedUploader) Upload(
+	context.Context,
+	filetransfer.UploadFile,
+	filetransfer.UploadDestination,
+) error {
+	return nil
+}
+
+func TestUploaders_For(t *testing.T) {
+	presigned := &namedUploader{"presigned"}
+	azure := &namedUploader{"azure"}
+	uploaders := filetransfer.Uploaders{Presigned: presigned, AzureBlob: azure}
+
+	testCases := []struct {
+		name        string
+		destination filetransfer.UploadDestination
+		expected    filetransfer.Uploader
+	}{
+		{
+			"azure host",
+			filetransfer.UploadDestination{
+				URL: "https://account.blob.core.windows.net/container/file?sig=x",
+			},
+			azure,
+		},
+		{
+			"azure blob type header",
+			filetransfer.UploadDestination{
+				URL:     "https://storage.example.com/container/file",
+				Headers: []string{"X-Ms-Blob-Type:BlockBlob"},
+			},
+			azure,
+		},
+		{
+			"s3",
+			filetransfer.UploadDestination{
+				URL:     "https://bucket.s3.amazonaws.com/file?X-Amz-Signature=x",
+				Headers: []string{"Content-Type:text/plain"},
+			},
+			presigned,
+		},
+		{
+			"gcs",
+			filetransfer.UploadDestination{
+				URL: "https://storage.googleapis.com/bucket/file?X-Goog-Signature=x",
+			},
+			presigned,
+		},
+		{
+			"invalid url",
+			filetransfer.UploadDestination{URL: "://blob.core.windows.net"},
+			presigned,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Same(t, tc.expected, uploaders.For(tc.destination))
+		})
+	}
+}
+
+func TestUploaders_ForWithoutAzure(t *testing.T) {
+	presigned := &namedUploader{"presigned"}
+	uploaders := filetransfer.Uploaders{Presigned: presigned}
+
+	assert.Same(t, presigned, uploaders.For(filetransfer.UploadDestination{
+		URL: "https://account.blob.core.windows.net/container/file",
+	}))
+}
+
+func TestPresignedUploader_Hooks(t *testing.T) {
+	contents := []byte("test content for upload")
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, contents, body)
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+
+			// fail the first attempt to check the hooks start over
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+	defer server.Close()
+	var progress []int64
+	var checksum []byte
+
+	err := filetransfer.NewPresignedUploader(
+		impatientClient(),
+		observability.NewNoOpLogger(),
+	).Upload(
+		context.Background(),
+		uploadFile(contents, filetransfer.UploadHooks{
+			Progress: func(sent, total int64) {
+				assert.EqualValues(t, len(contents), total)
+				progress = append(progress, sent)
+			},
+			Checksum: func(sum []byte) error {
+				checksum = sum
+				return nil
+			},
+		}),
+		filetransfer.UploadDestination{
+			URL:     server.URL,
+			Headers: []string{"Content-Type:text/plain"},
+		},
+	)
+
+	require.NoError(t, err)
+	expected := md5.Sum(contents)
+	assert.Equal(t, expected[:], checksum)
+	assert.Equal(t, 2, attempts)
+	assert.EqualValues(t, len(contents), progress[len(progress)-1])
+}
+
+// The file transfer uploads to Azure through its own API, and checks the
+// file against the MD5 the backend expects.
+func TestDefaultFileTransfer_UploadToAzure(t *testing.T) {
+	blob, server := newFakeAzureBlob(t)
+	ft := filetransfer.NewDefaultFileTransfer(
+		impatientClient(),
+		observability.NewNoOpLogger(),
+		filetransfer.NewFileTransferStats(),
+	)
+	contents := []byte("test content for upload")
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, contents, 0644))
+	sum := md5.Sum(contents)
+
+	err := ft.Upload(&filetransfer.Task{
+		Type: filetransfer.UploadTask,
+		Path: path,
+		Url:  server.URL + "/container/file.txt?sig=sas",
+		Headers: []string{
+			"x-ms-blob-type:BlockBlob",
+			"Content-MD5:" + base64.StdEncoding.EncodeToString(sum[:]),
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, contents, blob.blob)
+}
+
+func TestDefaultFileTransfer_UploadChangedFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	ft := filetransfer.NewDefaultFileTransfer(
+		impatientClient(),
+		observability.NewNoOpLogger(),
+		filetransfer.NewFileTransferStats(),
+	)
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("changed"), 0644))
+	sum := md5.Sum([]byte("original"))
+
+	err := ft.Upload(&filetransfer.Task{
+		Type: filetransfer.UploadTask,
+		Path: path,
+		Url:  server.URL,
+		Headers: []string{
+			"Content-MD5:" + base64.StdEncoding.EncodeToString(sum[:]),
+		},
+	})
+
+	assert.ErrorContains(t, err, "changed while it was uploaded")
+}
@@ -0,0 +1,158 @@
+package filetransfer
+
+import (
+	"sync"
+	"time"
+)
+
+// ProgressUpdate is how far a group of uploads got.
+type ProgressUpdate struct {
+	// DoneBytes is the number of bytes uploaded or skipped so far.
+	DoneBytes int64
+
+	// TotalBytes is the number of bytes in the group.
+	TotalBytes int64
+
+	// DoneFiles is the number of files uploaded or skipped so far.
+	DoneFiles int
+
+	// TotalFiles is the number of files in the group, or 0 if the group
+	// isn't counted by file.
+	TotalFiles int
+}
+
+// Percent is the percentage of the group's bytes that are done.
+func (u ProgressUpdate) Percent() float64 {
+	if u.TotalBytes <= 0 {
+		return 100
+	}
+	return 100 * float64(u.DoneBytes) / float64(u.TotalBytes)
+}
+
+// ProgressReporter adds up the progress of a group of uploads, and reports
+// it at most once per interval.
+//
+// Files that don't need uploading, like ones the server already has, count
+// as done, so that a finished group reaches 100%. The methods of a nil
+// reporter do nothing.
+type ProgressReporter struct {
+	mu sync.Mutex
+
+	// interval is the least time between reports
+	interval time.Duration
+
+	// report is called with each report, while the lock is held so that
+	// reports arrive in order and none arrive after Finish
+	report func(ProgressUpdate)
+
+	// lastReport is when the last report was made
+	lastReport time.Time
+
+	// finished is whether Finish was called
+	finished bool
+
+	update ProgressUpdate
+
+	// doneByFile is the number of bytes done of each file in progress
+	doneByFile map[string]int64
+}
+
+// NewProgressReporter returns a reporter that calls report with the
+// group's progress at most once per interval.
+func NewProgressReporter(
+	interval time.Duration,
+	report func(ProgressUpdate),
+) *ProgressReporter {
+	return &ProgressReporter{
+		interval:   interval,
+		report:     report,
+		doneByFile: make(map[string]int64),
+	}
+}
+
+// AddFile adds a file of the given size to the group.
+func (p *ProgressReporter) AddFile(size int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update.TotalBytes += size
+	p.update.TotalFiles++
+}
+
+// Callback returns a task progress callback for the named file.
+func (p *ProgressReporter) Callback(name string) func(processed, total int) {
+	if p == nil {
+		return nil
+	}
+
+	return func(processed, _ int) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		// a retried upload starts over, so only the latest count matters
+		p.update.DoneBytes += int64(processed) - p.doneByFile[name]
+		p.doneByFile[name] = int64(processed)
+		p.maybeReport(false)
+	}
+}
+
+// Complete marks the named file of the given size as done, whether it was
+// uploaded or skipped.
+func (p *ProgressReporter) Complete(name string, size int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update.DoneBytes += size - p.doneByFile[name]
+	delete(p.doneByFile, name)
+	p.update.DoneFiles++
+	p.maybeReport(false)
+}
+
+// Set replaces the group's progress, for groups whose progress is counted
+// elsewhere.
+func (p *ProgressReporter) Set(update ProgressUpdate) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update = update
+	p.maybeReport(false)
+}
+
+// Finish reports the group's progress one last time.
+//
+// Nothing is reported after Finish returns.
+func (p *ProgressReporter) Finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maybeReport(true)
+	p.finished = true
+}
+
+// maybeReport reports the group's progress if the interval passed since
+// the last report, or if forced.
+//
+// The lock must be held.
+func (p *ProgressReporter) maybeReport(force bool) {
+	if p.finished {
+		return
+	}
+	if !force && time.Since(p.lastReport) < p.interval {
+		return
+	}
+
+	p.lastReport = time.Now()
+	p.report(p.update)
+}
@@ -0,0 +1,84 @@
+package filetransfer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wandb/wandb/core/internal/filetransfer"
+)
+
+func TestProgressReporter_CountsSkippedFiles(t *testing.T) {
+	var reports []filetransfer.ProgressUpdate
+	progress := filetransfer.NewProgressReporter(
+		0,
+		func(update filetransfer.ProgressUpdate) {
+			reports = append(reports, update)
+		},
+	)
+
+	progress.AddFile(100)
+	progress.AddFile(50)
+	progress.Complete("deduped", 50)
+	progress.Callback("uploaded")(40, 100)
+	progress.Complete("uploaded", 100)
+	progress.Finish()
+
+	assert.Equal(t,
+		filetransfer.ProgressUpdate{
+			DoneBytes:  150,
+			TotalBytes: 150,
+			DoneFiles:  2,
+			TotalFiles: 2,
+		},
+		reports[len(reports)-1])
+	assert.EqualValues(t, 100, reports[len(reports)-1].Percent())
+	assert.EqualValues(t, 90, reports[1].DoneBytes)
+}
+
+func TestProgressReporter_RetryStartsOver(t *testing.T) {
+	var last filetransfer.ProgressUpdate
+	progress := filetransfer.NewProgressReporter(
+		0,
+		func(update filetransfer.ProgressUpdate) { last = update },
+	)
+
+	progress.AddFile(100)
+	callback := progress.Callback("file")
+	callback(80, 100)
+	callback(10, 100)
+
+	assert.EqualValues(t, 10, last.DoneBytes)
+}
+
+func TestProgressReporter_Throttles(t *testing.T) {
+	reports := 0
+	progress := filetransfer.NewProgressReporter(
+		time.Hour,
+		func(filetransfer.ProgressUpdate) { reports++ },
+	)
+
+	progress.AddFile(100)
+	callback := progress.Callback("file")
+	for i := 0; i <= 100; i++ {
+		callback(i, 100)
+	}
+	assert.Equal(t, 1, reports)
+
+	// Finishing always reports, and nothing is reported after.
+	progress.Finish()
+	assert.Equal(t, 2, reports)
+	callback(100, 100)
+	progress.Finish()
+	assert.Equal(t, 2, reports)
+}
+
+func TestProgressReporter_Nil(t *testing.T) {
+	var progress *filetransfer.ProgressReporter
+
+	progress.AddFile(100)
+	progress.Complete("file", 100)
+	progress.Finish()
+
+	assert.Nil(t, progress.Callback("file"))
+}
@@ -0,0 +1,143 @@
+package filetransfer
+
+import (
+	"net/url"
+	"sync"
+)
+
+// sharedUploads shares the uploads of files with the same content.
+//
+// A run may upload the same content more than once, such as a checkpoint
+// that's both saved with the run and logged in an artifact. A task whose
+// content is being or was last uploaded to the same destination doesn't
+// upload it again, and finishes with the other upload's result.
+//
+// A file uploaded again from the same path isn't shared with its earlier
+// upload, as it's uploaded again on purpose. Tasks without a digest are
+// never shared.
+type sharedUploads struct {
+	mu sync.Mutex
+
+	// uploads are the last upload to each destination
+	uploads map[string]*sharedUpload
+
+	// unfinished are the uploads that haven't finished, by their tasks
+	unfinished map[*Task]*sharedUpload
+}
+
+// sharedUpload is an upload that other tasks may share.
+type sharedUpload struct {
+	// owner is the task making the upload
+	owner *Task
+
+	// done is whether the upload finished
+	done bool
+
+	// sharers are the tasks waiting for the upload to finish
+	sharers []*Task
+}
+
+func newSharedUploads() *sharedUploads {
+	return &sharedUploads{
+		uploads:    make(map[string]*sharedUpload),
+		unfinished: make(map[*Task]*sharedUpload),
+	}
+}
+
+// uploadDestination returns where the task uploads its file, and whether
+// its upload can be shared.
+//
+// The destination is the upload URL without its query, which for signed
+// URLs differs between requests for the same object.
+func uploadDestination(task *Task) (string, bool) {
+	if task.Type != UploadTask || task.Digest == "" {
+		return "", false
+	}
+
+	destination, err := url.Parse(task.Url)
+	if err != nil {
+		return "", false
+	}
+	destination.RawQuery = ""
+	destination.Fragment = ""
+	return destination.String(), true
+}
+
+// canShare is whether the task can share the upload.
+func (u *sharedUpload) canShare(task *Task) bool {
+	switch {
+	case u.owner.Digest != task.Digest:
+		return false
+	case !u.done:
+		return true
+	default:
+		// a failed upload is made again, rather than failing every task
+		// with the same content
+		return u.owner.Err == nil && u.owner.Path != task.Path
+	}
+}
+
+// share returns whether the task shares an upload that was started
+// before, in which case it mustn't be uploaded.
+//
+// The task is passed to done once the shared upload is, with the upload's
+// result, which may be right away.
+func (s *sharedUploads) share(task *Task, done func(*Task)) bool {
+	destination, ok := uploadDestination(task)
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	upload := s.uploads[destination]
+	switch {
+	case upload == nil || !upload.canShare(task):
+		upload = &sharedUpload{owner: task}
+		s.uploads[destination] = upload
+		s.unfinished[task] = upload
+		s.mu.Unlock()
+		return false
+
+	case !upload.done:
+		upload.sharers = append(upload.sharers, task)
+		s.mu.Unlock()
+		return true
+
+	default:
+		task.Size = upload.owner.Size
+		s.mu.Unlock()
+		done(task)
+		return true
+	}
+}
+
+// finish records that the task's upload finished, and passes the tasks
+// that shared it to done with its result.
+//
+// The upload may no longer be the last to its destination, but the tasks
+// that shared it are still finished. If the task was cancelled, the tasks
+// that shared its upload weren't, so they're passed to redo instead to be
+// uploaded on their own.
+func (s *sharedUploads) finish(task *Task, done func(*Task), redo func(*Task)) {
+	s.mu.Lock()
+	upload := s.unfinished[task]
+	if upload == nil {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.unfinished, task)
+	upload.done = true
+	sharers := upload.sharers
+	upload.sharers = nil
+	s.mu.Unlock()
+
+	for _, sharer := range sharers {
+		if task.Cancelled() {
+			redo(sharer)
+			continue
+		}
+		sharer.Err = task.Err
+		sharer.Size = task.Size
+		done(sharer)
+	}
+}
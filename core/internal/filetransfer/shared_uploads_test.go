@@ -0,0 +1,197 @@
+package filetransfer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// blockingFileTransfer uploads files once it's released, recording the
+// URLs uploaded to, or fails the uploads that are cancelled first.
+type blockingFileTransfer struct {
+	mu      sync.Mutex
+	urls    []string
+	release chan struct{}
+
+	// started, if not nil, gets each task whose upload started
+	started chan *filetransfer.Task
+}
+
+func (ft *blockingFileTransfer) Upload(task *filetransfer.Task) error {
+	if ft.started != nil {
+		ft.started <- task
+	}
+	var cancelled <-chan struct{}
+	if task.Context != nil {
+		cancelled = task.Context.Done()
+	}
+	select {
+	case <-ft.release:
+	case <-cancelled:
+		return task.Context.Err()
+	}
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.urls = append(ft.urls, task.Url)
+	task.Size = 100
+	return nil
+}
+
+func (ft *blockingFileTransfer) Download(*filetransfer.Task) error {
+	return nil
+}
+
+// uploadCheckpoint saves a checkpoint with the run and logs the same
+// content in an artifact, uploading them to the URLs, and returns the
+// URLs uploaded to and the stats.
+func uploadCheckpoint(
+	t *testing.T,
+	runFileURL string,
+	artifactURL string,
+) ([]string, filetransfer.FileTransferStats) {
+	t.Helper()
+	ft := &blockingFileTransfer{release: make(chan struct{})}
+	stats := filetransfer.NewFileTransferStats()
+	manager := filetransfer.NewFileTransferManager(
+		filetransfer.WithLogger(observability.NewNoOpLogger()),
+		filetransfer.WithFileTransfer(ft),
+		filetransfer.WithFileTransferStats(stats),
+	)
+	manager.Start()
+
+	var finished []*filetransfer.Task
+	var finishedMu sync.Mutex
+	addTask := func(kind filetransfer.RunFileKind, path, url string) {
+		task := &filetransfer.Task{
+			FileKind: kind,
+			Type:     filetransfer.UploadTask,
+			Path:     path,
+			Url:      url,
+			Digest:   "checkpoint-digest",
+		}
+		task.SetCompletionCallback(func(task *filetransfer.Task) {
+			finishedMu.Lock()
+			defer finishedMu.Unlock()
+			finished = append(finished, task)
+		})
+		manager.AddTask(task)
+	}
+	addTask(filetransfer.RunFileKindOther, "files/model.ckpt", runFileURL)
+	addTask(filetransfer.RunFileKindArtifact, "staging/model.ckpt", artifactURL)
+	close(ft.release)
+	manager.Close()
+
+	require.Len(t, finished, 2)
+	for _, task := range finished {
+		assert.NoError(t, task.Err)
+		assert.EqualValues(t, 100, task.Size)
+	}
+	return ft.urls, stats
+}
+
+func TestFileTransferManager_SameContentSameDestination(t *testing.T) {
+	urls, stats := uploadCheckpoint(t,
+		"https://storage.example.com/bucket/model.ckpt?signature=1",
+		"https://storage.example.com/bucket/model.ckpt?signature=2",
+	)
+
+	assert.Len(t, urls, 1)
+	assert.EqualValues(t, 100, stats.GetFilesStats().TotalBytes)
+	assert.EqualValues(t, 100, stats.GetFilesStats().UploadedBytes)
+	assert.EqualValues(t, 1, stats.GetFileCounts().OtherCount)
+	assert.EqualValues(t, 1, stats.GetFileCounts().ArtifactCount)
+}
+
+func TestFileTransferManager_SameContentOtherDestination(t *testing.T) {
+	urls, stats := uploadCheckpoint(t,
+		"https://storage.example.com/runs/model.ckpt",
+		"https://storage.example.com/artifacts/checkpoint-digest",
+	)
+
+	assert.Len(t, urls, 2)
+	assert.EqualValues(t, 100, stats.GetFilesStats().TotalBytes)
+	assert.EqualValues(t, 100, stats.GetFilesStats().UploadedBytes)
+}
+
+// A file uploaded again from the same path is uploaded even if its content
+// was already uploaded there, since it's uploaded again on purpose.
+func TestFileTransferManager_ReuploadIsNotShared(t *testing.T) {
+	ft := &blockingFileTransfer{release: make(chan struct{})}
+	close(ft.release)
+	manager := filetransfer.NewFileTransferManager(
+		filetransfer.WithLogger(observability.NewNoOpLogger()),
+		filetransfer.WithFileTransfer(ft),
+		filetransfer.WithFileTransferStats(filetransfer.NewFileTransferStats()),
+	)
+	manager.Start()
+
+	for range 2 {
+		done := make(chan struct{})
+		task := &filetransfer.Task{
+			Type:   filetransfer.UploadTask,
+			Path:   "files/model.ckpt",
+			Url:    "https://storage.example.com/runs/model.ckpt",
+			Digest: "checkpoint-digest",
+		}
+		task.SetCompletionCallback(func(*filetransfer.Task) { close(done) })
+		manager.AddTask(task)
+		<-done
+	}
+	manager.Close()
+
+	assert.Len(t, ft.urls, 2)
+}
+
+// A task that shared a cancelled upload isn't cancelled with it, and is
+// uploaded on its own.
+func TestFileTransferManager_CancelledUploadIsRedone(t *testing.T) {
+	ft := &blockingFileTransfer{
+		release: make(chan struct{}),
+		started: make(chan *filetransfer.Task, 2),
+	}
+	manager := filetransfer.NewFileTransferManager(
+		filetransfer.WithLogger(observability.NewNoOpLogger()),
+		filetransfer.WithFileTransfer(ft),
+		filetransfer.WithFileTransferStats(filetransfer.NewFileTransferStats()),
+	)
+	manager.Start()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	owner := &filetransfer.Task{
+		Type:    filetransfer.UploadTask,
+		Path:    "staging/model.ckpt",
+		Url:     "https://storage.example.com/bucket/model.ckpt",
+		Digest:  "checkpoint-digest",
+		Context: ctx,
+	}
+	sharer := &filetransfer.Task{
+		Type:   filetransfer.UploadTask,
+		Path:   "files/model.ckpt",
+		Url:    "https://storage.example.com/bucket/model.ckpt",
+		Digest: "checkpoint-digest",
+	}
+	ownerDone := make(chan struct{})
+	owner.SetCompletionCallback(func(*filetransfer.Task) { close(ownerDone) })
+	sharer.SetCompletionCallback(func(*filetransfer.Task) {})
+	manager.AddTask(owner)
+	<-ft.started
+	manager.AddTask(sharer)
+	// the sharer is uploaded on its own either way, but is most likely
+	// sharing the upload by now
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-ownerDone
+	close(ft.release)
+	manager.Close()
+
+	assert.ErrorIs(t, owner.Err, context.Canceled)
+	assert.NoError(t, sharer.Err)
+	assert.Equal(t, []string{sharer.Url}, ft.urls)
+}
@@ -32,9 +32,18 @@ type Task struct {
 	// Size is the size of the file
 	Size int64
 
+	// Digest is the base64-encoded MD5 of the file's content, if known.
+	//
+	// Uploads of the same content to the same destination are made once,
+	// and its bytes count once in the upload stats.
+	Digest string
+
 	// Error, if any.
 	Err error
 
+	// Retries is how many times the upload was retried.
+	Retries int
+
 	// Callback to execute after completion (success or failure).
 	CompletionCallback func(*Task)
 
@@ -45,6 +54,12 @@ type Task struct {
 	Context context.Context
 }
 
+// Cancelled returns whether the task's context was cancelled, so that it
+// stopped or never started.
+func (ut *Task) Cancelled() bool {
+	return ut.Context != nil && ut.Context.Err() != nil
+}
+
 func (ut *Task) SetProgressCallback(callback func(int, int)) {
 	ut.ProgressCallback = callback
 }
@@ -52,6 +52,12 @@ func (m *FakeFileTransferManager) Close() {
 	m.CompleteTasks()
 }
 
+func (m *FakeFileTransferManager) PendingTasks() int {
+	m.tasksMu.Lock()
+	defer m.tasksMu.Unlock()
+	return len(m.unfinishedTasks)
+}
+
 func (m *FakeFileTransferManager) AddTask(t *filetransfer.Task) {
 	m.tasksMu.Lock()
 	defer m.tasksMu.Unlock()
@@ -194,8 +194,9 @@ func (v *CommitArtifactCommitArtifactCommitArtifactPayload) GetArtifact() Commit
 
 // CommitArtifactCommitArtifactCommitArtifactPayloadArtifact includes the requested fields of the GraphQL type Artifact.
 type CommitArtifactCommitArtifactCommitArtifactPayloadArtifact struct {
-	Id     string `json:"id"`
-	Digest string `json:"digest"`
+	Id           string `json:"id"`
+	Digest       string `json:"digest"`
+	VersionIndex *int   `json:"versionIndex"`
 }
 
 // GetId returns CommitArtifactCommitArtifactCommitArtifactPayloadArtifact.Id, and is useful for accessing the field via an interface.
@@ -206,6 +207,11 @@ func (v *CommitArtifactCommitArtifactCommitArtifactPayloadArtifact) GetDigest()
 	return v.Digest
 }
 
+// GetVersionIndex returns CommitArtifactCommitArtifactCommitArtifactPayloadArtifact.VersionIndex, and is useful for accessing the field via an interface.
+func (v *CommitArtifactCommitArtifactCommitArtifactPayloadArtifact) GetVersionIndex() *int {
+	return v.VersionIndex
+}
+
 // CommitArtifactResponse is returned by CommitArtifact on success.
 type CommitArtifactResponse struct {
 	CommitArtifact *CommitArtifactCommitArtifactCommitArtifactPayload `json:"commitArtifact"`
@@ -216,6 +222,36 @@ func (v *CommitArtifactResponse) GetCommitArtifact() *CommitArtifactCommitArtifa
 	return v.CommitArtifact
 }
 
+// CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayload includes the requested fields of the GraphQL type CreateAnonymousEntityPayload.
+type CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayload struct {
+	ApiKey *CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayloadApiKey `json:"apiKey"`
+}
+
+// GetApiKey returns CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayload.ApiKey, and is useful for accessing the field via an interface.
+func (v *CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayload) GetApiKey() *CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayloadApiKey {
+	return v.ApiKey
+}
+
+// CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayloadApiKey includes the requested fields of the GraphQL type ApiKey.
+type CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayloadApiKey struct {
+	Name string `json:"name"`
+}
+
+// GetName returns CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayloadApiKey.Name, and is useful for accessing the field via an interface.
+func (v *CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayloadApiKey) GetName() string {
+	return v.Name
+}
+
+// CreateAnonymousApiKeyResponse is returned by CreateAnonymousApiKey on success.
+type CreateAnonymousApiKeyResponse struct {
+	CreateAnonymousEntity *CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayload `json:"createAnonymousEntity"`
+}
+
+// GetCreateAnonymousEntity returns CreateAnonymousApiKeyResponse.CreateAnonymousEntity, and is useful for accessing the field via an interface.
+func (v *CreateAnonymousApiKeyResponse) GetCreateAnonymousEntity() *CreateAnonymousApiKeyCreateAnonymousEntityCreateAnonymousEntityPayload {
+	return v.CreateAnonymousEntity
+}
+
 // CreateArtifactCreateArtifactCreateArtifactPayload includes the requested fields of the GraphQL type CreateArtifactPayload.
 type CreateArtifactCreateArtifactCreateArtifactPayload struct {
 	Artifact CreateArtifactCreateArtifactCreateArtifactPayloadArtifact `json:"artifact"`
@@ -230,6 +266,7 @@ func (v *CreateArtifactCreateArtifactCreateArtifactPayload) GetArtifact() Create
 type CreateArtifactCreateArtifactCreateArtifactPayloadArtifact struct {
 	Id               string                                                                    `json:"id"`
 	State            ArtifactState                                                             `json:"state"`
+	VersionIndex     *int                                                                      `json:"versionIndex"`
 	ArtifactSequence CreateArtifactCreateArtifactCreateArtifactPayloadArtifactArtifactSequence `json:"artifactSequence"`
 }
 
@@ -241,6 +278,11 @@ func (v *CreateArtifactCreateArtifactCreateArtifactPayloadArtifact) GetState() A
 	return v.State
 }
 
+// GetVersionIndex returns CreateArtifactCreateArtifactCreateArtifactPayloadArtifact.VersionIndex, and is useful for accessing the field via an interface.
+func (v *CreateArtifactCreateArtifactCreateArtifactPayloadArtifact) GetVersionIndex() *int {
+	return v.VersionIndex
+}
+
 // GetArtifactSequence returns CreateArtifactCreateArtifactCreateArtifactPayloadArtifact.ArtifactSequence, and is useful for accessing the field via an interface.
 func (v *CreateArtifactCreateArtifactCreateArtifactPayloadArtifact) GetArtifactSequence() CreateArtifactCreateArtifactCreateArtifactPayloadArtifactArtifactSequence {
 	return v.ArtifactSequence
@@ -479,6 +521,44 @@ func (v *CreateRunFilesResponse) GetCreateRunFiles() *CreateRunFilesCreateRunFil
 	return v.CreateRunFiles
 }
 
+// DeleteArtifactDeleteArtifactDeleteArtifactPayload includes the requested fields of the GraphQL type DeleteArtifactPayload.
+type DeleteArtifactDeleteArtifactDeleteArtifactPayload struct {
+	ClientMutationId *string `json:"clientMutationId"`
+}
+
+// GetClientMutationId returns DeleteArtifactDeleteArtifactDeleteArtifactPayload.ClientMutationId, and is useful for accessing the field via an interface.
+func (v *DeleteArtifactDeleteArtifactDeleteArtifactPayload) GetClientMutationId() *string {
+	return v.ClientMutationId
+}
+
+// DeleteArtifactResponse is returned by DeleteArtifact on success.
+type DeleteArtifactResponse struct {
+	DeleteArtifact *DeleteArtifactDeleteArtifactDeleteArtifactPayload `json:"deleteArtifact"`
+}
+
+// GetDeleteArtifact returns DeleteArtifactResponse.DeleteArtifact, and is useful for accessing the field via an interface.
+func (v *DeleteArtifactResponse) GetDeleteArtifact() *DeleteArtifactDeleteArtifactDeleteArtifactPayload {
+	return v.DeleteArtifact
+}
+
+// DeleteRunDeleteRunDeleteRunPayload includes the requested fields of the GraphQL type DeleteRunPayload.
+type DeleteRunDeleteRunDeleteRunPayload struct {
+	ClientMutationId *string `json:"clientMutationId"`
+}
+
+// GetClientMutationId returns DeleteRunDeleteRunDeleteRunPayload.ClientMutationId, and is useful for accessing the field via an interface.
+func (v *DeleteRunDeleteRunDeleteRunPayload) GetClientMutationId() *string {
+	return v.ClientMutationId
+}
+
+// DeleteRunResponse is returned by DeleteRun on success.
+type DeleteRunResponse struct {
+	DeleteRun *DeleteRunDeleteRunDeleteRunPayload `json:"deleteRun"`
+}
+
+// GetDeleteRun returns DeleteRunResponse.DeleteRun, and is useful for accessing the field via an interface.
+func (v *DeleteRunResponse) GetDeleteRun() *DeleteRunDeleteRunDeleteRunPayload { return v.DeleteRun }
+
 // LinkArtifactLinkArtifactLinkArtifactPayload includes the requested fields of the GraphQL type LinkArtifactPayload.
 type LinkArtifactLinkArtifactLinkArtifactPayload struct {
 	VersionIndex *int `json:"versionIndex"`
@@ -517,6 +597,36 @@ func (v *NotifyScriptableRunAlertResponse) GetNotifyScriptableRunAlert() *Notify
 	return v.NotifyScriptableRunAlert
 }
 
+// ProjectSettingsProject includes the requested fields of the GraphQL type Project.
+type ProjectSettingsProject struct {
+	RunDefaults *ProjectSettingsProjectRunDefaults `json:"runDefaults"`
+}
+
+// GetRunDefaults returns ProjectSettingsProject.RunDefaults, and is useful for accessing the field via an interface.
+func (v *ProjectSettingsProject) GetRunDefaults() *ProjectSettingsProjectRunDefaults {
+	return v.RunDefaults
+}
+
+// ProjectSettingsProjectRunDefaults includes the requested fields of the GraphQL type RunDefaults.
+type ProjectSettingsProjectRunDefaults struct {
+	SaveCode   *bool `json:"saveCode"`
+	DisableGit *bool `json:"disableGit"`
+}
+
+// GetSaveCode returns ProjectSettingsProjectRunDefaults.SaveCode, and is useful for accessing the field via an interface.
+func (v *ProjectSettingsProjectRunDefaults) GetSaveCode() *bool { return v.SaveCode }
+
+// GetDisableGit returns ProjectSettingsProjectRunDefaults.DisableGit, and is useful for accessing the field via an interface.
+func (v *ProjectSettingsProjectRunDefaults) GetDisableGit() *bool { return v.DisableGit }
+
+// ProjectSettingsResponse is returned by ProjectSettings on success.
+type ProjectSettingsResponse struct {
+	Project *ProjectSettingsProject `json:"project"`
+}
+
+// GetProject returns ProjectSettingsResponse.Project, and is useful for accessing the field via an interface.
+func (v *ProjectSettingsResponse) GetProject() *ProjectSettingsProject { return v.Project }
+
 // RunResumeStatusModelProject includes the requested fields of the GraphQL type Project.
 type RunResumeStatusModelProject struct {
 	Id     string                                `json:"id"`
@@ -631,6 +741,92 @@ type RunStoppedStatusResponse struct {
 // GetProject returns RunStoppedStatusResponse.Project, and is useful for accessing the field via an interface.
 func (v *RunStoppedStatusResponse) GetProject() *RunStoppedStatusProject { return v.Project }
 
+// RunVerificationProject includes the requested fields of the GraphQL type Project.
+type RunVerificationProject struct {
+	Run *RunVerificationProjectRun `json:"run"`
+}
+
+// GetRun returns RunVerificationProject.Run, and is useful for accessing the field via an interface.
+func (v *RunVerificationProject) GetRun() *RunVerificationProjectRun { return v.Run }
+
+// RunVerificationProjectRun includes the requested fields of the GraphQL type Run.
+type RunVerificationProjectRun struct {
+	HistoryLineCount *int                                                        `json:"historyLineCount"`
+	SummaryMetrics   *string                                                     `json:"summaryMetrics"`
+	Files            *RunVerificationProjectRunFilesFileConnection               `json:"files"`
+	OutputArtifacts  *RunVerificationProjectRunOutputArtifactsArtifactConnection `json:"outputArtifacts"`
+}
+
+// GetHistoryLineCount returns RunVerificationProjectRun.HistoryLineCount, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRun) GetHistoryLineCount() *int { return v.HistoryLineCount }
+
+// GetSummaryMetrics returns RunVerificationProjectRun.SummaryMetrics, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRun) GetSummaryMetrics() *string { return v.SummaryMetrics }
+
+// GetFiles returns RunVerificationProjectRun.Files, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRun) GetFiles() *RunVerificationProjectRunFilesFileConnection {
+	return v.Files
+}
+
+// GetOutputArtifacts returns RunVerificationProjectRun.OutputArtifacts, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRun) GetOutputArtifacts() *RunVerificationProjectRunOutputArtifactsArtifactConnection {
+	return v.OutputArtifacts
+}
+
+// RunVerificationProjectRunFilesFileConnection includes the requested fields of the GraphQL type FileConnection.
+type RunVerificationProjectRunFilesFileConnection struct {
+	Edges []RunVerificationProjectRunFilesFileConnectionEdgesFileEdge `json:"edges"`
+}
+
+// GetEdges returns RunVerificationProjectRunFilesFileConnection.Edges, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRunFilesFileConnection) GetEdges() []RunVerificationProjectRunFilesFileConnectionEdgesFileEdge {
+	return v.Edges
+}
+
+// RunVerificationProjectRunFilesFileConnectionEdgesFileEdge includes the requested fields of the GraphQL type FileEdge.
+type RunVerificationProjectRunFilesFileConnectionEdgesFileEdge struct {
+	Node *RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile `json:"node"`
+}
+
+// GetNode returns RunVerificationProjectRunFilesFileConnectionEdgesFileEdge.Node, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRunFilesFileConnectionEdgesFileEdge) GetNode() *RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile {
+	return v.Node
+}
+
+// RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile includes the requested fields of the GraphQL type File.
+type RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile struct {
+	Name string  `json:"name"`
+	Md5  *string `json:"md5"`
+}
+
+// GetName returns RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile.Name, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile) GetName() string {
+	return v.Name
+}
+
+// GetMd5 returns RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile.Md5, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRunFilesFileConnectionEdgesFileEdgeNodeFile) GetMd5() *string {
+	return v.Md5
+}
+
+// RunVerificationProjectRunOutputArtifactsArtifactConnection includes the requested fields of the GraphQL type ArtifactConnection.
+type RunVerificationProjectRunOutputArtifactsArtifactConnection struct {
+	TotalCount int `json:"totalCount"`
+}
+
+// GetTotalCount returns RunVerificationProjectRunOutputArtifactsArtifactConnection.TotalCount, and is useful for accessing the field via an interface.
+func (v *RunVerificationProjectRunOutputArtifactsArtifactConnection) GetTotalCount() int {
+	return v.TotalCount
+}
+
+// RunVerificationResponse is returned by RunVerification on success.
+type RunVerificationResponse struct {
+	Project *RunVerificationProject `json:"project"`
+}
+
+// GetProject returns RunVerificationResponse.Project, and is useful for accessing the field via an interface.
+func (v *RunVerificationResponse) GetProject() *RunVerificationProject { return v.Project }
+
 // ServerInfoResponse is returned by ServerInfo on success.
 type ServerInfoResponse struct {
 	ServerInfo *ServerInfoServerInfo `json:"serverInfo"`
@@ -716,6 +912,9 @@ type UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun struct {
 	Description *string                                                      `json:"description"`
 	Config      *string                                                      `json:"config"`
 	SweepName   *string                                                      `json:"sweepName"`
+	GroupName   *string                                                      `json:"groupName"`
+	JobType     *string                                                      `json:"jobType"`
+	Host        *string                                                      `json:"host"`
 	Project     *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRunProject `json:"project"`
 }
 
@@ -743,6 +942,19 @@ func (v *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun) GetSweepName() *s
 	return v.SweepName
 }
 
+// GetGroupName returns UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun.GroupName, and is useful for accessing the field via an interface.
+func (v *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun) GetGroupName() *string {
+	return v.GroupName
+}
+
+// GetJobType returns UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun.JobType, and is useful for accessing the field via an interface.
+func (v *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun) GetJobType() *string {
+	return v.JobType
+}
+
+// GetHost returns UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun.Host, and is useful for accessing the field via an interface.
+func (v *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun) GetHost() *string { return v.Host }
+
 // GetProject returns UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun.Project, and is useful for accessing the field via an interface.
 func (v *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRun) GetProject() *UpsertBucketUpsertBucketUpsertBucketPayloadBucketRunProject {
 	return v.Project
@@ -1042,6 +1254,22 @@ func (v *__CreateRunFilesInput) GetRun() string { return v.Run }
 // GetFiles returns __CreateRunFilesInput.Files, and is useful for accessing the field via an interface.
 func (v *__CreateRunFilesInput) GetFiles() []string { return v.Files }
 
+// __DeleteArtifactInput is used internally by genqlient
+type __DeleteArtifactInput struct {
+	ArtifactID string `json:"artifactID"`
+}
+
+// GetArtifactID returns __DeleteArtifactInput.ArtifactID, and is useful for accessing the field via an interface.
+func (v *__DeleteArtifactInput) GetArtifactID() string { return v.ArtifactID }
+
+// __DeleteRunInput is used internally by genqlient
+type __DeleteRunInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __DeleteRunInput.Id, and is useful for accessing the field via an interface.
+func (v *__DeleteRunInput) GetId() string { return v.Id }
+
 // __LinkArtifactInput is used internally by genqlient
 type __LinkArtifactInput struct {
 	ArtifactPortfolioName string               `json:"artifactPortfolioName"`
@@ -1102,6 +1330,18 @@ func (v *__NotifyScriptableRunAlertInput) GetSeverity() *AlertSeverity { return
 // GetWaitDuration returns __NotifyScriptableRunAlertInput.WaitDuration, and is useful for accessing the field via an interface.
 func (v *__NotifyScriptableRunAlertInput) GetWaitDuration() *int64 { return v.WaitDuration }
 
+// __ProjectSettingsInput is used internally by genqlient
+type __ProjectSettingsInput struct {
+	EntityName  *string `json:"entityName"`
+	ProjectName *string `json:"projectName"`
+}
+
+// GetEntityName returns __ProjectSettingsInput.EntityName, and is useful for accessing the field via an interface.
+func (v *__ProjectSettingsInput) GetEntityName() *string { return v.EntityName }
+
+// GetProjectName returns __ProjectSettingsInput.ProjectName, and is useful for accessing the field via an interface.
+func (v *__ProjectSettingsInput) GetProjectName() *string { return v.ProjectName }
+
 // __RunResumeStatusInput is used internally by genqlient
 type __RunResumeStatusInput struct {
 	Project *string `json:"project"`
@@ -1134,6 +1374,26 @@ func (v *__RunStoppedStatusInput) GetProjectName() *string { return v.ProjectNam
 // GetRunId returns __RunStoppedStatusInput.RunId, and is useful for accessing the field via an interface.
 func (v *__RunStoppedStatusInput) GetRunId() string { return v.RunId }
 
+// __RunVerificationInput is used internally by genqlient
+type __RunVerificationInput struct {
+	EntityName  *string  `json:"entityName"`
+	ProjectName *string  `json:"projectName"`
+	RunId       string   `json:"runId"`
+	FileNames   []string `json:"fileNames"`
+}
+
+// GetEntityName returns __RunVerificationInput.EntityName, and is useful for accessing the field via an interface.
+func (v *__RunVerificationInput) GetEntityName() *string { return v.EntityName }
+
+// GetProjectName returns __RunVerificationInput.ProjectName, and is useful for accessing the field via an interface.
+func (v *__RunVerificationInput) GetProjectName() *string { return v.ProjectName }
+
+// GetRunId returns __RunVerificationInput.RunId, and is useful for accessing the field via an interface.
+func (v *__RunVerificationInput) GetRunId() string { return v.RunId }
+
+// GetFileNames returns __RunVerificationInput.FileNames, and is useful for accessing the field via an interface.
+func (v *__RunVerificationInput) GetFileNames() []string { return v.FileNames }
+
 // __UpsertBucketInput is used internally by genqlient
 type __UpsertBucketInput struct {
 	Id             *string  `json:"id"`
@@ -1365,6 +1625,7 @@ mutation CommitArtifact ($artifactID: ID!) {
 		artifact {
 			id
 			digest
+			versionIndex
 		}
 	}
 }
@@ -1396,6 +1657,39 @@ func CommitArtifact(
 	return &data_, err_
 }
 
+// The query or mutation executed by CreateAnonymousApiKey.
+const CreateAnonymousApiKey_Operation = `
+mutation CreateAnonymousApiKey {
+	createAnonymousEntity(input: {}) {
+		apiKey {
+			name
+		}
+	}
+}
+`
+
+func CreateAnonymousApiKey(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (*CreateAnonymousApiKeyResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "CreateAnonymousApiKey",
+		Query:  CreateAnonymousApiKey_Operation,
+	}
+	var err_ error
+
+	var data_ CreateAnonymousApiKeyResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by CreateArtifact.
 const CreateArtifact_Operation = `
 mutation CreateArtifact ($entityName: String!, $projectName: String!, $artifactTypeName: String!, $artifactCollectionName: String!, $runName: String, $digest: String!, $description: String, $aliases: [ArtifactAliasInput!], $metadata: JSONString, $ttlDurationSeconds: Int64, $historyStep: Int64, $distributedID: String, $clientID: ID!, $sequenceClientID: ID!) {
@@ -1403,6 +1697,7 @@ mutation CreateArtifact ($entityName: String!, $projectName: String!, $artifactT
 		artifact {
 			id
 			state
+			versionIndex
 			artifactSequence {
 				latestArtifact {
 					id
@@ -1616,6 +1911,76 @@ func CreateRunFiles(
 	return &data_, err_
 }
 
+// The query or mutation executed by DeleteArtifact.
+const DeleteArtifact_Operation = `
+mutation DeleteArtifact ($artifactID: ID!) {
+	deleteArtifact(input: {artifactID:$artifactID}) {
+		clientMutationId
+	}
+}
+`
+
+func DeleteArtifact(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	artifactID string,
+) (*DeleteArtifactResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteArtifact",
+		Query:  DeleteArtifact_Operation,
+		Variables: &__DeleteArtifactInput{
+			ArtifactID: artifactID,
+		},
+	}
+	var err_ error
+
+	var data_ DeleteArtifactResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by DeleteRun.
+const DeleteRun_Operation = `
+mutation DeleteRun ($id: ID!) {
+	deleteRun(input: {id:$id}) {
+		clientMutationId
+	}
+}
+`
+
+func DeleteRun(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (*DeleteRunResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "DeleteRun",
+		Query:  DeleteRun_Operation,
+		Variables: &__DeleteRunInput{
+			Id: id,
+		},
+	}
+	var err_ error
+
+	var data_ DeleteRunResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by LinkArtifact.
 const LinkArtifact_Operation = `
 mutation LinkArtifact ($artifactPortfolioName: String!, $entityName: String!, $projectName: String!, $aliases: [ArtifactAliasInput!], $clientId: ID, $artifactId: ID) {
@@ -1708,6 +2073,46 @@ func NotifyScriptableRunAlert(
 	return &data_, err_
 }
 
+// The query or mutation executed by ProjectSettings.
+const ProjectSettings_Operation = `
+query ProjectSettings ($entityName: String, $projectName: String) {
+	project(name: $projectName, entityName: $entityName) {
+		runDefaults {
+			saveCode
+			disableGit
+		}
+	}
+}
+`
+
+func ProjectSettings(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	entityName *string,
+	projectName *string,
+) (*ProjectSettingsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "ProjectSettings",
+		Query:  ProjectSettings_Operation,
+		Variables: &__ProjectSettingsInput{
+			EntityName:  entityName,
+			ProjectName: projectName,
+		},
+	}
+	var err_ error
+
+	var data_ ProjectSettingsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by RunResumeStatus.
 const RunResumeStatus_Operation = `
 query RunResumeStatus ($project: String, $entity: String, $name: String!) {
@@ -1806,6 +2211,61 @@ func RunStoppedStatus(
 	return &data_, err_
 }
 
+// The query or mutation executed by RunVerification.
+const RunVerification_Operation = `
+query RunVerification ($entityName: String, $projectName: String, $runId: String!, $fileNames: [String!]) {
+	project(name: $projectName, entityName: $entityName) {
+		run(name: $runId) {
+			historyLineCount
+			summaryMetrics
+			files(names: $fileNames) {
+				edges {
+					node {
+						name
+						md5
+					}
+				}
+			}
+			outputArtifacts {
+				totalCount
+			}
+		}
+	}
+}
+`
+
+func RunVerification(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	entityName *string,
+	projectName *string,
+	runId string,
+	fileNames []string,
+) (*RunVerificationResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "RunVerification",
+		Query:  RunVerification_Operation,
+		Variables: &__RunVerificationInput{
+			EntityName:  entityName,
+			ProjectName: projectName,
+			RunId:       runId,
+			FileNames:   fileNames,
+		},
+	}
+	var err_ error
+
+	var data_ RunVerificationResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by ServerInfo.
 const ServerInfo_Operation = `
 query ServerInfo {
@@ -1853,6 +2313,9 @@ mutation UpsertBucket ($id: String, $name: String, $project: String, $entity: St
 			description
 			config
 			sweepName
+			groupName
+			jobType
+			host
 			project {
 				id
 				name
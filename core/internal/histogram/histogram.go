@@ -0,0 +1,239 @@
+// Package histogram bins sequences of numbers into histograms, the way
+// numpy.histogram does by default.
+//
+// Clients may log a histogram as the raw sequence to bin,
+//
+//	{"_type": "histogram", "sequence": [...], "num_bins": 64}
+//
+// instead of precomputing its bins and values. BinSequence replaces such
+// a history value by the standard histogram format,
+//
+//	{"_type": "histogram", "bins": [...], "values": [...]}
+//
+// which is what wandb.Histogram produces in the Python SDK.
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	json "github.com/wandb/simplejsonext"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// DefaultNumBins is the number of bins if a client doesn't specify it.
+	DefaultNumBins = 64
+
+	// MaxNumBins is the largest number of bins allowed.
+	MaxNumBins = 512
+
+	// maxSequenceLength is the length above which a sequence is sampled
+	// before being binned, to bound the work per histogram.
+	maxSequenceLength = 1 << 20
+)
+
+// Histogram is the count of values in each bin.
+//
+// Bins are the len(Values)+1 edges of the bins. Each bin includes its
+// lower edge, and the last bin also includes its upper edge.
+type Histogram struct {
+	Bins   []float64
+	Values []int64
+}
+
+// Compute bins the sequence into numBins equal-width bins spanning its
+// minimum and maximum.
+//
+// NaNs and infinities are excluded. A constant sequence is put into
+// a single bin of width 1 centered on the value, and an empty sequence
+// into numBins empty bins spanning [0, 1], as numpy does.
+//
+// Sequences longer than about a million values are binned from a random
+// sample. The sample's range is the sequence's range and its counts are
+// scaled up to the sequence's length. The sampling is seeded, so the same
+// sequence always produces the same histogram.
+func Compute(sequence []float64, numBins int) Histogram {
+	return compute(sequence, numBins, maxSequenceLength)
+}
+
+func compute(sequence []float64, numBins int, maxLength int) Histogram {
+	var count int
+	first, last := math.Inf(1), math.Inf(-1)
+	for _, x := range sequence {
+		if isFinite(x) {
+			count++
+			first = min(first, x)
+			last = max(last, x)
+		}
+	}
+
+	switch {
+	case count == 0:
+		return bin(nil, numBins, 0, 1)
+
+	case first == last:
+		return Histogram{
+			Bins:   []float64{first - 0.5, last + 0.5},
+			Values: []int64{int64(count)},
+		}
+
+	case count <= maxLength:
+		values := make([]float64, 0, count)
+		for _, x := range sequence {
+			if isFinite(x) {
+				values = append(values, x)
+			}
+		}
+		return bin(values, numBins, first, last)
+	}
+
+	// reservoir sampling, so that only the sample is held in memory
+	rng := rand.New(rand.NewSource(1))
+	sample := make([]float64, 0, maxLength)
+	var seen int
+	for _, x := range sequence {
+		if !isFinite(x) {
+			continue
+		}
+
+		if len(sample) < maxLength {
+			sample = append(sample, x)
+		} else if j := rng.Intn(seen + 1); j < maxLength {
+			sample[j] = x
+		}
+		seen++
+	}
+
+	histogram := bin(sample, numBins, first, last)
+	scale := float64(count) / float64(len(sample))
+	for i, n := range histogram.Values {
+		histogram.Values[i] = int64(math.Round(float64(n) * scale))
+	}
+	return histogram
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// bin counts the values in numBins equal-width bins from first to last.
+//
+// This follows numpy's algorithm so that values near the edges fall into
+// the same bins.
+func bin(values []float64, numBins int, first, last float64) Histogram {
+	// the edges are computed like numpy.linspace
+	edges := make([]float64, numBins+1)
+	delta := last - first
+	step := delta / float64(numBins)
+	for i := range edges {
+		if step == 0 {
+			edges[i] = float64(i)/float64(numBins)*delta + first
+		} else {
+			edges[i] = float64(i)*step + first
+		}
+	}
+	edges[numBins] = last
+
+	counts := make([]int64, numBins)
+	for _, x := range values {
+		if x < first || x > last {
+			continue
+		}
+
+		i := int((x - first) / delta * float64(numBins))
+		if i == numBins {
+			i--
+		}
+
+		// correct for rounding errors in computing the index
+		if x < edges[i] {
+			i--
+		} else if i != numBins-1 && x >= edges[i+1] {
+			i++
+		}
+
+		counts[i]++
+	}
+
+	return Histogram{Bins: edges, Values: counts}
+}
+
+// BinSequence replaces a history item's raw histogram by its bins and
+// values.
+//
+// Items that are not raw histograms are left unchanged.
+func BinSequence(item *service.HistoryItem) error {
+	valueJSON := item.GetValueJson()
+	if !strings.Contains(valueJSON, `"sequence"`) {
+		return nil
+	}
+
+	value, err := json.Unmarshal([]byte(valueJSON))
+	if err != nil {
+		return nil
+	}
+	raw, ok := value.(map[string]any)
+	if !ok || raw["_type"] != "histogram" {
+		return nil
+	}
+	rawSequence, ok := raw["sequence"].([]any)
+	if !ok {
+		return nil
+	}
+
+	numBins := DefaultNumBins
+	if rawNumBins, ok := raw["num_bins"]; ok {
+		n, ok := toFloat(rawNumBins)
+		if !ok || n != math.Trunc(n) || n < 1 || n > MaxNumBins {
+			return fmt.Errorf(
+				"histogram: num_bins must be an integer from 1 to %d, got %v",
+				MaxNumBins, rawNumBins,
+			)
+		}
+		numBins = int(n)
+	}
+
+	sequence := make([]float64, len(rawSequence))
+	for i, rawX := range rawSequence {
+		x, ok := toFloat(rawX)
+		if !ok {
+			return errors.New("histogram: sequence has a non-numeric value")
+		}
+		sequence[i] = x
+	}
+
+	histogram := Compute(sequence, numBins)
+	binsJSON, err := json.Marshal(histogram.Bins)
+	if err != nil {
+		return fmt.Errorf("histogram: failed to encode bins: %v", err)
+	}
+	valuesJSON, err := json.Marshal(histogram.Values)
+	if err != nil {
+		return fmt.Errorf("histogram: failed to encode values: %v", err)
+	}
+
+	item.ValueJson = fmt.Sprintf(
+		`{"_type":"histogram","bins":%s,"values":%s}`,
+		binsJSON, valuesJSON,
+	)
+	return nil
+}
+
+// toFloat converts a decoded JSON number to a float64.
+func toFloat(value any) (float64, bool) {
+	switch value := value.(type) {
+	case int64:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	case float64:
+		return value, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,27 @@
+package histogram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute_SamplesLongSequences(t *testing.T) {
+	sequence := make([]float64, 10000)
+	for i := range sequence {
+		sequence[i] = float64(i % 100)
+	}
+
+	result := compute(sequence, 10, 500)
+
+	assert.Equal(t, compute(sequence, 10, 500), result, "not reproducible")
+	assert.Equal(t, 0.0, result.Bins[0])
+	assert.Equal(t, 99.0, result.Bins[10])
+	var total int64
+	for _, count := range result.Values {
+		total += count
+		// each bin holds a tenth of the values
+		assert.InDelta(t, 1000, count, 300)
+	}
+	assert.InDelta(t, 10000, total, 10)
+}
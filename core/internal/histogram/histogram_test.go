@@ -0,0 +1,126 @@
+package histogram_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/histogram"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// numpyFixture is a histogram computed by numpy.histogram.
+//
+// The fixtures are generated by testdata/gen_numpy_histograms.py.
+type numpyFixture struct {
+	Name     string    `json:"name"`
+	Sequence []float64 `json:"sequence"`
+	NumBins  int       `json:"num_bins"`
+	Bins     []float64 `json:"bins"`
+	Values   []int64   `json:"values"`
+}
+
+func TestCompute_MatchesNumpy(t *testing.T) {
+	content, err := os.ReadFile("testdata/numpy_histograms.json")
+	require.NoError(t, err)
+	var fixtures []numpyFixture
+	require.NoError(t, json.Unmarshal(content, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			result := histogram.Compute(fixture.Sequence, fixture.NumBins)
+
+			assert.Equal(t, fixture.Bins, result.Bins)
+			assert.Equal(t, fixture.Values, result.Values)
+		})
+	}
+}
+
+func TestCompute_ConstantIsSingleBin(t *testing.T) {
+	result := histogram.Compute([]float64{2.5, 2.5, 2.5}, 64)
+
+	assert.Equal(t,
+		histogram.Histogram{Bins: []float64{2, 3}, Values: []int64{3}},
+		result)
+}
+
+func TestCompute_ExcludesNaNAndInfinity(t *testing.T) {
+	result := histogram.Compute(
+		[]float64{math.NaN(), 0, math.Inf(1), 1, 2, math.Inf(-1), 4},
+		4,
+	)
+
+	assert.Equal(t, histogram.Compute([]float64{0, 1, 2, 4}, 4), result)
+}
+
+func TestCompute_Empty(t *testing.T) {
+	result := histogram.Compute([]float64{math.NaN()}, 2)
+
+	assert.Equal(t,
+		histogram.Histogram{Bins: []float64{0, 0.5, 1}, Values: []int64{0, 0}},
+		result)
+}
+
+func TestBinSequence(t *testing.T) {
+	item := &service.HistoryItem{
+		Key:       "weights",
+		ValueJson: `{"_type": "histogram", "sequence": [0, 1, 2, 3, NaN, 4], "num_bins": 4}`,
+	}
+
+	err := histogram.BinSequence(item)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"_type":"histogram","bins":[0,1,2,3,4],"values":[1,1,1,2]}`,
+		item.ValueJson)
+}
+
+func TestBinSequence_DefaultNumBins(t *testing.T) {
+	item := &service.HistoryItem{
+		Key:       "weights",
+		ValueJson: `{"_type": "histogram", "sequence": [0, 64]}`,
+	}
+
+	require.NoError(t, histogram.BinSequence(item))
+
+	var value struct {
+		Bins   []float64 `json:"bins"`
+		Values []int64   `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(item.ValueJson), &value))
+	assert.Len(t, value.Bins, histogram.DefaultNumBins+1)
+	assert.Len(t, value.Values, histogram.DefaultNumBins)
+}
+
+func TestBinSequence_Invalid(t *testing.T) {
+	for _, valueJSON := range []string{
+		`{"_type": "histogram", "sequence": [1, 2], "num_bins": 0}`,
+		`{"_type": "histogram", "sequence": [1, 2], "num_bins": 1.5}`,
+		`{"_type": "histogram", "sequence": [1, 2], "num_bins": 513}`,
+		`{"_type": "histogram", "sequence": [1, "two"]}`,
+	} {
+		item := &service.HistoryItem{Key: "weights", ValueJson: valueJSON}
+
+		assert.Error(t, histogram.BinSequence(item), valueJSON)
+		assert.Equal(t, valueJSON, item.ValueJson)
+	}
+}
+
+func TestBinSequence_IgnoresOtherValues(t *testing.T) {
+	for _, valueJSON := range []string{
+		`"sequence"`,
+		`{"sequence": [1, 2, 3]}`,
+		`{"_type": "histogram", "bins": [0, 1], "values": [1], "sequence": 1}`,
+		`{"_type": "table", "sequence": [1, 2, 3]}`,
+	} {
+		item := &service.HistoryItem{Key: "x", ValueJson: valueJSON}
+
+		assert.NoError(t, histogram.BinSequence(item), valueJSON)
+		assert.Equal(t, valueJSON, item.ValueJson)
+	}
+}
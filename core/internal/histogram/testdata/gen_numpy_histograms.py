@@ -0,0 +1,52 @@
+"""Generates numpy_histograms.json, the fixtures for histogram_test.go.
+
+Run from this directory with numpy installed:
+
+    python gen_numpy_histograms.py
+"""
+
+import json
+import random
+
+import numpy as np
+
+
+def cases():
+    rng = random.Random(0)
+    tenths = [i / 10 for i in range(31)]
+    return [
+        ("integers", [0, 1, 2, 3, 4], 4),
+        ("unsorted", [1, 2, 1], 3),
+        ("tenths in 3 bins", tenths, 3),
+        ("tenths in 7 bins", tenths, 7),
+        ("edges", [0, 0.25, 0.5, 0.75, 1] * 3, 4),
+        ("thirds", [0, 1 / 3, 2 / 3, 1, 0.1, 0.9], 3),
+        ("gaussian", [rng.gauss(0, 1) for _ in range(500)], 64),
+        ("uniform in 512 bins", [rng.uniform(-1e3, 1e3) for _ in range(2000)], 512),
+        ("single bin", [rng.uniform(0, 1) for _ in range(50)], 1),
+        ("wide range", [1e-300, 1e300, 5, -7.5, -1e299], 10),
+        ("negative", [-rng.expovariate(0.1) for _ in range(300)], 17),
+    ]
+
+
+def main():
+    fixtures = []
+    for name, sequence, num_bins in cases():
+        values, bins = np.histogram(np.array(sequence, dtype=float), bins=num_bins)
+        fixtures.append(
+            {
+                "name": name,
+                "sequence": [float(x) for x in sequence],
+                "num_bins": num_bins,
+                "bins": [float(x) for x in bins],
+                "values": [int(x) for x in values],
+            }
+        )
+
+    with open("numpy_histograms.json", "w") as f:
+        json.dump(fixtures, f, indent=1)
+        f.write("\n")
+
+
+if __name__ == "__main__":
+    main()
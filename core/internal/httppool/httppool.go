@@ -0,0 +1,240 @@
+// Package httppool shares HTTP transports between the clients of a
+// process, so that connections are reused across requests, clients and
+// streams instead of being set up again for each burst of requests.
+package httppool
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Class is a kind of destination, which has its own connection pool.
+type Class string
+
+const (
+	// API is the W&B API host, used for GraphQL and filestream requests.
+	API Class = "api"
+
+	// Storage is the hosts of presigned URLs, used for file transfers.
+	Storage Class = "storage"
+)
+
+const (
+	defaultIdleConnTimeout = 90 * time.Second
+
+	// Requests to the API are few at a time, but file transfers come in
+	// bursts of up to the file transfer manager's concurrency limit.
+	defaultAPIMaxIdleConnsPerHost     = 16
+	defaultStorageMaxIdleConnsPerHost = 128
+)
+
+// Options tunes the connections of a transport.
+//
+// The zero value uses the defaults for the transport's class.
+type Options struct {
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+
+	// MaxIdleConnsPerHost is the number of idle connections kept open per
+	// host.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the connections per host, if not zero.
+	MaxConnsPerHost int
+
+	// DisableHTTP2 makes requests use HTTP/1.1 even if the server
+	// supports HTTP/2.
+	DisableHTTP2 bool
+}
+
+// Pool is a set of shared transports.
+type Pool struct {
+	mu sync.Mutex
+
+	// transports are the shared transports, by class and options
+	transports map[poolKey]*http.Transport
+}
+
+type poolKey struct {
+	class Class
+	opts  Options
+}
+
+// Default is the pool shared by the whole process.
+var Default = New()
+
+func New() *Pool {
+	return &Pool{transports: make(map[poolKey]*http.Transport)}
+}
+
+// Transport returns a transport for the class of destinations, sharing
+// connections with all other transports returned for the same class and
+// options.
+//
+// The connections it makes and reuses are counted in stats, if not nil.
+func (p *Pool) Transport(
+	class Class,
+	opts Options,
+	stats *Stats,
+) http.RoundTripper {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := poolKey{class, opts}
+	transport, ok := p.transports[key]
+	if !ok {
+		transport = newTransport(class, opts)
+		p.transports[key] = transport
+	}
+
+	if stats == nil {
+		return transport
+	}
+	return &countingTransport{
+		delegate: transport,
+		counts:   stats.forClass(class),
+	}
+}
+
+// CloseIdleConnections closes the idle connections of all transports.
+func (p *Pool) CloseIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, transport := range p.transports {
+		transport.CloseIdleConnections()
+	}
+}
+
+// newTransport returns a transport like http.DefaultTransport, tuned by
+// the options.
+func newTransport(class Class, opts Options) *http.Transport {
+	idleConnTimeout := opts.IdleConnTimeout
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = defaultIdleConnTimeout
+	}
+
+	maxIdleConnsPerHost := opts.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost <= 0 {
+		switch class {
+		case Storage:
+			maxIdleConnsPerHost = defaultStorageMaxIdleConnsPerHost
+		default:
+			maxIdleConnsPerHost = defaultAPIMaxIdleConnsPerHost
+		}
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     !opts.DisableHTTP2,
+		IdleConnTimeout:       idleConnTimeout,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		MaxConnsPerHost:       max(opts.MaxConnsPerHost, 0),
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if opts.DisableHTTP2 {
+		// A non-nil empty map disables HTTP/2.
+		transport.TLSNextProto = make(
+			map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return transport
+}
+
+// Stats counts the connections used by some clients.
+type Stats struct {
+	mu     sync.Mutex
+	counts map[Class]*counts
+}
+
+// Counts are the number of requests made on new and reused connections.
+type Counts struct {
+	New    int64
+	Reused int64
+}
+
+// counts is the atomic version of Counts.
+type counts struct {
+	new    atomic.Int64
+	reused atomic.Int64
+
+	// lastSuccess is when a response that wasn't a server error was last
+	// received, in Unix nanoseconds, or zero if none was
+	lastSuccess atomic.Int64
+}
+
+func NewStats() *Stats {
+	return &Stats{counts: make(map[Class]*counts)}
+}
+
+func (s *Stats) forClass(class Class) *counts {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counts[class]
+	if !ok {
+		c = &counts{}
+		s.counts[class] = c
+	}
+	return c
+}
+
+// Snapshot returns the counts so far by class.
+func (s *Stats) Snapshot() map[Class]Counts {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(map[Class]Counts, len(s.counts))
+	for class, c := range s.counts {
+		snapshot[class] = Counts{New: c.new.Load(), Reused: c.reused.Load()}
+	}
+	return snapshot
+}
+
+// LastSuccess returns when a response to the class of destinations that
+// wasn't a server error was last received, or the zero time if none was.
+func (s *Stats) LastSuccess(class Class) time.Time {
+	s.mu.Lock()
+	c, ok := s.counts[class]
+	s.mu.Unlock()
+
+	if !ok || c.lastSuccess.Load() == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, c.lastSuccess.Load())
+}
+
+// countingTransport counts whether each request reuses a connection, and
+// notes when responses are received.
+type countingTransport struct {
+	delegate http.RoundTripper
+	counts   *counts
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				t.counts.reused.Add(1)
+			} else {
+				t.counts.new.Add(1)
+			}
+		},
+	}
+
+	ctx := httptrace.WithClientTrace(req.Context(), trace)
+	resp, err := t.delegate.RoundTrip(req.WithContext(ctx))
+	if err == nil && resp.StatusCode < http.StatusInternalServerError {
+		t.counts.lastSuccess.Store(time.Now().UnixNano())
+	}
+	return resp, err
+}
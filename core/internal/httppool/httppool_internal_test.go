@@ -0,0 +1,42 @@
+package httppool
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_HTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto))
+		}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, tc := range []struct {
+		disable bool
+		proto   string
+	}{{false, "HTTP/2.0"}, {true, "HTTP/1.1"}} {
+		pool := New()
+		transport := pool.Transport(Storage, Options{DisableHTTP2: tc.disable}, nil)
+		// trust the server's certificate, without its client's protocols
+		tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+		tlsConfig.NextProtos = nil
+		transport.(*http.Transport).TLSClientConfig = tlsConfig
+
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, tc.proto, string(body))
+		pool.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,149 @@
+package httppool_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/httppool"
+)
+
+// countingServer is a test server that counts the connections made to it.
+type countingServer struct {
+	*httptest.Server
+	conns atomic.Int64
+}
+
+func newCountingServer() *countingServer {
+	s := &countingServer{}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+	s.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			s.conns.Add(1)
+		}
+	}
+	s.Start()
+	return s
+}
+
+func get(t testing.TB, transport http.RoundTripper, url string) {
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func TestTransport_SharedByClass(t *testing.T) {
+	server := newCountingServer()
+	defer server.Close()
+	pool := httppool.New()
+	defer pool.CloseIdleConnections()
+	stats := httppool.NewStats()
+
+	get(t, pool.Transport(httppool.API, httppool.Options{}, stats), server.URL)
+	get(t, pool.Transport(httppool.API, httppool.Options{}, stats), server.URL)
+	get(t, pool.Transport(httppool.Storage, httppool.Options{}, stats), server.URL)
+
+	assert.EqualValues(t, 2, server.conns.Load())
+	assert.Equal(t,
+		map[httppool.Class]httppool.Counts{
+			httppool.API:     {New: 1, Reused: 1},
+			httppool.Storage: {New: 1},
+		},
+		stats.Snapshot())
+}
+
+func TestTransport_OptionsNotShared(t *testing.T) {
+	server := newCountingServer()
+	defer server.Close()
+	pool := httppool.New()
+	defer pool.CloseIdleConnections()
+
+	get(t, pool.Transport(httppool.API, httppool.Options{}, nil), server.URL)
+	get(t,
+		pool.Transport(httppool.API, httppool.Options{MaxConnsPerHost: 1}, nil),
+		server.URL)
+
+	assert.EqualValues(t, 2, server.conns.Load())
+}
+
+func TestStats_LastSuccessIgnoresServerErrors(t *testing.T) {
+	var status atomic.Int64
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(int(status.Load()))
+		}))
+	defer server.Close()
+	pool := httppool.New()
+	defer pool.CloseIdleConnections()
+	stats := httppool.NewStats()
+	transport := pool.Transport(httppool.API, httppool.Options{}, stats)
+	assert.True(t, stats.LastSuccess(httppool.API).IsZero())
+
+	before := time.Now()
+	get(t, transport, server.URL)
+	succeeded := stats.LastSuccess(httppool.API)
+	status.Store(http.StatusBadGateway)
+	get(t, transport, server.URL)
+
+	assert.False(t, succeeded.Before(before))
+	assert.Equal(t, succeeded, stats.LastSuccess(httppool.API))
+	assert.True(t, stats.LastSuccess(httppool.Storage).IsZero())
+}
+
+// Bursts of small uploads like the file transfer manager makes, counting
+// the connections opened per burst.
+func BenchmarkTransport_UploadBursts(b *testing.B) {
+	const burstSize = 128
+
+	for _, bc := range []struct {
+		name      string
+		transport func() http.RoundTripper
+	}{
+		{"per_client", func() http.RoundTripper {
+			return retryablehttp.NewClient().HTTPClient.Transport
+		}},
+		{"pooled", func() http.RoundTripper {
+			return httppool.New().Transport(httppool.Storage, httppool.Options{}, nil)
+		}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			server := newCountingServer()
+			defer server.Close()
+			client := &http.Client{Transport: bc.transport()}
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				var wg sync.WaitGroup
+				for i := 0; i < burstSize; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						resp, err := client.Post(
+							server.URL, "text/plain", strings.NewReader("content"))
+						if err != nil {
+							b.Error(err)
+							return
+						}
+						_ = resp.Body.Close()
+					}()
+				}
+				wg.Wait()
+			}
+			b.StopTimer()
+
+			b.ReportMetric(float64(server.conns.Load())/float64(b.N), "conns/op")
+			client.CloseIdleConnections()
+		})
+	}
+}
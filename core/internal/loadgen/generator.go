@@ -0,0 +1,135 @@
+// Package loadgen drives a stream with a steady load of the records a
+// training script logs, for benchmarks and soak tests.
+package loadgen
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Mix is the share of each kind of record in a load, as weights.
+type Mix struct {
+	// History is the weight of flushed rows of history.
+	History int
+
+	// Console is the weight of lines of console output.
+	Console int
+
+	// Files is the weight of files saved for upload right away.
+	Files int
+}
+
+// Mixes are the loads that benchmarks and the load generator run, by name.
+var Mixes = map[string]Mix{
+	"history": {History: 90, Console: 5, Files: 5},
+	"console": {History: 5, Console: 90, Files: 5},
+	"files":   {History: 5, Console: 5, Files: 90},
+}
+
+// numFiles is how many files a generator saves over and over.
+const numFiles = 8
+
+// fileSize is the size of each file a generator saves.
+const fileSize = 4 << 10
+
+// Generator makes the records of a load.
+//
+// The kinds of record follow the mix's weights in a fixed pseudo-random
+// order, so that every run of a load is the same.
+type Generator struct {
+	mix   Mix
+	total int
+	rand  *rand.Rand
+	step  int64
+	line  int
+}
+
+// NewGenerator returns a generator of the mix's records, writing the files
+// that its files records save into filesDir.
+func NewGenerator(mix Mix, filesDir string) (*Generator, error) {
+	total := mix.History + mix.Console + mix.Files
+	if mix.History < 0 || mix.Console < 0 || mix.Files < 0 || total == 0 {
+		return nil, fmt.Errorf("loadgen: invalid mix %+v", mix)
+	}
+
+	if mix.Files > 0 {
+		dir := filepath.Join(filesDir, "loadgen")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("loadgen: failed to create files: %v", err)
+		}
+		contents := make([]byte, fileSize)
+		for i := range numFiles {
+			path := filepath.Join(dir, fileName(i))
+			if err := os.WriteFile(path, contents, 0o644); err != nil {
+				return nil, fmt.Errorf("loadgen: failed to create files: %v", err)
+			}
+		}
+	}
+
+	return &Generator{
+		mix:   mix,
+		total: total,
+		rand:  rand.New(rand.NewSource(1)),
+	}, nil
+}
+
+// Next returns the next record of the load.
+func (g *Generator) Next() *service.Record {
+	n := g.rand.Intn(g.total)
+	switch {
+	case n < g.mix.History:
+		return g.history()
+	case n < g.mix.History+g.mix.Console:
+		return g.console()
+	default:
+		return g.file()
+	}
+}
+
+func (g *Generator) history() *service.Record {
+	g.step++
+	return &service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_PartialHistory{
+				PartialHistory: &service.PartialHistoryRequest{
+					Item: []*service.HistoryItem{
+						{Key: "loss", ValueJson: strconv.FormatFloat(1/float64(g.step), 'g', -1, 64)},
+						{Key: "accuracy", ValueJson: strconv.FormatFloat(g.rand.Float64(), 'g', -1, 64)},
+						{Key: "epoch", ValueJson: strconv.FormatInt(g.step/100, 10)},
+					},
+					Action: &service.HistoryAction{Flush: true},
+				},
+			},
+		}},
+	}
+}
+
+func (g *Generator) console() *service.Record {
+	g.line++
+	return &service.Record{
+		RecordType: &service.Record_OutputRaw{OutputRaw: &service.OutputRawRecord{
+			OutputType: service.OutputRawRecord_STDOUT,
+			Line:       fmt.Sprintf("step %d: training, loss is decreasing\n", g.line),
+		}},
+	}
+}
+
+func (g *Generator) file() *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Files{Files: &service.FilesRecord{
+			Files: []*service.FilesItem{{
+				Path:   filepath.Join("loadgen", fileName(g.rand.Intn(numFiles))),
+				Policy: service.FilesItem_NOW,
+			}},
+		}},
+	}
+}
+
+func fileName(i int) string {
+	return fmt.Sprintf("file-%d.bin", i)
+}
@@ -0,0 +1,104 @@
+package loadgen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/loadgen"
+	"github.com/wandb/wandb/core/internal/servertest"
+)
+
+const upsertBucketResponse = `{
+	"upsertBucket": {
+		"bucket": {
+			"id": "storage-id",
+			"displayName": "loadgen-run",
+			"project": {"name": "loadgen", "entity": {"name": "loadgen-entity"}}
+		}
+	}
+}`
+
+func TestGenerator_FollowsMix(t *testing.T) {
+	first, err := loadgen.NewGenerator(loadgen.Mixes["console"], t.TempDir())
+	require.NoError(t, err)
+	second, err := loadgen.NewGenerator(loadgen.Mixes["console"], t.TempDir())
+	require.NoError(t, err)
+
+	counts := make(map[string]int)
+	for range 1000 {
+		record := first.Next()
+		assert.True(t, proto.Equal(record, second.Next()), "the loads differ")
+		switch {
+		case record.GetRequest().GetPartialHistory() != nil:
+			counts["history"]++
+		case record.GetOutputRaw() != nil:
+			counts["console"]++
+		case record.GetFiles() != nil:
+			counts["files"]++
+		}
+	}
+
+	assert.InDelta(t, 900, counts["console"], 50)
+	assert.InDelta(t, 50, counts["history"], 30)
+	assert.InDelta(t, 50, counts["files"], 30)
+}
+
+func TestGenerator_InvalidMix(t *testing.T) {
+	_, err := loadgen.NewGenerator(loadgen.Mix{}, t.TempDir())
+	assert.Error(t, err)
+
+	_, err = loadgen.NewGenerator(loadgen.Mix{History: 1, Files: -1}, t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestSession_SendsLoad(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketResponse)
+	backend.StubCreateRunFiles()
+
+	session, err := loadgen.Start(
+		loadgen.Settings(t.TempDir(), "loadgen", backend.URL(), "test-api-key"),
+		"loadgen",
+	)
+	require.NoError(t, err)
+	generator, err := session.Generator(loadgen.Mixes["history"])
+	require.NoError(t, err)
+	result, err := session.Send(generator, 250)
+	require.NoError(t, err)
+	session.Finish(0)
+
+	assert.Equal(t, 250, result.Records)
+	assert.Positive(t, result.RecordsPerSecond())
+	// a probe after every 100 records, and one at the end
+	assert.Len(t, result.Latencies, 3)
+	p95, err := result.Percentile(0.95)
+	require.NoError(t, err)
+	assert.Positive(t, p95)
+	assert.NotEmpty(t, backend.Requests(servertest.RouteFileStream))
+}
+
+func TestResult_Percentile(t *testing.T) {
+	result := &loadgen.Result{}
+	for i := 10; i > 0; i-- {
+		result.Latencies = append(result.Latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	p95, err := result.Percentile(0.95)
+	require.NoError(t, err)
+	p50, err := result.Percentile(0.5)
+	require.NoError(t, err)
+	p0, err := result.Percentile(0)
+	require.NoError(t, err)
+
+	assert.Equal(t, 10*time.Millisecond, p95)
+	assert.Equal(t, 5*time.Millisecond, p50)
+	assert.Equal(t, time.Millisecond, p0)
+
+	_, err = (&loadgen.Result{}).Percentile(0.95)
+	assert.Error(t, err)
+}
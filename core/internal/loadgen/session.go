@@ -0,0 +1,265 @@
+package loadgen
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// connectionID is the connection a session's results are addressed to.
+const connectionID = "loadgen"
+
+// probeEvery is how many records a session sends between probes.
+const probeEvery = 100
+
+// resultTimeout is how long to wait for the stream to answer a record,
+// which for a real backend includes upserting the run.
+const resultTimeout = time.Minute
+
+// Settings returns the settings of a run that a session drives, whose
+// files and logs are kept in dir.
+func Settings(dir, runID, baseURL, apiKey string) *service.Settings {
+	return &service.Settings{
+		RunId:              &wrapperspb.StringValue{Value: runID},
+		BaseUrl:            &wrapperspb.StringValue{Value: baseURL},
+		ApiKey:             &wrapperspb.StringValue{Value: apiKey},
+		LogDir:             &wrapperspb.StringValue{Value: dir},
+		LogInternal:        &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:           &wrapperspb.StringValue{Value: filepath.Join(dir, "run-"+runID+".wandb")},
+		FilesDir:           &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats:      &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:       &wrapperspb.BoolValue{Value: true},
+		DisableJobCreation: &wrapperspb.BoolValue{Value: true},
+	}
+}
+
+// Session is a run whose stream is sent loads.
+type Session struct {
+	stream   *server.Stream
+	probes   *probes
+	filesDir string
+	sent     int
+}
+
+// Start starts a stream with the settings, and starts its run in the
+// project like a client does.
+func Start(runSettings *service.Settings, project string) (*Session, error) {
+	stream, err := server.NewStream(settings.From(runSettings), "")
+	if err != nil {
+		return nil, err
+	}
+	probes := &probes{
+		sent:    make(map[string]time.Time),
+		results: make(chan *service.Result, 16),
+	}
+	stream.AddResponders(server.ResponderEntry{Responder: probes, ID: connectionID})
+	stream.Start()
+	session := &Session{
+		stream:   stream,
+		probes:   probes,
+		filesDir: runSettings.GetFilesDir().GetValue(),
+	}
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{Run: &service.RunRecord{
+			RunId:   runSettings.GetRunId().GetValue(),
+			Project: project,
+		}},
+		Control: &service.Control{ConnectionId: connectionID, ReqResp: true},
+		Uuid:    "run",
+	})
+	result, err := probes.await("run")
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+	if runErr := result.GetRunResult().GetError(); runErr != nil {
+		stream.Close()
+		return nil, fmt.Errorf("loadgen: failed to start the run: %s", runErr.GetMessage())
+	}
+
+	// the stream may still be using the run it answered with
+	run := proto.Clone(result.GetRunResult().GetRun()).(*service.RunRecord)
+	run.StartTime = timestamppb.Now()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_RunStart{
+				RunStart: &service.RunStartRequest{Run: run},
+			},
+		}},
+	})
+	return session, nil
+}
+
+// Generator returns a generator of the mix for the session's run.
+func (s *Session) Generator(mix Mix) (*Generator, error) {
+	return NewGenerator(mix, s.filesDir)
+}
+
+// Send sends n of the generator's records, and returns once the stream
+// has passed all of them to the sender.
+//
+// A probe is sent after every probeEvery records, and its round trip
+// through the pipeline is a sample of the pipeline's latency.
+func (s *Session) Send(generator *Generator, n int) (*Result, error) {
+	start := time.Now()
+	s.probes.reset()
+	for i := range n {
+		s.stream.HandleRecord(generator.Next())
+		if (i+1)%probeEvery == 0 {
+			s.probe(false)
+		}
+	}
+
+	// the pipeline keeps the order of records, so the last probe is answered
+	// after every record before it is sent
+	uuid := s.probe(true)
+	if _, err := s.probes.await(uuid); err != nil {
+		return nil, err
+	}
+	return &Result{
+		Records:   n,
+		Duration:  time.Since(start),
+		Latencies: s.probes.takeLatencies(),
+	}, nil
+}
+
+// Finish finishes the run with the exit code and closes the stream.
+func (s *Session) Finish(exitCode int32) {
+	s.stream.FinishAndClose(exitCode)
+}
+
+// probe sends a probe through the pipeline and returns its UUID.
+//
+// Only the results of probes that are awaited are passed on.
+func (s *Session) probe(awaited bool) string {
+	s.sent++
+	uuid := "probe-" + strconv.Itoa(s.sent)
+	s.probes.start(uuid, awaited)
+	s.stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_GetSettings{
+				GetSettings: &service.GetSettingsRequest{},
+			},
+		}},
+		Control: &service.Control{ConnectionId: connectionID, ReqResp: true},
+		Uuid:    uuid,
+	})
+	return uuid
+}
+
+// probes times the round trips of probes, and passes on the results that
+// are awaited.
+type probes struct {
+	mu        sync.Mutex
+	sent      map[string]time.Time
+	awaited   string
+	latencies []time.Duration
+
+	results chan *service.Result
+}
+
+func (p *probes) start(uuid string, awaited bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent[uuid] = time.Now()
+	if awaited {
+		p.awaited = uuid
+	}
+}
+
+func (p *probes) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latencies = nil
+}
+
+func (p *probes) takeLatencies() []time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	latencies := p.latencies
+	p.latencies = nil
+	return latencies
+}
+
+func (p *probes) Respond(response *service.ServerResponse) {
+	result := response.GetResultCommunicate()
+	if result == nil {
+		return
+	}
+
+	p.mu.Lock()
+	sent, isProbe := p.sent[result.GetUuid()]
+	if isProbe {
+		delete(p.sent, result.GetUuid())
+		p.latencies = append(p.latencies, time.Since(sent))
+	}
+	awaited := !isProbe || result.GetUuid() == p.awaited
+	p.mu.Unlock()
+
+	if awaited {
+		p.results <- result
+	}
+}
+
+// await returns the result answering the record with the UUID.
+func (p *probes) await(uuid string) (*service.Result, error) {
+	timeout := time.After(resultTimeout)
+	for {
+		select {
+		case result := <-p.results:
+			if result.GetUuid() == uuid {
+				return result, nil
+			}
+		case <-timeout:
+			return nil, fmt.Errorf("loadgen: %s wasn't answered in %v", uuid, resultTimeout)
+		}
+	}
+}
+
+// Result is how the stream kept up with a load.
+type Result struct {
+	// Records is the number of records sent.
+	Records int
+
+	// Duration is the time from sending the first record until the sender
+	// had all of them.
+	Duration time.Duration
+
+	// Latencies are the round trips of the probes through the pipeline.
+	Latencies []time.Duration
+}
+
+// RecordsPerSecond is the rate at which the pipeline took records.
+func (r *Result) RecordsPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Records) / r.Duration.Seconds()
+}
+
+// Percentile returns the latency that the fraction p of the probes were
+// faster than or as fast as, by the nearest rank.
+func (r *Result) Percentile(p float64) (time.Duration, error) {
+	if len(r.Latencies) == 0 {
+		return 0, errors.New("loadgen: no latencies were measured")
+	}
+
+	sorted := slices.Clone(r.Latencies)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1], nil
+}
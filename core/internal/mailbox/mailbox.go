@@ -2,22 +2,75 @@ package mailbox
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+var (
+	// ErrCanceled is returned when waiting on a slot that was canceled.
+	ErrCanceled = errors.New("mailbox: slot canceled")
+
+	// ErrClosed is returned when waiting on a slot of a closed mailbox.
+	ErrClosed = errors.New("mailbox: closed")
 )
 
-// Mailbox is used to process cancel requests from the client.
+// Mailbox correlates the results of requests with the requests.
+//
+// A request whose result is awaited carries the ID of a reserved slot in
+// its Control, and whichever component produces the result tags it with
+// the same ID. The result is then delivered to the slot instead of a
+// client connection.
+//
+// The mailbox is also used to process cancel requests from the client.
 // For example, if the run initialization times out, the client
 // sends a cancel request, which then cancels the context for the run
 // so that all in-flight network requests are cancelled.
 type Mailbox struct {
 	mb map[string]context.CancelFunc
 	*sync.Mutex
+
+	// slots are the reserved slots awaiting results, by ID
+	slots map[string]*Slot
+
+	// closed is whether Close was called
+	closed bool
+
+	// prefix starts the IDs of the mailbox's slots, so that they don't
+	// collide with the slots of clients
+	prefix string
+
+	// nextID numbers the mailbox's slots
+	nextID atomic.Int64
+}
+
+// Slot is a reserved place in a mailbox for the result of a request.
+type Slot struct {
+	id      string
+	mailbox *Mailbox
+
+	// result receives the slot's result, or is closed if the slot is
+	// released first
+	result chan *service.Result
+
+	// done is whether the slot received its result or was released,
+	// guarded by the mailbox's mutex
+	done bool
+
+	// err is why the slot was released, if result is closed
+	err error
 }
 
 func NewMailbox() *Mailbox {
 	return &Mailbox{
-		mb:    make(map[string]context.CancelFunc),
-		Mutex: &sync.Mutex{},
+		mb:     make(map[string]context.CancelFunc),
+		Mutex:  &sync.Mutex{},
+		slots:  make(map[string]*Slot),
+		prefix: fmt.Sprintf("core-%s-", utils.ShortID(8)),
 	}
 }
 
@@ -31,11 +84,138 @@ func (m *Mailbox) Add(ctx context.Context, cancel context.CancelFunc, key string
 	return ctx
 }
 
+// Cancel cancels the context added for the key, and releases the slot
+// with the key as its ID, if any.
 func (m *Mailbox) Cancel(key string) {
-	if cancel, ok := m.mb[key]; ok {
+	m.Lock()
+	cancel, ok := m.mb[key]
+	delete(m.mb, key)
+	slot := m.slots[key]
+	m.Unlock()
+
+	if ok {
 		cancel()
-		m.Lock()
-		delete(m.mb, key)
-		m.Unlock()
 	}
+	if slot != nil {
+		slot.release(ErrCanceled)
+	}
+}
+
+// Remove forgets the context added for the key without canceling it.
+func (m *Mailbox) Remove(key string) {
+	m.Lock()
+	delete(m.mb, key)
+	m.Unlock()
+}
+
+// Reserve reserves a slot with a new ID.
+//
+// The slot is released by waiting on it. If the mailbox is closed, the
+// slot is released immediately.
+func (m *Mailbox) Reserve() *Slot {
+	slot := &Slot{
+		id:      fmt.Sprintf("%s%d", m.prefix, m.nextID.Add(1)),
+		mailbox: m,
+		result:  make(chan *service.Result, 1),
+	}
+
+	m.Lock()
+	closed := m.closed
+	if !closed {
+		m.slots[slot.id] = slot
+	}
+	m.Unlock()
+
+	if closed {
+		slot.done = true
+		slot.err = ErrClosed
+		close(slot.result)
+	}
+	return slot
+}
+
+// Deliver delivers the result to the slot it's tagged with and releases
+// the slot.
+//
+// It returns false if no slot with that ID is reserved, in which case the
+// result is for someone else.
+func (m *Mailbox) Deliver(result *service.Result) bool {
+	id := result.GetControl().GetMailboxSlot()
+	if id == "" {
+		return false
+	}
+
+	m.Lock()
+	slot, ok := m.slots[id]
+	if ok {
+		delete(m.slots, id)
+		slot.done = true
+	}
+	m.Unlock()
+
+	if !ok {
+		return false
+	}
+	slot.result <- result
+	return true
+}
+
+// Close releases all slots and makes new ones release immediately.
+func (m *Mailbox) Close() {
+	m.Lock()
+	m.closed = true
+	slots := make([]*Slot, 0, len(m.slots))
+	for _, slot := range m.slots {
+		slots = append(slots, slot)
+	}
+	m.Unlock()
+
+	for _, slot := range slots {
+		slot.release(ErrClosed)
+	}
+}
+
+// ID is the slot's ID, to set as the MailboxSlot of the request's Control.
+func (s *Slot) ID() string {
+	return s.id
+}
+
+// Wait returns the slot's result once it's delivered.
+//
+// It returns an error if the slot is canceled, the mailbox is closed or
+// the context is done first. Either way, the slot is released, and a
+// result delivered later is dropped.
+func (s *Slot) Wait(ctx context.Context) (*service.Result, error) {
+	select {
+	case result, ok := <-s.result:
+		if !ok {
+			return nil, s.err
+		}
+		return result, nil
+	case <-ctx.Done():
+	}
+
+	// The result may have been delivered just before the slot was released.
+	s.release(ctx.Err())
+	result, ok := <-s.result
+	if !ok {
+		return nil, s.err
+	}
+	return result, nil
+}
+
+// release removes the slot from the mailbox with the error, unless it
+// already received its result or was released.
+func (s *Slot) release(err error) {
+	s.mailbox.Lock()
+	if s.done {
+		s.mailbox.Unlock()
+		return
+	}
+	s.done = true
+	s.err = err
+	delete(s.mailbox.slots, s.id)
+	s.mailbox.Unlock()
+
+	close(s.result)
 }
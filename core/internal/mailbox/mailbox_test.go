@@ -0,0 +1,159 @@
+package mailbox_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func resultFor(slot string, uuid string) *service.Result {
+	return &service.Result{
+		Control: &service.Control{MailboxSlot: slot},
+		Uuid:    uuid,
+	}
+}
+
+func TestReserve_UniqueIDs(t *testing.T) {
+	mb := mailbox.NewMailbox()
+
+	ids := make(map[string]struct{})
+	for range 100 {
+		ids[mb.Reserve().ID()] = struct{}{}
+	}
+
+	assert.Len(t, ids, 100)
+	assert.NotEqual(t,
+		mb.Reserve().ID(),
+		mailbox.NewMailbox().Reserve().ID())
+}
+
+func TestDeliver(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	slot := mb.Reserve()
+
+	assert.True(t, mb.Deliver(resultFor(slot.ID(), "first")))
+	assert.False(t, mb.Deliver(resultFor(slot.ID(), "second")))
+	result, err := slot.Wait(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "first", result.GetUuid())
+}
+
+func TestDeliver_UnknownSlot(t *testing.T) {
+	mb := mailbox.NewMailbox()
+
+	assert.False(t, mb.Deliver(resultFor("", "no-slot")))
+	assert.False(t, mb.Deliver(resultFor("client-slot", "not-reserved")))
+}
+
+func TestWait_WaitsForDelivery(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	slot := mb.Reserve()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var result *service.Result
+	var err error
+	go func() {
+		defer wg.Done()
+		result, err = slot.Wait(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	mb.Deliver(resultFor(slot.ID(), "late"))
+	wg.Wait()
+
+	require.NoError(t, err)
+	assert.Equal(t, "late", result.GetUuid())
+}
+
+func TestWait_TimeoutReleasesSlot(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	slot := mb.Reserve()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	result, err := slot.Wait(ctx)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, mb.Deliver(resultFor(slot.ID(), "too-late")))
+}
+
+func TestCancel_ReleasesSlot(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	slot := mb.Reserve()
+
+	mb.Cancel(slot.ID())
+	result, err := slot.Wait(context.Background())
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, mailbox.ErrCanceled)
+	assert.False(t, mb.Deliver(resultFor(slot.ID(), "too-late")))
+}
+
+func TestCancel_CancelsContext(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	ctx := mb.Add(context.Background(), nil, "client-slot")
+
+	mb.Cancel("client-slot")
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestRemove_ForgetsContext(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	ctx := mb.Add(context.Background(), nil, "client-slot")
+
+	mb.Remove("client-slot")
+	mb.Cancel("client-slot")
+
+	assert.NoError(t, ctx.Err())
+}
+
+func TestClose_ReleasesSlots(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	slot := mb.Reserve()
+
+	mb.Close()
+	_, err := slot.Wait(context.Background())
+	_, errAfterClose := mb.Reserve().Wait(context.Background())
+
+	assert.ErrorIs(t, err, mailbox.ErrClosed)
+	assert.ErrorIs(t, errAfterClose, mailbox.ErrClosed)
+	assert.False(t, mb.Deliver(resultFor(slot.ID(), "too-late")))
+}
+
+func TestDeliver_RacesWithTimeout(t *testing.T) {
+	mb := mailbox.NewMailbox()
+
+	for range 100 {
+		slot := mb.Reserve()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var delivered bool
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivered = mb.Deliver(resultFor(slot.ID(), "racing"))
+		}()
+		cancel()
+		result, err := slot.Wait(ctx)
+		wg.Wait()
+
+		// Either the result arrives or the slot times out, never both.
+		if delivered {
+			require.NoError(t, err)
+			assert.Equal(t, "racing", result.GetUuid())
+		} else {
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+	}
+}
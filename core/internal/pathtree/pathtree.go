@@ -2,6 +2,7 @@ package pathtree
 
 import (
 	"fmt"
+	"slices"
 )
 
 // TreeData is an internal representation for a nested key-value pair.
@@ -69,6 +70,41 @@ func (pt *PathTree) ApplyUpdate(
 	}
 }
 
+// Sets values in the tree, replacing any value in the way.
+//
+// Unlike ApplyUpdate, a leaf in place of a map that a path goes through
+// is replaced by a map. It returns the paths at which a leaf replaced
+// a map or a map replaced a leaf.
+func (pt *PathTree) ApplyUpdateReplacing(items []*PathItem) []TreePath {
+	var replaced []TreePath
+
+	for _, item := range items {
+		tree := pt.tree
+		for i, key := range item.Path[:len(item.Path)-1] {
+			node, exists := tree[key]
+			subtree, ok := node.(TreeData)
+			if !ok || IsTypedValue(subtree) {
+				if exists {
+					replaced = append(replaced, slices.Clone(item.Path[:i+1]))
+				}
+				subtree = make(TreeData)
+				tree[key] = subtree
+			}
+			tree = subtree
+		}
+
+		key := item.Path[len(item.Path)-1]
+		if old, ok := tree[key].(TreeData); ok && !IsTypedValue(old) {
+			if value, ok := item.Value.(TreeData); !ok || IsTypedValue(value) {
+				replaced = append(replaced, slices.Clone(item.Path))
+			}
+		}
+		tree[key] = item.Value
+	}
+
+	return replaced
+}
+
 // Removes values from the tree.
 func (pt *PathTree) ApplyRemove(
 	items []*PathItem,
@@ -89,6 +125,26 @@ func (pt *PathTree) removeAtPath(path TreePath) {
 	}
 }
 
+// Removes values from the tree, and then any maps left empty by their
+// removal.
+func (pt *PathTree) ApplyRemoveAndPrune(
+	items []*PathItem,
+) {
+	for _, item := range items {
+		pt.removeAtPath(item.Path)
+
+		prefix := item.Path[:len(item.Path)-1]
+		for len(prefix) > 0 {
+			subtree := getSubtree(pt.tree, prefix)
+			if subtree == nil || len(subtree) > 0 {
+				break
+			}
+			pt.removeAtPath(prefix)
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+}
+
 // Uses the given subtree for keys that aren't already set.
 func (pt *PathTree) AddUnsetKeysFromSubtree(
 	tree TreeData,
@@ -126,12 +182,18 @@ func (pt *PathTree) Flatten() []PathItem {
 // The order of the leaves is determined by the order of the tree traversal.
 // The tree traversal is depth-first but based on a map, so the order is not
 // guaranteed.
+//
+// Typed values, like histograms, are leaves even though they are maps.
 func flatten(tree TreeData, prefix []string) []PathItem {
 	var leaves []PathItem
 	for key, value := range tree {
 		switch value := value.(type) {
 		case TreeData:
-			leaves = append(leaves, flatten(value, append(prefix, key))...)
+			if IsTypedValue(value) {
+				leaves = append(leaves, PathItem{append(prefix, key), value})
+			} else {
+				leaves = append(leaves, flatten(value, append(prefix, key))...)
+			}
 		default:
 			leaves = append(leaves, PathItem{append(prefix, key), value})
 		}
@@ -139,6 +201,16 @@ func flatten(tree TreeData, prefix []string) []PathItem {
 	return leaves
 }
 
+// IsTypedValue returns whether the map is a W&B data type, such as
+// a histogram, rather than a subtree.
+//
+// Data types are marked by a string "_type" key, and their fields only
+// make sense together.
+func IsTypedValue(tree TreeData) bool {
+	_, ok := tree["_type"].(string)
+	return ok
+}
+
 // Sets the value at the path in the config tree.
 func updateAtPath(
 	tree TreeData,
@@ -106,3 +106,79 @@ func TestFlattenEmptyTree(t *testing.T) {
 		t.Errorf("Expected no items, got %d", len(items))
 	}
 }
+
+// TestFlattenTypedValue checks that typed values are not split up.
+func TestFlattenTypedValue(t *testing.T) {
+
+	histogram := map[string]interface{}{
+		"_type":  "histogram",
+		"bins":   []interface{}{0.0, 0.5, 1.0},
+		"values": []interface{}{3, 4},
+	}
+	pt := pathtree.NewFrom(pathtree.TreeData{
+		"train": map[string]interface{}{"weights": histogram},
+	})
+
+	leaves := pt.Flatten()
+
+	expectedLeaves := []pathtree.PathItem{
+		{Path: []string{"train", "weights"}, Value: histogram},
+	}
+	if !reflect.DeepEqual(leaves, expectedLeaves) {
+		t.Errorf("Expected %v, got %v", expectedLeaves, leaves)
+	}
+}
+
+func TestApplyUpdateReplacing(t *testing.T) {
+
+	pt := pathtree.NewFrom(pathtree.TreeData{
+		"leaf":  1,
+		"tree":  pathtree.TreeData{"a": 1},
+		"other": pathtree.TreeData{"a": 1},
+	})
+
+	replaced := pt.ApplyUpdateReplacing([]*pathtree.PathItem{
+		{Path: []string{"leaf", "nested"}, Value: 2},
+		{Path: []string{"tree"}, Value: 3},
+		{Path: []string{"other", "b"}, Value: 4},
+		{Path: []string{"new", "deep", "key"}, Value: 5},
+	})
+
+	expectedTree := pathtree.TreeData{
+		"leaf":  pathtree.TreeData{"nested": 2},
+		"tree":  3,
+		"other": pathtree.TreeData{"a": 1, "b": 4},
+		"new":   pathtree.TreeData{"deep": pathtree.TreeData{"key": 5}},
+	}
+	if !reflect.DeepEqual(pt.Tree(), expectedTree) {
+		t.Errorf("Expected %v, got %v", expectedTree, pt.Tree())
+	}
+	expectedReplaced := []pathtree.TreePath{{"leaf"}, {"tree"}}
+	if !reflect.DeepEqual(replaced, expectedReplaced) {
+		t.Errorf("Expected %v, got %v", expectedReplaced, replaced)
+	}
+}
+
+func TestApplyRemoveAndPrune(t *testing.T) {
+
+	pt := pathtree.NewFrom(pathtree.TreeData{
+		"a": pathtree.TreeData{
+			"b": pathtree.TreeData{"c": 1},
+			"d": 2,
+		},
+		"e": pathtree.TreeData{"f": pathtree.TreeData{"g": 3}},
+	})
+
+	pt.ApplyRemoveAndPrune([]*pathtree.PathItem{
+		{Path: []string{"a", "b", "c"}},
+		{Path: []string{"e", "f", "g"}},
+		{Path: []string{"missing", "key"}},
+	})
+
+	expectedTree := pathtree.TreeData{
+		"a": pathtree.TreeData{"d": 2},
+	}
+	if !reflect.DeepEqual(pt.Tree(), expectedTree) {
+		t.Errorf("Expected %v, got %v", expectedTree, pt.Tree())
+	}
+}
@@ -0,0 +1,318 @@
+// Package recordsink mirrors a run's records to an external HTTP endpoint.
+//
+// Records are posted as JSON in batches, alongside the normal pipeline
+// and isolated from it: records are only ever queued, and are dropped
+// instead when the queue is full or the endpoint keeps failing.
+package recordsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/clients"
+	"github.com/wandb/wandb/core/internal/redact"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultQueueSize     = 1000
+	defaultRetryMax      = 3
+	defaultRetryWaitMin  = 500 * time.Millisecond
+	defaultRetryWaitMax  = 5 * time.Second
+	requestTimeout       = 10 * time.Second
+
+	// closeTimeout is the longest Close waits for queued records to be
+	// sent, so that a slow sink never holds up the end of a run for long.
+	closeTimeout = 5 * time.Second
+)
+
+// neverMirrored are the record types that aren't mirrored whatever the
+// filter, because they're internal to the run.
+var neverMirrored = map[string]bool{
+	"request":    true,
+	"checkpoint": true,
+}
+
+// Params configure a Sink.
+type Params struct {
+	// URL is the endpoint to post records to.
+	URL string
+
+	// RecordTypes are the names of the record types to mirror, like
+	// "history" or "exit", or empty to mirror all of them.
+	RecordTypes []string
+
+	Logger *observability.CoreLogger
+
+	// The rest are optional, for tests.
+	BatchSize     int
+	FlushInterval time.Duration
+	QueueSize     int
+	RetryMax      int
+	RetryWaitMin  time.Duration
+	RetryWaitMax  time.Duration
+}
+
+// Sink posts records to an external endpoint.
+//
+// The methods of a nil Sink do nothing.
+type Sink struct {
+	url    string
+	types  map[string]bool
+	logger *observability.CoreLogger
+	client *retryablehttp.Client
+
+	batchSize     int
+	flushInterval time.Duration
+
+	// queue holds the records waiting to be posted
+	queue chan *service.Record
+
+	// closeMu guards closing the queue
+	closeMu sync.RWMutex
+	closed  bool
+
+	// ctx is cancelled to abandon requests once Close times out
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// failing is whether the last batch failed, to log only the first
+	// failure of an outage
+	failing bool
+
+	sent    atomic.Int64
+	dropped atomic.Int64
+}
+
+// New starts a sink, or returns nil if the URL is empty.
+func New(params Params) *Sink {
+	if params.URL == "" {
+		return nil
+	}
+
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	client.RetryMax = valueOr(params.RetryMax, defaultRetryMax)
+	client.RetryWaitMin = valueOr(params.RetryWaitMin, defaultRetryWaitMin)
+	client.RetryWaitMax = valueOr(params.RetryWaitMax, defaultRetryWaitMax)
+	client.Backoff = clients.ExponentialBackoffWithJitter
+	client.HTTPClient.Timeout = requestTimeout
+
+	var types map[string]bool
+	if len(params.RecordTypes) > 0 {
+		types = make(map[string]bool, len(params.RecordTypes))
+		for _, name := range params.RecordTypes {
+			types[name] = true
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Sink{
+		url:           params.URL,
+		types:         types,
+		logger:        params.Logger,
+		client:        client,
+		batchSize:     valueOr(params.BatchSize, defaultBatchSize),
+		flushInterval: valueOr(params.FlushInterval, defaultFlushInterval),
+		queue:         make(chan *service.Record, valueOr(params.QueueSize, defaultQueueSize)),
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+func valueOr[T int | time.Duration](value, fallback T) T {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// Mirror queues the record to be posted, if its type is mirrored.
+//
+// It never blocks: if the queue is full, the record is dropped.
+func (s *Sink) Mirror(record *service.Record) {
+	if s == nil {
+		return
+	}
+
+	name := recordType(record)
+	if name == "" || neverMirrored[name] || (s.types != nil && !s.types[name]) {
+		return
+	}
+
+	// a run's settings may hold secrets, and aren't needed by the sink
+	mirrored := proto.Clone(record).(*service.Record)
+	mirrored.Control = nil
+	if run := mirrored.GetRun(); run != nil {
+		run.Settings = nil
+	}
+	mirrored = redact.Proto(mirrored)
+
+	s.closeMu.RLock()
+	defer s.closeMu.RUnlock()
+	if s.closed {
+		s.dropped.Add(1)
+		return
+	}
+
+	select {
+	case s.queue <- mirrored:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Close posts the queued records and stops the sink.
+//
+// Records still queued after a few seconds are dropped.
+func (s *Sink) Close() {
+	if s == nil {
+		return
+	}
+
+	s.closeMu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.closeMu.Unlock()
+
+	select {
+	case <-s.done:
+	case <-time.After(closeTimeout):
+		s.cancel()
+		<-s.done
+	}
+	s.cancel()
+
+	s.logger.Info(
+		"recordsink: closed",
+		"sent", s.sent.Load(),
+		"dropped", s.dropped.Load(),
+	)
+}
+
+// Sent is the number of records posted to the sink.
+func (s *Sink) Sent() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.sent.Load()
+}
+
+// Dropped is the number of records that weren't posted, because the sink
+// failed or fell behind.
+func (s *Sink) Dropped() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.dropped.Load()
+}
+
+// loop posts queued records in batches, until the queue is closed.
+func (s *Sink) loop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*service.Record, 0, s.batchSize)
+	for {
+		select {
+		case record, ok := <-s.queue:
+			if !ok {
+				s.post(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= s.batchSize {
+				s.post(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			s.post(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// post sends the batch to the sink, dropping it if that fails.
+func (s *Sink) post(batch []*service.Record) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := s.postBatch(batch); err != nil {
+		s.dropped.Add(int64(len(batch)))
+		if !s.failing {
+			s.logger.Warn("recordsink: dropping records", "error", err)
+		}
+		s.failing = true
+		return
+	}
+
+	if s.failing {
+		s.logger.Info("recordsink: recovered", "dropped", s.dropped.Load())
+	}
+	s.failing = false
+	s.sent.Add(int64(len(batch)))
+}
+
+func (s *Sink) postBatch(batch []*service.Record) error {
+	records := make([]json.RawMessage, 0, len(batch))
+	for _, record := range batch {
+		data, err := protojson.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("recordsink: can't encode a record: %v", err)
+		}
+		records = append(records, data)
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(
+		s.ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("recordsink: the sink responded %s", resp.Status)
+	}
+	return nil
+}
+
+// recordType is the name of the record's type, like "history".
+func recordType(record *service.Record) string {
+	m := record.ProtoReflect()
+	field := m.WhichOneof(m.Descriptor().Oneofs().ByName("record_type"))
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
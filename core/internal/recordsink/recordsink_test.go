@@ -0,0 +1,198 @@
+package recordsink_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/wandb/wandb/core/internal/recordsink"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func newSink(
+	backend *servertest.FakeBackend,
+	types []string,
+) *recordsink.Sink {
+	return recordsink.New(recordsink.Params{
+		URL:           backend.SinkURL(),
+		RecordTypes:   types,
+		Logger:        observability.NewNoOpLogger(),
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		RetryMax:      1,
+		RetryWaitMin:  time.Millisecond,
+		RetryWaitMax:  time.Millisecond,
+	})
+}
+
+func historyRecord(step int64) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_History{
+			History: &service.HistoryRecord{
+				Step: &service.HistoryStep{Num: step},
+			},
+		},
+	}
+}
+
+// received decodes the records posted to the backend's sink.
+func received(t *testing.T, backend *servertest.FakeBackend) [][]*service.Record {
+	var batches [][]*service.Record
+	for _, request := range backend.Requests(servertest.RouteSink) {
+		var body struct {
+			Records []json.RawMessage `json:"records"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+
+		var batch []*service.Record
+		for _, data := range body.Records {
+			record := &service.Record{}
+			require.NoError(t, protojson.Unmarshal(data, record))
+			batch = append(batch, record)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func TestSink_PostsBatches(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	sink := newSink(backend, nil)
+
+	for i := range 25 {
+		sink.Mirror(historyRecord(int64(i)))
+	}
+	sink.Close()
+
+	batches := received(t, backend)
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 10)
+	assert.Len(t, batches[2], 5)
+	assert.EqualValues(t, 24, batches[2][4].GetHistory().GetStep().GetNum())
+	assert.EqualValues(t, 25, sink.Sent())
+	assert.Zero(t, sink.Dropped())
+}
+
+func TestSink_FiltersRecordTypes(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	sink := newSink(backend, []string{"exit", "alert"})
+
+	sink.Mirror(historyRecord(0))
+	sink.Mirror(&service.Record{
+		RecordType: &service.Record_Alert{
+			Alert: &service.AlertRecord{Title: "loss is NaN"},
+		},
+	})
+	sink.Mirror(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+	})
+	sink.Close()
+
+	batches := received(t, backend)
+	require.Len(t, batches, 1)
+	require.Len(t, batches[0], 2)
+	assert.Equal(t, "loss is NaN", batches[0][0].GetAlert().GetTitle())
+	assert.NotNil(t, batches[0][1].GetExit())
+}
+
+func TestSink_ExcludesSecrets(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	sink := newSink(backend, []string{"run", "request"})
+
+	sink.Mirror(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				RunId: "run",
+				Settings: &service.SettingsRecord{
+					Item: []*service.SettingsItem{
+						{Key: "api_key", ValueJson: `"secret-api-key"`},
+					},
+				},
+			},
+		},
+	})
+	sink.Mirror(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{}},
+	})
+	sink.Close()
+
+	requests := backend.Requests(servertest.RouteSink)
+	require.Len(t, requests, 1)
+	assert.NotContains(t, string(requests[0].Body), "secret-api-key")
+
+	batches := received(t, backend)
+	require.Len(t, batches[0], 1)
+	assert.Equal(t, "run", batches[0][0].GetRun().GetRunId())
+	assert.Nil(t, batches[0][0].GetRun().GetSettings())
+}
+
+func TestSink_DropsRecordsWhenDown(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	for range 2 {
+		backend.InjectFault(servertest.RouteSink,
+			servertest.Fault{StatusCode: http.StatusInternalServerError})
+	}
+	sink := newSink(backend, nil)
+
+	for i := range 10 {
+		sink.Mirror(historyRecord(int64(i)))
+	}
+	for i := range 3 {
+		sink.Mirror(historyRecord(int64(10 + i)))
+	}
+	sink.Close()
+
+	// The first batch failed on both attempts; the rest made it.
+	assert.EqualValues(t, 10, sink.Dropped())
+	assert.EqualValues(t, 3, sink.Sent())
+	assert.Len(t, backend.Requests(servertest.RouteSink), 3)
+}
+
+func TestSink_MirrorNeverBlocks(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.SetUnreachable(true)
+	sink := recordsink.New(recordsink.Params{
+		URL:           backend.SinkURL(),
+		Logger:        observability.NewNoOpLogger(),
+		BatchSize:     1,
+		QueueSize:     5,
+		FlushInterval: time.Hour,
+		RetryMax:      1,
+		RetryWaitMin:  time.Second,
+		RetryWaitMax:  time.Second,
+	})
+
+	start := time.Now()
+	for i := range 100 {
+		sink.Mirror(historyRecord(int64(i)))
+	}
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.GreaterOrEqual(t, sink.Dropped(), int64(90))
+	backend.SetUnreachable(false)
+	sink.Close()
+	assert.EqualValues(t, 100, sink.Sent()+sink.Dropped())
+}
+
+func TestSink_NoURL(t *testing.T) {
+	sink := recordsink.New(recordsink.Params{})
+
+	sink.Mirror(historyRecord(0))
+	sink.Close()
+
+	assert.Nil(t, sink)
+	assert.Zero(t, sink.Sent())
+	assert.Zero(t, sink.Dropped())
+}
@@ -0,0 +1,257 @@
+// Package redact removes secrets like API keys from data that is logged,
+// stored or reported.
+package redact
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Value is what secrets are replaced with in logs and reports.
+const Value = "***"
+
+// secretFields are the names of proto fields whose strings are secret.
+//
+// Every string in such a field is redacted, including the values of
+// maps and wrapper messages.
+var secretFields = map[protoreflect.Name]bool{
+	"api_key":                         true,
+	"_mirror_api_key":                 true,
+	"_extra_http_headers":             true,
+	"azure_account_url_to_access_key": true,
+}
+
+// urlFields are the names of proto fields holding URLs that may embed
+// credentials.
+//
+// Only the user info of these URLs is redacted.
+var urlFields = map[protoreflect.Name]bool{
+	"_proxies":         true,
+	"_record_sink_url": true,
+	"_mirror_base_url": true,
+}
+
+// secretHeaders are the HTTP headers whose values are secret.
+var secretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Proto returns a copy of the message with its secrets redacted.
+//
+// The message itself is returned if it contains no secrets.
+func Proto[M proto.Message](msg M) M {
+	m := msg.ProtoReflect()
+	if !m.IsValid() || !redactMessage(m, false) {
+		return msg
+	}
+
+	redacted := proto.Clone(msg).(M)
+	redactMessage(redacted.ProtoReflect(), true)
+	return redacted
+}
+
+// Headers returns a copy of the header with its secrets redacted.
+func Headers(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range secretHeaders {
+		if len(redacted.Values(key)) > 0 {
+			redacted.Set(key, Value)
+		}
+	}
+	return redacted
+}
+
+// Attr redacts secrets in proto messages and HTTP headers that are
+// logged.
+//
+// It is meant to be used as the ReplaceAttr option of a slog handler.
+func Attr(_ []string, attr slog.Attr) slog.Attr {
+	attr.Value = SlogValue(attr.Value)
+	return attr
+}
+
+// SlogValue redacts secrets in a proto message or HTTP header held by the
+// value.
+//
+// The oneof wrappers of messages, like the RecordType of a record, are
+// redacted too, since they are often logged in place of the message.
+func SlogValue(value slog.Value) slog.Value {
+	if value.Kind() != slog.KindAny {
+		return value
+	}
+
+	switch x := value.Any().(type) {
+	case proto.Message:
+		return slog.AnyValue(Proto(x))
+	case http.Header:
+		return slog.AnyValue(Headers(x))
+	default:
+		return slog.AnyValue(oneof(x))
+	}
+}
+
+// oneof redacts the message held by a oneof wrapper, like a
+// *service.Record_Request.
+//
+// Anything that isn't a oneof wrapper is returned as is.
+func oneof(x any) any {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Pointer || v.IsNil() ||
+		v.Elem().Kind() != reflect.Struct || v.Elem().NumField() != 1 ||
+		!v.Elem().Type().Field(0).IsExported() {
+		return x
+	}
+
+	msg, ok := v.Elem().Field(0).Interface().(proto.Message)
+	if !ok || reflect.ValueOf(msg).IsNil() {
+		return x
+	}
+
+	redacted := Proto(msg)
+	if redacted == msg {
+		return x
+	}
+
+	wrapper := reflect.New(v.Elem().Type())
+	wrapper.Elem().Field(0).Set(reflect.ValueOf(redacted))
+	return wrapper.Interface()
+}
+
+// redactMessage reports whether the message contains secrets, redacting
+// them if apply is true.
+func redactMessage(m protoreflect.Message, apply bool) bool {
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case secretFields[fd.Name()]:
+			found = redactField(m, fd, v, redactString, apply) || found
+		case urlFields[fd.Name()]:
+			found = redactField(m, fd, v, redactURL, apply) || found
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				found = redactMessage(list.Get(i).Message(), apply) || found
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				found = redactMessage(v.Message(), apply) || found
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			found = redactMessage(v.Message(), apply) || found
+		}
+		return true
+	})
+	return found
+}
+
+// redactField redacts every string in the field, reporting whether any
+// of them is secret.
+func redactField(
+	m protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+	v protoreflect.Value,
+	redact func(string) string,
+	apply bool,
+) bool {
+	found := false
+
+	// replace returns the redacted string and whether it should be set
+	replace := func(s string) (protoreflect.Value, bool) {
+		r := redact(s)
+		if r == s {
+			return protoreflect.Value{}, false
+		}
+		found = true
+		return protoreflect.ValueOfString(r), apply
+	}
+
+	switch {
+	case fd.IsList():
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			switch {
+			case fd.Kind() == protoreflect.StringKind:
+				if r, ok := replace(list.Get(i).String()); ok {
+					list.Set(i, r)
+				}
+			case fd.Message() != nil:
+				found = redactStrings(list.Get(i).Message(), redact, apply) || found
+			}
+		}
+	case fd.IsMap():
+		// the map isn't modified while iterating over it
+		var keys []protoreflect.MapKey
+		var values []protoreflect.Value
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			switch {
+			case fd.MapValue().Kind() == protoreflect.StringKind:
+				if r, ok := replace(mv.String()); ok {
+					keys = append(keys, k)
+					values = append(values, r)
+				}
+			case fd.MapValue().Message() != nil:
+				found = redactStrings(mv.Message(), redact, apply) || found
+			}
+			return true
+		})
+		for i, k := range keys {
+			v.Map().Set(k, values[i])
+		}
+	case fd.Kind() == protoreflect.StringKind:
+		if r, ok := replace(v.String()); ok {
+			m.Set(fd, r)
+		}
+	case fd.Message() != nil:
+		found = redactStrings(v.Message(), redact, apply) || found
+	}
+
+	return found
+}
+
+// redactStrings redacts every string in the message, reporting whether
+// any of them is secret.
+func redactStrings(
+	m protoreflect.Message,
+	redact func(string) string,
+	apply bool,
+) bool {
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		found = redactField(m, fd, v, redact, apply) || found
+		return true
+	})
+	return found
+}
+
+// redactString redacts a secret string, leaving empty strings alone.
+func redactString(s string) string {
+	if s == "" {
+		return s
+	}
+	return Value
+}
+
+// redactURL redacts the user info of a URL.
+//
+// Strings that aren't URLs are redacted entirely, since they may hold
+// credentials in some other form.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return redactString(s)
+	}
+	if u.User == nil {
+		return s
+	}
+	u.User = url.User(Value)
+	return u.String()
+}
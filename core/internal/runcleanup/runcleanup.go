@@ -0,0 +1,227 @@
+// Package runcleanup deletes old run directories from a wandb directory.
+//
+// Only directories of finished runs whose transaction log fully reached
+// the server are deleted, so that nothing is lost that `wandb sync` could
+// still upload. Runs are found through the wandb directory's registry
+// (see package runregistry).
+package runcleanup
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// Why a run directory was deleted.
+const (
+	ReasonAge  = "age"
+	ReasonSize = "size"
+)
+
+// Policy is which run directories to delete.
+type Policy struct {
+	// MaxAge is how long after a run finished its directory is deleted, or
+	// 0 to delete directories only by size.
+	MaxAge time.Duration
+
+	// MaxTotalBytes is the most bytes of run directories to keep, deleting
+	// the oldest first, or 0 for no limit.
+	MaxTotalBytes int64
+
+	// DryRun is whether to only report what would be deleted.
+	DryRun bool
+}
+
+// Enabled is whether the policy deletes anything.
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxTotalBytes > 0
+}
+
+// Removal is a run directory that was deleted, or would be in a dry run.
+type Removal struct {
+	RunID  string
+	Path   string
+	Bytes  int64
+	Reason string
+}
+
+// Report is what a cleanup deleted.
+type Report struct {
+	Removed        []Removal
+	ReclaimedBytes int64
+	DryRun         bool
+}
+
+// Summary describes the report for the user.
+func (r *Report) Summary() string {
+	verb := "Deleted"
+	if r.DryRun {
+		verb = "Would delete"
+	}
+	return fmt.Sprintf(
+		"%s %d old run directories, reclaiming %d bytes.",
+		verb, len(r.Removed), r.ReclaimedBytes,
+	)
+}
+
+// candidate is a run directory that may be deleted.
+type candidate struct {
+	entry runregistry.Entry
+	bytes int64
+}
+
+// Run deletes the run directories in the wandb directory that the policy
+// selects.
+//
+// Directories that can't be deleted are skipped, and the errors returned
+// along with the report of the rest.
+func Run(wandbDir string, policy Policy, now time.Time) (*Report, error) {
+	report := &Report{DryRun: policy.DryRun}
+	if !policy.Enabled() {
+		return report, nil
+	}
+
+	registry := runregistry.New(wandbDir)
+	runs, err := registry.Runs()
+	if err != nil {
+		return nil, fmt.Errorf("runcleanup: can't read the registry: %v", err)
+	}
+
+	var totalBytes int64
+	var candidates []candidate
+	var errs []error
+	for _, entry := range runs {
+		if entry.State == runregistry.StateDeleted {
+			continue
+		}
+
+		bytes, err := dirSize(entry.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		totalBytes += bytes
+
+		if isRemovable(wandbDir, entry) {
+			candidates = append(candidates, candidate{entry: entry, bytes: bytes})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].entry.UpdatedAt.Before(candidates[j].entry.UpdatedAt)
+	})
+
+	remove := func(c candidate, reason string) {
+		if !policy.DryRun {
+			if err := os.RemoveAll(c.entry.Path); err != nil {
+				errs = append(errs, err)
+				return
+			}
+
+			deleted := c.entry
+			deleted.State = runregistry.StateDeleted
+			deleted.UpdatedAt = now
+			if err := registry.Record(deleted); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		totalBytes -= c.bytes
+		report.ReclaimedBytes += c.bytes
+		report.Removed = append(report.Removed, Removal{
+			RunID:  c.entry.RunID,
+			Path:   c.entry.Path,
+			Bytes:  c.bytes,
+			Reason: reason,
+		})
+	}
+
+	var kept []candidate
+	for _, c := range candidates {
+		if policy.MaxAge > 0 && now.Sub(c.entry.UpdatedAt) > policy.MaxAge {
+			remove(c, ReasonAge)
+		} else {
+			kept = append(kept, c)
+		}
+	}
+
+	for _, c := range kept {
+		if policy.MaxTotalBytes <= 0 || totalBytes <= policy.MaxTotalBytes {
+			break
+		}
+		remove(c, ReasonSize)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// isRemovable is whether the run finished and its transaction log fully
+// reached the server.
+//
+// Directories outside the wandb directory are never removed, in case the
+// registry was edited or moved.
+func isRemovable(wandbDir string, entry runregistry.Entry) bool {
+	switch entry.State {
+	case runregistry.StateFinished,
+		runregistry.StateFailed,
+		runregistry.StateInterrupted,
+		runregistry.StateCrashed:
+	default:
+		return false
+	}
+
+	if !isInside(wandbDir, entry.Path) || entry.SyncFile == "" {
+		return false
+	}
+
+	state, err := transactionlog.ReadSyncState(entry.SyncFile)
+	return err == nil && state != nil && state.Complete
+}
+
+// isInside is whether the path is in the directory, and isn't the
+// directory itself.
+func isInside(dir, path string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(dir, path)
+	return err == nil &&
+		rel != "." &&
+		rel != ".." &&
+		!strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// dirSize returns the total size of the files in the directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
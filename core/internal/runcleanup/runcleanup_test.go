@@ -0,0 +1,182 @@
+package runcleanup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runcleanup"
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+var now = time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+
+type testRun struct {
+	id       string
+	state    string
+	age      time.Duration
+	bytes    int
+	synced   bool
+	location string
+}
+
+// makeRuns creates the runs' directories in the wandb directory and
+// registers them.
+func makeRuns(t *testing.T, wandbDir string, runs ...testRun) {
+	registry := runregistry.New(wandbDir)
+	for _, run := range runs {
+		location := run.location
+		if location == "" {
+			location = wandbDir
+		}
+		runDir := filepath.Join(location, "run-"+run.id)
+		syncFile := filepath.Join(runDir, "run-"+run.id+".wandb")
+		require.NoError(t, os.MkdirAll(filepath.Join(runDir, "files"), 0o755))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(runDir, "files", "data.bin"),
+			make([]byte, run.bytes),
+			0o644,
+		))
+		if run.synced {
+			require.NoError(t, transactionlog.WriteSyncState(
+				syncFile,
+				transactionlog.SyncState{LastAcked: 10, Complete: true},
+			))
+		}
+
+		require.NoError(t, registry.Record(runregistry.Entry{
+			RunID:     run.id,
+			Path:      runDir,
+			SyncFile:  syncFile,
+			State:     run.state,
+			StartedAt: now.Add(-run.age),
+			UpdatedAt: now.Add(-run.age),
+		}))
+	}
+}
+
+func removedIDs(report *runcleanup.Report) []string {
+	var ids []string
+	for _, removed := range report.Removed {
+		ids = append(ids, removed.RunID)
+	}
+	return ids
+}
+
+const day = 24 * time.Hour
+
+func TestRun_ByAge(t *testing.T) {
+	wandbDir := t.TempDir()
+	makeRuns(t, wandbDir,
+		testRun{id: "old", state: runregistry.StateFinished, age: 10 * day, bytes: 100, synced: true},
+		testRun{id: "failed", state: runregistry.StateFailed, age: 9 * day, bytes: 50, synced: true},
+		testRun{id: "recent", state: runregistry.StateFinished, age: day, bytes: 100, synced: true},
+		testRun{id: "unsynced", state: runregistry.StateFinished, age: 10 * day, bytes: 100},
+		testRun{id: "active", state: runregistry.StateRunning, age: 10 * day, bytes: 100, synced: true},
+	)
+
+	report, err := runcleanup.Run(wandbDir, runcleanup.Policy{MaxAge: 7 * day}, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old", "failed"}, removedIDs(report))
+	assert.Equal(t, runcleanup.ReasonAge, report.Removed[0].Reason)
+	assert.GreaterOrEqual(t, report.Removed[0].Bytes, int64(100))
+	assert.Equal(t,
+		report.Removed[0].Bytes+report.Removed[1].Bytes,
+		report.ReclaimedBytes)
+	assert.NoDirExists(t, filepath.Join(wandbDir, "run-old"))
+	assert.NoDirExists(t, filepath.Join(wandbDir, "run-failed"))
+	assert.DirExists(t, filepath.Join(wandbDir, "run-recent"))
+	assert.DirExists(t, filepath.Join(wandbDir, "run-unsynced"))
+	assert.DirExists(t, filepath.Join(wandbDir, "run-active"))
+
+	runs, err := runregistry.New(wandbDir).Runs()
+	require.NoError(t, err)
+	assert.Equal(t, runregistry.StateDeleted, runs[0].State)
+
+	// Deleted runs aren't reported again.
+	report, err = runcleanup.Run(wandbDir, runcleanup.Policy{MaxAge: 7 * day}, now)
+	require.NoError(t, err)
+	assert.Empty(t, report.Removed)
+}
+
+func TestRun_BySize(t *testing.T) {
+	wandbDir := t.TempDir()
+	makeRuns(t, wandbDir,
+		testRun{id: "unsynced", state: runregistry.StateFinished, age: 5 * day, bytes: 1000},
+		testRun{id: "oldest", state: runregistry.StateFinished, age: 4 * day, bytes: 1000, synced: true},
+		testRun{id: "older", state: runregistry.StateFinished, age: 3 * day, bytes: 1000, synced: true},
+		testRun{id: "newest", state: runregistry.StateFinished, age: 2 * day, bytes: 1000, synced: true},
+	)
+
+	// The unsynced run counts toward the total, but isn't deleted.
+	report, err := runcleanup.Run(wandbDir, runcleanup.Policy{MaxTotalBytes: 2500}, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oldest", "older"}, removedIDs(report))
+	assert.Equal(t, runcleanup.ReasonSize, report.Removed[0].Reason)
+	assert.DirExists(t, filepath.Join(wandbDir, "run-unsynced"))
+	assert.DirExists(t, filepath.Join(wandbDir, "run-newest"))
+}
+
+func TestRun_DryRun(t *testing.T) {
+	wandbDir := t.TempDir()
+	makeRuns(t, wandbDir,
+		testRun{id: "old", state: runregistry.StateFinished, age: 10 * day, bytes: 100, synced: true},
+	)
+
+	report, err := runcleanup.Run(
+		wandbDir,
+		runcleanup.Policy{MaxAge: day, DryRun: true},
+		now,
+	)
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []string{"old"}, removedIDs(report))
+	assert.Positive(t, report.ReclaimedBytes)
+	assert.Contains(t, report.Summary(), "Would delete 1 old run directories")
+	assert.DirExists(t, filepath.Join(wandbDir, "run-old"))
+	runs, err := runregistry.New(wandbDir).Runs()
+	require.NoError(t, err)
+	assert.Equal(t, runregistry.StateFinished, runs[0].State)
+}
+
+func TestRun_NeverOutsideWandbDir(t *testing.T) {
+	wandbDir := t.TempDir()
+	elsewhere := t.TempDir()
+	makeRuns(t, wandbDir,
+		testRun{
+			id:       "moved",
+			state:    runregistry.StateFinished,
+			age:      10 * day,
+			bytes:    100,
+			synced:   true,
+			location: elsewhere,
+		},
+	)
+
+	report, err := runcleanup.Run(wandbDir, runcleanup.Policy{MaxAge: day}, now)
+
+	require.NoError(t, err)
+	assert.Empty(t, report.Removed)
+	assert.DirExists(t, filepath.Join(elsewhere, "run-moved"))
+}
+
+func TestRun_Disabled(t *testing.T) {
+	wandbDir := t.TempDir()
+	makeRuns(t, wandbDir,
+		testRun{id: "old", state: runregistry.StateFinished, age: 100 * day, bytes: 100, synced: true},
+	)
+
+	report, err := runcleanup.Run(wandbDir, runcleanup.Policy{}, now)
+
+	require.NoError(t, err)
+	assert.Empty(t, report.Removed)
+	assert.DirExists(t, filepath.Join(wandbDir, "run-old"))
+}
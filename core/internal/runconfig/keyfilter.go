@@ -0,0 +1,163 @@
+package runconfig
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+)
+
+// KeyFilter selects the config keys that are sent to the server.
+//
+// Patterns are dotted paths into the config, like "paths.data_dir", whose
+// parts may use the glob syntax of path.Match, like "secrets.*" or
+// "*.token". A pattern matching a key also matches everything nested
+// under it.
+//
+// A key is kept if it matches an include pattern, or if there are none,
+// and if it matches no exclude pattern. Keys under "_wandb" are kept
+// unless explicitly excluded, since the server relies on them.
+type KeyFilter struct {
+	include [][]string
+	exclude [][]string
+}
+
+// NewKeyFilter returns a filter for the include and exclude patterns.
+//
+// Returns nil if there are no patterns. Invalid patterns are skipped and
+// reported in the error, in which case the returned filter still uses
+// the valid ones.
+func NewKeyFilter(include, exclude []string) (*KeyFilter, error) {
+	var errs []error
+
+	parse := func(patterns []string) [][]string {
+		var parsed [][]string
+		for _, pattern := range patterns {
+			parts, err := parsePattern(pattern)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			parsed = append(parsed, parts)
+		}
+		return parsed
+	}
+
+	filter := &KeyFilter{include: parse(include), exclude: parse(exclude)}
+	if len(filter.include) == 0 && len(filter.exclude) == 0 {
+		filter = nil
+	}
+
+	return filter, errors.Join(errs...)
+}
+
+// parsePattern splits a dotted key pattern into its parts.
+func parsePattern(pattern string) ([]string, error) {
+	parts := strings.Split(strings.TrimSpace(pattern), ".")
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("runconfig: invalid key pattern %q", pattern)
+		}
+		if _, err := path.Match(part, ""); err != nil {
+			return nil, fmt.Errorf("runconfig: invalid key pattern %q: %v", pattern, err)
+		}
+	}
+	return parts, nil
+}
+
+// Filtered returns the config with only the keys the filters keep.
+//
+// The result shares values with the config and must not be modified.
+func (rc *RunConfig) Filtered(filters ...*KeyFilter) *RunConfig {
+	tree := rc.pathTree.Tree()
+	for _, filter := range filters {
+		if filter != nil {
+			tree = filter.apply(tree, nil)
+		}
+	}
+	return NewFrom(tree)
+}
+
+// apply returns the subtree at the prefix with only the kept keys.
+//
+// Values that are entirely kept are not copied.
+func (f *KeyFilter) apply(
+	tree pathtree.TreeData,
+	prefix []string,
+) pathtree.TreeData {
+	filtered := make(pathtree.TreeData, len(tree))
+
+	for key, value := range tree {
+		keyPath := append(prefix[:len(prefix):len(prefix)], key)
+		if anyMatches(f.exclude, keyPath) {
+			continue
+		}
+
+		if len(f.include) == 0 ||
+			anyMatches(f.include, keyPath) ||
+			(len(keyPath) == 1 && key == "_wandb") {
+			// Nested keys may still be excluded.
+			if subtree, ok := value.(pathtree.TreeData); ok &&
+				anyMatchesPrefix(f.exclude, keyPath) {
+				value = f.excludeOnly(subtree, keyPath)
+			}
+			filtered[key] = value
+			continue
+		}
+
+		// Keep the nested keys that are included.
+		subtree, ok := value.(pathtree.TreeData)
+		if !ok || !anyMatchesPrefix(f.include, keyPath) {
+			continue
+		}
+		if subtree = f.apply(subtree, keyPath); len(subtree) > 0 {
+			filtered[key] = subtree
+		}
+	}
+
+	return filtered
+}
+
+// excludeOnly returns the subtree at the prefix without excluded keys.
+func (f *KeyFilter) excludeOnly(
+	tree pathtree.TreeData,
+	prefix []string,
+) pathtree.TreeData {
+	return (&KeyFilter{exclude: f.exclude}).apply(tree, prefix)
+}
+
+// anyMatches reports whether any pattern matches the key path.
+func anyMatches(patterns [][]string, keyPath []string) bool {
+	for _, pattern := range patterns {
+		if len(pattern) == len(keyPath) && matchParts(pattern, keyPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyMatchesPrefix reports whether any pattern may match a key nested
+// under the key path.
+func anyMatchesPrefix(patterns [][]string, keyPath []string) bool {
+	for _, pattern := range patterns {
+		if len(pattern) > len(keyPath) &&
+			matchParts(pattern[:len(keyPath)], keyPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchParts reports whether each part of the key path matches the
+// corresponding part of the pattern.
+func matchParts(pattern []string, keyPath []string) bool {
+	for i, part := range pattern {
+		// Patterns are validated in NewKeyFilter.
+		if matched, _ := path.Match(part, keyPath[i]); !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package runconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+	"github.com/wandb/wandb/core/internal/runconfig"
+)
+
+func testConfig() *runconfig.RunConfig {
+	return runconfig.NewFrom(pathtree.TreeData{
+		"lr": 0.1,
+		"paths": pathtree.TreeData{
+			"data_dir":  "/data",
+			"cache_dir": "/cache",
+			"name":      "run",
+		},
+		"service": pathtree.TreeData{
+			"url":   "http://internal",
+			"token": "secret",
+		},
+		"_wandb": pathtree.TreeData{"cli_version": "1.0"},
+	})
+}
+
+func TestKeyFilter_Exclude(t *testing.T) {
+	filter, err := runconfig.NewKeyFilter(nil, []string{"paths.*_dir", "service"})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		pathtree.TreeData{
+			"lr":     0.1,
+			"paths":  pathtree.TreeData{"name": "run"},
+			"_wandb": pathtree.TreeData{"cli_version": "1.0"},
+		},
+		testConfig().Filtered(filter).Tree())
+}
+
+func TestKeyFilter_Include(t *testing.T) {
+	filter, err := runconfig.NewKeyFilter(
+		[]string{"lr", "paths.name", "service.*"},
+		[]string{"*.token"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		pathtree.TreeData{
+			"lr":      0.1,
+			"paths":   pathtree.TreeData{"name": "run"},
+			"service": pathtree.TreeData{"url": "http://internal"},
+			"_wandb":  pathtree.TreeData{"cli_version": "1.0"},
+		},
+		testConfig().Filtered(filter).Tree())
+}
+
+func TestKeyFilter_IncludeDropsEmptyParents(t *testing.T) {
+	filter, err := runconfig.NewKeyFilter([]string{"paths.missing", "lr.x"}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		pathtree.TreeData{"_wandb": pathtree.TreeData{"cli_version": "1.0"}},
+		testConfig().Filtered(filter).Tree())
+}
+
+func TestKeyFilter_SeveralFilters(t *testing.T) {
+	first, err := runconfig.NewKeyFilter(nil, []string{"lr"})
+	require.NoError(t, err)
+	second, err := runconfig.NewKeyFilter(nil, []string{"paths", "service"})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		pathtree.TreeData{"_wandb": pathtree.TreeData{"cli_version": "1.0"}},
+		testConfig().Filtered(first, nil, second).Tree())
+}
+
+func TestKeyFilter_DoesNotModifyConfig(t *testing.T) {
+	config := testConfig()
+	filter, err := runconfig.NewKeyFilter(nil, []string{"paths.name"})
+	require.NoError(t, err)
+
+	config.Filtered(filter)
+
+	assert.Equal(t, testConfig().Tree(), config.Tree())
+}
+
+func TestNewKeyFilter_NoPatternsIsNil(t *testing.T) {
+	filter, err := runconfig.NewKeyFilter(nil, []string{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+}
+
+func TestNewKeyFilter_InvalidPatterns(t *testing.T) {
+	filter, err := runconfig.NewKeyFilter(nil, []string{"a..b", "[", "lr"})
+
+	assert.ErrorContains(t, err, `"a..b"`)
+	assert.ErrorContains(t, err, `"["`)
+	assert.Equal(t,
+		pathtree.TreeData{"x": 1},
+		runconfig.NewFrom(pathtree.TreeData{"x": 1, "lr": 2}).Filtered(filter).Tree())
+}
@@ -109,6 +109,11 @@ func (rc *RunConfig) AddTelemetryAndMetrics(
 	}
 }
 
+// Sets a W&B-internal value in the run's configuration.
+func (rc *RunConfig) SetInternal(key string, value any) {
+	rc.internalSubtree()[key] = value
+}
+
 // Incorporates the config from a run that's being resumed.
 func (rc *RunConfig) MergeResumedConfig(oldConfig pathtree.TreeData) error {
 	// Add any top-level keys that aren't already set.
@@ -153,6 +158,34 @@ func (rc *RunConfig) internalSubtree() pathtree.TreeData {
 	return node.(pathtree.TreeData)
 }
 
+// Flatten returns the config as the updates that would build it up.
+//
+// The order of the items is not guaranteed.
+func (rc *RunConfig) Flatten() ([]*service.ConfigItem, error) {
+	leaves := rc.pathTree.Flatten()
+
+	items := make([]*service.ConfigItem, 0, len(leaves))
+	for _, leaf := range leaves {
+		if len(leaf.Path) == 0 {
+			return nil, fmt.Errorf("runconfig: empty path for item %v", leaf)
+		}
+
+		value, err := json.Marshal(leaf.Value)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"runconfig: failed to marshal value for item %v: %v",
+				leaf, err,
+			)
+		}
+
+		items = append(items, &service.ConfigItem{
+			NestedKey: leaf.Path,
+			ValueJson: string(value),
+		})
+	}
+	return items, nil
+}
+
 func (rc *RunConfig) Tree() pathtree.TreeData {
 	return rc.pathTree.Tree()
 }
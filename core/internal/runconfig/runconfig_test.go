@@ -151,3 +151,20 @@ func TestCloneTree(t *testing.T) {
 		runConfig.Tree(),
 	)
 }
+
+func TestFlatten_RebuildsConfig(t *testing.T) {
+	runConfig := runconfig.NewFrom(pathtree.TreeData{
+		"a": 1.0,
+		"b": pathtree.TreeData{
+			"c": "text",
+			"d": []any{1.0, 2.0},
+		},
+	})
+
+	items, err := runConfig.Flatten()
+	assert.NoError(t, err)
+	rebuilt := runconfig.New()
+	rebuilt.ApplyChangeRecord(&service.ConfigRecord{Update: items}, ignoreError)
+
+	assert.Equal(t, runConfig.Tree(), rebuilt.Tree())
+}
@@ -0,0 +1,363 @@
+// Package runexport mirrors the files a run uploads into a local directory.
+//
+// This is for users whose runs must also be kept outside of W&B, such as
+// on a network share of an air-gapped cluster. Files are exported at the
+// points where they're uploaded, whether or not the run is online, in the
+// layout of the run's files tab:
+//
+//	<export dir>/
+//	    <entity>/<project>/<run ID>/
+//	        output.log
+//	        config.yaml
+//	        wandb-metadata.json
+//	        wandb-summary.json
+//	        media/...           files saved by the run, at their run paths
+//	        artifacts/<artifact name>/<artifact digest>/...
+//	                            the files of each logged artifact version
+//
+// A run without an entity, such as an offline run that wasn't given one, is
+// exported under the entity "_", and a run without a project under the
+// "uncategorized" project, which is where W&B puts it too.
+//
+// Each file is written to a temporary file next to its destination and then
+// renamed over it, so that readers of the export directory never see a
+// partially written file. Exporting is isolated from uploading: failures
+// are logged and otherwise ignored.
+package runexport
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// noEntity is the directory of runs whose entity isn't known.
+	noEntity = "_"
+
+	// noProject is the directory of runs without a project, which are
+	// logged to this project by default.
+	noProject = "uncategorized"
+)
+
+// Params configure an Exporter.
+type Params struct {
+	// Dir is the directory to export runs into.
+	Dir string
+
+	// HardLink is whether to hard-link files instead of copying them.
+	//
+	// Files are copied if they can't be linked, such as when the export
+	// directory is on another filesystem. A linked file is the same file
+	// as the original, so it changes if the original is written to.
+	HardLink bool
+
+	Logger *observability.CoreLogger
+}
+
+// Exporter copies a run's files into an export directory.
+//
+// The methods of a nil Exporter do nothing.
+type Exporter struct {
+	dir      string
+	hardLink bool
+	logger   *observability.CoreLogger
+
+	mu sync.Mutex
+
+	// runDir is the run's directory in the export directory, once known
+	runDir string
+
+	// queue are the run files waiting to be exported, in order
+	queue []exportJob
+
+	// working is whether a goroutine is exporting the queued files
+	working bool
+
+	wg sync.WaitGroup
+}
+
+type exportJob struct {
+	// dest is the path to export the file to
+	dest string
+
+	// src is the path of the file to export
+	src string
+}
+
+// New returns an Exporter, or nil if the directory is empty.
+func New(params Params) *Exporter {
+	if params.Dir == "" {
+		return nil
+	}
+
+	if params.Logger == nil {
+		params.Logger = observability.NewNoOpLogger()
+	}
+
+	return &Exporter{
+		dir:      params.Dir,
+		hardLink: params.HardLink,
+		logger:   params.Logger,
+	}
+}
+
+// SetRun sets the run whose files are exported.
+//
+// Files can't be exported before the run is known. It may be set again as
+// the run is updated, such as when the server resolves its entity.
+func (e *Exporter) SetRun(entity, project, runID string) {
+	if e == nil {
+		return
+	}
+
+	if entity == "" {
+		entity = noEntity
+	}
+	if project == "" {
+		project = noProject
+	}
+	for _, name := range []string{entity, project, runID} {
+		if !isPathComponent(name) {
+			e.logger.Warn(
+				"runexport: not exporting run with invalid path",
+				"entity", entity,
+				"project", project,
+				"runID", runID,
+			)
+			return
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.runDir = filepath.Join(e.dir, entity, project, runID)
+}
+
+// ExportRunFile asynchronously exports a file of the run.
+//
+// The run path is the file's path relative to the run's files directory,
+// which is also its path in the export. Files are exported in the order
+// in which they're requested, so that the last export of a file wins.
+func (e *Exporter) ExportRunFile(runPath, localPath string) {
+	if e == nil {
+		return
+	}
+
+	dest, ok := e.destination(runPath)
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.queue = append(e.queue, exportJob{dest: dest, src: localPath})
+	if !e.working {
+		e.working = true
+		e.wg.Add(1)
+		go e.work()
+	}
+}
+
+// ExportArtifact exports the local files of an artifact version.
+//
+// Unlike run files, artifact files are exported before this returns,
+// since their staged copies are deleted once they're uploaded. Files that
+// only reference external storage aren't exported.
+func (e *Exporter) ExportArtifact(artifact *service.ArtifactRecord) {
+	if e == nil {
+		return
+	}
+
+	if !isPathComponent(artifact.GetName()) ||
+		!isPathComponent(artifact.GetDigest()) {
+		e.logger.Warn(
+			"runexport: not exporting artifact with invalid path",
+			"name", artifact.GetName(),
+			"digest", artifact.GetDigest(),
+		)
+		return
+	}
+	artifactDir := filepath.Join(
+		"artifacts",
+		artifact.GetName(),
+		artifact.GetDigest(),
+	)
+
+	for _, entry := range artifact.GetManifest().GetContents() {
+		if entry.GetLocalPath() == "" {
+			continue
+		}
+		path := filepath.FromSlash(entry.GetPath())
+		if !filepath.IsLocal(path) {
+			e.logger.Warn(
+				"runexport: not exporting file outside the artifact's directory",
+				"path", path)
+			continue
+		}
+
+		dest, ok := e.destination(filepath.Join(artifactDir, path))
+		if !ok {
+			continue
+		}
+
+		e.export(exportJob{dest: dest, src: entry.GetLocalPath()})
+	}
+}
+
+// Finish waits for the run files that are being exported.
+func (e *Exporter) Finish() {
+	if e == nil {
+		return
+	}
+
+	e.wg.Wait()
+}
+
+// destination returns where to export the file at the path relative to
+// the run's directory.
+func (e *Exporter) destination(path string) (string, bool) {
+	e.mu.Lock()
+	runDir := e.runDir
+	e.mu.Unlock()
+
+	path = filepath.FromSlash(path)
+	switch {
+	case runDir == "":
+		e.logger.Warn(
+			"runexport: not exporting file before the run is known",
+			"path", path)
+		return "", false
+	case !filepath.IsLocal(path):
+		e.logger.Warn(
+			"runexport: not exporting file outside the run's directory",
+			"path", path)
+		return "", false
+	}
+
+	return filepath.Join(runDir, path), true
+}
+
+// work exports queued run files until there are none left.
+func (e *Exporter) work() {
+	defer e.wg.Done()
+
+	for {
+		e.mu.Lock()
+		if len(e.queue) == 0 {
+			e.working = false
+			e.mu.Unlock()
+			return
+		}
+		job := e.queue[0]
+		e.queue = e.queue[1:]
+		e.mu.Unlock()
+
+		e.export(job)
+	}
+}
+
+// export writes a file to its destination atomically, logging failures.
+func (e *Exporter) export(job exportJob) {
+	if err := e.writeAtomically(job); err != nil {
+		e.logger.Warn(
+			"runexport: failed to export file",
+			"path", job.src,
+			"error", err,
+		)
+	}
+}
+
+func (e *Exporter) writeAtomically(job exportJob) error {
+	if err := os.MkdirAll(filepath.Dir(job.dest), 0o755); err != nil {
+		return err
+	}
+
+	tmpPath, err := e.stage(job)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, job.dest); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// stage puts the file next to its destination under a temporary name, and
+// returns the name.
+func (e *Exporter) stage(job exportJob) (string, error) {
+	tmp, err := os.CreateTemp(
+		filepath.Dir(job.dest),
+		fmt.Sprintf(".%s.*.tmp", filepath.Base(job.dest)),
+	)
+	if err != nil {
+		return "", err
+	}
+	tmpPath := tmp.Name()
+
+	if e.hardLink {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		if os.Link(job.src, tmpPath) == nil {
+			return tmpPath, nil
+		}
+
+		// Linking fails across filesystems, so fall back to copying.
+		tmp, err = os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := copyFile(tmp, job.src); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+
+	return tmpPath, nil
+}
+
+// copyFile copies the file at the path into dst, with the same mode.
+func copyFile(dst *os.File, srcPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+
+	return dst.Chmod(info.Mode().Perm())
+}
+
+// isPathComponent returns whether the name can be used as a single
+// component of a path in the export directory.
+func isPathComponent(name string) bool {
+	return name != "" &&
+		name != "." &&
+		name != ".." &&
+		!strings.ContainsAny(name, `/\`) &&
+		filepath.IsLocal(name)
+}
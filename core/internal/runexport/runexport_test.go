@@ -0,0 +1,178 @@
+package runexport_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runexport"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// exportedFiles returns the contents of the files in the export directory
+// by their slash-separated paths.
+func exportedFiles(t *testing.T, dir string) map[string]string {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func writeFile(t *testing.T, path, content string) string {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestExporter_Layout(t *testing.T) {
+	filesDir := t.TempDir()
+	exportDir := t.TempDir()
+	exporter := runexport.New(runexport.Params{Dir: exportDir})
+	exporter.SetRun("entity", "project", "run1")
+
+	exporter.ExportRunFile("output.log",
+		writeFile(t, filepath.Join(filesDir, "output.log"), "hello"))
+	exporter.ExportRunFile("media/images/plot_0.png",
+		writeFile(t, filepath.Join(filesDir, "media", "images", "plot_0.png"), "png"))
+	exporter.ExportArtifact(&service.ArtifactRecord{
+		Name:   "dataset",
+		Digest: "0123abcd",
+		Manifest: &service.ArtifactManifest{
+			Contents: []*service.ArtifactManifestEntry{
+				{
+					Path:      "train/data.csv",
+					LocalPath: writeFile(t, filepath.Join(t.TempDir(), "data.csv"), "1,2"),
+				},
+				{Path: "remote.csv", Ref: "s3://bucket/remote.csv"},
+			},
+		},
+	})
+	exporter.Finish()
+
+	assert.Equal(t,
+		map[string]string{
+			"entity/project/run1/output.log":                                "hello",
+			"entity/project/run1/media/images/plot_0.png":                   "png",
+			"entity/project/run1/artifacts/dataset/0123abcd/train/data.csv": "1,2",
+		},
+		exportedFiles(t, exportDir))
+}
+
+func TestExporter_LastExportWins(t *testing.T) {
+	exportDir := t.TempDir()
+	path := filepath.Join(t.TempDir(), "output.log")
+	exporter := runexport.New(runexport.Params{Dir: exportDir})
+	exporter.SetRun("entity", "project", "run1")
+
+	writeFile(t, path, "first")
+	exporter.ExportRunFile("output.log", path)
+	exporter.Finish()
+	writeFile(t, path, "second")
+	exporter.ExportRunFile("output.log", path)
+	exporter.Finish()
+
+	// no temporary files are left behind
+	assert.Equal(t,
+		map[string]string{"entity/project/run1/output.log": "second"},
+		exportedFiles(t, exportDir))
+}
+
+func TestExporter_HardLink(t *testing.T) {
+	exportDir := t.TempDir()
+	path := writeFile(t, filepath.Join(exportDir, "src", "config.yaml"), "a: 1")
+	exporter := runexport.New(runexport.Params{Dir: exportDir, HardLink: true})
+	exporter.SetRun("entity", "project", "run1")
+
+	exporter.ExportRunFile("config.yaml", path)
+	exporter.Finish()
+
+	original, err := os.Stat(path)
+	require.NoError(t, err)
+	exported, err := os.Stat(
+		filepath.Join(exportDir, "entity", "project", "run1", "config.yaml"))
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(original, exported))
+}
+
+func TestExporter_RunWithoutEntityOrProject(t *testing.T) {
+	exportDir := t.TempDir()
+	exporter := runexport.New(runexport.Params{Dir: exportDir})
+	exporter.SetRun("", "", "run1")
+
+	exporter.ExportRunFile("config.yaml",
+		writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), "a: 1"))
+	exporter.Finish()
+
+	assert.Equal(t,
+		map[string]string{"_/uncategorized/run1/config.yaml": "a: 1"},
+		exportedFiles(t, exportDir))
+}
+
+func TestExporter_SkipsUnsafePaths(t *testing.T) {
+	exportDir := t.TempDir()
+	path := writeFile(t, filepath.Join(t.TempDir(), "file.txt"), "x")
+	exporter := runexport.New(runexport.Params{Dir: exportDir})
+
+	// before the run is known
+	exporter.ExportRunFile("file.txt", path)
+	exporter.SetRun("entity", "../project", "run1")
+	exporter.ExportRunFile("file.txt", path)
+	exporter.SetRun("entity", "project", "run1")
+	exporter.ExportRunFile("../file.txt", path)
+	exporter.ExportRunFile(path, path)
+	exporter.ExportArtifact(&service.ArtifactRecord{
+		Name:   "dataset",
+		Digest: "0123abcd",
+		Manifest: &service.ArtifactManifest{
+			Contents: []*service.ArtifactManifestEntry{
+				{Path: "../../escape.txt", LocalPath: path},
+			},
+		},
+	})
+	exporter.Finish()
+
+	assert.Empty(t, exportedFiles(t, exportDir))
+}
+
+func TestExporter_MissingFileIsSkipped(t *testing.T) {
+	exportDir := t.TempDir()
+	exporter := runexport.New(runexport.Params{Dir: exportDir})
+	exporter.SetRun("entity", "project", "run1")
+
+	exporter.ExportRunFile("missing.txt", filepath.Join(t.TempDir(), "missing.txt"))
+	exporter.ExportRunFile("present.txt",
+		writeFile(t, filepath.Join(t.TempDir(), "present.txt"), "x"))
+	exporter.Finish()
+
+	assert.Equal(t,
+		map[string]string{"entity/project/run1/present.txt": "x"},
+		exportedFiles(t, exportDir))
+}
+
+func TestExporter_Nil(t *testing.T) {
+	exporter := runexport.New(runexport.Params{})
+
+	assert.Nil(t, exporter)
+	exporter.SetRun("entity", "project", "run1")
+	exporter.ExportRunFile("output.log", "output.log")
+	exporter.ExportArtifact(&service.ArtifactRecord{})
+	exporter.Finish()
+}
@@ -0,0 +1,148 @@
+package runfiles
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+// mtimeGranularity is the coarsest resolution with which filesystems record
+// modification times.
+//
+// A file changed within this long of being hashed may have kept its
+// modification time, so its size and modification time can't be trusted
+// to tell whether it changed.
+const mtimeGranularity = 2 * time.Second
+
+// fileContent identifies the content of a file.
+type fileContent struct {
+	size    int64
+	modTime time.Time
+
+	// digest is the base64-encoded MD5 of the content, as in artifact
+	// manifests, so that an upload of the same content in an artifact
+	// can be shared.
+	digest string
+
+	// hashedAt is when the hash was computed.
+	hashedAt time.Time
+}
+
+// fileContents remembers the content of files as of their last successful
+// upload, so that files saved again without changes aren't reuploaded.
+type fileContents struct {
+	sync.Mutex
+
+	// Content last uploaded, by path relative to the files directory.
+	uploaded map[string]fileContent
+
+	// Files whose next upload happens even if they haven't changed.
+	forced map[string]struct{}
+}
+
+// newFileContents returns the contents of files, starting from those
+// uploaded by a previous wandb-core process, if any.
+func newFileContents(uploaded map[string]transactionlog.UploadedFile) *fileContents {
+	c := &fileContents{
+		uploaded: make(map[string]fileContent, len(uploaded)),
+		forced:   make(map[string]struct{}),
+	}
+	for runPath, file := range uploaded {
+		c.uploaded[runPath] = fileContent{
+			size:     file.Size,
+			modTime:  file.ModTime,
+			digest:   file.Digest,
+			hashedAt: file.HashedAt,
+		}
+	}
+	return c
+}
+
+// Snapshot returns the content of each file as of its last upload.
+func (c *fileContents) Snapshot() map[string]transactionlog.UploadedFile {
+	c.Lock()
+	defer c.Unlock()
+
+	snapshot := make(map[string]transactionlog.UploadedFile, len(c.uploaded))
+	for runPath, content := range c.uploaded {
+		snapshot[runPath] = transactionlog.UploadedFile{
+			Size:     content.size,
+			ModTime:  content.modTime,
+			Digest:   content.digest,
+			HashedAt: content.hashedAt,
+		}
+	}
+	return snapshot
+}
+
+// Force makes the next upload of the file happen even if it's unchanged.
+func (c *fileContents) Force(runPath string) {
+	c.Lock()
+	defer c.Unlock()
+	c.forced[runPath] = struct{}{}
+}
+
+// Check returns the file's current content and whether it's the same as
+// was last uploaded.
+//
+// The file's size and modification time are compared first, and the file
+// is hashed only if they differ or are too recent to be trusted.
+func (c *fileContents) Check(runPath, realPath string) (fileContent, bool, error) {
+	c.Lock()
+	last, uploaded := c.uploaded[runPath]
+	_, forced := c.forced[runPath]
+	delete(c.forced, runPath)
+	c.Unlock()
+
+	info, err := os.Stat(realPath)
+	if err != nil {
+		return fileContent{}, false, err
+	}
+
+	if uploaded && !forced &&
+		info.Size() == last.size &&
+		info.ModTime().Equal(last.modTime) &&
+		last.modTime.Before(last.hashedAt.Add(-mtimeGranularity)) {
+		return last, true, nil
+	}
+
+	content, err := hashFile(realPath, info)
+	if err != nil {
+		return fileContent{}, false, err
+	}
+
+	unchanged := uploaded && !forced && content.digest == last.digest
+	if unchanged {
+		// remember the newer modification time, so that the next check
+		// can skip hashing
+		c.Uploaded(runPath, content)
+	}
+	return content, unchanged, nil
+}
+
+// Uploaded records that the content of the file was uploaded.
+func (c *fileContents) Uploaded(runPath string, content fileContent) {
+	c.Lock()
+	defer c.Unlock()
+	c.uploaded[runPath] = content
+}
+
+// hashFile returns the content of the file, whose info is given.
+func hashFile(path string, info os.FileInfo) (fileContent, error) {
+	hashedAt := time.Now()
+
+	digest, err := utils.ComputeFileB64MD5(path)
+	if err != nil {
+		return fileContent{}, err
+	}
+
+	return fileContent{
+		size:     info.Size(),
+		modTime:  info.ModTime(),
+		digest:   digest,
+		hashedAt: hashedAt,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package runfiles
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// fileReports records what became of each of the run's files.
+//
+// Only the outcome of a file's last upload is kept.
+type fileReports struct {
+	mu sync.Mutex
+
+	// byPath maps paths relative to the files directory to their reports.
+	byPath map[string]*service.RunFileReport
+}
+
+func newFileReports() *fileReports {
+	return &fileReports{byPath: make(map[string]*service.RunFileReport)}
+}
+
+// Set replaces the file's report.
+func (r *fileReports) Set(report *service.RunFileReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byPath[report.GetPath()] = report
+}
+
+// Failed reports that the files failed to upload.
+func (r *fileReports) Failed(err error, paths ...string) {
+	for _, path := range paths {
+		r.Set(&service.RunFileReport{
+			Path:   path,
+			Status: service.RunFileReport_FAILED,
+			Error:  err.Error(),
+		})
+	}
+}
+
+// Proto returns the reports, failed files first and then by path, with
+// at most limit of them unless limit is 0.
+func (r *fileReports) Proto(limit int) *service.RunFilesReport {
+	r.mu.Lock()
+	reports := make([]*service.RunFileReport, 0, len(r.byPath))
+	for _, report := range r.byPath {
+		reports = append(reports, proto.Clone(report).(*service.RunFileReport))
+	}
+	r.mu.Unlock()
+
+	slices.SortFunc(reports, func(a, b *service.RunFileReport) int {
+		aFailed := a.GetStatus() == service.RunFileReport_FAILED
+		bFailed := b.GetStatus() == service.RunFileReport_FAILED
+		switch {
+		case aFailed && !bFailed:
+			return -1
+		case bFailed && !aFailed:
+			return 1
+		default:
+			return strings.Compare(a.GetPath(), b.GetPath())
+		}
+	})
+
+	var overflow int
+	if limit > 0 && len(reports) > limit {
+		overflow = len(reports) - limit
+		reports = reports[:limit]
+	}
+	return &service.RunFilesReport{
+		Files:    reports,
+		Overflow: int32(overflow),
+	}
+}
@@ -10,12 +10,14 @@ import (
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/runexport"
 	"github.com/wandb/wandb/core/internal/settings"
 	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/watcher2"
 	"github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
 )
 
 // Uploader uploads the files in a run's files directory.
@@ -41,6 +43,25 @@ type Uploader interface {
 	//
 	// After this, no other methods may be called.
 	Finish()
+
+	// Unfinished returns the files whose requested uploads haven't
+	// finished, sorted.
+	//
+	// The paths are relative to the run's files directory. Unlike other
+	// methods, this may be called while Finish is waiting.
+	Unfinished() []string
+
+	// Report returns what became of each of the run's files so far,
+	// listing at most limit files, or all of them if limit is 0.
+	//
+	// Like Unfinished, this may be called while Finish is waiting.
+	Report(limit int) *service.RunFilesReport
+
+	// Uploaded returns the content of each file as of its last upload,
+	// by path relative to the run's files directory.
+	//
+	// Like Unfinished, this may be called while Finish is waiting.
+	Uploaded() map[string]transactionlog.UploadedFile
 }
 
 func NewUploader(params UploaderParams) Uploader {
@@ -65,6 +86,22 @@ type UploaderParams struct {
 	GraphQL      graphql.Client
 	FileWatcher  watcher2.Watcher
 
+	// Counts the files not uploaded again because they hadn't changed.
+	//
+	// This may be nil.
+	FileTransferStats filetransfer.FileTransferStats
+
+	// Mirrors files into an export directory as they're uploaded.
+	//
+	// This may be nil. If it's not, files are exported even when offline.
+	Exporter *runexport.Exporter
+
+	// The content of the files uploaded by a previous wandb-core process
+	// that ran the run, which aren't uploaded again unless they changed.
+	//
+	// This may be nil.
+	Uploaded map[string]transactionlog.UploadedFile
+
 	// How long to wait to batch upload operations.
 	//
 	// This helps if multiple uploads are scheduled around the same time by
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +24,8 @@ import (
 	"github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/filestreamtest"
 	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+	"github.com/wandb/wandb/core/pkg/utils"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -45,6 +49,28 @@ func stubCreateRunFilesOneFile(
 	)
 }
 
+func stubCreateRunFiles(
+	mockGQLClient *gqlmock.MockClient,
+	paths ...string,
+) {
+	files := make([]string, len(paths))
+	for i, path := range paths {
+		files[i] = fmt.Sprintf(
+			`{"name": %q, "uploadUrl": "https://example.com/%v"}`,
+			path, path)
+	}
+
+	mockGQLClient.StubMatchOnce(
+		gqlmock.WithOpName("CreateRunFiles"),
+		fmt.Sprintf(`{
+			"createRunFiles": {
+				"runID": "test-run",
+				"files": [%v]
+			}
+		}`, strings.Join(files, ",")),
+	)
+}
+
 func writeEmptyFile(t *testing.T, path string) {
 	require.NoError(t,
 		os.MkdirAll(
@@ -63,6 +89,7 @@ func TestUploader(t *testing.T) {
 	var fakeFileTransfer *filetransfertest.FakeFileTransferManager
 	var mockGQLClient *gqlmock.MockClient
 	var fakeFileWatcher *watcher2test.FakeWatcher
+	var fileTransferStats filetransfer.FileTransferStats
 	var uploader Uploader
 
 	// Optional batch delay to use in the uploader.
@@ -80,6 +107,12 @@ func TestUploader(t *testing.T) {
 	// The _sync mode to set on Settings.
 	var isSync bool
 
+	// The _upload_url_batch_size to set on Settings.
+	var uploadURLBatchSize int32
+
+	// The files uploaded by a previous process.
+	var uploaded map[string]transactionlog.UploadedFile
+
 	// Resets test objects and runs a given test.
 	runTest := func(
 		name string,
@@ -92,6 +125,8 @@ func TestUploader(t *testing.T) {
 		ignoreGlobs = []string{}
 		isOffline = false
 		isSync = false
+		uploadURLBatchSize = 0
+		uploaded = nil
 		configure()
 
 		fakeFileStream = filestreamtest.NewFakeFileStream()
@@ -103,18 +138,25 @@ func TestUploader(t *testing.T) {
 
 		fakeFileWatcher = watcher2test.NewFakeWatcher()
 
+		fileTransferStats = filetransfer.NewFileTransferStats()
+
 		uploader = NewUploader(runfilestest.WithTestDefaults(UploaderParams{
-			Ctx:          context.Background(),
-			GraphQL:      mockGQLClient,
-			FileStream:   fakeFileStream,
-			FileTransfer: fakeFileTransfer,
-			FileWatcher:  fakeFileWatcher,
-			BatchDelay:   batchDelay,
+			Ctx:               context.Background(),
+			GraphQL:           mockGQLClient,
+			FileStream:        fakeFileStream,
+			FileTransfer:      fakeFileTransfer,
+			FileTransferStats: fileTransferStats,
+			FileWatcher:       fakeFileWatcher,
+			BatchDelay:        batchDelay,
+			Uploaded:          uploaded,
 			Settings: settings.From(&service.Settings{
 				FilesDir:    &wrapperspb.StringValue{Value: filesDir},
 				IgnoreGlobs: &service.ListStringValue{Value: ignoreGlobs},
 				XOffline:    &wrapperspb.BoolValue{Value: isOffline},
 				XSync:       &wrapperspb.BoolValue{Value: isSync},
+				XUploadUrlBatchSize: &wrapperspb.Int32Value{
+					Value: uploadURLBatchSize,
+				},
 			}),
 		}))
 
@@ -175,6 +217,23 @@ func TestUploader(t *testing.T) {
 			assert.Len(t, fakeFileTransfer.Tasks(), 0)
 		})
 
+	runTest("Process during sync uploads at the end",
+		func() { isSync = true },
+		func(t *testing.T) {
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			writeEmptyFile(t, filepath.Join(filesDir, "test.txt"))
+
+			uploader.Process(&service.FilesRecord{
+				Files: []*service.FilesItem{
+					{Path: "test.txt", Policy: service.FilesItem_NOW},
+				},
+			})
+			uploader.UploadRemaining()
+			uploader.Finish()
+
+			assert.Len(t, fakeFileTransfer.Tasks(), 1)
+		})
+
 	runTest("Process sets file category",
 		func() {},
 		func(t *testing.T) {
@@ -199,6 +258,26 @@ func TestUploader(t *testing.T) {
 			)
 		})
 
+	runTest("Process requests upload URLs in batches",
+		func() { uploadURLBatchSize = 2 },
+		func(t *testing.T) {
+			stubCreateRunFiles(mockGQLClient, "a.txt", "b.txt")
+			stubCreateRunFiles(mockGQLClient, "c.txt", "d.txt")
+			stubCreateRunFiles(mockGQLClient, "e.txt")
+			var files []*service.FilesItem
+			for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt"} {
+				writeEmptyFile(t, filepath.Join(filesDir, name))
+				files = append(files,
+					&service.FilesItem{Path: name, Policy: service.FilesItem_NOW})
+			}
+
+			uploader.Process(&service.FilesRecord{Files: files})
+			uploader.Finish()
+
+			assert.Len(t, mockGQLClient.AllRequests(), 3)
+			assert.Len(t, fakeFileTransfer.Tasks(), 5)
+		})
+
 	runTest("UploadNow uploads given file",
 		func() {},
 		func(t *testing.T) {
@@ -264,6 +343,22 @@ func TestUploader(t *testing.T) {
 				fakeFileStream.GetUpdates())
 		})
 
+	runTest("upload includes the content's digest",
+		func() {},
+		func(t *testing.T) {
+			path := filepath.Join(filesDir, "model.ckpt")
+			require.NoError(t, os.WriteFile(path, []byte("weights"), 0o644))
+
+			stubCreateRunFilesOneFile(mockGQLClient, "model.ckpt")
+			uploader.UploadNow("model.ckpt")
+			uploader.Finish()
+
+			require.Len(t, fakeFileTransfer.Tasks(), 1)
+			assert.Equal(t,
+				utils.ComputeB64MD5([]byte("weights")),
+				fakeFileTransfer.Tasks()[0].Digest)
+		})
+
 	runTest("upload serializes uploads of the same file",
 		func() {},
 		func(t *testing.T) {
@@ -289,6 +384,200 @@ func TestUploader(t *testing.T) {
 			assert.Len(t, fakeFileTransfer.Tasks(), 2)
 		})
 
+	runTest("upload skips file unchanged since last upload",
+		func() {},
+		func(t *testing.T) {
+			path := filepath.Join(filesDir, "test.txt")
+			require.NoError(t, os.WriteFile(path, []byte("content"), 0o644))
+
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.(UploaderTesting).FlushSchedulingForTest()
+			uploader.UploadNow("test.txt")
+			uploader.Finish()
+
+			assert.Len(t, fakeFileTransfer.Tasks(), 1)
+			assert.EqualValues(t, 1,
+				fileTransferStats.GetFileCounts().GetUnchangedCount())
+		})
+
+	runTest("upload skips file uploaded unchanged by a previous process",
+		func() {
+			path := filepath.Join(filesDir, "test.txt")
+			require.NoError(t, os.WriteFile(path, []byte("content"), 0o644))
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			digest, err := utils.ComputeFileB64MD5(path)
+			require.NoError(t, err)
+			uploaded = map[string]transactionlog.UploadedFile{
+				"test.txt": {
+					Size:     info.Size(),
+					ModTime:  info.ModTime(),
+					Digest:   digest,
+					HashedAt: info.ModTime().Add(time.Minute),
+				},
+			}
+		},
+		func(t *testing.T) {
+			uploader.UploadNow("test.txt")
+			uploader.Finish()
+
+			assert.Empty(t, fakeFileTransfer.Tasks())
+			assert.Equal(t, uploaded, uploader.Uploaded())
+		})
+
+	runTest("Uploaded returns the content of uploaded files",
+		func() {},
+		func(t *testing.T) {
+			path := filepath.Join(filesDir, "test.txt")
+			require.NoError(t, os.WriteFile(path, []byte("content"), 0o644))
+			digest, err := utils.ComputeFileB64MD5(path)
+			require.NoError(t, err)
+
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.Finish()
+
+			require.Contains(t, uploader.Uploaded(), "test.txt")
+			assert.Equal(t, digest, uploader.Uploaded()["test.txt"].Digest)
+		})
+
+	runTest("upload reuploads file changed since last upload",
+		func() {},
+		func(t *testing.T) {
+			path := filepath.Join(filesDir, "test.txt")
+			require.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.(UploaderTesting).FlushSchedulingForTest()
+			require.NoError(t, os.WriteFile(path, []byte("new content"), 0o644))
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.Finish()
+
+			assert.Len(t, fakeFileTransfer.Tasks(), 2)
+			assert.EqualValues(t, 0,
+				fileTransferStats.GetFileCounts().GetUnchangedCount())
+		})
+
+	runTest("upload reuploads file changed without changing size or mtime",
+		func() {},
+		func(t *testing.T) {
+			path := filepath.Join(filesDir, "test.txt")
+			require.NoError(t, os.WriteFile(path, []byte("aaaa"), 0o644))
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.(UploaderTesting).FlushSchedulingForTest()
+			// Simulate a write within the filesystem's mtime granularity.
+			require.NoError(t, os.WriteFile(path, []byte("bbbb"), 0o644))
+			require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.Finish()
+
+			assert.Len(t, fakeFileTransfer.Tasks(), 2)
+		})
+
+	runTest("Process with force reuploads unchanged file",
+		func() {},
+		func(t *testing.T) {
+			path := filepath.Join(filesDir, "test.txt")
+			require.NoError(t, os.WriteFile(path, []byte("content"), 0o644))
+
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.UploadNow("test.txt")
+			uploader.(UploaderTesting).FlushSchedulingForTest()
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+			uploader.Process(&service.FilesRecord{
+				Files: []*service.FilesItem{
+					{Path: "test.txt", Policy: service.FilesItem_NOW},
+				},
+				Force: true,
+			})
+			uploader.Finish()
+
+			assert.Len(t, fakeFileTransfer.Tasks(), 2)
+		})
+
+	runTest("Unfinished lists files still uploading",
+		func() {},
+		func(t *testing.T) {
+			writeEmptyFile(t, filepath.Join(filesDir, "test.txt"))
+			fakeFileTransfer.ShouldCompleteImmediately = false
+			stubCreateRunFilesOneFile(mockGQLClient, "test.txt")
+
+			uploader.UploadNow("test.txt")
+			uploader.(UploaderTesting).FlushSchedulingForTest()
+			unfinished := uploader.Unfinished()
+			upload := fakeFileTransfer.Tasks()[0]
+			upload.CompletionCallback(upload)
+
+			assert.Equal(t, []string{"test.txt"}, unfinished)
+			assert.Empty(t, uploader.Unfinished())
+		})
+
+	runTest("Report lists what became of each file",
+		func() { ignoreGlobs = []string{"*.log"} },
+		func(t *testing.T) {
+			require.NoError(t,
+				os.WriteFile(filepath.Join(filesDir, "a.txt"), []byte("aaa"), 0o644))
+			require.NoError(t,
+				os.WriteFile(filepath.Join(filesDir, "b.txt"), []byte("bb"), 0o644))
+			require.NoError(t,
+				os.WriteFile(filepath.Join(filesDir, "c.log"), []byte("c"), 0o644))
+			fakeFileTransfer.ShouldCompleteImmediately = false
+			stubCreateRunFiles(mockGQLClient, "a.txt", "b.txt")
+
+			uploader.Process(&service.FilesRecord{
+				Files: []*service.FilesItem{
+					{Path: "a.txt", Policy: service.FilesItem_NOW},
+					{Path: "b.txt", Policy: service.FilesItem_NOW},
+					{Path: "c.log", Policy: service.FilesItem_NOW},
+				},
+			})
+			uploader.(UploaderTesting).FlushSchedulingForTest()
+			for _, task := range fakeFileTransfer.Tasks() {
+				task.Size = 3
+				if task.Name == "b.txt" {
+					task.Size = 2
+					task.Err = fmt.Errorf("status 500")
+					task.Retries = 3
+				}
+				task.CompletionCallback(task)
+			}
+			uploader.UploadNow("a.txt")
+			uploader.Finish()
+			report := uploader.Report(0)
+			capped := uploader.Report(1)
+
+			require.Len(t, report.GetFiles(), 3)
+			assert.Equal(t, "b.txt", report.GetFiles()[0].GetPath())
+			assert.Equal(t,
+				service.RunFileReport_FAILED,
+				report.GetFiles()[0].GetStatus())
+			assert.Equal(t, "status 500", report.GetFiles()[0].GetError())
+			assert.EqualValues(t, 3, report.GetFiles()[0].GetRetries())
+			assert.EqualValues(t, 2, report.GetFiles()[0].GetSize())
+			assert.Equal(t, "a.txt", report.GetFiles()[1].GetPath())
+			assert.Equal(t,
+				service.RunFileReport_SKIPPED_UNCHANGED,
+				report.GetFiles()[1].GetStatus())
+			assert.EqualValues(t, 3, report.GetFiles()[1].GetSize())
+			assert.Equal(t, "c.log", report.GetFiles()[2].GetPath())
+			assert.Equal(t,
+				service.RunFileReport_SKIPPED_IGNORED,
+				report.GetFiles()[2].GetStatus())
+			assert.EqualValues(t, 1, report.GetFiles()[2].GetSize())
+			assert.Zero(t, report.GetOverflow())
+			require.Len(t, capped.GetFiles(), 1)
+			assert.Equal(t, "b.txt", capped.GetFiles()[0].GetPath())
+			assert.EqualValues(t, 2, capped.GetOverflow())
+		})
+
 	runTest("upload batches and deduplicates CreateRunFiles calls",
 		func() { batchDelay = waitingtest.NewFakeDelay() },
 		func(t *testing.T) {
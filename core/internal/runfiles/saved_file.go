@@ -1,20 +1,27 @@
 package runfiles
 
 import (
+	"context"
 	"sync"
 
 	"github.com/wandb/wandb/core/internal/filetransfer"
 	"github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
 )
 
 // savedFile is a file in the run's files directory.
 type savedFile struct {
 	sync.Mutex
 
-	fs     filestream.FileStream
-	ftm    filetransfer.FileTransferManager
-	logger *observability.CoreLogger
+	// ctx cancels the file's uploads when it's done.
+	ctx context.Context
+
+	fs       filestream.FileStream
+	ftm      filetransfer.FileTransferManager
+	logger   *observability.CoreLogger
+	contents *fileContents
+	reports  *fileReports
 
 	// The path to the actual file.
 	realPath string
@@ -43,19 +50,28 @@ type savedFile struct {
 	// HTTP headers to set on the reupload request for the file if
 	// `reuploadScheduled`.
 	reuploadHeaders []string
+
+	// The content of the file to reupload if `reuploadScheduled`, if known.
+	reuploadContent *fileContent
 }
 
 func newSavedFile(
+	ctx context.Context,
 	fs filestream.FileStream,
 	ftm filetransfer.FileTransferManager,
 	logger *observability.CoreLogger,
+	contents *fileContents,
+	reports *fileReports,
 	realPath string,
 	runPath string,
 ) *savedFile {
 	return &savedFile{
+		ctx:      ctx,
 		fs:       fs,
 		ftm:      ftm,
 		logger:   logger,
+		contents: contents,
+		reports:  reports,
 		realPath: realPath,
 		runPath:  runPath,
 
@@ -73,9 +89,12 @@ func (f *savedFile) SetCategory(category filetransfer.RunFileKind) {
 //
 // If there is an ongoing upload operation for the file, the new upload
 // is scheduled to happen after the previous one completes.
+//
+// The content of the file is recorded once it's uploaded, if given.
 func (f *savedFile) Upload(
 	url string,
 	headers []string,
+	content *fileContent,
 ) {
 	f.Lock()
 	defer f.Unlock()
@@ -88,16 +107,21 @@ func (f *savedFile) Upload(
 		f.reuploadScheduled = true
 		f.reuploadURL = url
 		f.reuploadHeaders = headers
+		f.reuploadContent = content
 		return
 	}
 
-	f.doUpload(url, headers)
+	f.doUpload(url, headers, content)
 }
 
 // doUpload sends an upload Task to the FileTransferManager.
 //
 // It must be called while a lock is held. It temporarily releases the lock.
-func (f *savedFile) doUpload(uploadURL string, uploadHeaders []string) {
+func (f *savedFile) doUpload(
+	uploadURL string,
+	uploadHeaders []string,
+	content *fileContent,
+) {
 	task := &filetransfer.Task{
 		FileKind: f.category,
 		Type:     filetransfer.UploadTask,
@@ -105,11 +129,17 @@ func (f *savedFile) doUpload(uploadURL string, uploadHeaders []string) {
 		Name:     f.runPath,
 		Url:      uploadURL,
 		Headers:  uploadHeaders,
+		Context:  f.ctx,
+	}
+	if content != nil {
+		task.Digest = content.digest
 	}
 
 	f.isUploading = true
 	f.wg.Add(1)
-	task.SetCompletionCallback(f.onFinishUpload)
+	task.SetCompletionCallback(func(task *filetransfer.Task) {
+		f.onFinishUpload(task, content)
+	})
 
 	// Temporarily unlock while we run arbitrary code.
 	f.Unlock()
@@ -118,24 +148,44 @@ func (f *savedFile) doUpload(uploadURL string, uploadHeaders []string) {
 }
 
 // onFinishUpload marks an upload completed and triggers another if scheduled.
-func (f *savedFile) onFinishUpload(task *filetransfer.Task) {
+func (f *savedFile) onFinishUpload(task *filetransfer.Task, content *fileContent) {
+	report := &service.RunFileReport{
+		Path:    f.runPath,
+		Size:    task.Size,
+		Retries: int32(task.Retries),
+	}
 	if task.Err == nil {
 		f.fs.StreamUpdate(&filestream.FilesUploadedUpdate{
 			RelativePath: f.runPath,
 		})
+		if content != nil {
+			f.contents.Uploaded(f.runPath, *content)
+		}
+		report.Status = service.RunFileReport_UPLOADED
+	} else {
+		report.Status = service.RunFileReport_FAILED
+		report.Error = task.Err.Error()
 	}
+	f.reports.Set(report)
 
 	f.Lock()
 	f.isUploading = false
 	if f.reuploadScheduled {
 		f.reuploadScheduled = false
-		f.doUpload(f.reuploadURL, f.reuploadHeaders)
+		f.doUpload(f.reuploadURL, f.reuploadHeaders, f.reuploadContent)
 	}
 	f.Unlock()
 
 	f.wg.Done()
 }
 
+// IsUploading returns whether an upload of the file hasn't finished.
+func (f *savedFile) IsUploading() bool {
+	f.Lock()
+	defer f.Unlock()
+	return f.isUploading || f.reuploadScheduled
+}
+
 // Finish waits for all scheduled uploads of the file to complete.
 func (f *savedFile) Finish() {
 	f.Lock()
@@ -16,6 +16,9 @@ type uploadBatcher struct {
 	// Files collected so far.
 	files map[string]struct{}
 
+	// Files in the batch being passed to upload.
+	uploading map[string]struct{}
+
 	// Whether an upload is queued to happen soon.
 	isQueued bool
 
@@ -78,6 +81,7 @@ func (b *uploadBatcher) uploadBatch() {
 	b.isQueued = false
 	files := b.files
 	b.files = make(map[string]struct{})
+	b.uploading = files
 	b.Unlock()
 
 	filesSlice := make([]string, 0, len(files))
@@ -87,5 +91,24 @@ func (b *uploadBatcher) uploadBatch() {
 
 	b.upload(filesSlice)
 
+	b.Lock()
+	b.uploading = nil
+	b.Unlock()
+
 	b.addWG.Done()
 }
+
+// Pending returns the files added but not yet passed to upload.
+func (b *uploadBatcher) Pending() []string {
+	b.Lock()
+	defer b.Unlock()
+
+	pending := make([]string, 0, len(b.files)+len(b.uploading))
+	for file := range b.files {
+		pending = append(pending, file)
+	}
+	for file := range b.uploading {
+		pending = append(pending, file)
+	}
+	return pending
+}
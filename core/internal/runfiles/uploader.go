@@ -2,19 +2,23 @@ package runfiles
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/wandb/wandb/core/internal/filetransfer"
 	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/runexport"
 	"github.com/wandb/wandb/core/internal/settings"
 	"github.com/wandb/wandb/core/internal/watcher2"
 	"github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
 )
 
 // uploader is the implementation of the Uploader interface.
@@ -33,12 +37,32 @@ type uploader struct {
 	// Files explicitly requested to be uploaded at the end of the run.
 	uploadAtEnd map[string]struct{}
 
+	// The content of files as of their last uploads.
+	contents *fileContents
+
+	// What became of each file.
+	reports *fileReports
+
+	// Counts files skipped because they're unchanged, if not nil.
+	stats filetransfer.FileTransferStats
+
+	// Mirrors files into an export directory, if not nil.
+	exporter *runexport.Exporter
+
 	// Whether 'Finish' was called.
 	isFinished bool
 
 	// Wait group for scheduling file uploads.
 	uploadWG *sync.WaitGroup
 
+	// Files whose uploads are being created but not yet scheduled, with
+	// how many uploads of each.
+	//
+	// This is guarded by its own mutex so that Unfinished never waits for
+	// uploads.
+	scheduling   map[string]int
+	schedulingMu *sync.Mutex
+
 	// Mutex that's locked whenever any state is being read or modified.
 	stateMu *sync.Mutex
 
@@ -57,9 +81,15 @@ func newUploader(params UploaderParams) *uploader {
 
 		knownFiles:  make(map[string]*savedFile),
 		uploadAtEnd: make(map[string]struct{}),
+		contents:    newFileContents(params.Uploaded),
+		reports:     newFileReports(),
+		stats:       params.FileTransferStats,
+		exporter:    params.Exporter,
 
-		uploadWG: &sync.WaitGroup{},
-		stateMu:  &sync.Mutex{},
+		uploadWG:     &sync.WaitGroup{},
+		stateMu:      &sync.Mutex{},
+		scheduling:   make(map[string]int),
+		schedulingMu: &sync.Mutex{},
 
 		watcher: params.FileWatcher,
 	}
@@ -78,8 +108,19 @@ func (u *uploader) Process(record *service.FilesRecord) {
 	}
 	defer u.stateMu.Unlock()
 
-	// Ignore file records in sync mode---we just upload everything at the end.
+	if record.GetForce() {
+		for _, file := range record.GetFiles() {
+			u.contents.Force(file.GetPath())
+		}
+	}
+
+	// In sync mode, we just upload everything at the end.
 	if u.settings.Proto.GetXSync().GetValue() {
+		for _, file := range record.GetFiles() {
+			u.knownFile(file.GetPath()).
+				SetCategory(filetransfer.RunFileKindFromProto(file.GetType()))
+			u.uploadAtEnd[file.GetPath()] = struct{}{}
+		}
 		return
 	}
 
@@ -179,11 +220,61 @@ func (u *uploader) Finish() {
 	u.uploadWG.Wait()
 
 	// Wait for all file uploads to complete.
+	//
+	// No files become known after this point, so the lock needn't be held
+	// while waiting.
+	for _, file := range u.knownFilesSnapshot() {
+		file.Finish()
+	}
+}
+
+func (u *uploader) Unfinished() []string {
+	unfinished := make(map[string]struct{})
+
+	// Files move from the batcher to scheduling to known files, so
+	// checking in this order finds every file at least once.
+	for _, path := range u.uploadBatcher.Pending() {
+		unfinished[path] = struct{}{}
+	}
+
+	u.schedulingMu.Lock()
+	for path := range u.scheduling {
+		unfinished[path] = struct{}{}
+	}
+	u.schedulingMu.Unlock()
+
+	for _, file := range u.knownFilesSnapshot() {
+		if file.IsUploading() {
+			unfinished[file.runPath] = struct{}{}
+		}
+	}
+
+	paths := make([]string, 0, len(unfinished))
+	for path := range unfinished {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func (u *uploader) Report(limit int) *service.RunFilesReport {
+	return u.reports.Proto(limit)
+}
+
+func (u *uploader) Uploaded() map[string]transactionlog.UploadedFile {
+	return u.contents.Snapshot()
+}
+
+// knownFilesSnapshot returns the files known so far.
+func (u *uploader) knownFilesSnapshot() []*savedFile {
 	u.stateMu.Lock()
 	defer u.stateMu.Unlock()
+
+	files := make([]*savedFile, 0, len(u.knownFiles))
 	for _, file := range u.knownFiles {
-		file.Finish()
+		files = append(files, file)
 	}
+	return files
 }
 
 func (u *uploader) FlushSchedulingForTest() {
@@ -195,9 +286,12 @@ func (u *uploader) FlushSchedulingForTest() {
 func (u *uploader) knownFile(runPath string) *savedFile {
 	if u.knownFiles[runPath] == nil {
 		u.knownFiles[runPath] = newSavedFile(
+			u.ctx,
 			u.fs,
 			u.ftm,
 			u.logger,
+			u.contents,
+			u.reports,
 			u.toRealPath(runPath),
 			runPath,
 		)
@@ -230,10 +324,12 @@ func (u *uploader) lockForOperation(method string) bool {
 
 // Uploads the given files unless we are offline.
 //
+// The files are also exported, if there's an exporter, even when offline.
+//
 // This increments `uploadWG` and returns immediately. The wait group is
 // signalled as file uploads finish.
 func (u *uploader) upload(relativePaths []string) {
-	if u.settings.IsOffline() {
+	if u.settings.IsOffline() && u.exporter == nil {
 		return
 	}
 
@@ -241,54 +337,95 @@ func (u *uploader) upload(relativePaths []string) {
 
 	relativePaths = u.filterNonExistingAndWarn(relativePaths)
 	relativePaths = u.filterIgnored(relativePaths)
+
+	for _, path := range relativePaths {
+		u.exporter.ExportRunFile(path, u.toRealPath(path))
+	}
+	if u.settings.IsOffline() {
+		return
+	}
+
+	relativePaths, contents := u.filterUnchanged(relativePaths)
+	if len(relativePaths) == 0 {
+		return
+	}
 	u.uploadWG.Add(len(relativePaths))
+	u.startScheduling(relativePaths)
 
+	// Upload URLs are requested in batches so that a large number of files
+	// doesn't make one huge request; files in a batch are uploaded while
+	// the URLs for the next one are being created.
 	go func() {
-		createRunFilesResponse, err := gql.CreateRunFiles(
-			u.ctx,
-			u.graphQL,
-			u.settings.GetEntity(),
-			u.settings.GetProject(),
-			u.settings.GetRunID(),
-			relativePaths,
-		)
-		if err != nil {
-			u.logger.CaptureError("runfiles: CreateRunFiles returned error", err)
-			u.uploadWG.Add(-len(relativePaths))
-			return
+		batchSize := u.settings.GetUploadURLBatchSize()
+		for start := 0; start < len(relativePaths); start += batchSize {
+			end := min(start+batchSize, len(relativePaths))
+			u.uploadBatch(relativePaths[start:end], contents)
 		}
+	}()
+}
 
-		if len(createRunFilesResponse.CreateRunFiles.Files) != len(relativePaths) {
-			u.logger.CaptureError(
-				"runfiles: CreateRunFiles returned unexpected number of files",
-				nil,
-				"expected",
-				len(relativePaths),
-				"actual",
-				len(createRunFilesResponse.CreateRunFiles.Files),
-			)
-			u.uploadWG.Add(-len(relativePaths))
-			return
-		}
+// uploadBatch creates upload URLs for the files and schedules their
+// uploads.
+//
+// The files must have been added to uploadWG and be marked as scheduling.
+func (u *uploader) uploadBatch(
+	relativePaths []string,
+	contents map[string]*fileContent,
+) {
+	createRunFilesResponse, err := gql.CreateRunFiles(
+		u.ctx,
+		u.graphQL,
+		u.settings.GetEntity(),
+		u.settings.GetProject(),
+		u.settings.GetRunID(),
+		relativePaths,
+	)
+	if err != nil {
+		u.logger.CaptureError("runfiles: CreateRunFiles returned error", err)
+		u.reports.Failed(err, relativePaths...)
+		u.uploadWG.Add(-len(relativePaths))
+		u.stopScheduling(relativePaths...)
+		return
+	}
 
-		for _, f := range createRunFilesResponse.CreateRunFiles.Files {
-			if f.UploadUrl == nil {
-				u.logger.CaptureWarn(
-					"runfiles: CreateRunFiles has empty UploadUrl",
-					"response",
-					createRunFilesResponse,
-				)
-				u.uploadWG.Done()
-				continue
-			}
+	if len(createRunFilesResponse.CreateRunFiles.Files) != len(relativePaths) {
+		u.logger.CaptureError(
+			"runfiles: CreateRunFiles returned unexpected number of files",
+			nil,
+			"expected",
+			len(relativePaths),
+			"actual",
+			len(createRunFilesResponse.CreateRunFiles.Files),
+		)
+		u.reports.Failed(
+			errors.New("runfiles: the server returned the wrong number of files"),
+			relativePaths...,
+		)
+		u.uploadWG.Add(-len(relativePaths))
+		u.stopScheduling(relativePaths...)
+		return
+	}
 
-			u.scheduleUploadTask(
-				f.Name,
-				*f.UploadUrl,
-				createRunFilesResponse.CreateRunFiles.UploadHeaders,
+	for _, f := range createRunFilesResponse.CreateRunFiles.Files {
+		if f.UploadUrl == nil {
+			u.logger.CaptureWarn(
+				"runfiles: CreateRunFiles has empty UploadUrl",
+				"response",
+				createRunFilesResponse,
 			)
+			u.reports.Failed(errors.New("runfiles: no upload URL"), f.Name)
+			u.uploadWG.Done()
+			u.stopScheduling(f.Name)
+			continue
 		}
-	}()
+
+		u.scheduleUploadTask(
+			f.Name,
+			*f.UploadUrl,
+			createRunFilesResponse.CreateRunFiles.UploadHeaders,
+			contents[f.Name],
+		)
+	}
 }
 
 // Warns for any non-existing files and returns a slice without them.
@@ -300,6 +437,7 @@ func (u *uploader) filterNonExistingAndWarn(relativePaths []string) []string {
 
 		if _, err := os.Stat(localPath); os.IsNotExist(err) {
 			u.logger.Warn("runfiles: upload: file does not exist", "path", localPath)
+			u.reports.Failed(errors.New("runfiles: file does not exist"), relativePath)
 		} else {
 			existingRelativePaths = append(existingRelativePaths, relativePath)
 		}
@@ -316,6 +454,14 @@ outerLoop:
 	for _, relativePath := range relativePaths {
 		for _, ignoreGlob := range u.settings.GetIgnoreGlobs() {
 			if matched, _ := filepath.Match(ignoreGlob, relativePath); matched {
+				report := &service.RunFileReport{
+					Path:   relativePath,
+					Status: service.RunFileReport_SKIPPED_IGNORED,
+				}
+				if info, err := os.Stat(u.toRealPath(relativePath)); err == nil {
+					report.Size = info.Size()
+				}
+				u.reports.Set(report)
 				continue outerLoop
 			}
 		}
@@ -326,6 +472,48 @@ outerLoop:
 	return includedPaths
 }
 
+// Filters any paths whose files haven't changed since they were last
+// uploaded, and returns the content of the rest that could be read.
+func (u *uploader) filterUnchanged(
+	relativePaths []string,
+) ([]string, map[string]*fileContent) {
+	changedPaths := make([]string, 0)
+	contents := make(map[string]*fileContent)
+
+	for _, relativePath := range relativePaths {
+		content, unchanged, err := u.contents.Check(
+			relativePath,
+			u.toRealPath(relativePath),
+		)
+
+		switch {
+		case err != nil:
+			u.logger.Warn(
+				"runfiles: upload: failed to read file",
+				"path", relativePath,
+				"error", err,
+			)
+		case unchanged:
+			u.logger.Debug("runfiles: upload: file unchanged", "path", relativePath)
+			if u.stats != nil {
+				u.stats.CountUnchanged(content.size)
+			}
+			u.reports.Set(&service.RunFileReport{
+				Path:   relativePath,
+				Size:   content.size,
+				Status: service.RunFileReport_SKIPPED_UNCHANGED,
+			})
+			continue
+		default:
+			contents[relativePath] = &content
+		}
+
+		changedPaths = append(changedPaths, relativePath)
+	}
+
+	return changedPaths, contents
+}
+
 // Schedules a file upload task.
 //
 // Decrements `uploadWG` when the task is complete or fails.
@@ -333,10 +521,36 @@ func (u *uploader) scheduleUploadTask(
 	relativePath string,
 	uploadURL string,
 	headers []string,
+	content *fileContent,
 ) {
 	u.stateMu.Lock()
 	defer u.stateMu.Unlock()
 
-	u.knownFile(relativePath).Upload(uploadURL, headers)
+	u.knownFile(relativePath).Upload(uploadURL, headers, content)
 	u.uploadWG.Done()
+	u.stopScheduling(relativePath)
+}
+
+// startScheduling records that uploads of the files are being created.
+func (u *uploader) startScheduling(relativePaths []string) {
+	u.schedulingMu.Lock()
+	defer u.schedulingMu.Unlock()
+
+	for _, path := range relativePaths {
+		u.scheduling[path]++
+	}
+}
+
+// stopScheduling records that uploads of the files were scheduled or
+// failed to be created.
+func (u *uploader) stopScheduling(relativePaths ...string) {
+	u.schedulingMu.Lock()
+	defer u.schedulingMu.Unlock()
+
+	for _, path := range relativePaths {
+		u.scheduling[path]--
+		if u.scheduling[path] <= 0 {
+			delete(u.scheduling, path)
+		}
+	}
 }
@@ -1,7 +1,11 @@
 package runhistory
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	// TODO: use simplejsonext for now until we replace the usage of json with
 	// protocol buffer and proto json marshaler
@@ -77,10 +81,12 @@ func (rh *RunHistory) ApplyChangeRecord(
 // The object is serialized to a JSON string.
 // This is needed to send the history to the the backend, which expects a JSON
 // string.
+//
+// Keys are sorted so that the same history always serializes the same way.
 func (rh *RunHistory) Serialize() ([]byte, error) {
 	// A configuration dict in the format expected by the backend.
 	value := rh.pathTree.Tree()
-	return json.Marshal(value)
+	return marshalSorted(value)
 }
 
 // Flatten returns a flat list of history items.
@@ -103,7 +109,7 @@ func (rh *RunHistory) Flatten() ([]*service.HistoryItem, error) {
 			)
 		}
 
-		value, err := json.Marshal(leaf.Value)
+		value, err := marshalSorted(leaf.Value)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"runhistory: failed to marshal value for item %v: %v",
@@ -141,3 +147,100 @@ func keyPath(item *service.HistoryItem) []string {
 	}
 	return []string{item.GetKey()}
 }
+
+// marshalSorted returns the JSON encoding of the value, with the keys of
+// maps in sorted order.
+func marshalSorted(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeSorted(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeSorted(buf *bytes.Buffer, value any) error {
+	switch value := value.(type) {
+	case pathtree.TreeData:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeSorted(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeSorted(buf, value[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+
+	case []any:
+		buf.WriteByte('[')
+		for i, element := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeSorted(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+
+	return nil
+}
+
+// TruncateStrings shortens string values that are longer than maxBytes.
+//
+// Only items whose values are strings are truncated, not strings nested
+// in maps or lists. Strings are cut on a character boundary. It returns
+// the keys of the truncated items, joined by periods if nested.
+func TruncateStrings(items []*service.HistoryItem, maxBytes int) []string {
+	var truncated []string
+
+	for _, item := range items {
+		// a JSON string is never shorter than the string it encodes
+		valueJSON := item.GetValueJson()
+		if len(valueJSON) <= maxBytes || !strings.HasPrefix(valueJSON, `"`) {
+			continue
+		}
+
+		value, err := json.Unmarshal([]byte(valueJSON))
+		if err != nil {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok || len(str) <= maxBytes {
+			continue
+		}
+
+		cut := maxBytes
+		for cut > 0 && !utf8.RuneStart(str[cut]) {
+			cut--
+		}
+		shortened, err := json.Marshal(str[:cut])
+		if err != nil {
+			continue
+		}
+
+		item.ValueJson = string(shortened)
+		truncated = append(truncated, strings.Join(keyPath(item), "."))
+	}
+
+	return truncated
+}
@@ -116,3 +116,81 @@ func TestSerialize(t *testing.T) {
 	}
 
 }
+
+func TestSerializeSortsKeys(t *testing.T) {
+	rh := runhistory.New()
+	rh.ApplyChangeRecord(
+		[]*service.HistoryItem{
+			{Key: "b", ValueJson: `{"y": [{"q": 1, "p": 2}], "x": true}`},
+			{Key: "a", ValueJson: `"text"`},
+		},
+		func(err error) {
+			t.Error("onError should not be called", err)
+		})
+
+	actualJson, err := rh.Serialize()
+	if err != nil {
+		t.Fatal("Serialize failed:", err)
+	}
+
+	expectedJson := `{"a":"text","b":{"x":true,"y":[{"p":2,"q":1}]}}`
+	if string(actualJson) != expectedJson {
+		t.Errorf("Expected %v, got %v", expectedJson, string(actualJson))
+	}
+}
+
+func TestFlattenKeepsTypedValues(t *testing.T) {
+	rh := runhistory.New()
+	rh.ApplyChangeRecord(
+		[]*service.HistoryItem{{
+			Key:       "weights",
+			ValueJson: `{"values": [3, 4], "bins": [0, 0.5, 1], "_type": "histogram"}`,
+		}},
+		func(err error) {
+			t.Error("onError should not be called", err)
+		})
+
+	items, err := rh.Flatten()
+	if err != nil {
+		t.Fatal("Flatten failed:", err)
+	}
+
+	expectedItems := []*service.HistoryItem{{
+		Key:       "weights",
+		ValueJson: `{"_type":"histogram","bins":[0,0.5,1],"values":[3,4]}`,
+	}}
+	if !reflect.DeepEqual(items, expectedItems) {
+		t.Errorf("Expected %v, got %v", expectedItems, items)
+	}
+}
+
+func TestTruncateStrings(t *testing.T) {
+	items := []*service.HistoryItem{
+		{Key: "long", ValueJson: `"abcdef"`},
+		{Key: "short", ValueJson: `"abc"`},
+		{Key: "escaped", ValueJson: `"a\"bc"`},
+		{NestedKey: []string{"nested", "unicode"}, ValueJson: `"abcé"`},
+		{Key: "number", ValueJson: `123456789`},
+		{Key: "dict", ValueJson: `{"text": "abcdef"}`},
+	}
+
+	truncated := runhistory.TruncateStrings(items, 4)
+
+	expectedTruncated := []string{"long", "nested.unicode"}
+	if !reflect.DeepEqual(truncated, expectedTruncated) {
+		t.Errorf("Expected %v, got %v", expectedTruncated, truncated)
+	}
+	expectedValues := []string{
+		`"abcd"`,
+		`"abc"`,
+		`"a\"bc"`,
+		`"abc"`,
+		`123456789`,
+		`{"text": "abcdef"}`,
+	}
+	for i, item := range items {
+		if item.ValueJson != expectedValues[i] {
+			t.Errorf("Expected %v, got %v", expectedValues[i], item.ValueJson)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package runname generates human-friendly display names for runs.
+//
+// Names look like "lucky-river-42": an adjective, a noun and a number.
+// The word lists are embedded from adjectives.txt and nouns.txt, which
+// have one word per line.
+package runname
+
+import (
+	_ "embed"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// maxNumber is one more than the largest number in a generated name.
+const maxNumber = 1000
+
+var (
+	//go:embed adjectives.txt
+	adjectivesData string
+
+	//go:embed nouns.txt
+	nounsData string
+
+	adjectives = strings.Fields(adjectivesData)
+	nouns      = strings.Fields(nounsData)
+)
+
+// Generate returns a display name for the run with the given ID.
+//
+// The name is derived from the run ID alone, so a run always gets the
+// same name, even when it is resumed or synced again.
+func Generate(runID string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(runID))
+	seed := h.Sum64()
+
+	adjective := adjectives[seed%uint64(len(adjectives))]
+	seed /= uint64(len(adjectives))
+
+	noun := nouns[seed%uint64(len(nouns))]
+	seed /= uint64(len(nouns))
+
+	number := seed%(maxNumber-1) + 1
+
+	return fmt.Sprintf("%s-%s-%d", adjective, noun, number)
+}
@@ -0,0 +1,27 @@
+package runname_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/internal/runname"
+)
+
+func TestGenerate_Stable(t *testing.T) {
+	assert.Equal(t, runname.Generate("abc123"), runname.Generate("abc123"))
+	assert.Equal(t, "fanciful-resonance-195", runname.Generate("abc123"))
+}
+
+func TestGenerate_Format(t *testing.T) {
+	format := regexp.MustCompile(`^[a-z]+-[a-z]+-[1-9][0-9]{0,2}$`)
+
+	for _, runID := range []string{"", "a", "run1", "3f9d8c2e", "some-long-run-id"} {
+		assert.Regexp(t, format, runname.Generate(runID))
+	}
+}
+
+func TestGenerate_DependsOnRunID(t *testing.T) {
+	assert.NotEqual(t, runname.Generate("run1"), runname.Generate("run2"))
+}
@@ -0,0 +1,227 @@
+// Package runregistry keeps track of the run directories in a wandb
+// directory.
+//
+// The latest-run link points at the most recent run's directory, and the
+// registry file lists every run with its state, so that tools like sync
+// and cleanup can enumerate runs instead of globbing for them.
+package runregistry
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// FileName is the name of the registry file in a wandb directory.
+const FileName = "runs.jsonl"
+
+// The states of a run in the registry.
+const (
+	StateRunning  = "running"
+	StateFinished = "finished"
+	StateFailed   = "failed"
+
+	// StateInterrupted is the state of a run that was exited because the
+	// service shut down before its process finished it.
+	StateInterrupted = "interrupted"
+
+	// StateCrashed is the state of a run whose service was shut down
+	// without waiting for its data to be uploaded.
+	StateCrashed = "crashed"
+
+	// StateDiscarded is the state of a run that its process aborted, which
+	// is never synced.
+	StateDiscarded = "discarded"
+
+	// StateDeleted is the state of a run whose directory was cleaned up.
+	StateDeleted = "deleted"
+)
+
+var (
+	// updateMu serializes the updates made by runs in this process.
+	updateMu sync.Mutex
+
+	// tmpCounter makes temporary names unique within this process.
+	tmpCounter atomic.Int64
+
+	// useSymlinks is whether the latest-run link is a symlink rather than
+	// a file containing the run directory's path.
+	//
+	// Creating symlinks on Windows requires privileges most users lack.
+	useSymlinks = runtime.GOOS != "windows"
+)
+
+// Entry is a run's line in the registry.
+type Entry struct {
+	RunID     string    `json:"run_id"`
+	Path      string    `json:"path"`
+	SyncFile  string    `json:"sync_file,omitempty"`
+	State     string    `json:"state"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// LogDigest is the final state of the run's transaction log, once the
+	// run ended with a complete log.
+	LogDigest *transactionlog.Digest `json:"log_digest,omitempty"`
+}
+
+// UpdateLatest points the latest-run link at the run directory.
+//
+// The link is replaced atomically, so readers always find either the old
+// run or the new one. It's a symlink where possible, and otherwise a file
+// containing the directory's absolute path.
+func UpdateLatest(link, runDir string) error {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+
+	runDir, err := filepath.Abs(runDir)
+	if err != nil {
+		return err
+	}
+	if useSymlinks {
+		err = symlinkLatest(link, tmpName(link), runDir)
+		if err == nil {
+			return nil
+		}
+	}
+
+	if err := atomicfile.Write(link, []byte(runDir), 0o644); err != nil {
+		return fmt.Errorf("runregistry: %v", err)
+	}
+	return nil
+}
+
+// symlinkLatest replaces the link with a relative symlink to the run
+// directory, through a temporary link.
+func symlinkLatest(link, tmp, runDir string) error {
+	target, err := filepath.Rel(filepath.Dir(link), runDir)
+	if err != nil {
+		target = runDir
+	}
+
+	if err := os.Symlink(target, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// ReadLatest returns the run directory the latest-run link points at.
+func ReadLatest(link string) (string, error) {
+	info, err := os.Lstat(link)
+	if err != nil {
+		return "", err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(link)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(link), target)
+		}
+		return filepath.Clean(target), nil
+	}
+
+	content, err := os.ReadFile(link)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// tmpName returns a name next to the path that no other update uses.
+func tmpName(path string) string {
+	return fmt.Sprintf("%s.%d.%d.tmp", path, os.Getpid(), tmpCounter.Add(1))
+}
+
+// Registry is the registry file of a wandb directory.
+type Registry struct {
+	path string
+}
+
+// New returns the registry of the wandb directory.
+func New(wandbDir string) *Registry {
+	return &Registry{path: filepath.Join(wandbDir, FileName)}
+}
+
+// Path is the registry file's path.
+func (r *Registry) Path() string {
+	return r.path
+}
+
+// Record appends the entry to the registry.
+//
+// An entry replaces the earlier entries for the same run. Each entry is a
+// single append, so entries from other processes don't interleave.
+func (r *Registry) Record(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	updateMu.Lock()
+	defer updateMu.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("runregistry: can't open %s: %v", r.path, err)
+	}
+	_, err = file.Write(line)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("runregistry: can't write %s: %v", r.path, err)
+	}
+	return nil
+}
+
+// Runs returns the latest entry of each run, in the order the runs were
+// first recorded.
+//
+// Lines that can't be parsed, like one cut short by a crash, are skipped.
+func (r *Registry) Runs() ([]Entry, error) {
+	file, err := os.Open(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var runs []Entry
+	index := make(map[string]int)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		if i, ok := index[entry.RunID]; ok {
+			runs[i] = entry
+		} else {
+			index[entry.RunID] = len(runs)
+			runs = append(runs, entry)
+		}
+	}
+	return runs, scanner.Err()
+}
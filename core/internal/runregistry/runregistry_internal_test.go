@@ -0,0 +1,45 @@
+package runregistry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateLatest_PointerFileWithoutSymlinks(t *testing.T) {
+	useSymlinks = false
+	t.Cleanup(func() { useSymlinks = true })
+	wandbDir := t.TempDir()
+	link := filepath.Join(wandbDir, "latest-run")
+	runDir := filepath.Join(wandbDir, "run-1")
+
+	require.NoError(t, UpdateLatest(link, filepath.Join(wandbDir, "run-0")))
+	require.NoError(t, UpdateLatest(link, runDir))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+	content, err := os.ReadFile(link)
+	require.NoError(t, err)
+	assert.Equal(t, runDir, string(content))
+	latest, err := ReadLatest(link)
+	require.NoError(t, err)
+	assert.Equal(t, runDir, latest)
+}
+
+func TestUpdateLatest_PointerFileReplacesSymlink(t *testing.T) {
+	wandbDir := t.TempDir()
+	link := filepath.Join(wandbDir, "latest-run")
+	require.NoError(t, UpdateLatest(link, filepath.Join(wandbDir, "run-0")))
+	useSymlinks = false
+	t.Cleanup(func() { useSymlinks = true })
+
+	require.NoError(t, UpdateLatest(link, filepath.Join(wandbDir, "run-1")))
+
+	latest, err := ReadLatest(link)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(wandbDir, "run-1"), latest)
+}
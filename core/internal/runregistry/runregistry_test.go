@@ -0,0 +1,129 @@
+package runregistry_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runregistry"
+)
+
+func TestUpdateLatest_ReplacesLink(t *testing.T) {
+	wandbDir := t.TempDir()
+	link := filepath.Join(wandbDir, "latest-run")
+	first := filepath.Join(wandbDir, "run-1")
+	second := filepath.Join(wandbDir, "run-2")
+
+	require.NoError(t, runregistry.UpdateLatest(link, first))
+	require.NoError(t, runregistry.UpdateLatest(link, second))
+
+	latest, err := runregistry.ReadLatest(link)
+	require.NoError(t, err)
+	assert.Equal(t, second, latest)
+	target, err := os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, "run-2", target)
+}
+
+func TestUpdateLatest_ConcurrentRuns(t *testing.T) {
+	wandbDir := t.TempDir()
+	link := filepath.Join(wandbDir, "latest-run")
+	runDirs := make(map[string]bool)
+	for i := range 20 {
+		runDirs[filepath.Join(wandbDir, fmt.Sprintf("run-%d", i))] = true
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	readErrs := make(chan error, 1)
+	require.NoError(t, runregistry.UpdateLatest(link, filepath.Join(wandbDir, "run-0")))
+	go func() {
+		// The link is replaced atomically, so it's never missing.
+		for {
+			select {
+			case <-stop:
+				close(readErrs)
+				return
+			default:
+			}
+			if _, err := runregistry.ReadLatest(link); err != nil {
+				readErrs <- err
+				close(readErrs)
+				return
+			}
+		}
+	}()
+	for runDir := range runDirs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				assert.NoError(t, runregistry.UpdateLatest(link, runDir))
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+
+	assert.NoError(t, <-readErrs)
+	latest, err := runregistry.ReadLatest(link)
+	require.NoError(t, err)
+	assert.True(t, runDirs[latest], "unexpected latest run %s", latest)
+	entries, err := os.ReadDir(wandbDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary links were left behind")
+}
+
+func TestRegistry_Runs(t *testing.T) {
+	registry := runregistry.New(t.TempDir())
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	running := func(runID string) runregistry.Entry {
+		return runregistry.Entry{
+			RunID:     runID,
+			Path:      filepath.Join("wandb", "run-"+runID),
+			State:     runregistry.StateRunning,
+			StartedAt: started,
+			UpdatedAt: started,
+		}
+	}
+
+	require.NoError(t, registry.Record(running("a")))
+	require.NoError(t, registry.Record(running("b")))
+	finished := running("a")
+	finished.State = runregistry.StateFinished
+	finished.UpdatedAt = started.Add(time.Minute)
+	require.NoError(t, registry.Record(finished))
+
+	runs, err := registry.Runs()
+	require.NoError(t, err)
+	assert.Equal(t, []runregistry.Entry{finished, running("b")}, runs)
+}
+
+func TestRegistry_SkipsTruncatedLines(t *testing.T) {
+	wandbDir := t.TempDir()
+	registry := runregistry.New(wandbDir)
+	require.NoError(t, os.WriteFile(
+		filepath.Join(wandbDir, runregistry.FileName),
+		[]byte(`{"run_id":"a","state":"running"}`+"\n"+`{"run_id":"b","st`),
+		0o644,
+	))
+
+	runs, err := registry.Runs()
+
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "a", runs[0].RunID)
+}
+
+func TestRegistry_NoFile(t *testing.T) {
+	runs, err := runregistry.New(t.TempDir()).Runs()
+
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+}
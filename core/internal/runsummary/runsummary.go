@@ -2,6 +2,8 @@ package runsummary
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	// TODO: use simplejsonext for now until we replace the usage of json with
 	// protocol buffer and proto json marshaler
@@ -27,10 +29,15 @@ func NewFrom(tree pathtree.TreeData) *RunSummary {
 //
 // Does a best-effort job to apply all changes. Errors are passed to `onError`
 // and skipped.
+//
+// A value in the way of an update is replaced: a leaf by the map that
+// a nested key needs, or a map by a leaf. The keys of the replaced values
+// are returned, joined by periods. Maps left empty by a removal are
+// removed too.
 func (rs *RunSummary) ApplyChangeRecord(
 	summaryRecord *service.SummaryRecord,
 	onError func(error),
-) {
+) []string {
 
 	updates := make([]*pathtree.PathItem, 0, len(summaryRecord.GetUpdate()))
 	for _, item := range summaryRecord.GetUpdate() {
@@ -44,7 +51,10 @@ func (rs *RunSummary) ApplyChangeRecord(
 			Value: update,
 		})
 	}
-	rs.pathTree.ApplyUpdate(updates, onError)
+	var replaced []string
+	for _, path := range rs.pathTree.ApplyUpdateReplacing(updates) {
+		replaced = append(replaced, strings.Join(path, "."))
+	}
 
 	removes := make([]*pathtree.PathItem, 0, len(summaryRecord.GetRemove()))
 	for _, item := range summaryRecord.GetRemove() {
@@ -52,7 +62,36 @@ func (rs *RunSummary) ApplyChangeRecord(
 			Path: keyPath(item),
 		})
 	}
-	rs.pathTree.ApplyRemove(removes)
+	rs.pathTree.ApplyRemoveAndPrune(removes)
+
+	return replaced
+}
+
+// ExpandDottedKeys turns the dotted keys of the record's items into
+// nested keys, so that "eval.accuracy" sets "accuracy" in the "eval" map.
+//
+// Keys with empty parts, like "a..b" or ".a", are kept as they are.
+func ExpandDottedKeys(summaryRecord *service.SummaryRecord) {
+	for _, item := range summaryRecord.GetUpdate() {
+		expandDottedKey(item)
+	}
+	for _, item := range summaryRecord.GetRemove() {
+		expandDottedKey(item)
+	}
+}
+
+func expandDottedKey(item *service.SummaryItem) {
+	if len(item.GetNestedKey()) > 0 || !strings.Contains(item.GetKey(), ".") {
+		return
+	}
+
+	path := strings.Split(item.GetKey(), ".")
+	if slices.Contains(path, "") {
+		return
+	}
+
+	item.Key = ""
+	item.NestedKey = path
 }
 
 // Flatten the summary tree into a slice of SummaryItems.
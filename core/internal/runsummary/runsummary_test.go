@@ -156,3 +156,85 @@ func TestSerialize(t *testing.T) {
 	}
 
 }
+
+// TestApplyUpdateReplacesTypedValues checks that a newer histogram
+// replaces the older one instead of being merged into it.
+func TestApplyUpdateReplacesTypedValues(t *testing.T) {
+
+	rs := runsummary.New()
+	for _, valueJson := range []string{
+		`{"_type": "histogram", "bins": [0, 1, 2], "values": [1, 2]}`,
+		`{"_type": "histogram", "packedBins": {"min": 0, "size": 1, "count": 1}, "values": [5]}`,
+	} {
+		rs.ApplyChangeRecord(
+			&service.SummaryRecord{
+				Update: []*service.SummaryItem{{Key: "weights", ValueJson: valueJson}},
+			},
+			func(err error) {
+				t.Error("onError should not be called", err)
+			})
+	}
+
+	items, err := rs.Flatten()
+	if err != nil {
+		t.Fatal("Flatten failed:", err)
+	}
+
+	if len(items) != 1 || items[0].Key != "weights" ||
+		strings.Contains(items[0].ValueJson, `"bins"`) {
+		t.Errorf("Expected only the latest histogram, got %v", items)
+	}
+}
+
+func TestDottedKeys(t *testing.T) {
+
+	rs := runsummary.New()
+	apply := func(summary *service.SummaryRecord) []string {
+		runsummary.ExpandDottedKeys(summary)
+		return rs.ApplyChangeRecord(summary,
+			func(err error) {
+				t.Error("onError should not be called", err)
+			})
+	}
+
+	apply(&service.SummaryRecord{
+		Update: []*service.SummaryItem{
+			{Key: "eval.accuracy", ValueJson: "0.5"},
+			{Key: "eval.loss", ValueJson: "2"},
+			{Key: "eval/f1", ValueJson: "0.25"},
+			{Key: "odd..key", ValueJson: "1"},
+			{Key: "old.metric", ValueJson: "3"},
+		},
+	})
+	replaced := apply(&service.SummaryRecord{
+		Update: []*service.SummaryItem{
+			{Key: "eval.accuracy.top1", ValueJson: "0.75"},
+		},
+		Remove: []*service.SummaryItem{
+			{Key: "eval.loss"},
+			{Key: "old.metric"},
+		},
+	})
+
+	expectedTree := pathtree.TreeData{
+		"eval": pathtree.TreeData{
+			"accuracy": pathtree.TreeData{"top1": float64(0.75)},
+		},
+		"eval/f1":  float64(0.25),
+		"odd..key": int64(1),
+	}
+	if !reflect.DeepEqual(rs.Tree(), expectedTree) {
+		t.Errorf("Expected %v, got %v", expectedTree, rs.Tree())
+	}
+	if !reflect.DeepEqual(replaced, []string{"eval.accuracy"}) {
+		t.Errorf("Expected eval.accuracy to be replaced, got %v", replaced)
+	}
+
+	actualJson, err := rs.Serialize()
+	if err != nil {
+		t.Fatal("Serialize failed:", err)
+	}
+	if !strings.Contains(string(actualJson), `"eval":{"accuracy":{"top1":0.75}}`) {
+		t.Errorf("Expected nested eval summary, got %v", string(actualJson))
+	}
+}
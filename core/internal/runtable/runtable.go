@@ -0,0 +1,548 @@
+// Package runtable keeps the tables that a run logs a few rows at a time.
+//
+// Instead of logging a whole wandb.Table each time it grows, a client can
+// append rows to it with TableRowsRecords. The rows are kept here, in memory
+// up to a limit and in a spill file beyond it, and the complete table is
+// written as a run file when the run is flushed:
+//
+//	<files dir>/media/table/<key>.table.json
+//
+// in the format of a logged wandb.Table, with the type of each column
+// inferred from its values. Only the tables that changed since they were
+// last written are written again.
+package runtable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/wandb/wandb/core/internal/runtmp"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// TableDir is the directory of the tables, relative to the run's files
+	// directory.
+	TableDir = "media/table"
+
+	// defaultMaxRowsInMemory is how many rows of a table are kept in
+	// memory by default before they're spilled to a file.
+	defaultMaxRowsInMemory = 1000
+)
+
+// The types of the values in a column, as named by wandb's data types.
+const (
+	typeNone    = "none"
+	typeBoolean = "boolean"
+	typeNumber  = "number"
+	typeString  = "string"
+	typeList    = "list"
+	typeObject  = "object"
+
+	// typeAny is the type of columns whose values have different types,
+	// and of columns of lists or objects, whose types aren't inferred.
+	typeAny = "any"
+)
+
+// Params configure Tables.
+type Params struct {
+	// FilesDir is the run's files directory.
+	FilesDir string
+
+	// MaxRowsInMemory is how many rows of a table are kept in memory
+	// before they're spilled to a file, or 0 for a default.
+	MaxRowsInMemory int
+
+	// TempDir is the stream's temporary directory, where the rows are
+	// spilled and the tables' files are written before they're promoted
+	// into the files directory.
+	//
+	// If it's nil, they're written in the files directory under hidden
+	// names.
+	TempDir *runtmp.Dir
+
+	Logger *observability.CoreLogger
+}
+
+// Tables are the tables a run logs incrementally, by their keys.
+//
+// The methods of nil Tables do nothing.
+type Tables struct {
+	filesDir        string
+	maxRowsInMemory int
+	tempDir         *runtmp.Dir
+	logger          *observability.CoreLogger
+
+	tables map[string]*table
+}
+
+// table is a table's columns and rows.
+type table struct {
+	key     string
+	columns []string
+
+	// types are the types inferred for the columns, or typeNone while
+	// a column has only had null values
+	types []string
+
+	// conflicted is whether each column had values of different types
+	conflicted []bool
+
+	// rows are the rows kept in memory, which come after those spilled
+	rows [][]json.RawMessage
+
+	// spilled is how many rows are in the spill file
+	spilled int
+
+	// spillPath is the path of the spill file, once rows are spilled
+	spillPath string
+
+	// dirty is whether the table changed since it was last written
+	dirty bool
+}
+
+// TypeConflict is a column with values of different types.
+//
+// The column's type is logged as "any".
+type TypeConflict struct {
+	Table  string
+	Column string
+
+	// Type is the column's type before the conflicting value.
+	Type string
+
+	// ConflictingType is the type of the conflicting value.
+	ConflictingType string
+}
+
+func (c TypeConflict) String() string {
+	return fmt.Sprintf(
+		"Column %q of table %q has both %s and %s values,"+
+			" so its type is logged as any.",
+		c.Column,
+		c.Table,
+		c.Type,
+		c.ConflictingType,
+	)
+}
+
+// New returns the run's tables, or nil if the run has no files directory.
+func New(params Params) *Tables {
+	if params.FilesDir == "" {
+		return nil
+	}
+
+	if params.MaxRowsInMemory <= 0 {
+		params.MaxRowsInMemory = defaultMaxRowsInMemory
+	}
+	if params.Logger == nil {
+		params.Logger = observability.NewNoOpLogger()
+	}
+
+	return &Tables{
+		filesDir:        params.FilesDir,
+		maxRowsInMemory: params.MaxRowsInMemory,
+		tempDir:         params.TempDir,
+		logger:          params.Logger,
+		tables:          make(map[string]*table),
+	}
+}
+
+// RunPath returns the path of a table's file relative to the run's files
+// directory.
+func RunPath(key string) string {
+	return path.Join(TableDir, key+".table.json")
+}
+
+// Append adds rows to a table, creating it if it's new.
+//
+// It returns the columns that got values of a different type than before,
+// the first time they do. The record is rejected if its columns don't
+// match the table's or its values aren't valid JSON.
+func (t *Tables) Append(record *service.TableRowsRecord) ([]TypeConflict, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	key := record.GetKey()
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return nil, fmt.Errorf("runtable: invalid table key %q", key)
+	}
+
+	tbl := t.tables[key]
+	if tbl == nil {
+		if len(record.GetColumns()) == 0 {
+			return nil, fmt.Errorf("runtable: table %q has no columns", key)
+		}
+		tbl = &table{
+			key:        key,
+			columns:    slices.Clone(record.GetColumns()),
+			types:      make([]string, len(record.GetColumns())),
+			conflicted: make([]bool, len(record.GetColumns())),
+			dirty:      true,
+		}
+		for i := range tbl.types {
+			tbl.types[i] = typeNone
+		}
+		t.tables[key] = tbl
+	} else if len(record.GetColumns()) > 0 &&
+		!slices.Equal(record.GetColumns(), tbl.columns) {
+		return nil, fmt.Errorf("runtable: columns of table %q changed", key)
+	}
+
+	rows := make([][]json.RawMessage, 0, len(record.GetRows()))
+	for _, row := range record.GetRows() {
+		values := row.GetValuesJson()
+		if len(values) != len(tbl.columns) {
+			return nil, fmt.Errorf(
+				"runtable: row of table %q has %d values for %d columns",
+				key, len(values), len(tbl.columns))
+		}
+
+		rawValues := make([]json.RawMessage, len(values))
+		for i, value := range values {
+			if !json.Valid([]byte(value)) {
+				return nil, fmt.Errorf(
+					"runtable: invalid value in column %q of table %q",
+					tbl.columns[i], key)
+			}
+			rawValues[i] = json.RawMessage(value)
+		}
+		rows = append(rows, rawValues)
+	}
+
+	var conflicts []TypeConflict
+	for _, row := range rows {
+		conflicts = append(conflicts, tbl.inferTypes(row)...)
+	}
+
+	if len(rows) > 0 {
+		tbl.rows = append(tbl.rows, rows...)
+		tbl.dirty = true
+	}
+	if len(tbl.rows) > t.maxRowsInMemory {
+		if err := t.spill(tbl); err != nil {
+			// the rows stay in memory, so nothing is lost
+			t.logger.Error(
+				"runtable: failed to spill rows",
+				"table", key,
+				"error", err,
+			)
+		}
+	}
+
+	return conflicts, nil
+}
+
+// inferTypes updates the types of the columns with the row's values.
+func (tbl *table) inferTypes(row []json.RawMessage) []TypeConflict {
+	var conflicts []TypeConflict
+
+	for i, value := range row {
+		valueType := typeOf(value)
+		switch {
+		case valueType == typeNone, valueType == tbl.types[i]:
+		case tbl.types[i] == typeNone:
+			tbl.types[i] = valueType
+		case !tbl.conflicted[i]:
+			tbl.conflicted[i] = true
+			conflicts = append(conflicts, TypeConflict{
+				Table:           tbl.key,
+				Column:          tbl.columns[i],
+				Type:            tbl.types[i],
+				ConflictingType: valueType,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// typeOf returns the type of a valid JSON value.
+func typeOf(value json.RawMessage) string {
+	value = bytes.TrimSpace(value)
+	switch value[0] {
+	case 'n':
+		return typeNone
+	case 't', 'f':
+		return typeBoolean
+	case '"':
+		return typeString
+	case '[':
+		return typeList
+	case '{':
+		return typeObject
+	default:
+		return typeNumber
+	}
+}
+
+// Flush writes the tables that changed since they were last written.
+//
+// It returns the paths of the written tables relative to the run's files
+// directory, sorted. Tables that fail to be written are skipped, and are
+// written again on the next flush.
+func (t *Tables) Flush() ([]string, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	var written []string
+	var errs []error
+	for key, tbl := range t.tables {
+		if !tbl.dirty {
+			continue
+		}
+
+		if err := t.write(tbl); err != nil {
+			errs = append(errs, fmt.Errorf("runtable: table %q: %v", key, err))
+			continue
+		}
+
+		tbl.dirty = false
+		written = append(written, RunPath(key))
+	}
+
+	slices.Sort(written)
+	return written, errors.Join(errs...)
+}
+
+// Close deletes the files the tables' rows were spilled to.
+func (t *Tables) Close() {
+	if t == nil {
+		return
+	}
+
+	for _, tbl := range t.tables {
+		if tbl.spillPath == "" {
+			continue
+		}
+		if err := os.Remove(tbl.spillPath); err != nil {
+			t.logger.Warn(
+				"runtable: failed to delete spill file",
+				"table", tbl.key,
+				"error", err,
+			)
+		}
+	}
+}
+
+// filePath returns the path of the table's file.
+func (t *Tables) filePath(tbl *table) string {
+	return filepath.Join(t.filesDir, filepath.FromSlash(RunPath(tbl.key)))
+}
+
+// newSpillPath returns the path of the file to spill the table's rows to.
+//
+// It's in the temporary directory if there's one, and otherwise next to
+// the table, but hidden so that it isn't uploaded with the run's other
+// files.
+func (t *Tables) newSpillPath(tbl *table) (string, error) {
+	if t.tempDir == nil {
+		dir, name := filepath.Split(t.filePath(tbl))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "."+name+".rows"), nil
+	}
+
+	file, err := t.tempDir.CreateTemp("table-*.rows")
+	if err != nil {
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// spill moves the rows kept in memory to the end of the spill file, one
+// JSON array per line.
+func (t *Tables) spill(tbl *table) error {
+	if tbl.spillPath == "" {
+		spillPath, err := t.newSpillPath(tbl)
+		if err != nil {
+			return err
+		}
+		tbl.spillPath = spillPath
+	}
+
+	file, err := os.OpenFile(tbl.spillPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, row := range tbl.rows {
+		line, err := json.Marshal(row)
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		_, _ = writer.Write(line)
+		_ = writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	tbl.spilled += len(tbl.rows)
+	tbl.rows = nil
+	return nil
+}
+
+// write writes the table's file, replacing it atomically.
+func (t *Tables) write(tbl *table) error {
+	filePath := t.filePath(tbl)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := t.createTemp(filePath)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	writer := bufio.NewWriter(tmp)
+	err = t.encode(writer, tbl)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = t.promote(tmpPath, filePath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// createTemp creates the temporary file that the file at the path is
+// written to before it's replaced.
+func (t *Tables) createTemp(filePath string) (*os.File, error) {
+	pattern := fmt.Sprintf(".%s.*.tmp", filepath.Base(filePath))
+	if t.tempDir == nil {
+		return os.CreateTemp(filepath.Dir(filePath), pattern)
+	}
+	return t.tempDir.CreateTemp(pattern)
+}
+
+// promote replaces the file at the path with the temporary file.
+func (t *Tables) promote(tmpPath, filePath string) error {
+	if t.tempDir == nil {
+		return os.Rename(tmpPath, filePath)
+	}
+	return t.tempDir.Promote(tmpPath, filePath)
+}
+
+// encode writes the table in the format of a logged wandb.Table.
+//
+// The rows are streamed from the spill file rather than loaded.
+func (t *Tables) encode(w io.Writer, tbl *table) error {
+	columns, err := json.Marshal(tbl.columns)
+	if err != nil {
+		return err
+	}
+	columnTypes, err := json.Marshal(tbl.columnTypes())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w,
+		`{"_type":"table","columns":%s,"column_types":%s,"data":[`,
+		columns,
+		columnTypes,
+	)
+	if err != nil {
+		return err
+	}
+
+	nrows := 0
+	writeRow := func(row []byte) error {
+		if nrows > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		nrows++
+		_, err := w.Write(row)
+		return err
+	}
+
+	if tbl.spilled > 0 {
+		if err := t.encodeSpilled(tbl, writeRow); err != nil {
+			return err
+		}
+	}
+	for _, row := range tbl.rows {
+		line, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		if err := writeRow(line); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, `],"ncols":%d,"nrows":%d}`, len(tbl.columns), nrows)
+	return err
+}
+
+// encodeSpilled passes each row in the table's spill file to writeRow.
+func (t *Tables) encodeSpilled(tbl *table, writeRow func([]byte) error) error {
+	file, err := os.Open(tbl.spillPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for rows := 0; rows < tbl.spilled; rows++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return fmt.Errorf("reading spilled rows: %v", err)
+		}
+		if err := writeRow(bytes.TrimSuffix(line, []byte("\n"))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// columnTypes returns the types of the table's columns as a wandb
+// typedDict.
+func (tbl *table) columnTypes() map[string]any {
+	typeMap := make(map[string]any, len(tbl.columns))
+	for i, column := range tbl.columns {
+		columnType := tbl.types[i]
+		if tbl.conflicted[i] || columnType == typeList || columnType == typeObject {
+			columnType = typeAny
+		}
+		typeMap[column] = map[string]string{"wb_type": columnType}
+	}
+
+	return map[string]any{
+		"wb_type": "typedDict",
+		"params":  map[string]any{"type_map": typeMap},
+	}
+}
@@ -0,0 +1,178 @@
+package runtable_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runtable"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// tableFile is the JSON of a written table.
+type tableFile struct {
+	Type        string          `json:"_type"`
+	Columns     []string        `json:"columns"`
+	ColumnTypes json.RawMessage `json:"column_types"`
+	Data        [][]any         `json:"data"`
+	NCols       int             `json:"ncols"`
+	NRows       int             `json:"nrows"`
+}
+
+func readTable(t *testing.T, filesDir, key string) tableFile {
+	content, err := os.ReadFile(
+		filepath.Join(filesDir, filepath.FromSlash(runtable.RunPath(key))))
+	require.NoError(t, err)
+	var table tableFile
+	require.NoError(t, json.Unmarshal(content, &table))
+	return table
+}
+
+func rowsRecord(key string, columns []string, rows ...[]string) *service.TableRowsRecord {
+	record := &service.TableRowsRecord{Key: key, Columns: columns}
+	for _, row := range rows {
+		record.Rows = append(record.Rows, &service.TableRow{ValuesJson: row})
+	}
+	return record
+}
+
+func TestAppend_WritesCompleteTableWithSpilledRows(t *testing.T) {
+	filesDir := t.TempDir()
+	tables := runtable.New(runtable.Params{FilesDir: filesDir, MaxRowsInMemory: 3})
+
+	for step := range 10 {
+		_, err := tables.Append(rowsRecord("preds", []string{"step", "label"},
+			[]string{fmt.Sprint(step), fmt.Sprintf("%q", fmt.Sprint("cat", step))}))
+		require.NoError(t, err)
+	}
+	written, err := tables.Flush()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"media/table/preds.table.json"}, written)
+	table := readTable(t, filesDir, "preds")
+	assert.Equal(t, "table", table.Type)
+	assert.Equal(t, []string{"step", "label"}, table.Columns)
+	assert.Equal(t, 2, table.NCols)
+	assert.Equal(t, 10, table.NRows)
+	require.Len(t, table.Data, 10)
+	for step, row := range table.Data {
+		assert.Equal(t, []any{float64(step), fmt.Sprint("cat", step)}, row)
+	}
+	assert.JSONEq(t,
+		`{"wb_type": "typedDict", "params": {"type_map": {
+			"step": {"wb_type": "number"},
+			"label": {"wb_type": "string"}
+		}}}`,
+		string(table.ColumnTypes))
+}
+
+func TestFlush_OnlyChangedTables(t *testing.T) {
+	filesDir := t.TempDir()
+	tables := runtable.New(runtable.Params{FilesDir: filesDir})
+	_, err := tables.Append(rowsRecord("a", []string{"x"}, []string{"1"}))
+	require.NoError(t, err)
+	_, err = tables.Append(rowsRecord("b", []string{"x"}, []string{"1"}))
+	require.NoError(t, err)
+
+	first, err := tables.Flush()
+	require.NoError(t, err)
+	_, err = tables.Append(rowsRecord("b", nil, []string{"2"}))
+	require.NoError(t, err)
+	second, err := tables.Flush()
+	require.NoError(t, err)
+	third, err := tables.Flush()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{"media/table/a.table.json", "media/table/b.table.json"},
+		first)
+	assert.Equal(t, []string{"media/table/b.table.json"}, second)
+	assert.Empty(t, third)
+	assert.Equal(t, 2, readTable(t, filesDir, "b").NRows)
+}
+
+func TestAppend_TypeConflictReportedOnce(t *testing.T) {
+	tables := runtable.New(runtable.Params{FilesDir: t.TempDir()})
+
+	conflicts1, err1 := tables.Append(rowsRecord("t", []string{"score", "name"},
+		[]string{"null", `"a"`},
+		[]string{"1.5", `"b"`}))
+	conflicts2, err2 := tables.Append(rowsRecord("t", nil,
+		[]string{`"high"`, `"c"`}))
+	conflicts3, err3 := tables.Append(rowsRecord("t", nil,
+		[]string{"true", `"d"`}))
+
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	require.NoError(t, err3)
+	assert.Empty(t, conflicts1)
+	assert.Equal(t,
+		[]runtable.TypeConflict{{
+			Table:           "t",
+			Column:          "score",
+			Type:            "number",
+			ConflictingType: "string",
+		}},
+		conflicts2)
+	assert.Empty(t, conflicts3)
+	assert.Contains(t, conflicts2[0].String(), `Column "score" of table "t"`)
+}
+
+func TestAppend_RejectsInvalidRecords(t *testing.T) {
+	tables := runtable.New(runtable.Params{FilesDir: t.TempDir()})
+	_, err := tables.Append(rowsRecord("t", []string{"x", "y"}, []string{"1", "2"}))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		record *service.TableRowsRecord
+	}{
+		{"no key", rowsRecord("", []string{"x"})},
+		{"key outside table dir", rowsRecord("../t", []string{"x"})},
+		{"no columns", rowsRecord("new", nil)},
+		{"changed columns", rowsRecord("t", []string{"x"})},
+		{"wrong width", rowsRecord("t", nil, []string{"1"})},
+		{"invalid JSON", rowsRecord("t", nil, []string{"1", "{"})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tables.Append(tc.record)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClose_DeletesSpillFiles(t *testing.T) {
+	filesDir := t.TempDir()
+	tables := runtable.New(runtable.Params{FilesDir: filesDir, MaxRowsInMemory: 1})
+	_, err := tables.Append(rowsRecord("t", []string{"x"}, []string{"1"}, []string{"2"}))
+	require.NoError(t, err)
+	_, err = tables.Flush()
+	require.NoError(t, err)
+
+	tables.Close()
+
+	entries, err := os.ReadDir(filepath.Join(filesDir, "media", "table"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "t.table.json", entries[0].Name())
+}
+
+func TestNil(t *testing.T) {
+	tables := runtable.New(runtable.Params{})
+
+	assert.Nil(t, tables)
+	conflicts, err := tables.Append(rowsRecord("t", []string{"x"}))
+	assert.Nil(t, conflicts)
+	assert.NoError(t, err)
+	written, err := tables.Flush()
+	assert.Nil(t, written)
+	assert.NoError(t, err)
+	tables.Close()
+}
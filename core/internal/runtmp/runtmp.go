@@ -0,0 +1,264 @@
+// Package runtmp manages the scratch space of a run's stream.
+//
+// Each stream gets a temporary directory in its run directory, created the
+// first time it's needed and named after the process:
+//
+//	<run dir>/.wandb-tmp-<pid>-<random>
+//
+// The stream removes it when it closes, even by force. A directory left
+// behind by a process that crashed is removed by the sweep of a later
+// stream, once its process is no longer running.
+//
+// Files are written in the temporary directory and then promoted into the
+// run's files directory, so that a crash never leaves a partial file
+// there to be uploaded.
+package runtmp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// dirPrefix starts the names of the temporary directories.
+const dirPrefix = ".wandb-tmp-"
+
+var (
+	// activeMu guards active.
+	activeMu sync.Mutex
+
+	// active are the paths of the temporary directories of this process
+	// that haven't been removed.
+	active = map[string]bool{}
+)
+
+// Dir is a stream's temporary directory.
+//
+// The methods of a nil Dir fail, except for Remove which does nothing.
+type Dir struct {
+	parent string
+
+	mu      sync.Mutex
+	path    string
+	removed bool
+}
+
+// New returns the temporary directory of a stream in the run directory,
+// without creating it, or nil if there's no run directory.
+func New(runDir string) *Dir {
+	if runDir == "" {
+		return nil
+	}
+	return &Dir{parent: runDir}
+}
+
+// Path returns the directory's path, creating it if it doesn't exist yet.
+func (d *Dir) Path() (string, error) {
+	if d == nil {
+		return "", errors.New("runtmp: no temporary directory")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.removed {
+		return "", errors.New("runtmp: temporary directory was removed")
+	}
+	if d.path != "" {
+		return d.path, nil
+	}
+
+	if err := os.MkdirAll(d.parent, 0o755); err != nil {
+		return "", fmt.Errorf("runtmp: can't create %s: %v", d.parent, err)
+	}
+
+	// the directory is marked active as it's created, so that a sweep
+	// never sees it otherwise
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	path, err := os.MkdirTemp(d.parent, fmt.Sprintf("%s%d-", dirPrefix, os.Getpid()))
+	if err != nil {
+		return "", fmt.Errorf("runtmp: can't create a temporary directory: %v", err)
+	}
+	active[path] = true
+
+	d.path = path
+	return path, nil
+}
+
+// CreateTemp creates a new file in the directory, like os.CreateTemp.
+func (d *Dir) CreateTemp(pattern string) (*os.File, error) {
+	path, err := d.Path()
+	if err != nil {
+		return nil, err
+	}
+	return os.CreateTemp(path, pattern)
+}
+
+// Promote moves a file from the directory to the destination, replacing
+// any file there atomically.
+//
+// The destination's directory is created if needed. If the file can't be
+// renamed there, such as because it's on another device, it's copied to a
+// temporary file next to the destination that's renamed instead.
+func (d *Dir) Promote(tmpPath, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("runtmp: can't create the directory of %s: %v", destPath, err)
+	}
+
+	if err := os.Rename(tmpPath, destPath); err == nil {
+		return nil
+	}
+
+	if err := copyReplace(tmpPath, destPath); err != nil {
+		return fmt.Errorf("runtmp: can't promote %s to %s: %v", tmpPath, destPath, err)
+	}
+	_ = os.Remove(tmpPath)
+	return nil
+}
+
+// copyReplace copies the file at src over dst through a temporary file in
+// dst's directory.
+func copyReplace(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp(
+		filepath.Dir(dst),
+		fmt.Sprintf(".%s.*.tmp", filepath.Base(dst)),
+	)
+	if err != nil {
+		return err
+	}
+	outPath := out.Name()
+
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(outPath, dst)
+	}
+	if err != nil {
+		_ = os.Remove(outPath)
+		return err
+	}
+	return nil
+}
+
+// Remove deletes the directory and everything in it.
+//
+// The directory can't be used after it's removed.
+func (d *Dir) Remove() error {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.removed = true
+	if d.path == "" {
+		return nil
+	}
+
+	activeMu.Lock()
+	delete(active, d.path)
+	activeMu.Unlock()
+
+	if err := os.RemoveAll(d.path); err != nil {
+		return fmt.Errorf("runtmp: can't remove %s: %v", d.path, err)
+	}
+	return nil
+}
+
+// Sweep removes the temporary directories in the run directories that
+// were left behind by streams that are gone, and returns their paths.
+//
+// A directory is left behind if its process isn't running. A directory of
+// this process is left behind if no stream is using it, which happens when
+// a crashed process had the same PID, as is common in containers.
+func Sweep(runDirs []string) ([]string, error) {
+	var removed []string
+	var errs []error
+
+	for _, runDir := range runDirs {
+		entries, err := os.ReadDir(runDir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(runDir, entry.Name())
+			if !isOrphaned(path, entry.Name()) {
+				continue
+			}
+
+			if err := os.RemoveAll(path); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			removed = append(removed, path)
+		}
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+// isOrphaned is whether the directory with the name is a temporary
+// directory whose stream is gone.
+func isOrphaned(path, name string) bool {
+	pid, ok := dirPID(name)
+	if !ok {
+		return false
+	}
+
+	if pid == os.Getpid() {
+		activeMu.Lock()
+		defer activeMu.Unlock()
+		return !active[path]
+	}
+
+	// if it can't be told whether the process is running, it's assumed
+	// to be so that nothing in use is removed
+	running, err := process.PidExists(int32(pid))
+	return err == nil && !running
+}
+
+// dirPID returns the PID of the process that created the temporary
+// directory with the name, if it's one.
+func dirPID(name string) (int, bool) {
+	rest, ok := strings.CutPrefix(name, dirPrefix)
+	if !ok {
+		return 0, false
+	}
+	pidText, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(pidText)
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
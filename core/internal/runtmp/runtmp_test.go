@@ -0,0 +1,121 @@
+package runtmp_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runtmp"
+)
+
+// deadPID returns the PID of a process that has exited.
+func deadPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, cmd.Run())
+	return cmd.ProcessState.Pid()
+}
+
+// tempDirs returns the names of the temporary directories in the run
+// directory.
+func tempDirs(t *testing.T, runDir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(runDir, ".wandb-tmp-*"))
+	require.NoError(t, err)
+	var names []string
+	for _, match := range matches {
+		names = append(names, filepath.Base(match))
+	}
+	return names
+}
+
+func TestDir_CreatedWhenUsedAndRemoved(t *testing.T) {
+	runDir := t.TempDir()
+	dir := runtmp.New(runDir)
+	assert.Empty(t, tempDirs(t, runDir))
+
+	file, err := dir.CreateTemp("scratch-*")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Len(t, tempDirs(t, runDir), 1)
+
+	require.NoError(t, dir.Remove())
+	assert.Empty(t, tempDirs(t, runDir))
+	_, err = dir.Path()
+	assert.Error(t, err)
+}
+
+func TestDir_Nil(t *testing.T) {
+	dir := runtmp.New("")
+
+	_, err := dir.Path()
+
+	assert.Nil(t, dir)
+	assert.Error(t, err)
+	assert.NoError(t, dir.Remove())
+}
+
+// A promoted file replaces the one at the destination, in a directory
+// that's created if needed.
+func TestDir_Promote(t *testing.T) {
+	runDir := t.TempDir()
+	dir := runtmp.New(runDir)
+	defer dir.Remove()
+	dest := filepath.Join(runDir, "files", "media", "output.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(dest), 0o755))
+	require.NoError(t, os.WriteFile(dest, []byte("old"), 0o644))
+
+	file, err := dir.CreateTemp("output-*")
+	require.NoError(t, err)
+	_, err = file.WriteString("new")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, dir.Promote(file.Name(), dest))
+
+	content, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	assert.NoFileExists(t, file.Name())
+	entries, err := os.ReadDir(filepath.Dir(dest))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+// The directories of crashed processes are swept, along with those of
+// this process that no stream uses, as left by a crashed process with the
+// same PID; those in use are kept.
+func TestSweep_RemovesDirectoriesOfCrashedProcesses(t *testing.T) {
+	runDir := t.TempDir()
+	otherRunDir := t.TempDir()
+	dir := runtmp.New(runDir)
+	defer dir.Remove()
+	inUse, err := dir.Path()
+	require.NoError(t, err)
+
+	crashed := filepath.Join(runDir, fmt.Sprintf(".wandb-tmp-%d-1", deadPID(t)))
+	sameNumber := filepath.Join(otherRunDir, fmt.Sprintf(".wandb-tmp-%d-2", os.Getpid()))
+	running := filepath.Join(runDir, fmt.Sprintf(".wandb-tmp-%d-3", os.Getppid()))
+	unrelated := filepath.Join(runDir, "files")
+	for _, path := range []string{crashed, sameNumber, running, unrelated} {
+		require.NoError(t, os.MkdirAll(filepath.Join(path, "partial"), 0o755))
+	}
+
+	removed, err := runtmp.Sweep([]string{
+		runDir,
+		otherRunDir,
+		filepath.Join(runDir, "deleted"),
+	})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{crashed, sameNumber}, removed)
+	assert.NoDirExists(t, crashed)
+	assert.NoDirExists(t, sameNumber)
+	assert.DirExists(t, inUse)
+	assert.DirExists(t, running)
+	assert.DirExists(t, unrelated)
+}
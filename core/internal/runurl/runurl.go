@@ -0,0 +1,239 @@
+// Package runurl announces where a run can be viewed, for orchestration
+// systems that want the run's URL as soon as it exists.
+//
+// The run's URL is written to a file in the run's directory, forwarded to
+// the run's attached clients and posted to an optional endpoint, once.
+package runurl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/internal/clients"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// FileName is the name of the file in the run's directory that says where
+// the run can be viewed.
+const FileName = "run-url.json"
+
+// SchemaVersion is the version of the file's schema.
+//
+// It changes only if a field is removed or its meaning changes.
+const SchemaVersion = 1
+
+const (
+	defaultRetryMax     = 3
+	defaultRetryWaitMin = 500 * time.Millisecond
+	defaultRetryWaitMax = 5 * time.Second
+	requestTimeout      = 10 * time.Second
+
+	// closeTimeout is the longest Close waits for the notification to be
+	// posted, so that a slow endpoint never holds up the end of a run.
+	closeTimeout = 5 * time.Second
+)
+
+// Info says where a run can be viewed.
+//
+// It's the content of the file, and the body posted to the notify URL.
+type Info struct {
+	Version int    `json:"version"`
+	Entity  string `json:"entity"`
+	Project string `json:"project"`
+	RunID   string `json:"run_id"`
+
+	// URL is where the run can be viewed, or nil if it's offline.
+	URL *string `json:"url"`
+
+	// LocalPath is the run's local directory.
+	LocalPath string `json:"local_path"`
+}
+
+// URL returns the URL of a run on the W&B server at the base URL.
+func URL(baseURL, entity, project, runID string) string {
+	appURL := strings.Replace(baseURL, "//api.", "//", 1)
+	return fmt.Sprintf("%v/%v/%v/runs/%v", appURL, entity, project, runID)
+}
+
+// Params configure an Announcer.
+type Params struct {
+	// RunDir is the run's local directory, where the file is written.
+	//
+	// No file is written if it's empty.
+	RunDir string
+
+	// NotifyURL is an endpoint to post the run's URL to, or empty.
+	NotifyURL string
+
+	Logger *observability.CoreLogger
+
+	// The rest are optional, for tests.
+	RetryMax     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+}
+
+// Announcer announces a run's URL once it's known.
+//
+// The methods of a nil Announcer do nothing.
+type Announcer struct {
+	runDir    string
+	notifyURL string
+	logger    *observability.CoreLogger
+	client    *retryablehttp.Client
+
+	// forward sends the info to the run's attached clients, if set
+	forward func(Info)
+
+	// once makes sure the run's URL is announced once
+	once sync.Once
+
+	// ctx is cancelled to abandon the notification once Close times out
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// wg counts the notifications being posted
+	wg sync.WaitGroup
+}
+
+func New(params Params) *Announcer {
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	client.RetryMax = valueOr(params.RetryMax, defaultRetryMax)
+	client.RetryWaitMin = valueOr(params.RetryWaitMin, defaultRetryWaitMin)
+	client.RetryWaitMax = valueOr(params.RetryWaitMax, defaultRetryWaitMax)
+	client.Backoff = clients.ExponentialBackoffWithJitter
+	client.HTTPClient.Timeout = requestTimeout
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Announcer{
+		runDir:    params.RunDir,
+		notifyURL: params.NotifyURL,
+		logger:    params.Logger,
+		client:    client,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+func valueOr[T int | time.Duration](value, fallback T) T {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// Forward makes the announcer send the run's URL to the function too.
+//
+// It must be called before the URL is announced.
+func (a *Announcer) Forward(forward func(Info)) {
+	if a == nil {
+		return
+	}
+	a.forward = forward
+}
+
+// Announce writes the file, forwards the info and posts it to the notify
+// URL, the first time it's called.
+//
+// The version and local path of the info are filled in. Posting happens
+// in the background; Close waits for it.
+func (a *Announcer) Announce(info Info) {
+	if a == nil {
+		return
+	}
+
+	a.once.Do(func() {
+		info.Version = SchemaVersion
+		info.LocalPath = a.runDir
+
+		if err := a.writeFile(info); err != nil {
+			a.logger.CaptureError("runurl: failed to write the run URL file", err)
+		}
+		if a.forward != nil {
+			a.forward(info)
+		}
+		if a.notifyURL != "" && info.URL != nil {
+			a.wg.Add(1)
+			go func() {
+				defer a.wg.Done()
+				a.notify(info)
+			}()
+		}
+	})
+}
+
+// Close waits a few seconds for the notification to be posted, then
+// abandons it.
+func (a *Announcer) Close() {
+	if a == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(closeTimeout):
+		a.cancel()
+		<-done
+	}
+	a.cancel()
+}
+
+// writeFile replaces the file in the run's directory with the info.
+func (a *Announcer) writeFile(info Info) error {
+	if a.runDir == "" {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.Write(filepath.Join(a.runDir, FileName), content, 0o644)
+}
+
+// notify posts the info to the notify URL.
+func (a *Announcer) notify(info Info) {
+	body, err := json.Marshal(info)
+	if err != nil {
+		a.logger.CaptureError("runurl: failed to encode the run URL", err)
+		return
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(
+		a.ctx, http.MethodPost, a.notifyURL, bytes.NewReader(body))
+	if err != nil {
+		a.logger.CaptureError("runurl: failed to make the notification", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		a.logger.Warn("runurl: failed to post the run URL", "error", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		a.logger.Warn("runurl: failed to post the run URL", "status", resp.Status)
+		return
+	}
+	a.logger.Info("runurl: posted the run URL", "url", a.notifyURL)
+}
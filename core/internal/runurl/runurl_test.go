@@ -0,0 +1,171 @@
+package runurl_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runurl"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+func ptr(s string) *string { return &s }
+
+func readFile(t *testing.T, dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, runurl.FileName))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestURL(t *testing.T) {
+	assert.Equal(t,
+		"https://wandb.ai/ent/proj/runs/abc",
+		runurl.URL("https://api.wandb.ai", "ent", "proj", "abc"))
+	assert.Equal(t,
+		"http://localhost:8080/ent/proj/runs/abc",
+		runurl.URL("http://localhost:8080", "ent", "proj", "abc"))
+}
+
+// The file's schema is what orchestration systems parse; changing it
+// needs a new SchemaVersion.
+func TestAnnounce_WritesVersionedFile(t *testing.T) {
+	dir := t.TempDir()
+	announcer := runurl.New(runurl.Params{
+		RunDir: dir,
+		Logger: observability.NewNoOpLogger(),
+	})
+
+	announcer.Announce(runurl.Info{
+		Entity:  "ent",
+		Project: "proj",
+		RunID:   "abc",
+		URL:     ptr("https://wandb.ai/ent/proj/runs/abc"),
+	})
+	announcer.Close()
+
+	assert.JSONEq(t,
+		`{
+			"version": 1,
+			"entity": "ent",
+			"project": "proj",
+			"run_id": "abc",
+			"url": "https://wandb.ai/ent/proj/runs/abc",
+			"local_path": `+jsonString(t, dir)+`
+		}`,
+		readFile(t, dir))
+}
+
+func TestAnnounce_OfflineWritesNullURL(t *testing.T) {
+	dir := t.TempDir()
+	posted := false
+	endpoint := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) { posted = true }))
+	defer endpoint.Close()
+	announcer := runurl.New(runurl.Params{
+		RunDir:    dir,
+		NotifyURL: endpoint.URL,
+		Logger:    observability.NewNoOpLogger(),
+	})
+
+	announcer.Announce(runurl.Info{Entity: "ent", Project: "proj", RunID: "abc"})
+	announcer.Close()
+
+	var info map[string]any
+	require.NoError(t, json.Unmarshal([]byte(readFile(t, dir)), &info))
+	assert.Contains(t, info, "url")
+	assert.Nil(t, info["url"])
+	assert.Equal(t, dir, info["local_path"])
+	assert.False(t, posted)
+}
+
+func TestAnnounce_OnlyOnce(t *testing.T) {
+	dir := t.TempDir()
+	var forwarded []runurl.Info
+	announcer := runurl.New(runurl.Params{
+		RunDir: dir,
+		Logger: observability.NewNoOpLogger(),
+	})
+	announcer.Forward(func(info runurl.Info) {
+		forwarded = append(forwarded, info)
+	})
+
+	announcer.Announce(runurl.Info{RunID: "first", URL: ptr("u1")})
+	announcer.Announce(runurl.Info{RunID: "second", URL: ptr("u2")})
+	announcer.Close()
+
+	require.Len(t, forwarded, 1)
+	assert.Equal(t, "first", forwarded[0].RunID)
+	assert.Equal(t, runurl.SchemaVersion, forwarded[0].Version)
+	assert.Equal(t, dir, forwarded[0].LocalPath)
+	assert.Contains(t, readFile(t, dir), `"first"`)
+}
+
+func TestAnnounce_PostsToNotifyURL(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	attempts := 0
+	endpoint := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+		}))
+	defer endpoint.Close()
+	announcer := runurl.New(runurl.Params{
+		NotifyURL:    endpoint.URL,
+		Logger:       observability.NewNoOpLogger(),
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	announcer.Announce(runurl.Info{
+		Entity:  "ent",
+		Project: "proj",
+		RunID:   "abc",
+		URL:     ptr("https://wandb.ai/ent/proj/runs/abc"),
+	})
+	announcer.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, bodies, 1)
+	assert.JSONEq(t,
+		`{
+			"version": 1,
+			"entity": "ent",
+			"project": "proj",
+			"run_id": "abc",
+			"url": "https://wandb.ai/ent/proj/runs/abc",
+			"local_path": ""
+		}`,
+		bodies[0])
+}
+
+func TestNil(t *testing.T) {
+	var announcer *runurl.Announcer
+
+	announcer.Forward(func(runurl.Info) {})
+	announcer.Announce(runurl.Info{})
+	announcer.Close()
+}
+
+func jsonString(t *testing.T, s string) string {
+	encoded, err := json.Marshal(s)
+	require.NoError(t, err)
+	return string(encoded)
+}
@@ -22,20 +22,36 @@ type ReservoirSampler[T comparable] struct {
 
 	// seen is a counter for the number of items seen thus far
 	seen int
+
+	// randFloat returns the random priorities of the items
+	randFloat func() float64
 }
 
 func NewReservoirSampler[T comparable](k int, delta float64) *ReservoirSampler[T] {
 	return &ReservoirSampler[T]{
-		pq:    NewPriorityQueue[T](),
-		k:     k,
-		delta: delta,
-		seen:  0,
+		pq:        NewPriorityQueue[T](),
+		k:         k,
+		delta:     delta,
+		seen:      0,
+		randFloat: rand.Float64,
 	}
 }
 
+// NewReservoirSamplerWithRand returns a sampler that draws the priorities
+// of the items from rng, making the samples reproducible.
+func NewReservoirSamplerWithRand[T comparable](
+	k int,
+	delta float64,
+	rng *rand.Rand,
+) *ReservoirSampler[T] {
+	rs := NewReservoirSampler[T](k, delta)
+	rs.randFloat = rng.Float64
+	return rs
+}
+
 // Add adds a new item to the reservoir with the given value.
 // TODO(WB-18332): revisit this alogirithm as it might not be correct in terms of the
-// definition of the reservoir sampling algorithm (how are we keeping size under control?)
+// definition of the reservoir sampling algorithm
 func (rs *ReservoirSampler[T]) Add(value T) {
 	seen := rs.seen + 1
 
@@ -45,7 +61,7 @@ func (rs *ReservoirSampler[T]) Add(value T) {
 	q := math.Min(1, ratio+gamma+math.Sqrt(math.Pow(gamma, 2)+2*gamma*ratio))
 
 	// generate a random priority for the current item
-	x := rand.Float64()
+	x := rs.randFloat()
 
 	// add the item to the reservoir if its priority is less than q
 	if x < q {
@@ -53,6 +69,16 @@ func (rs *ReservoirSampler[T]) Add(value T) {
 		rs.pq.Push(item)
 	}
 
+	// items outside the k with the lowest priorities can never be sampled,
+	// so they are dropped to keep the reservoir's size bounded
+	if rs.pq.Len() > 2*rs.k {
+		lowest := rs.popLowest()
+		rs.pq = NewPriorityQueue[T]()
+		for _, item := range lowest {
+			rs.pq.Push(item)
+		}
+	}
+
 	// update the total number of items seen so far
 	rs.seen = seen
 }
@@ -60,25 +86,32 @@ func (rs *ReservoirSampler[T]) Add(value T) {
 // Returns up to k items from the reservoir as samples based on the priorities
 // of the items. The items are returned in the order they were added to the
 // reservoir.
+//
+// Sampling doesn't change the reservoir, so it may be sampled repeatedly.
 func (rs *ReservoirSampler[T]) Sample() []T {
-	// number of items to return
-	k := min(rs.k, rs.pq.Len())
-
-	// pop k items from the priority queue
-	topK := make([]*Item[T], k)
-	for i := 0; i < k; i++ {
-		topK[i] = rs.pq.Pop()
+	topK := rs.popLowest()
+	for _, item := range topK {
+		rs.pq.Push(item)
 	}
 
 	// sort the items by their index, so they are returned in the order they were added
 	sort.Slice(topK, func(i, j int) bool {
 		return topK[i].index < topK[j].index
 	})
-
 	// return only the values of the items as the samples
-	samples := make([]T, k)
+	samples := make([]T, len(topK))
 	for i, item := range topK {
 		samples[i] = item.value
 	}
 	return samples
 }
+
+// popLowest removes and returns up to k items with the lowest priorities.
+func (rs *ReservoirSampler[T]) popLowest() []*Item[T] {
+	k := min(rs.k, rs.pq.Len())
+	lowest := make([]*Item[T], k)
+	for i := 0; i < k; i++ {
+		lowest[i] = rs.pq.Pop()
+	}
+	return lowest
+}
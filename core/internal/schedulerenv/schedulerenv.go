@@ -0,0 +1,103 @@
+// Package schedulerenv collects information about the HPC scheduler job
+// that a run is part of from the environment.
+package schedulerenv
+
+import (
+	"strings"
+)
+
+// MaxValueLength is the maximum length of a captured value.
+//
+// Some variables, such as node lists of large jobs, can be very long;
+// longer values are truncated.
+const MaxValueLength = 1024
+
+// configVars are the variables that are added to the run config, which
+// makes them queryable in the UI and the public API.
+var configVars = map[string]bool{
+	"SLURM_JOB_ID":        true,
+	"SLURM_JOB_NAME":      true,
+	"SLURM_JOB_NODELIST":  true,
+	"SLURM_ARRAY_JOB_ID":  true,
+	"SLURM_ARRAY_TASK_ID": true,
+	"SLURM_PROCID":        true,
+	"PBS_JOBID":           true,
+	"PBS_JOBNAME":         true,
+	"PBS_ARRAY_INDEX":     true,
+	"LSB_JOBID":           true,
+	"LSB_JOBNAME":         true,
+	"LSB_JOBINDEX":        true,
+}
+
+// secretMarkers are substrings of variable names that suggest that
+// the value is a credential, which must never be captured.
+var secretMarkers = []string{"TOKEN", "KEY", "SECRET"}
+
+// Env is the scheduler-related environment of a process.
+type Env struct {
+	// Slurm maps SLURM_* variables to their values.
+	//
+	// The keys are the lowercase variable names without the prefix,
+	// e.g. "job_id" for SLURM_JOB_ID.
+	Slurm map[string]string
+
+	// Scheduler maps PBS_* and LSB_* variables to their values.
+	//
+	// The keys are the lowercase variable names, e.g. "pbs_jobid".
+	Scheduler map[string]string
+
+	// Config holds the selected variables to add to the run config.
+	//
+	// The keys are the lowercase variable names, e.g. "slurm_job_id".
+	Config map[string]string
+}
+
+// Collect extracts scheduler variables from an environment given in the
+// format returned by os.Environ.
+func Collect(environ []string) *Env {
+	env := &Env{
+		Slurm:     make(map[string]string),
+		Scheduler: make(map[string]string),
+		Config:    make(map[string]string),
+	}
+
+	for _, entry := range environ {
+		name, value, found := strings.Cut(entry, "=")
+		if !found || isSecret(name) {
+			continue
+		}
+		if len(value) > MaxValueLength {
+			value = value[:MaxValueLength]
+		}
+
+		switch {
+		case strings.HasPrefix(name, "SLURM_"):
+			env.Slurm[strings.ToLower(strings.TrimPrefix(name, "SLURM_"))] = value
+		case strings.HasPrefix(name, "PBS_"), strings.HasPrefix(name, "LSB_"):
+			env.Scheduler[strings.ToLower(name)] = value
+		default:
+			continue
+		}
+
+		if configVars[name] {
+			env.Config[strings.ToLower(name)] = value
+		}
+	}
+
+	return env
+}
+
+// IsEmpty returns whether no scheduler variables were found.
+func (env *Env) IsEmpty() bool {
+	return len(env.Slurm) == 0 && len(env.Scheduler) == 0
+}
+
+func isSecret(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range secretMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
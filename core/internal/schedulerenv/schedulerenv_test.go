@@ -0,0 +1,90 @@
+package schedulerenv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/internal/schedulerenv"
+)
+
+func TestCollect_Slurm(t *testing.T) {
+	env := schedulerenv.Collect([]string{
+		"HOME=/home/user",
+		"SLURM_JOB_ID=1234",
+		"SLURM_ARRAY_TASK_ID=7",
+		"SLURM_JOB_NODELIST=node[01-04]",
+		"SLURM_CPUS_ON_NODE=32",
+	})
+
+	assert.Equal(t,
+		map[string]string{
+			"job_id":        "1234",
+			"array_task_id": "7",
+			"job_nodelist":  "node[01-04]",
+			"cpus_on_node":  "32",
+		},
+		env.Slurm,
+	)
+	assert.Empty(t, env.Scheduler)
+	assert.Equal(t,
+		map[string]string{
+			"slurm_job_id":        "1234",
+			"slurm_array_task_id": "7",
+			"slurm_job_nodelist":  "node[01-04]",
+		},
+		env.Config,
+	)
+	assert.False(t, env.IsEmpty())
+}
+
+func TestCollect_PBSAndLSF(t *testing.T) {
+	env := schedulerenv.Collect([]string{
+		"PBS_JOBID=99.server",
+		"PBS_O_WORKDIR=/scratch",
+		"LSB_JOBID=42",
+	})
+
+	assert.Empty(t, env.Slurm)
+	assert.Equal(t,
+		map[string]string{
+			"pbs_jobid":     "99.server",
+			"pbs_o_workdir": "/scratch",
+			"lsb_jobid":     "42",
+		},
+		env.Scheduler,
+	)
+	assert.Equal(t,
+		map[string]string{"pbs_jobid": "99.server", "lsb_jobid": "42"},
+		env.Config,
+	)
+}
+
+func TestCollect_ExcludesSecrets(t *testing.T) {
+	env := schedulerenv.Collect([]string{
+		"SLURM_JOB_ID=1",
+		"SLURM_AUTH_TOKEN=hunter2",
+		"SLURM_JWT_KEY=hunter2",
+		"PBS_SECRET=hunter2",
+		"LSB_api_key=hunter2",
+	})
+
+	assert.Equal(t, map[string]string{"job_id": "1"}, env.Slurm)
+	assert.Empty(t, env.Scheduler)
+}
+
+func TestCollect_TruncatesLongValues(t *testing.T) {
+	long := strings.Repeat("n", schedulerenv.MaxValueLength+10)
+	env := schedulerenv.Collect([]string{"SLURM_JOB_NODELIST=" + long})
+
+	assert.Len(t, env.Slurm["job_nodelist"], schedulerenv.MaxValueLength)
+	assert.Len(t, env.Config["slurm_job_nodelist"], schedulerenv.MaxValueLength)
+}
+
+func TestCollect_Empty(t *testing.T) {
+	env := schedulerenv.Collect([]string{"HOME=/home/user", "MALFORMED"})
+
+	assert.True(t, env.IsEmpty())
+	assert.Empty(t, env.Config)
+}
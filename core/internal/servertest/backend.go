@@ -0,0 +1,485 @@
+// Package servertest provides an in-memory W&B backend and a recorder for
+// the channels of a stream, for end-to-end tests of the core.
+package servertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Route is one of the endpoints of a FakeBackend.
+type Route string
+
+const (
+	// RouteGraphQL is the GraphQL API at /graphql.
+	RouteGraphQL Route = "graphql"
+
+	// RouteFileStream is the filestream API at
+	// /files/<entity>/<project>/<run>/file_stream.
+	RouteFileStream Route = "filestream"
+
+	// RouteUpload accepts file uploads at the URLs from UploadURL.
+	RouteUpload Route = "upload"
+
+	// RouteSink accepts mirrored records at SinkURL, like an external
+	// record sink.
+	RouteSink Route = "sink"
+
+	// RouteUnknown is any other path, to which the backend responds with
+	// a 404.
+	RouteUnknown Route = "unknown"
+)
+
+// Request is a request received by a FakeBackend.
+type Request struct {
+	Route  Route
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+
+	// OperationName is the name of the GraphQL operation, if the request
+	// was made to RouteGraphQL.
+	OperationName string
+
+	// ReceivedAt is when the backend received the request.
+	ReceivedAt time.Time
+}
+
+// Fault is a failure that a FakeBackend injects into a response.
+type Fault struct {
+	// Delay is how long to wait before responding.
+	//
+	// If it's the only field set, the request is handled normally after
+	// the delay.
+	Delay time.Duration
+
+	// StatusCode is the status to respond with instead of handling the
+	// request, if not zero.
+	StatusCode int
+
+	// Body is the body to respond with along with StatusCode.
+	Body string
+
+	// ResetConnection is whether to reset the connection instead of
+	// responding.
+	ResetConnection bool
+}
+
+// RunFinishedFault is the filestream's response to data for a run that
+// was already finished, such as from the UI or by another process.
+func RunFinishedFault() Fault {
+	return Fault{
+		StatusCode: http.StatusConflict,
+		Body:       `{"error":"run has already been marked as finished"}`,
+	}
+}
+
+// FakeBackend is an HTTP server that imitates the W&B backend.
+//
+// It records all requests it receives. By default, the filestream and
+// upload endpoints accept everything while GraphQL operations fail
+// unless stubbed with StubGraphQL or StubGraphQLOnce.
+type FakeBackend struct {
+	mu sync.Mutex
+
+	server   *httptest.Server
+	requests []Request
+
+	// graphqlStubs are the responses to GraphQL operations, by name
+	graphqlStubs map[string]string
+
+	// graphqlStubsOnce are the next responses to GraphQL operations,
+	// which take precedence over graphqlStubs
+	graphqlStubsOnce map[string][]string
+
+	// graphqlErrorStubs are the errors to respond to GraphQL operations
+	// with, by name, which take precedence over graphqlStubs
+	graphqlErrorStubs map[string]string
+
+	// stubRunFiles is whether to answer CreateRunFiles with an upload URL
+	// for each requested file
+	stubRunFiles bool
+
+	// stubArtifactFiles is whether to answer CreateArtifactFiles with an
+	// upload URL for each requested file
+	stubArtifactFiles bool
+
+	// faults are the faults to inject into the next requests, by route
+	faults map[Route][]Fault
+
+	// unreachable is whether to reset all connections, as if the network
+	// were down
+	unreachable bool
+}
+
+// NewFakeBackend starts a FakeBackend.
+//
+// Close must be called to stop it.
+func NewFakeBackend() *FakeBackend {
+	b := &FakeBackend{
+		graphqlStubs:      make(map[string]string),
+		graphqlStubsOnce:  make(map[string][]string),
+		graphqlErrorStubs: make(map[string]string),
+		faults:            make(map[Route][]Fault),
+	}
+	b.server = httptest.NewServer(http.HandlerFunc(b.serveHTTP))
+	return b
+}
+
+// URL is the base URL of the backend, to use as the base_url setting.
+func (b *FakeBackend) URL() string {
+	return b.server.URL
+}
+
+// UploadURL returns a URL at which the backend accepts an upload.
+func (b *FakeBackend) UploadURL(name string) string {
+	return fmt.Sprintf("%s/upload/%s", b.server.URL, strings.TrimPrefix(name, "/"))
+}
+
+// SinkURL is the URL at which the backend accepts mirrored records.
+func (b *FakeBackend) SinkURL() string {
+	return b.server.URL + "/sink"
+}
+
+// Close stops the backend.
+func (b *FakeBackend) Close() {
+	b.server.Close()
+}
+
+// StubGraphQL sets the response to every request for the operation.
+//
+// The response is the JSON of the "data" field of the GraphQL response.
+func (b *FakeBackend) StubGraphQL(operationName string, responseJSON string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.graphqlStubs[operationName] = responseJSON
+}
+
+// StubGraphQLOnce sets the response to the next request for the operation.
+//
+// Responses stubbed this way are used in order, before any response set
+// with StubGraphQL.
+func (b *FakeBackend) StubGraphQLOnce(operationName string, responseJSON string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.graphqlStubsOnce[operationName] = append(
+		b.graphqlStubsOnce[operationName],
+		responseJSON,
+	)
+}
+
+// StubGraphQLError makes every request for the operation fail with a
+// GraphQL error that has the code in its extensions.
+//
+// Responses stubbed with StubGraphQLOnce still take precedence.
+func (b *FakeBackend) StubGraphQLError(operationName, code, message string) {
+	response, _ := json.Marshal(map[string]any{
+		"errors": []map[string]any{{
+			"message":    message,
+			"extensions": map[string]string{"code": code},
+		}},
+	})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.graphqlErrorStubs[operationName] = string(response)
+}
+
+// StubCreateRunFiles makes every CreateRunFiles request succeed with an
+// upload URL for each of the requested files.
+//
+// Responses stubbed with StubGraphQLOnce still take precedence.
+func (b *FakeBackend) StubCreateRunFiles() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stubRunFiles = true
+}
+
+// StubCreateArtifactFiles makes every CreateArtifactFiles request succeed
+// with an upload URL for each of the requested files.
+//
+// Responses stubbed with StubGraphQLOnce still take precedence.
+func (b *FakeBackend) StubCreateArtifactFiles() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stubArtifactFiles = true
+}
+
+// InjectFault makes the next request to the route fail.
+//
+// Faults injected into the same route apply to consecutive requests in
+// the order they were injected.
+func (b *FakeBackend) InjectFault(route Route, fault Fault) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.faults[route] = append(b.faults[route], fault)
+}
+
+// SetUnreachable makes the backend reset every connection without
+// recording its request, as if the network were down, until it's called
+// again with false.
+func (b *FakeBackend) SetUnreachable(unreachable bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unreachable = unreachable
+}
+
+// Requests returns the requests received on the route.
+func (b *FakeBackend) Requests(route Route) []Request {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.DeleteFunc(
+		slices.Clone(b.requests),
+		func(r Request) bool { return r.Route != route },
+	)
+}
+
+// GraphQLRequests returns the GraphQL requests for the operation.
+func (b *FakeBackend) GraphQLRequests(operationName string) []Request {
+	return slices.DeleteFunc(
+		b.Requests(RouteGraphQL),
+		func(r Request) bool { return r.OperationName != operationName },
+	)
+}
+
+func (b *FakeBackend) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	unreachable := b.unreachable
+	b.mu.Unlock()
+	if unreachable {
+		resetConnection(w)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := Request{
+		Route:  routeOf(r.URL.Path),
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+
+		ReceivedAt: time.Now(),
+	}
+	if request.Route == RouteGraphQL {
+		request.OperationName = operationName(body)
+	}
+
+	fault, hasFault := b.record(request)
+	if hasFault && b.inject(w, r, fault) {
+		return
+	}
+
+	switch request.Route {
+	case RouteGraphQL:
+		b.serveGraphQL(w, request.OperationName, body)
+	case RouteFileStream:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+	case RouteUpload, RouteSink:
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// record records the request and returns the fault to inject, if any.
+func (b *FakeBackend) record(request Request) (Fault, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.requests = append(b.requests, request)
+
+	faults := b.faults[request.Route]
+	if len(faults) == 0 {
+		return Fault{}, false
+	}
+	b.faults[request.Route] = faults[1:]
+	return faults[0], true
+}
+
+// inject applies the fault and returns whether the request was handled.
+func (b *FakeBackend) inject(
+	w http.ResponseWriter,
+	r *http.Request,
+	fault Fault,
+) bool {
+	if fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	switch {
+	case fault.ResetConnection:
+		resetConnection(w)
+		return true
+	case fault.StatusCode != 0:
+		w.WriteHeader(fault.StatusCode)
+		_, _ = w.Write([]byte(fault.Body))
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *FakeBackend) serveGraphQL(
+	w http.ResponseWriter,
+	operationName string,
+	body []byte,
+) {
+	b.mu.Lock()
+	responseJSON, ok := b.graphqlStubs[operationName]
+	if b.stubRunFiles && operationName == "CreateRunFiles" {
+		responseJSON, ok = b.createRunFilesResponse(body), true
+	}
+	if b.stubArtifactFiles && operationName == "CreateArtifactFiles" {
+		responseJSON, ok = b.createArtifactFilesResponse(body), true
+	}
+	errorJSON, failed := b.graphqlErrorStubs[operationName]
+	if once := b.graphqlStubsOnce[operationName]; len(once) > 0 {
+		responseJSON, ok = once[0], true
+		failed = false
+		b.graphqlStubsOnce[operationName] = once[1:]
+	}
+	b.mu.Unlock()
+
+	var response bytes.Buffer
+	if failed {
+		response.WriteString(errorJSON)
+	} else if ok {
+		fmt.Fprintf(&response, `{"data":%s}`, responseJSON)
+	} else {
+		message, _ := json.Marshal(
+			fmt.Sprintf("servertest: no stub for %q", operationName))
+		fmt.Fprintf(&response, `{"errors":[{"message":%s}]}`, message)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response.Bytes())
+}
+
+// createRunFilesResponse returns a CreateRunFiles response with an upload
+// URL for each file requested in the body.
+func (b *FakeBackend) createRunFilesResponse(body []byte) string {
+	var request struct {
+		Variables struct {
+			Files []string `json:"files"`
+		} `json:"variables"`
+	}
+	_ = json.Unmarshal(body, &request)
+
+	type file struct {
+		Name      string `json:"name"`
+		UploadURL string `json:"uploadUrl"`
+	}
+	files := make([]file, len(request.Variables.Files))
+	for i, name := range request.Variables.Files {
+		files[i] = file{Name: name, UploadURL: b.UploadURL(name)}
+	}
+
+	response, _ := json.Marshal(map[string]any{
+		"createRunFiles": map[string]any{
+			"runID":         "run-id",
+			"uploadHeaders": []string{},
+			"files":         files,
+		},
+	})
+	return string(response)
+}
+
+// createArtifactFilesResponse returns a CreateArtifactFiles response with
+// an upload URL for each file requested in the body.
+func (b *FakeBackend) createArtifactFilesResponse(body []byte) string {
+	var request struct {
+		Variables struct {
+			ArtifactFiles []struct {
+				ArtifactID string `json:"artifactID"`
+				Name       string `json:"name"`
+			} `json:"artifactFiles"`
+		} `json:"variables"`
+	}
+	_ = json.Unmarshal(body, &request)
+
+	type node struct {
+		UploadURL     string            `json:"uploadUrl"`
+		UploadHeaders []string          `json:"uploadHeaders"`
+		Artifact      map[string]string `json:"artifact"`
+	}
+	edges := make([]map[string]node, len(request.Variables.ArtifactFiles))
+	for i, file := range request.Variables.ArtifactFiles {
+		edges[i] = map[string]node{"node": {
+			UploadURL:     b.UploadURL(file.Name),
+			UploadHeaders: []string{},
+			Artifact:      map[string]string{"id": file.ArtifactID},
+		}}
+	}
+
+	response, _ := json.Marshal(map[string]any{
+		"createArtifactFiles": map[string]any{
+			"files": map[string]any{"edges": edges},
+		},
+	})
+	return string(response)
+}
+
+// resetConnection closes the request's connection without responding,
+// so that the client sees a reset rather than a clean close.
+func resetConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("servertest: connection cannot be hijacked")
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(fmt.Sprintf("servertest: failed to hijack connection: %v", err))
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+func routeOf(path string) Route {
+	switch {
+	case path == "/graphql":
+		return RouteGraphQL
+	case strings.HasPrefix(path, "/files/") &&
+		strings.HasSuffix(path, "/file_stream"):
+		return RouteFileStream
+	case strings.HasPrefix(path, "/upload/"):
+		return RouteUpload
+	case path == "/sink":
+		return RouteSink
+	default:
+		return RouteUnknown
+	}
+}
+
+// operationName returns the name of the GraphQL operation in a request
+// body, or an empty string.
+func operationName(body []byte) string {
+	var request struct {
+		OperationName string `json:"operationName"`
+	}
+	_ = json.Unmarshal(body, &request)
+	return request.OperationName
+}
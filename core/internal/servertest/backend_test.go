@@ -0,0 +1,202 @@
+package servertest_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+)
+
+func postGraphQL(t *testing.T, backend *servertest.FakeBackend, op string) string {
+	resp, err := http.Post(
+		backend.URL()+"/graphql",
+		"application/json",
+		strings.NewReader(`{"operationName":"`+op+`","query":"q"}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestFakeBackend_GraphQLStubs(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+
+	backend.StubGraphQL("Viewer", `{"viewer":{"entity":"always"}}`)
+	backend.StubGraphQLOnce("Viewer", `{"viewer":{"entity":"first"}}`)
+
+	assert.JSONEq(t,
+		`{"data":{"viewer":{"entity":"first"}}}`,
+		postGraphQL(t, backend, "Viewer"))
+	assert.JSONEq(t,
+		`{"data":{"viewer":{"entity":"always"}}}`,
+		postGraphQL(t, backend, "Viewer"))
+	assert.Contains(t,
+		postGraphQL(t, backend, "UpsertBucket"),
+		`no stub for \"UpsertBucket\"`)
+
+	assert.Len(t, backend.GraphQLRequests("Viewer"), 2)
+	assert.Len(t, backend.Requests(servertest.RouteGraphQL), 3)
+}
+
+func TestFakeBackend_RecordsRequests(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+
+	fsResp, err := http.Post(
+		backend.URL()+"/files/entity/project/run/file_stream",
+		"application/json",
+		strings.NewReader(`{"complete":true}`),
+	)
+	require.NoError(t, err)
+	fsResp.Body.Close()
+	req, err := http.NewRequest(
+		http.MethodPut,
+		backend.UploadURL("files/config.yaml"),
+		strings.NewReader("config"),
+	)
+	require.NoError(t, err)
+	uploadResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	uploadResp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, fsResp.StatusCode)
+	assert.Equal(t, http.StatusOK, uploadResp.StatusCode)
+	fsRequests := backend.Requests(servertest.RouteFileStream)
+	require.Len(t, fsRequests, 1)
+	assert.Equal(t, `{"complete":true}`, string(fsRequests[0].Body))
+	uploads := backend.Requests(servertest.RouteUpload)
+	require.Len(t, uploads, 1)
+	assert.Equal(t, "/upload/files/config.yaml", uploads[0].Path)
+	assert.Equal(t, http.MethodPut, uploads[0].Method)
+	assert.Equal(t, "config", string(uploads[0].Body))
+}
+
+func TestFakeBackend_Faults(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	url := backend.URL() + "/files/entity/project/run/file_stream"
+
+	backend.InjectFault(servertest.RouteFileStream,
+		servertest.Fault{StatusCode: http.StatusServiceUnavailable})
+	backend.InjectFault(servertest.RouteFileStream,
+		servertest.Fault{ResetConnection: true})
+	backend.InjectFault(servertest.RouteFileStream,
+		servertest.Fault{Delay: 50 * time.Millisecond})
+
+	resp, err := http.Post(url, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	_, err = http.Post(url, "application/json", strings.NewReader("{}"))
+	assert.Error(t, err)
+
+	start := time.Now()
+	resp, err = http.Post(url, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	assert.Len(t, backend.Requests(servertest.RouteFileStream), 3)
+}
+
+func TestFakeBackend_Unreachable(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	url := backend.URL() + "/files/entity/project/run/file_stream"
+
+	backend.SetUnreachable(true)
+	_, err := http.Post(url, "application/json", strings.NewReader("{}"))
+	assert.Error(t, err)
+
+	backend.SetUnreachable(false)
+	resp, err := http.Post(url, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Len(t, backend.Requests(servertest.RouteFileStream), 1)
+}
+
+func TestFakeBackend_UnknownRoute(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+
+	resp, err := http.Get(backend.URL() + "/nope")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Len(t, backend.Requests(servertest.RouteUnknown), 1)
+}
+
+func TestFakeBackend_StubCreateRunFiles(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubCreateRunFiles()
+
+	resp, err := http.Post(
+		backend.URL()+"/graphql",
+		"application/json",
+		strings.NewReader(`{
+			"operationName": "CreateRunFiles",
+			"variables": {"files": ["config.yaml", "media/a.png"]}
+		}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`{"data":{"createRunFiles":{
+			"runID": "run-id",
+			"uploadHeaders": [],
+			"files": [
+				{"name": "config.yaml", "uploadUrl": "`+backend.UploadURL("config.yaml")+`"},
+				{"name": "media/a.png", "uploadUrl": "`+backend.UploadURL("media/a.png")+`"}
+			]
+		}}}`,
+		string(body))
+}
+
+func TestFakeBackend_StubCreateArtifactFiles(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubCreateArtifactFiles()
+
+	resp, err := http.Post(
+		backend.URL()+"/graphql",
+		"application/json",
+		strings.NewReader(`{
+			"operationName": "CreateArtifactFiles",
+			"variables": {"artifactFiles": [
+				{"artifactID": "artifact-id", "name": "data/a.csv"}
+			]}
+		}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`{"data":{"createArtifactFiles":{"files":{"edges":[
+			{"node": {
+				"uploadUrl": "`+backend.UploadURL("data/a.csv")+`",
+				"uploadHeaders": [],
+				"artifact": {"id": "artifact-id"}
+			}}
+		]}}}}`,
+		string(body))
+}
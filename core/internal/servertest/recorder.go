@@ -0,0 +1,157 @@
+package servertest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// waitTimeout is how long StreamRecorder waits for messages to arrive.
+const waitTimeout = 5 * time.Second
+
+// StreamRecorder records the messages that flow between the components
+// of a stream.
+//
+// It taps a component's output channels: the component sends to the
+// channel returned by TapRecords or TapResults, and the recorder
+// captures each message under the tap's name before forwarding it.
+type StreamRecorder struct {
+	mu sync.Mutex
+
+	records map[string][]*service.Record
+	results map[string][]*service.Result
+
+	// changed is closed and replaced whenever a message is recorded
+	changed chan struct{}
+}
+
+func NewStreamRecorder() *StreamRecorder {
+	return &StreamRecorder{
+		records: make(map[string][]*service.Record),
+		results: make(map[string][]*service.Result),
+		changed: make(chan struct{}),
+	}
+}
+
+// TapRecords returns a channel whose records are recorded under the name
+// and then forwarded to next.
+//
+// If next is nil, records are discarded after being recorded. Closing
+// the returned channel closes next.
+func (r *StreamRecorder) TapRecords(
+	name string,
+	next chan *service.Record,
+) chan *service.Record {
+	return tap(r, name, next, r.records)
+}
+
+// TapResults returns a channel whose results are recorded under the name
+// and then forwarded to next.
+//
+// If next is nil, results are discarded after being recorded. Closing
+// the returned channel closes next.
+func (r *StreamRecorder) TapResults(
+	name string,
+	next chan *service.Result,
+) chan *service.Result {
+	return tap(r, name, next, r.results)
+}
+
+// Records returns the records that passed through the tap so far.
+func (r *StreamRecorder) Records(name string) []*service.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.records[name])
+}
+
+// Results returns the results that passed through the tap so far.
+func (r *StreamRecorder) Results(name string) []*service.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.results[name])
+}
+
+// WaitForRecords waits until at least n records passed through the tap
+// and returns them, failing the test if they don't arrive in time.
+func (r *StreamRecorder) WaitForRecords(
+	t *testing.T,
+	name string,
+	n int,
+) []*service.Record {
+	t.Helper()
+	return waitFor(t, r, name, n, r.records)
+}
+
+// WaitForResults waits until at least n results passed through the tap
+// and returns them, failing the test if they don't arrive in time.
+func (r *StreamRecorder) WaitForResults(
+	t *testing.T,
+	name string,
+	n int,
+) []*service.Result {
+	t.Helper()
+	return waitFor(t, r, name, n, r.results)
+}
+
+func tap[T any](
+	r *StreamRecorder,
+	name string,
+	next chan T,
+	messages map[string][]T,
+) chan T {
+	tapped := make(chan T, cap(next))
+
+	go func() {
+		for message := range tapped {
+			r.mu.Lock()
+			messages[name] = append(messages[name], message)
+			close(r.changed)
+			r.changed = make(chan struct{})
+			r.mu.Unlock()
+
+			if next != nil {
+				next <- message
+			}
+		}
+
+		if next != nil {
+			close(next)
+		}
+	}()
+
+	return tapped
+}
+
+func waitFor[T any](
+	t *testing.T,
+	r *StreamRecorder,
+	name string,
+	n int,
+	messages map[string][]T,
+) []T {
+	t.Helper()
+	timeout := time.After(waitTimeout)
+
+	for {
+		r.mu.Lock()
+		recorded := slices.Clone(messages[name])
+		changed := r.changed
+		r.mu.Unlock()
+
+		if len(recorded) >= n {
+			return recorded
+		}
+
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf(
+				"servertest: got %d messages on %q after %v, want %d",
+				len(recorded), name, waitTimeout, n)
+			return nil
+		}
+	}
+}
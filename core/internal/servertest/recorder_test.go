@@ -0,0 +1,42 @@
+package servertest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestStreamRecorder_RecordsAndForwards(t *testing.T) {
+	recorder := servertest.NewStreamRecorder()
+	next := make(chan *service.Record, 2)
+	tapped := recorder.TapRecords("fwd", next)
+
+	first := &service.Record{Num: 1}
+	second := &service.Record{Num: 2}
+	tapped <- first
+	tapped <- second
+	close(tapped)
+
+	assert.Equal(t,
+		[]*service.Record{first, second},
+		recorder.WaitForRecords(t, "fwd", 2))
+	assert.Same(t, first, <-next)
+	assert.Same(t, second, <-next)
+	_, open := <-next
+	assert.False(t, open)
+}
+
+func TestStreamRecorder_DiscardsWithoutNext(t *testing.T) {
+	recorder := servertest.NewStreamRecorder()
+	tapped := recorder.TapResults("out", nil)
+
+	tapped <- &service.Result{}
+	tapped <- &service.Result{}
+
+	assert.Len(t, recorder.WaitForResults(t, "out", 2), 2)
+	assert.Empty(t, recorder.Records("out"))
+	assert.Empty(t, recorder.Results("other"))
+}
@@ -0,0 +1,78 @@
+package settings
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// configFilterFields are the settings that filter the config sent to the
+// server.
+var configFilterFields = []protoreflect.Name{
+	"config_exclude_keys",
+	"config_include_keys",
+}
+
+// ConfigFilterRecord returns the config key filters as stored in the run
+// record of the transaction log, or nil if there are none.
+//
+// Storing them lets a later sync of the run filter its config the same
+// way.
+func (s *Settings) ConfigFilterRecord() (*service.SettingsRecord, error) {
+	return s.fieldsRecord(configFilterFields)
+}
+
+// fieldsRecord returns the settings with the given names that are set, as
+// stored in the transaction log, or nil if none are.
+func (s *Settings) fieldsRecord(
+	names []protoreflect.Name,
+) (*service.SettingsRecord, error) {
+	m := s.Proto.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	var record *service.SettingsRecord
+	for _, name := range names {
+		fd := fields.ByName(name)
+		if !m.Has(fd) {
+			continue
+		}
+
+		item, err := settingsItem(fd, m.Get(fd))
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			record = &service.SettingsRecord{}
+		}
+		record.Item = append(record.Item, item)
+	}
+
+	return record, nil
+}
+
+// ConfigFiltersFromRecord returns the config key filters stored in a run
+// record's settings, if any.
+func ConfigFiltersFromRecord(
+	record *service.SettingsRecord,
+) (include []string, exclude []string, err error) {
+	for _, item := range record.GetItem() {
+		var patterns *[]string
+		switch item.GetKey() {
+		case "config_include_keys":
+			patterns = &include
+		case "config_exclude_keys":
+			patterns = &exclude
+		default:
+			continue
+		}
+
+		value := &service.ListStringValue{}
+		if err := protojson.Unmarshal([]byte(item.GetValueJson()), value); err != nil {
+			return nil, nil, err
+		}
+		*patterns = value.GetValue()
+	}
+
+	return include, exclude, nil
+}
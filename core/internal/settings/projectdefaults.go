@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// projectDefaultFields are the settings that a run's project may set
+// defaults for.
+var projectDefaultFields = []protoreflect.Name{
+	"disable_git",
+	"save_code",
+}
+
+// ProjectDefaultsRecord returns the settings that a project may set
+// defaults for, as stored in the transaction log, or nil if none are set.
+func (s *Settings) ProjectDefaultsRecord() (*service.SettingsRecord, error) {
+	return s.fieldsRecord(projectDefaultFields)
+}
+
+// MergeProjectDefaults applies a project's defaults to the settings that
+// aren't set otherwise, such as explicitly or by the environment.
+//
+// Settings that a project can't set defaults for are ignored.
+func (s *Settings) MergeProjectDefaults(defaults *service.SettingsRecord) error {
+	return s.applyProjectRecord(defaults, false)
+}
+
+// ApplyProjectSettings applies the settings that a project may set
+// defaults for, as in effect for a run, over the ones set otherwise.
+//
+// It's used when syncing a run, so that it's synced with the settings it
+// ran with rather than the ones of the sync.
+func (s *Settings) ApplyProjectSettings(effective *service.SettingsRecord) error {
+	return s.applyProjectRecord(effective, true)
+}
+
+func (s *Settings) applyProjectRecord(
+	record *service.SettingsRecord,
+	overwrite bool,
+) error {
+	m := s.Proto.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	for _, item := range record.GetItem() {
+		fd := fields.ByName(protoreflect.Name(item.GetKey()))
+		if fd == nil || !slices.Contains(projectDefaultFields, fd.Name()) {
+			continue
+		}
+		if !overwrite && s.provenance.Source(fd.Name(), m.Has(fd)) >
+			service.EffectiveSetting_PROJECT {
+			continue
+		}
+
+		// project defaults are all wrapper messages
+		value := m.NewField(fd)
+		err := protojson.Unmarshal(
+			[]byte(item.GetValueJson()),
+			value.Message().Interface(),
+		)
+		if err != nil {
+			return fmt.Errorf("settings: failed to parse %s: %v", fd.Name(), err)
+		}
+		m.Set(fd, value)
+		s.provenance.Set(fd.Name(), service.EffectiveSetting_PROJECT)
+	}
+
+	return nil
+}
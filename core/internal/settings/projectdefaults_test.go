@@ -0,0 +1,92 @@
+package settings_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func projectRecord(items map[string]string) *service.SettingsRecord {
+	record := &service.SettingsRecord{}
+	for key, value := range items {
+		record.Item = append(record.Item,
+			&service.SettingsItem{Key: key, ValueJson: value})
+	}
+	return record
+}
+
+// A project's defaults apply only to the settings that aren't set
+// otherwise, and only to those a project may set.
+func TestMergeProjectDefaults_LocalSettingsTakePrecedence(t *testing.T) {
+	s := settings.From(&service.Settings{
+		DisableGit: wrapperspb.Bool(false),
+	})
+
+	err := s.MergeProjectDefaults(projectRecord(map[string]string{
+		"save_code":   "true",
+		"disable_git": "true",
+		"project":     `"from-the-project"`,
+	}))
+
+	require.NoError(t, err)
+	assert.True(t, s.Proto.GetSaveCode().GetValue())
+	assert.False(t, s.Proto.GetDisableGit().GetValue())
+	assert.Nil(t, s.Proto.GetProject())
+}
+
+// The settings in effect are recorded, whether they came from the project
+// or not.
+func TestProjectDefaultsRecord(t *testing.T) {
+	s := settings.From(&service.Settings{
+		DisableGit: wrapperspb.Bool(false),
+		Project:    wrapperspb.String("project"),
+	})
+	require.NoError(t, s.MergeProjectDefaults(projectRecord(map[string]string{
+		"save_code": "true",
+	})))
+
+	record, err := s.ProjectDefaultsRecord()
+
+	require.NoError(t, err)
+	require.Len(t, record.GetItem(), 2)
+	assert.Equal(t, "disable_git", record.GetItem()[0].GetKey())
+	assert.Equal(t, "false", record.GetItem()[0].GetValueJson())
+	assert.Equal(t, "save_code", record.GetItem()[1].GetKey())
+	assert.Equal(t, "true", record.GetItem()[1].GetValueJson())
+}
+
+func TestProjectDefaultsRecord_NoneSet(t *testing.T) {
+	record, err := settings.From(&service.Settings{}).ProjectDefaultsRecord()
+
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+// The settings a run ran with override the local ones when it's synced.
+func TestApplyProjectSettings_OverridesLocalSettings(t *testing.T) {
+	s := settings.From(&service.Settings{
+		SaveCode: wrapperspb.Bool(false),
+	})
+
+	err := s.ApplyProjectSettings(projectRecord(map[string]string{
+		"save_code": "true",
+	}))
+
+	require.NoError(t, err)
+	assert.True(t, s.Proto.GetSaveCode().GetValue())
+}
+
+func TestMergeProjectDefaults_InvalidValue(t *testing.T) {
+	s := settings.From(&service.Settings{})
+
+	err := s.MergeProjectDefaults(projectRecord(map[string]string{
+		"save_code": `"yes"`,
+	}))
+
+	assert.ErrorContains(t, err, "save_code")
+}
@@ -0,0 +1,236 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// builtinDefaults are the values of the settings that W&B uses if nothing
+// else sets them.
+//
+// They're not copied into the settings, so that the transaction log only
+// stores what was set; the getters fall back to them instead.
+var builtinDefaults = &service.Settings{
+	XNetworkProbeIntervalSeconds:    wrapperspb.Double(10),
+	XRetryBudgetPerMinute:           wrapperspb.Int32(30),
+	XSummaryDebounceIntervalSeconds: wrapperspb.Double(30),
+	XStreamStateIntervalSeconds:     wrapperspb.Double(15),
+	XExitGraceSeconds:               wrapperspb.Double(10),
+	XBackpressureSlowDepth:          wrapperspb.Int32(32),
+	XBackpressureCongestedDepth:     wrapperspb.Int32(64),
+	XVerifyTimeoutSeconds:           wrapperspb.Double(30),
+	XUploadUrlBatchSize:             wrapperspb.Int32(500),
+	XConsoleCapture:                 wrapperspb.String("auto"),
+	XMaxTrackedHistoryKeys:          wrapperspb.Int32(100_000),
+	XMaxSampledHistoryKeys:          wrapperspb.Int32(10_000),
+	XMaxSummaryKeys:                 wrapperspb.Int32(100_000),
+	XMaxPartialHistoryKeys:          wrapperspb.Int32(100_000),
+	XMaxMetricDefinitions:           wrapperspb.Int32(10_000),
+	XMaxReportedFiles:               wrapperspb.Int32(100),
+}
+
+// orDefault returns the value of a setting, or its built-in default if
+// it's not set.
+func orDefault[T any](value, builtin *T) *T {
+	if value == nil {
+		return builtin
+	}
+	return value
+}
+
+// Provenance is where the values of a run's settings came from.
+//
+// It's shared by everything that changes the settings while the run goes
+// on, such as when the project's defaults are fetched. It's safe to use
+// concurrently, and a nil Provenance records nothing.
+type Provenance struct {
+	mu sync.Mutex
+
+	// sources are the sources of the settings by proto field name
+	sources map[protoreflect.Name]service.EffectiveSetting_Source
+}
+
+// NewProvenance returns a Provenance with no sources recorded.
+func NewProvenance() *Provenance {
+	return &Provenance{
+		sources: make(map[protoreflect.Name]service.EffectiveSetting_Source),
+	}
+}
+
+// Set records where a setting's value came from.
+func (p *Provenance) Set(
+	name protoreflect.Name,
+	source service.EffectiveSetting_Source,
+) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sources[name] = source
+}
+
+// Source returns where a setting's value came from.
+//
+// A value with no recorded source was set by the client, which is how
+// settings made without Resolve are treated.
+func (p *Provenance) Source(
+	name protoreflect.Name,
+	isSet bool,
+) service.EffectiveSetting_Source {
+	if !isSet {
+		return service.EffectiveSetting_DEFAULT
+	}
+	if p == nil {
+		return service.EffectiveSetting_CLIENT
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if source, ok := p.sources[name]; ok {
+		return source
+	}
+	return service.EffectiveSetting_CLIENT
+}
+
+// layer is one source of settings that Resolve merges.
+type layer struct {
+	source   service.EffectiveSetting_Source
+	settings *service.Settings
+}
+
+// Resolve merges the layers of a run's settings, recording where each
+// value came from.
+//
+// In increasing precedence, the layers are W&B's built-in defaults, the
+// defaults of the run's project, the client's settings, and environment
+// variables of wandb-core that override them. The built-in defaults are
+// what the getters fall back to, and the project's are merged by
+// MergeProjectDefaults once the run's project is known.
+func Resolve(client *service.Settings) *Settings {
+	s := WithProvenance(&service.Settings{}, NewProvenance())
+	for _, l := range []layer{
+		{service.EffectiveSetting_CLIENT, client},
+		{service.EffectiveSetting_ENV, envOverrides()},
+	} {
+		s.merge(l)
+	}
+	return s
+}
+
+// WithProvenance returns the settings whose values came from the sources
+// in the provenance.
+func WithProvenance(proto *service.Settings, provenance *Provenance) *Settings {
+	return &Settings{Proto: proto, provenance: provenance}
+}
+
+// Provenance returns where the settings' values came from, or nil if the
+// settings weren't resolved.
+func (s *Settings) Provenance() *Provenance {
+	return s.provenance
+}
+
+// merge sets the values that a layer sets over the settings.
+func (s *Settings) merge(l layer) {
+	m := s.Proto.ProtoReflect()
+	proto.Clone(l.settings).ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			m.Set(fd, v)
+			s.provenance.Set(fd.Name(), l.source)
+			return true
+		})
+}
+
+// envOverrides returns the settings that the environment variables of
+// wandb-core override.
+//
+// Invalid values are left for the getters to report.
+func envOverrides() *service.Settings {
+	overrides := &service.Settings{}
+	if spec, ok := os.LookupEnv(RunLabelsEnv); ok {
+		if labels, err := parseRunLabels(spec); err == nil {
+			overrides.XRunLabels = &service.MapStringKeyStringValue{Value: labels}
+		}
+	}
+	if spec, ok := os.LookupEnv(RunLabelKeysEnv); ok {
+		overrides.XRunLabelKeys = &service.ListStringValue{Value: parseRunLabelKeys(spec)}
+	}
+	return overrides
+}
+
+// parseRunLabels parses the value of RunLabelsEnv.
+func parseRunLabels(spec string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("settings: %s: %q is not key=value", RunLabelsEnv, pair)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
+
+// parseRunLabelKeys parses the value of RunLabelKeysEnv.
+func parseRunLabelKeys(spec string) []string {
+	var keys []string
+	for _, key := range strings.Split(spec, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Effective returns every setting's value without secrets and where it
+// came from, sorted by the settings' proto field names.
+//
+// Settings that aren't set have their built-in default, if they have one,
+// and otherwise the value null.
+func (s *Settings) Effective() ([]*service.EffectiveSetting, error) {
+	m := s.Redacted().ProtoReflect()
+	builtin := builtinDefaults.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	effective := make([]*service.EffectiveSetting, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		valueJSON := "null"
+		var item *service.SettingsItem
+		var err error
+		switch {
+		case m.Has(fd):
+			item, err = settingsItem(fd, m.Get(fd))
+		case builtin.Has(fd):
+			item, err = settingsItem(fd, builtin.Get(fd))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			valueJSON = item.ValueJson
+		}
+
+		effective = append(effective, &service.EffectiveSetting{
+			Key:       string(fd.Name()),
+			ValueJson: valueJSON,
+			Source:    s.provenance.Source(fd.Name(), m.Has(fd)),
+		})
+	}
+
+	sort.Slice(effective, func(i, j int) bool {
+		return effective[i].Key < effective[j].Key
+	})
+	return effective, nil
+}
@@ -0,0 +1,117 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// effectiveByKey returns the effective settings by key.
+func effectiveByKey(t *testing.T, s *settings.Settings) map[string]*service.EffectiveSetting {
+	t.Helper()
+	effective, err := s.Effective()
+	require.NoError(t, err)
+	byKey := make(map[string]*service.EffectiveSetting)
+	for _, setting := range effective {
+		byKey[setting.GetKey()] = setting
+	}
+	return byKey
+}
+
+func assertEffective(
+	t *testing.T,
+	byKey map[string]*service.EffectiveSetting,
+	key, valueJSON string,
+	source service.EffectiveSetting_Source,
+) {
+	t.Helper()
+	require.Contains(t, byKey, key)
+	assert.Equal(t, valueJSON, byKey[key].GetValueJson(), key)
+	assert.Equal(t, source, byKey[key].GetSource(), key)
+}
+
+// Each setting is tagged with the layer whose value is in effect.
+func TestResolve_ProvenanceOfEachLayer(t *testing.T) {
+	t.Setenv(settings.RunLabelsEnv, "team=ml")
+	t.Setenv("WANDB_API_KEY", "env-secret")
+	client := &service.Settings{
+		BaseUrl:    wrapperspb.String("https://api.wandb.ai"),
+		Project:    wrapperspb.String("client-project"),
+		DisableGit: wrapperspb.Bool(false),
+		XRunLabels: &service.MapStringKeyStringValue{
+			Value: map[string]string{"team": "client"},
+		},
+		XMaxSummaryKeys: wrapperspb.Int32(5),
+	}
+
+	s := settings.Resolve(client)
+	require.NoError(t, s.EnsureAPIKey())
+	require.NoError(t, s.MergeProjectDefaults(projectRecord(map[string]string{
+		"save_code":   "true",
+		"disable_git": "true",
+	})))
+	byKey := effectiveByKey(t, s)
+
+	assertEffective(t, byKey, "_exit_grace_seconds", "10", service.EffectiveSetting_DEFAULT)
+	assertEffective(t, byKey, "run_name", "null", service.EffectiveSetting_DEFAULT)
+	assertEffective(t, byKey, "save_code", "true", service.EffectiveSetting_PROJECT)
+	assertEffective(t, byKey, "disable_git", "false", service.EffectiveSetting_CLIENT)
+	assertEffective(t, byKey, "project", `"client-project"`, service.EffectiveSetting_CLIENT)
+	assertEffective(t, byKey, "_max_summary_keys", "5", service.EffectiveSetting_CLIENT)
+	assertEffective(t, byKey, "_run_labels", `{"value":{"team":"ml"}}`, service.EffectiveSetting_ENV)
+	assert.Equal(t, service.EffectiveSetting_ENV, byKey["api_key"].GetSource())
+	assert.NotContains(t, byKey["api_key"].GetValueJson(), "env-secret")
+	assert.Equal(t, "env-secret", s.GetAPIKey())
+
+	// the client's settings are left as they were
+	assert.Equal(t, "client", client.GetXRunLabels().GetValue()["team"])
+	assert.Nil(t, client.GetSaveCode())
+}
+
+func TestResolve_APIKeyFromNetrc(t *testing.T) {
+	netrc := filepath.Join(t.TempDir(), "netrc")
+	require.NoError(t, os.WriteFile(netrc,
+		[]byte("machine api.wandb.ai\n  login user\n  password netrc-secret\n"),
+		0o600))
+	t.Setenv("NETRC", netrc)
+	t.Setenv("WANDB_API_KEY", "")
+
+	s := settings.Resolve(&service.Settings{
+		BaseUrl: wrapperspb.String("https://api.wandb.ai"),
+	})
+	require.NoError(t, s.EnsureAPIKey())
+	byKey := effectiveByKey(t, s)
+
+	assert.Equal(t, service.EffectiveSetting_NETRC, byKey["api_key"].GetSource())
+	assert.NotContains(t, byKey["api_key"].GetValueJson(), "netrc-secret")
+}
+
+// The settings of a synced run replace the ones set otherwise, and are
+// tagged as the project's.
+func TestResolve_ProjectSettingsOfSyncedRun(t *testing.T) {
+	s := settings.Resolve(&service.Settings{SaveCode: wrapperspb.Bool(true)})
+
+	require.NoError(t, s.ApplyProjectSettings(projectRecord(map[string]string{
+		"save_code": "false",
+	})))
+
+	assertEffective(t, effectiveByKey(t, s),
+		"save_code", "false", service.EffectiveSetting_PROJECT)
+}
+
+// Settings made without Resolve are taken to be the client's.
+func TestEffective_WithoutProvenance(t *testing.T) {
+	s := settings.From(&service.Settings{Project: wrapperspb.String("p")})
+
+	byKey := effectiveByKey(t, s)
+
+	assertEffective(t, byKey, "project", `"p"`, service.EffectiveSetting_CLIENT)
+	assertEffective(t, byKey, "_console_capture", `"auto"`, service.EffectiveSetting_DEFAULT)
+}
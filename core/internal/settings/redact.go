@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/wandb/wandb/core/internal/redact"
+	"github.com/wandb/wandb/core/pkg/service"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Whether to store the redacted settings in the transaction log.
+func (s *Settings) StoresRedactedSettings() bool {
+	return s.Proto.XStoreRedactedSettings.GetValue()
+}
+
+// Redacted returns a copy of the settings without secrets like the API
+// key, which is safe to log or store.
+func (s *Settings) Redacted() *service.Settings {
+	return redact.Proto(s.Proto)
+}
+
+// RedactedRecord returns the settings without secrets, as stored in the
+// run record of the transaction log.
+//
+// Each item is keyed by the setting's proto field name and holds its
+// value as JSON. Since the API key is not stored, syncing the run later
+// requires a key from the environment.
+func (s *Settings) RedactedRecord() (*service.SettingsRecord, error) {
+	record := &service.SettingsRecord{}
+
+	var err error
+	s.Redacted().ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			var item *service.SettingsItem
+			item, err = settingsItem(fd, v)
+			if err != nil {
+				return false
+			}
+
+			record.Item = append(record.Item, item)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(record.Item, func(i, j int) bool {
+		return record.Item[i].Key < record.Item[j].Key
+	})
+	return record, nil
+}
+
+// settingsItem returns a setting's value as stored in a settings record.
+func settingsItem(
+	fd protoreflect.FieldDescriptor,
+	v protoreflect.Value,
+) (*service.SettingsItem, error) {
+	var valueJSON []byte
+	var err error
+	if fd.Message() != nil {
+		valueJSON, err = protojson.Marshal(v.Message().Interface())
+	} else {
+		valueJSON, err = json.Marshal(v.Interface())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("settings: failed to marshal %s: %v", fd.Name(), err)
+	}
+
+	return &service.SettingsItem{
+		Key:       string(fd.Name()),
+		ValueJson: string(valueJSON),
+	}, nil
+}
@@ -0,0 +1,238 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// RunDirs are where a run's files are kept locally.
+type RunDirs struct {
+	// FilesDir is the directory of the files uploaded with the run.
+	FilesDir string
+
+	// LogDir is the directory of the run's logs.
+	LogDir string
+
+	// SyncFile is the run's transaction log.
+	SyncFile string
+
+	// Fallbacks describe the directories that couldn't be used and were
+	// replaced by temporary ones.
+	Fallbacks []string
+}
+
+// PrepareRunDirs creates the run's files and log directories and the
+// directory of its transaction log, and makes the settings point to them.
+//
+// Relative paths are relative to the root directory, or to the working
+// directory if there is none. A directory that can't be created or
+// written to is replaced by one under the system's temporary directory.
+// It's an error only if that fails too.
+func (s *Settings) PrepareRunDirs() (*RunDirs, error) {
+	dirs := &RunDirs{}
+	fallbackRoot := filepath.Join(os.TempDir(), "wandb", s.runDirName())
+
+	filesDir, err := s.prepareDir(
+		"files",
+		s.GetFilesDir(),
+		filepath.Join(fallbackRoot, "files"),
+		dirs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	originalLogDir := s.absPath(s.GetLogDir())
+	logDir, err := s.prepareDir(
+		"log",
+		s.GetLogDir(),
+		filepath.Join(fallbackRoot, "logs"),
+		dirs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var syncFile string
+	if path := s.Proto.GetSyncFile().GetValue(); path != "" {
+		path = s.absPath(path)
+		syncDir, err := s.prepareDir(
+			"transaction log",
+			filepath.Dir(path),
+			fallbackRoot,
+			dirs,
+		)
+		if err != nil {
+			return nil, err
+		}
+		syncFile = filepath.Join(syncDir, filepath.Base(path))
+	}
+
+	// the internal log moves with the log directory, unless it was put
+	// somewhere else
+	if internalLog := s.GetInternalLogFile(); internalLog != "" {
+		internalLog = s.absPath(internalLog)
+		if logDir != "" && filepath.Dir(internalLog) == originalLogDir {
+			internalLog = filepath.Join(logDir, filepath.Base(internalLog))
+		}
+		setString(&s.Proto.LogInternal, internalLog)
+	}
+
+	dirs.FilesDir = filesDir
+	dirs.LogDir = logDir
+	dirs.SyncFile = syncFile
+	setString(&s.Proto.FilesDir, filesDir)
+	setString(&s.Proto.LogDir, logDir)
+	setString(&s.Proto.SyncFile, syncFile)
+	s.runDirs = dirs
+	return dirs, nil
+}
+
+// GetRunDirs returns where the run's files are kept, or nil if
+// PrepareRunDirs wasn't called.
+func (s *Settings) GetRunDirs() *RunDirs {
+	return s.runDirs
+}
+
+// GetFilesDir returns the directory of the run's files, if any.
+func (d *RunDirs) GetFilesDir() string {
+	if d == nil {
+		return ""
+	}
+	return d.FilesDir
+}
+
+// GetLogDir returns the directory of the run's logs, if any.
+func (d *RunDirs) GetLogDir() string {
+	if d == nil {
+		return ""
+	}
+	return d.LogDir
+}
+
+// GetSyncFile returns the run's transaction log, if any.
+func (d *RunDirs) GetSyncFile() string {
+	if d == nil {
+		return ""
+	}
+	return d.SyncFile
+}
+
+// GetFallbacks describes the directories that were replaced by
+// temporary ones, if any.
+func (d *RunDirs) GetFallbacks() []string {
+	if d == nil {
+		return nil
+	}
+	return d.Fallbacks
+}
+
+// KeepRunDirs makes the settings use the run directories prepared for
+// other settings of the same run, if any.
+func (s *Settings) KeepRunDirs(prepared *Settings) {
+	dirs := prepared.GetRunDirs()
+	if dirs == nil {
+		return
+	}
+
+	setString(&s.Proto.FilesDir, dirs.FilesDir)
+	setString(&s.Proto.LogDir, dirs.LogDir)
+	setString(&s.Proto.SyncFile, dirs.SyncFile)
+	setString(&s.Proto.LogInternal, prepared.GetInternalLogFile())
+	s.runDirs = dirs
+}
+
+// prepareDir creates the directory at the path, or the fallback if the
+// path can't be used, and returns the directory that was created.
+//
+// An empty path isn't used, and neither is its fallback.
+func (s *Settings) prepareDir(
+	kind string,
+	path string,
+	fallback string,
+	dirs *RunDirs,
+) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	path = s.absPath(path)
+
+	err := ensureWritableDir(path)
+	if err == nil {
+		return path, nil
+	}
+
+	if fallbackErr := ensureWritableDir(fallback); fallbackErr != nil {
+		return "", fmt.Errorf(
+			"settings: can't create the %s directory %s (%v)"+
+				" or its fallback %s (%v)",
+			kind, path, err, fallback, fallbackErr,
+		)
+	}
+
+	dirs.Fallbacks = append(dirs.Fallbacks, fmt.Sprintf(
+		"Can't use %s as the %s directory (%v), using %s instead.",
+		path, kind, err, fallback,
+	))
+	return fallback, nil
+}
+
+// absPath makes a relative path relative to the root directory, or to
+// the working directory if there is none.
+func (s *Settings) absPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	if root := s.Proto.GetRootDir().GetValue(); root != "" {
+		return filepath.Join(root, path)
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// runDirName names the directory of the run's temporary files.
+func (s *Settings) runDirName() string {
+	if runID := s.GetRunID(); runID != "" {
+		return "run-" + runID
+	}
+	return "run"
+}
+
+// setString sets a string setting, unless it would clear it.
+func setString(field **wrapperspb.StringValue, value string) {
+	if value == "" {
+		return
+	}
+	*field = &wrapperspb.StringValue{Value: value}
+}
+
+// ensureWritableDir creates the directory if it doesn't exist, and checks
+// that files can be created in it.
+func ensureWritableDir(path string) error {
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			return pathErr.Err
+		}
+		return err
+	}
+
+	probe, err := os.CreateTemp(path, ".wandb-write-check-*")
+	if err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			return pathErr.Err
+		}
+		return err
+	}
+	_ = probe.Close()
+	return os.Remove(probe.Name())
+}
@@ -0,0 +1,144 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func runDirsSettings(root, filesDir, logDir, syncFile string) *settings.Settings {
+	return settings.From(&service.Settings{
+		RunId:       &wrapperspb.StringValue{Value: "dirs"},
+		RootDir:     &wrapperspb.StringValue{Value: root},
+		FilesDir:    &wrapperspb.StringValue{Value: filesDir},
+		LogDir:      &wrapperspb.StringValue{Value: logDir},
+		LogInternal: &wrapperspb.StringValue{Value: filepath.Join(logDir, "debug-internal.log")},
+		SyncFile:    &wrapperspb.StringValue{Value: syncFile},
+	})
+}
+
+func TestPrepareRunDirs_RelativePaths(t *testing.T) {
+	root := t.TempDir()
+	s := runDirsSettings(
+		root,
+		filepath.Join("out", "files"),
+		filepath.Join("out", "logs"),
+		filepath.Join("out", "run-dirs.wandb"),
+	)
+
+	dirs, err := s.PrepareRunDirs()
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "out", "files"), dirs.FilesDir)
+	assert.Equal(t, filepath.Join(root, "out", "logs"), dirs.LogDir)
+	assert.Equal(t, filepath.Join(root, "out", "run-dirs.wandb"), dirs.SyncFile)
+	assert.Empty(t, dirs.Fallbacks)
+	assert.DirExists(t, dirs.FilesDir)
+	assert.DirExists(t, dirs.LogDir)
+	assert.Equal(t, dirs.FilesDir, s.GetFilesDir())
+	assert.Equal(t, dirs.LogDir, s.GetLogDir())
+	assert.Equal(t, dirs.SyncFile, s.Proto.GetSyncFile().GetValue())
+	assert.Equal(t,
+		filepath.Join(root, "out", "logs", "debug-internal.log"),
+		s.GetInternalLogFile())
+	assert.Same(t, dirs, s.GetRunDirs())
+}
+
+func TestPrepareRunDirs_UnusableParentFallsBack(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	root := t.TempDir()
+	blocker := filepath.Join(root, "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o644))
+	logDir := filepath.Join(blocker, "logs")
+	s := runDirsSettings(
+		root,
+		filepath.Join(blocker, "files"),
+		logDir,
+		filepath.Join(root, "sync", "run-dirs.wandb"),
+	)
+
+	dirs, err := s.PrepareRunDirs()
+
+	require.NoError(t, err)
+	fallbackRoot := filepath.Join(tmp, "wandb", "run-dirs")
+	assert.Equal(t, filepath.Join(fallbackRoot, "files"), dirs.FilesDir)
+	assert.Equal(t, filepath.Join(fallbackRoot, "logs"), dirs.LogDir)
+	assert.Equal(t, filepath.Join(root, "sync", "run-dirs.wandb"), dirs.SyncFile)
+	assert.Len(t, dirs.Fallbacks, 2)
+	assert.Contains(t, dirs.Fallbacks[0], filepath.Join(blocker, "files"))
+	assert.DirExists(t, dirs.FilesDir)
+	assert.DirExists(t, dirs.LogDir)
+	assert.Equal(t, dirs.FilesDir, s.GetFilesDir())
+	assert.Equal(t, dirs.LogDir, s.GetLogDir())
+	// The internal log moves with the log directory.
+	assert.Equal(t,
+		filepath.Join(fallbackRoot, "logs", "debug-internal.log"),
+		s.GetInternalLogFile())
+}
+
+func TestPrepareRunDirs_ReadOnlyParent(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions don't apply to root")
+	}
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	readOnly := t.TempDir()
+	require.NoError(t, os.Chmod(readOnly, 0o555))
+	t.Cleanup(func() { _ = os.Chmod(readOnly, 0o755) })
+	s := runDirsSettings(
+		readOnly,
+		"files",
+		t.TempDir(),
+		filepath.Join("sync", "run-dirs.wandb"),
+	)
+
+	dirs, err := s.PrepareRunDirs()
+
+	require.NoError(t, err)
+	fallbackRoot := filepath.Join(tmp, "wandb", "run-dirs")
+	assert.Equal(t, filepath.Join(fallbackRoot, "files"), dirs.FilesDir)
+	assert.Equal(t, filepath.Join(fallbackRoot, "run-dirs.wandb"), dirs.SyncFile)
+	assert.Len(t, dirs.Fallbacks, 2)
+	assert.Contains(t, dirs.Fallbacks[0], "permission denied")
+}
+
+func TestPrepareRunDirs_NoFallback(t *testing.T) {
+	tmp := t.TempDir()
+	blocker := filepath.Join(tmp, "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o644))
+	t.Setenv("TMPDIR", blocker)
+	s := runDirsSettings(
+		tmp,
+		filepath.Join(blocker, "files"),
+		"",
+		"",
+	)
+
+	_, err := s.PrepareRunDirs()
+
+	assert.ErrorContains(t, err, "can't create the files directory")
+	assert.Nil(t, s.GetRunDirs())
+}
+
+func TestKeepRunDirs(t *testing.T) {
+	root := t.TempDir()
+	prepared := runDirsSettings(root, "files", "logs", "run-dirs.wandb")
+	_, err := prepared.PrepareRunDirs()
+	require.NoError(t, err)
+	started := runDirsSettings(root, "files", "logs", "run-dirs.wandb")
+
+	started.KeepRunDirs(prepared)
+
+	assert.Equal(t, filepath.Join(root, "files"), started.GetFilesDir())
+	assert.Equal(t, filepath.Join(root, "logs"), started.GetLogDir())
+	assert.Equal(t, prepared.GetInternalLogFile(), started.GetInternalLogFile())
+	assert.Same(t, prepared.GetRunDirs(), started.GetRunDirs())
+}
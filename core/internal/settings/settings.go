@@ -4,6 +4,8 @@ package settings
 import (
 	"fmt"
 	"net/url"
+	"os"
+	"time"
 
 	"github.com/wandb/wandb/core/pkg/auth"
 	"github.com/wandb/wandb/core/pkg/service"
@@ -19,18 +21,50 @@ type Settings struct {
 	//
 	// DO NOT ADD USAGES. Used to refactor incrementally.
 	Proto *service.Settings
+
+	// runDirs are the run's directories, once prepared
+	runDirs *RunDirs
+
+	// provenance is where the settings' values came from, or nil
+	provenance *Provenance
 }
 
 // Parses the Settings proto into a Settings object.
+//
+// Use Resolve instead for the settings a client sends, to know where
+// their values came from.
 func From(proto *service.Settings) *Settings {
 	return &Settings{Proto: proto}
 }
 
+// Values of the `anonymous` setting.
+const (
+	// Log to an anonymous entity if there is no API key.
+	AnonymousAllow = "allow"
+
+	// Always log to an anonymous entity.
+	AnonymousMust = "must"
+
+	// Never log to an anonymous entity.
+	AnonymousNever = "never"
+
+	// Set once an anonymous API key is used for the session.
+	AnonymousActive = "true"
+)
+
 // Ensures the APIKey is set if it needs to be.
 //
-// Reads the API key from .netrc if it's not already set.
+// If it's not already set, reads the API key from the WANDB_API_KEY
+// environment variable, and otherwise from .netrc. It's not an error for
+// there to be no key if an anonymous one may be created instead.
 func (s *Settings) EnsureAPIKey() error {
-	if s.GetAPIKey() != "" || s.IsOffline() {
+	if s.GetAPIKey() != "" || s.IsOffline() || s.IsDisabled() {
+		return nil
+	}
+
+	if apiKey := os.Getenv("WANDB_API_KEY"); apiKey != "" {
+		s.Proto.ApiKey = &wrapperspb.StringValue{Value: apiKey}
+		s.provenance.Set("api_key", service.EffectiveSetting_ENV)
 		return nil
 	}
 
@@ -43,13 +77,32 @@ func (s *Settings) EnsureAPIKey() error {
 	host := u.Hostname()
 	_, password, err := auth.GetNetrcLogin(host)
 	if err != nil {
+		if s.AllowsAnonymous() {
+			return nil
+		}
 		return fmt.Errorf("settings: failed to get API key from netrc: %v", err)
 	}
 	s.Proto.ApiKey = &wrapperspb.StringValue{Value: password}
+	s.provenance.Set("api_key", service.EffectiveSetting_NETRC)
 
 	return nil
 }
 
+// The anonymous mode, which is one of the Anonymous* values or empty.
+func (s *Settings) GetAnonymous() string {
+	return s.Proto.Anonymous.GetValue()
+}
+
+// Whether the run may be logged to an anonymous entity.
+func (s *Settings) AllowsAnonymous() bool {
+	switch s.GetAnonymous() {
+	case AnonymousAllow, AnonymousMust, AnonymousActive:
+		return true
+	default:
+		return false
+	}
+}
+
 // The W&B API key.
 //
 // This can be empty if we're in offline mode.
@@ -67,6 +120,16 @@ func (s *Settings) IsOffline() bool {
 	return s.Proto.XOffline.GetValue()
 }
 
+// Whether the run is disabled, so that nothing is saved or sent.
+//
+// A disabled run's stream accepts every record and answers every request,
+// but does no disk or network I/O.
+func (s *Settings) IsDisabled() bool {
+	return s.Proto.Mode.GetValue() == "disabled" ||
+		s.Proto.Disabled.GetValue() ||
+		s.Proto.XNoop.GetValue()
+}
+
 // The ID of the run.
 func (s *Settings) GetRunID() string {
 	return s.Proto.RunId.GetValue()
@@ -97,6 +160,407 @@ func (s *Settings) GetInternalLogFile() string {
 	return s.Proto.LogInternal.GetValue()
 }
 
+// A log file shared by all of the service's streams, or empty if each
+// run has its own internal log.
+func (s *Settings) GetSharedInternalLogFile() string {
+	return s.Proto.XSharedInternalLog.GetValue()
+}
+
+// The file the stream's internal log is written to: the shared log if
+// there is one, or else the run's own.
+func (s *Settings) GetStreamLogFile() string {
+	if shared := s.GetSharedInternalLogFile(); shared != "" {
+		return shared
+	}
+	return s.GetInternalLogFile()
+}
+
+// The format of the internal log, "text" or "json".
+//
+// It is empty if not set, in which case the log is JSON.
+func (s *Settings) GetLogFormat() string {
+	return s.Proto.XLogFormat.GetValue()
+}
+
+// How often to log a stream's diagnostics, or 0 to never log them.
+func (s *Settings) GetDiagnosticsInterval() time.Duration {
+	return time.Duration(
+		s.Proto.XDiagnosticsIntervalSeconds.GetValue() * float64(time.Second))
+}
+
+// The memory used by wandb-core in bytes above which to log a warning,
+// or 0 to never warn.
+func (s *Settings) GetCoreRSSWarningBytes() uint64 {
+	return uint64(max(s.Proto.XCoreRssWarningMb.GetValue(), 0)) << 20
+}
+
+// How long to keep idle HTTP connections open, or 0 for the default.
+func (s *Settings) GetHTTPIdleConnTimeout() time.Duration {
+	return time.Duration(
+		s.Proto.XHttpIdleConnTimeoutSeconds.GetValue() * float64(time.Second))
+}
+
+// The idle HTTP connections to keep open per host, or 0 for the default.
+func (s *Settings) GetHTTPMaxIdleConnsPerHost() int {
+	return int(s.Proto.XHttpMaxIdleConnsPerHost.GetValue())
+}
+
+// The most HTTP connections to make per host, or 0 for no limit.
+func (s *Settings) GetHTTPMaxConnsPerHost() int {
+	return int(s.Proto.XHttpMaxConnsPerHost.GetValue())
+}
+
+// Whether to use HTTP/1.1 even with servers that support HTTP/2.
+func (s *Settings) IsHTTP2Disabled() bool {
+	return s.Proto.XHttpDisableHttp2.GetValue()
+}
+
+// How long the filestream batches updates before sending them, or 0 for
+// the default.
+func (s *Settings) GetFileStreamTransmitInterval() time.Duration {
+	return time.Duration(
+		s.Proto.XFileStreamTransmitIntervalSeconds.GetValue() * float64(time.Second))
+}
+
+// The shortest the filestream transmit interval gets with a large backlog,
+// or 0 for the default.
+func (s *Settings) GetFileStreamMinTransmitInterval() time.Duration {
+	return time.Duration(
+		s.Proto.XFileStreamMinTransmitIntervalSeconds.GetValue() * float64(time.Second))
+}
+
+// The longest the filestream transmit interval gets while idle, or 0 for
+// the default.
+func (s *Settings) GetFileStreamMaxTransmitInterval() time.Duration {
+	return time.Duration(
+		s.Proto.XFileStreamMaxTransmitIntervalSeconds.GetValue() * float64(time.Second))
+}
+
+// The most bytes to send in one filestream request, or 0 for the default.
+func (s *Settings) GetFileStreamMaxRequestBytes() int {
+	return int(s.Proto.XFileStreamMaxRequestBytes.GetValue())
+}
+
+// How long requests must fail with network errors before the run goes
+// offline, or 0 to keep retrying them.
+func (s *Settings) GetNetworkOfflineAfter() time.Duration {
+	return time.Duration(
+		s.Proto.XNetworkOfflineAfterSeconds.GetValue() * float64(time.Second))
+}
+
+// How often to check whether the network is back while offline.
+func (s *Settings) GetNetworkProbeInterval() time.Duration {
+	return time.Duration(orDefault(
+		s.Proto.XNetworkProbeIntervalSeconds,
+		builtinDefaults.XNetworkProbeIntervalSeconds,
+	).GetValue() * float64(time.Second))
+}
+
+// How many retries to an endpoint in a minute trigger a warning.
+//
+// This is only for visibility: it doesn't affect retries.
+func (s *Settings) GetRetryBudgetPerMinute() int {
+	if perMinute := s.Proto.XRetryBudgetPerMinute.GetValue(); perMinute > 0 {
+		return int(perMinute)
+	}
+	return int(builtinDefaults.XRetryBudgetPerMinute.GetValue())
+}
+
+// The least time between summary updates sent to the backend.
+func (s *Settings) GetSummaryDebounceInterval() time.Duration {
+	return time.Duration(orDefault(
+		s.Proto.XSummaryDebounceIntervalSeconds,
+		builtinDefaults.XSummaryDebounceIntervalSeconds,
+	).GetValue() * float64(time.Second))
+}
+
+// How often the sender saves how much of the run reached the server.
+func (s *Settings) GetStreamStateInterval() time.Duration {
+	return time.Duration(orDefault(
+		s.Proto.XStreamStateIntervalSeconds,
+		builtinDefaults.XStreamStateIntervalSeconds,
+	).GetValue() * float64(time.Second))
+}
+
+// How long a finishing run waits for its data to be uploaded, or 0 to
+// wait until it's done.
+func (s *Settings) GetExitTimeout() time.Duration {
+	return time.Duration(
+		s.Proto.XExitTimeoutSeconds.GetValue() * float64(time.Second))
+}
+
+// How long after a run's exit to keep accepting its data.
+//
+// Data logged later, or once the run's final summary is flushed, is
+// rejected.
+func (s *Settings) GetExitGrace() time.Duration {
+	return time.Duration(orDefault(
+		s.Proto.XExitGraceSeconds,
+		builtinDefaults.XExitGraceSeconds,
+	).GetValue() * float64(time.Second))
+}
+
+// How many records and updates the sender may have yet to send before the
+// handler slows down taking in history and console output, or 0 to never
+// slow down.
+func (s *Settings) GetBackpressureSlowDepth() int {
+	return int(orDefault(
+		s.Proto.XBackpressureSlowDepth,
+		builtinDefaults.XBackpressureSlowDepth,
+	).GetValue())
+}
+
+// How many records and updates the sender may have yet to send before the
+// stream rejects history and console output, or 0 to never reject them.
+func (s *Settings) GetBackpressureCongestedDepth() int {
+	return int(orDefault(
+		s.Proto.XBackpressureCongestedDepth,
+		builtinDefaults.XBackpressureCongestedDepth,
+	).GetValue())
+}
+
+// How old a finished, fully synced run directory must be to be cleaned
+// up, or 0 to clean up directories of any age only by size.
+func (s *Settings) GetCleanupMaxAge() time.Duration {
+	return time.Duration(s.Proto.XCleanupMaxAgeDays.GetValue()) * 24 * time.Hour
+}
+
+// The most bytes of run directories to keep in the wandb directory before
+// cleaning up the oldest finished, fully synced ones, or 0 for no limit.
+func (s *Settings) GetCleanupMaxTotalBytes() int64 {
+	return s.Proto.XCleanupMaxTotalBytes.GetValue()
+}
+
+// Whether the cleanup only reports what it would delete.
+func (s *Settings) IsCleanupDryRun() bool {
+	return s.Proto.XCleanupDryRun.GetValue()
+}
+
+// Whether to check that the run's data reached the server as it exits.
+func (s *Settings) IsVerifyOnExit() bool {
+	return s.Proto.XVerifyOnExit.GetValue()
+}
+
+// How long the exit waits for the server to have the run's data when
+// verifying it.
+func (s *Settings) GetVerifyTimeout() time.Duration {
+	if seconds := s.Proto.XVerifyTimeoutSeconds.GetValue(); seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return time.Duration(
+		builtinDefaults.XVerifyTimeoutSeconds.GetValue() * float64(time.Second))
+}
+
+// The cap on the bandwidth of file and artifact uploads in bytes per
+// second, or 0 for no cap.
+func (s *Settings) GetUploadBytesPerSecond() int64 {
+	return s.Proto.XUploadBytesPerSecond.GetValue()
+}
+
+// The cap on the bandwidth of filestream traffic in bytes per second, or 0
+// for no cap.
+func (s *Settings) GetFileStreamBytesPerSecond() int64 {
+	return s.Proto.XFileStreamBytesPerSecond.GetValue()
+}
+
+// How many files' upload URLs to request from the server at once.
+func (s *Settings) GetUploadURLBatchSize() int {
+	if size := s.Proto.GetXUploadUrlBatchSize().GetValue(); size > 0 {
+		return int(size)
+	}
+	return int(builtinDefaults.XUploadUrlBatchSize.GetValue())
+}
+
+// The directory to mirror the run's files into, or an empty string to not
+// mirror them.
+func (s *Settings) GetExportDir() string {
+	return s.Proto.GetXExportDir().GetValue()
+}
+
+// Whether to hard-link exported files instead of copying them.
+func (s *Settings) IsExportHardLink() bool {
+	return s.Proto.GetXExportHardLink().GetValue()
+}
+
+// The URL of the second W&B server to mirror the run to, or an empty string
+// to not mirror it.
+func (s *Settings) GetMirrorBaseURL() string {
+	return s.Proto.GetXMirrorBaseUrl().GetValue()
+}
+
+// The API key for the server the run is mirrored to.
+func (s *Settings) GetMirrorAPIKey() string {
+	return s.Proto.GetXMirrorApiKey().GetValue()
+}
+
+// The program that started the run.
+//
+// An override takes precedence over the probed program, which is wrong
+// when the run is started through a wrapper.
+func (s *Settings) GetProgram() string {
+	if program := s.Proto.GetXProgramOverride().GetValue(); program != "" {
+		return program
+	}
+	return s.Proto.GetProgram().GetValue()
+}
+
+// The arguments the program was started with.
+func (s *Settings) GetArgs() []string {
+	if args := s.Proto.GetXArgsOverride().GetValue(); len(args) > 0 {
+		return args
+	}
+	return s.Proto.GetXArgs().GetValue()
+}
+
+// The program's path relative to the root directory.
+func (s *Settings) GetCodePath() string {
+	if codePath := s.Proto.GetXCodePathOverride().GetValue(); codePath != "" {
+		return codePath
+	}
+	return s.Proto.GetProgramRelpath().GetValue()
+}
+
+// The program's path relative to the working directory.
+//
+// The code path override takes precedence here too, as the probed path
+// is of the wrapper.
+func (s *Settings) GetCodePathLocal() string {
+	if codePath := s.Proto.GetXCodePathOverride().GetValue(); codePath != "" {
+		return codePath
+	}
+	return s.Proto.GetXCodePathLocal().GetValue()
+}
+
+// The root directory of the run's code.
+func (s *Settings) GetRootDir() string {
+	if root := s.Proto.GetXRootDirOverride().GetValue(); root != "" {
+		return root
+	}
+	return s.Proto.GetRootDir().GetValue()
+}
+
+// The endpoint to post the run's URL to once the run is created on the
+// server, or an empty string to not post it.
+func (s *Settings) GetRunURLNotifyURL() string {
+	return s.Proto.GetXRunUrlNotifyUrl().GetValue()
+}
+
+// The HTTP endpoint to mirror the run's records to, or an empty string to
+// not mirror them.
+func (s *Settings) GetRecordSinkURL() string {
+	return s.Proto.XRecordSinkUrl.GetValue()
+}
+
+// How many times in a row a console line is output before its repeats are
+// collapsed, or 0 to not collapse them.
+func (s *Settings) GetConsoleDedupThreshold() int {
+	return int(s.Proto.XConsoleDedupThreshold.GetValue())
+}
+
+// Whether console lines are prefixed with when they were printed in the
+// output file, and streamed with that time.
+func (s *Settings) StampsConsoleLines() bool {
+	return s.Proto.XConsoleTimestamps.GetValue()
+}
+
+// Which console streams are saved and uploaded: "off", "auto", "stdout",
+// "stderr" or "both".
+func (s *Settings) GetConsoleCapture() string {
+	if capture := s.Proto.XConsoleCapture.GetValue(); capture != "" {
+		return capture
+	}
+	return builtinDefaults.XConsoleCapture.GetValue()
+}
+
+// Whether the timestamps in history are corrected for a local clock that
+// is far off the server's.
+func (s *Settings) CorrectsClockSkew() bool {
+	return !s.Proto.XDisableClockSkewCorrection.GetValue()
+}
+
+// The most history rows per second to send to the server, or 0 for no
+// limit.
+func (s *Settings) GetMaxHistoryRowsPerSecond() float64 {
+	return s.Proto.XMaxHistoryRowsPerSecond.GetValue()
+}
+
+// The most distinct history keys to summarize and sample, or 0 for no
+// limit.
+//
+// Values of keys beyond the limit are still saved and sent.
+func (s *Settings) GetMaxTrackedHistoryKeys() int {
+	return int(orDefault(
+		s.Proto.XMaxTrackedHistoryKeys,
+		builtinDefaults.XMaxTrackedHistoryKeys,
+	).GetValue())
+}
+
+// The most history metrics to keep samples of, or 0 for no limit.
+//
+// The samples of the least recently logged metrics are dropped first.
+func (s *Settings) GetMaxSampledHistoryKeys() int {
+	return int(orDefault(
+		s.Proto.XMaxSampledHistoryKeys,
+		builtinDefaults.XMaxSampledHistoryKeys,
+	).GetValue())
+}
+
+// The most top-level summary keys, or 0 for no limit.
+//
+// Updates of keys beyond the limit are dropped.
+func (s *Settings) GetMaxSummaryKeys() int {
+	return int(orDefault(
+		s.Proto.XMaxSummaryKeys,
+		builtinDefaults.XMaxSummaryKeys,
+	).GetValue())
+}
+
+// The most top-level keys in a history row built from partial history, or
+// 0 for no limit.
+//
+// Values of keys beyond the limit are dropped.
+func (s *Settings) GetMaxPartialHistoryKeys() int {
+	return int(orDefault(
+		s.Proto.XMaxPartialHistoryKeys,
+		builtinDefaults.XMaxPartialHistoryKeys,
+	).GetValue())
+}
+
+// The most metrics to define, or 0 for no limit.
+//
+// Definitions beyond the limit are dropped.
+func (s *Settings) GetMaxMetricDefinitions() int {
+	return int(orDefault(
+		s.Proto.XMaxMetricDefinitions,
+		builtinDefaults.XMaxMetricDefinitions,
+	).GetValue())
+}
+
+// The most files to list in the run's exit results, or 0 for no limit.
+func (s *Settings) GetMaxReportedFiles() int {
+	return int(orDefault(
+		s.Proto.XMaxReportedFiles,
+		builtinDefaults.XMaxReportedFiles,
+	).GetValue())
+}
+
+// Whether the writer hands records to the sender before saving them.
+func (s *Settings) IsLowLatency() bool {
+	return s.Proto.XLowLatency.GetValue()
+}
+
+// The types of records to mirror, like "history", or empty to mirror all.
+func (s *Settings) GetRecordSinkTypes() []string {
+	return s.Proto.XRecordSinkTypes.GetValue()
+}
+
+// The faults to inject into the core, for testing.
+//
+// See package faults for the format.
+func (s *Settings) GetFaultInjection() string {
+	return s.Proto.XFaultInjection.GetValue()
+}
+
 // The local directory where the run's files are stored.
 func (s *Settings) GetFilesDir() string {
 	return s.Proto.FilesDir.GetValue()
@@ -106,3 +570,53 @@ func (s *Settings) GetFilesDir() string {
 func (s *Settings) GetIgnoreGlobs() []string {
 	return s.Proto.IgnoreGlobs.GetValue()
 }
+
+// Dotted config key patterns to send to the server, or empty to send all.
+func (s *Settings) GetConfigIncludeKeys() []string {
+	return s.Proto.ConfigIncludeKeys.GetValue()
+}
+
+// Dotted config key patterns to keep local instead of sending.
+func (s *Settings) GetConfigExcludeKeys() []string {
+	return s.Proto.ConfigExcludeKeys.GetValue()
+}
+
+// Environment variables with which the infrastructure running a run
+// overrides its labels settings.
+const (
+	RunLabelsEnv    = "WANDB_NEXUS_RUN_LABELS"
+	RunLabelKeysEnv = "WANDB_NEXUS_RUN_LABEL_KEYS"
+)
+
+// The labels that the infrastructure stamps on the run.
+//
+// RunLabelsEnv overrides the setting with "key=value" pairs separated by
+// commas. It's an error for a pair to have no "=".
+func (s *Settings) GetRunLabels() (map[string]string, error) {
+	spec, ok := os.LookupEnv(RunLabelsEnv)
+	if !ok {
+		return s.Proto.XRunLabels.GetValue(), nil
+	}
+
+	return parseRunLabels(spec)
+}
+
+// The keys of the labels that may be stamped on the run.
+//
+// RunLabelKeysEnv overrides the setting with keys separated by commas.
+func (s *Settings) GetRunLabelKeys() []string {
+	spec, ok := os.LookupEnv(RunLabelKeysEnv)
+	if !ok {
+		return s.Proto.XRunLabelKeys.GetValue()
+	}
+
+	return parseRunLabelKeys(spec)
+}
+
+// Whether requests meant for the backend are recorded in the run's
+// directory instead of being sent.
+//
+// See package dryrun.
+func (s *Settings) IsDryRun() bool {
+	return s.Proto.XDryRun.GetValue()
+}
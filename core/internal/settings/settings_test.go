@@ -0,0 +1,91 @@
+package settings_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestProgramOverrides(t *testing.T) {
+	probed := &service.Settings{
+		Program:        wrapperspb.String("/usr/bin/entrypoint.sh"),
+		XArgs:          &service.ListStringValue{Value: []string{"--wrapped"}},
+		ProgramRelpath: wrapperspb.String("entrypoint.sh"),
+		XCodePathLocal: wrapperspb.String("entrypoint.sh"),
+		RootDir:        wrapperspb.String("/"),
+	}
+
+	testCases := []struct {
+		name          string
+		settings      *service.Settings
+		program       string
+		args          []string
+		codePath      string
+		codePathLocal string
+		root          string
+	}{
+		{
+			"probed",
+			probed,
+			"/usr/bin/entrypoint.sh", []string{"--wrapped"},
+			"entrypoint.sh", "entrypoint.sh", "/",
+		},
+		{
+			"empty overrides",
+			&service.Settings{
+				Program:           probed.Program,
+				XArgs:             probed.XArgs,
+				ProgramRelpath:    probed.ProgramRelpath,
+				XCodePathLocal:    probed.XCodePathLocal,
+				RootDir:           probed.RootDir,
+				XProgramOverride:  wrapperspb.String(""),
+				XArgsOverride:     &service.ListStringValue{},
+				XCodePathOverride: wrapperspb.String(""),
+				XRootDirOverride:  wrapperspb.String(""),
+			},
+			"/usr/bin/entrypoint.sh", []string{"--wrapped"},
+			"entrypoint.sh", "entrypoint.sh", "/",
+		},
+		{
+			"overrides",
+			&service.Settings{
+				Program:           probed.Program,
+				XArgs:             probed.XArgs,
+				ProgramRelpath:    probed.ProgramRelpath,
+				XCodePathLocal:    probed.XCodePathLocal,
+				RootDir:           probed.RootDir,
+				XProgramOverride:  wrapperspb.String("train.py"),
+				XArgsOverride:     &service.ListStringValue{Value: []string{"--lr", "0.1"}},
+				XCodePathOverride: wrapperspb.String("src/train.py"),
+				XRootDirOverride:  wrapperspb.String("/workspace"),
+			},
+			"train.py", []string{"--lr", "0.1"},
+			"src/train.py", "src/train.py", "/workspace",
+		},
+		{
+			"overrides without probed values",
+			&service.Settings{
+				XProgramOverride: wrapperspb.String("train.py"),
+				XArgsOverride:    &service.ListStringValue{Value: []string{"--lr", "0.1"}},
+			},
+			"train.py", []string{"--lr", "0.1"},
+			"", "", "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := settings.From(tc.settings)
+
+			assert.Equal(t, tc.program, s.GetProgram())
+			assert.Equal(t, tc.args, s.GetArgs())
+			assert.Equal(t, tc.codePath, s.GetCodePath())
+			assert.Equal(t, tc.codePathLocal, s.GetCodePathLocal())
+			assert.Equal(t, tc.root, s.GetRootDir())
+		})
+	}
+}
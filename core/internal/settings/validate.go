@@ -0,0 +1,101 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// The longest allowed run ID.
+	maxRunIDLength = 64
+
+	// The longest allowed project or entity name.
+	maxNameLength = 128
+
+	// Characters that may not appear in project and entity names, because
+	// they have a special meaning in run paths and URLs.
+	invalidNameChars = `/\#?%:`
+)
+
+// Validate checks the run ID, project and entity for problems that would
+// make the server reject the run, and that a mirror has credentials.
+//
+// Empty values are valid; they're chosen later, either by the client or
+// by the server.
+func (s *Settings) Validate() error {
+	if err := ValidateRunID(s.GetRunID()); err != nil {
+		return err
+	}
+	if err := ValidateName("project", s.GetProject()); err != nil {
+		return err
+	}
+	if err := ValidateName("entity", s.GetEntity()); err != nil {
+		return err
+	}
+	if s.GetMirrorBaseURL() != "" && s.GetMirrorAPIKey() == "" {
+		return fmt.Errorf(
+			"no API key for the mirror %s: set _mirror_api_key",
+			s.GetMirrorBaseURL(),
+		)
+	}
+	return nil
+}
+
+// ValidateRunID checks that a run ID has only lowercase letters, digits,
+// dashes and underscores, and is at most 64 characters long.
+//
+// IDs generated by the SDK are drawn from lowercase letters and digits,
+// so they are always valid.
+func ValidateRunID(runID string) error {
+	if len(runID) > maxRunIDLength {
+		return fmt.Errorf(
+			"invalid run id %q: run id may be at most %d characters long",
+			runID, maxRunIDLength,
+		)
+	}
+
+	for _, c := range runID {
+		switch {
+		case 'a' <= c && c <= 'z':
+		case '0' <= c && c <= '9':
+		case c == '-', c == '_':
+		default:
+			return fmt.Errorf(
+				"invalid run id %q: run id may only contain lowercase letters,"+
+					" digits, dashes and underscores, found %q",
+				runID, c,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateName checks that a project or entity name is at most 128
+// characters long and has none of the characters /\#?%:.
+//
+// The kind is "project" or "entity" and is used in the error message.
+func ValidateName(kind string, name string) error {
+	if len(name) > maxNameLength {
+		return fmt.Errorf(
+			"invalid %s name %q: %s name may be at most %d characters long",
+			kind, name, kind, maxNameLength,
+		)
+	}
+
+	if i := strings.IndexAny(name, invalidNameChars); i >= 0 {
+		return fmt.Errorf(
+			"invalid %s name %q: %s name may not contain any of %q, found %q",
+			kind, name, kind, invalidNameChars, name[i],
+		)
+	}
+
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf(
+			"invalid %s name %q: %s name may not start or end with whitespace",
+			kind, name, kind,
+		)
+	}
+
+	return nil
+}
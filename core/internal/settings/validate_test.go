@@ -0,0 +1,108 @@
+package settings_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+func TestValidateRunID(t *testing.T) {
+	testCases := []struct {
+		runID string
+		err   string
+	}{
+		{"", ""},
+		{"3f9d8c2e", ""},
+		{"my-run_2", ""},
+		{strings.Repeat("a", 64), ""},
+		{strings.Repeat("a", 65), "run id may be at most 64 characters long"},
+		{"MyRun", "run id may only contain lowercase letters, digits, dashes and underscores, found 'M'"},
+		{"team/run", "run id may only contain lowercase letters, digits, dashes and underscores, found '/'"},
+		{"my run", "run id may only contain lowercase letters, digits, dashes and underscores, found ' '"},
+		{"run.1", "run id may only contain lowercase letters, digits, dashes and underscores, found '.'"},
+		{"rün", "run id may only contain lowercase letters, digits, dashes and underscores, found 'ü'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.runID, func(t *testing.T) {
+			err := settings.ValidateRunID(tc.runID)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateRunID_GeneratedIDs(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, settings.ValidateRunID(utils.ShortID(8)))
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  string
+	}{
+		{"", ""},
+		{"my-project", ""},
+		{"My Project 2", ""},
+		{strings.Repeat("p", 128), ""},
+		{strings.Repeat("p", 129), "project name may be at most 128 characters long"},
+		{"team/project", `project name may not contain any of "/\\#?%:", found '/'`},
+		{"project#1", `project name may not contain any of "/\\#?%:", found '#'`},
+		{"100%", `project name may not contain any of "/\\#?%:", found '%'`},
+		{"a:b", `project name may not contain any of "/\\#?%:", found ':'`},
+		{" project", "project name may not start or end with whitespace"},
+		{"project\n", "project name may not start or end with whitespace"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := settings.ValidateName("project", tc.name)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := &service.Settings{
+		RunId:   &wrapperspb.StringValue{Value: "abc123"},
+		Project: &wrapperspb.StringValue{Value: "my-project"},
+		Entity:  &wrapperspb.StringValue{Value: "my-team"},
+	}
+	assert.NoError(t, settings.From(valid).Validate())
+	assert.NoError(t, settings.From(&service.Settings{}).Validate())
+
+	badEntity := &service.Settings{
+		RunId:  &wrapperspb.StringValue{Value: "abc123"},
+		Entity: &wrapperspb.StringValue{Value: "my/team"},
+	}
+	assert.ErrorContains(t, settings.From(badEntity).Validate(),
+		`invalid entity name "my/team"`)
+}
+
+func TestValidate_MirrorNeedsAPIKey(t *testing.T) {
+	mirror := &service.Settings{
+		XMirrorBaseUrl: &wrapperspb.StringValue{Value: "https://api.wandb.ai"},
+	}
+	assert.ErrorContains(t, settings.From(mirror).Validate(),
+		"no API key for the mirror https://api.wandb.ai")
+
+	mirror.XMirrorApiKey = &wrapperspb.StringValue{Value: "mirror-key"}
+	assert.NoError(t, settings.From(mirror).Validate())
+}
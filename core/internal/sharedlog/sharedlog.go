@@ -0,0 +1,163 @@
+// Package sharedlog writes the internal logs of many streams to one file
+// that's rotated by size.
+//
+// A process that creates thousands of short-lived streams would otherwise
+// leave thousands of small log files behind. Streams that share a log
+// tell their lines apart by a stream ID attribute.
+//
+// When the file would grow past its limit, it's renamed to "<name>.1",
+// the previous "<name>.1" to "<name>.2" and so on, dropping the oldest,
+// and a new file is started.
+package sharedlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// DefaultMaxBytes is how large a shared log grows before it's rotated.
+	DefaultMaxBytes = 64 << 20
+
+	// DefaultBackups is how many rotated files of a shared log are kept.
+	DefaultBackups = 5
+)
+
+var (
+	// openMu guards open.
+	openMu sync.Mutex
+
+	// open are the shared logs opened by the process, by absolute path.
+	open = make(map[string]*Log)
+)
+
+// Log is a log file that's rotated by size.
+//
+// It is safe for concurrent use. Each Write goes to the file whole, so
+// lines written by different streams never interleave, as long as each
+// line is written in one call as the slog handlers do.
+type Log struct {
+	mu sync.Mutex
+
+	path     string
+	maxBytes int64
+	backups  int
+
+	// file is the file being written, or nil if it couldn't be reopened
+	// after a rotation
+	file *os.File
+
+	// size is the number of bytes in file
+	size int64
+}
+
+// Open returns the shared log at the path, opening it if it isn't open.
+//
+// Every caller that opens the same path gets the same Log, which stays
+// open for the life of the process. The limits only apply when the log
+// is first opened; maxBytes and backups of zero select the defaults.
+func Open(path string, maxBytes int64, backups int) (*Log, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("sharedlog: bad path: %v", err)
+	}
+
+	openMu.Lock()
+	defer openMu.Unlock()
+	if log, ok := open[path]; ok {
+		return log, nil
+	}
+
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if backups <= 0 {
+		backups = DefaultBackups
+	}
+	log := &Log{path: path, maxBytes: maxBytes, backups: backups}
+	if err := log.openFile(); err != nil {
+		return nil, err
+	}
+	open[path] = log
+	return log, nil
+}
+
+// Path is the absolute path of the log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Write appends p to the log, first rotating it if p would take it past
+// its limit.
+//
+// A line larger than the limit is written to a file of its own.
+func (l *Log) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.size > 0 && l.size+int64(len(p)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	if l.file == nil {
+		if err := l.openFile(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+	return n, err
+}
+
+// rotate moves the current file to the first backup and starts a new one.
+//
+// The mutex must be held.
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("sharedlog: failed to close the log: %v", err)
+	}
+	l.file = nil
+
+	// the oldest backup is overwritten by the one before it
+	for i := l.backups - 1; i > 0; i-- {
+		err := os.Rename(l.backupPath(i), l.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("sharedlog: failed to rotate the log: %v", err)
+		}
+	}
+	if err := os.Rename(l.path, l.backupPath(1)); err != nil {
+		return fmt.Errorf("sharedlog: failed to rotate the log: %v", err)
+	}
+
+	return l.openFile()
+}
+
+// openFile opens the log file for appending.
+//
+// The mutex must be held, or the log not yet shared.
+func (l *Log) openFile() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("sharedlog: can't create the log directory: %v", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		return fmt.Errorf("sharedlog: can't open the log: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("sharedlog: can't open the log: %v", err)
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+func (l *Log) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", l.path, i)
+}
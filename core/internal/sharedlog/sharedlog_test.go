@@ -0,0 +1,103 @@
+package sharedlog_test
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/sharedlog"
+)
+
+// readLines returns the lines of the log and its backups, oldest first.
+func readLines(t *testing.T, path string, backups int) []string {
+	t.Helper()
+	var lines []string
+	for i := backups; i >= 0; i-- {
+		name := path
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d", path, i)
+		}
+		file, err := os.Open(name)
+		if os.IsNotExist(err) {
+			continue
+		}
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		require.NoError(t, file.Close())
+	}
+	return lines
+}
+
+func TestOpen_SamePathSameLog(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := sharedlog.Open(filepath.Join(dir, "core.log"), 0, 0)
+	require.NoError(t, err)
+	second, err := sharedlog.Open(filepath.Join(dir, ".", "core.log"), 0, 0)
+	require.NoError(t, err)
+	other, err := sharedlog.Open(filepath.Join(dir, "other.log"), 0, 0)
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+}
+
+func TestWrite_RotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "core.log")
+	log, err := sharedlog.Open(path, 100, 2)
+	require.NoError(t, err)
+
+	for i := range 10 {
+		_, err := fmt.Fprintf(log, "line %02d %s\n", i, strings.Repeat("x", 30))
+		require.NoError(t, err)
+	}
+
+	// each file holds two 40-byte lines, and only two backups are kept
+	assert.NoFileExists(t, path+".3")
+	lines := readLines(t, path, 2)
+	require.Len(t, lines, 6)
+	for i, line := range lines {
+		assert.True(t, strings.HasPrefix(line, fmt.Sprintf("line %02d", i+4)), line)
+	}
+}
+
+func TestWrite_ConcurrentLinesStayWhole(t *testing.T) {
+	const writers, lines = 8, 200
+	path := filepath.Join(t.TempDir(), "core.log")
+	log, err := sharedlog.Open(path, 4096, 100)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range lines {
+				_, err := fmt.Fprintf(log, "stream=%d line=%03d end\n", w, i)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	all := readLines(t, path, 100)
+	require.Len(t, all, writers*lines)
+	next := make(map[int]int)
+	for _, line := range all {
+		var w, i int
+		_, err := fmt.Sscanf(line, "stream=%d line=%d end", &w, &i)
+		require.NoError(t, err, line)
+		assert.Equal(t, next[w], i, "stream %d's lines out of order", w)
+		next[w] = i + 1
+	}
+}
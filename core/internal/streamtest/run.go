@@ -0,0 +1,290 @@
+package streamtest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// resultTimeout is how long to wait for the stream to answer a record.
+const resultTimeout = 10 * time.Second
+
+// startTime is the start time of every scripted run.
+var startTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// upsertBucketResponse is the default response to UpsertBucket.
+const upsertBucketResponse = `{
+	"upsertBucket": {
+		"bucket": {
+			"id": "storage-id",
+			"displayName": "golden-run",
+			"project": {"name": "golden", "entity": {"name": "golden-entity"}}
+		}
+	}
+}`
+
+// resultResponder passes on the results it's sent.
+type resultResponder chan *service.Result
+
+func (r resultResponder) Respond(response *service.ServerResponse) {
+	r <- response.GetResultCommunicate()
+}
+
+// Run drives the script through a stream against a fake backend, and
+// returns a snapshot of what the backend received and the files the run
+// produced once the run is finished.
+func Run(t *testing.T, script *Script) *Snapshot {
+	t.Helper()
+
+	backend := servertest.NewFakeBackend()
+	t.Cleanup(backend.Close)
+	stubBackend(t, backend, script)
+
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	syncFile := filepath.Join(dir, "run-"+script.RunID+".wandb")
+
+	// the client creates the files directory before starting the run
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+
+	stream, responses := startStream(t, backend, dir, &service.Settings{
+		SyncFile: &wrapperspb.StringValue{Value: syncFile},
+		FilesDir: &wrapperspb.StringValue{Value: filesDir},
+		Resume:   &wrapperspb.StringValue{Value: script.Resume},
+		XOffline: &wrapperspb.BoolValue{Value: script.Offline},
+	}, script)
+	startRun(t, stream, responses, script)
+	for _, step := range script.Steps {
+		runStep(t, stream, filesDir, step)
+	}
+
+	if script.Offline {
+		syncRun(t, backend, dir, syncFile, filesDir, script)
+	}
+
+	return takeSnapshot(t, backend, filesDir, dir, backend.URL())
+}
+
+// stubBackend stubs the backend's responses to GraphQL operations.
+func stubBackend(t *testing.T, backend *servertest.FakeBackend, script *Script) {
+	t.Helper()
+	backend.StubGraphQL("UpsertBucket", upsertBucketResponse)
+	if _, ok := script.GraphQL["CreateRunFiles"]; !ok {
+		backend.StubCreateRunFiles()
+	}
+	for operation, data := range script.GraphQL {
+		response, err := json.Marshal(data)
+		require.NoError(t, err)
+		backend.StubGraphQL(operation, string(response))
+	}
+}
+
+// startStream starts a stream for the run with the given settings on top
+// of those every scripted run uses.
+func startStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	dir string,
+	runSettings *service.Settings,
+	script *Script,
+) (*server.Stream, resultResponder) {
+	t.Helper()
+	runSettings.RunId = &wrapperspb.StringValue{Value: script.RunID}
+	runSettings.BaseUrl = &wrapperspb.StringValue{Value: backend.URL()}
+	runSettings.ApiKey = &wrapperspb.StringValue{Value: "test-api-key"}
+	runSettings.LogDir = &wrapperspb.StringValue{Value: dir}
+	runSettings.LogInternal = &wrapperspb.StringValue{
+		Value: filepath.Join(dir, "debug-internal.log"),
+	}
+	runSettings.XDisableStats = &wrapperspb.BoolValue{Value: true}
+	runSettings.XDisableMeta = &wrapperspb.BoolValue{Value: true}
+	runSettings.DisableJobCreation = &wrapperspb.BoolValue{Value: true}
+
+	stream, err := server.NewStream(settings.From(runSettings), "")
+	require.NoError(t, err)
+	responses := make(resultResponder, 256)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	return stream, responses
+}
+
+// startRun sends the run and starts it with the run the stream answers
+// with, like a client does.
+func startRun(
+	t *testing.T,
+	stream *server.Stream,
+	responses resultResponder,
+	script *Script,
+) {
+	t.Helper()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: script.RunID, Project: script.Project},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	result := awaitResult(t, responses, "run").GetRunResult()
+	require.Nil(t, result.GetError(), "the run failed to start")
+
+	// the stream may still be using the run it answered with, and a client
+	// gets its own copy
+	run := proto.Clone(result.GetRun()).(*service.RunRecord)
+	run.StartTime = timestamppb.New(startTime)
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_RunStart{
+				RunStart: &service.RunStartRequest{Run: run},
+			},
+		}},
+	})
+}
+
+// runStep sends the records for the step.
+func runStep(t *testing.T, stream *server.Stream, filesDir string, step Step) {
+	t.Helper()
+	switch {
+	case step.Config != nil:
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_Config{Config: &service.ConfigRecord{
+				Update: configItems(t, step.Config),
+			}},
+		})
+
+	case step.History != nil:
+		for range max(step.History.Rows, 1) {
+			stream.HandleRecord(&service.Record{
+				RecordType: &service.Record_Request{Request: &service.Request{
+					RequestType: &service.Request_PartialHistory{
+						PartialHistory: &service.PartialHistoryRequest{
+							Item:   historyItems(t, step.History.Items),
+							Action: &service.HistoryAction{Flush: true},
+						},
+					},
+				}},
+			})
+		}
+
+	case step.Summary != nil:
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_Summary{Summary: &service.SummaryRecord{
+				Update: summaryItems(t, step.Summary),
+			}},
+		})
+
+	case step.File != nil:
+		path := filepath.Join(filesDir, step.File.Name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(step.File.Contents), 0o644))
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_Files{Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{
+					Path:   step.File.Name,
+					Policy: service.FilesItem_END,
+				}},
+			}},
+		})
+
+	case step.Exit != nil:
+		stream.FinishAndClose(step.Exit.Code)
+	}
+}
+
+// syncRun syncs the offline run's transaction log to the backend.
+func syncRun(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	runDir string,
+	syncFile string,
+	filesDir string,
+	script *Script,
+) {
+	t.Helper()
+	dir := filepath.Join(runDir, "sync")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	sync, responses := startStream(t, backend, dir, &service.Settings{
+		SyncFile: &wrapperspb.StringValue{Value: syncFile},
+		FilesDir: &wrapperspb.StringValue{Value: filesDir},
+		XSync:    &wrapperspb.BoolValue{Value: true},
+	}, script)
+	defer sync.Close()
+
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+		}},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "sync",
+	})
+	response := awaitResult(t, responses, "sync").GetResponse().GetSyncResponse()
+	require.Nil(t, response.GetError(), "the run failed to sync")
+}
+
+// awaitResult returns the result answering the record with the UUID.
+func awaitResult(t *testing.T, responses resultResponder, uuid string) *service.Result {
+	t.Helper()
+	timeout := time.After(resultTimeout)
+	for {
+		select {
+		case result := <-responses:
+			if result.GetUuid() == uuid {
+				return result
+			}
+		case <-timeout:
+			t.Fatalf("streamtest: %s wasn't answered", uuid)
+			return nil
+		}
+	}
+}
+
+func configItems(t *testing.T, values map[string]any) []*service.ConfigItem {
+	var items []*service.ConfigItem
+	for key, value := range values {
+		items = append(items, &service.ConfigItem{
+			Key:       key,
+			ValueJson: mustJSON(t, value),
+		})
+	}
+	return items
+}
+
+func historyItems(t *testing.T, values map[string]any) []*service.HistoryItem {
+	var items []*service.HistoryItem
+	for key, value := range values {
+		items = append(items, &service.HistoryItem{
+			Key:       key,
+			ValueJson: mustJSON(t, value),
+		})
+	}
+	return items
+}
+
+func summaryItems(t *testing.T, values map[string]any) []*service.SummaryItem {
+	var items []*service.SummaryItem
+	for key, value := range values {
+		items = append(items, &service.SummaryItem{
+			Key:       key,
+			ValueJson: mustJSON(t, value),
+		})
+	}
+	return items
+}
+
+func mustJSON(t *testing.T, value any) string {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	require.NoError(t, err)
+	return string(encoded)
+}
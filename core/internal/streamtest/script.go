@@ -0,0 +1,147 @@
+// Package streamtest runs scripts of records through a real stream against
+// a fake backend, and compares what the backend received and the files the
+// run produced to golden files.
+//
+// Scripts are YAML fixtures that describe a run the way a client would
+// drive it: its settings, the responses the backend should give, and the
+// records to log. Golden files are regenerated by running the tests with
+// the update flag.
+package streamtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Script is a run to drive through a stream.
+type Script struct {
+	// RunID and Project identify the run.
+	RunID   string `yaml:"run_id"`
+	Project string `yaml:"project"`
+
+	// Resume is the run's resume setting, such as "allow" or "must".
+	Resume string `yaml:"resume"`
+
+	// Offline is whether the run is logged offline and then synced to the
+	// backend from its transaction log.
+	Offline bool `yaml:"offline"`
+
+	// GraphQL are the responses of the backend to GraphQL operations, by
+	// operation name, given as the "data" of the response.
+	//
+	// UpsertBucket and CreateRunFiles are stubbed with a successful
+	// response unless given.
+	GraphQL map[string]any `yaml:"graphql"`
+
+	// Steps are what the run logs, in order. The last one must be an exit.
+	Steps []Step `yaml:"steps"`
+}
+
+// Step is one thing a run logs. Exactly one of its fields is set.
+type Step struct {
+	// Config updates the run's config.
+	Config map[string]any `yaml:"config"`
+
+	// History logs rows of history.
+	History *HistoryStep `yaml:"history"`
+
+	// Summary updates the run's summary.
+	Summary map[string]any `yaml:"summary"`
+
+	// File writes a file to the run's files directory and saves it.
+	File *FileStep `yaml:"file"`
+
+	// Exit finishes the run.
+	Exit *ExitStep `yaml:"exit"`
+}
+
+// HistoryStep logs rows of history at consecutive steps.
+type HistoryStep struct {
+	// Rows is how many rows to log, or 0 for one.
+	Rows int `yaml:"rows"`
+
+	// Items are the values logged in each row.
+	Items map[string]any `yaml:"items"`
+}
+
+// FileStep saves a file with the run.
+type FileStep struct {
+	// Name is the file's path relative to the files directory.
+	Name string `yaml:"name"`
+
+	// Contents are the file's contents.
+	Contents string `yaml:"contents"`
+}
+
+// ExitStep finishes the run with an exit code.
+type ExitStep struct {
+	Code int32 `yaml:"code"`
+}
+
+// ReadScript reads and validates the script at the path.
+func ReadScript(path string) (*Script, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := ParseScript(contents)
+	if err != nil {
+		return nil, fmt.Errorf("streamtest: %s: %v", path, err)
+	}
+	return script, nil
+}
+
+// ParseScript parses and validates a script.
+func ParseScript(contents []byte) (*Script, error) {
+	var script Script
+	if err := yaml.Unmarshal(contents, &script); err != nil {
+		return nil, err
+	}
+
+	if script.RunID == "" || script.Project == "" {
+		return nil, errors.New("run_id and project are required")
+	}
+	if len(script.Steps) == 0 || script.Steps[len(script.Steps)-1].Exit == nil {
+		return nil, errors.New("the last step must be an exit")
+	}
+	for i, step := range script.Steps {
+		if n := step.kinds(); n != 1 {
+			return nil, fmt.Errorf("step %d has %d kinds, expected 1", i, n)
+		}
+		if step.Exit != nil && i != len(script.Steps)-1 {
+			return nil, fmt.Errorf("step %d exits before the last step", i)
+		}
+		if step.File != nil && step.File.Name == "" {
+			return nil, fmt.Errorf("step %d saves a file without a name", i)
+		}
+	}
+	for operation, data := range script.GraphQL {
+		if _, err := json.Marshal(data); err != nil {
+			return nil, fmt.Errorf("graphql response for %s: %v", operation, err)
+		}
+	}
+
+	return &script, nil
+}
+
+// kinds is how many of the step's fields are set.
+func (s Step) kinds() int {
+	n := 0
+	for _, set := range []bool{
+		s.Config != nil,
+		s.History != nil,
+		s.Summary != nil,
+		s.File != nil,
+		s.Exit != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,286 @@
+package streamtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+)
+
+// masked replaces values that differ between runs of the same script.
+const masked = "<masked>"
+
+// maskedKeys are the keys whose values differ between runs of the same
+// script, such as times and versions.
+var maskedKeys = map[string]bool{
+	"_runtime":     true,
+	"_timestamp":   true,
+	"runtime":      true,
+	"start_time":   true,
+	"cli_version":  true,
+	"core_version": true,
+	"go_version":   true,
+}
+
+// maskedPaths are the dotted paths from the root of a file's contents
+// whose values differ between runs of the same script.
+var maskedPaths = map[string]bool{
+	// the core's resource usage
+	"_wandb.value.nexus": true,
+
+	// the version of the core in the run's telemetry
+	"_wandb.value.t.12": true,
+}
+
+// Snapshot is what the backend received from a run and the files the run
+// produced, with the values that differ between runs masked.
+type Snapshot struct {
+	// Operations are the names of the GraphQL operations the backend
+	// received, sorted and without repeats.
+	//
+	// Their order and number depend on timing.
+	Operations []string `json:"operations"`
+
+	// Config is the run's config as last upserted.
+	Config any `json:"config"`
+
+	// FileStream are the lines of each file sent through the filestream,
+	// by file name, in order.
+	FileStream map[string][]any `json:"filestream"`
+
+	// ExitCode is the exit code the filestream was completed with, or nil
+	// if it wasn't.
+	ExitCode *int `json:"exit_code"`
+
+	// Uploads are the contents of the files uploaded last, by name.
+	Uploads map[string]any `json:"uploads"`
+
+	// Files are the contents of the run's files directory, by path.
+	Files map[string]any `json:"files"`
+}
+
+// takeSnapshot returns a snapshot of the backend and the files directory.
+//
+// The strings in replace, such as temporary directories, are masked too.
+func takeSnapshot(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	filesDir string,
+	replace ...string,
+) *Snapshot {
+	t.Helper()
+	snapshot := &Snapshot{
+		FileStream: make(map[string][]any),
+		Uploads:    make(map[string]any),
+		Files:      make(map[string]any),
+	}
+	normalize := func(contents string) string {
+		for _, s := range replace {
+			contents = strings.ReplaceAll(contents, s, masked)
+		}
+		return contents
+	}
+
+	for _, request := range backend.Requests(servertest.RouteGraphQL) {
+		if !slices.Contains(snapshot.Operations, request.OperationName) {
+			snapshot.Operations = append(snapshot.Operations, request.OperationName)
+		}
+		if config := upsertedConfig(request); config != "" {
+			snapshot.Config = parseContents("config.json", normalize(config))
+		}
+	}
+	sort.Strings(snapshot.Operations)
+
+	snapshot.FileStream, snapshot.ExitCode = streamedFiles(t, backend, normalize)
+
+	for _, request := range backend.Requests(servertest.RouteUpload) {
+		name := strings.TrimPrefix(request.Path, "/upload/")
+		snapshot.Uploads[name] = parseContents(name, normalize(string(request.Body)))
+	}
+
+	err := filepath.WalkDir(filesDir, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == filesDir {
+			return nil
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(filesDir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		snapshot.Files[name] = parseContents(name, normalize(string(contents)))
+		return nil
+	})
+	require.NoError(t, err)
+
+	return snapshot
+}
+
+// upsertedConfig returns the config in an UpsertBucket request, or "".
+func upsertedConfig(request servertest.Request) string {
+	if request.OperationName != "UpsertBucket" {
+		return ""
+	}
+
+	var body struct {
+		Variables struct {
+			Config string `json:"config"`
+		} `json:"variables"`
+	}
+	_ = json.Unmarshal(request.Body, &body)
+	return body.Variables.Config
+}
+
+// streamedFiles returns the lines of each file sent through the
+// filestream, and the exit code it was completed with.
+func streamedFiles(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	normalize func(string) string,
+) (map[string][]any, *int) {
+	t.Helper()
+	lines := make(map[string]map[int]string)
+	var exitCode *int
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Offset  int      `json:"offset"`
+				Content []string `json:"content"`
+			} `json:"files"`
+			Complete *bool `json:"complete"`
+			ExitCode *int  `json:"exitcode"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+
+		for name, chunk := range body.Files {
+			if lines[name] == nil {
+				lines[name] = make(map[int]string)
+			}
+			for i, line := range chunk.Content {
+				lines[name][chunk.Offset+i] = line
+			}
+		}
+		if body.Complete != nil && *body.Complete {
+			exitCode = body.ExitCode
+		}
+	}
+
+	files := make(map[string][]any)
+	for name, byOffset := range lines {
+		offsets := make([]int, 0, len(byOffset))
+		for offset := range byOffset {
+			offsets = append(offsets, offset)
+		}
+		sort.Ints(offsets)
+		for _, offset := range offsets {
+			files[name] = append(files[name],
+				parseContents(".json", normalize(byOffset[offset])))
+		}
+	}
+	return files, exitCode
+}
+
+// parseContents parses the contents of a JSON or YAML file so that its
+// values can be masked, or returns other contents as they are.
+func parseContents(name string, contents string) any {
+	var value any
+	var err error
+	switch filepath.Ext(name) {
+	case ".json", ".jsonl":
+		err = json.Unmarshal([]byte(contents), &value)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal([]byte(contents), &value)
+	default:
+		return contents
+	}
+	if err != nil {
+		return contents
+	}
+	return mask(value, "")
+}
+
+// mask replaces the values of maskedKeys and maskedPaths in the value at
+// the path, recursively.
+func mask(value any, path string) any {
+	switch value := value.(type) {
+	case map[any]any:
+		// YAML mappings with keys that aren't all strings
+		converted := make(map[string]any, len(value))
+		for key, nested := range value {
+			converted[fmt.Sprint(key)] = nested
+		}
+		return mask(converted, path)
+	case map[string]any:
+		for key, nested := range value {
+			nestedPath := key
+			if path != "" {
+				nestedPath = path + "." + key
+			}
+			if maskedKeys[key] || maskedPaths[nestedPath] {
+				value[key] = masked
+			} else {
+				value[key] = mask(nested, nestedPath)
+			}
+		}
+		return value
+	case []any:
+		for i, nested := range value {
+			value[i] = mask(nested, path)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// Marshal encodes the snapshot the way it's stored in golden files.
+func (s *Snapshot) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CompareGolden fails the test if the snapshot differs from the one in the
+// golden file at the path.
+//
+// If update is true, the golden file is rewritten with the snapshot
+// instead.
+func CompareGolden(t *testing.T, path string, snapshot *Snapshot, update bool) {
+	t.Helper()
+	actual, err := snapshot.Marshal()
+	require.NoError(t, err)
+
+	if update {
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "no golden file; run the test with -update to create it")
+	assert.Equal(t, string(expected), string(actual),
+		"the snapshot differs from %s; run the test with -update if the change is expected", path)
+}
@@ -0,0 +1,80 @@
+package streamtest_test
+
+import (
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/streamtest"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden files")
+
+// Each script in testdata produces the snapshot in its golden file.
+//
+// Run with -update to regenerate the golden files after an intended
+// change, and review their diff.
+func TestScripts(t *testing.T) {
+	scripts, err := filepath.Glob("testdata/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, scripts)
+
+	for _, path := range scripts {
+		name := strings.TrimSuffix(filepath.Base(path), ".yaml")
+		t.Run(name, func(t *testing.T) {
+			script, err := streamtest.ReadScript(path)
+			require.NoError(t, err)
+
+			snapshot := streamtest.Run(t, script)
+
+			streamtest.CompareGolden(t,
+				filepath.Join("testdata", name+".golden.json"),
+				snapshot,
+				*update)
+		})
+	}
+}
+
+func TestParseScript_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{
+			"no run",
+			"steps: [{exit: {}}]",
+			"run_id and project are required",
+		},
+		{
+			"no exit",
+			"{run_id: r, project: p, steps: [{summary: {a: 1}}]}",
+			"the last step must be an exit",
+		},
+		{
+			"exit before the end",
+			"{run_id: r, project: p, steps: [{exit: {}}, {exit: {}}]}",
+			"step 0 exits before the last step",
+		},
+		{
+			"two kinds",
+			"{run_id: r, project: p, steps: [{summary: {a: 1}, config: {a: 1}}, {exit: {}}]}",
+			"step 0 has 2 kinds",
+		},
+		{
+			"unnamed file",
+			"{run_id: r, project: p, steps: [{file: {contents: x}}, {exit: {}}]}",
+			"step 0 saves a file without a name",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := streamtest.ParseScript([]byte(tc.script))
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
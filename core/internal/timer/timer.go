@@ -1,9 +1,18 @@
 package timer
 
-import "time"
+import (
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+)
 
 // Timer is used to track the run start and execution times
+//
+// It is safe for concurrent use.
 type Timer struct {
+	mu          sync.Mutex
+	clock       waiting.Clock
 	startTime   time.Time
 	resumeTime  time.Time
 	accumulated time.Duration
@@ -12,44 +21,74 @@ type Timer struct {
 }
 
 func New() *Timer {
-	return &Timer{}
+	return NewWithClock(waiting.NewClock())
+}
+
+// NewWithClock returns a timer that tells the time with the clock.
+func NewWithClock(clock waiting.Clock) *Timer {
+	return &Timer{clock: clock}
 }
 
 func (t *Timer) GetStartTimeMicro() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	return float64(t.startTime.UnixMicro()) / 1e6
 }
 
 func (t *Timer) Start(startTime *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if startTime != nil {
 		t.startTime = *startTime
 	} else {
-		t.startTime = time.Now()
+		t.startTime = t.clock.Now()
 	}
 	t.resumeTime = t.startTime
 	t.isStarted = true
 }
 
+// AddElapsed adds to the elapsed time, such as the time a resumed run ran
+// for before it was resumed.
+func (t *Timer) AddElapsed(elapsed time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.accumulated += elapsed
+}
+
 func (t *Timer) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if !t.isPaused {
-		elapsed := time.Since(t.resumeTime)
+		elapsed := t.clock.Now().Sub(t.resumeTime)
 		t.accumulated += elapsed
 		t.isPaused = true
 	}
 }
 
 func (t *Timer) Resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if t.isPaused {
-		t.resumeTime = time.Now()
+		t.resumeTime = t.clock.Now()
 		t.isPaused = false
 	}
 }
 
+// IsStarted returns whether the timer was started.
+func (t *Timer) IsStarted() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.isStarted
+}
+
 func (t *Timer) Elapsed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if !t.isStarted {
 		return 0
 	}
 	if t.isPaused {
 		return t.accumulated
 	}
-	return t.accumulated + time.Since(t.resumeTime)
+	return t.accumulated + t.clock.Now().Sub(t.resumeTime)
 }
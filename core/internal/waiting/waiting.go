@@ -41,6 +41,17 @@ func NewStopwatch(duration time.Duration) Stopwatch {
 	return s
 }
 
+// Clock tells the time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// NewClock returns a clock that tells the system time.
+func NewClock() Clock {
+	return realClock{}
+}
+
 type realDelay struct {
 	duration time.Duration
 }
@@ -83,3 +94,9 @@ func (s *realStopwatch) IsDone() bool {
 func (s *realStopwatch) Reset() {
 	s.startTimeMicros.Store(time.Now().UnixMicro())
 }
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,96 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrCancelled is the error of saving an artifact that was cancelled.
+var ErrCancelled = errors.New("saving the artifact was cancelled")
+
+// Cancellation cancels saving an artifact until it's committed.
+//
+// Once an artifact starts to be committed, whether its commit succeeds
+// isn't known until the server answers, so it can no longer be cancelled.
+// The zero value and nil are ready to use; a nil Cancellation is never
+// cancelled.
+type Cancellation struct {
+	mu sync.Mutex
+
+	// cancels cancel the saves that use the cancellation
+	cancels []context.CancelCauseFunc
+
+	// requested is whether the cancellation was requested
+	requested bool
+
+	// finishing is whether a save started to commit its artifact, or
+	// otherwise finish, after which it can't be cancelled
+	finishing bool
+}
+
+// Cancel cancels saving the artifact.
+//
+// Returns false if the artifact started to be committed first.
+func (c *Cancellation) Cancel() bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.finishing {
+		return false
+	}
+	c.requested = true
+	for _, cancel := range c.cancels {
+		cancel(ErrCancelled)
+	}
+	return true
+}
+
+// Context returns a context that's cancelled with the cancellation, and a
+// function to call once it's no longer used.
+func (c *Cancellation) Context(ctx context.Context) (context.Context, func()) {
+	if c == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.requested {
+		cancel(ErrCancelled)
+	}
+	c.cancels = append(c.cancels, cancel)
+	return ctx, func() { cancel(nil) }
+}
+
+// finish notes that a save is about to commit its artifact, or finish
+// otherwise, after which the artifact can't be cancelled.
+//
+// Returns false if it was cancelled first.
+func (c *Cancellation) finish() bool {
+	if c == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.requested {
+		return false
+	}
+	c.finishing = true
+	return true
+}
+
+// isRequested returns whether the cancellation was requested.
+func (c *Cancellation) isRequested() bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requested
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/segmentio/encoding/json"
 
+	"github.com/wandb/wandb/core/internal/httppool"
 	"github.com/wandb/wandb/core/pkg/service"
 	"github.com/wandb/wandb/core/pkg/utils"
 )
@@ -82,7 +83,10 @@ func (m *Manifest) GetManifestEntryFromArtifactFilePath(path string) (ManifestEn
 }
 
 func loadManifestFromURL(url string) (Manifest, error) {
-	resp, err := retryablehttp.NewClient().Get(url)
+	client := retryablehttp.NewClient()
+	client.HTTPClient.Transport = httppool.Default.Transport(
+		httppool.Storage, httppool.Options{}, nil)
+	resp, err := client.Get(url)
 
 	if err != nil {
 		return Manifest{}, err
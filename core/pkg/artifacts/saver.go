@@ -2,8 +2,10 @@ package artifacts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/Khan/genqlient/graphql"
 
+	"github.com/wandb/wandb/core/internal/api"
 	"github.com/wandb/wandb/core/internal/filetransfer"
 	"github.com/wandb/wandb/core/internal/gql"
 	"github.com/wandb/wandb/core/pkg/service"
@@ -23,12 +26,43 @@ type ArtifactSaver struct {
 	GraphqlClient       graphql.Client
 	FileTransferManager filetransfer.FileTransferManager
 	FileCache           Cache
+	// Progress reports how much of the artifact's files was uploaded, if
+	// not nil.
+	Progress *filetransfer.ProgressReporter
+	// URLBatchSize is how many files' upload URLs to request at once, or
+	// 0 for the default.
+	URLBatchSize int
+	// URLLifetime is how long upload URLs are assumed to be valid, or 0
+	// for the default. Expired URLs are requested again.
+	URLLifetime time.Duration
+	// Cancellation cancels saving the artifact, if not nil.
+	Cancellation *Cancellation
+	// OnDraft is passed the ID of the artifact's draft once it's created
+	// on the server, if not nil.
+	OnDraft func(artifactID string)
 	// Input.
 	Artifact    *service.ArtifactRecord
 	HistoryStep int64
 	StagingDir  string
 }
 
+// deleteDraftTimeout is how long deleting the draft of a cancelled artifact
+// may take.
+const deleteDraftTimeout = 30 * time.Second
+
+// SavedArtifact describes an artifact version saved on the server.
+type SavedArtifact struct {
+	// ID is the artifact's ID on the server.
+	ID string
+
+	// Digest is the digest of the artifact's manifest.
+	Digest string
+
+	// VersionIndex is the artifact's version, like 3 for "v3", or nil if
+	// the server didn't assign one, as when the artifact isn't committed.
+	VersionIndex *int
+}
+
 func NewArtifactSaver(
 	ctx context.Context,
 	graphQLClient graphql.Client,
@@ -123,10 +157,17 @@ func (as *ArtifactSaver) createManifest(
 	return response.GetCreateArtifactManifest().ArtifactManifest, nil
 }
 
+// uploadFiles uploads the files in the manifest that the server doesn't
+// already have.
+//
+// Upload URLs are created in batches, pipelined with the uploads, and
+// files are only handed to the file transfer manager while its backlog
+// is small. A URL that's expired by the time its file is handed over, or
+// that fails after it likely expired, is created again.
+//
+// A file that fails to upload doesn't stop the others; the errors are
+// returned together once all files are done.
 func (as *ArtifactSaver) uploadFiles(artifactID string, manifest *Manifest, manifestID string, _ chan<- *service.Record) error {
-	const batchSize int = 10000
-	const maxBacklog int = 10000
-
 	type TaskResult struct {
 		Task *filetransfer.Task
 		Name string
@@ -134,6 +175,7 @@ func (as *ArtifactSaver) uploadFiles(artifactID string, manifest *Manifest, mani
 
 	// Prepare all file specs.
 	var fileSpecs []gql.CreateArtifactFileSpecInput
+	specsByName := make(map[string]gql.CreateArtifactFileSpecInput)
 	for name, entry := range manifest.Contents {
 		if entry.LocalPath == nil {
 			continue
@@ -145,86 +187,120 @@ func (as *ArtifactSaver) uploadFiles(artifactID string, manifest *Manifest, mani
 			ArtifactManifestID: &manifestID,
 		}
 		fileSpecs = append(fileSpecs, fileSpec)
+		specsByName[name] = fileSpec
+		as.Progress.AddFile(entry.Size)
 	}
+	defer as.Progress.Finish()
+
+	batchSize := as.urlBatchSize()
+	urlLifetime := as.urlLifetime()
+	maxBacklog := 2 * batchSize
+
+	// Buffered so that finishing an upload never blocks its worker while
+	// URLs are being created.
+	taskResultsChan := make(chan TaskResult, len(fileSpecs))
+	urlCreatedAt := make(map[string]time.Time)
+	retried := make(map[string]bool)
+	numInProgress := 0
+	var errs []error
 
-	// Upload in batches.
-	numInProgress, numDone := 0, 0
-	nameToScheduledTime := map[string]time.Time{}
-	taskResultsChan := make(chan TaskResult)
-	fileSpecsBatch := make([]gql.CreateArtifactFileSpecInput, 0, batchSize)
-	for numDone < len(fileSpecs) {
-		// Prepare a batch.
-		now := time.Now()
-		fileSpecsBatch = fileSpecsBatch[:0]
-		for _, fileSpec := range fileSpecs {
-			if _, ok := nameToScheduledTime[fileSpec.Name]; ok {
+	// schedule uploads the files in the batch, and returns those whose
+	// URLs expired before they could be handed over, if checkExpiry.
+	schedule := func(
+		batch uploadURLs,
+		checkExpiry bool,
+	) (expired []gql.CreateArtifactFileSpecInput) {
+		if batch.err != nil {
+			errs = append(errs, batch.err)
+			return nil
+		}
+
+		for i, file := range batch.files {
+			name := batch.specs[i].Name
+			entry := manifest.Contents[name]
+			if file.Artifact != nil {
+				entry.BirthArtifactID = &file.Artifact.Id
+				manifest.Contents[name] = entry
+			}
+			if file.UploadUrl == nil {
+				// The server already has the file.
+				as.Progress.Complete(name, entry.Size)
 				continue
 			}
-			nameToScheduledTime[fileSpec.Name] = now
-			fileSpecsBatch = append(fileSpecsBatch, fileSpec)
-			if len(fileSpecsBatch) >= batchSize {
-				break
+			if checkExpiry && time.Since(batch.createdAt) > urlLifetime {
+				expired = append(expired, batch.specs[i])
+				continue
 			}
-		}
-		if len(fileSpecsBatch) > 0 {
-			// Fetch upload URLs.
-			response, err := gql.CreateArtifactFiles(
-				as.Ctx,
-				as.GraphqlClient,
-				fileSpecsBatch,
-				gql.ArtifactStorageLayoutV2,
-			)
-			if err != nil {
-				return err
+
+			urlCreatedAt[name] = batch.createdAt
+			numInProgress++
+			task := &filetransfer.Task{
+				FileKind: filetransfer.RunFileKindArtifact,
+				Type:     filetransfer.UploadTask,
+				Path:     *entry.LocalPath,
+				Url:      *file.UploadUrl,
+				Headers:  file.UploadHeaders,
+				Digest:   entry.Digest,
+				Context:  as.Ctx,
 			}
-			if len(fileSpecsBatch) != len(response.CreateArtifactFiles.Files.Edges) {
-				return fmt.Errorf(
-					"expected %v upload URLs, got %v",
-					len(fileSpecsBatch),
-					len(response.CreateArtifactFiles.Files.Edges),
-				)
+			task.SetCompletionCallback(
+				func(t *filetransfer.Task) {
+					taskResultsChan <- TaskResult{t, name}
+				},
+			)
+			task.SetProgressCallback(as.Progress.Callback(name))
+			as.FileTransferManager.AddTask(task)
+		}
+		return expired
+	}
+
+	batches := as.createUploadURLsInBatches(fileSpecs)
+	var retries []gql.CreateArtifactFileSpecInput
+	for batches != nil || numInProgress > 0 || len(retries) > 0 {
+		// Request new URLs for failed uploads together, once there's a
+		// batch of them or nothing else to wait for.
+		if len(retries) >= batchSize || (len(retries) > 0 && numInProgress == 0) {
+			schedule(as.createUploadURLs(retries), false)
+			retries = nil
+			continue
+		}
+
+		// Don't take more URLs while the backlog is full, so that they
+		// don't expire waiting for a worker.
+		nextBatches := batches
+		if numInProgress >= maxBacklog {
+			nextBatches = nil
+		}
+
+		select {
+		case batch, ok := <-nextBatches:
+			if !ok {
+				batches = nil
+				continue
 			}
-			// Save birth artifact ids, schedule uploads.
-			for i, edge := range response.CreateArtifactFiles.Files.Edges {
-				name := fileSpecsBatch[i].Name
-				entry := manifest.Contents[name]
-				entry.BirthArtifactID = &edge.Node.Artifact.Id
-				manifest.Contents[name] = entry
-				if edge.Node.UploadUrl == nil {
-					numDone++
-					continue
-				}
-				numInProgress++
-				task := &filetransfer.Task{
-					FileKind: filetransfer.RunFileKindArtifact,
-					Type:     filetransfer.UploadTask,
-					Path:     *entry.LocalPath,
-					Url:      *edge.Node.UploadUrl,
-					Headers:  edge.Node.UploadHeaders,
-				}
-				task.SetCompletionCallback(
-					func(t *filetransfer.Task) {
-						taskResultsChan <- TaskResult{t, name}
-					},
-				)
-				as.FileTransferManager.AddTask(task)
+			if expired := schedule(batch, true); len(expired) > 0 {
+				schedule(as.createUploadURLs(expired), false)
 			}
-		}
-		// Wait for filetransfer to catch up. If there's nothing more to schedule, wait for all in progress tasks.
-		for numInProgress > maxBacklog || (len(fileSpecsBatch) == 0 && numInProgress > 0) {
+
+		case result := <-taskResultsChan:
 			numInProgress--
-			result := <-taskResultsChan
 			if result.Task.Err != nil {
-				// We want to retry when the signed URL expires. However, distinguishing that error from others is not
-				// trivial. As a heuristic, we retry if the request failed more than an hour after we fetched the URL.
-				if time.Since(nameToScheduledTime[result.Name]) < 1*time.Hour {
-					return result.Task.Err
+				// We want to retry when the signed URL expires. However,
+				// distinguishing that error from others is not trivial. As
+				// a heuristic, we retry once if the request failed after
+				// the URL's lifetime.
+				if time.Since(urlCreatedAt[result.Name]) > urlLifetime &&
+					!retried[result.Name] {
+					retried[result.Name] = true
+					retries = append(retries, specsByName[result.Name])
+				} else {
+					errs = append(errs,
+						fmt.Errorf("failed to upload %s: %v", result.Name, result.Task.Err))
 				}
-				delete(nameToScheduledTime, result.Name) // retry
 				continue
 			}
-			numDone++
 			entry := manifest.Contents[result.Name]
+			as.Progress.Complete(result.Name, entry.Size)
 			if !entry.SkipCache {
 				digest := entry.Digest
 				go func() {
@@ -234,9 +310,12 @@ func (as *ArtifactSaver) uploadFiles(artifactID string, manifest *Manifest, mani
 					}
 				}()
 			}
+
+		case <-as.Ctx.Done():
+			return as.Ctx.Err()
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (as *ArtifactSaver) resolveClientIDReferences(manifest *Manifest) error {
@@ -280,6 +359,7 @@ func (as *ArtifactSaver) uploadManifest(manifestFile string, uploadUrl *string,
 		Path:     manifestFile,
 		Url:      *uploadUrl,
 		Headers:  uploadHeaders,
+		Context:  as.Ctx,
 	}
 	task.SetCompletionCallback(
 		func(t *filetransfer.Task) {
@@ -292,13 +372,27 @@ func (as *ArtifactSaver) uploadManifest(manifestFile string, uploadUrl *string,
 	return task.Err
 }
 
-func (as *ArtifactSaver) commitArtifact(artifactID string) error {
-	_, err := gql.CommitArtifact(
-		as.Ctx,
+func (as *ArtifactSaver) commitArtifact(artifactID string) (
+	*gql.CommitArtifactCommitArtifactCommitArtifactPayloadArtifact,
+	error,
+) {
+	// Conflicts when committing an artifact are transient.
+	ctx := api.WithErrorClassOverrides(as.Ctx, api.ErrorClassOverrides{
+		http.StatusConflict: api.Retryable,
+	})
+	response, err := gql.CommitArtifact(
+		ctx,
 		as.GraphqlClient,
 		artifactID,
 	)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	if response.GetCommitArtifact() == nil {
+		return nil, fmt.Errorf("no artifact in response")
+	}
+	artifact := response.GetCommitArtifact().GetArtifact()
+	return &artifact, nil
 }
 
 func (as *ArtifactSaver) deleteStagingFiles(manifest *Manifest) {
@@ -311,19 +405,64 @@ func (as *ArtifactSaver) deleteStagingFiles(manifest *Manifest) {
 	}
 }
 
-func (as *ArtifactSaver) Save(ch chan<- *service.Record) (artifactID string, rerr error) {
+// deleteDraft deletes the draft version of an artifact whose saving was
+// cancelled.
+//
+// A server that can't delete it leaves it uncommitted.
+func (as *ArtifactSaver) deleteDraft(ctx context.Context, artifactID string) {
+	ctx, cancel := context.WithTimeout(ctx, deleteDraftTimeout)
+	defer cancel()
+	_, err := gql.DeleteArtifact(ctx, as.GraphqlClient, artifactID)
+	if err != nil {
+		slog.Warn(
+			"failed to delete draft of cancelled artifact",
+			"artifactID", artifactID,
+			"err", err,
+		)
+	}
+}
+
+// Save creates the artifact on the server, uploads its files and manifest,
+// and commits it if it's finalized.
+//
+// If it's cancelled before it's committed, its uploads stop, its draft is
+// deleted from the server and ErrCancelled is returned.
+func (as *ArtifactSaver) Save(ch chan<- *service.Record) (_ SavedArtifact, err error) {
+	// once it's being committed, the artifact is saved with the context
+	// that isn't cancelled by the cancellation
+	finishCtx := as.Ctx
+	ctx, done := as.Cancellation.Context(as.Ctx)
+	defer done()
+	as.Ctx = ctx
+
+	var draftID string
+	defer func() {
+		if err == nil || !as.Cancellation.isRequested() {
+			return
+		}
+		err = ErrCancelled
+		if draftID != "" {
+			as.deleteDraft(finishCtx, draftID)
+		}
+	}()
+
 	manifest, err := NewManifestFromProto(as.Artifact.Manifest)
 	if err != nil {
-		return "", err
+		return SavedArtifact{}, err
 	}
 
 	defer as.deleteStagingFiles(&manifest)
 
 	artifactAttrs, err := as.createArtifact()
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.createArtifact: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.createArtifact: %w", err)
+	}
+	artifactID := artifactAttrs.Id
+	saved := SavedArtifact{
+		ID:           artifactID,
+		Digest:       as.Artifact.Digest,
+		VersionIndex: artifactAttrs.VersionIndex,
 	}
-	artifactID = artifactAttrs.Id
 	var baseArtifactId *string
 	if as.Artifact.BaseId != "" {
 		baseArtifactId = &as.Artifact.BaseId
@@ -331,6 +470,11 @@ func (as *ArtifactSaver) Save(ch chan<- *service.Record) (artifactID string, rer
 		baseArtifactId = &artifactAttrs.ArtifactSequence.LatestArtifact.Id
 	}
 	if artifactAttrs.State == gql.ArtifactStateCommitted {
+		if !as.Cancellation.finish() {
+			return SavedArtifact{}, ErrCancelled
+		}
+		as.Ctx = finishCtx
+
 		if as.Artifact.UseAfterCommit {
 			_, err := gql.UseArtifact(
 				as.Ctx,
@@ -341,51 +485,66 @@ func (as *ArtifactSaver) Save(ch chan<- *service.Record) (artifactID string, rer
 				artifactID,
 			)
 			if err != nil {
-				return "", fmt.Errorf("gql.UseArtifact: %w", err)
+				return SavedArtifact{}, fmt.Errorf("gql.UseArtifact: %w", err)
 			}
 		}
-		return artifactID, nil
+		return saved, nil
 	}
 	// DELETED is for old servers, see https://github.com/wandb/wandb/pull/6190
 	if artifactAttrs.State != gql.ArtifactStatePending && artifactAttrs.State != gql.ArtifactStateDeleted {
-		return "", fmt.Errorf("unexpected artifact state %v", artifactAttrs.State)
+		return SavedArtifact{}, fmt.Errorf("unexpected artifact state %v", artifactAttrs.State)
+	}
+	draftID = artifactID
+	if as.OnDraft != nil {
+		as.OnDraft(artifactID)
 	}
 
 	manifestAttrs, err := as.createManifest(
 		artifactID, baseArtifactId, "" /* manifestDigest */, false, /* includeUpload */
 	)
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.createManifest: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.createManifest: %w", err)
 	}
 
 	err = as.uploadFiles(artifactID, &manifest, manifestAttrs.Id, ch)
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.uploadFiles: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.uploadFiles: %w", err)
 	}
 
 	err = as.resolveClientIDReferences(&manifest)
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.resolveClientIDReferences: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.resolveClientIDReferences: %w", err)
 	}
 	// TODO: check if size is needed
 	manifestFile, manifestDigest, _, err := manifest.WriteToFile()
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.writeManifest: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.writeManifest: %w", err)
 	}
 	defer os.Remove(manifestFile)
 	manifestAttrs, err = as.createManifest(artifactID, baseArtifactId, manifestDigest, true /* includeUpload */)
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.createManifest: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.createManifest: %w", err)
 	}
 	err = as.uploadManifest(manifestFile, manifestAttrs.File.UploadUrl, manifestAttrs.File.UploadHeaders, ch)
 	if err != nil {
-		return "", fmt.Errorf("ArtifactSaver.uploadManifest: %w", err)
+		return SavedArtifact{}, fmt.Errorf("ArtifactSaver.uploadManifest: %w", err)
 	}
 
+	if !as.Cancellation.finish() {
+		return SavedArtifact{}, ErrCancelled
+	}
+	as.Ctx = finishCtx
+
 	if as.Artifact.Finalize {
-		err = as.commitArtifact(artifactID)
+		committed, err := as.commitArtifact(artifactID)
 		if err != nil {
-			return "", fmt.Errorf("ArtifactSacer.commitArtifact: %w", err)
+			return SavedArtifact{}, fmt.Errorf("ArtifactSacer.commitArtifact: %w", err)
+		}
+		if committed.GetDigest() != "" {
+			saved.Digest = committed.GetDigest()
+		}
+		if committed.GetVersionIndex() != nil {
+			saved.VersionIndex = committed.GetVersionIndex()
 		}
 
 		if as.Artifact.UseAfterCommit {
@@ -398,10 +557,10 @@ func (as *ArtifactSaver) Save(ch chan<- *service.Record) (artifactID string, rer
 				artifactID,
 			)
 			if err != nil {
-				return "", fmt.Errorf("gql.UseArtifact: %w", err)
+				return SavedArtifact{}, fmt.Errorf("gql.UseArtifact: %w", err)
 			}
 		}
 	}
 
-	return artifactID, nil
+	return saved, nil
 }
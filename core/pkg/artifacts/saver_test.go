@@ -0,0 +1,238 @@
+package artifacts_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/artifacts"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// newArtifactBackend returns a backend that accepts an artifact and its
+// files.
+func newArtifactBackend() *servertest.FakeBackend {
+	backend := servertest.NewFakeBackend()
+	backend.StubGraphQL("CreateArtifact", `{
+		"createArtifact": {
+			"artifact": {"id": "artifact-id", "state": "PENDING"}
+		}
+	}`)
+	backend.StubGraphQL("CreateArtifactManifest", fmt.Sprintf(`{
+		"createArtifactManifest": {
+			"artifactManifest": {
+				"id": "manifest-id",
+				"file": {"id": "file-id", "uploadUrl": %q, "uploadHeaders": []}
+			}
+		}
+	}`, backend.UploadURL("wandb_manifest.json")))
+	backend.StubGraphQL("CommitArtifact", `{
+		"commitArtifact": {"artifact": {"id": "artifact-id"}}
+	}`)
+	backend.StubCreateArtifactFiles()
+	return backend
+}
+
+// newArtifactSaver returns a saver for an artifact with n tiny files.
+func newArtifactSaver(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	n int,
+) artifacts.ArtifactSaver {
+	dir := t.TempDir()
+	var entries []*service.ArtifactManifestEntry
+	for i := range n {
+		name := fmt.Sprintf("file%05d.txt", i)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o644))
+		entries = append(entries, &service.ArtifactManifestEntry{
+			Path:      name,
+			Digest:    name,
+			Size:      int64(len(name)),
+			LocalPath: path,
+			SkipCache: true,
+		})
+	}
+
+	fileTransferManager := server.NewFileTransferManager(
+		filetransfer.NewFileTransferStats(),
+		observability.NewNoOpLogger(),
+		settings.From(&service.Settings{
+			XFileTransferRetryMax: &wrapperspb.Int32Value{Value: 0},
+		}),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	fileTransferManager.Start()
+	t.Cleanup(fileTransferManager.Close)
+
+	return artifacts.NewArtifactSaver(
+		context.Background(),
+		graphql.NewClient(backend.URL()+"/graphql", http.DefaultClient),
+		fileTransferManager,
+		&service.ArtifactRecord{
+			Entity:   "entity",
+			Project:  "project",
+			RunId:    "run",
+			Type:     "dataset",
+			Name:     "tiny-files",
+			Digest:   "digest",
+			Finalize: true,
+			Manifest: &service.ArtifactManifest{
+				Version:       1,
+				StoragePolicy: "wandb-storage-policy-v1",
+				Contents:      entries,
+			},
+		},
+		0,
+		"",
+	)
+}
+
+// uploadedFiles returns the names of the artifact's files that the
+// backend received uploads for, without the manifest.
+func uploadedFiles(backend *servertest.FakeBackend) map[string]bool {
+	uploaded := make(map[string]bool)
+	for _, request := range backend.Requests(servertest.RouteUpload) {
+		name := strings.TrimPrefix(request.Path, "/upload/")
+		if name != "wandb_manifest.json" {
+			uploaded[name] = true
+		}
+	}
+	return uploaded
+}
+
+func TestArtifactSaver_RequestsURLsInBatches(t *testing.T) {
+	backend := newArtifactBackend()
+	defer backend.Close()
+	saver := newArtifactSaver(t, backend, 3000)
+	saver.URLBatchSize = 500
+
+	start := time.Now()
+	_, err := saver.Save(nil)
+	t.Logf("saved 3000 files in %v", time.Since(start))
+
+	require.NoError(t, err)
+	assert.Len(t, uploadedFiles(backend), 3000)
+	assert.Len(t, backend.GraphQLRequests("CreateArtifactFiles"), 6)
+}
+
+func TestArtifactSaver_RecreatesExpiredURLs(t *testing.T) {
+	backend := newArtifactBackend()
+	defer backend.Close()
+	saver := newArtifactSaver(t, backend, 100)
+	saver.URLBatchSize = 50
+	saver.URLLifetime = time.Nanosecond
+
+	_, err := saver.Save(nil)
+
+	require.NoError(t, err)
+	assert.Len(t, uploadedFiles(backend), 100)
+	// each batch expires before it's handed over and is created again
+	assert.Len(t, backend.GraphQLRequests("CreateArtifactFiles"), 4)
+}
+
+func TestArtifactSaver_FailedFileDoesNotStopOthers(t *testing.T) {
+	backend := newArtifactBackend()
+	defer backend.Close()
+	backend.InjectFault(servertest.RouteUpload,
+		servertest.Fault{StatusCode: http.StatusForbidden})
+	saver := newArtifactSaver(t, backend, 1000)
+	saver.URLBatchSize = 100
+
+	_, err := saver.Save(nil)
+
+	require.Error(t, err)
+	assert.Equal(t, 1, strings.Count(err.Error(), ".txt:"), err.Error())
+	// every file was tried once, and the manifest wasn't uploaded
+	assert.Len(t, backend.Requests(servertest.RouteUpload), 1000)
+	assert.Len(t, uploadedFiles(backend), 1000)
+	assert.Empty(t, backend.GraphQLRequests("CommitArtifact"))
+}
+
+// Cancelling an artifact stops its uploads right away, and deletes its
+// draft rather than committing it.
+func TestArtifactSaver_CancelMidUpload(t *testing.T) {
+	backend := newArtifactBackend()
+	defer backend.Close()
+	backend.StubGraphQL("DeleteArtifact", `{
+		"deleteArtifact": {"clientMutationId": null}
+	}`)
+	for range 1000 {
+		backend.InjectFault(servertest.RouteUpload,
+			servertest.Fault{Delay: time.Hour})
+	}
+	saver := newArtifactSaver(t, backend, 1000)
+	saver.URLBatchSize = 100
+	cancellation := &artifacts.Cancellation{}
+	saver.Cancellation = cancellation
+
+	saved := make(chan error, 1)
+	go func() {
+		_, err := saver.Save(nil)
+		saved <- err
+	}()
+	require.Eventually(t, func() bool {
+		return len(backend.Requests(servertest.RouteUpload)) >= 10
+	}, 10*time.Second, 10*time.Millisecond)
+	require.True(t, cancellation.Cancel())
+
+	select {
+	case err := <-saved:
+		assert.ErrorIs(t, err, artifacts.ErrCancelled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the save didn't stop once cancelled")
+	}
+	assert.Eventually(t, func() bool {
+		return saver.FileTransferManager.PendingTasks() == 0
+	}, time.Second, 10*time.Millisecond, "uploads still take up workers")
+	assert.Less(t, len(uploadedFiles(backend)), 1000)
+	assert.Len(t, backend.GraphQLRequests("DeleteArtifact"), 1)
+	assert.Empty(t, backend.GraphQLRequests("CommitArtifact"))
+}
+
+func TestArtifactSaver_CancelBeforeStart(t *testing.T) {
+	backend := newArtifactBackend()
+	defer backend.Close()
+	saver := newArtifactSaver(t, backend, 10)
+	saver.Cancellation = &artifacts.Cancellation{}
+	require.True(t, saver.Cancellation.Cancel())
+
+	_, err := saver.Save(nil)
+
+	assert.ErrorIs(t, err, artifacts.ErrCancelled)
+	assert.Empty(t, backend.Requests(servertest.RouteUpload))
+	// no draft was created, so there's none to delete
+	assert.Empty(t, backend.GraphQLRequests("DeleteArtifact"))
+}
+
+func TestArtifactSaver_CannotCancelOnceCommitted(t *testing.T) {
+	backend := newArtifactBackend()
+	defer backend.Close()
+	saver := newArtifactSaver(t, backend, 10)
+	saver.Cancellation = &artifacts.Cancellation{}
+
+	_, err := saver.Save(nil)
+
+	require.NoError(t, err)
+	assert.False(t, saver.Cancellation.Cancel())
+	assert.Empty(t, backend.GraphQLRequests("DeleteArtifact"))
+}
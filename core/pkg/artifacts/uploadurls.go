@@ -0,0 +1,112 @@
+package artifacts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/gql"
+)
+
+const (
+	// defaultURLBatchSize is how many files' upload URLs are requested in
+	// one GraphQL call, unless the saver says otherwise.
+	defaultURLBatchSize = 500
+
+	// defaultURLLifetime is how long an upload URL is assumed to be valid
+	// after it's created, unless the saver says otherwise.
+	defaultURLLifetime = time.Hour
+)
+
+// uploadURLs are the upload URLs created for a batch of an artifact's
+// files.
+type uploadURLs struct {
+	// specs are the files in the batch
+	specs []gql.CreateArtifactFileSpecInput
+
+	// files are where to upload the files, in the same order as specs
+	files []*gql.CreateArtifactFilesCreateArtifactFilesCreateArtifactFilesPayloadFilesFileConnectionEdgesFileEdgeNodeFile
+
+	// createdAt is when the URLs were created
+	createdAt time.Time
+
+	// err is why the URLs couldn't be created, if they couldn't
+	err error
+}
+
+func (as *ArtifactSaver) urlBatchSize() int {
+	if as.URLBatchSize > 0 {
+		return as.URLBatchSize
+	}
+	return defaultURLBatchSize
+}
+
+func (as *ArtifactSaver) urlLifetime() time.Duration {
+	if as.URLLifetime > 0 {
+		return as.URLLifetime
+	}
+	return defaultURLLifetime
+}
+
+// createUploadURLs creates upload URLs for the files in one GraphQL call.
+func (as *ArtifactSaver) createUploadURLs(
+	specs []gql.CreateArtifactFileSpecInput,
+) uploadURLs {
+	batch := uploadURLs{specs: specs, createdAt: time.Now()}
+
+	response, err := gql.CreateArtifactFiles(
+		as.Ctx,
+		as.GraphqlClient,
+		specs,
+		gql.ArtifactStorageLayoutV2,
+	)
+	if err != nil {
+		batch.err = err
+		return batch
+	}
+
+	var edges []gql.CreateArtifactFilesCreateArtifactFilesCreateArtifactFilesPayloadFilesFileConnectionEdgesFileEdge
+	if response.CreateArtifactFiles != nil {
+		edges = response.CreateArtifactFiles.Files.Edges
+	}
+	if len(edges) != len(specs) {
+		batch.err = fmt.Errorf(
+			"expected %v upload URLs, got %v",
+			len(specs),
+			len(edges),
+		)
+		return batch
+	}
+
+	for _, edge := range edges {
+		batch.files = append(batch.files, edge.Node)
+	}
+	return batch
+}
+
+// createUploadURLsInBatches creates upload URLs for the files in batches
+// and sends each batch on the returned channel, which is closed after the
+// last one.
+//
+// A batch is created while the previous one is taken, so that URLs are
+// ready as soon as there's room for more uploads, but not so far ahead
+// that they expire waiting.
+func (as *ArtifactSaver) createUploadURLsInBatches(
+	specs []gql.CreateArtifactFileSpecInput,
+) <-chan uploadURLs {
+	batches := make(chan uploadURLs)
+	batchSize := as.urlBatchSize()
+
+	go func() {
+		defer close(batches)
+		for start := 0; start < len(specs); start += batchSize {
+			batch := as.createUploadURLs(specs[start:min(start+batchSize, len(specs))])
+			select {
+			case batches <- batch:
+			case <-as.Ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return batches
+}
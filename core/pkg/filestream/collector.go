@@ -11,13 +11,32 @@ var chunkFilename = map[ChunkTypeEnum]string{
 	SummaryChunk: SummaryFileName,
 }
 
+// OffsetsByFileName returns the offsets of the files whose number of lines
+// are given by file name, as passed to FlushUpdate.OnAcked.
+//
+// Lines of files the filestream doesn't send are ignored.
+func OffsetsByFileName(lines map[string]int) FileStreamOffsetMap {
+	offsets := make(FileStreamOffsetMap, len(lines))
+	for chunkType, name := range chunkFilename {
+		if n, ok := lines[name]; ok {
+			offsets[chunkType] = n
+		}
+	}
+	return offsets
+}
+
 type chunkCollector struct {
 	// A stream of updates which get batched together.
 	input <-chan CollectorStateUpdate
 
 	// Maximum time to wait before finalizing a batch.
+	//
+	// Used only if interval is nil.
 	processDelay waiting.Delay
 
+	// Adapts the time to wait before finalizing a batch, if not nil.
+	interval *transmitInterval
+
 	// Maximum number of chunks to include in a push.
 	maxItemsPerPush int
 
@@ -40,7 +59,17 @@ func (cr *chunkCollector) CollectAndDump(
 ) (*FsTransmitData, bool) {
 	itemsCollected := 0
 
-	maxChunkWait := cr.processDelay.Wait()
+	var maxChunkWait <-chan struct{}
+	switch {
+	case cr.state.Complete != nil:
+		// After the run exits, the final batch is sent as soon as the input
+		// is closed rather than after the interval.
+	case cr.interval != nil:
+		maxChunkWait = waiting.NewDelay(cr.interval.Get()).Wait()
+	default:
+		maxChunkWait = cr.processDelay.Wait()
+	}
+
 	for readMore := true; readMore; {
 		select {
 		case update, ok := <-cr.input:
@@ -53,7 +82,7 @@ func (cr *chunkCollector) CollectAndDump(
 			update.Apply(&cr.state)
 			itemsCollected++
 
-			if itemsCollected >= cr.maxItemsPerPush {
+			if itemsCollected >= cr.maxItemsPerPush || cr.state.Flush {
 				readMore = false
 			}
 
@@ -63,6 +92,10 @@ func (cr *chunkCollector) CollectAndDump(
 	}
 
 	data, ok := cr.state.Consume(offsetMap, cr.isDone)
+	if cr.interval != nil {
+		cr.interval.Observe(data.lineCount()+len(cr.input), !ok)
+	}
+
 	if !ok {
 		return nil, false
 	} else {
@@ -64,6 +64,32 @@ func TestCollectSomething(t *testing.T) {
 	)
 }
 
+func TestCollectSummaryParts(t *testing.T) {
+	input := make(chan CollectorStateUpdate, 32)
+	input <- &TransmitChunk{SummaryParts: []string{"stale"}}
+	input <- &TransmitChunk{LatestSummary: "whole"}
+	input <- &TransmitChunk{SummaryParts: []string{"part1"}}
+	input <- &TransmitChunk{SummaryParts: []string{"part2"}}
+
+	collector := chunkCollector{
+		input:           input,
+		processDelay:    waiting.NewDelay(10 * time.Millisecond),
+		maxItemsPerPush: 100,
+	}
+	data, ok := collector.CollectAndDump(make(FileStreamOffsetMap))
+
+	assert.True(t, ok)
+	assert.Equal(t,
+		map[string]fsTransmitFileData{
+			"wandb-summary.json": {
+				Offset:  0,
+				Content: []string{"whole", "part1", "part2"},
+			},
+		},
+		data.Files,
+	)
+}
+
 func TestCollectFinal(t *testing.T) {
 	input := make(chan CollectorStateUpdate, 32)
 	input <- &TransmitChunk{
@@ -123,3 +149,60 @@ func TestCollectProcessedChunkUpdate(t *testing.T) {
 	assert.True(t, ok)
 	assert.NotNil(t, data)
 }
+
+func TestCollectFlush(t *testing.T) {
+	input := make(chan CollectorStateUpdate, 32)
+	input <- &TransmitChunk{HistoryLines: []string{"line"}}
+	var flushed map[string]int
+	input <- &collectorFlushUpdate{
+		onAcked: func(lines map[string]int) { flushed = lines },
+	}
+	input <- &TransmitChunk{HistoryLines: []string{"line2"}}
+	collector := chunkCollector{
+		input:           input,
+		processDelay:    waiting.NewDelay(time.Hour),
+		maxItemsPerPush: 100,
+	}
+
+	data, ok := collector.CollectAndDump(FileStreamOffsetMap{HistoryChunk: 3})
+
+	assert.True(t, ok)
+	assert.Equal(t,
+		map[string]fsTransmitFileData{
+			"wandb-history.jsonl": {Offset: 3, Content: []string{"line"}},
+		},
+		data.Files,
+	)
+	for _, onAcked := range data.onAcked {
+		onAcked()
+	}
+	assert.Equal(t, map[string]int{"wandb-history.jsonl": 4}, flushed)
+	assert.Len(t, input, 1)
+}
+
+func TestCollectFlushWithoutData(t *testing.T) {
+	input := make(chan CollectorStateUpdate, 32)
+	input <- &collectorFlushUpdate{}
+	collector := chunkCollector{
+		input:           input,
+		processDelay:    waiting.NewDelay(time.Hour),
+		maxItemsPerPush: 100,
+	}
+
+	data, ok := collector.CollectAndDump(FileStreamOffsetMap{})
+
+	assert.True(t, ok)
+	assert.Equal(t, &FsTransmitData{}, data)
+}
+
+func TestOffsetsByFileName(t *testing.T) {
+	offsets := OffsetsByFileName(map[string]int{
+		HistoryFileName: 10,
+		OutputFileName:  3,
+		"unknown.txt":   7,
+	})
+
+	assert.Equal(t,
+		FileStreamOffsetMap{HistoryChunk: 10, OutputChunk: 3},
+		offsets)
+}
@@ -14,6 +14,13 @@ type CollectorState struct {
 	//
 	// This is sent with the final transmission.
 	Complete *bool
+
+	// Flush is whether to send the buffered data without waiting for more.
+	Flush bool
+
+	// OnFlushed are called once the flushed data is acknowledged, with the
+	// number of lines of each file sent by then.
+	OnFlushed []func(map[string]int)
 }
 
 // CollectorStateUpdate is a mutation to a CollectorState.
@@ -32,6 +39,11 @@ func (s *CollectorState) Consume(
 	transmitData := FsTransmitData{}
 
 	hasData := s.Buffer.Write(&transmitData, offsets)
+	if s.Flush {
+		hasData = true
+		transmitData.onAcked = append(transmitData.onAcked,
+			flushedCallbacks(s.OnFlushed, offsets)...)
+	}
 	if isDone {
 		transmitData.Exitcode = s.ExitCode
 		transmitData.Complete = s.Complete
@@ -39,6 +51,30 @@ func (s *CollectorState) Consume(
 	}
 
 	s.Buffer = TransmitChunk{}
+	s.Flush = false
+	s.OnFlushed = nil
 
 	return &transmitData, hasData
 }
+
+// flushedCallbacks returns callbacks for when a request is acknowledged
+// that pass the files' offsets after it to the OnFlushed callbacks.
+func flushedCallbacks(
+	onFlushed []func(map[string]int),
+	offsets FileStreamOffsetMap,
+) []func() {
+	if len(onFlushed) == 0 {
+		return nil
+	}
+
+	lines := make(map[string]int, len(offsets))
+	for chunkType, offset := range offsets {
+		lines[chunkFilename[chunkType]] = offset
+	}
+
+	callbacks := make([]func(), len(onFlushed))
+	for i, callback := range onFlushed {
+		callbacks[i] = func() { callback(lines) }
+	}
+	return callbacks
+}
@@ -32,9 +32,11 @@
 package filestream
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/wandb/wandb/core/internal/api"
@@ -50,15 +52,21 @@ const (
 	SummaryFileName          = "wandb-summary.json"
 	OutputFileName           = "output.log"
 	defaultMaxItemsPerPush   = 5_000
-	defaultDelayProcess      = 20 * time.Millisecond
 	defaultHeartbeatInterval = 30 * time.Second
 
 	// Maximum line length for filestream jsonl files, imposed by the back-end.
 	//
 	// See https://github.com/wandb/core/pull/7339 for history.
 	maxFileLineBytes = (10 << 20) - (100 << 10)
+
+	// Space left in a request for everything but a line of the summary.
+	summaryLineReserveBytes = 1 << 10
 )
 
+// ErrRunFinished is the error of requests that the backend rejected because
+// the run was already finished, such as from the UI or by another process.
+var ErrRunFinished = errors.New("filestream: the run was already finished")
+
 type ChunkTypeEnum int8
 type FileStreamOffsetMap map[ChunkTypeEnum]int
 
@@ -89,6 +97,20 @@ type FileStream interface {
 
 	// StreamUpdate uploads information through the filestream API.
 	StreamUpdate(update Update)
+
+	// Backlog returns how much data is waiting to be uploaded.
+	Backlog() Backlog
+}
+
+// Backlog is how much data a filestream has yet to upload.
+type Backlog struct {
+	// QueuedUpdates is the number of updates not yet batched into a
+	// request.
+	QueuedUpdates int
+
+	// UnackedLines is the number of lines sent in a request that the
+	// server hasn't acknowledged yet.
+	UnackedLines int
 }
 
 // fileStream is a stream of data to the server
@@ -122,7 +144,16 @@ type fileStream struct {
 	apiClient api.Client
 
 	maxItemsPerPush int
-	delayProcess    waiting.Delay
+
+	// maxRequestBytes is the most bytes to send in one request's body.
+	maxRequestBytes int
+
+	// delayProcess is a fixed time to batch updates, used instead of
+	// transmitInterval if set.
+	delayProcess waiting.Delay
+
+	// transmitInterval adapts the time to batch updates to the backlog.
+	transmitInterval *transmitInterval
 
 	// A schedule on which to send heartbeats to the backend
 	// to prove the run is still alive.
@@ -133,6 +164,18 @@ type fileStream struct {
 	// A channel that is closed if there is a fatal error.
 	deadChan     chan struct{}
 	deadChanOnce *sync.Once
+
+	// Called once if there is a fatal error, if not nil.
+	fatalErrorHandler func(err error)
+
+	// Called once if the run was finished on the backend, if not nil.
+	runFinishedHandler func(err error)
+
+	// The number of lines in the request being sent.
+	unackedLines *atomic.Int64
+
+	// Reports whether the backend is reachable, if not nil.
+	reachable func() bool
 }
 
 type FileStreamParams struct {
@@ -144,6 +187,38 @@ type FileStreamParams struct {
 	ClientId           string
 	DelayProcess       waiting.Delay
 	HeartbeatStopwatch waiting.Stopwatch
+
+	// TransmitInterval is how long to batch updates before sending them,
+	// and MinTransmitInterval and MaxTransmitInterval are the bounds within
+	// which it adapts to the backlog. Zero values use the defaults.
+	//
+	// They're ignored if DelayProcess is set.
+	TransmitInterval    time.Duration
+	MinTransmitInterval time.Duration
+	MaxTransmitInterval time.Duration
+
+	// MaxRequestBytes limits the size of a request's body, if positive.
+	//
+	// Larger batches are split into several requests.
+	MaxRequestBytes int
+
+	// FatalErrorHandler, if set, is called once when the filestream stops
+	// working because of a fatal error.
+	FatalErrorHandler func(err error)
+
+	// RunFinishedHandler, if set, is called once when the filestream stops
+	// because the backend rejected its requests as the run was already
+	// finished. The error wraps ErrRunFinished.
+	RunFinishedHandler func(err error)
+
+	// Reachable, if set, reports whether the backend is reachable.
+	//
+	// Requests that fail because the network is unavailable are then
+	// kept and sent once it's reachable again, rather than being fatal.
+	// If the filestream is closed while the backend is unreachable, the
+	// remaining requests are dropped; the data is still in the run's
+	// transaction log.
+	Reachable func() bool
 }
 
 func NewFileStream(params FileStreamParams) FileStream {
@@ -168,13 +243,23 @@ func NewFileStream(params FileStreamParams) FileStream {
 		feedbackChan:    make(chan map[string]interface{}, BufferSize),
 		offsetMap:       make(FileStreamOffsetMap),
 		maxItemsPerPush: defaultMaxItemsPerPush,
+		maxRequestBytes: defaultMaxRequestBytes,
 		deadChanOnce:    &sync.Once{},
 		deadChan:        make(chan struct{}),
+
+		fatalErrorHandler:  params.FatalErrorHandler,
+		runFinishedHandler: params.RunFinishedHandler,
+		unackedLines:       &atomic.Int64{},
+		reachable:          params.Reachable,
 	}
 
 	fs.delayProcess = params.DelayProcess
 	if fs.delayProcess == nil {
-		fs.delayProcess = waiting.NewDelay(defaultDelayProcess)
+		fs.transmitInterval = newTransmitInterval(
+			params.TransmitInterval,
+			params.MinTransmitInterval,
+			params.MaxTransmitInterval,
+		)
 	}
 
 	fs.heartbeatStopwatch = params.HeartbeatStopwatch
@@ -186,6 +271,10 @@ func NewFileStream(params FileStreamParams) FileStream {
 		fs.maxItemsPerPush = params.MaxItemsPerPush
 	}
 
+	if params.MaxRequestBytes > 0 {
+		fs.maxRequestBytes = params.MaxRequestBytes
+	}
+
 	// TODO: this should become the default
 	if fs.settings.GetXShared().GetValue() && params.ClientId != "" {
 		fs.clientId = params.ClientId
@@ -237,6 +326,13 @@ func (fs *fileStream) StreamUpdate(update Update) {
 	fs.addProcess(update)
 }
 
+func (fs *fileStream) Backlog() Backlog {
+	return Backlog{
+		QueuedUpdates: len(fs.processChan) + len(fs.transmitChan),
+		UnackedLines:  int(fs.unackedLines.Load()),
+	}
+}
+
 func (fs *fileStream) Close() {
 	close(fs.processChan)
 	fs.processWait.Wait()
@@ -256,11 +352,49 @@ func (fs *fileStream) logFatalAndStopWorking(err error) {
 	fs.logger.CaptureFatal("filestream: fatal error", err)
 	fs.deadChanOnce.Do(func() {
 		close(fs.deadChan)
-		fs.printer.Write(
-			"Fatal error while uploading data. Some run data will" +
-				" not be synced, but it will still be written to disk. Use" +
-				" `wandb sync` at the end of the run to try uploading.",
-		)
+		// the user is told about exceeded limits when they're noticed
+		if !errors.As(err, new(*api.QuotaExceededError)) {
+			fs.printer.Emit(observability.UserMessage{
+				Level: observability.MessageError,
+				Key:   "filestream-failed",
+				Text: "Fatal error while uploading data. Some run data will" +
+					" not be synced, but it will still be written to disk. Use" +
+					" `wandb sync` at the end of the run to try uploading.",
+			})
+		}
+
+		if fs.fatalErrorHandler != nil {
+			fs.fatalErrorHandler(err)
+		}
+	})
+}
+
+// stopRunFinished kills the filestream because the backend rejects the
+// run's data, as the run was finished elsewhere.
+//
+// Unlike a fatal error, this is expected and isn't reported as a crash.
+// The run's data is still written to disk.
+func (fs *fileStream) stopRunFinished(err error) {
+	fs.logger.Warn("filestream: the run was finished on the server", "error", err)
+	fs.deadChanOnce.Do(func() {
+		close(fs.deadChan)
+
+		text := "The run was finished on the server, such as from the UI," +
+			" while it was running. Its remaining data will not be uploaded," +
+			" but it will still be written to disk."
+		if fs.settings.GetXSync().GetValue() {
+			text = "Can't sync to the run because it's already finished on" +
+				" the server. Use `wandb sync --id` to sync to a new run instead."
+		}
+		fs.printer.Emit(observability.UserMessage{
+			Level: observability.MessageWarning,
+			Key:   "filestream-run-finished",
+			Text:  text,
+		})
+
+		if fs.runFinishedHandler != nil {
+			fs.runFinishedHandler(err)
+		}
 	})
 }
 
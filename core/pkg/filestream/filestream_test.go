@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/wandb/wandb/core/internal/api"
 	"github.com/wandb/wandb/core/internal/apitest"
 	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/waitingtest"
@@ -17,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wandb/wandb/core/pkg/service"
 )
 
@@ -36,6 +40,7 @@ func TestFileStream(t *testing.T) {
 	var printer *observability.Printer
 	var heartbeatStopwatch waiting.Stopwatch
 	var processDelay waiting.Delay
+	var fatalErrors []error
 
 	setup := func(configure func()) filestream.FileStream {
 		fakeClient = apitest.NewFakeClient("test-url")
@@ -43,6 +48,7 @@ func TestFileStream(t *testing.T) {
 		// By default, chunk everything and prevent heartbeats.
 		heartbeatStopwatch = waitingtest.NewFakeStopwatch()
 		processDelay = waitingtest.NewFakeDelay()
+		fatalErrors = nil
 
 		// Allow tests to override the above objects.
 		configure()
@@ -54,6 +60,9 @@ func TestFileStream(t *testing.T) {
 			ApiClient:          fakeClient,
 			DelayProcess:       processDelay,
 			HeartbeatStopwatch: heartbeatStopwatch,
+			FatalErrorHandler: func(err error) {
+				fatalErrors = append(fatalErrors, err)
+			},
 		})
 	}
 
@@ -129,5 +138,270 @@ func TestFileStream(t *testing.T) {
 		messages := printer.Read()
 		assert.Len(t, messages, 1)
 		assert.Contains(t, messages[0], "Fatal error")
+		assert.Len(t, fatalErrors, 1)
+	})
+
+	t.Run("reports backlog", func(t *testing.T) {
+		fs := setup(func() {})
+
+		fakeClient.SetResponse(&apitest.TestResponse{StatusCode: 200}, nil)
+		fs.StreamUpdate(NewHistoryRecord())
+		fs.StreamUpdate(NewHistoryRecord())
+		queued := fs.Backlog()
+		fs.Start("entity", "project", "run", filestream.FileStreamOffsetMap{})
+		fs.Close()
+
+		assert.Equal(t, filestream.Backlog{QueuedUpdates: 2}, queued)
+		assert.Equal(t, filestream.Backlog{}, fs.Backlog())
+	})
+}
+
+func TestFileStream_SplitsRequestsOverMaxSize(t *testing.T) {
+	const maxRequestBytes = 16 << 10
+	fakeClient := apitest.NewFakeClient("test-url")
+	fakeClient.SetResponse(&apitest.TestResponse{StatusCode: 200}, nil)
+	printer := observability.NewPrinter()
+	fs := filestream.NewFileStream(filestream.FileStreamParams{
+		Settings:           &service.Settings{},
+		Logger:             observability.NewNoOpLogger(),
+		Printer:            printer,
+		ApiClient:          fakeClient,
+		DelayProcess:       waitingtest.NewFakeDelay(),
+		HeartbeatStopwatch: waitingtest.NewFakeStopwatch(),
+		MaxRequestBytes:    maxRequestBytes,
+	})
+	wideRow := &service.HistoryRecord{}
+	for i := range 5000 {
+		wideRow.Item = append(wideRow.Item, &service.HistoryItem{
+			Key:       fmt.Sprintf("metric_%d", i),
+			ValueJson: "0.5",
+		})
+	}
+	row := func(i int) filestream.Update {
+		return &filestream.HistoryUpdate{
+			Record: &service.HistoryRecord{
+				Item: []*service.HistoryItem{
+					{Key: "row", ValueJson: fmt.Sprintf("%d", i)},
+					{Key: "padding", ValueJson: fmt.Sprintf("%q", strings.Repeat("x", 1000))},
+				},
+			},
+		}
+	}
+
+	fs.Start("entity", "project", "run", filestream.FileStreamOffsetMap{})
+	for i := range 20 {
+		fs.StreamUpdate(row(i))
+	}
+	fs.StreamUpdate(&filestream.HistoryUpdate{Record: wideRow})
+	for i := 20; i < 40; i++ {
+		fs.StreamUpdate(row(i))
+	}
+	fs.StreamUpdate(&filestream.ExitUpdate{Record: &service.RunExitRecord{}})
+	fs.Close()
+
+	requests := fakeClient.GetRequests()
+	require.Greater(t, len(requests), 1)
+	var lines []string
+	for i, req := range requests {
+		assert.LessOrEqual(t, len(req.Body), maxRequestBytes)
+
+		var body struct {
+			Files map[string]struct {
+				Offset  int      `json:"offset"`
+				Content []string `json:"content"`
+			} `json:"files"`
+			Complete *bool `json:"complete"`
+		}
+		require.NoError(t, json.Unmarshal(req.Body, &body))
+		history := body.Files[filestream.HistoryFileName]
+		assert.Equal(t, len(lines), history.Offset)
+		lines = append(lines, history.Content...)
+		assert.Equal(t, i == len(requests)-1, body.Complete != nil)
+	}
+	require.Len(t, lines, 41)
+	for i, line := range lines[:20] {
+		assert.Contains(t, line, fmt.Sprintf(`"row":%d`, i))
+	}
+	assert.True(t, strings.HasPrefix(lines[20], `{"metric_0":0.5,`))
+	for i, line := range lines[21:] {
+		assert.Contains(t, line, fmt.Sprintf(`"row":%d`, i+20))
+	}
+	assert.Equal(t,
+		[]string{"Truncated uploaded run data that exceeded size limit."},
+		printer.Read())
+}
+
+func TestFileStream_HoldsRequestsWhileOffline(t *testing.T) {
+	fakeClient := apitest.NewFakeClient("test-url")
+	fakeClient.SetResponse(nil, fmt.Errorf("send: %w", api.ErrNetworkUnavailable))
+	// The network goes down once the first request fails.
+	var online atomic.Bool
+	reachable := func() bool {
+		return online.Load() || len(fakeClient.GetRequests()) == 0
+	}
+	var fatalErrors []error
+	fs := filestream.NewFileStream(filestream.FileStreamParams{
+		Settings:           &service.Settings{},
+		Logger:             observability.NewNoOpLogger(),
+		Printer:            observability.NewPrinter(),
+		ApiClient:          fakeClient,
+		DelayProcess:       waiting.NoDelay(),
+		HeartbeatStopwatch: waitingtest.NewFakeStopwatch(),
+		Reachable:          reachable,
+		FatalErrorHandler: func(err error) {
+			fatalErrors = append(fatalErrors, err)
+		},
 	})
+	row := func(i int) filestream.Update {
+		return &filestream.HistoryUpdate{
+			Record: &service.HistoryRecord{
+				Item: []*service.HistoryItem{
+					{Key: "row", ValueJson: fmt.Sprintf("%d", i)},
+				},
+			},
+		}
+	}
+
+	fs.Start("entity", "project", "run", filestream.FileStreamOffsetMap{})
+	fs.StreamUpdate(row(0))
+	require.Eventually(t,
+		func() bool { return len(fakeClient.GetRequests()) == 1 },
+		time.Second, time.Millisecond)
+	fs.StreamUpdate(row(1))
+	fakeClient.SetResponse(&apitest.TestResponse{StatusCode: 200}, nil)
+	online.Store(true)
+	fs.Close()
+
+	assert.Empty(t, fatalErrors)
+	requests := fakeClient.GetRequests()
+	require.GreaterOrEqual(t, len(requests), 3)
+	assert.Equal(t, requests[0].Body, requests[1].Body)
+	var lines []string
+	for _, req := range requests[1:] {
+		var body struct {
+			Files map[string]struct {
+				Offset  int      `json:"offset"`
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(req.Body, &body))
+		if history, ok := body.Files[filestream.HistoryFileName]; ok {
+			assert.Equal(t, len(lines), history.Offset)
+			lines = append(lines, history.Content...)
+		}
+	}
+	assert.Equal(t, []string{`{"row":0}`, `{"row":1}`}, lines)
+}
+
+func TestFileStream_ClosesWhileOffline(t *testing.T) {
+	fakeClient := apitest.NewFakeClient("test-url")
+	fakeClient.SetResponse(nil, fmt.Errorf("send: %w", api.ErrNetworkUnavailable))
+	// The network goes down once the first request fails.
+	var online atomic.Bool
+	reachable := func() bool {
+		return online.Load() || len(fakeClient.GetRequests()) == 0
+	}
+	var fatalErrors []error
+	fs := filestream.NewFileStream(filestream.FileStreamParams{
+		Settings:           &service.Settings{},
+		Logger:             observability.NewNoOpLogger(),
+		Printer:            observability.NewPrinter(),
+		ApiClient:          fakeClient,
+		DelayProcess:       waiting.NoDelay(),
+		HeartbeatStopwatch: waitingtest.NewFakeStopwatch(),
+		Reachable:          reachable,
+		FatalErrorHandler: func(err error) {
+			fatalErrors = append(fatalErrors, err)
+		},
+	})
+
+	fs.Start("entity", "project", "run", filestream.FileStreamOffsetMap{})
+	fs.StreamUpdate(NewHistoryRecord())
+	require.Eventually(t,
+		func() bool { return len(fakeClient.GetRequests()) == 1 },
+		time.Second, time.Millisecond)
+	fs.StreamUpdate(&filestream.ExitUpdate{Record: &service.RunExitRecord{}})
+	fs.Close()
+
+	assert.Empty(t, fatalErrors)
+	assert.Len(t, fakeClient.GetRequests(), 1)
+}
+
+func TestFileStream_OnAckedOnceRequestSucceeds(t *testing.T) {
+	fakeClient := apitest.NewFakeClient("test-url")
+	fakeClient.SetResponse(nil, fmt.Errorf("send: %w", api.ErrNetworkUnavailable))
+	// The network goes down once the first request fails.
+	var online atomic.Bool
+	reachable := func() bool {
+		return online.Load() || len(fakeClient.GetRequests()) == 0
+	}
+	fs := filestream.NewFileStream(filestream.FileStreamParams{
+		Settings:           &service.Settings{},
+		Logger:             observability.NewNoOpLogger(),
+		Printer:            observability.NewPrinter(),
+		ApiClient:          fakeClient,
+		DelayProcess:       waiting.NoDelay(),
+		HeartbeatStopwatch: waitingtest.NewFakeStopwatch(),
+		Reachable:          reachable,
+	})
+	var acked atomic.Int32
+
+	fs.Start("entity", "project", "run", filestream.FileStreamOffsetMap{})
+	fs.StreamUpdate(&filestream.HistoryUpdate{
+		Record: &service.HistoryRecord{
+			Item: []*service.HistoryItem{{Key: "row", ValueJson: "0"}},
+		},
+		OnAcked: func() { acked.Add(1) },
+	})
+	require.Eventually(t,
+		func() bool { return len(fakeClient.GetRequests()) == 1 },
+		time.Second, time.Millisecond)
+	ackedWhileOffline := acked.Load()
+	fakeClient.SetResponse(&apitest.TestResponse{StatusCode: 200}, nil)
+	online.Store(true)
+	fs.StreamUpdate(NewHistoryRecord())
+	fs.Close()
+
+	assert.Zero(t, ackedWhileOffline)
+	assert.EqualValues(t, 1, acked.Load())
+}
+
+func TestFileStream_StopsOnceRunFinished(t *testing.T) {
+	fakeClient := apitest.NewFakeClient("test-url")
+	fakeClient.SetResponse(&apitest.TestResponse{StatusCode: http.StatusConflict}, nil)
+	printer := observability.NewPrinter()
+	var fatalErrors, finishedErrors []error
+	fs := filestream.NewFileStream(filestream.FileStreamParams{
+		Settings:           &service.Settings{},
+		Logger:             observability.NewNoOpLogger(),
+		Printer:            printer,
+		ApiClient:          fakeClient,
+		DelayProcess:       waiting.NoDelay(),
+		HeartbeatStopwatch: waitingtest.NewFakeStopwatch(),
+		FatalErrorHandler: func(err error) {
+			fatalErrors = append(fatalErrors, err)
+		},
+		RunFinishedHandler: func(err error) {
+			finishedErrors = append(finishedErrors, err)
+		},
+	})
+
+	fs.Start("entity", "project", "run", filestream.FileStreamOffsetMap{})
+	fs.StreamUpdate(NewHistoryRecord())
+	require.Eventually(t,
+		func() bool { return len(fakeClient.GetRequests()) == 1 },
+		time.Second, time.Millisecond)
+	// more updates than the filestream buffers, none of which are sent
+	for range 4 * filestream.BufferSize {
+		fs.StreamUpdate(NewHistoryRecord())
+	}
+	fs.Close()
+
+	assert.Len(t, fakeClient.GetRequests(), 1)
+	assert.Empty(t, fatalErrors)
+	require.Len(t, finishedErrors, 1)
+	assert.ErrorIs(t, finishedErrors[0], filestream.ErrRunFinished)
+	messages := printer.Read()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "finished on the server")
 }
@@ -16,8 +16,9 @@ func (fs *fileStream) loopProcess(inChan <-chan Update) {
 		err := update.Apply(UpdateContext{
 			ModifyRequest: fs.addTransmit,
 
-			Settings: fs.settings,
-			ClientID: fs.clientId,
+			Settings:        fs.settings,
+			ClientID:        fs.clientId,
+			MaxRequestBytes: fs.maxRequestBytes,
 
 			Logger:  fs.logger,
 			Printer: fs.printer,
@@ -1,12 +1,15 @@
 package filestream
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/segmentio/encoding/json"
 	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/pkg/observability"
 )
 
 // FsTransmitData is serialized and sent to a W&B server
@@ -17,6 +20,9 @@ type FsTransmitData struct {
 	Preempting bool                          `json:"preempting,omitempty"`
 	Dropped    int32                         `json:"dropped,omitempty"`
 	Uploaded   []string                      `json:"uploaded,omitempty"`
+
+	// onAcked are called once the request is acknowledged.
+	onAcked []func()
 }
 
 // FsServerFileData (part of FsTransmitData) is serialized and sent to a W&B server
@@ -25,35 +31,128 @@ type fsTransmitFileData struct {
 	Content []string `json:"content"`
 }
 
+// lineCount returns the number of lines in the request.
+func (d *FsTransmitData) lineCount() int {
+	n := 0
+	for _, file := range d.Files {
+		n += len(file.Content)
+	}
+	return n
+}
+
 func (fs *fileStream) addTransmit(chunk CollectorStateUpdate) {
-	fs.transmitChan <- chunk
+	select {
+	case fs.transmitChan <- chunk:
+
+	// If the filestream dies, nothing reads the transmit channel anymore.
+	case <-fs.deadChan:
+	}
 }
 
 func (fs *fileStream) loopTransmit(inChan <-chan CollectorStateUpdate) {
 	collector := chunkCollector{
 		input:           inChan,
 		processDelay:    fs.delayProcess,
+		interval:        fs.transmitInterval,
 		maxItemsPerPush: fs.maxItemsPerPush,
 	}
+
+	// Requests not sent yet, in order. Requests accumulate here while the
+	// backend is unreachable.
+	var pending []*FsTransmitData
+
 	for !collector.isDone {
 		data, ok := collector.CollectAndDump(fs.offsetMap)
 
 		if ok {
-			if err := fs.send(data); err != nil {
-				fs.logFatalAndStopWorking(err)
-				return
-			}
+			pending = append(pending, fs.splitRequest(data)...)
+		} else if len(pending) == 0 && fs.heartbeatStopwatch.IsDone() {
+			pending = append(pending, &FsTransmitData{})
+		}
 
-			fs.heartbeatStopwatch.Reset()
-		} else if fs.heartbeatStopwatch.IsDone() {
-			if err := fs.send(&FsTransmitData{}); err != nil {
-				fs.logFatalAndStopWorking(err)
-				return
-			}
+		if len(pending) == 0 || fs.isOffline() {
+			continue
+		}
 
+		var err error
+		pending, err = fs.sendInOrder(pending)
+		switch {
+		case err == nil:
 			fs.heartbeatStopwatch.Reset()
+
+		case errors.Is(err, ErrRunFinished):
+			fs.stopRunFinished(err)
+			return
+
+		case errors.Is(err, api.ErrNetworkUnavailable) && fs.reachable != nil:
+			fs.logger.Warn(
+				"filestream: network unavailable, holding requests",
+				"requests", len(pending),
+			)
+
+		default:
+			fs.logFatalAndStopWorking(err)
+			return
 		}
 	}
+
+	if len(pending) > 0 {
+		fs.logger.Warn(
+			"filestream: closed while network unavailable, dropping requests",
+			"requests", len(pending),
+		)
+	}
+}
+
+// splitRequest splits a batch into requests under the size limit,
+// warning if any lines had to be truncated.
+func (fs *fileStream) splitRequest(data *FsTransmitData) []*FsTransmitData {
+	requests, truncated := splitRequest(data, fs.maxRequestBytes)
+	if truncated > 0 {
+		fs.logger.CaptureWarn(
+			"filestream: lines too long for a request, truncating",
+			"lines", truncated,
+			"max", fs.maxRequestBytes,
+		)
+		fs.printer.
+			AtMostEvery(time.Minute).
+			Emit(observability.UserMessage{
+				Level:    observability.MessageWarning,
+				Category: "data-truncated",
+				Text:     "Truncated uploaded run data that exceeded size limit.",
+			})
+	}
+	return requests
+}
+
+// sendInOrder sends the requests one at a time.
+//
+// It stops at the first error and returns the requests that weren't sent.
+func (fs *fileStream) sendInOrder(
+	requests []*FsTransmitData,
+) ([]*FsTransmitData, error) {
+	lines := 0
+	for _, request := range requests {
+		lines += request.lineCount()
+	}
+	fs.unackedLines.Store(int64(lines))
+
+	for i, request := range requests {
+		if err := fs.send(request); err != nil {
+			return requests[i:], err
+		}
+		for _, onAcked := range request.onAcked {
+			onAcked()
+		}
+	}
+
+	fs.unackedLines.Store(0)
+	return nil, nil
+}
+
+// isOffline reports whether the backend is known to be unreachable.
+func (fs *fileStream) isOffline() bool {
+	return fs.reachable != nil && !fs.reachable()
 }
 
 func (fs *fileStream) send(data *FsTransmitData) error {
@@ -81,14 +180,10 @@ func (fs *fileStream) send(data *FsTransmitData) error {
 
 	switch {
 	case err != nil:
-		return fmt.Errorf("filestream: error making HTTP request: %v", err)
+		return fmt.Errorf("filestream: error making HTTP request: %w", err)
 	case resp == nil:
 		// Sometimes resp and err can both be nil in retryablehttp's Client.
 		return fmt.Errorf("filestream: nil response and nil error")
-	case resp.StatusCode < 200 || resp.StatusCode > 300:
-		// If we reach here, that means all retries were exhausted. This could
-		// mean, for instance, that the user's internet connection broke.
-		return fmt.Errorf("filestream: failed to upload: %v", resp.Status)
 	}
 
 	defer func(Body io.ReadCloser) {
@@ -97,11 +192,23 @@ func (fs *fileStream) send(data *FsTransmitData) error {
 		}
 	}(resp.Body)
 
+	if err := api.ResponseError(resp); err != nil {
+		// If we reach here, that means all retries were exhausted or that
+		// the error can't be fixed by retrying.
+		if resp.StatusCode == http.StatusConflict {
+			return fmt.Errorf("%w: %w", ErrRunFinished, err)
+		}
+		return fmt.Errorf("filestream: failed to upload: %w", err)
+	}
+
 	var res map[string]interface{}
 	err = json.NewDecoder(resp.Body).Decode(&res)
 	if err != nil {
 		fs.logger.CaptureError("json decode error", err)
 	}
+	if fs.transmitInterval != nil {
+		fs.transmitInterval.SetHint(res)
+	}
 	fs.addFeedback(res)
 	fs.logger.Debug("filestream: post response", "response", res)
 	return nil
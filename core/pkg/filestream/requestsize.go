@@ -0,0 +1,181 @@
+package filestream
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// defaultMaxRequestBytes is the default limit on the size of a request's
+// body.
+const defaultMaxRequestBytes = 10 << 20
+
+// fileOrder is the order in which files are split across requests.
+var fileOrder = []string{
+	HistoryFileName,
+	EventsFileName,
+	OutputFileName,
+	SummaryFileName,
+}
+
+// splitRequest splits a request into requests whose bodies are at most
+// maxBytes, to be sent in order.
+//
+// Each file's lines keep their order, and each request's offsets account
+// for the lines in the requests before it. The exit status is sent with
+// the last request and other fields with the first. Callbacks for when the
+// data is acknowledged are called after the last request.
+//
+// A line that doesn't fit in a request by itself is truncated. Returns
+// the requests and the number of truncated lines.
+//
+// Sizes are upper bounds on the JSON encoding, so a request may be split
+// a little earlier than necessary.
+func splitRequest(
+	data *FsTransmitData,
+	maxBytes int,
+) ([]*FsTransmitData, int) {
+	// Every request reserves space for all fields other than files, since
+	// which request is last is only known at the end.
+	overhead := nonFileSize(data) + len(`,"files":{}`)
+
+	size := overhead
+	for name, file := range data.Files {
+		size += fileEntrySize(name, file.Offset)
+		for _, line := range file.Content {
+			size += encodedStringLen(line) + 1
+		}
+	}
+	if size <= maxBytes {
+		return []*FsTransmitData{data}, 0
+	}
+
+	current := &FsTransmitData{
+		Preempting: data.Preempting,
+		Dropped:    data.Dropped,
+		Uploaded:   data.Uploaded,
+	}
+	requests := []*FsTransmitData{current}
+	currentSize := overhead
+	truncated := 0
+
+	for _, name := range fileOrder {
+		file, ok := data.Files[name]
+		if !ok {
+			continue
+		}
+
+		// Offsets only grow, so this bounds the entry's size in any request.
+		entrySize := fileEntrySize(name, file.Offset+len(file.Content))
+
+		for i, line := range file.Content {
+			needed := encodedStringLen(line) + 1
+			if _, ok := current.Files[name]; !ok {
+				needed += entrySize
+			}
+
+			if currentSize+needed > maxBytes && currentSize > overhead {
+				current = &FsTransmitData{}
+				requests = append(requests, current)
+				currentSize = overhead
+				needed = encodedStringLen(line) + 1 + entrySize
+			}
+
+			if currentSize+needed > maxBytes {
+				line = truncateEncoded(line, maxBytes-currentSize-entrySize-3)
+				needed = encodedStringLen(line) + 1 + entrySize
+				truncated++
+			}
+
+			if current.Files == nil {
+				current.Files = make(map[string]fsTransmitFileData)
+			}
+			fileData, ok := current.Files[name]
+			if !ok {
+				fileData.Offset = file.Offset + i
+			}
+			fileData.Content = append(fileData.Content, line)
+			current.Files[name] = fileData
+			currentSize += needed
+		}
+	}
+
+	current.Complete = data.Complete
+	current.Exitcode = data.Exitcode
+	current.onAcked = data.onAcked
+	return requests, truncated
+}
+
+// nonFileSize is the encoded size of the request without its files.
+func nonFileSize(data *FsTransmitData) int {
+	encoded, err := json.Marshal(&FsTransmitData{
+		Complete:   data.Complete,
+		Exitcode:   data.Exitcode,
+		Preempting: data.Preempting,
+		Dropped:    data.Dropped,
+		Uploaded:   data.Uploaded,
+	})
+	if err != nil {
+		return 0
+	}
+	return len(encoded)
+}
+
+// fileEntrySize is the encoded size of a file in a request without its
+// lines, including a separating comma.
+func fileEntrySize(name string, offset int) int {
+	return len(`"":{"offset":,"content":[]},`) +
+		len(name) +
+		len(strconv.Itoa(offset))
+}
+
+// encodedStringLen is an upper bound on the size of a string encoded as
+// JSON, including its quotes.
+func encodedStringLen(s string) int {
+	n := 2
+	for i := 0; i < len(s); {
+		size, runeLen := encodedRuneLen(s[i:])
+		n += size
+		i += runeLen
+	}
+	return n
+}
+
+// truncateEncoded returns the longest prefix of the string whose encoding
+// without quotes is at most maxBytes.
+func truncateEncoded(s string, maxBytes int) string {
+	n := 0
+	for i := 0; i < len(s); {
+		size, runeLen := encodedRuneLen(s[i:])
+		if n+size > maxBytes {
+			return s[:i]
+		}
+		n += size
+		i += runeLen
+	}
+	return s
+}
+
+// encodedRuneLen returns an upper bound on the encoded size of the first
+// rune in the string, and the rune's length in the string.
+func encodedRuneLen(s string) (int, int) {
+	if b := s[0]; b < utf8.RuneSelf {
+		switch {
+		case b == '"', b == '\\', b == '\n', b == '\r', b == '\t':
+			return 2, 1
+		case b < 0x20, b == '<', b == '>', b == '&':
+			return len(`\u0000`), 1
+		default:
+			return 1, 1
+		}
+	}
+
+	r, runeLen := utf8.DecodeRuneInString(s)
+	switch {
+	case r == utf8.RuneError && runeLen == 1, r == '\u2028', r == '\u2029':
+		return len(`\ufffd`), runeLen
+	default:
+		return runeLen, runeLen
+	}
+}
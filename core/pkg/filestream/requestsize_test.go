@@ -0,0 +1,90 @@
+package filestream
+
+import (
+	"testing"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodedStringLen_IsUpperBound(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"plain",
+		`{"quoted": "value\\"}`,
+		"tab\tnewline\nreturn\r",
+		"control\x01\x1f",
+		"<html> & co",
+		"ünïcödé 日本語",
+		"invalid \xff utf8",
+		"separators \u2028\u2029",
+	} {
+		encoded, err := json.Marshal(s)
+		require.NoError(t, err)
+
+		assert.GreaterOrEqual(t, encodedStringLen(s), len(encoded), "for %q", s)
+	}
+}
+
+func TestTruncateEncoded_KeepsWholeRunes(t *testing.T) {
+	assert.Equal(t, "ab", truncateEncoded("ab日本", 4))
+	assert.Equal(t, "ab日", truncateEncoded("ab日本", 5))
+	assert.Equal(t, "a", truncateEncoded(`a"b`, 2))
+}
+
+func TestSplitRequest_SmallRequestUnchanged(t *testing.T) {
+	data := &FsTransmitData{
+		Files: map[string]fsTransmitFileData{
+			HistoryFileName: {Offset: 3, Content: []string{"a", "b"}},
+		},
+	}
+
+	requests, truncated := splitRequest(data, 1000)
+
+	assert.Equal(t, []*FsTransmitData{data}, requests)
+	assert.Zero(t, truncated)
+}
+
+func TestSplitRequest_SplitsFilesInOrder(t *testing.T) {
+	boolTrue := true
+	exitCode := int32(1)
+	data := &FsTransmitData{
+		Files: map[string]fsTransmitFileData{
+			HistoryFileName: {Offset: 10, Content: []string{"h1", "h2", "h3"}},
+			OutputFileName:  {Offset: 5, Content: []string{"o1", "o2"}},
+		},
+		Complete: &boolTrue,
+		Exitcode: &exitCode,
+		Uploaded: []string{"file.txt"},
+	}
+	// Room for the fields other than files and about two lines.
+	maxBytes := nonFileSize(data) + 11 + 2*fileEntrySize(HistoryFileName, 13) + 12
+
+	requests, truncated := splitRequest(data, maxBytes)
+
+	assert.Zero(t, truncated)
+	var history, output []string
+	for i, request := range requests {
+		encoded, err := json.Marshal(request)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(encoded), maxBytes)
+
+		if file, ok := request.Files[HistoryFileName]; ok {
+			assert.Equal(t, 10+len(history), file.Offset)
+			history = append(history, file.Content...)
+		}
+		if file, ok := request.Files[OutputFileName]; ok {
+			assert.Equal(t, 5+len(output), file.Offset)
+			output = append(output, file.Content...)
+		}
+
+		isFirst, isLast := i == 0, i == len(requests)-1
+		assert.Equal(t, isFirst, request.Uploaded != nil)
+		assert.Equal(t, isLast, request.Complete != nil)
+		assert.Equal(t, isLast, request.Exitcode != nil)
+	}
+	assert.Greater(t, len(requests), 1)
+	assert.Equal(t, []string{"h1", "h2", "h3"}, history)
+	assert.Equal(t, []string{"o1", "o2"}, output)
+}
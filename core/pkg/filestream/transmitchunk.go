@@ -11,10 +11,17 @@ type TransmitChunk struct {
 
 	LatestSummary string
 
+	// SummaryParts are the lines of a summary split across several lines,
+	// which are sent after LatestSummary.
+	SummaryParts []string
+
 	UploadedFiles []string
 
 	HasPreempting bool
 	Preempting    bool
+
+	// OnAcked are called once the request with the data is acknowledged.
+	OnAcked []func()
 }
 
 func (c *TransmitChunk) Apply(state *CollectorState) {
@@ -25,9 +32,13 @@ func (c *TransmitChunk) Apply(state *CollectorState) {
 	state.Buffer.ConsoleLogLines =
 		append(state.Buffer.ConsoleLogLines, c.ConsoleLogLines...)
 
+	// a whole summary supersedes the parts of earlier ones
 	if c.LatestSummary != "" {
 		state.Buffer.LatestSummary = c.LatestSummary
+		state.Buffer.SummaryParts = nil
 	}
+	state.Buffer.SummaryParts =
+		append(state.Buffer.SummaryParts, c.SummaryParts...)
 
 	state.Buffer.UploadedFiles =
 		append(state.Buffer.UploadedFiles, c.UploadedFiles...)
@@ -36,6 +47,8 @@ func (c *TransmitChunk) Apply(state *CollectorState) {
 		state.Buffer.HasPreempting = true
 		state.Buffer.Preempting = c.Preempting
 	}
+
+	state.Buffer.OnAcked = append(state.Buffer.OnAcked, c.OnAcked...)
 }
 
 // Write writes the buffered data to the filestream request.
@@ -59,9 +72,11 @@ func (c *TransmitChunk) Write(
 	addLines(EventsChunk, c.EventsLines)
 	addLines(OutputChunk, c.ConsoleLogLines)
 
+	var summaryLines []string
 	if c.LatestSummary != "" {
-		addLines(SummaryChunk, []string{c.LatestSummary})
+		summaryLines = append(summaryLines, c.LatestSummary)
 	}
+	addLines(SummaryChunk, append(summaryLines, c.SummaryParts...))
 
 	hasData := false
 
@@ -80,5 +95,6 @@ func (c *TransmitChunk) Write(
 		hasData = true
 	}
 
+	data.onAcked = c.OnAcked
 	return hasData
 }
@@ -0,0 +1,106 @@
+package filestream
+
+import "time"
+
+const (
+	defaultTransmitInterval    = 500 * time.Millisecond
+	defaultMinTransmitInterval = 20 * time.Millisecond
+	defaultMaxTransmitInterval = 5 * time.Second
+
+	// highBacklogLines is the number of pending lines above which the
+	// interval shrinks.
+	highBacklogLines = 100
+
+	// transmitIntervalHintKey is the key of the interval, in seconds, that
+	// the server may ask for in its responses.
+	transmitIntervalHintKey = "transmit_interval_seconds"
+)
+
+// transmitInterval decides how long to batch updates before sending them.
+//
+// The interval shrinks toward its minimum while lines pile up faster than
+// they're sent, grows toward its maximum while there's nothing to send,
+// and otherwise returns to its default. A hint from the server overrides
+// this, within the same bounds.
+//
+// It is not safe for concurrent use.
+type transmitInterval struct {
+	// base is the interval used when the backlog is small.
+	base time.Duration
+
+	// floor and ceiling bound the interval.
+	floor, ceiling time.Duration
+
+	// current is the interval before the next transmission.
+	current time.Duration
+
+	// hint is the interval the server last asked for, or 0.
+	hint time.Duration
+}
+
+// newTransmitInterval returns an interval starting at its default.
+//
+// Non-positive durations are replaced by the package defaults, and the
+// default is clamped to the bounds.
+func newTransmitInterval(base, floor, ceiling time.Duration) *transmitInterval {
+	if floor <= 0 {
+		floor = defaultMinTransmitInterval
+	}
+	if ceiling <= 0 {
+		ceiling = defaultMaxTransmitInterval
+	}
+	ceiling = max(ceiling, floor)
+
+	if base <= 0 {
+		base = defaultTransmitInterval
+	}
+	base = min(max(base, floor), ceiling)
+
+	return &transmitInterval{
+		base:    base,
+		floor:   floor,
+		ceiling: ceiling,
+		current: base,
+	}
+}
+
+// Get returns the interval to wait before the next transmission.
+func (ti *transmitInterval) Get() time.Duration {
+	if ti.hint > 0 {
+		return min(max(ti.hint, ti.floor), ti.ceiling)
+	}
+	return ti.current
+}
+
+// Observe adjusts the interval after collecting a batch.
+//
+// pendingLines is the number of lines in the batch plus the number of
+// updates still queued. idle is whether there was nothing to send.
+func (ti *transmitInterval) Observe(pendingLines int, idle bool) {
+	switch {
+	case pendingLines >= highBacklogLines:
+		ti.current = max(ti.current/2, ti.floor)
+
+	case idle:
+		ti.current = min(ti.current*2, ti.ceiling)
+
+	case ti.current < ti.base:
+		ti.current = min(ti.current*2, ti.base)
+
+	case ti.current > ti.base:
+		ti.current = max(ti.current/2, ti.base)
+	}
+}
+
+// SetHint sets the interval the server asked for in a response.
+//
+// A response without a valid hint clears it.
+func (ti *transmitInterval) SetHint(response map[string]any) {
+	seconds, ok := response[transmitIntervalHintKey].(float64)
+	if !ok || seconds <= 0 {
+		ti.hint = 0
+		return
+	}
+
+	ti.hint = time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,113 @@
+package filestream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransmitInterval_FollowsBacklog(t *testing.T) {
+	ti := newTransmitInterval(
+		400*time.Millisecond,
+		50*time.Millisecond,
+		3200*time.Millisecond,
+	)
+
+	// A run that starts idle, logs a burst that builds up and drains, and
+	// then logs a little at a time before going idle again.
+	curve := []struct {
+		pendingLines int
+		idle         bool
+		expected     time.Duration
+	}{
+		{0, true, 800 * time.Millisecond},
+		{0, true, 1600 * time.Millisecond},
+		{0, true, 3200 * time.Millisecond},
+		{0, true, 3200 * time.Millisecond},
+		{150, false, 1600 * time.Millisecond},
+		{600, false, 800 * time.Millisecond},
+		{2000, false, 400 * time.Millisecond},
+		{5000, false, 200 * time.Millisecond},
+		{5000, false, 100 * time.Millisecond},
+		{3000, false, 50 * time.Millisecond},
+		{1000, false, 50 * time.Millisecond},
+		{20, false, 100 * time.Millisecond},
+		{5, false, 200 * time.Millisecond},
+		{5, false, 400 * time.Millisecond},
+		{5, false, 400 * time.Millisecond},
+		{0, true, 800 * time.Millisecond},
+	}
+
+	for i, point := range curve {
+		ti.Observe(point.pendingLines, point.idle)
+		assert.Equal(t, point.expected, ti.Get(), "at point %d", i)
+	}
+}
+
+func TestTransmitInterval_ServerHint(t *testing.T) {
+	ti := newTransmitInterval(
+		400*time.Millisecond,
+		50*time.Millisecond,
+		3200*time.Millisecond,
+	)
+
+	ti.SetHint(map[string]any{"transmit_interval_seconds": 1.5})
+	ti.Observe(5000, false)
+	assert.Equal(t, 1500*time.Millisecond, ti.Get())
+
+	ti.SetHint(map[string]any{"transmit_interval_seconds": 60.0})
+	assert.Equal(t, 3200*time.Millisecond, ti.Get())
+
+	ti.SetHint(map[string]any{"transmit_interval_seconds": 0.001})
+	assert.Equal(t, 50*time.Millisecond, ti.Get())
+
+	// Without a hint, the interval is what the backlog made it.
+	ti.SetHint(map[string]any{})
+	assert.Equal(t, 200*time.Millisecond, ti.Get())
+}
+
+func TestTransmitInterval_Defaults(t *testing.T) {
+	ti := newTransmitInterval(0, 0, 0)
+
+	assert.Equal(t, defaultTransmitInterval, ti.Get())
+	for range 10 {
+		ti.Observe(0, true)
+	}
+	assert.Equal(t, defaultMaxTransmitInterval, ti.Get())
+	for range 10 {
+		ti.Observe(highBacklogLines, false)
+	}
+	assert.Equal(t, defaultMinTransmitInterval, ti.Get())
+}
+
+func TestTransmitInterval_ClampsDefault(t *testing.T) {
+	ti := newTransmitInterval(time.Hour, time.Second, 2*time.Second)
+
+	assert.Equal(t, 2*time.Second, ti.Get())
+}
+
+func TestCollectFinal_BypassesInterval(t *testing.T) {
+	input := make(chan CollectorStateUpdate, 32)
+	collector := chunkCollector{
+		input:           input,
+		interval:        newTransmitInterval(time.Hour, time.Hour, time.Hour),
+		maxItemsPerPush: 100,
+	}
+
+	input <- &TransmitChunk{HistoryLines: []string{"line"}}
+	input <- &collectorExitUpdate{exitCode: 1}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(input)
+	}()
+
+	start := time.Now()
+	data, ok := collector.CollectAndDump(make(FileStreamOffsetMap))
+
+	assert.Less(t, time.Since(start), time.Minute)
+	assert.True(t, ok)
+	assert.True(t, collector.isDone)
+	assert.True(t, *data.Complete)
+	assert.Equal(t, []string{"line"}, data.Files[HistoryFileName].Content)
+}
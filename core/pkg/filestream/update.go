@@ -20,6 +20,9 @@ type UpdateContext struct {
 	Settings *service.Settings
 	ClientID string
 
+	// MaxRequestBytes is the most bytes in a request's body.
+	MaxRequestBytes int
+
 	Logger  *observability.CoreLogger
 	Printer *observability.Printer
 }
@@ -0,0 +1,29 @@
+package filestream
+
+// FlushUpdate sends the data buffered so far right away, rather than
+// waiting to batch it with more.
+//
+// The request is sent even if there's no data, so that it's acknowledged.
+type FlushUpdate struct {
+	// OnAcked, if set, is called once the backend acknowledges the data,
+	// with the number of lines of each file sent by then, by file name.
+	//
+	// It isn't called if the request isn't sent.
+	OnAcked func(lines map[string]int)
+}
+
+func (u *FlushUpdate) Apply(ctx UpdateContext) error {
+	ctx.ModifyRequest(&collectorFlushUpdate{onAcked: u.OnAcked})
+	return nil
+}
+
+type collectorFlushUpdate struct {
+	onAcked func(map[string]int)
+}
+
+func (u *collectorFlushUpdate) Apply(state *CollectorState) {
+	state.Flush = true
+	if u.onAcked != nil {
+		state.OnFlushed = append(state.OnFlushed, u.onAcked)
+	}
+}
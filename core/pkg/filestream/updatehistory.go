@@ -6,12 +6,19 @@ import (
 	"time"
 
 	"github.com/wandb/wandb/core/internal/runhistory"
+	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
 )
 
 // HistoryUpdate contains run metrics from `run.log()`.
 type HistoryUpdate struct {
 	Record *service.HistoryRecord
+
+	// OnAcked, if set, is called once the backend acknowledges the request
+	// with the row.
+	//
+	// It isn't called if the row isn't sent.
+	OnAcked func()
 }
 
 func (u *HistoryUpdate) Apply(ctx UpdateContext) error {
@@ -54,11 +61,17 @@ func (u *HistoryUpdate) Apply(ctx UpdateContext) error {
 		)
 		ctx.Printer.
 			AtMostEvery(time.Minute).
-			Write("Skipped uploading run.log() data that exceeded size limit.")
+			Emit(observability.UserMessage{
+				Level:    observability.MessageWarning,
+				Category: "data-skipped",
+				Text:     "Skipped uploading run.log() data that exceeded size limit.",
+			})
 	} else {
-		ctx.ModifyRequest(&TransmitChunk{
-			HistoryLines: []string{string(line)},
-		})
+		chunk := &TransmitChunk{HistoryLines: []string{string(line)}}
+		if u.OnAcked != nil {
+			chunk.OnAcked = []func(){u.OnAcked}
+		}
+		ctx.ModifyRequest(chunk)
 	}
 
 	return nil
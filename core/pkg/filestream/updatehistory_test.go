@@ -0,0 +1,100 @@
+package filestream_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// historyLines returns the history lines that the update sends.
+func historyLines(t *testing.T, update *filestream.HistoryUpdate) []string {
+	var lines []string
+	err := update.Apply(filestream.UpdateContext{
+		ModifyRequest: func(state filestream.CollectorStateUpdate) {
+			if chunk, ok := state.(*filestream.TransmitChunk); ok {
+				lines = append(lines, chunk.HistoryLines...)
+			}
+		},
+		Settings: &service.Settings{},
+		Logger:   observability.NewNoOpLogger(),
+		Printer:  observability.NewPrinter(),
+	})
+	require.NoError(t, err)
+	return lines
+}
+
+func TestHistoryUpdate_ValueTypes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		valueJSON string
+		expected  string
+	}{
+		{"int", `7`, `{"x":7}`},
+		{"float", `0.25`, `{"x":0.25}`},
+		{"nan", `NaN`, `{"x":NaN}`},
+		{"string", `"hello"`, `{"x":"hello"}`},
+		{"escaped string", `"line\n\"quoted\" é"`, `{"x":"line\n\"quoted\" é"}`},
+		{"empty string", `""`, `{"x":""}`},
+		{"true", `true`, `{"x":true}`},
+		{"false", `false`, `{"x":false}`},
+		{"null", `null`, `{"x":null}`},
+		{"list", `[1, "a", false]`, `{"x":[1,"a",false]}`},
+		{
+			"nested dict",
+			`{"b": {"d": 1, "c": "s"}, "a": [{"y": 2, "x": 1}]}`,
+			`{"x":{"a":[{"x":1,"y":2}],"b":{"c":"s","d":1}}}`,
+		},
+		{
+			"histogram",
+			`{"_type": "histogram", "values": [3, 0, 4], "bins": [0, 0.5, 1, 1.5]}`,
+			`{"x":{"_type":"histogram","bins":[0,0.5,1,1.5],"values":[3,0,4]}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := historyLines(t, &filestream.HistoryUpdate{
+				Record: &service.HistoryRecord{
+					Item: []*service.HistoryItem{
+						{Key: "x", ValueJson: tc.valueJSON},
+					},
+				},
+			})
+
+			assert.Equal(t, []string{tc.expected}, lines)
+		})
+	}
+}
+
+func TestHistoryUpdate_NestedItemsAndMixedRow(t *testing.T) {
+	lines := historyLines(t, &filestream.HistoryUpdate{
+		Record: &service.HistoryRecord{
+			Item: []*service.HistoryItem{
+				{Key: "loss", ValueJson: `0.5`},
+				{Key: "label", ValueJson: `"cat"`},
+				{Key: "done", ValueJson: `true`},
+				{NestedKey: []string{"eval", "acc"}, ValueJson: `0.9`},
+				{NestedKey: []string{"eval", "split"}, ValueJson: `"val"`},
+				{
+					NestedKey: []string{"eval", "weights"},
+					ValueJson: `{"_type": "histogram", "bins": [0, 1], "values": [2]}`,
+				},
+				{Key: "_step", ValueJson: `3`},
+			},
+		},
+	})
+
+	assert.Equal(t,
+		[]string{
+			`{"_step":3,"done":true,` +
+				`"eval":{"acc":0.9,"split":"val",` +
+				`"weights":{"_type":"histogram","bins":[0,1],"values":[2]}},` +
+				`"label":"cat","loss":0.5}`,
+		},
+		lines)
+}
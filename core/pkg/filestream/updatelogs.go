@@ -14,11 +14,20 @@ const rfc3339Micro = "2006-01-02T15:04:05.000000Z07:00"
 // LogsUpdate is new lines in a run's console output.
 type LogsUpdate struct {
 	Record *service.OutputRawRecord
+
+	// Timestamped is whether the line is timed by the record's timestamp,
+	// rather than by when it's streamed.
+	Timestamped bool
 }
 
 func (u *LogsUpdate) Apply(ctx UpdateContext) error {
+	now := time.Now()
+	if u.Timestamped && u.Record.Timestamp != nil {
+		now = u.Record.Timestamp.AsTime()
+	}
+
 	// generate compatible timestamp to python iso-format (microseconds without Z)
-	t := strings.TrimSuffix(time.Now().UTC().Format(rfc3339Micro), "Z")
+	t := strings.TrimSuffix(now.UTC().Format(rfc3339Micro), "Z")
 
 	var line string
 	switch u.Record.OutputType {
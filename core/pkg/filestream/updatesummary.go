@@ -5,12 +5,43 @@ import (
 	"time"
 
 	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
 )
 
 // SummaryUpdate contains a run's most recent summary.
 type SummaryUpdate struct {
 	Record *service.SummaryRecord
+
+	// Part is whether the record is one part of a summary that's split
+	// across several lines, all of which are sent rather than only the
+	// latest.
+	Part bool
+
+	// OnAcked, if set, is called once the backend acknowledges the line.
+	//
+	// It isn't called if the line is skipped.
+	OnAcked func()
+}
+
+// SummaryLineBytes returns the most bytes that a line of the summary file
+// may take up in requests of at most maxRequestBytes, or in requests of the
+// default size if it's 0.
+//
+// Lines take up their EncodedLineBytes.
+func SummaryLineBytes(maxRequestBytes int) int {
+	if maxRequestBytes <= 0 {
+		return maxFileLineBytes
+	}
+	return max(1, min(maxFileLineBytes, maxRequestBytes-summaryLineReserveBytes))
+}
+
+// EncodedLineBytes returns an upper bound on the bytes that a line, or a
+// piece of one, takes up in a request, where it's escaped as a JSON string.
+//
+// The bytes of a line are the sum of those of its pieces.
+func EncodedLineBytes(line string) int {
+	return encodedStringLen(line) - len(`""`)
 }
 
 func (u *SummaryUpdate) Apply(ctx UpdateContext) error {
@@ -32,20 +63,33 @@ func (u *SummaryUpdate) Apply(ctx UpdateContext) error {
 		)
 	}
 
-	if len(line) > maxFileLineBytes {
+	// A line longer than a request would be truncated.
+	lineBytes := EncodedLineBytes(string(line))
+	if maxLineBytes := SummaryLineBytes(ctx.MaxRequestBytes); lineBytes > maxLineBytes {
 		// Failing to upload the summary is non-blocking.
 		ctx.Logger.CaptureWarn(
 			"filestream: run summary line too long, skipping",
-			"len", len(line),
-			"max", maxFileLineBytes,
+			"len", lineBytes,
+			"max", maxLineBytes,
 		)
 		ctx.Printer.
 			AtMostEvery(time.Minute).
-			Write("Skipped uploading summary data that exceeded size limit.")
+			Emit(observability.UserMessage{
+				Level:    observability.MessageWarning,
+				Category: "data-skipped",
+				Text:     "Skipped uploading summary data that exceeded size limit.",
+			})
 	} else {
-		ctx.ModifyRequest(&TransmitChunk{
-			LatestSummary: string(line),
-		})
+		chunk := &TransmitChunk{}
+		if u.Part {
+			chunk.SummaryParts = []string{string(line)}
+		} else {
+			chunk.LatestSummary = string(line)
+		}
+		if u.OnAcked != nil {
+			chunk.OnAcked = []func(){u.OnAcked}
+		}
+		ctx.ModifyRequest(chunk)
 	}
 
 	return nil
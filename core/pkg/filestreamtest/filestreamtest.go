@@ -39,6 +39,10 @@ func (fs *FakeFileStream) Start(
 
 func (fs *FakeFileStream) Close() {}
 
+func (fs *FakeFileStream) Backlog() filestream.Backlog {
+	return filestream.Backlog{}
+}
+
 func (fs *FakeFileStream) StreamUpdate(update filestream.Update) {
 	fs.Lock()
 	defer fs.Unlock()
@@ -241,10 +241,28 @@ func (j *JobBuilder) handleMetadataFile() (*RunMetadata, error) {
 	if err != nil {
 		return nil, err
 	}
+	j.applyOverrides(&runMetadata)
 
 	return &runMetadata, nil
 }
 
+// applyOverrides replaces the program, code path and root directory in the
+// run's metadata with the ones set in the settings, if any.
+//
+// Runs started through a wrapper probe the wrapper instead of the program.
+func (j *JobBuilder) applyOverrides(metadata *RunMetadata) {
+	if program := j.settings.GetXProgramOverride().GetValue(); program != "" {
+		metadata.Program = &program
+	}
+	if codePath := j.settings.GetXCodePathOverride().GetValue(); codePath != "" {
+		metadata.CodePath = &codePath
+		metadata.CodePathLocal = &codePath
+	}
+	if root := j.settings.GetXRootDirOverride().GetValue(); root != "" {
+		metadata.Root = &root
+	}
+}
+
 func (j *JobBuilder) getProgramRelpath(metadata RunMetadata, sourceType SourceType) *string {
 	if j.isNotebookRun {
 		if metadata.Program == nil {
@@ -529,6 +547,13 @@ func (j *JobBuilder) Build(
 	}
 
 	metadata, err := j.handleMetadataFile()
+	if os.IsNotExist(err) {
+		j.logIfVerbose(
+			"No wandb-metadata.json found, not creating job artifact. See https://docs.wandb.ai/guides/launch/create-job",
+			Warn,
+		)
+		return nil, nil
+	}
 	if err != nil {
 		j.logger.Debug("jobBuilder: error handling metadata file", err)
 		return nil, err
@@ -552,7 +577,10 @@ func (j *JobBuilder) Build(
 	} else {
 		sourceType, err = j.GetSourceType(*metadata)
 		if err != nil {
-			return nil, err
+			// the missing ingredients for the requested source type are
+			// already logged, and aren't an error for the run
+			j.logger.Debug("jobBuilder: not creating job artifact", "error", err)
+			return nil, nil
 		}
 		if sourceType == nil {
 			j.logger.Debug("jobBuilder: unable to determine source type")
@@ -381,8 +381,7 @@ func TestJobBuilderDisabledOrMissingFiles(t *testing.T) {
 		jobBuilder := NewJobBuilder(settings, observability.NewNoOpLogger(), true)
 		artifact, err := jobBuilder.Build(nil)
 		assert.Nil(t, artifact)
-		assert.NotNil(t, err)
-		assert.Contains(t, err.Error(), "wandb-metadata.json: no such file or directory")
+		assert.Nil(t, err)
 	})
 
 	t.Run("Missing python in metadata", func(t *testing.T) {
@@ -403,6 +402,29 @@ func TestJobBuilderDisabledOrMissingFiles(t *testing.T) {
 		assert.Nil(t, artifact)
 		assert.Nil(t, err)
 	})
+
+	t.Run("Missing ingredients for source type", func(t *testing.T) {
+		metadata := map[string]interface{}{
+			"python":   "3.11.2",
+			"codePath": "blah/test.py",
+		}
+		fdir := filepath.Join(os.TempDir(), "test")
+		err := os.MkdirAll(fdir, 0777)
+		assert.Nil(t, err)
+		writeRequirements(t, fdir)
+		writeWandbMetadata(t, fdir, metadata)
+
+		defer os.RemoveAll(fdir)
+
+		settings := &service.Settings{
+			FilesDir:  toWrapperPb(fdir).(*wrapperspb.StringValue),
+			JobSource: toWrapperPb("image").(*wrapperspb.StringValue),
+		}
+		jobBuilder := NewJobBuilder(settings, observability.NewNoOpLogger(), true)
+		artifact, err := jobBuilder.Build(nil)
+		assert.Nil(t, artifact)
+		assert.Nil(t, err)
+	})
 }
 
 func TestJobBuilderFromPartial(t *testing.T) {
@@ -1125,3 +1147,46 @@ func TestConfigFileParameters(t *testing.T) {
 		},
 	}, files)
 }
+
+func TestJobBuilderOverrides(t *testing.T) {
+	fdir := t.TempDir()
+	writeRequirements(t, fdir)
+	writeDiffFile(t, fdir)
+	writeWandbMetadata(t, fdir, map[string]interface{}{
+		"python": "3.11.2",
+		"git": map[string]interface{}{
+			"commit": "1234567890",
+			"remote": "example.com",
+		},
+		"program":  "/usr/bin/entrypoint.sh",
+		"codePath": "entrypoint.sh",
+		"root":     "/",
+	})
+	settings := &service.Settings{
+		Project:           toWrapperPb("testProject").(*wrapperspb.StringValue),
+		Entity:            toWrapperPb("testEntity").(*wrapperspb.StringValue),
+		RunId:             toWrapperPb("testRunId").(*wrapperspb.StringValue),
+		FilesDir:          toWrapperPb(fdir).(*wrapperspb.StringValue),
+		XCodePathOverride: toWrapperPb("src/train.py").(*wrapperspb.StringValue),
+	}
+	jobBuilder := NewJobBuilder(settings, observability.NewNoOpLogger(), true)
+
+	artifact, err := jobBuilder.Build(nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "job-example.com_src_train.py", artifact.Name)
+	for _, content := range artifact.Manifest.Contents {
+		if content.Path != "wandb-job.json" {
+			continue
+		}
+		jobFile, err := os.ReadFile(content.LocalPath)
+		assert.Nil(t, err)
+		var data struct {
+			Source struct {
+				Entrypoint []string `json:"entrypoint"`
+			} `json:"source"`
+		}
+		assert.Nil(t, json.Unmarshal(jobFile, &data))
+		assert.Equal(t, []string{"python3.11", "src/train.py"}, data.Source.Entrypoint)
+	}
+}
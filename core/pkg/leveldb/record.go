@@ -140,19 +140,35 @@ type Reader struct {
 	err error
 	// buf is the buffer.
 	buf [blockSize]byte
+	// blockOffset is the offset in r of the block held in buf.
+	blockOffset int64
+	// nextBlockOffset is the offset in r of the block after it.
+	nextBlockOffset int64
+	// lastRecordOffset is the offset in r of the record most recently
+	// returned by Next, or -1 if there is no such record.
+	lastRecordOffset int64
 	// CRC function
 	crc func([]byte) uint32
 }
 
 // NewReader returns a new reader.
 func NewReaderExt(r io.Reader, algo CRCAlgo) *Reader {
+	var o int64
+	if s, ok := r.(io.Seeker); ok {
+		var err error
+		if o, err = s.Seek(0, io.SeekCurrent); err != nil {
+			o = 0
+		}
+	}
 	crc := CRCCustom
 	if algo == CRCAlgoIEEE {
 		crc = CRCStandard
 	}
 	return &Reader{
-		r:   r,
-		crc: crc,
+		r:                r,
+		nextBlockOffset:  o,
+		lastRecordOffset: -1,
+		crc:              crc,
 	}
 }
 
@@ -190,6 +206,11 @@ func (r *Reader) nextChunk(wantFirst bool) error {
 					r.Recover()
 					continue
 				}
+				if r.n < blockSize {
+					// The chunk extends past the end of the data, as when
+					// the writer stopped in the middle of a record.
+					return io.ErrUnexpectedEOF
+				}
 				return errors.New("leveldb/record: invalid chunk (length overflows block)")
 			}
 			if checksum != r.crc(r.buf[r.i-1:r.j]) {
@@ -219,6 +240,8 @@ func (r *Reader) nextChunk(wantFirst bool) error {
 			return err
 		}
 		r.i, r.j, r.n = 0, 0, n
+		r.blockOffset = r.nextBlockOffset
+		r.nextBlockOffset += int64(n)
 	}
 }
 
@@ -236,9 +259,25 @@ func (r *Reader) Next() (io.Reader, error) {
 		return nil, r.err
 	}
 	r.started = true
+	r.lastRecordOffset = r.blockOffset + int64(r.i-headerSize)
 	return singleReader{r, r.seq}, nil
 }
 
+// LastRecordOffset returns the offset in the underlying io.Reader of the
+// record most recently returned by Next. It is the offset of the first chunk
+// header, suitable to pass to SeekRecord.
+//
+// Like Writer.LastRecordOffset, the offset is absolute if the io.Reader also
+// implements io.Seeker, and relative to where reading started otherwise.
+//
+// If Next hasn't returned a record, LastRecordOffset returns ErrNoLastRecord.
+func (r *Reader) LastRecordOffset() (int64, error) {
+	if r.lastRecordOffset < 0 {
+		return 0, ErrNoLastRecord
+	}
+	return r.lastRecordOffset, nil
+}
+
 // Recover clears any errors read so far, so that calling Next will start
 // reading from the next good 32KiB block. If there are no such blocks, Next
 // will return io.EOF. Recover also marks the current reader, the one most
@@ -290,6 +329,7 @@ func (r *Reader) SeekRecord(offset int64) error {
 	if _, r.err = s.Seek(offset&^blockSizeMask, io.SeekStart); r.err != nil {
 		return r.err
 	}
+	r.nextBlockOffset = offset &^ blockSizeMask
 
 	// Clear the state of the internal reader.
 	r.i, r.j, r.n = 0, 0, 0
@@ -323,6 +363,10 @@ func (x singleReader) Read(p []byte) (int, error) {
 			return 0, io.EOF
 		}
 		if r.err = r.nextChunk(false); r.err != nil {
+			if r.err == io.EOF {
+				// The record's last chunk is missing.
+				r.err = io.ErrUnexpectedEOF
+			}
 			return 0, r.err
 		}
 	}
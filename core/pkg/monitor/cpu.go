@@ -51,15 +51,6 @@ func (c *CPU) SampleMetrics() {
 			)
 		}
 	}
-	// number of threads used by process
-	procThreads, err := proc.NumThreads()
-	if err == nil {
-		c.metrics["proc.cpu.threads"] = append(
-			c.metrics["proc.cpu.threads"],
-			float64(procThreads),
-		)
-	}
-
 	// total system CPU usage in percent
 	utilization, err := cpu.Percent(0, true)
 	if err == nil {
@@ -80,10 +71,6 @@ func (c *CPU) AggregateMetrics() map[string]float64 {
 	aggregates := make(map[string]float64)
 	for metric, samples := range c.metrics {
 		if len(samples) > 0 {
-			if metric == "proc.cpu.threads" {
-				aggregates[metric] = samples[len(samples)-1]
-				continue
-			}
 			aggregates[metric] = Average(samples)
 		}
 	}
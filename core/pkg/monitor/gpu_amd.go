@@ -23,11 +23,12 @@ const rocmSMICmd string = "/usr/bin/rocm-smi"
 type StatsKeys string
 
 const (
-	GPU             StatsKeys = "gpu"
-	MemoryAllocated StatsKeys = "memoryAllocated"
-	Temp            StatsKeys = "temp"
-	PowerWatts      StatsKeys = "powerWatts"
-	PowerPercent    StatsKeys = "powerPercent"
+	GPU                  StatsKeys = "gpu"
+	MemoryAllocated      StatsKeys = "memoryAllocated"
+	MemoryAllocatedBytes StatsKeys = "memoryAllocatedBytes"
+	Temp                 StatsKeys = "temp"
+	PowerWatts           StatsKeys = "powerWatts"
+	PowerPercent         StatsKeys = "powerPercent"
 )
 
 type Stats map[StatsKeys]float64
@@ -39,6 +40,7 @@ type GPUAMD struct {
 	settings            *service.Settings
 	metrics             map[string][]float64
 	GetROCMSMIStatsFunc func() (InfoDict, error)
+	GetSysfsStatsFunc   func() (InfoDict, error)
 	mutex               sync.RWMutex
 }
 
@@ -49,6 +51,9 @@ func NewGPUAMD(settings *service.Settings) *GPUAMD {
 		metrics:  make(map[string][]float64),
 		// this is done this way to be able to mock the function in tests
 		GetROCMSMIStatsFunc: getROCMSMIStats,
+		GetSysfsStatsFunc: func() (InfoDict, error) {
+			return GetSysfsStats(DRMClassPath)
+		},
 	}
 	return g
 }
@@ -67,9 +72,10 @@ func GetRocmSMICmd() (string, error) {
 }
 
 func (g *GPUAMD) IsAvailable() bool {
-	_, err := GetRocmSMICmd()
-	if err != nil {
-		return false
+	// fall back to the amdgpu sysfs interface if rocm-smi is not installed
+	if _, err := GetRocmSMICmd(); err != nil {
+		stats, err := g.GetSysfsStatsFunc()
+		return err == nil && len(stats) > 0
 	}
 
 	isDriverInitialized := false
@@ -89,9 +95,22 @@ func (g *GPUAMD) IsAvailable() bool {
 	return isDriverInitialized && canReadRocmSmi
 }
 
+// getStats returns the stats reported by rocm-smi, or the stats read from
+// sysfs if rocm-smi is unavailable.
+func (g *GPUAMD) getStats() (InfoDict, error) {
+	stats, err := g.GetROCMSMIStatsFunc()
+	if err == nil {
+		return stats, nil
+	}
+	if sysfsStats, sysfsErr := g.GetSysfsStatsFunc(); sysfsErr == nil {
+		return sysfsStats, nil
+	}
+	return nil, err
+}
+
 func (g *GPUAMD) getCards() map[int]Stats {
 
-	rawStats, err := g.GetROCMSMIStatsFunc()
+	rawStats, err := g.getStats()
 	if err != nil {
 		log.Printf("Error getting ROCm SMI stats: %v", err)
 		return nil
@@ -122,7 +141,7 @@ func (g *GPUAMD) getCards() map[int]Stats {
 //gocyclo:ignore
 func (g *GPUAMD) Probe() *service.MetadataRequest {
 
-	rawStats, err := g.GetROCMSMIStatsFunc()
+	rawStats, err := g.getStats()
 	if err != nil {
 		log.Printf("Error getting ROCm SMI stats: %v", err)
 		return nil
@@ -257,6 +276,22 @@ func (g *GPUAMD) ParseStats(stats map[string]interface{}) Stats {
 			}
 			return nil
 		},
+		"Temperature (Sensor edge) (C)": func(s string) *Stats {
+			// consumer cards don't report the memory temperature
+			if _, ok := stats["Temperature (Sensor memory) (C)"]; ok {
+				return nil
+			}
+			if f, err := parseFloat(s); err == nil {
+				return &Stats{Temp: f}
+			}
+			return nil
+		},
+		"VRAM Total Used Memory (B)": func(s string) *Stats {
+			if f, err := parseFloat(s); err == nil {
+				return &Stats{MemoryAllocatedBytes: f}
+			}
+			return nil
+		},
 		"Average Graphics Package Power (W)": func(s string) *Stats {
 			maxPowerWatts, ok := stats["Max Graphics Package Power (W)"].(string)
 			if !ok {
@@ -0,0 +1,149 @@
+//go:build linux && !libwandb_core
+
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DRMClassPath is the default location of the DRM device class in sysfs.
+const DRMClassPath string = "/sys/class/drm"
+
+// amdVendorID is the PCI vendor ID of AMD GPUs.
+const amdVendorID string = "0x1002"
+
+// GetSysfsStats reads AMD GPU stats from the amdgpu driver's sysfs interface.
+//
+// This is used when rocm-smi is not installed. The stats are returned in
+// the same layout as the output of `rocm-smi -a --json`, keyed by "cardN",
+// so that they can be parsed the same way. Only cards with the AMD PCI
+// vendor ID are included, so other GPUs on mixed machines are ignored.
+func GetSysfsStats(drmPath string) (InfoDict, error) {
+	entries, err := os.ReadDir(drmPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(InfoDict)
+	for _, entry := range entries {
+		name := entry.Name()
+		// skip connectors such as card0-DP-1 and render nodes
+		cardID, err := strconv.Atoi(strings.TrimPrefix(name, "card"))
+		if !strings.HasPrefix(name, "card") || err != nil {
+			continue
+		}
+
+		devicePath := filepath.Join(drmPath, name, "device")
+		if vendor, err := readSysfsString(devicePath, "vendor"); err != nil || vendor != amdVendorID {
+			continue
+		}
+
+		stats[fmt.Sprintf("card%d", cardID)] = getSysfsCardStats(devicePath)
+	}
+
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("no AMD GPUs found in %s", drmPath)
+	}
+	return stats, nil
+}
+
+// getSysfsCardStats reads the stats of a single card from its device directory.
+//
+// Files that are missing or unreadable are skipped, since the set of files
+// exposed by the driver depends on the kernel version and the GPU model.
+func getSysfsCardStats(devicePath string) map[string]interface{} {
+	cardStats := map[string]interface{}{
+		"Card vendor": "Advanced Micro Devices, Inc. [AMD/ATI]",
+	}
+
+	setString := func(key string, file string) {
+		if value, err := readSysfsString(devicePath, file); err == nil {
+			cardStats[key] = value
+		}
+	}
+	setString("GPU ID", "device")
+	setString("Unique ID", "unique_id")
+	setString("VBIOS version", "vbios_version")
+	setString("Performance Level", "power_dpm_force_performance_level")
+	setString("Card series", "product_name")
+	setString("Card model", "subsystem_device")
+	setString("Card SKU", "product_number")
+	setString("GPU use (%)", "gpu_busy_percent")
+
+	vramUsed, errUsed := readSysfsInt(devicePath, "mem_info_vram_used")
+	vramTotal, errTotal := readSysfsInt(devicePath, "mem_info_vram_total")
+	if errUsed == nil {
+		cardStats["VRAM Total Used Memory (B)"] = strconv.FormatInt(vramUsed, 10)
+	}
+	if errTotal == nil {
+		cardStats["VRAM Total Memory (B)"] = strconv.FormatInt(vramTotal, 10)
+	}
+	if errUsed == nil && errTotal == nil && vramTotal > 0 {
+		percent := float64(vramUsed) / float64(vramTotal) * 100
+		cardStats["GPU memory use (%)"] = strconv.FormatFloat(percent, 'f', -1, 64)
+	}
+
+	hwmonPath := findHwmonPath(devicePath)
+	if hwmonPath == "" {
+		return cardStats
+	}
+
+	// temperatures are reported in millidegrees Celsius
+	for i := 1; ; i++ {
+		input := fmt.Sprintf("temp%d_input", i)
+		temp, err := readSysfsInt(hwmonPath, input)
+		if err != nil {
+			break
+		}
+		label, err := readSysfsString(hwmonPath, fmt.Sprintf("temp%d_label", i))
+		if err != nil {
+			label = "edge"
+		}
+		key := fmt.Sprintf("Temperature (Sensor %s) (C)", label)
+		if label == "mem" {
+			key = "Temperature (Sensor memory) (C)"
+		}
+		cardStats[key] = strconv.FormatFloat(float64(temp)/1000, 'f', -1, 64)
+	}
+
+	// power is reported in microwatts
+	if power, err := readSysfsInt(hwmonPath, "power1_average"); err == nil {
+		cardStats["Average Graphics Package Power (W)"] = strconv.FormatFloat(float64(power)/1e6, 'f', -1, 64)
+	}
+	if powerCap, err := readSysfsInt(hwmonPath, "power1_cap"); err == nil {
+		cardStats["Max Graphics Package Power (W)"] = strconv.FormatFloat(float64(powerCap)/1e6, 'f', -1, 64)
+	}
+
+	return cardStats
+}
+
+// findHwmonPath returns the first hwmon directory of a device, if any.
+func findHwmonPath(devicePath string) string {
+	matches, err := filepath.Glob(filepath.Join(devicePath, "hwmon", "hwmon*"))
+	if err != nil || len(matches) == 0 {
+		return ""
+	}
+	sort.Strings(matches)
+	return matches[0]
+}
+
+func readSysfsString(dir string, file string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func readSysfsInt(dir string, file string) (int64, error) {
+	value, err := readSysfsString(dir, file)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -3,6 +3,9 @@
 package monitor_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/segmentio/encoding/json"
@@ -78,3 +81,92 @@ func TestGPUAMD_Probe(t *testing.T) {
 	assert.Equal(t, info.GpuCount, uint32(2))
 	assert.Len(t, info.GpuAmd, 2)
 }
+
+// makeSysfs creates a fake /sys/class/drm tree with two AMD cards
+// and one NVIDIA card.
+func makeSysfs(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"card0/device/vendor":                      "0x1002\n",
+		"card0/device/device":                      "0x740c\n",
+		"card0/device/unique_id":                   "719d230578348e8c\n",
+		"card0/device/product_name":                "AMD Instinct MI250X\n",
+		"card0/device/gpu_busy_percent":            "42\n",
+		"card0/device/mem_info_vram_used":          "17179869184\n",
+		"card0/device/mem_info_vram_total":         "68719476736\n",
+		"card0/device/hwmon/hwmon3/temp1_input":    "35000\n",
+		"card0/device/hwmon/hwmon3/temp1_label":    "edge\n",
+		"card0/device/hwmon/hwmon3/temp2_input":    "43000\n",
+		"card0/device/hwmon/hwmon3/temp2_label":    "mem\n",
+		"card0/device/hwmon/hwmon3/power1_average": "89000000\n",
+		"card0/device/hwmon/hwmon3/power1_cap":     "560000000\n",
+		"card0-DP-1/status":                        "disconnected\n",
+		"card1/device/vendor":                      "0x10de\n",
+		"card2/device/vendor":                      "0x1002\n",
+		"card2/device/gpu_busy_percent":            "7\n",
+		"card2/device/hwmon/hwmon5/temp1_input":    "51500\n",
+		"renderD128/device/vendor":                 "0x1002\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func TestGetSysfsStats(t *testing.T) {
+	stats, err := monitor.GetSysfsStats(makeSysfs(t))
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+	assert.Contains(t, stats, "card0")
+	assert.Contains(t, stats, "card2")
+
+	card0 := stats["card0"].(map[string]interface{})
+	assert.Equal(t, "0x740c", card0["GPU ID"])
+	assert.Equal(t, "AMD Instinct MI250X", card0["Card series"])
+	assert.Equal(t, "35", card0["Temperature (Sensor edge) (C)"])
+	assert.Equal(t, "43", card0["Temperature (Sensor memory) (C)"])
+	assert.Equal(t, "89", card0["Average Graphics Package Power (W)"])
+	assert.Equal(t, "560", card0["Max Graphics Package Power (W)"])
+}
+
+func TestGetSysfsStats_NoAMDGPUs(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "card0", "device", "vendor")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte("0x10de\n"), 0644))
+
+	_, err := monitor.GetSysfsStats(root)
+	assert.Error(t, err)
+
+	_, err = monitor.GetSysfsStats(filepath.Join(root, "missing"))
+	assert.Error(t, err)
+}
+
+func TestGPUAMD_SampleStatsSysfs(t *testing.T) {
+	root := makeSysfs(t)
+	gpu := monitor.NewGPUAMD(nil)
+	gpu.GetROCMSMIStatsFunc = func() (monitor.InfoDict, error) {
+		return nil, errors.New("rocm-smi not found")
+	}
+	gpu.GetSysfsStatsFunc = func() (monitor.InfoDict, error) {
+		return monitor.GetSysfsStats(root)
+	}
+
+	gpu.SampleMetrics()
+	aggregateMetrics := gpu.AggregateMetrics()
+	assert.Equal(t, 42.0, aggregateMetrics["gpu.0.gpu"])
+	assert.Equal(t, 25.0, aggregateMetrics["gpu.0.memoryAllocated"])
+	assert.Equal(t, 17179869184.0, aggregateMetrics["gpu.0.memoryAllocatedBytes"])
+	assert.Equal(t, 43.0, aggregateMetrics["gpu.0.temp"])
+	assert.Equal(t, 89.0, aggregateMetrics["gpu.0.powerWatts"])
+	assert.InDelta(t, 15.89, aggregateMetrics["gpu.0.powerPercent"], 0.01)
+	assert.Equal(t, 7.0, aggregateMetrics["gpu.2.gpu"])
+	assert.Equal(t, 51.5, aggregateMetrics["gpu.2.temp"])
+	assert.NotContains(t, aggregateMetrics, "gpu.1.gpu")
+
+	info := gpu.Probe()
+	assert.Equal(t, uint32(2), info.GpuCount)
+	assert.Len(t, info.GpuAmd, 2)
+}
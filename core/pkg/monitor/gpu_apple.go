@@ -1,16 +1,28 @@
+//go:build darwin && arm64
+
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/segmentio/encoding/json"
+	"golang.org/x/sys/unix"
+
 	"github.com/wandb/wandb/core/pkg/service"
 )
 
+// appleStatsTimeout is the maximum time to wait for apple_gpu_stats.
+//
+// The helper queries IOKit and the SMC, which can occasionally stall;
+// if it does, the sample is skipped rather than blocking the monitor.
+const appleStatsTimeout = 2 * time.Second
+
 func getExecPath() (string, error) {
 	ex, err := os.Executable()
 	if err != nil {
@@ -50,7 +62,10 @@ func NewGPUApple(settings *service.Settings) *GPUApple {
 }
 
 func (g *GPUApple) parseStats() (map[string]interface{}, error) {
-	rawStats, err := exec.Command(g.exPath).Output()
+	ctx, cancel := context.WithTimeout(context.Background(), appleStatsTimeout)
+	defer cancel()
+
+	rawStats, err := exec.CommandContext(ctx, g.exPath).Output()
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +92,9 @@ func (g *GPUApple) SampleMetrics() {
 	//  such as render or tiler utilization
 
 	// GPU + Neural Engine Total Power (W)
-	if powerUsage, ok := stats["gpuPower"]; ok {
+	// the SMC keys read as 0 when they can't be accessed, e.g. without
+	// elevated permissions, so zero power readings are skipped
+	if powerUsage, ok := stats["gpuPower"]; ok && powerUsage.(float64) > 0 {
 		key := fmt.Sprintf("gpu.%d.powerWatts", 0)
 		g.metrics[key] = append(
 			g.metrics[key],
@@ -86,7 +103,7 @@ func (g *GPUApple) SampleMetrics() {
 	}
 
 	// System Power (W)
-	if systemPower, ok := stats["systemPower"]; ok {
+	if systemPower, ok := stats["systemPower"]; ok && systemPower.(float64) > 0 {
 		key := "system.powerWatts"
 		g.metrics[key] = append(
 			g.metrics[key],
@@ -94,6 +111,15 @@ func (g *GPUApple) SampleMetrics() {
 		)
 	}
 
+	// thermal pressure (0: nominal, 1: fair, 2: serious, 3: critical)
+	if thermalState, ok := stats["thermalState"]; ok {
+		key := "system.thermalPressure"
+		g.metrics[key] = append(
+			g.metrics[key],
+			thermalState.(float64),
+		)
+	}
+
 	// recover count
 	if recoveryCount, ok := stats["recoveryCount"]; ok {
 		key := "gpu.0.recoveryCount"
@@ -216,5 +242,17 @@ func (g *GPUApple) Probe() *service.MetadataRequest {
 		info.GpuApple.Cores = uint32(cores.(float64))
 	}
 
+	// static chip info, e.g. "Apple M2 Pro" with 8 performance
+	// and 4 efficiency cores
+	if model, err := unix.Sysctl("machdep.cpu.brand_string"); err == nil {
+		info.GpuApple.Model = model
+	}
+	if pCores, err := unix.SysctlUint32("hw.perflevel0.physicalcpu"); err == nil {
+		info.GpuApple.PerformanceCores = pCores
+	}
+	if eCores, err := unix.SysctlUint32("hw.perflevel1.physicalcpu"); err == nil {
+		info.GpuApple.EfficiencyCores = eCores
+	}
+
 	return &info
 }
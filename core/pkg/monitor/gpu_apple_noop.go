@@ -0,0 +1,37 @@
+//go:build !darwin || !arm64
+
+package monitor
+
+import (
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+type GPUApple struct {
+	name     string
+	settings *service.Settings
+}
+
+func NewGPUApple(settings *service.Settings) *GPUApple {
+	gpu := &GPUApple{
+		name:     "gpu",
+		settings: settings,
+	}
+
+	return gpu
+}
+
+func (g *GPUApple) Name() string { return g.name }
+
+func (g *GPUApple) SampleMetrics() {}
+
+func (g *GPUApple) AggregateMetrics() map[string]float64 {
+	return map[string]float64{}
+}
+
+func (g *GPUApple) ClearMetrics() {}
+
+func (g *GPUApple) IsAvailable() bool { return false }
+
+func (g *GPUApple) Probe() *service.MetadataRequest {
+	return nil
+}
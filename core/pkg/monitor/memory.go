@@ -6,7 +6,6 @@ import (
 	"github.com/wandb/wandb/core/pkg/service"
 
 	"github.com/shirou/gopsutil/v3/mem"
-	"github.com/shirou/gopsutil/v3/process"
 )
 
 type Memory struct {
@@ -44,23 +43,6 @@ func (m *Memory) SampleMetrics() {
 			float64(virtualMem.Available)/1024/1024,
 		)
 	}
-
-	// process-related metrics
-	proc := process.Process{Pid: m.settings.XStatsPid.GetValue()}
-	procMem, err := proc.MemoryInfo()
-	if err == nil {
-		// process memory usage in MB
-		m.metrics["proc.memory.rssMB"] = append(
-			m.metrics["proc.memory.rssMB"],
-			// this sometimes panics:
-			float64(procMem.RSS)/1024/1024,
-		)
-		// process memory usage in percent
-		m.metrics["proc.memory.percent"] = append(
-			m.metrics["proc.memory.percent"],
-			float64(procMem.RSS)/float64(virtualMem.Total)*100,
-		)
-	}
 }
 
 func (m *Memory) AggregateMetrics() map[string]float64 {
@@ -3,8 +3,6 @@ package monitor
 import (
 	"context"
 	"fmt"
-	"os"
-	"strings"
 	"sync"
 	"time"
 
@@ -163,14 +161,10 @@ func NewSystemMonitor(
 		buffer:   buffer,
 	}
 
-	// if stats are disabled, return early
-	if settings.XDisableStats.GetValue() {
-		return systemMonitor
-	}
-
 	assets := []Asset{
 		NewMemory(settings),
 		NewCPU(settings),
+		NewProcessTree(settings),
 		NewDisk(settings),
 		NewNetwork(settings),
 		NewGPUNvidia(settings),
@@ -203,40 +197,24 @@ func (sm *SystemMonitor) Do() {
 	}
 }
 
-func getSlurmEnvVars() map[string]string {
-	slurmVars := make(map[string]string)
-	for _, envVar := range os.Environ() {
-		keyValPair := strings.SplitN(envVar, "=", 2)
-		key := keyValPair[0]
-		value := keyValPair[1]
-
-		if strings.HasPrefix(key, "SLURM_") {
-			suffix := strings.ToLower(strings.TrimPrefix(key, "SLURM_"))
-			slurmVars[suffix] = value
-		}
-	}
-	return slurmVars
-}
-
 func (sm *SystemMonitor) Probe() *service.MetadataRequest {
 	if sm == nil {
 		return nil
 	}
 	systemInfo := service.MetadataRequest{}
+	// on machines with GPUs from several vendors, each asset reports
+	// its own GPU count, so they need to be added up rather than merged
+	gpuCount := uint32(0)
 	for _, asset := range sm.assets {
 		probeResponse := asset.Probe()
 		if probeResponse != nil {
 			proto.Merge(&systemInfo, probeResponse)
+			gpuCount += probeResponse.GpuCount
 		}
 	}
-	// capture SLURM-related environment variables
-	for k, v := range getSlurmEnvVars() {
-		if systemInfo.Slurm == nil {
-			systemInfo.Slurm = make(map[string]string)
-		}
-		systemInfo.Slurm[k] = v
+	if gpuCount > 0 {
+		systemInfo.GpuCount = gpuCount
 	}
-
 	return &systemInfo
 }
 
@@ -0,0 +1,186 @@
+package monitor
+
+import (
+	"sync"
+
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// procKey identifies a process across samples.
+//
+// The creation time is part of the key so that a PID that is reused by
+// a new process between samples is not mistaken for the old one.
+type procKey struct {
+	pid        int32
+	createTime int64
+}
+
+// ProcessTree monitors the process tree rooted at the user process.
+//
+// The metrics of the root process and all of its descendants are added
+// up, so that e.g. data loader workers are accounted for as well.
+type ProcessTree struct {
+	name     string
+	metrics  map[string][]float64
+	settings *service.Settings
+	mutex    sync.RWMutex
+
+	// procs are the processes seen in the previous sample.
+	//
+	// CPU usage is computed from the CPU time used since the previous
+	// sample, so the process handles must be kept between samples.
+	procs map[procKey]*process.Process
+}
+
+func NewProcessTree(settings *service.Settings) *ProcessTree {
+	return &ProcessTree{
+		name:     "proc",
+		metrics:  map[string][]float64{},
+		settings: settings,
+		procs:    map[procKey]*process.Process{},
+	}
+}
+
+func (p *ProcessTree) Name() string { return p.name }
+
+// descendants returns the PIDs of the process tree rooted at pid.
+//
+// The tree is built from a single snapshot of the process table, so each
+// process is visited exactly once even if processes start or exit while
+// the tree is being walked.
+func descendants(pid int32) []int32 {
+	procs, err := process.Processes()
+	if err != nil {
+		return []int32{pid}
+	}
+
+	children := make(map[int32][]int32)
+	for _, proc := range procs {
+		ppid, err := proc.Ppid()
+		if err != nil || ppid == proc.Pid {
+			continue
+		}
+		children[ppid] = append(children[ppid], proc.Pid)
+	}
+
+	pids := []int32{}
+	visited := map[int32]bool{}
+	queue := []int32{pid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		pids = append(pids, current)
+		queue = append(queue, children[current]...)
+	}
+	return pids
+}
+
+func (p *ProcessTree) SampleMetrics() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var cpuPercent, rss float64
+	var threads, fds int32
+	var hasCPU, hasRSS, hasThreads, hasFDs bool
+
+	procs := make(map[procKey]*process.Process)
+	for _, pid := range descendants(p.settings.XStatsPid.GetValue()) {
+		proc, err := process.NewProcess(pid)
+		if err != nil {
+			// the process exited since the process table was read
+			continue
+		}
+		createTime, err := proc.CreateTime()
+		if err != nil {
+			continue
+		}
+		key := procKey{pid: pid, createTime: createTime}
+		if prev, ok := p.procs[key]; ok {
+			proc = prev
+		}
+		procs[key] = proc
+
+		// errors are expected for processes we aren't allowed to inspect,
+		// e.g. children running in a container as a different user,
+		// so such processes are skipped
+		if percent, err := proc.Percent(0); err == nil {
+			cpuPercent += percent
+			hasCPU = true
+		}
+		if memInfo, err := proc.MemoryInfo(); err == nil {
+			rss += float64(memInfo.RSS)
+			hasRSS = true
+		}
+		if numThreads, err := proc.NumThreads(); err == nil {
+			threads += numThreads
+			hasThreads = true
+		}
+		if numFDs, err := proc.NumFDs(); err == nil {
+			fds += numFDs
+			hasFDs = true
+		}
+	}
+	// forget processes that have exited
+	p.procs = procs
+
+	if hasCPU {
+		p.metrics["proc.cpu.percent"] = append(p.metrics["proc.cpu.percent"], cpuPercent)
+	}
+	if hasRSS {
+		// process tree memory usage in MB
+		p.metrics["proc.memory.rssMB"] = append(p.metrics["proc.memory.rssMB"], rss/1024/1024)
+		// process tree memory usage in percent
+		if virtualMem, err := mem.VirtualMemory(); err == nil && virtualMem.Total > 0 {
+			p.metrics["proc.memory.percent"] = append(
+				p.metrics["proc.memory.percent"],
+				rss/float64(virtualMem.Total)*100,
+			)
+		}
+	}
+	if hasThreads {
+		p.metrics["proc.cpu.threads"] = append(p.metrics["proc.cpu.threads"], float64(threads))
+	}
+	if hasFDs {
+		p.metrics["proc.fds"] = append(p.metrics["proc.fds"], float64(fds))
+	}
+}
+
+func (p *ProcessTree) AggregateMetrics() map[string]float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	aggregates := make(map[string]float64)
+	for metric, samples := range p.metrics {
+		if len(samples) > 0 {
+			// counts are reported as of the latest sample
+			if metric == "proc.cpu.threads" || metric == "proc.fds" {
+				aggregates[metric] = samples[len(samples)-1]
+				continue
+			}
+			aggregates[metric] = Average(samples)
+		}
+	}
+	return aggregates
+}
+
+func (p *ProcessTree) ClearMetrics() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.metrics = map[string][]float64{}
+}
+
+func (p *ProcessTree) IsAvailable() bool {
+	return p.settings.XStatsPid.GetValue() > 0
+}
+
+func (p *ProcessTree) Probe() *service.MetadataRequest {
+	return nil
+}
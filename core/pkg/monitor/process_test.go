@@ -0,0 +1,56 @@
+package monitor_test
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/monitor"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestProcessTree_NotAvailableWithoutPid(t *testing.T) {
+	proc := monitor.NewProcessTree(&service.Settings{})
+	assert.False(t, proc.IsAvailable())
+}
+
+func TestProcessTree_IncludesChildren(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on windows")
+	}
+
+	// a shell with two single-threaded children
+	root := exec.Command("sh", "-c", "sleep 10 & sleep 10 & wait")
+	assert.NoError(t, root.Start())
+	defer func() {
+		_ = root.Process.Kill()
+		_ = root.Wait()
+	}()
+
+	settings := &service.Settings{
+		XStatsPid: &wrapperspb.Int32Value{Value: int32(root.Process.Pid)},
+	}
+	proc := monitor.NewProcessTree(settings)
+	assert.True(t, proc.IsAvailable())
+
+	assert.Eventually(t, func() bool {
+		proc.ClearMetrics()
+		proc.SampleMetrics()
+		return proc.AggregateMetrics()["proc.cpu.threads"] == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// sampling again reuses the tracked processes
+	proc.SampleMetrics()
+	metrics := proc.AggregateMetrics()
+	assert.Equal(t, 3.0, metrics["proc.cpu.threads"])
+	assert.Contains(t, metrics, "proc.cpu.percent")
+	assert.Greater(t, metrics["proc.memory.rssMB"], 0.0)
+	assert.Contains(t, metrics, "proc.memory.percent")
+	if runtime.GOOS == "linux" {
+		assert.Greater(t, metrics["proc.fds"], 0.0)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"io"
 	"log/slog"
+
+	"github.com/wandb/wandb/core/internal/redact"
 )
 
 type Tags map[string]string
 
 // NewTags creates a new Tags from a mix of slog.Attr and a string and its corresponding value.
-// It ignores incomplete pairs and other types.
+// It ignores incomplete pairs and other types, and redacts secrets.
 func NewTags(args ...any) Tags {
 	var done bool
 	tags := Tags{}
@@ -17,7 +19,7 @@ func NewTags(args ...any) Tags {
 	for len(args) > 0 && !done {
 		switch x := args[0].(type) {
 		case slog.Attr:
-			tags[x.Key] = x.Value.String()
+			tags[x.Key] = redact.SlogValue(x.Value).String()
 			args = args[1:]
 		case string:
 			if len(args) < 2 {
@@ -25,7 +27,7 @@ func NewTags(args ...any) Tags {
 				break
 			}
 			attr := slog.Any(x, args[1])
-			tags[attr.Key] = attr.Value.String()
+			tags[attr.Key] = redact.SlogValue(attr.Value).String()
 			args = args[2:]
 		default:
 			args = args[1:]
@@ -36,6 +38,36 @@ func NewTags(args ...any) Tags {
 
 const LevelFatal = slog.Level(12)
 
+// Keys of the attributes that log records share, so that they can be
+// queried the same way in every log.
+const (
+	StreamIDKey   = "stream_id"
+	ComponentKey  = "component"
+	RecordTypeKey = "record_type"
+	ErrorKey      = "error"
+)
+
+// Formats of the internal log.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// NewLogHandler returns a handler that writes records in the format.
+//
+// Each record is written on a single line in either format, with
+// newlines in values escaped. Unknown formats fall back to JSON.
+func NewLogHandler(
+	w io.Writer,
+	format string,
+	opts *slog.HandlerOptions,
+) slog.Handler {
+	if format == LogFormatText {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
 type CoreLogger struct {
 	*slog.Logger
 	tags             Tags
@@ -78,6 +110,17 @@ func NewCoreLogger(logger *slog.Logger, opts ...CoreLoggerOption) *CoreLogger {
 	return cl
 }
 
+// With returns a logger that adds the attributes to every record and
+// otherwise behaves like this one.
+func (cl *CoreLogger) With(args ...any) *CoreLogger {
+	return &CoreLogger{
+		Logger:           cl.Logger.With(args...),
+		tags:             cl.tags,
+		captureException: cl.captureException,
+		captureMessage:   cl.captureMessage,
+	}
+}
+
 func (cl *CoreLogger) tagsWithArgs(args ...any) Tags {
 	tags := NewTags(args...)
 	// add tags from logger:
@@ -95,7 +138,7 @@ func (cl *CoreLogger) SetTags(tags Tags) {
 
 // CaptureError logs an error and sends it to sentry.
 func (cl *CoreLogger) CaptureError(msg string, err error, args ...any) {
-	args = append(args, "error", err)
+	args = append(args, ErrorKey, err)
 	cl.Logger.Error(msg, args...)
 	if err != nil {
 		// send error to sentry:
@@ -110,7 +153,7 @@ func (cl *CoreLogger) CaptureError(msg string, err error, args ...any) {
 // CaptureFatal logs an error at the fatal level and sends it to sentry.
 func (cl *CoreLogger) CaptureFatal(msg string, err error, args ...any) {
 	// TODO: make sure this level is printed nicely
-	args = append(args, "error", err)
+	args = append(args, ErrorKey, err)
 	cl.Logger.Log(context.TODO(), LevelFatal, msg, args...)
 	if err != nil {
 		// send error to sentry:
@@ -3,11 +3,16 @@ package observability_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
 )
 
 func TestNewTags(t *testing.T) {
@@ -56,6 +61,16 @@ func TestNewTags(t *testing.T) {
 	}
 }
 
+func TestNewTags_RedactsSecrets(t *testing.T) {
+	request := &service.CredentialsUpdateRequest{ApiKey: "secret-key"}
+
+	tags := observability.NewTags("request", request, slog.Any("attr", request))
+
+	assert.NotContains(t, tags["request"], "secret-key")
+	assert.NotContains(t, tags["attr"], "secret-key")
+	assert.Equal(t, "secret-key", request.ApiKey)
+}
+
 func TestNewNoOpLogger(t *testing.T) {
 	// Call the function to get a CoreLogger
 	logger := observability.NewNoOpLogger()
@@ -114,3 +129,69 @@ func TestNewCoreLoggerWithTags(t *testing.T) {
 	assert.Equal(t, "value1", logMessage.Key1, "Unexpected value for key1")
 	assert.Equal(t, "value2", logMessage.Key2, "Unexpected value for key2")
 }
+
+func TestNewLogHandler_SameKeysInEveryFormat(t *testing.T) {
+	for _, format := range []string{
+		observability.LogFormatText,
+		observability.LogFormatJSON,
+	} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := observability.NewCoreLogger(
+				slog.New(observability.NewLogHandler(&buf, format, nil)).
+					With(observability.StreamIDKey, "run1"),
+				observability.WithTags(observability.Tags{}),
+			).With(observability.ComponentKey, "sender")
+
+			logger.Info("sender: started")
+			logger.Debug("not logged at the default level")
+			logger.Info("sender: processing record",
+				observability.RecordTypeKey, "history")
+			logger.CaptureError("sender: failed",
+				errors.New("first line\nsecond line"))
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			require.Len(t, lines, 3, "each record must be on a single line")
+			for _, line := range lines {
+				values := parseLogLine(t, format, line)
+				assert.Equal(t, "run1", values[observability.StreamIDKey])
+				assert.Equal(t, "sender", values[observability.ComponentKey])
+			}
+			assert.Equal(t, "history",
+				parseLogLine(t, format, lines[1])[observability.RecordTypeKey])
+			assert.Equal(t, "first line\nsecond line",
+				parseLogLine(t, format, lines[2])[observability.ErrorKey])
+		})
+	}
+}
+
+// parseLogLine returns the top-level string values of a log line.
+func parseLogLine(t *testing.T, format string, line string) map[string]string {
+	values := make(map[string]string)
+
+	if format == observability.LogFormatJSON {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		for key, value := range record {
+			if s, ok := value.(string); ok {
+				values[key] = s
+			}
+		}
+		return values
+	}
+
+	for len(line) > 0 {
+		key, rest, ok := strings.Cut(line, "=")
+		require.True(t, ok, "no value in %q", line)
+
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			require.NoError(t, err)
+			values[key], _ = strconv.Unquote(quoted)
+			line = strings.TrimPrefix(rest[len(quoted):], " ")
+		} else {
+			values[key], line, _ = strings.Cut(rest, " ")
+		}
+	}
+	return values
+}
@@ -1,14 +1,80 @@
 package observability
 
 import (
+	"fmt"
 	"sync"
 	"time"
 )
 
-// Printer stores console messages to display to the user.
+// maxQueuedMessages is how many user messages are kept while nobody can
+// receive them, after which the oldest are dropped.
+const maxQueuedMessages = 100
+
+// MessageLevel is how severe a message for the user is.
+type MessageLevel int
+
+const (
+	MessageInfo MessageLevel = iota
+	MessageWarning
+	MessageError
+)
+
+// UserMessage is something to tell the user in their terminal.
+//
+// Unlike a log, which is for whoever debugs wandb, it should make sense to
+// the person running the script.
+type UserMessage struct {
+	Level MessageLevel
+
+	// Key deduplicates the message, if not empty.
+	//
+	// Only the first message with a given key is shown.
+	Key string
+
+	// Category is the kind of problem the message is about, if not empty.
+	//
+	// It groups the repeats of a message in the run's warnings. Messages
+	// without one are grouped by their key up to its first colon, or else
+	// by their text.
+	Category string
+
+	Text string
+}
+
+// Printer is the bus for messages to display to the user.
+//
+// Components emit messages, which are forwarded to the user's terminal if
+// something can display them. Otherwise, they're queued until they can be
+// forwarded or until they're read for the run's footer.
 type Printer struct {
 	sync.Mutex
-	messages []string
+
+	// queue are the messages that weren't forwarded yet, oldest first.
+	queue []UserMessage
+
+	// dropped is the number of messages dropped because the queue was full.
+	dropped int
+
+	// seenKeys are the keys of the messages emitted so far.
+	seenKeys map[string]struct{}
+
+	// forward shows a message to the user, returning false if nothing could.
+	//
+	// It is called with the lock held, so that messages are forwarded in
+	// the order in which they were emitted.
+	forward func(UserMessage) bool
+
+	// save is given every message emitted, whether or not it's forwarded,
+	// if not nil.
+	//
+	// Like forward, it is called with the lock held.
+	save func(UserMessage)
+
+	// observers are given every message emitted, including repeats and
+	// rate-limited messages that aren't shown.
+	//
+	// Like forward, they're called with the lock held.
+	observers []func(UserMessage)
 
 	// For rate-limited messages, this is the next time a message may be sent.
 	rateLimits map[string]time.Time
@@ -19,6 +85,7 @@ type Printer struct {
 
 func NewPrinter() *Printer {
 	printer := &Printer{
+		seenKeys:   make(map[string]struct{}),
 		rateLimits: make(map[string]time.Time),
 		getNow:     time.Now,
 	}
@@ -42,22 +109,115 @@ func NewPrinter() *Printer {
 	return printer
 }
 
-// Read returns all buffered messages and clears the buffer.
+// Forward sets how messages are shown to the user and flushes the queue.
+//
+// The function returns false if nothing could display the message, in
+// which case it stays queued.
+func (p *Printer) Forward(forward func(UserMessage) bool) {
+	p.Lock()
+	defer p.Unlock()
+	p.forward = forward
+	p.flush()
+}
+
+// Save sets a function that's given every message emitted from now on,
+// such as to keep it where the user can find it later.
+func (p *Printer) Save(save func(UserMessage)) {
+	p.Lock()
+	defer p.Unlock()
+	p.save = save
+}
+
+// Observe adds a function that's given every message emitted from now on,
+// including those not shown for repeating an earlier one or for being
+// rate-limited, such as to count how often a problem happened.
+func (p *Printer) Observe(observe func(UserMessage)) {
+	p.Lock()
+	defer p.Unlock()
+	p.observers = append(p.observers, observe)
+}
+
+// Flush forwards the queued messages, such as after a client attaches.
+func (p *Printer) Flush() {
+	p.Lock()
+	defer p.Unlock()
+	p.flush()
+}
+
+func (p *Printer) flush() {
+	if p.forward == nil {
+		return
+	}
+
+	for len(p.queue) > 0 {
+		if !p.forward(p.queue[0]) {
+			return
+		}
+		p.queue = p.queue[1:]
+	}
+}
+
+// Read returns the text of all queued messages and clears the queue.
 func (p *Printer) Read() []string {
 	p.Lock()
 	defer p.Unlock()
 
-	polledMessages := p.messages
-	p.messages = make([]string, 0)
+	polledMessages := make([]string, 0, len(p.queue)+1)
+	if p.dropped > 0 {
+		polledMessages = append(polledMessages,
+			fmt.Sprintf("%d earlier messages were dropped.", p.dropped))
+		p.dropped = 0
+	}
+	for _, message := range p.queue {
+		polledMessages = append(polledMessages, message.Text)
+	}
+	p.queue = nil
 
 	return polledMessages
 }
 
-// Write adds a message to the console.
-func (p *Printer) Write(message string) {
+// Emit shows a message to the user, unless one with its key was emitted.
+func (p *Printer) Emit(message UserMessage) {
 	p.Lock()
 	defer p.Unlock()
-	p.messages = append(p.messages, message)
+
+	p.observe(message)
+	if message.Key != "" {
+		if _, seen := p.seenKeys[message.Key]; seen {
+			return
+		}
+		p.seenKeys[message.Key] = struct{}{}
+	}
+
+	p.emit(message)
+}
+
+func (p *Printer) observe(message UserMessage) {
+	for _, observe := range p.observers {
+		observe(message)
+	}
+}
+
+func (p *Printer) emit(message UserMessage) {
+	if p.save != nil {
+		p.save(message)
+	}
+
+	// Forwarding out of order would show an old message after a new one.
+	if len(p.queue) == 0 && p.forward != nil && p.forward(message) {
+		return
+	}
+
+	p.queue = append(p.queue, message)
+	if len(p.queue) > maxQueuedMessages {
+		p.queue = p.queue[1:]
+		p.dropped++
+	}
+}
+
+// Write shows a warning to the user.
+func (p *Printer) Write(message string) {
+	p.Emit(UserMessage{Level: MessageWarning, Text: message})
 }
 
 // AtMostEvery allows rate-limiting how often a message is printed.
@@ -87,19 +247,27 @@ type writeDSL struct {
 
 // See [Printer.Write].
 func (dsl writeDSL) Write(message string) {
+	dsl.Emit(UserMessage{Level: MessageWarning, Text: message})
+}
+
+// See [Printer.Emit].
+//
+// Messages are rate-limited by their text, and their keys are ignored.
+func (dsl writeDSL) Emit(message UserMessage) {
 	dsl.printer.Lock()
 	defer dsl.printer.Unlock()
 
+	dsl.printer.observe(message)
 	if dsl.duration > 0 {
-		blockUntil := dsl.printer.rateLimits[message]
+		blockUntil := dsl.printer.rateLimits[message.Text]
 
 		now := dsl.printer.getNow()
 		if now.Before(blockUntil) {
 			return
 		}
 
-		dsl.printer.rateLimits[message] = now.Add(dsl.duration)
+		dsl.printer.rateLimits[message.Text] = now.Add(dsl.duration)
 	}
 
-	dsl.printer.messages = append(dsl.printer.messages, message)
+	dsl.printer.emit(message)
 }
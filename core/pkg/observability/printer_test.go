@@ -1,6 +1,7 @@
 package observability
 
 import (
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -36,3 +37,89 @@ func TestRateLimitedWrite(t *testing.T) {
 		[]string{"hey there", "hey there"},
 		p.Read())
 }
+
+func TestEmit_DeduplicatesByKey(t *testing.T) {
+	p := NewPrinter()
+
+	p.Emit(UserMessage{Key: "offline", Text: "first"})
+	p.Emit(UserMessage{Key: "offline", Text: "second"})
+	p.Emit(UserMessage{Text: "no key"})
+	p.Emit(UserMessage{Text: "no key"})
+
+	assert.Equal(t,
+		[]string{"first", "no key", "no key"},
+		p.Read())
+}
+
+func TestEmit_QueuedUntilForwarded(t *testing.T) {
+	var forwarded []UserMessage
+	attached := false
+	p := NewPrinter()
+
+	p.Emit(UserMessage{Level: MessageWarning, Text: "before"})
+	p.Forward(func(message UserMessage) bool {
+		if !attached {
+			return false
+		}
+		forwarded = append(forwarded, message)
+		return true
+	})
+	p.Emit(UserMessage{Level: MessageError, Text: "while detached"})
+	assert.Empty(t, forwarded)
+
+	attached = true
+	p.Flush()
+	p.Emit(UserMessage{Level: MessageInfo, Text: "after"})
+
+	assert.Equal(t,
+		[]UserMessage{
+			{Level: MessageWarning, Text: "before"},
+			{Level: MessageError, Text: "while detached"},
+			{Level: MessageInfo, Text: "after"},
+		},
+		forwarded)
+	assert.Empty(t, p.Read())
+}
+
+func TestEmit_QueueIsBounded(t *testing.T) {
+	p := NewPrinter()
+
+	for i := range maxQueuedMessages + 2 {
+		p.Write(fmt.Sprintf("message %d", i))
+	}
+
+	messages := p.Read()
+	assert.Len(t, messages, maxQueuedMessages+1)
+	assert.Equal(t, "2 earlier messages were dropped.", messages[0])
+	assert.Equal(t, "message 2", messages[1])
+}
+
+func TestSave_GetsForwardedAndQueuedMessages(t *testing.T) {
+	var saved []string
+	p := NewPrinter()
+	p.Save(func(message UserMessage) { saved = append(saved, message.Text) })
+
+	p.Write("queued")
+	p.Forward(func(UserMessage) bool { return true })
+	p.Write("forwarded")
+	p.Emit(UserMessage{Key: "once", Text: "keyed"})
+	p.Emit(UserMessage{Key: "once", Text: "keyed again"})
+
+	assert.Equal(t, []string{"queued", "forwarded", "keyed"}, saved)
+}
+
+func TestObserve_GetsRepeatedAndRateLimitedMessages(t *testing.T) {
+	var observed []string
+	p := NewPrinter()
+	p.Observe(func(message UserMessage) { observed = append(observed, message.Text) })
+
+	p.Emit(UserMessage{Key: "once", Text: "keyed"})
+	p.Emit(UserMessage{Key: "once", Text: "keyed again"})
+	p.AtMostEvery(time.Minute).Write("limited")
+	p.AtMostEvery(time.Minute).Write("limited")
+
+	assert.Equal(t,
+		[]string{"keyed", "keyed again", "limited", "limited"},
+		observed)
+	assert.Equal(t, []string{"keyed", "limited"}, p.Read())
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// errRunDiscarded fails the sync of a run that the client aborted.
+var errRunDiscarded = errors.New("the run was aborted, so it isn't synced")
+
+// handleRequestAbortRun discards the run, unless it started to exit.
+//
+// The run's data handled from now on is dropped. The abort is passed on
+// as a record, which the writer saves to the transaction log so that
+// syncing the log doesn't create the run again, and which the sender
+// answers once the run is deleted from the server.
+func (h *Handler) handleRequestAbortRun(record *service.Record) {
+	if h.exiting {
+		h.respond(record, &service.Response{
+			ResponseType: &service.Response_AbortRunResponse{
+				AbortRunResponse: &service.AbortRunResponse{
+					Error: &service.ErrorInfo{
+						Code:    service.ErrorInfo_USAGE,
+						Message: "the run can't be aborted once it started to exit",
+					},
+				},
+			},
+		})
+		return
+	}
+
+	if !h.aborted {
+		h.aborted = true
+		h.systemMonitor.Stop()
+		h.tbHandler.Close()
+	}
+
+	h.fwdRecordWithControl(
+		&service.Record{
+			RecordType: &service.Record_Aborted{
+				Aborted: &service.RunAbortedRecord{},
+			},
+			Control: record.Control,
+			Uuid:    record.Uuid,
+		},
+		func(control *service.Control) {
+			// unlike the request, the abort is saved, and the sender
+			// answers it even when offline
+			control.Local = false
+			control.AlwaysSend = true
+		},
+	)
+}
+
+// keptWhenAborted is whether the record is still handled after the run is
+// aborted: requests, and the exit that finishes the stream.
+func keptWhenAborted(record *service.Record) bool {
+	return record.GetRequest() != nil || record.GetExit() != nil
+}
+
+// dropAborted drops a record of an aborted run, answering it if the client
+// waits for an answer.
+func (h *Handler) dropAborted(record *service.Record) {
+	h.logger.Debug(
+		"handler: dropping a record of an aborted run",
+		observability.RecordTypeKey, recordTypeName(record),
+	)
+
+	if record.GetRun() == nil {
+		return
+	}
+	if !record.GetControl().GetReqResp() && record.GetControl().GetMailboxSlot() == "" {
+		return
+	}
+	h.outChan <- &service.Result{
+		ResultType: &service.Result_RunResult{
+			RunResult: &service.RunUpdateResult{
+				Error: &service.ErrorInfo{
+					Code:    service.ErrorInfo_USAGE,
+					Message: "the run was aborted",
+				},
+			},
+		},
+		Control: record.Control,
+		Uuid:    record.Uuid,
+	}
+}
+
+// sendAborted discards the run: its uploads are cancelled and, if it was
+// created on the server, it's deleted.
+//
+// A run is only created on the server when it's upserted, so a run
+// aborted before then is never created. The exit that follows skips the
+// rest of the run's data.
+func (s *Sender) sendAborted(record *service.Record) {
+	s.discarded.Store(true)
+	s.cancelUploads()
+
+	response := &service.AbortRunResponse{}
+	deleted, err := s.deleteRun()
+	if err != nil {
+		s.logger.CaptureError("sender: failed to delete the aborted run", err)
+		response.Error = backendErrorInfo(
+			fmt.Errorf("failed to delete the run: %w", err))
+	}
+	response.Deleted = deleted
+
+	s.respond(record, &service.Response{
+		ResponseType: &service.Response_AbortRunResponse{
+			AbortRunResponse: response,
+		},
+	})
+}
+
+// deleteRun deletes the run from the server, and returns whether it did.
+//
+// Runs that weren't created on the server are left as they are, and so are
+// shared-mode runs by their secondary writers, since other processes are
+// still writing to them.
+func (s *Sender) deleteRun() (bool, error) {
+	storageID := s.RunRecord.GetStorageId()
+	if storageID == "" || s.graphqlClient == nil || s.secondary {
+		return false, nil
+	}
+
+	if _, err := gql.DeleteRun(s.ctx, s.graphqlClient, storageID); err != nil {
+		return false, err
+	}
+	s.logger.Info("sender: deleted the aborted run", "id", storageID)
+	return true, nil
+}
@@ -0,0 +1,244 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const upsertBucketWithIDResponse = `{
+	"upsertBucket": {
+		"bucket": {
+			"id": "storage-id",
+			"displayName": "FakeName",
+			"project": {
+				"name": "FakeProject",
+				"entity": {
+					"name": "FakeEntity"
+				}
+			}
+		}
+	}
+}`
+
+// newAbortStream starts a stream that sends the run to the backend and
+// saves its transaction log to syncFile.
+func newAbortStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	syncFile string,
+) (*server.Stream, chanResponder) {
+	t.Helper()
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "abort"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	return stream, responses
+}
+
+func abortRunRequest(uuid string) *service.Record {
+	return disabledRequest(uuid, &service.Request{
+		RequestType: &service.Request_AbortRun{
+			AbortRun: &service.AbortRunRequest{},
+		},
+	})
+}
+
+// exitRun sends the run's exit and returns its result.
+func exitRun(
+	t *testing.T,
+	stream *server.Stream,
+	responses chanResponder,
+) *service.RunExitResult {
+	t.Helper()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	return awaitResult(t, responses, "exit").GetExitResult()
+}
+
+// hasAbortedMarker returns whether the transaction log has the marker of
+// an aborted run.
+func hasAbortedMarker(t *testing.T, syncFile string) bool {
+	t.Helper()
+	for _, record := range readLog(t, syncFile) {
+		if record.GetAborted() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// A run aborted before it's upserted is never created on the server.
+func TestAbortRun_BeforeUpsert(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	syncFile := filepath.Join(t.TempDir(), "run-abort.wandb")
+	stream, responses := newAbortStream(t, backend, syncFile)
+	defer stream.Close()
+
+	stream.HandleRecord(abortRunRequest("abort"))
+	aborted := awaitResult(t, responses, "abort").
+		GetResponse().GetAbortRunResponse()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "abort", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	run := awaitResult(t, responses, "run").GetRunResult()
+	exit := exitRun(t, stream, responses)
+
+	assert.Nil(t, aborted.GetError())
+	assert.False(t, aborted.GetDeleted())
+	assert.NotNil(t, run.GetError())
+	assert.True(t, exit.GetDiscarded())
+	assert.Empty(t, backend.GraphQLRequests("UpsertBucket"))
+	assert.Empty(t, backend.GraphQLRequests("DeleteRun"))
+	assert.True(t, hasAbortedMarker(t, syncFile))
+}
+
+// A run aborted after it's upserted is deleted, and its data logged
+// afterwards isn't sent.
+func TestAbortRun_AfterUpsert(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubGraphQL("DeleteRun", `{"deleteRun": {"clientMutationId": null}}`)
+	syncFile := filepath.Join(t.TempDir(), "run-abort.wandb")
+	stream, responses := newAbortStream(t, backend, syncFile)
+	defer stream.Close()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "abort", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	awaitResult(t, responses, "run")
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(abortRunRequest("abort"))
+	aborted := awaitResult(t, responses, "abort").
+		GetResponse().GetAbortRunResponse()
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		step:  0,
+	}))
+	exit := exitRun(t, stream, responses)
+
+	assert.Nil(t, aborted.GetError())
+	assert.True(t, aborted.GetDeleted())
+	assert.True(t, exit.GetDiscarded())
+	deletes := backend.GraphQLRequests("DeleteRun")
+	require.Len(t, deletes, 1)
+	assert.Contains(t, string(deletes[0].Body), `"id":"storage-id"`)
+	assert.Len(t, backend.GraphQLRequests("UpsertBucket"), 1)
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		assert.NotContains(t, string(request.Body), "loss")
+		assert.NotContains(t, string(request.Body), `"complete"`)
+	}
+	assert.True(t, hasAbortedMarker(t, syncFile))
+}
+
+// A run can't be aborted once it started to exit.
+func TestAbortRun_FailsAfterExit(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	syncFile := filepath.Join(t.TempDir(), "run-abort.wandb")
+	stream, responses := newAbortStream(t, backend, syncFile)
+	defer stream.Close()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	stream.HandleRecord(abortRunRequest("abort"))
+	aborted := awaitResult(t, responses, "abort").
+		GetResponse().GetAbortRunResponse()
+	exit := awaitResult(t, responses, "exit").GetExitResult()
+
+	assert.Equal(t, service.ErrorInfo_USAGE, aborted.GetError().GetCode())
+	assert.False(t, exit.GetDiscarded())
+	assert.False(t, hasAbortedMarker(t, syncFile))
+}
+
+// Syncing the transaction log of an aborted run fails without creating
+// the run.
+func TestAbortRun_NotSynced(t *testing.T) {
+	syncFile := filepath.Join(t.TempDir(), "run-abort.wandb")
+	writeEverything(t, syncFile, []*service.Record{
+		{
+			RecordType: &service.Record_Run{
+				Run: &service.RunRecord{RunId: "abort", Project: "testProject"},
+			},
+		},
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.5"}, step: 0}),
+		{RecordType: &service.Record_Aborted{Aborted: &service.RunAbortedRecord{}}},
+		{RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}}},
+	})
+
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	dir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "abort"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	sync.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	sync.Start()
+	defer sync.Close()
+
+	sync.HandleRecord(disabledRequest("sync", &service.Request{
+		RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+	}))
+	var synced *service.SyncResponse
+	select {
+	case result := <-responses:
+		synced = result.GetResponse().GetSyncResponse()
+	case <-time.After(10 * time.Second):
+		t.Fatal("the sync didn't finish")
+	}
+
+	assert.Contains(t, synced.GetError().GetMessage(), "aborted")
+	assert.Empty(t, backend.GraphQLRequests("UpsertBucket"))
+	assert.Empty(t, backend.Requests(servertest.RouteFileStream))
+}
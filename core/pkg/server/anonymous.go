@@ -0,0 +1,71 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// errNoAPIKey is returned when a run can't be created because there is no
+// API key and anonymous logging is not allowed.
+var errNoAPIKey = errors.New(
+	"no API key configured. Use `wandb login` or set the WANDB_API_KEY" +
+		" environment variable, or pass anonymous=\"allow\" to log the run" +
+		" anonymously",
+)
+
+// ensureCredentials makes sure there is an API key to create the run with.
+//
+// Depending on the `anonymous` setting, this creates an anonymous entity
+// and uses its API key for the rest of the session: always if it is
+// "must", and only if no API key is configured if it is "allow". If it is
+// "never" and there is no API key, this returns errNoAPIKey.
+func (s *Sender) ensureCredentials() error {
+	mode := settings.From(s.settings).GetAnonymous()
+	hasAPIKey := s.settings.GetApiKey().GetValue() != ""
+
+	switch {
+	case mode == settings.AnonymousMust:
+	case hasAPIKey:
+		return nil
+	case mode == settings.AnonymousAllow, mode == settings.AnonymousActive:
+	case mode == settings.AnonymousNever:
+		return errNoAPIKey
+	default:
+		return nil
+	}
+
+	data, err := gql.CreateAnonymousApiKey(s.ctx, s.graphqlClient)
+	if err != nil {
+		return fmt.Errorf("failed to create an anonymous API key: %v", err)
+	}
+	apiKey := data.GetCreateAnonymousEntity().GetApiKey().GetName()
+	if apiKey == "" {
+		return errors.New("failed to create an anonymous API key: empty response")
+	}
+
+	s.settings.ApiKey = &wrapperspb.StringValue{Value: apiKey}
+	s.settings.Anonymous = &wrapperspb.StringValue{Value: settings.AnonymousActive}
+	s.settingsProvenance.Set("api_key", service.EffectiveSetting_SERVER)
+	s.settingsProvenance.Set("anonymous", service.EffectiveSetting_SERVER)
+	if s.backend != nil {
+		s.backend.UpdateAPIKey(apiKey)
+	}
+	s.logger.Info("sender: ensureCredentials: using an anonymous API key")
+	return nil
+}
+
+// keepAnonymousCredentials copies the anonymous API key created for the
+// session, if any, to settings replacing the ones it was created for.
+func keepAnonymousCredentials(from *service.Settings, to *service.Settings) {
+	if from.GetAnonymous().GetValue() != settings.AnonymousActive {
+		return
+	}
+	to.ApiKey = from.ApiKey
+	to.Anonymous = from.Anonymous
+}
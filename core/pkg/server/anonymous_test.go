@@ -0,0 +1,135 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/gqlmock"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const validCreateAnonymousApiKeyResponse = `{
+	"createAnonymousEntity": {"apiKey": {"name": "anonymous-key"}}
+}`
+
+// startAnonymousRun sends a run record to a sender with the given anonymous
+// mode and returns its result and the GraphQL operations it made.
+func startAnonymousRun(
+	t *testing.T,
+	anonymous string,
+	apiKey string,
+) (*service.Settings, *service.RunUpdateResult, []string) {
+	// keep the API key from being read from the environment
+	t.Setenv("WANDB_API_KEY", "")
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "netrc"))
+
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateAnonymousApiKey"),
+		validCreateAnonymousApiKeyResponse,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		validUpsertBucketResponse,
+	)
+
+	proto := &service.Settings{
+		BaseUrl:   &wrapperspb.StringValue{Value: "https://api.wandb.ai"},
+		RunId:     &wrapperspb.StringValue{Value: "run1"},
+		Anonymous: &wrapperspb.StringValue{Value: anonymous},
+	}
+	if apiKey != "" {
+		t.Setenv("WANDB_API_KEY", apiKey)
+	}
+	// like the connection, go on if there is no key so that the sender
+	// reports it
+	_ = settings.From(proto).EnsureAPIKey()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	outChan := make(chan *service.Result, 1)
+	sender := server.NewSender(ctx, cancel,
+		&server.SenderParams{
+			Logger:        observability.NewNoOpLogger(),
+			Settings:      proto,
+			FwdChan:       make(chan *service.Record, 1),
+			OutChan:       outChan,
+			Mailbox:       mailbox.NewMailbox(),
+			GraphqlClient: mockGQL,
+		},
+	)
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				RunId:   "run1",
+				Entity:  "testEntity",
+				Project: "testProject",
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	})
+	result := (<-outChan).GetRunResult()
+
+	var ops []string
+	for _, request := range mockGQL.AllRequests() {
+		ops = append(ops, request.OpName)
+	}
+	return proto, result, ops
+}
+
+func TestAnonymous_AllowWithEnvKey(t *testing.T) {
+	proto, result, ops := startAnonymousRun(t, settings.AnonymousAllow, "env-key")
+
+	assert.Nil(t, result.GetError())
+	assert.Equal(t, []string{"UpsertBucket", "ProjectSettings"}, ops)
+	assert.Equal(t, "env-key", proto.GetApiKey().GetValue())
+	assert.Equal(t, settings.AnonymousAllow, proto.GetAnonymous().GetValue())
+}
+
+func TestAnonymous_AllowWithoutKey(t *testing.T) {
+	proto, result, ops := startAnonymousRun(t, settings.AnonymousAllow, "")
+
+	assert.Nil(t, result.GetError())
+	assert.Equal(t, []string{"CreateAnonymousApiKey", "UpsertBucket", "ProjectSettings"}, ops)
+	assert.Equal(t, "anonymous-key", proto.GetApiKey().GetValue())
+	assert.Equal(t, settings.AnonymousActive, proto.GetAnonymous().GetValue())
+}
+
+func TestAnonymous_MustOverridesEnvKey(t *testing.T) {
+	proto, result, ops := startAnonymousRun(t, settings.AnonymousMust, "env-key")
+
+	assert.Nil(t, result.GetError())
+	assert.Equal(t, []string{"CreateAnonymousApiKey", "UpsertBucket", "ProjectSettings"}, ops)
+	assert.Equal(t, "anonymous-key", proto.GetApiKey().GetValue())
+}
+
+func TestAnonymous_NeverWithEnvKey(t *testing.T) {
+	proto, result, ops := startAnonymousRun(t, settings.AnonymousNever, "env-key")
+
+	assert.Nil(t, result.GetError())
+	assert.Equal(t, []string{"UpsertBucket", "ProjectSettings"}, ops)
+	assert.Equal(t, "env-key", proto.GetApiKey().GetValue())
+}
+
+func TestAnonymous_NeverWithoutKey(t *testing.T) {
+	t.Setenv("WANDB_API_KEY", "")
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "netrc"))
+	proto := &service.Settings{
+		BaseUrl:   &wrapperspb.StringValue{Value: "https://api.wandb.ai"},
+		Anonymous: &wrapperspb.StringValue{Value: settings.AnonymousNever},
+	}
+	assert.Error(t, settings.From(proto).EnsureAPIKey())
+
+	_, result, ops := startAnonymousRun(t, settings.AnonymousNever, "")
+
+	assert.Equal(t, service.ErrorInfo_AUTHENTICATION, result.GetError().GetCode())
+	assert.Contains(t, result.GetError().GetMessage(), "no API key")
+	assert.Empty(t, ops)
+}
@@ -0,0 +1,82 @@
+package server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func artifactCancelRecord(uuid string, clientID string) *service.Record {
+	return disabledRequest(uuid, &service.Request{
+		RequestType: &service.Request_ArtifactCancel{
+			ArtifactCancel: &service.ArtifactCancelRequest{ClientId: clientID},
+		},
+	})
+}
+
+// A cancelled artifact stops being saved, everyone waiting for it is told
+// so, and the run's exit doesn't wait for it.
+func TestArtifactCancel_StopsSaving(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream := startArtifactExitStream(t, backend, &service.Settings{
+		XFaultInjection: &wrapperspb.StringValue{Value: "artifact-delay=1h"},
+	})
+
+	stream.HandleRecord(logArtifactRecord("artifact"))
+	wait := artifactWaitRecord("wait", "client-id")
+	wait.Control = &service.Control{ConnectionId: "client", ReqResp: true}
+	stream.HandleRecord(wait)
+	stream.HandleRecord(artifactCancelRecord("cancel", "client-id"))
+	cancelled := stream.result(t, "cancel").GetResponse().GetArtifactCancelResponse()
+	waited := stream.result(t, "wait").GetResponse().GetArtifactWaitResponse()
+	artifact := stream.result(t, "artifact").GetResponse().GetLogArtifactResponse()
+	stream.exit()
+	exit := stream.result(t, "exit").GetExitResult()
+	stream.Close()
+
+	assert.Equal(t, service.ArtifactCancelResponse_CANCELLED, cancelled.GetState())
+	assert.Equal(t, service.ArtifactWaitResponse_CANCELLED, waited.GetState())
+	assert.Contains(t, artifact.GetErrorMessage(), "cancelled")
+	assert.Nil(t, exit.GetUnfinished())
+	assert.Empty(t, backend.GraphQLRequests("CreateArtifact"))
+}
+
+func TestArtifactCancel_AlreadyCommitted(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream := startArtifactExitStream(t, backend, &service.Settings{})
+
+	stream.HandleRecord(logArtifactRecord("artifact"))
+	stream.result(t, "artifact")
+	stream.HandleRecord(artifactCancelRecord("cancel", "client-id"))
+	cancelled := stream.result(t, "cancel").GetResponse().GetArtifactCancelResponse()
+	stream.exit()
+	stream.result(t, "exit")
+	stream.Close()
+
+	assert.Equal(t,
+		service.ArtifactCancelResponse_ALREADY_COMMITTED,
+		cancelled.GetState())
+	assert.Contains(t, cancelled.GetErrorMessage(), "can't be cancelled")
+}
+
+func TestArtifactCancel_Unknown(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream := startArtifactExitStream(t, backend, &service.Settings{})
+
+	stream.HandleRecord(artifactCancelRecord("cancel", "unknown"))
+	cancelled := stream.result(t, "cancel").GetResponse().GetArtifactCancelResponse()
+	stream.exit()
+	stream.result(t, "exit")
+	stream.Close()
+
+	assert.Equal(t,
+		service.ArtifactCancelResponse_NOT_IN_PROGRESS,
+		cancelled.GetState())
+}
@@ -0,0 +1,203 @@
+package server_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// artifactExitStream is a running stream whose responses are kept by the
+// UUIDs of their records.
+type artifactExitStream struct {
+	*server.Stream
+	responses chanResponder
+	results   map[string]*service.Result
+}
+
+func startArtifactExitStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	proto *service.Settings,
+) *artifactExitStream {
+	t.Helper()
+	dir := t.TempDir()
+	proto.RunId = &wrapperspb.StringValue{Value: "artifacts"}
+	proto.BaseUrl = &wrapperspb.StringValue{Value: backend.URL()}
+	proto.ApiKey = &wrapperspb.StringValue{Value: "test-api-key"}
+	proto.LogDir = &wrapperspb.StringValue{Value: dir}
+	proto.LogInternal = &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")}
+	proto.SyncFile = &wrapperspb.StringValue{Value: filepath.Join(dir, "run-artifacts.wandb")}
+	proto.FilesDir = &wrapperspb.StringValue{Value: filepath.Join(dir, "files")}
+	proto.XDisableStats = &wrapperspb.BoolValue{Value: true}
+	proto.XDisableMeta = &wrapperspb.BoolValue{Value: true}
+
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubGraphQL("CreateArtifact", committedCreateArtifactResponse)
+	backend.StubCreateRunFiles()
+	stream, err := server.NewStream(settings.From(proto), "")
+	require.NoError(t, err)
+	s := &artifactExitStream{
+		Stream:    stream,
+		responses: make(chanResponder, 64),
+		results:   make(map[string]*service.Result),
+	}
+	stream.AddResponders(server.ResponderEntry{Responder: s.responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "artifacts", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	return s
+}
+
+// result returns the response to the record with the UUID.
+func (s *artifactExitStream) result(t *testing.T, uuid string) *service.Result {
+	t.Helper()
+	for {
+		if result, ok := s.results[uuid]; ok {
+			return result
+		}
+		select {
+		case result := <-s.responses:
+			s.results[result.GetUuid()] = result
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%s wasn't answered", uuid)
+			return nil
+		}
+	}
+}
+
+// exit sends the run's exit, which is answered once the run finished.
+func (s *artifactExitStream) exit() {
+	s.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+}
+
+func logArtifactRecord(uuid string) *service.Record {
+	return disabledRequest(uuid, &service.Request{
+		RequestType: &service.Request_LogArtifact{
+			LogArtifact: &service.LogArtifactRequest{
+				Artifact: &service.ArtifactRecord{
+					RunId:    "artifacts",
+					Project:  "testProject",
+					Entity:   "test-entity",
+					Type:     "dataset",
+					Name:     "dataset",
+					Digest:   "test-digest",
+					Manifest: &service.ArtifactManifest{Version: 1},
+					Finalize: true,
+					ClientId: "client-id",
+				},
+			},
+		},
+	})
+}
+
+// An artifact logged right before the exit is committed before any final
+// upsert of the run and the filestream transmission that marks it finished,
+// and is reported as pending while the exit waits for it.
+func TestArtifactExit_CommittedBeforeRunFinishes(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream := startArtifactExitStream(t, backend, &service.Settings{
+		XFaultInjection: &wrapperspb.StringValue{Value: "artifact-delay=500ms"},
+	})
+
+	stream.HandleRecord(logArtifactRecord("artifact"))
+	stream.exit()
+	var pending *service.ExitProgress
+	for i := 0; pending == nil && i < 200; i++ {
+		uuid := fmt.Sprintf("poll-%d", i)
+		poll := pollExitRecord()
+		poll.Uuid = uuid
+		stream.HandleRecord(poll)
+		progress := stream.result(t, uuid).GetResponse().GetPollExitResponse().GetExitProgress()
+		if progress.GetPendingArtifacts() > 0 {
+			pending = progress
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	artifact := stream.result(t, "artifact").GetResponse().GetLogArtifactResponse()
+	exit := stream.result(t, "exit").GetExitResult()
+	stream.Close()
+
+	require.NotNil(t, pending, "pending artifact not reported")
+	assert.EqualValues(t, 1, pending.GetPendingArtifacts())
+	assert.Equal(t, service.DeferRequest_FLUSH_TB, pending.GetState())
+	assert.Empty(t, artifact.GetErrorMessage())
+	assert.Equal(t, "artifact-id", artifact.GetArtifactId())
+	assert.Nil(t, exit.GetUnfinished())
+
+	createArtifact := backend.GraphQLRequests("CreateArtifact")
+	require.Len(t, createArtifact, 1)
+	committedAt := createArtifact[0].ReceivedAt
+	// the first upsert creates the run; any other is part of the exit
+	for _, upsert := range backend.GraphQLRequests("UpsertBucket")[1:] {
+		assert.True(t, upsert.ReceivedAt.After(committedAt),
+			"run upserted before the artifact was committed")
+	}
+	fileStream := backend.Requests(servertest.RouteFileStream)
+	finished := fileStream[len(fileStream)-1]
+	assert.Contains(t, string(finished.Body), `"complete":true`)
+	assert.True(t, finished.ReceivedAt.After(committedAt),
+		"run finished before the artifact was committed")
+}
+
+// An artifact logged once the run started to exit fails right away,
+// rather than after the run is finished.
+func TestArtifactExit_RejectsArtifactsAfterExit(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream := startArtifactExitStream(t, backend, &service.Settings{})
+
+	stream.exit()
+	stream.HandleRecord(logArtifactRecord("late"))
+	wait := artifactWaitRecord("wait", "client-id")
+	wait.Control = &service.Control{ConnectionId: "client", ReqResp: true}
+	stream.HandleRecord(wait)
+	late := stream.result(t, "late").GetResponse().GetLogArtifactResponse()
+	waited := stream.result(t, "wait").GetResponse().GetArtifactWaitResponse()
+	stream.result(t, "exit")
+	stream.Close()
+
+	assert.Contains(t, late.GetErrorMessage(), "already started to exit")
+	assert.Empty(t, late.GetArtifactId())
+	assert.Equal(t, service.ArtifactWaitResponse_FAILED, waited.GetState())
+	assert.Equal(t, late.GetErrorMessage(), waited.GetErrorMessage())
+	assert.Empty(t, backend.GraphQLRequests("CreateArtifact"))
+}
+
+// The exit waits for a stalled artifact only until it times out.
+func TestArtifactExit_WaitBoundedByExitTimeout(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream := startArtifactExitStream(t, backend, &service.Settings{
+		XFaultInjection:     &wrapperspb.StringValue{Value: "artifact-delay=1h"},
+		XExitTimeoutSeconds: &wrapperspb.DoubleValue{Value: 0.5},
+	})
+
+	stream.HandleRecord(logArtifactRecord("artifact"))
+	stream.exit()
+	exit := stream.result(t, "exit").GetExitResult()
+	stream.Close()
+
+	require.NotNil(t, exit.GetUnfinished())
+	assert.GreaterOrEqual(t, exit.GetUnfinished().GetPendingUploads(), int32(1))
+	assert.Empty(t, backend.GraphQLRequests("CreateArtifact"))
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/artifacts"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// artifactWaits tracks the artifacts the sender saves by their client IDs,
+// so that clients can wait for them to be committed, or cancel them.
+//
+// It is safe for concurrent use.
+type artifactWaits struct {
+	mu sync.Mutex
+
+	// done has a channel for each logged artifact that's closed once the
+	// artifact is saved or fails to be
+	done map[string]chan struct{}
+
+	// results are the outcomes of the artifacts that are done
+	results map[string]*service.ArtifactWaitResponse
+
+	// cancellations cancel the artifacts that are being saved
+	cancellations map[string]*artifacts.Cancellation
+}
+
+func newArtifactWaits() *artifactWaits {
+	return &artifactWaits{
+		done:          make(map[string]chan struct{}),
+		results:       make(map[string]*service.ArtifactWaitResponse),
+		cancellations: make(map[string]*artifacts.Cancellation),
+	}
+}
+
+// Start notes that the artifact with the client ID is being saved.
+//
+// It must be called before a request to wait for or cancel the artifact is
+// handled. Artifacts without a client ID can't be waited for and are
+// ignored.
+//
+// Returns the cancellation with which to save the artifact, which is nil
+// if it has no client ID.
+func (w *artifactWaits) Start(clientID string) *artifacts.Cancellation {
+	if clientID == "" {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, isSaving := w.done[clientID]; isSaving && w.results[clientID] == nil {
+		// logged again before it was saved; waiters get the first outcome,
+		// and cancelling it cancels both
+		return w.cancellations[clientID]
+	}
+	w.done[clientID] = make(chan struct{})
+	delete(w.results, clientID)
+	w.cancellations[clientID] = &artifacts.Cancellation{}
+	return w.cancellations[clientID]
+}
+
+// Finish records the outcome of saving the artifact and answers everyone
+// waiting for it.
+func (w *artifactWaits) Finish(
+	clientID string,
+	saved artifacts.SavedArtifact,
+	err error,
+) {
+	if clientID == "" {
+		return
+	}
+
+	result := &service.ArtifactWaitResponse{}
+	if errors.Is(err, artifacts.ErrCancelled) {
+		result.State = service.ArtifactWaitResponse_CANCELLED
+		result.ErrorMessage = err.Error()
+	} else if err != nil {
+		result.State = service.ArtifactWaitResponse_FAILED
+		result.ErrorMessage = err.Error()
+	} else {
+		result.State = service.ArtifactWaitResponse_COMMITTED
+		result.ArtifactId = saved.ID
+		result.Digest = saved.Digest
+		if saved.VersionIndex != nil {
+			result.VersionIndex = wrapperspb.Int32(int32(*saved.VersionIndex))
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	done, ok := w.done[clientID]
+	if !ok || w.results[clientID] != nil {
+		return
+	}
+	w.results[clientID] = result
+	close(done)
+}
+
+// Wait blocks until the artifact with the client ID is saved or fails to
+// be, and returns how it went.
+//
+// If the context is done first, the artifact is reported as in progress.
+func (w *artifactWaits) Wait(
+	ctx context.Context,
+	clientID string,
+) *service.ArtifactWaitResponse {
+	w.mu.Lock()
+	done, ok := w.done[clientID]
+	w.mu.Unlock()
+
+	if !ok {
+		return &service.ArtifactWaitResponse{
+			State: service.ArtifactWaitResponse_FAILED,
+			ErrorMessage: fmt.Sprintf(
+				"no artifact with client ID %q was logged", clientID),
+		}
+	}
+
+	select {
+	case <-done:
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		// each waiter gets its own copy, as results are sent concurrently
+		return proto.Clone(w.results[clientID]).(*service.ArtifactWaitResponse)
+	case <-ctx.Done():
+		return &service.ArtifactWaitResponse{
+			State: service.ArtifactWaitResponse_IN_PROGRESS,
+		}
+	}
+}
+
+// Cancel cancels saving the artifact with the client ID, and answers once
+// it stopped being saved.
+//
+// If the context is done first, the artifact is reported as not being
+// saved, though it may still be.
+func (w *artifactWaits) Cancel(
+	ctx context.Context,
+	clientID string,
+) *service.ArtifactCancelResponse {
+	w.mu.Lock()
+	cancellation := w.cancellations[clientID]
+	w.mu.Unlock()
+
+	if cancellation == nil {
+		return &service.ArtifactCancelResponse{
+			State: service.ArtifactCancelResponse_NOT_IN_PROGRESS,
+			ErrorMessage: fmt.Sprintf(
+				"no artifact with client ID %q was logged", clientID),
+		}
+	}
+
+	// whether or not it's committed first, its outcome tells what happened
+	cancellation.Cancel()
+	result := w.Wait(ctx, clientID)
+	switch result.GetState() {
+	case service.ArtifactWaitResponse_CANCELLED:
+		return &service.ArtifactCancelResponse{
+			State: service.ArtifactCancelResponse_CANCELLED,
+		}
+
+	case service.ArtifactWaitResponse_COMMITTED:
+		return &service.ArtifactCancelResponse{
+			State: service.ArtifactCancelResponse_ALREADY_COMMITTED,
+			ErrorMessage: fmt.Sprintf(
+				"the artifact with client ID %q is already committed,"+
+					" and committed versions can't be cancelled",
+				clientID),
+		}
+
+	case service.ArtifactWaitResponse_FAILED:
+		return &service.ArtifactCancelResponse{
+			State: service.ArtifactCancelResponse_NOT_IN_PROGRESS,
+			ErrorMessage: fmt.Sprintf(
+				"the artifact with client ID %q already failed to be saved: %s",
+				clientID, result.GetErrorMessage()),
+		}
+
+	default:
+		return &service.ArtifactCancelResponse{
+			State: service.ArtifactCancelResponse_NOT_IN_PROGRESS,
+			ErrorMessage: fmt.Sprintf(
+				"the artifact with client ID %q didn't stop being saved in time",
+				clientID),
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/pkg/artifacts"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestArtifactWaits_TimeoutIsInProgress(t *testing.T) {
+	waits := newArtifactWaits()
+	waits.Start("client-id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result := waits.Wait(ctx, "client-id")
+
+	assert.Equal(t, service.ArtifactWaitResponse_IN_PROGRESS, result.State)
+	assert.Empty(t, result.ErrorMessage)
+}
+
+func TestArtifactWaits_FinishAnswersAllWaiters(t *testing.T) {
+	waits := newArtifactWaits()
+	waits.Start("client-id")
+
+	var wg sync.WaitGroup
+	results := make([]*service.ArtifactWaitResponse, 3)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = waits.Wait(context.Background(), "client-id")
+		}()
+	}
+	waits.Finish("client-id", artifacts.SavedArtifact{}, errors.New("upload failed"))
+	wg.Wait()
+
+	for _, result := range results {
+		assert.Equal(t, service.ArtifactWaitResponse_FAILED, result.State)
+		assert.Equal(t, "upload failed", result.ErrorMessage)
+	}
+}
+
+func TestArtifactWaits_AfterFinish(t *testing.T) {
+	waits := newArtifactWaits()
+	version := 2
+	waits.Start("client-id")
+	waits.Finish("client-id",
+		artifacts.SavedArtifact{ID: "id", Digest: "digest", VersionIndex: &version},
+		nil)
+
+	result := waits.Wait(context.Background(), "client-id")
+
+	assert.Equal(t, service.ArtifactWaitResponse_COMMITTED, result.State)
+	assert.Equal(t, "id", result.ArtifactId)
+	assert.EqualValues(t, 2, result.GetVersionIndex().GetValue())
+}
+
+func TestArtifactWaits_CancelUnknown(t *testing.T) {
+	waits := newArtifactWaits()
+
+	result := waits.Cancel(context.Background(), "client-id")
+
+	assert.Equal(t, service.ArtifactCancelResponse_NOT_IN_PROGRESS, result.State)
+}
+
+func TestArtifactWaits_CancelAnswersWaiters(t *testing.T) {
+	waits := newArtifactWaits()
+	waits.Start("client-id")
+
+	waited := make(chan *service.ArtifactWaitResponse)
+	go func() { waited <- waits.Wait(context.Background(), "client-id") }()
+	cancelled := make(chan *service.ArtifactCancelResponse)
+	go func() { cancelled <- waits.Cancel(context.Background(), "client-id") }()
+	waits.Finish("client-id", artifacts.SavedArtifact{}, artifacts.ErrCancelled)
+
+	assert.Equal(t, service.ArtifactCancelResponse_CANCELLED, (<-cancelled).State)
+	assert.Equal(t, service.ArtifactWaitResponse_CANCELLED, (<-waited).State)
+}
+
+func TestArtifactWaits_CancelCommitted(t *testing.T) {
+	waits := newArtifactWaits()
+	waits.Start("client-id")
+	waits.Finish("client-id", artifacts.SavedArtifact{ID: "id"}, nil)
+
+	result := waits.Cancel(context.Background(), "client-id")
+
+	assert.Equal(t, service.ArtifactCancelResponse_ALREADY_COMMITTED, result.State)
+	assert.Contains(t, result.ErrorMessage, "already committed")
+}
@@ -0,0 +1,123 @@
+package server_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/gqlmock"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const committedCreateArtifactResponse = `{
+	"createArtifact": {
+		"artifact": {
+			"id": "artifact-id",
+			"state": "COMMITTED",
+			"versionIndex": 3
+		}
+	}
+}`
+
+func artifactWaitRecord(uuid string, clientID string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_ArtifactWait{
+					ArtifactWait: &service.ArtifactWaitRequest{ClientId: clientID},
+				},
+			},
+		},
+		Uuid: uuid,
+	}
+}
+
+// waitResponses returns the responses to artifact wait requests by the
+// UUIDs of the requests.
+func waitResponses(
+	results chan *service.Result,
+	n int,
+) map[string]*service.ArtifactWaitResponse {
+	responses := make(map[string]*service.ArtifactWaitResponse)
+	for len(responses) < n {
+		result := <-results
+		if response := result.GetResponse().GetArtifactWaitResponse(); response != nil {
+			responses[result.GetUuid()] = response
+		}
+	}
+	return responses
+}
+
+func TestArtifactWait_AnswersEveryWaiter(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateArtifact"),
+		committedCreateArtifactResponse,
+	)
+	results := make(chan *service.Result, 10)
+	sender := makeSender(mockGQL, make(chan *service.Record, 10), results)
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Artifact{
+			Artifact: &service.ArtifactRecord{
+				RunId:    "test-run-id",
+				Project:  "test-project",
+				Entity:   "test-entity",
+				Type:     "dataset",
+				Name:     "test-artifact",
+				Digest:   "test-digest",
+				Manifest: &service.ArtifactManifest{Version: 1},
+				Finalize: true,
+				ClientId: "client-id",
+			},
+		},
+	})
+	sender.SendRecord(artifactWaitRecord("first", "client-id"))
+	sender.SendRecord(artifactWaitRecord("second", "client-id"))
+	responses := waitResponses(results, 2)
+	sender.Close()
+
+	for _, uuid := range []string{"first", "second"} {
+		response := responses[uuid]
+		require.NotNil(t, response, uuid)
+		assert.Equal(t, service.ArtifactWaitResponse_COMMITTED, response.State)
+		assert.Equal(t, "artifact-id", response.ArtifactId)
+		assert.Equal(t, "test-digest", response.Digest)
+		assert.EqualValues(t, 3, response.GetVersionIndex().GetValue())
+	}
+}
+
+func TestArtifactWait_FailedArtifact(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	results := make(chan *service.Result, 10)
+	sender := makeSender(mockGQL, make(chan *service.Record, 10), results)
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Artifact{
+			Artifact: &service.ArtifactRecord{
+				Name:     "test-artifact",
+				Manifest: &service.ArtifactManifest{Version: 1},
+				ClientId: "client-id",
+			},
+		},
+	})
+	sender.SendRecord(artifactWaitRecord("wait", "client-id"))
+	response := waitResponses(results, 1)["wait"]
+	sender.Close()
+
+	assert.Equal(t, service.ArtifactWaitResponse_FAILED, response.State)
+	assert.NotEmpty(t, response.ErrorMessage)
+}
+
+func TestArtifactWait_UnknownArtifact(t *testing.T) {
+	results := make(chan *service.Result, 10)
+	sender := makeSender(gqlmock.NewMockClient(), make(chan *service.Record, 10), results)
+
+	sender.SendRecord(artifactWaitRecord("wait", "never-logged"))
+	response := waitResponses(results, 1)["wait"]
+	sender.Close()
+
+	assert.Equal(t, service.ArtifactWaitResponse_FAILED, response.State)
+	assert.Contains(t, response.ErrorMessage, "never-logged")
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// attachTimeout is the longest attaching waits for the handler to return
+// the stream's run.
+const attachTimeout = time.Minute
+
+// Attach adds the responder to the stream, and returns the stream's run
+// and whether it's provisional, not yet confirmed by the server.
+//
+// This is how a new client connection, like one from a restarted notebook
+// kernel, takes over a stream whose original connection went away: the
+// results of the records it sends are routed back to it.
+func (s *Stream) Attach(
+	ctx context.Context,
+	entry ResponderEntry,
+) (*service.RunRecord, bool, error) {
+	if s.closed.Load() {
+		return nil, false, ErrStreamFinished
+	}
+
+	s.AddResponders(entry)
+
+	// the run is asked for through the handler, which owns it, and comes
+	// back once the records sent before the attach are handled
+	ctx, cancel := context.WithTimeout(ctx, attachTimeout)
+	defer cancel()
+	slot := s.mailbox.Reserve()
+	s.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Attach{
+					Attach: &service.AttachRequest{AttachId: s.settings.GetRunID()},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: slot.ID()},
+	})
+
+	result, err := slot.Wait(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("no run from the stream: %v", err)
+	}
+	response := result.GetResponse().GetAttachResponse()
+	return response.GetRun(), response.GetProvisional(), nil
+}
+
+// handleInformAttach is called when the client sends an InformAttach message
+// to the server, to attach to an existing stream.
+// this is used for attaching to a stream that was previously started
+// hence multiple clients can attach to the same stream
+func (nc *Connection) handleInformAttach(msg *service.ServerInformAttachRequest) {
+	streamId := msg.GetXInfo().GetStreamId()
+	slog.Info("handle attach received", "streamId", streamId, "id", nc.id)
+
+	response := &service.ServerInformAttachResponse{XInfo: msg.XInfo}
+	stream, err := streamMux.GetStream(streamId)
+	var run *service.RunRecord
+	var provisional bool
+	if err == nil {
+		run, provisional, err = stream.Attach(nc.ctx, ResponderEntry{nc, nc.id})
+	}
+
+	if err != nil {
+		slog.Error("handleInformAttach: can't attach", "err", err, "streamId", streamId, "id", nc.id)
+		response.Error = attachError(streamId, err)
+	} else {
+		nc.stream = stream
+		response.Settings = stream.settings.Proto
+		response.Run = run
+		response.Provisional = provisional
+	}
+
+	nc.Respond(&service.ServerResponse{
+		ServerResponseType: &service.ServerResponse_InformAttachResponse{
+			InformAttachResponse: response,
+		},
+	})
+}
+
+// attachError is the error to show the user for failing to attach to the
+// stream.
+func attachError(streamId string, err error) *service.ErrorInfo {
+	switch {
+	case errors.Is(err, ErrStreamNotFound):
+		return &service.ErrorInfo{
+			Code: service.ErrorInfo_USAGE,
+			Message: fmt.Sprintf(
+				"Can't attach to run %s: it isn't running in this wandb service.",
+				streamId),
+		}
+	case errors.Is(err, ErrStreamFinished):
+		return &service.ErrorInfo{
+			Code:    service.ErrorInfo_USAGE,
+			Message: fmt.Sprintf("Can't attach to run %s: it has already finished.", streamId),
+		}
+	default:
+		return &service.ErrorInfo{
+			Code:    service.ErrorInfo_UNKNOWN,
+			Message: fmt.Sprintf("Can't attach to run %s: %v", streamId, err),
+		}
+	}
+}
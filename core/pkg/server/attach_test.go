@@ -0,0 +1,213 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// testClient talks to connections the way the wandb library does.
+type testClient struct {
+	t       *testing.T
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+// serveConnections accepts connections until the test ends, and returns
+// a function to connect a new client.
+func serveConnections(t *testing.T) func() *testClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.NewConnection(ctx, cancel, conn).HandleConnection()
+		}
+	}()
+
+	return func() *testClient {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(conn)
+		scanner.Split((&server.Tokenizer{}).Split)
+		return &testClient{t: t, conn: conn, scanner: scanner}
+	}
+}
+
+func (c *testClient) send(request *service.ServerRequest) {
+	data, err := proto.Marshal(request)
+	require.NoError(c.t, err)
+	header := server.Header{Magic: byte('W'), DataLength: uint32(len(data))}
+	require.NoError(c.t, binary.Write(c.conn, binary.LittleEndian, &header))
+	_, err = c.conn.Write(data)
+	require.NoError(c.t, err)
+}
+
+func (c *testClient) publish(streamId string, record *service.Record) {
+	record.XInfo = &service.XRecordInfo{StreamId: streamId}
+	c.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_RecordPublish{RecordPublish: record},
+	})
+}
+
+func (c *testClient) communicate(streamId string, record *service.Record) {
+	record.XInfo = &service.XRecordInfo{StreamId: streamId}
+	c.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_RecordCommunicate{RecordCommunicate: record},
+	})
+}
+
+// receive returns the first response that matches, skipping the others.
+func (c *testClient) receive(matches func(*service.ServerResponse) bool) *service.ServerResponse {
+	require.NoError(c.t, c.conn.SetReadDeadline(time.Now().Add(30*time.Second)))
+	for c.scanner.Scan() {
+		response := &service.ServerResponse{}
+		require.NoError(c.t, proto.Unmarshal(c.scanner.Bytes(), response))
+		if matches(response) {
+			return response
+		}
+	}
+	require.FailNow(c.t, "no response", "error: %v", c.scanner.Err())
+	return nil
+}
+
+func (c *testClient) attach(streamId string) *service.ServerInformAttachResponse {
+	c.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_InformAttach{
+			InformAttach: &service.ServerInformAttachRequest{
+				XInfo: &service.XRecordInfo{StreamId: streamId},
+			},
+		},
+	})
+	return c.receive(func(response *service.ServerResponse) bool {
+		return response.GetInformAttachResponse() != nil
+	}).GetInformAttachResponse()
+}
+
+func TestConnection_AttachToLiveStream(t *testing.T) {
+	connect := serveConnections(t)
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-attach.wandb")
+	const streamId = "attach-live"
+
+	// The original client starts the run, logs a row, and goes away.
+	original := connect()
+	original.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_InformInit{
+			InformInit: &service.ServerInformInitRequest{
+				Settings: &service.Settings{
+					RunId:         &wrapperspb.StringValue{Value: streamId},
+					LogDir:        &wrapperspb.StringValue{Value: dir},
+					LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+					SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+					FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+					XOffline:      &wrapperspb.BoolValue{Value: true},
+					XDisableStats: &wrapperspb.BoolValue{Value: true},
+					XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+				},
+				XInfo: &service.XRecordInfo{StreamId: streamId},
+			},
+		},
+	})
+	run := &service.RunRecord{RunId: streamId, Project: "attach"}
+	original.publish(streamId, &service.Record{
+		RecordType: &service.Record_Run{Run: run},
+	})
+	original.publish(streamId, &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_RunStart{
+					RunStart: &service.RunStartRequest{Run: run},
+				},
+			},
+		},
+	})
+	original.publish(streamId, makePartialHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		step:  0,
+		flush: true,
+	}))
+	original.communicate(streamId, &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Status{Status: &service.StatusRequest{}},
+			},
+		},
+	})
+	original.receive(func(response *service.ServerResponse) bool {
+		return response.GetResultCommunicate().GetResponse().GetStatusResponse() != nil
+	})
+	require.NoError(t, original.conn.Close())
+
+	// A new client attaches, logs another row, and finishes the run.
+	attached := connect()
+	response := attached.attach(streamId)
+	require.Nil(t, response.GetError())
+	assert.Equal(t, streamId, response.GetSettings().GetRunId().GetValue())
+	assert.Equal(t, streamId, response.GetRun().GetRunId())
+	assert.Equal(t, "attach", response.GetRun().GetProject())
+	// offline runs are never created on the server, so are as the client
+	// started them
+	assert.False(t, response.GetProvisional())
+
+	attached.publish(streamId, makePartialHistoryRecord(data{
+		items: map[string]string{"loss": "0.25"},
+		step:  1,
+		flush: true,
+	}))
+	attached.communicate(streamId, &service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{AlwaysSend: true, ReqResp: true},
+	})
+	attached.receive(func(response *service.ServerResponse) bool {
+		return response.GetResultCommunicate().GetExitResult() != nil
+	})
+	attached.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_InformFinish{
+			InformFinish: &service.ServerInformFinishRequest{
+				XInfo: &service.XRecordInfo{StreamId: streamId},
+			},
+		},
+	})
+
+	finished := attached.attach(streamId)
+	assert.Equal(t, service.ErrorInfo_USAGE, finished.GetError().GetCode())
+	assert.Contains(t, finished.GetError().GetMessage(), "already finished")
+	assert.Nil(t, finished.GetRun())
+
+	state, err := server.Replay(syncFile, -1, false)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.5, 0.25}, state.SampledHistory["loss"])
+	assert.EqualValues(t, 0.25, state.Summary["loss"])
+}
+
+func TestConnection_AttachToUnknownStream(t *testing.T) {
+	connect := serveConnections(t)
+
+	response := connect().attach("attach-unknown")
+
+	assert.Equal(t, service.ErrorInfo_USAGE, response.GetError().GetCode())
+	assert.Contains(t, response.GetError().GetMessage(), "isn't running")
+	assert.Nil(t, response.GetSettings())
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// ErrBackendCongested is why the stream rejects a record while the sender
+// is too far behind the backend to take in more.
+var ErrBackendCongested = errors.New("the backend is congested")
+
+const (
+	// backpressureCheckInterval is how often the sender checks its backlog
+	// while no records arrive, so that backpressure is released once the
+	// backlog drains.
+	backpressureCheckInterval = time.Second
+
+	// maxHeldHistoryRows is the most history rows the handler holds back
+	// while slowed down, after which the oldest are forwarded.
+	maxHeldHistoryRows = 256
+
+	// slowOutputSampling is how many lines of console output are kept
+	// for each one uploaded while slowed down.
+	slowOutputSampling = 10
+
+	// congestionRetryInterval is how often a connection retries a record
+	// rejected for congestion.
+	congestionRetryInterval = 10 * time.Millisecond
+
+	// maxCongestionWait is the longest a connection holds back a record
+	// rejected for congestion, before handing it over regardless.
+	maxCongestionWait = time.Second
+)
+
+// Backpressure slows down the stream's intake while the sender is behind.
+//
+// When the backend is slow, records pile up between the writer and the
+// sender, and in the filestream's queues. The sender reports its backlog to
+// the handler through the loopback channel whenever it crosses one of two
+// thresholds. Over the first, the handler holds back history rows and
+// uploads only a sample of the console output; the rest of it is still
+// saved in the transaction log. Over the second, the stream also rejects
+// history and console output with ErrBackendCongested, so that the
+// connection throttles the client.
+//
+// Each threshold is released once the backlog is under half of it, and
+// both are released when the run exits.
+//
+// The levels are set by the handler's goroutine and may be read from any.
+//
+// The methods of a nil Backpressure never slow anything down.
+type Backpressure struct {
+	slowDepth      int
+	congestedDepth int
+
+	// slow and congested are the levels the handler was last told of
+	slow      atomic.Bool
+	congested atomic.Bool
+
+	// reported is the last report the sender sent, or nil; it's only used
+	// by the sender's goroutine
+	reported *service.BackpressureRequest
+}
+
+// NewBackpressure returns backpressure with the thresholds, or nil if
+// neither is positive.
+func NewBackpressure(slowDepth, congestedDepth int) *Backpressure {
+	if slowDepth <= 0 && congestedDepth <= 0 {
+		return nil
+	}
+	return &Backpressure{slowDepth: slowDepth, congestedDepth: congestedDepth}
+}
+
+// IsSlow returns whether the handler is slowing down its intake.
+func (b *Backpressure) IsSlow() bool {
+	return b != nil && b.slow.Load()
+}
+
+// IsCongested returns whether history and console output are rejected.
+func (b *Backpressure) IsCongested() bool {
+	return b != nil && b.congested.Load()
+}
+
+// report returns what to tell the handler about a backlog of pending
+// records and updates, or nil if the levels didn't change.
+func (b *Backpressure) report(pending int) *service.BackpressureRequest {
+	if b == nil {
+		return nil
+	}
+
+	report := &service.BackpressureRequest{
+		Pending:   int32(pending),
+		Slow:      isOverDepth(pending, b.slowDepth, b.reported.GetSlow()),
+		Congested: isOverDepth(pending, b.congestedDepth, b.reported.GetCongested()),
+	}
+	// congestion slows down intake too
+	report.Slow = report.Slow || report.Congested
+
+	if report.Slow == b.reported.GetSlow() &&
+		report.Congested == b.reported.GetCongested() {
+		return nil
+	}
+	b.reported = report
+	return report
+}
+
+// isOverDepth returns whether a backlog is over a threshold, which is
+// released only once the backlog is under half of it.
+func isOverDepth(pending int, depth int, engaged bool) bool {
+	switch {
+	case depth <= 0:
+		return false
+	case engaged:
+		return pending*2 >= depth
+	default:
+		return pending >= depth
+	}
+}
+
+// set sets the levels the handler was told of.
+func (b *Backpressure) set(slow, congested bool) {
+	if b == nil {
+		return
+	}
+	b.slow.Store(slow)
+	b.congested.Store(congested)
+}
+
+// isThrottled returns whether a record is slowed down and rejected under
+// backpressure.
+//
+// Only history and console output are, as the client can log them at a
+// rate the backend can't keep up with, and delaying them changes nothing
+// else about the run.
+func isThrottled(record *service.Record) bool {
+	switch x := record.GetRecordType().(type) {
+	case *service.Record_History,
+		*service.Record_Output,
+		*service.Record_OutputRaw:
+		return true
+	case *service.Record_Request:
+		return x.Request.GetPartialHistory() != nil
+	default:
+		return false
+	}
+}
+
+// reportBacklog tells the handler if the sender's backlog crossed one of
+// the backpressure thresholds.
+//
+// waiting is the number of records waiting for the sender.
+func (s *Sender) reportBacklog(waiting int) {
+	// once the run exits, the handler no longer slows down
+	if s.backpressure == nil || s.exitRecord != nil {
+		return
+	}
+
+	// once the stream is aborted, the handler may no longer be listening
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+	if s.abandoned.Load() {
+		return
+	}
+
+	pending := waiting
+	if s.fileStream != nil {
+		pending += s.fileStream.Backlog().QueuedUpdates
+	}
+
+	report := s.backpressure.report(pending)
+	if report == nil {
+		return
+	}
+	s.fwdRecord(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_Backpressure{Backpressure: report},
+		}},
+		Control: &service.Control{Local: true},
+	})
+}
+
+// handleRequestBackpressure slows down or speeds up the handler's intake
+// as the sender reports its backlog.
+func (h *Handler) handleRequestBackpressure(request *service.BackpressureRequest) {
+	// the run's exit releases backpressure for good
+	if h.exiting {
+		return
+	}
+
+	wasSlow := h.backpressure.IsSlow()
+	h.backpressure.set(request.GetSlow(), request.GetCongested())
+	h.logger.Info(
+		"handler: backpressure changed",
+		"pending", request.GetPending(),
+		"slow", request.GetSlow(),
+		"congested", request.GetCongested(),
+	)
+
+	switch {
+	case request.GetSlow() && !wasSlow:
+		h.terminalPrinter.Emit(observability.UserMessage{
+			Level: observability.MessageWarning,
+			Key:   "backend-slow",
+			Text: "W&B is uploading data slower than it's logged. Until it" +
+				" catches up, history is uploaded late and only some console" +
+				" output is uploaded, though all of it is saved locally.",
+		})
+	case !request.GetSlow():
+		h.releaseHistory()
+	}
+}
+
+// releaseBackpressure stops slowing down the handler's intake, such as
+// when the run exits.
+func (h *Handler) releaseBackpressure() {
+	h.backpressure.set(false, false)
+	h.releaseHistory()
+}
+
+// holdHistory holds back a history row while slowed down, and returns
+// whether it did.
+//
+// Rows are held in order, and forwarded once backpressure is released,
+// or oldest first once too many are held.
+func (h *Handler) holdHistory(record *service.Record) bool {
+	if !h.backpressure.IsSlow() || h.exiting {
+		return false
+	}
+
+	h.heldHistory = append(h.heldHistory, record)
+	if len(h.heldHistory) > maxHeldHistoryRows {
+		oldest := h.heldHistory[0]
+		h.heldHistory = h.heldHistory[1:]
+		h.fwdHistory(oldest)
+	}
+	return true
+}
+
+// releaseHistory forwards the history rows held back, in order.
+func (h *Handler) releaseHistory() {
+	held := h.heldHistory
+	h.heldHistory = nil
+	for _, record := range held {
+		h.fwdHistory(record)
+	}
+}
+
+// sampleOutput forwards console output, of which only a sample is sent
+// while slowed down. The rest is only saved in the transaction log.
+func (h *Handler) sampleOutput(record *service.Record) {
+	if !h.backpressure.IsSlow() || h.exiting {
+		h.fwdRecord(record)
+		return
+	}
+
+	h.outputLines++
+	if h.outputLines%slowOutputSampling == 1 {
+		h.fwdRecord(record)
+		return
+	}
+	h.fwdRecordWithControl(record, func(control *service.Control) {
+		control.PersistOnly = true
+	})
+}
+
+// handleThrottled hands a record to the stream, holding it back while the
+// backend is congested so that the client is throttled.
+//
+// Records are read from the client one at a time, so while one is held
+// back, the client's writes back up. A record is held back for at most
+// maxCongestionWait, so that the client is only slowed down, never
+// stopped.
+func (nc *Connection) handleThrottled(record *service.Record) {
+	deadline := time.Now().Add(maxCongestionWait)
+	for {
+		err := nc.stream.TryHandleRecord(record)
+		if !errors.Is(err, ErrBackendCongested) {
+			return
+		}
+		if time.Now().After(deadline) {
+			slog.Debug("connection: handing over record despite congestion", "id", nc.id)
+			nc.stream.HandleRecord(record)
+			return
+		}
+		time.Sleep(congestionRetryInterval)
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Each threshold is crossed at its depth, and released once the backlog is
+// under half of it.
+func TestBackpressure_ReportsCrossedThresholds(t *testing.T) {
+	backpressure := NewBackpressure(10, 20)
+
+	steps := []struct {
+		pending   int
+		changed   bool
+		slow      bool
+		congested bool
+	}{
+		{pending: 5},
+		{pending: 10, changed: true, slow: true},
+		{pending: 6},
+		{pending: 20, changed: true, slow: true, congested: true},
+		{pending: 10},
+		{pending: 9, changed: true, slow: true},
+		{pending: 5},
+		{pending: 4, changed: true},
+		{pending: 9},
+	}
+	for _, step := range steps {
+		report := backpressure.report(step.pending)
+		if !step.changed {
+			assert.Nil(t, report, "pending %d", step.pending)
+			continue
+		}
+		if assert.NotNil(t, report, "pending %d", step.pending) {
+			assert.Equal(t, step.slow, report.GetSlow(), "pending %d", step.pending)
+			assert.Equal(t, step.congested, report.GetCongested(), "pending %d", step.pending)
+		}
+	}
+}
+
+func TestBackpressure_CongestedOnly(t *testing.T) {
+	backpressure := NewBackpressure(0, 8)
+
+	report := backpressure.report(8)
+
+	assert.True(t, report.GetSlow())
+	assert.True(t, report.GetCongested())
+}
+
+func TestBackpressure_Disabled(t *testing.T) {
+	backpressure := NewBackpressure(0, 0)
+
+	assert.Nil(t, backpressure)
+	assert.Nil(t, backpressure.report(1000))
+	assert.False(t, backpressure.IsSlow())
+	assert.False(t, backpressure.IsCongested())
+}
+
+func TestIsThrottled(t *testing.T) {
+	assert.True(t, isThrottled(&service.Record{
+		RecordType: &service.Record_History{History: &service.HistoryRecord{}},
+	}))
+	assert.True(t, isThrottled(&service.Record{
+		RecordType: &service.Record_OutputRaw{OutputRaw: &service.OutputRawRecord{}},
+	}))
+	assert.True(t, isThrottled(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_PartialHistory{
+				PartialHistory: &service.PartialHistoryRequest{},
+			},
+		}},
+	}))
+	assert.False(t, isThrottled(&service.Record{
+		RecordType: &service.Record_Summary{Summary: &service.SummaryRecord{}},
+	}))
+	assert.False(t, isThrottled(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+	}))
+}
@@ -0,0 +1,180 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// streamedHistoryLines counts the distinct history lines the backend
+// received through the filestream.
+func streamedHistoryLines(t *testing.T, backend *servertest.FakeBackend) int {
+	t.Helper()
+	offsets := make(map[int]bool)
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Offset  int      `json:"offset"`
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		history := body.Files["wandb-history.jsonl"]
+		for i := range history.Content {
+			offsets[history.Offset+i] = true
+		}
+	}
+	return len(offsets)
+}
+
+// While the backend is slow, history is rejected once the sender's backlog
+// is over the threshold, and accepted again once it drains, without any
+// accepted row being lost.
+func TestBackpressure_RejectsWhileCongestedAndRecovers(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	backend.InjectFault(servertest.RouteFileStream, servertest.Fault{Delay: 3 * time.Second})
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "backpressure"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-backpressure.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XBackpressureSlowDepth:      &wrapperspb.Int32Value{Value: 4},
+		XBackpressureCongestedDepth: &wrapperspb.Int32Value{Value: 8},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "backpressure", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	awaitResult(t, responses, "run")
+	stream.HandleRecord(makeRunStartRecord())
+
+	accepted := 0
+	logRow := func() error {
+		err := stream.TryHandleRecord(makeHistoryRecord(data{
+			items: map[string]string{"loss": fmt.Sprint(accepted)},
+			step:  int64(accepted),
+		}))
+		if err == nil {
+			accepted++
+		}
+		return err
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for err := logRow(); err == nil; err = logRow() {
+		require.True(t, time.Now().Before(deadline), "history was never rejected")
+	}
+	require.ErrorIs(t, logRow(), server.ErrBackendCongested)
+
+	deadline = time.Now().Add(15 * time.Second)
+	for logRow() != nil {
+		require.True(t, time.Now().Before(deadline),
+			"history was still rejected once the backlog drained")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stream.FinishAndClose(0)
+	assert.Equal(t, accepted, streamedHistoryLines(t, backend))
+}
+
+func backpressureRecord(slow, congested bool) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_Backpressure{
+				Backpressure: &service.BackpressureRequest{
+					Slow:      slow,
+					Congested: congested,
+				},
+			},
+		}},
+	}
+}
+
+// While slowed down, the handler holds back history rows and sends only a
+// sample of the console output, and it catches up once released.
+func TestBackpressure_HandlerSlowsDownAndCatchesUp(t *testing.T) {
+	inChan := make(chan *service.Record, 64)
+	fwdChan := make(chan *service.Record, 64)
+	backpressure := server.NewBackpressure(4, 8)
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{},
+		FwdChan:         fwdChan,
+		OutChan:         make(chan *service.Result, 16),
+		TerminalPrinter: observability.NewPrinter(),
+		RunSummary:      runsummary.New(),
+		MetricHandler:   server.NewMetricHandler(),
+		Backpressure:    backpressure,
+	})
+	go handler.Do(inChan)
+	defer close(inChan)
+
+	inChan <- backpressureRecord(true, false)
+	for i := range 3 {
+		inChan <- makeHistoryRecord(data{
+			items: map[string]string{"loss": fmt.Sprint(i)},
+			step:  int64(i),
+		})
+	}
+	for range 20 {
+		inChan <- &service.Record{
+			RecordType: &service.Record_Output{Output: &service.OutputRecord{Line: "line"}},
+		}
+	}
+	inChan <- backpressureRecord(false, false)
+	inChan <- makeHistoryRecord(data{items: map[string]string{"loss": "3"}, step: 3})
+
+	var steps []int64
+	var sent, persisted int
+	for len(steps) < 4 {
+		select {
+		case record := <-fwdChan:
+			switch {
+			case record.GetHistory() != nil:
+				steps = append(steps, record.GetHistory().GetStep().GetNum())
+				// rows come only once released, after all the output
+				assert.Equal(t, 20, sent+persisted)
+			case record.GetOutput() != nil && record.GetControl().GetPersistOnly():
+				persisted++
+			case record.GetOutput() != nil:
+				sent++
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("history wasn't released")
+		}
+	}
+	assert.Equal(t, []int64{0, 1, 2, 3}, steps)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, 18, persisted)
+	assert.False(t, backpressure.IsSlow())
+}
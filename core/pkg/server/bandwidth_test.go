@@ -0,0 +1,48 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/bandwidth"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// A settings update changes the bandwidth caps it sets, and leaves the
+// rest as they are.
+func TestSendSettingsUpdate_ChangesBandwidthCaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uploads := bandwidth.NewLimiter(0)
+	fileStream := bandwidth.NewLimiter(50_000)
+	sender := server.NewSender(ctx, cancel, &server.SenderParams{
+		Logger:            observability.NewNoOpLogger(),
+		Settings:          &service.Settings{},
+		UploadLimiter:     uploads,
+		FileStreamLimiter: fileStream,
+		FwdChan:           make(chan *service.Record, 1),
+		OutChan:           make(chan *service.Result, 1),
+		Mailbox:           mailbox.NewMailbox(),
+	})
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_SettingsUpdate{
+					SettingsUpdate: &service.SettingsUpdateRequest{
+						UploadBytesPerSecond: wrapperspb.Int64(1_000_000),
+					},
+				},
+			},
+		},
+	})
+
+	assert.EqualValues(t, 1_000_000, uploads.BytesPerSecond())
+	assert.EqualValues(t, 50_000, fileStream.BytesPerSecond())
+}
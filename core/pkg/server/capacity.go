@@ -0,0 +1,74 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrAtCapacity is why a connection or stream is rejected when the server
+// already has as many as it's limited to.
+var ErrAtCapacity = errors.New("wandb-core is at capacity")
+
+// rejectTimeout is the longest a rejected connection is kept open, waiting
+// for the client to start a run that it can be told about the rejection.
+const rejectTimeout = 10 * time.Second
+
+// initErrorCode is the code of the error returned to the client for why
+// its stream couldn't be created.
+func initErrorCode(err error) service.ErrorInfo_ErrorCode {
+	if errors.Is(err, ErrAtCapacity) {
+		return service.ErrorInfo_CAPACITY
+	}
+	return service.ErrorInfo_USAGE
+}
+
+// HandleRejected handles a connection the server has no capacity for.
+//
+// The connection is kept only until the client starts a run, which is
+// answered with the error so that the client can fail fast or retry later.
+// Other requests are dropped. The connection is closed after at most
+// rejectTimeout, so that rejected clients can't hold on to resources.
+func (nc *Connection) HandleRejected(err error) {
+	nc.initErr = err
+	if err := nc.conn.SetDeadline(time.Now().Add(rejectTimeout)); err != nil {
+		slog.Error("connection: failed to set deadline", "err", err, "id", nc.id)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		nc.handleServerResponse()
+		wg.Done()
+	}()
+
+	nc.respondRejected()
+	if !nc.closed.Swap(true) {
+		close(nc.outChan)
+	}
+	wg.Wait()
+	nc.Close()
+}
+
+// respondRejected reads the client's requests until it starts a run, and
+// responds to it with the error the connection was rejected for.
+func (nc *Connection) respondRejected() {
+	scanner := bufio.NewScanner(nc.conn)
+	scanner.Buffer(make([]byte, messageSize), maxMessageSize)
+	scanner.Split((&Tokenizer{}).Split)
+	for scanner.Scan() {
+		msg := &service.ServerRequest{}
+		if err := proto.Unmarshal(scanner.Bytes(), msg); err != nil {
+			continue
+		}
+		if record := msg.GetRecordCommunicate(); record.GetRun() != nil {
+			nc.respondInitError(record)
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// startServer starts a server that handles at most one connection, and
+// returns a function to connect a new client to it.
+func startServer(t *testing.T) func() *testClient {
+	portFile := filepath.Join(t.TempDir(), "port.txt")
+	ctx, cancel := context.WithCancel(context.Background())
+	srv, err := server.NewServer(ctx, "127.0.0.1:0", portFile)
+	require.NoError(t, err)
+	srv.SetMaxConnections(1)
+	srv.Start()
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+
+	port := readPort(t, portFile)
+	return func() *testClient {
+		conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", port))
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(conn)
+		scanner.Split((&server.Tokenizer{}).Split)
+		return &testClient{t: t, conn: conn, scanner: scanner}
+	}
+}
+
+// readPort returns the port from the server's port file.
+func readPort(t *testing.T, portFile string) string {
+	data, err := os.ReadFile(portFile)
+	require.NoError(t, err)
+	var port int
+	_, err = fmt.Sscanf(string(data), "sock=%d", &port)
+	require.NoError(t, err)
+	return strconv.Itoa(port)
+}
+
+// startRun starts a run the way the wandb library does, and returns the
+// error it fails to start with, if any.
+func (c *testClient) startRun(streamId string) *service.ErrorInfo {
+	return c.startRunWithSettings(streamId, &service.Settings{})
+}
+
+// startRunWithSettings is startRun for a run with the settings.
+func (c *testClient) startRunWithSettings(
+	streamId string,
+	settings *service.Settings,
+) *service.ErrorInfo {
+	c.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_InformInit{
+			InformInit: &service.ServerInformInitRequest{
+				Settings: settings,
+				XInfo:    &service.XRecordInfo{StreamId: streamId},
+			},
+		},
+	})
+	c.communicate(streamId, &service.Record{
+		RecordType: &service.Record_Run{Run: &service.RunRecord{RunId: streamId}},
+		Uuid:       streamId,
+	})
+	return c.receive(func(response *service.ServerResponse) bool {
+		return response.GetResultCommunicate().GetRunResult() != nil
+	}).GetResultCommunicate().GetRunResult().GetError()
+}
+
+// Connections past the limit are told the server is at capacity and
+// closed, without the rejected connections accumulating resources.
+func TestServer_RejectsConnectionsPastLimit(t *testing.T) {
+	connect := startServer(t)
+	held := connect()
+	defer held.conn.Close()
+	// the connection is counted once the server accepts it
+	time.Sleep(100 * time.Millisecond)
+
+	reject := func() {
+		client := connect()
+		defer client.conn.Close()
+
+		err := client.startRun("rejected")
+
+		assert.Equal(t, service.ErrorInfo_CAPACITY, err.GetCode())
+		assert.Contains(t, err.GetMessage(), "1 of 1 connections")
+		assert.False(t, client.scanner.Scan(), "connection wasn't closed")
+	}
+	usage := func() (int, uint64) {
+		runtime.GC()
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return runtime.NumGoroutine(), stats.HeapAlloc
+	}
+
+	for i := 0; i < 50; i++ {
+		reject()
+	}
+	goroutines, heap := usage()
+	for i := 0; i < 500; i++ {
+		reject()
+	}
+	goroutinesAfter, heapAfter := usage()
+
+	assert.LessOrEqual(t, goroutinesAfter, goroutines+5)
+	assert.Less(t, heapAfter, heap+4*1024*1024)
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/runhistory"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// checkpointVersion is the version of the format of the checkpoints
+	// written; checkpoints of other versions are ignored.
+	checkpointVersion = 2
+
+	// defaultCheckpointRecords is how many records the writer stores
+	// between checkpoints.
+	defaultCheckpointRecords = 100_000
+
+	// defaultCheckpointBytes is how many bytes the writer stores between
+	// checkpoints.
+	defaultCheckpointBytes = 64 << 20
+)
+
+var checkpointCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Checkpoints decides when the handler's state is checkpointed in the
+// transaction log.
+//
+// The writer counts the records and bytes it stores, and asks for a
+// checkpoint once there are enough since the last one. The handler then
+// makes one after the record it is handling, so that the checkpoint sits
+// in the log exactly where its state is reached.
+//
+// The methods of a nil Checkpoints do nothing.
+type Checkpoints struct {
+	// records and bytes are how much is stored between checkpoints
+	records int64
+	bytes   int64
+
+	// due is whether the writer asked for a checkpoint
+	due atomic.Bool
+
+	// numberedRecords counts the records the writer numbered since the
+	// last checkpoint, in the writer's goroutine
+	numberedRecords int64
+
+	// startOffset is the offset of the last checkpoint, in the goroutine
+	// that writes the log
+	startOffset int64
+}
+
+// NewCheckpoints returns the Checkpoints for a run, asking for one every
+// records records or bytes bytes, whichever comes first.
+//
+// Zero values use the defaults.
+func NewCheckpoints(records, bytes int64) *Checkpoints {
+	if records <= 0 {
+		records = defaultCheckpointRecords
+	}
+	if bytes <= 0 {
+		bytes = defaultCheckpointBytes
+	}
+	return &Checkpoints{records: records, bytes: bytes}
+}
+
+// numbered is called by the writer when it numbers a record to store.
+//
+// Records are counted as they're numbered rather than once written, so
+// that the handler isn't far ahead when it's asked for a checkpoint.
+func (c *Checkpoints) numbered(record *service.Record) {
+	if c == nil {
+		return
+	}
+
+	if record.GetCheckpoint() != nil {
+		c.numberedRecords = 0
+		return
+	}
+
+	c.numberedRecords++
+	if c.numberedRecords >= c.records {
+		c.due.Store(true)
+	}
+}
+
+// stored is called by the writer after writing a record at the offset.
+//
+// It returns whether the record was a checkpoint.
+func (c *Checkpoints) stored(record *service.Record, offset int64) bool {
+	if c == nil {
+		return false
+	}
+
+	if record.GetCheckpoint() != nil {
+		c.startOffset = offset
+		return true
+	}
+
+	if offset-c.startOffset >= c.bytes {
+		c.due.Store(true)
+	}
+	return false
+}
+
+// takeDue is called by the handler, and returns whether it should make a
+// checkpoint.
+func (c *Checkpoints) takeDue() bool {
+	if c == nil {
+		return false
+	}
+	return c.due.CompareAndSwap(true, false)
+}
+
+// sealCheckpoint sets the checkpoint's version and checksum.
+func sealCheckpoint(checkpoint *service.CheckpointRecord) {
+	checkpoint.Version = checkpointVersion
+	checkpoint.Checksum = checkpointChecksum(checkpoint)
+}
+
+// validateCheckpoint returns an error if the checkpoint can't be used,
+// because it's of another version or it's corrupted.
+func validateCheckpoint(checkpoint *service.CheckpointRecord) error {
+	if checkpoint == nil {
+		return errors.New("not a checkpoint")
+	}
+	if v := checkpoint.GetVersion(); v != checkpointVersion {
+		return fmt.Errorf("unsupported checkpoint version %d", v)
+	}
+	if checkpointChecksum(checkpoint) != checkpoint.GetChecksum() {
+		return errors.New("checkpoint checksum mismatch")
+	}
+	return nil
+}
+
+// checkpointChecksum is the checksum of the checkpoint serialized without
+// its checksum.
+func checkpointChecksum(checkpoint *service.CheckpointRecord) uint32 {
+	unsealed := proto.Clone(checkpoint).(*service.CheckpointRecord)
+	unsealed.Checksum = 0
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(unsealed)
+	if err != nil {
+		// a checksum of a failed serialization can't match
+		return 0
+	}
+	return crc32.Checksum(data, checkpointCRCTable)
+}
+
+// fwdCheckpoint forwards a checkpoint of the handler's state to the writer,
+// which completes it when storing it.
+func (h *Handler) fwdCheckpoint() {
+	summary, err := h.runSummary.Flatten()
+	if err != nil {
+		h.logger.CaptureError("handler: can't checkpoint the summary", err)
+		return
+	}
+	config, err := h.runConfig.Flatten()
+	if err != nil {
+		h.logger.CaptureError("handler: can't checkpoint the config", err)
+		return
+	}
+
+	checkpoint := &service.CheckpointRecord{
+		Config:     config,
+		Summary:    summary,
+		Metrics:    h.metricHandler.allMetrics(),
+		Aggregates: h.metricHandler.aggregateProtos(),
+	}
+	if h.runHistory != nil {
+		checkpoint.Step = h.runHistory.GetStep()
+	}
+	if h.runRecord != nil {
+		// the config is checkpointed on its own, as it is now
+		run := proto.Clone(h.runRecord).(*service.RunRecord)
+		run.Config = nil
+		run.Settings = nil
+		checkpoint.Run = run
+	}
+
+	h.fwdRecord(&service.Record{
+		RecordType: &service.Record_Checkpoint{Checkpoint: checkpoint},
+	})
+}
+
+// handleCheckpoint restores the handler's state from a checkpoint read
+// back from a transaction log.
+//
+// Replaying a log from its start passes its checkpoints too, which then
+// restore the state the handler already has.
+func (h *Handler) handleCheckpoint(checkpoint *service.CheckpointRecord) {
+	if err := validateCheckpoint(checkpoint); err != nil {
+		h.logger.Warn("handler: ignoring checkpoint", "error", err)
+		return
+	}
+
+	onError := func(err error) {
+		h.logger.CaptureError("handler: error restoring checkpoint", err)
+	}
+	h.runSummary.ApplyChangeRecord(
+		&service.SummaryRecord{Update: checkpoint.GetSummary()},
+		onError,
+	)
+	if h.runConfig != nil {
+		h.runConfig.ApplyChangeRecord(
+			&service.ConfigRecord{Update: checkpoint.GetConfig()},
+			onError,
+		)
+	}
+	for _, metric := range checkpoint.GetMetrics() {
+		var err error
+		if metric.GetGlobName() != "" {
+			_, err = addMetric(metric, metric.GetGlobName(), &h.metricHandler.globMetrics)
+		} else {
+			_, err = addMetric(metric, metric.GetName(), &h.metricHandler.definedMetrics)
+		}
+		if err != nil {
+			onError(err)
+		}
+		if key := metric.GetStepMetric(); key != "" {
+			h.trackStepMetric(key)
+		}
+	}
+	h.metricHandler.restoreAggregates(checkpoint.GetAggregates())
+	if h.runHistory == nil && checkpoint.GetStep() > 0 {
+		h.runHistory = runhistory.NewWithStep(checkpoint.GetStep())
+	}
+}
@@ -0,0 +1,184 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// writeCheckpointedLog passes a run with the given number of history rows
+// through a handler and a writer that checkpoint every few records, and
+// returns the path of the transaction log.
+func writeCheckpointedLog(t testing.TB, rows int, every int64) string {
+	path := filepath.Join(t.TempDir(), "run-checkpoint.wandb")
+	settings := &service.Settings{SyncFile: &wrapperspb.StringValue{Value: path}}
+	checkpoints := server.NewCheckpoints(every, 0)
+
+	// the handler is kept in step with the writer, so that it makes the
+	// checkpoints as soon as they're due
+	inChan := make(chan *service.Record)
+	handlerFwd := make(chan *service.Record)
+	writerFwd := make(chan *service.Record, server.BufferSize)
+	outChan := make(chan *service.Result, server.BufferSize)
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        settings,
+		FwdChan:         handlerFwd,
+		OutChan:         outChan,
+		RunSummary:      runsummary.New(),
+		MetricHandler:   server.NewMetricHandler(),
+		TerminalPrinter: observability.NewPrinter(),
+		SamplerRand:     rand.New(rand.NewSource(0)),
+		Checkpoints:     checkpoints,
+	})
+	writer := server.NewWriter(context.Background(), &server.WriterParams{
+		Logger:      observability.NewNoOpLogger(),
+		Settings:    settings,
+		FwdChan:     writerFwd,
+		Checkpoints: checkpoints,
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() { handler.Do(inChan); wg.Done() }()
+	go func() { writer.Do(handlerFwd); wg.Done() }()
+	go func() {
+		for range writerFwd {
+		}
+		wg.Done()
+	}()
+	go func() {
+		for range outChan {
+		}
+		wg.Done()
+	}()
+
+	start := time.Unix(1700000000, 0)
+	run := &service.RunRecord{
+		RunId:     "checkpoint",
+		StartTime: timestamppb.New(start),
+		Config: &service.ConfigRecord{Update: []*service.ConfigItem{
+			{Key: "lr", ValueJson: "0.1"},
+		}},
+	}
+	inChan <- &service.Record{RecordType: &service.Record_Run{Run: run}}
+	inChan <- &service.Record{RecordType: &service.Record_Request{
+		Request: &service.Request{RequestType: &service.Request_RunStart{
+			RunStart: &service.RunStartRequest{Run: run},
+		}},
+	}}
+	inChan <- &service.Record{RecordType: &service.Record_Metric{
+		Metric: &service.MetricRecord{
+			Name:    "acc",
+			Summary: &service.MetricSummary{Max: true},
+		},
+	}}
+	for i := range rows {
+		if i == rows/2 {
+			inChan <- &service.Record{RecordType: &service.Record_Config{
+				Config: &service.ConfigRecord{Update: []*service.ConfigItem{
+					{Key: "epochs", ValueJson: "3"},
+				}},
+			}}
+		}
+		inChan <- &service.Record{RecordType: &service.Record_History{
+			History: &service.HistoryRecord{
+				Step: &service.HistoryStep{Num: int64(i)},
+				Item: []*service.HistoryItem{
+					{Key: "acc", ValueJson: fmt.Sprint(i % 7)},
+					{Key: "_step", ValueJson: fmt.Sprint(i)},
+					{Key: "_timestamp", ValueJson: fmt.Sprint(start.Unix() + int64(i))},
+				},
+			},
+		}}
+	}
+	close(inChan)
+	wg.Wait()
+	return path
+}
+
+func TestCheckpoint_ReplayMatchesFullReplay(t *testing.T) {
+	path := writeCheckpointedLog(t, 200, 20)
+
+	index, err := transactionlog.ReadCheckpointIndex(path)
+	require.NoError(t, err)
+	require.NotNil(t, index, "no checkpoint was written")
+
+	full, err := server.Replay(path, -1, false)
+	require.NoError(t, err)
+	fromCheckpoint, err := server.Replay(path, -1, true)
+	require.NoError(t, err)
+
+	assert.Empty(t, fromCheckpoint.Errors)
+	assert.Equal(t, index.Offset, fromCheckpoint.CheckpointOffset)
+	assert.Zero(t, full.CheckpointOffset)
+	assert.Less(t, fromCheckpoint.Records, full.Records)
+	assert.Equal(t, full.LastOffset, fromCheckpoint.LastOffset)
+	assert.Equal(t, full.Config, fromCheckpoint.Config)
+	assert.Equal(t, full.Summary, fromCheckpoint.Summary)
+	assert.EqualValues(t, 6, fromCheckpoint.Summary["acc"].(map[string]any)["max"])
+	assert.EqualValues(t, 3, fromCheckpoint.Config["epochs"])
+}
+
+func TestCheckpoint_CorruptedFallsBackToFullReplay(t *testing.T) {
+	path := writeCheckpointedLog(t, 50, 10)
+	index, err := transactionlog.ReadCheckpointIndex(path)
+	require.NoError(t, err)
+	require.NotNil(t, index)
+
+	// Point the index at a record that isn't a checkpoint.
+	full, err := server.Replay(path, -1, false)
+	require.NoError(t, err)
+	require.NoError(t, transactionlog.WriteCheckpointIndex(path,
+		transactionlog.CheckpointIndex{Offset: index.Offset + 1}))
+	fallback, err := server.Replay(path, -1, true)
+	require.NoError(t, err)
+
+	assert.Zero(t, fallback.CheckpointOffset)
+	require.Len(t, fallback.Errors, 1)
+	assert.Contains(t, fallback.Errors[0], "checkpoint:")
+	assert.Equal(t, full.Records, fallback.Records)
+	assert.Equal(t, full.Summary, fallback.Summary)
+}
+
+func TestCheckpoint_UntilOffsetBeforeCheckpoint(t *testing.T) {
+	path := writeCheckpointedLog(t, 50, 10)
+	index, err := transactionlog.ReadCheckpointIndex(path)
+	require.NoError(t, err)
+	require.NotNil(t, index)
+
+	state, err := server.Replay(path, index.Offset-1, true)
+
+	require.NoError(t, err)
+	assert.Zero(t, state.CheckpointOffset)
+	assert.Empty(t, state.Errors)
+}
+
+func BenchmarkReplay(b *testing.B) {
+	path := writeCheckpointedLog(b, 100_000, 10_000)
+
+	for _, fromCheckpoint := range []bool{false, true} {
+		b.Run(fmt.Sprintf("fromCheckpoint=%v", fromCheckpoint), func(b *testing.B) {
+			for range b.N {
+				if _, err := server.Replay(path, -1, fromCheckpoint); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// maxPartBytes is about the largest a part of a response sent in parts
+// can be.
+//
+// A single item of a response that's larger than this is sent in a part
+// of its own.
+const maxPartBytes = 1 << 20
+
+// maxElementOverhead bounds the bytes that encoding an element of a
+// repeated field adds to the element, for its tag and length.
+const maxElementOverhead = 16
+
+// markMultipart marks the result as one the dispatcher may send to the
+// client in parts, if it's too large to send at once.
+//
+// Only the repeated fields of the result's response are split up among the
+// parts, so this is for responses that are large because they have many
+// items, like sampled history.
+func markMultipart(result *service.Result) *service.Result {
+	result.Chunk = &service.ResultChunk{}
+	return result
+}
+
+// splitResult sends the result in parts of about maxBytes each, in order,
+// until send returns false.
+//
+// It returns false if send stopped it. The first part has the fields of
+// the response that aren't repeated; the others only have elements of its
+// repeated fields. A result no larger than maxBytes is sent whole, without
+// a chunk.
+//
+// Parts are made as they're sent, so stopping early saves the work of
+// making the rest.
+func splitResult(
+	result *service.Result,
+	maxBytes int,
+	send func(*service.Result) bool,
+) bool {
+	response := result.GetResponse().ProtoReflect()
+	responseType := response.Descriptor().Oneofs().ByName("response_type")
+	field := response.WhichOneof(responseType)
+	if field == nil || proto.Size(result) <= maxBytes {
+		return send(result)
+	}
+	items := response.Get(field).Message()
+
+	// newPart returns a result with an empty response of the same type,
+	// and the response's message to add elements to.
+	newPart := func(first bool) (*service.Result, protoreflect.Message) {
+		partItems := items.New()
+		if first {
+			items.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+				if !fd.IsList() {
+					partItems.Set(fd, v)
+				}
+				return true
+			})
+		}
+		partResponse := &service.Response{}
+		partResponse.ProtoReflect().Set(field, protoreflect.ValueOfMessage(partItems))
+		return &service.Result{
+			ResultType: &service.Result_Response{Response: partResponse},
+			Control:    result.Control,
+			Uuid:       result.Uuid,
+			XInfo:      result.XInfo,
+		}, partItems
+	}
+
+	index := int32(0)
+	part, partItems := newPart(true)
+	size := proto.Size(part)
+	empty := true
+
+	// sendPart sends the part that's been filled in.
+	sendPart := func(final bool) bool {
+		part.Chunk = &service.ResultChunk{Index: index, Final: final}
+		index++
+		return send(part)
+	}
+
+	fields := items.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !fd.IsList() {
+			continue
+		}
+
+		list := items.Get(fd).List()
+		for j := 0; j < list.Len(); j++ {
+			element := list.Get(j)
+			elementSize := listElementSize(fd, element)
+
+			if !empty && size+elementSize > maxBytes {
+				if !sendPart(false) {
+					return false
+				}
+				part, partItems = newPart(false)
+				size = proto.Size(part)
+				empty = true
+			}
+
+			partItems.Mutable(fd).List().Append(element)
+			size += elementSize
+			empty = false
+		}
+	}
+
+	return sendPart(true)
+}
+
+// listElementSize returns at least the number of bytes that an element of
+// the repeated field adds to an encoded message.
+func listElementSize(fd protoreflect.FieldDescriptor, element protoreflect.Value) int {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return proto.Size(element.Message().Interface()) + maxElementOverhead
+	case protoreflect.StringKind:
+		return len(element.String()) + maxElementOverhead
+	case protoreflect.BytesKind:
+		return len(element.Bytes()) + maxElementOverhead
+	default:
+		return maxElementOverhead
+	}
+}
+
+// ResultAssembler puts back together the responses that the service sent
+// in parts.
+//
+// The parts of a response arrive in order, tagged with the mailbox slot
+// of its request. See [service.ResultChunk].
+type ResultAssembler struct {
+	// partial are the responses whose last part hasn't arrived, by slot
+	partial map[string]*service.Result
+}
+
+func NewResultAssembler() *ResultAssembler {
+	return &ResultAssembler{partial: make(map[string]*service.Result)}
+}
+
+// Add returns the result if it's whole, the whole response if the result
+// is its last part, or nil if the result is a part and more are to come.
+//
+// The assembler takes ownership of the parts. A part that's out of order,
+// meaning that a part was lost, is an error, and the parts of its
+// response received so far are discarded.
+func (a *ResultAssembler) Add(result *service.Result) (*service.Result, error) {
+	chunk := result.GetChunk()
+	if chunk == nil {
+		return result, nil
+	}
+
+	slot := result.GetControl().GetMailboxSlot()
+	whole, ok := a.partial[slot]
+	switch {
+	case !ok && chunk.GetIndex() == 0:
+		whole = result
+	case ok && chunk.GetIndex() == whole.GetChunk().GetIndex()+1:
+		proto.Merge(whole, result)
+	default:
+		delete(a.partial, slot)
+		return nil, fmt.Errorf(
+			"server: part %d of the response for slot %q is out of order",
+			chunk.GetIndex(),
+			slot,
+		)
+	}
+
+	if !chunk.GetFinal() {
+		a.partial[slot] = whole
+		return nil, nil
+	}
+
+	delete(a.partial, slot)
+	whole.Chunk = nil
+	return whole, nil
+}
+
+// Discard drops the parts received so far of the response for the slot,
+// such as after its request is canceled.
+func (a *ResultAssembler) Discard(slot string) {
+	delete(a.partial, slot)
+}
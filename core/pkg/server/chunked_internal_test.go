@@ -0,0 +1,210 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// sampledHistoryResult returns a sampled history response for a client
+// with n metrics of 100 samples each.
+func sampledHistoryResult(slot string, n int) *service.Result {
+	items := make([]*service.SampledHistoryItem, n)
+	for i := range items {
+		items[i] = &service.SampledHistoryItem{
+			Key:         fmt.Sprintf("metric-%04d", i),
+			ValuesFloat: make([]float32, 100),
+			Steps:       make([]int64, 100),
+		}
+	}
+	return &service.Result{
+		ResultType: &service.Result_Response{
+			Response: &service.Response{
+				ResponseType: &service.Response_SampledHistoryResponse{
+					SampledHistoryResponse: &service.SampledHistoryResponse{Item: items},
+				},
+			},
+		},
+		Control: &service.Control{ConnectionId: "client", MailboxSlot: slot},
+		Uuid:    "sampled-history",
+	}
+}
+
+// resultResponder records the results it receives.
+type resultResponder struct {
+	mu      sync.Mutex
+	results []*service.Result
+
+	// gate, if not nil, blocks Respond until it is closed
+	gate chan struct{}
+}
+
+func (r *resultResponder) Respond(response *service.ServerResponse) {
+	if r.gate != nil {
+		<-r.gate
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, response.GetResultCommunicate())
+}
+
+func (r *resultResponder) Results() []*service.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*service.Result(nil), r.results...)
+}
+
+func TestSplitResult_ReassemblesToOriginal(t *testing.T) {
+	original := sampledHistoryResult("slot", 200)
+	const maxBytes = 10_000
+
+	var parts []*service.Result
+	sent := splitResult(proto.Clone(original).(*service.Result), maxBytes,
+		func(part *service.Result) bool {
+			parts = append(parts, part)
+			return true
+		})
+
+	require.True(t, sent)
+	require.Greater(t, len(parts), 10)
+	assembler := NewResultAssembler()
+	var whole *service.Result
+	for i, part := range parts {
+		assert.LessOrEqual(t, proto.Size(part), maxBytes)
+		assert.EqualValues(t, i, part.GetChunk().GetIndex())
+		assert.Equal(t, i == len(parts)-1, part.GetChunk().GetFinal())
+		assert.Equal(t, "slot", part.GetControl().GetMailboxSlot())
+
+		result, err := assembler.Add(part)
+		require.NoError(t, err)
+		if i < len(parts)-1 {
+			assert.Nil(t, result)
+		} else {
+			whole = result
+		}
+	}
+	assert.True(t, proto.Equal(original, whole))
+}
+
+func TestSplitResult_SmallResultIsWhole(t *testing.T) {
+	original := sampledHistoryResult("slot", 2)
+
+	var parts []*service.Result
+	splitResult(original, maxPartBytes, func(part *service.Result) bool {
+		parts = append(parts, part)
+		return true
+	})
+
+	require.Len(t, parts, 1)
+	assert.Nil(t, parts[0].GetChunk())
+	assert.Same(t, original, parts[0])
+}
+
+func TestSplitResult_Stops(t *testing.T) {
+	sends := 0
+
+	sent := splitResult(sampledHistoryResult("slot", 200), 10_000,
+		func(part *service.Result) bool {
+			sends++
+			return sends < 2
+		})
+
+	assert.False(t, sent)
+	assert.Equal(t, 2, sends)
+}
+
+func TestResultAssembler_PartOutOfOrder(t *testing.T) {
+	var parts []*service.Result
+	splitResult(sampledHistoryResult("slot", 200), 10_000,
+		func(part *service.Result) bool {
+			parts = append(parts, part)
+			return true
+		})
+	assembler := NewResultAssembler()
+
+	_, err0 := assembler.Add(parts[0])
+	_, err2 := assembler.Add(parts[2])
+	_, errNext := assembler.Add(parts[3])
+
+	assert.NoError(t, err0)
+	assert.ErrorContains(t, err2, "out of order")
+	assert.ErrorContains(t, errNext, "out of order")
+}
+
+func TestDispatcher_SendsMultipartResultInParts(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), mailbox.NewMailbox(), nil)
+	d.partBytes = 10_000
+	r := &resultResponder{}
+	d.AddResponders(ResponderEntry{r, "client"})
+	original := sampledHistoryResult("client-slot", 200)
+
+	d.handleRespond(markMultipart(proto.Clone(original).(*service.Result)))
+	d.Close()
+
+	results := r.Results()
+	require.Greater(t, len(results), 10)
+	assembler := NewResultAssembler()
+	var whole *service.Result
+	for _, result := range results {
+		var err error
+		whole, err = assembler.Add(result)
+		require.NoError(t, err)
+	}
+	assert.True(t, proto.Equal(original, whole))
+}
+
+func TestDispatcher_SmallMultipartResultIsWhole(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	r := &resultResponder{}
+	d.AddResponders(ResponderEntry{r, "client"})
+
+	d.handleRespond(markMultipart(sampledHistoryResult("client-slot", 2)))
+	d.Close()
+
+	require.Len(t, r.Results(), 1)
+	assert.Nil(t, r.Results()[0].GetChunk())
+}
+
+func TestDispatcher_CancelStopsSendingParts(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	d := NewDispatcher(observability.NewNoOpLogger(), mb, nil)
+	d.partBytes = 10_000
+	d.queueSize = 1
+	r := &resultResponder{gate: make(chan struct{})}
+	d.AddResponders(ResponderEntry{r, "client"})
+
+	// the responder is stuck, so parts wait for room in its queue
+	d.handleRespond(markMultipart(sampledHistoryResult("client-slot", 200)))
+	assert.Eventually(t,
+		func() bool { return len(d.queues["client"]) == 1 },
+		time.Second, time.Millisecond,
+	)
+	mb.Cancel("client-slot")
+	done := make(chan struct{})
+	go func() {
+		d.partsWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("canceling didn't stop sending the parts")
+	}
+	close(r.gate)
+	d.Close()
+
+	results := r.Results()
+	assert.Less(t, len(results), 4)
+	for _, result := range results {
+		assert.False(t, result.GetChunk().GetFinal())
+	}
+}
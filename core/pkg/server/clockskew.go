@@ -0,0 +1,46 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// correctTimestamp shifts the row's timestamp by how far the local clock
+// is off the server's, if it's far off.
+//
+// The runtime is timed by the local clock alone, and isn't shifted.
+func (h *Handler) correctTimestamp(history *service.HistoryRecord) {
+	offset := h.clockSkew.Offset()
+	if offset == 0 {
+		return
+	}
+
+	for _, item := range history.GetItem() {
+		if item.GetKey() != "_timestamp" || len(item.GetNestedKey()) > 0 {
+			continue
+		}
+		timestamp, err := strconv.ParseFloat(item.GetValueJson(), 64)
+		if err != nil {
+			continue
+		}
+		item.ValueJson = strconv.FormatFloat(timestamp+offset.Seconds(), 'f', -1, 64)
+	}
+}
+
+// probeSystem returns the system's info for the run's metadata, with the
+// clock's skew if it was measured.
+func (h *Handler) probeSystem() *service.MetadataRequest {
+	info := h.systemMonitor.Probe()
+
+	skew, ok := h.clockSkew.Measured()
+	if !ok {
+		return info
+	}
+	if info == nil {
+		info = &service.MetadataRequest{}
+	}
+	seconds := skew.Seconds()
+	info.ClockSkewSeconds = &seconds
+	return info
+}
@@ -0,0 +1,400 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wandb/wandb/core/internal/runconfig"
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// ErrLogUnfinished is the error of compacting the transaction log of a run
+// that didn't finish.
+var ErrLogUnfinished = errors.New("compact: the log's run didn't finish")
+
+// CompactionResult describes a compacted transaction log.
+type CompactionResult struct {
+	// OriginalRecords is the number of records in the original log.
+	OriginalRecords int64 `json:"original_records"`
+
+	// OriginalBytes is the size of the original log.
+	OriginalBytes int64 `json:"original_bytes"`
+
+	// Records is the number of records in the compacted log.
+	Records int64 `json:"records"`
+
+	// Bytes is the size of the compacted log.
+	Bytes int64 `json:"bytes"`
+}
+
+// Compact writes a compacted copy of the transaction log at src to dst.
+//
+// The copy keeps the run's history, output, stats and everything else the
+// sync needs, but replaces its config, summary, telemetry and metric
+// definitions with their final forms, each where the last of its updates
+// was. Syncing the copy gives the same run as syncing the original. The
+// copy's header record says that it was compacted, and its digest is
+// recorded next to it.
+//
+// Only the logs of finished runs are compacted, and never in place: dst
+// mustn't exist. A log with missing or corrupt records, or that changed
+// after its run finished, isn't compacted either, since the copy would
+// hide it from the sync.
+func Compact(src, dst string) (*CompactionResult, error) {
+	if err := checkCompactable(src, dst); err != nil {
+		return nil, err
+	}
+
+	compaction, err := scanForCompaction(src)
+	if err != nil {
+		return nil, err
+	}
+
+	// the copy is only put in place once it's complete
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	digest, err := compaction.write(src, tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("compact: can't write %s: %v", dst, err)
+	}
+
+	if _, err := os.Stat(dst); !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("compact: %s already exists", dst)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return nil, err
+	}
+	digest.FinishedAt = time.Now()
+	if err := transactionlog.WriteDigest(dst, digest); err != nil {
+		return nil, err
+	}
+
+	return &CompactionResult{
+		OriginalRecords: compaction.records,
+		OriginalBytes:   compaction.bytes,
+		Records:         digest.Records,
+		Bytes:           digest.Bytes,
+	}, nil
+}
+
+// checkCompactable returns an error if the log at src can't be compacted
+// to dst.
+func checkCompactable(src, dst string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	dstInfo, err := os.Stat(dst)
+	switch {
+	case err == nil && os.SameFile(srcInfo, dstInfo):
+		return errors.New("compact: a log can't be compacted in place")
+	case err == nil:
+		return fmt.Errorf("compact: %s already exists", dst)
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	gap, err := readLogGap(src)
+	if err != nil {
+		return err
+	}
+	if gap != nil {
+		return fmt.Errorf("compact: %v", gap.Err())
+	}
+
+	if _, err := transactionlog.VerifyDigest(src); err != nil {
+		return fmt.Errorf("compact: %w", err)
+	}
+	return nil
+}
+
+// logCompaction is what compacting a transaction log replaces its records
+// with.
+type logCompaction struct {
+	// records and bytes are the number of records in the original log and
+	// its size
+	records int64
+	bytes   int64
+
+	// the final forms of the run's config, summary and telemetry, and the
+	// indexes of the last records updating them, or -1 if there are none
+	config        *runconfig.RunConfig
+	lastConfig    int64
+	summary       *runsummary.RunSummary
+	lastSummary   int64
+	telemetry     *service.TelemetryRecord
+	lastTelemetry int64
+
+	// metrics are the final definitions of the metrics with names, and
+	// lastMetric the index of the last record defining one
+	metrics    *compactedMetrics
+	lastMetric int64
+}
+
+// scanForCompaction reads the transaction log at the path and returns what
+// compacting it replaces its records with.
+func scanForCompaction(path string) (*logCompaction, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := transactionlog.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	c := &logCompaction{
+		bytes:         info.Size(),
+		config:        runconfig.New(),
+		lastConfig:    -1,
+		summary:       runsummary.New(),
+		lastSummary:   -1,
+		telemetry:     &service.TelemetryRecord{},
+		lastTelemetry: -1,
+		metrics:       newCompactedMetrics(),
+		lastMetric:    -1,
+	}
+
+	// the sync skips the same invalid values
+	onError := func(error) {}
+	exited := false
+	for ; ; c.records++ {
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("compact: %v", err)
+		}
+
+		switch x := record.RecordType.(type) {
+		case *service.Record_Config:
+			c.config.ApplyChangeRecord(x.Config, onError)
+			c.lastConfig = c.records
+		case *service.Record_Summary:
+			c.summary.ApplyChangeRecord(x.Summary, onError)
+			c.lastSummary = c.records
+		case *service.Record_Telemetry:
+			proto.Merge(c.telemetry, x.Telemetry)
+			c.lastTelemetry = c.records
+		case *service.Record_Metric:
+			if x.Metric.GetGlobName() == "" {
+				c.metrics.add(x.Metric)
+				c.lastMetric = c.records
+			}
+		case *service.Record_Exit:
+			exited = true
+		}
+	}
+
+	if !exited {
+		return nil, ErrLogUnfinished
+	}
+	return c, nil
+}
+
+// write writes the compacted log to w, reading the original's records from
+// the path, and returns its digest.
+func (c *logCompaction) write(path string, w io.Writer) (transactionlog.Digest, error) {
+	reader, err := transactionlog.Open(path)
+	if err != nil {
+		return transactionlog.Digest{}, err
+	}
+	defer reader.Close()
+
+	log, err := transactionlog.NewWriter(w)
+	if err != nil {
+		return transactionlog.Digest{}, err
+	}
+
+	var num int64
+	write := func(record *service.Record) error {
+		num++
+		record.Num = num
+		_, err := log.Write(record)
+		return err
+	}
+
+	for i := int64(0); ; i++ {
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return transactionlog.Digest{}, err
+		}
+
+		var records []*service.Record
+		if i == 0 {
+			records, record = c.header(record)
+		}
+		if record != nil {
+			compacted, err := c.compactRecord(i, record)
+			if err != nil {
+				return transactionlog.Digest{}, err
+			}
+			records = append(records, compacted...)
+		}
+
+		for _, record := range records {
+			if err := write(record); err != nil {
+				return transactionlog.Digest{}, err
+			}
+		}
+	}
+
+	if err := log.Close(); err != nil {
+		return transactionlog.Digest{}, err
+	}
+	return log.Digest(), nil
+}
+
+// header returns the compacted log's header record, followed by the
+// original's first record if that isn't a header.
+func (c *logCompaction) header(
+	first *service.Record,
+) ([]*service.Record, *service.Record) {
+	compaction := &service.CompactionInfo{
+		OriginalRecords: c.records,
+		OriginalBytes:   c.bytes,
+		CompactedAt:     timestamppb.Now(),
+	}
+
+	if first.GetHeader() == nil {
+		header := &service.Record{
+			RecordType: &service.Record_Header{
+				Header: &service.HeaderRecord{Compaction: compaction},
+			},
+		}
+		return []*service.Record{header}, first
+	}
+
+	first.GetHeader().Compaction = compaction
+	return []*service.Record{first}, nil
+}
+
+// compactRecord returns the records that replace the record with the index
+// in the compacted log.
+func (c *logCompaction) compactRecord(
+	i int64,
+	record *service.Record,
+) ([]*service.Record, error) {
+	switch x := record.RecordType.(type) {
+	case *service.Record_Config:
+		if i != c.lastConfig {
+			return nil, nil
+		}
+		items, err := c.config.Flatten()
+		if err != nil {
+			return nil, err
+		}
+		return []*service.Record{{
+			RecordType: &service.Record_Config{
+				Config: &service.ConfigRecord{Update: items},
+			},
+		}}, nil
+
+	case *service.Record_Summary:
+		if i != c.lastSummary {
+			return nil, nil
+		}
+		items, err := c.summary.Flatten()
+		if err != nil {
+			return nil, err
+		}
+		return []*service.Record{{
+			RecordType: &service.Record_Summary{
+				Summary: &service.SummaryRecord{Update: items},
+			},
+		}}, nil
+
+	case *service.Record_Telemetry:
+		if i != c.lastTelemetry {
+			return nil, nil
+		}
+		return []*service.Record{{
+			RecordType: &service.Record_Telemetry{Telemetry: c.telemetry},
+		}}, nil
+
+	case *service.Record_Metric:
+		if x.Metric.GetGlobName() != "" {
+			return []*service.Record{record}, nil
+		}
+		if i != c.lastMetric {
+			return nil, nil
+		}
+		return c.metrics.records(), nil
+
+	case *service.Record_Checkpoint:
+		// checkpoints speed up replaying the original log, whose offsets
+		// they no longer match
+		return nil, nil
+
+	default:
+		return []*service.Record{record}, nil
+	}
+}
+
+// compactedMetrics are the final definitions of a run's metrics, as the
+// sender puts them in the run's config.
+//
+// The sender keeps the last definition of each metric, in the order the
+// metrics were first defined, and refers to a step metric by its place in
+// that order if it was defined before. The definitions are kept with
+// their step metrics resolved that way, so that defining them again in
+// order gives the same config.
+type compactedMetrics struct {
+	index       map[string]int
+	definitions []*service.MetricRecord
+}
+
+func newCompactedMetrics() *compactedMetrics {
+	return &compactedMetrics{index: make(map[string]int)}
+}
+
+// add records the definition of a metric with a name.
+func (m *compactedMetrics) add(metric *service.MetricRecord) {
+	if stepIndex, ok := m.index[metric.GetStepMetric()]; ok {
+		metric = proto.Clone(metric).(*service.MetricRecord)
+		metric.StepMetric = ""
+		metric.StepMetricIndex = int32(stepIndex) + 1
+	}
+
+	if index, ok := m.index[metric.GetName()]; ok {
+		m.definitions[index] = metric
+	} else {
+		m.index[metric.GetName()] = len(m.definitions)
+		m.definitions = append(m.definitions, metric)
+	}
+}
+
+// records returns the metric records that define the metrics.
+func (m *compactedMetrics) records() []*service.Record {
+	records := make([]*service.Record, 0, len(m.definitions))
+	for _, metric := range m.definitions {
+		records = append(records, &service.Record{
+			RecordType: &service.Record_Metric{Metric: metric},
+		})
+	}
+	return records
+}
@@ -0,0 +1,271 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// longRunRecords returns the records of a run that updates its config and
+// summary with every step, ending with its exit if finished.
+func longRunRecords(steps int, finished bool) []*service.Record {
+	metric := func(metric *service.MetricRecord) *service.Record {
+		return &service.Record{RecordType: &service.Record_Metric{Metric: metric}}
+	}
+	telemetry := func(telemetry *service.TelemetryRecord) *service.Record {
+		return &service.Record{
+			RecordType: &service.Record_Telemetry{Telemetry: telemetry},
+		}
+	}
+	config := func(config *service.ConfigRecord) *service.Record {
+		return &service.Record{RecordType: &service.Record_Config{Config: config}}
+	}
+
+	records := []*service.Record{
+		{RecordType: &service.Record_Header{Header: &service.HeaderRecord{}}},
+		{
+			RecordType: &service.Record_Run{
+				Run: &service.RunRecord{RunId: "compact", Project: "testProject"},
+			},
+		},
+		telemetry(&service.TelemetryRecord{PythonVersion: "3.11.4"}),
+		// the step metric is defined after the metric that uses it
+		metric(&service.MetricRecord{Name: "acc", StepMetric: "epoch"}),
+		metric(&service.MetricRecord{Name: "epoch"}),
+		metric(&service.MetricRecord{GlobName: "val/*"}),
+		config(&service.ConfigRecord{
+			Update: []*service.ConfigItem{
+				{Key: "batch_size", ValueJson: "32"},
+				{NestedKey: []string{"optimizer", "name"}, ValueJson: `"adam"`},
+				{Key: "scratch", ValueJson: "true"},
+			},
+		}),
+	}
+
+	for i := range steps {
+		records = append(records,
+			makeHistoryRecord(data{
+				items: map[string]string{
+					"_step": fmt.Sprint(i),
+					"acc":   fmt.Sprintf("0.%d", i),
+				},
+				step: int64(i),
+			}),
+			&service.Record{
+				RecordType: &service.Record_Summary{
+					Summary: &service.SummaryRecord{
+						Update: []*service.SummaryItem{
+							{Key: "acc", ValueJson: fmt.Sprintf("0.%d", i)},
+							{NestedKey: []string{"eval", "step"}, ValueJson: fmt.Sprint(i)},
+						},
+					},
+				},
+			},
+			config(&service.ConfigRecord{
+				Update: []*service.ConfigItem{
+					{NestedKey: []string{"optimizer", "lr"}, ValueJson: fmt.Sprint(i)},
+				},
+			}),
+		)
+	}
+
+	records = append(records,
+		metric(&service.MetricRecord{
+			Name:       "acc",
+			StepMetric: "epoch",
+			Summary:    &service.MetricSummary{Max: true},
+		}),
+		telemetry(&service.TelemetryRecord{CliVersion: "0.17.0"}),
+		config(&service.ConfigRecord{
+			Remove: []*service.ConfigItem{{Key: "scratch"}},
+		}),
+		&service.Record{
+			RecordType: &service.Record_Summary{
+				Summary: &service.SummaryRecord{
+					Remove: []*service.SummaryItem{{NestedKey: []string{"eval", "step"}}},
+				},
+			},
+		},
+	)
+	if finished {
+		records = append(records, &service.Record{
+			RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		})
+	}
+	return records
+}
+
+// syncedRun is what the backend received from syncing a run.
+type syncedRun struct {
+	history []string
+	summary map[string]any
+	config  map[string]any
+}
+
+// syncRun syncs the run "compact" from the transaction log and returns what
+// the backend received.
+func syncRun(t *testing.T, syncFile string) syncedRun {
+	t.Helper()
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	dir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "compact"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	run := syncedRun{summary: map[string]any{}}
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		run.history = append(run.history, body.Files["wandb-history.jsonl"].Content...)
+		for _, line := range body.Files["wandb-summary.json"].Content {
+			run.summary = map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(line), &run.summary))
+		}
+	}
+	for _, request := range backend.GraphQLRequests("UpsertBucket") {
+		var body struct {
+			Variables struct {
+				Config *string `json:"config"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		if body.Variables.Config != nil {
+			run.config = map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(*body.Variables.Config), &run.config))
+		}
+	}
+	// the resources used by the core differ between syncs
+	if internal, ok := run.config["_wandb"].(map[string]any); ok {
+		if value, ok := internal["value"].(map[string]any); ok {
+			delete(value, "nexus")
+		}
+	}
+	return run
+}
+
+func TestCompact_SyncsTheSameRun(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "run-compact.wandb")
+	compacted := filepath.Join(dir, "run-compact-compacted.wandb")
+	writeEverything(t, original, longRunRecords(50, true))
+
+	result, err := server.Compact(original, compacted)
+	require.NoError(t, err)
+
+	records := readLog(t, compacted)
+	assert.EqualValues(t, len(longRunRecords(50, true)), result.OriginalRecords)
+	assert.EqualValues(t, len(records), result.Records)
+	assert.Less(t, result.Records, result.OriginalRecords)
+	assert.Less(t, result.Bytes, result.OriginalBytes)
+	compaction := records[0].GetHeader().GetCompaction()
+	require.NotNil(t, compaction)
+	assert.Equal(t, result.OriginalRecords, compaction.GetOriginalRecords())
+	for i, record := range records {
+		assert.EqualValues(t, i+1, record.Num)
+	}
+	digest, err := transactionlog.VerifyDigest(compacted)
+	require.NoError(t, err)
+	assert.NotNil(t, digest)
+
+	want := syncRun(t, original)
+	got := syncRun(t, compacted)
+
+	require.Len(t, want.history, 50)
+	assert.Equal(t, want.history, got.history)
+	assert.Equal(t, 0.49, want.summary["acc"])
+	assert.Equal(t, want.summary, got.summary)
+	require.NotNil(t, want.config["optimizer"])
+	assert.NotContains(t, want.config, "scratch")
+	assert.Equal(t, want.config, got.config)
+}
+
+func TestCompact_RefusesUnfinishedRun(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "run-compact.wandb")
+	compacted := filepath.Join(dir, "run-compact-compacted.wandb")
+	writeEverything(t, original, longRunRecords(5, false))
+
+	_, err := server.Compact(original, compacted)
+
+	assert.ErrorIs(t, err, server.ErrLogUnfinished)
+	assert.NoFileExists(t, compacted)
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+}
+
+func TestCompact_NeverInPlace(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "run-compact.wandb")
+	writeEverything(t, original, longRunRecords(5, true))
+	before, err := os.ReadFile(original)
+	require.NoError(t, err)
+
+	_, err = server.Compact(original, original)
+	assert.ErrorContains(t, err, "in place")
+
+	existing := filepath.Join(dir, "existing.wandb")
+	require.NoError(t, os.WriteFile(existing, []byte("keep"), 0o644))
+	_, err = server.Compact(original, existing)
+	assert.ErrorContains(t, err, "already exists")
+
+	after, err := os.ReadFile(original)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	kept, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(kept))
+}
+
+func TestCompact_RefusesModifiedLog(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "run-compact.wandb")
+	compacted := filepath.Join(dir, "run-compact-compacted.wandb")
+	writeEverything(t, original, longRunRecords(5, true))
+	require.NoError(t, transactionlog.WriteDigest(original, transactionlog.Digest{
+		SHA256: "0000",
+		Bytes:  1,
+	}))
+
+	_, err := server.Compact(original, compacted)
+
+	assert.ErrorIs(t, err, transactionlog.ErrDigestMismatch)
+	assert.NoFileExists(t, compacted)
+}
@@ -50,6 +50,9 @@ type Connection struct {
 	// however, a stream can have multiple connections
 	stream *Stream
 
+	// initErr is why the stream couldn't be created, if it couldn't
+	initErr error
+
 	// closed indicates if the outChan is closed
 	closed *atomic.Bool
 }
@@ -144,6 +147,8 @@ func (nc *Connection) readConnection() {
 				"unmarshalling error",
 				"err", err,
 				"conn", nc.conn.RemoteAddr())
+		} else if ping := msg.GetPing(); ping != nil {
+			nc.handlePing(ping)
 		} else {
 			nc.inChan <- msg
 		}
@@ -154,6 +159,12 @@ func (nc *Connection) readConnection() {
 	close(nc.inChan)
 }
 
+// ReceivesUserMessages returns whether the client is still connected to
+// display messages for the user.
+func (nc *Connection) ReceivesUserMessages() bool {
+	return !nc.closed.Load()
+}
+
 // handleServerRequest handles outgoing messages from the server
 // to the client, it writes the messages to the connection
 // the client is responsible for reading and parsing the messages
@@ -207,8 +218,9 @@ func (nc *Connection) handleServerRequest() {
 		case *service.ServerRequest_InformTeardown:
 			nc.handleInformTeardown(x.InformTeardown)
 		case nil:
-			slog.Error("ServerRequestType is nil", "id", nc.id)
-			panic("ServerRequestType is nil")
+			// requests added after this version are read without a type,
+			// and are dropped rather than ending the service
+			slog.Error("ServerRequestType is nil or unknown", "id", nc.id)
 		default:
 			slog.Error("ServerRequestType is unknown", "type", x, "id", nc.id)
 			panic(fmt.Sprintf("ServerRequestType is unknown, %T", x))
@@ -223,8 +235,39 @@ func (nc *Connection) handleServerRequest() {
 // handleInformInit is called when the client sends an InformInit message
 // to the server, to start a new stream
 func (nc *Connection) handleInformInit(msg *service.ServerInformInitRequest) {
-	settings := settings.From(msg.GetSettings())
+	settings := settings.Resolve(msg.GetSettings())
+	streamId := msg.GetXInfo().GetStreamId()
+
+	// reject invalid settings before creating any files or connecting to
+	// the server; the error is reported in response to the run record
+	if err := settings.Validate(); err != nil {
+		slog.Error(
+			"connection init failed, invalid settings",
+			"err", err,
+			"streamId", streamId,
+			"id", nc.id,
+		)
+		nc.initErr = err
+		return
+	}
+
+	// the run's directories may fall back to temporary ones, which the
+	// stream warns about; a disabled run has none
+	if !settings.IsDisabled() {
+		if _, err := settings.PrepareRunDirs(); err != nil {
+			slog.Error(
+				"connection init failed, can't create run directories",
+				"err", err,
+				"streamId", streamId,
+				"id", nc.id,
+			)
+			nc.initErr = err
+			return
+		}
+	}
 
+	// without an API key, the run fails to start with an error telling
+	// the user how to log in
 	err := settings.EnsureAPIKey()
 	if err != nil {
 		slog.Error(
@@ -232,19 +275,53 @@ func (nc *Connection) handleInformInit(msg *service.ServerInformInitRequest) {
 			"err", err,
 			"id", nc.id,
 		)
-		panic(err)
 	}
 
-	streamId := msg.GetXInfo().GetStreamId()
+	// the client is told about the limit in response to its run record,
+	// before any of the stream's files are created
+	if err := streamMux.CheckCapacity(); err != nil {
+		slog.Warn(
+			"connection init rejected, at capacity",
+			"err", err,
+			"streams", streamMux.NumStreams(),
+			"streamId", streamId,
+			"id", nc.id,
+		)
+		nc.initErr = err
+		return
+	}
+
 	slog.Info("connection init received", "streamId", streamId, "id", nc.id)
 
-	nc.stream = NewStream(settings, streamId)
+	stream, err := NewStream(settings, streamId)
+	if err != nil {
+		slog.Error(
+			"connection init failed, can't create stream",
+			"err", err,
+			"streamId", streamId,
+			"id", nc.id,
+		)
+		nc.initErr = err
+		return
+	}
+	nc.stream = stream
 	nc.stream.AddResponders(ResponderEntry{nc, nc.id})
 	nc.stream.Start()
 	slog.Info("connection init completed", "streamId", streamId, "id", nc.id)
 
 	if err := streamMux.AddStream(streamId, nc.stream); err != nil {
-		slog.Error("connection init failed, stream already exists", "streamId", streamId, "id", nc.id)
+		slog.Error("connection init failed", "err", err, "streamId", streamId, "id", nc.id)
+		// the streams are being torn down, and this one must be too
+		if errors.Is(err, ErrStreamMuxClosed) {
+			nc.stream.FinishAndClose(0)
+		}
+		// the limit was reached since it was checked, and the client is
+		// told about it like above
+		if errors.Is(err, ErrAtCapacity) {
+			nc.stream.FinishAndClose(0)
+			nc.stream = nil
+			nc.initErr = err
+		}
 		// TODO: should we Close the stream?
 		return
 	}
@@ -256,7 +333,14 @@ func (nc *Connection) handleInformInit(msg *service.ServerInformInitRequest) {
 func (nc *Connection) handleInformStart(msg *service.ServerInformStartRequest) {
 	// todo: if we keep this and end up updating the settings here
 	//       we should update the stream logger to use the new settings as well
-	nc.stream.settings = settings.From(msg.GetSettings())
+	if nc.stream == nil {
+		slog.Error("handleInformStart: stream not found", "err", nc.initErr, "id", nc.id)
+		return
+	}
+	keepAnonymousCredentials(nc.stream.settings.Proto, msg.GetSettings())
+	started := settings.From(msg.GetSettings())
+	started.KeepRunDirs(nc.stream.settings)
+	nc.stream.settings = started
 
 	// update sentry tags
 	// add attrs from settings:
@@ -268,33 +352,6 @@ func (nc *Connection) handleInformStart(msg *service.ServerInformStartRequest) {
 	nc.stream.logger.CaptureInfo("wandb-core", nil)
 }
 
-// handleInformAttach is called when the client sends an InformAttach message
-// to the server, to attach to an existing stream.
-// this is used for attaching to a stream that was previously started
-// hence multiple clients can attach to the same stream
-func (nc *Connection) handleInformAttach(msg *service.ServerInformAttachRequest) {
-	streamId := msg.GetXInfo().GetStreamId()
-	slog.Debug("handle record received", "streamId", streamId, "id", nc.id)
-	var err error
-	nc.stream, err = streamMux.GetStream(streamId)
-	if err != nil {
-		slog.Error("handleInformAttach: stream not found", "streamId", streamId, "id", nc.id)
-	} else {
-		nc.stream.AddResponders(ResponderEntry{nc, nc.id})
-		// TODO: we should redo this attach logic, so that the stream handles
-		//       the attach logic
-		resp := &service.ServerResponse{
-			ServerResponseType: &service.ServerResponse_InformAttachResponse{
-				InformAttachResponse: &service.ServerInformAttachResponse{
-					XInfo:    msg.XInfo,
-					Settings: nc.stream.settings.Proto,
-				},
-			},
-		}
-		nc.Respond(resp)
-	}
-}
-
 // handleInformRecord is called when the client sends a record message
 // this is the regular communication between the client and the server
 // for a specific stream, the messages are part of the regular execution
@@ -302,7 +359,9 @@ func (nc *Connection) handleInformAttach(msg *service.ServerInformAttachRequest)
 func (nc *Connection) handleInformRecord(msg *service.Record) {
 	streamId := msg.GetXInfo().GetStreamId()
 	slog.Debug("handle record received", "streamId", streamId, "id", nc.id)
-	if nc.stream == nil {
+	if nc.stream == nil && nc.initErr != nil {
+		nc.respondInitError(msg)
+	} else if nc.stream == nil {
 		slog.Error("handleInformRecord: stream not found", "streamId", streamId, "id", nc.id)
 	} else {
 		// add connection id to control message
@@ -313,10 +372,42 @@ func (nc *Connection) handleInformRecord(msg *service.Record) {
 		} else {
 			msg.Control = &service.Control{ConnectionId: nc.id}
 		}
-		nc.stream.HandleRecord(msg)
+		nc.handleThrottled(msg)
 	}
 }
 
+// respondInitError responds to a run record with the error that
+// prevented the stream from being created.
+//
+// Other records are dropped, as there is no stream to handle them.
+func (nc *Connection) respondInitError(msg *service.Record) {
+	if msg.GetRun() == nil {
+		slog.Error(
+			"handleInformRecord: dropping record, stream failed to init",
+			"err", nc.initErr,
+			"id", nc.id,
+		)
+		return
+	}
+
+	nc.Respond(&service.ServerResponse{
+		ServerResponseType: &service.ServerResponse_ResultCommunicate{
+			ResultCommunicate: &service.Result{
+				ResultType: &service.Result_RunResult{
+					RunResult: &service.RunUpdateResult{
+						Error: &service.ErrorInfo{
+							Message: nc.initErr.Error(),
+							Code:    initErrorCode(nc.initErr),
+						},
+					},
+				},
+				Control: msg.Control,
+				Uuid:    msg.Uuid,
+			},
+		},
+	})
+}
+
 // handleInformFinish is called when the client sends a finish message
 // this should happen when the client want to close a specific stream
 func (nc *Connection) handleInformFinish(msg *service.ServerInformFinishRequest) {
@@ -332,10 +423,16 @@ func (nc *Connection) handleInformFinish(msg *service.ServerInformFinishRequest)
 // handleInformTeardown is called when the client sends a teardown message
 // this should happen when the client is shutting down and wants to close
 // all streams
+//
+// A forced teardown doesn't wait for the streams' data to be uploaded.
 func (nc *Connection) handleInformTeardown(teardown *service.ServerInformTeardownRequest) {
 	slog.Debug("handle teardown received", "id", nc.id)
 	// cancel the context to signal the server to shutdown
 	// this will trigger all the connections to close
 	nc.cancel()
-	streamMux.FinishAndCloseAllStreams(teardown.ExitCode)
+	if teardown.GetForce() {
+		streamMux.AbortAllStreams()
+	} else {
+		streamMux.FinishAndCloseAllStreams(teardown.ExitCode)
+	}
 }
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// consoleCapture is which of the run's console streams are kept.
+//
+// Output of a stream that isn't kept is dropped by the handler, so it's
+// neither saved in the transaction log nor uploaded.
+type consoleCapture struct {
+	stdout bool
+	stderr bool
+}
+
+// parseConsoleCapture returns the console streams to keep for a value of
+// the _console_capture setting.
+//
+// Unknown values keep both streams, along with an error.
+func parseConsoleCapture(value string) (consoleCapture, error) {
+	switch value {
+	case "", "auto", "both":
+		return consoleCapture{stdout: true, stderr: true}, nil
+	case "stdout":
+		return consoleCapture{stdout: true}, nil
+	case "stderr":
+		return consoleCapture{stderr: true}, nil
+	case "off":
+		return consoleCapture{}, nil
+	default:
+		return consoleCapture{stdout: true, stderr: true},
+			fmt.Errorf("server: unknown console capture %q", value)
+	}
+}
+
+// keepsOutput returns whether a line of console output is kept.
+func (c consoleCapture) keepsOutput(output *service.OutputRecord) bool {
+	if output.GetOutputType() == service.OutputRecord_STDERR {
+		return c.stderr
+	}
+	return c.stdout
+}
+
+// keepsOutputRaw returns whether a chunk of console output is kept.
+func (c consoleCapture) keepsOutputRaw(outputRaw *service.OutputRawRecord) bool {
+	if outputRaw.GetOutputType() == service.OutputRawRecord_STDERR {
+		return c.stderr
+	}
+	return c.stdout
+}
@@ -0,0 +1,150 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func outputRawRecord(
+	outputType service.OutputRawRecord_OutputType,
+	line string,
+) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_OutputRaw{
+			OutputRaw: &service.OutputRawRecord{OutputType: outputType, Line: line},
+		},
+	}
+}
+
+func consoleCaptureUpdateRecord(capture string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_SettingsUpdate{
+					SettingsUpdate: &service.SettingsUpdateRequest{
+						ConsoleCapture: &wrapperspb.StringValue{Value: capture},
+					},
+				},
+			},
+		},
+	}
+}
+
+// runWithConsole starts a run with the console capture setting, sends it
+// the records and finishes it.
+//
+// It returns the run's output.log, its transaction log and the bodies of
+// its filestream requests.
+func runWithConsole(
+	t *testing.T,
+	capture string,
+	records ...*service.Record,
+) (string, []*service.Record, string) {
+	backend := newInProcessBackend()
+	defer backend.Close()
+	settingsProto := inProcessSettings(t, backend)
+	if capture != "" {
+		settingsProto.XConsoleCapture = &wrapperspb.StringValue{Value: capture}
+	}
+	s := settings.From(settingsProto)
+	_, err := s.PrepareRunDirs()
+	require.NoError(t, err)
+
+	stream, err := server.NewStream(s, "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "inprocess", Project: "pipeline"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	for _, record := range records {
+		stream.HandleRecord(record)
+	}
+	stream.FinishAndClose(0)
+
+	outputLog, err := os.ReadFile(filepath.Join(s.GetFilesDir(), server.OutputFileName))
+	if !os.IsNotExist(err) {
+		require.NoError(t, err)
+	}
+	return string(outputLog), readLog(t, s.Proto.GetSyncFile().GetValue()), fileStreamBodies(backend)
+}
+
+func TestConsoleCapture_Modes(t *testing.T) {
+	testCases := []struct {
+		capture    string
+		kept       []string
+		notKept    []string
+		logsOutput bool
+	}{
+		{"", []string{"to stdout", "to stderr"}, nil, true},
+		{"auto", []string{"to stdout", "to stderr"}, nil, true},
+		{"both", []string{"to stdout", "to stderr"}, nil, true},
+		{"stdout", []string{"to stdout"}, []string{"to stderr"}, true},
+		{"stderr", []string{"to stderr"}, []string{"to stdout"}, true},
+		{"off", nil, []string{"to stdout", "to stderr"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.capture, func(t *testing.T) {
+			outputLog, records, bodies := runWithConsole(t, tc.capture,
+				outputRawRecord(service.OutputRawRecord_STDOUT, "to stdout"),
+				outputRawRecord(service.OutputRawRecord_STDERR, "to stderr"),
+			)
+
+			for _, line := range tc.kept {
+				assert.Contains(t, outputLog, line)
+				assert.Contains(t, bodies, line)
+			}
+			for _, line := range tc.notKept {
+				assert.NotContains(t, outputLog, line)
+				assert.NotContains(t, bodies, line)
+			}
+			logsOutput := false
+			for _, record := range records {
+				if record.GetOutputRaw() != nil {
+					logsOutput = true
+				}
+			}
+			assert.Equal(t, tc.logsOutput, logsOutput)
+		})
+	}
+}
+
+func TestConsoleCapture_ChangesMidRun(t *testing.T) {
+	outputLog, _, bodies := runWithConsole(t, "both",
+		outputRawRecord(service.OutputRawRecord_STDERR, "first stderr"),
+		consoleCaptureUpdateRecord("stdout"),
+		outputRawRecord(service.OutputRawRecord_STDERR, "second stderr"),
+		outputRawRecord(service.OutputRawRecord_STDOUT, "second stdout"),
+		consoleCaptureUpdateRecord("off"),
+		outputRawRecord(service.OutputRawRecord_STDOUT, "third stdout"),
+	)
+
+	assert.Equal(t, "first stderr\nsecond stdout\n", outputLog)
+	assert.Contains(t, bodies, "first stderr")
+	assert.Contains(t, bodies, "second stdout")
+	assert.NotContains(t, bodies, "second stderr")
+	assert.NotContains(t, bodies, "third stdout")
+}
+
+func TestConsoleCapture_IgnoresUnknownUpdate(t *testing.T) {
+	outputLog, _, _ := runWithConsole(t, "stdout",
+		consoleCaptureUpdateRecord("everything"),
+		outputRawRecord(service.OutputRawRecord_STDOUT, "to stdout"),
+		outputRawRecord(service.OutputRawRecord_STDERR, "to stderr"),
+	)
+
+	assert.Contains(t, outputLog, "to stdout")
+	assert.NotContains(t, outputLog, "to stderr")
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+// coreUsageInterval is how often wandb-core's resource usage is sampled
+// while a stream is open.
+const coreUsageInterval = 15 * time.Second
+
+// CoreUsage tracks the resource usage of the wandb-core process itself,
+// unlike the system monitor, which samples the user's processes.
+//
+// The peaks seen while a stream is open are added to its run's config
+// when it exits, under "_wandb.nexus" so that they aren't charted.
+type CoreUsage struct {
+	mu sync.Mutex
+
+	// procOrNil is this process, or nil if it can't be inspected
+	procOrNil *process.Process
+
+	logger *observability.CoreLogger
+
+	// rssWarningBytes is the memory use above which a warning is logged,
+	// or 0 to never warn
+	rssWarningBytes uint64
+
+	// overRSSWarning is whether the last sample was above rssWarningBytes,
+	// so that the warning is logged once each time it's crossed
+	overRSSWarning bool
+
+	peakRSSBytes   uint64
+	peakGoroutines int
+	peakFDs        int32
+	cpuSeconds     float64
+}
+
+func NewCoreUsage(
+	logger *observability.CoreLogger,
+	rssWarningBytes uint64,
+) *CoreUsage {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		logger.Warn("coreusage: can't inspect own process", "error", err)
+		proc = nil
+	}
+
+	return &CoreUsage{
+		procOrNil:       proc,
+		logger:          logger,
+		rssWarningBytes: rssWarningBytes,
+	}
+}
+
+// Sample records the current usage.
+func (u *CoreUsage) Sample() {
+	if u == nil {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.peakGoroutines = max(u.peakGoroutines, runtime.NumGoroutine())
+
+	if u.procOrNil == nil {
+		return
+	}
+
+	// errors are expected on platforms that don't support a statistic,
+	// like open file descriptors on Windows, which are then left out
+	if memInfo, err := u.procOrNil.MemoryInfo(); err == nil {
+		u.peakRSSBytes = max(u.peakRSSBytes, memInfo.RSS)
+		u.checkRSS(memInfo.RSS)
+	}
+	if times, err := u.procOrNil.Times(); err == nil {
+		// CPU time only grows, so the latest is the peak
+		u.cpuSeconds = times.User + times.System
+	}
+	if fds, err := u.procOrNil.NumFDs(); err == nil {
+		u.peakFDs = max(u.peakFDs, fds)
+	}
+}
+
+// checkRSS warns if the memory use just went above the threshold.
+func (u *CoreUsage) checkRSS(rss uint64) {
+	if u.rssWarningBytes == 0 {
+		return
+	}
+
+	over := rss > u.rssWarningBytes
+	if over && !u.overRSSWarning {
+		u.logger.Warn(
+			"coreusage: wandb-core is using a lot of memory, it may have a backlog",
+			"rss_bytes", rss,
+			"warning_bytes", u.rssWarningBytes,
+			"goroutines", runtime.NumGoroutine(),
+		)
+	}
+	u.overRSSWarning = over
+}
+
+// Run samples the usage at every interval until the context is cancelled.
+func (u *CoreUsage) Run(ctx context.Context, interval time.Duration) {
+	if u == nil || interval <= 0 {
+		return
+	}
+
+	u.Sample()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.Sample()
+		}
+	}
+}
+
+// Peaks returns the highest usage sampled, as values for the run's config.
+//
+// Statistics that couldn't be sampled are left out.
+func (u *CoreUsage) Peaks() map[string]any {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	peaks := map[string]any{
+		"peak_goroutines": u.peakGoroutines,
+	}
+	if u.peakRSSBytes > 0 {
+		peaks["peak_rss_bytes"] = u.peakRSSBytes
+	}
+	if u.cpuSeconds > 0 {
+		peaks["cpu_seconds"] = u.cpuSeconds
+	}
+	if u.peakFDs > 0 {
+		peaks["peak_fds"] = u.peakFDs
+	}
+	return peaks
+}
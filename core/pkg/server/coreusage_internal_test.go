@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+func TestCoreUsage_PeaksArePopulatedAndBounded(t *testing.T) {
+	usage := NewCoreUsage(observability.NewNoOpLogger(), 0)
+
+	usage.Sample()
+	peaks := usage.Peaks()
+
+	goroutines, ok := peaks["peak_goroutines"].(int)
+	require.True(t, ok)
+	assert.Positive(t, goroutines)
+	assert.Less(t, goroutines, 100_000)
+
+	rss, ok := peaks["peak_rss_bytes"].(uint64)
+	require.True(t, ok)
+	assert.Greater(t, rss, uint64(1<<20))
+	assert.Less(t, rss, uint64(64<<30))
+
+	// the CPU time can't be more than every core busy since the process
+	// started
+	if cpuSeconds, ok := peaks["cpu_seconds"].(float64); ok {
+		uptime := time.Since(processStart(t)).Seconds() + 1
+		assert.Less(t, cpuSeconds, uptime*float64(runtime.NumCPU()))
+	}
+
+	if runtime.GOOS == "linux" {
+		fds, ok := peaks["peak_fds"].(int32)
+		require.True(t, ok)
+		assert.Positive(t, fds)
+		assert.Less(t, fds, int32(1_000_000))
+	}
+}
+
+func TestCoreUsage_KeepsPeaks(t *testing.T) {
+	usage := NewCoreUsage(observability.NewNoOpLogger(), 0)
+	release := make(chan struct{})
+	for range 100 {
+		go func() { <-release }()
+	}
+	usage.Sample()
+	close(release)
+
+	usage.Sample()
+
+	assert.GreaterOrEqual(t, usage.Peaks()["peak_goroutines"], 100)
+}
+
+func TestCoreUsage_WarnsOnceWhenRSSCrossesThreshold(t *testing.T) {
+	logs := &syncBuffer{}
+	logger := observability.NewCoreLogger(slog.New(slog.NewTextHandler(logs, nil)))
+	usage := NewCoreUsage(logger, 1)
+
+	usage.Sample()
+	usage.Sample()
+
+	assert.Equal(t, 1, strings.Count(logs.String(), "wandb-core is using a lot of memory"))
+}
+
+func TestCoreUsage_NoWarningIfDisabled(t *testing.T) {
+	logs := &syncBuffer{}
+	logger := observability.NewCoreLogger(slog.New(slog.NewTextHandler(logs, nil)))
+	usage := NewCoreUsage(logger, 0)
+
+	usage.Sample()
+
+	assert.NotContains(t, logs.String(), "wandb-core is using a lot of memory")
+}
+
+func TestCoreUsage_RunSamplesUntilCancelled(t *testing.T) {
+	usage := NewCoreUsage(observability.NewNoOpLogger(), 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		usage.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+	require.Eventually(t,
+		func() bool { return usage.Peaks()["peak_goroutines"].(int) > 0 },
+		time.Second,
+		time.Millisecond,
+	)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run didn't stop after its context was cancelled")
+	}
+}
+
+// processStart returns when the test process started.
+func processStart(t *testing.T) time.Time {
+	usage := NewCoreUsage(observability.NewNoOpLogger(), 0)
+	require.NotNil(t, usage.procOrNil)
+	createTime, err := usage.procOrNil.CreateTime()
+	require.NoError(t, err)
+	return time.UnixMilli(createTime)
+}
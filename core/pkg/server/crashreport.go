@@ -0,0 +1,346 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/internal/redact"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+const (
+	// CrashReportFileName is the name of the crash report in the run's
+	// directory.
+	CrashReportFileName = "crash-report.tar.gz"
+
+	// crashReportRecords is how many of the last records processed by
+	// each component are kept for a crash report.
+	crashReportRecords = 32
+)
+
+// recordSummary describes a record that a component processed.
+type recordSummary struct {
+	Type string    `json:"type"`
+	Size int       `json:"size"`
+	Time time.Time `json:"time"`
+
+	// Record is the redacted record, kept only in debug mode
+	Record json.RawMessage `json:"record,omitempty"`
+}
+
+// recordRing holds the summaries of the last records a component
+// processed.
+type recordRing struct {
+	mu sync.Mutex
+
+	// summaries is the ring buffer, of which next is the oldest entry
+	// once it is full
+	summaries []recordSummary
+	next      int
+
+	// processed is the number of records the component processed
+	processed int64
+}
+
+func (r *recordRing) add(summary recordSummary) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.processed++
+	if len(r.summaries) < crashReportRecords {
+		r.summaries = append(r.summaries, summary)
+		return
+	}
+	r.summaries[r.next] = summary
+	r.next = (r.next + 1) % crashReportRecords
+}
+
+// snapshot returns the summaries from oldest to newest and the number of
+// records processed.
+func (r *recordRing) snapshot() ([]recordSummary, int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summaries := make([]recordSummary, 0, len(r.summaries))
+	summaries = append(summaries, r.summaries[r.next:]...)
+	summaries = append(summaries, r.summaries[:r.next]...)
+	return summaries, r.processed
+}
+
+// CrashReporter writes a crash report into the run's directory when the
+// stream fails irrecoverably.
+//
+// The report is a gzipped tarball with the error and stack trace, the
+// last records each component processed, the settings without secrets,
+// the versions of the core and the OS, and where the run's internal log
+// is. Only the first failure is
+// reported.
+type CrashReporter struct {
+	settings *settings.Settings
+
+	// keepRecords is whether to keep whole records rather than only their
+	// type, size and time
+	keepRecords bool
+
+	// rings are the last records processed, by component
+	ringsMu sync.Mutex
+	rings   map[string]*recordRing
+
+	// mu is held while a report is written
+	mu sync.Mutex
+
+	// reported is whether a report was attempted
+	reported bool
+
+	// path is the path of the written report, if any
+	path string
+}
+
+func NewCrashReporter(
+	settings *settings.Settings,
+	keepRecords bool,
+) *CrashReporter {
+	return &CrashReporter{
+		settings:    settings,
+		keepRecords: keepRecords,
+		rings:       make(map[string]*recordRing),
+	}
+}
+
+// Observe notes that the component processed the record.
+func (r *CrashReporter) Observe(component string, record *service.Record) {
+	if r == nil {
+		return
+	}
+
+	summary := recordSummary{
+		Type: recordTypeName(record),
+		Size: proto.Size(record),
+		Time: time.Now(),
+	}
+	if r.keepRecords {
+		summary.Record, _ = protojson.Marshal(redact.Proto(record))
+	}
+
+	r.ring(component).add(summary)
+}
+
+// LastProcessed returns when each component last processed a record, by
+// component.
+func (r *CrashReporter) LastProcessed() map[string]time.Time {
+	if r == nil {
+		return nil
+	}
+
+	r.ringsMu.Lock()
+	defer r.ringsMu.Unlock()
+
+	last := make(map[string]time.Time, len(r.rings))
+	for component, ring := range r.rings {
+		if summaries, _ := ring.snapshot(); len(summaries) > 0 {
+			last[component] = summaries[len(summaries)-1].Time
+		}
+	}
+	return last
+}
+
+func (r *CrashReporter) ring(component string) *recordRing {
+	r.ringsMu.Lock()
+	defer r.ringsMu.Unlock()
+
+	ring, ok := r.rings[component]
+	if !ok {
+		ring = &recordRing{}
+		r.rings[component] = ring
+	}
+	return ring
+}
+
+// ReportPanic writes a crash report if the component is panicking, and
+// lets the panic continue.
+//
+// It must be deferred, like observability.CoreLogger.Reraise.
+func (r *CrashReporter) ReportPanic(component string) {
+	if r == nil {
+		return
+	}
+
+	if err := recover(); err != nil {
+		path, reportErr := r.Report(component, fmt.Errorf("panic: %v", err), debug.Stack())
+		if reportErr == nil {
+			// the stream won't print its footer after a panic
+			utils.PrintFooterCrashReport(path)
+		}
+		panic(err)
+	}
+}
+
+// Report writes a crash report for the component's error, returning the
+// report's path.
+//
+// Only the first report is written; later calls return its path.
+func (r *CrashReporter) Report(
+	component string,
+	err error,
+	stack []byte,
+) (string, error) {
+	if r == nil {
+		return "", nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reported {
+		return r.path, nil
+	}
+	r.reported = true
+
+	runDir := r.settings.Proto.GetSyncDir().GetValue()
+	if runDir == "" {
+		return "", errors.New("crash report: no run directory")
+	}
+
+	path := filepath.Join(runDir, CrashReportFileName)
+	if err := r.write(path, component, err, stack); err != nil {
+		return "", err
+	}
+	r.path = path
+	return path, nil
+}
+
+// Path is the path of the crash report, or empty if none was written.
+func (r *CrashReporter) Path() string {
+	if r == nil {
+		return ""
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.path
+}
+
+// write writes the crash report to the path.
+//
+// The archive is built in memory and written atomically, so that a crash
+// while writing it doesn't leave a truncated report.
+func (r *CrashReporter) write(
+	path string,
+	component string,
+	reportedErr error,
+	stack []byte,
+) error {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+
+	entries, err := r.entries(component, reportedErr, stack)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    entry.name,
+			Mode:    0o644,
+			Size:    int64(len(entry.content)),
+			ModTime: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("crash report: failed to write %s: %v", entry.name, err)
+		}
+		if _, err := tw.Write(entry.content); err != nil {
+			return fmt.Errorf("crash report: failed to write %s: %v", entry.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("crash report: failed to close archive: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("crash report: failed to compress archive: %v", err)
+	}
+	if err := atomicfile.Write(path, archive.Bytes(), 0o666); err != nil {
+		return fmt.Errorf("crash report: failed to write file: %v", err)
+	}
+	return nil
+}
+
+type crashReportEntry struct {
+	name    string
+	content []byte
+}
+
+// entries returns the files of the crash report.
+func (r *CrashReporter) entries(
+	component string,
+	reportedErr error,
+	stack []byte,
+) ([]crashReportEntry, error) {
+	type componentRecords struct {
+		Processed int64           `json:"processed"`
+		Last      []recordSummary `json:"last"`
+	}
+	records := make(map[string]componentRecords)
+	r.ringsMu.Lock()
+	for name, ring := range r.rings {
+		last, processed := ring.snapshot()
+		records[name] = componentRecords{Processed: processed, Last: last}
+	}
+	r.ringsMu.Unlock()
+
+	recordsJSON, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("crash report: failed to encode records: %v", err)
+	}
+
+	settingsJSON, err := protojson.MarshalOptions{Multiline: true}.
+		Marshal(r.settings.Redacted())
+	if err != nil {
+		return nil, fmt.Errorf("crash report: failed to encode settings: %v", err)
+	}
+
+	infoJSON, err := json.MarshalIndent(map[string]string{
+		"core_version": version.Version,
+		"go_version":   runtime.Version(),
+		"os":           runtime.GOOS,
+		"arch":         runtime.GOARCH,
+		"time":         time.Now().Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("crash report: failed to encode info: %v", err)
+	}
+
+	// the run's lines in a shared log are those with its stream ID
+	logJSON, err := json.MarshalIndent(map[string]any{
+		"path":      r.settings.GetStreamLogFile(),
+		"shared":    r.settings.GetSharedInternalLogFile() != "",
+		"stream_id": r.settings.GetRunID(),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("crash report: failed to encode log location: %v", err)
+	}
+
+	crash := fmt.Sprintf("component: %s\nerror: %v\n\n%s", component, reportedErr, stack)
+
+	return []crashReportEntry{
+		{"crash.txt", []byte(crash)},
+		{"records.json", recordsJSON},
+		{"settings.json", settingsJSON},
+		{"info.json", infoJSON},
+		{"log.json", logJSON},
+	}, nil
+}
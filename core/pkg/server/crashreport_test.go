@@ -0,0 +1,190 @@
+package server_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func makeCrashReporter(t *testing.T, keepRecords bool) (*server.CrashReporter, string) {
+	runDir := t.TempDir()
+	reporter := server.NewCrashReporter(
+		settings.From(&service.Settings{
+			SyncDir: &wrapperspb.StringValue{Value: runDir},
+			ApiKey:  &wrapperspb.StringValue{Value: "secret-api-key"},
+		}),
+		keepRecords,
+	)
+	return reporter, runDir
+}
+
+// readCrashReport returns the files in a crash report by name.
+func readCrashReport(t *testing.T, path string) map[string]string {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+type crashReportRecords map[string]struct {
+	Processed int64 `json:"processed"`
+	Last      []struct {
+		Type   string          `json:"type"`
+		Size   int             `json:"size"`
+		Record json.RawMessage `json:"record"`
+	} `json:"last"`
+}
+
+func TestCrashReporter_Report(t *testing.T) {
+	reporter, runDir := makeCrashReporter(t, false)
+	for i := 0; i < 40; i++ {
+		reporter.Observe("sender", makeHistoryRecord(data{
+			step:  int64(i),
+			items: map[string]string{"loss": "0.5"},
+		}))
+	}
+	reporter.Observe("writer", makeDeferRecord(service.DeferRequest_FLUSH_FS))
+
+	path, err := reporter.Report("sender", errors.New("gave up"), []byte("the stack"))
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(runDir, server.CrashReportFileName), path)
+	assert.Equal(t, path, reporter.Path())
+	files := readCrashReport(t, path)
+	assert.Contains(t, files["crash.txt"], "component: sender")
+	assert.Contains(t, files["crash.txt"], "error: gave up")
+	assert.Contains(t, files["crash.txt"], "the stack")
+	assert.Contains(t, files["info.json"], "core_version")
+	assert.Contains(t, files["settings.json"], runDir)
+	for name, content := range files {
+		assert.NotContains(t, content, "secret-api-key", "secret in %s", name)
+	}
+
+	var records crashReportRecords
+	require.NoError(t, json.Unmarshal([]byte(files["records.json"]), &records))
+	assert.EqualValues(t, 40, records["sender"].Processed)
+	assert.Len(t, records["sender"].Last, 32)
+	assert.Equal(t, "history", records["sender"].Last[0].Type)
+	assert.Positive(t, records["sender"].Last[0].Size)
+	assert.Nil(t, records["sender"].Last[0].Record)
+	assert.Equal(t, "request.defer", records["writer"].Last[0].Type)
+}
+
+func TestCrashReporter_KeepsRecordsInDebugMode(t *testing.T) {
+	reporter, _ := makeCrashReporter(t, true)
+	reporter.Observe("handler", &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_CredentialsUpdate{
+					CredentialsUpdate: &service.CredentialsUpdateRequest{
+						ApiKey: "secret-new-key",
+					},
+				},
+			},
+		},
+	})
+
+	path, err := reporter.Report("handler", errors.New("failed"), nil)
+	require.NoError(t, err)
+
+	files := readCrashReport(t, path)
+	var records crashReportRecords
+	require.NoError(t, json.Unmarshal([]byte(files["records.json"]), &records))
+	assert.Contains(t, string(records["handler"].Last[0].Record), "credentialsUpdate")
+	assert.NotContains(t, files["records.json"], "secret-new-key")
+}
+
+func TestCrashReporter_OnlyFirstFailure(t *testing.T) {
+	reporter, _ := makeCrashReporter(t, false)
+
+	path, err := reporter.Report("filestream", errors.New("first"), nil)
+	require.NoError(t, err)
+	secondPath, err := reporter.Report("sender", errors.New("second"), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, path, secondPath)
+	assert.Contains(t, readCrashReport(t, path)["crash.txt"], "first")
+}
+
+func TestCrashReporter_ReportPanic(t *testing.T) {
+	reporter, _ := makeCrashReporter(t, false)
+
+	recovered := func() (err any) {
+		defer func() { err = recover() }()
+		defer reporter.ReportPanic("handler")
+		panic("boom")
+	}()
+
+	assert.Equal(t, "boom", recovered)
+	require.NotEmpty(t, reporter.Path())
+	crash := readCrashReport(t, reporter.Path())["crash.txt"]
+	assert.Contains(t, crash, "panic: boom")
+	assert.Contains(t, crash, "goroutine")
+}
+
+func TestCrashReporter_Nil(t *testing.T) {
+	var reporter *server.CrashReporter
+
+	reporter.Observe("handler", &service.Record{})
+	path, err := reporter.Report("handler", errors.New("failed"), nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+	assert.Empty(t, reporter.Path())
+}
+
+func TestCrashReporter_NamesSharedLog(t *testing.T) {
+	runDir := t.TempDir()
+	shared := filepath.Join(t.TempDir(), "core.log")
+	reporter := server.NewCrashReporter(
+		settings.From(&service.Settings{
+			RunId:              &wrapperspb.StringValue{Value: "crashed"},
+			SyncDir:            &wrapperspb.StringValue{Value: runDir},
+			LogInternal:        &wrapperspb.StringValue{Value: filepath.Join(runDir, "debug-internal.log")},
+			XSharedInternalLog: &wrapperspb.StringValue{Value: shared},
+		}),
+		false,
+	)
+
+	path, err := reporter.Report("sender", errors.New("gave up"), nil)
+	require.NoError(t, err)
+
+	var location struct {
+		Path     string `json:"path"`
+		Shared   bool   `json:"shared"`
+		StreamID string `json:"stream_id"`
+	}
+	files := readCrashReport(t, path)
+	require.NoError(t, json.Unmarshal([]byte(files["log.json"]), &location))
+	assert.Equal(t, shared, location.Path)
+	assert.True(t, location.Shared)
+	assert.Equal(t, "crashed", location.StreamID)
+}
@@ -0,0 +1,116 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// runWithDebugLog runs the run "debug-log" to its exit with its debug log
+// at the path, and returns where the status and settings responses say
+// the log is.
+func runWithDebugLog(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	logFile string,
+) (status *service.RunPaths, getSettings *service.GetSettingsResponse) {
+	t.Helper()
+	dir := t.TempDir()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         wrapperspb.String("debug-log"),
+		BaseUrl:       wrapperspb.String(backend.URL()),
+		ApiKey:        wrapperspb.String("test-api-key"),
+		LogDir:        wrapperspb.String(dir),
+		LogInternal:   wrapperspb.String(logFile),
+		SyncFile:      wrapperspb.String(filepath.Join(dir, "run-debug-log.wandb")),
+		FilesDir:      wrapperspb.String(filepath.Join(dir, "files")),
+		XDisableStats: wrapperspb.Bool(true),
+		XDisableMeta:  wrapperspb.Bool(true),
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "debug-log", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	require.Nil(t, awaitResult(t, responses, "run").GetRunResult().GetError())
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(disabledRequest("status", &service.Request{
+		RequestType: &service.Request_Status{Status: &service.StatusRequest{}},
+	}))
+	status = awaitResult(t, responses, "status").
+		GetResponse().GetStatusResponse().GetPaths()
+	stream.HandleRecord(disabledRequest("settings", &service.Request{
+		RequestType: &service.Request_GetSettings{
+			GetSettings: &service.GetSettingsRequest{},
+		},
+	}))
+	getSettings = awaitResult(t, responses, "settings").
+		GetResponse().GetGetSettingsResponse()
+	stream.FinishAndClose(0)
+
+	fileStream := backend.Requests(servertest.RouteFileStream)
+	require.NotEmpty(t, fileStream)
+	assert.Contains(t, string(fileStream[len(fileStream)-1].Body), `"complete":true`)
+	return status, getSettings
+}
+
+func TestDebugLog_WrittenWhereConfigured(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	logFile := filepath.Join(t.TempDir(), "debug-internal.log")
+
+	status, getSettings := runWithDebugLog(t, backend, logFile)
+
+	assert.Equal(t, logFile, status.GetDebugLog())
+	assert.Equal(t, logFile, getSettings.GetDebugLog())
+	assert.FileExists(t, logFile)
+}
+
+// A debug log that can't be created is written to a temporary file, and
+// the run goes on as usual.
+func TestDebugLog_FallsBackToTempFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+
+	status, getSettings := runWithDebugLog(t, backend, unwritableLogFile(t))
+
+	debugLog := status.GetDebugLog()
+	assert.Equal(t, tmpDir, filepath.Dir(debugLog))
+	assert.Equal(t, debugLog, getSettings.GetDebugLog())
+	log, err := os.ReadFile(debugLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "stream: debug log fallback")
+	assert.Contains(t, string(log), "not-a-dir")
+}
+
+// A debug log that can't be written to any file is written to stderr.
+func TestDebugLog_FallsBackToStderr(t *testing.T) {
+	t.Setenv("TMPDIR", filepath.Dir(unwritableLogFile(t)))
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+
+	status, getSettings := runWithDebugLog(t, backend, unwritableLogFile(t))
+
+	assert.Equal(t, "stderr", status.GetDebugLog())
+	assert.Equal(t, "stderr", getSettings.GetDebugLog())
+}
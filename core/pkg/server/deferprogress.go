@@ -0,0 +1,311 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// slowDeferStateThreshold is how long the sender may stay in a defer
+	// state before it is logged.
+	slowDeferStateThreshold = 60 * time.Second
+
+	// slowDeferStateCheckInterval is how often the defer state is checked
+	// against slowDeferStateThreshold.
+	slowDeferStateCheckInterval = 10 * time.Second
+)
+
+// deferStateDescriptions say what the sender does in each defer state.
+var deferStateDescriptions = map[service.DeferRequest_DeferState]string{
+	service.DeferRequest_BEGIN:                 "starting to exit",
+	service.DeferRequest_FLUSH_RUN:             "flushing the run",
+	service.DeferRequest_FLUSH_STATS:           "flushing system metrics",
+	service.DeferRequest_FLUSH_PARTIAL_HISTORY: "flushing partial history",
+	service.DeferRequest_FLUSH_TB:              "committing artifacts",
+	service.DeferRequest_FLUSH_SUM:             "uploading the summary",
+	service.DeferRequest_FLUSH_DEBOUNCER:       "uploading the config",
+	service.DeferRequest_FLUSH_OUTPUT:          "flushing console output",
+	service.DeferRequest_FLUSH_JOB:             "saving the job artifact",
+	service.DeferRequest_FLUSH_DIR:             "flushing the files directory",
+	service.DeferRequest_FLUSH_FP:              "waiting for file uploads",
+	service.DeferRequest_JOIN_FP:               "finishing file uploads",
+	service.DeferRequest_FLUSH_FS:              "flushing the filestream",
+	service.DeferRequest_FLUSH_FINAL:           "finalizing the run",
+	service.DeferRequest_END:                   "finishing",
+}
+
+// phaseSeconds returns the field of the timing for the phase of the exit
+// that includes the defer state.
+func phaseSeconds(
+	timing *service.ShutdownTiming,
+	state service.DeferRequest_DeferState,
+) *float64 {
+	switch state {
+	case service.DeferRequest_BEGIN,
+		service.DeferRequest_FLUSH_RUN,
+		service.DeferRequest_FLUSH_STATS,
+		service.DeferRequest_FLUSH_PARTIAL_HISTORY,
+		service.DeferRequest_FLUSH_OUTPUT:
+		return &timing.WriterFlushSeconds
+	case service.DeferRequest_FLUSH_SUM,
+		service.DeferRequest_FLUSH_DEBOUNCER:
+		return &timing.FinalUpsertSeconds
+	case service.DeferRequest_FLUSH_TB,
+		service.DeferRequest_FLUSH_JOB:
+		return &timing.ArtifactCommitSeconds
+	case service.DeferRequest_FLUSH_DIR,
+		service.DeferRequest_FLUSH_FP,
+		service.DeferRequest_JOIN_FP:
+		return &timing.FileUploadSeconds
+	case service.DeferRequest_FLUSH_FS:
+		return &timing.FileStreamFlushSeconds
+	default:
+		return &timing.FooterSeconds
+	}
+}
+
+// DeferProgress tracks the sender's progress through the defer states
+// in which a run exits.
+//
+// It is written by the sender and read by the handler, to report where
+// a run that is taking long to exit is stuck. The times at which each
+// state was entered also give the breakdown of how long the exit took.
+type DeferProgress struct {
+	mu sync.Mutex
+
+	// started is whether the sender entered any defer state
+	started bool
+
+	// state is the defer state the sender is in
+	state service.DeferRequest_DeferState
+
+	// enteredAt is when the sender entered the state
+	enteredAt time.Time
+
+	// requestedAt is when the handler got the exit record
+	requestedAt time.Time
+
+	// history is the defer states entered so far, in order
+	history []deferStateEntry
+
+	// finishedAt is when the sender finished exiting
+	finishedAt time.Time
+
+	// fileStreamDone is whether the filestream uploaded all of its data
+	fileStreamDone bool
+
+	// pendingArtifacts is the number of artifacts being saved
+	pendingArtifacts int
+}
+
+type deferStateEntry struct {
+	state     service.DeferRequest_DeferState
+	enteredAt time.Time
+}
+
+func NewDeferProgress() *DeferProgress {
+	return &DeferProgress{}
+}
+
+// Enter records that the sender entered the defer state.
+func (p *DeferProgress) Enter(state service.DeferRequest_DeferState) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = true
+	p.state = state
+	p.enteredAt = time.Now()
+	p.history = append(p.history, deferStateEntry{state, p.enteredAt})
+}
+
+// Requested records that the run was asked to exit.
+//
+// Only the first call counts.
+func (p *DeferProgress) Requested() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.requestedAt.IsZero() {
+		p.requestedAt = time.Now()
+	}
+}
+
+// Finish records that the sender is done exiting.
+func (p *DeferProgress) Finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finishedAt = time.Now()
+}
+
+// FileStreamDone records that the filestream uploaded all of its data.
+func (p *DeferProgress) FileStreamDone() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fileStreamDone = true
+}
+
+// ArtifactStarted records that the sender started saving an artifact.
+func (p *DeferProgress) ArtifactStarted() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pendingArtifacts++
+}
+
+// ArtifactFinished records that the sender saved an artifact or failed to.
+func (p *DeferProgress) ArtifactFinished() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pendingArtifacts--
+}
+
+// IsFileStreamDone returns whether the filestream uploaded all of its data.
+func (p *DeferProgress) IsFileStreamDone() bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.fileStreamDone
+}
+
+// Timing returns how long each phase of the exit took, or nil if the
+// sender hasn't finished exiting.
+func (p *DeferProgress) Timing() *service.ShutdownTiming {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finishedAt.IsZero() || len(p.history) == 0 {
+		return nil
+	}
+
+	timing := &service.ShutdownTiming{}
+	start := p.history[0].enteredAt
+	if !p.requestedAt.IsZero() && p.requestedAt.Before(start) {
+		timing.DrainSeconds = start.Sub(p.requestedAt).Seconds()
+		start = p.requestedAt
+	}
+
+	for i, entry := range p.history {
+		leftAt := p.finishedAt
+		if i+1 < len(p.history) {
+			leftAt = p.history[i+1].enteredAt
+		}
+		*phaseSeconds(timing, entry.state) += leftAt.Sub(entry.enteredAt).Seconds()
+	}
+
+	timing.TotalSeconds = p.finishedAt.Sub(start).Seconds()
+	return timing
+}
+
+// logShutdownTiming logs how long each phase of the exit took.
+func logShutdownTiming(logger *observability.CoreLogger, timing *service.ShutdownTiming) {
+	if timing == nil {
+		return
+	}
+
+	logger.Info(
+		"sender: exit timing",
+		"total_seconds", timing.GetTotalSeconds(),
+		"drain_seconds", timing.GetDrainSeconds(),
+		"writer_flush_seconds", timing.GetWriterFlushSeconds(),
+		"final_upsert_seconds", timing.GetFinalUpsertSeconds(),
+		"artifact_commit_seconds", timing.GetArtifactCommitSeconds(),
+		"file_upload_seconds", timing.GetFileUploadSeconds(),
+		"file_stream_flush_seconds", timing.GetFileStreamFlushSeconds(),
+		"footer_seconds", timing.GetFooterSeconds(),
+	)
+}
+
+// Proto returns the current defer state and how long the sender has
+// been in it, or nil if the run isn't exiting.
+func (p *DeferProgress) Proto() *service.ExitProgress {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.started {
+		return nil
+	}
+
+	return &service.ExitProgress{
+		State:            p.state,
+		Description:      deferStateDescriptions[p.state],
+		SecondsInState:   time.Since(p.enteredAt).Seconds(),
+		PendingArtifacts: int32(p.pendingArtifacts),
+	}
+}
+
+// Watch logs a warning whenever the sender spends longer than
+// slowDeferStateThreshold in a defer state, repeating while it stays
+// there.
+//
+// It returns once the sender reaches the END state or the context is
+// cancelled.
+func (p *DeferProgress) Watch(ctx context.Context, logger *observability.CoreLogger) {
+	if p == nil {
+		return
+	}
+
+	ticker := time.NewTicker(slowDeferStateCheckInterval)
+	defer ticker.Stop()
+
+	var lastLogged time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		progress := p.Proto()
+		if progress.GetState() == service.DeferRequest_END {
+			return
+		}
+
+		inState := time.Duration(progress.GetSecondsInState() * float64(time.Second))
+		if inState < slowDeferStateThreshold ||
+			time.Since(lastLogged) < slowDeferStateThreshold {
+			continue
+		}
+
+		lastLogged = time.Now()
+		logger.Warn(
+			"sender: exit is taking long",
+			"state", progress.GetState().String(),
+			"description", progress.GetDescription(),
+			"seconds_in_state", int(progress.GetSecondsInState()),
+		)
+	}
+}
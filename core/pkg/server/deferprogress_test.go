@@ -0,0 +1,123 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestDeferProgress_NotExiting(t *testing.T) {
+	assert.Nil(t, server.NewDeferProgress().Proto())
+
+	var nilProgress *server.DeferProgress
+	nilProgress.Enter(service.DeferRequest_FLUSH_FS)
+	assert.Nil(t, nilProgress.Proto())
+}
+
+func TestDeferProgress_Enter(t *testing.T) {
+	progress := server.NewDeferProgress()
+
+	progress.Enter(service.DeferRequest_FLUSH_FP)
+	progress.Enter(service.DeferRequest_FLUSH_FS)
+
+	exitProgress := progress.Proto()
+	require.NotNil(t, exitProgress)
+	assert.Equal(t, service.DeferRequest_FLUSH_FS, exitProgress.GetState())
+	assert.Equal(t, "flushing the filestream", exitProgress.GetDescription())
+	assert.GreaterOrEqual(t, exitProgress.GetSecondsInState(), 0.0)
+}
+
+func TestDeferProgress_PendingArtifacts(t *testing.T) {
+	progress := server.NewDeferProgress()
+
+	progress.ArtifactStarted()
+	progress.ArtifactStarted()
+	progress.ArtifactFinished()
+	progress.Enter(service.DeferRequest_FLUSH_TB)
+
+	exitProgress := progress.Proto()
+	assert.EqualValues(t, 1, exitProgress.GetPendingArtifacts())
+	assert.Equal(t, "committing artifacts", exitProgress.GetDescription())
+}
+
+func TestDeferProgress_EveryStateHasDescription(t *testing.T) {
+	progress := server.NewDeferProgress()
+
+	for state := range service.DeferRequest_DeferState_name {
+		progress.Enter(service.DeferRequest_DeferState(state))
+		assert.NotEmpty(t, progress.Proto().GetDescription(), "state %d", state)
+	}
+}
+
+func TestDeferProgress_Timing(t *testing.T) {
+	progress := server.NewDeferProgress()
+	assert.Nil(t, progress.Timing())
+
+	progress.Requested()
+	time.Sleep(20 * time.Millisecond)
+	for state := service.DeferRequest_BEGIN; state <= service.DeferRequest_END; state++ {
+		progress.Enter(state)
+		if state == service.DeferRequest_FLUSH_FP {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	assert.Nil(t, progress.Timing(), "not finished yet")
+	progress.Finish()
+
+	timing := progress.Timing()
+	require.NotNil(t, timing)
+	assert.GreaterOrEqual(t, timing.GetDrainSeconds(), 0.02)
+	assert.GreaterOrEqual(t, timing.GetFileUploadSeconds(), 0.05)
+	assert.Less(t, timing.GetFileStreamFlushSeconds(), 0.05)
+	assert.InDelta(t,
+		timing.GetTotalSeconds(),
+		timing.GetDrainSeconds()+
+			timing.GetWriterFlushSeconds()+
+			timing.GetFinalUpsertSeconds()+
+			timing.GetArtifactCommitSeconds()+
+			timing.GetFileUploadSeconds()+
+			timing.GetFileStreamFlushSeconds()+
+			timing.GetFooterSeconds(),
+		1e-6)
+}
+
+func TestHandlePollExit_ReportsDeferState(t *testing.T) {
+	progress := server.NewDeferProgress()
+	inChan := make(chan *service.Record, 1)
+	outChan := make(chan *service.Result, 1)
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:        observability.NewNoOpLogger(),
+			Settings:      &service.Settings{},
+			FwdChan:       make(chan *service.Record, 1),
+			OutChan:       outChan,
+			DeferProgress: progress,
+		},
+	)
+	go h.Do(inChan)
+	defer close(inChan)
+
+	progress.Enter(service.DeferRequest_FLUSH_FP)
+	inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_PollExit{
+					PollExit: &service.PollExitRequest{},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	}
+	result := <-outChan
+
+	exitProgress := result.GetResponse().GetPollExitResponse().GetExitProgress()
+	assert.Equal(t, service.DeferRequest_FLUSH_FP, exitProgress.GetState())
+	assert.Equal(t, "waiting for file uploads", exitProgress.GetDescription())
+}
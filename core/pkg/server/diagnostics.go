@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Diagnostics takes snapshots of a stream's internals, to help debug
+// slowdowns and leaks.
+//
+// Snapshots are returned in status responses, and are logged
+// periodically if the stream is configured to.
+type Diagnostics struct {
+	// channels return the depths of the stream's channels, by name
+	channels map[string]func() int
+
+	// fileStreamOrNil is the filestream whose backlog is reported
+	fileStreamOrNil filestream.FileStream
+
+	// fileTransferManagerOrNil is the manager whose tasks are reported
+	fileTransferManagerOrNil filetransfer.FileTransferManager
+
+	// uploadsOrNil is the sender's upload lane
+	uploadsOrNil *uploadLane
+
+	// retries counts the stream's retried requests
+	retries *RetryBudget
+
+	// historyLimiter counts the history rows that weren't sent
+	historyLimiter *HistoryLimiter
+
+	// historyKeys counts the history values that weren't aggregated
+	historyKeys *HistoryKeyLimit
+
+	// unknownTypes counts the records of types this version doesn't know
+	unknownTypes *UnknownTypes
+
+	// handlerMemory is the size of the handler's state
+	handlerMemory *HandlerMemory
+
+	// latency measures how long history rows take to go through the stream
+	latency *PipelineLatency
+
+	// polls counts the sender's status polls and the queries they made
+	polls *pollCoalescer
+}
+
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{channels: make(map[string]func() int)}
+}
+
+// watchChannel includes the channel's depth in the diagnostics.
+//
+// Channels must all be watched before the stream starts.
+func watchChannel[T any](d *Diagnostics, name string, ch chan T) {
+	d.channels[name] = func() int { return len(ch) }
+}
+
+// Proto returns a snapshot of the stream's diagnostics.
+func (d *Diagnostics) Proto() *service.StreamDiagnostics {
+	if d == nil {
+		return nil
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	diagnostics := &service.StreamDiagnostics{
+		ChannelDepths:  make(map[string]int32, len(d.channels)),
+		Goroutines:     int32(runtime.NumGoroutine()),
+		HeapInUseBytes: memStats.HeapInuse,
+		Retries:        d.retries.Stats(),
+
+		CoalescedHistoryRows:   d.historyLimiter.Coalesced(),
+		UntrackedHistoryValues: d.historyKeys.Untracked(),
+		PipelineLatency:        d.latency.Proto(),
+		Polls:                  d.polls.Proto(),
+		HandlerMemory:          d.handlerMemory.Proto(),
+		UnknownRecordTypes:     d.unknownTypes.Counts(),
+	}
+
+	for name, depth := range d.channels {
+		diagnostics.ChannelDepths[name] = int32(depth())
+	}
+
+	if d.fileTransferManagerOrNil != nil {
+		diagnostics.PendingUploads += int32(d.fileTransferManagerOrNil.PendingTasks())
+	}
+	if d.uploadsOrNil != nil {
+		diagnostics.PendingUploads += int32(d.uploadsOrNil.Pending())
+	}
+
+	if d.fileStreamOrNil != nil {
+		backlog := d.fileStreamOrNil.Backlog()
+		diagnostics.QueuedFilestreamUpdates = int32(backlog.QueuedUpdates)
+		diagnostics.UnackedFilestreamLines = int32(backlog.UnackedLines)
+	}
+
+	return diagnostics
+}
+
+// Log logs a diagnostics snapshot at every interval until the context
+// is cancelled.
+func (d *Diagnostics) Log(
+	ctx context.Context,
+	interval time.Duration,
+	logger *observability.CoreLogger,
+) {
+	if d == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		diagnostics := d.Proto()
+		logger.Info(
+			"diagnostics",
+			"channel_depths", diagnostics.GetChannelDepths(),
+			"goroutines", diagnostics.GetGoroutines(),
+			"heap_in_use_bytes", diagnostics.GetHeapInUseBytes(),
+			"pending_uploads", diagnostics.GetPendingUploads(),
+			"queued_filestream_updates", diagnostics.GetQueuedFilestreamUpdates(),
+			"unacked_filestream_lines", diagnostics.GetUnackedFilestreamLines(),
+			"retries", retryTotals(diagnostics.GetRetries()),
+			"coalesced_history_rows", diagnostics.GetCoalescedHistoryRows(),
+			"untracked_history_values", diagnostics.GetUntrackedHistoryValues(),
+			"handler_memory", handlerMemorySizes(diagnostics.GetHandlerMemory()),
+			"unknown_record_types", diagnostics.GetUnknownRecordTypes(),
+			"persisted_latency_p95_seconds",
+			diagnostics.GetPipelineLatency().GetPersisted().GetP95Seconds(),
+			"acknowledged_latency_p95_seconds",
+			diagnostics.GetPipelineLatency().GetAcknowledged().GetP95Seconds(),
+		)
+	}
+}
+
+// handlerMemorySizes returns the sizes of the handler's state and the
+// number of entries dropped to bound it, for logging.
+func handlerMemorySizes(memory *service.HandlerMemory) map[string]int64 {
+	if memory == nil {
+		return nil
+	}
+
+	return map[string]int64{
+		"sampled_keys":         int64(memory.GetSampledKeys()),
+		"summary_keys":         int64(memory.GetSummaryKeys()),
+		"partial_history_keys": int64(memory.GetPartialHistoryKeys()),
+		"metric_definitions":   int64(memory.GetMetricDefinitions()),
+		"dropped": memory.GetEvictedSampledKeys() +
+			memory.GetRefusedSummaryValues() +
+			memory.GetRefusedPartialHistoryValues() +
+			memory.GetRefusedMetricDefinitions(),
+	}
+}
+
+// retryTotals returns the total retries by endpoint class, for logging.
+func retryTotals(stats map[string]*service.RetryStats) map[string]int32 {
+	totals := make(map[string]int32, len(stats))
+	for endpoint, endpointStats := range stats {
+		totals[endpoint] = endpointStats.GetTotal()
+	}
+	return totals
+}
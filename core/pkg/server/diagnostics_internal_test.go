@@ -0,0 +1,157 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/filetransfertest"
+	"github.com/wandb/wandb/core/pkg/filestreamtest"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// syncBuffer is a bytes.Buffer that is safe to use concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDiagnostics_Proto(t *testing.T) {
+	fileTransferManager := filetransfertest.NewFakeFileTransferManager()
+	fileTransferManager.AddTask(&filetransfer.Task{
+		CompletionCallback: func(*filetransfer.Task) {},
+	})
+	uploads := newUploadLane()
+	release := make(chan struct{})
+	uploads.Go(func() { <-release })
+	defer func() {
+		close(release)
+		uploads.Wait()
+	}()
+	records := make(chan *service.Record, 10)
+	records <- &service.Record{}
+	records <- &service.Record{}
+
+	d := NewDiagnostics()
+	d.fileStreamOrNil = filestreamtest.NewFakeFileStream()
+	d.fileTransferManagerOrNil = fileTransferManager
+	d.uploadsOrNil = uploads
+	d.retries = NewRetryBudget(10, &testClock{now: time.Unix(1000, 0)}, nil)
+	d.retries.Record(retryEndpointGraphQL, "server error")
+	d.historyKeys = NewHistoryKeyLimit(1)
+	d.historyKeys.track([]*service.HistoryItem{{Key: "a"}, {Key: "b"}, {Key: "c"}})
+	d.handlerMemory = NewHandlerMemory(HandlerMemoryLimits{})
+	d.handlerMemory.summaryKeys.Store(3)
+	watchChannel(d, "handler.fwd", records)
+	watchChannel(d, "sender.out", make(chan *service.Result, 10))
+
+	diagnostics := d.Proto()
+
+	assert.Equal(t,
+		map[string]int32{"handler.fwd": 2, "sender.out": 0},
+		diagnostics.GetChannelDepths())
+	assert.EqualValues(t, 2, diagnostics.GetPendingUploads())
+	assert.EqualValues(t, 1, diagnostics.GetRetries()[retryEndpointGraphQL].GetTotal())
+	assert.EqualValues(t, 2, diagnostics.GetUntrackedHistoryValues())
+	assert.EqualValues(t, 3, diagnostics.GetHandlerMemory().GetSummaryKeys())
+	assert.Positive(t, diagnostics.GetGoroutines())
+	assert.Positive(t, diagnostics.GetHeapInUseBytes())
+}
+
+func TestDiagnostics_Nil(t *testing.T) {
+	var d *Diagnostics
+
+	assert.Nil(t, d.Proto())
+	d.Log(context.Background(), time.Millisecond, observability.NewNoOpLogger())
+}
+
+func TestDiagnostics_LogStopsWithContext(t *testing.T) {
+	var buf syncBuffer
+	logger := observability.NewCoreLogger(
+		slog.New(slog.NewJSONHandler(&buf, nil)),
+		observability.WithTags(observability.Tags{}),
+	)
+	d := NewDiagnostics()
+	watchChannel(d, "stream.in", make(chan *service.Record, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		d.Log(ctx, time.Millisecond, logger)
+		close(done)
+	}()
+
+	assert.Eventually(t,
+		func() bool { return strings.Contains(buf.String(), `"heap_in_use_bytes"`) },
+		time.Second, time.Millisecond,
+	)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "diagnostics kept logging after the context was cancelled")
+	}
+	assert.Contains(t, buf.String(), `"channel_depths":{"stream.in":0}`)
+}
+
+func TestDiagnostics_DisabledDoesNothing(t *testing.T) {
+	d := NewDiagnostics()
+
+	// returns right away rather than logging until cancelled
+	d.Log(context.Background(), 0, observability.NewNoOpLogger())
+}
+
+func TestHandleRequestStatus_ReportsDiagnostics(t *testing.T) {
+	inChan := make(chan *service.Record, 1)
+	outChan := make(chan *service.Result, 1)
+	d := NewDiagnostics()
+	watchChannel(d, "stream.in", inChan)
+	h := NewHandler(context.Background(),
+		&HandlerParams{
+			Logger:      observability.NewNoOpLogger(),
+			Settings:    &service.Settings{},
+			FwdChan:     make(chan *service.Record, 1),
+			OutChan:     outChan,
+			Diagnostics: d,
+		},
+	)
+	go h.Do(inChan)
+	defer close(inChan)
+
+	inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Status{
+					Status: &service.StatusRequest{},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	}
+	result := <-outChan
+
+	diagnostics := result.GetResponse().GetStatusResponse().GetDiagnostics()
+	require.NotNil(t, diagnostics)
+	assert.Contains(t, diagnostics.GetChannelDepths(), "stream.in")
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// pushedState is a piece of a run's state that's pushed to the server as
+// a whole.
+type pushedState int
+
+const (
+	// configState is the run's config, pushed by upserting the run.
+	configState pushedState = iota
+
+	// summaryState is the run's summary, pushed through the filestream.
+	summaryState
+
+	// telemetryState is the run's telemetry, pushed in the run's config.
+	telemetryState
+
+	numPushedStates
+)
+
+// dirtyState tracks which pieces of a run's state changed since they were
+// last pushed to the server.
+//
+// Each piece is dirty until a push of it is acknowledged, including before
+// it was ever pushed, and becomes dirty again when it changes. A change
+// made while a push is in flight keeps the piece dirty, since the push may
+// not include it.
+//
+// W&B's own values that change on their own, like the runtime and the core
+// usage, don't count as changes: they're sent along with the next push, and
+// are in the config and summary files uploaded at exit regardless.
+//
+// It's safe to use concurrently, since pushes are acknowledged from other
+// goroutines.
+type dirtyState struct {
+	mu sync.Mutex
+
+	// changes counts the changes to each piece of state
+	changes [numPushedStates]uint64
+
+	// pushed is the count of changes that the last acknowledged push of
+	// each piece of state included
+	pushed [numPushedStates]uint64
+
+	// everPushed is whether each piece of state was ever pushed
+	everPushed [numPushedStates]bool
+}
+
+// Changed records that the pieces of state changed.
+func (d *dirtyState) Changed(states ...pushedState) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, state := range states {
+		d.changes[state]++
+	}
+}
+
+// Dirty returns whether any of the pieces of state changed since it was
+// last pushed, or was never pushed.
+func (d *dirtyState) Dirty(states ...pushedState) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, state := range states {
+		if !d.everPushed[state] || d.pushed[state] != d.changes[state] {
+			return true
+		}
+	}
+	return false
+}
+
+// Pushing is called as the pieces of state are pushed as they are now.
+//
+// Returns a function to call once the push is acknowledged.
+func (d *dirtyState) Pushing(states ...pushedState) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var changes [numPushedStates]uint64
+	for _, state := range states {
+		changes[state] = d.changes[state]
+	}
+
+	return func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		for _, state := range states {
+			if !d.everPushed[state] || changes[state] > d.pushed[state] {
+				d.pushed[state] = changes[state]
+			}
+			d.everPushed[state] = true
+		}
+	}
+}
+
+// onlyRuntime returns whether a summary update only sets the runtime,
+// which the handler adds to every summary update.
+func onlyRuntime(summary *service.SummaryRecord) bool {
+	for _, item := range summary.GetUpdate() {
+		if !slices.Equal(item.GetNestedKey(), []string{"_wandb", "runtime"}) {
+			return false
+		}
+	}
+	return len(summary.GetRemove()) == 0
+}
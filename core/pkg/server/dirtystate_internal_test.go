@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirtyState_DirtyUntilPushAcked(t *testing.T) {
+	dirty := &dirtyState{}
+	assert.True(t, dirty.Dirty(configState), "never pushed")
+
+	acked := dirty.Pushing(configState)
+	assert.True(t, dirty.Dirty(configState), "push not acknowledged")
+	acked()
+	assert.False(t, dirty.Dirty(configState))
+	assert.True(t, dirty.Dirty(configState, summaryState))
+}
+
+func TestDirtyState_ChangeDuringPushStaysDirty(t *testing.T) {
+	dirty := &dirtyState{}
+	dirty.Changed(summaryState)
+
+	acked := dirty.Pushing(summaryState)
+	dirty.Changed(summaryState)
+	acked()
+	assert.True(t, dirty.Dirty(summaryState))
+
+	// an older push acknowledged late doesn't undo a newer one
+	older := dirty.Pushing(summaryState)
+	newer := dirty.Pushing(summaryState)
+	newer()
+	older()
+	assert.False(t, dirty.Dirty(summaryState))
+}
@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// startDirtyStateStream starts a run whose filestream transmits quickly.
+func startDirtyStateStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+) (*server.Stream, chanResponder) {
+	t.Helper()
+	dir := t.TempDir()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:                              wrapperspb.String("dirty"),
+		BaseUrl:                            wrapperspb.String(backend.URL()),
+		ApiKey:                             wrapperspb.String("test-api-key"),
+		LogDir:                             wrapperspb.String(dir),
+		SyncFile:                           wrapperspb.String(filepath.Join(dir, "run-dirty.wandb")),
+		FilesDir:                           wrapperspb.String(filepath.Join(dir, "files")),
+		XDisableStats:                      wrapperspb.Bool(true),
+		XDisableMeta:                       wrapperspb.Bool(true),
+		XFileStreamTransmitIntervalSeconds: wrapperspb.Double(0.05),
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "dirty", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	return stream, responses
+}
+
+func configRecord(key, valueJSON string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Config{
+			Config: &service.ConfigRecord{
+				Update: []*service.ConfigItem{{Key: key, ValueJson: valueJSON}},
+			},
+		},
+	}
+}
+
+// streamedUntil waits until a filestream request contains the text, and
+// returns the number of filestream requests by then.
+func streamedUntil(t *testing.T, backend *servertest.FakeBackend, text string) int {
+	t.Helper()
+	var count int
+	require.Eventually(t, func() bool {
+		requests := backend.Requests(servertest.RouteFileStream)
+		count = len(requests)
+		for _, request := range requests {
+			if strings.Contains(string(request.Body), text) {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 10*time.Millisecond)
+	return count
+}
+
+// exitDirtyStateStream exits the run and waits for it to finish.
+func exitDirtyStateStream(
+	t *testing.T,
+	stream *server.Stream,
+	responses chanResponder,
+) {
+	t.Helper()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	awaitResult(t, responses, "exit")
+	stream.Close()
+}
+
+// A run that logs nothing after its config and summary were pushed and
+// acknowledged doesn't push them again as it exits.
+func TestDirtyState_NoRedundantExitPushes(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream, responses := startDirtyStateStream(t, backend)
+
+	stream.HandleRecord(configRecord("lr", "0.1"))
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Summary{
+			Summary: &service.SummaryRecord{
+				Update: []*service.SummaryItem{{Key: "accuracy", ValueJson: "0.9"}},
+			},
+		},
+	})
+	streamedUntil(t, backend, "wandb-summary.json")
+	// the filestream sends one request at a time, so the summary's was
+	// acknowledged once a later one is sent
+	stream.HandleRecord(makeOutputRawRecord("done\n"))
+	beforeExit := streamedUntil(t, backend, "done")
+	upserts := len(backend.GraphQLRequests("UpsertBucket"))
+	exitDirtyStateStream(t, stream, responses)
+
+	assert.Len(t, backend.GraphQLRequests("UpsertBucket"), upserts)
+	for _, request := range backend.Requests(servertest.RouteFileStream)[beforeExit:] {
+		assert.NotContains(t, string(request.Body), "wandb-summary.json")
+	}
+}
+
+// A config change that wasn't pushed yet is pushed as the run exits.
+func TestDirtyState_PushesUnpushedChangesAtExit(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	stream, responses := startDirtyStateStream(t, backend)
+
+	stream.HandleRecord(configRecord("lr", "0.1"))
+	// debounced until the exit
+	stream.HandleRecord(configRecord("lr", "0.01"))
+	stream.HandleRecord(makeOutputRawRecord("done\n"))
+	streamedUntil(t, backend, "done")
+	upserts := len(backend.GraphQLRequests("UpsertBucket"))
+	exitDirtyStateStream(t, stream, responses)
+
+	exitUpserts := backend.GraphQLRequests("UpsertBucket")[upserts:]
+	require.Len(t, exitUpserts, 1)
+	assert.Contains(t, string(exitUpserts[0].Body), `0.01`)
+}
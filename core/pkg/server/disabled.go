@@ -0,0 +1,326 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runname"
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// newDisabledStream returns the stream of a disabled run, which does no
+// disk or network I/O.
+//
+// Its handler works as it does for any run, so that steps, the summary
+// and the run itself are what the client expects, but the records it
+// forwards go to a disabledSender instead of the writer and the sender.
+// Nothing is logged, and no directories are created.
+func newDisabledStream(s *settings.Settings) *Stream {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &Stream{
+		ctx:            ctx,
+		cancel:         cancel,
+		logger:         observability.NewNoOpLogger(),
+		settings:       s,
+		inChan:         make(chan *service.Record, BufferSize),
+		loopBackChan:   make(chan *service.Record, BufferSize),
+		controlChan:    make(chan *service.Record, BufferSize),
+		controlOutChan: make(chan *service.Result, BufferSize),
+		startup:        newStartupQueue(),
+		mailbox:        mailbox.NewMailbox(),
+		writerDone:     make(chan struct{}),
+		printer:        observability.NewPrinter(),
+		diagnostics:    NewDiagnostics(),
+		closed:         &atomic.Bool{},
+	}
+	stream.handlerCtx, stream.handlerCancel = context.WithCancel(ctx)
+	stream.writerCtx, stream.writerCancel = context.WithCancel(ctx)
+	stream.senderCtx, stream.senderCancel = context.WithCancel(ctx)
+
+	// without directories, the handler keeps its tables in memory and
+	// never writes them out
+	handlerSettings := proto.Clone(s.Proto).(*service.Settings)
+	handlerSettings.FilesDir = nil
+	handlerSettings.LogDir = nil
+	handlerSettings.SyncDir = nil
+	handlerSettings.SyncFile = nil
+	handlerSettings.WandbDir = nil
+
+	unknownTypes := NewUnknownTypes()
+	stream.diagnostics.unknownTypes = unknownTypes
+	stream.handler = NewHandler(stream.handlerCtx,
+		&HandlerParams{
+			Logger:            stream.logger,
+			Settings:          handlerSettings,
+			FwdChan:           make(chan *service.Record, BufferSize),
+			OutChan:           make(chan *service.Result, BufferSize),
+			FileTransferStats: filetransfer.NewFileTransferStats(),
+			RunSummary:        runsummary.New(),
+			MetricHandler:     NewMetricHandler(),
+			Mailbox:           stream.mailbox,
+			TerminalPrinter:   stream.printer,
+			DeferProgress:     NewDeferProgress(),
+			Diagnostics:       stream.diagnostics,
+			UnknownTypes:      unknownTypes,
+		},
+	)
+
+	stream.disabled = &disabledSender{
+		settings:     s.Proto,
+		cancel:       cancel,
+		loopBackChan: stream.loopBackChan,
+		outChan:      make(chan *service.Result, BufferSize),
+	}
+
+	watchChannel(stream.diagnostics, "stream.in", stream.inChan)
+	watchChannel(stream.diagnostics, "handler.fwd", stream.handler.fwdChan)
+	watchChannel(stream.diagnostics, "handler.out", stream.handler.outChan)
+
+	stream.dispatcher = NewDispatcher(stream.logger, stream.mailbox, nil)
+	stream.printer.Forward(stream.dispatcher.forwardUserMessage)
+	return stream
+}
+
+// startDisabled starts a disabled stream's handler, its disabledSender
+// and its dispatcher.
+func (s *Stream) startDisabled() {
+	fwdChan := make(chan *service.Record, BufferSize)
+	s.wg.Add(1)
+	go func() {
+		wg := sync.WaitGroup{}
+		for _, ch := range []chan *service.Record{s.inChan, s.loopBackChan} {
+			wg.Add(1)
+			go func(ch chan *service.Record) {
+				for record := range ch {
+					fwdChan <- record
+				}
+				wg.Done()
+			}(ch)
+		}
+		wg.Wait()
+		close(fwdChan)
+		s.wg.Done()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		s.handler.Do(fwdChan)
+		s.wg.Done()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		s.disabled.Do(s.handler.fwdChan)
+		close(s.writerDone)
+		s.wg.Done()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		for record := range s.controlChan {
+			if record.GetRequest().GetStreamPing() != nil {
+				s.controlOutChan <- s.streamPingResult(record)
+				continue
+			}
+			s.controlOutChan <- disabledResult(record, &service.Response{
+				ResponseType: &service.Response_ServerInfoResponse{
+					ServerInfoResponse: &service.ServerInfoResponse{},
+				},
+			})
+		}
+		close(s.controlOutChan)
+		s.wg.Done()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		wg := sync.WaitGroup{}
+		for _, ch := range []chan *service.Result{
+			s.handler.outChan,
+			s.disabled.outChan,
+			s.controlOutChan,
+		} {
+			wg.Add(1)
+			go func(ch chan *service.Result) {
+				for result := range ch {
+					s.dispatcher.handleRespond(result)
+				}
+				wg.Done()
+			}(ch)
+		}
+		wg.Wait()
+		s.dispatcher.Close()
+		s.mailbox.Close()
+		s.wg.Done()
+	}()
+}
+
+// disabledSender takes the place of the writer and the sender in a
+// disabled run's stream.
+//
+// It drops every record, answering those that expect a response as if
+// they had succeeded.
+type disabledSender struct {
+	settings *service.Settings
+
+	// cancel cancels the stream once the run exits
+	cancel context.CancelFunc
+
+	// loopBackChan sends records back to the handler
+	loopBackChan chan *service.Record
+
+	// outChan is the channel for the responses
+	outChan chan *service.Result
+
+	// run is the run, once its record arrives
+	run *service.RunRecord
+}
+
+// Do answers the records until the channel is closed.
+func (s *disabledSender) Do(inChan <-chan *service.Record) {
+	for record := range inChan {
+		s.send(record)
+	}
+	close(s.outChan)
+}
+
+func (s *disabledSender) send(record *service.Record) {
+	switch x := record.RecordType.(type) {
+	case *service.Record_Run:
+		s.sendRun(record, x.Run)
+	case *service.Record_Exit:
+		s.respondIfExpected(record, &service.Result{
+			ResultType: &service.Result_ExitResult{
+				ExitResult: &service.RunExitResult{},
+			},
+		})
+		s.cancel()
+	case *service.Record_Request:
+		s.sendRequest(record, x.Request)
+	default:
+		s.respondIfExpected(record, &service.Result{
+			ResultType: &service.Result_Response{Response: &service.Response{}},
+		})
+	}
+}
+
+// sendRun fills in the run's defaults and passes it back to the handler
+// as if the server had created it.
+func (s *disabledSender) sendRun(record *service.Record, run *service.RunRecord) {
+	if s.run == nil {
+		s.run = proto.Clone(run).(*service.RunRecord)
+		if s.run.GetEntity() == "" {
+			s.run.Entity = s.settings.GetEntity().GetValue()
+		}
+		if s.run.GetProject() == "" {
+			s.run.Project = s.settings.GetProject().GetValue()
+		}
+		if s.run.GetProject() == "" {
+			s.run.Project = defaultProject
+		}
+		if s.run.GetDisplayName() == "" {
+			s.run.DisplayName = runname.Generate(s.run.GetRunId())
+		}
+	}
+
+	s.loopBackChan <- &service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_RunUpdated{
+				RunUpdated: &service.RunUpdatedRequest{
+					Run: proto.Clone(s.run).(*service.RunRecord),
+				},
+			},
+		}},
+		Control: &service.Control{AlwaysSend: true, Local: true},
+	}
+
+	s.respondIfExpected(record, &service.Result{
+		ResultType: &service.Result_RunResult{
+			RunResult: &service.RunUpdateResult{Run: s.run},
+		},
+	})
+}
+
+// sendRequest answers the requests the sender would answer.
+//
+// The rest were answered by the handler, if they expect an answer. The
+// stream finishes on the exit record, without deferring.
+func (s *disabledSender) sendRequest(record *service.Record, request *service.Request) {
+	response := &service.Response{}
+	switch request.RequestType.(type) {
+	case *service.Request_NetworkStatus:
+		response.ResponseType = &service.Response_NetworkStatusResponse{
+			NetworkStatusResponse: &service.NetworkStatusResponse{},
+		}
+	case *service.Request_LogArtifact:
+		response.ResponseType = &service.Response_LogArtifactResponse{
+			LogArtifactResponse: &service.LogArtifactResponse{},
+		}
+	case *service.Request_ArtifactWait:
+		response.ResponseType = &service.Response_ArtifactWaitResponse{
+			ArtifactWaitResponse: &service.ArtifactWaitResponse{},
+		}
+	case *service.Request_ArtifactCancel:
+		response.ResponseType = &service.Response_ArtifactCancelResponse{
+			ArtifactCancelResponse: &service.ArtifactCancelResponse{},
+		}
+	case *service.Request_DownloadArtifact:
+		response.ResponseType = &service.Response_DownloadArtifactResponse{
+			DownloadArtifactResponse: &service.DownloadArtifactResponse{},
+		}
+	case *service.Request_Sync:
+		response.ResponseType = &service.Response_SyncResponse{
+			SyncResponse: &service.SyncResponse{},
+		}
+	case *service.Request_StopStatus:
+		response.ResponseType = &service.Response_StopStatusResponse{
+			StopStatusResponse: &service.StopStatusResponse{},
+		}
+	case *service.Request_ServerInfo:
+		response.ResponseType = &service.Response_ServerInfoResponse{
+			ServerInfoResponse: &service.ServerInfoResponse{},
+		}
+	case *service.Request_StreamPing:
+		response.ResponseType = &service.Response_StreamPingResponse{
+			StreamPingResponse: &service.StreamPingResponse{},
+		}
+	default:
+		return
+	}
+	s.respond(record, &service.Result{
+		ResultType: &service.Result_Response{Response: response},
+	})
+}
+
+// respond sends the result answering the record.
+func (s *disabledSender) respond(record *service.Record, result *service.Result) {
+	result.Control = record.Control
+	result.Uuid = record.Uuid
+	s.outChan <- result
+}
+
+// respondIfExpected sends the result answering the record if the client
+// waits for one, as the sender does for records other than requests.
+func (s *disabledSender) respondIfExpected(record *service.Record, result *service.Result) {
+	if !record.GetControl().GetReqResp() && record.GetControl().GetMailboxSlot() == "" {
+		return
+	}
+	s.respond(record, result)
+}
+
+// disabledResult is the result answering the record with the response.
+func disabledResult(record *service.Record, response *service.Response) *service.Result {
+	return &service.Result{
+		ResultType: &service.Result_Response{Response: response},
+		Control:    record.Control,
+		Uuid:       record.Uuid,
+	}
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// awaitResult returns the result answering the record with the UUID.
+func awaitResult(
+	t *testing.T,
+	responses chanResponder,
+	uuid string,
+) *service.Result {
+	t.Helper()
+	for {
+		select {
+		case result := <-responses:
+			if result.GetUuid() == uuid {
+				return result
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s wasn't answered", uuid)
+			return nil
+		}
+	}
+}
+
+func disabledRequest(uuid string, request *service.Request) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{Request: request},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       uuid,
+	}
+}
+
+// A disabled run answers the client as if it were online, but creates no
+// files at all.
+func TestStream_DisabledDoesNoIO(t *testing.T) {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		Mode:        &wrapperspb.StringValue{Value: "disabled"},
+		RunId:       &wrapperspb.StringValue{Value: "disabled"},
+		BaseUrl:     &wrapperspb.StringValue{Value: "http://127.0.0.1:1"},
+		WandbDir:    &wrapperspb.StringValue{Value: dir},
+		SyncDir:     &wrapperspb.StringValue{Value: filepath.Join(dir, "run")},
+		LogDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "logs")},
+		LogInternal: &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "logs", "debug-internal.log")},
+		SyncFile:    &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "run-disabled.wandb")},
+		FilesDir:    &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "files")},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 64)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "disabled"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	run := awaitResult(t, responses, "run").GetRunResult()
+	stream.HandleRecord(makeRunStartRecord())
+	for range 3 {
+		stream.HandleRecord(makePartialHistoryRecord(data{
+			items:   map[string]string{"loss": "0.5"},
+			flush:   true,
+			stepNil: true,
+		}))
+	}
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{Path: "model.pt"}},
+			},
+		},
+	})
+	stream.HandleRecord(disabledRequest("summary", &service.Request{
+		RequestType: &service.Request_GetSummary{
+			GetSummary: &service.GetSummaryRequest{},
+		},
+	}))
+	summary := awaitResult(t, responses, "summary").
+		GetResponse().GetGetSummaryResponse()
+	stream.HandleRecord(disabledRequest("stop", &service.Request{
+		RequestType: &service.Request_StopStatus{
+			StopStatus: &service.StopStatusRequest{},
+		},
+	}))
+	stop := awaitResult(t, responses, "stop").GetResponse()
+	stream.HandleRecord(disabledRequest("attach", &service.Request{
+		RequestType: &service.Request_Attach{
+			Attach: &service.AttachRequest{},
+		},
+	}))
+	attached := awaitResult(t, responses, "attach").
+		GetResponse().GetAttachResponse()
+	stream.FinishAndClose(0)
+
+	assert.Nil(t, run.GetError())
+	assert.Equal(t, "uncategorized", run.GetRun().GetProject())
+	assert.NotEmpty(t, run.GetRun().GetDisplayName())
+	steps := map[string]string{}
+	for _, item := range summary.GetItem() {
+		steps[item.GetKey()] = item.GetValueJson()
+	}
+	assert.Equal(t, "2", steps["_step"])
+	assert.NotNil(t, stop.GetStopStatusResponse())
+	assert.Equal(t, run.GetRun().GetDisplayName(), attached.GetRun().GetDisplayName())
+	assert.False(t, attached.GetProvisional())
+	var created []string
+	require.NoError(t, filepath.WalkDir(dir,
+		func(path string, _ fs.DirEntry, err error) error {
+			if path != dir {
+				created = append(created, path)
+			}
+			return err
+		}))
+	assert.Empty(t, created)
+}
@@ -0,0 +1,93 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/dryrun"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// A dry run goes through the whole pipeline, recording the requests it
+// would send in its directory without sending any.
+func TestStream_DryRunRecordsRequestsWithoutSending(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(filesDir, "data.txt"), []byte("data"), 0o644))
+
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "dry"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncDir:       &wrapperspb.StringValue{Value: dir},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-dry.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+		XDryRun:       &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "dry", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{Path: "data.txt"}},
+			},
+		},
+	})
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	stream.FinishAndClose(0)
+
+	assert.Empty(t, backend.Requests(servertest.RouteGraphQL))
+	assert.Empty(t, backend.Requests(servertest.RouteFileStream))
+	assert.Empty(t, backend.Requests(servertest.RouteUpload))
+
+	graphql, err := dryrun.ReadGraphQL(dir)
+	require.NoError(t, err)
+	var operations []string
+	for _, request := range graphql {
+		operations = append(operations, request.OperationName)
+	}
+	assert.Contains(t, operations, "UpsertBucket")
+	assert.Contains(t, operations, "CreateRunFiles")
+
+	filestream, err := dryrun.ReadFileStream(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, filestream)
+	assert.Equal(t,
+		"/files/dry-run/testProject/dry/file_stream",
+		filestream[0].Path)
+	assert.Contains(t, string(filestream[len(filestream)-1].Body), `"complete":true`)
+
+	uploads, err := dryrun.ReadUploads(dir)
+	require.NoError(t, err)
+	var urls []string
+	for _, upload := range uploads {
+		urls = append(urls, upload.URL)
+	}
+	assert.Contains(t, urls, dryrun.UploadURL("data.txt"))
+}
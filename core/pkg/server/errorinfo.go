@@ -0,0 +1,21 @@
+package server
+
+import (
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// backendErrorInfo describes a failed backend operation to the client.
+//
+// Its code says how the failure was classified, so that the client can
+// tell a rejected API key or a missing resource from a network problem.
+func backendErrorInfo(err error) *service.ErrorInfo {
+	code := service.ErrorInfo_COMMUNICATION
+	switch api.ClassifyGraphQLError(err) {
+	case api.FatalAuth:
+		code = service.ErrorInfo_AUTHENTICATION
+	case api.FatalNotFound, api.FatalQuota:
+		code = service.ErrorInfo_USAGE
+	}
+	return &service.ErrorInfo{Message: err.Error(), Code: code}
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// ExitGrace is how long the handler accepts a run's data after its exit.
+//
+// Frameworks sometimes log final metrics from atexit hooks after the run
+// started to exit. Such data is accepted, and makes it into the run's
+// final history and summary, until the grace window ends or the final
+// summary is flushed, whichever is sooner. Data logged after that is
+// rejected, with an error result if the client waits for one, and the user
+// is warned once.
+//
+// Only data that clients log is subject to the window. Data that the
+// stream produces itself, such as the run's metadata, is finished by the
+// exit's flushes.
+//
+// The window is tracked by the handler's goroutine, and the counts may be
+// read from any.
+//
+// The methods of a nil ExitGrace accept all data.
+type ExitGrace struct {
+	window time.Duration
+	clock  waiting.Clock
+
+	accepted atomic.Int64
+	rejected atomic.Int64
+
+	// deadline is when the window ends, or zero until the run exits
+	deadline time.Time
+
+	// historyFlushed is whether the run's partial history was flushed for
+	// its exit
+	historyFlushed bool
+
+	// summaryFlushed is whether the run's final summary was flushed, which
+	// ends the window
+	summaryFlushed bool
+
+	// warned is whether the user was warned about rejected data
+	warned bool
+}
+
+func NewExitGrace(window time.Duration, clock waiting.Clock) *ExitGrace {
+	if clock == nil {
+		clock = waiting.NewClock()
+	}
+	return &ExitGrace{window: max(window, 0), clock: clock}
+}
+
+// Proto returns the window and what was logged in and after it.
+func (g *ExitGrace) Proto() *service.LateRecords {
+	if g == nil {
+		return nil
+	}
+
+	return &service.LateRecords{
+		GraceSeconds: g.window.Seconds(),
+		Accepted:     g.accepted.Load(),
+		Rejected:     g.rejected.Load(),
+	}
+}
+
+// exited starts the window.
+func (g *ExitGrace) exited() {
+	if g == nil || !g.deadline.IsZero() {
+		return
+	}
+	g.deadline = g.clock.Now().Add(g.window)
+}
+
+// flushedHistory notes that the run's partial history was flushed for its
+// exit.
+func (g *ExitGrace) flushedHistory() {
+	if g != nil {
+		g.historyFlushed = true
+	}
+}
+
+// flushedSummary notes that the run's final summary was flushed, which
+// ends the window.
+func (g *ExitGrace) flushedSummary() {
+	if g != nil {
+		g.summaryFlushed = true
+	}
+}
+
+// admit returns whether data logged after the exit is accepted, and
+// counts it.
+func (g *ExitGrace) admit() bool {
+	if g == nil {
+		return true
+	}
+
+	if g.summaryFlushed || !g.clock.Now().Before(g.deadline) {
+		g.rejected.Add(1)
+		return false
+	}
+	g.accepted.Add(1)
+	return true
+}
+
+// isLateData is whether the record is data that a client logged, which
+// is subject to the window if it comes after the exit.
+func isLateData(record *service.Record) bool {
+	if record.GetControl().GetConnectionId() == "" {
+		return false
+	}
+
+	switch record.RecordType.(type) {
+	case *service.Record_History,
+		*service.Record_Summary,
+		*service.Record_Config,
+		*service.Record_Metric,
+		*service.Record_Output,
+		*service.Record_OutputRaw,
+		*service.Record_TableRows:
+		return true
+	default:
+		return record.GetRequest().GetPartialHistory() != nil
+	}
+}
+
+// rejectLate drops data logged too long after the run's exit, answering
+// it if the client waits for an answer.
+func (h *Handler) rejectLate(record *service.Record) {
+	h.logger.Warn(
+		"handler: rejecting data logged after the exit grace window",
+		observability.RecordTypeKey, recordTypeName(record),
+	)
+
+	if !h.exitGrace.warned {
+		h.exitGrace.warned = true
+		h.terminalPrinter.Write(
+			"Some data was logged after the run finished exiting, and was" +
+				" dropped. Log it before calling run.finish(), or raise the" +
+				" _exit_grace_seconds setting.")
+	}
+
+	if !record.GetControl().GetReqResp() && record.GetControl().GetMailboxSlot() == "" {
+		return
+	}
+	h.outChan <- &service.Result{
+		Rejected: &service.ErrorInfo{
+			Code: service.ErrorInfo_USAGE,
+			Message: fmt.Sprintf(
+				"the run already finished exiting, so data logged more"+
+					" than %v after its exit, or once its summary was"+
+					" flushed, is dropped",
+				h.exitGrace.window,
+			),
+		},
+		Control: record.Control,
+		Uuid:    record.Uuid,
+	}
+}
+
+// flushLateHistory makes a partial history row logged after the run's
+// history was flushed for its exit be flushed right away, as nothing
+// else would.
+func (h *Handler) flushLateHistory(request *service.PartialHistoryRequest) {
+	if h.exitGrace == nil || !h.exitGrace.historyFlushed {
+		return
+	}
+	if request.Action == nil {
+		request.Action = &service.HistoryAction{}
+	}
+	request.Action.Flush = true
+}
@@ -0,0 +1,254 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// graceHandler is a running handler whose data is accepted for a grace
+// window after its exit.
+type graceHandler struct {
+	inChan  chan *service.Record
+	fwdChan chan *service.Record
+	outChan chan *service.Result
+	printer *observability.Printer
+	clock   *settableClock
+	grace   *server.ExitGrace
+}
+
+func startGraceHandler(window time.Duration) *graceHandler {
+	h := &graceHandler{
+		inChan:  make(chan *service.Record, 16),
+		fwdChan: make(chan *service.Record, 64),
+		outChan: make(chan *service.Result, 16),
+		printer: observability.NewPrinter(),
+		clock:   &settableClock{now: time.Unix(1000, 0)},
+	}
+	h.grace = server.NewExitGrace(window, h.clock)
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{},
+		FwdChan:         h.fwdChan,
+		OutChan:         h.outChan,
+		TerminalPrinter: h.printer,
+		RunSummary:      runsummary.New(),
+		MetricHandler:   server.NewMetricHandler(),
+		ExitGrace:       h.grace,
+	})
+	go handler.Do(h.inChan)
+	return h
+}
+
+// awaitForwarded returns the first record forwarded from now on that
+// matches.
+func (h *graceHandler) awaitForwarded(
+	t *testing.T,
+	matches func(*service.Record) bool,
+) *service.Record {
+	t.Helper()
+	for {
+		select {
+		case record := <-h.fwdChan:
+			if matches(record) {
+				return record
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no matching record was forwarded")
+			return nil
+		}
+	}
+}
+
+// exit sends the exit and waits for it to be handled.
+func (h *graceHandler) exit(t *testing.T) {
+	t.Helper()
+	h.inChan <- &service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+	}
+	h.awaitForwarded(t, func(record *service.Record) bool {
+		return record.GetExit() != nil
+	})
+}
+
+// flush sends the defer request for the state and waits for it to be
+// handled.
+func (h *graceHandler) flush(t *testing.T, state service.DeferRequest_DeferState) {
+	t.Helper()
+	h.inChan <- &service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_Defer{
+				Defer: &service.DeferRequest{State: state},
+			},
+		}},
+	}
+	h.awaitForwarded(t, func(record *service.Record) bool {
+		return record.GetRequest().GetDefer().GetState() == state
+	})
+}
+
+// historyKeys returns the keys of the forwarded history rows, until the
+// handler stops.
+func (h *graceHandler) historyKeys() []string {
+	close(h.inChan)
+	var keys []string
+	for record := range h.fwdChan {
+		for _, item := range record.GetHistory().GetItem() {
+			if item.GetKey() != "_runtime" && item.GetKey() != "_step" {
+				keys = append(keys, item.GetKey())
+			}
+		}
+	}
+	return keys
+}
+
+// clientHistory is a history row logged by a client that waits for an
+// answer.
+func clientHistory(uuid, key string) *service.Record {
+	record := makeHistoryRecord(data{items: map[string]string{key: "1"}})
+	record.Control = &service.Control{ConnectionId: "client", ReqResp: true}
+	record.Uuid = uuid
+	return record
+}
+
+// Data logged just before the window ends is accepted, and data logged
+// as it ends is rejected with an error result.
+func TestExitGrace_WindowBoundary(t *testing.T) {
+	h := startGraceHandler(10 * time.Second)
+	h.exit(t)
+
+	h.clock.advance(10*time.Second - time.Millisecond)
+	h.inChan <- clientHistory("early", "early")
+	h.awaitForwarded(t, func(record *service.Record) bool {
+		return record.GetHistory() != nil
+	})
+	h.clock.advance(time.Millisecond)
+	h.inChan <- clientHistory("late", "late")
+	result := <-h.outChan
+
+	assert.Equal(t, "late", result.GetUuid())
+	assert.Equal(t, service.ErrorInfo_USAGE, result.GetRejected().GetCode())
+	assert.Contains(t, result.GetRejected().GetMessage(), "finished exiting")
+	assert.Empty(t, h.historyKeys())
+	stats := h.grace.Proto()
+	assert.EqualValues(t, 10, stats.GetGraceSeconds())
+	assert.EqualValues(t, 1, stats.GetAccepted())
+	assert.EqualValues(t, 1, stats.GetRejected())
+	messages := h.printer.Read()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "_exit_grace_seconds")
+}
+
+// The window ends early once the final summary is flushed, since later
+// data wouldn't make it into the run.
+func TestExitGrace_EndsWhenSummaryFlushed(t *testing.T) {
+	h := startGraceHandler(time.Hour)
+	h.exit(t)
+
+	h.flush(t, service.DeferRequest_FLUSH_SUM)
+	h.inChan <- clientHistory("late", "late")
+	result := <-h.outChan
+
+	assert.NotNil(t, result.GetRejected())
+	assert.Empty(t, h.historyKeys())
+	assert.EqualValues(t, 1, h.grace.Proto().GetRejected())
+}
+
+// Partial history logged after the run's history was flushed for its exit
+// is flushed right away instead of being left in an unfinished row.
+func TestExitGrace_FlushesLatePartialHistory(t *testing.T) {
+	h := startGraceHandler(time.Hour)
+	h.exit(t)
+	h.flush(t, service.DeferRequest_FLUSH_PARTIAL_HISTORY)
+
+	record := makePartialHistoryRecord(data{
+		items:   map[string]string{"final": "1"},
+		stepNil: true,
+	})
+	record.Control.ConnectionId = "client"
+	h.inChan <- record
+
+	assert.Equal(t, []string{"final"}, h.historyKeys())
+	assert.EqualValues(t, 1, h.grace.Proto().GetAccepted())
+}
+
+// Data that the stream produces itself isn't subject to the window.
+func TestExitGrace_IgnoresInternalRecords(t *testing.T) {
+	h := startGraceHandler(0)
+	h.exit(t)
+
+	h.inChan <- makeHistoryRecord(data{items: map[string]string{"internal": "1"}})
+
+	assert.Equal(t, []string{"internal"}, h.historyKeys())
+	assert.EqualValues(t, 0, h.grace.Proto().GetRejected())
+}
+
+// Records handled while the stream closes are dropped rather than sent on
+// a closed channel, and the exit result reports the window.
+func TestExitGrace_RecordsRacingStreamClose(t *testing.T) {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:             &wrapperspb.StringValue{Value: "grace"},
+		LogDir:            &wrapperspb.StringValue{Value: dir},
+		LogInternal:       &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:          &wrapperspb.StringValue{Value: filepath.Join(dir, "run-grace.wandb")},
+		FilesDir:          &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XOffline:          &wrapperspb.BoolValue{Value: true},
+		XDisableStats:     &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:      &wrapperspb.BoolValue{Value: true},
+		XExitGraceSeconds: &wrapperspb.DoubleValue{Value: 3},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 1024)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "grace", Project: "testProject"},
+		},
+	})
+
+	// keep logging until the stream is closed
+	closed := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-closed:
+				return
+			default:
+			}
+			record := makeHistoryRecord(data{
+				items: map[string]string{"late": "1"},
+				step:  int64(i),
+			})
+			record.Control = &service.Control{ConnectionId: "client"}
+			stream.HandleRecord(record)
+		}
+	}()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	result := awaitResult(t, responses, "exit")
+	stream.Close()
+	close(closed)
+	wg.Wait()
+
+	assert.EqualValues(t, 3, result.GetExitResult().GetLateRecords().GetGraceSeconds())
+}
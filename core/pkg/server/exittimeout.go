@@ -0,0 +1,95 @@
+package server
+
+import (
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// awaitExitStep runs a step of the run's exit that waits for uploads, and
+// returns whether it finished before the exit timed out.
+//
+// Steps run in order, so if the exit times out, the step and any later
+// ones keep running in the background, and what's left undone is recorded
+// for syncing the run later.
+func (s *Sender) awaitExitStep(step func()) bool {
+	if s.exitDeadline.IsZero() {
+		step()
+		return true
+	}
+
+	done := make(chan struct{})
+	s.exitSteps.Go(func() {
+		step()
+		close(done)
+	})
+
+	timeout := time.NewTimer(time.Until(s.exitDeadline))
+	defer timeout.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timeout.C:
+		s.recordUnfinishedWork()
+		return false
+	}
+}
+
+// recordUnfinishedWork records what was left undone when the run's exit
+// timed out, the first time it's called.
+//
+// Files whose uploads hadn't finished are saved to the transaction log
+// again, after the run's exit, so that they're uploaded if the run is
+// synced. Syncing resends all of the run's history, so unsent filestream
+// data needs no record.
+func (s *Sender) recordUnfinishedWork() {
+	if s.unfinished != nil {
+		return
+	}
+
+	unfinished := s.unfinishedWork()
+	s.unfinished = unfinished
+
+	s.logger.Warn(
+		"sender: exit timed out, leaving the rest to sync",
+		"timeout", s.exitTimeout,
+		"files", unfinished.Files,
+		"pendingUploads", unfinished.PendingUploads,
+		"queuedFilestreamUpdates", unfinished.QueuedFilestreamUpdates,
+		"unackedFilestreamLines", unfinished.UnackedFilestreamLines,
+	)
+
+	if len(unfinished.Files) == 0 {
+		return
+	}
+
+	files := make([]*service.FilesItem, len(unfinished.Files))
+	for i, path := range unfinished.Files {
+		files[i] = &service.FilesItem{Path: path, Policy: service.FilesItem_NOW}
+	}
+	s.fwdRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{Files: files},
+		},
+	})
+}
+
+// unfinishedWork returns what the run has yet to upload.
+func (s *Sender) unfinishedWork() *service.UnfinishedWork {
+	unfinished := &service.UnfinishedWork{
+		PendingUploads: int32(s.uploads.Pending()),
+	}
+	if s.runfilesUploader != nil {
+		unfinished.Files = s.runfilesUploader.Unfinished()
+	}
+	if s.fileTransferManager != nil {
+		unfinished.PendingUploads += int32(s.fileTransferManager.PendingTasks())
+	}
+	if s.fileStream != nil {
+		backlog := s.fileStream.Backlog()
+		unfinished.QueuedFilestreamUpdates = int32(backlog.QueuedUpdates)
+		unfinished.UnackedFilestreamLines = int32(backlog.UnackedLines)
+	}
+	return unfinished
+}
@@ -0,0 +1,159 @@
+package server_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// A run whose uploads stall finishes once its exit times out, and leaves
+// enough in its transaction log for syncing to finish the uploads.
+func TestExitTimeout_LeavesUnfinishedUploadsToSync(t *testing.T) {
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(filesDir, "data.txt"), []byte("data"), 0o644))
+	syncFile := filepath.Join(dir, "run-exit.wandb")
+	debugLog := filepath.Join(dir, "debug-internal.log")
+
+	stalled := servertest.NewFakeBackend()
+	defer stalled.Close()
+	stalled.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	stalled.StubCreateRunFiles()
+	run, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "exit"},
+		BaseUrl:       &wrapperspb.StringValue{Value: stalled.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: debugLog},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XFaultInjection:     &wrapperspb.StringValue{Value: "upload-delay=1h"},
+		XExitTimeoutSeconds: &wrapperspb.DoubleValue{Value: 0.5},
+	}), "")
+	require.NoError(t, err)
+	run.Start()
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "exit", Project: "testProject"},
+		},
+	})
+	run.HandleRecord(makeRunStartRecord())
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{Path: "data.txt"}},
+			},
+		},
+	})
+	run.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+
+	finished := make(chan struct{})
+	go func() {
+		run.FinishAndClose(0)
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(30 * time.Second):
+		t.Fatal("run did not finish after its exit timed out")
+	}
+
+	assert.Empty(t, stalled.Requests(servertest.RouteUpload))
+	content, err := os.ReadFile(debugLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "sender: exit timed out")
+	assert.Contains(t, string(content), `"files":["config.yaml","data.txt","wandb-summary.json"]`)
+
+	// The unfinished file is saved again after the exit.
+	reader, err := transactionlog.Open(syncFile)
+	require.NoError(t, err)
+	var exited, savedAfterExit bool
+	for {
+		record, _, err := reader.Next()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+		if record.GetExit() != nil {
+			exited = true
+		}
+		for _, file := range record.GetFiles().GetFiles() {
+			if exited && file.Path == "data.txt" {
+				savedAfterExit = true
+			}
+		}
+	}
+	require.NoError(t, reader.Close())
+	assert.True(t, savedAfterExit, "unfinished file not saved after the exit")
+	// The run's directory is kept until it's synced.
+	state, err := transactionlog.ReadSyncState(syncFile)
+	require.NoError(t, err)
+	assert.False(t, state != nil && state.Complete)
+
+	healthy := servertest.NewFakeBackend()
+	defer healthy.Close()
+	healthy.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	healthy.StubCreateRunFiles()
+	syncDir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "exit"},
+		BaseUrl:       &wrapperspb.StringValue{Value: healthy.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: syncDir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(syncDir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	var uploaded []string
+	for _, request := range healthy.Requests(servertest.RouteUpload) {
+		uploaded = append(uploaded, request.Path)
+	}
+	assert.Contains(t, uploaded, "/upload/data.txt")
+	var fileStreamBodies []string
+	for _, request := range healthy.Requests(servertest.RouteFileStream) {
+		fileStreamBodies = append(fileStreamBodies, string(request.Body))
+	}
+	require.NotEmpty(t, fileStreamBodies)
+	assert.Contains(t, strings.Join(fileStreamBodies, "\n"), `\"loss\":0.5`)
+	assert.Contains(t, fileStreamBodies[len(fileStreamBodies)-1], `"complete":true`)
+	state, err = transactionlog.ReadSyncState(syncFile)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.Complete)
+	assert.Positive(t, state.LastAcked)
+}
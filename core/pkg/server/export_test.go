@@ -0,0 +1,115 @@
+package server_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runexport"
+	wbsettings "github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// exportedPaths returns the slash-separated paths of the files in the
+// export directory.
+func exportedPaths(t *testing.T, dir string) []string {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		paths = append(paths, filepath.ToSlash(relPath))
+		return err
+	})
+	require.NoError(t, err)
+	return paths
+}
+
+// An offline run's files are exported in the layout documented in package
+// runexport.
+func TestSender_ExportsOfflineRun(t *testing.T) {
+	filesDir := t.TempDir()
+	exportDir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := observability.NewNoOpLogger()
+	settings := wbsettings.From(&service.Settings{
+		RunId:      wrapperspb.String("run1"),
+		Project:    wrapperspb.String("project"),
+		FilesDir:   wrapperspb.String(filesDir),
+		XOffline:   wrapperspb.Bool(true),
+		XExportDir: wrapperspb.String(exportDir),
+	})
+	exporter := runexport.New(runexport.Params{Dir: exportDir, Logger: logger})
+	sender := server.NewSender(ctx, cancel, &server.SenderParams{
+		Logger:   logger,
+		Settings: settings.Proto,
+		RunfilesUploader: server.NewRunfilesUploader(
+			ctx, logger, settings, nil, nil, nil, nil, exporter, nil),
+		Exporter: exporter,
+		FwdChan:  make(chan *service.Record, 10),
+		OutChan:  make(chan *service.Result, 10),
+		Mailbox:  mailbox.NewMailbox(),
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(filesDir, "media", "images"), 0o755))
+	for _, path := range []string{"wandb-metadata.json", "media/images/plot_0.png"} {
+		require.NoError(t, os.WriteFile(filepath.Join(filesDir, path), []byte("x"), 0o644))
+	}
+	artifactFile := filepath.Join(t.TempDir(), "data.csv")
+	require.NoError(t, os.WriteFile(artifactFile, []byte("1,2"), 0o644))
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "run1", Project: "project"},
+		},
+	})
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{
+					{Path: "wandb-metadata.json", Policy: service.FilesItem_NOW},
+					{Path: "media/images/plot_0.png", Policy: service.FilesItem_NOW},
+				},
+			},
+		},
+	})
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_OutputRaw{
+			OutputRaw: &service.OutputRawRecord{Line: "hello\n"},
+		},
+	})
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Artifact{
+			Artifact: &service.ArtifactRecord{
+				Name:   "dataset",
+				Digest: "0123abcd",
+				Manifest: &service.ArtifactManifest{
+					Contents: []*service.ArtifactManifestEntry{
+						{Path: "data.csv", LocalPath: artifactFile},
+					},
+				},
+			},
+		},
+	})
+	sender.SendRecord(makeDeferRecord(service.DeferRequest_FLUSH_OUTPUT))
+	sender.SendRecord(makeDeferRecord(service.DeferRequest_FLUSH_FP))
+
+	assert.ElementsMatch(t,
+		[]string{
+			"_/project/run1/output.log",
+			"_/project/run1/wandb-metadata.json",
+			"_/project/run1/media/images/plot_0.png",
+			"_/project/run1/artifacts/dataset/0123abcd/data.csv",
+		},
+		exportedPaths(t, exportDir))
+}
@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/faults"
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/servertest"
+	wbsettings "github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Closing the file transfer manager while an upload is stalled must not
+// hang once the upload is cancelled.
+func TestFileTransferManager_CloseDuringUploadStall(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	injector, err := faults.Parse("upload-delay=1h")
+	require.NoError(t, err)
+	manager := server.NewFileTransferManager(
+		filetransfer.NewFileTransferStats(),
+		observability.NewNoOpLogger(),
+		wbsettings.From(&service.Settings{
+			XFileTransferRetryMax: &wrapperspb.Int32Value{Value: 0},
+		}),
+		injector,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("content"), 0o644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	task := &filetransfer.Task{
+		Type:               filetransfer.UploadTask,
+		Path:               path,
+		Url:                backend.UploadURL("file.txt"),
+		Context:            ctx,
+		CompletionCallback: func(*filetransfer.Task) { close(done) },
+	}
+	manager.Start()
+	manager.AddTask(task)
+
+	select {
+	case <-done:
+		t.Fatal("upload finished during the stall")
+	case <-time.After(50 * time.Millisecond):
+	}
+	cancel()
+	closed := make(chan struct{})
+	go func() {
+		manager.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return")
+	}
+	assert.ErrorIs(t, task.Err, context.Canceled)
+	assert.Empty(t, backend.Requests(servertest.RouteUpload))
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// The exit result tells the client which of the run's files were
+// uploaded, which failed and why, and which were skipped.
+func TestFilesReport_InExitResult(t *testing.T) {
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	for name, size := range map[string]int{
+		"model.pt":   4096,
+		"data.csv":   100,
+		"output.log": 10,
+	} {
+		require.NoError(t, os.WriteFile(
+			filepath.Join(filesDir, name),
+			make([]byte, size),
+			0o644,
+		))
+	}
+
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	backend.StubGraphQLOnce("CreateRunFiles", `{
+		"createRunFiles": {
+			"runID": "run-id",
+			"uploadHeaders": [],
+			"files": [
+				{"name": "model.pt", "uploadUrl": "`+backend.UploadURL("model.pt")+`"},
+				{"name": "data.csv", "uploadUrl": ""}
+			]
+		}
+	}`)
+	backend.InjectFault(servertest.RouteUpload,
+		servertest.Fault{StatusCode: http.StatusInternalServerError})
+	run, err := server.NewStream(settings.From(&service.Settings{
+		RunId:                            &wrapperspb.StringValue{Value: "files"},
+		BaseUrl:                          &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:                           &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:                           &wrapperspb.StringValue{Value: dir},
+		LogInternal:                      &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:                         &wrapperspb.StringValue{Value: filepath.Join(dir, "run-files.wandb")},
+		FilesDir:                         &wrapperspb.StringValue{Value: filesDir},
+		IgnoreGlobs:                      &service.ListStringValue{Value: []string{"*.log"}},
+		XFileTransferRetryMax:            &wrapperspb.Int32Value{Value: 3},
+		XFileTransferRetryWaitMinSeconds: &wrapperspb.DoubleValue{Value: 0.01},
+		XFileTransferRetryWaitMaxSeconds: &wrapperspb.DoubleValue{Value: 0.01},
+		XDisableStats:                    &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:                     &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	run.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	run.Start()
+	defer run.Close()
+
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "files", Project: "testProject"},
+		},
+	})
+	run.HandleRecord(makeRunStartRecord())
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{
+					{Path: "model.pt", Policy: service.FilesItem_NOW},
+					{Path: "data.csv", Policy: service.FilesItem_NOW},
+					{Path: "output.log", Policy: service.FilesItem_NOW},
+				},
+			},
+		},
+	})
+	require.Eventually(t,
+		func() bool { return len(backend.Requests(servertest.RouteUpload)) == 2 },
+		10*time.Second, 10*time.Millisecond,
+		"model.pt wasn't uploaded")
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	var exit *service.RunExitResult
+	timeout := time.After(30 * time.Second)
+	for exit == nil {
+		select {
+		case result := <-responses:
+			exit = result.GetExitResult()
+		case <-timeout:
+			t.Fatal("the run didn't exit")
+		}
+	}
+
+	files := map[string]*service.RunFileReport{}
+	for _, file := range exit.GetFiles().GetFiles() {
+		files[file.GetPath()] = file
+	}
+	require.Contains(t, files, "data.csv")
+	require.Contains(t, files, "model.pt")
+	require.Contains(t, files, "output.log")
+	assert.Equal(t, "data.csv", exit.GetFiles().GetFiles()[0].GetPath())
+	assert.Equal(t, service.RunFileReport_FAILED, files["data.csv"].GetStatus())
+	assert.NotEmpty(t, files["data.csv"].GetError())
+	assert.Equal(t, service.RunFileReport_UPLOADED, files["model.pt"].GetStatus())
+	assert.EqualValues(t, 4096, files["model.pt"].GetSize())
+	assert.EqualValues(t, 1, files["model.pt"].GetRetries())
+	assert.Empty(t, files["model.pt"].GetError())
+	assert.Equal(t,
+		service.RunFileReport_SKIPPED_IGNORED,
+		files["output.log"].GetStatus())
+	assert.EqualValues(t, 10, files["output.log"].GetSize())
+	assert.Zero(t, exit.GetFiles().GetOverflow())
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/wandb/simplejsonext"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+	fs "github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// userSummary is the top-level summary keys whose values the user set,
+// rather than ones derived from the run's history.
+//
+// Keys starting with an underscore, like "_step" or "_wandb", are W&B's
+// and never count as the user's. The zero value tracks nothing.
+type userSummary struct {
+	keys map[string]struct{}
+}
+
+// Update records the keys of a summary update.
+//
+// A key belongs to whichever set it last.
+func (u *userSummary) Update(summary *service.SummaryRecord) {
+	if u.keys == nil {
+		u.keys = make(map[string]struct{})
+	}
+	for _, item := range summary.GetUpdate() {
+		key := topLevelSummaryKey(item)
+		if summary.GetDerived() || strings.HasPrefix(key, "_") {
+			delete(u.keys, key)
+		} else {
+			u.keys[key] = struct{}{}
+		}
+	}
+	for _, item := range summary.GetRemove() {
+		if len(item.GetNestedKey()) <= 1 {
+			delete(u.keys, topLevelSummaryKey(item))
+		}
+	}
+}
+
+// Has returns whether the user set the top-level key.
+func (u *userSummary) Has(key string) bool {
+	_, ok := u.keys[key]
+	return ok
+}
+
+// topLevelSummaryKey returns the key of the summary's top level under
+// which the item is.
+func topLevelSummaryKey(item *service.SummaryItem) string {
+	if len(item.GetNestedKey()) > 0 {
+		return item.GetNestedKey()[0]
+	}
+	return item.GetKey()
+}
+
+// splitSummary splits a summary into parts whose serializations take up
+// at most maxLineBytes in a filestream request.
+//
+// Each top-level key is serialized on its own, the ones the user set first,
+// so that a huge summary is never serialized in one piece. Once the
+// deadline passes, the keys derived from history that are left are
+// omitted; the user's are always included. A key whose value is too large
+// to fit in a part is omitted too. A zero deadline never passes.
+//
+// Returns the parts and the omitted keys, sorted.
+func splitSummary(
+	tree pathtree.TreeData,
+	user *userSummary,
+	maxLineBytes int,
+	deadline time.Time,
+) ([]*service.SummaryRecord, []string, error) {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if user.Has(keys[i]) != user.Has(keys[j]) {
+			return user.Has(keys[i])
+		}
+		return keys[i] < keys[j]
+	})
+
+	var parts []*service.SummaryRecord
+	var omitted []string
+	var part *service.SummaryRecord
+	partBytes := 0
+	for _, key := range keys {
+		if !user.Has(key) && !deadline.IsZero() && time.Now().After(deadline) {
+			omitted = append(omitted, key)
+			continue
+		}
+
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to serialize summary key %q: %v", key, err)
+		}
+		valueJSON, err := json.Marshal(tree[key])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to serialize summary key %q: %v", key, err)
+		}
+
+		// the key, a colon, the value and a comma or a closing brace
+		itemBytes := fs.EncodedLineBytes(string(keyJSON)) +
+			fs.EncodedLineBytes(string(valueJSON)) + 2
+		if 1+itemBytes > maxLineBytes {
+			omitted = append(omitted, key)
+			continue
+		}
+		if part == nil || partBytes+itemBytes > maxLineBytes {
+			part = &service.SummaryRecord{}
+			parts = append(parts, part)
+			partBytes = 1
+		}
+		part.Update = append(part.Update, &service.SummaryItem{
+			Key:       key,
+			ValueJson: string(valueJSON),
+		})
+		partBytes += itemBytes
+	}
+
+	sort.Strings(omitted)
+	return parts, omitted, nil
+}
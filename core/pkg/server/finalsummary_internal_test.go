@@ -0,0 +1,121 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+	"github.com/wandb/wandb/core/internal/runsummary"
+	fs "github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// hugeSummary returns a summary of 50,000 keys derived from history and
+// the few that the user set.
+func hugeSummary() (pathtree.TreeData, *userSummary) {
+	tree := make(pathtree.TreeData)
+	for i := range 50_000 {
+		tree[fmt.Sprintf("metric_%05d", i)] = map[string]any{
+			"min": float64(i),
+			"max": float64(i + 1),
+		}
+	}
+	tree["_wandb"] = map[string]any{"runtime": int64(60)}
+
+	derived := &service.SummaryRecord{Derived: true}
+	for key := range tree {
+		derived.Update = append(derived.Update, &service.SummaryItem{Key: key})
+	}
+	set := &service.SummaryRecord{}
+	for _, key := range []string{"best_accuracy", "metric_00042"} {
+		tree[key] = "user"
+		set.Update = append(set.Update, &service.SummaryItem{Key: key})
+	}
+
+	user := &userSummary{}
+	user.Update(derived)
+	user.Update(set)
+	return tree, user
+}
+
+// partKeys returns the keys of the parts, in order.
+func partKeys(parts []*service.SummaryRecord) []string {
+	var keys []string
+	for _, part := range parts {
+		for _, item := range part.GetUpdate() {
+			keys = append(keys, item.GetKey())
+		}
+	}
+	return keys
+}
+
+func TestSplitSummary_PartsBelowLineLimit(t *testing.T) {
+	tree, user := hugeSummary()
+	maxLineBytes := 64 << 10
+
+	parts, omitted, err := splitSummary(tree, user, maxLineBytes, time.Time{})
+
+	require.NoError(t, err)
+	assert.Empty(t, omitted)
+	assert.Greater(t, len(parts), 10)
+	for _, part := range parts {
+		rs := runsummary.New()
+		rs.ApplyChangeRecord(part, func(err error) { require.NoError(t, err) })
+		line, err := rs.Serialize()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, fs.EncodedLineBytes(string(line)), maxLineBytes)
+	}
+	keys := partKeys(parts)
+	assert.Len(t, keys, len(tree))
+	assert.Equal(t, []string{"best_accuracy", "metric_00042"}, keys[:2])
+}
+
+// Once the deadline passes, the keys derived from history are omitted but
+// the ones the user set are still included.
+func TestSplitSummary_UserKeysFirstPastDeadline(t *testing.T) {
+	tree, user := hugeSummary()
+
+	parts, omitted, err := splitSummary(
+		tree, user, 64<<10, time.Now().Add(-time.Second))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"best_accuracy", "metric_00042"}, partKeys(parts))
+	assert.Len(t, omitted, len(tree)-2)
+	assert.Contains(t, omitted, "_wandb")
+	assert.NotContains(t, omitted, "metric_00042")
+}
+
+func TestSplitSummary_OmitsValuesTooLargeForALine(t *testing.T) {
+	tree := pathtree.TreeData{
+		"small": 1.0,
+		"large": string(make([]byte, 100)),
+	}
+
+	parts, omitted, err := splitSummary(tree, &userSummary{}, 50, time.Time{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"small"}, partKeys(parts))
+	assert.Equal(t, []string{"large"}, omitted)
+}
+
+func TestUserSummary_KeyBelongsToLastSetter(t *testing.T) {
+	user := &userSummary{}
+
+	user.Update(&service.SummaryRecord{Update: []*service.SummaryItem{
+		{Key: "loss"},
+		{NestedKey: []string{"eval", "accuracy"}},
+		{NestedKey: []string{"_wandb", "runtime"}},
+	}})
+	user.Update(&service.SummaryRecord{
+		Update:  []*service.SummaryItem{{Key: "loss"}},
+		Derived: true,
+	})
+
+	assert.False(t, user.Has("loss"))
+	assert.True(t, user.Has("eval"))
+	assert.False(t, user.Has("_wandb"))
+}
@@ -0,0 +1,107 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// A final summary too large for a filestream request is sent in parts that
+// fit, and a value too large for any is left out and reported.
+func TestFinalSummary_SplitIntoParts(t *testing.T) {
+	const maxRequestBytes = 64 << 10
+	dir := t.TempDir()
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:                      wrapperspb.String("summary"),
+		BaseUrl:                    wrapperspb.String(backend.URL()),
+		ApiKey:                     wrapperspb.String("test-api-key"),
+		LogDir:                     wrapperspb.String(dir),
+		SyncFile:                   wrapperspb.String(filepath.Join(dir, "run-summary.wandb")),
+		FilesDir:                   wrapperspb.String(filepath.Join(dir, "files")),
+		XDisableStats:              wrapperspb.Bool(true),
+		XDisableMeta:               wrapperspb.Bool(true),
+		XFileStreamMaxRequestBytes: wrapperspb.Int32(maxRequestBytes),
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	defer stream.Close()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "summary", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	summary := &service.SummaryRecord{}
+	for i := range 50_000 {
+		summary.Update = append(summary.Update, &service.SummaryItem{
+			Key:       fmt.Sprintf("metric_%05d", i),
+			ValueJson: "1.5",
+		})
+	}
+	summary.Update = append(summary.Update, &service.SummaryItem{
+		Key:       "blob",
+		ValueJson: fmt.Sprintf("%q", strings.Repeat("x", maxRequestBytes)),
+	})
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Summary{Summary: summary},
+	})
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	var exit *service.RunExitResult
+	timeout := time.After(30 * time.Second)
+	for exit == nil {
+		select {
+		case result := <-responses:
+			exit = result.GetExitResult()
+		case <-timeout:
+			t.Fatal("the run didn't exit")
+		}
+	}
+
+	assert.Equal(t, []string{"blob"}, exit.GetOmittedSummaryKeys())
+	var summaryLines []string
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		assert.LessOrEqual(t, len(request.Body), maxRequestBytes)
+		var body struct {
+			Files map[string]struct {
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		summaryLines = append(summaryLines, body.Files["wandb-summary.json"].Content...)
+	}
+	assert.Greater(t, len(summaryLines), 1)
+	keys := make(map[string]bool)
+	for _, line := range summaryLines {
+		var part map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &part))
+		for key := range part {
+			keys[key] = true
+		}
+	}
+	assert.Len(t, keys, 50_001) // and _wandb
+	assert.True(t, keys["metric_49999"])
+	assert.False(t, keys["blob"])
+}
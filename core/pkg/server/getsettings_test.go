@@ -0,0 +1,89 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// A running run answers with its settings tagged by where they came from,
+// including the ones set once the server created the run, and logs them
+// as it starts.
+func TestGetSettings_EffectiveSettingsWithProvenance(t *testing.T) {
+	t.Setenv(settings.RunLabelsEnv, "team=ml")
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubGraphQL("ProjectSettings", projectSettingsResponse)
+	backend.StubCreateRunFiles()
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "debug-internal.log")
+	stream, err := server.NewStream(settings.Resolve(&service.Settings{
+		RunId:         wrapperspb.String("settings"),
+		BaseUrl:       wrapperspb.String(backend.URL()),
+		ApiKey:        wrapperspb.String("test-api-key"),
+		LogDir:        wrapperspb.String(dir),
+		LogInternal:   wrapperspb.String(logFile),
+		SyncFile:      wrapperspb.String(filepath.Join(dir, "run-settings.wandb")),
+		FilesDir:      wrapperspb.String(filepath.Join(dir, "files")),
+		XDisableStats: wrapperspb.Bool(true),
+		XDisableMeta:  wrapperspb.Bool(true),
+		DisableGit:    wrapperspb.Bool(false),
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "settings", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	require.Nil(t, awaitResult(t, responses, "run").GetRunResult().GetError())
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(disabledRequest("settings", &service.Request{
+		RequestType: &service.Request_GetSettings{
+			GetSettings: &service.GetSettingsRequest{},
+		},
+	}))
+	response := awaitResult(t, responses, "settings").
+		GetResponse().GetGetSettingsResponse()
+	stream.FinishAndClose(0)
+
+	byKey := make(map[string]*service.EffectiveSetting)
+	for _, setting := range response.GetSettings() {
+		byKey[setting.GetKey()] = setting
+	}
+	sources := map[string]service.EffectiveSetting_Source{
+		"_exit_grace_seconds": service.EffectiveSetting_DEFAULT,
+		"save_code":           service.EffectiveSetting_PROJECT,
+		"disable_git":         service.EffectiveSetting_CLIENT,
+		"run_id":              service.EffectiveSetting_CLIENT,
+		"_run_labels":         service.EffectiveSetting_ENV,
+		"entity":              service.EffectiveSetting_SERVER,
+	}
+	for key, source := range sources {
+		assert.Equal(t, source, byKey[key].GetSource(), key)
+	}
+	assert.Equal(t, `"FakeEntity"`, byKey["entity"].GetValueJson())
+	assert.Equal(t, "true", byKey["save_code"].GetValueJson())
+	assert.NotContains(t, byKey["api_key"].GetValueJson(), "test-api-key")
+
+	log, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "stream: effective settings")
+	assert.Contains(t, string(log), `{\"value\":{\"team\":\"ml\"}} (env)`)
+	assert.NotContains(t, string(log), "test-api-key")
+}
@@ -4,24 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"math/rand"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/segmentio/encoding/json"
 	"github.com/wandb/wandb/core/pkg/monitor"
-	"github.com/wandb/wandb/core/pkg/utils"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/faults"
 	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/histogram"
 	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runcleanup"
+	"github.com/wandb/wandb/core/internal/runconfig"
 	"github.com/wandb/wandb/core/internal/runfiles"
 	"github.com/wandb/wandb/core/internal/runhistory"
 	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/runtable"
+	"github.com/wandb/wandb/core/internal/runtmp"
 	"github.com/wandb/wandb/core/internal/sampler"
+	"github.com/wandb/wandb/core/internal/settings"
 	"github.com/wandb/wandb/core/internal/timer"
 	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
 )
@@ -35,6 +44,10 @@ const (
 	ConfigFileName       = "config.yaml"
 )
 
+// maxHistoryStringBytes is the size above which string values in history
+// are truncated, to keep history rows under the filestream's line limit.
+const maxHistoryStringBytes = 1 << 20
+
 type HandlerParams struct {
 	Settings          *service.Settings
 	FwdChan           chan *service.Record
@@ -47,7 +60,77 @@ type HandlerParams struct {
 	RunfilesUploader  runfiles.Uploader
 	TBHandler         *TBHandler
 	SystemMonitor     *monitor.SystemMonitor
+	RunMetadata       *RunMetadata
 	TerminalPrinter   *observability.Printer
+	DeferProgress     *DeferProgress
+	CrashReporter     *CrashReporter
+	Diagnostics       *Diagnostics
+	FaultInjector     *faults.Injector
+
+	// RunDirs are where the run's files are kept, or nil if unknown.
+	RunDirs *settings.RunDirs
+
+	// DebugLog is where the stream's debug log is written, if anywhere.
+	DebugLog string
+
+	// Clock times the run, or nil to use the system clock.
+	Clock waiting.Clock
+
+	// SamplerRand is the source of randomness for sampling history, or
+	// nil to use the global source.
+	SamplerRand *rand.Rand
+
+	// Checkpoints says when to checkpoint the handler's state in the
+	// transaction log, or is nil to never checkpoint it.
+	Checkpoints *Checkpoints
+
+	// ClockSkew is how far the local clock is off the server's, to
+	// correct the timestamps in history, or nil to not correct them.
+	ClockSkew *api.ClockSkew
+
+	// HistoryLimiter limits the rate of history rows sent to the server,
+	// or is nil to send all of them.
+	HistoryLimiter *HistoryLimiter
+
+	// HistoryKeyLimit limits the distinct history keys summarized and
+	// sampled, or is nil to track all of them.
+	HistoryKeyLimit *HistoryKeyLimit
+
+	// Memory bounds the handler's state, or is nil to not bound it.
+	Memory *HandlerMemory
+
+	// PipelineLatency measures how long history rows take to go through
+	// the stream, or is nil.
+	PipelineLatency *PipelineLatency
+
+	// Mirror sends the run to a second server, or is nil.
+	Mirror *Mirror
+
+	// RunOutputs tracks the run's files, artifacts and media to list for
+	// tools, or is nil.
+	RunOutputs *RunOutputs
+
+	// OfflineMessages are the messages for the user to save to the
+	// transaction log, or nil if the run isn't offline.
+	OfflineMessages *OfflineMessages
+
+	// Backpressure slows down the handler while the sender is behind, or
+	// is nil.
+	Backpressure *Backpressure
+
+	// ExitGrace is how long data is accepted after the run's exit, or nil
+	// to accept it until the stream closes.
+	ExitGrace *ExitGrace
+
+	// RunLabels are the labels to stamp on the run, or nil.
+	RunLabels *RunLabels
+
+	// TempDir is the stream's temporary directory, or nil.
+	TempDir *runtmp.Dir
+
+	// UnknownTypes counts the records of types this version doesn't know,
+	// or is nil.
+	UnknownTypes *UnknownTypes
 }
 
 // Handler is the handler for a stream it handles the incoming messages, processes them
@@ -74,6 +157,13 @@ type Handler struct {
 	// runRecord is the runRecord record received from the server
 	runRecord *service.RunRecord
 
+	// serverRun is the run as the server last created or updated it,
+	// or nil if the server hasn't yet
+	//
+	// The server may change the run, such as by assigning it a display
+	// name, so its fields take precedence over the client's in runRecord.
+	serverRun *service.RunRecord
+
 	// runHistory is the current active history entry being updated
 	runHistory *runhistory.RunHistory
 
@@ -82,7 +172,14 @@ type Handler struct {
 	// the sparkline in the terminal
 	//
 	// TODO: currently only values that can be cast to float32 are supported
-	samplers map[string]*sampler.ReservoirSampler[float32]
+	samplers map[string]*sampler.ReservoirSampler[historySample]
+
+	// nonNumericKeys are the keys of history metrics whose values aren't
+	// numbers, which have no samplers
+	nonNumericKeys map[string]struct{}
+
+	// samplerRandOrNil makes the samplers reproducible, if not nil
+	samplerRandOrNil *rand.Rand
 
 	// metricHandler is the metric handler for the stream
 	metricHandler *MetricHandler
@@ -91,8 +188,48 @@ type Handler struct {
 	runSummary *runsummary.RunSummary
 
 	// systemMonitor is the system monitor for the stream
+	//
+	// It is nil if system metrics are disabled.
 	systemMonitor *monitor.SystemMonitor
 
+	// runMetadata captures the environment of the run
+	//
+	// It is nil if metadata capture is disabled.
+	runMetadata *RunMetadata
+
+	// runStarted is whether the run start request was handled
+	runStarted bool
+
+	// exiting is whether the run's exit was handled
+	exiting bool
+
+	// aborted is whether the client aborted the run, after which its data
+	// is dropped
+	aborted bool
+
+	// captureWG waits for the capture of the run's environment, which
+	// runs in the background once the run starts
+	captureWG sync.WaitGroup
+
+	// deferProgress is where the sender is in exiting the run
+	deferProgress *DeferProgress
+
+	// crashReporter notes the records handled, for crash reports
+	crashReporter *CrashReporter
+
+	// diagnostics is the stream's diagnostics, for status responses
+	diagnostics *Diagnostics
+
+	// runDirs are where the run's files are kept, for status responses
+	runDirs *settings.RunDirs
+
+	// debugLog is where the stream's debug log is written, for status
+	// responses
+	debugLog string
+
+	// faultInjector may make the handler panic, for testing
+	faultInjector *faults.Injector
+
 	// tbHandler is the tensorboard handler
 	tbHandler *TBHandler
 
@@ -108,7 +245,81 @@ type Handler struct {
 	// terminalPrinter gathers terminal messages to send back to the user process
 	terminalPrinter *observability.Printer
 
+	// label namespaces the system metrics and console output of this
+	// process in shared-mode runs; it is empty if they are not labeled
+	label string
+
+	// consoleCapture is which console streams are kept
+	consoleCapture consoleCapture
+
 	mailbox *mailbox.Mailbox
+
+	// checkpoints says when to checkpoint the handler's state
+	checkpoints *Checkpoints
+
+	// runConfig tracks the run's config for checkpoints
+	//
+	// It is nil if the handler doesn't checkpoint its state.
+	runConfig *runconfig.RunConfig
+
+	// clockSkew corrects the timestamps in history, or is nil
+	clockSkew *api.ClockSkew
+
+	// historyLimiter limits the rate of history rows sent, or is nil
+	historyLimiter *HistoryLimiter
+
+	// historyKeys limits the history keys summarized and sampled, or is nil
+	historyKeys *HistoryKeyLimit
+
+	// unknownTypes counts the records of types this version doesn't know,
+	// or is nil
+	unknownTypes *UnknownTypes
+
+	// memory bounds the handler's state and reports its size, or is nil
+	memory *HandlerMemory
+
+	// latency measures how long history rows take to go through the
+	// stream, or is nil
+	latency *PipelineLatency
+
+	// mirror reports how far mirroring the run got, or is nil
+	mirror *Mirror
+
+	// runOutputs tracks the run's files, artifacts and media, or is nil
+	runOutputs *RunOutputs
+
+	// offlineMessages are the messages for the user to save to the
+	// transaction log, or nil
+	offlineMessages *OfflineMessages
+
+	// exitGrace is how long data is accepted after the run's exit, or nil
+	exitGrace *ExitGrace
+
+	// backpressure slows down the handler while the sender is behind, or
+	// is nil
+	backpressure *Backpressure
+
+	// heldHistory are the history rows held back while slowed down
+	heldHistory []*service.Record
+
+	// outputLines counts the console output forwarded while slowed down,
+	// to sample it
+	outputLines int
+
+	// tables keeps the tables logged a few rows at a time, or is nil if
+	// the run has no files directory
+	tables *runtable.Tables
+
+	// explicitSummary is the summary keys the user set that the pending
+	// history row must not overwrite
+	explicitSummary explicitSummary
+
+	// metricKeyWarnings are the invalid metric keys the user was warned
+	// about
+	metricKeyWarnings metricKeyWarnings
+
+	// runLabels are the labels stamped on the run, or nil
+	runLabels *RunLabels
 }
 
 // NewHandler creates a new handler
@@ -116,9 +327,26 @@ func NewHandler(
 	ctx context.Context,
 	params *HandlerParams,
 ) *Handler {
+	clock := params.Clock
+	if clock == nil {
+		clock = waiting.NewClock()
+	}
+
+	var runConfigOrNil *runconfig.RunConfig
+	if params.Checkpoints != nil {
+		runConfigOrNil = runconfig.New()
+	}
+
+	capture, err := parseConsoleCapture(
+		settings.From(params.Settings).GetConsoleCapture())
+	if err != nil {
+		params.Logger.CaptureError("handler: invalid console capture setting", err)
+	}
+
 	return &Handler{
 		ctx:                   ctx,
-		runTimer:              timer.New(),
+		runTimer:              timer.NewWithClock(clock),
+		samplerRandOrNil:      params.SamplerRand,
 		terminalPrinter:       params.TerminalPrinter,
 		logger:                params.Logger,
 		settings:              params.Settings,
@@ -131,24 +359,67 @@ func NewHandler(
 		runfilesUploaderOrNil: params.RunfilesUploader,
 		tbHandler:             params.TBHandler,
 		systemMonitor:         params.SystemMonitor,
+		runMetadata:           params.RunMetadata,
+		deferProgress:         params.DeferProgress,
+		crashReporter:         params.CrashReporter,
+		diagnostics:           params.Diagnostics,
+		runDirs:               params.RunDirs,
+		debugLog:              params.DebugLog,
+		faultInjector:         params.FaultInjector,
+		label:                 writerLabel(params.Settings),
+		consoleCapture:        capture,
+		checkpoints:           params.Checkpoints,
+		clockSkew:             params.ClockSkew,
+		historyLimiter:        params.HistoryLimiter,
+		historyKeys:           params.HistoryKeyLimit,
+		unknownTypes:          params.UnknownTypes,
+		memory:                params.Memory,
+		latency:               params.PipelineLatency,
+		mirror:                params.Mirror,
+		runOutputs:            params.RunOutputs,
+		offlineMessages:       params.OfflineMessages,
+		exitGrace:             params.ExitGrace,
+		backpressure:          params.Backpressure,
+		runLabels:             params.RunLabels,
+		runConfig:             runConfigOrNil,
+		tables: runtable.New(runtable.Params{
+			FilesDir: params.Settings.GetFilesDir().GetValue(),
+			TempDir:  params.TempDir,
+			Logger:   params.Logger,
+		}),
 	}
 }
 
 // Do starts the handler
 func (h *Handler) Do(inChan <-chan *service.Record) {
 	defer h.logger.Reraise()
-	h.logger.Info("handler: started", "stream_id", h.settings.RunId)
+	defer h.crashReporter.ReportPanic("handler")
+	h.logger.Info("handler: started")
 	for record := range inChan {
-		h.logger.Debug("handle: got a message", "record_type", record.RecordType, "stream_id", h.settings.RunId)
+		h.crashReporter.Observe("handler", record)
+		h.faultInjector.MaybePanic(faults.Handler)
+		h.logger.Debug(
+			"handle: got a message",
+			observability.RecordTypeKey, recordTypeName(record),
+			"record", record.RecordType,
+		)
 		h.handleRecord(record)
+		if h.checkpoints.takeDue() {
+			h.fwdCheckpoint()
+		}
 	}
 	h.Close()
 }
 
 func (h *Handler) Close() {
+	// the rows held back are saved even if the run never exits, such as
+	// when the stream is aborted
+	h.releaseHistory()
+	h.captureWG.Wait()
+	h.tables.Close()
 	close(h.outChan)
 	close(h.fwdChan)
-	h.logger.Debug("handler: Close: closed", "stream_id", h.settings.RunId)
+	h.logger.Debug("handler: Close: closed")
 }
 
 // respond sends a response to the client
@@ -161,6 +432,16 @@ func (h *Handler) respond(record *service.Record, response *service.Response) {
 	h.outChan <- result
 }
 
+// respondInParts sends a response to the record's request that the client
+// may receive in parts, for responses that can be large.
+func (h *Handler) respondInParts(record *service.Record, response *service.Response) {
+	h.outChan <- markMultipart(&service.Result{
+		ResultType: &service.Result_Response{Response: response},
+		Control:    record.Control,
+		Uuid:       record.Uuid,
+	})
+}
+
 // fwdRecord forwards a record to the next component
 func (h *Handler) fwdRecord(record *service.Record) {
 	if record == nil {
@@ -187,11 +468,25 @@ func (h *Handler) fwdRecordWithControl(record *service.Record, controlOptions ..
 
 //gocyclo:ignore
 func (h *Handler) handleRecord(record *service.Record) {
+	h.saveOfflineMessages()
+
+	if h.aborted && !keptWhenAborted(record) {
+		h.dropAborted(record)
+		return
+	}
+
+	if h.exiting && isLateData(record) && !h.exitGrace.admit() {
+		h.rejectLate(record)
+		return
+	}
+
 	switch x := record.RecordType.(type) {
 	case *service.Record_Alert:
 		h.handleAlert(record)
 	case *service.Record_Artifact:
 		h.handleArtifact(record)
+	case *service.Record_Checkpoint:
+		h.handleCheckpoint(x.Checkpoint)
 	case *service.Record_Config:
 		h.handleConfig(record)
 	case *service.Record_Exit:
@@ -205,11 +500,14 @@ func (h *Handler) handleRecord(record *service.Record) {
 	case *service.Record_Header:
 		h.handleHeader(record)
 	case *service.Record_History:
+		x.History.Item = h.normalizeHistoryItems(x.History.GetItem())
 		h.handleHistory(x.History)
 	case *service.Record_LinkArtifact:
 		h.handleLinkArtifact(record)
 	case *service.Record_Metric:
-		h.handleMetric(record, x.Metric)
+		if h.normalizeMetric(x.Metric) {
+			h.handleMetric(record, x.Metric)
+		}
 	case *service.Record_Output:
 		h.handleOutput(record)
 	case *service.Record_OutputRaw:
@@ -223,14 +521,28 @@ func (h *Handler) handleRecord(record *service.Record) {
 	case *service.Record_Stats:
 		h.handleSystemMetrics(record)
 	case *service.Record_Summary:
+		h.normalizeSummary(x.Summary)
+		runsummary.ExpandDottedKeys(x.Summary)
+		h.explicitSummary.Set(x.Summary)
 		h.handleSummary(record, x.Summary)
+	case *service.Record_TableRows:
+		h.handleTableRows(x.TableRows)
 	case *service.Record_Tbrecord:
 		h.handleTBrecord(record)
 	case *service.Record_Telemetry:
 		h.handleTelemetry(record)
 	case *service.Record_UseArtifact:
 		h.handleUseArtifact(record)
+	case *service.Record_Aborted, *service.Record_UserMessage:
+		// made by the handler, and only read back from the log by replays
+	case *service.Record_Conflicted, *service.Record_ProjectSettings:
+		// made by the sender, and passed on to be saved
+		h.fwdRecord(record)
 	case nil:
+		if isUnknownType(record) {
+			h.handleUnknownType(record)
+			return
+		}
 		err := fmt.Errorf("handler: handleRecord: record type is nil")
 		h.logger.CaptureFatalAndPanic("error handling record", err)
 	default:
@@ -285,12 +597,24 @@ func (h *Handler) handleRequest(record *service.Record) {
 		h.handleRequestSampledHistory(record)
 	case *service.Request_ServerInfo:
 		h.handleRequestServerInfo(record)
+	case *service.Request_GetSettings:
+		h.handleRequestGetSettings(record)
 	case *service.Request_PythonPackages:
 		h.handleRequestPythonPackages(record, x.PythonPackages)
 	case *service.Request_StopStatus:
 		h.handleRequestStopStatus(record)
 	case *service.Request_LogArtifact:
 		h.handleRequestLogArtifact(record)
+	case *service.Request_ArtifactWait:
+		h.handleRequestArtifactWait(record)
+	case *service.Request_ArtifactCancel:
+		h.handleRequestArtifactCancel(record)
+	case *service.Request_ListRunOutputs:
+		h.handleRequestListRunOutputs(record)
+	case *service.Request_AbortRun:
+		h.handleRequestAbortRun(record)
+	case *service.Request_PrepareForPreemption:
+		h.handleRequestPrepareForPreemption(record)
 	case *service.Request_DownloadArtifact:
 		h.handleRequestDownloadArtifact(record)
 	case *service.Request_Attach:
@@ -311,7 +635,21 @@ func (h *Handler) handleRequest(record *service.Record) {
 		h.handleRequestSenderRead(record)
 	case *service.Request_JobInput:
 		h.handleRequestJobInput(record)
+	case *service.Request_CredentialsUpdate:
+		h.handleRequestCredentialsUpdate(record)
+	case *service.Request_SettingsUpdate:
+		h.handleRequestSettingsUpdate(record)
+	case *service.Request_Cleanup:
+		h.handleRequestCleanup(record, x.Cleanup)
+	case *service.Request_RunUpdated:
+		h.handleRequestRunUpdated(x.RunUpdated)
+	case *service.Request_Backpressure:
+		h.handleRequestBackpressure(x.Backpressure)
 	case nil:
+		if isUnknownType(record) {
+			h.handleUnknownType(record)
+			return
+		}
 		err := fmt.Errorf("handler: handleRequest: request type is nil")
 		h.logger.CaptureFatalAndPanic("error handling request", err)
 	default:
@@ -338,7 +676,52 @@ func (h *Handler) handleRequestRunStatus(record *service.Record) {
 }
 
 func (h *Handler) handleRequestStatus(record *service.Record) {
-	h.respond(record, &service.Response{})
+	h.respond(record, &service.Response{
+		ResponseType: &service.Response_StatusResponse{
+			StatusResponse: &service.StatusResponse{
+				ExitProgress: h.deferProgress.Proto(),
+				Diagnostics:  h.diagnostics.Proto(),
+				Paths:        runPathsProto(h.runDirs, h.debugLog),
+			},
+		},
+	})
+}
+
+// handleRequestCleanup deletes old run directories by the settings'
+// cleanup policy, or only reports what it would delete.
+func (h *Handler) handleRequestCleanup(
+	record *service.Record,
+	request *service.CleanupRequest,
+) {
+	policy := cleanupPolicy(settings.From(h.settings))
+	policy.DryRun = policy.DryRun || request.GetDryRun()
+
+	wandbDir := h.settings.GetWandbDir().GetValue()
+	report, err := runcleanup.Run(wandbDir, policy, time.Now())
+	if err != nil {
+		h.logger.CaptureError("handler: failed to clean up run directories", err)
+	}
+
+	h.respond(record, &service.Response{
+		ResponseType: &service.Response_CleanupResponse{
+			CleanupResponse: cleanupResponse(report, err),
+		},
+	})
+}
+
+// runPathsProto describes where the run's files and debug log are kept,
+// or returns nil if that's unknown.
+func runPathsProto(dirs *settings.RunDirs, debugLog string) *service.RunPaths {
+	if dirs == nil && debugLog == "" {
+		return nil
+	}
+	return &service.RunPaths{
+		FilesDir:  dirs.GetFilesDir(),
+		LogDir:    dirs.GetLogDir(),
+		SyncFile:  dirs.GetSyncFile(),
+		Fallbacks: dirs.GetFallbacks(),
+		DebugLog:  debugLog,
+	}
 }
 
 func (h *Handler) handleRequestSenderMark(_ *service.Record) {
@@ -359,11 +742,15 @@ func (h *Handler) handleStepMetric(key string) {
 	if key == "" {
 		return
 	}
+	h.trackStepMetric(key)
 
 	// already exists no need to add
 	if _, defined := h.metricHandler.definedMetrics[key]; defined {
 		return
 	}
+	if !h.admitMetric(false) {
+		return
+	}
 
 	metric, err := addMetric(key, key, &h.metricHandler.definedMetrics)
 
@@ -371,6 +758,7 @@ func (h *Handler) handleStepMetric(key string) {
 		h.logger.CaptureError("error adding metric to map", err)
 		return
 	}
+	h.noteMetricDefinitions()
 
 	stepRecord := &service.Record{
 		RecordType: &service.Record_Metric{
@@ -388,16 +776,27 @@ func (h *Handler) handleMetric(record *service.Record, metric *service.MetricRec
 	// TODO: replace glob-name/name with one-of field
 	switch {
 	case metric.GetGlobName() != "":
+		_, defined := h.metricHandler.globMetrics[metric.GetGlobName()]
+		if !h.admitMetric(defined) {
+			return
+		}
 		if _, err := addMetric(metric, metric.GetGlobName(), &h.metricHandler.globMetrics); err != nil {
 			h.logger.CaptureError("error adding metric to map", err)
 			return
 		}
+		h.noteMetricDefinitions()
 		h.fwdRecord(record)
 	case metric.GetName() != "":
+		_, defined := h.metricHandler.definedMetrics[metric.GetName()]
+		if !h.admitMetric(defined) {
+			return
+		}
+		h.checkGoalChange(metric)
 		if _, err := addMetric(metric, metric.GetName(), &h.metricHandler.definedMetrics); err != nil {
 			h.logger.CaptureError("error adding metric to map", err)
 			return
 		}
+		h.noteMetricDefinitions()
 		h.handleStepMetric(metric.GetStepMetric())
 		h.fwdRecord(record)
 	default:
@@ -405,6 +804,23 @@ func (h *Handler) handleMetric(record *service.Record, metric *service.MetricRec
 	}
 }
 
+// checkGoalChange restarts tracking the best value of a metric that is
+// redefined with another goal, since its best so far is for the old goal.
+func (h *Handler) checkGoalChange(metric *service.MetricRecord) {
+	defined, ok := h.metricHandler.definedMetrics[metric.GetName()]
+	if !ok || defined.GetGoal() == metric.GetGoal() {
+		return
+	}
+
+	if h.metricHandler.restartBest(metric.GetName()) {
+		h.logger.Warn(
+			"handler: metric goal changed, restarting its best value",
+			"metric", metric.GetName(),
+			"goal", metric.GetGoal().String(),
+		)
+	}
+}
+
 func (h *Handler) handleRequestDefer(record *service.Record, request *service.DeferRequest) {
 	switch request.State {
 	case service.DeferRequest_BEGIN:
@@ -413,6 +829,9 @@ func (h *Handler) handleRequestDefer(record *service.Record, request *service.De
 		// stop the system monitor to ensure that we don't send any more system metrics
 		// after the run has exited
 		h.systemMonitor.Stop()
+
+		// the run's metadata and other files are sent before it exits
+		h.captureWG.Wait()
 	case service.DeferRequest_FLUSH_PARTIAL_HISTORY:
 		// This will force the content of h.runHistory to be flushed and sent
 		// over to the sender.
@@ -429,9 +848,12 @@ func (h *Handler) handleRequestDefer(record *service.Record, request *service.De
 				},
 			},
 		)
+		h.exitGrace.flushedHistory()
 	case service.DeferRequest_FLUSH_TB:
 		h.tbHandler.Close()
 	case service.DeferRequest_FLUSH_SUM:
+		h.exitGrace.flushedSummary()
+		h.flushTables()
 	case service.DeferRequest_FLUSH_DEBOUNCER:
 	case service.DeferRequest_FLUSH_OUTPUT:
 	case service.DeferRequest_FLUSH_JOB:
@@ -468,13 +890,38 @@ func (h *Handler) handleRequestStopStatus(record *service.Record) {
 }
 
 func (h *Handler) handleArtifact(record *service.Record) {
+	h.runOutputs.ArtifactLogged(record.GetArtifact())
 	h.fwdRecord(record)
 }
 
 func (h *Handler) handleRequestLogArtifact(record *service.Record) {
+	h.runOutputs.ArtifactLogged(record.GetRequest().GetLogArtifact().GetArtifact())
 	h.fwdRecord(record)
 }
 
+func (h *Handler) handleRequestArtifactWait(record *service.Record) {
+	h.fwdRecord(record)
+}
+
+func (h *Handler) handleRequestArtifactCancel(record *service.Record) {
+	h.fwdRecord(record)
+}
+
+// handleRequestListRunOutputs lists the run's files, artifacts and media.
+//
+// It's answered from what the run logged so far, so it works the same
+// offline; the listing can be long, so it may be sent in parts.
+func (h *Handler) handleRequestListRunOutputs(record *service.Record) {
+	h.respondInParts(record, &service.Response{
+		ResponseType: &service.Response_ListRunOutputsResponse{
+			ListRunOutputsResponse: h.runOutputs.Proto(
+				h.settings.GetFilesDir().GetValue(),
+				h.runfilesUploaderOrNil,
+			),
+		},
+	})
+}
+
 func (h *Handler) handleRequestDownloadArtifact(record *service.Record) {
 	h.fwdRecord(record)
 }
@@ -497,6 +944,14 @@ func (h *Handler) handleRequestPollExit(record *service.Record) {
 		}
 	}
 
+	pollExitResponse.ExitProgress = h.deferProgress.Proto()
+	pollExitResponse.FilestreamDone = h.deferProgress.IsFileStreamDone()
+
+	// the run is done regardless of its mirror, which is reported apart
+	pollExitResponse.MirrorProgress = h.mirror.Progress()
+
+	pollExitResponse.Files = runFilesReport(h.runfilesUploaderOrNil, h.settings)
+
 	response := &service.Response{
 		ResponseType: &service.Response_PollExitResponse{
 			PollExitResponse: pollExitResponse,
@@ -564,71 +1019,78 @@ func (h *Handler) handleRequestServerInfo(record *service.Record) {
 	)
 }
 
+// handleRequestGetSettings forwards the request to the sender, which is
+// what changes the settings once the run is created, even when offline.
+func (h *Handler) handleRequestGetSettings(record *service.Record) {
+	h.fwdRecordWithControl(record,
+		func(control *service.Control) {
+			control.AlwaysSend = true
+		},
+	)
+}
+
+// handleRequestRunStart starts the run once it's been upserted.
+//
+// The run's timer, the system monitor and the capture of the run's
+// environment start here rather than with the stream, so that nothing is
+// sampled before the run exists. The capture runs in the background, and
+// the client's request is answered without waiting for it.
+//
+// The client may retry the request, in which case the run was already
+// started and only the response is sent again.
 func (h *Handler) handleRequestRunStart(record *service.Record, request *service.RunStartRequest) {
+	if h.runStarted {
+		h.logger.Info("handler: handleRequestRunStart: run already started")
+		h.respond(record, &service.Response{})
+		return
+	}
+	h.runStarted = true
+
 	var ok bool
 	run := request.Run
 
-	// start the run timer
-	startTime := run.StartTime.AsTime()
-	h.runTimer.Start(&startTime)
+	// start the run timer from the time the client says the run started,
+	// or from now if it doesn't say
+	var startTime *time.Time
+	if run.GetStartTime() != nil {
+		t := run.GetStartTime().AsTime()
+		startTime = &t
+	}
+	h.runTimer.Start(startTime)
 
 	if h.runRecord, ok = proto.Clone(run).(*service.RunRecord); !ok {
 		err := fmt.Errorf("handleRunStart: failed to clone run")
 		h.logger.CaptureFatalAndPanic("error handling run start", err)
 	}
+	if h.serverRun != nil {
+		applyServerRun(h.runRecord, h.serverRun)
+	}
+
+	// a resumed run's runtime includes the time it ran before
+	if h.runRecord.GetResumed() {
+		h.runTimer.AddElapsed(time.Duration(h.runRecord.GetRuntime()) * time.Second)
+	}
 	h.fwdRecord(record)
 
 	// TODO: mark OutputFileName as a WANDB file
 	_ = OutputFileName
 
 	// start the system monitor
-	if !h.settings.GetXDisableStats().GetValue() {
-		h.systemMonitor.Do()
-	}
-
-	// save code and patch
-	if h.settings.GetSaveCode().GetValue() {
-		h.handleCodeSave()
-		h.handlePatchSave()
-	}
+	h.systemMonitor.Do()
 
 	// NOTE: once this request arrives in the sender,
 	// the latter will start its filestream and uploader
-	// initialize the run metadata from settings
-	var git *service.GitRepoRecord
-	if run.GetGit().GetRemoteUrl() != "" || run.GetGit().GetCommit() != "" {
-		git = &service.GitRepoRecord{
-			RemoteUrl: run.GetGit().GetRemoteUrl(),
-			Commit:    run.GetGit().GetCommit(),
-		}
-	}
 
-	metadata := &service.MetadataRequest{
-		Os:            h.settings.GetXOs().GetValue(),
-		Python:        h.settings.GetXPython().GetValue(),
-		Host:          h.settings.GetHost().GetValue(),
-		Cuda:          h.settings.GetXCuda().GetValue(),
-		Program:       h.settings.GetProgram().GetValue(),
-		CodePath:      h.settings.GetProgramRelpath().GetValue(),
-		CodePathLocal: h.settings.GetXCodePathLocal().GetValue(),
-		Email:         h.settings.GetEmail().GetValue(),
-		Root:          h.settings.GetRootDir().GetValue(),
-		Username:      h.settings.GetUsername().GetValue(),
-		Docker:        h.settings.GetDocker().GetValue(),
-		Executable:    h.settings.GetXExecutable().GetValue(),
-		Args:          h.settings.GetXArgs().GetValue(),
-		Colab:         h.settings.GetColabUrl().GetValue(),
-		StartedAt:     run.GetStartTime(),
-		Git:           git,
-	}
-
-	if !h.settings.GetXDisableStats().GetValue() {
-		systemInfo := h.systemMonitor.Probe()
-		if systemInfo != nil {
-			proto.Merge(metadata, systemInfo)
+	// capture the environment in the background, from a copy of the run
+	// since the handler's copy changes as the run is updated
+	captured := proto.Clone(h.runRecord).(*service.RunRecord)
+	h.captureWG.Add(1)
+	go func() {
+		defer h.captureWG.Done()
+		for _, record := range h.runMetadata.Capture(captured, h.probeSystem()) {
+			h.fwdRecord(record)
 		}
-	}
-	h.handleMetadata(metadata)
+	}()
 
 	h.respond(record, &service.Response{})
 }
@@ -636,174 +1098,57 @@ func (h *Handler) handleRequestRunStart(record *service.Record, request *service
 func (h *Handler) handleRequestPythonPackages(_ *service.Record, request *service.PythonPackagesRequest) {
 	// write all requirements to a file
 	// send the file as a Files record
-	filename := filepath.Join(h.settings.GetFilesDir().GetValue(), RequirementsFileName)
-	file, err := os.Create(filename)
-	if err != nil {
-		h.logger.Error("error creating requirements file", "error", err)
-		return
-	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			h.logger.Error("error closing requirements file", "error", err)
-		}
-	}(file)
-
-	for _, pkg := range request.Package {
-		line := fmt.Sprintf("%s==%s\n", pkg.Name, pkg.Version)
-		_, err := file.WriteString(line)
-		if err != nil {
-			h.logger.Error("error writing requirements file", "error", err)
-			return
-		}
-	}
-	record := &service.Record{
-		RecordType: &service.Record_Files{
-			Files: &service.FilesRecord{
-				Files: []*service.FilesItem{
-					{
-						Path: RequirementsFileName,
-						Type: service.FilesItem_WANDB,
-					},
-				},
-			},
-		},
-	}
-	h.handleFiles(record)
-}
-
-func (h *Handler) handleCodeSave() {
-	programRelative := h.settings.GetProgramRelpath().GetValue()
-	if programRelative == "" {
-		h.logger.Warn("handleCodeSave: program relative path is empty")
-		return
-	}
-
-	programAbsolute := h.settings.GetProgramAbspath().GetValue()
-	if _, err := os.Stat(programAbsolute); err != nil {
-		h.logger.Warn("handleCodeSave: program absolute path does not exist", "path", programAbsolute)
-		return
-	}
-
-	codeDir := filepath.Join(h.settings.GetFilesDir().GetValue(), "code")
-	if err := os.MkdirAll(filepath.Join(codeDir, filepath.Dir(programRelative)), os.ModePerm); err != nil {
-		return
-	}
-	savedProgram := filepath.Join(codeDir, programRelative)
-	if _, err := os.Stat(savedProgram); err != nil {
-		if err = utils.CopyFile(programAbsolute, savedProgram); err != nil {
-			return
-		}
-	}
-	record := &service.Record{
-		RecordType: &service.Record_Files{
-			Files: &service.FilesRecord{
-				Files: []*service.FilesItem{
-					{
-						Path: filepath.Join("code", programRelative),
-						Type: service.FilesItem_WANDB,
-					},
-				},
-			},
-		},
+	if record := h.runMetadata.SaveRequirements(request); record != nil {
+		h.handleFiles(record)
 	}
-	h.handleFiles(record)
 }
 
-func (h *Handler) handlePatchSave() {
-	// capture git state
-	if h.settings.GetDisableGit().GetValue() {
-		return
-	}
-
-	git := NewGit(h.settings.GetRootDir().GetValue(), h.logger)
-	if !git.IsAvailable() {
-		return
-	}
-
-	var files []*service.FilesItem
-
-	filesDirPath := h.settings.GetFilesDir().GetValue()
-	file := filepath.Join(filesDirPath, DiffFileName)
-	if err := git.SavePatch("HEAD", file); err != nil {
-		h.logger.Error("error generating diff", "error", err)
-	} else {
-		files = append(files, &service.FilesItem{Path: DiffFileName, Type: service.FilesItem_WANDB})
-	}
-
-	if output, err := git.LatestCommit("@{u}"); err != nil {
-		h.logger.Error("error getting latest commit", "error", err)
-	} else {
-		diffFileName := fmt.Sprintf("diff_%s.patch", output)
-		file = filepath.Join(filesDirPath, diffFileName)
-		if err := git.SavePatch("@{u}", file); err != nil {
-			h.logger.Error("error generating diff", "error", err)
-		} else {
-			files = append(files, &service.FilesItem{Path: diffFileName, Type: service.FilesItem_WANDB})
-		}
-	}
-
-	if len(files) == 0 {
-		return
-	}
-
-	record := &service.Record{
-		RecordType: &service.Record_Files{
-			Files: &service.FilesRecord{
-				Files: files,
+func (h *Handler) handleRequestAttach(record *service.Record) {
+	response := &service.Response{
+		ResponseType: &service.Response_AttachResponse{
+			AttachResponse: &service.AttachResponse{
+				Run:         h.runRecord,
+				Provisional: h.isRunProvisional(),
 			},
 		},
 	}
-	h.handleFiles(record)
+	h.respond(record, response)
 }
 
-func (h *Handler) handleMetadata(request *service.MetadataRequest) {
-	// TODO: Sending metadata as a request for now, eventually this should be turned into
-	//  a record and stored in the transaction log
-	if h.settings.GetXDisableMeta().GetValue() {
-		return
-	}
-
-	mo := protojson.MarshalOptions{
-		Indent: "  ",
-		// EmitUnpopulated: true,
-	}
-	jsonBytes, err := mo.Marshal(request)
-	if err != nil {
-		h.logger.CaptureError("error marshalling metadata", err)
-		return
-	}
-	filePath := filepath.Join(h.settings.GetFilesDir().GetValue(), MetaFileName)
-	if err := os.WriteFile(filePath, jsonBytes, 0644); err != nil {
-		h.logger.CaptureError("error writing metadata file", err)
-		return
+// handleRequestRunUpdated updates the run to match what the server has,
+// after the sender creates or updates it.
+func (h *Handler) handleRequestRunUpdated(request *service.RunUpdatedRequest) {
+	h.serverRun = request.GetRun()
+	if h.runRecord != nil {
+		applyServerRun(h.runRecord, h.serverRun)
 	}
-
-	record := &service.Record{
-		RecordType: &service.Record_Files{
-			Files: &service.FilesRecord{
-				Files: []*service.FilesItem{
-					{
-						Path: MetaFileName,
-						Type: service.FilesItem_WANDB,
-					},
-				},
-			},
-		},
-	}
-
-	h.handleFiles(record)
 }
 
-func (h *Handler) handleRequestAttach(record *service.Record) {
-	response := &service.Response{
-		ResponseType: &service.Response_AttachResponse{
-			AttachResponse: &service.AttachResponse{
-				Run: h.runRecord,
-			},
-		},
+// isRunProvisional returns whether the run may still change once the
+// server creates it.
+//
+// Offline runs are never created on the server, so they're as the
+// client describes them.
+func (h *Handler) isRunProvisional() bool {
+	return h.serverRun == nil && !h.settings.GetXOffline().GetValue()
+}
+
+// applyServerRun overwrites the fields of the run that the server decides.
+func applyServerRun(run *service.RunRecord, server *service.RunRecord) {
+	run.StorageId = server.GetStorageId()
+	run.Entity = server.GetEntity()
+	run.Project = server.GetProject()
+	run.DisplayName = server.GetDisplayName()
+	run.SweepId = server.GetSweepId()
+	run.RunGroup = server.GetRunGroup()
+	run.JobType = server.GetJobType()
+	run.Host = server.GetHost()
+	run.StartingStep = server.GetStartingStep()
+	run.Runtime = server.GetRuntime()
+	run.Resumed = server.GetResumed()
+	if server.GetStartTime() != nil {
+		run.StartTime = server.GetStartTime()
 	}
-	h.respond(record, response)
 }
 
 func (h *Handler) handleRequestCancel(request *service.CancelRequest) {
@@ -821,19 +1166,92 @@ func (h *Handler) handleRequestPause() {
 
 func (h *Handler) handleRequestResume() {
 	h.runTimer.Resume()
-	h.systemMonitor.Do()
+	if h.runStarted {
+		h.systemMonitor.Do()
+	}
 }
 
 func (h *Handler) handleSystemMetrics(record *service.Record) {
+	if h.label != "" {
+		labelStats(record.GetStats(), h.label)
+	}
 	h.fwdRecord(record)
 }
 
 func (h *Handler) handleOutput(record *service.Record) {
-	h.fwdRecord(record)
+	if !h.consoleCapture.keepsOutput(record.GetOutput()) {
+		return
+	}
+	if h.label != "" {
+		output := record.GetOutput()
+		output.Line = labelLine(output.Line, h.label)
+	}
+	h.sampleOutput(record)
+}
+
+// handleTableRows appends rows to a table, which is written and uploaded
+// when the run is flushed.
+func (h *Handler) handleTableRows(rows *service.TableRowsRecord) {
+	conflicts, err := h.tables.Append(rows)
+	if err != nil {
+		h.logger.Error("handler: failed to append table rows", "error", err)
+		h.terminalPrinter.Emit(observability.UserMessage{
+			Level: observability.MessageWarning,
+			Key:   "table-rows-rejected:" + rows.GetKey(),
+			Text: fmt.Sprintf(
+				"Rows of table %q weren't logged: %v",
+				rows.GetKey(),
+				err,
+			),
+		})
+		return
+	}
+
+	for _, conflict := range conflicts {
+		h.terminalPrinter.Emit(observability.UserMessage{
+			Level: observability.MessageWarning,
+			Key:   "table-type-conflict:" + conflict.Table + ":" + conflict.Column,
+			Text:  conflict.String(),
+		})
+	}
+}
+
+// flushTables writes the tables that changed and uploads them.
+//
+// Unchanged tables aren't uploaded again.
+func (h *Handler) flushTables() {
+	paths, err := h.tables.Flush()
+	if err != nil {
+		h.logger.CaptureError("handler: failed to write tables", err)
+	}
+	if len(paths) == 0 {
+		return
+	}
+
+	files := make([]*service.FilesItem, len(paths))
+	for i, path := range paths {
+		files[i] = &service.FilesItem{
+			Path:   path,
+			Type:   service.FilesItem_MEDIA,
+			Policy: service.FilesItem_NOW,
+		}
+	}
+	h.fwdRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{Files: files},
+		},
+	})
 }
 
 func (h *Handler) handleOutputRaw(record *service.Record) {
-	h.fwdRecord(record)
+	if !h.consoleCapture.keepsOutputRaw(record.GetOutputRaw()) {
+		return
+	}
+	if h.label != "" {
+		outputRaw := record.GetOutputRaw()
+		outputRaw.Line = labelLine(outputRaw.Line, h.label)
+	}
+	h.sampleOutput(record)
 }
 
 func (h *Handler) handlePreempting(record *service.Record) {
@@ -841,6 +1259,11 @@ func (h *Handler) handlePreempting(record *service.Record) {
 }
 
 func (h *Handler) handleRun(record *service.Record) {
+	h.runLabels.stampRun(record.GetRun())
+	h.trackConfig(record.GetRun().GetConfig())
+	h.storeSweepID(record.GetRun())
+	h.storeRedactedSettings(record.GetRun())
+	h.storeConfigFilters(record.GetRun())
 	h.fwdRecordWithControl(record,
 		func(control *service.Control) {
 			control.AlwaysSend = true
@@ -848,23 +1271,89 @@ func (h *Handler) handleRun(record *service.Record) {
 	)
 }
 
+// storeSweepID adds the sweep from the settings to a run record that is
+// about to be written to the transaction log, so that an offline sweep run
+// is synced into its sweep.
+func (h *Handler) storeSweepID(run *service.RunRecord) {
+	s := settings.From(h.settings)
+	sweepID := h.settings.GetSweepId().GetValue()
+	if run == nil || run.SweepId != "" || sweepID == "" || s.IsSync() {
+		return
+	}
+	run.SweepId = sweepID
+}
+
+// storeRedactedSettings adds the settings, without secrets, to a run record
+// that is about to be written to the transaction log, if enabled.
+func (h *Handler) storeRedactedSettings(run *service.RunRecord) {
+	s := settings.From(h.settings)
+	if run == nil || run.Settings != nil ||
+		!s.StoresRedactedSettings() || s.IsSync() {
+		return
+	}
+
+	settingsRecord, err := s.RedactedRecord()
+	if err != nil {
+		h.logger.CaptureError("handler: failed to redact settings", err)
+		return
+	}
+	run.Settings = settingsRecord
+}
+
+// storeConfigFilters adds the config key filters to a run record that is
+// about to be written to the transaction log, unless the redacted settings
+// already include them.
+func (h *Handler) storeConfigFilters(run *service.RunRecord) {
+	s := settings.From(h.settings)
+	if run == nil || run.Settings != nil || s.IsSync() {
+		return
+	}
+
+	settingsRecord, err := s.ConfigFilterRecord()
+	if err != nil {
+		h.logger.CaptureError("handler: failed to store config filters", err)
+		return
+	}
+	if settingsRecord != nil {
+		run.Settings = settingsRecord
+	}
+}
+
 func (h *Handler) handleConfig(record *service.Record) {
+	h.runLabels.stampConfig(record.GetConfig(), false)
+	h.trackConfig(record.GetConfig())
 	h.fwdRecord(record)
 }
 
+// trackConfig applies a config update to the config kept for
+// checkpoints, if they're made.
+func (h *Handler) trackConfig(config *service.ConfigRecord) {
+	if h.runConfig == nil || config == nil {
+		return
+	}
+	h.runConfig.ApplyChangeRecord(config, func(err error) {
+		h.logger.CaptureError("handler: error tracking config", err)
+	})
+}
+
 func (h *Handler) handleAlert(record *service.Record) {
 	h.fwdRecord(record)
 }
 
 func (h *Handler) handleExit(record *service.Record, exit *service.RunExitRecord) {
+	h.exiting = true
+	h.exitGrace.exited()
+	h.releaseBackpressure()
+	h.deferProgress.Requested()
+
 	// stop the run timer and set the runtime
 	h.runTimer.Pause()
 	exit.Runtime = int32(h.runTimer.Elapsed().Seconds())
 
-	if !h.settings.GetXSync().GetValue() {
+	if !h.settings.GetXSync().GetValue() && !h.aborted {
 		summaryRecord := &service.Record{
 			RecordType: &service.Record_Summary{
-				Summary: &service.SummaryRecord{},
+				Summary: &service.SummaryRecord{Derived: true},
 			},
 		}
 		h.handleSummary(summaryRecord, summaryRecord.GetSummary())
@@ -886,6 +1375,7 @@ func (h *Handler) handleFiles(record *service.Record) {
 	if record.GetFiles() == nil {
 		return
 	}
+	h.runOutputs.FilesSaved(record.GetFiles())
 	h.fwdRecord(record)
 }
 
@@ -971,6 +1461,29 @@ func (h *Handler) handleRequestJobInput(record *service.Record) {
 	h.fwdRecord(record)
 }
 
+func (h *Handler) handleRequestCredentialsUpdate(record *service.Record) {
+	h.fwdRecord(record)
+}
+
+// handleRequestSettingsUpdate applies the settings that the handler uses,
+// and passes the update on to the sender.
+//
+// The console streams to keep change for the output received after it.
+func (h *Handler) handleRequestSettingsUpdate(record *service.Record) {
+	if value := record.GetRequest().GetSettingsUpdate().GetConsoleCapture(); value != nil {
+		capture, err := parseConsoleCapture(value.GetValue())
+		if err != nil {
+			h.logger.Warn("handler: handleRequestSettingsUpdate: ignoring console capture",
+				"error", err)
+		} else {
+			h.consoleCapture = capture
+			h.logger.Info("handler: handleRequestSettingsUpdate: updated the console capture",
+				"capture", value.GetValue())
+		}
+	}
+	h.fwdRecord(record)
+}
+
 func (h *Handler) handleSummary(record *service.Record, summary *service.SummaryRecord) {
 	if !h.settings.GetXSync().GetValue() {
 		// if sync is enabled, we don't need to do all this
@@ -983,12 +1496,26 @@ func (h *Handler) handleSummary(record *service.Record, summary *service.Summary
 		})
 	}
 
-	h.runSummary.ApplyChangeRecord(
+	h.boundSummary(summary)
+	replaced := h.runSummary.ApplyChangeRecord(
 		summary,
 		func(err error) {
 			h.logger.CaptureError("Error updating run summary", err)
 		},
 	)
+	h.noteSummaryKeys()
+	if len(replaced) > 0 {
+		h.logger.Warn(
+			"handler: summary values replaced by values of another kind",
+			"keys", replaced,
+		)
+		h.terminalPrinter.
+			AtMostEvery(time.Minute).
+			Write(fmt.Sprintf(
+				"Replaced summary values that conflicted with nested keys: %s",
+				strings.Join(replaced, ", "),
+			))
+	}
 
 	h.fwdRecordWithControl(record,
 		func(control *service.Control) {
@@ -1025,6 +1552,7 @@ func (h *Handler) handleHistory(history *service.HistoryRecord) {
 	if len(history.GetItem()) == 0 {
 		return
 	}
+	h.latency.entered(history)
 
 	runtime := h.runTimer.Elapsed().Seconds()
 	history.Item = append(history.Item, &service.HistoryItem{
@@ -1037,38 +1565,66 @@ func (h *Handler) handleHistory(history *service.HistoryRecord) {
 			ValueJson: fmt.Sprintf("%d", history.GetStep().GetNum()),
 		})
 	}
+	h.correctTimestamp(history)
 
 	// handles all history items. It is responsible for matching current history
 	// items with defined metrics, and creating new metrics if needed. It also handles step metric in case
 	// it needs to be synced, but not part of the history record.
 	// This means that there are metrics defined for this run
 	if h.metricHandler != nil {
-		items := make([]*service.HistoryItem, 0, len(history.GetItem()))
-		for _, item := range history.GetItem() {
-			// TODO: handle nested metric metrics (e.g. metric defined by another metric)
-			if metric := h.imputeStepMetric(item); metric != nil {
-				items = append(items, metric)
-			}
+		history.Item = append(history.Item, h.imputeStepMetrics(history.GetItem())...)
+		h.metricHandler.updateStepValues(history.GetItem())
+	}
+
+	for _, item := range history.GetItem() {
+		if err := histogram.BinSequence(item); err != nil {
+			h.logger.CaptureError("handler: failed to bin histogram", err)
 		}
-		history.Item = append(history.Item, items...)
 	}
 
-	h.sampleHistory(history)
+	if truncated := runhistory.TruncateStrings(
+		history.GetItem(),
+		maxHistoryStringBytes,
+	); len(truncated) > 0 {
+		h.logger.Warn(
+			"handler: truncated long strings in history",
+			"keys", truncated,
+			"max", maxHistoryStringBytes,
+		)
+		h.terminalPrinter.
+			AtMostEvery(time.Minute).
+			Write("Truncated run.log() strings that exceeded the size limit.")
+	}
+
+	// all values are sent, but only those of tracked keys are aggregated
+	tracked := h.trackedHistoryItems(history.GetItem())
+	h.sampleHistory(history.GetStep().GetNum(), tracked)
+	h.runOutputs.HistoryLogged(history)
 
 	record := &service.Record{
 		RecordType: &service.Record_History{
 			History: history,
 		},
 	}
-	h.fwdRecord(record)
+	if !h.holdHistory(record) {
+		h.fwdHistory(record)
+	}
 
 	// TODO add an option to disable summary (this could be quite expensive)
 	if h.runSummary == nil {
 		return
 	}
 
-	summary := make([]*service.SummaryItem, 0, len(history.GetItem()))
-	for _, item := range history.GetItem() {
+	summary := make([]*service.SummaryItem, 0, len(tracked))
+	for _, item := range tracked {
+		if h.explicitSummary.Has(item) {
+			continue
+		}
+		if updates, ok := h.summarizeMetric(item, history.GetStep().GetNum()); ok {
+			summary = append(summary, updates...)
+			continue
+		}
+
 		summaryItem := &service.SummaryItem{
 			Key:       item.Key,
 			NestedKey: item.NestedKey,
@@ -1076,14 +1632,18 @@ func (h *Handler) handleHistory(history *service.HistoryRecord) {
 		}
 		summary = append(summary, summaryItem)
 	}
+	h.explicitSummary.Flushed()
 
 	record = &service.Record{
 		RecordType: &service.Record_Summary{
 			Summary: &service.SummaryRecord{
-				Update: summary,
+				Update:  summary,
+				Derived: true,
 			},
 		},
 	}
+	// dotted keys nest in the summary whether they're logged or set
+	runsummary.ExpandDottedKeys(record.GetSummary())
 	h.handleSummary(record, record.GetSummary())
 }
 
@@ -1099,11 +1659,14 @@ func (h *Handler) handleRequestNetworkStatus(record *service.Record) {
 // determined by the action in the partial history request and the step number.
 // Once a full history record is received, it is forwarded to the writer.
 func (h *Handler) handleRequestPartialHistory(_ *service.Record, request *service.PartialHistoryRequest) {
+	request.Item = h.normalizeHistoryItems(request.GetItem())
+	h.flushLateHistory(request)
 	if h.settings.GetXShared().GetValue() {
 		h.handlePartialHistoryAsync(request)
 	} else {
 		h.handlePartialHistorySync(request)
 	}
+	h.notePartialHistoryKeys()
 }
 
 // The main entry point for partial history records in the shared mode.
@@ -1117,10 +1680,12 @@ func (h *Handler) handlePartialHistoryAsync(request *service.PartialHistoryReque
 		h.runHistory = runhistory.New()
 	}
 	// Append the history items from the request to the current history record.
-	h.runHistory.ApplyChangeRecord(request.GetItem(),
+	items := h.boundPartialHistory(request.GetItem())
+	h.runHistory.ApplyChangeRecord(items,
 		func(err error) {
 			h.logger.CaptureError("Error updating run history", err)
 		})
+	h.explicitSummary.Logged(items)
 
 	// Flush the history record and start to collect a new one
 	if request.GetAction() == nil || request.GetAction().GetFlush() {
@@ -1217,10 +1782,12 @@ func (h *Handler) handlePartialHistorySync(request *service.PartialHistoryReques
 	}
 
 	// Append the history items from the request to the current history record.
-	h.runHistory.ApplyChangeRecord(request.GetItem(),
+	items := h.boundPartialHistory(request.GetItem())
+	h.runHistory.ApplyChangeRecord(items,
 		func(err error) {
 			h.logger.CaptureError("Error updating run history", err)
 		})
+	h.explicitSummary.Logged(items)
 
 	// Flush the history record and start to collect a new one with
 	// the next step number.
@@ -1276,108 +1843,160 @@ func (h *Handler) matchHistoryItemMetric(item *service.HistoryItem) *service.Met
 	return metric
 }
 
-// sync history item with step metric if needed
-//
-// This function checks if a history item matches a defined metric or a glob
-// metric. If the step metric is not part of the history record, and it needs to
-// be synced, the function imputes the step metric and returns it.
-func (h *Handler) imputeStepMetric(item *service.HistoryItem) *service.HistoryItem {
+// summarizeMetric returns the summary updates for a history item of a
+// defined metric that says how to summarize it, and whether it says so.
+func (h *Handler) summarizeMetric(
+	item *service.HistoryItem,
+	step int64,
+) ([]*service.SummaryItem, bool) {
+	if h.metricHandler == nil || len(item.GetNestedKey()) > 0 {
+		return nil, false
+	}
 
-	// check if history item matches a defined metric or a glob metric
 	metric := h.matchHistoryItemMetric(item)
-
-	key := metric.GetStepMetric()
-	// check if step metric is defined and if it needs to be synced
-	if !(metric.GetOptions().GetStepSync() && key != "") {
-		return nil
+	if metric == nil {
+		return nil, false
 	}
+	return h.metricHandler.summarizeMetric(metric, item, step)
+}
 
-	// check if step metric is already in history
-	// TODO: avoid using the Tree method
-	if _, ok := h.runHistory.Tree()[key]; ok {
-		return nil
-	}
+// historySample is a sampled value of a history metric.
+type historySample struct {
+	step  int64
+	value float32
+}
 
-	// TODO: avoid using the tree representation of the summary
-	// TODO: avoid using json marshalling
-	// we use the summary value of the metric as the algorithm for imputing the step metric
-	if value, ok := h.runSummary.Tree()[key]; ok {
-		v, err := json.Marshal(value)
-		if err != nil {
-			h.logger.CaptureError("error marshalling step metric value", err)
-			return nil
+// handleRequestSampledHistory responds with samples of the history
+// metrics, for displaying sparklines in the terminal and for quick access
+// to the run's history from the client.
+//
+// The metrics may be filtered by key, and the samples of each metric
+// evenly thinned to at most the requested number. Metrics whose values
+// aren't numbers have no samples and are marked as such.
+func (h *Handler) handleRequestSampledHistory(record *service.Record) {
+	request := record.GetRequest().GetSampledHistory()
+
+	keys := slices.Clone(request.GetKeys())
+	if len(keys) == 0 {
+		keys = make([]string, 0, len(h.samplers)+len(h.nonNumericKeys))
+		for key := range h.samplers {
+			keys = append(keys, key)
 		}
-		item := []*service.HistoryItem{
-			{
-				Key:       key,
-				ValueJson: string(v),
-			},
+		for key := range h.nonNumericKeys {
+			keys = append(keys, key)
 		}
-		h.runHistory.ApplyChangeRecord(
-			item,
-			func(err error) {
-				h.logger.CaptureError("Error updating run history", err)
-			},
-		)
-		return item[0]
 	}
-	return nil
-}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
 
-// samples history items and updates the history record with the sampled values
-//
-// This function samples history items and updates the history record with the
-// sampled values. It is used to display a subset of the history items in the
-// terminal. The sampling is done using a reservoir sampling algorithm.
-func (h *Handler) handleRequestSampledHistory(record *service.Record) {
-	response := &service.Response{}
+	items := make([]*service.SampledHistoryItem, 0, len(keys))
+	for _, key := range keys {
+		// keys are answered as requested, but looked up as they're logged
+		logged, _ := normalizeMetricKey(key)
+		if _, ok := h.nonNumericKeys[logged]; ok {
+			items = append(items, &service.SampledHistoryItem{
+				Key:       key,
+				ValueType: service.SampledHistoryItem_NON_NUMERIC,
+			})
+			continue
+		}
 
-	if h.samplers != nil {
-		var items []*service.SampledHistoryItem
-		for key, sampler := range h.samplers {
-			values := sampler.Sample()
-			item := &service.SampledHistoryItem{
-				Key:         key,
-				ValuesFloat: values,
-			}
-			items = append(items, item)
+		sampler, ok := h.samplers[logged]
+		if !ok {
+			continue
+		}
+		samples := thinSamples(sampler.Sample(), int(request.GetSamples()))
+		item := &service.SampledHistoryItem{
+			Key:         key,
+			ValuesFloat: make([]float32, len(samples)),
+			Steps:       make([]int64, len(samples)),
+		}
+		for i, sample := range samples {
+			item.ValuesFloat[i] = sample.value
+			item.Steps[i] = sample.step
 		}
+		items = append(items, item)
+	}
 
-		response.ResponseType = &service.Response_SampledHistoryResponse{
+	h.respondInParts(record, &service.Response{
+		ResponseType: &service.Response_SampledHistoryResponse{
 			SampledHistoryResponse: &service.SampledHistoryResponse{
 				Item: items,
 			},
-		}
+		},
+	})
+}
+
+// thinSamples returns at most n evenly spaced samples, including the first
+// and the last, or all of them if n isn't positive.
+func thinSamples(samples []historySample, n int) []historySample {
+	if n <= 0 || len(samples) <= n {
+		return samples
+	}
+	if n == 1 {
+		return samples[len(samples)-1:]
 	}
 
-	h.respond(record, response)
+	thinned := make([]historySample, n)
+	for i := range n {
+		thinned[i] = samples[i*(len(samples)-1)/(n-1)]
+	}
+	return thinned
 }
 
 // sample history items and update the samplers map before flushing the history
 // record as these values are finalized for the current step
-func (h *Handler) sampleHistory(history *service.HistoryRecord) {
+func (h *Handler) sampleHistory(step int64, items []*service.HistoryItem) {
 	// initialize the samplers map if it doesn't exist
 	if h.samplers == nil {
-		h.samplers = make(map[string]*sampler.ReservoirSampler[float32])
+		h.samplers = make(map[string]*sampler.ReservoirSampler[historySample])
+		h.nonNumericKeys = make(map[string]struct{})
 	}
 
-	for _, item := range history.GetItem() {
-		var value float32
-		if err := json.Unmarshal([]byte(item.ValueJson), &value); err != nil {
-			// ignore items that cannot be parsed as float32
+	for _, item := range items {
+		// nested items, like the fields of a dict, are not sampled
+		if item.GetKey() == "" {
+			continue
+		}
+		h.touchSampledKey(item.Key)
+
+		// ignore items that cannot be parsed as float32, such as strings,
+		// booleans, nulls and histograms
+		var value *float32
+		if err := json.Unmarshal([]byte(item.ValueJson), &value); err != nil ||
+			value == nil {
+			if _, ok := h.samplers[item.Key]; !ok {
+				h.nonNumericKeys[item.Key] = struct{}{}
+			}
 			continue
 		}
 
 		// create a new sampler if it doesn't exist
 		if _, ok := h.samplers[item.Key]; !ok {
-			h.samplers[item.Key] = sampler.NewReservoirSampler[float32](48, 0.0005)
+			h.samplers[item.Key] = h.newSampler()
+			delete(h.nonNumericKeys, item.Key)
 		}
 
 		// add the new value to the sampler
-		h.samplers[item.Key].Add(value)
+		h.samplers[item.Key].Add(historySample{
+			step:  step,
+			value: *value,
+		})
+	}
+	h.boundSampledKeys()
+	h.noteSampledKeys()
+}
+
+func (h *Handler) newSampler() *sampler.ReservoirSampler[historySample] {
+	if h.samplerRandOrNil != nil {
+		return sampler.NewReservoirSamplerWithRand[historySample](
+			48, 0.0005, h.samplerRandOrNil)
 	}
+	return sampler.NewReservoirSampler[historySample](48, 0.0005)
 }
 
-func (h *Handler) GetRun() *service.RunRecord {
-	return h.runRecord
+// GetRun returns the run and whether it's provisional, meaning the server
+// hasn't yet confirmed it and it may still change.
+func (h *Handler) GetRun() (*service.RunRecord, bool) {
+	return h.runRecord, h.isRunProvisional()
 }
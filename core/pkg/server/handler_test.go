@@ -2,8 +2,21 @@ package server_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/runsummary"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/server"
 	"github.com/wandb/wandb/core/pkg/service"
@@ -842,3 +855,628 @@ func TestHandleHistory(t *testing.T) {
 	}
 
 }
+
+func TestHandlePartialHistory_ValueTypes(t *testing.T) {
+	inChan := make(chan *service.Record, server.BufferSize)
+	fwdChan := make(chan *service.Record, server.BufferSize)
+	outChan := make(chan *service.Result, server.BufferSize)
+	makeHandler(inChan, fwdChan, outChan)
+	defer close(inChan)
+	histogram := `{"_type":"histogram","bins":[0,0.5,1],"values":[3,4]}`
+	longString := strings.Repeat("a", 2<<20)
+
+	inChan <- makePartialHistoryRecord(data{
+		items: map[string]string{
+			"loss":      "0.5",
+			"label":     `"cat"`,
+			"done":      "true",
+			"missing":   "null",
+			"nested":    `{"acc":0.9,"split":"val"}`,
+			"weights":   histogram,
+			"long_text": `"` + longString + `"`,
+		},
+		flush: true,
+	})
+	inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_SampledHistory{
+					SampledHistory: &service.SampledHistoryRequest{},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	}
+
+	items := make(map[string]string)
+	for _, item := range (<-fwdChan).GetHistory().GetItem() {
+		key := item.GetKey()
+		if key == "" {
+			key = strings.Join(item.GetNestedKey(), ".")
+		}
+		items[key] = item.GetValueJson()
+	}
+	assert.Equal(t, "0.5", items["loss"])
+	assert.Equal(t, `"cat"`, items["label"])
+	assert.Equal(t, "true", items["done"])
+	assert.Equal(t, "null", items["missing"])
+	assert.Equal(t, "0.9", items["nested.acc"])
+	assert.Equal(t, `"val"`, items["nested.split"])
+	assert.Equal(t, histogram, items["weights"])
+	assert.Len(t, items["long_text"], (1<<20)+2)
+
+	var sampledKeys, nonNumericKeys []string
+	result := <-outChan
+	for _, item := range result.GetResponse().GetSampledHistoryResponse().GetItem() {
+		if item.GetValueType() == service.SampledHistoryItem_NON_NUMERIC {
+			assert.Empty(t, item.GetValuesFloat())
+			nonNumericKeys = append(nonNumericKeys, item.GetKey())
+		} else {
+			sampledKeys = append(sampledKeys, item.GetKey())
+		}
+	}
+	require.NotEmpty(t, sampledKeys)
+	assert.ElementsMatch(t, []string{"loss", "_step", "_runtime"}, sampledKeys)
+	assert.ElementsMatch(t,
+		[]string{"label", "done", "missing", "weights", "long_text"},
+		nonNumericKeys)
+}
+
+func TestHandleHistory_BinsRawHistograms(t *testing.T) {
+	inChan := make(chan *service.Record, server.BufferSize)
+	fwdChan := make(chan *service.Record, server.BufferSize)
+	outChan := make(chan *service.Result, server.BufferSize)
+	makeHandler(inChan, fwdChan, outChan)
+	defer close(inChan)
+
+	inChan <- makeHistoryRecord(data{
+		items: map[string]string{
+			"weights": `{"_type": "histogram", "sequence": [0, 1, 2, 3, 4], "num_bins": 4}`,
+		},
+	})
+
+	items := make(map[string]string)
+	for _, item := range (<-fwdChan).GetHistory().GetItem() {
+		items[item.GetKey()] = item.GetValueJson()
+	}
+	assert.Equal(t,
+		`{"_type":"histogram","bins":[0,1,2,3,4],"values":[1,1,1,2]}`,
+		items["weights"])
+}
+
+func TestHandleRequestGetSummary_AggregatesDefinedMetrics(t *testing.T) {
+	for _, offline := range []bool{false, true} {
+		t.Run(fmt.Sprintf("offline=%v", offline), func(t *testing.T) {
+			inChan := make(chan *service.Record, server.BufferSize)
+			fwdChan := make(chan *service.Record, server.BufferSize)
+			outChan := make(chan *service.Result, server.BufferSize)
+			h := server.NewHandler(context.Background(),
+				&server.HandlerParams{
+					Logger: observability.NewNoOpLogger(),
+					Settings: &service.Settings{
+						XOffline: &wrapperspb.BoolValue{Value: offline},
+					},
+					FwdChan:         fwdChan,
+					OutChan:         outChan,
+					RunSummary:      runsummary.New(),
+					MetricHandler:   server.NewMetricHandler(),
+					TerminalPrinter: observability.NewPrinter(),
+				},
+			)
+			go h.Do(inChan)
+
+			inChan <- &service.Record{
+				RecordType: &service.Record_Metric{
+					Metric: &service.MetricRecord{
+						Name:    "loss",
+						Summary: &service.MetricSummary{Max: true, Mean: true},
+					},
+				},
+			}
+			inChan <- &service.Record{
+				RecordType: &service.Record_Metric{
+					Metric: &service.MetricRecord{
+						Name:    "acc",
+						Summary: &service.MetricSummary{Best: true},
+						Goal:    service.MetricRecord_GOAL_MAXIMIZE,
+					},
+				},
+			}
+			for i, loss := range []string{"0.5", "0.9", "0.1"} {
+				inChan <- makeHistoryRecord(data{
+					items: map[string]string{
+						"loss":  loss,
+						"acc":   fmt.Sprintf("0.%d", i+1),
+						"epoch": fmt.Sprint(i),
+					},
+					step: int64(i),
+				})
+			}
+			inChan <- &service.Record{
+				RecordType: &service.Record_Request{
+					Request: &service.Request{
+						RequestType: &service.Request_GetSummary{
+							GetSummary: &service.GetSummaryRequest{},
+						},
+					},
+				},
+				Control: &service.Control{MailboxSlot: "summary"},
+			}
+
+			result := <-outChan
+			summary := make(map[string]string)
+			for _, item := range result.GetResponse().GetGetSummaryResponse().GetItem() {
+				key := item.GetKey()
+				if len(item.GetNestedKey()) > 0 {
+					key = strings.Join(item.GetNestedKey(), ".")
+				}
+				summary[key] = item.GetValueJson()
+			}
+			assert.Equal(t, "summary", result.GetControl().GetMailboxSlot())
+			assert.Equal(t, "0.9", summary["loss.max"])
+			assert.Equal(t, "0.5", summary["loss.mean"])
+			assert.NotContains(t, summary, "loss")
+			assert.Equal(t, "0.3", summary["acc.best"])
+			assert.Equal(t, "2", summary["epoch"])
+			assert.Contains(t, summary, "_runtime")
+			assert.Contains(t, summary, "_wandb.runtime")
+		})
+	}
+}
+
+func sampledHistoryRequest(request *service.SampledHistoryRequest) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_SampledHistory{
+					SampledHistory: request,
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	}
+}
+
+func TestHandleRequestSampledHistory_LongRun(t *testing.T) {
+	const steps = 100_000
+	inChan := make(chan *service.Record, server.BufferSize)
+	fwdChan := make(chan *service.Record, server.BufferSize)
+	outChan := make(chan *service.Result, server.BufferSize)
+	makeHandler(inChan, fwdChan, outChan)
+	defer close(inChan)
+	go func() {
+		for range fwdChan {
+		}
+	}()
+
+	for step := range steps {
+		inChan <- makeHistoryRecord(data{
+			items: map[string]string{
+				"loss":  fmt.Sprint(step),
+				"label": `"cat"`,
+			},
+			step: int64(step),
+		})
+	}
+	inChan <- sampledHistoryRequest(&service.SampledHistoryRequest{})
+	inChan <- sampledHistoryRequest(&service.SampledHistoryRequest{})
+	inChan <- sampledHistoryRequest(&service.SampledHistoryRequest{
+		Keys:    []string{"loss", "label", "unknown"},
+		Samples: 10,
+	})
+	all := (<-outChan).GetResponse().GetSampledHistoryResponse()
+	again := (<-outChan).GetResponse().GetSampledHistoryResponse()
+	filtered := (<-outChan).GetResponse().GetSampledHistoryResponse()
+
+	var loss *service.SampledHistoryItem
+	for _, item := range all.GetItem() {
+		if item.GetKey() == "loss" {
+			loss = item
+		}
+	}
+	require.NotNil(t, loss)
+	assert.LessOrEqual(t, len(loss.GetValuesFloat()), 48)
+	assert.Greater(t, len(loss.GetValuesFloat()), 40)
+	require.Len(t, loss.GetSteps(), len(loss.GetValuesFloat()))
+	for i, step := range loss.GetSteps() {
+		assert.Equal(t, float32(step), loss.GetValuesFloat()[i])
+		if i > 0 {
+			assert.Greater(t, step, loss.GetSteps()[i-1])
+		}
+	}
+	assert.Equal(t, all.GetItem(), again.GetItem())
+
+	require.Len(t, filtered.GetItem(), 2)
+	assert.Equal(t, "label", filtered.GetItem()[0].GetKey())
+	assert.Equal(t,
+		service.SampledHistoryItem_NON_NUMERIC,
+		filtered.GetItem()[0].GetValueType())
+	filteredLoss := filtered.GetItem()[1]
+	assert.Equal(t, "loss", filteredLoss.GetKey())
+	assert.Len(t, filteredLoss.GetValuesFloat(), 10)
+	assert.Equal(t, loss.GetSteps()[0], filteredLoss.GetSteps()[0])
+	assert.Equal(t,
+		loss.GetSteps()[len(loss.GetSteps())-1],
+		filteredLoss.GetSteps()[9])
+}
+
+func TestHandleRun_StoresSweepFromSettings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		run      *service.RunRecord
+		settings *service.Settings
+		expected string
+	}{
+		{
+			name: "from settings",
+			run:  &service.RunRecord{RunId: "run"},
+			settings: &service.Settings{
+				SweepId: &wrapperspb.StringValue{Value: "sweep1"},
+			},
+			expected: "sweep1",
+		},
+		{
+			name: "set by client",
+			run:  &service.RunRecord{RunId: "run", SweepId: "sweep2"},
+			settings: &service.Settings{
+				SweepId: &wrapperspb.StringValue{Value: "sweep1"},
+			},
+			expected: "sweep2",
+		},
+		{
+			name: "syncing",
+			run:  &service.RunRecord{RunId: "run"},
+			settings: &service.Settings{
+				SweepId: &wrapperspb.StringValue{Value: "sweep1"},
+				XSync:   &wrapperspb.BoolValue{Value: true},
+			},
+			expected: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			inChan := make(chan *service.Record, 1)
+			fwdChan := make(chan *service.Record, 1)
+			h := server.NewHandler(context.Background(),
+				&server.HandlerParams{
+					Logger:          observability.NewNoOpLogger(),
+					Settings:        tc.settings,
+					FwdChan:         fwdChan,
+					OutChan:         make(chan *service.Result, 1),
+					TerminalPrinter: observability.NewPrinter(),
+				},
+			)
+			go h.Do(inChan)
+
+			inChan <- &service.Record{RecordType: &service.Record_Run{Run: tc.run}}
+			forwarded := <-fwdChan
+			close(inChan)
+
+			assert.Equal(t, tc.expected, forwarded.GetRun().GetSweepId())
+		})
+	}
+}
+
+// stepMetricRows passes the records through a handler that keeps metrics,
+// and returns the user keys of the history rows it forwards.
+func stepMetricRows(t *testing.T, records ...*service.Record) []map[string]string {
+	inChan := make(chan *service.Record, server.BufferSize)
+	fwdChan := make(chan *service.Record, server.BufferSize)
+	outChan := make(chan *service.Result, server.BufferSize)
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        &service.Settings{},
+			FwdChan:         fwdChan,
+			OutChan:         outChan,
+			RunSummary:      runsummary.New(),
+			MetricHandler:   server.NewMetricHandler(),
+			TerminalPrinter: observability.NewPrinter(),
+		},
+	)
+	for _, record := range records {
+		inChan <- record
+	}
+	close(inChan)
+	h.Do(inChan)
+
+	var rows []map[string]string
+	for record := range fwdChan {
+		if record.GetHistory() == nil {
+			continue
+		}
+		row := make(map[string]string)
+		for _, item := range record.GetHistory().GetItem() {
+			if !strings.HasPrefix(item.Key, "_") {
+				row[item.Key] = item.ValueJson
+			}
+		}
+		rows = append(rows, row)
+	}
+	require.NotEmpty(t, rows)
+	return rows
+}
+
+func defineStepMetric(name, stepMetric string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Metric{
+			Metric: &service.MetricRecord{
+				Name:       name,
+				StepMetric: stepMetric,
+				Options:    &service.MetricOptions{StepSync: true},
+			},
+		},
+	}
+}
+
+func TestHandleHistory_ImputesStepMetricChains(t *testing.T) {
+	rows := stepMetricRows(t,
+		defineStepMetric("epoch", "global_step"),
+		defineStepMetric("loss", "epoch"),
+		makeHistoryRecord(data{items: map[string]string{"global_step": "10"}, step: 0}),
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.5"}, step: 1}),
+		makeHistoryRecord(data{items: map[string]string{"epoch": "1"}, step: 2}),
+		makeHistoryRecord(data{items: map[string]string{"global_step": "20"}, step: 3}),
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.4"}, step: 4}),
+	)
+
+	assert.Equal(t,
+		[]map[string]string{
+			{"global_step": "10"},
+			// epoch wasn't logged yet, so it's left out
+			{"loss": "0.5"},
+			{"epoch": "1", "global_step": "10"},
+			{"global_step": "20"},
+			{"loss": "0.4", "epoch": "1", "global_step": "20"},
+		},
+		rows)
+}
+
+func TestHandleHistory_StepMetricDefinedLater(t *testing.T) {
+	rows := stepMetricRows(t,
+		makeHistoryRecord(data{items: map[string]string{"epoch": "3"}, step: 0}),
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.9"}, step: 1}),
+		defineStepMetric("loss", "epoch"),
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.8"}, step: 2}),
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.7", "epoch": "4"}, step: 3}),
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.6"}, step: 4}),
+	)
+
+	assert.Equal(t,
+		[]map[string]string{
+			{"epoch": "3"},
+			{"loss": "0.9"},
+			{"loss": "0.8", "epoch": "3"},
+			{"loss": "0.7", "epoch": "4"},
+			{"loss": "0.6", "epoch": "4"},
+		},
+		rows)
+}
+
+func TestHandleHistory_StepMetricCycle(t *testing.T) {
+	rows := stepMetricRows(t,
+		defineStepMetric("a", "b"),
+		defineStepMetric("b", "a"),
+		makeHistoryRecord(data{items: map[string]string{"a": "1"}, step: 0}),
+		makeHistoryRecord(data{items: map[string]string{"b": "2"}, step: 1}),
+	)
+
+	assert.Equal(t,
+		[]map[string]string{
+			{"a": "1"},
+			{"b": "2", "a": "1"},
+		},
+		rows)
+}
+
+// bestSummary logs the values of "loss" at steps 0, 1, ... through a handler
+// after each of the metric definitions at the step it's keyed by, and
+// returns the loss's summary.
+func bestSummary(
+	t *testing.T,
+	definitions map[int]*service.MetricRecord,
+	values ...string,
+) map[string]string {
+	inChan := make(chan *service.Record, server.BufferSize)
+	fwdChan := make(chan *service.Record, server.BufferSize)
+	outChan := make(chan *service.Result, server.BufferSize)
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        &service.Settings{},
+			FwdChan:         fwdChan,
+			OutChan:         outChan,
+			RunSummary:      runsummary.New(),
+			MetricHandler:   server.NewMetricHandler(),
+			TerminalPrinter: observability.NewPrinter(),
+		},
+	)
+	go h.Do(inChan)
+	defer close(inChan)
+
+	for i, value := range values {
+		if metric, ok := definitions[i]; ok {
+			inChan <- &service.Record{
+				RecordType: &service.Record_Metric{Metric: metric},
+			}
+		}
+		inChan <- makeHistoryRecord(data{
+			items: map[string]string{"loss": value},
+			step:  int64(i),
+		})
+	}
+	inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_GetSummary{
+					GetSummary: &service.GetSummaryRequest{},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "summary"},
+	}
+
+	result := <-outChan
+	summary := make(map[string]string)
+	for _, item := range result.GetResponse().GetGetSummaryResponse().GetItem() {
+		if len(item.GetNestedKey()) == 2 && item.GetNestedKey()[0] == "loss" {
+			summary[item.GetNestedKey()[1]] = item.GetValueJson()
+		}
+	}
+	require.NotEmpty(t, summary)
+	return summary
+}
+
+func bestMetric(goal service.MetricRecord_MetricGoal) *service.MetricRecord {
+	return &service.MetricRecord{
+		Name:    "loss",
+		Summary: &service.MetricSummary{Best: true},
+		Goal:    goal,
+	}
+}
+
+func TestSummarizeMetric_BestMinimize(t *testing.T) {
+	summary := bestSummary(t,
+		map[int]*service.MetricRecord{
+			0: bestMetric(service.MetricRecord_GOAL_MINIMIZE),
+		},
+		"0.9", "0.7", "0.8", "0.3", "0.35", "0.3", "0.6",
+	)
+
+	assert.Equal(t, "0.3", summary["best"])
+	assert.Equal(t, "3", summary["best_step"])
+}
+
+func TestSummarizeMetric_BestMaximize(t *testing.T) {
+	summary := bestSummary(t,
+		map[int]*service.MetricRecord{
+			0: bestMetric(service.MetricRecord_GOAL_MAXIMIZE),
+		},
+		"0.1", "0.5", "0.4", "0.9", "0.2", "0.9", "\"nan\"",
+	)
+
+	assert.Equal(t, "0.9", summary["best"])
+	assert.Equal(t, "3", summary["best_step"])
+}
+
+func TestSummarizeMetric_BestDefaultsToMaximize(t *testing.T) {
+	summary := bestSummary(t,
+		map[int]*service.MetricRecord{
+			0: bestMetric(service.MetricRecord_GOAL_UNSET),
+		},
+		"0.2", "0.6", "0.1",
+	)
+
+	assert.Equal(t, "0.6", summary["best"])
+	assert.Equal(t, "1", summary["best_step"])
+}
+
+func TestSummarizeMetric_GoalChangeRestartsBest(t *testing.T) {
+	summary := bestSummary(t,
+		map[int]*service.MetricRecord{
+			0: bestMetric(service.MetricRecord_GOAL_MAXIMIZE),
+			3: bestMetric(service.MetricRecord_GOAL_MINIMIZE),
+		},
+		"0.1", "0.9", "0.5", "0.7", "0.4", "0.6",
+	)
+
+	// The minimum since the goal changed, not the 0.1 before
+	assert.Equal(t, "0.4", summary["best"])
+	assert.Equal(t, "4", summary["best_step"])
+}
+
+func TestHandleHistory_CorrectsClockSkew(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Date",
+				time.Now().Add(-2*time.Hour).UTC().Format(http.TimeFormat))
+		}))
+	defer apiServer.Close()
+	baseURL, err := url.Parse(apiServer.URL)
+	require.NoError(t, err)
+	backend := api.New(api.BackendOptions{
+		BaseURL:            baseURL,
+		ClockSkewThreshold: time.Minute,
+	})
+	_, err = backend.NewClient(api.ClientOptions{}).
+		Send(&api.Request{Method: http.MethodGet, Path: "viewer"})
+	require.NoError(t, err)
+
+	inChan := make(chan *service.Record, server.BufferSize)
+	fwdChan := make(chan *service.Record, server.BufferSize)
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        &service.Settings{},
+			FwdChan:         fwdChan,
+			OutChan:         make(chan *service.Result, server.BufferSize),
+			TerminalPrinter: observability.NewPrinter(),
+			ClockSkew:       backend.ClockSkew(),
+		},
+	)
+	go h.Do(inChan)
+	defer close(inChan)
+
+	inChan <- makeHistoryRecord(data{
+		items: map[string]string{"_timestamp": "1700007200.5", "loss": "1"},
+	})
+	row := makeOutput(<-fwdChan).items
+
+	timestamp, err := strconv.ParseFloat(row["_timestamp"], 64)
+	require.NoError(t, err)
+	assert.InDelta(t, 1700000000.5, timestamp, 2)
+	runtime, err := strconv.ParseFloat(row["_runtime"], 64)
+	require.NoError(t, err)
+	assert.Less(t, runtime, 60.0)
+}
+
+// A resumed run's runtime includes the time it ran before it was resumed.
+func TestHandleExit_RuntimeIncludesResumedRuntime(t *testing.T) {
+	clock := &settableClock{now: time.Unix(1000, 0)}
+	inChan := make(chan *service.Record, 10)
+	fwdChan := make(chan *service.Record, 100)
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger: observability.NewNoOpLogger(),
+			Settings: &service.Settings{
+				XDisableMeta: &wrapperspb.BoolValue{Value: true},
+			},
+			FwdChan:         fwdChan,
+			OutChan:         make(chan *service.Result, 10),
+			TerminalPrinter: observability.NewPrinter(),
+			RunSummary:      runsummary.New(),
+			Clock:           clock,
+		},
+	)
+	go h.Do(inChan)
+
+	inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_RunStart{
+					RunStart: &service.RunStartRequest{
+						Run: &service.RunRecord{
+							RunId:   "run",
+							Resumed: true,
+							Runtime: 100,
+						},
+					},
+				},
+			},
+		},
+	}
+	require.Eventually(t,
+		func() bool { return len(fwdChan) > 0 },
+		time.Second, time.Millisecond)
+	clock.advance(5 * time.Second)
+	inChan <- &service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+	}
+	close(inChan)
+
+	for record := range fwdChan {
+		if exit := record.GetExit(); exit != nil {
+			assert.EqualValues(t, 105, exit.GetRuntime())
+			return
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package server
+
+import (
+	"container/list"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// HandlerMemoryLimits are the bounds on the state that the handler keeps
+// about the run, each 0 for no limit.
+type HandlerMemoryLimits struct {
+	// MaxSampledKeys is the most history metrics to keep samples of.
+	MaxSampledKeys int
+
+	// MaxSummaryKeys is the most top-level summary keys.
+	MaxSummaryKeys int
+
+	// MaxPartialHistoryKeys is the most top-level keys in a history row
+	// built from partial history.
+	MaxPartialHistoryKeys int
+
+	// MaxMetricDefinitions is the most metrics to define, including glob
+	// metrics.
+	MaxMetricDefinitions int
+}
+
+// HandlerMemory bounds the state that the handler keeps about the run, and
+// reports its size.
+//
+// Runs that log a new key on every step, or define a metric for each,
+// would otherwise grow the handler's memory without bound. Where dropping
+// old state is harmless, as for the samples behind the terminal's
+// sparklines, the least recently logged metrics are evicted. Where it
+// isn't, as for the summary that the user reads back, entries beyond the
+// limit are refused and the user is warned once.
+//
+// Sizes are updated by the handler's goroutine and may be read from any.
+//
+// The methods of a nil HandlerMemory bound nothing.
+type HandlerMemory struct {
+	limits HandlerMemoryLimits
+
+	sampledKeys        atomic.Int64
+	summaryKeys        atomic.Int64
+	partialHistoryKeys atomic.Int64
+	metricDefinitions  atomic.Int64
+
+	evictedSampledKeys          atomic.Int64
+	refusedSummaryValues        atomic.Int64
+	refusedPartialHistoryValues atomic.Int64
+	refusedMetricDefinitions    atomic.Int64
+
+	// sampledRecency orders the sampled history keys from least to most
+	// recently logged, if their number is limited
+	//
+	// It is only used by the handler's goroutine.
+	sampledRecency *recencyList
+
+	// warned are the limits the user was warned about, by name
+	//
+	// It is only used by the handler's goroutine.
+	warned map[string]bool
+}
+
+func NewHandlerMemory(limits HandlerMemoryLimits) *HandlerMemory {
+	m := &HandlerMemory{
+		limits: limits,
+		warned: make(map[string]bool),
+	}
+	if limits.MaxSampledKeys > 0 {
+		m.sampledRecency = newRecencyList()
+	}
+	return m
+}
+
+// Proto returns the size of the handler's state, and what was dropped to
+// keep it within its bounds.
+func (m *HandlerMemory) Proto() *service.HandlerMemory {
+	if m == nil {
+		return nil
+	}
+
+	return &service.HandlerMemory{
+		SampledKeys:        int32(m.sampledKeys.Load()),
+		SummaryKeys:        int32(m.summaryKeys.Load()),
+		PartialHistoryKeys: int32(m.partialHistoryKeys.Load()),
+		MetricDefinitions:  int32(m.metricDefinitions.Load()),
+
+		EvictedSampledKeys:          m.evictedSampledKeys.Load(),
+		RefusedSummaryValues:        m.refusedSummaryValues.Load(),
+		RefusedPartialHistoryValues: m.refusedPartialHistoryValues.Load(),
+		RefusedMetricDefinitions:    m.refusedMetricDefinitions.Load(),
+	}
+}
+
+// recencyList orders keys from least to most recently used.
+type recencyList struct {
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+func newRecencyList() *recencyList {
+	return &recencyList{
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// Touch marks the key as the most recently used.
+func (r *recencyList) Touch(key string) {
+	if element, ok := r.elements[key]; ok {
+		r.order.MoveToBack(element)
+	} else {
+		r.elements[key] = r.order.PushBack(key)
+	}
+}
+
+// RemoveOldest forgets the least recently used key and returns it.
+func (r *recencyList) RemoveOldest() string {
+	key := r.order.Remove(r.order.Front()).(string)
+	delete(r.elements, key)
+	return key
+}
+
+func (r *recencyList) Len() int {
+	return r.order.Len()
+}
+
+// topLevelKey is the key of the item at the root of the summary or
+// history tree.
+func topLevelKey(key string, nestedKey []string) string {
+	if len(nestedKey) > 0 {
+		return nestedKey[0]
+	}
+	return key
+}
+
+type keyedItem interface {
+	GetKey() string
+	GetNestedKey() []string
+}
+
+// admitItems returns the items whose top-level keys are in the tree or fit
+// under max along with it, and the number of other items.
+//
+// Keys in exempt are always admitted, and don't count towards max.
+func admitItems[T keyedItem](
+	items []T,
+	tree pathtree.TreeData,
+	max int,
+	exempt map[string]struct{},
+) ([]T, int) {
+	if max <= 0 {
+		return items, 0
+	}
+
+	// exempt keys don't count towards the limit
+	size := len(tree)
+	for key := range exempt {
+		if _, ok := tree[key]; ok {
+			size--
+		}
+	}
+
+	admitted := make([]T, 0, len(items))
+	added := make(map[string]struct{})
+	refused := 0
+	for _, item := range items {
+		key := topLevelKey(item.GetKey(), item.GetNestedKey())
+
+		_, present := tree[key]
+		_, isNew := added[key]
+		_, isExempt := exempt[key]
+		if !present && !isNew && !isExempt {
+			if size+len(added) >= max {
+				refused++
+				continue
+			}
+			added[key] = struct{}{}
+		}
+		admitted = append(admitted, item)
+	}
+	return admitted, refused
+}
+
+// warnMemoryLimit tells the user, the first time, that the run reached
+// one of the limits on the handler's state.
+func (h *Handler) warnMemoryLimit(name string, max int, message string) {
+	if h.memory.warned[name] {
+		return
+	}
+	h.memory.warned[name] = true
+
+	h.logger.Warn(
+		"handler: reached a limit on the handler's state",
+		"limit", name,
+		"max", max,
+	)
+	h.terminalPrinter.Write(message)
+}
+
+// touchSampledKey marks the key as logged.
+func (h *Handler) touchSampledKey(key string) {
+	if h.memory == nil || h.memory.sampledRecency == nil {
+		return
+	}
+	h.memory.sampledRecency.Touch(key)
+}
+
+// boundSampledKeys drops the samples of the least recently logged metrics
+// if there are too many.
+//
+// It's called after sampling a whole row, so that the row's keys only
+// evict each other if the row alone has too many.
+func (h *Handler) boundSampledKeys() {
+	if h.memory == nil || h.memory.sampledRecency == nil {
+		return
+	}
+
+	recency := h.memory.sampledRecency
+	for recency.Len() > h.memory.limits.MaxSampledKeys {
+		evicted := recency.RemoveOldest()
+		delete(h.samplers, evicted)
+		delete(h.nonNumericKeys, evicted)
+		h.memory.evictedSampledKeys.Add(1)
+
+		h.warnMemoryLimit("sampled_keys", h.memory.limits.MaxSampledKeys,
+			fmt.Sprintf(
+				"The run logged more than %d history metrics, so the"+
+					" samples of the least recently logged ones are dropped"+
+					" from its sampled history.",
+				h.memory.limits.MaxSampledKeys,
+			))
+	}
+}
+
+// noteSampledKeys updates the number of sampled history keys.
+func (h *Handler) noteSampledKeys() {
+	if h.memory == nil {
+		return
+	}
+	h.memory.sampledKeys.Store(int64(len(h.samplers) + len(h.nonNumericKeys)))
+}
+
+// coreSummaryKeys are the summary keys that the core sets, which are
+// never refused.
+var coreSummaryKeys = map[string]struct{}{"_wandb": {}}
+
+// boundSummary drops the updates of new summary keys beyond the limit.
+//
+// Unlike sampled history, the summary can't drop old keys to make room,
+// since the user reads it back.
+func (h *Handler) boundSummary(summary *service.SummaryRecord) {
+	if h.memory == nil {
+		return
+	}
+
+	max := h.memory.limits.MaxSummaryKeys
+	admitted, refused := admitItems(
+		summary.GetUpdate(), h.runSummary.Tree(), max, coreSummaryKeys)
+	if refused == 0 {
+		return
+	}
+
+	summary.Update = admitted
+	h.memory.refusedSummaryValues.Add(int64(refused))
+	h.warnMemoryLimit("summary_keys", max, fmt.Sprintf(
+		"The run's summary reached the limit of %d keys; updates of new"+
+			" keys are dropped.",
+		max,
+	))
+}
+
+// noteSummaryKeys updates the number of summary keys.
+func (h *Handler) noteSummaryKeys() {
+	if h.memory == nil {
+		return
+	}
+	h.memory.summaryKeys.Store(int64(len(h.runSummary.Tree())))
+}
+
+// boundPartialHistory returns the partial history items that fit in the
+// history row being built, dropping the values of new keys beyond the
+// limit.
+func (h *Handler) boundPartialHistory(
+	items []*service.HistoryItem,
+) []*service.HistoryItem {
+	if h.memory == nil || h.runHistory == nil {
+		return items
+	}
+
+	max := h.memory.limits.MaxPartialHistoryKeys
+	admitted, refused := admitItems(items, h.runHistory.Tree(), max, nil)
+	if refused == 0 {
+		return items
+	}
+
+	h.memory.refusedPartialHistoryValues.Add(int64(refused))
+	h.warnMemoryLimit("partial_history_keys", max, fmt.Sprintf(
+		"A history row reached the limit of %d keys; values of new keys"+
+			" in it are dropped.",
+		max,
+	))
+	return admitted
+}
+
+// notePartialHistoryKeys updates the number of keys in the history row
+// being built.
+func (h *Handler) notePartialHistoryKeys() {
+	if h.memory == nil || h.runHistory == nil {
+		return
+	}
+	h.memory.partialHistoryKeys.Store(int64(len(h.runHistory.Tree())))
+}
+
+// admitMetric returns whether a metric may be defined, given whether it
+// already is.
+//
+// Redefining a metric is always allowed, but a new one is refused if
+// there are too many.
+func (h *Handler) admitMetric(defined bool) bool {
+	if h.memory == nil || defined {
+		return true
+	}
+
+	max := h.memory.limits.MaxMetricDefinitions
+	count := len(h.metricHandler.definedMetrics) + len(h.metricHandler.globMetrics)
+	if max <= 0 || count < max {
+		return true
+	}
+
+	h.memory.refusedMetricDefinitions.Add(1)
+	h.warnMemoryLimit("metric_definitions", max, fmt.Sprintf(
+		"The run defined more than %d metrics; further definitions are"+
+			" ignored.",
+		max,
+	))
+	return false
+}
+
+// noteMetricDefinitions updates the number of defined metrics.
+func (h *Handler) noteMetricDefinitions() {
+	if h.memory == nil {
+		return
+	}
+	h.memory.metricDefinitions.Store(int64(
+		len(h.metricHandler.definedMetrics) + len(h.metricHandler.globMetrics)))
+}
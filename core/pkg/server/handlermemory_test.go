@@ -0,0 +1,231 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// startMemoryHandler starts a handler whose state is bounded by memory,
+// collecting its forwarded records if collect is true.
+func startMemoryHandler(
+	memory *server.HandlerMemory,
+	collect bool,
+) *keyLimitHandler {
+	h := &keyLimitHandler{
+		inChan:  make(chan *service.Record, 16),
+		outChan: make(chan *service.Result, 16),
+		printer: observability.NewPrinter(),
+		done:    make(chan struct{}),
+	}
+	fwdChan := make(chan *service.Record, 16)
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{},
+		FwdChan:         fwdChan,
+		OutChan:         h.outChan,
+		TerminalPrinter: h.printer,
+		RunSummary:      runsummary.New(),
+		MetricHandler:   server.NewMetricHandler(),
+		Memory:          memory,
+	})
+
+	go func() {
+		for record := range fwdChan {
+			if collect {
+				h.forwarded = append(h.forwarded, record)
+			}
+		}
+		close(h.done)
+	}()
+	go handler.Do(h.inChan)
+	return h
+}
+
+func makeMetricRecord(name string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Metric{
+			Metric: &service.MetricRecord{Name: name},
+		},
+	}
+}
+
+// A run logging a new metric on every step keeps the samples of the
+// metrics it logged most recently, including ones it logs all along.
+func TestHandlerMemory_EvictsLeastRecentlySampledKeys(t *testing.T) {
+	memory := server.NewHandlerMemory(server.HandlerMemoryLimits{
+		MaxSampledKeys: 4,
+	})
+	h := startMemoryHandler(memory, false)
+
+	for i := range 1000 {
+		h.inChan <- makeHistoryRecord(data{
+			items: map[string]string{
+				"loss":                    "0.5",
+				fmt.Sprintf("once/%d", i): "1",
+			},
+			step: int64(i),
+		})
+	}
+	keys := h.sampledKeys()
+	h.finish()
+
+	assert.ElementsMatch(t,
+		[]string{"_runtime", "_step", "loss", "once/999"}, keys)
+	stats := memory.Proto()
+	assert.EqualValues(t, 4, stats.GetSampledKeys())
+	assert.EqualValues(t, 999, stats.GetEvictedSampledKeys())
+	messages := h.printer.Read()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "more than 4 history metrics")
+}
+
+// New summary keys beyond the limit are refused, while keys already in the
+// summary keep being updated.
+func TestHandlerMemory_RefusesSummaryKeysBeyondLimit(t *testing.T) {
+	memory := server.NewHandlerMemory(server.HandlerMemoryLimits{
+		MaxSummaryKeys: 2,
+	})
+	h := startMemoryHandler(memory, true)
+
+	h.inChan <- makeSummaryRecord("a", "1")
+	h.inChan <- makeSummaryRecord("b", "2")
+	for i := range 100 {
+		h.inChan <- makeSummaryRecord(fmt.Sprintf("new/%d", i), "3")
+	}
+	h.inChan <- makeSummaryRecord("a", "4")
+	h.finish()
+
+	var summarized []string
+	for _, record := range h.forwarded {
+		for _, item := range record.GetSummary().GetUpdate() {
+			summarized = append(summarized, item.GetKey())
+		}
+	}
+	assert.Contains(t, summarized, "a")
+	assert.Contains(t, summarized, "b")
+	assert.NotContains(t, summarized, "new/0")
+	stats := memory.Proto()
+	assert.EqualValues(t, 3, stats.GetSummaryKeys()) // and _wandb
+	assert.EqualValues(t, 100, stats.GetRefusedSummaryValues())
+	messages := h.printer.Read()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "limit of 2 keys")
+}
+
+// A history row built from partial history keeps only the limit's worth
+// of keys, however many are logged to it.
+func TestHandlerMemory_RefusesPartialHistoryKeysBeyondLimit(t *testing.T) {
+	memory := server.NewHandlerMemory(server.HandlerMemoryLimits{
+		MaxPartialHistoryKeys: 3,
+	})
+	h := startMemoryHandler(memory, true)
+
+	for i := range 100 {
+		h.inChan <- makePartialHistoryRecord(data{
+			items: map[string]string{fmt.Sprintf("key/%d", i): "1"},
+			step:  0,
+		})
+	}
+	h.inChan <- makePartialHistoryRecord(data{
+		items: map[string]string{"key/0": "2"},
+		step:  0,
+		flush: true,
+	})
+	h.finish()
+
+	var rows [][]string
+	for _, record := range h.forwarded {
+		if history := record.GetHistory(); history != nil {
+			var keys []string
+			for _, item := range history.GetItem() {
+				keys = append(keys, item.GetKey())
+			}
+			rows = append(rows, keys)
+		}
+	}
+	require.Len(t, rows, 1)
+	assert.ElementsMatch(t,
+		[]string{"key/0", "key/1", "key/2", "_runtime", "_step"}, rows[0])
+	assert.EqualValues(t, 97, memory.Proto().GetRefusedPartialHistoryValues())
+	assert.Len(t, h.printer.Read(), 1)
+}
+
+// Metric definitions beyond the limit are refused, while metrics already
+// defined may be redefined.
+func TestHandlerMemory_RefusesMetricDefinitionsBeyondLimit(t *testing.T) {
+	memory := server.NewHandlerMemory(server.HandlerMemoryLimits{
+		MaxMetricDefinitions: 2,
+	})
+	h := startMemoryHandler(memory, true)
+
+	for i := range 10 {
+		h.inChan <- makeMetricRecord(fmt.Sprintf("metric/%d", i))
+	}
+	h.inChan <- makeMetricRecord("metric/0")
+	h.finish()
+
+	var defined []string
+	for _, record := range h.forwarded {
+		if metric := record.GetMetric(); metric != nil {
+			defined = append(defined, metric.GetName())
+		}
+	}
+	assert.Equal(t, []string{"metric/0", "metric/1", "metric/0"}, defined)
+	stats := memory.Proto()
+	assert.EqualValues(t, 2, stats.GetMetricDefinitions())
+	assert.EqualValues(t, 8, stats.GetRefusedMetricDefinitions())
+	assert.Len(t, h.printer.Read(), 1)
+}
+
+// A run that logs a million distinct keys keeps the samples and summary of
+// only the limits' worth of them in memory.
+func TestHandlerMemory_MillionKeysBoundedMemory(t *testing.T) {
+	const rows = 1000
+	const keysPerRow = 1000
+	memory := server.NewHandlerMemory(server.HandlerMemoryLimits{
+		MaxSampledKeys: 1000,
+		MaxSummaryKeys: 1000,
+	})
+	h := startMemoryHandler(memory, false)
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	for i := range rows {
+		items := make(map[string]string, keysPerRow)
+		for j := range keysPerRow {
+			items[fmt.Sprintf("metric/%d", i*keysPerRow+j)] = "0.5"
+		}
+		h.inChan <- makeHistoryRecord(data{items: items, step: int64(i)})
+	}
+	keys := h.sampledKeys()
+
+	var after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	h.finish()
+
+	assert.Len(t, keys, 1000)
+	stats := memory.Proto()
+	assert.EqualValues(t, 1000, stats.GetSampledKeys())
+	assert.EqualValues(t, 1001, stats.GetSummaryKeys()) // and _wandb
+	growth := int64(after.HeapInuse) - int64(before.HeapInuse)
+	assert.Less(t, growth, int64(64<<20), "heap grew by %d bytes", growth)
+}
+
+func TestHandlerMemory_Nil(t *testing.T) {
+	var memory *server.HandlerMemory
+
+	assert.Nil(t, memory.Proto())
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// untrackedKeyExamples is how many of the keys beyond the limit on
+// tracked history keys the warning names.
+const untrackedKeyExamples = 5
+
+// coreHistoryKeys are the keys that the core adds to history rows, which
+// are always tracked.
+var coreHistoryKeys = map[string]struct{}{
+	"_runtime":   {},
+	"_step":      {},
+	"_timestamp": {},
+}
+
+// HistoryKeyLimit limits the distinct history keys that the handler tracks
+// for the run's summary and sampled history.
+//
+// A run that logs a new key on every step, such as a key with an index in
+// it, would otherwise grow the handler's memory without bound. Values of
+// keys beyond the limit are saved and sent like any other, but they aren't
+// summarized or sampled. The keys that the core adds to each row are
+// always tracked.
+//
+// The methods of a nil HistoryKeyLimit track every key.
+type HistoryKeyLimit struct {
+	max int
+
+	// tracked are the keys tracked so far, up to max
+	//
+	// It is only used by the handler's goroutine.
+	tracked map[string]struct{}
+
+	// warned is whether the user was told about the untracked keys
+	warned bool
+
+	// untracked is the number of values not tracked
+	untracked atomic.Int64
+}
+
+// NewHistoryKeyLimit returns a limit of max keys, or nil if it isn't
+// positive.
+func NewHistoryKeyLimit(max int) *HistoryKeyLimit {
+	if max <= 0 {
+		return nil
+	}
+	return &HistoryKeyLimit{
+		max:     max,
+		tracked: make(map[string]struct{}),
+	}
+}
+
+// Untracked is the number of history values that weren't summarized or
+// sampled, because their keys were beyond the limit.
+func (l *HistoryKeyLimit) Untracked() int64 {
+	if l == nil {
+		return 0
+	}
+	return l.untracked.Load()
+}
+
+// track returns the items whose keys are tracked, and the keys of the
+// other items.
+//
+// Keys are tracked in the order they're first seen until there are max
+// of them.
+func (l *HistoryKeyLimit) track(
+	items []*service.HistoryItem,
+) ([]*service.HistoryItem, []string) {
+	if l == nil {
+		return items, nil
+	}
+
+	tracked := make([]*service.HistoryItem, 0, len(items))
+	var untracked []string
+	for _, item := range items {
+		key := summaryKey(item.GetKey(), item.GetNestedKey())
+
+		_, ok := l.tracked[key]
+		if _, core := coreHistoryKeys[key]; !ok && (core || len(l.tracked) < l.max) {
+			l.tracked[key] = struct{}{}
+			ok = true
+		}
+
+		if ok {
+			tracked = append(tracked, item)
+		} else {
+			untracked = append(untracked, key)
+		}
+	}
+
+	l.untracked.Add(int64(len(untracked)))
+	return tracked, untracked
+}
+
+// trackedHistoryItems returns the items of a history row whose keys are
+// tracked for the summary and sampled history.
+//
+// The first time some keys aren't, the user is warned and told a few of
+// them.
+func (h *Handler) trackedHistoryItems(
+	items []*service.HistoryItem,
+) []*service.HistoryItem {
+	tracked, untracked := h.historyKeys.track(items)
+	if len(untracked) == 0 || h.historyKeys.warned {
+		return tracked
+	}
+	h.historyKeys.warned = true
+
+	examples := untracked[:min(len(untracked), untrackedKeyExamples)]
+	h.logger.Warn(
+		"handler: too many distinct history keys to track",
+		"max", h.historyKeys.max,
+		"examples", examples,
+	)
+	h.terminalPrinter.Write(fmt.Sprintf(
+		"The run logged more than %d distinct history keys, such as %s."+
+			" Keys beyond the limit are uploaded but not summarized;"+
+			" consider logging fewer keys, or ones without an index in them.",
+		h.historyKeys.max,
+		strings.Join(examples, ", "),
+	))
+	return tracked
+}
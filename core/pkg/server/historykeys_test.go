@@ -0,0 +1,174 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// keyLimitHandler is a handler with a limit on tracked history keys whose
+// forwarded records are collected, or discarded if collect is false.
+type keyLimitHandler struct {
+	inChan    chan *service.Record
+	outChan   chan *service.Result
+	printer   *observability.Printer
+	forwarded []*service.Record
+	done      chan struct{}
+}
+
+func startKeyLimitHandler(
+	limit *server.HistoryKeyLimit,
+	collect bool,
+) *keyLimitHandler {
+	h := &keyLimitHandler{
+		inChan:  make(chan *service.Record, 16),
+		outChan: make(chan *service.Result, 16),
+		printer: observability.NewPrinter(),
+		done:    make(chan struct{}),
+	}
+	fwdChan := make(chan *service.Record, 16)
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{},
+		FwdChan:         fwdChan,
+		OutChan:         h.outChan,
+		TerminalPrinter: h.printer,
+		RunSummary:      runsummary.New(),
+		MetricHandler:   server.NewMetricHandler(),
+		HistoryKeyLimit: limit,
+	})
+
+	go func() {
+		for record := range fwdChan {
+			if collect {
+				h.forwarded = append(h.forwarded, record)
+			}
+		}
+		close(h.done)
+	}()
+	go handler.Do(h.inChan)
+	return h
+}
+
+// sampledKeys returns the keys of the sampled history.
+func (h *keyLimitHandler) sampledKeys() []string {
+	h.inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_SampledHistory{
+					SampledHistory: &service.SampledHistoryRequest{},
+				},
+			},
+		},
+	}
+	var keys []string
+	for _, item := range (<-h.outChan).GetResponse().GetSampledHistoryResponse().GetItem() {
+		keys = append(keys, item.GetKey())
+	}
+	return keys
+}
+
+func (h *keyLimitHandler) finish() {
+	close(h.inChan)
+	<-h.done
+}
+
+func TestHistoryKeyLimit_SendsButDoesNotTrackKeysBeyondLimit(t *testing.T) {
+	limit := server.NewHistoryKeyLimit(2)
+	h := startKeyLimitHandler(limit, true)
+
+	h.inChan <- makeHistoryRecord(data{
+		items: map[string]string{"a": "1", "b": "2"},
+		step:  0,
+	})
+	h.inChan <- makeHistoryRecord(data{
+		items: map[string]string{"a": "3", "c": "4", "d": "5"},
+		step:  1,
+	})
+	keys := h.sampledKeys()
+	h.finish()
+
+	assert.ElementsMatch(t, []string{"a", "b", "_runtime", "_step"}, keys)
+	var sent, summarized []string
+	for _, record := range h.forwarded {
+		for _, item := range record.GetHistory().GetItem() {
+			sent = append(sent, item.GetKey())
+		}
+		for _, item := range record.GetSummary().GetUpdate() {
+			summarized = append(summarized, item.GetKey())
+		}
+	}
+	assert.Subset(t, sent, []string{"a", "b", "c", "d"})
+	assert.NotContains(t, summarized, "c")
+	assert.NotContains(t, summarized, "d")
+	assert.Contains(t, summarized, "a")
+	assert.EqualValues(t, 2, limit.Untracked())
+	messages := h.printer.Read()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "more than 2 distinct history keys")
+	assert.Contains(t, messages[0], "c")
+}
+
+func TestHistoryKeyLimit_WarnsOnce(t *testing.T) {
+	limit := server.NewHistoryKeyLimit(1)
+	h := startKeyLimitHandler(limit, false)
+
+	for i := range 5 {
+		h.inChan <- makeHistoryRecord(data{
+			items: map[string]string{fmt.Sprintf("key-%d", i): "1"},
+			step:  int64(i),
+		})
+	}
+	h.finish()
+
+	assert.EqualValues(t, 4, limit.Untracked())
+	assert.Len(t, h.printer.Read(), 1)
+}
+
+func TestHistoryKeyLimit_NoLimit(t *testing.T) {
+	limit := server.NewHistoryKeyLimit(0)
+
+	assert.Nil(t, limit)
+	assert.Zero(t, limit.Untracked())
+}
+
+// A run that logs a million distinct keys keeps only the limit's worth of
+// them in memory.
+func TestHistoryKeyLimit_MillionKeysBoundedMemory(t *testing.T) {
+	const rows = 1000
+	const keysPerRow = 1000
+	limit := server.NewHistoryKeyLimit(1000)
+	h := startKeyLimitHandler(limit, false)
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	for i := range rows {
+		items := make(map[string]string, keysPerRow)
+		for j := range keysPerRow {
+			items[fmt.Sprintf("metric/%d", i*keysPerRow+j)] = "0.5"
+		}
+		h.inChan <- makeHistoryRecord(data{items: items, step: int64(i)})
+	}
+	keys := h.sampledKeys()
+
+	var after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	h.finish()
+
+	assert.Len(t, keys, 1000+2) // and _runtime and _step
+	assert.EqualValues(t, rows*keysPerRow-1000, limit.Untracked())
+	growth := int64(after.HeapInuse) - int64(before.HeapInuse)
+	assert.Less(t, growth, int64(64<<20), "heap grew by %d bytes", growth)
+}
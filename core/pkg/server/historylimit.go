@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// HistoryLimiter limits the rate of history rows sent to the server.
+//
+// Rows logged faster than the limit are coalesced: they are saved in the
+// transaction log and count towards the summary, but only about every
+// Nth of them is sent, where N is how many times over the limit the rows
+// come. Up to a second's worth of rows may be logged at once.
+//
+// The methods of a nil HistoryLimiter do nothing.
+type HistoryLimiter struct {
+	rowsPerSecond float64
+	limiter       *rate.Limiter
+	clock         waiting.Clock
+
+	// coalesced is the number of rows not sent
+	coalesced atomic.Int64
+}
+
+// NewHistoryLimiter returns a limiter of rowsPerSecond, or nil if it
+// isn't positive.
+func NewHistoryLimiter(rowsPerSecond float64, clock waiting.Clock) *HistoryLimiter {
+	if rowsPerSecond <= 0 {
+		return nil
+	}
+	if clock == nil {
+		clock = waiting.NewClock()
+	}
+
+	burst := int(math.Max(1, math.Floor(rowsPerSecond)))
+	return &HistoryLimiter{
+		rowsPerSecond: rowsPerSecond,
+		limiter:       rate.NewLimiter(rate.Limit(rowsPerSecond), burst),
+		clock:         clock,
+	}
+}
+
+// coalesce returns whether the next row is over the limit, and counts it
+// if so.
+func (l *HistoryLimiter) coalesce() bool {
+	if l == nil || l.limiter.AllowN(l.clock.Now(), 1) {
+		return false
+	}
+	l.coalesced.Add(1)
+	return true
+}
+
+// Coalesced is the number of history rows that weren't sent, because they
+// were over the limit.
+func (l *HistoryLimiter) Coalesced() int64 {
+	if l == nil {
+		return 0
+	}
+	return l.coalesced.Load()
+}
+
+// fwdHistory forwards a history record to the writer, to be stored but
+// not sent if it's over the history row rate limit.
+func (h *Handler) fwdHistory(record *service.Record) {
+	if !h.historyLimiter.coalesce() {
+		h.fwdRecord(record)
+		return
+	}
+
+	if h.historyLimiter.Coalesced() == 1 {
+		h.logger.Warn(
+			"handler: coalescing history rows over the limit",
+			"rows_per_second", h.historyLimiter.rowsPerSecond,
+		)
+		h.terminalPrinter.Write(fmt.Sprintf(
+			"Logging history faster than the limit of %v rows per second."+
+				" Only some rows are uploaded, though all of them are saved"+
+				" locally; consider calling run.log() less often.",
+			h.historyLimiter.rowsPerSecond,
+		))
+	}
+
+	h.fwdRecordWithControl(record, func(control *service.Control) {
+		control.PersistOnly = true
+	})
+}
@@ -0,0 +1,147 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// tickingClock is a clock that moves on by a tick whenever it's read.
+type tickingClock struct {
+	now  time.Time
+	tick time.Duration
+}
+
+func (c *tickingClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.tick)
+	return now
+}
+
+// limitHistory passes history rows through a handler with the limiter,
+// and returns the history records it forwarded and the messages it
+// printed.
+func limitHistory(
+	t *testing.T,
+	limiter *server.HistoryLimiter,
+	rows int,
+) ([]*service.Record, []string) {
+	inChan := make(chan *service.Record, rows)
+	fwdChan := make(chan *service.Record, 2*rows)
+	printer := observability.NewPrinter()
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{},
+		FwdChan:         fwdChan,
+		OutChan:         make(chan *service.Result, rows),
+		TerminalPrinter: printer,
+		HistoryLimiter:  limiter,
+	})
+
+	done := make(chan struct{})
+	go func() { handler.Do(inChan); close(done) }()
+	for i := range rows {
+		inChan <- makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5"},
+			step:  int64(i),
+		})
+	}
+	close(inChan)
+	<-done
+
+	var history []*service.Record
+	for record := range fwdChan {
+		if record.GetHistory() != nil {
+			history = append(history, record)
+		}
+	}
+	return history, printer.Read()
+}
+
+func TestHistoryLimiter_CoalescesRowsOverLimit(t *testing.T) {
+	clock := &tickingClock{now: time.Unix(1000, 0)}
+	limiter := server.NewHistoryLimiter(2, clock)
+
+	history, messages := limitHistory(t, limiter, 6)
+
+	// Every row reaches the writer, but only the first burst is sent.
+	require.Len(t, history, 6)
+	for i, record := range history {
+		assert.Equal(t, i >= 2, record.GetControl().GetPersistOnly(), "row %d", i)
+	}
+	assert.EqualValues(t, 4, limiter.Coalesced())
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "limit of 2 rows per second")
+}
+
+func TestHistoryLimiter_KeepsRowsAtLimit(t *testing.T) {
+	clock := &tickingClock{now: time.Unix(1000, 0), tick: 25 * time.Millisecond}
+	limiter := server.NewHistoryLimiter(10, clock)
+
+	// Rows come at 40 per second for a little over a second, so a burst
+	// of a second's worth goes out, then every fourth row.
+	history, _ := limitHistory(t, limiter, 50)
+
+	var sent int
+	for _, record := range history {
+		if !record.GetControl().GetPersistOnly() {
+			sent++
+		}
+	}
+	assert.Equal(t, 10+12, sent)
+	assert.EqualValues(t, 50-sent, limiter.Coalesced())
+}
+
+func TestHistoryLimiter_NoLimit(t *testing.T) {
+	limiter := server.NewHistoryLimiter(0, nil)
+
+	history, messages := limitHistory(t, limiter, 20)
+
+	assert.Nil(t, limiter)
+	require.Len(t, history, 20)
+	for _, record := range history {
+		assert.False(t, record.GetControl().GetPersistOnly())
+	}
+	assert.Empty(t, messages)
+	assert.Zero(t, limiter.Coalesced())
+}
+
+func TestWriter_StoresButDoesNotSendPersistOnly(t *testing.T) {
+	syncFile := filepath.Join(t.TempDir(), "run-limited.wandb")
+	inChan := make(chan *service.Record, 4)
+	fwdChan := make(chan *service.Record, 4)
+	writer := server.NewWriter(context.Background(), &server.WriterParams{
+		Logger:   observability.NewNoOpLogger(),
+		Settings: &service.Settings{SyncFile: &wrapperspb.StringValue{Value: syncFile}},
+		FwdChan:  fwdChan,
+	})
+
+	for i := range 4 {
+		record := makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5"},
+			step:  int64(i),
+		})
+		record.Control = &service.Control{PersistOnly: i%2 == 1}
+		inChan <- record
+	}
+	close(inChan)
+	writer.Do(inChan)
+
+	var forwarded []int64
+	for record := range fwdChan {
+		forwarded = append(forwarded, record.GetHistory().GetStep().GetNum())
+	}
+	assert.Equal(t, []int64{0, 2}, forwarded)
+	state, err := server.Replay(syncFile, -1, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, state.Records)
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+// defaultInitTimeout is how long starting an in-process run waits for the
+// server to create it, unless the settings say otherwise.
+const defaultInitTimeout = 90 * time.Second
+
+// InProcessClient logs a run through a stream in the same process, for Go
+// programs that embed wandb instead of starting the wandb service and
+// talking to it over its socket.
+//
+// It is safe for concurrent use.
+type InProcessClient struct {
+	// mu is held for reading while sending records, and for writing while
+	// finishing, so that no record is sent once the stream closes
+	mu sync.RWMutex
+
+	// id routes the stream's results to the client's responder
+	id string
+
+	stream   *Stream
+	finished bool
+}
+
+// inProcessResponder takes the results meant for an in-process client.
+//
+// The client only waits for results through the stream's mailbox, so the
+// others, like upload progress, are dropped. Messages for the user are
+// printed, since the client shares the user's terminal.
+type inProcessResponder struct{}
+
+func (inProcessResponder) Respond(response *service.ServerResponse) {
+	message := response.GetResultCommunicate().GetResponse().GetUserMessageResponse()
+	if message != nil {
+		utils.PrintUserMessage(message)
+	}
+}
+
+// NewInProcessClient starts a stream for a run and waits for the server
+// to create the run.
+//
+// The settings are prepared the way the service prepares a client's.
+func NewInProcessClient(
+	ctx context.Context,
+	settingsProto *service.Settings,
+) (*InProcessClient, error) {
+	s := settings.Resolve(settingsProto)
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if !s.IsDisabled() {
+		if _, err := s.PrepareRunDirs(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.EnsureAPIKey(); err != nil && !s.IsOffline() {
+		return nil, err
+	}
+
+	stream, err := NewStream(s, s.GetRunID())
+	if err != nil {
+		return nil, err
+	}
+	c := &InProcessClient{id: "inprocess-" + utils.ShortID(8), stream: stream}
+	c.stream.AddResponders(ResponderEntry{inProcessResponder{}, c.id})
+	c.stream.Start()
+
+	run, err := c.startRun(ctx, s)
+	if err != nil {
+		c.stream.FinishAndClose(1)
+		return nil, err
+	}
+
+	c.send(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_RunStart{
+					RunStart: &service.RunStartRequest{Run: run},
+				},
+			},
+		},
+	})
+	return c, nil
+}
+
+// startRun sends the run record and returns the run as the server created
+// it.
+func (c *InProcessClient) startRun(
+	ctx context.Context,
+	s *settings.Settings,
+) (*service.RunRecord, error) {
+	timeout := defaultInitTimeout
+	if seconds := s.Proto.GetInitTimeout(); seconds != nil {
+		timeout = time.Duration(seconds.GetValue() * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	slot := c.stream.mailbox.Reserve()
+	c.send(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				RunId:       s.GetRunID(),
+				Entity:      s.GetEntity(),
+				Project:     s.GetProject(),
+				DisplayName: s.Proto.GetRunName().GetValue(),
+				RunGroup:    s.Proto.GetRunGroup().GetValue(),
+				JobType:     s.Proto.GetRunJobType().GetValue(),
+				Notes:       s.Proto.GetRunNotes().GetValue(),
+				Tags:        s.Proto.GetRunTags().GetValue(),
+			},
+		},
+		Control: &service.Control{MailboxSlot: slot.ID()},
+	})
+
+	result, err := slot.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("server: no response to starting the run: %v", err)
+	}
+	runResult := result.GetRunResult()
+	if runError := runResult.GetError(); runError != nil {
+		return nil, fmt.Errorf("server: can't start the run: %s", runError.GetMessage())
+	}
+	return runResult.GetRun(), nil
+}
+
+// send passes the record to the stream, addressed to the client.
+func (c *InProcessClient) send(record *service.Record) {
+	if record.Control == nil {
+		record.Control = &service.Control{}
+	}
+	record.Control.ConnectionId = c.id
+	c.stream.HandleRecord(record)
+}
+
+// sendUnlessFinished sends the record unless the run has finished.
+func (c *InProcessClient) sendUnlessFinished(record *service.Record) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.finished {
+		return ErrStreamFinished
+	}
+	c.send(record)
+	return nil
+}
+
+// LogHistory logs a row of the run's history at the next step.
+//
+// The values are encoded as JSON.
+func (c *InProcessClient) LogHistory(row map[string]any) error {
+	items := make([]*service.HistoryItem, 0, len(row))
+	for _, key := range sortedKeys(row) {
+		valueJSON, err := json.Marshal(row[key])
+		if err != nil {
+			return fmt.Errorf("server: can't log %q: %v", key, err)
+		}
+		items = append(items, &service.HistoryItem{
+			Key:       key,
+			ValueJson: string(valueJSON),
+		})
+	}
+
+	return c.sendUnlessFinished(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_PartialHistory{
+					PartialHistory: &service.PartialHistoryRequest{
+						Item:   items,
+						Action: &service.HistoryAction{Flush: true},
+					},
+				},
+			},
+		},
+	})
+}
+
+// UpdateConfig sets keys of the run's config.
+//
+// The values are encoded as JSON.
+func (c *InProcessClient) UpdateConfig(config map[string]any) error {
+	items := make([]*service.ConfigItem, 0, len(config))
+	for _, key := range sortedKeys(config) {
+		valueJSON, err := json.Marshal(config[key])
+		if err != nil {
+			return fmt.Errorf("server: can't set config %q: %v", key, err)
+		}
+		items = append(items, &service.ConfigItem{
+			Key:       key,
+			ValueJson: string(valueJSON),
+		})
+	}
+
+	return c.sendUnlessFinished(&service.Record{
+		RecordType: &service.Record_Config{
+			Config: &service.ConfigRecord{Update: items},
+		},
+	})
+}
+
+// SaveFile uploads a file in the run's files directory.
+//
+// The path is relative to the files directory.
+func (c *InProcessClient) SaveFile(path string) error {
+	if !filepath.IsLocal(path) {
+		return fmt.Errorf("server: can't save %q: not in the run's files directory", path)
+	}
+
+	return c.sendUnlessFinished(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{
+					Path:   filepath.ToSlash(path),
+					Policy: service.FilesItem_NOW,
+				}},
+			},
+		},
+	})
+}
+
+// Finish finishes the run with the exit code, uploads its data and closes
+// its stream, like a client's teardown.
+//
+// Other methods return ErrStreamFinished once it has been called.
+func (c *InProcessClient) Finish(exitCode int32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.finished {
+		return ErrStreamFinished
+	}
+	c.finished = true
+	c.stream.FinishAndClose(exitCode)
+	return nil
+}
+
+// sortedKeys returns the map's keys in order, so that records are the
+// same for the same map.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// inProcessSettings returns settings for an in-process run logged to the
+// backend.
+func inProcessSettings(t *testing.T, backend *servertest.FakeBackend) *service.Settings {
+	dir := t.TempDir()
+	return &service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "inprocess"},
+		Project:       &wrapperspb.StringValue{Value: "pipeline"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-inprocess.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}
+}
+
+// newInProcessBackend returns a backend that accepts a run and its files.
+func newInProcessBackend() *servertest.FakeBackend {
+	backend := servertest.NewFakeBackend()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	return backend
+}
+
+// A Go program logs a short run without the wandb service.
+func TestInProcessClient_LogsShortRun(t *testing.T) {
+	backend := newInProcessBackend()
+	defer backend.Close()
+	settings := inProcessSettings(t, backend)
+
+	client, err := server.NewInProcessClient(context.Background(), settings)
+	require.NoError(t, err)
+	require.NoError(t, client.UpdateConfig(map[string]any{"batch_size": 32}))
+	for step := range 3 {
+		require.NoError(t, client.LogHistory(map[string]any{"rows": step * 100}))
+	}
+	filesDir := settings.GetFilesDir().GetValue()
+	require.NoError(t, os.WriteFile(filepath.Join(filesDir, "report.txt"), []byte("done"), 0o644))
+	require.NoError(t, client.SaveFile("report.txt"))
+	require.NoError(t, client.Finish(0))
+
+	bodies := fileStreamBodies(backend)
+	for step := range 3 {
+		assert.Contains(t, bodies, fmt.Sprintf(`\"rows\":%d`, step*100))
+	}
+	assert.Contains(t, bodies, `"complete":true`)
+	var upserts []string
+	for _, request := range backend.Requests(servertest.RouteGraphQL) {
+		if strings.Contains(string(request.Body), "UpsertBucket") {
+			upserts = append(upserts, string(request.Body))
+		}
+	}
+	assert.Contains(t, strings.Join(upserts, "\n"), "batch_size")
+	var uploaded []string
+	for _, request := range backend.Requests(servertest.RouteUpload) {
+		uploaded = append(uploaded, request.Path)
+	}
+	assert.Contains(t, uploaded, "/upload/report.txt")
+}
+
+func TestInProcessClient_SafeForConcurrentUse(t *testing.T) {
+	backend := newInProcessBackend()
+	defer backend.Close()
+	client, err := server.NewInProcessClient(
+		context.Background(),
+		inProcessSettings(t, backend),
+	)
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for worker := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 10 {
+				key := fmt.Sprintf("worker_%d", worker)
+				assert.NoError(t, client.LogHistory(map[string]any{key: i}))
+				assert.NoError(t, client.UpdateConfig(map[string]any{key: i}))
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, client.Finish(0))
+
+	bodies := fileStreamBodies(backend)
+	for worker := range 4 {
+		assert.Contains(t, bodies, fmt.Sprintf(`\"worker_%d\":9`, worker))
+	}
+}
+
+func TestInProcessClient_ErrorsOnceFinished(t *testing.T) {
+	backend := newInProcessBackend()
+	defer backend.Close()
+	client, err := server.NewInProcessClient(
+		context.Background(),
+		inProcessSettings(t, backend),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Finish(0))
+
+	assert.ErrorIs(t, client.LogHistory(map[string]any{"x": 1}), server.ErrStreamFinished)
+	assert.ErrorIs(t, client.UpdateConfig(map[string]any{"x": 1}), server.ErrStreamFinished)
+	assert.ErrorIs(t, client.SaveFile("x.txt"), server.ErrStreamFinished)
+	assert.ErrorIs(t, client.Finish(0), server.ErrStreamFinished)
+}
+
+func TestInProcessClient_SaveFileOutsideFilesDir(t *testing.T) {
+	backend := newInProcessBackend()
+	defer backend.Close()
+	client, err := server.NewInProcessClient(
+		context.Background(),
+		inProcessSettings(t, backend),
+	)
+	require.NoError(t, err)
+	defer client.Finish(0)
+
+	assert.Error(t, client.SaveFile("../secrets.txt"))
+}
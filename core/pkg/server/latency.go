@@ -0,0 +1,216 @@
+package server
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// latencySampleInterval is the least time between sampled history rows.
+	//
+	// Rows are batched into filestream requests over several seconds, so
+	// this samples a row or a few from each batch.
+	latencySampleInterval = time.Second
+
+	// maxPendingLatencySamples is how many sampled rows may be waiting to
+	// be persisted or acknowledged.
+	//
+	// Rows that never are, like those of an offline run, are forgotten
+	// oldest first.
+	maxPendingLatencySamples = 16
+
+	// latencyWindow is how many of the most recent latencies of each stage
+	// are summarized.
+	latencyWindow = 256
+)
+
+// PipelineLatency measures how long history rows take to go through the
+// stream: from the handler until the writer persists them, and until the
+// backend acknowledges the filestream request with them, which is when
+// they're visible in the UI.
+//
+// Only a row every latencySampleInterval is measured, so that it's cheap
+// for every other row. The methods of a nil PipelineLatency do nothing.
+type PipelineLatency struct {
+	clock waiting.Clock
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// lastSample is when the last row was sampled.
+	lastSample time.Time
+
+	// pending are the sampled rows not yet both persisted and acknowledged.
+	pending map[*service.HistoryRecord]*latencySample
+
+	// pendingOrder are the keys of pending, oldest first.
+	pendingOrder []*service.HistoryRecord
+
+	// persistedLatencies and ackedLatencies are the most recent latencies
+	// of each stage.
+	persistedLatencies latencyRing
+	ackedLatencies     latencyRing
+}
+
+// latencySample is a sampled row on its way through the stream.
+type latencySample struct {
+	// entered is when the handler got the row.
+	entered time.Time
+
+	persisted bool
+	acked     bool
+}
+
+func NewPipelineLatency(clock waiting.Clock) *PipelineLatency {
+	return &PipelineLatency{
+		clock:   clock,
+		pending: make(map[*service.HistoryRecord]*latencySample),
+	}
+}
+
+// entered notes that the handler got the row, sampling it if it's time.
+func (l *PipelineLatency) entered(row *service.HistoryRecord) {
+	if l == nil || row == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if !l.lastSample.IsZero() && now.Sub(l.lastSample) < latencySampleInterval {
+		return
+	}
+	l.lastSample = now
+
+	if len(l.pendingOrder) >= maxPendingLatencySamples {
+		delete(l.pending, l.pendingOrder[0])
+		l.pendingOrder = l.pendingOrder[1:]
+	}
+	l.pending[row] = &latencySample{entered: now}
+	l.pendingOrder = append(l.pendingOrder, row)
+}
+
+// persisted notes that the writer saved the row to the transaction log.
+func (l *PipelineLatency) persisted(row *service.HistoryRecord) {
+	if l == nil || row == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sample, ok := l.pending[row]
+	if !ok || sample.persisted {
+		return
+	}
+	sample.persisted = true
+	l.persistedLatencies.add(l.clock.Now().Sub(sample.entered))
+	l.forgetIfDone(row, sample)
+}
+
+// onAcked returns a function to call once the backend acknowledges the
+// row, or nil if the row isn't sampled.
+func (l *PipelineLatency) onAcked(row *service.HistoryRecord) func() {
+	if l == nil || row == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.pending[row]; !ok {
+		return nil
+	}
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		sample, ok := l.pending[row]
+		if !ok || sample.acked {
+			return
+		}
+		sample.acked = true
+		l.ackedLatencies.add(l.clock.Now().Sub(sample.entered))
+		l.forgetIfDone(row, sample)
+	}
+}
+
+// forgetIfDone stops tracking the row once it's been persisted and
+// acknowledged.
+//
+// The mutex must be held.
+func (l *PipelineLatency) forgetIfDone(
+	row *service.HistoryRecord,
+	sample *latencySample,
+) {
+	if !sample.persisted || !sample.acked {
+		return
+	}
+
+	delete(l.pending, row)
+	l.pendingOrder = slices.DeleteFunc(l.pendingOrder,
+		func(r *service.HistoryRecord) bool { return r == row })
+}
+
+// Proto summarizes the recent latencies, or returns nil if no rows have
+// been measured.
+func (l *PipelineLatency) Proto() *service.PipelineLatency {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.persistedLatencies.latencies) == 0 &&
+		len(l.ackedLatencies.latencies) == 0 {
+		return nil
+	}
+	return &service.PipelineLatency{
+		Persisted:    l.persistedLatencies.stats(),
+		Acknowledged: l.ackedLatencies.stats(),
+	}
+}
+
+// latencyRing holds the latencyWindow most recent latencies.
+type latencyRing struct {
+	latencies []time.Duration
+
+	// next is the index to overwrite once the ring is full.
+	next int
+}
+
+func (r *latencyRing) add(latency time.Duration) {
+	if len(r.latencies) < latencyWindow {
+		r.latencies = append(r.latencies, latency)
+		return
+	}
+	r.latencies[r.next] = latency
+	r.next = (r.next + 1) % latencyWindow
+}
+
+// stats returns the percentiles of the latencies, or nil if there are
+// none.
+func (r *latencyRing) stats() *service.LatencyStats {
+	if len(r.latencies) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(r.latencies)
+	slices.Sort(sorted)
+	percentile := func(p int) float64 {
+		return sorted[(len(sorted)-1)*p/100].Seconds()
+	}
+
+	return &service.LatencyStats{
+		Samples:    int32(len(sorted)),
+		P50Seconds: percentile(50),
+		P95Seconds: percentile(95),
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestPipelineLatency_MeasuresEachStage(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	latency := NewPipelineLatency(clock)
+	row := &service.HistoryRecord{}
+
+	latency.entered(row)
+	clock.now = clock.now.Add(2 * time.Second)
+	latency.persisted(row)
+	onAcked := latency.onAcked(row)
+	clock.now = clock.now.Add(3 * time.Second)
+	require.NotNil(t, onAcked)
+	onAcked()
+	onAcked()
+
+	stats := latency.Proto()
+	assert.EqualValues(t, 1, stats.GetPersisted().GetSamples())
+	assert.Equal(t, 2.0, stats.GetPersisted().GetP50Seconds())
+	assert.EqualValues(t, 1, stats.GetAcknowledged().GetSamples())
+	assert.Equal(t, 5.0, stats.GetAcknowledged().GetP95Seconds())
+	assert.Empty(t, latency.pending)
+}
+
+func TestPipelineLatency_SamplesOneRowPerInterval(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	latency := NewPipelineLatency(clock)
+	first := &service.HistoryRecord{}
+	second := &service.HistoryRecord{}
+	third := &service.HistoryRecord{}
+
+	latency.entered(first)
+	clock.now = clock.now.Add(latencySampleInterval / 2)
+	latency.entered(second)
+	clock.now = clock.now.Add(latencySampleInterval)
+	latency.entered(third)
+
+	assert.NotNil(t, latency.onAcked(first))
+	assert.Nil(t, latency.onAcked(second))
+	assert.NotNil(t, latency.onAcked(third))
+}
+
+func TestPipelineLatency_Percentiles(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	latency := NewPipelineLatency(clock)
+
+	for i := range 100 {
+		row := &service.HistoryRecord{}
+		latency.entered(row)
+		clock.now = clock.now.Add(time.Duration(i+1) * time.Millisecond)
+		latency.onAcked(row)()
+		clock.now = clock.now.Add(latencySampleInterval)
+	}
+
+	stats := latency.Proto().GetAcknowledged()
+	assert.EqualValues(t, 100, stats.GetSamples())
+	assert.InDelta(t, 0.050, stats.GetP50Seconds(), 0.001)
+	assert.InDelta(t, 0.095, stats.GetP95Seconds(), 0.001)
+	assert.Nil(t, latency.Proto().GetPersisted())
+	assert.Len(t, latency.pending, maxPendingLatencySamples)
+}
+
+func TestPipelineLatency_Nil(t *testing.T) {
+	var latency *PipelineLatency
+
+	latency.entered(&service.HistoryRecord{})
+	latency.persisted(&service.HistoryRecord{})
+
+	assert.Nil(t, latency.onAcked(&service.HistoryRecord{}))
+	assert.Nil(t, latency.Proto())
+}
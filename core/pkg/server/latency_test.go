@@ -0,0 +1,108 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// pipelineLatency asks the stream for its status and returns the
+// measured latencies.
+func pipelineLatency(
+	t *testing.T,
+	stream *server.Stream,
+	responses chanResponder,
+) *service.PipelineLatency {
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Status{
+					Status: &service.StatusRequest{},
+				},
+			},
+		},
+		Control: &service.Control{ConnectionId: "client"},
+		Uuid:    "status",
+	})
+
+	for {
+		select {
+		case result := <-responses:
+			if status := result.GetResponse().GetStatusResponse(); status != nil {
+				return status.GetDiagnostics().GetPipelineLatency()
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("status request wasn't answered")
+			return nil
+		}
+	}
+}
+
+// A row's latency includes the time the writer and the backend take.
+func TestStream_MeasuresPipelineLatency(t *testing.T) {
+	const writerDelay = 50 * time.Millisecond
+	const backendDelay = 300 * time.Millisecond
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	for range 5 {
+		backend.InjectFault(servertest.RouteFileStream,
+			servertest.Fault{Delay: backendDelay})
+	}
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:           &wrapperspb.StringValue{Value: "latency"},
+		BaseUrl:         &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:          &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:          &wrapperspb.StringValue{Value: dir},
+		LogInternal:     &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:        &wrapperspb.StringValue{Value: filepath.Join(dir, "run-latency.wandb")},
+		FilesDir:        &wrapperspb.StringValue{Value: dir},
+		XDisableStats:   &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:    &wrapperspb.BoolValue{Value: true},
+		XFaultInjection: &wrapperspb.StringValue{Value: "writer-delay=50ms"},
+
+		XFileStreamTransmitIntervalSeconds: &wrapperspb.DoubleValue{Value: 0.05},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	defer stream.FinishAndClose(0)
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "latency", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+
+	var latency *service.PipelineLatency
+	require.Eventually(t,
+		func() bool {
+			latency = pipelineLatency(t, stream, responses)
+			return latency.GetAcknowledged().GetSamples() > 0
+		},
+		10*time.Second, 50*time.Millisecond)
+
+	assert.EqualValues(t, 1, latency.GetPersisted().GetSamples())
+	assert.GreaterOrEqual(t,
+		latency.GetPersisted().GetP50Seconds(), writerDelay.Seconds())
+	assert.EqualValues(t, 1, latency.GetAcknowledged().GetSamples())
+	assert.GreaterOrEqual(t,
+		latency.GetAcknowledged().GetP50Seconds(), backendDelay.Seconds())
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// syncDigestLog syncs the transaction log of the run "digest" with the
+// request and returns the sync's response.
+func syncDigestLog(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	syncFile string,
+	request *service.SyncRequest,
+) *service.SyncResponse {
+	t.Helper()
+	dir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "digest"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	sync.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	sync.Start()
+	defer sync.Close()
+
+	sync.HandleRecord(disabledRequest("sync", &service.Request{
+		RequestType: &service.Request_Sync{Sync: request},
+	}))
+	return awaitResult(t, responses, "sync").GetResponse().GetSyncResponse()
+}
+
+// A finished run records the digest of its transaction log, and a log that
+// changed afterwards is only synced if the user allows it.
+func TestLogDigest_RecordedAndVerified(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubCreateRunFiles()
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-digest.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "digest"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 64)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "digest", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	awaitResult(t, responses, "run")
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		step:  0,
+	}))
+	stream.FinishAndClose(0)
+
+	contents, err := os.ReadFile(syncFile)
+	require.NoError(t, err)
+	sum := sha256.Sum256(contents)
+	digest, err := transactionlog.ReadDigest(syncFile)
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	assert.Equal(t, hex.EncodeToString(sum[:]), digest.SHA256)
+	assert.EqualValues(t, len(contents), digest.Bytes)
+	assert.EqualValues(t, len(readLog(t, syncFile)), digest.Records)
+
+	// append a record, as if the log were edited after the run finished
+	records := readLog(t, syncFile)
+	records = append(records, makeHistoryRecord(data{
+		items: map[string]string{"loss": "0.25"},
+		step:  1,
+	}))
+	writeEverything(t, syncFile, records)
+
+	refusedBackend := servertest.NewFakeBackend()
+	defer refusedBackend.Close()
+	refusedBackend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	refused := syncDigestLog(t, refusedBackend, syncFile, &service.SyncRequest{})
+
+	assert.Contains(t, refused.GetError().GetMessage(), "changed after the run finished")
+	assert.Contains(t, refused.GetError().GetMessage(), "--allow-modified-log")
+	assert.Empty(t, refusedBackend.GraphQLRequests("UpsertBucket"))
+
+	allowedBackend := servertest.NewFakeBackend()
+	defer allowedBackend.Close()
+	allowedBackend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	allowedBackend.StubCreateRunFiles()
+	allowed := syncDigestLog(t, allowedBackend, syncFile,
+		&service.SyncRequest{AllowModifiedLog: true})
+
+	assert.Nil(t, allowed.GetError())
+	assert.NotEmpty(t, allowedBackend.GraphQLRequests("UpsertBucket"))
+}
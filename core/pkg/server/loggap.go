@@ -0,0 +1,163 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+)
+
+const (
+	// logWriteAttempts is how many times a write to the transaction log is
+	// attempted before the writer gives up on the log.
+	logWriteAttempts = 3
+
+	// logWriteRetryWait is how long to wait between attempts to write to
+	// the transaction log.
+	logWriteRetryWait = 50 * time.Millisecond
+
+	// logGapSuffix is appended to the path of a transaction log to name
+	// the file recording that the log is incomplete.
+	logGapSuffix = ".gap"
+)
+
+// logGap records that a transaction log is missing records because the
+// writer stopped saving them.
+type logGap struct {
+	// FirstMissing is the number of the first record that may be missing.
+	//
+	// All records from then on are missing, except for any that were
+	// already flushed.
+	FirstMissing int64 `json:"first_missing"`
+
+	// NoSpace is whether the records are missing because the disk was
+	// full.
+	NoSpace bool `json:"no_space"`
+
+	// Error is the error that stopped the writer.
+	Error string `json:"error"`
+}
+
+// Err describes the gap as an error.
+func (g *logGap) Err() error {
+	return fmt.Errorf(
+		"transaction log is incomplete from record %d: %s",
+		g.FirstMissing,
+		g.Error,
+	)
+}
+
+// writeLogGap records the gap next to the transaction log at the path.
+func writeLogGap(path string, gap *logGap) error {
+	data, err := json.Marshal(gap)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(path+logGapSuffix, data, 0666)
+}
+
+// readLogGap returns the gap recorded for the transaction log at the path,
+// or nil if the log is complete.
+func readLogGap(path string) (*logGap, error) {
+	gap := &logGap{}
+	found, err := atomicfile.ReadJSON(path+logGapSuffix, gap)
+	if err != nil || !found {
+		return nil, err
+	}
+	return gap, nil
+}
+
+// retryingWriter retries failed writes to a transaction log a few times,
+// in case the failure is brief, like a full disk that's being cleaned up.
+type retryingWriter struct {
+	w io.Writer
+}
+
+func (r retryingWriter) Write(p []byte) (int, error) {
+	written := 0
+	for attempt := 1; ; attempt++ {
+		n, err := r.w.Write(p[written:])
+		written += n
+		if err == nil || attempt == logWriteAttempts {
+			return written, err
+		}
+		time.Sleep(logWriteRetryWait)
+	}
+}
+
+// stopStoring switches the writer to forwarding records without saving
+// them, after the transaction log failed.
+//
+// The run's data still reaches the server, but the log can't be used to
+// sync the run, which is recorded next to the log and shown to the user.
+func (w *Writer) stopStoring(err error) {
+	if w.storeFailed.Swap(true) {
+		return
+	}
+
+	path := w.settings.GetSyncFile().GetValue()
+	w.gap = &logGap{
+		FirstMissing: w.lastFlushedNum.Load() + 1,
+		NoSpace:      errors.Is(err, syscall.ENOSPC),
+		Error:        err.Error(),
+	}
+
+	var warning string
+	if w.gap.NoSpace {
+		w.logger.CaptureError(
+			"writer: disk full, no longer saving to the transaction log", err,
+			"path", path,
+			"firstMissing", w.gap.FirstMissing,
+		)
+		warning = fmt.Sprintf(
+			"The disk is full, so W&B stopped saving this run to %s."+
+				" Its data is still being uploaded, but the run can't be"+
+				" synced from that file.",
+			path,
+		)
+	} else {
+		w.logger.CaptureError(
+			"writer: I/O error, no longer saving to the transaction log", err,
+			"path", path,
+			"firstMissing", w.gap.FirstMissing,
+		)
+		warning = fmt.Sprintf(
+			"W&B couldn't write to %s (%v), so it stopped saving this run"+
+				" there. Its data is still being uploaded, but the run can't"+
+				" be synced from that file.",
+			path,
+			err,
+		)
+	}
+	if w.terminalPrinter != nil {
+		w.terminalPrinter.Write(warning)
+	}
+
+	w.saveGap()
+}
+
+// saveGap records the gap in the transaction log, unless it's saved
+// already.
+//
+// It may fail while the disk is full, so it's tried again as the writer
+// closes.
+func (w *Writer) saveGap() {
+	if w.gap == nil || w.gapSaved {
+		return
+	}
+
+	path := w.settings.GetSyncFile().GetValue()
+	if err := writeLogGap(path, w.gap); err != nil {
+		w.logger.Error(
+			"writer: can't record the gap in the transaction log",
+			"error", err,
+			"path", path+logGapSuffix,
+		)
+		return
+	}
+	w.gapSaved = true
+}
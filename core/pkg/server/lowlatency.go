@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// storedPollInterval is how often to check whether the writer stored a
+// record, while waiting for it in low-latency mode.
+const storedPollInterval = 10 * time.Millisecond
+
+// storeQueue holds the records a low-latency writer has yet to store.
+//
+// In low-latency mode, the writer hands each record to the sender before
+// saving it. The queue has no bound, so that a slow disk never holds up
+// the records on their way to the sender; they wait in memory instead.
+type storeQueue struct {
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// records are the records not yet handed to the store, oldest first
+	records []*service.Record
+
+	// closed is whether no more records will be pushed
+	closed bool
+
+	// ready is signaled when records are pushed or the queue is closed
+	ready chan struct{}
+}
+
+func newStoreQueue() *storeQueue {
+	return &storeQueue{ready: make(chan struct{}, 1)}
+}
+
+// push adds the record to the queue without blocking.
+func (q *storeQueue) push(record *service.Record) {
+	q.mu.Lock()
+	q.records = append(q.records, record)
+	q.mu.Unlock()
+	q.signal()
+}
+
+// close marks the end of the records, once they've all been pushed.
+func (q *storeQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.signal()
+}
+
+func (q *storeQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pump hands the records to the channel in order, and closes it once the
+// queue is closed and empty.
+func (q *storeQueue) pump(out chan<- *service.Record) {
+	for {
+		q.mu.Lock()
+		records := q.records
+		q.records = nil
+		closed := q.closed
+		q.mu.Unlock()
+
+		for _, record := range records {
+			out <- record
+		}
+
+		switch {
+		case len(records) > 0:
+		case closed:
+			close(out)
+			return
+		default:
+			<-q.ready
+		}
+	}
+}
+
+// WaitStored waits until the record with the number is flushed to the
+// transaction log, and returns whether it was.
+//
+// In low-latency mode, the sender may be ahead of the writer, and uses
+// this before relying on the log. It returns false early if the log
+// failed or the context is done. A nil Writer stores nothing, so there is
+// nothing to wait for.
+func (w *Writer) WaitStored(ctx context.Context, num int64) bool {
+	if w == nil || num == 0 {
+		return true
+	}
+
+	ticker := time.NewTicker(storedPollInterval)
+	defer ticker.Stop()
+	for {
+		switch {
+		case w.lastFlushedNum.Load() >= num:
+			return true
+		case w.storeFailed.Load():
+			return false
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// awaitStored waits until the writer stored the record with the number,
+// in low-latency mode, and returns whether the transaction log has it.
+func (s *Sender) awaitStored(num int64) bool {
+	if s.writer.WaitStored(s.ctx, num) {
+		return true
+	}
+	s.logger.Warn("sender: record not in the transaction log", "num", num)
+	return false
+}
@@ -0,0 +1,213 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/faults"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// readStoredRecords returns the records in the transaction log.
+func readStoredRecords(t *testing.T, syncFile string) []*service.Record {
+	t.Helper()
+	store := server.NewStore(context.Background(), syncFile, observability.NewNoOpLogger())
+	require.NoError(t, store.Open(os.O_RDONLY))
+	defer store.Close()
+	var records []*service.Record
+	for {
+		record, err := store.Read()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+// newSlowDiskStream starts an online stream whose transaction log writes
+// are slow.
+func newSlowDiskStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	syncFile string,
+	lowLatency bool,
+) *server.Stream {
+	t.Helper()
+	dir := filepath.Dir(syncFile)
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:           &wrapperspb.StringValue{Value: "latency"},
+		BaseUrl:         &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:          &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:          &wrapperspb.StringValue{Value: dir},
+		LogInternal:     &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:        &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:        &wrapperspb.StringValue{Value: dir},
+		XDisableStats:   &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:    &wrapperspb.BoolValue{Value: true},
+		XLowLatency:     &wrapperspb.BoolValue{Value: lowLatency},
+		XFaultInjection: &wrapperspb.StringValue{Value: "writer-delay=1ms"},
+	}), "")
+	require.NoError(t, err)
+	return stream
+}
+
+// Aborting a stream saves every record handled so far, whether or not the
+// writer forwards them before saving them.
+func TestLowLatency_AbortKeepsRecords(t *testing.T) {
+	const records = 200
+	for name, lowLatency := range map[string]bool{
+		"serial":      false,
+		"low latency": true,
+	} {
+		t.Run(name, func(t *testing.T) {
+			backend := servertest.NewFakeBackend()
+			defer backend.Close()
+			backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+			syncFile := filepath.Join(t.TempDir(), "run-latency.wandb")
+			stream := newSlowDiskStream(t, backend, syncFile, lowLatency)
+			stream.Start()
+
+			stream.HandleRecord(&service.Record{
+				RecordType: &service.Record_Run{
+					Run: &service.RunRecord{RunId: "latency", Project: "testProject"},
+				},
+			})
+			stream.HandleRecord(makeRunStartRecord())
+			for i := range records {
+				stream.HandleRecord(makeHistoryRecord(data{
+					items: map[string]string{"loss": "0.5"},
+					step:  int64(i),
+				}))
+			}
+			stream.Abort()
+
+			var steps []int64
+			for _, record := range readStoredRecords(t, syncFile) {
+				if history := record.GetHistory(); history != nil {
+					steps = append(steps, history.GetStep().GetNum())
+				}
+			}
+			require.Len(t, steps, records)
+			for i, step := range steps {
+				assert.EqualValues(t, i, step)
+			}
+		})
+	}
+}
+
+// In low-latency mode, the run's exit is only answered once the writer
+// has saved the records the sender got ahead of.
+func TestLowLatency_ExitWaitsForWriter(t *testing.T) {
+	const records = 100
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	syncFile := filepath.Join(t.TempDir(), "run-latency.wandb")
+	stream := newSlowDiskStream(t, backend, syncFile, true)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	defer stream.Close()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "latency", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	for i := range records {
+		stream.HandleRecord(makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5"},
+			step:  int64(i),
+		}))
+	}
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	require.NotNil(t, awaitResult(t, responses, "exit").GetExitResult())
+
+	history, exited := 0, false
+	for _, record := range readStoredRecords(t, syncFile) {
+		switch {
+		case record.GetHistory() != nil:
+			history++
+		case record.GetExit() != nil:
+			exited = true
+		}
+	}
+	assert.Equal(t, records, history)
+	assert.True(t, exited)
+}
+
+// BenchmarkWriter_ForwardLatency measures how long records take to reach
+// the sender while transaction log writes are slow.
+func BenchmarkWriter_ForwardLatency(b *testing.B) {
+	for name, lowLatency := range map[string]bool{
+		"serial":      false,
+		"low latency": true,
+	} {
+		b.Run(name, func(b *testing.B) {
+			injector, err := faults.Parse("writer-delay=20us")
+			require.NoError(b, err)
+			inChan := make(chan *service.Record, server.BufferSize)
+			fwdChan := make(chan *service.Record, server.BufferSize)
+			writer := server.NewWriter(context.Background(), &server.WriterParams{
+				Logger: observability.NewNoOpLogger(),
+				Settings: &service.Settings{
+					SyncFile:    wrapperspb.String(filepath.Join(b.TempDir(), "run.wandb")),
+					XLowLatency: wrapperspb.Bool(lowLatency),
+				},
+				FwdChan:       fwdChan,
+				FaultInjector: injector,
+			})
+			written := make(chan struct{})
+			go func() {
+				writer.Do(inChan)
+				close(written)
+			}()
+
+			sent := make([]time.Time, b.N)
+			var total time.Duration
+			done := make(chan struct{})
+			go func() {
+				i := 0
+				for range fwdChan {
+					total += time.Since(sent[i])
+					i++
+				}
+				close(done)
+			}()
+
+			b.ResetTimer()
+			for i := range b.N {
+				sent[i] = time.Now()
+				inChan <- makeHistoryRecord(data{
+					items: map[string]string{"loss": "0.5"},
+					step:  int64(i),
+				})
+			}
+			close(inChan)
+			<-done
+			b.StopTimer()
+			b.ReportMetric(float64(total.Nanoseconds())/float64(b.N), "ns/forward")
+			<-written
+		})
+	}
+}
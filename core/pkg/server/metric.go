@@ -2,7 +2,7 @@ package server
 
 import (
 	"errors"
-	"path/filepath"
+	"slices"
 
 	"github.com/wandb/wandb/core/internal/corelib"
 	"github.com/wandb/wandb/core/pkg/service"
@@ -12,13 +12,45 @@ import (
 type MetricHandler struct {
 	definedMetrics map[string]*service.MetricRecord
 	globMetrics    map[string]*service.MetricRecord
+
+	// aggregates summarize the history of metrics by key, for metrics
+	// that say how to summarize them
+	aggregates map[string]*metricAggregate
+
+	// stepValues are the latest values logged of the metrics that are the
+	// step metric of another, or empty if none was logged yet
+	stepValues map[string]string
 }
 
 func NewMetricHandler() *MetricHandler {
 	return &MetricHandler{
 		definedMetrics: make(map[string]*service.MetricRecord),
 		globMetrics:    make(map[string]*service.MetricRecord),
+		aggregates:     make(map[string]*metricAggregate),
+		stepValues:     make(map[string]string),
+	}
+}
+
+// allMetrics returns copies of the defined and glob metrics, ordered by
+// name.
+func (mh *MetricHandler) allMetrics() []*service.MetricRecord {
+	metrics := make([]*service.MetricRecord, 0,
+		len(mh.definedMetrics)+len(mh.globMetrics))
+	for _, byName := range []map[string]*service.MetricRecord{
+		mh.definedMetrics,
+		mh.globMetrics,
+	} {
+		names := make([]string, 0, len(byName))
+		for name := range byName {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			metrics = append(metrics,
+				proto.Clone(byName[name]).(*service.MetricRecord))
+		}
 	}
+	return metrics
 }
 
 // addMetric adds a metric to the target map. If the metric already exists, it will be merged
@@ -54,10 +86,7 @@ func addMetric(arg interface{}, key string, target *map[string]*service.MetricRe
 // based on the glob metric and return it.
 func (mh *MetricHandler) createMatchingGlobMetric(key string) *service.MetricRecord {
 	for pattern, globMetric := range mh.globMetrics {
-		if match, err := filepath.Match(pattern, key); err != nil {
-			// h.logger.CaptureError("error matching metric", err)
-			continue
-		} else if match {
+		if matchesMetricGlob(pattern, key) {
 			metric := proto.Clone(globMetric).(*service.MetricRecord)
 			metric.Name = key
 			metric.Options.Defined = false
@@ -0,0 +1,335 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// The keys of the run's history, summary and metric definitions are
+// normalized as they enter the handler, so that a key means the same thing
+// wherever it's used:
+//
+//   - A key is valid UTF-8 in Unicode normal form C, without control
+//     characters, which become underscores, or surrounding spaces.
+//   - Slashes group metrics into sections, and dots separate the levels
+//     of nested summary keys: "eval.acc" is "acc" in the "eval" map of the
+//     summary, whether it was set or logged. Repeated slashes and dots, and
+//     those at either end, would make empty sections and levels, and are
+//     removed.
+//   - The parts of a nested key are split at their dots the same way.
+//   - "_step", "_runtime" and "_wandb" belong to the core, and values set
+//     for them are dropped. Other keys starting with an underscore, such
+//     as "_timestamp", are the user's.
+//   - A key that's empty once normalized is dropped.
+//
+// The user is warned once about each key that's changed or dropped.
+
+// maxWarnedMetricKeys is how many keys the handler remembers warning
+// about, which bounds its memory for runs logging many bad keys.
+const maxWarnedMetricKeys = 1000
+
+// reservedMetricKeys are the top-level keys that the core sets.
+var reservedMetricKeys = map[string]struct{}{
+	"_runtime": {},
+	"_step":    {},
+	"_wandb":   {},
+}
+
+// metricKeyRule is a step of normalizing a metric key.
+type metricKeyRule struct {
+	// apply returns the key with the rule applied
+	apply func(string) string
+
+	// change describes what applying the rule changed
+	change string
+}
+
+// metricKeyRules are the steps of normalizing a key, in order.
+//
+// A single pass through them normalizes any key.
+var metricKeyRules = []metricKeyRule{
+	{
+		apply:  func(key string) string { return strings.ToValidUTF8(key, "\uFFFD") },
+		change: "replaced invalid UTF-8",
+	},
+	{
+		apply:  norm.NFC.String,
+		change: "composed its Unicode characters",
+	},
+	{
+		apply: func(key string) string {
+			return strings.TrimFunc(key, func(r rune) bool {
+				return unicode.IsSpace(r) || r == '/' || r == '.'
+			})
+		},
+		change: "trimmed spaces, slashes and dots around it",
+	},
+	{
+		apply: func(key string) string {
+			return strings.Map(func(r rune) rune {
+				if unicode.IsControl(r) {
+					return '_'
+				}
+				return r
+			}, key)
+		},
+		change: "replaced control characters with underscores",
+	},
+	{
+		apply:  func(key string) string { return collapseRepeats(key, "/") },
+		change: "removed repeated slashes",
+	},
+	{
+		apply:  func(key string) string { return collapseRepeats(key, ".") },
+		change: "removed repeated dots",
+	},
+}
+
+// collapseRepeats replaces the runs of the separator in the key with one.
+func collapseRepeats(key, sep string) string {
+	double := sep + sep
+	for strings.Contains(key, double) {
+		key = strings.ReplaceAll(key, double, sep)
+	}
+	return key
+}
+
+// isNormalMetricKey is whether the key is printable ASCII that needs no
+// normalizing, which most keys are.
+func isNormalMetricKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] >= 0x7f {
+			return false
+		}
+	}
+	first, last := key[0], key[len(key)-1]
+	return !strings.ContainsRune(" /.", rune(first)) &&
+		!strings.ContainsRune(" /.", rune(last)) &&
+		!strings.Contains(key, "//") &&
+		!strings.Contains(key, "..")
+}
+
+// normalizeMetricKey returns the normalized key, and what normalizing it
+// changed.
+func normalizeMetricKey(key string) (string, []string) {
+	if isNormalMetricKey(key) {
+		return key, nil
+	}
+
+	var changes []string
+	for _, rule := range metricKeyRules {
+		if normalized := rule.apply(key); normalized != key {
+			key = normalized
+			changes = append(changes, rule.change)
+		}
+	}
+	return key, changes
+}
+
+// normalizeNestedMetricKey returns the normalized parts of a nested key,
+// split at their dots, and what normalizing them changed.
+func normalizeNestedMetricKey(nestedKey []string) ([]string, []string) {
+	normalized := make([]string, 0, len(nestedKey))
+	var changes []string
+	for _, part := range nestedKey {
+		part, partChanges := normalizeMetricKey(part)
+		if part == "" {
+			partChanges = append(partChanges, "removed empty levels")
+		}
+		for _, change := range partChanges {
+			if !slices.Contains(changes, change) {
+				changes = append(changes, change)
+			}
+		}
+
+		if part != "" {
+			normalized = append(normalized, strings.Split(part, ".")...)
+		}
+	}
+	return normalized, changes
+}
+
+// isReservedMetricKey is whether the top level of a normalized key is set
+// by the core.
+func isReservedMetricKey(key string, nestedKey []string) bool {
+	top := key
+	if len(nestedKey) > 0 {
+		top = nestedKey[0]
+	} else if i := strings.IndexByte(key, '.'); i >= 0 {
+		top = key[:i]
+	}
+	_, reserved := reservedMetricKeys[top]
+	return reserved
+}
+
+// normalizeItemKey returns the normalized key or nested key of a history
+// or summary item, and what was wrong with it, or "" if nothing was.
+//
+// Both are empty if the item is dropped.
+func normalizeItemKey(
+	key string,
+	nestedKey []string,
+) (string, []string, string) {
+	var changes []string
+	if len(nestedKey) > 0 {
+		nestedKey, changes = normalizeNestedMetricKey(nestedKey)
+		if len(nestedKey) == 0 {
+			return "", nil, "it's empty once normalized"
+		}
+	} else {
+		key, changes = normalizeMetricKey(key)
+		if key == "" {
+			return "", nil, "it's empty once normalized"
+		}
+	}
+
+	if isReservedMetricKey(key, nestedKey) {
+		return "", nil, "it's reserved for W&B"
+	}
+	return key, nestedKey, strings.Join(changes, ", ")
+}
+
+// metricKeyWarnings are the keys the user was warned about.
+type metricKeyWarnings struct {
+	warned map[string]struct{}
+
+	// full is whether no more keys are remembered
+	full bool
+}
+
+// warnMetricKey tells the user the first time that the key was changed or
+// dropped, and why.
+func (h *Handler) warnMetricKey(key, normalized, problem string) {
+	w := &h.metricKeyWarnings
+	if _, ok := w.warned[key]; ok || w.full {
+		return
+	}
+	if w.warned == nil {
+		w.warned = make(map[string]struct{})
+	}
+
+	if len(w.warned) >= maxWarnedMetricKeys {
+		w.full = true
+		h.logger.Warn(
+			"handler: too many invalid metric keys to warn about",
+			"max", maxWarnedMetricKeys,
+		)
+		h.terminalPrinter.Write(fmt.Sprintf(
+			"The run logged more than %d invalid metric keys;"+
+				" later ones are changed or dropped without a warning.",
+			maxWarnedMetricKeys,
+		))
+		return
+	}
+	w.warned[key] = struct{}{}
+
+	if normalized == "" {
+		h.logger.Warn("handler: dropped metric key", "key", key, "problem", problem)
+		h.terminalPrinter.Write(fmt.Sprintf(
+			"Dropped the values of the metric key %q: %s.", key, problem))
+	} else {
+		h.logger.Warn(
+			"handler: normalized metric key",
+			"key", key,
+			"normalized", normalized,
+			"changes", problem,
+		)
+		h.terminalPrinter.Write(fmt.Sprintf(
+			"Saved the metric key %q as %q: %s.", key, normalized, problem))
+	}
+}
+
+// keepNormalizedItem normalizes the key or nested key of a history or
+// summary item the user made, warning about it if it changed, and returns
+// whether the item is kept.
+func (h *Handler) keepNormalizedItem(key *string, nestedKey *[]string) bool {
+	normalizedKey, normalizedNestedKey, problem := normalizeItemKey(*key, *nestedKey)
+	if problem != "" {
+		h.warnMetricKey(
+			summaryKey(*key, *nestedKey),
+			summaryKey(normalizedKey, normalizedNestedKey),
+			problem,
+		)
+	}
+	*key, *nestedKey = normalizedKey, normalizedNestedKey
+	return normalizedKey != "" || len(normalizedNestedKey) > 0
+}
+
+// normalizeHistoryItems normalizes the keys of the items that the user
+// logged, dropping those without a valid one.
+func (h *Handler) normalizeHistoryItems(
+	items []*service.HistoryItem,
+) []*service.HistoryItem {
+	return slices.DeleteFunc(items, func(item *service.HistoryItem) bool {
+		return !h.keepNormalizedItem(&item.Key, &item.NestedKey)
+	})
+}
+
+// normalizeSummary normalizes the keys of a summary update the user made,
+// dropping the items without a valid one.
+func (h *Handler) normalizeSummary(summary *service.SummaryRecord) {
+	drop := func(item *service.SummaryItem) bool {
+		return !h.keepNormalizedItem(&item.Key, &item.NestedKey)
+	}
+	summary.Update = slices.DeleteFunc(summary.GetUpdate(), drop)
+	summary.Remove = slices.DeleteFunc(summary.GetRemove(), drop)
+}
+
+// normalizeMetric normalizes the name, glob and step metric of a metric
+// definition, and returns whether it's still valid.
+//
+// A glob keeps its wildcards, which match normalized keys.
+func (h *Handler) normalizeMetric(metric *service.MetricRecord) bool {
+	normalize := func(key string) string {
+		if key == "" {
+			return ""
+		}
+		normalized, changes := normalizeMetricKey(key)
+		switch {
+		case normalized == "":
+			h.warnMetricKey(key, "", "it's empty once normalized")
+		case len(changes) > 0:
+			h.warnMetricKey(key, normalized, strings.Join(changes, ", "))
+		}
+		return normalized
+	}
+
+	switch {
+	case metric.GetGlobName() != "":
+		metric.GlobName = normalize(metric.GetGlobName())
+		if metric.GetGlobName() == "" {
+			return false
+		}
+	case metric.GetName() != "":
+		metric.Name = normalize(metric.GetName())
+		if metric.GetName() == "" {
+			return false
+		}
+	}
+
+	metric.StepMetric = normalize(metric.GetStepMetric())
+	return true
+}
+
+// matchesMetricGlob is whether a key matches the glob of a metric
+// definition, where "*" also matches slashes, so that "val/*" matches the
+// metrics in the "val" section and all of its subsections.
+func matchesMetricGlob(glob, key string) bool {
+	// path.Match stops "*" at slashes, so they're swapped for a character
+	// that normalized keys don't have
+	match, err := path.Match(
+		strings.ReplaceAll(glob, "/", "\x00"),
+		strings.ReplaceAll(key, "/", "\x00"),
+	)
+	return err == nil && match
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeItemKey(t *testing.T) {
+	testCases := []struct {
+		name       string
+		key        string
+		nestedKey  []string
+		wantKey    string
+		wantNested []string
+		changed    bool
+	}{
+		{name: "plain", key: "loss", wantKey: "loss"},
+		{name: "section", key: "train/loss", wantKey: "train/loss"},
+		{name: "dotted", key: "eval.acc", wantKey: "eval.acc"},
+		{name: "spaces inside", key: "train loss", wantKey: "train loss"},
+		{name: "underscore", key: "_timestamp", wantKey: "_timestamp"},
+		{name: "glob characters", key: "acc[0]*?", wantKey: "acc[0]*?"},
+		{name: "unicode", key: "précision", wantKey: "précision"},
+		{name: "emoji", key: "🚀/speed", wantKey: "🚀/speed"},
+		{
+			name:    "decomposed unicode",
+			key:     "pre\u0301cision",
+			wantKey: "précision",
+			changed: true,
+		},
+		{
+			name:    "invalid UTF-8",
+			key:     "loss\xff",
+			wantKey: "loss\uFFFD",
+			changed: true,
+		},
+		{name: "surrounding spaces", key: "  loss\t", wantKey: "loss", changed: true},
+		{name: "newline inside", key: "lo\nss", wantKey: "lo_ss", changed: true},
+		{name: "NUL", key: "lo\x00ss", wantKey: "lo_ss", changed: true},
+		{name: "DEL", key: "loss\x7f", wantKey: "loss_", changed: true},
+		{name: "leading slash", key: "/train/loss", wantKey: "train/loss", changed: true},
+		{name: "trailing slash", key: "train/", wantKey: "train", changed: true},
+		{name: "repeated slashes", key: "train//loss", wantKey: "train/loss", changed: true},
+		{name: "repeated dots", key: "eval...acc", wantKey: "eval.acc", changed: true},
+		{name: "leading dot", key: ".acc", wantKey: "acc", changed: true},
+		{name: "trailing dot", key: "acc.", wantKey: "acc", changed: true},
+		{name: "spaces then slash", key: " / loss", wantKey: "loss", changed: true},
+		{name: "mixed separators", key: "a/./b", wantKey: "a/./b"},
+		{name: "empty", key: "", changed: true},
+		{name: "only spaces", key: "   ", changed: true},
+		{name: "only separators", key: "/./", changed: true},
+		{name: "only control", key: "\x00", wantKey: "_", changed: true},
+		{name: "reserved step", key: "_step", changed: true},
+		{name: "reserved runtime", key: "_runtime", changed: true},
+		{name: "reserved wandb", key: "_wandb", changed: true},
+		{name: "inside reserved", key: "_wandb.runtime", changed: true},
+		{name: "reserved once trimmed", key: " _step ", changed: true},
+		{name: "similar to reserved", key: "_steps", wantKey: "_steps"},
+		{
+			name:       "nested",
+			nestedKey:  []string{"eval", "acc"},
+			wantNested: []string{"eval", "acc"},
+		},
+		{
+			name:       "nested with dots",
+			nestedKey:  []string{"eval.top", "acc"},
+			wantNested: []string{"eval", "top", "acc"},
+		},
+		{
+			name:       "nested with empty part",
+			nestedKey:  []string{"eval", "", " acc "},
+			wantNested: []string{"eval", "acc"},
+			changed:    true,
+		},
+		{name: "nested empty", nestedKey: []string{"", "."}, changed: true},
+		{name: "nested reserved", nestedKey: []string{"_wandb", "runtime"}, changed: true},
+		{
+			name:       "nested takes precedence",
+			key:        "ignored",
+			nestedKey:  []string{"eval"},
+			wantKey:    "ignored",
+			wantNested: []string{"eval"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, nestedKey, problem := normalizeItemKey(tc.key, tc.nestedKey)
+
+			if len(tc.wantNested) > 0 {
+				assert.Equal(t, tc.wantNested, nestedKey)
+			} else {
+				assert.Equal(t, tc.wantKey, key)
+				assert.Empty(t, nestedKey)
+			}
+			if tc.changed {
+				assert.NotEmpty(t, problem)
+			} else {
+				assert.Empty(t, problem)
+			}
+		})
+	}
+}
+
+func TestNormalizeMetricKey_Idempotent(t *testing.T) {
+	for _, key := range []string{
+		" /a//b..c/ ",
+		"a\x00\n.b",
+		"é/\xff",
+		"./ . /",
+	} {
+		normalized, _ := normalizeMetricKey(key)
+		again, changes := normalizeMetricKey(normalized)
+		assert.Equal(t, normalized, again)
+		assert.Empty(t, changes)
+	}
+}
+
+func TestMatchesMetricGlob(t *testing.T) {
+	testCases := []struct {
+		glob, key string
+		match     bool
+	}{
+		{"*", "loss", true},
+		{"*", "train/loss", true},
+		{"val/*", "val/loss", true},
+		{"val/*", "val/top/acc", true},
+		{"val/*", "train/val/loss", false},
+		{"*/loss", "train/fold/loss", true},
+		{"*_acc", "val/top1_acc", true},
+		{"acc?", "acc1", true},
+		{"a?c", "a/c", true},
+		{"acc[0-9]", "acc7", true},
+		{"eval.*", "eval.acc", true},
+		{"[", "[", false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.match, matchesMetricGlob(tc.glob, tc.key),
+			"%q matching %q", tc.glob, tc.key)
+	}
+}
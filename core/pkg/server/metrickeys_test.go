@@ -0,0 +1,213 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// metricKeysHandler is a handler for testing how it treats metric keys.
+type metricKeysHandler struct {
+	in      chan *service.Record
+	fwd     chan *service.Record
+	out     chan *service.Result
+	printer *observability.Printer
+}
+
+func startMetricKeysHandler(t *testing.T) *metricKeysHandler {
+	t.Helper()
+	h := &metricKeysHandler{
+		in:      make(chan *service.Record, server.BufferSize),
+		fwd:     make(chan *service.Record, server.BufferSize),
+		out:     make(chan *service.Result, server.BufferSize),
+		printer: observability.NewPrinter(),
+	}
+	handler := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        &service.Settings{},
+			FwdChan:         h.fwd,
+			OutChan:         h.out,
+			RunSummary:      runsummary.New(),
+			MetricHandler:   server.NewMetricHandler(),
+			TerminalPrinter: h.printer,
+		},
+	)
+	go handler.Do(h.in)
+	t.Cleanup(func() { close(h.in) })
+	return h
+}
+
+func (h *metricKeysHandler) metric(metric *service.MetricRecord) {
+	h.in <- &service.Record{
+		RecordType: &service.Record_Metric{Metric: metric},
+	}
+}
+
+func (h *metricKeysHandler) summary(items ...*service.SummaryItem) {
+	h.in <- &service.Record{
+		RecordType: &service.Record_Summary{
+			Summary: &service.SummaryRecord{Update: items},
+		},
+	}
+}
+
+// getSummary returns the run's summary, with nested keys joined by dots.
+func (h *metricKeysHandler) getSummary() map[string]string {
+	h.in <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_GetSummary{
+					GetSummary: &service.GetSummaryRequest{},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "summary"},
+	}
+	summary := make(map[string]string)
+	for result := range h.out {
+		response := result.GetResponse().GetGetSummaryResponse()
+		if response == nil {
+			continue
+		}
+		for _, item := range response.GetItem() {
+			key := item.GetKey()
+			if len(item.GetNestedKey()) > 0 {
+				key = strings.Join(item.GetNestedKey(), ".")
+			}
+			summary[key] = item.GetValueJson()
+		}
+		return summary
+	}
+	return nil
+}
+
+// historyKeys returns the keys of the forwarded history rows.
+func (h *metricKeysHandler) historyKeys() []string {
+	var keys []string
+	for {
+		select {
+		case record := <-h.fwd:
+			for _, item := range record.GetHistory().GetItem() {
+				keys = append(keys, summaryKeyOf(item.GetKey(), item.GetNestedKey()))
+			}
+		default:
+			return keys
+		}
+	}
+}
+
+func summaryKeyOf(key string, nestedKey []string) string {
+	if len(nestedKey) > 0 {
+		return strings.Join(nestedKey, ".")
+	}
+	return key
+}
+
+func TestMetricKeys_SameEverywhere(t *testing.T) {
+	h := startMetricKeysHandler(t)
+
+	h.metric(&service.MetricRecord{
+		Name:    "eval//acc",
+		Summary: &service.MetricSummary{Max: true},
+	})
+	h.metric(&service.MetricRecord{
+		GlobName: "/val/*",
+		Summary:  &service.MetricSummary{Min: true},
+		Options:  &service.MetricOptions{},
+	})
+	for i := range 3 {
+		h.in <- makePartialHistoryRecord(data{
+			items: map[string]string{
+				"eval/acc":      fmt.Sprintf("0.%d", i+1),
+				" val/top/loss": fmt.Sprint(3 - i),
+				"test..acc":     "0.5",
+				"_step":         "99",
+				"_timestamp":    "1700000000",
+			},
+			step:  int64(i),
+			flush: true,
+		})
+	}
+	h.summary(
+		&service.SummaryItem{Key: "best.score/", ValueJson: "7"},
+		&service.SummaryItem{Key: "_wandb", ValueJson: `{"runtime": 0}`},
+	)
+	h.in <- sampledHistoryRequest(&service.SampledHistoryRequest{
+		Keys: []string{"eval//acc"},
+	})
+
+	sampled := (<-h.out).GetResponse().GetSampledHistoryResponse()
+	summary := h.getSummary()
+	keys := h.historyKeys()
+
+	require.Len(t, sampled.GetItem(), 1)
+	assert.Equal(t, "eval//acc", sampled.GetItem()[0].GetKey())
+	assert.Len(t, sampled.GetItem()[0].GetValuesFloat(), 3)
+
+	assert.Contains(t, keys, "eval/acc")
+	assert.Contains(t, keys, "val/top/loss")
+	assert.Contains(t, keys, "test.acc")
+	assert.Contains(t, keys, "_timestamp")
+	assert.NotContains(t, keys, " val/top/loss")
+
+	assert.Equal(t, "0.3", summary["eval/acc.max"])
+	assert.Equal(t, "1", summary["val/top/loss.min"])
+	assert.Equal(t, "0.5", summary["test.acc"])
+	assert.Equal(t, "7", summary["best.score"])
+	assert.Equal(t, "2", summary["_step"])
+	assert.Contains(t, summary, "_wandb.runtime")
+	assert.NotContains(t, summary, "_wandb")
+}
+
+func TestMetricKeys_WarnsOncePerKey(t *testing.T) {
+	h := startMetricKeysHandler(t)
+
+	for i := range 3 {
+		h.in <- makePartialHistoryRecord(data{
+			items: map[string]string{
+				"train//loss": fmt.Sprint(i),
+				"_runtime":    "1",
+				" ":           "2",
+			},
+			step:  int64(i),
+			flush: true,
+		})
+	}
+	h.getSummary()
+
+	messages := h.printer.Read()
+	assert.Len(t, messages, 3)
+	assert.Contains(t, strings.Join(messages, "\n"),
+		`Saved the metric key "train//loss" as "train/loss"`)
+	assert.Contains(t, strings.Join(messages, "\n"),
+		`Dropped the values of the metric key "_runtime": it's reserved`)
+}
+
+func TestMetricKeys_BoundsWarnings(t *testing.T) {
+	h := startMetricKeysHandler(t)
+	var warnings atomic.Int64
+	h.printer.Observe(func(observability.UserMessage) { warnings.Add(1) })
+
+	items := make(map[string]string)
+	for i := range 2000 {
+		items[fmt.Sprintf("/key%d", i)] = "1"
+	}
+	h.in <- makePartialHistoryRecord(data{items: items, flush: true})
+	h.getSummary()
+
+	messages := h.printer.Read()
+	assert.EqualValues(t, 1001, warnings.Load())
+	assert.Contains(t, strings.Join(messages, "\n"),
+		"later ones are changed or dropped without a warning")
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// metricAggregate is what's kept of a metric's history to summarize it.
+type metricAggregate struct {
+	min, max float64
+	sum      float64
+	count    int
+
+	// best is the best value, logged at bestStep, if hasBest; the best is
+	// the max or the min as bestGoal says
+	hasBest  bool
+	best     float64
+	bestStep int64
+	bestGoal service.MetricRecord_MetricGoal
+}
+
+// aggregateProtos returns the metrics' aggregates, ordered by key, for a
+// checkpoint.
+func (mh *MetricHandler) aggregateProtos() []*service.CheckpointRecord_MetricAggregate {
+	keys := make([]string, 0, len(mh.aggregates))
+	for key := range mh.aggregates {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	aggregates := make([]*service.CheckpointRecord_MetricAggregate, 0, len(keys))
+	for _, key := range keys {
+		aggregate := mh.aggregates[key]
+		aggregates = append(aggregates, &service.CheckpointRecord_MetricAggregate{
+			Key:      key,
+			Min:      aggregate.min,
+			Max:      aggregate.max,
+			Sum:      aggregate.sum,
+			Count:    int64(aggregate.count),
+			HasBest:  aggregate.hasBest,
+			Best:     aggregate.best,
+			BestStep: aggregate.bestStep,
+			BestGoal: aggregate.bestGoal,
+		})
+	}
+	return aggregates
+}
+
+// restoreAggregates sets the metrics' aggregates from a checkpoint.
+func (mh *MetricHandler) restoreAggregates(
+	aggregates []*service.CheckpointRecord_MetricAggregate,
+) {
+	for _, aggregate := range aggregates {
+		mh.aggregates[aggregate.GetKey()] = &metricAggregate{
+			min:      aggregate.GetMin(),
+			max:      aggregate.GetMax(),
+			sum:      aggregate.GetSum(),
+			count:    int(aggregate.GetCount()),
+			hasBest:  aggregate.GetHasBest(),
+			best:     aggregate.GetBest(),
+			bestStep: aggregate.GetBestStep(),
+			bestGoal: aggregate.GetBestGoal(),
+		}
+	}
+}
+
+// hasSummaryOptions is whether the metric says how to summarize it, rather
+// than leaving the summary to be the last value logged.
+func hasSummaryOptions(metric *service.MetricRecord) bool {
+	s := metric.GetSummary()
+	return s.GetMin() || s.GetMax() || s.GetMean() ||
+		s.GetBest() || s.GetLast() || s.GetNone()
+}
+
+// restartBest forgets the best value of a metric, and returns whether it
+// had one.
+func (mh *MetricHandler) restartBest(key string) bool {
+	aggregate, ok := mh.aggregates[key]
+	if !ok || !aggregate.hasBest {
+		return false
+	}
+	aggregate.hasBest = false
+	return true
+}
+
+// summarizeMetric returns the summary updates for a history item of a
+// metric that says how to summarize it, logged in the row at the step,
+// and whether it says so.
+//
+// Such a metric's summary is a dict of its aggregates, like
+// {"max": 0.9, "mean": 0.5}, instead of its last value, unless the metric
+// also asks to copy the value. Values that aren't numbers are left out of
+// the aggregates.
+//
+// The best value is the max, or the min if the metric's goal is to
+// minimize it, and comes with the step it was logged at as "best_step".
+func (mh *MetricHandler) summarizeMetric(
+	metric *service.MetricRecord,
+	item *service.HistoryItem,
+	step int64,
+) ([]*service.SummaryItem, bool) {
+	summary := metric.GetSummary()
+	switch {
+	case !hasSummaryOptions(metric) || summary.GetCopy():
+		return nil, false
+	case summary.GetNone():
+		return nil, true
+	}
+
+	var value float64
+	if err := json.Unmarshal([]byte(item.GetValueJson()), &value); err != nil ||
+		math.IsNaN(value) {
+		return nil, true
+	}
+
+	aggregate, ok := mh.aggregates[item.GetKey()]
+	if !ok {
+		aggregate = &metricAggregate{}
+		mh.aggregates[item.GetKey()] = aggregate
+	}
+
+	var updates []*service.SummaryItem
+	update := func(name string, value any) bool {
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			// Infinities can't be encoded, and are left out.
+			return false
+		}
+		updates = append(updates, &service.SummaryItem{
+			NestedKey: append(strings.Split(item.GetKey(), "."), name),
+			ValueJson: string(valueJSON),
+		})
+		return true
+	}
+
+	first := aggregate.count == 0
+	aggregate.count++
+	aggregate.sum += value
+
+	if first || value > aggregate.max {
+		aggregate.max = value
+		if summary.GetMax() {
+			update("max", value)
+		}
+	}
+	if first || value < aggregate.min {
+		aggregate.min = value
+		if summary.GetMin() {
+			update("min", value)
+		}
+	}
+	if summary.GetBest() && aggregate.isBetter(value, metric.GetGoal()) &&
+		update("best", value) {
+		aggregate.hasBest = true
+		aggregate.best = value
+		aggregate.bestStep = step
+		aggregate.bestGoal = metric.GetGoal()
+		update("best_step", step)
+	}
+	if summary.GetMean() {
+		update("mean", aggregate.sum/float64(aggregate.count))
+	}
+	if summary.GetLast() {
+		update("last", value)
+	}
+
+	return updates, true
+}
+
+// isBetter is whether the value is better than the best one, for the goal.
+func (aggregate *metricAggregate) isBetter(
+	value float64,
+	goal service.MetricRecord_MetricGoal,
+) bool {
+	switch {
+	case !aggregate.hasBest:
+		return true
+	case goal == service.MetricRecord_GOAL_MINIMIZE:
+		return value < aggregate.best
+	default:
+		return value > aggregate.best
+	}
+}
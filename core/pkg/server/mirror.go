@@ -0,0 +1,381 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/bandwidth"
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/httppool"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const (
+	// mirrorQueueSize is how many records may wait for the mirror before
+	// it's considered to have fallen behind, and new ones are dropped.
+	mirrorQueueSize = 64 * BufferSize
+
+	// mirrorFinishTimeout is how long closing the stream waits for the
+	// mirror once the run itself is finished.
+	mirrorFinishTimeout = 10 * time.Minute
+)
+
+// Mirror sends a run to a second W&B server, alongside the primary one.
+//
+// This is for deployments that log runs to two servers at once, such as
+// while migrating from a self-hosted server to W&B's cloud. The mirror
+// runs its own Sender, with its own connections and retries, on copies of
+// the records that the writer passes to the primary sender. The run is
+// created on the mirror with the same ID.
+//
+// Nothing the mirror does affects the primary: records are handed to it
+// without blocking, its results are dropped, and the records it would
+// loop back to the handler are discarded, since the primary's reach it
+// anyway. Artifacts and alerts are only sent to the primary, and requests
+// from the client are only answered by it.
+//
+// The methods of a nil Mirror do nothing.
+type Mirror struct {
+	baseURL string
+	logger  *observability.CoreLogger
+
+	// printer tells the user about problems with the mirror
+	printer *observability.Printer
+
+	// sender sends the mirrored records to the mirror's server
+	sender *Sender
+
+	// records are the records waiting to be mirrored
+	records chan *service.Record
+
+	// cancel stops the mirror's requests
+	cancel context.CancelFunc
+
+	fileTransferStats filetransfer.FileTransferStats
+	deferProgress     *DeferProgress
+
+	// done is closed once the mirror's sender stops
+	done chan struct{}
+
+	// dropped counts the records not mirrored because the mirror fell
+	// behind
+	dropped atomic.Int64
+
+	// failedOnce guards failure
+	failedOnce sync.Once
+
+	// failure is why the mirror stopped, if it failed
+	failure atomic.Pointer[string]
+}
+
+// NewMirror returns the mirror of the run, or nil if it isn't mirrored.
+//
+// Offline runs and runs being synced aren't mirrored. The printer is the
+// stream's, to which the mirror's messages for the user are passed on.
+func NewMirror(
+	logger *observability.CoreLogger,
+	printer *observability.Printer,
+	s *settings.Settings,
+	connStats *httppool.Stats,
+) *Mirror {
+	baseURL := s.GetMirrorBaseURL()
+	if baseURL == "" || s.IsOffline() || s.IsSync() {
+		return nil
+	}
+
+	logger = logger.With(observability.ComponentKey, "mirror")
+	mirrorSettings := mirrorSettings(s)
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Mirror{
+		baseURL:           baseURL,
+		logger:            logger,
+		printer:           printer,
+		records:           make(chan *service.Record, mirrorQueueSize),
+		cancel:            cancel,
+		fileTransferStats: filetransfer.NewFileTransferStats(),
+		deferProgress:     NewDeferProgress(),
+		done:              make(chan struct{}),
+	}
+
+	// the mirror's messages are shown as the stream's, saying which
+	// server they're about
+	mirrorPrinter := observability.NewPrinter()
+	mirrorPrinter.Forward(func(message observability.UserMessage) bool {
+		if message.Key != "" {
+			message.Key = "mirror:" + message.Key
+		}
+		message.Text = fmt.Sprintf("Mirror %s: %s", baseURL, message.Text)
+		printer.Emit(message)
+		return true
+	})
+
+	retries := NewRetryBudget(
+		mirrorSettings.GetRetryBudgetPerMinute(),
+		waiting.NewClock(),
+		func(warning string) {
+			logger.Warn("mirror: retry budget exceeded", "warning", warning)
+		},
+	)
+	peeker := &observability.Peeker{}
+	uploadLimiter := bandwidth.NewLimiter(mirrorSettings.GetUploadBytesPerSecond())
+	fileStreamLimiter := bandwidth.NewLimiter(mirrorSettings.GetFileStreamBytesPerSecond())
+	backend := NewBackend(logger, mirrorPrinter, mirrorSettings)
+	graphqlClient := NewGraphQLClient(backend, mirrorSettings, peeker, connStats, retries)
+	fileStream := NewFileStream(
+		backend,
+		logger,
+		mirrorPrinter,
+		mirrorSettings,
+		peeker,
+		nil,
+		nil,
+		connStats,
+		retries,
+		fileStreamLimiter,
+		nil,
+	)
+	fileTransferManager := NewFileTransferManager(
+		m.fileTransferStats,
+		logger,
+		mirrorSettings,
+		nil,
+		connStats,
+		retries,
+		uploadLimiter,
+		backend.Quota(),
+	)
+
+	m.sender = NewSender(ctx, cancel, &SenderParams{
+		Logger:              logger,
+		Settings:            mirrorSettings.Proto,
+		Backend:             backend,
+		FileStream:          fileStream,
+		FileTransferManager: fileTransferManager,
+		FileTransferStats:   m.fileTransferStats,
+		UploadLimiter:       uploadLimiter,
+		FileStreamLimiter:   fileStreamLimiter,
+		RunfilesUploader: NewRunfilesUploader(
+			ctx,
+			logger,
+			mirrorSettings,
+			fileStream,
+			fileTransferManager,
+			m.fileTransferStats,
+			graphqlClient,
+			nil,
+			nil,
+		),
+		Peeker:        peeker,
+		RunSummary:    runsummary.New(),
+		GraphqlClient: graphqlClient,
+		FwdChan:       make(chan *service.Record, BufferSize),
+		OutChan:       make(chan *service.Result, BufferSize),
+		Mailbox:       mailbox.NewMailbox(),
+		DeferProgress: m.deferProgress,
+		RetryBudget:   retries,
+		Mirror:        m,
+	})
+
+	return m
+}
+
+// mirrorSettings returns the settings of the mirror's sender.
+//
+// They're the run's settings, with the mirror's server and credentials,
+// and without the work only the primary does.
+func mirrorSettings(s *settings.Settings) *settings.Settings {
+	mirrored := proto.Clone(s.Proto).(*service.Settings)
+	mirrored.BaseUrl = wrapperspb.String(s.GetMirrorBaseURL())
+	mirrored.ApiKey = wrapperspb.String(s.GetMirrorAPIKey())
+	mirrored.Anonymous = wrapperspb.String(settings.AnonymousNever)
+	mirrored.DisableJobCreation = wrapperspb.Bool(true)
+	mirrored.XVerifyOnExit = nil
+	mirrored.SyncFile = nil
+	mirrored.XExportDir = nil
+	mirrored.XMirrorBaseUrl = nil
+	mirrored.XMirrorApiKey = nil
+	return settings.From(mirrored)
+}
+
+// Tee passes on the records to the primary sender, mirroring them.
+//
+// The returned channel is closed after the input channel, after which
+// the mirror finishes sending the records it was given.
+func (m *Mirror) Tee(records <-chan *service.Record) <-chan *service.Record {
+	out := make(chan *service.Record, BufferSize)
+
+	go m.run()
+	go func() {
+		for record := range records {
+			// the copy is made before the primary gets the record, which
+			// it may modify
+			m.offer(record)
+			out <- record
+		}
+		close(out)
+		close(m.records)
+	}()
+
+	return out
+}
+
+// offer queues a copy of the record for the mirror, unless it's only for
+// the primary or the mirror fell behind.
+func (m *Mirror) offer(record *service.Record) {
+	if !isMirrored(record) {
+		return
+	}
+
+	select {
+	case m.records <- proto.Clone(record).(*service.Record):
+	default:
+		if m.dropped.Add(1) == 1 {
+			m.logger.Warn("mirror: fell behind, dropping records")
+			m.printer.Emit(observability.UserMessage{
+				Level: observability.MessageWarning,
+				Key:   "mirror-behind",
+				Text: fmt.Sprintf(
+					"Mirror %s fell behind, so some of the run's data"+
+						" won't be mirrored.",
+					m.baseURL,
+				),
+			})
+		}
+	}
+}
+
+// isMirrored returns whether the record is sent to the mirror.
+func isMirrored(record *service.Record) bool {
+	switch x := record.RecordType.(type) {
+	case *service.Record_Artifact,
+		*service.Record_UseArtifact,
+		*service.Record_LinkArtifact,
+		*service.Record_Alert:
+		return false
+	case *service.Record_Request:
+		switch x.Request.RequestType.(type) {
+		case *service.Request_RunStart, *service.Request_Defer:
+			return true
+		default:
+			return false
+		}
+	default:
+		return true
+	}
+}
+
+// run sends the mirrored records until they're closed.
+func (m *Mirror) run() {
+	defer close(m.done)
+	defer func() {
+		if err := recover(); err != nil {
+			m.logger.CaptureError("mirror: panicked", fmt.Errorf("%v", err))
+			m.fail(fmt.Errorf("internal error: %v", err))
+		}
+	}()
+
+	// the mirror's results and looped back records are discarded
+	go func() {
+		for range m.sender.outChan {
+		}
+	}()
+	go func() {
+		for {
+			select {
+			case <-m.sender.fwdChan:
+			case <-m.done:
+				return
+			}
+		}
+	}()
+
+	m.sender.Do(m.records)
+}
+
+// fail stops mirroring the run after an error.
+func (m *Mirror) fail(err error) {
+	if m == nil {
+		return
+	}
+
+	m.failedOnce.Do(func() {
+		message := err.Error()
+		m.failure.Store(&message)
+		m.logger.Error("mirror: stopped mirroring", "error", err)
+		m.printer.Emit(observability.UserMessage{
+			Level: observability.MessageWarning,
+			Key:   "mirror-failed",
+			Text: fmt.Sprintf(
+				"Stopped mirroring the run to %s: %v",
+				m.baseURL,
+				err,
+			),
+		})
+		m.sender.abandon()
+	})
+}
+
+// Finish waits for the mirror to send the records it was given, up to
+// the timeout, after which it's abandoned.
+//
+// The records being mirrored must be closed first.
+func (m *Mirror) Finish(timeout time.Duration) {
+	if m == nil {
+		return
+	}
+
+	select {
+	case <-m.done:
+		m.cancel()
+	case <-time.After(timeout):
+		m.logger.Warn("mirror: timed out finishing", "timeout", timeout)
+		m.Abandon()
+	}
+}
+
+// Abandon stops mirroring the run without sending the rest of it.
+func (m *Mirror) Abandon() {
+	if m == nil {
+		return
+	}
+
+	m.sender.abandon()
+	m.cancel()
+}
+
+// Progress returns how far mirroring the run got, or nil if the run isn't
+// mirrored.
+func (m *Mirror) Progress() *service.MirrorProgress {
+	if m == nil {
+		return nil
+	}
+
+	progress := &service.MirrorProgress{
+		BaseUrl:        m.baseURL,
+		PusherStats:    m.fileTransferStats.GetFilesStats(),
+		ExitProgress:   m.deferProgress.Proto(),
+		DroppedRecords: m.dropped.Load(),
+	}
+	if failure := m.failure.Load(); failure != nil {
+		progress.ErrorMessage = *failure
+		progress.Done = true
+	}
+	select {
+	case <-m.done:
+		progress.Done = true
+	default:
+		progress.Done = progress.Done || m.deferProgress.Timing() != nil
+	}
+	return progress
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestIsMirrored(t *testing.T) {
+	testCases := []struct {
+		name     string
+		record   *service.Record
+		mirrored bool
+	}{
+		{"history", &service.Record{
+			RecordType: &service.Record_History{},
+		}, true},
+		{"run", &service.Record{
+			RecordType: &service.Record_Run{},
+		}, true},
+		{"artifact", &service.Record{
+			RecordType: &service.Record_Artifact{},
+		}, false},
+		{"use artifact", &service.Record{
+			RecordType: &service.Record_UseArtifact{},
+		}, false},
+		{"alert", &service.Record{
+			RecordType: &service.Record_Alert{},
+		}, false},
+		{"defer", &service.Record{
+			RecordType: &service.Record_Request{Request: &service.Request{
+				RequestType: &service.Request_Defer{},
+			}},
+		}, true},
+		{"poll exit", &service.Record{
+			RecordType: &service.Record_Request{Request: &service.Request{
+				RequestType: &service.Request_PollExit{},
+			}},
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.mirrored, isMirrored(tc.record))
+		})
+	}
+}
+
+func TestMirror_OfferDropsWhenBehind(t *testing.T) {
+	printer := observability.NewPrinter()
+	m := &Mirror{
+		baseURL: "https://mirror.example.com",
+		logger:  observability.NewNoOpLogger(),
+		printer: printer,
+		records: make(chan *service.Record, 1),
+
+		fileTransferStats: filetransfer.NewFileTransferStats(),
+		deferProgress:     NewDeferProgress(),
+		done:              make(chan struct{}),
+	}
+	history := &service.Record{
+		RecordType: &service.Record_History{
+			History: &service.HistoryRecord{Step: &service.HistoryStep{Num: 1}},
+		},
+	}
+
+	m.offer(history)
+	m.offer(history)
+	m.offer(history)
+
+	// the mirror gets a copy, so the primary may change the original
+	mirrored := <-m.records
+	assert.NotSame(t, history, mirrored)
+	assert.EqualValues(t, 1, mirrored.GetHistory().GetStep().GetNum())
+	assert.EqualValues(t, 2, m.Progress().GetDroppedRecords())
+	assert.Equal(t,
+		[]string{
+			"Mirror https://mirror.example.com fell behind," +
+				" so some of the run's data won't be mirrored.",
+		},
+		printer.Read())
+}
+
+func TestNewMirror_NotMirrored(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings *service.Settings
+	}{
+		{"no mirror", &service.Settings{}},
+		{"offline", &service.Settings{
+			XOffline:       wrapperspb.Bool(true),
+			XMirrorBaseUrl: wrapperspb.String("https://mirror.example.com"),
+			XMirrorApiKey:  wrapperspb.String("key"),
+		}},
+		{"sync", &service.Settings{
+			XSync:          wrapperspb.Bool(true),
+			XMirrorBaseUrl: wrapperspb.String("https://mirror.example.com"),
+			XMirrorApiKey:  wrapperspb.String("key"),
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMirror(
+				observability.NewNoOpLogger(),
+				observability.NewPrinter(),
+				settings.From(tc.settings),
+				nil,
+			)
+
+			assert.Nil(t, m)
+			assert.Nil(t, m.Progress())
+			m.fail(assert.AnError)
+			m.Finish(0)
+			m.Abandon()
+		})
+	}
+}
+
+func TestMirrorSettings(t *testing.T) {
+	s := settings.From(&service.Settings{
+		BaseUrl:        wrapperspb.String("https://primary.example.com"),
+		ApiKey:         wrapperspb.String("primary-key"),
+		RunId:          wrapperspb.String("run1"),
+		XExportDir:     wrapperspb.String("/export"),
+		XMirrorBaseUrl: wrapperspb.String("https://mirror.example.com"),
+		XMirrorApiKey:  wrapperspb.String("mirror-key"),
+	})
+
+	mirrored := mirrorSettings(s)
+
+	assert.Equal(t, "https://mirror.example.com", mirrored.Proto.GetBaseUrl().GetValue())
+	assert.Equal(t, "mirror-key", mirrored.GetAPIKey())
+	assert.Equal(t, "run1", mirrored.GetRunID())
+	assert.Empty(t, mirrored.GetExportDir())
+	assert.Empty(t, mirrored.GetMirrorBaseURL())
+	// the run's own settings are unchanged
+	assert.Equal(t, "https://primary.example.com", s.Proto.GetBaseUrl().GetValue())
+}
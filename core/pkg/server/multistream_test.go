@@ -0,0 +1,164 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// isolatedRun is a run logged by one of several streams of a process.
+type isolatedRun struct {
+	runID   string
+	apiKey  string
+	entity  string
+	project string
+	backend *servertest.FakeBackend
+}
+
+func newIsolatedRun(t *testing.T, runID, apiKey, entity, project string) *isolatedRun {
+	t.Helper()
+	backend := servertest.NewFakeBackend()
+	t.Cleanup(backend.Close)
+	backend.StubGraphQL("Viewer",
+		fmt.Sprintf(`{"viewer": {"id": "user-id", "entity": %q}}`, entity))
+	backend.StubGraphQL("UpsertBucket", fmt.Sprintf(`{
+		"upsertBucket": {
+			"bucket": {
+				"displayName": "FakeName",
+				"project": {"name": %q, "entity": {"name": %q}}
+			}
+		}
+	}`, project, entity))
+	backend.StubCreateRunFiles()
+	return &isolatedRun{
+		runID:   runID,
+		apiKey:  apiKey,
+		entity:  entity,
+		project: project,
+		backend: backend,
+	}
+}
+
+// log runs a stream that logs a few rows to the run without an entity,
+// so that the viewer's default entity is used.
+func (r *isolatedRun) log(t *testing.T) *service.RunUpdateResult {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: r.runID},
+		BaseUrl:       &wrapperspb.StringValue{Value: r.backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: r.apiKey},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: r.runID, Project: r.project},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	result := awaitResult(t, responses, "run").GetRunResult()
+	stream.HandleRecord(makeRunStartRecord())
+	for i := range 3 {
+		stream.HandleRecord(makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5"},
+			step:  int64(i),
+		}))
+	}
+	stream.FinishAndClose(0)
+	return result
+}
+
+// Streams of one process that log to different servers, entities and
+// projects with different API keys don't share any of them.
+func TestStreams_IsolatedPerSettings(t *testing.T) {
+	runs := []*isolatedRun{
+		newIsolatedRun(t, "run-a", "key-a", "entity-a", "project-a"),
+		newIsolatedRun(t, "run-b", "key-b", "entity-b", "project-b"),
+	}
+
+	results := make([]*service.RunUpdateResult, len(runs))
+	var wg sync.WaitGroup
+	for i, run := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run.log(t)
+		}()
+	}
+	wg.Wait()
+
+	for i, run := range runs {
+		require.Nil(t, results[i].GetError())
+		assert.Equal(t, run.entity, results[i].GetRun().GetEntity())
+		assert.Equal(t, run.project, results[i].GetRun().GetProject())
+
+		requests := run.backend.Requests(servertest.RouteGraphQL)
+		requests = append(requests,
+			run.backend.Requests(servertest.RouteFileStream)...)
+		require.NotEmpty(t, run.backend.Requests(servertest.RouteFileStream))
+		for _, request := range requests {
+			_, password, ok := (&http.Request{Header: request.Header}).BasicAuth()
+			assert.True(t, ok, "request to %s without credentials", request.Path)
+			assert.Equal(t, run.apiKey, password, "request to %s", request.Path)
+
+			if request.Route == servertest.RouteFileStream {
+				assert.Equal(t,
+					fmt.Sprintf("/files/%s/%s/%s/file_stream",
+						run.entity, run.project, run.runID),
+					request.Path)
+			}
+			for _, other := range runs {
+				if other == run {
+					continue
+				}
+				assert.NotContains(t, string(request.Body), other.runID)
+				assert.NotContains(t, string(request.Body), other.project)
+			}
+		}
+	}
+}
+
+// The default entity of an API key is looked up on each server it's used
+// with, rather than reused from another server.
+func TestStreams_DefaultEntityCachedPerServer(t *testing.T) {
+	runs := []*isolatedRun{
+		newIsolatedRun(t, "run-c", "same-key", "entity-c", "project-c"),
+		newIsolatedRun(t, "run-d", "same-key", "entity-d", "project-d"),
+	}
+
+	for _, run := range runs {
+		result := run.log(t)
+
+		require.Nil(t, result.GetError())
+		upserts := run.backend.GraphQLRequests("UpsertBucket")
+		require.NotEmpty(t, upserts)
+		assert.True(t,
+			strings.Contains(string(upserts[0].Body),
+				fmt.Sprintf(`"entity":%q`, run.entity)),
+			"upserted to the wrong entity: %s", upserts[0].Body)
+	}
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// maxOfflineMessages is how many distinct messages of an offline run are
+// kept, after which the rest are only counted.
+const maxOfflineMessages = 100
+
+// OfflineMessages keeps the messages for the user of an offline run.
+//
+// An offline run may have no terminal to show messages in, and there's no
+// backend to record them, so warnings such as about skipped files or rate
+// limits would otherwise be lost. The handler saves the messages to the
+// transaction log, from which syncing the run adds them to its console
+// logs, and they're listed in the run's footer.
+//
+// A message repeated at the same level is only kept once.
+//
+// The methods of a nil OfflineMessages do nothing.
+type OfflineMessages struct {
+	mu sync.Mutex
+
+	clock waiting.Clock
+
+	// seen are the messages kept so far
+	seen map[offlineMessageKey]struct{}
+
+	// kept are the messages kept, oldest first
+	kept []*service.UserMessageRecord
+
+	// unsaved is the number of messages at the end of kept that the
+	// handler didn't save yet
+	unsaved int
+
+	// dropped is the number of messages not kept for being over the limit
+	dropped int
+}
+
+type offlineMessageKey struct {
+	level service.UserMessageResponse_Level
+	text  string
+}
+
+func NewOfflineMessages(clock waiting.Clock) *OfflineMessages {
+	if clock == nil {
+		clock = waiting.NewClock()
+	}
+	return &OfflineMessages{
+		clock: clock,
+		seen:  make(map[offlineMessageKey]struct{}),
+	}
+}
+
+// Add keeps a message emitted for the user, unless it's a repeat or over
+// the limit.
+//
+// It's meant to be given to [observability.Printer.Save].
+func (m *OfflineMessages) Add(message observability.UserMessage) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := offlineMessageKey{level: userMessageLevel(message.Level), text: message.Text}
+	if _, seen := m.seen[key]; seen {
+		return
+	}
+	if len(m.kept) >= maxOfflineMessages {
+		m.dropped++
+		return
+	}
+
+	m.seen[key] = struct{}{}
+	m.kept = append(m.kept, &service.UserMessageRecord{
+		Level:     key.level,
+		Message:   key.text,
+		Timestamp: timestamppb.New(m.clock.Now()),
+	})
+	m.unsaved++
+}
+
+// takeUnsaved returns the messages kept since it was last called.
+func (m *OfflineMessages) takeUnsaved() []*service.UserMessageRecord {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unsaved := m.kept[len(m.kept)-m.unsaved:]
+	m.unsaved = 0
+	return unsaved
+}
+
+// Kept returns the messages kept, and how many more weren't because they
+// were over the limit.
+func (m *OfflineMessages) Kept() ([]*service.UserMessageRecord, int) {
+	if m == nil {
+		return nil, 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.kept, m.dropped
+}
+
+// saveOfflineMessages passes on the messages for the user kept since the
+// last record, for the writer to save them to the transaction log.
+func (h *Handler) saveOfflineMessages() {
+	for _, message := range h.offlineMessages.takeUnsaved() {
+		h.fwdRecord(&service.Record{
+			RecordType: &service.Record_UserMessage{UserMessage: message},
+		})
+	}
+}
+
+// sendUserMessage adds a message saved by an offline run to the console
+// logs of the run being synced, as a terminal would have shown it.
+func (s *Sender) sendUserMessage(record *service.Record, message *service.UserMessageRecord) {
+	prefix := "wandb: "
+	switch message.GetLevel() {
+	case service.UserMessageResponse_WARNING:
+		prefix += "WARNING "
+	case service.UserMessageResponse_ERROR:
+		prefix += "ERROR "
+	}
+
+	s.sendOutputRaw(record, &service.OutputRawRecord{
+		OutputType: service.OutputRawRecord_STDERR,
+		Timestamp:  message.GetTimestamp(),
+		Line:       prefix + message.GetMessage() + "\n",
+	})
+}
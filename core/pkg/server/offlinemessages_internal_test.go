@@ -0,0 +1,40 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+func TestOfflineMessages_DeduplicatesAndCaps(t *testing.T) {
+	messages := NewOfflineMessages(nil)
+
+	messages.Add(observability.UserMessage{Level: observability.MessageWarning, Text: "same"})
+	messages.Add(observability.UserMessage{Level: observability.MessageWarning, Text: "same"})
+	messages.Add(observability.UserMessage{Level: observability.MessageError, Text: "same"})
+	assert.Len(t, messages.takeUnsaved(), 2)
+
+	for i := range maxOfflineMessages {
+		messages.Add(observability.UserMessage{Text: fmt.Sprintf("message %d", i)})
+	}
+	assert.Len(t, messages.takeUnsaved(), maxOfflineMessages-2)
+	assert.Empty(t, messages.takeUnsaved())
+
+	kept, dropped := messages.Kept()
+	assert.Len(t, kept, maxOfflineMessages)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, "same", kept[0].GetMessage())
+}
+
+func TestOfflineMessages_NilKeepsNothing(t *testing.T) {
+	var messages *OfflineMessages
+	messages.Add(observability.UserMessage{Text: "lost"})
+
+	kept, dropped := messages.Kept()
+	assert.Empty(t, kept)
+	assert.Zero(t, dropped)
+	assert.Empty(t, messages.takeUnsaved())
+}
@@ -0,0 +1,136 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// The warnings of an offline run are passed on to be saved once each.
+func TestHandler_SavesOfflineMessages(t *testing.T) {
+	inChan := make(chan *service.Record, 8)
+	fwdChan := make(chan *service.Record, 16)
+	printer := observability.NewPrinter()
+	messages := server.NewOfflineMessages(nil)
+	printer.Save(messages.Add)
+	handler := server.NewHandler(context.Background(), &server.HandlerParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{},
+		FwdChan:         fwdChan,
+		OutChan:         make(chan *service.Result, 8),
+		TerminalPrinter: printer,
+		HistoryLimiter: server.NewHistoryLimiter(1,
+			&tickingClock{now: time.Unix(1000, 0)}),
+		OfflineMessages: messages,
+	})
+
+	done := make(chan struct{})
+	go func() { handler.Do(inChan); close(done) }()
+	for i := range 4 {
+		inChan <- makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5"},
+			step:  int64(i),
+		})
+	}
+	printer.Write("a file was skipped")
+	printer.Write("a file was skipped")
+	inChan <- makeHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		step:  4,
+	})
+	close(inChan)
+	<-done
+
+	var saved []string
+	for record := range fwdChan {
+		if message := record.GetUserMessage(); message != nil {
+			assert.Equal(t, service.UserMessageResponse_WARNING, message.GetLevel())
+			assert.NotNil(t, message.GetTimestamp())
+			saved = append(saved, message.GetMessage())
+		}
+	}
+	require.Len(t, saved, 2)
+	assert.Contains(t, saved, "a file was skipped")
+	assert.Contains(t, strings.Join(saved, "\n"), "limit of 1 rows per second")
+
+	kept, dropped := messages.Kept()
+	assert.Len(t, kept, 2)
+	assert.Zero(t, dropped)
+}
+
+// Syncing an offline run adds its saved messages to the run's console
+// logs.
+func TestSync_SendsOfflineMessagesToConsoleLogs(t *testing.T) {
+	syncFile := filepath.Join(t.TempDir(), "run-messages.wandb")
+	writeEverything(t, syncFile, []*service.Record{
+		{
+			RecordType: &service.Record_Run{
+				Run: &service.RunRecord{RunId: "messages", Project: "testProject"},
+			},
+		},
+		{
+			RecordType: &service.Record_UserMessage{
+				UserMessage: &service.UserMessageRecord{
+					Level:     service.UserMessageResponse_WARNING,
+					Message:   "a file was skipped",
+					Timestamp: timestamppb.New(time.Unix(1000, 0)),
+				},
+			},
+		},
+		{RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}}},
+	})
+
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	dir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "messages"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	var output []string
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		output = append(output, body.Files["output.log"].Content...)
+	}
+	assert.Contains(t, strings.Join(output, "\n"), "wandb: WARNING a file was skipped")
+}
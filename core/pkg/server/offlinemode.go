@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+const (
+	// heldRecordsPollInterval is how often to reread the transaction log
+	// while waiting for the writer to flush held records.
+	heldRecordsPollInterval = 10 * time.Millisecond
+
+	// heldRecordsTimeout is how long to wait for held records to appear
+	// in the transaction log before giving up on them.
+	heldRecordsTimeout = time.Minute
+)
+
+// offlineHold is what the sender tracks while it holds back records
+// because the network is unavailable.
+//
+// Held records are already in the transaction log, or about to be in
+// low-latency mode, so nothing but their numbers is kept in memory.
+type offlineHold struct {
+	// highWater is the number of the last stored record sent before the
+	// network became unavailable.
+	highWater int64
+
+	// lastHeld is the number of the last record held back, or 0.
+	lastHeld int64
+
+	// sentAnyway are the numbers of the records after highWater that were
+	// processed while offline, like the run's exit.
+	sentAnyway map[int64]struct{}
+
+	// caughtUp is closed when the sender stops holding records.
+	caughtUp chan struct{}
+}
+
+// holdIfOffline returns whether to hold back the record because the
+// network is unavailable.
+//
+// Once the network is back, the next stored record makes the sender first
+// catch up on the held records, reading them from the transaction log.
+func (s *Sender) holdIfOffline(record *service.Record) bool {
+	if s.backend == nil || s.settings.GetXSync().GetValue() {
+		return false
+	}
+
+	switch reachable := s.backend.Reachable(); {
+	case s.offline == nil && !reachable:
+		s.goOffline(nil)
+
+	// Held records can't be sent after the exit, which finishes the run.
+	case s.offline != nil && reachable && record.Num > 0 && s.exitRecord == nil:
+		s.catchUp()
+	}
+
+	if s.offline == nil || record.Num == 0 {
+		return false
+	}
+
+	if mustSendOffline(record) {
+		s.offline.sentAnyway[record.Num] = struct{}{}
+		if record.GetExit() != nil {
+			s.logger.Warn(
+				"sender: run finished while offline, held records remain in the transaction log",
+				"highWater", s.offline.highWater,
+				"lastHeld", s.offline.lastHeld,
+			)
+		}
+		return false
+	}
+
+	s.offline.lastHeld = record.Num
+	return true
+}
+
+// mustSendOffline returns whether the sender processes the record even
+// while offline.
+//
+// These are the run and its exit, which finish the run, and records that
+// the client is waiting on.
+func mustSendOffline(record *service.Record) bool {
+	switch record.RecordType.(type) {
+	case *service.Record_Run, *service.Record_Exit:
+		return true
+	}
+
+	control := record.GetControl()
+	return control.GetReqResp() || control.GetMailboxSlot() != ""
+}
+
+// goOffline starts holding back records and probing the backend until
+// it's reachable.
+//
+// If the network was lost while catching up, previous is the hold that
+// was being caught up on.
+func (s *Sender) goOffline(previous *offlineHold) {
+	s.offline = &offlineHold{
+		highWater:  s.lastSentNum,
+		sentAnyway: make(map[int64]struct{}),
+		caughtUp:   make(chan struct{}),
+	}
+	if previous != nil {
+		s.offline.lastHeld = previous.lastHeld
+		s.offline.sentAnyway = previous.sentAnyway
+	}
+
+	s.logger.Warn(
+		"sender: network unavailable, holding records",
+		"highWater", s.offline.highWater,
+	)
+
+	go s.probeUntilCaughtUp(s.offline.caughtUp)
+}
+
+// probeUntilCaughtUp probes the backend while it's unreachable, until the
+// sender catches up or the stream ends.
+func (s *Sender) probeUntilCaughtUp(caughtUp <-chan struct{}) {
+	ticker := time.NewTicker(s.networkProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-caughtUp:
+			return
+		case <-ticker.C:
+		}
+
+		if !s.backend.Reachable() {
+			s.backend.Probe(s.ctx)
+		}
+	}
+}
+
+// catchUp sends the records held back while offline and stops holding
+// records, unless the network is lost again meanwhile.
+func (s *Sender) catchUp() {
+	hold := s.offline
+	s.offline = nil
+	close(hold.caughtUp)
+
+	if hold.lastHeld == 0 {
+		s.logger.Info("sender: network available, no records were held")
+		return
+	}
+
+	// in low-latency mode, the writer may not have stored them yet
+	if !s.awaitStored(hold.lastHeld) {
+		s.logger.CaptureError(
+			"sender: failed to send records held while offline",
+			fmt.Errorf("sender: record %d isn't in the transaction log", hold.lastHeld),
+		)
+		return
+	}
+
+	s.logger.Info(
+		"sender: network available, sending held records",
+		"highWater", hold.highWater,
+		"lastHeld", hold.lastHeld,
+	)
+
+	lostNetwork := false
+	err := forEachHeldRecord(
+		s.ctx,
+		s.settings.GetSyncFile().GetValue(),
+		hold,
+		func(record *service.Record) bool {
+			if !s.backend.Reachable() {
+				lostNetwork = true
+				return false
+			}
+			s.processRecord(record)
+			return true
+		},
+	)
+
+	switch {
+	case err != nil:
+		s.logger.CaptureError(
+			"sender: failed to send records held while offline", err)
+	case lostNetwork:
+		s.goOffline(hold)
+	}
+}
+
+// forEachHeldRecord calls fn on each of the held records, in order,
+// reading them from the transaction log at the path.
+//
+// The writer may not have flushed the last held records yet, so the log
+// is reread until they appear. It stops early if fn returns false.
+func forEachHeldRecord(
+	ctx context.Context,
+	path string,
+	hold *offlineHold,
+	fn func(*service.Record) bool,
+) error {
+	// The offset of the last record read, or 0.
+	var offset int64
+	lastProgress := time.Now()
+
+	for {
+		progressed, done, err := readHeldRecords(path, &offset, hold, fn)
+		switch {
+		case err != nil:
+			return err
+		case done:
+			return nil
+		case progressed:
+			lastProgress = time.Now()
+		case time.Since(lastProgress) > heldRecordsTimeout:
+			return fmt.Errorf(
+				"sender: timed out waiting for record %d in the transaction log",
+				hold.lastHeld,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(heldRecordsPollInterval):
+		}
+	}
+}
+
+// readHeldRecords calls fn on the held records in the transaction log
+// after the record at the offset, and updates the offset.
+//
+// It returns whether it read any records, and whether it's done because
+// it passed the last held record or because fn returned false.
+func readHeldRecords(
+	path string,
+	offset *int64,
+	hold *offlineHold,
+	fn func(*service.Record) bool,
+) (progressed bool, done bool, err error) {
+	reader, err := transactionlog.Open(path)
+	if err != nil {
+		return false, false, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	if *offset > 0 {
+		if err := reader.SeekRecord(*offset); err != nil {
+			return false, false, err
+		}
+		if _, _, err := reader.Next(); err != nil {
+			return false, false, err
+		}
+	}
+
+	for {
+		record, recordOffset, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return progressed, false, nil
+		}
+		if err != nil {
+			return progressed, false, err
+		}
+		*offset = recordOffset
+		progressed = true
+
+		if record.Num <= hold.highWater {
+			continue
+		}
+		if record.Num > hold.lastHeld {
+			return true, true, nil
+		}
+		if _, ok := hold.sentAnyway[record.Num]; ok {
+			continue
+		}
+		if record.GetControl().GetPersistOnly() {
+			continue
+		}
+		if !fn(record) || record.Num == hold.lastHeld {
+			return true, true, nil
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// offlineRun is a run whose network can be taken away.
+type offlineRun struct {
+	t        *testing.T
+	backend  *servertest.FakeBackend
+	stream   *server.Stream
+	debugLog string
+	syncFile string
+}
+
+func startOfflineRun(t *testing.T) *offlineRun {
+	t.Helper()
+	backend := servertest.NewFakeBackend()
+	t.Cleanup(backend.Close)
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	dir := t.TempDir()
+	run := &offlineRun{
+		t:        t,
+		backend:  backend,
+		debugLog: filepath.Join(dir, "debug-internal.log"),
+		syncFile: filepath.Join(dir, "run-offline.wandb"),
+	}
+
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "offline"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: run.debugLog},
+		SyncFile:      &wrapperspb.StringValue{Value: run.syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XNetworkOfflineAfterSeconds:        &wrapperspb.DoubleValue{Value: 0.1},
+		XNetworkProbeIntervalSeconds:       &wrapperspb.DoubleValue{Value: 0.02},
+		XFileStreamRetryMax:                &wrapperspb.Int32Value{Value: 1000},
+		XFileStreamRetryWaitMinSeconds:     &wrapperspb.DoubleValue{Value: 0.005},
+		XFileStreamRetryWaitMaxSeconds:     &wrapperspb.DoubleValue{Value: 0.005},
+		XFileStreamTransmitIntervalSeconds: &wrapperspb.DoubleValue{Value: 0.02},
+	}), "")
+	require.NoError(t, err)
+	run.stream = stream
+	run.stream.Start()
+	run.stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "offline", Project: "testProject"},
+		},
+	})
+	run.stream.HandleRecord(makeRunStartRecord())
+	return run
+}
+
+func (run *offlineRun) logLoss(loss int) {
+	run.stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": fmt.Sprint(loss)},
+		flush:   true,
+		stepNil: true,
+	}))
+}
+
+// waitForLog waits until the debug log contains the message.
+func (run *offlineRun) waitForLog(message string) {
+	run.t.Helper()
+	require.Eventually(run.t,
+		func() bool {
+			debugLog, _ := os.ReadFile(run.debugLog)
+			return strings.Contains(string(debugLog), message)
+		},
+		10*time.Second,
+		5*time.Millisecond,
+		"debug log never contained %q", message,
+	)
+}
+
+// historyLines returns the history lines the backend received, by offset.
+func (run *offlineRun) historyLines() map[int]string {
+	lines := make(map[int]string)
+	for _, request := range run.backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Offset  int      `json:"offset"`
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(run.t, json.Unmarshal(request.Body, &body))
+
+		history := body.Files[filestream.HistoryFileName]
+		for i, line := range history.Content {
+			lines[history.Offset+i] = line
+		}
+	}
+	return lines
+}
+
+// A run whose network disappears and comes back sends everything it
+// logged in the meantime exactly once, in order.
+func TestOfflineMode_ResumesAfterNetworkReturns(t *testing.T) {
+	run := startOfflineRun(t)
+	run.logLoss(1)
+	require.Eventually(t,
+		func() bool { return len(run.historyLines()) == 1 },
+		10*time.Second, 5*time.Millisecond)
+
+	run.backend.SetUnreachable(true)
+	run.logLoss(2)
+	run.waitForLog("api: backend unreachable")
+	run.logLoss(3)
+	run.logLoss(4)
+	run.waitForLog("sender: network unavailable, holding records")
+
+	run.backend.SetUnreachable(false)
+	run.waitForLog("api: backend reachable again")
+	run.logLoss(5)
+	run.stream.FinishAndClose(0)
+
+	lines := run.historyLines()
+	require.Len(t, lines, 5)
+	for i := range 5 {
+		assert.Contains(t, lines[i], fmt.Sprintf(`"loss":%d`, i+1))
+	}
+	requests := run.backend.Requests(servertest.RouteFileStream)
+	assert.Contains(t, string(requests[len(requests)-1].Body), `"complete":true`)
+	debugLog, err := os.ReadFile(run.debugLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(debugLog), "sender: network available, sending held records")
+}
+
+// A run whose network never comes back still finishes, and keeps what it
+// logged in the transaction log for syncing later.
+func TestOfflineMode_FinishesWithoutNetwork(t *testing.T) {
+	run := startOfflineRun(t)
+	run.logLoss(1)
+	require.Eventually(t,
+		func() bool { return len(run.historyLines()) == 1 },
+		10*time.Second, 5*time.Millisecond)
+
+	run.backend.SetUnreachable(true)
+	run.logLoss(2)
+	run.waitForLog("api: backend unreachable")
+	run.logLoss(3)
+
+	finished := make(chan struct{})
+	go func() {
+		run.stream.FinishAndClose(0)
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(30 * time.Second):
+		t.Fatal("run did not finish while offline")
+	}
+
+	assert.Len(t, run.historyLines(), 1)
+	reader, err := transactionlog.Open(run.syncFile)
+	require.NoError(t, err)
+	defer reader.Close()
+	var losses []string
+	for {
+		record, _, err := reader.Next()
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+		for _, item := range record.GetHistory().GetItem() {
+			if item.Key == "loss" {
+				losses = append(losses, item.ValueJson)
+			}
+		}
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, losses)
+	debugLog, err := os.ReadFile(run.debugLog)
+	require.NoError(t, err)
+	assert.Contains(t, string(debugLog), "sender: run finished while offline")
+	assert.NotContains(t, string(debugLog), "filestream: fatal error")
+}
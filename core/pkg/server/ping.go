@@ -0,0 +1,79 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wandb/wandb/core/internal/httppool"
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// coreStartTime is roughly when wandb-core started, for its uptime.
+var coreStartTime = time.Now()
+
+// handlePing answers a ping right away, without involving any stream.
+//
+// It's called as the request is read, so that it's answered even while
+// the connection's other requests are stuck.
+func (nc *Connection) handlePing(_ *service.ServerPingRequest) {
+	nc.Respond(&service.ServerResponse{
+		ServerResponseType: &service.ServerResponse_PingResponse{
+			PingResponse: &service.ServerPingResponse{
+				CoreVersion:   version.Version,
+				UptimeSeconds: time.Since(coreStartTime).Seconds(),
+				ActiveStreams: int32(streamMux.NumStreams()),
+				Streams:       streamMux.Health(),
+			},
+		},
+	})
+}
+
+// Health returns the health of each stream in the mux.
+func (sm *StreamMux) Health() []*service.StreamHealth {
+	var health []*service.StreamHealth
+	sm.streams.Range(func(streamId, stream any) bool {
+		health = append(health, stream.(*Stream).health(streamId.(string)))
+		return true
+	})
+	return health
+}
+
+// health returns what's known about the stream without asking its
+// pipeline.
+func (s *Stream) health(streamId string) *service.StreamHealth {
+	health := &service.StreamHealth{StreamId: streamId}
+	if s.connStats == nil {
+		return health
+	}
+	if contact := s.connStats.LastSuccess(httppool.API); !contact.IsZero() {
+		health.LastBackendContact = timestamppb.New(contact)
+	}
+	return health
+}
+
+// streamPingResult answers a stream ping on the control lane, with when
+// each part of the pipeline last made progress.
+func (s *Stream) streamPingResult(record *service.Record) *service.Result {
+	lastProcessed := make(map[string]*timestamppb.Timestamp)
+	for component, last := range s.crashReporter.LastProcessed() {
+		lastProcessed[component] = timestamppb.New(last)
+	}
+
+	return &service.Result{
+		ResultType: &service.Result_Response{
+			Response: &service.Response{
+				ResponseType: &service.Response_StreamPingResponse{
+					StreamPingResponse: &service.StreamPingResponse{
+						Health:        s.health(s.settings.GetRunID()),
+						LastProcessed: lastProcessed,
+						Diagnostics:   s.diagnostics.Proto(),
+					},
+				},
+			},
+		},
+		Control: record.Control,
+		Uuid:    record.Uuid,
+	}
+}
@@ -0,0 +1,245 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func streamPingRecord(uuid string) *service.Record {
+	return disabledRequest(uuid, &service.Request{
+		RequestType: &service.Request_StreamPing{
+			StreamPing: &service.StreamPingRequest{},
+		},
+	})
+}
+
+func (c *testClient) ping() *service.ServerPingResponse {
+	c.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_Ping{
+			Ping: &service.ServerPingRequest{},
+		},
+	})
+	return c.receive(func(response *service.ServerResponse) bool {
+		return response.GetPingResponse() != nil
+	}).GetPingResponse()
+}
+
+// newPingStream returns an online stream with the faults of the spec.
+func newPingStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	faultSpec string,
+) (*server.Stream, chanResponder) {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:           &wrapperspb.StringValue{Value: "ping"},
+		BaseUrl:         &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:          &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:          &wrapperspb.StringValue{Value: dir},
+		LogInternal:     &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:        &wrapperspb.StringValue{Value: filepath.Join(dir, "run-ping.wandb")},
+		FilesDir:        &wrapperspb.StringValue{Value: dir},
+		XDisableStats:   &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:    &wrapperspb.BoolValue{Value: true},
+		XFaultInjection: &wrapperspb.StringValue{Value: faultSpec},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 64)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	return stream, responses
+}
+
+func TestConnection_PingWithoutStream(t *testing.T) {
+	connect := serveConnections(t)
+
+	response := connect().ping()
+
+	assert.Equal(t, version.Version, response.GetCoreVersion())
+	assert.Positive(t, response.GetUptimeSeconds())
+	assert.EqualValues(t, len(response.GetStreams()), response.GetActiveStreams())
+}
+
+func TestConnection_PingListsStreams(t *testing.T) {
+	connect := serveConnections(t)
+	dir := t.TempDir()
+	const streamId = "ping-listed"
+	client := connect()
+	client.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_InformInit{
+			InformInit: &service.ServerInformInitRequest{
+				Settings: &service.Settings{
+					RunId:         &wrapperspb.StringValue{Value: streamId},
+					LogDir:        &wrapperspb.StringValue{Value: dir},
+					LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+					SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-ping.wandb")},
+					FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+					XOffline:      &wrapperspb.BoolValue{Value: true},
+					XDisableStats: &wrapperspb.BoolValue{Value: true},
+					XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+				},
+				XInfo: &service.XRecordInfo{StreamId: streamId},
+			},
+		},
+	})
+
+	// the stream is started by the time a request to it is answered
+	client.communicate(streamId, &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Status{Status: &service.StatusRequest{}},
+			},
+		},
+	})
+	client.receive(func(response *service.ServerResponse) bool {
+		return response.GetResultCommunicate().GetResponse().GetStatusResponse() != nil
+	})
+	response := client.ping()
+	client.communicate(streamId, &service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{AlwaysSend: true, ReqResp: true},
+	})
+	client.receive(func(response *service.ServerResponse) bool {
+		return response.GetResultCommunicate().GetExitResult() != nil
+	})
+	client.send(&service.ServerRequest{
+		ServerRequestType: &service.ServerRequest_InformFinish{
+			InformFinish: &service.ServerInformFinishRequest{
+				XInfo: &service.XRecordInfo{StreamId: streamId},
+			},
+		},
+	})
+
+	var listed *service.StreamHealth
+	for _, health := range response.GetStreams() {
+		if health.GetStreamId() == streamId {
+			listed = health
+		}
+	}
+	require.NotNil(t, listed, "the stream isn't listed")
+	// offline streams never contact the backend
+	assert.Nil(t, listed.GetLastBackendContact())
+	assert.GreaterOrEqual(t, response.GetActiveStreams(), int32(1))
+}
+
+func TestStreamPing_ReportsBackendContact(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	stream, responses := newPingStream(t, backend, "")
+	defer stream.FinishAndClose(0)
+
+	before := time.Now()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "ping", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	awaitResult(t, responses, "run")
+	stream.HandleRecord(streamPingRecord("ping"))
+	ping := awaitResult(t, responses, "ping").GetResponse().GetStreamPingResponse()
+
+	require.NotNil(t, ping)
+	assert.Equal(t, "ping", ping.GetHealth().GetStreamId())
+	assert.False(t,
+		ping.GetHealth().GetLastBackendContact().AsTime().Before(before),
+		"the upsert isn't reported as contact with the backend")
+	assert.Contains(t, ping.GetLastProcessed(), "sender")
+	assert.NotNil(t, ping.GetDiagnostics())
+}
+
+// A stream whose sender is stuck still answers pings, and the answer
+// shows where the pipeline stopped making progress.
+func TestStreamPing_AnsweredWhileSenderIsWedged(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	stream, responses := newPingStream(t, backend, "sender-stall=1h")
+	defer stream.Abort()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "ping", Project: "testProject"},
+		},
+	})
+	for range 5 {
+		stream.HandleRecord(makePartialHistoryRecord(data{
+			items:   map[string]string{"loss": "0.5"},
+			flush:   true,
+			stepNil: true,
+		}))
+	}
+
+	// the condition of Eventually runs in its own goroutine, where the test
+	// can't be stopped
+	pingStream := func() *service.StreamPingResponse {
+		stream.HandleRecord(streamPingRecord("ping"))
+		select {
+		case result := <-responses:
+			return result.GetResponse().GetStreamPingResponse()
+		case <-time.After(time.Second):
+			t.Error("the ping wasn't answered while the sender was stuck")
+			return nil
+		}
+	}
+	var ping *service.StreamPingResponse
+	require.Eventually(t, func() bool {
+		ping = pingStream()
+		return ping.GetDiagnostics().GetChannelDepths()["writer.fwd"] > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the handler goes on handling records, but the sender doesn't move
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.25"},
+		flush:   true,
+		stepNil: true,
+	}))
+	handled := ping.GetLastProcessed()["handler"].AsTime()
+	var later *service.StreamPingResponse
+	require.Eventually(t, func() bool {
+		later = pingStream()
+		return later.GetLastProcessed()["handler"].AsTime().After(handled)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t,
+		ping.GetLastProcessed()["sender"].AsTime(),
+		later.GetLastProcessed()["sender"].AsTime())
+	assert.Empty(t, backend.GraphQLRequests("UpsertBucket"))
+}
+
+func TestStreamPing_Disabled(t *testing.T) {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		Mode:        &wrapperspb.StringValue{Value: "disabled"},
+		RunId:       &wrapperspb.StringValue{Value: "disabled"},
+		WandbDir:    &wrapperspb.StringValue{Value: dir},
+		SyncDir:     &wrapperspb.StringValue{Value: filepath.Join(dir, "run")},
+		LogDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "logs")},
+		LogInternal: &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "logs", "debug-internal.log")},
+		SyncFile:    &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "run-disabled.wandb")},
+		FilesDir:    &wrapperspb.StringValue{Value: filepath.Join(dir, "run", "files")},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 64)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	defer stream.FinishAndClose(0)
+
+	stream.HandleRecord(streamPingRecord("ping"))
+	ping := awaitResult(t, responses, "ping").GetResponse().GetStreamPingResponse()
+
+	require.NotNil(t, ping)
+	assert.Nil(t, ping.GetHealth().GetLastBackendContact())
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// pollWindow is how long a poll's answer is reused for polls of the same
+// type that come after it.
+const pollWindow = time.Second
+
+// pollCoalescer lets status polls of the same type share backend queries.
+//
+// With several clients attached to a stream, each polls the run's status
+// on its own schedule, and would otherwise cost a query each. A poll made
+// while a query of its type is in flight waits for that query, and one
+// made within the window after a query finished gets that query's answer.
+// Each poll is still answered to its own client.
+type pollCoalescer struct {
+	mu sync.Mutex
+
+	window time.Duration
+	clock  waiting.Clock
+
+	// polls are the polls of each type, by record type name
+	polls map[string]*pollGroup
+}
+
+// pollGroup is the state of one type of poll.
+type pollGroup struct {
+	// waiting are the polls to answer when the query in flight finishes,
+	// or nil if there's no query in flight
+	waiting []*service.Record
+
+	// answer is the answer of the last query, and answered is when it
+	// finished
+	answer   *service.Response
+	answered time.Time
+
+	requests int32
+	queries  int32
+}
+
+func newPollCoalescer(window time.Duration, clock waiting.Clock) *pollCoalescer {
+	if clock == nil {
+		clock = waiting.NewClock()
+	}
+	return &pollCoalescer{
+		window: window,
+		clock:  clock,
+		polls:  make(map[string]*pollGroup),
+	}
+}
+
+// Poll answers a poll with the answer of a query of its type.
+//
+// If a query of the poll's type is in flight or finished within the
+// window, its answer is used; otherwise query is run in a new goroutine.
+// respond is called with the answer for each poll, unless the answer is
+// nil, in which case the polls aren't answered.
+func (c *pollCoalescer) Poll(
+	record *service.Record,
+	query func() *service.Response,
+	respond func(*service.Record, *service.Response),
+) {
+	key := recordTypeName(record)
+
+	c.mu.Lock()
+	group, ok := c.polls[key]
+	if !ok {
+		group = &pollGroup{}
+		c.polls[key] = group
+	}
+	group.requests++
+
+	switch {
+	case group.waiting != nil:
+		group.waiting = append(group.waiting, record)
+		c.mu.Unlock()
+		return
+
+	case !group.answered.IsZero() &&
+		c.clock.Now().Sub(group.answered) < c.window:
+		answer := group.answer
+		c.mu.Unlock()
+		if answer != nil {
+			respond(record, answer)
+		}
+		return
+	}
+
+	group.waiting = []*service.Record{record}
+	group.queries++
+	c.mu.Unlock()
+
+	go func() {
+		answer := query()
+
+		c.mu.Lock()
+		waiting := group.waiting
+		group.waiting = nil
+		group.answer = answer
+		group.answered = c.clock.Now()
+		c.mu.Unlock()
+
+		if answer == nil {
+			return
+		}
+		for _, record := range waiting {
+			respond(record, answer)
+		}
+	}()
+}
+
+// Proto returns the number of polls and queries of each type.
+func (c *pollCoalescer) Proto() map[string]*service.PollStats {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[string]*service.PollStats, len(c.polls))
+	for key, group := range c.polls {
+		stats[key] = &service.PollStats{
+			Requests: group.requests,
+			Queries:  group.queries,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func networkStatusPoll(uuid string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_NetworkStatus{
+					NetworkStatus: &service.NetworkStatusRequest{},
+				},
+			},
+		},
+		Uuid: uuid,
+	}
+}
+
+func TestPollCoalescer_ReusesAnswerWithinWindow(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	polls := newPollCoalescer(time.Second, clock)
+	answered := make(chan string, 1)
+	queries := 0
+	poll := func(uuid string) string {
+		polls.Poll(networkStatusPoll(uuid),
+			func() *service.Response {
+				queries++
+				return &service.Response{}
+			},
+			func(record *service.Record, _ *service.Response) {
+				answered <- record.GetUuid()
+			},
+		)
+		return <-answered
+	}
+
+	assert.Equal(t, "first", poll("first"))
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	assert.Equal(t, "within", poll("within"))
+	clock.now = clock.now.Add(time.Second)
+	assert.Equal(t, "after", poll("after"))
+
+	assert.Equal(t, 2, queries)
+	stats := polls.Proto()["request.network_status"]
+	assert.EqualValues(t, 3, stats.GetRequests())
+	assert.EqualValues(t, 2, stats.GetQueries())
+}
+
+func TestPollCoalescer_NilAnswerIsNotSent(t *testing.T) {
+	polls := newPollCoalescer(time.Second, &testClock{now: time.Unix(1000, 0)})
+	done := make(chan struct{})
+	polls.Poll(networkStatusPoll("first"),
+		func() *service.Response {
+			defer close(done)
+			return nil
+		},
+		func(*service.Record, *service.Response) {
+			t.Error("answered a poll with nothing to say")
+		},
+	)
+	<-done
+
+	// the answer of nothing is reused too
+	polls.Poll(networkStatusPoll("second"),
+		func() *service.Response {
+			t.Error("queried again within the window")
+			return nil
+		},
+		func(*service.Record, *service.Response) {
+			t.Error("answered a poll with nothing to say")
+		},
+	)
+}
@@ -0,0 +1,120 @@
+package server_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// pollStopStatus has each of the clients poll the stop status at once,
+// and returns the stream's poll stats.
+func pollStopStatus(t *testing.T, clients int) *service.PollStats {
+	t.Helper()
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubGraphQL("RunStoppedStatus",
+		`{"project": {"run": {"stopped": true}}}`)
+
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "polls"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-polls.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make([]chanResponder, clients)
+	for i := range responses {
+		responses[i] = make(chanResponder, 16)
+		stream.AddResponders(server.ResponderEntry{
+			Responder: responses[i],
+			ID:        fmt.Sprintf("client-%d", i),
+		})
+	}
+	stream.Start()
+	defer stream.FinishAndClose(0)
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "polls", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client-0", ReqResp: true},
+		Uuid:    "run",
+	})
+	awaitResult(t, responses[0], "run")
+	stream.HandleRecord(makeRunStartRecord())
+
+	// a slow backend keeps the first query in flight while the rest poll
+	backend.InjectFault(servertest.RouteGraphQL,
+		servertest.Fault{Delay: 200 * time.Millisecond})
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream.HandleRecord(&service.Record{
+				RecordType: &service.Record_Request{
+					Request: &service.Request{
+						RequestType: &service.Request_StopStatus{
+							StopStatus: &service.StopStatusRequest{},
+						},
+					},
+				},
+				Control: &service.Control{
+					ConnectionId: fmt.Sprintf("client-%d", i),
+					ReqResp:      true,
+				},
+				Uuid: "stop-status",
+			})
+			status := awaitResult(t, responses[i], "stop-status").
+				GetResponse().GetStopStatusResponse()
+			assert.True(t, status.GetRunShouldStop())
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, backend.GraphQLRequests("RunStoppedStatus"), 1)
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Status{
+					Status: &service.StatusRequest{},
+				},
+			},
+		},
+		Control: &service.Control{ConnectionId: "client-0", ReqResp: true},
+		Uuid:    "status",
+	})
+	return awaitResult(t, responses[0], "status").
+		GetResponse().GetStatusResponse().GetDiagnostics().
+		GetPolls()["request.stop_status"]
+}
+
+// Every client polling the stop status gets an answer, but the backend
+// is queried once however many clients poll.
+func TestStopStatus_CoalescesPollsFromClients(t *testing.T) {
+	one := pollStopStatus(t, 1)
+	five := pollStopStatus(t, 5)
+
+	assert.EqualValues(t, 1, one.GetRequests())
+	assert.EqualValues(t, 1, one.GetQueries())
+	assert.EqualValues(t, 5, five.GetRequests())
+	assert.EqualValues(t, 1, five.GetQueries())
+}
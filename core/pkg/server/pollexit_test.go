@@ -0,0 +1,129 @@
+package server_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func pollExitRecord() *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_PollExit{
+					PollExit: &service.PollExitRequest{},
+				},
+			},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+	}
+}
+
+// The client polls the exit like its progress bar does, while uploads
+// are slow, and sees progress that only goes forward and that adds up
+// once the exit is done.
+func TestPollExit_ProgressIsMonotonicAndReachesTotals(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	for range 10 {
+		backend.InjectFault(servertest.RouteUpload, servertest.Fault{Delay: 100 * time.Millisecond})
+	}
+	backend.InjectFault(servertest.RouteFileStream, servertest.Fault{Delay: 200 * time.Millisecond})
+
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	var files []*service.FilesItem
+	for i := range 5 {
+		name := fmt.Sprintf("data%d.txt", i)
+		content := strings.Repeat("x", 1000*(i+1))
+		require.NoError(t, os.WriteFile(filepath.Join(filesDir, name), []byte(content), 0o644))
+		files = append(files, &service.FilesItem{Path: name})
+	}
+
+	responses := make(chanResponder, 1000)
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "pollexit"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-pollexit.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "pollexit", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{Files: &service.FilesRecord{Files: files}},
+	})
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{AlwaysSend: true},
+	})
+
+	// poll until the exit is done, like the client does before it
+	// finishes the stream
+	var polls []*service.PollExitResponse
+	deadline := time.After(30 * time.Second)
+	for len(polls) == 0 || !polls[len(polls)-1].GetDone() {
+		stream.HandleRecord(pollExitRecord())
+		select {
+		case result := <-responses:
+			if poll := result.GetResponse().GetPollExitResponse(); poll != nil {
+				polls = append(polls, poll)
+			}
+		case <-deadline:
+			t.Fatal("the exit didn't finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stream.Close()
+
+	require.Greater(t, len(polls), 1)
+	for i, poll := range polls {
+		stats := poll.GetPusherStats()
+		assert.LessOrEqual(t, stats.GetUploadedBytes(), stats.GetTotalBytes(), "poll %d", i)
+		if i == 0 {
+			continue
+		}
+		previous := polls[i-1].GetPusherStats()
+		assert.GreaterOrEqual(t, stats.GetUploadedBytes(), previous.GetUploadedBytes(), "poll %d", i)
+		assert.GreaterOrEqual(t, stats.GetTotalBytes(), previous.GetTotalBytes(), "poll %d", i)
+		if polls[i-1].GetFilestreamDone() {
+			assert.True(t, poll.GetFilestreamDone(), "poll %d", i)
+		}
+	}
+	last := polls[len(polls)-1]
+	assert.True(t, last.GetFilestreamDone())
+	assert.Equal(t, last.GetPusherStats().GetTotalBytes(), last.GetPusherStats().GetUploadedBytes())
+	assert.GreaterOrEqual(t, last.GetPusherStats().GetTotalBytes(), int64(15_000))
+	assert.GreaterOrEqual(t, last.GetFileCounts().GetOtherCount(), int32(5))
+}
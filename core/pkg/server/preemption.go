@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	fs "github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// defaultPreemptionTimeout is how long preparing for preemption takes at
+// most, unless the request says otherwise.
+//
+// Preemption notices are often 30 seconds, which leaves the client time
+// to act on the response.
+const defaultPreemptionTimeout = 20 * time.Second
+
+// handleRequestPrepareForPreemption gets the run ready for its process to
+// be killed.
+//
+// The step being logged is committed, as nothing else would commit it, and
+// the run is marked as preempting. Both are passed on ahead of the
+// request, which the sender answers even if the run is offline.
+func (h *Handler) handleRequestPrepareForPreemption(record *service.Record) {
+	if h.runHistory != nil && len(h.runHistory.Tree()) > 0 {
+		h.handleRequestPartialHistory(
+			nil,
+			&service.PartialHistoryRequest{
+				Action: &service.HistoryAction{Flush: true},
+			},
+		)
+	}
+
+	alwaysSend := func(control *service.Control) {
+		control.AlwaysSend = true
+	}
+	h.fwdRecordWithControl(
+		&service.Record{
+			RecordType: &service.Record_Preempting{
+				Preempting: &service.RunPreemptingRecord{},
+			},
+		},
+		alwaysSend,
+	)
+	h.fwdRecordWithControl(record, alwaysSend)
+}
+
+// sendRequestPrepareForPreemption gets the run ready for its process to be
+// killed, and answers once it is or the request's timeout passes.
+//
+// The transaction log is synced to disk up to the request, and the data
+// buffered for the filestream is sent right away. What the server
+// acknowledged and the run files not yet uploaded are recorded next to the
+// log. Syncing the log afterwards uploads whatever didn't make it.
+func (s *Sender) sendRequestPrepareForPreemption(
+	record *service.Record,
+	request *service.PrepareForPreemptionRequest,
+) {
+	timeout := defaultPreemptionTimeout
+	if seconds := request.GetTimeoutSeconds(); seconds > 0 {
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	acked := s.flushForPreemption()
+
+	response := &service.PrepareForPreemptionResponse{}
+	if err := s.logWriter.SyncStored(ctx, s.lastSentNum); err != nil {
+		s.logger.CaptureError("sender: run not durable before preemption", err)
+		response.Error = &service.ErrorInfo{
+			Code:    service.ErrorInfo_UNKNOWN,
+			Message: err.Error(),
+		}
+	} else {
+		response.Durable = true
+	}
+
+	var lines map[string]int
+	if acked != nil {
+		select {
+		case lines = <-acked:
+			response.Flushed = true
+		case <-ctx.Done():
+		}
+	}
+
+	response.Unfinished = s.unfinishedWork()
+	if response.Durable {
+		s.recordPreemptionState(response.Flushed, lines, response.Unfinished.Files)
+	}
+
+	s.logger.Info(
+		"sender: prepared for preemption",
+		"durable", response.Durable,
+		"flushed", response.Flushed,
+		"files", response.Unfinished.Files,
+		"pendingUploads", response.Unfinished.PendingUploads,
+	)
+	s.respond(record, &service.Response{
+		ResponseType: &service.Response_PrepareForPreemptionResponse{
+			PrepareForPreemptionResponse: response,
+		},
+	})
+}
+
+// flushForPreemption sends the filestream the run's buffered output and
+// summary without waiting to batch more.
+//
+// Returns a channel that receives the number of lines of each filestream
+// file once the server acknowledges them, or nil if there's no filestream.
+func (s *Sender) flushForPreemption() <-chan map[string]int {
+	if s.fileStream == nil {
+		return nil
+	}
+
+	s.flushOutput()
+	s.summaryDebouncer.Flush(s.streamSummary)
+
+	acked := make(chan map[string]int, 1)
+	s.fileStream.StreamUpdate(&fs.FlushUpdate{
+		OnAcked: func(lines map[string]int) { acked <- lines },
+	})
+	return acked
+}
+
+// recordPreemptionState records next to the transaction log how much of
+// the run reached the server before it was preempted.
+//
+// If the filestream was flushed, the server acknowledged everything
+// streamed from the log so far, up to the offsets.
+func (s *Sender) recordPreemptionState(
+	flushed bool,
+	lines map[string]int,
+	pendingFiles []string,
+) {
+	if s.syncState == nil {
+		return
+	}
+
+	state := transactionlog.SyncState{
+		FileStreamOffsets: lines,
+		PendingFiles:      pendingFiles,
+		UpdatedAt:         time.Now(),
+	}
+	if flushed {
+		state.LastAcked = s.lastSentNum
+	}
+	if err := s.syncState.write(state); err != nil {
+		s.logger.Error("sender: can't record the sync state", "error", err, "path", s.syncState.path)
+	}
+}
+
+// SyncStored waits until the record with the number is flushed to the
+// transaction log, then syncs the log to disk.
+//
+// It fails if the run has no log, the log failed, or the context is done
+// first.
+func (w *Writer) SyncStored(ctx context.Context, num int64) error {
+	if w == nil || w.settings.GetXSync().GetValue() {
+		return errors.New("writer: the run has no transaction log")
+	}
+
+	if !w.WaitStored(ctx, num) {
+		if w.storeFailed.Load() {
+			return errors.New("writer: the transaction log failed")
+		}
+		return fmt.Errorf("writer: record %d not stored: %v", num, ctx.Err())
+	}
+
+	w.syncMu.Lock()
+	defer w.syncMu.Unlock()
+	var err error
+	switch {
+	case w.storeFailed.Load():
+		return errors.New("writer: the transaction log failed")
+	case w.storeClosed:
+		err = syncFile(w.settings.GetSyncFile().GetValue())
+	default:
+		err = w.store.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("writer: can't sync the transaction log: %v", err)
+	}
+	return nil
+}
+
+// syncFile commits the file at the path to disk.
+func syncFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
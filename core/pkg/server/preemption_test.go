@@ -0,0 +1,182 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+func prepareForPreemptionRequest(uuid string) *service.Record {
+	return disabledRequest(uuid, &service.Request{
+		RequestType: &service.Request_PrepareForPreemption{
+			PrepareForPreemption: &service.PrepareForPreemptionRequest{
+				TimeoutSeconds: 10,
+			},
+		},
+	})
+}
+
+// streamedHistory returns the history lines the backend received.
+func streamedHistory(t *testing.T, backend *servertest.FakeBackend) []string {
+	t.Helper()
+	var history []string
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		history = append(history, body.Files["wandb-history.jsonl"].Content...)
+	}
+	return history
+}
+
+// A run prepared for preemption can be killed right after the response,
+// and syncing its log finishes it with all of its history.
+func TestPrepareForPreemption_KilledRunSyncs(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-preempt.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "preempt"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "preempt", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	for i := range 6 {
+		// the last step is still being logged
+		stream.HandleRecord(makePartialHistoryRecord(data{
+			items: map[string]string{"loss": fmt.Sprint(i)},
+			step:  int64(i),
+			flush: i < 5,
+		}))
+	}
+
+	start := time.Now()
+	stream.HandleRecord(prepareForPreemptionRequest("prepare"))
+	response := awaitResult(t, responses, "prepare").
+		GetResponse().GetPrepareForPreemptionResponse()
+	stream.Abort()
+
+	require.NotNil(t, response)
+	assert.Nil(t, response.GetError())
+	assert.True(t, response.GetDurable())
+	assert.True(t, response.GetFlushed())
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Len(t, streamedHistory(t, backend), 6)
+	assert.Contains(t, fileStreamBodies(backend), `"preempting":true`)
+
+	state, err := transactionlog.ReadSyncState(syncFile)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.False(t, state.Complete)
+	assert.Positive(t, state.LastAcked)
+	assert.Equal(t, 6, state.FileStreamOffsets["wandb-history.jsonl"])
+
+	syncBackend := servertest.NewFakeBackend()
+	defer syncBackend.Close()
+	syncBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	syncBackend.StubCreateRunFiles()
+	syncDir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "preempt"},
+		BaseUrl:       &wrapperspb.StringValue{Value: syncBackend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: syncDir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(syncDir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(syncDir, "files")},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	assert.Equal(t, streamedHistory(t, backend), streamedHistory(t, syncBackend))
+	assert.Contains(t, fileStreamBodies(syncBackend), `"preempting":true`)
+}
+
+// An offline run has nothing to send, and is ready once its log is on
+// disk.
+func TestPrepareForPreemption_Offline(t *testing.T) {
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-preempt.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "preempt"},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	defer stream.Abort()
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		flush: true,
+	}))
+
+	stream.HandleRecord(prepareForPreemptionRequest("prepare"))
+	response := awaitResult(t, responses, "prepare").
+		GetResponse().GetPrepareForPreemptionResponse()
+
+	require.NotNil(t, response)
+	assert.True(t, response.GetDurable())
+	assert.False(t, response.GetFlushed())
+	var history, preempting int
+	for _, record := range readLog(t, syncFile) {
+		switch {
+		case record.GetHistory() != nil:
+			history++
+		case record.GetPreempting() != nil:
+			preempting++
+		}
+	}
+	assert.Equal(t, 1, history)
+	assert.Equal(t, 1, preempting)
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// projectSettingsTimeout is how long the run's creation waits for the
+// defaults of its project, before going on with the local ones.
+const projectSettingsTimeout = 10 * time.Second
+
+// fetchProjectSettings applies the defaults that the run's project sets to
+// the settings the run doesn't set itself, once the run is created, and
+// saves the settings in effect to the transaction log.
+//
+// They're applied before the client is told that the run was created, so
+// before the run starts and its environment is captured from the settings.
+// If they can't be fetched, such as from a server that doesn't support
+// them, the local defaults are used.
+func (s *Sender) fetchProjectSettings() {
+	// only the primary writer of a shared-mode run saves code, and a
+	// synced run uses the settings saved in its transaction log
+	if s.projectSettingsFetched || s.secondary ||
+		s.settings.GetXSync().GetValue() {
+		return
+	}
+	s.projectSettingsFetched = true
+
+	ctx, cancel := context.WithTimeout(s.ctx, projectSettingsTimeout)
+	defer cancel()
+
+	entity := s.RunRecord.GetEntity()
+	project := s.RunRecord.GetProject()
+	data, err := gql.ProjectSettings(ctx, s.graphqlClient, &entity, &project)
+	if err != nil {
+		s.logger.Debug(
+			"sender: fetchProjectSettings: using local defaults",
+			"error", err)
+		return
+	}
+
+	if data.GetProject() == nil || data.GetProject().GetRunDefaults() == nil {
+		return
+	}
+	runDefaults := data.GetProject().GetRunDefaults()
+	defaults := &service.Settings{}
+	if saveCode := runDefaults.GetSaveCode(); saveCode != nil {
+		defaults.SaveCode = wrapperspb.Bool(*saveCode)
+	}
+	if disableGit := runDefaults.GetDisableGit(); disableGit != nil {
+		defaults.DisableGit = wrapperspb.Bool(*disableGit)
+	}
+
+	defaultsRecord, err := settings.From(defaults).ProjectDefaultsRecord()
+	if err != nil {
+		s.logger.CaptureError("sender: fetchProjectSettings", err)
+		return
+	}
+	if defaultsRecord == nil {
+		return
+	}
+
+	runSettings := settings.WithProvenance(s.settings, s.settingsProvenance)
+	if err := runSettings.MergeProjectDefaults(defaultsRecord); err != nil {
+		s.logger.CaptureError("sender: failed to apply the project's settings", err)
+		return
+	}
+	effective, err := runSettings.ProjectDefaultsRecord()
+	if err != nil {
+		s.logger.CaptureError("sender: failed to store the project's settings", err)
+		return
+	}
+	s.logger.Info("sender: applied the project's settings", "effective", effective)
+
+	s.fwdRecord(&service.Record{
+		RecordType: &service.Record_ProjectSettings{
+			ProjectSettings: &service.ProjectSettingsRecord{
+				Defaults:  defaultsRecord,
+				Effective: effective,
+			},
+		},
+	})
+}
+
+// sendProjectSettings applies the settings that a synced run ran with,
+// as saved in its transaction log.
+//
+// A live run's record was saved by the writer, and needs nothing else.
+func (s *Sender) sendProjectSettings(projectSettings *service.ProjectSettingsRecord) {
+	if !s.settings.GetXSync().GetValue() {
+		return
+	}
+
+	err := settings.WithProvenance(s.settings, s.settingsProvenance).
+		ApplyProjectSettings(projectSettings.GetEffective())
+	if err != nil {
+		s.logger.CaptureError("sender: failed to apply the run's project settings", err)
+	}
+}
@@ -0,0 +1,162 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const projectSettingsResponse = `{
+	"project": {
+		"runDefaults": {"saveCode": true, "disableGit": true}
+	}
+}`
+
+// runWithProjectSettings runs the run "project-settings" to its exit with
+// the settings, and returns its transaction log's records.
+func runWithProjectSettings(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	proto *service.Settings,
+) []*service.Record {
+	t.Helper()
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-project-settings.wandb")
+	proto.RunId = &wrapperspb.StringValue{Value: "project-settings"}
+	proto.BaseUrl = &wrapperspb.StringValue{Value: backend.URL()}
+	proto.ApiKey = &wrapperspb.StringValue{Value: "test-api-key"}
+	proto.LogDir = &wrapperspb.StringValue{Value: dir}
+	proto.LogInternal = &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")}
+	proto.SyncFile = &wrapperspb.StringValue{Value: syncFile}
+	proto.FilesDir = &wrapperspb.StringValue{Value: filepath.Join(dir, "files")}
+	proto.XDisableStats = &wrapperspb.BoolValue{Value: true}
+	proto.XDisableMeta = &wrapperspb.BoolValue{Value: true}
+
+	stream, err := server.NewStream(settings.From(proto), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "project-settings", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	result := awaitResult(t, responses, "run")
+	require.Nil(t, result.GetRunResult().GetError())
+	stream.HandleRecord(makeRunStartRecord())
+	stream.FinishAndClose(0)
+
+	return readLog(t, syncFile)
+}
+
+func savedProjectSettings(records []*service.Record) *service.ProjectSettingsRecord {
+	for _, record := range records {
+		if record.GetProjectSettings() != nil {
+			return record.GetProjectSettings()
+		}
+	}
+	return nil
+}
+
+// The project's defaults apply to the settings the run doesn't set itself,
+// and the settings in effect are saved for the sync.
+func TestProjectSettings_LocalSettingsTakePrecedence(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubGraphQL("ProjectSettings", projectSettingsResponse)
+	backend.StubCreateRunFiles()
+	proto := &service.Settings{DisableGit: wrapperspb.Bool(false)}
+
+	records := runWithProjectSettings(t, backend, proto)
+
+	assert.True(t, proto.GetSaveCode().GetValue())
+	assert.False(t, proto.GetDisableGit().GetValue())
+	// the project is the one the server resolved the run's to
+	requests := backend.GraphQLRequests("ProjectSettings")
+	require.Len(t, requests, 1)
+	assert.Contains(t, string(requests[0].Body),
+		`"variables":{"entityName":"FakeEntity","projectName":"FakeProject"}`)
+
+	saved := savedProjectSettings(records)
+	require.NotNil(t, saved)
+	effective := map[string]string{}
+	for _, item := range saved.GetEffective().GetItem() {
+		effective[item.GetKey()] = item.GetValueJson()
+	}
+	assert.Equal(t,
+		map[string]string{"save_code": "true", "disable_git": "false"},
+		effective)
+}
+
+// A backend that doesn't support project settings leaves the local
+// defaults in effect, and the run is created as usual.
+func TestProjectSettings_UnsupportedByBackend(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubGraphQLError("ProjectSettings", "GRAPHQL_VALIDATION_FAILED",
+		`Cannot query field "runDefaults" on type "Project".`)
+	backend.StubCreateRunFiles()
+	proto := &service.Settings{}
+
+	records := runWithProjectSettings(t, backend, proto)
+
+	assert.Nil(t, proto.GetSaveCode())
+	assert.Nil(t, proto.GetDisableGit())
+	assert.Len(t, backend.GraphQLRequests("ProjectSettings"), 1)
+	assert.Nil(t, savedProjectSettings(records))
+}
+
+// Syncing a run applies the settings it ran with, rather than fetching
+// the project's again.
+func TestProjectSettings_SyncUsesSavedSettings(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubGraphQLOnce("ProjectSettings", projectSettingsResponse)
+	backend.StubCreateRunFiles()
+	proto := &service.Settings{}
+	runWithProjectSettings(t, backend, proto)
+
+	dir := t.TempDir()
+	syncProto := &service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "project-settings"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      proto.GetSyncFile(),
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+		SaveCode:      wrapperspb.Bool(false),
+	}
+	sync, err := server.NewStream(settings.From(syncProto), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	sync.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	sync.Start()
+	sync.HandleRecord(disabledRequest("sync", &service.Request{
+		RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+	}))
+	result := awaitResult(t, responses, "sync")
+	sync.Close()
+
+	assert.Nil(t, result.GetResponse().GetSyncResponse().GetError())
+	assert.Len(t, backend.GraphQLRequests("ProjectSettings"), 1)
+	assert.True(t, syncProto.GetSaveCode().GetValue())
+	assert.True(t, syncProto.GetDisableGit().GetValue())
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// quotaNotice is what the user is told when their organization exceeds a
+// limit.
+//
+// It names the limit and links to the settings page where usage can be
+// managed.
+func quotaNotice(settings *settings.Settings, err *api.QuotaExceededError) string {
+	appURL := strings.Replace(settings.Proto.GetBaseUrl().GetValue(), "//api.", "//", 1)
+	return fmt.Sprintf(
+		"Your W&B organization has exceeded its %s limit, so uploads"+
+			" are stopped. The run's data is still saved locally; sync it"+
+			" with `wandb sync` once the limit allows. Manage your"+
+			" organization's usage at %s/settings.",
+		err.Limit,
+		appURL,
+	)
+}
+
+// quotaGraphQLClient notices GraphQL operations that fail because a limit
+// was exceeded.
+//
+// The backend sees most such failures in its responses, but GraphQL
+// errors can come with a successful status.
+type quotaGraphQLClient struct {
+	delegate graphql.Client
+	quota    *api.QuotaWatcher
+}
+
+func (c *quotaGraphQLClient) MakeRequest(
+	ctx context.Context,
+	req *graphql.Request,
+	resp *graphql.Response,
+) error {
+	err := c.delegate.MakeRequest(ctx, req, resp)
+	if quotaErr := api.QuotaExceededInGraphQL(err); quotaErr != nil {
+		c.quota.Report(quotaErr)
+		return fmt.Errorf("%w: %v", quotaErr, err)
+	}
+	return err
+}
+
+// quotaExceededResult describes the limit that stopped the run's uploads,
+// or returns nil if none did.
+func quotaExceededResult(quota *api.QuotaWatcher) *service.QuotaExceeded {
+	exceeded := quota.Exceeded()
+	if exceeded == nil {
+		return nil
+	}
+	return &service.QuotaExceeded{
+		Limit:   exceeded.Limit,
+		Message: exceeded.Message,
+	}
+}
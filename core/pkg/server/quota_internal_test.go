@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+type graphQLClientFunc func(context.Context, *graphql.Request, *graphql.Response) error
+
+func (f graphQLClientFunc) MakeRequest(
+	ctx context.Context,
+	req *graphql.Request,
+	resp *graphql.Response,
+) error {
+	return f(ctx, req, resp)
+}
+
+func TestQuotaGraphQLClient_ReportsExceededLimit(t *testing.T) {
+	var reported []string
+	quota := api.NewQuotaWatcher(func(err *api.QuotaExceededError) {
+		reported = append(reported, err.Limit)
+	})
+	client := &quotaGraphQLClient{
+		delegate: graphQLClientFunc(
+			func(context.Context, *graphql.Request, *graphql.Response) error {
+				return gqlerror.List{{
+					Message:    "Your organization has used all of its tracked hours.",
+					Extensions: map[string]any{"code": "TRACKED_HOURS_LIMIT_EXCEEDED"},
+				}}
+			}),
+		quota: quota,
+	}
+
+	for range 2 {
+		err := client.MakeRequest(context.Background(),
+			&graphql.Request{}, &graphql.Response{})
+
+		var quotaErr *api.QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, "tracked hours", quotaErr.Limit)
+	}
+	assert.Equal(t, []string{"tracked hours"}, reported)
+	assert.Equal(t,
+		&service.QuotaExceeded{
+			Limit:   "tracked hours",
+			Message: "Your organization has used all of its tracked hours.",
+		},
+		quotaExceededResult(quota))
+}
+
+func TestQuotaNotice(t *testing.T) {
+	s := settings.From(&service.Settings{
+		BaseUrl: wrapperspb.String("https://api.wandb.ai"),
+	})
+
+	notice := quotaNotice(s, &api.QuotaExceededError{Limit: "storage"})
+
+	assert.Contains(t, notice, "storage limit")
+	assert.Contains(t, notice, "wandb sync")
+	assert.Contains(t, notice, "https://wandb.ai/settings")
+}
+
+func TestQuotaExceededResult_NoneExceeded(t *testing.T) {
+	assert.Nil(t, quotaExceededResult(nil))
+	assert.Nil(t, quotaExceededResult(api.NewQuotaWatcher(nil)))
+}
@@ -0,0 +1,106 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// startRankStream starts the stream of one labeled writer of the shared
+// run "run1", with its files in filesDir.
+func startRankStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	filesDir string,
+	label string,
+	primary bool,
+) *server.Stream {
+	t.Helper()
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         wrapperspb.String("run1"),
+		BaseUrl:       wrapperspb.String(backend.URL()),
+		ApiKey:        wrapperspb.String("test-api-key"),
+		LogDir:        wrapperspb.String(dir),
+		SyncFile:      wrapperspb.String(filepath.Join(dir, "run-run1.wandb")),
+		FilesDir:      wrapperspb.String(filesDir),
+		XDisableStats: wrapperspb.Bool(true),
+		XDisableMeta:  wrapperspb.Bool(true),
+		XShared:       wrapperspb.Bool(true),
+		XPrimary:      wrapperspb.Bool(primary),
+		XLabel:        wrapperspb.String(label),
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				RunId:   "run1",
+				Entity:  "FakeEntity",
+				Project: "FakeProject",
+			},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	require.Nil(t, awaitResult(t, responses, "run").GetRunResult().GetError())
+	stream.HandleRecord(makeRunStartRecord())
+	return stream
+}
+
+// Each rank other than the primary writes its console output to a file of
+// its own, which is uploaded at exit, while its streamed lines are tagged
+// with its label.
+func TestRankOutput_FilePerLabeledWriter(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubGraphQL("RunResumeStatus", validRunResumeStatusResponse)
+	backend.StubCreateRunFiles()
+	filesDir := t.TempDir()
+	rank0 := startRankStream(t, backend, filesDir, "node0", true)
+	rank1 := startRankStream(t, backend, filesDir, "node1", false)
+
+	rank0.HandleRecord(makeOutputRawRecord("rank 0 epoch 1\n"))
+	rank1.HandleRecord(makeOutputRawRecord("rank 1 epoch 1\n"))
+	// the primary marks the run as finished only after the secondary
+	rank1.FinishAndClose(0)
+	rank0.FinishAndClose(0)
+
+	rank0Output, err := os.ReadFile(filepath.Join(filesDir, "output.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rank0Output), "rank 0 epoch 1")
+	assert.NotContains(t, string(rank0Output), "rank 1")
+	rank1Output, err := os.ReadFile(filepath.Join(filesDir, "output_node1.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rank1Output), "[node1] rank 1 epoch 1")
+	assert.NotContains(t, string(rank1Output), "rank 0")
+	assert.NoFileExists(t, filepath.Join(filesDir, "output_node0.log"))
+
+	uploaded := make(map[string]string)
+	for _, request := range backend.Requests(servertest.RouteUpload) {
+		uploaded[filepath.Base(request.Path)] = string(request.Body)
+	}
+	assert.Contains(t, uploaded["output_node1.log"], "[node1] rank 1 epoch 1")
+	assert.NotContains(t, uploaded, "output.log")
+
+	var streamed string
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		streamed += string(request.Body)
+	}
+	assert.Contains(t, streamed, "rank 0 epoch 1")
+	assert.Contains(t, streamed, "[node1] rank 1 epoch 1")
+	assert.NotContains(t, streamed, "[node0]")
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// persistence is how the writer saves a type of record to the transaction
+// log.
+type persistence int
+
+const (
+	// persist saves the record as it is.
+	persist persistence = iota
+
+	// skip doesn't save the record, because replaying it changes nothing
+	// on the backend.
+	skip
+
+	// persistCompacted saves the record without the fields that only
+	// route it between the client and wandb-core, which mean nothing once
+	// the client is gone.
+	persistCompacted
+)
+
+// recordPolicies is how each type of record is saved, by the name that
+// recordTypeName gives it.
+//
+// Every type of record has an entry, so that adding a type to the protocol
+// is a decision about whether syncing needs it.
+var recordPolicies = map[string]persistence{
+	// records that finish the run or that the backend state is built from
+	// exactly as the client sent them
+	"run":           persist,
+	"exit":          persist,
+	"final":         persist,
+	"header":        persist,
+	"footer":        persist,
+	"preempting":    persist,
+	"checkpoint":    persist,
+	"artifact":      persist,
+	"link_artifact": persist,
+	"use_artifact":  persist,
+	"tbrecord":      persist,
+
+	// the uploader reports progress to the connection that saved the files,
+	// including when they are read back from the log after going offline
+	"files": persist,
+
+	// the handler writes the table that the rows make up, whose files
+	// record is what's replayed
+	"table_rows": skip,
+
+	// the abort of the run is saved so that the run isn't synced, and is
+	// answered like the request it comes from
+	"aborted": persistCompacted,
+
+	// so is the note that the run was finished on the server while it ran,
+	// so that syncing doesn't append to the finished run
+	"conflicted": persist,
+
+	// so are the settings the run's project had defaults for, so that
+	// syncing uses the ones the run ran with
+	"project_settings": persist,
+
+	// an offline run's messages for the user are added to its console logs
+	// when it's synced
+	"user_message": persist,
+
+	// records that are logged often, whose data is what's replayed
+	"history":    persistCompacted,
+	"summary":    persistCompacted,
+	"output":     persistCompacted,
+	"output_raw": persistCompacted,
+	"config":     persistCompacted,
+	"stats":      persistCompacted,
+	"metric":     persistCompacted,
+	"telemetry":  persistCompacted,
+	"alert":      persistCompacted,
+
+	// requests ask about or steer the live run, and the sync makes its own
+	"request.stop_status":            skip,
+	"request.network_status":         skip,
+	"request.defer":                  skip,
+	"request.get_summary":            skip,
+	"request.login":                  skip,
+	"request.pause":                  skip,
+	"request.resume":                 skip,
+	"request.poll_exit":              skip,
+	"request.sampled_history":        skip,
+	"request.partial_history":        skip,
+	"request.run_start":              skip,
+	"request.check_version":          skip,
+	"request.log_artifact":           skip,
+	"request.download_artifact":      skip,
+	"request.keepalive":              skip,
+	"request.run_status":             skip,
+	"request.cancel":                 skip,
+	"request.metadata":               skip,
+	"request.internal_messages":      skip,
+	"request.python_packages":        skip,
+	"request.shutdown":               skip,
+	"request.attach":                 skip,
+	"request.status":                 skip,
+	"request.server_info":            skip,
+	"request.stream_ping":            skip,
+	"request.sender_mark":            skip,
+	"request.sender_read":            skip,
+	"request.status_report":          skip,
+	"request.summary_record":         skip,
+	"request.telemetry_record":       skip,
+	"request.job_info":               skip,
+	"request.get_system_metrics":     skip,
+	"request.sync":                   skip,
+	"request.job_input":              skip,
+	"request.credentials_update":     skip,
+	"request.cleanup":                skip,
+	"request.settings_update":        skip,
+	"request.run_updated":            skip,
+	"request.artifact_wait":          skip,
+	"request.artifact_cancel":        skip,
+	"request.list_run_outputs":       skip,
+	"request.abort_run":              skip,
+	"request.backpressure":           skip,
+	"request.prepare_for_preemption": skip,
+	"request.get_settings":           skip,
+	"request.test_inject":            skip,
+}
+
+// recordPolicy returns how the record is saved to the transaction log.
+//
+// Requests without a policy are skipped like other requests, and other
+// records without one are saved as they are, so that nothing the sync may
+// need is lost. That includes records of types this version doesn't know,
+// which are saved with their unknown fields for a newer version to sync.
+func recordPolicy(record *service.Record) persistence {
+	if policy, ok := recordPolicies[recordTypeName(record)]; ok {
+		return policy
+	}
+	if record.GetRequest() != nil {
+		return skip
+	}
+	return persist
+}
+
+// compactRecord returns the record without the fields that route it
+// between the client and wandb-core.
+//
+// The result shares the record's data, so neither may be modified after.
+func compactRecord(record *service.Record) *service.Record {
+	compacted := &service.Record{
+		Num:        record.Num,
+		RecordType: record.RecordType,
+	}
+
+	if control := record.GetControl(); control != nil {
+		kept := &service.Control{
+			Local:       control.Local,
+			AlwaysSend:  control.AlwaysSend,
+			FlowControl: control.FlowControl,
+			EndOffset:   control.EndOffset,
+			PersistOnly: control.PersistOnly,
+		}
+		if kept.Local || kept.AlwaysSend || kept.FlowControl ||
+			kept.EndOffset != 0 || kept.PersistOnly {
+			compacted.Control = kept
+		}
+	}
+
+	return compacted
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// knownRecordTypes returns the names of every type of record in the
+// protocol, as recordTypeName gives them.
+func knownRecordTypes() []string {
+	var names []string
+
+	recordTypes := (&service.Record{}).ProtoReflect().Descriptor().
+		Oneofs().ByName("record_type").Fields()
+	for i := range recordTypes.Len() {
+		if name := string(recordTypes.Get(i).Name()); name != "request" {
+			names = append(names, name)
+		}
+	}
+
+	requestTypes := (&service.Request{}).ProtoReflect().Descriptor().
+		Oneofs().ByName("request_type").Fields()
+	for i := range requestTypes.Len() {
+		names = append(names, "request."+string(requestTypes.Get(i).Name()))
+	}
+
+	return names
+}
+
+func TestRecordPolicies_CoverEveryRecordType(t *testing.T) {
+	known := knownRecordTypes()
+
+	for _, name := range known {
+		_, ok := recordPolicies[name]
+		assert.True(t, ok, "no persistence policy for %q", name)
+	}
+	for name := range recordPolicies {
+		assert.Contains(t, known, name, "policy for unknown record type")
+	}
+}
+
+func TestRecordPolicies_SkipEveryRequest(t *testing.T) {
+	for _, name := range knownRecordTypes() {
+		if strings.HasPrefix(name, "request.") {
+			assert.Equal(t, skip, recordPolicies[name], name)
+		}
+	}
+}
+
+func TestRecordPolicy_UnknownTypes(t *testing.T) {
+	record := parseWithField(t, &service.Record{}, 500)
+	request := &service.Record{
+		RecordType: &service.Record_Request{
+			Request: parseWithField(t, &service.Request{}, 500),
+		},
+	}
+
+	assert.Equal(t, persist, recordPolicy(record))
+	assert.Equal(t, skip, recordPolicy(request))
+}
+
+func TestCompactRecord_DropsRouting(t *testing.T) {
+	history := &service.HistoryRecord{
+		Item: []*service.HistoryItem{{Key: "loss", ValueJson: "0.5"}},
+	}
+	record := &service.Record{
+		Num:        3,
+		RecordType: &service.Record_History{History: history},
+		Uuid:       "uuid",
+		XInfo:      &service.XRecordInfo{StreamId: "stream"},
+		Control: &service.Control{
+			ReqResp:      true,
+			RelayId:      "relay",
+			MailboxSlot:  "slot",
+			ConnectionId: "client",
+			AlwaysSend:   true,
+		},
+	}
+
+	compacted := compactRecord(record)
+
+	assert.EqualValues(t, 3, compacted.Num)
+	assert.Same(t, history, compacted.GetHistory())
+	assert.Empty(t, compacted.Uuid)
+	assert.Nil(t, compacted.XInfo)
+	assert.True(t, compacted.GetControl().GetAlwaysSend())
+	assert.False(t, compacted.GetControl().GetReqResp())
+	assert.Empty(t, compacted.GetControl().GetRelayId())
+	assert.Empty(t, compacted.GetControl().GetMailboxSlot())
+	assert.Empty(t, compacted.GetControl().GetConnectionId())
+	// the original is still routed back to its client
+	assert.Equal(t, "client", record.GetControl().GetConnectionId())
+}
+
+func TestCompactRecord_NoControlIfOnlyRouting(t *testing.T) {
+	record := &service.Record{
+		RecordType: &service.Record_Summary{Summary: &service.SummaryRecord{}},
+		Control:    &service.Control{MailboxSlot: "slot"},
+	}
+
+	assert.Nil(t, compactRecord(record).Control)
+}
@@ -0,0 +1,209 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// liveRecords returns what a client sends during a short run, routed the
+// way the client routes them.
+//
+// Its requests are ones that the sender accepts when syncing.
+func liveRecords() []*service.Record {
+	routed := func(record *service.Record) *service.Record {
+		record.Control = &service.Control{
+			ConnectionId: "client",
+			MailboxSlot:  "slot",
+			RelayId:      "relay",
+			ReqResp:      true,
+		}
+		record.Uuid = "uuid"
+		record.XInfo = &service.XRecordInfo{StreamId: "policy"}
+		return record
+	}
+	request := func(request *service.Request) *service.Record {
+		return routed(&service.Record{
+			RecordType: &service.Record_Request{Request: request},
+		})
+	}
+
+	return []*service.Record{
+		routed(&service.Record{
+			RecordType: &service.Record_Run{
+				Run: &service.RunRecord{RunId: "policy", Project: "testProject"},
+			},
+		}),
+		request(&service.Request{
+			RequestType: &service.Request_ServerInfo{
+				ServerInfo: &service.ServerInfoRequest{},
+			},
+		}),
+		routed(makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5", "_step": "0"},
+			step:  0,
+		})),
+		request(&service.Request{
+			RequestType: &service.Request_StopStatus{
+				StopStatus: &service.StopStatusRequest{},
+			},
+		}),
+		routed(makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.25", "_step": "1"},
+			step:  1,
+		})),
+		request(&service.Request{
+			RequestType: &service.Request_NetworkStatus{
+				NetworkStatus: &service.NetworkStatusRequest{},
+			},
+		}),
+		routed(&service.Record{
+			RecordType: &service.Record_Summary{
+				Summary: &service.SummaryRecord{
+					Update: []*service.SummaryItem{{Key: "loss", ValueJson: "0.25"}},
+				},
+			},
+		}),
+		routed(&service.Record{
+			RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		}),
+	}
+}
+
+// writeThroughWriter saves the records as the writer does.
+func writeThroughWriter(t *testing.T, syncFile string, records []*service.Record) {
+	inChan := make(chan *service.Record, len(records))
+	fwdChan := make(chan *service.Record, len(records))
+	writer := server.NewWriter(context.Background(), &server.WriterParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{SyncFile: &wrapperspb.StringValue{Value: syncFile}},
+		FwdChan:         fwdChan,
+		TerminalPrinter: observability.NewPrinter(),
+	})
+	for _, record := range records {
+		inChan <- proto.Clone(record).(*service.Record)
+	}
+	close(inChan)
+	writer.Do(inChan)
+	for range fwdChan {
+	}
+}
+
+// writeEverything saves all the records as they are.
+func writeEverything(t *testing.T, syncFile string, records []*service.Record) {
+	log, err := transactionlog.Create(syncFile)
+	require.NoError(t, err)
+	for i, record := range records {
+		record = proto.Clone(record).(*service.Record)
+		record.Num = int64(i + 1)
+		_, err := log.Write(record)
+		require.NoError(t, err)
+	}
+	require.NoError(t, log.Close())
+}
+
+// syncedState syncs the transaction log and returns the history lines and
+// summary the backend received.
+func syncedState(t *testing.T, syncFile string) ([]string, map[string]any) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	dir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "policy"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	var history []string
+	summary := map[string]any{}
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		history = append(history, body.Files["wandb-history.jsonl"].Content...)
+		for _, line := range body.Files["wandb-summary.json"].Content {
+			require.NoError(t, json.Unmarshal([]byte(line), &summary))
+		}
+	}
+	return history, summary
+}
+
+func TestWriter_SkipsRequestsAndCompactsRecords(t *testing.T) {
+	syncFile := filepath.Join(t.TempDir(), "run-policy.wandb")
+
+	writeThroughWriter(t, syncFile, liveRecords())
+
+	records := readLog(t, syncFile)
+	var types []string
+	for i, record := range records {
+		assert.EqualValues(t, i+1, record.Num)
+		assert.Nil(t, record.GetRequest())
+		switch record.RecordType.(type) {
+		case *service.Record_History:
+			types = append(types, "history")
+			assert.Nil(t, record.Control)
+			assert.Empty(t, record.Uuid)
+			assert.Nil(t, record.XInfo)
+		case *service.Record_Run:
+			types = append(types, "run")
+			assert.Equal(t, "client", record.GetControl().GetConnectionId())
+		default:
+			types = append(types, "other")
+		}
+	}
+	assert.Equal(t,
+		[]string{"run", "history", "history", "other", "other"},
+		types)
+}
+
+func TestWriter_SyncIsSameWithoutSkippedRecords(t *testing.T) {
+	dir := t.TempDir()
+	everything := filepath.Join(dir, "run-everything.wandb")
+	written := filepath.Join(dir, "run-written.wandb")
+	writeEverything(t, everything, liveRecords())
+	writeThroughWriter(t, written, liveRecords())
+	require.Less(t, len(readLog(t, written)), len(readLog(t, everything)))
+
+	wantHistory, wantSummary := syncedState(t, everything)
+	history, summary := syncedState(t, written)
+
+	require.Len(t, wantHistory, 2)
+	assert.Equal(t, wantHistory, history)
+	assert.Equal(t, 0.25, wantSummary["loss"])
+	assert.Equal(t, wantSummary, summary)
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// recordTypeName returns the name of the record's type as it is logged,
+// like "history" or, for requests, "request.defer".
+//
+// Types that this version doesn't know are named by their field number,
+// like "unknown_32" or "request.unknown_90". It is empty for records
+// without a type.
+func recordTypeName(record *service.Record) string {
+	m := record.ProtoReflect()
+	field := m.WhichOneof(m.Descriptor().Oneofs().ByName("record_type"))
+	if field == nil {
+		if number := unknownTypeNumber(m, "record_type"); number != 0 {
+			return fmt.Sprintf("unknown_%d", number)
+		}
+		return ""
+	}
+	name := string(field.Name())
+
+	request := record.GetRequest().ProtoReflect()
+	if requestField := request.WhichOneof(
+		request.Descriptor().Oneofs().ByName("request_type"),
+	); requestField != nil {
+		name += "." + string(requestField.Name())
+	} else if number := unknownTypeNumber(request, "request_type"); number != 0 {
+		name += fmt.Sprintf(".unknown_%d", number)
+	}
+
+	return name
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// parseWithField returns the message parsed with an extra field of an
+// empty message, like a type added after this version.
+func parseWithField[T proto.Message](
+	t *testing.T,
+	message T,
+	number protowire.Number,
+) T {
+	data, err := proto.Marshal(message)
+	require.NoError(t, err)
+	data = protowire.AppendTag(data, number, protowire.BytesType)
+	data = protowire.AppendBytes(data, nil)
+
+	parsed := message.ProtoReflect().New().Interface().(T)
+	require.NoError(t, proto.Unmarshal(data, parsed))
+	return parsed
+}
+
+func TestRecordTypeName(t *testing.T) {
+	assert.Equal(t, "", recordTypeName(&service.Record{}))
+	assert.Equal(t, "history", recordTypeName(&service.Record{
+		RecordType: &service.Record_History{History: &service.HistoryRecord{}},
+	}))
+	assert.Equal(t, "request.defer", recordTypeName(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Defer{Defer: &service.DeferRequest{}},
+			},
+		},
+	}))
+}
+
+func TestRecordTypeName_Unknown(t *testing.T) {
+	record := parseWithField(t, &service.Record{Uuid: "uuid"}, 500)
+	request := &service.Record{
+		RecordType: &service.Record_Request{
+			Request: parseWithField(t, &service.Request{}, 500),
+		},
+	}
+
+	assert.Equal(t, "unknown_500", recordTypeName(record))
+	assert.Equal(t, "request.unknown_500", recordTypeName(request))
+	assert.True(t, isUnknownType(record))
+	assert.True(t, isUnknownType(request))
+	assert.False(t, isUnknownType(&service.Record{}))
+}
+
+// Fields added to known types aren't mistaken for new types.
+func TestIsUnknownType_NewFieldOfKnownType(t *testing.T) {
+	record := parseWithField(t, &service.Record{
+		RecordType: &service.Record_History{History: &service.HistoryRecord{}},
+	}, 500)
+
+	assert.False(t, isUnknownType(record))
+	assert.Equal(t, "history", recordTypeName(record))
+}
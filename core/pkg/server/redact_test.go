@@ -0,0 +1,109 @@
+package server_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+const (
+	secretAPIKey = "0123456789abcdef0123456789abcdef01234567"
+	secretHeader = "very-secret-header-value"
+	secretNewKey = "fedcba9876543210fedcba9876543210fedcba98"
+)
+
+// No file produced by a run may contain its secrets, even with debug
+// logging and with the settings stored in the transaction log.
+func TestStream_SecretsNotInProducedFiles(t *testing.T) {
+	t.Setenv("WANDB_CORE_DEBUG", "1")
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-redact.wandb")
+	s := settings.From(&service.Settings{
+		RunId:       &wrapperspb.StringValue{Value: "redact"},
+		ApiKey:      &wrapperspb.StringValue{Value: secretAPIKey},
+		LogDir:      &wrapperspb.StringValue{Value: dir},
+		LogInternal: &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:    &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:    &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XOffline:    &wrapperspb.BoolValue{Value: true},
+		XExtraHttpHeaders: &service.MapStringKeyStringValue{
+			Value: map[string]string{"X-Secret": secretHeader},
+		},
+		XDisableStats:          &wrapperspb.BoolValue{Value: true},
+		XStoreRedactedSettings: &wrapperspb.BoolValue{Value: true},
+	})
+
+	stream, err := server.NewStream(s, "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "redact"},
+		},
+	})
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_CredentialsUpdate{
+					CredentialsUpdate: &service.CredentialsUpdateRequest{
+						ApiKey: secretNewKey,
+					},
+				},
+			},
+		},
+	})
+	stream.FinishAndClose(0)
+
+	var files int
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files++
+		for _, secret := range []string{secretAPIKey, secretHeader, secretNewKey} {
+			assert.NotContains(t, string(content), secret, "secret in %s", path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, files, 2)
+
+	reader, err := transactionlog.Open(syncFile)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var stored *service.SettingsRecord
+	for {
+		record, _, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if run := record.GetRun(); run != nil {
+			stored = run.GetSettings()
+		}
+	}
+	require.NotNil(t, stored)
+
+	items := make(map[string]string)
+	for _, item := range stored.GetItem() {
+		items[item.GetKey()] = item.GetValueJson()
+	}
+	assert.Equal(t, `"redact"`, items["run_id"])
+	assert.Equal(t, `"***"`, items["api_key"])
+}
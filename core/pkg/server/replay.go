@@ -0,0 +1,442 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/pathtree"
+	"github.com/wandb/wandb/core/internal/runconfig"
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+const (
+	// replaySeed seeds the history samplers of a replay.
+	replaySeed = 0
+
+	// replayCaughtUpSlot is the mailbox slot of the requests that check
+	// that the handler processed all records replayed so far.
+	replayCaughtUpSlot = "replay-caught-up"
+
+	// replaySampledHistorySlot is the mailbox slot of the request for the
+	// replayed run's sampled history.
+	replaySampledHistorySlot = "replay-sampled-history"
+)
+
+// ReplayState is the state derived from replaying a transaction log.
+type ReplayState struct {
+	// Records is the number of records replayed.
+	Records int `json:"records"`
+
+	// LastOffset is the offset of the last record replayed.
+	LastOffset int64 `json:"last_offset"`
+
+	// CheckpointOffset is the offset of the checkpoint the replay started
+	// from, or 0 if it started from the first record.
+	//
+	// Replaying from a checkpoint restores the config, summary and
+	// metrics from it, but the files and sampled history only include
+	// the records after it.
+	CheckpointOffset int64 `json:"checkpoint_offset,omitempty"`
+
+	// Errors are the errors reading the log, which skipped records.
+	Errors []string `json:"errors,omitempty"`
+
+	Config         pathtree.TreeData    `json:"config"`
+	Summary        pathtree.TreeData    `json:"summary"`
+	SampledHistory map[string][]float32 `json:"sampled_history"`
+	Files          []string             `json:"files"`
+}
+
+// Replay passes the records of a transaction log through a new handler
+// and returns the state it derives.
+//
+// Records after untilOffset are not replayed, unless it is negative.
+//
+// If fromCheckpoint is set and the log's last checkpoint is usable, only
+// the records after it are replayed, on top of the state it saved. A
+// missing or corrupted checkpoint falls back to replaying everything.
+//
+// The replay is deterministic: the run is timed by the timestamps in the
+// records rather than the system clock, and history is sampled with a
+// fixed seed. Nothing is written or sent; the handler's output goes to a
+// sender that only collects the config and the list of files.
+func Replay(
+	path string,
+	untilOffset int64,
+	fromCheckpoint bool,
+) (*ReplayState, error) {
+	state := &ReplayState{LastOffset: -1}
+
+	var reader *transactionlog.Reader
+	var checkpoint *replayCheckpoint
+	if fromCheckpoint {
+		var err error
+		reader, checkpoint, err = openAtCheckpoint(path, untilOffset)
+		if err != nil {
+			state.Errors = append(state.Errors,
+				fmt.Sprintf("checkpoint: %v", err))
+		}
+	}
+	if reader == nil {
+		var err error
+		reader, err = transactionlog.Open(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer reader.Close()
+
+	r := newReplay(nil)
+	if checkpoint != nil {
+		state.CheckpointOffset = checkpoint.offset
+		state.LastOffset = checkpoint.offset
+		r.restore(checkpoint.record)
+	}
+	for {
+		record, offset, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			state.Errors = append(state.Errors, err.Error())
+			continue
+		}
+		if untilOffset >= 0 && offset > untilOffset {
+			break
+		}
+
+		state.Records++
+		state.LastOffset = offset
+		r.replayRecord(record)
+	}
+
+	r.finish(state)
+	return state, nil
+}
+
+// replayCheckpoint is a checkpoint read from a transaction log.
+type replayCheckpoint struct {
+	record *service.Record
+	offset int64
+}
+
+// openAtCheckpoint opens the transaction log for reading after its last
+// checkpoint, which it returns.
+//
+// It returns a nil reader if the log has no checkpoint before untilOffset,
+// and an error too if the checkpoint can't be used.
+func openAtCheckpoint(
+	path string,
+	untilOffset int64,
+) (*transactionlog.Reader, *replayCheckpoint, error) {
+	index, err := transactionlog.ReadCheckpointIndex(path)
+	if err != nil || index == nil {
+		return nil, nil, err
+	}
+	if untilOffset >= 0 && index.Offset > untilOffset {
+		return nil, nil, nil
+	}
+
+	reader, err := transactionlog.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record, err := readCheckpoint(reader, index.Offset)
+	if err != nil {
+		_ = reader.Close()
+		return nil, nil, err
+	}
+	return reader, &replayCheckpoint{record: record, offset: index.Offset}, nil
+}
+
+// readCheckpoint reads the checkpoint at the offset and checks that it can
+// be used.
+func readCheckpoint(
+	reader *transactionlog.Reader,
+	offset int64,
+) (*service.Record, error) {
+	if err := reader.SeekRecord(offset); err != nil {
+		return nil, err
+	}
+
+	record, recordOffset, err := reader.Next()
+	switch {
+	case err != nil:
+		return nil, err
+	case recordOffset != offset:
+		return nil, fmt.Errorf("no record at offset %d", offset)
+	}
+	if err := validateCheckpoint(record.GetCheckpoint()); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// replay runs a handler for Replay.
+type replay struct {
+	wg sync.WaitGroup
+
+	inChan     chan *service.Record
+	clock      *replayClock
+	runSummary *runsummary.RunSummary
+	sender     *replaySender
+
+	// caughtUp signals that the handler processed all records so far
+	caughtUp chan struct{}
+
+	// sampledHistory is the handler's response to the last request
+	sampledHistory *service.SampledHistoryResponse
+}
+
+// newReplay starts a replay whose handler checkpoints its state when the
+// checkpoints say, if not nil.
+func newReplay(checkpoints *Checkpoints) *replay {
+	r := &replay{
+		inChan:     make(chan *service.Record, BufferSize),
+		clock:      &replayClock{},
+		runSummary: runsummary.New(),
+		sender:     newReplaySender(),
+		caughtUp:   make(chan struct{}),
+	}
+
+	fwdChan := make(chan *service.Record, BufferSize)
+	outChan := make(chan *service.Result, BufferSize)
+	handler := NewHandler(context.Background(),
+		&HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        &service.Settings{},
+			FwdChan:         fwdChan,
+			OutChan:         outChan,
+			RunSummary:      r.runSummary,
+			MetricHandler:   NewMetricHandler(),
+			TerminalPrinter: observability.NewPrinter(),
+			Clock:           r.clock,
+			SamplerRand:     rand.New(rand.NewSource(replaySeed)),
+			Checkpoints:     checkpoints,
+		},
+	)
+
+	r.wg.Add(3)
+	go func() {
+		handler.Do(r.inChan)
+		r.wg.Done()
+	}()
+	go func() {
+		r.sender.do(fwdChan)
+		r.wg.Done()
+	}()
+	go func() {
+		r.readResults(outChan)
+		r.wg.Done()
+	}()
+
+	return r
+}
+
+// replayRecord passes a record from a transaction log to the handler.
+func (r *replay) replayRecord(record *service.Record) {
+	// the clock may only move once the handler is done with the previous
+	// records, or they'd be timed by this one
+	if timestamp, ok := recordTimestamp(record); ok &&
+		timestamp.After(r.clock.Now()) {
+		r.catchUp()
+		r.clock.set(timestamp)
+	}
+
+	r.inChan <- record
+
+	// like syncing, start the run after its run record, as the request
+	// that started it isn't in the log
+	if run := record.GetRun(); run != nil {
+		r.inChan <- &service.Record{
+			RecordType: &service.Record_Request{
+				Request: &service.Request{
+					RequestType: &service.Request_RunStart{
+						RunStart: &service.RunStartRequest{
+							Run: proto.Clone(run).(*service.RunRecord),
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+// restore seeds the replay with the state saved in a checkpoint.
+func (r *replay) restore(record *service.Record) {
+	checkpoint := record.GetCheckpoint()
+
+	// the config is kept by the sender rather than the handler
+	r.sender.runConfig.ApplyChangeRecord(
+		&service.ConfigRecord{Update: checkpoint.GetConfig()},
+		func(err error) {
+			r.sender.errors = append(r.sender.errors,
+				fmt.Sprintf("config: %v", err))
+		},
+	)
+
+	if run := checkpoint.GetRun(); run != nil {
+		if run.GetStartTime() != nil {
+			r.clock.set(run.GetStartTime().AsTime())
+		}
+		r.inChan <- &service.Record{
+			RecordType: &service.Record_Request{
+				Request: &service.Request{
+					RequestType: &service.Request_RunStart{
+						RunStart: &service.RunStartRequest{
+							Run: proto.Clone(run).(*service.RunRecord),
+						},
+					},
+				},
+			},
+		}
+	}
+	r.inChan <- record
+}
+
+// catchUp waits until the handler processed all records sent so far.
+func (r *replay) catchUp() {
+	r.inChan <- replayRequest(
+		&service.Request{
+			RequestType: &service.Request_Status{
+				Status: &service.StatusRequest{},
+			},
+		},
+		replayCaughtUpSlot,
+	)
+	<-r.caughtUp
+}
+
+// finish stops the handler and fills in the derived state.
+func (r *replay) finish(state *ReplayState) {
+	r.inChan <- replayRequest(
+		&service.Request{
+			RequestType: &service.Request_SampledHistory{
+				SampledHistory: &service.SampledHistoryRequest{},
+			},
+		},
+		replaySampledHistorySlot,
+	)
+	close(r.inChan)
+	r.wg.Wait()
+
+	state.Errors = append(state.Errors, r.sender.errors...)
+	state.Config = r.sender.runConfig.Tree()
+	state.Summary = r.runSummary.Tree()
+	state.SampledHistory = make(map[string][]float32)
+	for _, item := range r.sampledHistory.GetItem() {
+		state.SampledHistory[item.GetKey()] = item.GetValuesFloat()
+	}
+	state.Files = r.sender.files
+	slices.Sort(state.Files)
+	state.Files = slices.Compact(state.Files)
+}
+
+func (r *replay) readResults(outChan <-chan *service.Result) {
+	for result := range outChan {
+		switch result.GetControl().GetMailboxSlot() {
+		case replayCaughtUpSlot:
+			r.caughtUp <- struct{}{}
+		case replaySampledHistorySlot:
+			r.sampledHistory = result.GetResponse().GetSampledHistoryResponse()
+		}
+	}
+}
+
+// replayRequest returns a request record whose response is identified by
+// the mailbox slot.
+func replayRequest(request *service.Request, slot string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{Request: request},
+		Control:    &service.Control{MailboxSlot: slot},
+	}
+}
+
+// recordTimestamp returns the time at which a record was made, if it
+// says.
+func recordTimestamp(record *service.Record) (time.Time, bool) {
+	switch x := record.GetRecordType().(type) {
+	case *service.Record_Run:
+		if x.Run.GetStartTime() != nil {
+			return x.Run.GetStartTime().AsTime(), true
+		}
+	case *service.Record_History:
+		for _, item := range x.History.GetItem() {
+			if item.GetKey() != "_timestamp" {
+				continue
+			}
+			seconds, err := strconv.ParseFloat(item.GetValueJson(), 64)
+			if err == nil {
+				return time.UnixMicro(int64(seconds * 1e6)), true
+			}
+		}
+	case *service.Record_Stats:
+		if x.Stats.GetTimestamp() != nil {
+			return x.Stats.GetTimestamp().AsTime(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// replayClock is a clock that is set by the replay.
+type replayClock struct {
+	now atomic.Int64
+}
+
+func (c *replayClock) Now() time.Time {
+	return time.UnixMicro(c.now.Load())
+}
+
+func (c *replayClock) set(now time.Time) {
+	c.now.Store(now.UnixMicro())
+}
+
+// replaySender stands in for the sender in a replay, collecting the
+// run's config and files instead of sending anything.
+type replaySender struct {
+	runConfig *runconfig.RunConfig
+	files     []string
+	errors    []string
+
+	// checkpoint is the last checkpoint the handler made
+	checkpoint *service.CheckpointRecord
+}
+
+func newReplaySender() *replaySender {
+	return &replaySender{runConfig: runconfig.New()}
+}
+
+func (s *replaySender) do(fwdChan <-chan *service.Record) {
+	onError := func(err error) {
+		s.errors = append(s.errors, fmt.Sprintf("config: %v", err))
+	}
+
+	for record := range fwdChan {
+		switch x := record.GetRecordType().(type) {
+		case *service.Record_Run:
+			s.runConfig.ApplyChangeRecord(x.Run.GetConfig(), onError)
+		case *service.Record_Config:
+			s.runConfig.ApplyChangeRecord(x.Config, onError)
+		case *service.Record_Files:
+			for _, file := range x.Files.GetFiles() {
+				s.files = append(s.files, file.GetPath())
+			}
+		case *service.Record_Checkpoint:
+			s.checkpoint = x.Checkpoint
+		}
+	}
+}
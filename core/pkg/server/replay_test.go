@@ -0,0 +1,107 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// writeReplayLog writes a run with a config, files and a few history
+// steps, and returns its path and the offset of each record.
+func writeReplayLog(t *testing.T) (string, []int64) {
+	path := filepath.Join(t.TempDir(), "run-replay.wandb")
+	writer, err := transactionlog.Create(path)
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+	records := []*service.Record{
+		{RecordType: &service.Record_Run{Run: &service.RunRecord{
+			RunId:     "replay",
+			StartTime: timestamppb.New(start),
+			Config: &service.ConfigRecord{Update: []*service.ConfigItem{
+				{Key: "lr", ValueJson: "0.1"},
+			}},
+		}}},
+		{RecordType: &service.Record_Config{Config: &service.ConfigRecord{
+			Update: []*service.ConfigItem{{Key: "epochs", ValueJson: "3"}},
+		}}},
+		{RecordType: &service.Record_Files{Files: &service.FilesRecord{
+			Files: []*service.FilesItem{{Path: "model.pt"}, {Path: "config.yaml"}},
+		}}},
+	}
+	for step := 0; step < 3; step++ {
+		records = append(records, &service.Record{
+			RecordType: &service.Record_History{History: &service.HistoryRecord{
+				Step: &service.HistoryStep{Num: int64(step)},
+				Item: []*service.HistoryItem{
+					{Key: "loss", ValueJson: []string{"0.5", "0.25", "0.125"}[step]},
+					{Key: "_timestamp", ValueJson: []string{
+						"1700000010", "1700000020", "1700000030"}[step]},
+				},
+			}},
+		})
+	}
+
+	var offsets []int64
+	for _, record := range records {
+		offset, err := writer.Write(record)
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+	require.NoError(t, writer.Close())
+	return path, offsets
+}
+
+func TestReplay(t *testing.T) {
+	path, offsets := writeReplayLog(t)
+
+	state, err := server.Replay(path, -1, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, state.Records)
+	assert.Equal(t, offsets[5], state.LastOffset)
+	assert.Empty(t, state.Errors)
+	assert.EqualValues(t, 0.1, state.Config["lr"])
+	assert.EqualValues(t, 3, state.Config["epochs"])
+	assert.Equal(t, []string{"config.yaml", "model.pt"}, state.Files)
+	assert.EqualValues(t, 0.125, state.Summary["loss"])
+	assert.EqualValues(t, 30, state.Summary["_runtime"])
+	assert.Equal(t, []float32{0.5, 0.25, 0.125}, state.SampledHistory["loss"])
+}
+
+func TestReplay_IsDeterministic(t *testing.T) {
+	path, _ := writeReplayLog(t)
+
+	first, err := server.Replay(path, -1, true)
+	require.NoError(t, err)
+	second, err := server.Replay(path, -1, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestReplay_UntilOffset(t *testing.T) {
+	path, offsets := writeReplayLog(t)
+
+	state, err := server.Replay(path, offsets[3], true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, state.Records)
+	assert.Equal(t, offsets[3], state.LastOffset)
+	assert.EqualValues(t, 0.5, state.Summary["loss"])
+	assert.EqualValues(t, 10, state.Summary["_runtime"])
+}
+
+func TestReplay_NotALog(t *testing.T) {
+	_, err := server.Replay(filepath.Join(t.TempDir(), "missing.wandb"), -1, true)
+
+	assert.Error(t, err)
+}
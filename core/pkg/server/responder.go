@@ -1,64 +1,332 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
+	"github.com/wandb/wandb/core/internal/faults"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runurl"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
 )
 
+// responderQueueSize is the number of responses that may be waiting to be
+// delivered to a single responder before new ones are dropped.
+const responderQueueSize = 1024
+
 type Responder interface {
 	Respond(response *service.ServerResponse)
 }
 
+// userMessageReceiver is a responder that can stop displaying messages
+// for the user while it's added, like a connection whose client exited.
+type userMessageReceiver interface {
+	ReceivesUserMessages() bool
+}
+
 type ResponderEntry struct {
 	Responder Responder
 	ID        string
 }
 
+// Dispatcher delivers results to the responders they are addressed to.
+//
+// Each responder has its own queue, drained by its own goroutine, so that
+// a responder receives its responses in the order they were dispatched
+// and a slow responder doesn't hold up the others.
+//
+// Results for a slot reserved in the mailbox go to the slot instead.
+//
+// A result marked as multi-part is sent in parts if it's large, from a
+// goroutine of its own, so other results may be delivered between them.
 type Dispatcher struct {
+	mu sync.Mutex
+
+	// queues are the pending responses of each responder, by ID
+	queues map[string]chan *service.ServerResponse
+
+	// responders are the responders, by ID
 	responders map[string]Responder
-	logger     *observability.CoreLogger
+
+	// queueSize is the capacity of each responder's queue
+	queueSize int
+
+	// wg counts the goroutines draining the queues
+	wg sync.WaitGroup
+
+	// partBytes is about the largest a part of a multi-part result can be
+	partBytes int
+
+	// partsWG counts the goroutines sending multi-part results
+	partsWG sync.WaitGroup
+
+	logger *observability.CoreLogger
+
+	// mailbox holds the slots awaiting results, if any
+	mailbox *mailbox.Mailbox
+
+	// faultInjector may drop responses, for testing
+	faultInjector *faults.Injector
 }
 
 // AddResponders adds the given responders to the stream's dispatcher.
 func (d *Dispatcher) AddResponders(entries ...ResponderEntry) {
-	if d.responders == nil {
-		d.responders = make(map[string]Responder)
-	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	for _, entry := range entries {
 		responderId := entry.ID
-		if _, ok := d.responders[responderId]; !ok {
-			d.responders[responderId] = entry.Responder
-		} else {
+		if _, ok := d.queues[responderId]; ok {
 			d.logger.CaptureWarn("Responder already exists", "responder", responderId)
+			continue
 		}
+
+		queue := make(chan *service.ServerResponse, d.queueSize)
+		d.queues[responderId] = queue
+		d.responders[responderId] = entry.Responder
+
+		d.wg.Add(1)
+		go func(responder Responder) {
+			defer d.wg.Done()
+			for response := range queue {
+				responder.Respond(response)
+			}
+		}(entry.Responder)
 	}
 }
 
 func (d *Dispatcher) handleRespond(result *service.Result) {
+	multipart := result.GetChunk() != nil
+	result.Chunk = nil
+
 	responderId := result.GetControl().GetConnectionId()
 	d.logger.Debug("dispatch: got result", "result", result)
+	if d.mailbox != nil && d.mailbox.Deliver(result) {
+		return
+	}
 	if responderId == "" {
 		d.logger.Debug("dispatch: got result with no connection id", "result", result)
 		return
 	}
+	if d.faultInjector.DropDelivery() {
+		d.logger.Warn("dispatch: dropping result (injected fault)", "responder", responderId)
+		return
+	}
 	response := &service.ServerResponse{
 		ServerResponseType: &service.ServerResponse_ResultCommunicate{
 			ResultCommunicate: result,
 		},
 	}
-	if responder, ok := d.responders[responderId]; ok {
-		responder.Respond(response)
-	} else {
+
+	// the lock is held while queueing so that responses are queued in the
+	// order in which they were dispatched
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	queue, ok := d.queues[responderId]
+	if !ok {
 		err := fmt.Errorf("dispatch: no responder found: %s", responderId)
 		d.logger.CaptureFatalAndPanic("dispatch: no responder found", err)
 	}
+
+	if multipart {
+		d.sendInParts(responderId, queue, result)
+		return
+	}
+
+	select {
+	case queue <- response:
+	default:
+		d.logger.CaptureWarn(
+			"dispatch: responder queue is full, dropping response",
+			"responder", responderId,
+			"result", result,
+		)
+	}
+}
+
+// sendInParts starts sending the result to the responder in parts.
+//
+// Parts wait for room in the responder's queue instead of being dropped,
+// since a response with a missing part is useless. Canceling the request
+// through the mailbox stops making and sending the parts.
+func (d *Dispatcher) sendInParts(
+	responderId string,
+	queue chan<- *service.ServerResponse,
+	result *service.Result,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	slot := result.GetControl().GetMailboxSlot()
+	if d.mailbox != nil && slot != "" {
+		d.mailbox.Add(ctx, cancel, slot)
+	}
+
+	d.partsWG.Add(1)
+	go func() {
+		defer d.partsWG.Done()
+		defer cancel()
+		if d.mailbox != nil && slot != "" {
+			defer d.mailbox.Remove(slot)
+		}
+
+		sent := splitResult(result, d.partBytes, func(part *service.Result) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			response := &service.ServerResponse{
+				ServerResponseType: &service.ServerResponse_ResultCommunicate{
+					ResultCommunicate: part,
+				},
+			}
+			select {
+			case queue <- response:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if !sent {
+			d.logger.Info(
+				"dispatch: stopped sending a result in parts",
+				"responder", responderId,
+				"slot", slot,
+			)
+		}
+	}()
+}
+
+// forwardUserMessage sends a message for the user to every responder that
+// can display it, returning false if there were none.
+//
+// This is how the stream's [observability.Printer] reaches the user.
+func (d *Dispatcher) forwardUserMessage(message observability.UserMessage) bool {
+	response := &service.ServerResponse{
+		ServerResponseType: &service.ServerResponse_ResultCommunicate{
+			ResultCommunicate: &service.Result{
+				ResultType: &service.Result_Response{
+					Response: &service.Response{
+						ResponseType: &service.Response_UserMessageResponse{
+							UserMessageResponse: &service.UserMessageResponse{
+								Level:   userMessageLevel(message.Level),
+								Message: message.Text,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return d.broadcast(response, "user message", func(responder Responder) bool {
+		receiver, ok := responder.(userMessageReceiver)
+		return !ok || receiver.ReceivesUserMessages()
+	})
+}
+
+// userMessageLevel is the level of a message for the user in the protocol.
+func userMessageLevel(level observability.MessageLevel) service.UserMessageResponse_Level {
+	switch level {
+	case observability.MessageInfo:
+		return service.UserMessageResponse_INFO
+	case observability.MessageError:
+		return service.UserMessageResponse_ERROR
+	default:
+		return service.UserMessageResponse_WARNING
+	}
+}
+
+// forwardRunURL sends where the run can be viewed to every responder.
+func (d *Dispatcher) forwardRunURL(info runurl.Info) {
+	response := &service.ServerResponse{
+		ServerResponseType: &service.ServerResponse_ResultCommunicate{
+			ResultCommunicate: &service.Result{
+				ResultType: &service.Result_Response{
+					Response: &service.Response{
+						ResponseType: &service.Response_RunUrlResponse{
+							RunUrlResponse: &service.RunUrlResponse{
+								Version:   int32(info.Version),
+								Entity:    info.Entity,
+								Project:   info.Project,
+								RunId:     info.RunID,
+								Url:       utils.ZeroIfNil(info.URL),
+								LocalPath: info.LocalPath,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	d.broadcast(response, "run URL", func(Responder) bool { return true })
+}
+
+// broadcast queues the response for every responder it's for, returning
+// false if there were none.
+//
+// It's never blocked by a responder: if one's queue is full, the response
+// is dropped for it.
+func (d *Dispatcher) broadcast(
+	response *service.ServerResponse,
+	description string,
+	isFor func(Responder) bool,
+) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	forwarded := false
+	for responderId, responder := range d.responders {
+		if !isFor(responder) {
+			continue
+		}
+
+		select {
+		case d.queues[responderId] <- response:
+			forwarded = true
+		default:
+			d.logger.CaptureWarn(
+				"dispatch: responder queue is full, dropping "+description,
+				"responder", responderId,
+			)
+		}
+	}
+	return forwarded
+}
+
+// Close delivers the queued responses and the rest of the results being
+// sent in parts, and stops the dispatcher.
+//
+// No results may be dispatched after this.
+func (d *Dispatcher) Close() {
+	d.partsWG.Wait()
+
+	d.mu.Lock()
+	for responderId, queue := range d.queues {
+		close(queue)
+		delete(d.queues, responderId)
+		delete(d.responders, responderId)
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
 }
 
-func NewDispatcher(logger *observability.CoreLogger) *Dispatcher {
+func NewDispatcher(
+	logger *observability.CoreLogger,
+	mailbox *mailbox.Mailbox,
+	faultInjector *faults.Injector,
+) *Dispatcher {
 	return &Dispatcher{
-		logger:     logger,
-		responders: make(map[string]Responder),
+		logger:        logger,
+		mailbox:       mailbox,
+		faultInjector: faultInjector,
+		queues:        make(map[string]chan *service.ServerResponse),
+		responders:    make(map[string]Responder),
+		queueSize:     responderQueueSize,
+		partBytes:     maxPartBytes,
 	}
 }
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/faults"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// fakeResponder records the UUIDs of the results it receives.
+type fakeResponder struct {
+	mu    sync.Mutex
+	uuids []string
+
+	// gate, if not nil, blocks Respond until it is closed
+	gate chan struct{}
+}
+
+func (r *fakeResponder) Respond(response *service.ServerResponse) {
+	if r.gate != nil {
+		<-r.gate
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.uuids = append(r.uuids, response.GetResultCommunicate().GetUuid())
+}
+
+func (r *fakeResponder) UUIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.uuids...)
+}
+
+func makeResult(responderID string, uuid string) *service.Result {
+	return &service.Result{
+		Control: &service.Control{ConnectionId: responderID},
+		Uuid:    uuid,
+	}
+}
+
+// burst dispatches n results to each responder, alternating between them.
+func burst(d *Dispatcher, source string, n int, responderIDs ...string) {
+	for i := 0; i < n; i++ {
+		for _, id := range responderIDs {
+			d.handleRespond(makeResult(id, fmt.Sprintf("%s-%d", source, i)))
+		}
+	}
+}
+
+// assertOrderedPerSource checks that the UUIDs from each source are in
+// increasing order.
+func assertOrderedPerSource(t *testing.T, uuids []string, n int, sources ...string) {
+	for _, source := range sources {
+		var fromSource []string
+		for _, uuid := range uuids {
+			var s string
+			var i int
+			if _, err := fmt.Sscanf(uuid, "%1s-%d", &s, &i); err == nil && s == source {
+				fromSource = append(fromSource, uuid)
+			}
+		}
+
+		expected := make([]string, n)
+		for i := range expected {
+			expected[i] = fmt.Sprintf("%s-%d", source, i)
+		}
+		assert.Equal(t, expected, fromSource)
+	}
+}
+
+func TestDispatcher_OrderedPerResponder(t *testing.T) {
+	const n = 200
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	r1, r2 := &fakeResponder{}, &fakeResponder{}
+	d.AddResponders(ResponderEntry{r1, "r1"}, ResponderEntry{r2, "r2"})
+
+	// the handler and the sender dispatch concurrently
+	var wg sync.WaitGroup
+	for _, source := range []string{"h", "s"} {
+		wg.Add(1)
+		go func(source string) {
+			defer wg.Done()
+			burst(d, source, n, "r1", "r2")
+		}(source)
+	}
+	wg.Wait()
+	d.Close()
+
+	for _, r := range []*fakeResponder{r1, r2} {
+		assert.Len(t, r.UUIDs(), 2*n)
+		assertOrderedPerSource(t, r.UUIDs(), n, "h", "s")
+	}
+}
+
+func TestDispatcher_SingleSourceFIFO(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	r := &fakeResponder{}
+	d.AddResponders(ResponderEntry{r, "r"})
+
+	burst(d, "h", 3, "r")
+	d.handleRespond(makeResult("r", "s-0"))
+	burst(d, "x", 2, "r")
+	d.Close()
+
+	assert.Equal(t, []string{"h-0", "h-1", "h-2", "s-0", "x-0", "x-1"}, r.UUIDs())
+}
+
+func TestDispatcher_SlowResponderDoesNotBlockOthers(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	slow := &fakeResponder{gate: make(chan struct{})}
+	fast := &fakeResponder{}
+	d.AddResponders(ResponderEntry{slow, "slow"}, ResponderEntry{fast, "fast"})
+
+	burst(d, "h", 10, "slow", "fast")
+
+	assert.Eventually(t,
+		func() bool { return len(fast.UUIDs()) == 10 },
+		time.Second, time.Millisecond,
+	)
+	assert.Empty(t, slow.UUIDs())
+
+	close(slow.gate)
+	d.Close()
+	assertOrderedPerSource(t, slow.UUIDs(), 10, "h")
+}
+
+func TestDispatcher_DropsWhenQueueFull(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	d.queueSize = 2
+	stuck := &fakeResponder{gate: make(chan struct{})}
+	d.AddResponders(ResponderEntry{stuck, "stuck"})
+
+	// one response is being delivered, two are queued and the rest are
+	// dropped; none of this blocks
+	d.handleRespond(makeResult("stuck", "h-0"))
+	assert.Eventually(t,
+		func() bool { return len(d.queues["stuck"]) == 0 },
+		time.Second, time.Millisecond,
+	)
+	for i := 1; i < 10; i++ {
+		d.handleRespond(makeResult("stuck", fmt.Sprintf("h-%d", i)))
+	}
+
+	close(stuck.gate)
+	d.Close()
+	assert.Equal(t, []string{"h-0", "h-1", "h-2"}, stuck.UUIDs())
+}
+
+func TestDispatcher_InjectedDrop(t *testing.T) {
+	injector, err := faults.Parse("dispatcher-drop=2")
+	require.NoError(t, err)
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, injector)
+	r := &fakeResponder{}
+	d.AddResponders(ResponderEntry{r, "r"})
+
+	burst(d, "h", 3, "r")
+	d.Close()
+
+	assert.Equal(t, []string{"h-0", "h-2"}, r.UUIDs())
+}
+
+func TestDispatcher_DeliversToMailboxSlot(t *testing.T) {
+	mb := mailbox.NewMailbox()
+	d := NewDispatcher(observability.NewNoOpLogger(), mb, nil)
+	r := &fakeResponder{}
+	d.AddResponders(ResponderEntry{r, "r"})
+	slot := mb.Reserve()
+
+	forSlot := makeResult("r", "slot-0")
+	forSlot.Control.MailboxSlot = slot.ID()
+	d.handleRespond(forSlot)
+	forClient := makeResult("r", "client-0")
+	forClient.Control.MailboxSlot = "client-slot"
+	d.handleRespond(forClient)
+	d.Close()
+
+	result, err := slot.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "slot-0", result.GetUuid())
+	assert.Equal(t, []string{"client-0"}, r.UUIDs())
+}
+
+// messageResponder records the user messages it receives.
+type messageResponder struct {
+	mu       sync.Mutex
+	messages []string
+
+	// detached is whether the responder no longer receives user messages
+	detached bool
+}
+
+func (r *messageResponder) Respond(response *service.ServerResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	message := response.GetResultCommunicate().GetResponse().GetUserMessageResponse()
+	r.messages = append(r.messages,
+		fmt.Sprintf("%v: %s", message.GetLevel(), message.GetMessage()))
+}
+
+func (r *messageResponder) ReceivesUserMessages() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.detached
+}
+
+func (r *messageResponder) Messages() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.messages...)
+}
+
+func TestDispatcher_UserMessagesQueuedUntilAttach(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	printer := observability.NewPrinter()
+	printer.Forward(d.forwardUserMessage)
+	detached := &messageResponder{detached: true}
+	d.AddResponders(ResponderEntry{detached, "detached"})
+
+	printer.Emit(observability.UserMessage{
+		Level: observability.MessageWarning,
+		Key:   "offline",
+		Text:  "Network unavailable.",
+	})
+	printer.Emit(observability.UserMessage{
+		Level: observability.MessageWarning,
+		Key:   "offline",
+		Text:  "Network unavailable.",
+	})
+	r := &messageResponder{}
+	d.AddResponders(ResponderEntry{r, "r"})
+	printer.Flush()
+	printer.Emit(observability.UserMessage{
+		Level: observability.MessageError,
+		Text:  "Upload failed.",
+	})
+	d.Close()
+
+	assert.Equal(t,
+		[]string{"WARNING: Network unavailable.", "ERROR: Upload failed."},
+		r.Messages())
+	assert.Empty(t, detached.Messages())
+	assert.Empty(t, printer.Read())
+}
+
+func TestDispatcher_UserMessagesKeptForFooterWithoutResponders(t *testing.T) {
+	d := NewDispatcher(observability.NewNoOpLogger(), nil, nil)
+	printer := observability.NewPrinter()
+	printer.Forward(d.forwardUserMessage)
+
+	printer.Write("Retried too many requests.")
+
+	assert.Equal(t, []string{"Retried too many requests."}, printer.Read())
+}
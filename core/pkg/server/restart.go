@@ -0,0 +1,429 @@
+package server
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/runresume"
+	"github.com/wandb/wandb/core/internal/settings"
+	fs "github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+// restartedLogSuffix is appended to the path of a transaction log to name
+// the log of the wandb-core process that ran the run before a restart,
+// while the new process copies its records.
+const restartedLogSuffix = ".restarted"
+
+// restartedRun is a run that a wandb-core process was running when it was
+// restarted, which a new process continues where it left off.
+//
+// When a client reconnects to a new wandb-core with the settings of its
+// run, the new process finds the state the old one saved next to the
+// run's transaction log. The handler's state is replayed from the log, the
+// writer appends to it, and the sender resends only the records whose data
+// the server didn't acknowledge.
+type restartedRun struct {
+	// log is the old process's transaction log
+	log string
+
+	// lastNum is the number of the last record in the log
+	lastNum int64
+
+	// checkpoint is the handler's state after the last record in the log
+	checkpoint *service.CheckpointRecord
+
+	// state is how much of the log reached the server
+	state *transactionlog.SyncState
+}
+
+// findRestartedRun returns the run that the stream continues after
+// wandb-core was restarted, or nil if it starts the run afresh.
+//
+// Only a run that was sending its data, that hadn't finished and whose
+// sync state was saved is continued.
+func findRestartedRun(
+	settings *settings.Settings,
+	logger *observability.CoreLogger,
+) *restartedRun {
+	path := settings.Proto.GetSyncFile().GetValue()
+	if path == "" || settings.IsSync() || settings.IsOffline() {
+		return nil
+	}
+	state, err := transactionlog.ReadSyncState(path)
+	if err != nil || state == nil || state.Complete {
+		return nil
+	}
+
+	// a restart interrupted while copying the log leaves the old log,
+	// which has all the records
+	log := path + restartedLogSuffix
+	if _, err := os.Stat(log); err != nil {
+		log = path
+	}
+
+	run, err := replayRestartedRun(log)
+	if err != nil {
+		logger.Warn(
+			"stream: can't continue the run after a restart, starting afresh",
+			"error", err,
+			"path", log,
+		)
+		return nil
+	}
+	if run == nil {
+		return nil
+	}
+
+	if log == path {
+		run.log = path + restartedLogSuffix
+		if err := os.Rename(path, run.log); err != nil {
+			logger.Warn(
+				"stream: can't continue the run after a restart, starting afresh",
+				"error", err,
+				"path", path,
+			)
+			return nil
+		}
+	} else {
+		run.log = log
+	}
+	run.state = state
+
+	logger.Info(
+		"stream: continuing the run after a restart",
+		"lastRecord", run.lastNum,
+		"lastAcked", state.LastAcked,
+		"savedAt", state.UpdatedAt,
+	)
+	return run
+}
+
+// logPath returns the old process's transaction log, or "" if there's
+// no run to continue.
+func (r *restartedRun) logPath() string {
+	if r == nil {
+		return ""
+	}
+	return r.log
+}
+
+// uploadedFiles returns the run's files that the old process uploaded, or
+// nil if there's no run to continue.
+func (r *restartedRun) uploadedFiles() map[string]transactionlog.UploadedFile {
+	if r == nil {
+		return nil
+	}
+	return r.state.UploadedFiles
+}
+
+// replayRestartedRun replays the transaction log at the path, and returns
+// the run to continue from the handler's state after its last record.
+//
+// It returns nil if the run never started or already exited.
+func replayRestartedRun(path string) (*restartedRun, error) {
+	reader, err := transactionlog.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	checkpoints := NewCheckpoints(0, 0)
+	r := newReplay(checkpoints)
+	run := &restartedRun{}
+	exited := false
+	for {
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			r.finish(&ReplayState{})
+			return nil, err
+		}
+
+		run.lastNum = max(run.lastNum, record.Num)
+		exited = exited || record.GetExit() != nil
+		r.replayRecord(record)
+	}
+
+	// the handler checkpoints its state after the next record it handles,
+	// so the checkpoint is only asked for once it handled all of the log
+	r.catchUp()
+	checkpoints.due.Store(true)
+	r.catchUp()
+	r.finish(&ReplayState{})
+
+	checkpoint := r.sender.checkpoint
+	if exited || checkpoint.GetRun() == nil {
+		return nil, nil
+	}
+	checkpoint.LastRecordNum = run.lastNum
+	sealCheckpoint(checkpoint)
+	run.checkpoint = checkpoint
+	return run, nil
+}
+
+// restartRecords returns the records that restore the run in the handler,
+// ahead of the client's records.
+//
+// The run is upserted again with the run record, whose result goes to the
+// slot, but isn't stored in the log a second time.
+func (r *restartedRun) restartRecords(slot string) []*service.Record {
+	run := proto.Clone(r.checkpoint.GetRun()).(*service.RunRecord)
+	run.Config = &service.ConfigRecord{Update: r.checkpoint.GetConfig()}
+
+	return []*service.Record{
+		{RecordType: &service.Record_Checkpoint{Checkpoint: r.checkpoint}},
+		{
+			RecordType: &service.Record_Run{Run: run},
+			Control:    &service.Control{Local: true, MailboxSlot: slot},
+		},
+		{
+			RecordType: &service.Record_Request{
+				Request: &service.Request{
+					RequestType: &service.Request_RunStart{
+						RunStart: &service.RunStartRequest{
+							Run: proto.Clone(run).(*service.RunRecord),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// restartRun restores the run in the handler, ahead of any records from
+// the client.
+//
+// The client isn't waiting for the run to be upserted again, so a failure
+// is only logged.
+func (s *Stream) restartRun(fwdChan chan<- *service.Record) {
+	slot := s.mailbox.Reserve()
+	for _, record := range s.restart.restartRecords(slot.ID()) {
+		fwdChan <- record
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		result, err := slot.Wait(s.ctx)
+		if err == nil && result.GetRunResult().GetError() != nil {
+			err = errors.New(result.GetRunResult().GetError().GetMessage())
+		}
+		if err != nil {
+			s.logger.Error("stream: failed to continue the run after a restart", "error", err)
+		}
+	}()
+}
+
+// continueLog copies the records of the transaction log that the previous
+// wandb-core process wrote into the new log, then deletes it.
+//
+// The records are written as they were, so they're at the same offsets,
+// and the new records are numbered after them.
+func (w *Writer) continueLog(path string) error {
+	reader, err := transactionlog.Open(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := w.store.Write(record); err != nil {
+			return err
+		}
+		w.checkpoints.stored(record, w.store.LastOffset())
+		w.recordNum = max(w.recordNum, record.Num)
+	}
+	if err := w.store.Flush(); err != nil {
+		return err
+	}
+	w.lastFlushedNum.Store(w.recordNum)
+
+	if err := os.Remove(path); err != nil {
+		w.logger.Warn("writer: can't delete the restarted run's log", "error", err, "path", path)
+	}
+	w.logger.Info("writer: continued the transaction log", "records", w.recordNum)
+	return nil
+}
+
+// restoreAfterRestart restores what the sender knew about the run before
+// wandb-core was restarted, as the run is upserted again.
+//
+// The filestream continues from the lines the server acknowledged. If the
+// server reports fewer lines, it continues from there instead, so that no
+// lines are missing.
+func (s *Sender) restoreAfterRestart() {
+	restart := s.restart
+	s.lastSentNum = restart.state.LastAcked
+	s.runSummary.ApplyChangeRecord(
+		&service.SummaryRecord{Update: restart.checkpoint.GetSummary()},
+		func(err error) {
+			s.logger.CaptureError("sender: error restoring the summary", err)
+		},
+	)
+
+	s.resumeState = runresume.NewResumeState(s.logger, runresume.None)
+	offsets := fs.OffsetsByFileName(restart.state.FileStreamOffsets)
+	for chunkType, offset := range s.serverLineCounts() {
+		if acked, ok := offsets[chunkType]; ok && offset < acked {
+			s.logger.Warn(
+				"sender: the server has fewer lines than were acknowledged",
+				"chunk", chunkType,
+				"acknowledged", acked,
+				"server", offset,
+			)
+			offsets[chunkType] = offset
+		}
+	}
+	for chunkType, offset := range offsets {
+		s.resumeState.AddOffset(chunkType, offset)
+	}
+}
+
+// serverLineCounts returns the number of lines of the run's files that
+// the server has, or nil if it can't be asked.
+func (s *Sender) serverLineCounts() fs.FileStreamOffsetMap {
+	if s.graphqlClient == nil {
+		return nil
+	}
+
+	run := s.RunRecord
+	data, err := gql.RunResumeStatus(
+		s.ctx,
+		s.graphqlClient,
+		&run.Project,
+		utils.NilIfZero(run.Entity),
+		run.RunId,
+	)
+	if err != nil {
+		s.logger.Warn("sender: can't verify the restarted run with the server", "error", err)
+		return nil
+	}
+
+	bucket := data.GetModel().GetBucket()
+	if bucket == nil {
+		return nil
+	}
+	counts := make(fs.FileStreamOffsetMap)
+	if n := bucket.GetHistoryLineCount(); n != nil {
+		counts[fs.HistoryChunk] = *n
+	}
+	if n := bucket.GetEventsLineCount(); n != nil {
+		counts[fs.EventsChunk] = *n
+	}
+	if n := bucket.GetLogLineCount(); n != nil {
+		counts[fs.OutputChunk] = *n
+	}
+	return counts
+}
+
+// resendAfterRestart sends what didn't reach the server before wandb-core
+// was restarted, once the run's filestream started.
+//
+// The records after the last one acknowledged are sent again, and so are
+// all files records, so that the uploader knows the run's files; those
+// uploaded unchanged aren't uploaded again. Artifacts whose saving was
+// interrupted are saved again, continuing their drafts.
+func (s *Sender) resendAfterRestart() {
+	restart := s.restart
+	s.restart = nil
+
+	drafts := make(map[int64]struct{})
+	for _, draft := range restart.state.ArtifactDrafts {
+		if draft.RecordNum == 0 {
+			s.logger.Warn(
+				"sender: artifact left as a draft by the restart",
+				"artifactID", draft.ID,
+			)
+			continue
+		}
+		drafts[draft.RecordNum] = struct{}{}
+	}
+
+	acked := restart.state.LastAcked
+	resent := 0
+	err := forEachHeldRecord(
+		s.ctx,
+		s.settings.GetSyncFile().GetValue(),
+		&offlineHold{lastHeld: restart.lastNum},
+		func(record *service.Record) bool {
+			_, isDraft := drafts[record.Num]
+			switch {
+			case record.Num > acked:
+				s.processRecord(record)
+				resent++
+			case record.GetFiles() != nil || isDraft:
+				s.sendRecord(record)
+			}
+			return true
+		},
+	)
+	if err != nil {
+		s.logger.CaptureError("sender: failed to resend records after a restart", err)
+		return
+	}
+	s.logger.Info("sender: resent records after a restart", "records", resent)
+}
+
+// artifactDrafts are the artifacts being saved whose drafts were created
+// on the server.
+type artifactDrafts struct {
+	mu sync.Mutex
+
+	// recordNums are the numbers of the records logging the artifacts, by
+	// the drafts' IDs
+	recordNums map[string]int64
+}
+
+func newArtifactDrafts() *artifactDrafts {
+	return &artifactDrafts{recordNums: make(map[string]int64)}
+}
+
+// add notes the draft of the artifact logged by the record with the number.
+func (d *artifactDrafts) add(artifactID string, recordNum int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.recordNums[artifactID] = recordNum
+}
+
+// remove forgets the draft, once the artifact is saved or fails to be.
+func (d *artifactDrafts) remove(artifactID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.recordNums, artifactID)
+}
+
+// list returns the drafts of the artifacts being saved.
+func (d *artifactDrafts) list() []transactionlog.ArtifactDraft {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var drafts []transactionlog.ArtifactDraft
+	for id, num := range d.recordNums {
+		drafts = append(drafts, transactionlog.ArtifactDraft{ID: id, RecordNum: num})
+	}
+	slices.SortFunc(drafts, func(a, b transactionlog.ArtifactDraft) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return drafts
+}
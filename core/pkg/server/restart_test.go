@@ -0,0 +1,206 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+func newRestartBackend() *servertest.FakeBackend {
+	backend := servertest.NewFakeBackend()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	return backend
+}
+
+func restartSettings(t *testing.T, dir, baseURL string, interval float64) *settings.Settings {
+	t.Helper()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	return settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "restart"},
+		BaseUrl:       &wrapperspb.StringValue{Value: baseURL},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-restart.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XStreamStateIntervalSeconds: &wrapperspb.DoubleValue{Value: interval},
+	})
+}
+
+// startRestartedRun starts a run and logs its first steps, then kills it
+// once its sync state says it's ready.
+func startRestartedRun(
+	t *testing.T,
+	settings *settings.Settings,
+	steps int,
+	ready func(*transactionlog.SyncState) bool,
+) {
+	t.Helper()
+	syncFile := settings.Proto.GetSyncFile().GetValue()
+
+	stream, err := server.NewStream(settings, "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "restart", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	require.Eventually(t, func() bool {
+		state, err := transactionlog.ReadSyncState(syncFile)
+		return err == nil && state != nil
+	}, 10*time.Second, 10*time.Millisecond)
+	for i := range steps {
+		stream.HandleRecord(makePartialHistoryRecord(data{
+			items: map[string]string{"loss": fmt.Sprint(i)},
+			step:  int64(i),
+			flush: true,
+		}))
+	}
+	require.Eventually(t, func() bool {
+		state, err := transactionlog.ReadSyncState(syncFile)
+		return err == nil && ready(state)
+	}, 10*time.Second, 10*time.Millisecond)
+	stream.Abort()
+}
+
+// continueRestartedRun continues a killed run in a new stream against the
+// backend, logs the steps after the first ones, and finishes it.
+func continueRestartedRun(
+	t *testing.T,
+	settings *settings.Settings,
+	baseURL string,
+	first, steps int,
+) {
+	t.Helper()
+	settings.Proto.BaseUrl = &wrapperspb.StringValue{Value: baseURL}
+	stream, err := server.NewStream(settings, "")
+	require.NoError(t, err)
+	stream.Start()
+	for i := first; i < steps; i++ {
+		stream.HandleRecord(makePartialHistoryRecord(data{
+			items: map[string]string{"loss": fmt.Sprint(i)},
+			step:  int64(i),
+			flush: true,
+		}))
+	}
+	stream.FinishAndClose(0)
+}
+
+// historyByOffset returns the history lines the backend received, by
+// their offsets, and how many lines were sent in all.
+func historyByOffset(t *testing.T, backend *servertest.FakeBackend) (map[int]string, int) {
+	t.Helper()
+	lines := make(map[int]string)
+	sent := 0
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Offset  int      `json:"offset"`
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		history := body.Files["wandb-history.jsonl"]
+		for i, line := range history.Content {
+			lines[history.Offset+i] = line
+			sent++
+		}
+	}
+	return lines, sent
+}
+
+// requireContinuedLog checks that the run's transaction log is numbered in
+// order across the restart, and that the run is marked as synced.
+func requireContinuedLog(t *testing.T, syncFile string) {
+	t.Helper()
+	reader, err := transactionlog.Open(syncFile)
+	require.NoError(t, err)
+	var nums []int64
+	for {
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		nums = append(nums, record.Num)
+	}
+	require.NoError(t, reader.Close())
+	for i := 1; i < len(nums); i++ {
+		assert.Greater(t, nums[i], nums[i-1])
+	}
+	assert.NoFileExists(t, syncFile+".restarted")
+
+	state, err := transactionlog.ReadSyncState(syncFile)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.Complete)
+}
+
+// A run whose wandb-core process was killed continues in a new process
+// from what reached the server, without sending it again.
+func TestRestart_ContinuesFromSyncState(t *testing.T) {
+	killed := newRestartBackend()
+	defer killed.Close()
+	restarted := newRestartBackend()
+	defer restarted.Close()
+	settings := restartSettings(t, t.TempDir(), killed.URL(), 0.05)
+
+	startRestartedRun(t, settings, 3, func(state *transactionlog.SyncState) bool {
+		return state.FileStreamOffsets["wandb-history.jsonl"] == 3
+	})
+	continueRestartedRun(t, settings, restarted.URL(), 3, 5)
+
+	lines, sent := historyByOffset(t, restarted)
+	assert.Equal(t, 2, sent)
+	require.Len(t, lines, 2)
+	for i := 3; i < 5; i++ {
+		assert.Contains(t, lines[i], fmt.Sprintf(`"_step":%d`, i))
+	}
+	requireContinuedLog(t, settings.Proto.GetSyncFile().GetValue())
+}
+
+// The records after the last one whose data the server acknowledged are
+// sent again by the new process, at the same offsets.
+func TestRestart_ResendsUnacknowledgedRecords(t *testing.T) {
+	killed := newRestartBackend()
+	defer killed.Close()
+	restarted := newRestartBackend()
+	defer restarted.Close()
+	settings := restartSettings(t, t.TempDir(), killed.URL(), 3600)
+
+	// only the state saved as the run started is recorded
+	startRestartedRun(t, settings, 3, func(state *transactionlog.SyncState) bool {
+		return state.FileStreamOffsets["wandb-history.jsonl"] == 0
+	})
+	continueRestartedRun(t, settings, restarted.URL(), 3, 5)
+
+	lines, sent := historyByOffset(t, restarted)
+	assert.Equal(t, 5, sent)
+	require.Len(t, lines, 5)
+	for i := range 5 {
+		assert.Contains(t, lines[i], fmt.Sprintf(`"_step":%d`, i))
+	}
+	requireContinuedLog(t, settings.Proto.GetSyncFile().GetValue())
+}
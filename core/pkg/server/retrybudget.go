@@ -0,0 +1,199 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// The classes of endpoints whose retries are counted separately.
+const (
+	retryEndpointGraphQL      = "graphql"
+	retryEndpointFileStream   = "filestream"
+	retryEndpointFileTransfer = "file transfer"
+)
+
+// retryBudgetWindow is the rolling window over which retries are budgeted.
+const retryBudgetWindow = time.Minute
+
+// RetryBudget counts the retries of a stream's requests, so that degraded
+// infrastructure doesn't go unnoticed.
+//
+// When an endpoint class is retried more than its budget allows in a
+// minute, the user is warned once. The budget never affects whether or
+// when requests are retried.
+type RetryBudget struct {
+	// perMinute is how many retries to an endpoint class in a minute
+	// trigger the warning.
+	perMinute int
+
+	// clock tells the time of each retry.
+	clock waiting.Clock
+
+	// onExceeded is called with the warning when the budget is first
+	// exceeded.
+	onExceeded func(warning string)
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// endpoints are the retries of each endpoint class.
+	endpoints map[string]*endpointRetries
+
+	// warned is whether onExceeded was called.
+	warned bool
+}
+
+// endpointRetries are the retries of requests to one class of endpoint.
+type endpointRetries struct {
+	// recent are the times of the retries in the rolling window, oldest
+	// first.
+	recent []time.Time
+
+	// total is the number of retries since the stream started.
+	total int32
+
+	// byCategory is the number of retries by failure category.
+	byCategory map[string]int32
+}
+
+func NewRetryBudget(
+	perMinute int,
+	clock waiting.Clock,
+	onExceeded func(warning string),
+) *RetryBudget {
+	return &RetryBudget{
+		perMinute:  perMinute,
+		clock:      clock,
+		onExceeded: onExceeded,
+		endpoints:  make(map[string]*endpointRetries),
+	}
+}
+
+// Observer returns a callback to count the retries of requests to the
+// endpoint class, or nil if the budget is nil.
+func (b *RetryBudget) Observer(endpoint string) func(*http.Response, error) {
+	if b == nil {
+		return nil
+	}
+
+	return func(resp *http.Response, err error) {
+		b.Record(endpoint, retryCategory(resp, err))
+	}
+}
+
+// Record counts a retry of a request to the endpoint class.
+func (b *RetryBudget) Record(endpoint string, category string) {
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	retries, ok := b.endpoints[endpoint]
+	if !ok {
+		retries = &endpointRetries{byCategory: make(map[string]int32)}
+		b.endpoints[endpoint] = retries
+	}
+	retries.total++
+	retries.byCategory[category]++
+	retries.recent = append(retries.recentAt(now), now)
+
+	exceeded := !b.warned && len(retries.recent) > b.perMinute
+	var warning string
+	if exceeded {
+		b.warned = true
+		warning = fmt.Sprintf(
+			"Requests to W&B are being retried often (%s: %d in the last minute; %s)."+
+				" The W&B server or the network may be degraded.",
+			endpoint,
+			len(retries.recent),
+			formatRetryCategories(retries.byCategory),
+		)
+	}
+	b.mu.Unlock()
+
+	if exceeded && b.onExceeded != nil {
+		b.onExceeded(warning)
+	}
+}
+
+// Stats returns the retries so far by endpoint class, or nil if there
+// were none.
+func (b *RetryBudget) Stats() map[string]*service.RetryStats {
+	if b == nil {
+		return nil
+	}
+
+	now := b.clock.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.endpoints) == 0 {
+		return nil
+	}
+
+	stats := make(map[string]*service.RetryStats, len(b.endpoints))
+	for endpoint, retries := range b.endpoints {
+		retries.recent = retries.recentAt(now)
+		stats[endpoint] = &service.RetryStats{
+			Total:      retries.total,
+			LastMinute: int32(len(retries.recent)),
+			ByCategory: maps.Clone(retries.byCategory),
+		}
+	}
+	return stats
+}
+
+// recentAt returns the retries still within the window at the time.
+func (r *endpointRetries) recentAt(now time.Time) []time.Time {
+	cutoff := now.Add(-retryBudgetWindow)
+	i := 0
+	for i < len(r.recent) && !r.recent[i].After(cutoff) {
+		i++
+	}
+	return r.recent[i:]
+}
+
+// retryCategory describes the failure that caused a retry.
+func retryCategory(resp *http.Response, err error) string {
+	switch {
+	case err != nil || resp == nil:
+		return "network error"
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return "rate limited"
+	case resp.StatusCode == http.StatusRequestTimeout:
+		return "timeout"
+	case resp.StatusCode >= 500:
+		return "server error"
+	default:
+		return fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+}
+
+// formatRetryCategories lists retry counts by category, most frequent
+// first.
+func formatRetryCategories(byCategory map[string]int32) string {
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	slices.SortFunc(categories, func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(byCategory[b], byCategory[a]),
+			cmp.Compare(a, b),
+		)
+	})
+
+	parts := make([]string, len(categories))
+	for i, category := range categories {
+		parts[i] = fmt.Sprintf("%s: %d", category, byCategory[category])
+	}
+	return strings.Join(parts, ", ")
+}
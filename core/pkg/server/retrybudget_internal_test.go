@@ -0,0 +1,95 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testClock is a clock that only moves when told to.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func TestRetryBudget_WarnsOnceWhenExceeded(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	var warnings []string
+	budget := NewRetryBudget(2, clock, func(warning string) {
+		warnings = append(warnings, warning)
+	})
+	onRetry := budget.Observer(retryEndpointFileStream)
+
+	onRetry(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	onRetry(nil, errors.New("connection reset"))
+	assert.Empty(t, warnings)
+
+	onRetry(&http.Response{StatusCode: http.StatusBadGateway}, nil)
+	onRetry(&http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+	budget.Record(retryEndpointGraphQL, "rate limited")
+	budget.Record(retryEndpointGraphQL, "rate limited")
+	budget.Record(retryEndpointGraphQL, "rate limited")
+
+	assert.Equal(t,
+		[]string{
+			"Requests to W&B are being retried often" +
+				" (filestream: 3 in the last minute; server error: 2, network error: 1)." +
+				" The W&B server or the network may be degraded.",
+		},
+		warnings)
+}
+
+func TestRetryBudget_RollingWindow(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	var warnings int
+	budget := NewRetryBudget(2, clock, func(string) { warnings++ })
+
+	for range 6 {
+		budget.Record(retryEndpointFileTransfer, "timeout")
+		clock.now = clock.now.Add(30 * time.Second)
+	}
+
+	assert.Zero(t, warnings)
+	stats := budget.Stats()[retryEndpointFileTransfer]
+	assert.EqualValues(t, 6, stats.GetTotal())
+	assert.EqualValues(t, 1, stats.GetLastMinute())
+	assert.Equal(t, map[string]int32{"timeout": 6}, stats.GetByCategory())
+}
+
+func TestRetryBudget_Nil(t *testing.T) {
+	var budget *RetryBudget
+
+	assert.Nil(t, budget.Observer(retryEndpointGraphQL))
+	assert.Nil(t, budget.Stats())
+}
+
+func TestRetryCategory(t *testing.T) {
+	testCases := []struct {
+		status   int
+		err      error
+		expected string
+	}{
+		{0, errors.New("dial tcp: refused"), "network error"},
+		{http.StatusTooManyRequests, nil, "rate limited"},
+		{http.StatusRequestTimeout, nil, "timeout"},
+		{http.StatusInternalServerError, nil, "server error"},
+		{http.StatusConflict, nil, "HTTP 409"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status}
+			}
+
+			assert.Equal(t, tc.expected, retryCategory(resp, tc.err))
+		})
+	}
+}
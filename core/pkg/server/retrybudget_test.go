@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// runWithFailingFileStream runs a stream whose first filestream requests
+// fail, and returns its debug log.
+func runWithFailingFileStream(t *testing.T) string {
+	t.Helper()
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	dir := t.TempDir()
+	debugLog := filepath.Join(dir, "debug-internal.log")
+
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "retries"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: debugLog},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-retries.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XFaultInjection:                &wrapperspb.StringValue{Value: "filestream-fail=1,filestream-fail=2,filestream-fail=3"},
+		XRetryBudgetPerMinute:          &wrapperspb.Int32Value{Value: 2},
+		XFileStreamRetryMax:            &wrapperspb.Int32Value{Value: 10},
+		XFileStreamRetryWaitMinSeconds: &wrapperspb.DoubleValue{Value: 0.005},
+		XFileStreamRetryWaitMaxSeconds: &wrapperspb.DoubleValue{Value: 0.005},
+	}), "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "retries", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	stream.FinishAndClose(0)
+
+	content, err := os.ReadFile(debugLog)
+	require.NoError(t, err)
+	return string(content)
+}
+
+// Retries past the budget cause a single warning, and are counted per
+// stream without changing whether the requests succeed.
+func TestRetryBudget_WarnsOncePerStream(t *testing.T) {
+	for range 2 {
+		debugLog := runWithFailingFileStream(t)
+
+		assert.Equal(t, 1, strings.Count(debugLog, "stream: retry budget exceeded"))
+		assert.Contains(t, debugLog, "filestream: 3 in the last minute; server error: 3")
+		assert.Contains(t, debugLog, "sender: retried requests")
+		assert.NotContains(t, debugLog, "filestream: fatal error")
+	}
+}
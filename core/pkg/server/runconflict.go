@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// errRunConflicted fails the sync of a run that was finished on the server
+// while it was running.
+var errRunConflicted = errors.New(
+	"the run was already finished on the server, so its data can't be" +
+		" appended to it; use `wandb sync --id` to sync it to a new run")
+
+// RunConflict notes that the server rejected the run's data because the
+// run was finished elsewhere, such as from the UI or by another process.
+//
+// The filestream notes the conflict when it happens, and the sender
+// finishes the run in the conflicted state as it exits. It's safe to use
+// from any goroutine, and the methods of a nil RunConflict do nothing.
+type RunConflict struct {
+	// message is the server's explanation, or nil if there's no conflict
+	message atomic.Pointer[string]
+}
+
+func NewRunConflict() *RunConflict {
+	return &RunConflict{}
+}
+
+// Set notes the conflict, with the error that the server responded with.
+func (c *RunConflict) Set(err error) {
+	if c == nil {
+		return
+	}
+	message := err.Error()
+	c.message.CompareAndSwap(nil, &message)
+}
+
+// Message returns the server's explanation, and whether there was a
+// conflict.
+func (c *RunConflict) Message() (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	if message := c.message.Load(); message != nil {
+		return *message, true
+	}
+	return "", false
+}
+
+// recordConflict finishes the run in the conflicted state if the server
+// rejected its data as finished.
+//
+// A live run's transaction log is marked so that syncing it doesn't
+// append to the finished run. A synced run's sync fails instead.
+func (s *Sender) recordConflict() {
+	message, ok := s.runConflict.Message()
+	if !ok || s.conflicted.Swap(true) {
+		return
+	}
+	s.logger.Warn("sender: the run was finished on the server", "message", message)
+
+	if s.settings.GetXSync().GetValue() {
+		s.syncService.SetConflicted()
+		return
+	}
+	s.fwdRecord(&service.Record{
+		RecordType: &service.Record_Conflicted{
+			Conflicted: &service.RunConflictedRecord{Message: message},
+		},
+	})
+}
+
+// checkLogMarkers skips the records of a transaction log whose run was
+// aborted, or finished on the server while it was running, so that syncing
+// it neither creates the run again nor appends to the finished run.
+func (s *Sender) checkLogMarkers() {
+	path := s.settings.GetSyncFile().GetValue()
+	markers, err := readLogMarkers(s.ctx, path, s.logger)
+	switch {
+	case err != nil:
+		s.logger.CaptureError("sender: checkLogMarkers: failed to read the log", err)
+	case markers.aborted:
+		s.logger.Info("sender: not syncing an aborted run", "path", path)
+		s.discarded.Store(true)
+		s.syncService.SetDiscarded()
+	case markers.conflicted:
+		s.logger.Info("sender: not syncing a run finished on the server", "path", path)
+		s.conflicted.Store(true)
+		s.syncService.SetConflicted()
+	}
+}
+
+// logMarkers are the records that mark a transaction log's run as not to
+// be synced.
+type logMarkers struct {
+	// aborted is whether the run was aborted by the client
+	aborted bool
+
+	// conflicted is whether the run was finished on the server while it
+	// was running
+	conflicted bool
+}
+
+// readLogMarkers returns the markers in the transaction log.
+func readLogMarkers(
+	ctx context.Context,
+	path string,
+	logger *observability.CoreLogger,
+) (logMarkers, error) {
+	var markers logMarkers
+
+	store := NewStore(ctx, path, logger)
+	if err := store.Open(os.O_RDONLY); err != nil {
+		return markers, err
+	}
+	defer func() { _ = store.Close() }()
+
+	for {
+		record, err := store.Read()
+		switch {
+		case record.GetAborted() != nil:
+			// an abort overrides anything else
+			markers.aborted = true
+			return markers, nil
+		case record.GetConflicted() != nil:
+			markers.conflicted = true
+		case err == io.EOF:
+			return markers, nil
+		case err != nil:
+			return markers, err
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package server_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// awaitResultAndMessages returns the result with the uuid, along with the
+// messages for the user that came before it.
+func awaitResultAndMessages(
+	t *testing.T,
+	responses chanResponder,
+	uuid string,
+) (*service.Result, []string) {
+	t.Helper()
+	var messages []string
+	for {
+		select {
+		case result := <-responses:
+			if message := result.GetResponse().GetUserMessageResponse(); message != nil {
+				messages = append(messages, message.GetMessage())
+			}
+			if result.GetUuid() == uuid {
+				return result, messages
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%s wasn't answered", uuid)
+			return nil, nil
+		}
+	}
+}
+
+// syncConflictLog syncs the transaction log of the run "conflict" and
+// returns the sync's response and the messages for the user.
+func syncConflictLog(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	syncFile string,
+) (*service.SyncResponse, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "conflict"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	sync.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	sync.Start()
+	defer sync.Close()
+
+	sync.HandleRecord(disabledRequest("sync", &service.Request{
+		RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+	}))
+	result, messages := awaitResultAndMessages(t, responses, "sync")
+	return result.GetResponse().GetSyncResponse(), messages
+}
+
+// A run finished on the server while it's running stops uploading, keeps
+// saving its data, and finishes in the conflicted state, which its
+// transaction log records so that it isn't synced to the finished run.
+func TestRunConflict_FinishedOnServer(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubCreateRunFiles()
+	backend.InjectFault(servertest.RouteFileStream, servertest.RunFinishedFault())
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-conflict.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "conflict"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 64)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	defer stream.Close()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "conflict", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	awaitResult(t, responses, "run")
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		step:  0,
+	}))
+	require.Eventually(t,
+		func() bool { return len(backend.Requests(servertest.RouteFileStream)) > 0 },
+		5*time.Second, time.Millisecond)
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{"loss": "0.25"},
+		step:  1,
+	}))
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+	result, messages := awaitResultAndMessages(t, responses, "exit")
+
+	assert.True(t, result.GetExitResult().GetConflicted())
+	assert.Len(t, backend.Requests(servertest.RouteFileStream), 1)
+	var finishedMessages int
+	for _, message := range messages {
+		assert.NotContains(t, message, "Fatal error")
+		if strings.Contains(message, "finished on the server") {
+			finishedMessages++
+		}
+	}
+	assert.Equal(t, 1, finishedMessages)
+
+	var conflicted *service.RunConflictedRecord
+	var savedSteps []int64
+	for _, record := range readLog(t, syncFile) {
+		if record.GetConflicted() != nil {
+			conflicted = record.GetConflicted()
+		}
+		if history := record.GetHistory(); history != nil {
+			savedSteps = append(savedSteps, history.GetStep().GetNum())
+		}
+	}
+	require.NotNil(t, conflicted)
+	assert.Contains(t, conflicted.GetMessage(), "already been marked as finished")
+	assert.Equal(t, []int64{0, 1}, savedSteps)
+
+	syncBackend := servertest.NewFakeBackend()
+	defer syncBackend.Close()
+	syncBackend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	synced, _ := syncConflictLog(t, syncBackend, syncFile)
+
+	assert.Contains(t, synced.GetError().GetMessage(), "already finished")
+	assert.Empty(t, syncBackend.GraphQLRequests("UpsertBucket"))
+	assert.Empty(t, syncBackend.Requests(servertest.RouteFileStream))
+}
+
+// Syncing a run that the server already finished fails, with a message
+// saying why.
+func TestRunConflict_SyncToFinishedRun(t *testing.T) {
+	syncFile := filepath.Join(t.TempDir(), "run-conflict.wandb")
+	writeEverything(t, syncFile, []*service.Record{
+		{
+			RecordType: &service.Record_Run{
+				Run: &service.RunRecord{RunId: "conflict", Project: "testProject"},
+			},
+		},
+		makeHistoryRecord(data{items: map[string]string{"loss": "0.5"}, step: 0}),
+		{RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}}},
+	})
+
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubCreateRunFiles()
+	backend.InjectFault(servertest.RouteFileStream, servertest.RunFinishedFault())
+	synced, messages := syncConflictLog(t, backend, syncFile)
+
+	assert.Contains(t, synced.GetError().GetMessage(), "already finished")
+	assert.Len(t, backend.Requests(servertest.RouteFileStream), 1)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "Can't sync to the run")
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/runname"
+	"github.com/wandb/wandb/core/internal/runresume"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// defaultProject is the project of runs that don't specify one.
+const defaultProject = "uncategorized"
+
+// viewerEntities caches the default entity of each API key.
+//
+// The cache lives as long as the server process, so that the viewer is
+// queried once per API key rather than once per run, like in a sweep.
+// Streams of the process may log to different servers, so keys are
+// cached per server.
+type viewerEntities struct {
+	mu sync.Mutex
+
+	// entities maps API keys to the viewer's default entity
+	entities map[viewerKey]string
+}
+
+// viewerKey identifies a viewer: an API key on a server.
+type viewerKey struct {
+	baseURL string
+	apiKey  string
+}
+
+// defaultEntities are the default entities looked up by this process.
+var defaultEntities = &viewerEntities{entities: make(map[viewerKey]string)}
+
+// Get returns the default entity of the API key on the server, if it was
+// looked up.
+func (v *viewerEntities) Get(baseURL, apiKey string) (string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	entity, ok := v.entities[viewerKey{baseURL, apiKey}]
+	return entity, ok
+}
+
+// Set records the default entity of the API key on the server.
+func (v *viewerEntities) Set(baseURL, apiKey string, entity string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entities[viewerKey{baseURL, apiKey}] = entity
+}
+
+// resolveRunDefaults fills in the fields of the run that the client
+// didn't set.
+//
+// The entity is the viewer's default entity, and the project is
+// defaultProject. If the viewer can't be queried, the entity is left
+// empty for the server to choose.
+//
+// The group, job type, sweep and host default to the settings, for
+// clients that only set them there.
+//
+// The display name is generated from the run ID, unless disabled in the
+// settings. Resumed runs and secondary writers of shared-mode runs keep
+// the name the run already has on the server.
+func (s *Sender) resolveRunDefaults(run *service.RunRecord) {
+	if run.GetEntity() == "" {
+		run.Entity = s.defaultEntity()
+	}
+	if run.GetProject() == "" {
+		run.Project = defaultProject
+	}
+	if run.GetRunGroup() == "" {
+		run.RunGroup = s.settings.GetRunGroup().GetValue()
+	}
+	if run.GetJobType() == "" {
+		run.JobType = s.settings.GetRunJobType().GetValue()
+	}
+	if run.GetSweepId() == "" {
+		run.SweepId = s.settings.GetSweepId().GetValue()
+	}
+	if run.GetHost() == "" {
+		run.Host = s.settings.GetHost().GetValue()
+	}
+	if run.GetDisplayName() == "" &&
+		!s.settings.GetXDisableGeneratedRunNames().GetValue() &&
+		runresume.ResumeMode(s.settings.GetResume().GetValue()) == runresume.None &&
+		!s.secondary {
+		run.DisplayName = runname.Generate(run.GetRunId())
+	}
+}
+
+// defaultEntity returns the viewer's default entity, or an empty string
+// if it can't be determined.
+func (s *Sender) defaultEntity() string {
+	if s.settings.GetXDisableViewer().GetValue() {
+		return ""
+	}
+
+	baseURL := s.settings.GetBaseUrl().GetValue()
+	apiKey := s.settings.GetApiKey().GetValue()
+	if entity, ok := defaultEntities.Get(baseURL, apiKey); ok {
+		return entity
+	}
+
+	data, err := gql.Viewer(s.ctx, s.graphqlClient)
+	if err != nil {
+		s.logger.Warn(
+			"sender: defaultEntity: failed to query the viewer,"+
+				" leaving the entity to the server",
+			"error", err)
+		return ""
+	}
+
+	var entity *string
+	if viewer := data.GetViewer(); viewer != nil {
+		entity = viewer.GetEntity()
+	}
+	if entity == nil || *entity == "" {
+		s.logger.Warn("sender: defaultEntity: the viewer has no default entity")
+		return ""
+	}
+
+	defaultEntities.Set(baseURL, apiKey, *entity)
+	return *entity
+}
@@ -0,0 +1,184 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/gqlmock"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runname"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const validViewerResponse = `{"viewer": {"id": "user-id", "entity": "viewer-entity"}}`
+
+func makeRunDefaultsSettings(apiKey string) *service.Settings {
+	return &service.Settings{
+		RunId:  &wrapperspb.StringValue{Value: "run1"},
+		ApiKey: &wrapperspb.StringValue{Value: apiKey},
+	}
+}
+
+// upsertRunWithoutEntity sends a run record without an entity, project or
+// display name to a sender and returns the result.
+func upsertRunWithoutEntity(
+	mockGQL *gqlmock.MockClient,
+	settings *service.Settings,
+) *service.RunUpdateResult {
+	ctx, cancel := context.WithCancel(context.Background())
+	outChan := make(chan *service.Result, 1)
+	sender := server.NewSender(ctx, cancel,
+		&server.SenderParams{
+			Logger:        observability.NewNoOpLogger(),
+			Settings:      settings,
+			FwdChan:       make(chan *service.Record, 1),
+			OutChan:       outChan,
+			Mailbox:       mailbox.NewMailbox(),
+			GraphqlClient: mockGQL,
+		},
+	)
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "run1"},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	})
+	return (<-outChan).GetRunResult()
+}
+
+func countOps(mockGQL *gqlmock.MockClient, opName string) int {
+	count := 0
+	for _, request := range mockGQL.AllRequests() {
+		if request.OpName == opName {
+			count++
+		}
+	}
+	return count
+}
+
+func TestRunDefaults_FromViewer(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("Viewer"), validViewerResponse)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		validUpsertBucketResponse,
+	)
+
+	result := upsertRunWithoutEntity(mockGQL, makeRunDefaultsSettings("viewer-test-key"))
+
+	assert.Nil(t, result.GetError())
+	assert.Equal(t, "FakeEntity", result.GetRun().GetEntity())
+	requests := mockGQL.AllRequests()
+	assert.Len(t, requests, 3)
+	gqlmock.AssertRequest(t,
+		gqlmock.WithVariables(
+			gqlmock.GQLVar("entity", gomock.Eq("viewer-entity")),
+			gqlmock.GQLVar("project", gomock.Eq("uncategorized")),
+		),
+		requests[1])
+}
+
+func TestRunDefaults_ViewerCachedPerAPIKey(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("Viewer"), validViewerResponse)
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("Viewer"), validViewerResponse)
+	for i := 0; i < 3; i++ {
+		mockGQL.StubMatchOnce(
+			gqlmock.WithOpName("UpsertBucket"),
+			validUpsertBucketResponse,
+		)
+	}
+
+	upsertRunWithoutEntity(mockGQL, makeRunDefaultsSettings("cached-key-1"))
+	upsertRunWithoutEntity(mockGQL, makeRunDefaultsSettings("cached-key-1"))
+	upsertRunWithoutEntity(mockGQL, makeRunDefaultsSettings("cached-key-2"))
+
+	assert.Equal(t, 2, countOps(mockGQL, "Viewer"))
+	assert.Equal(t, 3, countOps(mockGQL, "UpsertBucket"))
+}
+
+func TestRunDefaults_ViewerErrorLeavesEntityToServer(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		validUpsertBucketResponse,
+	)
+
+	result := upsertRunWithoutEntity(mockGQL, makeRunDefaultsSettings("failing-viewer-key"))
+
+	assert.Nil(t, result.GetError())
+	requests := mockGQL.AllRequests()
+	assert.Len(t, requests, 3)
+	gqlmock.AssertRequest(t,
+		gqlmock.WithVariables(
+			gqlmock.GQLVar("entity", gomock.Nil()),
+			gqlmock.GQLVar("project", gomock.Eq("uncategorized")),
+		),
+		requests[1])
+}
+
+func TestRunDefaults_GeneratedDisplayName(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("Viewer"), validViewerResponse)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		`{"upsertBucket": {"bucket": {"project": {"name": "p", "entity": {"name": "e"}}}}}`,
+	)
+
+	result := upsertRunWithoutEntity(mockGQL, makeRunDefaultsSettings("display-name-key"))
+
+	assert.Equal(t, runname.Generate("run1"), result.GetRun().GetDisplayName())
+	gqlmock.AssertRequest(t,
+		gqlmock.WithVariables(
+			gqlmock.GQLVar("displayName", gomock.Eq(runname.Generate("run1"))),
+		),
+		mockGQL.AllRequests()[1])
+}
+
+func TestRunDefaults_GeneratedDisplayNameDisabled(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("Viewer"), validViewerResponse)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		validUpsertBucketResponse,
+	)
+	settings := makeRunDefaultsSettings("no-display-name-key")
+	settings.XDisableGeneratedRunNames = &wrapperspb.BoolValue{Value: true}
+
+	upsertRunWithoutEntity(mockGQL, settings)
+
+	gqlmock.AssertRequest(t,
+		gqlmock.WithVariables(gqlmock.GQLVar("displayName", gomock.Nil())),
+		mockGQL.AllRequests()[1])
+}
+
+func TestRunDefaults_GroupJobTypeSweepHostFromSettings(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("Viewer"), validViewerResponse)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		validUpsertBucketResponse,
+	)
+	settings := makeRunDefaultsSettings("settings-defaults-key")
+	settings.RunGroup = &wrapperspb.StringValue{Value: "group"}
+	settings.RunJobType = &wrapperspb.StringValue{Value: "eval"}
+	settings.SweepId = &wrapperspb.StringValue{Value: "sweep1"}
+	settings.Host = &wrapperspb.StringValue{Value: "node1"}
+
+	upsertRunWithoutEntity(mockGQL, settings)
+
+	gqlmock.AssertRequest(t,
+		gqlmock.WithVariables(
+			gqlmock.GQLVar("groupName", gomock.Eq("group")),
+			gqlmock.GQLVar("jobType", gomock.Eq("eval")),
+			gqlmock.GQLVar("sweep", gomock.Eq("sweep1")),
+			gqlmock.GQLVar("host", gomock.Eq("node1")),
+		),
+		mockGQL.AllRequests()[1])
+}
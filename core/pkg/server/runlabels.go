@@ -0,0 +1,176 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Limits on the labels stamped on a run.
+const (
+	maxRunLabels          = 32
+	maxRunLabelKeyBytes   = 32
+	maxRunLabelValueBytes = 64
+)
+
+// runLabelKeyPattern is what a label's key is made of.
+//
+// Keys are kept to characters that mean nothing in config paths or tags.
+var runLabelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// runLabelsPath is where the labels are in the run's config.
+var runLabelsPath = []string{"_wandb", "labels"}
+
+// RunLabels are the labels that the infrastructure running a run stamps on
+// it, such as its team, cost center or cluster, without the user's code
+// setting them.
+//
+// The labels are added to the run's config under "_wandb.labels", and to
+// its tags as "key:value". They're added to the run and config records the
+// user makes, after the user's changes, so that the user can't remove or
+// replace them by accident, and so that they're in the transaction log
+// that the run is synced from.
+type RunLabels struct {
+	// labels are the labels by key
+	labels map[string]string
+
+	// tags are the labels' tags, sorted
+	tags []string
+
+	// stamped is whether the run record that creates the run was stamped
+	stamped bool
+}
+
+// NewRunLabels returns the valid labels with allowed keys, or nil if there
+// are none.
+//
+// The problems with the other labels are returned, for the user to be
+// warned about.
+func NewRunLabels(
+	labels map[string]string,
+	allowedKeys []string,
+) (*RunLabels, []string) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	l := &RunLabels{labels: make(map[string]string)}
+	var problems []string
+	for _, key := range keys {
+		value := labels[key]
+		switch {
+		case !slices.Contains(allowedKeys, key):
+			problems = append(problems,
+				fmt.Sprintf("label %q is not among the allowed label keys", key))
+		case len(key) > maxRunLabelKeyBytes || !runLabelKeyPattern.MatchString(key):
+			problems = append(problems,
+				fmt.Sprintf("label key %q must be up to %d letters, digits, "+
+					"underscores or dashes", key, maxRunLabelKeyBytes))
+		case len(value) > maxRunLabelValueBytes:
+			problems = append(problems,
+				fmt.Sprintf("label %q is longer than %d bytes", key, maxRunLabelValueBytes))
+		case len(l.labels) >= maxRunLabels:
+			problems = append(problems,
+				fmt.Sprintf("label %q is beyond the limit of %d labels", key, maxRunLabels))
+		default:
+			l.labels[key] = value
+			l.tags = append(l.tags, key+":"+value)
+		}
+	}
+
+	if len(l.labels) == 0 {
+		return nil, problems
+	}
+	return l, problems
+}
+
+// runLabelsFromSettings returns the labels to stamp on the run, or nil,
+// warning the user about those that can't be.
+func runLabelsFromSettings(
+	s *settings.Settings,
+	logger *observability.CoreLogger,
+	printer *observability.Printer,
+) *RunLabels {
+	labels, err := s.GetRunLabels()
+	if err != nil {
+		logger.Warn("stream: invalid run labels", "error", err)
+		printer.Write(fmt.Sprintf("Not labeling the run: %v", err))
+		return nil
+	}
+
+	runLabels, problems := NewRunLabels(labels, s.GetRunLabelKeys())
+	for _, problem := range problems {
+		logger.Warn("stream: dropped run label", "problem", problem)
+		printer.Write(fmt.Sprintf("Not labeling the run: %s.", problem))
+	}
+	return runLabels
+}
+
+// stampRun adds the labels to a run record the user made.
+//
+// The record that creates the run always gets them. Later records update
+// the run, and get the tags if they replace its tags, and the config if
+// they change it where the labels are.
+func (l *RunLabels) stampRun(run *service.RunRecord) {
+	if l == nil || run == nil {
+		return
+	}
+	creates := !l.stamped
+	l.stamped = true
+
+	if creates || len(run.GetTags()) > 0 {
+		for _, tag := range l.tags {
+			if !slices.Contains(run.Tags, tag) {
+				run.Tags = append(run.Tags, tag)
+			}
+		}
+	}
+
+	if creates && run.Config == nil {
+		run.Config = &service.ConfigRecord{}
+	}
+	l.stampConfig(run.Config, creates)
+}
+
+// stampConfig adds the labels back to a config update the user made that
+// changes them, or that creates the run.
+//
+// Removals apply after updates, so the ones that would remove the labels
+// are dropped instead.
+func (l *RunLabels) stampConfig(config *service.ConfigRecord, creates bool) {
+	if l == nil || config == nil {
+		return
+	}
+	config.Remove = slices.DeleteFunc(config.Remove, touchesRunLabels)
+	if creates || slices.ContainsFunc(config.Update, touchesRunLabels) {
+		config.Update = append(config.Update, l.configItem())
+	}
+}
+
+// configItem returns the config item that sets the labels.
+func (l *RunLabels) configItem() *service.ConfigItem {
+	// a map of strings always encodes
+	valueJSON, _ := json.Marshal(l.labels)
+	return &service.ConfigItem{
+		NestedKey: slices.Clone(runLabelsPath),
+		ValueJson: string(valueJSON),
+	}
+}
+
+// touchesRunLabels is whether a config item is the labels, one of them, or
+// a key containing them.
+func touchesRunLabels(item *service.ConfigItem) bool {
+	path := item.GetNestedKey()
+	if len(path) == 0 {
+		path = []string{item.GetKey()}
+	}
+	n := min(len(path), len(runLabelsPath))
+	return slices.Equal(path[:n], runLabelsPath[:n])
+}
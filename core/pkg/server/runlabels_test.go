@@ -0,0 +1,216 @@
+package server_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// upsertedRun is what the backend was last told about a run.
+type upsertedRun struct {
+	tags   []string
+	config map[string]any
+}
+
+// lastUpsertedRun returns the tags and config that the run was last
+// upserted with.
+func lastUpsertedRun(t *testing.T, backend *servertest.FakeBackend) upsertedRun {
+	t.Helper()
+	var run upsertedRun
+	for _, request := range backend.GraphQLRequests("UpsertBucket") {
+		var body struct {
+			Variables struct {
+				Tags   []string `json:"tags"`
+				Config *string  `json:"config"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		if len(body.Variables.Tags) > 0 {
+			run.tags = body.Variables.Tags
+		}
+		if body.Variables.Config != nil {
+			run.config = map[string]any{}
+			require.NoError(t,
+				json.Unmarshal([]byte(*body.Variables.Config), &run.config))
+		}
+	}
+	return run
+}
+
+// labelsOf returns the labels in the run's config.
+func labelsOf(run upsertedRun) any {
+	internal, _ := run.config["_wandb"].(map[string]any)
+	value, _ := internal["value"].(map[string]any)
+	return value["labels"]
+}
+
+func labelsStream(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	proto *service.Settings,
+) (*server.Stream, chanResponder) {
+	t.Helper()
+	dir := t.TempDir()
+	proto.RunId = &wrapperspb.StringValue{Value: "labels"}
+	proto.BaseUrl = &wrapperspb.StringValue{Value: backend.URL()}
+	proto.ApiKey = &wrapperspb.StringValue{Value: "test-api-key"}
+	proto.LogDir = &wrapperspb.StringValue{Value: dir}
+	proto.LogInternal = &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")}
+	proto.FilesDir = &wrapperspb.StringValue{Value: filepath.Join(dir, "files")}
+	proto.XDisableStats = &wrapperspb.BoolValue{Value: true}
+	proto.XDisableMeta = &wrapperspb.BoolValue{Value: true}
+	if proto.SyncFile == nil {
+		proto.SyncFile = &wrapperspb.StringValue{Value: filepath.Join(dir, "run-labels.wandb")}
+	}
+
+	stream, err := server.NewStream(settings.From(proto), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	return stream, responses
+}
+
+func runRecord(uuid string, run *service.RunRecord) *service.Record {
+	run.RunId = "labels"
+	run.Project = "testProject"
+	return &service.Record{
+		RecordType: &service.Record_Run{Run: run},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       uuid,
+	}
+}
+
+func TestNewRunLabels(t *testing.T) {
+	labels, problems := server.NewRunLabels(
+		map[string]string{
+			"team":        "ml",
+			"cost-center": "1234",
+			"cluster":     strings.Repeat("x", 65),
+			"bad.key":     "value",
+			"secret":      "value",
+		},
+		[]string{"team", "cost-center", "cluster", "bad.key"},
+	)
+
+	require.NotNil(t, labels)
+	assert.Len(t, problems, 3)
+	assert.Contains(t, strings.Join(problems, "\n"), `"cluster" is longer than 64 bytes`)
+	assert.Contains(t, strings.Join(problems, "\n"), `"bad.key" must be`)
+	assert.Contains(t, strings.Join(problems, "\n"), `"secret" is not among the allowed`)
+
+	labels, problems = server.NewRunLabels(
+		map[string]string{"team": "ml"},
+		nil,
+	)
+	assert.Nil(t, labels)
+	assert.Len(t, problems, 1)
+}
+
+// The labels are applied after the user's config and tags, so that the
+// user's updates don't remove them.
+func TestRunLabels_AppliedAfterUser(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	backend.StubCreateRunFiles()
+	stream, responses := labelsStream(t, backend, &service.Settings{
+		XRunLabels: &service.MapStringKeyStringValue{
+			Value: map[string]string{"team": "ml", "cluster": "a100"},
+		},
+		XRunLabelKeys: &service.ListStringValue{Value: []string{"team", "cluster"}},
+	})
+
+	// the client's first run record replaces the whole "_wandb" key
+	stream.HandleRecord(runRecord("run", &service.RunRecord{
+		Tags: []string{"mine"},
+		Config: &service.ConfigRecord{
+			Update: []*service.ConfigItem{
+				{Key: "_wandb", ValueJson: `{"cli_version": "0.17.0"}`},
+				{Key: "lr", ValueJson: "0.1"},
+			},
+		},
+	}))
+	require.Nil(t, awaitResult(t, responses, "run").GetRunResult().GetError())
+	firstRun := lastUpsertedRun(t, backend)
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Config{
+			Config: &service.ConfigRecord{
+				Remove: []*service.ConfigItem{{NestedKey: []string{"_wandb", "labels"}}},
+			},
+		},
+	})
+	stream.HandleRecord(runRecord("tags", &service.RunRecord{
+		Tags: []string{"other", "team:ml"},
+	}))
+	require.Nil(t, awaitResult(t, responses, "tags").GetRunResult().GetError())
+	retagged := lastUpsertedRun(t, backend)
+	stream.FinishAndClose(0)
+	final := lastUpsertedRun(t, backend)
+
+	assert.Equal(t, []string{"mine", "cluster:a100", "team:ml"}, firstRun.tags)
+	assert.Equal(t, []string{"other", "team:ml", "cluster:a100"}, retagged.tags)
+	wantLabels := map[string]any{"team": "ml", "cluster": "a100"}
+	assert.Equal(t, wantLabels, labelsOf(firstRun))
+	assert.Equal(t, wantLabels, labelsOf(final))
+	assert.Equal(t,
+		map[string]any{"value": 0.1},
+		final.config["lr"])
+}
+
+// Labels from the environment are saved in an offline run's transaction
+// log, and syncing it labels the run without them.
+func TestRunLabels_OfflineRunSyncsLabelsFromEnv(t *testing.T) {
+	t.Setenv(settings.RunLabelsEnv, "team=ml, zone = us-east ,")
+	t.Setenv(settings.RunLabelKeysEnv, "team,zone")
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	syncFile := filepath.Join(t.TempDir(), "run-labels.wandb")
+	stream, _ := labelsStream(t, backend, &service.Settings{
+		XOffline: &wrapperspb.BoolValue{Value: true},
+		SyncFile: &wrapperspb.StringValue{Value: syncFile},
+		XRunLabels: &service.MapStringKeyStringValue{
+			Value: map[string]string{"team": "overridden"},
+		},
+	})
+	stream.HandleRecord(runRecord("run", &service.RunRecord{}))
+	stream.HandleRecord(makeRunStartRecord())
+	stream.FinishAndClose(0)
+
+	// the sync doesn't stamp labels of its own
+	t.Setenv(settings.RunLabelsEnv, "team=sync")
+	syncBackend := servertest.NewFakeBackend()
+	defer syncBackend.Close()
+	syncBackend.StubGraphQL("UpsertBucket", upsertBucketWithIDResponse)
+	syncBackend.StubCreateRunFiles()
+	sync, _ := labelsStream(t, syncBackend, &service.Settings{
+		XSync:    &wrapperspb.BoolValue{Value: true},
+		SyncFile: &wrapperspb.StringValue{Value: syncFile},
+	})
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+	synced := lastUpsertedRun(t, syncBackend)
+
+	assert.Empty(t, backend.GraphQLRequests("UpsertBucket"))
+	assert.Equal(t, []string{"team:ml", "zone:us-east"}, synced.tags)
+	assert.Equal(t,
+		map[string]any{"team": "ml", "zone": "us-east"},
+		labelsOf(synced))
+}
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/segmentio/encoding/json"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/internal/containerenv"
+	"github.com/wandb/wandb/core/internal/schedulerenv"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+// RunMetadata captures information about the environment of a run.
+//
+// This is the run's metadata (wandb-metadata.json), its requirements,
+// its code and git diffs, which are saved to the run's files directory.
+//
+// All methods are safe to call on a nil *RunMetadata and do nothing;
+// a nil value is used when metadata capture is disabled.
+type RunMetadata struct {
+	// ctx bounds the time spent probing the environment
+	ctx context.Context
+
+	// settings is the settings for the run
+	settings *service.Settings
+
+	// logger is the logger for the stream
+	logger *observability.CoreLogger
+}
+
+// NewRunMetadata returns a RunMetadata, or nil if metadata capture is
+// disabled in the settings.
+func NewRunMetadata(
+	ctx context.Context,
+	settings *service.Settings,
+	logger *observability.CoreLogger,
+) *RunMetadata {
+	if settings.GetXDisableMeta().GetValue() {
+		return nil
+	}
+	return &RunMetadata{ctx: ctx, settings: settings, logger: logger}
+}
+
+// Capture probes the environment at the start of a run.
+//
+// systemInfo is the system monitor's description of the hardware, and
+// may be nil. Returns the records to forward: the files that were saved
+// and the config and telemetry updates describing the environment.
+//
+// Secondary writers of a shared-mode run only capture their own node's
+// metadata, and only if they are labeled; everything else is captured
+// by the primary process.
+func (m *RunMetadata) Capture(
+	run *service.RunRecord,
+	systemInfo *service.MetadataRequest,
+) []*service.Record {
+	if m == nil {
+		return nil
+	}
+
+	var records []*service.Record
+	var files []*service.FilesItem
+	secondary := isSecondary(m.settings)
+	if secondary && writerLabel(m.settings) == "" {
+		return nil
+	}
+
+	// save code and patch
+	if m.settings.GetSaveCode().GetValue() && !secondary {
+		files = append(files, m.saveCode()...)
+		files = append(files, m.savePatches()...)
+	}
+
+	// initialize the run metadata from settings
+	var git *service.GitRepoRecord
+	if run.GetGit().GetRemoteUrl() != "" || run.GetGit().GetCommit() != "" {
+		git = &service.GitRepoRecord{
+			RemoteUrl: run.GetGit().GetRemoteUrl(),
+			Commit:    run.GetGit().GetCommit(),
+		}
+	}
+
+	// the program and its arguments may be overridden, for programs
+	// started through a wrapper
+	runSettings := settings.From(m.settings)
+	metadata := &service.MetadataRequest{
+		Os:            m.settings.GetXOs().GetValue(),
+		Python:        m.settings.GetXPython().GetValue(),
+		Host:          m.settings.GetHost().GetValue(),
+		Cuda:          m.settings.GetXCuda().GetValue(),
+		Program:       runSettings.GetProgram(),
+		CodePath:      runSettings.GetCodePath(),
+		CodePathLocal: runSettings.GetCodePathLocal(),
+		Email:         m.settings.GetEmail().GetValue(),
+		Root:          runSettings.GetRootDir(),
+		Username:      m.settings.GetUsername().GetValue(),
+		Docker:        m.settings.GetDocker().GetValue(),
+		Executable:    m.settings.GetXExecutable().GetValue(),
+		Args:          runSettings.GetArgs(),
+		Colab:         m.settings.GetColabUrl().GetValue(),
+		StartedAt:     run.GetStartTime(),
+		Git:           git,
+	}
+
+	if systemInfo != nil {
+		proto.Merge(metadata, systemInfo)
+	}
+
+	// capture the HPC scheduler job the run is part of
+	schedulerEnv := schedulerenv.Collect(os.Environ())
+	if !schedulerEnv.IsEmpty() {
+		metadata.Slurm = schedulerEnv.Slurm
+		metadata.Scheduler = schedulerEnv.Scheduler
+		if record := m.schedulerConfig(schedulerEnv); record != nil && !secondary {
+			records = append(records, record)
+		}
+	}
+
+	if record := m.containerInfo(metadata); record != nil && !secondary {
+		records = append(records, record)
+	}
+
+	files = append(files, m.writeMetadata(metadata)...)
+
+	if len(files) > 0 {
+		records = append(records, &service.Record{
+			RecordType: &service.Record_Files{
+				Files: &service.FilesRecord{Files: files},
+			},
+		})
+	}
+	return records
+}
+
+// schedulerConfig returns a config record that adds the scheduler job
+// info to the run config under the "_wandb" key.
+func (m *RunMetadata) schedulerConfig(schedulerEnv *schedulerenv.Env) *service.Record {
+	if len(schedulerEnv.Config) == 0 {
+		return nil
+	}
+
+	valueJson, err := json.Marshal(schedulerEnv.Config)
+	if err != nil {
+		m.logger.CaptureError("error marshalling scheduler config", err)
+		return nil
+	}
+
+	return &service.Record{
+		RecordType: &service.Record_Config{
+			Config: &service.ConfigRecord{
+				Update: []*service.ConfigItem{
+					{
+						NestedKey: []string{"_wandb", "scheduler"},
+						ValueJson: string(valueJson),
+					},
+				},
+			},
+		},
+	}
+}
+
+// containerInfo adds the container or Kubernetes pod the run is in
+// to the metadata and returns a telemetry record reporting it.
+func (m *RunMetadata) containerInfo(metadata *service.MetadataRequest) *service.Record {
+	ctx, cancel := context.WithTimeout(m.ctx, containerenv.DefaultTimeout)
+	defer cancel()
+
+	info := containerenv.NewDetector().Detect(ctx)
+	if info == nil {
+		return nil
+	}
+
+	metadata.Container = &service.ContainerInfo{
+		Runtime:     info.Runtime,
+		ContainerId: info.ContainerID,
+		Image:       info.Image,
+		PodName:     info.PodName,
+		Namespace:   info.Namespace,
+		NodeName:    info.NodeName,
+	}
+
+	return &service.Record{
+		RecordType: &service.Record_Telemetry{
+			Telemetry: &service.TelemetryRecord{
+				Env: &service.Env{
+					Container:  true,
+					Kubernetes: info.IsKubernetes(),
+				},
+			},
+		},
+	}
+}
+
+// SaveRequirements writes the user's Python packages to requirements.txt.
+//
+// Returns a files record for the file, or nil if it was not written.
+// Secondary writers of a shared-mode run leave this to the primary.
+func (m *RunMetadata) SaveRequirements(request *service.PythonPackagesRequest) *service.Record {
+	if m == nil || isSecondary(m.settings) {
+		return nil
+	}
+
+	filename := filepath.Join(m.settings.GetFilesDir().GetValue(), RequirementsFileName)
+	file, err := os.Create(filename)
+	if err != nil {
+		m.logger.Error("error creating requirements file", "error", err)
+		return nil
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			m.logger.Error("error closing requirements file", "error", err)
+		}
+	}(file)
+
+	for _, pkg := range request.Package {
+		line := fmt.Sprintf("%s==%s\n", pkg.Name, pkg.Version)
+		_, err := file.WriteString(line)
+		if err != nil {
+			m.logger.Error("error writing requirements file", "error", err)
+			return nil
+		}
+	}
+	return &service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{
+					{
+						Path: RequirementsFileName,
+						Type: service.FilesItem_WANDB,
+					},
+				},
+			},
+		},
+	}
+}
+
+// saveCode copies the user's program into the "code" directory.
+func (m *RunMetadata) saveCode() []*service.FilesItem {
+	programRelative := m.settings.GetProgramRelpath().GetValue()
+	if programRelative == "" {
+		m.logger.Warn("saveCode: program relative path is empty")
+		return nil
+	}
+
+	programAbsolute := m.settings.GetProgramAbspath().GetValue()
+	if _, err := os.Stat(programAbsolute); err != nil {
+		m.logger.Warn("saveCode: program absolute path does not exist", "path", programAbsolute)
+		return nil
+	}
+
+	codeDir := filepath.Join(m.settings.GetFilesDir().GetValue(), "code")
+	if err := os.MkdirAll(filepath.Join(codeDir, filepath.Dir(programRelative)), os.ModePerm); err != nil {
+		return nil
+	}
+	savedProgram := filepath.Join(codeDir, programRelative)
+	if _, err := os.Stat(savedProgram); err != nil {
+		if err = utils.CopyFile(programAbsolute, savedProgram); err != nil {
+			return nil
+		}
+	}
+	return []*service.FilesItem{
+		{
+			Path: filepath.Join("code", programRelative),
+			Type: service.FilesItem_WANDB,
+		},
+	}
+}
+
+// savePatches saves the uncommitted changes and the changes since the
+// upstream branch of the git repository as patches.
+func (m *RunMetadata) savePatches() []*service.FilesItem {
+	// capture git state
+	if m.settings.GetDisableGit().GetValue() {
+		return nil
+	}
+
+	git := NewGit(m.settings.GetRootDir().GetValue(), m.logger)
+	if !git.IsAvailable() {
+		return nil
+	}
+
+	var files []*service.FilesItem
+
+	filesDirPath := m.settings.GetFilesDir().GetValue()
+	file := filepath.Join(filesDirPath, DiffFileName)
+	if err := git.SavePatch("HEAD", file); err != nil {
+		m.logger.Error("error generating diff", "error", err)
+	} else {
+		files = append(files, &service.FilesItem{Path: DiffFileName, Type: service.FilesItem_WANDB})
+	}
+
+	if output, err := git.LatestCommit("@{u}"); err != nil {
+		m.logger.Error("error getting latest commit", "error", err)
+	} else {
+		diffFileName := fmt.Sprintf("diff_%s.patch", output)
+		file = filepath.Join(filesDirPath, diffFileName)
+		if err := git.SavePatch("@{u}", file); err != nil {
+			m.logger.Error("error generating diff", "error", err)
+		} else {
+			files = append(files, &service.FilesItem{Path: diffFileName, Type: service.FilesItem_WANDB})
+		}
+	}
+
+	return files
+}
+
+// writeMetadata writes the metadata to wandb-metadata.json.
+//
+// Labeled writers of a shared-mode run write their metadata to a file
+// of their own instead, which makes up the node's section of the run's
+// metadata.
+func (m *RunMetadata) writeMetadata(metadata *service.MetadataRequest) []*service.FilesItem {
+	// TODO: Sending metadata as a request for now, eventually this should be turned into
+	//  a record and stored in the transaction log
+	mo := protojson.MarshalOptions{
+		Indent: "  ",
+		// EmitUnpopulated: true,
+	}
+	jsonBytes, err := mo.Marshal(metadata)
+	if err != nil {
+		m.logger.CaptureError("error marshalling metadata", err)
+		return nil
+	}
+	fileName := MetaFileName
+	if label := writerLabel(m.settings); label != "" {
+		fileName = labeledMetaFileName(label)
+	}
+	filePath := filepath.Join(m.settings.GetFilesDir().GetValue(), fileName)
+	if err := atomicfile.Write(filePath, jsonBytes, 0644); err != nil {
+		m.logger.CaptureError("error writing metadata file", err)
+		return nil
+	}
+
+	return []*service.FilesItem{
+		{
+			Path: fileName,
+			Type: service.FilesItem_WANDB,
+		},
+	}
+}
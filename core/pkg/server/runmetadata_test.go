@@ -0,0 +1,262 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/pkg/monitor"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func makeRunStartRecord() *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_RunStart{
+					RunStart: &service.RunStartRequest{
+						Run: &service.RunRecord{
+							RunId:     "test-run",
+							StartTime: timestamppb.Now(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makePythonPackagesRecord() *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_PythonPackages{
+					PythonPackages: &service.PythonPackagesRequest{
+						Package: []*service.PythonPackagesRequest_PythonPackage{
+							{Name: "numpy", Version: "1.26.4"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewRunMetadata_Disabled(t *testing.T) {
+	settings := &service.Settings{XDisableMeta: &wrapperspb.BoolValue{Value: true}}
+
+	runMetadata := server.NewRunMetadata(context.Background(), settings, observability.NewNoOpLogger())
+
+	assert.Nil(t, runMetadata)
+	assert.Nil(t, runMetadata.Capture(&service.RunRecord{}, nil))
+	assert.Nil(t, runMetadata.SaveRequirements(&service.PythonPackagesRequest{}))
+}
+
+func TestRunMetadata_Capture(t *testing.T) {
+	filesDir := t.TempDir()
+	settings := &service.Settings{
+		FilesDir: &wrapperspb.StringValue{Value: filesDir},
+		Program:  &wrapperspb.StringValue{Value: "train.py"},
+	}
+	runMetadata := server.NewRunMetadata(context.Background(), settings, observability.NewNoOpLogger())
+
+	records := runMetadata.Capture(
+		&service.RunRecord{StartTime: timestamppb.Now()},
+		&service.MetadataRequest{CpuCount: 8},
+	)
+
+	require.NotEmpty(t, records)
+	files := records[len(records)-1].GetFiles()
+	require.NotNil(t, files)
+	assert.Equal(t, server.MetaFileName, files.GetFiles()[0].GetPath())
+
+	content, err := os.ReadFile(filepath.Join(filesDir, server.MetaFileName))
+	require.NoError(t, err)
+	var metadata map[string]any
+	require.NoError(t, json.Unmarshal(content, &metadata))
+	assert.Equal(t, "train.py", metadata["program"])
+	assert.EqualValues(t, 8, metadata["cpu_count"])
+}
+
+// Overrides are recorded instead of the probed program, with arguments
+// kept exactly as given.
+func TestRunMetadata_CaptureOverrides(t *testing.T) {
+	filesDir := t.TempDir()
+	args := []string{"--name", "hello world", "--label", "日本語 ☃", ""}
+	settings := &service.Settings{
+		FilesDir:          &wrapperspb.StringValue{Value: filesDir},
+		Program:           &wrapperspb.StringValue{Value: "/usr/bin/entrypoint.sh"},
+		ProgramRelpath:    &wrapperspb.StringValue{Value: "entrypoint.sh"},
+		RootDir:           &wrapperspb.StringValue{Value: "/"},
+		XArgs:             &service.ListStringValue{Value: []string{"--wrapped"}},
+		XProgramOverride:  &wrapperspb.StringValue{Value: "train.py"},
+		XCodePathOverride: &wrapperspb.StringValue{Value: "src/train.py"},
+		XRootDirOverride:  &wrapperspb.StringValue{Value: "/workspace"},
+		XArgsOverride:     &service.ListStringValue{Value: args},
+	}
+	runMetadata := server.NewRunMetadata(context.Background(), settings, observability.NewNoOpLogger())
+
+	runMetadata.Capture(&service.RunRecord{StartTime: timestamppb.Now()}, nil)
+
+	content, err := os.ReadFile(filepath.Join(filesDir, server.MetaFileName))
+	require.NoError(t, err)
+	var metadata struct {
+		Program       string   `json:"program"`
+		CodePath      string   `json:"codePath"`
+		CodePathLocal string   `json:"codePathLocal"`
+		Root          string   `json:"root"`
+		Args          []string `json:"args"`
+	}
+	require.NoError(t, json.Unmarshal(content, &metadata))
+	assert.Equal(t, "train.py", metadata.Program)
+	assert.Equal(t, "src/train.py", metadata.CodePath)
+	assert.Equal(t, "src/train.py", metadata.CodePathLocal)
+	assert.Equal(t, "/workspace", metadata.Root)
+	assert.Equal(t, args, metadata.Args)
+}
+
+// A run with stats and metadata disabled must not write any files nor
+// forward anything but the run start request itself.
+func TestHandleRunStart_StatsAndMetaDisabled(t *testing.T) {
+	filesDir := t.TempDir()
+	settings := &service.Settings{
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		SaveCode:      &wrapperspb.BoolValue{Value: true},
+	}
+	inChan := make(chan *service.Record, 1)
+	recorder := servertest.NewStreamRecorder()
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        settings,
+			FwdChan:         recorder.TapRecords("fwd", nil),
+			OutChan:         recorder.TapResults("out", nil),
+			TerminalPrinter: observability.NewPrinter(),
+			RunMetadata: server.NewRunMetadata(
+				context.Background(),
+				settings,
+				observability.NewNoOpLogger(),
+			),
+		},
+	)
+	go h.Do(inChan)
+
+	inChan <- makePythonPackagesRecord()
+	inChan <- makeRunStartRecord()
+	recorder.WaitForResults(t, "out", 1)
+
+	forwarded := recorder.WaitForRecords(t, "fwd", 1)
+	assert.Len(t, forwarded, 1)
+	assert.NotNil(t, forwarded[0].GetRequest().GetRunStart())
+
+	entries, err := os.ReadDir(filesDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRunMetadata_SharedModeSecondary(t *testing.T) {
+	filesDir := t.TempDir()
+	settings := &service.Settings{
+		FilesDir: &wrapperspb.StringValue{Value: filesDir},
+		XShared:  &wrapperspb.BoolValue{Value: true},
+		XPrimary: &wrapperspb.BoolValue{Value: false},
+	}
+	runMetadata := server.NewRunMetadata(context.Background(), settings, observability.NewNoOpLogger())
+
+	assert.Nil(t, runMetadata.Capture(&service.RunRecord{}, nil))
+	assert.Nil(t, runMetadata.SaveRequirements(makePythonPackagesRecord().GetRequest().GetPythonPackages()))
+
+	entries, err := os.ReadDir(filesDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// Nothing about the environment is sampled until the run starts, and a
+// retried run start doesn't start anything twice.
+func TestHandleRunStart_StartsMonitorAndCapture(t *testing.T) {
+	settings := &service.Settings{
+		FilesDir:                &wrapperspb.StringValue{Value: t.TempDir()},
+		XStatsSampleRateSeconds: &wrapperspb.DoubleValue{Value: 0.01},
+		XStatsSamplesToAverage:  &wrapperspb.Int32Value{Value: 1},
+	}
+	inChan := make(chan *service.Record, 1)
+	recorder := servertest.NewStreamRecorder()
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        settings,
+			FwdChan:         recorder.TapRecords("fwd", nil),
+			OutChan:         recorder.TapResults("out", nil),
+			TerminalPrinter: observability.NewPrinter(),
+			SystemMonitor: monitor.NewSystemMonitor(
+				observability.NewNoOpLogger(),
+				settings,
+				recorder.TapRecords("stats", nil),
+			),
+			RunMetadata: server.NewRunMetadata(
+				context.Background(),
+				settings,
+				observability.NewNoOpLogger(),
+			),
+		},
+	)
+	go h.Do(inChan)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, recorder.Records("stats"))
+
+	inChan <- makeRunStartRecord()
+	inChan <- makeRunStartRecord()
+	recorder.WaitForResults(t, "out", 2)
+	recorder.WaitForRecords(t, "stats", 1)
+
+	inChan <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Defer{
+					Defer: &service.DeferRequest{
+						State: service.DeferRequest_FLUSH_STATS,
+					},
+				},
+			},
+		},
+	}
+	close(inChan)
+	require.Eventually(t,
+		func() bool {
+			for _, record := range recorder.Records("fwd") {
+				if record.GetRequest().GetDefer() != nil {
+					return true
+				}
+			}
+			return false
+		},
+		5*time.Second, 10*time.Millisecond)
+
+	var runStarts, metadataFiles int
+	for _, record := range recorder.Records("fwd") {
+		if record.GetRequest().GetRunStart() != nil {
+			runStarts++
+		}
+		for _, file := range record.GetFiles().GetFiles() {
+			if file.GetPath() == server.MetaFileName {
+				metadataFiles++
+			}
+		}
+	}
+	assert.Equal(t, 1, runStarts)
+	assert.Equal(t, 1, metadataFiles)
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runfiles"
+	"github.com/wandb/wandb/core/pkg/artifacts"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// RunOutputs keeps track of what a run produced: the files it saved, the
+// artifacts it logged and the media files its history refers to.
+//
+// The handler notes what the run logs and the sender notes what became of
+// its artifacts, so that tools can list the outputs whether or not the
+// run is online.
+//
+// It is safe for concurrent use. A nil RunOutputs tracks nothing.
+type RunOutputs struct {
+	mu sync.Mutex
+
+	// files are the paths saved by Files records, relative to the files
+	// directory
+	files map[string]struct{}
+
+	// artifacts are the logged artifacts in the order they were logged
+	artifacts []*service.RunArtifactOutput
+
+	// artifactsByKey indexes artifacts by artifactKey
+	artifactsByKey map[string]*service.RunArtifactOutput
+
+	// media are the media files referred to by history, by path
+	media map[string]*service.RunMediaOutput
+}
+
+func NewRunOutputs() *RunOutputs {
+	return &RunOutputs{
+		files:          make(map[string]struct{}),
+		artifactsByKey: make(map[string]*service.RunArtifactOutput),
+		media:          make(map[string]*service.RunMediaOutput),
+	}
+}
+
+// artifactKey identifies a logged artifact by its client ID, or by its
+// name and digest if it has none.
+func artifactKey(artifact *service.ArtifactRecord) string {
+	if id := artifact.GetClientId(); id != "" {
+		return id
+	}
+	return artifact.GetName() + ":" + artifact.GetDigest()
+}
+
+// FilesSaved notes the files in a Files record.
+func (o *RunOutputs) FilesSaved(files *service.FilesRecord) {
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, file := range files.GetFiles() {
+		o.files[file.GetPath()] = struct{}{}
+	}
+}
+
+// ArtifactLogged notes an artifact that's about to be saved.
+//
+// Logging the same artifact again marks it pending again.
+func (o *RunOutputs) ArtifactLogged(artifact *service.ArtifactRecord) {
+	if o == nil || artifact == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	output := o.artifactOutput(artifact)
+	output.State = service.RunArtifactOutput_PENDING
+	output.ArtifactId = ""
+	output.VersionIndex = nil
+	output.ErrorMessage = ""
+}
+
+// ArtifactSaved records the outcome of saving an artifact.
+func (o *RunOutputs) ArtifactSaved(
+	artifact *service.ArtifactRecord,
+	saved artifacts.SavedArtifact,
+	err error,
+) {
+	if o == nil || artifact == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	output := o.artifactOutput(artifact)
+	if err != nil {
+		output.State = service.RunArtifactOutput_FAILED
+		output.ErrorMessage = err.Error()
+		return
+	}
+
+	output.State = service.RunArtifactOutput_COMMITTED
+	output.ArtifactId = saved.ID
+	if saved.Digest != "" {
+		output.Digest = saved.Digest
+	}
+	if saved.VersionIndex != nil {
+		output.VersionIndex = wrapperspb.Int32(int32(*saved.VersionIndex))
+	}
+}
+
+// artifactOutput returns the output for the artifact, adding it if it's
+// new.
+//
+// The mutex must be held.
+func (o *RunOutputs) artifactOutput(
+	artifact *service.ArtifactRecord,
+) *service.RunArtifactOutput {
+	key := artifactKey(artifact)
+	output, ok := o.artifactsByKey[key]
+	if !ok {
+		output = &service.RunArtifactOutput{ClientId: artifact.GetClientId()}
+		o.artifactsByKey[key] = output
+		o.artifacts = append(o.artifacts, output)
+	}
+	output.Name = artifact.GetName()
+	output.Type = artifact.GetType()
+	output.Digest = artifact.GetDigest()
+	output.Aliases = slices.Clone(artifact.GetAliases())
+	return output
+}
+
+// HistoryLogged notes the media files a history row refers to.
+//
+// Only the first row referring to each file is kept.
+func (o *RunOutputs) HistoryLogged(history *service.HistoryRecord) {
+	if o == nil {
+		return
+	}
+
+	var found []*service.RunMediaOutput
+	for _, item := range history.GetItem() {
+		if media := parseMediaItem(item); media != nil {
+			media.Step = history.GetStep().GetNum()
+			found = append(found, media)
+		}
+	}
+	if len(found) == 0 {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, media := range found {
+		if _, ok := o.media[media.Path]; !ok {
+			o.media[media.Path] = media
+		}
+	}
+}
+
+// parseMediaItem returns the media file a history item refers to, or nil
+// if it isn't a media object.
+func parseMediaItem(item *service.HistoryItem) *service.RunMediaOutput {
+	// skip the JSON decoding for the numbers that make up most of history
+	if !strings.Contains(item.GetValueJson(), `"_type"`) {
+		return nil
+	}
+
+	var value struct {
+		Type   string `json:"_type"`
+		Path   string `json:"path"`
+		Size   int64  `json:"size"`
+		SHA256 string `json:"sha256"`
+	}
+	if err := json.Unmarshal([]byte(item.GetValueJson()), &value); err != nil ||
+		value.Type == "" || value.Path == "" {
+		return nil
+	}
+
+	key := item.GetKey()
+	if len(item.GetNestedKey()) > 0 {
+		key = strings.Join(item.GetNestedKey(), ".")
+	}
+	return &service.RunMediaOutput{
+		Path:   value.Path,
+		Type:   value.Type,
+		Key:    key,
+		Size:   value.Size,
+		Sha256: value.SHA256,
+	}
+}
+
+// Proto lists the run's outputs.
+//
+// A file's status comes from the uploader if it has one and is otherwise
+// PENDING, and its size is its current size in the files directory, so
+// that offline runs list their files like online ones.
+func (o *RunOutputs) Proto(
+	filesDir string,
+	uploader runfiles.Uploader,
+) *service.ListRunOutputsResponse {
+	response := &service.ListRunOutputsResponse{}
+	if o == nil {
+		return response
+	}
+
+	reports := make(map[string]*service.RunFileReport)
+	if uploader != nil {
+		for _, report := range uploader.Report(0).GetFiles() {
+			reports[report.GetPath()] = report
+		}
+	}
+
+	o.mu.Lock()
+	for path := range o.files {
+		if _, ok := reports[path]; !ok {
+			reports[path] = &service.RunFileReport{
+				Path:   path,
+				Status: service.RunFileReport_PENDING,
+			}
+		}
+	}
+	for _, artifact := range o.artifacts {
+		response.Artifacts = append(response.Artifacts,
+			proto.Clone(artifact).(*service.RunArtifactOutput))
+	}
+	for _, media := range o.media {
+		response.Media = append(response.Media,
+			proto.Clone(media).(*service.RunMediaOutput))
+	}
+	o.mu.Unlock()
+
+	for _, report := range reports {
+		if filesDir != "" {
+			info, err := os.Stat(filepath.Join(filesDir, report.GetPath()))
+			if err == nil && info.Mode().IsRegular() {
+				report.Size = info.Size()
+			}
+		}
+		response.Files = append(response.Files, report)
+	}
+
+	slices.SortFunc(response.Files, func(a, b *service.RunFileReport) int {
+		return strings.Compare(a.GetPath(), b.GetPath())
+	})
+	slices.SortFunc(response.Media, func(a, b *service.RunMediaOutput) int {
+		return strings.Compare(a.GetPath(), b.GetPath())
+	})
+	return response
+}
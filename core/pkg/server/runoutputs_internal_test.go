@@ -0,0 +1,49 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/pkg/artifacts"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestRunOutputs_ArtifactOutcomes(t *testing.T) {
+	outputs := NewRunOutputs()
+	dataset := &service.ArtifactRecord{Name: "dataset", Digest: "d1", ClientId: "client-1"}
+	model := &service.ArtifactRecord{Name: "model", Digest: "d2", ClientId: "client-2"}
+	outputs.ArtifactLogged(dataset)
+	outputs.ArtifactLogged(model)
+
+	version := 3
+	outputs.ArtifactSaved(dataset,
+		artifacts.SavedArtifact{ID: "artifact-id", Digest: "d1", VersionIndex: &version},
+		nil)
+	outputs.ArtifactSaved(model, artifacts.SavedArtifact{}, errors.New("upload failed"))
+
+	listed := outputs.Proto("", nil).GetArtifacts()
+	require.Len(t, listed, 2)
+	assert.Equal(t, service.RunArtifactOutput_COMMITTED, listed[0].GetState())
+	assert.Equal(t, "artifact-id", listed[0].GetArtifactId())
+	assert.EqualValues(t, 3, listed[0].GetVersionIndex().GetValue())
+	assert.Equal(t, service.RunArtifactOutput_FAILED, listed[1].GetState())
+	assert.Equal(t, "upload failed", listed[1].GetErrorMessage())
+
+	// logging it again means saving it again
+	outputs.ArtifactLogged(dataset)
+	listed = outputs.Proto("", nil).GetArtifacts()
+	require.Len(t, listed, 2)
+	assert.Equal(t, service.RunArtifactOutput_PENDING, listed[0].GetState())
+	assert.Nil(t, listed[0].GetVersionIndex())
+}
+
+func TestRunOutputs_NilListsNothing(t *testing.T) {
+	var outputs *RunOutputs
+	outputs.FilesSaved(&service.FilesRecord{Files: []*service.FilesItem{{Path: "a"}}})
+	outputs.HistoryLogged(&service.HistoryRecord{})
+
+	assert.Empty(t, outputs.Proto("", nil).GetFiles())
+}
@@ -0,0 +1,226 @@
+package server_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// newOfflineOutputsStream starts an offline stream whose files directory
+// is filesDir.
+func newOfflineOutputsStream(
+	t *testing.T,
+	filesDir string,
+) (*server.Stream, chanResponder) {
+	t.Helper()
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "outputs"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-outputs.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 16)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	t.Cleanup(func() { stream.FinishAndClose(0) })
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "outputs", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	return stream, responses
+}
+
+// listRunOutputs requests the run's outputs and puts the response back
+// together if it comes in parts.
+func listRunOutputs(
+	t *testing.T,
+	stream *server.Stream,
+	responses chanResponder,
+) (*service.ListRunOutputsResponse, int) {
+	t.Helper()
+	stream.HandleRecord(disabledRequest("outputs", &service.Request{
+		RequestType: &service.Request_ListRunOutputs{
+			ListRunOutputs: &service.ListRunOutputsRequest{},
+		},
+	}))
+
+	assembler := server.NewResultAssembler()
+	parts := 0
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case result := <-responses:
+			if result.GetUuid() != "outputs" {
+				continue
+			}
+			parts++
+			whole, err := assembler.Add(result)
+			require.NoError(t, err)
+			if whole != nil {
+				return whole.GetResponse().GetListRunOutputsResponse(), parts
+			}
+		case <-timeout:
+			t.Fatal("the outputs weren't listed")
+		}
+	}
+}
+
+// An offline run lists the files it saved, the media its history refers
+// to and the artifacts it logged.
+func TestListRunOutputs_Offline(t *testing.T) {
+	filesDir := t.TempDir()
+	for name, size := range map[string]int{
+		"model.pt":            4096,
+		"notes.txt":           12,
+		"media/images/a.png":  3,
+		"media/images/b.png":  5,
+		"media/table/t.json":  7,
+		"wandb-metadata.json": 2,
+	} {
+		path := filepath.Join(filesDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+	}
+	stream, responses := newOfflineOutputsStream(t, filesDir)
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{
+					{Path: "model.pt", Policy: service.FilesItem_NOW},
+					{Path: "notes.txt", Policy: service.FilesItem_END},
+				},
+			},
+		},
+	})
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{
+			"loss": "0.5",
+			"image": `{"_type": "image-file", "path": "media/images/a.png",` +
+				` "sha256": "aaa", "size": 3}`,
+		},
+		step: 0,
+	}))
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{
+			"image": `{"_type": "image-file", "path": "media/images/b.png",` +
+				` "sha256": "bbb", "size": 5}`,
+			"table": `{"_type": "table-file", "path": "media/table/t.json", "size": 7}`,
+			"label": `"not media"`,
+		},
+		step: 1,
+	}))
+	stream.HandleRecord(makeHistoryRecord(data{
+		items: map[string]string{
+			"image": `{"_type": "image-file", "path": "media/images/a.png",` +
+				` "sha256": "aaa", "size": 3}`,
+		},
+		step: 2,
+	}))
+	for _, artifact := range []*service.ArtifactRecord{
+		{
+			Name:     "dataset",
+			Type:     "dataset",
+			Digest:   "d1",
+			Aliases:  []string{"latest"},
+			ClientId: "client-1",
+			Manifest: &service.ArtifactManifest{Version: 1, StoragePolicy: "wandb-storage-policy-v1"},
+		},
+		{
+			Name:     "model",
+			Type:     "model",
+			Digest:   "d2",
+			Aliases:  []string{"best", "latest"},
+			ClientId: "client-2",
+			Manifest: &service.ArtifactManifest{Version: 1, StoragePolicy: "wandb-storage-policy-v1"},
+		},
+	} {
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_Artifact{Artifact: artifact},
+		})
+	}
+
+	outputs, _ := listRunOutputs(t, stream, responses)
+
+	var paths []string
+	sizes := map[string]int64{}
+	for _, file := range outputs.GetFiles() {
+		paths = append(paths, file.GetPath())
+		sizes[file.GetPath()] = file.GetSize()
+		assert.Equal(t, service.RunFileReport_PENDING, file.GetStatus(), file.GetPath())
+	}
+	assert.Equal(t, []string{"model.pt", "notes.txt"}, paths)
+	assert.EqualValues(t, 4096, sizes["model.pt"])
+	assert.EqualValues(t, 12, sizes["notes.txt"])
+
+	require.Len(t, outputs.GetMedia(), 3)
+	a, b, table := outputs.GetMedia()[0], outputs.GetMedia()[1], outputs.GetMedia()[2]
+	assert.Equal(t, "media/images/a.png", a.GetPath())
+	assert.Equal(t, "image-file", a.GetType())
+	assert.Equal(t, "image", a.GetKey())
+	assert.EqualValues(t, 0, a.GetStep())
+	assert.EqualValues(t, 3, a.GetSize())
+	assert.Equal(t, "aaa", a.GetSha256())
+	assert.Equal(t, "media/images/b.png", b.GetPath())
+	assert.EqualValues(t, 1, b.GetStep())
+	assert.Equal(t, "media/table/t.json", table.GetPath())
+	assert.Equal(t, "table-file", table.GetType())
+	assert.Equal(t, "table", table.GetKey())
+
+	require.Len(t, outputs.GetArtifacts(), 2)
+	dataset, model := outputs.GetArtifacts()[0], outputs.GetArtifacts()[1]
+	assert.Equal(t, "dataset", dataset.GetName())
+	assert.Equal(t, "d1", dataset.GetDigest())
+	assert.Equal(t, "client-1", dataset.GetClientId())
+	assert.Equal(t, service.RunArtifactOutput_PENDING, dataset.GetState())
+	assert.Equal(t, "model", model.GetName())
+	assert.Equal(t, "model", model.GetType())
+	assert.Equal(t, []string{"best", "latest"}, model.GetAliases())
+	assert.Equal(t, service.RunArtifactOutput_PENDING, model.GetState())
+	assert.Nil(t, model.GetVersionIndex())
+}
+
+// A listing too long to send at once is sent in parts.
+func TestListRunOutputs_LongListingInParts(t *testing.T) {
+	const files = 12_000
+	stream, responses := newOfflineOutputsStream(t, t.TempDir())
+
+	items := make([]*service.FilesItem, files)
+	for i := range items {
+		items[i] = &service.FilesItem{
+			Path:   fmt.Sprintf("checkpoints/%s/%05d.pt", strings.Repeat("x", 80), i),
+			Policy: service.FilesItem_END,
+		}
+	}
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{Files: &service.FilesRecord{Files: items}},
+	})
+
+	outputs, parts := listRunOutputs(t, stream, responses)
+
+	assert.Greater(t, parts, 1)
+	require.Len(t, outputs.GetFiles(), files)
+	for i, file := range outputs.GetFiles() {
+		assert.Equal(t, items[i].GetPath(), file.GetPath())
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/runcleanup"
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// registerRun points the latest-run link at the run's directory and adds
+// the run to the wandb directory's registry.
+//
+// Runs being synced aren't registered, as they're not the latest run.
+// Failures are logged, since the run works without the registry.
+func (s *Stream) registerRun() {
+	dirs := s.settings.GetRunDirs()
+	if dirs == nil || dirs.SyncFile == "" || s.settings.IsSync() {
+		return
+	}
+	runDir := filepath.Dir(dirs.SyncFile)
+
+	if link := s.settings.Proto.GetSyncSymlinkLatest().GetValue(); link != "" {
+		if err := runregistry.UpdateLatest(link, runDir); err != nil {
+			s.logger.Error(
+				"stream: can't update the latest run link",
+				"error", err,
+				"link", link,
+			)
+		}
+	}
+
+	wandbDir := s.settings.Proto.GetWandbDir().GetValue()
+	if wandbDir == "" {
+		return
+	}
+	now := time.Now()
+	s.registry = runregistry.New(wandbDir)
+	s.registryEntry = runregistry.Entry{
+		RunID:     s.settings.GetRunID(),
+		Path:      runDir,
+		SyncFile:  dirs.SyncFile,
+		State:     runregistry.StateRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	s.recordRun()
+}
+
+// unregisterRun records in the registry that the run ended in the state.
+func (s *Stream) unregisterRun(state string) {
+	if s.registry == nil {
+		return
+	}
+
+	s.registryEntry.State = state
+	s.registryEntry.UpdatedAt = time.Now()
+	if s.writer != nil {
+		s.registryEntry.LogDigest = s.writer.LogDigest()
+	}
+	s.recordRun()
+}
+
+// exitState is the registry state of a run that exited, or finished
+// without an exit.
+func exitState(exit *service.RunExitRecord) string {
+	switch {
+	case exit.GetInterrupted():
+		return runregistry.StateInterrupted
+	case exit.GetExitCode() != 0:
+		return runregistry.StateFailed
+	default:
+		return runregistry.StateFinished
+	}
+}
+
+// recordRun appends the run's entry to the registry.
+func (s *Stream) recordRun() {
+	if err := s.registry.Record(s.registryEntry); err != nil {
+		s.logger.Error(
+			"stream: can't record the run in the registry",
+			"error", err,
+			"path", s.registry.Path(),
+		)
+	}
+}
+
+// cleanupPolicy is which old run directories the settings ask to delete.
+func cleanupPolicy(s *settings.Settings) runcleanup.Policy {
+	return runcleanup.Policy{
+		MaxAge:        s.GetCleanupMaxAge(),
+		MaxTotalBytes: s.GetCleanupMaxTotalBytes(),
+		DryRun:        s.IsCleanupDryRun(),
+	}
+}
+
+// startCleanup deletes old run directories in the background, if the
+// settings ask for it, and tells the user what was deleted.
+func (s *Stream) startCleanup(terminalPrinter *observability.Printer) {
+	policy := cleanupPolicy(s.settings)
+	wandbDir := s.settings.Proto.GetWandbDir().GetValue()
+	if !policy.Enabled() || wandbDir == "" || s.settings.IsSync() {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		report, err := runcleanup.Run(wandbDir, policy, time.Now())
+		if err != nil {
+			s.logger.CaptureError("stream: failed to clean up run directories", err)
+		}
+		if report == nil || len(report.Removed) == 0 {
+			return
+		}
+
+		for _, removed := range report.Removed {
+			s.logger.Info(
+				"stream: cleaned up run directory",
+				"dryRun", report.DryRun,
+				"runId", removed.RunID,
+				"path", removed.Path,
+				"bytes", removed.Bytes,
+				"reason", removed.Reason,
+			)
+		}
+		terminalPrinter.Write(report.Summary())
+	}()
+}
+
+// cleanupResponse describes what a cleanup deleted.
+func cleanupResponse(report *runcleanup.Report, err error) *service.CleanupResponse {
+	response := &service.CleanupResponse{}
+	if err != nil {
+		response.Error = &service.ErrorInfo{
+			Message: err.Error(),
+			Code:    service.ErrorInfo_UNKNOWN,
+		}
+	}
+	if report == nil {
+		return response
+	}
+
+	response.ReclaimedBytes = report.ReclaimedBytes
+	response.DryRun = report.DryRun
+	for _, removed := range report.Removed {
+		response.Removed = append(response.Removed, &service.RemovedRunDir{
+			RunId:  removed.RunID,
+			Path:   removed.Path,
+			Bytes:  removed.Bytes,
+			Reason: removed.Reason,
+		})
+	}
+	return response
+}
@@ -0,0 +1,84 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// A run registers itself in its wandb directory, points the latest-run
+// link at itself and cleans up old synced runs when it starts.
+func TestStream_RegistersRunAndCleansUp(t *testing.T) {
+	wandbDir := t.TempDir()
+	oldDir := filepath.Join(wandbDir, "run-old")
+	oldSyncFile := filepath.Join(oldDir, "run-old.wandb")
+	require.NoError(t, os.MkdirAll(oldDir, 0o755))
+	require.NoError(t, transactionlog.WriteSyncState(
+		oldSyncFile,
+		transactionlog.SyncState{Complete: true},
+	))
+	registry := runregistry.New(wandbDir)
+	longAgo := time.Now().Add(-30 * 24 * time.Hour)
+	require.NoError(t, registry.Record(runregistry.Entry{
+		RunID:     "old",
+		Path:      oldDir,
+		SyncFile:  oldSyncFile,
+		State:     runregistry.StateFinished,
+		StartedAt: longAgo,
+		UpdatedAt: longAgo,
+	}))
+
+	runDir := filepath.Join(wandbDir, "run-new")
+	s := settings.From(&service.Settings{
+		RunId:             &wrapperspb.StringValue{Value: "new"},
+		WandbDir:          &wrapperspb.StringValue{Value: wandbDir},
+		SyncSymlinkLatest: &wrapperspb.StringValue{Value: filepath.Join(wandbDir, "latest-run")},
+		LogDir:            &wrapperspb.StringValue{Value: filepath.Join(runDir, "logs")},
+		LogInternal:       &wrapperspb.StringValue{Value: filepath.Join(runDir, "logs", "debug-internal.log")},
+		SyncFile:          &wrapperspb.StringValue{Value: filepath.Join(runDir, "run-new.wandb")},
+		FilesDir:          &wrapperspb.StringValue{Value: filepath.Join(runDir, "files")},
+		XOffline:          &wrapperspb.BoolValue{Value: true},
+		XDisableStats:     &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:      &wrapperspb.BoolValue{Value: true},
+
+		XCleanupMaxAgeDays: &wrapperspb.Int32Value{Value: 7},
+	})
+	_, err := s.PrepareRunDirs()
+	require.NoError(t, err)
+
+	stream, err := server.NewStream(s, "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "new", Project: "testProject"},
+		},
+	})
+	stream.FinishAndClose(1)
+
+	latest, err := runregistry.ReadLatest(filepath.Join(wandbDir, "latest-run"))
+	require.NoError(t, err)
+	assert.Equal(t, runDir, latest)
+	assert.NoDirExists(t, oldDir)
+	runs, err := registry.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, runregistry.StateDeleted, runs[0].State)
+	assert.Equal(t, "new", runs[1].RunID)
+	assert.Equal(t, runregistry.StateFailed, runs[1].State)
+	// An offline run still needs to be synced.
+	state, err := transactionlog.ReadSyncState(filepath.Join(runDir, "run-new.wandb"))
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
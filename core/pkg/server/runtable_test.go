@@ -0,0 +1,78 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// A table logged a few rows at a time is uploaded once as the run exits,
+// with all of its rows.
+func TestStream_UploadsIncrementalTableOnce(t *testing.T) {
+	dir := t.TempDir()
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "tables"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-tables.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "tables", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	for step := range 50 {
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_TableRows{
+				TableRows: &service.TableRowsRecord{
+					Key:     "predictions",
+					Columns: []string{"step", "loss"},
+					Rows: []*service.TableRow{
+						{ValuesJson: []string{fmt.Sprint(step), "0.5"}},
+					},
+				},
+			},
+		})
+	}
+	stream.FinishAndClose(0)
+
+	var uploads []servertest.Request
+	for _, request := range backend.Requests(servertest.RouteUpload) {
+		if request.Path == "/upload/media/table/predictions.table.json" {
+			uploads = append(uploads, request)
+		}
+	}
+	require.Len(t, uploads, 1)
+	var table struct {
+		Data  [][]float64 `json:"data"`
+		NRows int         `json:"nrows"`
+	}
+	require.NoError(t, json.Unmarshal(uploads[0].Body, &table))
+	assert.Equal(t, 50, table.NRows)
+	for step, row := range table.Data {
+		assert.Equal(t, []float64{float64(step), 0.5}, row)
+	}
+}
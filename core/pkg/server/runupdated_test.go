@@ -0,0 +1,110 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// upsertRenamedResponse is how the server responds when it gives the run
+// a display name of its own and normalizes its project's name.
+const upsertRenamedResponse = `{
+	"upsertBucket": {
+		"bucket": {
+			"displayName": "server-name",
+			"project": {
+				"name": "Project",
+				"entity": {
+					"name": "entity"
+				}
+			}
+		}
+	}
+}`
+
+// startRenamedRun starts a run that the backend renames.
+func startRenamedRun(t *testing.T, backend *servertest.FakeBackend) *server.Stream {
+	dir := t.TempDir()
+	backend.StubGraphQL("UpsertBucket", upsertRenamedResponse)
+	run, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "renamed"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-renamed.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	run.Start()
+
+	runRecord := &service.RunRecord{
+		RunId:       "renamed",
+		Project:     "project",
+		DisplayName: "client-name",
+	}
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{Run: runRecord},
+	})
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_RunStart{
+					RunStart: &service.RunStartRequest{Run: runRecord},
+				},
+			},
+		},
+	})
+	return run
+}
+
+func TestAttach_ReturnsRunAsServerCreatedIt(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	run := startRenamedRun(t, backend)
+	defer run.FinishAndClose(0)
+	responder := server.ResponderEntry{Responder: make(chanResponder, 1), ID: "client"}
+
+	var attached *service.RunRecord
+	require.Eventually(t,
+		func() bool {
+			record, provisional, err := run.Attach(context.Background(), responder)
+			attached = record
+			return err == nil && !provisional
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+
+	assert.Equal(t, "server-name", attached.GetDisplayName())
+	assert.Equal(t, "Project", attached.GetProject())
+	assert.Equal(t, "entity", attached.GetEntity())
+	assert.Equal(t, "renamed", attached.GetRunId())
+}
+
+func TestAttach_ReturnsProvisionalRunBeforeServerResponds(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.InjectFault(servertest.RouteGraphQL, servertest.Fault{Delay: 2 * time.Second})
+	run := startRenamedRun(t, backend)
+	defer run.FinishAndClose(0)
+	responder := server.ResponderEntry{Responder: make(chanResponder, 1), ID: "client"}
+
+	attached, provisional, err := run.Attach(context.Background(), responder)
+
+	require.NoError(t, err)
+	assert.True(t, provisional)
+	assert.Equal(t, "client-name", attached.GetDisplayName())
+}
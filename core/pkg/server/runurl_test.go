@@ -0,0 +1,92 @@
+package server_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runurl"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// The run's URL is written to its directory and sent to its clients once
+// the run is created, or without a URL if the run is offline.
+func TestStream_AnnouncesRunURL(t *testing.T) {
+	for _, offline := range []bool{false, true} {
+		name := "online"
+		if offline {
+			name = "offline"
+		}
+		t.Run(name, func(t *testing.T) {
+			backend := servertest.NewFakeBackend()
+			defer backend.Close()
+			backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+			backend.StubCreateRunFiles()
+
+			dir := t.TempDir()
+			responses := make(chanResponder, 100)
+			stream, err := server.NewStream(settings.From(&service.Settings{
+				RunId:         &wrapperspb.StringValue{Value: "url"},
+				BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+				ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+				LogDir:        &wrapperspb.StringValue{Value: dir},
+				LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+				SyncDir:       &wrapperspb.StringValue{Value: dir},
+				SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-url.wandb")},
+				FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+				XOffline:      &wrapperspb.BoolValue{Value: offline},
+				XDisableStats: &wrapperspb.BoolValue{Value: true},
+				XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+			}), "")
+			require.NoError(t, err)
+			stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+			stream.Start()
+
+			stream.HandleRecord(&service.Record{
+				RecordType: &service.Record_Run{
+					Run: &service.RunRecord{RunId: "url", Project: "testProject"},
+				},
+			})
+			stream.HandleRecord(makeRunStartRecord())
+			stream.FinishAndClose(0)
+
+			var announced []*service.RunUrlResponse
+			for len(responses) > 0 {
+				result := <-responses
+				if response := result.GetResponse().GetRunUrlResponse(); response != nil {
+					announced = append(announced, response)
+				}
+			}
+			content, err := os.ReadFile(filepath.Join(dir, runurl.FileName))
+			require.NoError(t, err)
+			var info runurl.Info
+			require.NoError(t, json.Unmarshal(content, &info))
+
+			assert.Equal(t, runurl.SchemaVersion, info.Version)
+			assert.Equal(t, "url", info.RunID)
+			assert.Equal(t, dir, info.LocalPath)
+			require.Len(t, announced, 1)
+			assert.Equal(t, "url", announced[0].GetRunId())
+			assert.Equal(t, dir, announced[0].GetLocalPath())
+			if offline {
+				assert.Nil(t, info.URL)
+				assert.Empty(t, announced[0].GetUrl())
+			} else {
+				url := backend.URL() + "/FakeEntity/FakeProject/runs/url"
+				require.NotNil(t, info.URL)
+				assert.Equal(t, url, *info.URL)
+				assert.Equal(t, "FakeEntity", info.Entity)
+				assert.Equal(t, "FakeProject", info.Project)
+				assert.Equal(t, url, announced[0].GetUrl())
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/redact"
+	"github.com/wandb/wandb/core/internal/waiting"
+	"github.com/wandb/wandb/core/pkg/observability"
+)
+
+const (
+	// maxRunWarnings is how many kinds of warnings are recorded in a run,
+	// after which new kinds are only counted.
+	maxRunWarnings = 20
+
+	// maxRunWarningLength is the length in bytes at which a warning's
+	// example message is cut.
+	maxRunWarningLength = 300
+)
+
+// secretPattern matches words that may be secrets, such as API keys and
+// signatures.
+var secretPattern = regexp.MustCompile(`[A-Za-z0-9_\-]{32,}`)
+
+// RunWarnings records the warnings shown to the user in the run itself.
+//
+// Problems like retried requests or truncated data are otherwise only
+// shown in a terminal that may be long gone by the time someone looks at
+// the run. The sender adds them to the run's config under
+// "_wandb.warnings" whenever it upserts the config, including at the exit.
+//
+// Warnings are grouped by kind, each with how often it happened, when it
+// was first and last seen, and an example message. A run's config is
+// visible to anyone who can see the run, so the example is sanitized:
+// paths are cut to their base names, URLs to their hosts, and words that
+// look like secrets are redacted.
+//
+// The methods of a nil RunWarnings do nothing.
+type RunWarnings struct {
+	mu sync.Mutex
+
+	clock waiting.Clock
+
+	// kinds are the warnings recorded, by kind
+	kinds map[string]*runWarning
+
+	// order are the kinds recorded, by when they were first seen
+	order []string
+
+	// dropped is the number of warnings not recorded for being of new
+	// kinds over the limit
+	dropped int
+}
+
+// runWarning is a kind of warning recorded in a run.
+type runWarning struct {
+	category  string
+	level     observability.MessageLevel
+	example   string
+	count     int
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+func NewRunWarnings(clock waiting.Clock) *RunWarnings {
+	if clock == nil {
+		clock = waiting.NewClock()
+	}
+	return &RunWarnings{
+		clock: clock,
+		kinds: make(map[string]*runWarning),
+	}
+}
+
+// Add records a message emitted for the user if it's a warning or error.
+//
+// It's meant to be given to [observability.Printer.Observe].
+func (w *RunWarnings) Add(message observability.UserMessage) {
+	if w == nil || message.Level < observability.MessageWarning {
+		return
+	}
+
+	category := warningCategory(message)
+	example := sanitizeWarning(message.Text)
+	kind := category
+	if kind == "" {
+		kind = example
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	warning, ok := w.kinds[kind]
+	if !ok {
+		if len(w.order) >= maxRunWarnings {
+			w.dropped++
+			return
+		}
+
+		warning = &runWarning{
+			category:  category,
+			level:     message.Level,
+			example:   example,
+			firstSeen: now,
+		}
+		w.kinds[kind] = warning
+		w.order = append(w.order, kind)
+	}
+	warning.count++
+	warning.lastSeen = now
+	warning.level = max(warning.level, message.Level)
+}
+
+// ConfigValue returns the warnings recorded, oldest first, as a value for
+// the run's config, and how many more weren't for being over the limit.
+//
+// It returns nil if no warnings were recorded.
+func (w *RunWarnings) ConfigValue() ([]any, int) {
+	if w == nil {
+		return nil, 0
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.order) == 0 {
+		return nil, w.dropped
+	}
+
+	warnings := make([]any, 0, len(w.order))
+	for _, kind := range w.order {
+		warning := w.kinds[kind]
+		value := map[string]any{
+			"level":      warningLevelName(warning.level),
+			"message":    warning.example,
+			"count":      warning.count,
+			"first_seen": warning.firstSeen.UTC().Format(time.RFC3339),
+			"last_seen":  warning.lastSeen.UTC().Format(time.RFC3339),
+		}
+		if warning.category != "" {
+			value["category"] = warning.category
+		}
+		warnings = append(warnings, value)
+	}
+	return warnings, w.dropped
+}
+
+// warningCategory is the kind of problem a message is about, or "" if
+// it's only known by its text.
+func warningCategory(message observability.UserMessage) string {
+	if message.Category != "" {
+		return message.Category
+	}
+	category, _, _ := strings.Cut(message.Key, ":")
+	return category
+}
+
+func warningLevelName(level observability.MessageLevel) string {
+	if level >= observability.MessageError {
+		return "error"
+	}
+	return "warning"
+}
+
+// sanitizeWarning returns the text of a warning without anything that
+// shouldn't be shown on the run's page.
+//
+// Paths are cut to their base names, URLs to their scheme and host, and
+// words that look like secrets are redacted. Whitespace is collapsed, and
+// long messages are cut.
+func sanitizeWarning(text string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		words[i] = sanitizeWord(word)
+	}
+
+	sanitized := strings.Join(words, " ")
+	if len(sanitized) > maxRunWarningLength {
+		sanitized = strings.ToValidUTF8(sanitized[:maxRunWarningLength], "") + "..."
+	}
+	return sanitized
+}
+
+// sanitizeWord sanitizes one word of a warning, keeping the punctuation
+// around it.
+func sanitizeWord(word string) string {
+	trimmed := strings.TrimLeft(word, `"'([{<`)
+	prefix := word[:len(word)-len(trimmed)]
+	core := strings.TrimRight(trimmed, `"')]}>,;:.!?`)
+	suffix := trimmed[len(core):]
+	if core == "" {
+		return word
+	}
+
+	if parsed, err := url.Parse(core); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		return prefix + parsed.Scheme + "://" + parsed.Host + suffix
+	}
+
+	// values of "key=value" pairs, such as in logged errors
+	if key, value, ok := strings.Cut(core, "="); ok {
+		return prefix + key + "=" + sanitizeWord(value) + suffix
+	}
+
+	if strings.ContainsAny(core, `/\`) {
+		core = strings.TrimRight(core, `/\`)
+		core = core[strings.LastIndexAny(core, `/\`)+1:]
+	}
+
+	return prefix + secretPattern.ReplaceAllString(core, redact.Value) + suffix
+}
+
+// addRunWarnings adds the warnings shown to the user so far to the run's
+// config.
+func (s *Sender) addRunWarnings() {
+	warnings, dropped := s.runWarnings.ConfigValue()
+	if warnings != nil {
+		s.runConfig.SetInternal("warnings", warnings)
+		if !reflect.DeepEqual(warnings, s.lastRunWarnings) {
+			s.lastRunWarnings = warnings
+			s.dirty.Changed(configState)
+		}
+	}
+	if dropped > 0 {
+		s.runConfig.SetInternal("warnings_dropped", dropped)
+	}
+}
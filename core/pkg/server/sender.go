@@ -2,56 +2,136 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/wandb/wandb/core/internal/api"
-	"github.com/wandb/wandb/core/internal/clients"
+	"github.com/wandb/wandb/core/internal/atomicfile"
+	"github.com/wandb/wandb/core/internal/bandwidth"
+	"github.com/wandb/wandb/core/internal/consolededup"
+	"github.com/wandb/wandb/core/internal/consolelines"
 	"github.com/wandb/wandb/core/internal/debounce"
+	"github.com/wandb/wandb/core/internal/faults"
 	"github.com/wandb/wandb/core/internal/filetransfer"
 	"github.com/wandb/wandb/core/internal/gql"
 	"github.com/wandb/wandb/core/internal/mailbox"
 	"github.com/wandb/wandb/core/internal/runconfig"
+	"github.com/wandb/wandb/core/internal/runexport"
 	"github.com/wandb/wandb/core/internal/runfiles"
 	"github.com/wandb/wandb/core/internal/runresume"
 	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/runurl"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/timer"
 	"github.com/wandb/wandb/core/internal/version"
 	"github.com/wandb/wandb/core/pkg/artifacts"
 	fs "github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/launch"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
 	"github.com/wandb/wandb/core/pkg/utils"
 )
 
 const (
 	configDebouncerRateLimit  = 1 / 30.0 // todo: audit rate limit
 	configDebouncerBurstSize  = 1        // todo: audit burst size
-	summaryDebouncerRateLimit = 1 / 30.0 // todo: audit rate limit
 	summaryDebouncerBurstSize = 1        // todo: audit burst size
+
+	// runtimeUpdateInterval is how often the runtime in the summary of a
+	// running run is updated, at least; it's the filestream's heartbeat.
+	runtimeUpdateInterval = 30 * time.Second
 )
 
+// upsertBucketErrorClasses are the exceptions to the usual error classes
+// when upserting a run.
+//
+// A conflict happens when the run is being modified concurrently, which
+// resolves itself.
+var upsertBucketErrorClasses = api.ErrorClassOverrides{
+	http.StatusConflict: api.Retryable,
+}
+
 type SenderParams struct {
 	Logger              *observability.CoreLogger
 	Settings            *service.Settings
 	Backend             *api.Backend
 	FileStream          fs.FileStream
 	FileTransferManager filetransfer.FileTransferManager
+	FileTransferStats   filetransfer.FileTransferStats
+	UploadLimiter       *bandwidth.Limiter
+	FileStreamLimiter   *bandwidth.Limiter
 	RunfilesUploader    runfiles.Uploader
+	Exporter            *runexport.Exporter
 	GraphqlClient       graphql.Client
 	Peeker              *observability.Peeker
 	RunSummary          *runsummary.RunSummary
 	Mailbox             *mailbox.Mailbox
 	OutChan             chan *service.Result
 	FwdChan             chan *service.Record
+	DeferProgress       *DeferProgress
+	CrashReporter       *CrashReporter
+	FaultInjector       *faults.Injector
+	RetryBudget         *RetryBudget
+	CoreUsage           *CoreUsage
+	RunWarnings         *RunWarnings
+	Mirror              *Mirror
+	RunURLAnnouncer     *runurl.Announcer
+	RunTimer            *timer.Timer
+	PipelineLatency     *PipelineLatency
+
+	// Writer stores the records this sender may be sent before they're
+	// stored, in low-latency mode; otherwise it's nil.
+	Writer *Writer
+
+	// LogWriter writes the run's transaction log, which is synced to disk
+	// before the run is preempted, or is nil.
+	LogWriter *Writer
+
+	// RunOutputs is told what became of the run's artifacts, or is nil.
+	RunOutputs *RunOutputs
+
+	// RunConflict notes that the server rejected the run's data because
+	// the run was already finished, or is nil.
+	RunConflict *RunConflict
+
+	// ExitGrace reports the data logged after the run's exit, or is nil.
+	ExitGrace *ExitGrace
+
+	// Backpressure is told how far behind the sender is, or is nil.
+	Backpressure *Backpressure
+
+	// UploadsCtx is the context of the run's file and artifact uploads,
+	// which CancelUploads cancels if the run is aborted.
+	//
+	// If UploadsCtx is nil, the uploads use the sender's context.
+	UploadsCtx    context.Context
+	CancelUploads context.CancelFunc
+
+	// SettingsProvenance is where the values of the settings came from,
+	// which the sender records as it changes them, or is nil.
+	SettingsProvenance *settings.Provenance
+
+	// DebugLog is where the stream's debug log is written, if anywhere.
+	DebugLog string
+
+	// Restart is the run that a wandb-core process was running before it
+	// was restarted, which the sender continues, or nil.
+	Restart *restartedRun
 }
 
 // Sender is the sender for a stream it handles the incoming messages and sends to the server
@@ -63,30 +143,59 @@ type Sender struct {
 	// cancel is the cancel function for the handler
 	cancel context.CancelFunc
 
+	// uploadsCtx is the context of the run's uploads, which cancelUploads
+	// cancels if the run is aborted
+	uploadsCtx    context.Context
+	cancelUploads context.CancelFunc
+
 	// logger is the logger for the sender
 	logger *observability.CoreLogger
 
 	// settings is the settings for the sender
 	settings *service.Settings
 
+	// settingsProvenance is where the values of the settings came from
+	settingsProvenance *settings.Provenance
+
+	// debugLog is where the stream's debug log is written, for settings
+	// responses
+	debugLog string
+
 	// fwdChan is the channel for loopback messages (messages from the sender to the handler)
 	fwdChan chan *service.Record
 
 	// outChan is the channel for dispatcher messages
 	outChan chan *service.Result
 
+	// backend is the W&B backend, or nil if we're offline
+	backend *api.Backend
+
 	// graphqlClient is the graphql client
 	graphqlClient graphql.Client
 
 	// fileStream is the file stream
 	fileStream fs.FileStream
 
+	// fileStreamStarted is whether the run's filestream was started
+	fileStreamStarted bool
+
 	// filetransfer is the file uploader/downloader
 	fileTransferManager filetransfer.FileTransferManager
 
+	// fileTransferStats reports the progress of file uploads, if not nil
+	fileTransferStats filetransfer.FileTransferStats
+
+	// uploadLimiter and fileStreamLimiter cap the bandwidth of uploads and
+	// of filestream traffic
+	uploadLimiter     *bandwidth.Limiter
+	fileStreamLimiter *bandwidth.Limiter
+
 	// runfilesUploader manages uploading a run's files
 	runfilesUploader runfiles.Uploader
 
+	// exporter mirrors the run's files into an export directory, or is nil
+	exporter *runexport.Exporter
+
 	// RunRecord is the run record
 	// TODO: remove this and use properly updated settings
 	//       + a flag indicating whether the run has started
@@ -110,11 +219,45 @@ type Sender struct {
 	// runSummary is the full summary for the run
 	runSummary *runsummary.RunSummary
 
+	// userSummary is the summary keys the user set, which the final
+	// summary includes first
+	userSummary userSummary
+
+	// omittedSummaryKeys are the keys left out of the final summary
+	omittedSummaryKeys []string
+
+	// dirty is which of the config, summary and telemetry changed since
+	// they were last pushed, so that the exit only pushes those that did
+	dirty dirtyState
+
+	// lastRunWarnings is the run's warnings as last added to its config
+	lastRunWarnings []any
+
+	// lastSummaryHash is the hash of the summary last streamed
+	lastSummaryHash [16]byte
+
+	// unchangedSummaries counts the summary updates not streamed because
+	// the summary was the same as the one last streamed
+	unchangedSummaries int
+
+	// runTimer times the run, to keep the runtime in its summary current,
+	// or nil
+	runTimer *timer.Timer
+
+	// latency is told when history rows are acknowledged, or is nil
+	latency *PipelineLatency
+
+	// lastRuntime is the runtime last set in the summary
+	lastRuntime time.Duration
+
 	// Keep track of config which is being updated incrementally
 	runConfig *runconfig.RunConfig
 
+	// configFilters select the config keys sent to the server
+	configFilters []*runconfig.KeyFilter
+
 	// Info about the (local) server we are talking to
-	serverInfo *gql.ServerInfoServerInfo
+	serverInfo *serverInfoCache
 
 	// Keep track of exit record to pass to file stream when the time comes
 	exitRecord *service.Record
@@ -125,18 +268,178 @@ type Sender struct {
 	// store is the store where the transaction log is stored
 	store *Store
 
+	// allowModifiedLog is whether to sync a transaction log that changed
+	// after its run finished
+	allowModifiedLog bool
+
 	// jobBuilder is the job builder for creating jobs from the run
 	// that allow users to re-run the run with different configurations
 	jobBuilder *launch.JobBuilder
 
-	// wgFileTransfer is a wait group for file transfers
-	wgFileTransfer sync.WaitGroup
+	// uploads runs artifact uploads, downloads and links in order, so
+	// that they don't hold up the rest of the records
+	uploads *uploadLane
+
+	// artifactWaits answers clients waiting for artifacts to be saved
+	artifactWaits *artifactWaits
+
+	// artifactDrafts are the artifacts being saved whose drafts exist on
+	// the server
+	artifactDrafts *artifactDrafts
 
 	// networkPeeker is a helper for peeking into network responses
 	networkPeeker *observability.Peeker
 
+	// polls lets status polls from several clients share queries
+	polls *pollCoalescer
+
 	// mailbox is used to store cancel functions for each mailbox slot
 	mailbox *mailbox.Mailbox
+
+	// deferProgress records the defer states the sender goes through
+	deferProgress *DeferProgress
+
+	// crashReporter notes the records sent, for crash reports
+	crashReporter *CrashReporter
+
+	// faultInjector may make the sender panic, for testing
+	faultInjector *faults.Injector
+
+	// coreUsageOrNil tracks wandb-core's resource usage during the run
+	coreUsageOrNil *CoreUsage
+
+	// runWarnings are the warnings shown to the user, which are added to
+	// the run's config
+	runWarnings *RunWarnings
+
+	// secondary is whether this is a secondary writer of a shared-mode run
+	secondary bool
+
+	// projectSettingsFetched is whether the defaults of the run's project
+	// were fetched, which is done once
+	projectSettingsFetched bool
+
+	// sharedRunKey identifies the run in secondaryWriters if this is a
+	// secondary writer that registered itself there
+	sharedRunKey string
+
+	// outputFileName is the name of the file with this writer's console
+	// output, which is per-rank for labeled writers of shared-mode runs
+	outputFileName string
+
+	// mirror is the mirror this sender sends the run to, or nil if this is
+	// the run's primary sender
+	//
+	// A mirror's sender leaves the run's files directory to the primary.
+	mirror *Mirror
+
+	// runURL announces where the run can be viewed once it's created
+	runURL *runurl.Announcer
+
+	// syncState records how much of the transaction log reached the
+	// server, or is nil if the run has no log
+	syncState *syncStateFile
+
+	// syncStateInterval is how often the sync state is saved while the
+	// run is sending its data
+	syncStateInterval time.Duration
+
+	// restart is the run to continue after wandb-core was restarted, until
+	// the sender resent what didn't reach the server, or nil
+	restart *restartedRun
+
+	// lastSentNum is the number of the last stored record processed
+	//
+	// In low-latency mode, the record may not be stored yet.
+	lastSentNum int64
+
+	// writer is the writer to wait on for records to be stored, in
+	// low-latency mode, or nil
+	writer *Writer
+
+	// logWriter writes the run's transaction log, or is nil
+	logWriter *Writer
+
+	// runOutputs is told what became of the run's artifacts, or is nil
+	runOutputs *RunOutputs
+
+	// abandoned is set once records are no longer to be sent, because the
+	// stream is closing without uploading the rest of its data
+	abandoned atomic.Bool
+
+	// reportMu is held while the backlog is reported to the handler, so
+	// that the sender isn't abandoned, and the loopback channel closed,
+	// in the middle of a report
+	reportMu sync.Mutex
+
+	// discarded is set once the run is aborted by the client, after which
+	// it's deleted rather than finished
+	discarded atomic.Bool
+
+	// runConflict notes that the server rejected the run's data because
+	// the run was already finished, or is nil
+	runConflict *RunConflict
+
+	// exitGrace reports the data logged after the run's exit, or is nil
+	exitGrace *ExitGrace
+
+	// backpressure is told how far behind the sender is, or is nil
+	backpressure *Backpressure
+
+	// conflicted is set once the run is finished in the conflicted state,
+	// after which its data is only saved locally
+	conflicted atomic.Bool
+
+	// offline is set while records are held back because the network is
+	// unavailable
+	offline *offlineHold
+
+	// networkProbeInterval is how often to check whether the network is
+	// back while offline
+	networkProbeInterval time.Duration
+
+	// retries counts the retried requests, or is nil
+	retries *RetryBudget
+
+	// exitTimeout is how long to wait for the run's data to be uploaded
+	// as it exits, or 0 to wait until it's done
+	exitTimeout time.Duration
+
+	// exitDeadline is when the run's exit times out, once it's exiting
+	exitDeadline time.Time
+
+	// exitSteps runs the steps of the exit that wait for uploads, in
+	// order, so that the sender can stop waiting for them
+	exitSteps *uploadLane
+
+	// unfinished is what was left undone if the exit timed out, else nil
+	unfinished *service.UnfinishedWork
+
+	// tally counts what the run recorded, to verify it reached the server
+	// as the run exits, or is nil if the run isn't verified
+	tally *exitTally
+
+	// verifyTimeout is how long the exit waits for the server to have the
+	// run's data when verifying it
+	verifyTimeout time.Duration
+
+	// verification is how the run's data compares with the server's, once
+	// it's verified
+	verification *service.RunVerification
+
+	// consoleDedup collapses repeated console lines, or is nil
+	consoleDedup *consolededup.Deduper
+
+	// consoleLines assembles timestamped console lines, or is nil if lines
+	// aren't timestamped
+	consoleLines *consolelines.Assembler
+
+	// resultsMu guards closing outChan, since uploads left running when
+	// the exit times out may still respond
+	resultsMu sync.RWMutex
+
+	// resultsClosed is whether outChan is closed
+	resultsClosed bool
 }
 
 // NewSender creates a new Sender with the given settings
@@ -151,30 +454,97 @@ func NewSender(
 		cancel:              cancel,
 		runConfig:           runconfig.New(),
 		telemetry:           &service.TelemetryRecord{CoreVersion: version.Version},
-		wgFileTransfer:      sync.WaitGroup{},
+		uploads:             newUploadLane(),
+		artifactWaits:       newArtifactWaits(),
+		artifactDrafts:      newArtifactDrafts(),
+		polls:               newPollCoalescer(pollWindow, nil),
 		logger:              params.Logger,
 		settings:            params.Settings,
+		settingsProvenance:  params.SettingsProvenance,
+		debugLog:            params.DebugLog,
+		syncState:           newSyncStateFile(params.Settings.GetSyncFile().GetValue()),
+		restart:             params.Restart,
 		fileStream:          params.FileStream,
 		fileTransferManager: params.FileTransferManager,
+		fileTransferStats:   params.FileTransferStats,
+		uploadLimiter:       params.UploadLimiter,
+		fileStreamLimiter:   params.FileStreamLimiter,
 		runfilesUploader:    params.RunfilesUploader,
+		exporter:            params.Exporter,
 		networkPeeker:       params.Peeker,
+		backend:             params.Backend,
 		graphqlClient:       params.GraphqlClient,
 		mailbox:             params.Mailbox,
+		deferProgress:       params.DeferProgress,
+		crashReporter:       params.CrashReporter,
+		faultInjector:       params.FaultInjector,
+		coreUsageOrNil:      params.CoreUsage,
+		runWarnings:         params.RunWarnings,
+		mirror:              params.Mirror,
+		runURL:              params.RunURLAnnouncer,
+		runTimer:            params.RunTimer,
+		latency:             params.PipelineLatency,
+		writer:              params.Writer,
+		logWriter:           params.LogWriter,
+		runOutputs:          params.RunOutputs,
+		runConflict:         params.RunConflict,
+		exitGrace:           params.ExitGrace,
+		backpressure:        params.Backpressure,
+		retries:             params.RetryBudget,
 		runSummary:          params.RunSummary,
 		outChan:             params.OutChan,
 		fwdChan:             params.FwdChan,
+		secondary:           isSecondary(params.Settings),
+		outputFileName:      writerOutputFileName(params.Settings),
+		networkProbeInterval: settings.From(params.Settings).
+			GetNetworkProbeInterval(),
+		syncStateInterval: settings.From(params.Settings).
+			GetStreamStateInterval(),
+		exitTimeout:   settings.From(params.Settings).GetExitTimeout(),
+		exitSteps:     newUploadLane(),
+		verifyTimeout: settings.From(params.Settings).GetVerifyTimeout(),
+		consoleDedup: consolededup.New(
+			settings.From(params.Settings).GetConsoleDedupThreshold()),
 		configDebouncer: debounce.NewDebouncer(
 			configDebouncerRateLimit,
 			configDebouncerBurstSize,
 			params.Logger,
 		),
 		summaryDebouncer: debounce.NewDebouncer(
-			summaryDebouncerRateLimit,
+			rate.Every(settings.From(params.Settings).GetSummaryDebounceInterval()),
 			summaryDebouncerBurstSize,
 			params.Logger,
 		),
 	}
 
+	if settings.From(params.Settings).StampsConsoleLines() {
+		s.consoleLines = consolelines.New()
+	}
+
+	s.uploadsCtx, s.cancelUploads = params.UploadsCtx, params.CancelUploads
+	if s.uploadsCtx == nil {
+		s.uploadsCtx, s.cancelUploads = ctx, func() {}
+	}
+
+	var probeServer func() (*gql.ServerInfoServerInfo, error)
+	if !s.settings.GetXOffline().GetValue() && s.graphqlClient != nil {
+		probeServer = func() (*gql.ServerInfoServerInfo, error) {
+			data, err := gql.ServerInfo(s.ctx, s.graphqlClient)
+			return data.GetServerInfo(), err
+		}
+	}
+	s.serverInfo = newServerInfoCache(probeServer, s.logger)
+
+	s.addConfigFilter(
+		s.settings.GetConfigIncludeKeys().GetValue(),
+		s.settings.GetConfigExcludeKeys().GetValue(),
+	)
+
+	// syncing runs resends their data, so only live runs are verified
+	if settings.From(s.settings).IsVerifyOnExit() && !s.settings.GetXSync().GetValue() {
+		s.tally = newExitTally()
+	}
+
 	backendOrNil := params.Backend
 	if !s.settings.GetXOffline().GetValue() && backendOrNil != nil && !s.settings.GetDisableJobCreation().GetValue() {
 		s.jobBuilder = launch.NewJobBuilder(s.settings, s.logger, false)
@@ -186,26 +556,168 @@ func NewSender(
 // do sending of messages to the server
 func (s *Sender) Do(inChan <-chan *service.Record) {
 	defer s.logger.Reraise()
-	s.logger.Info("sender: started", "stream_id", s.settings.RunId)
+	defer s.crashReporter.ReportPanic("sender")
+	s.logger.Info("sender: started")
 
-	for record := range inChan {
-		s.logger.Debug(
-			"sender: processing record",
-			"record", record.RecordType,
-			"stream_id", s.settings.RunId,
-		)
-		s.sendRecord(record)
-		// TODO: reevaluate the logic here
-		s.configDebouncer.Debounce(s.upsertConfig)
-		s.summaryDebouncer.Debounce(s.streamSummary)
+	// the runtime is kept current even while no records arrive
+	runtimeTicker := time.NewTicker(runtimeUpdateInterval)
+	defer runtimeTicker.Stop()
+
+	// the backlog is checked even while no records arrive, so that
+	// backpressure is released once it drains
+	var backlogTicks <-chan time.Time
+	if s.backpressure != nil {
+		backlogTicker := time.NewTicker(backpressureCheckInterval)
+		defer backlogTicker.Stop()
+		backlogTicks = backlogTicker.C
+	}
+
+	// how much of the run reached the server is saved as it's sent, for
+	// a restarted wandb-core to continue from
+	var syncStateTicks <-chan time.Time
+	if s.syncState != nil && s.fileStream != nil &&
+		!s.settings.GetXSync().GetValue() && s.syncStateInterval > 0 {
+		syncStateTicker := time.NewTicker(s.syncStateInterval)
+		defer syncStateTicker.Stop()
+		syncStateTicks = syncStateTicker.C
+	}
+
+	for {
+		select {
+		case record, ok := <-inChan:
+			if !ok {
+				s.Close()
+				s.logger.Info("sender: closed")
+				return
+			}
+
+			s.crashReporter.Observe("sender", record)
+			s.faultInjector.MaybePanic(faults.Sender)
+			s.faultInjector.StallSender(s.ctx)
+			s.logger.Debug(
+				"sender: processing record",
+				observability.RecordTypeKey, recordTypeName(record),
+				"record", record.RecordType,
+			)
+			if s.abandoned.Load() || s.holdIfOffline(record) {
+				continue
+			}
+			s.processRecord(record)
+			s.reportBacklog(len(inChan))
+
+		case <-backlogTicks:
+			s.reportBacklog(len(inChan))
+
+		case <-syncStateTicks:
+			if !s.abandoned.Load() {
+				s.saveSyncState()
+			}
+
+		case <-runtimeTicker.C:
+			if s.abandoned.Load() {
+				continue
+			}
+			s.markRuntimeIfDue()
+			s.summaryDebouncer.Debounce(s.streamSummary)
+		}
+	}
+}
+
+// abandon makes the sender skip the records it has yet to send.
+func (s *Sender) abandon() {
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+	s.abandoned.Store(true)
+}
+
+// processRecord sends the record and any debounced updates that are due.
+func (s *Sender) processRecord(record *service.Record) {
+	s.sendRecord(record)
+	if record.Num > 0 {
+		s.lastSentNum = record.Num
+	}
+
+	// TODO: reevaluate the logic here
+	s.configDebouncer.Debounce(s.upsertConfig)
+	s.markRuntimeIfDue()
+	s.summaryDebouncer.Debounce(s.streamSummary)
+}
+
+// markRuntimeIfDue marks the summary to be streamed if its runtime is
+// out of date, so that a running run's runtime stays current.
+func (s *Sender) markRuntimeIfDue() {
+	if !s.tracksRuntime() {
+		return
 	}
-	s.Close()
-	s.logger.Info("sender: closed", "stream_id", s.settings.RunId)
+	if s.runTimer.Elapsed()-s.lastRuntime >= runtimeUpdateInterval {
+		s.summaryDebouncer.SetNeedsDebounce()
+	}
+}
+
+// updateRuntime sets the runtime in the run's summary to how long the
+// run has run, including before it was resumed.
+//
+// It's called before each time the summary is streamed, so the streamed
+// runtimes only go up. The handler stops the run timer as the run exits,
+// so the final runtime is the one in the exit record.
+func (s *Sender) updateRuntime() {
+	if !s.tracksRuntime() {
+		return
+	}
+
+	s.lastRuntime = s.runTimer.Elapsed()
+	s.runSummary.ApplyChangeRecord(
+		&service.SummaryRecord{
+			Update: []*service.SummaryItem{{
+				NestedKey: []string{"_wandb", "runtime"},
+				ValueJson: fmt.Sprintf("%d", int32(s.lastRuntime.Seconds())),
+			}},
+		},
+		func(err error) {
+			s.logger.CaptureError("sender: failed to update the runtime", err)
+		},
+	)
+}
+
+// tracksRuntime returns whether the sender keeps the runtime in the run's
+// summary current.
+//
+// When syncing an offline run, the runtime is as the run recorded it.
+func (s *Sender) tracksRuntime() bool {
+	return s.runTimer != nil &&
+		s.runTimer.IsStarted() &&
+		!s.settings.GetXSync().GetValue()
 }
 
 func (s *Sender) Close() {
+	// tasks in the upload lane may still respond to their records, unless
+	// they were left running because the exit timed out, was abandoned or
+	// the run was discarded
+	if s.unfinished == nil && !s.abandoned.Load() && !s.discarded.Load() {
+		s.uploads.Wait()
+	}
+
 	// sender is done processing data, close our dispatch channel
+	s.resultsMu.Lock()
+	s.resultsClosed = true
 	close(s.outChan)
+	s.resultsMu.Unlock()
+}
+
+// sendResult passes the result to the dispatcher, unless the sender is
+// closed.
+func (s *Sender) sendResult(result *service.Result) {
+	s.resultsMu.RLock()
+	defer s.resultsMu.RUnlock()
+
+	if s.resultsClosed {
+		s.logger.Warn(
+			"sender: dropping result after close",
+			"uuid", result.GetUuid(),
+		)
+		return
+	}
+	s.outChan <- result
 }
 
 func (s *Sender) respond(record *service.Record, response any) {
@@ -236,7 +748,7 @@ func (s *Sender) respondRunUpdate(record *service.Record, run *service.RunUpdate
 		Control:    record.Control,
 		Uuid:       record.Uuid,
 	}
-	s.outChan <- result
+	s.sendResult(result)
 }
 
 // respondResponse responds to a response record
@@ -246,7 +758,7 @@ func (s *Sender) respondResponse(record *service.Record, response *service.Respo
 		Control:    record.Control,
 		Uuid:       record.Uuid,
 	}
-	s.outChan <- result
+	s.sendResult(result)
 }
 
 // respondExit responds to an exit record
@@ -259,7 +771,7 @@ func (s *Sender) respondExit(record *service.Record, exit *service.RunExitResult
 		Control:    record.Control,
 		Uuid:       record.Uuid,
 	}
-	s.outChan <- result
+	s.sendResult(result)
 }
 
 // fwdRecord forwards a record to the next component
@@ -280,6 +792,7 @@ func (s *Sender) SendRecord(record *service.Record) {
 //
 //gocyclo:ignore
 func (s *Sender) sendRecord(record *service.Record) {
+	s.tally.add(record)
 	switch x := record.RecordType.(type) {
 	case *service.Record_Header:
 		// no-op
@@ -287,6 +800,10 @@ func (s *Sender) sendRecord(record *service.Record) {
 		// no-op
 	case *service.Record_Final:
 		// no-op
+	case *service.Record_Checkpoint:
+		// no-op
+	case *service.Record_TableRows:
+		// no-op: the handler writes the tables as run files
 	case *service.Record_Run:
 		s.sendRun(record, x.Run)
 	case *service.Record_Exit:
@@ -313,6 +830,14 @@ func (s *Sender) sendRecord(record *service.Record) {
 		s.sendTelemetry(record, x.Telemetry)
 	case *service.Record_Preempting:
 		s.sendPreempting(x.Preempting)
+	case *service.Record_Aborted:
+		s.sendAborted(record)
+	case *service.Record_UserMessage:
+		s.sendUserMessage(record, x.UserMessage)
+	case *service.Record_Conflicted:
+		// saved to the transaction log by the writer, for the sync
+	case *service.Record_ProjectSettings:
+		s.sendProjectSettings(x.ProjectSettings)
 	case *service.Record_Request:
 		s.sendRequest(record, x.Request)
 	case *service.Record_LinkArtifact:
@@ -322,6 +847,16 @@ func (s *Sender) sendRecord(record *service.Record) {
 	case *service.Record_Artifact:
 		s.sendArtifact(record, x.Artifact)
 	case nil:
+		if isUnknownType(record) {
+			// records of types this version doesn't know can't be sent;
+			// they get here if they couldn't be saved, or when syncing a
+			// log that a newer version wrote
+			s.logger.Debug(
+				"sender: skipping record of unknown type",
+				observability.RecordTypeKey, recordTypeName(record),
+			)
+			return
+		}
 		err := fmt.Errorf("sender: sendRecord: nil RecordType")
 		s.logger.CaptureFatalAndPanic("sender: sendRecord: nil RecordType", err)
 	default:
@@ -341,8 +876,16 @@ func (s *Sender) sendRequest(record *service.Record, request *service.Request) {
 		s.sendRequestDefer(x.Defer)
 	case *service.Request_LogArtifact:
 		s.sendRequestLogArtifact(record, x.LogArtifact)
+	case *service.Request_ArtifactWait:
+		s.sendRequestArtifactWait(record, x.ArtifactWait)
+	case *service.Request_ArtifactCancel:
+		s.sendRequestArtifactCancel(record, x.ArtifactCancel)
+	case *service.Request_PrepareForPreemption:
+		s.sendRequestPrepareForPreemption(record, x.PrepareForPreemption)
 	case *service.Request_ServerInfo:
 		s.sendRequestServerInfo(record, x.ServerInfo)
+	case *service.Request_GetSettings:
+		s.sendRequestGetSettings(record, x.GetSettings)
 	case *service.Request_DownloadArtifact:
 		s.sendRequestDownloadArtifact(record, x.DownloadArtifact)
 	case *service.Request_Sync:
@@ -353,6 +896,10 @@ func (s *Sender) sendRequest(record *service.Record, request *service.Request) {
 		s.sendRequestStopStatus(record, x.StopStatus)
 	case *service.Request_JobInput:
 		s.sendRequestJobInput(x.JobInput)
+	case *service.Request_CredentialsUpdate:
+		s.sendRequestCredentialsUpdate(x.CredentialsUpdate)
+	case *service.Request_SettingsUpdate:
+		s.sendRequestSettingsUpdate(x.SettingsUpdate)
 	case nil:
 		err := fmt.Errorf("sender: sendRequest: nil RequestType")
 		s.logger.CaptureFatalAndPanic("sender: sendRequest: nil RequestType", err)
@@ -377,6 +924,7 @@ func (s *Sender) updateSettings() {
 	// TODO: verify that this is the correct update logic
 	if s.RunRecord.GetEntity() != "" {
 		s.settings.Entity = &wrapperspb.StringValue{Value: s.RunRecord.Entity}
+		s.settingsProvenance.Set("entity", service.EffectiveSetting_SERVER)
 	}
 	if s.RunRecord.GetProject() != "" && s.settings.Project == nil {
 		s.settings.Project = &wrapperspb.StringValue{Value: s.RunRecord.Project}
@@ -388,6 +936,11 @@ func (s *Sender) updateSettings() {
 
 // sendRun starts up all the resources for a run
 func (s *Sender) sendRequestRunStart(_ *service.RunStartRequest) {
+	// a run aborted before it started uploads nothing
+	if s.discarded.Load() {
+		return
+	}
+
 	s.updateSettings()
 
 	if s.fileStream != nil {
@@ -397,11 +950,27 @@ func (s *Sender) sendRequestRunStart(_ *service.RunStartRequest) {
 			s.RunRecord.GetRunId(),
 			s.resumeState.GetFileStreamOffset(),
 		)
+		s.fileStreamStarted = true
+
+		// let the primary know that it has to wait for this writer
+		// before marking the run as finished
+		if s.secondary && s.mirror == nil {
+			s.sharedRunKey = sharedRunKey(s.settings, s.RunRecord)
+			secondaryWriters.Add(s.sharedRunKey)
+		}
 	}
 
 	if s.fileTransferManager != nil {
 		s.fileTransferManager.Start()
 	}
+
+	if s.restart != nil {
+		s.resendAfterRestart()
+	}
+
+	// the state is saved from the start, so that a restart right away
+	// doesn't send the run again
+	s.saveSyncState()
 }
 
 func (s *Sender) sendRequestNetworkStatus(
@@ -414,24 +983,29 @@ func (s *Sender) sendRequestNetworkStatus(
 	}
 
 	// send the network status response if there is any
-	if response := s.networkPeeker.Read(); len(response) > 0 {
-		s.respond(record,
-			&service.Response{
+	s.polls.Poll(record,
+		func() *service.Response {
+			response := s.networkPeeker.Read()
+			if len(response) == 0 {
+				return nil
+			}
+			return &service.Response{
 				ResponseType: &service.Response_NetworkStatusResponse{
 					NetworkStatusResponse: &service.NetworkStatusResponse{
 						NetworkResponses: response,
 					},
 				},
-			},
-		)
-	}
+			}
+		},
+		s.respondResponse,
+	)
 }
 
 func (s *Sender) sendJobFlush() {
-	if s.jobBuilder == nil {
+	if s.jobBuilder == nil || s.secondary {
 		return
 	}
-	s.jobBuilder.SetRunConfig(*s.runConfig)
+	s.jobBuilder.SetRunConfig(*s.runConfig.Filtered(s.configFilters...))
 
 	output, err := s.runSummary.CloneTree()
 	if err != nil {
@@ -451,14 +1025,18 @@ func (s *Sender) sendJobFlush() {
 	saver := artifacts.NewArtifactSaver(
 		s.ctx, s.graphqlClient, s.fileTransferManager, artifact, 0, "",
 	)
+	saver.URLBatchSize = settings.From(s.settings).GetUploadURLBatchSize()
 	if _, err = saver.Save(s.fwdChan); err != nil {
 		s.logger.Error("sender: sendDefer: failed to save job artifact", "error", err)
 	}
 }
 
 func (s *Sender) sendRequestDefer(request *service.DeferRequest) {
+	s.deferProgress.Enter(request.State)
+
 	switch request.State {
 	case service.DeferRequest_BEGIN:
+		go s.deferProgress.Watch(s.ctx, s.logger)
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_RUN:
@@ -471,56 +1049,102 @@ func (s *Sender) sendRequestDefer(request *service.DeferRequest) {
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_TB:
+		// the server rejects artifacts once the run is finished, so they're
+		// committed before the final upserts and filestream transmission
+		s.awaitExitStep(s.uploads.Wait)
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_SUM:
-		s.summaryDebouncer.Flush(s.streamSummary)
+		s.streamFinalSummary()
 		s.uploadSummaryFile()
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_DEBOUNCER:
-		s.configDebouncer.SetNeedsDebounce()
+		s.addCoreUsage()
+		s.addRunWarnings()
+		if s.dirty.Dirty(configState, telemetryState) {
+			s.configDebouncer.SetNeedsDebounce()
+		} else {
+			s.logger.Info("sender: config unchanged since pushed, not pushing it again")
+			s.configDebouncer.UnsetNeedsDebounce()
+		}
 		s.configDebouncer.Flush(s.upsertConfig)
 		s.uploadConfigFile()
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_OUTPUT:
+		s.flushOutput()
+		s.exportOutputFile()
+		s.uploadOutputFile()
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_JOB:
-		s.sendJobFlush()
+		// the job refers to the code artifact, if one is being logged
+		if s.awaitExitStep(s.uploads.Wait) {
+			s.sendJobFlush()
+		}
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_DIR:
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_FP:
-		s.wgFileTransfer.Wait()
-		if s.fileTransferManager != nil {
-			s.runfilesUploader.Finish()
-			s.fileTransferManager.Close()
-		}
+		stopProgress := s.watchExitUploads()
+		s.awaitExitStep(func() {
+			s.uploads.Wait()
+			if s.runfilesUploader != nil {
+				s.runfilesUploader.Finish()
+			}
+			if s.fileTransferManager != nil {
+				s.fileTransferManager.Close()
+			}
+			s.exporter.Finish()
+		})
+		stopProgress()
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_JOIN_FP:
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_FS:
-		if s.fileStream != nil {
-			s.fileStream.Close()
+		if s.awaitExitStep(s.closeFileStream) {
+			s.deferProgress.FileStreamDone()
 		}
+		s.recordConflict()
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_FLUSH_FINAL:
+		s.verification = s.verifyRun()
 		request.State++
 		s.fwdRequestDefer(request)
 	case service.DeferRequest_END:
 		request.State++
+		discarded := s.discarded.Load()
+		conflicted := s.conflicted.Load()
+		logComplete := s.awaitStored(s.exitRecord.GetNum())
 		s.syncService.Flush()
+		s.logRetries()
+		s.deferProgress.Finish()
+		timing := s.deferProgress.Timing()
+		logShutdownTiming(s.logger, timing)
 		if !s.settings.GetXSync().GetValue() {
+			if s.mirror == nil && logComplete && !discarded && !conflicted {
+				s.markSynced()
+			}
 			// if sync is enabled, we don't need to do this
 			// since exit is already stored in the transaction log
-			s.respond(s.exitRecord, &service.RunExitResult{})
+			s.respond(s.exitRecord, &service.RunExitResult{
+				Unfinished:     s.unfinished,
+				Verification:   s.verification,
+				ShutdownTiming: timing,
+				QuotaExceeded:  quotaExceededResult(s.backend.Quota()),
+				Files:          runFilesReport(s.runfilesUploader, s.settings),
+				Discarded:      discarded,
+				Conflicted:     conflicted,
+				LateRecords:    s.exitGrace.Proto(),
+
+				OmittedSummaryKeys: s.omittedSummaryKeys,
+			})
 		}
 		// cancel tells the stream to close the loopback and input channels
 		s.cancel()
@@ -530,6 +1154,67 @@ func (s *Sender) sendRequestDefer(request *service.DeferRequest) {
 	}
 }
 
+// runFilesReport describes what became of the run's files, or returns nil
+// if it has none.
+func runFilesReport(
+	uploader runfiles.Uploader,
+	settingsProto *service.Settings,
+) *service.RunFilesReport {
+	if uploader == nil {
+		return nil
+	}
+	report := uploader.Report(settings.From(settingsProto).GetMaxReportedFiles())
+	if len(report.GetFiles()) == 0 && report.GetOverflow() == 0 {
+		return nil
+	}
+	return report
+}
+
+// logRetries logs how often the run's requests were retried.
+func (s *Sender) logRetries() {
+	for endpoint, stats := range s.retries.Stats() {
+		s.logger.Info(
+			"sender: retried requests",
+			"endpoint", endpoint,
+			"total", stats.GetTotal(),
+			"byCategory", stats.GetByCategory(),
+		)
+	}
+}
+
+// closeFileStream flushes and closes the filestream.
+//
+// The final transmission of the primary process of a shared-mode run marks
+// the run as finished, so the primary first waits for the secondaries in
+// this process to flush their data.
+func (s *Sender) closeFileStream() {
+	if s.fileStream == nil {
+		return
+	}
+
+	if s.settings.GetXShared().GetValue() &&
+		!s.secondary &&
+		s.mirror == nil &&
+		s.RunRecord != nil {
+		if !secondaryWriters.Wait(
+			sharedRunKey(s.settings, s.RunRecord),
+			sharedRunFlushTimeout,
+		) {
+			s.logger.Warn(
+				"sender: closeFileStream: timed out waiting for secondary writers",
+				"timeout", sharedRunFlushTimeout,
+			)
+		}
+	}
+
+	s.fileStream.Close()
+
+	if s.sharedRunKey != "" {
+		secondaryWriters.Done(s.sharedRunKey)
+		s.sharedRunKey = ""
+	}
+}
+
 func (s *Sender) fwdRequestDefer(request *service.DeferRequest) {
 	record := &service.Record{
 		RecordType: &service.Record_Request{Request: &service.Request{
@@ -540,8 +1225,26 @@ func (s *Sender) fwdRequestDefer(request *service.DeferRequest) {
 	s.fwdRecord(record)
 }
 
+// fwdRunUpdated sends the run as the server has it back to the handler.
+func (s *Sender) fwdRunUpdated() {
+	run, ok := proto.Clone(s.RunRecord).(*service.RunRecord)
+	if !ok {
+		s.logger.CaptureError("sender: failed to clone RunRecord", nil)
+		return
+	}
+
+	s.fwdRecord(&service.Record{
+		RecordType: &service.Record_Request{Request: &service.Request{
+			RequestType: &service.Request_RunUpdated{
+				RunUpdated: &service.RunUpdatedRequest{Run: run},
+			},
+		}},
+		Control: &service.Control{AlwaysSend: true, Local: true},
+	})
+}
+
 func (s *Sender) sendTelemetry(_ *service.Record, telemetry *service.TelemetryRecord) {
-	proto.Merge(s.telemetry, telemetry)
+	s.mergeTelemetry(telemetry)
 	s.updateConfigPrivate()
 	// TODO(perf): improve when debounce config is added, for now this sends all the time
 	s.configDebouncer.SetNeedsDebounce()
@@ -555,20 +1258,26 @@ func (s *Sender) sendPreempting(record *service.RunPreemptingRecord) {
 	s.fileStream.StreamUpdate(&fs.PreemptingUpdate{Record: record})
 }
 
+// sendLinkArtifact links an artifact to a portfolio.
+//
+// This runs in the upload lane because the artifact may be one that is
+// still being saved there.
 func (s *Sender) sendLinkArtifact(record *service.Record) {
-	linker := artifacts.ArtifactLinker{
-		Ctx:           s.ctx,
-		Logger:        s.logger,
-		LinkArtifact:  record.GetLinkArtifact(),
-		GraphqlClient: s.graphqlClient,
-	}
-	err := linker.Link()
-	if err != nil {
-		s.logger.CaptureFatalAndPanic("sender: sendLinkArtifact: link failure", err)
-	}
+	s.uploads.Go(func() {
+		linker := artifacts.ArtifactLinker{
+			Ctx:           s.ctx,
+			Logger:        s.logger,
+			LinkArtifact:  record.GetLinkArtifact(),
+			GraphqlClient: s.graphqlClient,
+		}
+		err := linker.Link()
+		if err != nil {
+			s.logger.CaptureFatalAndPanic("sender: sendLinkArtifact: link failure", err)
+		}
 
-	// why is this here?
-	s.respond(record, &service.Response{})
+		// why is this here?
+		s.respond(record, &service.Response{})
+	})
 }
 
 func (s *Sender) sendUseArtifact(record *service.Record) {
@@ -579,6 +1288,19 @@ func (s *Sender) sendUseArtifact(record *service.Record) {
 	s.jobBuilder.HandleUseArtifactRecord(record)
 }
 
+// mergeTelemetry merges the telemetry into the run's, noting whether it
+// changed it.
+func (s *Sender) mergeTelemetry(telemetry *service.TelemetryRecord) {
+	if telemetry == nil {
+		return
+	}
+	previous := proto.Clone(s.telemetry)
+	proto.Merge(s.telemetry, telemetry)
+	if !proto.Equal(previous, s.telemetry) {
+		s.dirty.Changed(telemetryState)
+	}
+}
+
 // Inserts W&B-internal information into the run configuration.
 //
 // Uses the given telemetry
@@ -591,9 +1313,27 @@ func (s *Sender) updateConfigPrivate() {
 	s.runConfig.AddTelemetryAndMetrics(s.telemetry, metrics)
 }
 
+// addConfigFilter filters the config keys sent to the server.
+//
+// Invalid patterns are reported and ignored.
+func (s *Sender) addConfigFilter(include, exclude []string) {
+	filter, err := runconfig.NewKeyFilter(include, exclude)
+	if err != nil {
+		s.logger.CaptureError("sender: invalid config key filter", err)
+	}
+	if filter != nil {
+		s.configFilters = append(s.configFilters, filter)
+		s.dirty.Changed(configState)
+	}
+}
+
 // Serializes the run configuration to send to the backend.
+//
+// Keys removed by the config filters are not included.
 func (s *Sender) serializeConfig(format runconfig.Format) (string, error) {
-	serializedConfig, err := s.runConfig.Serialize(format)
+	serializedConfig, err := s.runConfig.
+		Filtered(s.configFilters...).
+		Serialize(format)
 
 	if err != nil {
 		err = fmt.Errorf("failed to marshal config: %s", err)
@@ -621,13 +1361,9 @@ func (s *Sender) checkAndUpdateResumeState(record *service.Record) error {
 	// If we couldn't get the resume status, we should fail if resume is set
 	data, err := gql.RunResumeStatus(s.ctx, s.graphqlClient, &run.Project, utils.NilIfZero(run.Entity), run.RunId)
 	if err != nil {
-		err = fmt.Errorf("failed to get run resume status: %s", err)
+		err = fmt.Errorf("failed to get run resume status: %w", err)
 		s.logger.Error("sender: checkAndUpdateResumeState", "error", err)
-		result := &service.RunUpdateResult{
-			Error: &service.ErrorInfo{
-				Message: err.Error(),
-				Code:    service.ErrorInfo_COMMUNICATION,
-			}}
+		result := &service.RunUpdateResult{Error: backendErrorInfo(err)}
 		s.respond(record, result)
 		return err
 	}
@@ -641,10 +1377,23 @@ func (s *Sender) checkAndUpdateResumeState(record *service.Record) error {
 		return err
 	}
 
+	// the resumed state is pushed again along with this process's changes
+	s.dirty.Changed(configState, summaryState)
 	return nil
 }
 
 func (s *Sender) sendRun(record *service.Record, run *service.RunRecord) {
+	// When syncing an offline run, filter its config the way the run
+	// was configured to in addition to the sync's own filters.
+	if s.RunRecord == nil && s.settings.GetXSync().GetValue() {
+		include, exclude, err := settings.ConfigFiltersFromRecord(run.GetSettings())
+		if err != nil {
+			s.logger.CaptureError("sender: failed to read config filters", err)
+		} else {
+			s.addConfigFilter(include, exclude)
+		}
+	}
+
 	if s.graphqlClient != nil {
 		// The first run record sent by the client is encoded incorrectly,
 		// causing it to overwrite the entire "_wandb" config key rather than
@@ -658,10 +1407,26 @@ func (s *Sender) sendRun(record *service.Record, run *service.RunRecord) {
 				s.logger.CaptureError("Error updating run config", err)
 			})
 
-		proto.Merge(s.telemetry, run.Telemetry)
+		s.dirty.Changed(configState)
+		s.mergeTelemetry(run.Telemetry)
 		s.updateConfigPrivate()
 
 		if s.RunRecord == nil {
+			if err := s.ensureCredentials(); err != nil {
+				s.logger.Error("sender: sendRun: no credentials", "error", err)
+				if record.GetControl().GetReqResp() || record.GetControl().GetMailboxSlot() != "" {
+					s.respond(record,
+						&service.RunUpdateResult{
+							Error: &service.ErrorInfo{
+								Message: err.Error(),
+								Code:    service.ErrorInfo_AUTHENTICATION,
+							},
+						},
+					)
+				}
+				return
+			}
+
 			var ok bool
 			s.RunRecord, ok = proto.Clone(run).(*service.RunRecord)
 			if !ok {
@@ -669,7 +1434,17 @@ func (s *Sender) sendRun(record *service.Record, run *service.RunRecord) {
 				s.logger.CaptureFatalAndPanic("sender: sendRun: ", err)
 			}
 
-			if err := s.checkAndUpdateResumeState(record); err != nil {
+			// the run record returned to the client must have the
+			// entity and project that the run is logged to
+			//
+			// they're resolved on the copy, as the writer may still be
+			// storing the record
+			s.resolveRunDefaults(s.RunRecord)
+			run = s.RunRecord
+
+			if s.restart != nil {
+				s.restoreAfterRestart()
+			} else if err := s.checkAndUpdateResumeState(record); err != nil {
 				s.logger.Error(
 					"sender: sendRun: failed to checkAndUpdateResumeState",
 					"error", err)
@@ -677,23 +1452,9 @@ func (s *Sender) sendRun(record *service.Record, run *service.RunRecord) {
 			}
 		}
 
-		config, _ := s.serializeConfig(runconfig.FormatJson)
-
-		var tags []string
-		tags = append(tags, run.Tags...)
-
-		var commit, repo string
-		git := run.GetGit()
-		if git != nil {
-			commit = git.GetCommit()
-			repo = git.GetRemoteUrl()
-		}
-
-		program := s.settings.GetProgram().GetValue()
-
 		// start a new context with an additional argument from the parent context
 		// this is used to pass the retry function to the graphql client
-		ctx := context.WithValue(s.ctx, clients.CtxRetryPolicyKey, clients.UpsertBucketRetryPolicy)
+		ctx := api.WithErrorClassOverrides(s.ctx, upsertBucketErrorClasses)
 
 		// if the record has a mailbox slot, create a new cancelable context
 		// and store the cancel function in the message registry so that
@@ -708,60 +1469,46 @@ func (s *Sender) sendRun(record *service.Record, run *service.RunRecord) {
 			s.logger.CaptureError("sender: sendRun: no mailbox slot", nil)
 		}
 
-		data, err := gql.UpsertBucket(
-			ctx,                              // ctx
-			s.graphqlClient,                  // client
-			nil,                              // id
-			&run.RunId,                       // name
-			utils.NilIfZero(run.Project),     // project
-			utils.NilIfZero(run.Entity),      // entity
-			utils.NilIfZero(run.RunGroup),    // groupName
-			nil,                              // description
-			utils.NilIfZero(run.DisplayName), // displayName
-			utils.NilIfZero(run.Notes),       // notes
-			utils.NilIfZero(commit),          // commit
-			&config,                          // config
-			utils.NilIfZero(run.Host),        // host
-			nil,                              // debug
-			utils.NilIfZero(program),         // program
-			utils.NilIfZero(repo),            // repo
-			utils.NilIfZero(run.JobType),     // jobType
-			nil,                              // state
-			utils.NilIfZero(run.SweepId),     // sweep
-			tags,                             // tags []string,
-			nil,                              // summaryMetrics
-		)
+		var err error
+		if isSecondary(s.settings) {
+			// only the primary process of a shared-mode run creates
+			// and updates the run, so secondaries just look it up
+			err = s.readSharedRun(ctx, run)
+		} else {
+			err = s.upsertRun(ctx, run)
+		}
+		if err != nil && s.mirror != nil {
+			// the run's records are pointless to the mirror without the run
+			s.mirror.fail(err)
+			return
+		}
 		if err != nil {
-			err = fmt.Errorf("failed to upsert bucket: %s", err)
 			s.logger.Error("sender: sendRun:", "error", err)
 			// TODO(run update): handle error communication back to the client
-			fmt.Println("ERROR: failed to upsert bucket", err.Error())
+			fmt.Println("ERROR:", err.Error())
 			// TODO(sync): make this more robust in case of a failed UpsertBucket request.
 			//  Need to inform the sync service that this ops failed.
 			if record.GetControl().GetReqResp() || record.GetControl().GetMailboxSlot() != "" {
 				s.respond(record,
-					&service.RunUpdateResult{
-						Error: &service.ErrorInfo{
-							Message: err.Error(),
-							Code:    service.ErrorInfo_COMMUNICATION,
-						},
-					},
+					&service.RunUpdateResult{Error: backendErrorInfo(err)},
 				)
 			}
 			return
 		}
 
-		bucket := data.GetUpsertBucket().GetBucket()
-		project := bucket.GetProject()
-		entity := project.GetEntity()
-		s.RunRecord.StorageId = bucket.GetId()
-		// s.RunRecord.RunId = bucket.GetName()
-		s.RunRecord.DisplayName = utils.ZeroIfNil(bucket.GetDisplayName())
-		s.RunRecord.Project = project.GetName()
-		s.RunRecord.Entity = entity.GetName()
-		s.RunRecord.SweepId = utils.ZeroIfNil(bucket.GetSweepName())
+		s.fwdRunUpdated()
+		s.fetchProjectSettings()
+	} else if s.exporter != nil {
+		// an offline run's config is only needed for its exported file
+		s.runConfig.ApplyChangeRecord(run.Config,
+			func(err error) {
+				s.logger.CaptureError("Error updating run config", err)
+			})
 	}
 
+	s.setExportedRun(run)
+	s.announceRunURL(run)
+
 	if record.GetControl().GetReqResp() || record.GetControl().GetMailboxSlot() != "" {
 		runResult := s.RunRecord
 		if runResult == nil {
@@ -774,21 +1521,347 @@ func (s *Sender) sendRun(record *service.Record, run *service.RunRecord) {
 	}
 }
 
-// sendHistory sends a history record to the file stream,
-// which will then send it to the server
-func (s *Sender) sendHistory(record *service.HistoryRecord) {
+// announceRunURL announces where the run can be viewed, the first time
+// the run is created on the server or, if it's offline, starts.
+func (s *Sender) announceRunURL(run *service.RunRecord) {
+	if s.mirror != nil {
+		return
+	}
+	if s.RunRecord != nil {
+		run = s.RunRecord
+	}
+
+	info := runurl.Info{
+		Entity:  run.GetEntity(),
+		Project: run.GetProject(),
+		RunID:   run.GetRunId(),
+	}
+	if info.Entity == "" {
+		info.Entity = s.settings.GetEntity().GetValue()
+	}
+	if info.Project == "" {
+		info.Project = s.settings.GetProject().GetValue()
+	}
+	if s.graphqlClient != nil {
+		url := runurl.URL(
+			s.settings.GetBaseUrl().GetValue(),
+			info.Entity,
+			info.Project,
+			info.RunID,
+		)
+		info.URL = &url
+	}
+
+	s.runURL.Announce(info)
+}
+
+// setExportedRun tells the exporter which run to export files for.
+//
+// The run's entity and project are as the server resolved them, and as
+// configured otherwise.
+func (s *Sender) setExportedRun(run *service.RunRecord) {
+	if s.exporter == nil {
+		return
+	}
+	if s.RunRecord != nil {
+		run = s.RunRecord
+	}
+
+	entity := run.GetEntity()
+	if entity == "" {
+		entity = s.settings.GetEntity().GetValue()
+	}
+	project := run.GetProject()
+	if project == "" {
+		project = s.settings.GetProject().GetValue()
+	}
+	s.exporter.SetRun(entity, project, run.GetRunId())
+}
+
+// exportOutputFile exports the run's console output.
+//
+// Unlike other files, the output is streamed rather than uploaded, so it's
+// exported once it's complete.
+func (s *Sender) exportOutputFile() {
+	if s.exporter == nil {
+		return
+	}
+
+	outputFile := filepath.Join(s.settings.GetFilesDir().GetValue(), s.outputFileName)
+	if _, err := os.Stat(outputFile); err != nil {
+		return
+	}
+	s.exporter.ExportRunFile(s.outputFileName, outputFile)
+}
+
+// uploadOutputFile uploads the console output of a labeled writer.
+//
+// The output of the primary is streamed into the run's output.log, but
+// that of each other rank is only complete in its own file, which is
+// uploaded once the run's output is flushed.
+func (s *Sender) uploadOutputFile() {
+	if s.outputFileName == OutputFileName || s.mirror != nil {
+		return
+	}
+
+	outputFile := filepath.Join(s.settings.GetFilesDir().GetValue(), s.outputFileName)
+	if _, err := os.Stat(outputFile); err != nil {
+		return
+	}
+
+	s.fwdRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{
+					{
+						Path: s.outputFileName,
+						Type: service.FilesItem_WANDB,
+					},
+				},
+			},
+		},
+	})
+}
+
+// addCoreUsage adds wandb-core's peak resource usage during the run to
+// the run's config.
+func (s *Sender) addCoreUsage() {
+	if s.coreUsageOrNil == nil {
+		return
+	}
+
+	s.coreUsageOrNil.Sample()
+	s.runConfig.SetInternal("nexus", s.coreUsageOrNil.Peaks())
+}
+
+// upsertRun creates or updates the run on the server.
+func (s *Sender) upsertRun(ctx context.Context, run *service.RunRecord) error {
+	acked := s.dirty.Pushing(configState, telemetryState)
+	config, _ := s.serializeConfig(runconfig.FormatJson)
+
+	var tags []string
+	tags = append(tags, run.Tags...)
+
+	var commit, repo string
+	git := run.GetGit()
+	if git != nil {
+		commit = git.GetCommit()
+		repo = git.GetRemoteUrl()
+	}
+
+	program := settings.From(s.settings).GetProgram()
+
+	data, err := gql.UpsertBucket(
+		ctx,                              // ctx
+		s.graphqlClient,                  // client
+		nil,                              // id
+		&run.RunId,                       // name
+		utils.NilIfZero(run.Project),     // project
+		utils.NilIfZero(run.Entity),      // entity
+		utils.NilIfZero(run.RunGroup),    // groupName
+		nil,                              // description
+		utils.NilIfZero(run.DisplayName), // displayName
+		utils.NilIfZero(run.Notes),       // notes
+		utils.NilIfZero(commit),          // commit
+		&config,                          // config
+		utils.NilIfZero(run.Host),        // host
+		nil,                              // debug
+		utils.NilIfZero(program),         // program
+		utils.NilIfZero(repo),            // repo
+		utils.NilIfZero(run.JobType),     // jobType
+		nil,                              // state
+		utils.NilIfZero(run.SweepId),     // sweep
+		tags,                             // tags []string,
+		nil,                              // summaryMetrics
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert bucket: %w", err)
+	}
+	acked()
+
+	bucket := data.GetUpsertBucket().GetBucket()
+	project := bucket.GetProject()
+	entity := project.GetEntity()
+	s.RunRecord.StorageId = bucket.GetId()
+	// s.RunRecord.RunId = bucket.GetName()
+	if displayName := bucket.GetDisplayName(); displayName != nil {
+		s.RunRecord.DisplayName = *displayName
+	}
+	s.RunRecord.Project = project.GetName()
+	s.RunRecord.Entity = entity.GetName()
+	s.RunRecord.SweepId = utils.ZeroIfNil(bucket.GetSweepName())
+
+	// like the Python sender, jobs aren't made for sweep runs, since their
+	// executable isn't consistent
+	if s.RunRecord.SweepId != "" && s.jobBuilder != nil {
+		s.jobBuilder.Disable = true
+	}
+
+	// the server may normalize these, so take them from the response
+	if groupName := bucket.GetGroupName(); groupName != nil {
+		s.RunRecord.RunGroup = *groupName
+	}
+	if jobType := bucket.GetJobType(); jobType != nil {
+		s.RunRecord.JobType = *jobType
+	}
+	if host := bucket.GetHost(); host != nil {
+		s.RunRecord.Host = *host
+	}
+	return nil
+}
+
+// readSharedRun looks up the run that a secondary writer of a shared-mode
+// run writes to.
+//
+// The run must have been created by the primary process.
+func (s *Sender) readSharedRun(ctx context.Context, run *service.RunRecord) error {
+	data, err := gql.RunResumeStatus(
+		ctx,
+		s.graphqlClient,
+		utils.NilIfZero(run.Project),
+		utils.NilIfZero(run.Entity),
+		run.RunId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to read shared run: %w", err)
+	}
+
+	model := data.GetModel()
+	bucket := model.GetBucket()
+	if bucket == nil {
+		return fmt.Errorf(
+			"failed to read shared run: run %s not found, it must be started by the primary process",
+			run.RunId,
+		)
+	}
+
+	s.RunRecord.StorageId = bucket.GetId()
+	s.RunRecord.DisplayName = utils.ZeroIfNil(bucket.GetDisplayName())
+	s.RunRecord.Project = model.GetName()
+	s.RunRecord.Entity = model.Entity.GetName()
+	return nil
+}
+
+// sendHistory sends a history record to the file stream,
+// which will then send it to the server
+//
+// The summary derived from a row comes in a record after it, and goes
+// through the same filestream, so it's never sent before the row.
+func (s *Sender) sendHistory(record *service.HistoryRecord) {
 	if s.fileStream == nil {
 		return
 	}
 
-	s.fileStream.StreamUpdate(&fs.HistoryUpdate{Record: record})
+	s.fileStream.StreamUpdate(&fs.HistoryUpdate{
+		Record:  record,
+		OnAcked: s.latency.onAcked(record),
+	})
 }
 
+// streamSummary streams the run's summary, unless it's the same as the
+// summary last streamed.
+//
+// Runs often set the same summary values every step, which would
+// otherwise cost a backend write each time.
 func (s *Sender) streamSummary() {
-	if s.fileStream == nil {
+	if s.fileStream == nil || s.secondary {
 		return
 	}
 
+	s.updateRuntime()
+	hash, err := s.summaryHash()
+	if err != nil {
+		s.logger.CaptureError("Error serializing run summary", err)
+		return
+	}
+	if hash == s.lastSummaryHash {
+		s.unchangedSummaries++
+		return
+	}
+
+	s.streamSummaryWithHash(hash)
+}
+
+// streamFinalSummary streams the run's summary as it exits, unless the run
+// never had a summary or it's unchanged since its last acknowledged push.
+func (s *Sender) streamFinalSummary() {
+	s.summaryDebouncer.UnsetNeedsDebounce()
+	if s.unchangedSummaries > 0 {
+		s.logger.Info(
+			"sender: skipped unchanged summary updates",
+			"count", s.unchangedSummaries,
+		)
+	}
+
+	if s.fileStream == nil || s.secondary || len(s.runSummary.Tree()) == 0 {
+		return
+	}
+	if !s.dirty.Dirty(summaryState) {
+		s.logger.Info("sender: summary unchanged since pushed, not pushing it again")
+		return
+	}
+
+	// the summary is split so that no line is too large to send
+	s.updateRuntime()
+	acked := s.dirty.Pushing(summaryState)
+	parts, omitted, err := splitSummary(
+		s.runSummary.Tree(),
+		&s.userSummary,
+		fs.SummaryLineBytes(
+			settings.From(s.settings).GetFileStreamMaxRequestBytes()),
+		s.finalSummaryDeadline(),
+	)
+	if err != nil {
+		s.logger.CaptureError("Error serializing run summary", err)
+		return
+	}
+
+	if len(parts) == 1 && len(omitted) == 0 {
+		// a summary that fits in a line is sent whole, like while running
+		update, err := s.runSummary.Flatten()
+		if err != nil {
+			s.logger.CaptureError("Error flattening run summary", err)
+			return
+		}
+		s.fileStream.StreamUpdate(&fs.SummaryUpdate{
+			Record:  &service.SummaryRecord{Update: update},
+			OnAcked: acked,
+		})
+		return
+	}
+
+	if len(omitted) > 0 {
+		s.omittedSummaryKeys = omitted
+		s.logger.Warn(
+			"sender: keys omitted from the final summary",
+			"count", len(omitted),
+		)
+	}
+	s.logger.Info("sender: final summary split into parts", "parts", len(parts))
+	for i, part := range parts {
+		update := &fs.SummaryUpdate{Record: part, Part: true}
+		if i == len(parts)-1 {
+			update.OnAcked = acked
+		}
+		s.fileStream.StreamUpdate(update)
+	}
+}
+
+// finalSummaryDeadline returns when the final summary stops including
+// values derived from the run's history, or zero if it doesn't.
+//
+// The summary takes at most half of the time left before the exit times
+// out, leaving the rest to the uploads after it.
+func (s *Sender) finalSummaryDeadline() time.Time {
+	if s.exitDeadline.IsZero() {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Until(s.exitDeadline) / 2)
+}
+
+// streamSummaryWithHash streams the run's summary, whose hash is given.
+func (s *Sender) streamSummaryWithHash(hash [16]byte) {
+	acked := s.dirty.Pushing(summaryState)
 	update, err := s.runSummary.Flatten()
 	if err != nil {
 		s.logger.CaptureError("Error flattening run summary", err)
@@ -796,11 +1869,32 @@ func (s *Sender) streamSummary() {
 	}
 
 	s.fileStream.StreamUpdate(&fs.SummaryUpdate{
-		Record: &service.SummaryRecord{Update: update},
+		Record:  &service.SummaryRecord{Update: update},
+		OnAcked: acked,
 	})
+	s.lastSummaryHash = hash
+}
+
+// summaryHash returns a hash of the run's summary.
+func (s *Sender) summaryHash() ([16]byte, error) {
+	var hash [16]byte
+
+	serialized, err := s.runSummary.Serialize()
+	if err != nil {
+		return hash, err
+	}
+
+	hasher := fnv.New128a()
+	_, _ = hasher.Write(serialized)
+	hasher.Sum(hash[:0])
+	return hash, nil
 }
 
 func (s *Sender) sendSummary(_ *service.Record, summary *service.SummaryRecord) {
+	s.userSummary.Update(summary)
+	if !onlyRuntime(summary) {
+		s.dirty.Changed(summaryState)
+	}
 
 	// TODO(network): buffer summary sending for network efficiency until we can send only updates
 	s.runSummary.ApplyChangeRecord(
@@ -814,7 +1908,7 @@ func (s *Sender) sendSummary(_ *service.Record, summary *service.SummaryRecord)
 }
 
 func (s *Sender) upsertConfig() {
-	if s.graphqlClient == nil {
+	if s.graphqlClient == nil || s.secondary {
 		return
 	}
 	if s.RunRecord == nil {
@@ -822,6 +1916,8 @@ func (s *Sender) upsertConfig() {
 		return
 	}
 
+	s.addRunWarnings()
+	acked := s.dirty.Pushing(configState, telemetryState)
 	config, err := s.serializeConfig(runconfig.FormatJson)
 	if err != nil {
 		s.logger.Error("sender: upsertConfig: failed to serialize config", "error", err)
@@ -831,7 +1927,7 @@ func (s *Sender) upsertConfig() {
 		return
 	}
 
-	ctx := context.WithValue(s.ctx, clients.CtxRetryPolicyKey, clients.UpsertBucketRetryPolicy)
+	ctx := api.WithErrorClassOverrides(s.ctx, upsertBucketErrorClasses)
 	_, err = gql.UpsertBucket(
 		ctx,                                  // ctx
 		s.graphqlClient,                      // client
@@ -857,7 +1953,9 @@ func (s *Sender) upsertConfig() {
 	)
 	if err != nil {
 		s.logger.Error("sender: sendConfig:", "error", err)
+		return
 	}
+	acked()
 }
 
 func (s *Sender) uploadSummaryFile() {
@@ -865,6 +1963,11 @@ func (s *Sender) uploadSummaryFile() {
 		// if sync is enabled, we don't need to do all this
 		return
 	}
+	if s.secondary || s.mirror != nil {
+		// the summary is uploaded by the primary process, and mirrored
+		// from the primary's files
+		return
+	}
 
 	summary, err := s.runSummary.Serialize()
 	if err != nil {
@@ -872,7 +1975,11 @@ func (s *Sender) uploadSummaryFile() {
 		return
 	}
 	summaryFile := filepath.Join(s.settings.GetFilesDir().GetValue(), SummaryFileName)
-	if err := os.WriteFile(summaryFile, summary, 0644); err != nil {
+	err = atomicfile.WriteWithHook(
+		summaryFile, summary, 0644,
+		s.faultInjector.AtomicWriteHook(),
+	)
+	if err != nil {
 		s.logger.Error("sender: uploadSummaryFile: failed to write summary file", "error", err)
 		return
 	}
@@ -897,6 +2004,11 @@ func (s *Sender) uploadConfigFile() {
 		// if sync is enabled, we don't need to do all this
 		return
 	}
+	if s.secondary || s.mirror != nil {
+		// the config is uploaded by the primary process, and mirrored
+		// from the primary's files
+		return
+	}
 
 	config, err := s.serializeConfig(runconfig.FormatYaml)
 	if err != nil {
@@ -904,7 +2016,11 @@ func (s *Sender) uploadConfigFile() {
 		return
 	}
 	configFile := filepath.Join(s.settings.GetFilesDir().GetValue(), ConfigFileName)
-	if err := os.WriteFile(configFile, []byte(config), 0644); err != nil {
+	err = atomicfile.WriteWithHook(
+		configFile, []byte(config), 0644,
+		s.faultInjector.AtomicWriteHook(),
+	)
+	if err != nil {
 		s.logger.Error("sender: writeAndSendConfigFile: failed to write config file", "error", err)
 		return
 	}
@@ -932,6 +2048,7 @@ func (s *Sender) sendConfig(_ *service.Record, configRecord *service.ConfigRecor
 			func(err error) {
 				s.logger.CaptureError("Error updating run config", err)
 			})
+		s.dirty.Changed(configState)
 	}
 	s.configDebouncer.SetNeedsDebounce()
 }
@@ -968,19 +2085,57 @@ func (s *Sender) sendOutputRaw(record *service.Record, outputRaw *service.Output
 	// - handle caching multiple (non-new lines) and sending them in one chunk
 	// - handle lines longer than ~60_000 characters
 
+	if s.consoleLines != nil {
+		for _, line := range s.consoleLines.Add(outputRaw) {
+			s.writeOutputLines(s.consoleDedup.Add(line))
+		}
+		return
+	}
+
 	// ignore empty "new lines"
 	if outputRaw.Line == "\n" {
 		return
 	}
 
-	outputFile := filepath.Join(s.settings.GetFilesDir().GetValue(), OutputFileName)
-	// append line to file
-	if err := writeOutputToFile(outputFile, outputRaw.Line); err != nil {
-		s.logger.Error("sender: sendOutput: failed to write to output file", "error", err)
+	s.writeOutputLines(s.consoleDedup.Add(outputRaw))
+}
+
+// flushOutput writes the console lines held back, as the run exits.
+func (s *Sender) flushOutput() {
+	if s.consoleLines != nil {
+		for _, line := range s.consoleLines.Flush() {
+			s.writeOutputLines(s.consoleDedup.Add(line))
+		}
 	}
+	s.writeOutputLines(s.consoleDedup.Flush())
+}
 
-	if s.fileStream != nil {
-		s.fileStream.StreamUpdate(&fs.LogsUpdate{Record: outputRaw})
+// writeOutputLines appends the lines to the output file and streams them.
+func (s *Sender) writeOutputLines(lines []*service.OutputRawRecord) {
+	if len(lines) == 0 {
+		return
+	}
+
+	outputFile := filepath.Join(s.settings.GetFilesDir().GetValue(), s.outputFileName)
+	for _, line := range lines {
+		text := line.Line
+		if s.consoleLines != nil {
+			text = consolelines.Stamped(line)
+		}
+
+		// append line to file, unless the primary sender does
+		if s.mirror == nil {
+			if err := writeOutputToFile(outputFile, text); err != nil {
+				s.logger.Error("sender: sendOutput: failed to write to output file", "error", err)
+			}
+		}
+
+		if s.fileStream != nil {
+			s.fileStream.StreamUpdate(&fs.LogsUpdate{
+				Record:      line,
+				Timestamped: s.consoleLines != nil,
+			})
+		}
 	}
 }
 
@@ -1020,15 +2175,29 @@ func (s *Sender) sendAlert(_ *service.Record, alert *service.AlertRecord) {
 func (s *Sender) sendExit(record *service.Record, exitRecord *service.RunExitRecord) {
 	// response is done by respond() and called when defer state machine is complete
 	s.exitRecord = record
+	if s.exitTimeout > 0 {
+		s.exitDeadline = time.Now().Add(s.exitTimeout)
+	}
 
-	if s.fileStream != nil {
+	// only the primary process marks a shared-mode run as finished, and a
+	// discarded run isn't finished at all, nor is a run synced after it
+	// was finished on the server or from a modified log
+	skipped := s.discarded.Load() || s.conflicted.Load() ||
+		s.syncService.SkipsData()
+	if s.fileStream != nil && !s.secondary && !skipped {
 		s.fileStream.StreamUpdate(&fs.ExitUpdate{Record: exitRecord})
 	}
 
 	// send a defer request to the handler to indicate that the user requested to finish the stream
 	// and the defer state machine can kick in triggering the shutdown process
+	//
+	// Such a run skips to the end, leaving the rest of its data unsent.
+	state := service.DeferRequest_BEGIN
+	if skipped {
+		state = service.DeferRequest_END
+	}
 	request := &service.Request{RequestType: &service.Request_Defer{
-		Defer: &service.DeferRequest{State: service.DeferRequest_BEGIN}},
+		Defer: &service.DeferRequest{State: state}},
 	}
 	if record.Control == nil {
 		record.Control = &service.Control{AlwaysSend: true}
@@ -1059,11 +2228,18 @@ func (s *Sender) sendMetric(record *service.Record, metric *service.MetricRecord
 
 	s.encodeMetricHints(record, metric)
 	s.updateConfigPrivate()
+	s.dirty.Changed(configState)
 	s.configDebouncer.SetNeedsDebounce()
 }
 
 // sendFiles uploads files according to a FilesRecord
 func (s *Sender) sendFiles(_ *service.Record, filesRecord *service.FilesRecord) {
+	// files left unfinished when the exit timed out are only recorded for
+	// syncing the run later
+	if s.unfinished != nil {
+		return
+	}
+
 	if s.runfilesUploader == nil {
 		s.logger.CaptureWarn(
 			"sender: tried to sendFiles, but runfiles uploader is nil",
@@ -1074,63 +2250,206 @@ func (s *Sender) sendFiles(_ *service.Record, filesRecord *service.FilesRecord)
 	s.runfilesUploader.Process(filesRecord)
 }
 
-func (s *Sender) sendArtifact(_ *service.Record, msg *service.ArtifactRecord) {
-	saver := artifacts.NewArtifactSaver(
-		s.ctx, s.graphqlClient, s.fileTransferManager, msg, 0, "",
-	)
-	artifactID, err := saver.Save(s.fwdChan)
-	if err != nil {
-		err = fmt.Errorf("sender: sendArtifact: failed to log artifact ID: %s; error: %s", artifactID, err)
-		s.logger.Error("sender: sendArtifact:", "error", err)
+func (s *Sender) sendArtifact(record *service.Record, msg *service.ArtifactRecord) {
+	if s.exitRecord != nil {
+		s.rejectArtifact(msg)
 		return
 	}
+
+	// an offline run's artifacts are only exported, and uploaded when the
+	// run is synced
+	if s.graphqlClient == nil {
+		s.uploads.Go(func() { s.exporter.ExportArtifact(msg) })
+		return
+	}
+
+	cancellation := s.startArtifact(msg)
+	s.uploads.Go(func() {
+		s.exporter.ExportArtifact(msg)
+		saver := artifacts.NewArtifactSaver(
+			s.uploadsCtx, s.graphqlClient, s.fileTransferManager, msg, 0, "",
+		)
+		saver.Cancellation = cancellation
+		_, err := s.saveArtifact(record, &saver)
+		if err != nil {
+			err = fmt.Errorf("sender: sendArtifact: failed to log artifact %s: %s", msg.GetName(), err)
+			s.logger.Error("sender: sendArtifact:", "error", err)
+			return
+		}
+	})
 }
 
 func (s *Sender) sendRequestLogArtifact(record *service.Record, msg *service.LogArtifactRequest) {
-	var response service.LogArtifactResponse
-	saver := artifacts.NewArtifactSaver(
-		s.ctx, s.graphqlClient, s.fileTransferManager, msg.Artifact, msg.HistoryStep, msg.StagingDir,
-	)
-	artifactID, err := saver.Save(s.fwdChan)
-	if err != nil {
-		response.ErrorMessage = err.Error()
-	} else {
-		response.ArtifactId = artifactID
+	if s.exitRecord != nil {
+		err := s.rejectArtifact(msg.GetArtifact())
+		s.respond(record,
+			&service.Response{
+				ResponseType: &service.Response_LogArtifactResponse{
+					LogArtifactResponse: &service.LogArtifactResponse{
+						ErrorMessage: err.Error(),
+					},
+				},
+			})
+		return
 	}
 
-	s.jobBuilder.HandleLogArtifactResult(&response, msg.Artifact)
-	s.respond(record,
-		&service.Response{
-			ResponseType: &service.Response_LogArtifactResponse{
-				LogArtifactResponse: &response,
-			},
-		})
+	cancellation := s.startArtifact(msg.GetArtifact())
+	s.uploads.Go(func() {
+		s.exporter.ExportArtifact(msg.Artifact)
+		var response service.LogArtifactResponse
+		saver := artifacts.NewArtifactSaver(
+			s.uploadsCtx, s.graphqlClient, s.fileTransferManager, msg.Artifact, msg.HistoryStep, msg.StagingDir,
+		)
+		saver.Cancellation = cancellation
+		saved, err := s.saveArtifact(record, &saver)
+		if err != nil {
+			response.ErrorMessage = err.Error()
+		} else {
+			response.ArtifactId = saved.ID
+		}
+
+		s.jobBuilder.HandleLogArtifactResult(&response, msg.Artifact)
+		s.respond(record,
+			&service.Response{
+				ResponseType: &service.Response_LogArtifactResponse{
+					LogArtifactResponse: &response,
+				},
+			})
+	})
+}
+
+// startArtifact notes that an artifact the user logged is to be saved.
+//
+// It's pending until saveArtifact finishes, and the run's exit waits for
+// it before the run is marked as finished, which the server rejects the
+// artifact after.
+//
+// Returns the cancellation with which to save it.
+func (s *Sender) startArtifact(artifact *service.ArtifactRecord) *artifacts.Cancellation {
+	s.deferProgress.ArtifactStarted()
+	return s.artifactWaits.Start(artifact.GetClientId())
+}
+
+// saveArtifact saves an artifact started by startArtifact, and reports
+// how it went.
+func (s *Sender) saveArtifact(
+	record *service.Record,
+	saver *artifacts.ArtifactSaver,
+) (artifacts.SavedArtifact, error) {
+	defer s.deferProgress.ArtifactFinished()
+
+	delayCtx, done := saver.Cancellation.Context(s.uploadsCtx)
+	s.faultInjector.DelayArtifact(delayCtx)
+	done()
+	saver.Progress = s.uploadProgress(record, saver.Artifact.GetName())
+	saver.URLBatchSize = settings.From(s.settings).GetUploadURLBatchSize()
+	var draftID string
+	saver.OnDraft = func(artifactID string) {
+		draftID = artifactID
+		s.artifactDrafts.add(artifactID, record.GetNum())
+	}
+	saved, err := saver.Save(s.fwdChan)
+	s.artifactDrafts.remove(draftID)
+	s.artifactWaits.Finish(saver.Artifact.GetClientId(), saved, err)
+	s.runOutputs.ArtifactSaved(saver.Artifact, saved, err)
+	return saved, err
+}
+
+// rejectArtifact fails an artifact the user logged once the run started
+// to exit.
+//
+// The exit doesn't wait for such an artifact, so it could only be saved
+// once the run is finished, which the server rejects.
+func (s *Sender) rejectArtifact(artifact *service.ArtifactRecord) error {
+	err := fmt.Errorf(
+		"the run already started to exit, so the artifact %q wasn't logged;"+
+			" log artifacts before finishing the run",
+		artifact.GetName(),
+	)
+	s.logger.Warn(
+		"sender: rejected artifact logged after the exit",
+		"artifact", artifact.GetName(),
+	)
+	s.artifactWaits.Start(artifact.GetClientId())
+	s.artifactWaits.Finish(artifact.GetClientId(), artifacts.SavedArtifact{}, err)
+	s.runOutputs.ArtifactSaved(artifact, artifacts.SavedArtifact{}, err)
+	return err
+}
+
+// sendRequestArtifactWait responds once the artifact is saved or fails to
+// be, or once the request's timeout passes.
+//
+// It waits off the sender's loop and outside the upload lane, since the
+// artifact is saved in the lane.
+func (s *Sender) sendRequestArtifactWait(record *service.Record, msg *service.ArtifactWaitRequest) {
+	go func() {
+		ctx := s.ctx
+		if timeout := msg.GetTimeoutSeconds(); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx,
+				time.Duration(timeout*float64(time.Second)))
+			defer cancel()
+		}
+
+		s.respond(record,
+			&service.Response{
+				ResponseType: &service.Response_ArtifactWaitResponse{
+					ArtifactWaitResponse: s.artifactWaits.Wait(ctx, msg.GetClientId()),
+				},
+			})
+	}()
+}
+
+// sendRequestArtifactCancel cancels saving an artifact, and responds once
+// it stopped being saved.
+//
+// Like waiting for the artifact, it happens off the sender's loop and
+// outside the upload lane.
+func (s *Sender) sendRequestArtifactCancel(record *service.Record, msg *service.ArtifactCancelRequest) {
+	go func() {
+		response := s.artifactWaits.Cancel(s.ctx, msg.GetClientId())
+		if response.GetState() == service.ArtifactCancelResponse_CANCELLED {
+			s.logger.Info(
+				"sender: cancelled artifact",
+				"clientID", msg.GetClientId(),
+			)
+		}
+
+		s.respond(record,
+			&service.Response{
+				ResponseType: &service.Response_ArtifactCancelResponse{
+					ArtifactCancelResponse: response,
+				},
+			})
+	}()
 }
 
 func (s *Sender) sendRequestDownloadArtifact(record *service.Record, msg *service.DownloadArtifactRequest) {
 	// TODO: this should be handled by a separate service startup mechanism
 	s.fileTransferManager.Start()
 
-	var response service.DownloadArtifactResponse
-	downloader := artifacts.NewArtifactDownloader(
-		s.ctx, s.graphqlClient, s.fileTransferManager, msg.ArtifactId, msg.DownloadRoot,
-		msg.AllowMissingReferences, msg.SkipCache, msg.PathPrefix)
-	err := downloader.Download()
-	if err != nil {
-		s.logger.CaptureError("senderError: downloadArtifact: failed to download artifact: %v", err)
-		response.ErrorMessage = err.Error()
-	}
+	s.uploads.Go(func() {
+		var response service.DownloadArtifactResponse
+		downloader := artifacts.NewArtifactDownloader(
+			s.ctx, s.graphqlClient, s.fileTransferManager, msg.ArtifactId, msg.DownloadRoot,
+			msg.AllowMissingReferences, msg.SkipCache, msg.PathPrefix)
+		err := downloader.Download()
+		if err != nil {
+			s.logger.CaptureError("senderError: downloadArtifact: failed to download artifact: %v", err)
+			response.ErrorMessage = err.Error()
+		}
 
-	s.respond(record,
-		&service.Response{
-			ResponseType: &service.Response_DownloadArtifactResponse{
-				DownloadArtifactResponse: &response,
-			},
-		})
+		s.respond(record,
+			&service.Response{
+				ResponseType: &service.Response_DownloadArtifactResponse{
+					DownloadArtifactResponse: &response,
+				},
+			})
+	})
 }
 
 func (s *Sender) sendRequestSync(record *service.Record, request *service.SyncRequest) {
-
+	s.allowModifiedLog = request.GetAllowModifiedLog()
 	s.syncService = NewSyncService(s.ctx,
 		WithSyncServiceLogger(s.logger),
 		WithSyncServiceSenderFunc(s.sendRecord),
@@ -1143,6 +2462,8 @@ func (s *Sender) sendRequestSync(record *service.Record, request *service.SyncRe
 					Message: err.Error(),
 					Code:    service.ErrorInfo_UNKNOWN,
 				}
+			} else {
+				s.markSynced()
 			}
 
 			var url string
@@ -1189,47 +2510,51 @@ func (s *Sender) sendRequestStopStatus(record *service.Record, _ *service.StopSt
 	project := s.RunRecord.GetProject()
 	runId := s.RunRecord.GetRunId()
 
-	var stopResponse *service.StopStatusResponse
-
 	// if any of the entity, project or runId is empty, we can't make the request
 	if entity == "" || project == "" || runId == "" {
 		s.logger.Error("sender: sendStopStatus: entity, project, runId are empty")
-		stopResponse = &service.StopStatusResponse{
-			RunShouldStop: false,
-		}
-	} else {
-		response, err := gql.RunStoppedStatus(s.ctx, s.graphqlClient, &entity, &project, runId)
-		switch {
-		case err != nil:
-			// if there is an error, we don't know if the run should stop
-			err = fmt.Errorf("sender: sendStopStatus: failed to get run stopped status: %s", err)
-			s.logger.CaptureError("sender received error", err)
-			stopResponse = &service.StopStatusResponse{
-				RunShouldStop: false,
-			}
-		case response == nil || response.GetProject() == nil || response.GetProject().GetRun() == nil:
-			// if there is no response, we don't know if the run should stop
-			stopResponse = &service.StopStatusResponse{
-				RunShouldStop: false,
-			}
-		default:
-			stopped := utils.ZeroIfNil(response.GetProject().GetRun().GetStopped())
-			stopResponse = &service.StopStatusResponse{
-				RunShouldStop: stopped,
-			}
-		}
+		s.respond(record, stopStatusResponse(false))
+		return
 	}
 
-	s.respond(record,
-		&service.Response{
-			ResponseType: &service.Response_StopStatusResponse{
-				StopStatusResponse: stopResponse,
-			},
+	// polls from every client share the query
+	s.polls.Poll(record,
+		func() *service.Response {
+			response, err := gql.RunStoppedStatus(s.ctx, s.graphqlClient, &entity, &project, runId)
+			switch {
+			case err != nil:
+				// if there is an error, we don't know if the run should stop
+				err = fmt.Errorf("sender: sendStopStatus: failed to get run stopped status: %s", err)
+				s.logger.CaptureError("sender received error", err)
+				return stopStatusResponse(false)
+			case response == nil || response.GetProject() == nil || response.GetProject().GetRun() == nil:
+				// if there is no response, we don't know if the run should stop
+				return stopStatusResponse(false)
+			default:
+				return stopStatusResponse(
+					utils.ZeroIfNil(response.GetProject().GetRun().GetStopped()))
+			}
 		},
+		s.respondResponse,
 	)
 }
 
+func stopStatusResponse(runShouldStop bool) *service.Response {
+	return &service.Response{
+		ResponseType: &service.Response_StopStatusResponse{
+			StopStatusResponse: &service.StopStatusResponse{
+				RunShouldStop: runShouldStop,
+			},
+		},
+	}
+}
+
 func (s *Sender) sendRequestSenderRead(_ *service.Record, _ *service.SenderReadRequest) {
+	if s.store == nil && s.settings.GetXSync().GetValue() {
+		s.checkLogGap()
+		s.checkLogDigest()
+		s.checkLogMarkers()
+	}
 	if s.store == nil {
 		store := NewStore(s.ctx, s.settings.GetSyncFile().GetValue(), s.logger)
 		err := store.Open(os.O_RDONLY)
@@ -1250,6 +2575,9 @@ func (s *Sender) sendRequestSenderRead(_ *service.Record, _ *service.SenderReadR
 	//
 	for {
 		record, err := s.store.Read()
+		if record.GetNum() > 0 {
+			s.lastSentNum = record.GetNum()
+		}
 		if s.settings.GetXSync().GetValue() {
 			s.syncService.SyncRecord(record, err)
 		} else if record != nil {
@@ -1265,54 +2593,128 @@ func (s *Sender) sendRequestSenderRead(_ *service.Record, _ *service.SenderReadR
 	}
 }
 
-func (s *Sender) getServerInfo() {
-	if s.graphqlClient == nil {
-		return
+// checkLogGap fails the sync if the transaction log is missing records,
+// because the run's writer stopped saving them.
+func (s *Sender) checkLogGap() {
+	path := s.settings.GetSyncFile().GetValue()
+	gap, err := readLogGap(path)
+	switch {
+	case err != nil:
+		s.logger.CaptureError("sender: checkLogGap: failed to read the log's gap", err)
+	case gap != nil:
+		s.logger.Warn(
+			"sender: syncing an incomplete transaction log",
+			"path", path,
+			"firstMissing", gap.FirstMissing,
+			"noSpace", gap.NoSpace,
+		)
+		s.syncService.SetIncomplete(gap.Err())
 	}
+}
 
-	data, err := gql.ServerInfo(s.ctx, s.graphqlClient)
-	if err != nil {
-		err = fmt.Errorf("sender: getServerInfo: failed to get server info: %s", err)
-		s.logger.CaptureError("sender received error", err)
-		return
+// checkLogDigest refuses to sync a transaction log that changed after its
+// run finished, unless the sync allows it.
+//
+// Logs without a recorded digest, like those of runs that didn't finish,
+// are synced as they are.
+func (s *Sender) checkLogDigest() {
+	path := s.settings.GetSyncFile().GetValue()
+	_, err := transactionlog.VerifyDigest(path)
+	switch {
+	case err == nil:
+	case !errors.Is(err, transactionlog.ErrDigestMismatch):
+		s.logger.CaptureError("sender: checkLogDigest: can't verify the log", err)
+	case s.allowModifiedLog:
+		s.logger.Warn(
+			"sender: syncing a modified transaction log",
+			"path", path,
+			"error", err,
+		)
+	default:
+		s.logger.Warn(
+			"sender: not syncing a modified transaction log",
+			"path", path,
+			"error", err,
+		)
+		s.syncService.SetModified(fmt.Errorf(
+			"%s changed after the run finished, so it isn't synced"+
+				" (use --allow-modified-log to sync it anyway): %w",
+			path,
+			err,
+		))
 	}
-	s.serverInfo = data.GetServerInfo()
-
-	s.logger.Info("sender: getServerInfo: got server info", "serverInfo", s.serverInfo)
 }
 
 // TODO: this function is for deciding which GraphQL query/mutation versions to use
 // func (s *Sender) getServerVersion() string {
-// 	if s.serverInfo == nil {
-// 		return ""
-// 	}
-// 	return s.serverInfo.GetLatestLocalVersionInfo().GetVersionOnThisInstanceString()
+// 	return s.serverInfo.Get().GetLatestLocalVersionInfo().GetVersionOnThisInstanceString()
 // }
 
 func (s *Sender) sendRequestServerInfo(record *service.Record, _ *service.ServerInfoRequest) {
-	if !s.settings.GetXOffline().GetValue() && s.serverInfo == nil {
-		s.getServerInfo()
-	}
+	s.respond(record,
+		&service.Response{
+			ResponseType: &service.Response_ServerInfoResponse{
+				ServerInfoResponse: s.serverInfoResponse(),
+			},
+		},
+	)
+}
 
-	localInfo := &service.LocalInfo{}
-	if s.serverInfo != nil && s.serverInfo.GetLatestLocalVersionInfo() != nil {
-		localInfo = &service.LocalInfo{
-			Version:   s.serverInfo.GetLatestLocalVersionInfo().GetLatestVersionString(),
-			OutOfDate: s.serverInfo.GetLatestLocalVersionInfo().GetOutOfDate(),
-		}
+// sendRequestGetSettings responds with the run's settings and where their
+// values came from.
+func (s *Sender) sendRequestGetSettings(record *service.Record, _ *service.GetSettingsRequest) {
+	effective, err := settings.WithProvenance(s.settings, s.settingsProvenance).Effective()
+	if err != nil {
+		s.logger.CaptureError("sender: failed to list the settings", err)
 	}
-
 	s.respond(record,
 		&service.Response{
-			ResponseType: &service.Response_ServerInfoResponse{
-				ServerInfoResponse: &service.ServerInfoResponse{
-					LocalInfo: localInfo,
+			ResponseType: &service.Response_GetSettingsResponse{
+				GetSettingsResponse: &service.GetSettingsResponse{
+					Settings: effective,
+					DebugLog: s.debugLog,
 				},
 			},
 		},
 	)
 }
 
+// sendRequestCredentialsUpdate replaces the API key used by the clients
+// of the backend, such as the GraphQL client and the filestream.
+//
+// The settings of the stream are left as they are, so the new key is
+// never written anywhere.
+func (s *Sender) sendRequestCredentialsUpdate(request *service.CredentialsUpdateRequest) {
+	if s.backend == nil {
+		s.logger.Warn("sender: sendRequestCredentialsUpdate: no backend, skipping")
+		return
+	}
+	if request.GetApiKey() == "" {
+		s.logger.Warn("sender: sendRequestCredentialsUpdate: empty API key, skipping")
+		return
+	}
+
+	s.backend.UpdateAPIKey(request.GetApiKey())
+	s.logger.Info("sender: sendRequestCredentialsUpdate: updated the API key")
+}
+
+// sendRequestSettingsUpdate applies the settings that can change mid-run.
+//
+// Like credentials updates, the settings of the stream are left as they
+// are.
+func (s *Sender) sendRequestSettingsUpdate(request *service.SettingsUpdateRequest) {
+	if limit := request.GetUploadBytesPerSecond(); limit != nil {
+		s.uploadLimiter.SetBytesPerSecond(limit.GetValue())
+		s.logger.Info("sender: sendRequestSettingsUpdate: updated the upload bandwidth cap",
+			"bytes_per_second", limit.GetValue())
+	}
+	if limit := request.GetFileStreamBytesPerSecond(); limit != nil {
+		s.fileStreamLimiter.SetBytesPerSecond(limit.GetValue())
+		s.logger.Info("sender: sendRequestSettingsUpdate: updated the filestream bandwidth cap",
+			"bytes_per_second", limit.GetValue())
+	}
+}
+
 func (s *Sender) sendRequestJobInput(request *service.JobInputRequest) {
 	if s.jobBuilder == nil {
 		s.logger.Warn("sender: sendJobInput: job builder disabled, skipping")
@@ -2,15 +2,28 @@ package server_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wandb/wandb/core/internal/filetransfer"
 	"github.com/wandb/wandb/core/internal/gqlmock"
 	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/servertest"
 	wbsettings "github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/timer"
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/filestreamtest"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/server"
 	"github.com/wandb/wandb/core/pkg/service"
@@ -44,18 +57,52 @@ const validCreateArtifactResponse = `{
 }`
 
 func makeSender(client graphql.Client, recordChan chan *service.Record, resultChan chan *service.Result) *server.Sender {
-	ctx, cancel := context.WithCancel(context.Background())
-	logger := observability.NewNoOpLogger()
 	settings := wbsettings.From(&service.Settings{
 		RunId: &wrapperspb.StringValue{Value: "run1"},
 	})
-	backend := server.NewBackend(logger, settings)
+	return makeSenderWithSettings(settings, client, recordChan, resultChan)
+}
+
+// makeSenderForBackend returns a sender that talks to the fake backend.
+func makeSenderForBackend(
+	fakeBackend *servertest.FakeBackend,
+	recordChan chan *service.Record,
+	resultChan chan *service.Result,
+) *server.Sender {
+	settings := wbsettings.From(&service.Settings{
+		RunId:   &wrapperspb.StringValue{Value: "run1"},
+		BaseUrl: &wrapperspb.StringValue{Value: fakeBackend.URL()},
+		ApiKey:  &wrapperspb.StringValue{Value: "test-api-key"},
+	})
+	return makeSenderWithSettings(settings, nil, recordChan, resultChan)
+}
+
+// makeSenderWithSettings returns a sender using the GraphQL client, or one
+// that talks to the settings' base URL if the client is nil.
+func makeSenderWithSettings(
+	settings *wbsettings.Settings,
+	client graphql.Client,
+	recordChan chan *service.Record,
+	resultChan chan *service.Result,
+) *server.Sender {
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := observability.NewNoOpLogger()
+	printer := observability.NewPrinter()
+	backend := server.NewBackend(logger, printer, settings)
+	if client == nil {
+		client = server.NewGraphQLClient(backend, settings, &observability.Peeker{}, nil, nil)
+	}
 	fileStream := server.NewFileStream(
-		backend, logger, observability.NewPrinter(), settings, nil)
+		backend, logger, printer, settings, nil, nil, nil, nil, nil, nil, nil)
 	fileTransferManager := server.NewFileTransferManager(
 		filetransfer.NewFileTransferStats(),
 		logger,
 		settings,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	runfilesUploader := server.NewRunfilesUploader(
 		ctx,
@@ -63,7 +110,10 @@ func makeSender(client graphql.Client, recordChan chan *service.Record, resultCh
 		settings,
 		fileStream,
 		fileTransferManager,
+		nil,
 		client,
+		nil,
+		nil,
 	)
 	sender := server.NewSender(
 		ctx,
@@ -86,13 +136,11 @@ func makeSender(client graphql.Client, recordChan chan *service.Record, resultCh
 
 // Verify that project and entity are properly passed through to graphql
 func TestSendRun(t *testing.T) {
-	mockGQL := gqlmock.NewMockClient()
-	mockGQL.StubMatchOnce(
-		gqlmock.WithOpName("UpsertBucket"),
-		validUpsertBucketResponse,
-	)
+	fakeBackend := servertest.NewFakeBackend()
+	defer fakeBackend.Close()
+	fakeBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
 	outChan := make(chan *service.Result, 1)
-	sender := makeSender(mockGQL, make(chan *service.Record, 1), outChan)
+	sender := makeSenderForBackend(fakeBackend, make(chan *service.Record, 1), outChan)
 
 	run := &service.Record{
 		RecordType: &service.Record_Run{
@@ -114,14 +162,106 @@ func TestSendRun(t *testing.T) {
 	}
 
 	sender.SendRecord(run)
+	result := <-outChan
+
+	assert.Equal(t,
+		"FakeProject",
+		result.GetRunResult().GetRun().GetProject())
+	requests := fakeBackend.GraphQLRequests("UpsertBucket")
+	require.Len(t, requests, 1)
+	var upsert struct {
+		Variables map[string]any `json:"variables"`
+	}
+	require.NoError(t, json.Unmarshal(requests[0].Body, &upsert))
+	assert.Equal(t, "testProject", upsert.Variables["project"])
+	assert.Equal(t, "testEntity", upsert.Variables["entity"])
+	assert.Equal(t,
+		[]string{"Basic " + base64.StdEncoding.EncodeToString([]byte("api:test-api-key"))},
+		requests[0].Header["Authorization"])
+}
+
+// Verify that grouping and sweep fields round-trip through the upsert
+func TestSendRun_GroupJobTypeSweepHost(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		`{
+			"upsertBucket": {
+				"bucket": {
+					"sweepName": "sweep1",
+					"groupName": "DDP",
+					"jobType": "train",
+					"host": "node0",
+					"project": {"name": "testProject", "entity": {"name": "testEntity"}}
+				}
+			}
+		}`,
+	)
+	outChan := make(chan *service.Result, 1)
+	sender := makeSender(mockGQL, make(chan *service.Record, 1), outChan)
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				Project:  "testProject",
+				Entity:   "testEntity",
+				RunGroup: "ddp",
+				JobType:  "train",
+				SweepId:  "sweep1",
+				Host:     "node0",
+			}},
+		Control: &service.Control{
+			MailboxSlot: "junk",
+		},
+	})
+	run := (<-outChan).GetRunResult().GetRun()
+
+	requests := mockGQL.AllRequests()
+	assert.Len(t, requests, 2)
+	gqlmock.AssertRequest(t,
+		gqlmock.WithVariables(
+			gqlmock.GQLVar("groupName", gomock.Eq("ddp")),
+			gqlmock.GQLVar("jobType", gomock.Eq("train")),
+			gqlmock.GQLVar("sweep", gomock.Eq("sweep1")),
+			gqlmock.GQLVar("host", gomock.Eq("node0")),
+		),
+		requests[0])
+	assert.Equal(t, "DDP", run.GetRunGroup())
+	assert.Equal(t, "train", run.GetJobType())
+	assert.Equal(t, "sweep1", run.GetSweepId())
+	assert.Equal(t, "node0", run.GetHost())
+}
+
+// The program override is sent instead of the probed program.
+func TestSendRun_ProgramOverride(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(gqlmock.WithOpName("UpsertBucket"), validUpsertBucketResponse)
+	outChan := make(chan *service.Result, 1)
+	settings := wbsettings.From(&service.Settings{
+		RunId:            &wrapperspb.StringValue{Value: "run1"},
+		Program:          &wrapperspb.StringValue{Value: "/usr/bin/entrypoint.sh"},
+		XProgramOverride: &wrapperspb.StringValue{Value: "train.py"},
+	})
+	sender := makeSenderWithSettings(settings, mockGQL, make(chan *service.Record, 1), outChan)
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				Project: "testProject",
+				Entity:  "testEntity",
+				Host:    "node0",
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	})
 	<-outChan
 
 	requests := mockGQL.AllRequests()
-	assert.Len(t, requests, 1)
+	require.Len(t, requests, 2)
 	gqlmock.AssertRequest(t,
 		gqlmock.WithVariables(
-			gqlmock.GQLVar("project", gomock.Eq("testProject")),
-			gqlmock.GQLVar("entity", gomock.Eq("testEntity")),
+			gqlmock.GQLVar("program", gomock.Eq("train.py")),
+			gqlmock.GQLVar("host", gomock.Eq("node0")),
 		),
 		requests[0])
 }
@@ -317,6 +457,7 @@ func TestSendArtifact(t *testing.T) {
 	}
 
 	sender.SendRecord(artifact)
+	sender.Close() // waits for the artifact to be saved
 
 	requests := mockGQL.AllRequests()
 	assert.Len(t, requests, 1)
@@ -326,3 +467,182 @@ func TestSendArtifact(t *testing.T) {
 		),
 		requests[0])
 }
+
+// summarySender is a sender that streams to a fake filestream.
+type summarySender struct {
+	fileStream *filestreamtest.FakeFileStream
+	records    chan *service.Record
+	done       chan struct{}
+}
+
+// startSummarySender starts a sender that debounces summaries by the
+// interval in seconds.
+func startSummarySender(debounceInterval float64) *summarySender {
+	return startTimedSummarySender(debounceInterval, nil)
+}
+
+// startTimedSummarySender starts a sender that debounces summaries by the
+// interval in seconds and keeps the runtime from the run timer current.
+func startTimedSummarySender(
+	debounceInterval float64,
+	runTimer *timer.Timer,
+) *summarySender {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &summarySender{
+		fileStream: filestreamtest.NewFakeFileStream(),
+		records:    make(chan *service.Record, 10),
+		done:       make(chan struct{}),
+	}
+	sender := server.NewSender(
+		ctx,
+		cancel,
+		&server.SenderParams{
+			Logger: observability.NewNoOpLogger(),
+			Settings: &service.Settings{
+				XSummaryDebounceIntervalSeconds: &wrapperspb.DoubleValue{
+					Value: debounceInterval,
+				},
+			},
+			FileStream: s.fileStream,
+			RunSummary: runsummary.New(),
+			FwdChan:    make(chan *service.Record, 10),
+			OutChan:    make(chan *service.Result, 10),
+			Mailbox:    mailbox.NewMailbox(),
+			RunTimer:   runTimer,
+		},
+	)
+	go func() {
+		sender.Do(s.records)
+		close(s.done)
+	}()
+	return s
+}
+
+func (s *summarySender) setSummary(key string, valueJSON string) {
+	s.records <- &service.Record{
+		RecordType: &service.Record_Summary{
+			Summary: &service.SummaryRecord{
+				Update: []*service.SummaryItem{{Key: key, ValueJson: valueJSON}},
+			},
+		},
+	}
+}
+
+// finish flushes the summary as the run exits, and returns the summary
+// updates streamed.
+func (s *summarySender) finish() []*filestream.SummaryUpdate {
+	s.records <- &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Defer{
+					Defer: &service.DeferRequest{State: service.DeferRequest_FLUSH_SUM},
+				},
+			},
+		},
+	}
+	close(s.records)
+	<-s.done
+
+	var updates []*filestream.SummaryUpdate
+	for _, update := range s.fileStream.GetUpdates() {
+		if summary, ok := update.(*filestream.SummaryUpdate); ok {
+			updates = append(updates, summary)
+		}
+	}
+	return updates
+}
+
+// Setting the same summary every step doesn't send it every step, even
+// without debouncing.
+func TestSendSummary_SkipsUnchangedSummaries(t *testing.T) {
+	sender := startSummarySender(0)
+
+	for range 10000 {
+		sender.setSummary("accuracy", "0.9")
+	}
+	updates := sender.finish()
+
+	// The first update, then the final update as the run exits.
+	require.Len(t, updates, 2)
+	for _, update := range updates {
+		assert.Equal(t, "0.9", update.Record.Update[0].ValueJson)
+	}
+}
+
+// Distinct summaries in quick succession are coalesced, and the latest is
+// always sent when the run exits.
+func TestSendSummary_CoalescesDistinctSummaries(t *testing.T) {
+	sender := startSummarySender(3600)
+
+	for i := range 100 {
+		sender.setSummary("step", fmt.Sprint(i))
+	}
+	updates := sender.finish()
+
+	require.Len(t, updates, 2)
+	assert.Equal(t, "0", updates[0].Record.Update[0].ValueJson)
+	assert.Equal(t, "99", updates[1].Record.Update[0].ValueJson)
+}
+
+// settableClock is a clock that only moves when told to.
+type settableClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *settableClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *settableClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// summaryRuntime returns the runtime in a streamed summary.
+func summaryRuntime(t *testing.T, update *filestream.SummaryUpdate) int {
+	for _, item := range update.Record.Update {
+		if slices.Equal(item.NestedKey, []string{"_wandb", "runtime"}) {
+			runtime, err := strconv.Atoi(item.ValueJson)
+			require.NoError(t, err)
+			return runtime
+		}
+	}
+	t.Fatal("no runtime in the summary")
+	return 0
+}
+
+// The runtime in the summary of a running run goes up as the run runs,
+// even if nothing else in the summary changes, and ends at the runtime
+// of the exit.
+func TestSendSummary_KeepsRuntimeCurrent(t *testing.T) {
+	clock := &settableClock{now: time.Unix(1000, 0)}
+	runTimer := timer.NewWithClock(clock)
+	runTimer.Start(nil)
+	runTimer.AddElapsed(100 * time.Second) // as if resumed
+	sender := startTimedSummarySender(0, runTimer)
+
+	for i := range 5 {
+		sender.setSummary("accuracy", "0.9")
+		require.Eventually(t,
+			func() bool { return len(sender.fileStream.GetUpdates()) == i+1 },
+			time.Second, time.Millisecond)
+		clock.advance(time.Minute)
+	}
+	// the handler stops the timer as the run exits
+	runTimer.Pause()
+	exitRuntime := int(runTimer.Elapsed().Seconds())
+	clock.advance(time.Minute)
+	updates := sender.finish()
+
+	require.Len(t, updates, 6)
+	var runtimes []int
+	for _, update := range updates {
+		runtimes = append(runtimes, summaryRuntime(t, update))
+	}
+	assert.Equal(t, []int{100, 160, 220, 280, 340, 400}, runtimes)
+	assert.Equal(t, exitRuntime, runtimes[len(runtimes)-1])
+}
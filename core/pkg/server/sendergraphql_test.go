@@ -0,0 +1,70 @@
+package server_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func upsertRun(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+) *service.RunUpdateResult {
+	t.Helper()
+	stream, responses := newAbortStream(t,
+		backend, filepath.Join(t.TempDir(), "run-abort.wandb"))
+	defer stream.FinishAndClose(0)
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "abort", Project: "testProject"},
+		},
+		Control: &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:    "run",
+	})
+	return awaitResult(t, responses, "run").GetRunResult()
+}
+
+// The sender upserts a run with the generated UpsertBucket operation.
+func TestSendRun_UsesGeneratedOperation(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+
+	result := upsertRun(t, backend)
+
+	require.Nil(t, result.GetError())
+	requests := backend.GraphQLRequests("UpsertBucket")
+	require.NotEmpty(t, requests)
+	var body struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	require.NoError(t, json.Unmarshal(requests[0].Body, &body))
+	assert.Equal(t, gql.UpsertBucket_Operation, body.Query)
+	assert.Equal(t, "abort", body.Variables["name"])
+	assert.Equal(t, "testProject", body.Variables["project"])
+}
+
+// A run the backend doesn't permit is reported as an authentication error
+// rather than a network problem.
+func TestSendRun_PermissionErrorIsAuthentication(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQLError("UpsertBucket",
+		"PERMISSION_ERROR", "not allowed to write to this project")
+
+	result := upsertRun(t, backend)
+
+	require.NotNil(t, result.GetError())
+	assert.Equal(t, service.ErrorInfo_AUTHENTICATION, result.GetError().GetCode())
+	assert.Contains(t, result.GetError().GetMessage(),
+		"not allowed to write to this project")
+}
@@ -29,6 +29,13 @@ type Server struct {
 	// wg is the WaitGroup to wait for all connections to finish
 	// and for the serve goroutine to finish
 	wg sync.WaitGroup
+
+	// connections is the number of connections being handled, not
+	// counting rejected ones
+	connections atomic.Int64
+
+	// maxConnections is the most connections handled at once, if positive
+	maxConnections int
 }
 
 // NewServer creates a new server
@@ -64,6 +71,22 @@ func (s *Server) SetDefaultLoggerPath(path string) {
 	defaultLoggerPath.Store(path)
 }
 
+// SetMaxConnections limits the number of connections handled at once,
+// unless n is zero.
+//
+// Connections past the limit are rejected with ErrAtCapacity.
+func (s *Server) SetMaxConnections(n int) {
+	s.maxConnections = n
+}
+
+// SetMaxStreams limits the number of streams open at once, unless n is
+// zero.
+//
+// Runs started past the limit fail with ErrAtCapacity.
+func (s *Server) SetMaxStreams(n int) {
+	streamMux.SetMaxStreams(n)
+}
+
 // Serve starts the server
 func (s *Server) Start() {
 	s.wg.Add(1)
@@ -86,11 +109,26 @@ func (s *Server) serve() {
 			default:
 				slog.Error("failed to accept conn.", "error", err)
 			}
+		} else if n := s.connections.Load(); s.maxConnections > 0 && n >= int64(s.maxConnections) {
+			slog.Warn(
+				"server: rejecting connection, at capacity",
+				"connections", n,
+				"maxConnections", s.maxConnections,
+				"streams", streamMux.NumStreams(),
+			)
+			err := fmt.Errorf("%w: %d of %d connections are open", ErrAtCapacity, n, s.maxConnections)
+			s.wg.Add(1)
+			go func() {
+				NewConnection(s.ctx, s.cancel, conn).HandleRejected(err)
+				s.wg.Done()
+			}()
 		} else {
+			s.connections.Add(1)
 			s.wg.Add(1)
 			go func() {
 				nc := NewConnection(s.ctx, s.cancel, conn)
 				nc.HandleConnection()
+				s.connections.Add(-1)
 				s.wg.Done()
 			}()
 		}
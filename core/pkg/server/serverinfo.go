@@ -0,0 +1,132 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// unknownServerInfo is reported for what couldn't be found out about the
+// server.
+const unknownServerInfo = "unknown"
+
+// serverInfoCache holds what was learned about the server by probing it.
+//
+// The server is probed at most once, on first use, and the cache may be
+// read from any goroutine, so that server info requests can be answered
+// without waiting for the sender's loop.
+type serverInfoCache struct {
+	mu sync.Mutex
+
+	// probe queries the server, or is nil if it mustn't be contacted
+	probe func() (*gql.ServerInfoServerInfo, error)
+
+	// probed is whether probe was called
+	probed bool
+
+	// info is what the probe returned, or nil if it failed
+	info *gql.ServerInfoServerInfo
+
+	logger *observability.CoreLogger
+}
+
+func newServerInfoCache(
+	probe func() (*gql.ServerInfoServerInfo, error),
+	logger *observability.CoreLogger,
+) *serverInfoCache {
+	return &serverInfoCache{probe: probe, logger: logger}
+}
+
+// Get returns the server's info, probing the server the first time.
+//
+// It returns nil if the server can't be contacted or the probe failed.
+func (c *serverInfoCache) Get() *gql.ServerInfoServerInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.probed || c.probe == nil {
+		return c.info
+	}
+	c.probed = true
+
+	info, err := c.probe()
+	if err != nil {
+		err = fmt.Errorf("sender: getServerInfo: failed to get server info: %s", err)
+		c.logger.CaptureError("sender received error", err)
+		return nil
+	}
+	c.info = info
+
+	c.logger.Info("sender: getServerInfo: got server info", "serverInfo", c.info)
+	return c.info
+}
+
+// serverInfoResponse describes the server and what it supports.
+//
+// It may be called from any goroutine.
+func (s *Sender) serverInfoResponse() *service.ServerInfoResponse {
+	info := s.serverInfo.Get()
+
+	localInfo := &service.LocalInfo{}
+	capabilities := &service.ServerCapabilities{
+		ServerVersion:             unknownServerInfo,
+		MaxFilestreamRequestBytes: int32(settings.From(s.settings).GetFileStreamMaxRequestBytes()),
+		CoreVersion:               version.Version,
+	}
+
+	switch {
+	case info == nil:
+		capabilities.Deployment = service.ServerCapabilities_UNKNOWN
+	case info.GetLatestLocalVersionInfo() == nil:
+		// Only local deployments report their version.
+		capabilities.Deployment = service.ServerCapabilities_SAAS
+	default:
+		latest := info.GetLatestLocalVersionInfo()
+		capabilities.Deployment = service.ServerCapabilities_LOCAL
+		if v := latest.GetVersionOnThisInstanceString(); v != "" {
+			capabilities.ServerVersion = v
+		}
+		localInfo = &service.LocalInfo{
+			Version:   latest.GetLatestVersionString(),
+			OutOfDate: latest.GetOutOfDate(),
+		}
+	}
+
+	return &service.ServerInfoResponse{
+		LocalInfo:    localInfo,
+		Capabilities: capabilities,
+	}
+}
+
+// serverInfoResult answers a server info request.
+//
+// It may be called from any goroutine.
+func (s *Sender) serverInfoResult(record *service.Record) *service.Result {
+	return &service.Result{
+		ResultType: &service.Result_Response{
+			Response: &service.Response{
+				ResponseType: &service.Response_ServerInfoResponse{
+					ServerInfoResponse: s.serverInfoResponse(),
+				},
+			},
+		},
+		Control: record.Control,
+		Uuid:    record.Uuid,
+	}
+}
+
+// isControlRecord is whether the record is a request answered on the
+// stream's control lane, ahead of the records queued in the pipeline.
+func isControlRecord(record *service.Record) bool {
+	switch record.GetRequest().GetRequestType().(type) {
+	case *service.Request_ServerInfo, *service.Request_StreamPing:
+		return true
+	default:
+		return false
+	}
+}
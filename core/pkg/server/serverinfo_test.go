@@ -0,0 +1,134 @@
+package server_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// chanResponder passes on the results it's sent.
+type chanResponder chan *service.Result
+
+func (r chanResponder) Respond(response *service.ServerResponse) {
+	r <- response.GetResultCommunicate()
+}
+
+func newServerInfoStream(t *testing.T, backend *servertest.FakeBackend) *server.Stream {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "info"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-info.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XFileStreamMaxRequestBytes: &wrapperspb.Int32Value{Value: 1 << 20},
+	}), "")
+	require.NoError(t, err)
+	return stream
+}
+
+func serverInfoRequest() *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_ServerInfo{
+					ServerInfo: &service.ServerInfoRequest{},
+				},
+			},
+		},
+		Control: &service.Control{ConnectionId: "client"},
+		Uuid:    "info",
+	}
+}
+
+func TestServerInfo_AnsweredWhilePipelineIsBusy(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubGraphQL("ServerInfo", `{"serverInfo": {
+		"cliVersionInfo": {},
+		"latestLocalVersionInfo": {
+			"outOfDate": true,
+			"latestVersionString": "0.60.0",
+			"versionOnThisInstanceString": "0.50.0"
+		}
+	}}`)
+	backend.InjectFault(servertest.RouteGraphQL, servertest.Fault{Delay: 3 * time.Second})
+	run := newServerInfoStream(t, backend)
+	responses := make(chanResponder, 1)
+	run.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	run.Start()
+	defer run.FinishAndClose(0)
+
+	// The sender is stuck upserting the run.
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "info", Project: "testProject"},
+		},
+	})
+	require.Eventually(t,
+		func() bool { return len(backend.GraphQLRequests("UpsertBucket")) > 0 },
+		5*time.Second, 10*time.Millisecond)
+	run.HandleRecord(serverInfoRequest())
+
+	// The run's URL may be announced as the upsert finishes.
+	var result *service.Result
+	for result == nil || result.GetResponse().GetRunUrlResponse() != nil {
+		select {
+		case result = <-responses:
+		case <-time.After(time.Second):
+			t.Fatal("server info wasn't answered while the sender was busy")
+		}
+	}
+	assert.Equal(t, "info", result.GetUuid())
+	response := result.GetResponse().GetServerInfoResponse()
+	assert.Equal(t,
+		&service.LocalInfo{Version: "0.60.0", OutOfDate: true},
+		response.GetLocalInfo())
+	assert.Equal(t, "0.50.0", response.GetCapabilities().GetServerVersion())
+	assert.Equal(t,
+		service.ServerCapabilities_LOCAL,
+		response.GetCapabilities().GetDeployment())
+	assert.EqualValues(t, 1<<20, response.GetCapabilities().GetMaxFilestreamRequestBytes())
+	assert.Equal(t, version.Version, response.GetCapabilities().GetCoreVersion())
+}
+
+func TestServerInfo_UnknownIfProbeFails(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	run := newServerInfoStream(t, backend)
+	responses := make(chanResponder, 2)
+	run.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	run.Start()
+	defer run.FinishAndClose(0)
+
+	run.HandleRecord(serverInfoRequest())
+	run.HandleRecord(serverInfoRequest())
+	first := (<-responses).GetResponse().GetServerInfoResponse()
+	second := (<-responses).GetResponse().GetServerInfoResponse()
+
+	assert.Equal(t, "unknown", first.GetCapabilities().GetServerVersion())
+	assert.Equal(t,
+		service.ServerCapabilities_UNKNOWN,
+		first.GetCapabilities().GetDeployment())
+	assert.Equal(t, version.Version, first.GetCapabilities().GetCoreVersion())
+	assert.Equal(t, first.GetCapabilities(), second.GetCapabilities())
+
+	// The failed probe is cached rather than repeated.
+	assert.Len(t, backend.GraphQLRequests("ServerInfo"), 1)
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// sharedRunFlushTimeout bounds how long the primary process of a shared-mode
+// run waits for the secondaries in the same process to flush their data.
+const sharedRunFlushTimeout = 30 * time.Second
+
+// isSecondary returns whether this process is a secondary writer of a
+// shared-mode run.
+//
+// Only the primary process creates and updates the run, pushes its config
+// and summary, uploads the files shared by all processes and marks the run
+// as finished. Secondaries only append history, console output and stats.
+func isSecondary(settings *service.Settings) bool {
+	return settings.GetXShared().GetValue() &&
+		settings.GetXPrimary() != nil &&
+		!settings.GetXPrimary().GetValue()
+}
+
+// sharedRunWriters tracks the secondary writers of shared-mode runs that
+// are active in this process.
+type sharedRunWriters struct {
+	mu sync.Mutex
+
+	// active is the number of active secondaries of each run
+	active map[string]int
+
+	// changed is closed and replaced when a secondary finishes
+	changed chan struct{}
+}
+
+// secondaryWriters are the secondary writers of this process.
+var secondaryWriters = &sharedRunWriters{
+	active:  make(map[string]int),
+	changed: make(chan struct{}),
+}
+
+// sharedRunKey identifies a run across the streams of a process.
+//
+// Streams may log to different servers, where the same path names
+// different runs.
+func sharedRunKey(settings *service.Settings, run *service.RunRecord) string {
+	return fmt.Sprintf("%s/%s/%s/%s",
+		settings.GetBaseUrl().GetValue(),
+		run.GetEntity(), run.GetProject(), run.GetRunId())
+}
+
+// Add registers a secondary that started writing to the run.
+func (w *sharedRunWriters) Add(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.active[key]++
+}
+
+// Done unregisters a secondary that flushed all of its data.
+func (w *sharedRunWriters) Done(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.active[key] <= 1 {
+		delete(w.active, key)
+	} else {
+		w.active[key]--
+	}
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+// Wait blocks until no secondaries of the run are active.
+//
+// Returns false if the timeout expired first.
+func (w *sharedRunWriters) Wait(key string, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		w.mu.Lock()
+		active, changed := w.active[key], w.changed
+		w.mu.Unlock()
+
+		if active == 0 {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return false
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/gqlmock"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/filestreamtest"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+const validRunResumeStatusResponse = `{
+	"model": {
+		"id": "project-id",
+		"name": "FakeProject",
+		"entity": {"id": "entity-id", "name": "FakeEntity"},
+		"bucket": {"id": "storage-id", "name": "run1", "displayName": "FakeName"}
+	}
+}`
+
+// makeSharedSender returns a sender for one process of a shared-mode run.
+func makeSharedSender(
+	client *gqlmock.MockClient,
+	primary bool,
+	fileStream filestream.FileStream,
+) (*server.Sender, chan *service.Result) {
+	ctx, cancel := context.WithCancel(context.Background())
+	outChan := make(chan *service.Result, 10)
+	sender := server.NewSender(
+		ctx,
+		cancel,
+		&server.SenderParams{
+			Logger: observability.NewNoOpLogger(),
+			Settings: &service.Settings{
+				RunId:    &wrapperspb.StringValue{Value: "run1"},
+				XShared:  &wrapperspb.BoolValue{Value: true},
+				XPrimary: &wrapperspb.BoolValue{Value: primary},
+			},
+			FileStream:    fileStream,
+			FwdChan:       make(chan *service.Record, 10),
+			OutChan:       outChan,
+			Mailbox:       mailbox.NewMailbox(),
+			GraphqlClient: client,
+		},
+	)
+	return sender, outChan
+}
+
+func makeDeferRecord(state service.DeferRequest_DeferState) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Defer{
+					Defer: &service.DeferRequest{State: state},
+				},
+			},
+		},
+	}
+}
+
+// startSharedRun sends the records with which a process starts writing
+// to the run.
+func startSharedRun(sender *server.Sender, outChan chan *service.Result) {
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				RunId:   "run1",
+				Entity:  "FakeEntity",
+				Project: "FakeProject",
+				Config: &service.ConfigRecord{
+					Update: []*service.ConfigItem{{Key: "_wandb", ValueJson: "{}"}},
+				},
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	})
+	<-outChan
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_RunStart{
+					RunStart: &service.RunStartRequest{},
+				},
+			},
+		},
+	})
+}
+
+func countExitUpdates(fileStream *filestreamtest.FakeFileStream) int {
+	count := 0
+	for _, update := range fileStream.GetUpdates() {
+		if _, ok := update.(*filestream.ExitUpdate); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSharedMode_OneUpsertAndOneFinish(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("UpsertBucket"),
+		validUpsertBucketResponse,
+	)
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("RunResumeStatus"),
+		validRunResumeStatusResponse,
+	)
+	rank0FileStream := filestreamtest.NewFakeFileStream()
+	rank1FileStream := filestreamtest.NewFakeFileStream()
+	rank0, rank0Out := makeSharedSender(mockGQL, true, rank0FileStream)
+	rank1, rank1Out := makeSharedSender(mockGQL, false, rank1FileStream)
+
+	startSharedRun(rank0, rank0Out)
+	startSharedRun(rank1, rank1Out)
+
+	exit := &service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+	}
+	rank0.SendRecord(exit)
+	rank1.SendRecord(exit)
+	// flushing the config of the secondary must not upsert the run
+	rank1.SendRecord(makeDeferRecord(service.DeferRequest_FLUSH_DEBOUNCER))
+
+	// the primary's filestream is flushed only after the secondary's
+	rank0Flushed := make(chan struct{})
+	go func() {
+		rank0.SendRecord(makeDeferRecord(service.DeferRequest_FLUSH_FS))
+		close(rank0Flushed)
+	}()
+	select {
+	case <-rank0Flushed:
+		t.Fatal("primary flushed before the secondary")
+	case <-time.After(50 * time.Millisecond):
+	}
+	rank1.SendRecord(makeDeferRecord(service.DeferRequest_FLUSH_FS))
+	select {
+	case <-rank0Flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("primary did not flush after the secondary")
+	}
+
+	upserts := 0
+	for _, request := range mockGQL.AllRequests() {
+		if request.OpName == "UpsertBucket" {
+			upserts++
+		}
+	}
+	assert.Equal(t, 1, upserts)
+	assert.Equal(t, 1, countExitUpdates(rank0FileStream))
+	assert.Equal(t, 0, countExitUpdates(rank1FileStream))
+}
+
+func TestSharedMode_SecondaryRequiresExistingRun(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("RunResumeStatus"),
+		`{"model": {"id": "project-id", "name": "FakeProject", "entity": {"name": "FakeEntity"}, "bucket": null}}`,
+	)
+	sender, outChan := makeSharedSender(mockGQL, false, filestreamtest.NewFakeFileStream())
+
+	sender.SendRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{
+				RunId:   "run1",
+				Entity:  "FakeEntity",
+				Project: "FakeProject",
+			},
+		},
+		Control: &service.Control{MailboxSlot: "junk"},
+	})
+	result := <-outChan
+
+	assert.Contains(t, result.GetRunResult().GetError().GetMessage(), "not found")
+	assert.Len(t, mockGQL.AllRequests(), 1)
+}
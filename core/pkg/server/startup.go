@@ -0,0 +1,99 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// startupQueue holds the records a stream is handed before it starts.
+//
+// Between NewStream and Start, nothing reads the stream's channels, so a
+// burst of records would block the client once the channels' buffers are
+// full. Instead, the records are queued without a bound until Start, then
+// handed to the stream in order. Records handled while the queue drains
+// are queued behind it, so that none overtake the others.
+type startupQueue struct {
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// records are the records not yet handed to the stream, oldest first
+	records []*service.Record
+
+	// draining is whether the stream started draining the queue
+	draining bool
+
+	// drained is whether the queue was emptied after the stream started,
+	// after which records go straight to the stream
+	drained bool
+
+	// done is closed once the queue is drained
+	done chan struct{}
+}
+
+func newStartupQueue() *startupQueue {
+	return &startupQueue{done: make(chan struct{})}
+}
+
+// hold queues the record and returns true, unless the queue is drained
+// and the record should go straight to the stream.
+func (q *startupQueue) hold(record *service.Record) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.drained {
+		return false
+	}
+	q.records = append(q.records, record)
+	return true
+}
+
+// start hands the queued records to the stream in order in the
+// background, until there are none left.
+//
+// It's called once, when the stream starts. Handing on the records may
+// block until the stream's components read them.
+func (q *startupQueue) start(handle func(*service.Record)) {
+	q.mu.Lock()
+	q.draining = true
+	q.mu.Unlock()
+
+	go func() {
+		defer close(q.done)
+		for {
+			q.mu.Lock()
+			records := q.records
+			q.records = nil
+			if len(records) == 0 {
+				q.drained = true
+				q.mu.Unlock()
+				return
+			}
+			q.mu.Unlock()
+
+			for _, record := range records {
+				handle(record)
+			}
+		}
+	}()
+}
+
+// wait waits until the queue is drained, before the stream's channels
+// are closed.
+//
+// If the stream was never started, it instead returns the number of
+// records left in the queue, which are dropped.
+func (q *startupQueue) wait() int {
+	q.mu.Lock()
+	if !q.draining {
+		defer q.mu.Unlock()
+		q.drained = true
+		dropped := len(q.records)
+		q.records = nil
+		return dropped
+	}
+	q.mu.Unlock()
+
+	<-q.done
+	return 0
+}
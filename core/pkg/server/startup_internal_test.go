@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func TestStartupQueue_HandsOnInOrder(t *testing.T) {
+	q := newStartupQueue()
+	var handled []string
+	for _, uuid := range []string{"a", "b"} {
+		assert.True(t, q.hold(&service.Record{Uuid: uuid}))
+	}
+
+	q.start(func(record *service.Record) {
+		handled = append(handled, record.Uuid)
+	})
+	dropped := q.wait()
+
+	assert.Zero(t, dropped)
+	assert.Equal(t, []string{"a", "b"}, handled)
+	assert.False(t, q.hold(&service.Record{Uuid: "c"}))
+}
+
+func TestStartupQueue_NeverStartedReportsDropped(t *testing.T) {
+	q := newStartupQueue()
+	q.hold(&service.Record{})
+	q.hold(&service.Record{})
+
+	assert.Equal(t, 2, q.wait())
+	assert.Zero(t, q.wait())
+}
@@ -0,0 +1,80 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Records handled between NewStream and Start don't block the client, and
+// are all handled once the stream starts.
+func TestStream_RecordsBeforeStartAreKept(t *testing.T) {
+	const records = 10_000
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-startup.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "startup"},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: dir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+
+	handled := make(chan struct{})
+	go func() {
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_Run{
+				Run: &service.RunRecord{RunId: "startup", Project: "testProject"},
+			},
+		})
+		for i := range records {
+			stream.HandleRecord(makeHistoryRecord(data{
+				items: map[string]string{"loss": "0.5"},
+				step:  int64(i),
+			}))
+		}
+		close(handled)
+	}()
+	select {
+	case <-handled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("handling records blocked before the stream started")
+	}
+	stream.Start()
+	stream.FinishAndClose(0)
+
+	store := server.NewStore(context.Background(), syncFile, observability.NewNoOpLogger())
+	require.NoError(t, store.Open(os.O_RDONLY))
+	defer store.Close()
+	var steps []int64
+	for {
+		record, err := store.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if history := record.GetHistory(); history != nil {
+			steps = append(steps, history.GetStep().GetNum())
+		}
+	}
+	require.Len(t, steps, records)
+	for i, step := range steps {
+		assert.EqualValues(t, i, step)
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// trackStepMetric starts keeping the latest value of a step metric, to
+// add it to the rows of the metrics plotted against it.
+//
+// A step metric defined after it was logged starts from its value in the
+// summary, unless the summary aggregates it.
+func (h *Handler) trackStepMetric(key string) {
+	if _, tracked := h.metricHandler.stepValues[key]; tracked {
+		return
+	}
+	h.metricHandler.stepValues[key] = ""
+
+	if h.runSummary == nil {
+		return
+	}
+	value, ok := h.runSummary.Tree()[key]
+	if _, isMap := value.(map[string]any); !ok || isMap {
+		return
+	}
+	if valueJSON, err := json.Marshal(value); err == nil {
+		h.metricHandler.stepValues[key] = string(valueJSON)
+	}
+}
+
+// imputeStepMetrics returns the latest values of the step metrics of the
+// row's metrics that aren't in the row.
+//
+// Step metrics that have step metrics of their own are followed in turn.
+// A step metric that wasn't logged yet is left out.
+func (h *Handler) imputeStepMetrics(items []*service.HistoryItem) []*service.HistoryItem {
+	inRow := make(map[string]bool, len(items))
+	for _, item := range items {
+		inRow[item.GetKey()] = true
+	}
+
+	var imputed []*service.HistoryItem
+	for pending := items; len(pending) > 0; {
+		var next []*service.HistoryItem
+		for _, item := range pending {
+			metric := h.matchHistoryItemMetric(item)
+			key := metric.GetStepMetric()
+			if key == "" || !metric.GetOptions().GetStepSync() || inRow[key] {
+				continue
+			}
+
+			// also stops at a cycle of step metrics
+			inRow[key] = true
+
+			value := h.metricHandler.stepValues[key]
+			if value == "" {
+				continue
+			}
+			stepItem := &service.HistoryItem{Key: key, ValueJson: value}
+			imputed = append(imputed, stepItem)
+			next = append(next, stepItem)
+		}
+		pending = next
+	}
+	return imputed
+}
+
+// updateStepValues keeps the values of step metrics in the row.
+func (mh *MetricHandler) updateStepValues(items []*service.HistoryItem) {
+	for _, item := range items {
+		if len(item.GetNestedKey()) > 0 {
+			continue
+		}
+		if _, tracked := mh.stepValues[item.GetKey()]; tracked {
+			mh.stepValues[item.GetKey()] = item.GetValueJson()
+		}
+	}
+}
@@ -2,65 +2,22 @@ package server
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/wandb/wandb/core/pkg/observability"
 
-	"github.com/wandb/wandb/core/pkg/leveldb"
 	"github.com/wandb/wandb/core/pkg/service"
-	"google.golang.org/protobuf/proto"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
 )
 
-type HeaderOptions struct {
-	IDENT   [4]byte
-	Magic   uint16
-	Version byte
-}
-
-const (
-	// headerMagic is the magic number for the header.
-	headerMagic = 0xBEE1
-	// headerVersion is the version of the header.
-	headerVersion = 0
-)
-
-// headerIdent returns the header identifier.
-func headerIdent() [4]byte {
-	return [4]byte{':', 'W', '&', 'B'}
-}
+// HeaderOptions is the header of a transaction log.
+type HeaderOptions = transactionlog.HeaderOptions
 
 // NewHeader returns a new header with default values.
 func NewHeader() *HeaderOptions {
-	return &HeaderOptions{
-		IDENT:   headerIdent(),
-		Magic:   headerMagic,
-		Version: headerVersion,
-	}
-}
-
-// MarshalBinary encodes the header to binary format.
-func (o *HeaderOptions) MarshalBinary(w io.Writer) error {
-
-	if err := binary.Write(w, binary.LittleEndian, o); err != nil {
-		return fmt.Errorf("error writing binary data: %w", err)
-	}
-	return nil
-}
-
-// UnmarshalBinary decodes binary data into the header.
-func (o *HeaderOptions) UnmarshalBinary(r io.Reader) error {
-	if err := binary.Read(r, binary.LittleEndian, o); err != nil {
-		return fmt.Errorf("error reading binary data: %w", err)
-	}
-	return nil
-}
-
-// Valid checks if the header is valid based on a reference header.
-func (o *HeaderOptions) Valid() bool {
-	return o.IDENT == headerIdent() && o.Magic == headerMagic && o.Version == headerVersion
+	return transactionlog.NewHeader()
 }
 
 // Store is the persistent store for a stream
@@ -72,16 +29,19 @@ type Store struct {
 	name string
 
 	// writer is the underlying writer
-	writer *leveldb.Writer
+	writer *transactionlog.Writer
 
 	// reader is the underlying reader
-	reader *leveldb.Reader
+	reader *transactionlog.Reader
 
 	// db is the underlying database
 	db *os.File
 
 	// logger is the logger for the store
 	logger *observability.CoreLogger
+
+	// lastOffset is the offset of the last record written
+	lastOffset int64
 }
 
 // NewStore creates a new store
@@ -103,14 +63,8 @@ func (sr *Store) Open(flag int) error {
 			return err
 		}
 		sr.db = f
-		sr.reader = leveldb.NewReaderExt(f, leveldb.CRCAlgoIEEE)
-		header := NewHeader()
-		if err := header.UnmarshalBinary(sr.db); err != nil {
-			sr.logger.CaptureError("can't read header", err)
-			return err
-		}
-		if !header.Valid() {
-			err := fmt.Errorf("invalid header")
+		sr.reader, err = transactionlog.NewReader(f)
+		if err != nil {
 			sr.logger.CaptureError("can't read header", err)
 			return err
 		}
@@ -122,9 +76,8 @@ func (sr *Store) Open(flag int) error {
 			return err
 		}
 		sr.db = f
-		sr.writer = leveldb.NewWriterExt(f, leveldb.CRCAlgoIEEE)
-		header := NewHeader()
-		if err := header.MarshalBinary(sr.db); err != nil {
+		sr.writer, err = transactionlog.NewWriter(retryingWriter{f})
+		if err != nil {
 			sr.logger.CaptureError("can't write header", err)
 			return err
 		}
@@ -155,22 +108,48 @@ func (sr *Store) Close() error {
 }
 
 func (sr *Store) Write(msg *service.Record) error {
-	writer, err := sr.writer.Next()
-	if err != nil {
-		sr.logger.CaptureError("can't write header", err)
+	if sr.writer == nil {
+		err := fmt.Errorf("store is not open for writing")
+		sr.logger.CaptureError("can't write record", err)
 		return err
 	}
-	out, err := proto.Marshal(msg)
+	offset, err := sr.writer.Write(msg)
 	if err != nil {
-		sr.logger.CaptureError("can't write header", err)
+		sr.logger.CaptureError("can't write record", err)
 		return err
 	}
+	sr.lastOffset = offset
+	return nil
+}
 
-	if _, err = writer.Write(out); err != nil {
-		sr.logger.CaptureError("can't write header", err)
-		return err
+// Digest returns the digest of the records written, which describes the
+// whole log once the store is closed.
+func (sr *Store) Digest() transactionlog.Digest {
+	if sr.writer == nil {
+		return transactionlog.Digest{}
 	}
-	return nil
+	return sr.writer.Digest()
+}
+
+// LastOffset is the offset of the last record written.
+func (sr *Store) LastOffset() int64 {
+	return sr.lastOffset
+}
+
+// Flush writes the buffered records to the file.
+func (sr *Store) Flush() error {
+	if sr.writer == nil {
+		return fmt.Errorf("store is not open for writing")
+	}
+	return sr.writer.Flush()
+}
+
+// Sync commits the flushed records to disk.
+func (sr *Store) Sync() error {
+	if sr.db == nil {
+		return fmt.Errorf("store is closed")
+	}
+	return sr.db.Sync()
 }
 
 func (sr *Store) WriteDirectlyToDB(data []byte) (int, error) {
@@ -186,24 +165,11 @@ func (sr *Store) Read() (*service.Record, error) {
 		return nil, err
 	}
 
-	reader, err := sr.reader.Next()
+	msg, _, err := sr.reader.Next()
 	if err == io.EOF {
 		return nil, err
 	}
-
 	if err != nil {
-		sr.logger.CaptureError("can't read record", err)
-		sr.reader.Recover()
-		return nil, err
-	}
-	buf, err := io.ReadAll(reader)
-	if err != nil {
-		sr.logger.CaptureError("can't read record", err)
-		sr.reader.Recover()
-		return nil, err
-	}
-	msg := &service.Record{}
-	if err = proto.Unmarshal(buf, msg); err != nil {
 		sr.logger.CaptureError("can't read record", err)
 		return nil, err
 	}
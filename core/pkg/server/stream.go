@@ -7,16 +7,29 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/wandb/wandb/core/internal/bandwidth"
+	"github.com/wandb/wandb/core/internal/faults"
 	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/httppool"
 	"github.com/wandb/wandb/core/internal/mailbox"
+	"github.com/wandb/wandb/core/internal/recordsink"
+	"github.com/wandb/wandb/core/internal/redact"
+	"github.com/wandb/wandb/core/internal/runexport"
 	"github.com/wandb/wandb/core/internal/runfiles"
+	"github.com/wandb/wandb/core/internal/runregistry"
 	"github.com/wandb/wandb/core/internal/runsummary"
+	"github.com/wandb/wandb/core/internal/runtmp"
+	"github.com/wandb/wandb/core/internal/runurl"
 	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/sharedlog"
 	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/watcher"
 	"github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/monitor"
@@ -25,10 +38,6 @@ import (
 	"github.com/wandb/wandb/core/pkg/utils"
 )
 
-const (
-	internalConnectionId = "internal"
-)
-
 // Stream is a collection of components that work together to handle incoming
 // data for a W&B run, store it locally, and send it to a W&B server.
 // Stream.handler receives incoming data from the client and dispatches it to
@@ -42,9 +51,25 @@ type Stream struct {
 	// cancel is the cancel function for the stream
 	cancel context.CancelFunc
 
+	// handlerCtx, writerCtx and senderCtx are the contexts of the stream's
+	// components, derived from ctx so that a forced close can stop the
+	// sender's network work while the writer finishes saving records
+	handlerCtx    context.Context
+	handlerCancel context.CancelFunc
+	writerCtx     context.Context
+	writerCancel  context.CancelFunc
+	senderCtx     context.Context
+	senderCancel  context.CancelFunc
+
+	// writerDone is closed once the writer has saved its last record
+	writerDone chan struct{}
+
 	// logger is the logger for the stream
 	logger *observability.CoreLogger
 
+	// debugLog is where the logger writes, for status responses
+	debugLog string
+
 	// wg is the WaitGroup for the stream
 	wg sync.WaitGroup
 
@@ -60,23 +85,181 @@ type Stream struct {
 	// sender is the sender for the stream
 	sender *Sender
 
+	// mirror sends the run to a second server, or is nil
+	mirror *Mirror
+
+	// disabled replaces the writer and the sender of a disabled run, or
+	// is nil
+	disabled *disabledSender
+
 	// inChan is the channel for incoming messages
 	inChan chan *service.Record
 
 	// loopBackChan is the channel for internal loopback messages
 	loopBackChan chan *service.Record
 
-	// internal responses from teardown path typically
-	outChan chan *service.ServerResponse
+	// controlChan is the channel for requests answered on the control
+	// lane, without waiting for the records ahead of them
+	controlChan chan *service.Record
+
+	// controlOutChan is the channel for the results of the control lane
+	controlOutChan chan *service.Result
+
+	// startup holds the records handled before the stream started
+	startup *startupQueue
+
+	// mailbox correlates requests made by the stream itself with their
+	// results
+	mailbox *mailbox.Mailbox
 
 	// dispatcher is the dispatcher for the stream
 	dispatcher *Dispatcher
 
-	// closed indicates if the inChan and loopBackChan are closed
+	// printer carries the stream's messages for the user, which the
+	// dispatcher forwards to the attached clients
+	printer *observability.Printer
+
+	// offlineMessages keeps the messages for the user of an offline run,
+	// or is nil if the run isn't offline
+	offlineMessages *OfflineMessages
+
+	// crashReporter writes a crash report if the stream fails
+	crashReporter *CrashReporter
+
+	// diagnostics takes snapshots of the stream's internals
+	diagnostics *Diagnostics
+
+	// restart is the run that the stream continues after wandb-core was
+	// restarted, or nil
+	restart *restartedRun
+
+	// coreUsage tracks wandb-core's own resource usage during the run
+	coreUsage *CoreUsage
+
+	// runWarnings records the warnings shown to the user in the run
+	runWarnings *RunWarnings
+
+	// backpressure slows down the stream's intake while the sender is
+	// behind, or is nil
+	backpressure *Backpressure
+
+	// faultInjector injects failures for testing, if configured
+	faultInjector *faults.Injector
+
+	// connStats counts the HTTP connections made and reused by the stream
+	connStats *httppool.Stats
+
+	// retries counts the stream's retried requests
+	retries *RetryBudget
+
+	// latency measures how long history rows take to go through the stream
+	latency *PipelineLatency
+
+	// historyLimiter limits the rate of history rows sent, or is nil
+	historyLimiter *HistoryLimiter
+
+	// runURL announces where the run can be viewed
+	runURL *runurl.Announcer
+
+	// registry lists the runs in the wandb directory, or is nil if the
+	// run isn't registered
+	registry *runregistry.Registry
+
+	// registryEntry is the run's latest entry in the registry
+	registryEntry runregistry.Entry
+
+	// tempDir is the stream's scratch space in the run directory, or nil
+	tempDir *runtmp.Dir
+
+	// exit is the run's exit, once the stream is sent one
+	exit atomic.Pointer[service.RunExitRecord]
+
+	// closed indicates if the inChan, loopBackChan and controlChan are
+	// closed
 	closed *atomic.Bool
+
+	// inputsMu is held to read while a record is handed to the input
+	// channels, and to write while they're closed, so that no record is
+	// sent on a closed channel
+	inputsMu sync.RWMutex
+}
+
+// isDebugEnabled is whether the stream collects debug information.
+func isDebugEnabled() bool {
+	return os.Getenv("WANDB_CORE_DEBUG") != ""
+}
+
+// stderrLog is the destination of a stream's debug log that couldn't be
+// written to a file.
+const stderrLog = "stderr"
+
+// streamLog is where a stream's debug log is written.
+type streamLog struct {
+	writer io.Writer
+
+	// destination is the log's file, stderrLog, or empty if the stream
+	// has no log
+	destination string
+
+	// fallback describes why the log isn't where it's configured to be, if
+	// it isn't
+	fallback string
+}
+
+// openStreamLog opens the stream's debug log: the service's shared log if
+// it has one, or else the run's own.
+//
+// Logging is nonessential, so a log that can't be opened is written to a
+// temporary file instead, or to stderr if that can't be created either.
+func openStreamLog(settings *settings.Settings) streamLog {
+	configured := settings.GetStreamLogFile()
+	if configured == "" {
+		// without a log file, such as in tests, nothing is logged
+		return streamLog{writer: io.Discard}
+	}
+
+	var writer io.Writer
+	var err error
+	if shared := settings.GetSharedInternalLogFile(); shared != "" {
+		writer, err = sharedlog.Open(shared, 0, 0)
+	} else {
+		writer, err = openLogFile(configured)
+	}
+	if err == nil {
+		return streamLog{writer: writer, destination: configured}
+	}
+
+	fallback := func(destination string) string {
+		return fmt.Sprintf(
+			"Couldn't write the debug log to %s, so it's written to %s instead: %v",
+			configured, destination, err)
+	}
+	if file, tempErr := os.CreateTemp("", "wandb-debug-internal-*.log"); tempErr == nil {
+		return streamLog{
+			writer:      file,
+			destination: file.Name(),
+			fallback:    fallback(file.Name()),
+		}
+	}
+	return streamLog{
+		writer:      os.Stderr,
+		destination: stderrLog,
+		fallback:    fallback(stderrLog),
+	}
+}
+
+// openLogFile opens a log file to append to, creating it and its
+// directory if needed.
+func openLogFile(name string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
 }
 
-func streamLogger(settings *settings.Settings) *observability.CoreLogger {
+// streamLogger returns the logger of the stream, which writes to the
+// stream's debug log.
+func streamLogger(settings *settings.Settings, log streamLog) *observability.CoreLogger {
 	// TODO: when we add session concept re-do this to use user provided path
 	targetPath := filepath.Join(settings.GetLogDir(), "debug-core.log")
 	if path := defaultLoggerPath.Load(); path != nil {
@@ -90,29 +273,21 @@ func streamLogger(settings *settings.Settings) *observability.CoreLogger {
 		}
 	}
 
-	var writers []io.Writer
-	name := settings.GetInternalLogFile()
-	file, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-	if err != nil {
-		slog.Error(fmt.Sprintf("error opening log file: %s", err))
-	} else {
-		writers = append(writers, file)
-	}
-	writer := io.MultiWriter(writers...)
-
 	// TODO: add a log level to the settings
 	level := slog.LevelInfo
-	if os.Getenv("WANDB_CORE_DEBUG") != "" {
+	if isDebugEnabled() {
 		level = slog.LevelDebug
 	}
 
 	opts := &slog.HandlerOptions{
 		Level: level,
 		// AddSource: true,
+		ReplaceAttr: redact.Attr,
 	}
 
+	handler := observability.NewLogHandler(log.writer, settings.GetLogFormat(), opts)
 	logger := observability.NewCoreLogger(
-		slog.New(slog.NewJSONHandler(writer, opts)),
+		slog.New(handler).With(observability.StreamIDKey, settings.GetRunID()),
 		observability.WithTags(observability.Tags{}),
 		observability.WithCaptureMessage(observability.CaptureMessage),
 		observability.WithCaptureException(observability.CaptureException),
@@ -126,24 +301,54 @@ func streamLogger(settings *settings.Settings) *observability.CoreLogger {
 		"entity":  settings.GetEntity(),
 	}
 	logger.SetTags(tags)
+	if log.fallback != "" {
+		logger.Warn("stream: debug log fallback", "fallback", log.fallback)
+	}
 
 	return logger
 }
 
 // NewStream creates a new stream with the given settings and responders.
-func NewStream(settings *settings.Settings, _ string) *Stream {
+//
+// It returns an error if the stream can't be created, such as when the
+// run's log file can't be written, in which case nothing is left running.
+func NewStream(settings *settings.Settings, _ string) (*Stream, error) {
+	// a disabled run doesn't even have a log file
+	if settings.IsDisabled() {
+		return newDisabledStream(settings), nil
+	}
+
+	// the fault injection spec is checked before anything is created, so
+	// that there's nothing to clean up if it's invalid
+	faultInjector, err := faults.New(settings.GetFaultInjection())
+	if err != nil {
+		return nil, fmt.Errorf("invalid fault injection spec: %v", err)
+	}
+
+	debugLog := openStreamLog(settings)
+	logger := streamLogger(settings, debugLog)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	s := &Stream{
-		ctx:          ctx,
-		cancel:       cancel,
-		logger:       streamLogger(settings),
-		wg:           sync.WaitGroup{},
-		settings:     settings,
-		inChan:       make(chan *service.Record, BufferSize),
-		loopBackChan: make(chan *service.Record, BufferSize),
-		outChan:      make(chan *service.ServerResponse, BufferSize),
-		closed:       &atomic.Bool{},
+		ctx:            ctx,
+		cancel:         cancel,
+		logger:         logger,
+		debugLog:       debugLog.destination,
+		wg:             sync.WaitGroup{},
+		settings:       settings,
+		inChan:         make(chan *service.Record, BufferSize),
+		loopBackChan:   make(chan *service.Record, BufferSize),
+		controlChan:    make(chan *service.Record, BufferSize),
+		controlOutChan: make(chan *service.Result, BufferSize),
+		startup:        newStartupQueue(),
+		mailbox:        mailbox.NewMailbox(),
+		writerDone:     make(chan struct{}),
+		closed:         &atomic.Bool{},
 	}
+	s.handlerCtx, s.handlerCancel = context.WithCancel(ctx)
+	s.writerCtx, s.writerCancel = context.WithCancel(ctx)
+	s.restart = findRestartedRun(settings, logger)
+	s.senderCtx, s.senderCancel = context.WithCancel(ctx)
 
 	w := watcher.New(watcher.Params{
 		Logger:   s.logger,
@@ -153,100 +358,346 @@ func NewStream(settings *settings.Settings, _ string) *Stream {
 	// TODO: replace this with a logger that can be read by the user
 	peeker := &observability.Peeker{}
 	terminalPrinter := observability.NewPrinter()
+	s.printer = terminalPrinter
+	if settings.IsOffline() {
+		s.offlineMessages = NewOfflineMessages(waiting.NewClock())
+		terminalPrinter.Save(s.offlineMessages.Add)
+	}
+	s.runWarnings = NewRunWarnings(waiting.NewClock())
+	terminalPrinter.Observe(s.runWarnings.Add)
+	for _, fallback := range settings.GetRunDirs().GetFallbacks() {
+		s.logger.Warn("stream: using a temporary run directory", "fallback", fallback)
+		terminalPrinter.Write(fallback)
+	}
+	if debugLog.fallback != "" {
+		terminalPrinter.Write(debugLog.fallback)
+	}
+	s.registerRun()
+	s.startCleanup(terminalPrinter)
+	s.tempDir = s.newTempDir()
+	s.startTempSweep()
+
+	s.crashReporter = NewCrashReporter(settings, isDebugEnabled())
+	s.diagnostics = NewDiagnostics()
+	s.coreUsage = NewCoreUsage(s.logger, settings.GetCoreRSSWarningBytes())
+
+	s.logEffectiveSettings()
+	if faultInjector != nil {
+		s.logger.Warn("stream: injecting faults")
+	}
+	s.faultInjector = faultInjector
+	s.connStats = httppool.NewStats()
+	s.retries = NewRetryBudget(
+		settings.GetRetryBudgetPerMinute(),
+		waiting.NewClock(),
+		func(warning string) {
+			s.logger.Warn("stream: retry budget exceeded", "warning", warning)
+			terminalPrinter.Emit(observability.UserMessage{
+				Level: observability.MessageWarning,
+				Key:   "retry-budget-exceeded",
+				Text:  warning,
+			})
+		},
+	)
+
+	s.mirror = NewMirror(s.logger, terminalPrinter, settings, s.connStats)
 
-	backendOrNil := NewBackend(s.logger, settings)
+	backendOrNil := NewBackend(s.logger, terminalPrinter, settings)
 	fileTransferStats := filetransfer.NewFileTransferStats()
+	uploadLimiter := bandwidth.NewLimiter(settings.GetUploadBytesPerSecond())
+	fileStreamLimiter := bandwidth.NewLimiter(settings.GetFileStreamBytesPerSecond())
 	var graphqlClientOrNil graphql.Client
 	var fileStreamOrNil filestream.FileStream
 	var fileTransferManagerOrNil filetransfer.FileTransferManager
 	var runfilesUploaderOrNil runfiles.Uploader
+	// the run's uploads are cancelled if it's aborted
+	uploadsCtx, cancelUploads := context.WithCancel(s.senderCtx)
+	exporterOrNil := runexport.New(runexport.Params{
+		Dir:      settings.GetExportDir(),
+		HardLink: settings.IsExportHardLink(),
+		Logger:   s.logger.With(observability.ComponentKey, "runexport"),
+	})
+	runConflict := NewRunConflict()
 	if backendOrNil != nil {
-		graphqlClientOrNil = NewGraphQLClient(backendOrNil, settings, peeker)
+		graphqlClientOrNil = NewGraphQLClient(backendOrNil, settings, peeker, s.connStats, s.retries)
 		fileStreamOrNil = NewFileStream(
 			backendOrNil,
 			s.logger,
 			terminalPrinter,
 			settings,
 			peeker,
+			s.crashReporter,
+			s.faultInjector,
+			s.connStats,
+			s.retries,
+			fileStreamLimiter,
+			runConflict,
 		)
 		fileTransferManagerOrNil = NewFileTransferManager(
 			fileTransferStats,
 			s.logger,
 			settings,
+			s.faultInjector,
+			s.connStats,
+			s.retries,
+			uploadLimiter,
+			backendOrNil.Quota(),
 		)
 		runfilesUploaderOrNil = NewRunfilesUploader(
-			s.ctx,
+			uploadsCtx,
 			s.logger,
 			settings,
 			fileStreamOrNil,
 			fileTransferManagerOrNil,
+			fileTransferStats,
 			graphqlClientOrNil,
+			exporterOrNil,
+			s.restart.uploadedFiles(),
+		)
+	} else if exporterOrNil != nil {
+		// an offline run's files are still exported
+		runfilesUploaderOrNil = NewRunfilesUploader(
+			uploadsCtx,
+			s.logger,
+			settings,
+			nil,
+			nil,
+			fileTransferStats,
+			nil,
+			exporterOrNil,
+			nil,
 		)
 	}
 
-	mailbox := mailbox.NewMailbox()
+	// the system monitor is not created at all if stats are disabled,
+	// so that no assets are probed and no sampling goroutines start
+	var systemMonitorOrNil *monitor.SystemMonitor
+	if !settings.Proto.GetXDisableStats().GetValue() {
+		systemMonitorOrNil = monitor.NewSystemMonitor(s.logger, s.settings.Proto, s.loopBackChan)
+	}
+
+	deferProgress := NewDeferProgress()
+	runOutputs := NewRunOutputs()
+
+	// a synced run's transaction log isn't written, so it has no checkpoints
+	var checkpointsOrNil *Checkpoints
+	if !s.settings.IsSync() {
+		checkpointsOrNil = NewCheckpoints(0, 0)
+	}
+
+	// rows are only held back while they'd be sent, and a synced run's
+	// were already limited when it ran
+	if !s.settings.IsSync() && !s.settings.IsOffline() {
+		s.historyLimiter = NewHistoryLimiter(
+			settings.GetMaxHistoryRowsPerSecond(),
+			waiting.NewClock(),
+		)
+	}
+
+	historyKeys := NewHistoryKeyLimit(settings.GetMaxTrackedHistoryKeys())
+	unknownTypes := NewUnknownTypes()
+	handlerMemory := NewHandlerMemory(HandlerMemoryLimits{
+		MaxSampledKeys:        settings.GetMaxSampledHistoryKeys(),
+		MaxSummaryKeys:        settings.GetMaxSummaryKeys(),
+		MaxPartialHistoryKeys: settings.GetMaxPartialHistoryKeys(),
+		MaxMetricDefinitions:  settings.GetMaxMetricDefinitions(),
+	})
+	s.latency = NewPipelineLatency(waiting.NewClock())
+	exitGrace := NewExitGrace(settings.GetExitGrace(), waiting.NewClock())
+
+	// a synced run's records are read from its transaction log by the
+	// sender, so there's no intake to slow down
+	if !s.settings.IsSync() {
+		s.backpressure = NewBackpressure(
+			settings.GetBackpressureSlowDepth(),
+			settings.GetBackpressureCongestedDepth(),
+		)
+	}
+
+	// a synced run's labels are in its transaction log
+	var runLabels *RunLabels
+	if !s.settings.IsSync() {
+		runLabels = runLabelsFromSettings(s.settings, s.logger, terminalPrinter)
+	}
 
-	s.handler = NewHandler(s.ctx,
+	s.handler = NewHandler(s.handlerCtx,
 		&HandlerParams{
-			Logger:            s.logger,
+			Logger:            s.logger.With(observability.ComponentKey, "handler"),
 			Settings:          s.settings.Proto,
 			FwdChan:           make(chan *service.Record, BufferSize),
 			OutChan:           make(chan *service.Result, BufferSize),
-			SystemMonitor:     monitor.NewSystemMonitor(s.logger, s.settings.Proto, s.loopBackChan),
+			SystemMonitor:     systemMonitorOrNil,
+			RunMetadata:       NewRunMetadata(s.handlerCtx, s.settings.Proto, s.logger),
 			RunfilesUploader:  runfilesUploaderOrNil,
 			TBHandler:         NewTBHandler(w, s.logger, s.settings.Proto, s.loopBackChan),
 			FileTransferStats: fileTransferStats,
 			RunSummary:        runsummary.New(),
 			MetricHandler:     NewMetricHandler(),
-			Mailbox:           mailbox,
+			Mailbox:           s.mailbox,
 			TerminalPrinter:   terminalPrinter,
+			DeferProgress:     deferProgress,
+			CrashReporter:     s.crashReporter,
+			Diagnostics:       s.diagnostics,
+			FaultInjector:     s.faultInjector,
+			RunDirs:           settings.GetRunDirs(),
+			DebugLog:          s.debugLog,
+			Checkpoints:       checkpointsOrNil,
+			ClockSkew:         backendOrNil.ClockSkew(),
+			HistoryLimiter:    s.historyLimiter,
+			HistoryKeyLimit:   historyKeys,
+			Memory:            handlerMemory,
+			PipelineLatency:   s.latency,
+			Mirror:            s.mirror,
+			RunOutputs:        runOutputs,
+			OfflineMessages:   s.offlineMessages,
+			ExitGrace:         exitGrace,
+			RunLabels:         runLabels,
+			Backpressure:      s.backpressure,
+			TempDir:           s.tempDir,
+			UnknownTypes:      unknownTypes,
 		},
 	)
 
-	s.writer = NewWriter(s.ctx,
+	// a synced run's records were mirrored when it ran, if at all
+	var recordSinkOrNil *recordsink.Sink
+	if !s.settings.IsSync() {
+		recordSinkOrNil = recordsink.New(recordsink.Params{
+			URL:         settings.GetRecordSinkURL(),
+			RecordTypes: settings.GetRecordSinkTypes(),
+			Logger:      s.logger.With(observability.ComponentKey, "recordsink"),
+		})
+	}
+
+	s.writer = NewWriter(s.writerCtx,
 		&WriterParams{
-			Logger:   s.logger,
-			Settings: s.settings.Proto,
-			FwdChan:  make(chan *service.Record, BufferSize),
+			Logger:          s.logger.With(observability.ComponentKey, "writer"),
+			Settings:        s.settings.Proto,
+			FwdChan:         make(chan *service.Record, BufferSize),
+			CrashReporter:   s.crashReporter,
+			FaultInjector:   s.faultInjector,
+			TerminalPrinter: terminalPrinter,
+			RecordSink:      recordSinkOrNil,
+			Checkpoints:     checkpointsOrNil,
+			PipelineLatency: s.latency,
+			ContinueLog:     s.restart.logPath(),
 		},
 	)
 
+	// in low-latency mode, the sender may be ahead of the writer
+	var lowLatencyWriterOrNil *Writer
+	if settings.IsLowLatency() {
+		lowLatencyWriterOrNil = s.writer
+	}
+
+	notifyURL := settings.GetRunURLNotifyURL()
+	if settings.IsOffline() || settings.IsDryRun() {
+		notifyURL = ""
+	}
+	s.runURL = runurl.New(runurl.Params{
+		RunDir:    settings.Proto.GetSyncDir().GetValue(),
+		NotifyURL: notifyURL,
+		Logger:    s.logger.With(observability.ComponentKey, "runurl"),
+	})
+
 	s.sender = NewSender(
-		s.ctx,
+		s.senderCtx,
 		s.cancel,
 		&SenderParams{
-			Logger:              s.logger,
+			Logger:              s.logger.With(observability.ComponentKey, "sender"),
 			Settings:            s.settings.Proto,
 			Backend:             backendOrNil,
 			FileStream:          fileStreamOrNil,
 			FileTransferManager: fileTransferManagerOrNil,
+			FileTransferStats:   fileTransferStats,
+			UploadLimiter:       uploadLimiter,
+			FileStreamLimiter:   fileStreamLimiter,
 			RunfilesUploader:    runfilesUploaderOrNil,
+			Exporter:            exporterOrNil,
 			Peeker:              peeker,
 			RunSummary:          runsummary.New(),
 			GraphqlClient:       graphqlClientOrNil,
 			FwdChan:             s.loopBackChan,
 			OutChan:             make(chan *service.Result, BufferSize),
-			Mailbox:             mailbox,
+			Mailbox:             s.mailbox,
+			DeferProgress:       deferProgress,
+			CrashReporter:       s.crashReporter,
+			FaultInjector:       s.faultInjector,
+			RetryBudget:         s.retries,
+			CoreUsage:           s.coreUsage,
+			RunWarnings:         s.runWarnings,
+			RunURLAnnouncer:     s.runURL,
+			RunTimer:            s.handler.runTimer,
+			PipelineLatency:     s.latency,
+			Writer:              lowLatencyWriterOrNil,
+			LogWriter:           s.writer,
+			RunOutputs:          runOutputs,
+			RunConflict:         runConflict,
+			ExitGrace:           exitGrace,
+			Backpressure:        s.backpressure,
+			UploadsCtx:          uploadsCtx,
+			CancelUploads:       cancelUploads,
+			SettingsProvenance:  s.settings.Provenance(),
+			DebugLog:            s.debugLog,
+			Restart:             s.restart,
 		},
 	)
 
-	s.dispatcher = NewDispatcher(s.logger)
+	s.diagnostics.retries = s.retries
+	s.diagnostics.fileStreamOrNil = fileStreamOrNil
+	s.diagnostics.fileTransferManagerOrNil = fileTransferManagerOrNil
+	s.diagnostics.uploadsOrNil = s.sender.uploads
+	s.diagnostics.polls = s.sender.polls
+	s.diagnostics.historyLimiter = s.historyLimiter
+	s.diagnostics.historyKeys = historyKeys
+	s.diagnostics.unknownTypes = unknownTypes
+	s.diagnostics.handlerMemory = handlerMemory
+	s.diagnostics.latency = s.latency
+	watchChannel(s.diagnostics, "stream.in", s.inChan)
+	watchChannel(s.diagnostics, "stream.loopback", s.loopBackChan)
+	watchChannel(s.diagnostics, "stream.control", s.controlChan)
+	watchChannel(s.diagnostics, "handler.fwd", s.handler.fwdChan)
+	watchChannel(s.diagnostics, "handler.out", s.handler.outChan)
+	watchChannel(s.diagnostics, "writer.fwd", s.writer.fwdChan)
+	watchChannel(s.diagnostics, "sender.out", s.sender.outChan)
+
+	s.dispatcher = NewDispatcher(
+		s.logger.With(observability.ComponentKey, "dispatcher"),
+		s.mailbox,
+		s.faultInjector,
+	)
+	terminalPrinter.Forward(s.dispatcher.forwardUserMessage)
+	s.runURL.Forward(s.dispatcher.forwardRunURL)
 
 	s.logger.Info("created new stream", "id", s.settings.GetRunID())
-	return s
+	return s, nil
 }
 
 // AddResponders adds the given responders to the stream's dispatcher.
+//
+// Messages for the user that no client could display are flushed to them.
 func (s *Stream) AddResponders(entries ...ResponderEntry) {
 	s.dispatcher.AddResponders(entries...)
+	s.printer.Flush()
 }
 
 // Start starts the stream's handler, writer, sender, and dispatcher.
 // We use Stream's wait group to ensure that all of these components are cleanly
 // finalized and closed when the stream is closed in Stream.Close().
 func (s *Stream) Start() {
+	// the records handled so far are handed on once the components are
+	// there to read them
+	s.startup.start(s.route)
+
+	if s.disabled != nil {
+		s.startDisabled()
+		return
+	}
+
 	// forward records from the inChan and loopBackChan to the handler
 	fwdChan := make(chan *service.Record, BufferSize)
+	if s.restart != nil {
+		s.restartRun(fwdChan)
+	}
 	s.wg.Add(1)
 	go func() {
 		wg := sync.WaitGroup{}
@@ -275,13 +726,33 @@ func (s *Stream) Start() {
 	s.wg.Add(1)
 	go func() {
 		s.writer.Do(s.handler.fwdChan)
+		close(s.writerDone)
+		s.wg.Done()
+	}()
+
+	// send the data to the server, and to the mirror if there is one
+	var senderChan <-chan *service.Record = s.writer.fwdChan
+	if s.mirror != nil {
+		senderChan = s.mirror.Tee(s.writer.fwdChan)
+	}
+	s.wg.Add(1)
+	go func() {
+		s.sender.Do(senderChan)
 		s.wg.Done()
 	}()
 
-	// send the data to the server
+	// answer the requests on the control lane from what the sender knows,
+	// so that they're answered even while the pipeline is busy
 	s.wg.Add(1)
 	go func() {
-		s.sender.Do(s.writer.fwdChan)
+		for record := range s.controlChan {
+			if record.GetRequest().GetStreamPing() != nil {
+				s.controlOutChan <- s.streamPingResult(record)
+			} else {
+				s.controlOutChan <- s.sender.serverInfoResult(record)
+			}
+		}
+		close(s.controlOutChan)
 		s.wg.Done()
 	}()
 
@@ -289,7 +760,11 @@ func (s *Stream) Start() {
 	s.wg.Add(1)
 	go func() {
 		wg := sync.WaitGroup{}
-		for _, ch := range []chan *service.Result{s.handler.outChan, s.sender.outChan} {
+		for _, ch := range []chan *service.Result{
+			s.handler.outChan,
+			s.sender.outChan,
+			s.controlOutChan,
+		} {
 			wg.Add(1)
 			go func(ch chan *service.Result) {
 				for result := range ch {
@@ -299,70 +774,293 @@ func (s *Stream) Start() {
 			}(ch)
 		}
 		wg.Wait()
-		close(s.outChan)
+		s.dispatcher.Close()
+		s.mailbox.Close()
 		s.wg.Done()
 	}()
+	// log diagnostics until the run exits, if enabled
+	if interval := s.settings.GetDiagnosticsInterval(); interval > 0 {
+		s.wg.Add(1)
+		go func() {
+			s.diagnostics.Log(s.ctx, interval, s.logger)
+			s.wg.Done()
+		}()
+	}
+
+	// sample wandb-core's own resource usage until the run exits
+	s.wg.Add(1)
+	go func() {
+		s.coreUsage.Run(s.ctx, coreUsageInterval)
+		s.wg.Done()
+	}()
+
 	s.logger.Debug("starting stream", "id", s.settings.GetRunID())
 }
 
 // HandleRecord handles the given record by sending it to the stream's handler.
 func (s *Stream) HandleRecord(rec *service.Record) {
 	s.logger.Debug("handling record", "record", rec)
+	s.inputsMu.RLock()
+	defer s.inputsMu.RUnlock()
 	if s.closed.Load() {
 		// this is to prevent trying to process messages after the stream is closed
 		s.logger.Error("context done, not handling record", "record", rec)
 		return
 	}
+	if exit := rec.GetExit(); exit != nil {
+		s.exit.Store(exit)
+	}
+	if s.startup.hold(rec) {
+		return
+	}
+	s.route(rec)
+}
+
+// TryHandleRecord is like HandleRecord, except that while the backend is
+// congested, it rejects history and console output with
+// ErrBackendCongested instead of taking them in.
+//
+// A rejected record isn't handled, and may be tried again later.
+func (s *Stream) TryHandleRecord(rec *service.Record) error {
+	if isThrottled(rec) && s.backpressure.IsCongested() {
+		return ErrBackendCongested
+	}
+	s.HandleRecord(rec)
+	return nil
+}
+
+// route passes the record to the control lane or the pipeline.
+func (s *Stream) route(rec *service.Record) {
+	if isControlRecord(rec) {
+		s.controlChan <- rec
+		return
+	}
 	s.inChan <- rec
 }
 
+// closeInputs closes the stream's input channels, once the records
+// handled before the stream started are in them.
+//
+// The records of a stream that never started are dropped, and reported.
+func (s *Stream) closeInputs() {
+	s.inputsMu.Lock()
+	defer s.inputsMu.Unlock()
+	if s.closed.Swap(true) {
+		return
+	}
+
+	if dropped := s.startup.wait(); dropped > 0 {
+		s.logger.CaptureError(
+			"stream: dropping records handled before it started",
+			fmt.Errorf("stream closed before Start with %d records", dropped),
+		)
+	}
+	close(s.loopBackChan)
+	close(s.inChan)
+	close(s.controlChan)
+}
+
 // Close Gracefully wait for handler, writer, sender, dispatcher to shut down cleanly
 // assumes an exit record has already been sent
 func (s *Stream) Close() {
 	// wait for the context to be canceled in the defer state machine in the sender
 	<-s.ctx.Done()
-	if !s.closed.Swap(true) {
-		close(s.loopBackChan)
-		close(s.inChan)
-	}
+	s.closeInputs()
 	s.wg.Wait()
+	if s.disabled != nil {
+		return
+	}
+	s.removeTempDir()
+
+	// the run is finished, but its mirror may still be catching up
+	s.mirror.Finish(mirrorFinishTimeout)
+	s.runURL.Close()
+
+	s.logConnStats()
 }
 
-// Respond Handle internal responses like from the finish and close path
-func (s *Stream) Respond(resp *service.ServerResponse) {
-	s.outChan <- resp
+// logConnStats logs how many requests reused an HTTP connection.
+func (s *Stream) logConnStats() {
+	for class, counts := range s.connStats.Snapshot() {
+		s.logger.Info(
+			"stream: HTTP connections",
+			"class", class,
+			"new", counts.New,
+			"reused", counts.Reused,
+		)
+	}
+}
+
+// logEffectiveSettings logs the settings that have a value, as a
+// GetSettings request would list them, with where each came from.
+func (s *Stream) logEffectiveSettings() {
+	effective, err := s.settings.Effective()
+	if err != nil {
+		s.logger.CaptureError("stream: failed to list the settings", err)
+		return
+	}
+
+	values := make(map[string]string)
+	for _, setting := range effective {
+		if setting.GetValueJson() == "null" {
+			continue
+		}
+		values[setting.GetKey()] = fmt.Sprintf("%s (%s)",
+			setting.GetValueJson(), strings.ToLower(setting.GetSource().String()))
+	}
+	s.logger.Info("stream: effective settings", "settings", values)
 }
 
+// slowExitFooterThreshold is how long a run may take to exit before the
+// footer shows where the time went.
+const slowExitFooterThreshold = 30 * time.Second
+
 // FinishAndClose closes the stream and sends an exit record to the handler.
 // This will be called when we recieve a teardown signal from the client.
 // So it is used to close all active streams in the system.
 func (s *Stream) FinishAndClose(exitCode int32) {
-	s.AddResponders(ResponderEntry{s, internalConnectionId})
+	s.finishAndClose(&service.RunExitRecord{ExitCode: exitCode})
+}
 
-	if !s.settings.IsSync() {
-		// send exit record to handler
+// Interrupt finishes the run like FinishAndClose, when the service shuts
+// down before the run's process finished it.
+//
+// The exit is marked as interrupted. If the run's process had already
+// sent its exit, that exit is waited for instead.
+func (s *Stream) Interrupt(exitCode int32) {
+	if s.exit.Load() != nil {
+		s.finishAndClose(nil)
+		return
+	}
+	s.finishAndClose(&service.RunExitRecord{ExitCode: exitCode, Interrupted: true})
+}
+
+// finishAndClose sends the exit, unless it's nil, and closes the stream
+// once the run is finished.
+func (s *Stream) finishAndClose(exit *service.RunExitRecord) {
+	var unfinished *service.UnfinishedWork
+	var verification *service.RunVerification
+	var timing *service.ShutdownTiming
+	if exit != nil && !s.settings.IsSync() {
+		// send exit record to handler and wait for the run to finish
+		slot := s.mailbox.Reserve()
 		record := &service.Record{
-			RecordType: &service.Record_Exit{
-				Exit: &service.RunExitRecord{
-					ExitCode: exitCode,
-				}},
-			Control: &service.Control{AlwaysSend: true, ConnectionId: internalConnectionId, ReqResp: true},
+			RecordType: &service.Record_Exit{Exit: exit},
+			Control:    &service.Control{AlwaysSend: true, MailboxSlot: slot.ID()},
 		}
 
 		s.HandleRecord(record)
-		<-s.outChan
+		result, err := slot.Wait(context.Background())
+		if err != nil {
+			s.logger.Error("stream: no exit result", "error", err)
+		}
+		unfinished = result.GetExitResult().GetUnfinished()
+		verification = result.GetExitResult().GetVerification()
+		timing = result.GetExitResult().GetShutdownTiming()
 	}
 
 	s.Close()
+	if s.disabled != nil {
+		utils.PrintFooterDisabled()
+		return
+	}
+	discarded := s.sender.discarded.Load()
+	if discarded {
+		s.unregisterRun(runregistry.StateDiscarded)
+	} else {
+		s.unregisterRun(exitState(s.exit.Load()))
+	}
 
 	// TODO: we are using service.Settings instead of settings.Settings
 	// because this package is used by the go wandb client package
-	if s.settings.IsOffline() {
+	switch {
+	case discarded:
+		utils.PrintFooterDiscarded(s.settings.GetRunID())
+	case s.settings.IsOffline():
+		utils.PrintFooterOfflineMessages(s.offlineMessages.Kept())
 		utils.PrintFooterOffline(s.settings.Proto)
-	} else {
-		run := s.handler.GetRun()
+	case s.settings.IsDryRun():
+		utils.PrintFooterDryRun(s.settings.Proto.GetSyncDir().GetValue())
+	default:
+		run, _ := s.handler.GetRun()
 		utils.PrintFooterOnline(run, s.settings.Proto)
 	}
+	utils.PrintFooterRetries(s.retries.Stats())
+	utils.PrintFooterCoalescedHistory(s.historyLimiter.Coalesced())
+	utils.PrintFooterPipelineLatency(s.latency.Proto())
+	utils.PrintFooterUnfinished(unfinished)
+	utils.PrintFooterVerification(verification)
+	if timing.GetTotalSeconds() >= slowExitFooterThreshold.Seconds() {
+		utils.PrintFooterShutdownTiming(timing)
+	}
+	if path := s.crashReporter.Path(); path != "" {
+		utils.PrintFooterCrashReport(path)
+	}
 
 	s.logger.Info("closed stream", "id", s.settings.GetRunID())
 }
+
+// abortTimeout is the longest Abort waits for the stream's records to be
+// saved.
+const abortTimeout = 10 * time.Second
+
+// Abort closes the stream without uploading the rest of its data, when
+// the service is shut down by force.
+//
+// The records handled so far are saved to the transaction log. Unless the
+// run had exited, the log ends with a record that the run was preempted,
+// so that syncing it later uploads the rest and marks the run crashed.
+func (s *Stream) Abort() {
+	if s.disabled != nil {
+		s.cancel()
+		s.Close()
+		return
+	}
+
+	if !s.settings.IsSync() && s.exit.Load() == nil {
+		s.HandleRecord(&service.Record{
+			RecordType: &service.Record_Preempting{
+				Preempting: &service.RunPreemptingRecord{},
+			},
+			Control: &service.Control{PersistOnly: true},
+		})
+	}
+
+	// the sender's network work stops first, and the records still queued
+	// are saved, but no longer sent
+	deadline := time.After(abortTimeout)
+	s.sender.abandon()
+	s.mirror.Abandon()
+	s.senderCancel()
+	s.writer.Abandon()
+	s.closeInputs()
+
+	select {
+	case <-s.writerDone:
+	case <-deadline:
+		s.logger.Warn("stream: abort timed out saving records", "timeout", abortTimeout)
+	}
+
+	// the rest is only cancelled once the records are saved, so that the
+	// transaction log is complete
+	s.handlerCancel()
+	s.writerCancel()
+	s.cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-deadline:
+		s.logger.Warn("stream: abort timed out", "timeout", abortTimeout)
+	}
+
+	// whatever is still running can't finish its writes anyway
+	s.removeTempDir()
+	s.unregisterRun(runregistry.StateCrashed)
+	utils.PrintFooterOffline(s.settings.Proto)
+	s.logger.Info("aborted stream", "id", s.settings.GetRunID())
+}
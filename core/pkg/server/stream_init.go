@@ -4,28 +4,52 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
+	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/wandb/wandb/core/internal/api"
+	"github.com/wandb/wandb/core/internal/bandwidth"
 	"github.com/wandb/wandb/core/internal/clients"
+	"github.com/wandb/wandb/core/internal/dryrun"
+	"github.com/wandb/wandb/core/internal/faults"
 	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/httppool"
+	"github.com/wandb/wandb/core/internal/runexport"
 	"github.com/wandb/wandb/core/internal/runfiles"
 	"github.com/wandb/wandb/core/internal/settings"
 	"github.com/wandb/wandb/core/internal/waiting"
 	"github.com/wandb/wandb/core/internal/watcher2"
 	"github.com/wandb/wandb/core/pkg/filestream"
 	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
 	"github.com/wandb/wandb/core/pkg/utils"
 )
 
+// unauthorizedNotice is printed when the server rejects the API key.
+const unauthorizedNotice = "The W&B server rejected the API key, the" +
+	" credentials may have expired. Provide a new API key to resume syncing."
+
+// offlineNotice is printed when the network becomes unavailable.
+const offlineNotice = "Network unavailable, data will sync on reconnect" +
+	" or via `wandb sync`."
+
+// clockSkewCorrectionThreshold is how far the local clock must be off the
+// server's for the timestamps in history to be corrected.
+const clockSkewCorrectionThreshold = time.Minute
+
 // NewBackend returns a Backend or nil if we're offline.
+//
+// The printer is used to tell the user when the API key is rejected, when
+// the network is unavailable, and when their organization exceeds a limit.
 func NewBackend(
 	logger *observability.CoreLogger,
+	printer *observability.Printer,
 	settings *settings.Settings,
 ) *api.Backend {
 	if settings.IsOffline() {
@@ -36,17 +60,89 @@ func NewBackend(
 	if err != nil {
 		logger.CaptureFatalAndPanic("sender: failed to parse base URL", err)
 	}
+
+	// A run being synced reads from its transaction log already, so it
+	// has no offline mode.
+	var offlineAfter time.Duration
+	if !settings.IsSync() {
+		offlineAfter = settings.GetNetworkOfflineAfter()
+	}
+
+	// the timestamps of a run being synced were corrected as it was logged
+	var clockSkewThreshold time.Duration
+	if settings.CorrectsClockSkew() && !settings.IsSync() {
+		clockSkewThreshold = clockSkewCorrectionThreshold
+	}
+
 	return api.New(api.BackendOptions{
 		BaseURL: baseURL,
 		Logger:  logger.Logger,
 		APIKey:  settings.GetAPIKey(),
+		OnUnauthorized: func() {
+			printer.Emit(observability.UserMessage{
+				Level: observability.MessageError,
+				Key:   "unauthorized",
+				Text:  unauthorizedNotice,
+			})
+		},
+		OfflineAfter: offlineAfter,
+		OnOffline: func() {
+			printer.Emit(observability.UserMessage{
+				Level: observability.MessageWarning,
+				Key:   "offline",
+				Text:  offlineNotice,
+			})
+		},
+		ProbeTransport:     transport(httppool.API, settings, nil),
+		ClockSkewThreshold: clockSkewThreshold,
+		OnQuotaExceeded: func(err *api.QuotaExceededError) {
+			logger.Warn("api: quota exceeded", "limit", err.Limit, "error", err)
+			printer.Emit(observability.UserMessage{
+				Level: observability.MessageError,
+				Key:   "quota-exceeded:" + err.Limit,
+				Text:  quotaNotice(settings, err),
+			})
+		},
 	})
 }
 
+// httpOptions returns the settings' tuning of HTTP connections.
+func httpOptions(settings *settings.Settings) httppool.Options {
+	return httppool.Options{
+		IdleConnTimeout:     settings.GetHTTPIdleConnTimeout(),
+		MaxIdleConnsPerHost: settings.GetHTTPMaxIdleConnsPerHost(),
+		MaxConnsPerHost:     settings.GetHTTPMaxConnsPerHost(),
+		DisableHTTP2:        settings.IsHTTP2Disabled(),
+	}
+}
+
+// transport returns the transport for requests to hosts of the class.
+//
+// Connections come from the process's shared pool, except in a dry run,
+// where requests are recorded in the run's directory instead of being
+// sent.
+func transport(
+	class httppool.Class,
+	settings *settings.Settings,
+	connStats *httppool.Stats,
+) http.RoundTripper {
+	if settings.IsDryRun() {
+		return dryrun.Transport(settings.Proto.GetSyncDir().GetValue())
+	}
+	return httppool.Default.Transport(class, httpOptions(settings), connStats)
+}
+
+// NewGraphQLClient returns a client for the backend's GraphQL API.
+//
+// Connections come from the process's shared pool for the API host, and
+// are counted in connStats if it's not nil. Retries are counted in the
+// retry budget if it's not nil.
 func NewGraphQLClient(
 	backend *api.Backend,
 	settings *settings.Settings,
 	peeker *observability.Peeker,
+	connStats *httppool.Stats,
+	retries *RetryBudget,
 ) graphql.Client {
 	graphqlHeaders := map[string]string{
 		"X-WANDB-USERNAME":   settings.Proto.GetUsername().GetValue(),
@@ -55,17 +151,21 @@ func NewGraphQLClient(
 	maps.Copy(graphqlHeaders, settings.Proto.GetXExtraHttpHeaders().GetValue())
 
 	httpClient := backend.NewClient(api.ClientOptions{
-		RetryPolicy:     clients.CheckRetry,
 		RetryMax:        int(settings.Proto.GetXGraphqlRetryMax().GetValue()),
 		RetryWaitMin:    clients.SecondsToDuration(settings.Proto.GetXGraphqlRetryWaitMinSeconds().GetValue()),
 		RetryWaitMax:    clients.SecondsToDuration(settings.Proto.GetXGraphqlRetryWaitMaxSeconds().GetValue()),
 		NonRetryTimeout: clients.SecondsToDuration(settings.Proto.GetXGraphqlTimeoutSeconds().GetValue()),
+		OnRetry:         retries.Observer(retryEndpointGraphQL),
 		ExtraHeaders:    graphqlHeaders,
 		NetworkPeeker:   peeker,
+		Transport:       transport(httppool.API, settings, connStats),
 	})
 	endpoint := fmt.Sprintf("%s/graphql", settings.Proto.GetBaseUrl().GetValue())
 
-	return graphql.NewClient(endpoint, httpClient)
+	return &quotaGraphQLClient{
+		delegate: graphql.NewClient(endpoint, api.StatusErrors(httpClient)),
+		quota:    backend.Quota(),
+	}
 }
 
 func NewFileStream(
@@ -74,6 +174,12 @@ func NewFileStream(
 	printer *observability.Printer,
 	settings *settings.Settings,
 	peeker api.Peeker,
+	crashReporter *CrashReporter,
+	faultInjector *faults.Injector,
+	connStats *httppool.Stats,
+	retries *RetryBudget,
+	limiter *bandwidth.Limiter,
+	conflict *RunConflict,
 ) filestream.FileStream {
 	fileStreamHeaders := map[string]string{}
 	if settings.Proto.GetXShared().GetValue() {
@@ -85,8 +191,13 @@ func NewFileStream(
 		RetryWaitMin:    clients.SecondsToDuration(settings.Proto.GetXFileStreamRetryWaitMinSeconds().GetValue()),
 		RetryWaitMax:    clients.SecondsToDuration(settings.Proto.GetXFileStreamRetryWaitMaxSeconds().GetValue()),
 		NonRetryTimeout: clients.SecondsToDuration(settings.Proto.GetXFileStreamTimeoutSeconds().GetValue()),
+		OnRetry:         retries.Observer(retryEndpointFileStream),
 		ExtraHeaders:    fileStreamHeaders,
 		NetworkPeeker:   peeker,
+		Transport:       transport(httppool.API, settings, connStats),
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return limiter.Transport(faultInjector.FileStreamTransport(rt))
+		},
 	})
 
 	params := filestream.FileStreamParams{
@@ -95,6 +206,24 @@ func NewFileStream(
 		Printer:   printer,
 		ApiClient: fileStreamRetryClient,
 		ClientId:  utils.ShortID(32),
+
+		TransmitInterval:    settings.GetFileStreamTransmitInterval(),
+		MinTransmitInterval: settings.GetFileStreamMinTransmitInterval(),
+		MaxTransmitInterval: settings.GetFileStreamMaxTransmitInterval(),
+		MaxRequestBytes:     settings.GetFileStreamMaxRequestBytes(),
+		Reachable:           backend.Reachable,
+
+		FatalErrorHandler: func(err error) {
+			// an exceeded limit is the user's to fix, not a crash
+			if errors.As(err, new(*api.QuotaExceededError)) {
+				return
+			}
+			_, reportErr := crashReporter.Report("filestream", err, nil)
+			if reportErr != nil {
+				logger.Error("failed to write crash report", "error", reportErr)
+			}
+		},
+		RunFinishedHandler: conflict.Set,
 	}
 
 	return filestream.NewFileStream(params)
@@ -104,15 +233,27 @@ func NewFileTransferManager(
 	fileTransferStats filetransfer.FileTransferStats,
 	logger *observability.CoreLogger,
 	settings *settings.Settings,
+	faultInjector *faults.Injector,
+	connStats *httppool.Stats,
+	retries *RetryBudget,
+	limiter *bandwidth.Limiter,
+	quota *api.QuotaWatcher,
 ) filetransfer.FileTransferManager {
 	fileTransferRetryClient := retryablehttp.NewClient()
 	fileTransferRetryClient.Logger = logger
 	fileTransferRetryClient.CheckRetry = filetransfer.FileTransferRetryPolicy
+	if onRetry := retries.Observer(retryEndpointFileTransfer); onRetry != nil {
+		fileTransferRetryClient.CheckRetry = api.ObserveRetries(
+			fileTransferRetryClient.CheckRetry, onRetry)
+	}
 	fileTransferRetryClient.RetryMax = int(settings.Proto.GetXFileTransferRetryMax().GetValue())
 	fileTransferRetryClient.RetryWaitMin = clients.SecondsToDuration(settings.Proto.GetXFileTransferRetryWaitMinSeconds().GetValue())
 	fileTransferRetryClient.RetryWaitMax = clients.SecondsToDuration(settings.Proto.GetXFileTransferRetryWaitMaxSeconds().GetValue())
 	fileTransferRetryClient.HTTPClient.Timeout = clients.SecondsToDuration(settings.Proto.GetXFileTransferTimeoutSeconds().GetValue())
 	fileTransferRetryClient.Backoff = clients.ExponentialBackoffWithJitter
+	fileTransferRetryClient.HTTPClient.Transport =
+		limiter.Transport(quota.Transport(faultInjector.UploadTransport(
+			transport(httppool.Storage, settings, connStats))))
 
 	defaultFileTransfer := filetransfer.NewDefaultFileTransfer(
 		fileTransferRetryClient,
@@ -127,22 +268,33 @@ func NewFileTransferManager(
 	)
 }
 
+// NewRunfilesUploader returns the uploader for the run's files.
+//
+// When offline, the file stream, file transfer manager and GraphQL client
+// are nil, and the uploader only exports files. The files uploaded by a
+// previous wandb-core process, if any, aren't uploaded again unchanged.
 func NewRunfilesUploader(
 	ctx context.Context,
 	logger *observability.CoreLogger,
 	settings *settings.Settings,
 	fileStream filestream.FileStream,
 	fileTransfer filetransfer.FileTransferManager,
+	fileTransferStats filetransfer.FileTransferStats,
 	graphQL graphql.Client,
+	exporter *runexport.Exporter,
+	uploaded map[string]transactionlog.UploadedFile,
 ) runfiles.Uploader {
 	return runfiles.NewUploader(runfiles.UploaderParams{
-		Ctx:          ctx,
-		Logger:       logger,
-		Settings:     settings,
-		FileStream:   fileStream,
-		FileTransfer: fileTransfer,
-		GraphQL:      graphQL,
-		FileWatcher:  watcher2.New(watcher2.Params{Logger: logger}),
-		BatchDelay:   waiting.NewDelay(50 * time.Millisecond),
+		Ctx:               ctx,
+		Logger:            logger,
+		Settings:          settings,
+		FileStream:        fileStream,
+		FileTransfer:      fileTransfer,
+		FileTransferStats: fileTransferStats,
+		GraphQL:           graphQL,
+		Exporter:          exporter,
+		Uploaded:          uploaded,
+		FileWatcher:       watcher2.New(watcher2.Params{Logger: logger}),
+		BatchDelay:        waiting.NewDelay(50 * time.Millisecond),
 	})
 }
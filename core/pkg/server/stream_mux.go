@@ -1,77 +1,173 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 )
 
+// ErrStreamMuxClosed is returned when adding a stream to a mux whose
+// streams have all been closed.
+var ErrStreamMuxClosed = errors.New("stream mux is closed")
+
+// ErrStreamNotFound is returned when looking up a stream that was never
+// added to a mux.
+var ErrStreamNotFound = errors.New("stream not found")
+
+// ErrStreamFinished is returned when looking up a stream that was removed
+// from a mux because it finished.
+var ErrStreamFinished = errors.New("stream already finished")
+
 // StreamMux is a multiplexer for streams.
 // It is thread-safe and is used to ensure that
 // only one stream exists for a given streamId so that
 // we can safely add responders to streams.
+//
+// Looking up streams doesn't take a lock, so that many streams can be
+// used concurrently. Connections look up their stream once and keep it.
 type StreamMux struct {
-	mux   map[string]*Stream
-	mutex sync.RWMutex
+	// streams maps stream IDs to *Stream
+	streams sync.Map
+
+	// finished holds the IDs of the streams that were removed
+	finished sync.Map
+
+	// mu orders adding streams with closing all of them, so that no stream
+	// is added after FinishAndCloseAllStreams
+	mu sync.Mutex
+
+	// closed is whether FinishAndCloseAllStreams was called
+	closed bool
+
+	// count is the number of streams in the mux
+	count atomic.Int64
+
+	// maxStreams is the most streams the mux holds at once, if positive
+	maxStreams int
 }
 
 // NewStreamMux creates a new stream mux.
 func NewStreamMux() *StreamMux {
-	return &StreamMux{
-		mux: make(map[string]*Stream),
+	return &StreamMux{}
+}
+
+// SetMaxStreams limits the number of streams in the mux, unless n is zero.
+func (sm *StreamMux) SetMaxStreams(n int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.maxStreams = n
+}
+
+// NumStreams returns the number of streams in the mux.
+func (sm *StreamMux) NumStreams() int {
+	return int(sm.count.Load())
+}
+
+// CheckCapacity returns an ErrAtCapacity error if the mux can't take
+// another stream.
+func (sm *StreamMux) CheckCapacity() error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.checkCapacity()
+}
+
+func (sm *StreamMux) checkCapacity() error {
+	if n := sm.NumStreams(); sm.maxStreams > 0 && n >= sm.maxStreams {
+		return fmt.Errorf("%w: %d of %d runs are open", ErrAtCapacity, n, sm.maxStreams)
 	}
+	return nil
 }
 
 // AddStream adds a stream to the mux if it doesn't already exist.
+//
+// Returns ErrStreamMuxClosed if all streams are being or have been closed,
+// and ErrAtCapacity if the mux already holds as many as it's limited to.
 func (sm *StreamMux) AddStream(streamId string, stream *Stream) error {
-	sm.mutex.Lock()
-	defer sm.mutex.Unlock()
-	if _, ok := sm.mux[streamId]; !ok {
-		sm.mux[streamId] = stream
-		return nil
-	} else {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.closed {
+		return ErrStreamMuxClosed
+	}
+	if err := sm.checkCapacity(); err != nil {
+		return err
+	}
+	if _, loaded := sm.streams.LoadOrStore(streamId, stream); loaded {
 		return fmt.Errorf("stream already exists")
 	}
+	sm.count.Add(1)
+	return nil
 }
 
 // GetStream gets a stream from the mux.
+//
+// Returns ErrStreamFinished if the stream was removed, and
+// ErrStreamNotFound if it was never added.
 func (sm *StreamMux) GetStream(streamId string) (*Stream, error) {
-	sm.mutex.RLock()
-	defer sm.mutex.RUnlock()
-	if stream, ok := sm.mux[streamId]; !ok {
-		return nil, fmt.Errorf("stream not found")
-	} else {
-		return stream, nil
+	if stream, ok := sm.streams.Load(streamId); ok {
+		return stream.(*Stream), nil
 	}
+	if _, ok := sm.finished.Load(streamId); ok {
+		return nil, ErrStreamFinished
+	}
+	return nil, ErrStreamNotFound
 }
 
 // RemoveStream removes a stream from the mux.
+//
+// A stream is returned by at most one call to RemoveStream, and only if
+// FinishAndCloseAllStreams hasn't closed it.
 func (sm *StreamMux) RemoveStream(streamId string) (*Stream, error) {
-	sm.mutex.Lock()
-	defer sm.mutex.Unlock()
-	if stream, ok := sm.mux[streamId]; !ok {
+	if stream, ok := sm.streams.LoadAndDelete(streamId); !ok {
 		return nil, fmt.Errorf("stream not found %s", streamId)
 	} else {
-		delete(sm.mux, streamId)
-		return stream, nil
+		sm.finished.Store(streamId, struct{}{})
+		sm.count.Add(-1)
+		return stream.(*Stream), nil
 	}
 }
 
-// FinishAndCloseAllStreams closes all streams in the mux.
+// FinishAndCloseAllStreams closes all streams in the mux, waiting for
+// their data to be uploaded.
+//
+// Runs that weren't exited are exited with the exit code, as interrupted.
+// Streams can't be added afterward.
 func (sm *StreamMux) FinishAndCloseAllStreams(exitCode int32) {
-	sm.mutex.RLock()
-	defer sm.mutex.RUnlock()
+	sm.closeAllStreams(func(stream *Stream) { stream.Interrupt(exitCode) })
+}
+
+// AbortAllStreams closes all streams in the mux right away, saving their
+// data to their transaction logs without uploading it.
+//
+// Streams can't be added afterward.
+func (sm *StreamMux) AbortAllStreams() {
+	sm.closeAllStreams((*Stream).Abort)
+}
+
+// closeAllStreams removes all streams from the mux and closes them
+// concurrently.
+func (sm *StreamMux) closeAllStreams(closeStream func(*Stream)) {
+	sm.mu.Lock()
+	sm.closed = true
+	sm.mu.Unlock()
 
 	wg := sync.WaitGroup{}
-	for streamId, stream := range sm.mux {
-		wg.Add(1)
-		go func(stream *Stream) {
-			stream.FinishAndClose(exitCode)
-			wg.Done()
-		}(stream)
-		// delete all streams from mux
-		delete(sm.mux, streamId)
-	}
+	sm.streams.Range(func(streamId, _ any) bool {
+		// the stream may have been removed concurrently, in which case
+		// whoever removed it closes it
+		if stream, ok := sm.streams.LoadAndDelete(streamId); ok {
+			sm.finished.Store(streamId, struct{}{})
+			sm.count.Add(-1)
+			wg.Add(1)
+			go func(stream *Stream) {
+				closeStream(stream)
+				wg.Done()
+			}(stream.(*Stream))
+		}
+		return true
+	})
 	wg.Wait()
 	slog.Debug("all streams were closed")
 }
@@ -0,0 +1,160 @@
+package server_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// makeOfflineStream returns a started offline stream.
+func makeOfflineStream(t *testing.T, id string) *server.Stream {
+	dir := t.TempDir()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: id},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), id)
+	require.NoError(t, err)
+	stream.Start()
+	return stream
+}
+
+func TestStreamMux_AddGetRemove(t *testing.T) {
+	mux := server.NewStreamMux()
+	stream := &server.Stream{}
+
+	require.NoError(t, mux.AddStream("a", stream))
+	assert.Error(t, mux.AddStream("a", &server.Stream{}))
+	got, err := mux.GetStream("a")
+	require.NoError(t, err)
+	assert.Same(t, stream, got)
+
+	removed, err := mux.RemoveStream("a")
+	require.NoError(t, err)
+	assert.Same(t, stream, removed)
+	_, err = mux.GetStream("a")
+	assert.Error(t, err)
+	_, err = mux.RemoveStream("a")
+	assert.Error(t, err)
+}
+
+func TestStreamMux_NoStreamsAddedAfterCloseAll(t *testing.T) {
+	mux := server.NewStreamMux()
+
+	mux.FinishAndCloseAllStreams(0)
+
+	assert.ErrorIs(t,
+		mux.AddStream("a", &server.Stream{}),
+		server.ErrStreamMuxClosed)
+}
+
+func TestStreamMux_MaxStreams(t *testing.T) {
+	mux := server.NewStreamMux()
+	mux.SetMaxStreams(2)
+
+	require.NoError(t, mux.AddStream("a", &server.Stream{}))
+	require.NoError(t, mux.AddStream("b", &server.Stream{}))
+	assert.ErrorIs(t, mux.CheckCapacity(), server.ErrAtCapacity)
+	assert.ErrorIs(t,
+		mux.AddStream("c", &server.Stream{}),
+		server.ErrAtCapacity)
+	assert.Equal(t, 2, mux.NumStreams())
+
+	_, err := mux.RemoveStream("a")
+	require.NoError(t, err)
+	assert.NoError(t, mux.AddStream("c", &server.Stream{}))
+	_, err = mux.GetStream("b")
+	assert.NoError(t, err)
+}
+
+// Each stream is closed exactly once when streams are removed while all
+// streams are being closed.
+func TestStreamMux_RemoveDuringCloseAll(t *testing.T) {
+	mux := server.NewStreamMux()
+	const numStreams = 10
+	for i := 0; i < numStreams; i++ {
+		id := fmt.Sprint(i)
+		require.NoError(t, mux.AddStream(id, makeOfflineStream(t, id)))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < numStreams; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if stream, err := mux.RemoveStream(id); err == nil {
+				stream.FinishAndClose(0)
+			}
+		}(fmt.Sprint(i))
+	}
+	mux.FinishAndCloseAllStreams(0)
+	wg.Wait()
+
+	for i := 0; i < numStreams; i++ {
+		_, err := mux.GetStream(fmt.Sprint(i))
+		assert.Error(t, err)
+	}
+}
+
+// 200 short runs start, log 1k records each and finish concurrently.
+func BenchmarkStreamMux_ConcurrentStreams(b *testing.B) {
+	const numStreams = 200
+	const numRecords = 1000
+
+	mux := server.NewStreamMux()
+	ids := make([]string, numStreams)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var wg sync.WaitGroup
+		for _, id := range ids {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				if err := mux.AddStream(id, &server.Stream{}); err != nil {
+					b.Error(err)
+					return
+				}
+				for j := 0; j < numRecords; j++ {
+					if _, err := mux.GetStream(id); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+				if _, err := mux.RemoveStream(id); err != nil {
+					b.Error(err)
+				}
+			}(id)
+		}
+		wg.Wait()
+	}
+}
+
+func TestStreamMux_GetStreamErrors(t *testing.T) {
+	mux := server.NewStreamMux()
+	require.NoError(t, mux.AddStream("a", &server.Stream{}))
+	_, err := mux.RemoveStream("a")
+	require.NoError(t, err)
+
+	_, err = mux.GetStream("a")
+	assert.ErrorIs(t, err, server.ErrStreamFinished)
+	_, err = mux.GetStream("b")
+	assert.ErrorIs(t, err, server.ErrStreamNotFound)
+}
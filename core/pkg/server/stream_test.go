@@ -0,0 +1,238 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// A stream sends its run and history to the backend, and marks the run
+// complete when it finishes.
+func TestStream_SendsRunAndHistoryToBackend(t *testing.T) {
+	fakeBackend := servertest.NewFakeBackend()
+	defer fakeBackend.Close()
+	fakeBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	dir := t.TempDir()
+	s := settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "e2e"},
+		BaseUrl:       &wrapperspb.StringValue{Value: fakeBackend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-e2e.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	})
+
+	stream, err := server.NewStream(s, "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "e2e", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	stream.FinishAndClose(0)
+
+	assert.NotEmpty(t, fakeBackend.GraphQLRequests("UpsertBucket"))
+	var fileStreamBodies []string
+	for _, request := range fakeBackend.Requests(servertest.RouteFileStream) {
+		fileStreamBodies = append(fileStreamBodies, string(request.Body))
+	}
+	require.NotEmpty(t, fileStreamBodies)
+	assert.Contains(t, strings.Join(fileStreamBodies, "\n"), `\"loss\":0.5`)
+	assert.Contains(t, fileStreamBodies[len(fileStreamBodies)-1], `"complete":true`)
+
+	debugLog, err := os.ReadFile(filepath.Join(dir, "debug-internal.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(debugLog), "stream: HTTP connections")
+}
+
+// Config keys excluded by the run's settings are kept in the transaction
+// log but not sent to the backend, including when the offline run is
+// synced later.
+func TestStream_SyncFiltersConfigLikeOfflineRun(t *testing.T) {
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-filter.wandb")
+	offline, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "filter"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+		ConfigExcludeKeys: &service.ListStringValue{
+			Value: []string{"paths.*_dir"},
+		},
+	}), "")
+	require.NoError(t, err)
+	offline.Start()
+	offline.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "filter", Project: "testProject"},
+		},
+	})
+	offline.HandleRecord(&service.Record{
+		RecordType: &service.Record_Config{
+			Config: &service.ConfigRecord{
+				Update: []*service.ConfigItem{
+					{Key: "lr", ValueJson: "0.1"},
+					{NestedKey: []string{"paths", "data_dir"}, ValueJson: `"/secret/data"`},
+					{NestedKey: []string{"paths", "name"}, ValueJson: `"run"`},
+				},
+			},
+		},
+	})
+	offline.FinishAndClose(0)
+
+	log, err := os.ReadFile(syncFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "/secret/data")
+
+	fakeBackend := servertest.NewFakeBackend()
+	defer fakeBackend.Close()
+	fakeBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	syncDir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "filter"},
+		BaseUrl:       &wrapperspb.StringValue{Value: fakeBackend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: syncDir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(syncDir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(syncDir, "files")},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	upserts := fakeBackend.GraphQLRequests("UpsertBucket")
+	require.NotEmpty(t, upserts)
+	var sentConfig bool
+	for _, upsert := range upserts {
+		body := string(upsert.Body)
+		assert.NotContains(t, body, "/secret/data")
+		assert.NotContains(t, body, "data_dir")
+		if strings.Contains(body, `\"name\":\"run\"`) {
+			sentConfig = true
+		}
+	}
+	assert.True(t, sentConfig, "config was not sent")
+}
+
+// unwritableLogFile returns a log file path that can't be created, since
+// its directory would be inside a regular file.
+func unwritableLogFile(t *testing.T) string {
+	notDir := filepath.Join(t.TempDir(), "not-a-dir")
+	require.NoError(t, os.WriteFile(notDir, []byte("x"), 0o644))
+	return filepath.Join(notDir, "logs", "debug-internal.log")
+}
+
+// A stream whose log file can't be written logs to a temporary file
+// instead.
+func TestNewStream_UnwritableLogDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	dir := t.TempDir()
+
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "unwritable"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: unwritableLogFile(t)},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run.wandb")},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.FinishAndClose(0)
+
+	logs, err := filepath.Glob(filepath.Join(tmpDir, "wandb-debug-internal-*.log"))
+	require.NoError(t, err)
+	assert.Len(t, logs, 1)
+}
+
+// A client whose stream can't be created is told why when it starts its
+// run.
+func TestServer_StreamInitErrorReturnedToClient(t *testing.T) {
+	client := startServer(t)()
+	dir := t.TempDir()
+
+	runErr := client.startRunWithSettings("invalid", &service.Settings{
+		RunId:           &wrapperspb.StringValue{Value: "invalid"},
+		LogDir:          &wrapperspb.StringValue{Value: dir},
+		FilesDir:        &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		SyncFile:        &wrapperspb.StringValue{Value: filepath.Join(dir, "run.wandb")},
+		XOffline:        &wrapperspb.BoolValue{Value: true},
+		XFaultInjection: &wrapperspb.StringValue{Value: "not-a-fault"},
+	})
+
+	require.NotNil(t, runErr)
+	assert.Contains(t, runErr.GetMessage(), "invalid fault injection spec")
+	assert.Equal(t, service.ErrorInfo_USAGE, runErr.GetCode())
+}
+
+// Streams given a shared log write their lines there, each tagged with its
+// run's ID, and create no log files of their own.
+func TestNewStream_SharedInternalLog(t *testing.T) {
+	shared := filepath.Join(t.TempDir(), "core.log")
+	var runDirs []string
+	for _, id := range []string{"first", "second"} {
+		dir := t.TempDir()
+		runDirs = append(runDirs, dir)
+		stream, err := server.NewStream(settings.From(&service.Settings{
+			RunId:              &wrapperspb.StringValue{Value: id},
+			LogDir:             &wrapperspb.StringValue{Value: dir},
+			LogInternal:        &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+			SyncFile:           &wrapperspb.StringValue{Value: filepath.Join(dir, "run.wandb")},
+			FilesDir:           &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+			XOffline:           &wrapperspb.BoolValue{Value: true},
+			XDisableStats:      &wrapperspb.BoolValue{Value: true},
+			XSharedInternalLog: &wrapperspb.StringValue{Value: shared},
+		}), "")
+		require.NoError(t, err)
+		stream.Start()
+		stream.HandleRecord(&service.Record{
+			RecordType: &service.Record_Run{Run: &service.RunRecord{RunId: id}},
+		})
+		stream.FinishAndClose(0)
+	}
+
+	content, err := os.ReadFile(shared)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"stream_id":"first"`)
+	assert.Contains(t, string(content), `"stream_id":"second"`)
+	for _, dir := range runDirs {
+		assert.NoFileExists(t, filepath.Join(dir, "debug-internal.log"))
+	}
+}
@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/wandb/wandb/core/internal/loadgen"
+	"github.com/wandb/wandb/core/internal/servertest"
+)
+
+// BenchmarkStream sends each mix of records through a stream against the
+// fake backend, from the handler to the sender.
+//
+// Run it with scripts/run-benchmarks.sh, and compare with the baseline in
+// testdata/benchmark_stream.txt.
+func BenchmarkStream(b *testing.B) {
+	names := make([]string, 0, len(loadgen.Mixes))
+	for name := range loadgen.Mixes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		b.Run(name, func(b *testing.B) {
+			backend := servertest.NewFakeBackend()
+			defer backend.Close()
+			backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+			backend.StubCreateRunFiles()
+
+			session, err := loadgen.Start(
+				loadgen.Settings(b.TempDir(), "benchmark", backend.URL(), "test-api-key"),
+				"benchmark",
+			)
+			require.NoError(b, err)
+			defer session.Finish(0)
+			generator, err := session.Generator(loadgen.Mixes[name])
+			require.NoError(b, err)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			result, err := session.Send(generator, b.N)
+			b.StopTimer()
+			require.NoError(b, err)
+
+			p95, err := result.Percentile(0.95)
+			require.NoError(b, err)
+			b.ReportMetric(result.RecordsPerSecond(), "records/s")
+			b.ReportMetric(float64(p95.Microseconds())/1000, "p95-ms")
+		})
+	}
+}
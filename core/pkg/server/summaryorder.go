@@ -0,0 +1,64 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// explicitSummary is the summary keys the user set since they last logged
+// them to history.
+//
+// History is buffered until its step is flushed, so a row's automatic
+// summary is made after any summary update the user set while the row
+// was being logged. Those updates win: the summary is what the user set
+// last. Keys logged to history again after being set are released, and
+// all keys are released once the row is flushed.
+//
+// The zero value tracks nothing.
+type explicitSummary struct {
+	keys map[string]struct{}
+}
+
+// Set records the keys of a summary update the user made.
+func (e *explicitSummary) Set(summary *service.SummaryRecord) {
+	if e.keys == nil {
+		e.keys = make(map[string]struct{})
+	}
+	for _, item := range summary.GetUpdate() {
+		e.keys[summaryKey(item.GetKey(), item.GetNestedKey())] = struct{}{}
+	}
+	for _, item := range summary.GetRemove() {
+		e.keys[summaryKey(item.GetKey(), item.GetNestedKey())] = struct{}{}
+	}
+}
+
+// Logged releases the keys logged to history after they were set.
+func (e *explicitSummary) Logged(items []*service.HistoryItem) {
+	if len(e.keys) == 0 {
+		return
+	}
+	for _, item := range items {
+		delete(e.keys, summaryKey(item.GetKey(), item.GetNestedKey()))
+	}
+}
+
+// Has returns whether the user set the history item's key in the summary
+// since logging it.
+func (e *explicitSummary) Has(item *service.HistoryItem) bool {
+	_, ok := e.keys[summaryKey(item.GetKey(), item.GetNestedKey())]
+	return ok
+}
+
+// Flushed releases all keys, once the history row is summarized.
+func (e *explicitSummary) Flushed() {
+	clear(e.keys)
+}
+
+// summaryKey identifies a summary or history key, nested or dotted.
+func summaryKey(key string, nestedKey []string) string {
+	if len(nestedKey) > 0 {
+		return strings.Join(nestedKey, ".")
+	}
+	return key
+}
@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func makeSummaryRecord(key, valueJSON string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Summary{
+			Summary: &service.SummaryRecord{
+				Update: []*service.SummaryItem{{Key: key, ValueJson: valueJSON}},
+			},
+		},
+	}
+}
+
+// A summary value set while its history row is being logged isn't
+// overwritten by the row's automatic summary, but one logged after the
+// summary value is set overwrites it.
+func TestStream_ExplicitSummaryWinsOverPendingHistory(t *testing.T) {
+	dir := t.TempDir()
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "summary"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-summary.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "summary", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	// step 0: the summary is set after the row's value is logged
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items: map[string]string{"acc": "1"},
+		step:  0,
+	}))
+	stream.HandleRecord(makeSummaryRecord("acc", "0.9"))
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items: map[string]string{"loss": "0.5"},
+		step:  0,
+		flush: true,
+	}))
+	// step 1: the row's value is logged after the summary is set
+	stream.HandleRecord(makeSummaryRecord("loss", "7"))
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items: map[string]string{"loss": "0.25"},
+		step:  1,
+		flush: true,
+	}))
+	stream.FinishAndClose(0)
+
+	var historyLines int
+	var summary map[string]any
+	for _, request := range backend.Requests(servertest.RouteFileStream) {
+		var body struct {
+			Files map[string]struct {
+				Content []string `json:"content"`
+			} `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(request.Body, &body))
+		historyLines += len(body.Files["wandb-history.jsonl"].Content)
+
+		content := body.Files["wandb-summary.json"].Content
+		if len(content) == 0 {
+			continue
+		}
+		require.NoError(t, json.Unmarshal([]byte(content[len(content)-1]), &summary))
+		// the summary never gets ahead of the history it's derived from
+		if summary["loss"] == 0.25 {
+			assert.Equal(t, 2, historyLines)
+		}
+	}
+	assert.Equal(t, 2, historyLines)
+	assert.EqualValues(t, 0.9, summary["acc"])
+	assert.EqualValues(t, 0.25, summary["loss"])
+}
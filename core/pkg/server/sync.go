@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
@@ -18,10 +19,28 @@ type SyncService struct {
 	inChan     chan *service.Record
 	// Result of offline sync to pass to the client when syncing is done
 	flushCallback func(error)
-	exitSeen      bool
 	syncErr       error
 	overwrite     *service.SyncOverwrite
 	skip          *service.SyncSkip
+
+	// exit is the run's exit, synced once all other records are
+	exit *service.Record
+
+	// incompleteErr says which records are missing from the transaction
+	// log, if any
+	incompleteErr error
+
+	// discarded is whether the run was aborted, so that only its exit is
+	// synced
+	discarded bool
+
+	// conflicted is whether the run was finished on the server while it
+	// was running, so that only its exit is synced
+	conflicted bool
+
+	// modifiedErr says how the transaction log changed after the run
+	// finished, if it did, so that only its exit is synced
+	modifiedErr error
 }
 
 type SyncServiceOption func(*SyncService)
@@ -68,21 +87,82 @@ func WithSyncServiceFlushCallback(syncResultCallback func(error)) SyncServiceOpt
 	}
 }
 
+// SetIncomplete notes that records are missing from the transaction log,
+// which fails the sync even if the records in the log are synced.
+func (s *SyncService) SetIncomplete(err error) {
+	if s == nil {
+		return
+	}
+	s.incompleteErr = err
+}
+
+// SetDiscarded notes that the run was aborted by the client.
+//
+// Only its exit is synced, to finish the sync, which fails.
+func (s *SyncService) SetDiscarded() {
+	if s == nil {
+		return
+	}
+	s.discarded = true
+}
+
+// SetConflicted notes that the run was finished on the server while it
+// was running, or while it's synced.
+//
+// Only its exit is synced, to finish the sync, which fails.
+func (s *SyncService) SetConflicted() {
+	if s == nil {
+		return
+	}
+	s.conflicted = true
+}
+
+// SetModified notes that the transaction log changed after the run
+// finished.
+//
+// Only its exit is synced, to finish the sync, which fails with the error.
+func (s *SyncService) SetModified(err error) {
+	if s == nil {
+		return
+	}
+	s.modifiedErr = err
+}
+
+// SkipsData is whether only the run's exit is synced.
+func (s *SyncService) SkipsData() bool {
+	if s == nil {
+		return false
+	}
+	return s.discarded || s.conflicted || s.modifiedErr != nil
+}
+
 func (s *SyncService) SyncRecord(record *service.Record, err error) {
 	if err != nil && err != io.EOF {
 		s.syncErr = err
 	}
 
-	if err != nil && !s.exitSeen {
-		record = &service.Record{
-			RecordType: &service.Record_Exit{
-				Exit: &service.RunExitRecord{
-					ExitCode: 1,
+	switch {
+	case err != nil:
+		exit := s.exit
+		if exit == nil {
+			exit = &service.Record{
+				RecordType: &service.Record_Exit{
+					Exit: &service.RunExitRecord{
+						ExitCode: 1,
+					},
 				},
-			},
+			}
 		}
-		s.inChan <- record
-	} else if record != nil {
+		s.inChan <- exit
+	case s.SkipsData() && record.GetExit() == nil:
+		// an aborted run's data isn't synced, nor appended to a run that
+		// was finished on the server, nor taken from a modified log
+	case record.GetExit() != nil:
+		// The exit finishes the run, so it's synced after everything else,
+		// including records saved after it, like files whose uploads were
+		// left unfinished when the run's exit timed out.
+		s.exit = record
+	case record != nil:
 		s.inChan <- record
 	}
 }
@@ -144,7 +224,6 @@ func (s *SyncService) syncRun(record *service.Record) {
 }
 
 func (s *SyncService) syncExit(record *service.Record) {
-	s.exitSeen = true
 	s.senderFunc(record)
 }
 
@@ -164,6 +243,14 @@ func (s *SyncService) Flush() {
 		s.logger.CaptureError("Flush without callback", fmt.Errorf("flushing sync service"))
 		return
 	}
-	s.flushCallback(s.syncErr)
+	var discardedErr error
+	switch {
+	case s.discarded:
+		discardedErr = errRunDiscarded
+	case s.conflicted:
+		discardedErr = errRunConflicted
+	}
+	s.flushCallback(errors.Join(
+		discardedErr, s.modifiedErr, s.incompleteErr, s.syncErr))
 
 }
@@ -172,4 +172,19 @@ func TestSyncService(t *testing.T) {
 		assert.True(t, callbackCalled)
 	})
 
+	// Test sync of a transaction log that's missing records
+	t.Run("SyncRecord of an incomplete log", func(t *testing.T) {
+		var syncErr error
+		mockSender := MockSender{}
+		syncService := server.NewSyncService(context.Background(),
+			server.WithSyncServiceSenderFunc(mockSender.Send),
+			server.WithSyncServiceFlushCallback(func(err error) { syncErr = err }),
+		)
+		syncService.Start()
+		syncService.SetIncomplete(errors.New("missing records"))
+		syncService.SyncRecord(nil, io.EOF)
+		syncService.Flush()
+		assert.EqualError(t, syncErr, "missing records")
+		assert.Len(t, mockSender.Records, 1)
+	})
 }
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	fs "github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// syncStateFile records the sync state of a run's transaction log, which
+// the sender and the filestream's callbacks both write.
+//
+// Once the log is marked as fully synced, other states are dropped, so that
+// a late acknowledgement doesn't mark a finished run as unfinished.
+type syncStateFile struct {
+	mu sync.Mutex
+
+	// path is the transaction log's path
+	path string
+
+	// complete is whether the log was marked as fully synced
+	complete bool
+}
+
+// newSyncStateFile returns the sync state of the transaction log at the
+// path, or nil if the run has no log.
+func newSyncStateFile(path string) *syncStateFile {
+	if path == "" {
+		return nil
+	}
+	return &syncStateFile{path: path}
+}
+
+// write records the state, unless the log was already fully synced.
+func (f *syncStateFile) write(state transactionlog.SyncState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.complete {
+		return nil
+	}
+	if err := transactionlog.WriteSyncState(f.path, state); err != nil {
+		return err
+	}
+	f.complete = state.Complete
+	return nil
+}
+
+// markSynced records that the transaction log fully reached the server,
+// so that the cleanup may delete the run's directory.
+//
+// Nothing is recorded if anything may be missing from the server: if the
+// run was offline, its exit timed out, or the log itself is incomplete.
+func (s *Sender) markSynced() {
+	if s.syncState == nil || s.backend == nil || s.offline != nil || s.unfinished != nil {
+		return
+	}
+	if gap, err := readLogGap(s.syncState.path); gap != nil || err != nil {
+		return
+	}
+
+	err := s.syncState.write(transactionlog.SyncState{
+		LastAcked: s.lastSentNum,
+		Complete:  true,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		s.logger.Error("sender: can't record the sync state", "error", err, "path", s.syncState.path)
+	}
+}
+
+// saveSyncState records how much of the run reached the server so far, so
+// that a restarted wandb-core continues the run from there.
+//
+// The data buffered for the filestream is sent right away, and the state
+// is recorded once the server acknowledges it: by then, everything
+// streamed from the records processed so far reached the server.
+func (s *Sender) saveSyncState() {
+	if s.syncState == nil || !s.fileStreamStarted ||
+		s.settings.GetXSync().GetValue() ||
+		s.offline != nil || s.exitRecord != nil {
+		return
+	}
+
+	state := transactionlog.SyncState{
+		LastAcked:      s.lastSentNum,
+		ArtifactDrafts: s.artifactDrafts.list(),
+	}
+	if s.runfilesUploader != nil {
+		state.PendingFiles = s.runfilesUploader.Unfinished()
+		state.UploadedFiles = s.runfilesUploader.Uploaded()
+	}
+
+	s.flushOutput()
+	s.summaryDebouncer.Flush(s.streamSummary)
+	s.fileStream.StreamUpdate(&fs.FlushUpdate{
+		OnAcked: func(lines map[string]int) {
+			state.FileStreamOffsets = lines
+			state.UpdatedAt = time.Now()
+			if err := s.syncState.write(state); err != nil {
+				s.logger.Error(
+					"sender: can't record the sync state",
+					"error", err,
+					"path", s.syncState.path,
+				)
+			}
+		},
+	})
+}
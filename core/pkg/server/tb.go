@@ -41,8 +41,11 @@ func NewTBHandler(
 	return tb
 }
 
+// Handle starts watching the record's directory for tensorboard events.
+//
+// A nil TBHandler, as in a disabled run, ignores the record.
 func (tb *TBHandler) Handle(record *service.Record) error {
-	if !tb.Active {
+	if tb == nil || !tb.Active {
 		return nil
 	}
 
@@ -119,5 +122,8 @@ func (tb *TBHandler) Handle(record *service.Record) error {
 }
 
 func (tb *TBHandler) Close() {
+	if tb == nil {
+		return
+	}
 	tb.Active = false
 }
@@ -0,0 +1,268 @@
+package server_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// startTornDownRun starts an online run in a mux, which logs a row of
+// history, and returns the mux and the run's transaction log.
+func startTornDownRun(
+	t *testing.T,
+	fakeBackend *servertest.FakeBackend,
+	wandbDir string,
+) (*server.StreamMux, string) {
+	runDir := filepath.Join(wandbDir, "run-teardown")
+	syncFile := filepath.Join(runDir, "run-teardown.wandb")
+	s := settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "teardown"},
+		BaseUrl:       &wrapperspb.StringValue{Value: fakeBackend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		WandbDir:      &wrapperspb.StringValue{Value: wandbDir},
+		LogDir:        &wrapperspb.StringValue{Value: filepath.Join(runDir, "logs")},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(runDir, "logs", "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(runDir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	})
+	_, err := s.PrepareRunDirs()
+	require.NoError(t, err)
+
+	stream, err := server.NewStream(s, "")
+	require.NoError(t, err)
+	stream.Start()
+	mux := server.NewStreamMux()
+	require.NoError(t, mux.AddStream("teardown", stream))
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "teardown", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	return mux, syncFile
+}
+
+// readLog returns the records in the transaction log.
+func readLog(t *testing.T, path string) []*service.Record {
+	reader, err := transactionlog.Open(path)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var records []*service.Record
+	for {
+		record, _, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+}
+
+// fileStreamBodies returns the bodies of the filestream requests.
+func fileStreamBodies(fakeBackend *servertest.FakeBackend) string {
+	var bodies []string
+	for _, request := range fakeBackend.Requests(servertest.RouteFileStream) {
+		bodies = append(bodies, string(request.Body))
+	}
+	return strings.Join(bodies, "\n")
+}
+
+func registryState(t *testing.T, wandbDir string) string {
+	runs, err := runregistry.New(wandbDir).Runs()
+	require.NoError(t, err)
+	require.NotEmpty(t, runs)
+	return runs[len(runs)-1].State
+}
+
+// A teardown exits the runs whose processes didn't, as interrupted, and
+// uploads their data.
+func TestStreamMux_TeardownInterruptsRuns(t *testing.T) {
+	fakeBackend := servertest.NewFakeBackend()
+	defer fakeBackend.Close()
+	fakeBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	wandbDir := t.TempDir()
+	mux, syncFile := startTornDownRun(t, fakeBackend, wandbDir)
+
+	mux.FinishAndCloseAllStreams(3)
+
+	bodies := fileStreamBodies(fakeBackend)
+	assert.Contains(t, bodies, `\"loss\":0.5`)
+	assert.Contains(t, bodies, `"complete":true`)
+	assert.Contains(t, bodies, `"exitcode":3`)
+	var exit *service.RunExitRecord
+	for _, record := range readLog(t, syncFile) {
+		if record.GetExit() != nil {
+			exit = record.GetExit()
+		}
+	}
+	require.NotNil(t, exit)
+	assert.True(t, exit.GetInterrupted())
+	assert.EqualValues(t, 3, exit.GetExitCode())
+	assert.Equal(t, runregistry.StateInterrupted, registryState(t, wandbDir))
+}
+
+// A forced teardown saves the runs' data without uploading the rest, and
+// the runs sync later as preempted crashes.
+func TestStreamMux_ForcedTeardownAbortsRuns(t *testing.T) {
+	fakeBackend := servertest.NewFakeBackend()
+	defer fakeBackend.Close()
+	fakeBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	wandbDir := t.TempDir()
+	mux, syncFile := startTornDownRun(t, fakeBackend, wandbDir)
+
+	mux.AbortAllStreams()
+
+	assert.NotContains(t, fileStreamBodies(fakeBackend), `"complete":true`)
+	var history, preempting, exits int
+	for _, record := range readLog(t, syncFile) {
+		switch {
+		case record.GetHistory() != nil:
+			history++
+		case record.GetPreempting() != nil:
+			preempting++
+		case record.GetExit() != nil:
+			exits++
+		}
+	}
+	assert.Equal(t, 1, history)
+	assert.Equal(t, 1, preempting)
+	assert.Zero(t, exits)
+	assert.Equal(t, runregistry.StateCrashed, registryState(t, wandbDir))
+
+	syncBackend := servertest.NewFakeBackend()
+	defer syncBackend.Close()
+	syncBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	syncDir := t.TempDir()
+	sync, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "teardown"},
+		BaseUrl:       &wrapperspb.StringValue{Value: syncBackend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: syncDir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(syncDir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(syncDir, "files")},
+		XSync:         &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	sync.Start()
+	sync.HandleRecord(&service.Record{
+		RecordType: &service.Record_Request{
+			Request: &service.Request{
+				RequestType: &service.Request_Sync{Sync: &service.SyncRequest{}},
+			},
+		},
+	})
+	sync.FinishAndClose(0)
+
+	bodies := fileStreamBodies(syncBackend)
+	assert.Contains(t, bodies, `\"loss\":0.5`)
+	assert.Contains(t, bodies, `"preempting":true`)
+	assert.Contains(t, bodies, `"exitcode":1`)
+}
+
+// A forced close stops the uploads in flight without waiting for them,
+// but only once every record handled is saved.
+func TestStream_AbortDuringSlowUploadSavesEveryRecord(t *testing.T) {
+	fakeBackend := servertest.NewFakeBackend()
+	defer fakeBackend.Close()
+	fakeBackend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	fakeBackend.StubCreateRunFiles()
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(filesDir, "data.txt"), []byte("data"), 0o644))
+	syncFile := filepath.Join(dir, "run-abort.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "abort"},
+		BaseUrl:       &wrapperspb.StringValue{Value: fakeBackend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XFaultInjection: &wrapperspb.StringValue{Value: "upload-delay=1h,writer-delay=2ms"},
+	}), "")
+	require.NoError(t, err)
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "abort", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{Path: "data.txt"}},
+			},
+		},
+	})
+	require.Eventually(t,
+		func() bool {
+			for _, request := range fakeBackend.Requests(servertest.RouteGraphQL) {
+				if strings.Contains(string(request.Body), "CreateRunFiles") {
+					return true
+				}
+			}
+			return false
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+	for i := range 100 {
+		stream.HandleRecord(makePartialHistoryRecord(data{
+			items:   map[string]string{"loss": fmt.Sprint(i)},
+			flush:   true,
+			stepNil: true,
+		}))
+	}
+
+	start := time.Now()
+	stream.Abort()
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Empty(t, fakeBackend.Requests(servertest.RouteUpload))
+	var history, files, preempting int
+	for _, record := range readLog(t, syncFile) {
+		switch {
+		case record.GetHistory() != nil:
+			history++
+		case record.GetFiles() != nil:
+			files++
+		case record.GetPreempting() != nil:
+			preempting++
+		}
+	}
+	assert.Equal(t, 100, history)
+	assert.Equal(t, 1, files)
+	assert.Equal(t, 1, preempting)
+}
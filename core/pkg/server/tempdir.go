@@ -0,0 +1,66 @@
+package server
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/internal/runtmp"
+)
+
+// newTempDir returns the stream's temporary directory in its run
+// directory, or nil if it has none.
+//
+// The directory is only created once a component needs it.
+func (s *Stream) newTempDir() *runtmp.Dir {
+	dirs := s.settings.GetRunDirs()
+	if dirs == nil || dirs.SyncFile == "" {
+		return nil
+	}
+	return runtmp.New(filepath.Dir(dirs.SyncFile))
+}
+
+// startTempSweep removes in the background the temporary directories left
+// behind by streams that crashed, in the run's directory and in those of
+// the runs in the registry.
+func (s *Stream) startTempSweep() {
+	dirs := s.settings.GetRunDirs()
+	if dirs == nil || dirs.SyncFile == "" {
+		return
+	}
+	runDirs := []string{filepath.Dir(dirs.SyncFile)}
+	registry := s.registry
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if registry != nil {
+			runs, err := registry.Runs()
+			if err != nil {
+				s.logger.Warn("stream: can't read the registry", "error", err)
+			}
+			for _, run := range runs {
+				if run.State != runregistry.StateDeleted &&
+					!slices.Contains(runDirs, run.Path) {
+					runDirs = append(runDirs, run.Path)
+				}
+			}
+		}
+
+		removed, err := runtmp.Sweep(runDirs)
+		for _, path := range removed {
+			s.logger.Info("stream: removed an orphaned temporary directory", "path", path)
+		}
+		if err != nil {
+			s.logger.Warn("stream: failed to remove orphaned temporary directories", "error", err)
+		}
+	}()
+}
+
+// removeTempDir deletes the stream's temporary directory.
+func (s *Stream) removeTempDir() {
+	if err := s.tempDir.Remove(); err != nil {
+		s.logger.Error("stream: failed to remove the temporary directory", "error", err)
+	}
+}
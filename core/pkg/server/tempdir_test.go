@@ -0,0 +1,146 @@
+package server_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/runregistry"
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// startTableRun starts a run in the wandb directory that logs a table
+// with more rows than are kept in memory, so that they're spilled to the
+// stream's temporary directory.
+func startTableRun(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	wandbDir string,
+) (*server.Stream, string) {
+	t.Helper()
+	runDir := filepath.Join(wandbDir, "run-tempdir")
+	s := settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "tempdir"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		WandbDir:      &wrapperspb.StringValue{Value: wandbDir},
+		LogDir:        &wrapperspb.StringValue{Value: runDir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(runDir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(runDir, "run-tempdir.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(runDir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	})
+	_, err := s.PrepareRunDirs()
+	require.NoError(t, err)
+
+	stream, err := server.NewStream(s, "")
+	require.NoError(t, err)
+	stream.Start()
+
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "tempdir", Project: "testProject"},
+		},
+	})
+	stream.HandleRecord(makeRunStartRecord())
+	rows := make([]*service.TableRow, 1500)
+	for i := range rows {
+		rows[i] = &service.TableRow{ValuesJson: []string{fmt.Sprint(i)}}
+	}
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_TableRows{
+			TableRows: &service.TableRowsRecord{
+				Key:     "predictions",
+				Columns: []string{"step"},
+				Rows:    rows,
+			},
+		},
+	})
+	return stream, runDir
+}
+
+// leftovers returns the hidden files and directories that a stream left
+// in the run directory.
+func leftovers(t *testing.T, runDir string) []string {
+	t.Helper()
+	var hidden []string
+	err := filepath.WalkDir(runDir, func(path string, _ os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(filepath.Base(path), ".") {
+			hidden = append(hidden, path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return hidden
+}
+
+func TestStream_RemovesTempDirOnClose(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, runDir := startTableRun(t, backend, t.TempDir())
+
+	stream.FinishAndClose(0)
+
+	assert.FileExists(t,
+		filepath.Join(runDir, "files", "media", "table", "predictions.table.json"))
+	assert.Empty(t, leftovers(t, runDir))
+}
+
+func TestStream_RemovesTempDirOnAbort(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	stream, runDir := startTableRun(t, backend, t.TempDir())
+	require.Eventually(t, func() bool {
+		return len(leftovers(t, runDir)) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stream.Abort()
+
+	assert.Empty(t, leftovers(t, runDir))
+}
+
+// A new stream removes the temporary directories left behind in the
+// wandb directory's runs by streams that crashed.
+func TestStream_SweepsTempDirsOfCrashedStreams(t *testing.T) {
+	wandbDir := t.TempDir()
+	crashedRunDir := filepath.Join(wandbDir, "run-crashed")
+	// the PID is this process's, as if it was reused after the crash
+	crashedTempDir := filepath.Join(crashedRunDir,
+		fmt.Sprintf(".wandb-tmp-%d-123", os.Getpid()))
+	require.NoError(t, os.MkdirAll(crashedTempDir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(crashedTempDir, "table-1.rows"), []byte("[1]\n"), 0o644))
+	require.NoError(t, runregistry.New(wandbDir).Record(runregistry.Entry{
+		RunID: "crashed",
+		Path:  crashedRunDir,
+		State: runregistry.StateRunning,
+	}))
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+
+	stream, _ := startTableRun(t, backend, wandbDir)
+	stream.FinishAndClose(0)
+
+	assert.NoDirExists(t, crashedTempDir)
+	assert.DirExists(t, crashedRunDir)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// unknownTypeNumber returns the field number of the message's type if it
+// is one that this version of wandb-core doesn't know, or 0.
+//
+// Newer clients may send types of records and requests added after this
+// version. Parsing keeps a field it doesn't know aside as an unknown
+// field, so a message of an unknown type has none of the oneof's fields
+// set, and an unknown field holding a message.
+func unknownTypeNumber(m protoreflect.Message, oneof protoreflect.Name) protowire.Number {
+	if !m.IsValid() || m.WhichOneof(m.Descriptor().Oneofs().ByName(oneof)) != nil {
+		return 0
+	}
+
+	unknown := m.GetUnknown()
+	for len(unknown) > 0 {
+		number, wireType, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return 0
+		}
+		unknown = unknown[n:]
+		if wireType == protowire.BytesType {
+			return number
+		}
+
+		n = protowire.ConsumeFieldValue(number, wireType, unknown)
+		if n < 0 {
+			return 0
+		}
+		unknown = unknown[n:]
+	}
+	return 0
+}
+
+// isUnknownType returns whether the record, or the request it holds, is of
+// a type that this version of wandb-core doesn't know.
+func isUnknownType(record *service.Record) bool {
+	return unknownTypeNumber(record.ProtoReflect(), "record_type") != 0 ||
+		unknownTypeNumber(record.GetRequest().ProtoReflect(), "request_type") != 0
+}
+
+// UnknownTypes counts the records and requests of types that this version
+// of wandb-core doesn't know, by the name that recordTypeName gives them.
+//
+// The methods of a nil UnknownTypes count nothing.
+type UnknownTypes struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func NewUnknownTypes() *UnknownTypes {
+	return &UnknownTypes{counts: make(map[string]int64)}
+}
+
+// add counts a record of the type, and returns whether it's the first.
+//
+// It always returns true if the counts are nil.
+func (u *UnknownTypes) add(name string) bool {
+	if u == nil {
+		return true
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.counts[name]++
+	return u.counts[name] == 1
+}
+
+// Counts returns the number of records of each unknown type.
+func (u *UnknownTypes) Counts() map[string]int64 {
+	if u == nil {
+		return nil
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return maps.Clone(u.counts)
+}
+
+// handleUnknownType handles a record of a type that this version of
+// wandb-core doesn't know.
+//
+// Unknown records are saved to the transaction log as they are, so that a
+// newer version can sync them, but aren't sent. Unknown requests are
+// answered with an error, as the client may be waiting on them.
+func (h *Handler) handleUnknownType(record *service.Record) {
+	name := recordTypeName(record)
+	if h.unknownTypes.add(name) {
+		h.logger.Warn(
+			"handler: record type unknown to this version",
+			observability.RecordTypeKey, name,
+			"version", version.Version,
+		)
+	}
+
+	if record.GetRequest() == nil {
+		h.fwdRecordWithControl(record,
+			func(control *service.Control) {
+				control.PersistOnly = true
+			},
+		)
+		return
+	}
+
+	if !record.GetControl().GetReqResp() && record.GetControl().GetMailboxSlot() == "" {
+		return
+	}
+	h.outChan <- &service.Result{
+		Rejected: &service.ErrorInfo{
+			Code: service.ErrorInfo_UNSUPPORTED,
+			Message: fmt.Sprintf(
+				"the request (%s) is unsupported by this version of"+
+					" wandb-core (%s), which is older than the client",
+				name,
+				version.Version,
+			),
+		},
+		Control: record.Control,
+		Uuid:    record.Uuid,
+	}
+}
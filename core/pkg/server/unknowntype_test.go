@@ -0,0 +1,157 @@
+package server_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/internal/version"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// futureTypeNumber is the field number of the types of record and request
+// that a newer client sends in these tests.
+const futureTypeNumber = 500
+
+// futureMessage returns a message with a field of the type numbered
+// futureTypeNumber, encoded.
+func futureMessage() []byte {
+	payload := protowire.AppendTag(nil, 1, protowire.BytesType)
+	payload = protowire.AppendString(payload, "from the future")
+
+	message := protowire.AppendTag(nil, futureTypeNumber, protowire.BytesType)
+	return protowire.AppendBytes(message, payload)
+}
+
+// futureRecord returns a record of a type added after this version, as
+// it's read from a newer client.
+func futureRecord(t *testing.T, uuid string) *service.Record {
+	data, err := proto.Marshal(&service.Record{
+		Control: &service.Control{ConnectionId: "client"},
+		Uuid:    uuid,
+	})
+	require.NoError(t, err)
+
+	record := &service.Record{}
+	require.NoError(t, proto.Unmarshal(append(data, futureMessage()...), record))
+	require.Nil(t, record.RecordType)
+	return record
+}
+
+// futureRequest returns a request of a type added after this version, as
+// it's read from a newer client.
+func futureRequest(t *testing.T, uuid string) *service.Record {
+	data, err := proto.Marshal(disabledRequest(uuid, &service.Request{}))
+	require.NoError(t, err)
+	requestField := (&service.Record{}).ProtoReflect().Descriptor().
+		Fields().ByName("request").Number()
+	// an empty request is encoded as its tag and length, so the fields of
+	// another with the same number are merged into it
+	data = protowire.AppendTag(data, requestField, protowire.BytesType)
+	data = protowire.AppendBytes(data, futureMessage())
+
+	record := &service.Record{}
+	require.NoError(t, proto.Unmarshal(data, record))
+	require.NotNil(t, record.GetRequest())
+	require.Nil(t, record.GetRequest().RequestType)
+	return record
+}
+
+func newUnknownTypeStream(t *testing.T) (*server.Stream, chanResponder, string) {
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "run-future.wandb")
+	stream, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "future"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: syncFile},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XOffline:      &wrapperspb.BoolValue{Value: true},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 64)
+	stream.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	stream.Start()
+	stream.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "future", Project: "future"},
+		},
+	})
+	return stream, responses, syncFile
+}
+
+// A record of a type from a newer client is saved as it is, so that a
+// newer version can sync it, and the stream goes on.
+func TestStream_UnknownRecordIsSavedAsIs(t *testing.T) {
+	stream, responses, syncFile := newUnknownTypeStream(t)
+
+	stream.HandleRecord(futureRecord(t, "future"))
+	stream.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	stream.HandleRecord(disabledRequest("status", &service.Request{
+		RequestType: &service.Request_Status{Status: &service.StatusRequest{}},
+	}))
+	status := awaitResult(t, responses, "status").GetResponse().GetStatusResponse()
+	stream.FinishAndClose(0)
+
+	assert.Equal(t,
+		map[string]int64{"unknown_500": 1},
+		status.GetDiagnostics().GetUnknownRecordTypes())
+	var saved, history int
+	for _, record := range readLog(t, syncFile) {
+		if record.GetHistory() != nil {
+			history++
+		}
+		if record.RecordType == nil &&
+			bytes.Contains(record.ProtoReflect().GetUnknown(), futureMessage()) {
+			saved++
+			assert.Equal(t, "future", record.GetUuid())
+		}
+	}
+	assert.Equal(t, 1, saved)
+	assert.Equal(t, 1, history)
+
+	// syncing the log with this version skips the record
+	_, err := server.Replay(syncFile, -1, false)
+	assert.NoError(t, err)
+}
+
+// A request of a type from a newer client is answered with an error that
+// names this version, and isn't saved.
+func TestStream_UnknownRequestIsUnsupported(t *testing.T) {
+	stream, responses, syncFile := newUnknownTypeStream(t)
+
+	stream.HandleRecord(futureRequest(t, "first"))
+	first := awaitResult(t, responses, "first")
+	stream.HandleRecord(futureRequest(t, "second"))
+	second := awaitResult(t, responses, "second")
+	stream.HandleRecord(disabledRequest("status", &service.Request{
+		RequestType: &service.Request_Status{Status: &service.StatusRequest{}},
+	}))
+	status := awaitResult(t, responses, "status").GetResponse().GetStatusResponse()
+	stream.FinishAndClose(0)
+
+	assert.Equal(t, service.ErrorInfo_UNSUPPORTED, first.GetRejected().GetCode())
+	assert.Contains(t, first.GetRejected().GetMessage(), "request.unknown_500")
+	assert.Contains(t, first.GetRejected().GetMessage(), version.Version)
+	assert.Equal(t, first.GetRejected(), second.GetRejected())
+	assert.Equal(t,
+		map[string]int64{"request.unknown_500": 2},
+		status.GetDiagnostics().GetUnknownRecordTypes())
+	for _, record := range readLog(t, syncFile) {
+		assert.Nil(t, record.GetRequest(), "a request was saved")
+	}
+}
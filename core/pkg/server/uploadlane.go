@@ -0,0 +1,62 @@
+package server
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// uploadLane runs file transfer work off the sender's main loop.
+//
+// Uploading an artifact can take minutes, and history, summary and other
+// filestream traffic queued behind it would otherwise wait as long. Tasks
+// in the lane run one at a time in the order they were added, so that an
+// artifact is saved before it is linked, but not in any particular order
+// relative to records processed by the main loop.
+type uploadLane struct {
+	mu sync.Mutex
+
+	// last is closed when the most recently added task finishes
+	last chan struct{}
+
+	// wg counts the tasks that haven't finished
+	wg sync.WaitGroup
+
+	// pending is the number of tasks that haven't finished
+	pending atomic.Int32
+}
+
+func newUploadLane() *uploadLane {
+	return &uploadLane{}
+}
+
+// Go schedules the task to run after all previously added tasks.
+func (l *uploadLane) Go(task func()) {
+	l.mu.Lock()
+	prev := l.last
+	done := make(chan struct{})
+	l.last = done
+	l.wg.Add(1)
+	l.pending.Add(1)
+	l.mu.Unlock()
+
+	go func() {
+		defer l.wg.Done()
+		defer l.pending.Add(-1)
+		defer close(done)
+
+		if prev != nil {
+			<-prev
+		}
+		task()
+	}()
+}
+
+// Pending returns the number of added tasks that haven't finished.
+func (l *uploadLane) Pending() int {
+	return int(l.pending.Load())
+}
+
+// Wait blocks until all added tasks finish.
+func (l *uploadLane) Wait() {
+	l.wg.Wait()
+}
@@ -0,0 +1,150 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/internal/gqlmock"
+	"github.com/wandb/wandb/core/internal/mailbox"
+	wbsettings "github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/filestreamtest"
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// slowArtifactClient blocks CreateArtifact requests until released.
+type slowArtifactClient struct {
+	graphql.Client
+	release chan struct{}
+}
+
+func (c *slowArtifactClient) MakeRequest(
+	ctx context.Context,
+	req *graphql.Request,
+	resp *graphql.Response,
+) error {
+	if req.OpName == "CreateArtifact" {
+		<-c.release
+	}
+	return c.Client.MakeRequest(ctx, req, resp)
+}
+
+func makeArtifactRecord() *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Artifact{
+			Artifact: &service.ArtifactRecord{
+				RunId:   "run1",
+				Project: "test-project",
+				Entity:  "test-entity",
+				Type:    "dataset",
+				Name:    "big-dataset",
+				Manifest: &service.ArtifactManifest{
+					Version:       1,
+					StoragePolicy: "wandb-storage-policy-v1",
+				},
+			},
+		},
+	}
+}
+
+func countHistoryUpdates(fileStream *filestreamtest.FakeFileStream) int {
+	n := 0
+	for _, update := range fileStream.GetUpdates() {
+		if _, ok := update.(*filestream.HistoryUpdate); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// A slow artifact upload must not hold up history, but the run must not
+// finish uploading files before the artifact is saved.
+func TestSender_SlowUploadDoesNotBlockHistory(t *testing.T) {
+	mockGQL := gqlmock.NewMockClient()
+	mockGQL.StubMatchOnce(
+		gqlmock.WithOpName("CreateArtifact"),
+		validCreateArtifactResponse,
+	)
+	client := &slowArtifactClient{Client: mockGQL, release: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := observability.NewNoOpLogger()
+	settings := wbsettings.From(&service.Settings{
+		RunId: &wrapperspb.StringValue{Value: "run1"},
+	})
+	fileStream := filestreamtest.NewFakeFileStream()
+	fileTransferManager := server.NewFileTransferManager(
+		filetransfer.NewFileTransferStats(),
+		logger,
+		settings,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	inChan := make(chan *service.Record, 10)
+	fwdChan := make(chan *service.Record, 10)
+	sender := server.NewSender(ctx, cancel, &server.SenderParams{
+		Logger:              logger,
+		Settings:            settings.Proto,
+		FileStream:          fileStream,
+		FileTransferManager: fileTransferManager,
+		RunfilesUploader: server.NewRunfilesUploader(
+			ctx,
+			logger,
+			settings,
+			fileStream,
+			fileTransferManager,
+			nil,
+			client,
+			nil,
+			nil,
+		),
+		FwdChan:       fwdChan,
+		OutChan:       make(chan *service.Result, 10),
+		Mailbox:       mailbox.NewMailbox(),
+		GraphqlClient: client,
+	})
+	go sender.Do(inChan)
+	defer close(inChan)
+
+	inChan <- makeArtifactRecord()
+	for step := int64(0); step < 3; step++ {
+		inChan <- makeHistoryRecord(data{
+			step:  step,
+			items: map[string]string{"loss": "0.5"},
+		})
+	}
+
+	assert.Eventually(t,
+		func() bool { return countHistoryUpdates(fileStream) == 3 },
+		time.Second, 10*time.Millisecond,
+	)
+
+	inChan <- makeDeferRecord(service.DeferRequest_FLUSH_FP)
+	select {
+	case record := <-fwdChan:
+		t.Fatalf("defer state advanced before the upload finished: %v", record)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(client.release)
+	select {
+	case record := <-fwdChan:
+		assert.Equal(t,
+			service.DeferRequest_JOIN_FP,
+			record.GetRequest().GetDefer().GetState())
+	case <-time.After(time.Second):
+		require.Fail(t, "defer state didn't advance after the upload finished")
+	}
+}
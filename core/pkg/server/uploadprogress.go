@@ -0,0 +1,119 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/filetransfer"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+const (
+	// uploadProgressInterval is how often a client is told how far a
+	// request's uploads got.
+	uploadProgressInterval = time.Second
+
+	// printedProgressInterval is how often the progress of uploads is
+	// printed when no client is attached.
+	//
+	// Uploads that finish sooner aren't printed at all.
+	printedProgressInterval = 10 * time.Second
+)
+
+// uploadProgress returns a reporter for the uploads the record asked for.
+//
+// The progress is sent to the client that sent the record, so that it can
+// draw a progress bar, or printed if no client is attached.
+func (s *Sender) uploadProgress(
+	record *service.Record,
+	name string,
+) *filetransfer.ProgressReporter {
+	connectionID := record.GetControl().GetConnectionId()
+	mailboxSlot := record.GetControl().GetMailboxSlot()
+
+	toProto := func(update filetransfer.ProgressUpdate) *service.UploadProgressResponse {
+		return &service.UploadProgressResponse{
+			MailboxSlot: mailboxSlot,
+			Name:        name,
+			DoneBytes:   update.DoneBytes,
+			TotalBytes:  update.TotalBytes,
+			DoneFiles:   int32(update.DoneFiles),
+			TotalFiles:  int32(update.TotalFiles),
+		}
+	}
+
+	if connectionID == "" {
+		started := time.Now()
+		return filetransfer.NewProgressReporter(
+			printedProgressInterval,
+			func(update filetransfer.ProgressUpdate) {
+				if update.TotalBytes == 0 ||
+					time.Since(started) < printedProgressInterval {
+					return
+				}
+				utils.PrintUploadProgress(toProto(update))
+			},
+		)
+	}
+
+	return filetransfer.NewProgressReporter(
+		uploadProgressInterval,
+		func(update filetransfer.ProgressUpdate) {
+			if update.TotalBytes == 0 && update.TotalFiles == 0 {
+				return
+			}
+			s.sendResult(&service.Result{
+				ResultType: &service.Result_Response{
+					Response: &service.Response{
+						ResponseType: &service.Response_UploadProgressResponse{
+							UploadProgressResponse: toProto(update),
+						},
+					},
+				},
+				Control: &service.Control{ConnectionId: connectionID},
+			})
+		},
+	)
+}
+
+// watchExitUploads reports the progress of the run's file uploads while
+// the run exits, until the returned function is called.
+func (s *Sender) watchExitUploads() func() {
+	if s.fileTransferStats == nil || s.exitRecord == nil {
+		return func() {}
+	}
+
+	progress := s.uploadProgress(s.exitRecord, "run files")
+	update := func() {
+		stats := s.fileTransferStats.GetFilesStats()
+		progress.Set(filetransfer.ProgressUpdate{
+			DoneBytes:  stats.GetUploadedBytes(),
+			TotalBytes: stats.GetTotalBytes(),
+		})
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(uploadProgressInterval)
+		defer ticker.Stop()
+		for {
+			update()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		wg.Wait()
+		update()
+		progress.Finish()
+	}
+}
@@ -0,0 +1,94 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// The client that exits a run is told how far the run's file uploads got,
+// until they're done.
+func TestUploadProgress_ReportedToExitingClient(t *testing.T) {
+	dir := t.TempDir()
+	filesDir := filepath.Join(dir, "files")
+	require.NoError(t, os.MkdirAll(filesDir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(filesDir, "data.bin"),
+		make([]byte, 4096),
+		0o644,
+	))
+
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	run, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "progress"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-progress.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filesDir},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 1)
+	run.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	run.Start()
+	defer run.Close()
+
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "progress", Project: "testProject"},
+		},
+	})
+	run.HandleRecord(makeRunStartRecord())
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Files{
+			Files: &service.FilesRecord{
+				Files: []*service.FilesItem{{Path: "data.bin"}},
+			},
+		},
+	})
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control: &service.Control{
+			ConnectionId: "client",
+			MailboxSlot:  "exit-slot",
+			ReqResp:      true,
+		},
+	})
+
+	var progress []*service.UploadProgressResponse
+	timeout := time.After(30 * time.Second)
+	for exited := false; !exited; {
+		select {
+		case result := <-responses:
+			if update := result.GetResponse().GetUploadProgressResponse(); update != nil {
+				progress = append(progress, update)
+			}
+			exited = result.GetExitResult() != nil
+		case <-timeout:
+			t.Fatal("the run didn't exit")
+		}
+	}
+
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	assert.Equal(t, "exit-slot", last.GetMailboxSlot())
+	assert.Equal(t, "run files", last.GetName())
+	assert.GreaterOrEqual(t, last.GetTotalBytes(), int64(4096))
+	assert.Equal(t, last.GetTotalBytes(), last.GetDoneBytes())
+}
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/gql"
+	"github.com/wandb/wandb/core/pkg/filestream"
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/utils"
+)
+
+// verifyRetryInterval is how long to wait before asking the server again
+// while its counts lag behind the run's.
+const verifyRetryInterval = time.Second
+
+// exitTally counts what a run recorded that should reach the server, to
+// verify it as the run exits.
+type exitTally struct {
+	historyRows int64
+	files       map[string]struct{}
+	artifacts   int
+}
+
+func newExitTally() *exitTally {
+	return &exitTally{files: make(map[string]struct{})}
+}
+
+// add counts what the record adds to the run.
+//
+// It does nothing if the tally is nil, which is when the run isn't
+// verified.
+func (t *exitTally) add(record *service.Record) {
+	if t == nil {
+		return
+	}
+
+	switch x := record.RecordType.(type) {
+	case *service.Record_History:
+		t.historyRows++
+	case *service.Record_Files:
+		for _, file := range x.Files.GetFiles() {
+			t.files[file.GetPath()] = struct{}{}
+		}
+	case *service.Record_Artifact:
+		t.artifacts++
+	case *service.Record_Request:
+		if x.Request.GetLogArtifact() != nil {
+			t.artifacts++
+		}
+	}
+}
+
+// verifyRun compares what the run recorded with what the server has, or
+// returns nil if the run isn't verified.
+//
+// Counts on the server may briefly lag, so it's asked again until they
+// match or the verification times out. It never delays the exit by more
+// than the timeout.
+func (s *Sender) verifyRun() *service.RunVerification {
+	if s.tally == nil || s.graphqlClient == nil || s.RunRecord == nil {
+		return nil
+	}
+
+	summaryKeys := make([]string, 0, len(s.runSummary.Tree()))
+	for key := range s.runSummary.Tree() {
+		summaryKeys = append(summaryKeys, key)
+	}
+	sort.Strings(summaryKeys)
+	files := s.localFiles()
+
+	verification := &service.RunVerification{
+		Local: &service.VerificationCounts{
+			HistoryRows: s.tally.historyRows,
+			SummaryKeys: int32(len(summaryKeys)),
+			Files:       int32(len(files)),
+			Artifacts:   int32(s.tally.artifacts),
+		},
+	}
+	if s.unfinished != nil {
+		verification.Error = "the exit timed out before the run's data was uploaded"
+		return verification
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.verifyTimeout)
+	defer cancel()
+
+	fileNames := make([]string, 0, len(files))
+	for name := range files {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+
+	for {
+		response, err := gql.RunVerification(
+			ctx,
+			s.graphqlClient,
+			&s.RunRecord.Entity,
+			&s.RunRecord.Project,
+			s.RunRecord.RunId,
+			fileNames,
+		)
+		switch {
+		case err != nil:
+			verification.Error = fmt.Sprintf("can't query the server: %v", err)
+		case response.GetProject() == nil || response.GetProject().GetRun() == nil:
+			verification.Error = "the run wasn't found on the server"
+		default:
+			verification.Error = ""
+			verification.Server, verification.Discrepancies = s.compareWithServer(
+				response.GetProject().GetRun(),
+				summaryKeys,
+				files,
+			)
+		}
+
+		if verification.Error == "" && len(verification.Discrepancies) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logVerification(verification)
+			return verification
+		case <-time.After(verifyRetryInterval):
+		}
+	}
+
+	s.logVerification(verification)
+	return verification
+}
+
+// localFiles returns the digests of the run's files that exist, by their
+// paths relative to the files directory.
+func (s *Sender) localFiles() map[string]string {
+	filesDir := s.settings.GetFilesDir().GetValue()
+	files := make(map[string]string, len(s.tally.files))
+	for name := range s.tally.files {
+		path := filepath.Join(filesDir, filepath.FromSlash(name))
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		digest, err := utils.ComputeFileB64MD5(path)
+		if err != nil {
+			continue
+		}
+		files[name] = digest
+	}
+	return files
+}
+
+// compareWithServer counts what the server has of the run, and describes
+// what it's missing.
+func (s *Sender) compareWithServer(
+	run *gql.RunVerificationProjectRun,
+	summaryKeys []string,
+	files map[string]string,
+) (*service.VerificationCounts, []string) {
+	var discrepancies []string
+	server := &service.VerificationCounts{}
+
+	// a resumed run's earlier history is on the server too
+	historyOffset := int64(
+		s.resumeState.GetFileStreamOffset()[filestream.HistoryChunk])
+	server.HistoryRows = int64(utils.ZeroIfNil(run.GetHistoryLineCount()))
+	if server.HistoryRows < historyOffset+s.tally.historyRows {
+		discrepancies = append(discrepancies, fmt.Sprintf(
+			"the server has %d history rows, expected at least %d",
+			server.HistoryRows, historyOffset+s.tally.historyRows,
+		))
+	}
+
+	var summary map[string]any
+	if metrics := utils.ZeroIfNil(run.GetSummaryMetrics()); metrics != "" {
+		if err := json.Unmarshal([]byte(metrics), &summary); err != nil {
+			discrepancies = append(discrepancies,
+				fmt.Sprintf("can't parse the server's summary: %v", err))
+		}
+	}
+	for _, key := range summaryKeys {
+		if _, ok := summary[key]; ok {
+			server.SummaryKeys++
+		} else {
+			discrepancies = append(discrepancies,
+				fmt.Sprintf("summary key %q is missing", key))
+		}
+	}
+
+	serverDigests := make(map[string]string)
+	var edges []gql.RunVerificationProjectRunFilesFileConnectionEdgesFileEdge
+	if run.GetFiles() != nil {
+		edges = run.GetFiles().GetEdges()
+	}
+	for _, edge := range edges {
+		if node := edge.GetNode(); node != nil {
+			serverDigests[node.GetName()] = utils.ZeroIfNil(node.GetMd5())
+		}
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		digest, ok := serverDigests[name]
+		switch {
+		case !ok:
+			discrepancies = append(discrepancies,
+				fmt.Sprintf("file %q is missing", name))
+		case digest != "" && digest != files[name]:
+			server.Files++
+			discrepancies = append(discrepancies,
+				fmt.Sprintf("file %q differs from the local copy", name))
+		default:
+			server.Files++
+		}
+	}
+
+	if artifacts := run.GetOutputArtifacts(); artifacts != nil {
+		server.Artifacts = int32(artifacts.GetTotalCount())
+	}
+	if server.Artifacts < int32(s.tally.artifacts) {
+		discrepancies = append(discrepancies, fmt.Sprintf(
+			"the server has %d artifact versions, expected at least %d",
+			server.Artifacts, s.tally.artifacts,
+		))
+	}
+
+	return server, discrepancies
+}
+
+func (s *Sender) logVerification(verification *service.RunVerification) {
+	if verification.GetError() == "" && len(verification.GetDiscrepancies()) == 0 {
+		s.logger.Info("sender: verified the run's data on the server",
+			"local", verification.GetLocal())
+		return
+	}
+
+	s.logger.Warn("sender: the server is missing some of the run's data",
+		"error", verification.GetError(),
+		"discrepancies", verification.GetDiscrepancies(),
+		"local", verification.GetLocal(),
+		"server", verification.GetServer(),
+	)
+}
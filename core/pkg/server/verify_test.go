@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/internal/servertest"
+	"github.com/wandb/wandb/core/internal/settings"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// verifiedRunExit runs a verified run that logs a row of history and
+// returns its exit result.
+func verifiedRunExit(
+	t *testing.T,
+	backend *servertest.FakeBackend,
+	verifyTimeout float64,
+) *service.RunExitResult {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "files"), 0o755))
+	run, err := server.NewStream(settings.From(&service.Settings{
+		RunId:         &wrapperspb.StringValue{Value: "verify"},
+		BaseUrl:       &wrapperspb.StringValue{Value: backend.URL()},
+		ApiKey:        &wrapperspb.StringValue{Value: "test-api-key"},
+		LogDir:        &wrapperspb.StringValue{Value: dir},
+		LogInternal:   &wrapperspb.StringValue{Value: filepath.Join(dir, "debug-internal.log")},
+		SyncFile:      &wrapperspb.StringValue{Value: filepath.Join(dir, "run-verify.wandb")},
+		FilesDir:      &wrapperspb.StringValue{Value: filepath.Join(dir, "files")},
+		XDisableStats: &wrapperspb.BoolValue{Value: true},
+		XDisableMeta:  &wrapperspb.BoolValue{Value: true},
+
+		XVerifyOnExit:         &wrapperspb.BoolValue{Value: true},
+		XVerifyTimeoutSeconds: &wrapperspb.DoubleValue{Value: verifyTimeout},
+	}), "")
+	require.NoError(t, err)
+	responses := make(chanResponder, 1)
+	run.AddResponders(server.ResponderEntry{Responder: responses, ID: "client"})
+	run.Start()
+
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "verify", Project: "testProject"},
+		},
+	})
+	run.HandleRecord(makeRunStartRecord())
+	run.HandleRecord(makePartialHistoryRecord(data{
+		items:   map[string]string{"loss": "0.5"},
+		flush:   true,
+		stepNil: true,
+	}))
+	run.HandleRecord(&service.Record{
+		RecordType: &service.Record_Exit{Exit: &service.RunExitRecord{}},
+		Control:    &service.Control{ConnectionId: "client", ReqResp: true},
+		Uuid:       "exit",
+	})
+
+	// The exit result follows the progress of the run's uploads.
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case result := <-responses:
+			if exit := result.GetExitResult(); exit != nil {
+				run.Close()
+				return exit
+			}
+		case <-timeout:
+			t.Fatal("the run didn't exit")
+		}
+	}
+}
+
+// The server's state of the run, as RunVerification returns it.
+func runVerificationResponse(historyLineCount int) string {
+	return fmt.Sprintf(`{
+		"project": {
+			"run": {
+				"historyLineCount": %d,
+				"summaryMetrics": %q,
+				"files": {"edges": [
+					{"node": {"name": "config.yaml"}},
+					{"node": {"name": "wandb-summary.json"}}
+				]},
+				"outputArtifacts": {"totalCount": 0}
+			}
+		}
+	}`,
+		historyLineCount,
+		`{"loss": 0.5, "_step": 0, "_runtime": 1, "_timestamp": 1, "_wandb": {}}`,
+	)
+}
+
+// Counts that lag behind the run on the server are asked for again until
+// they catch up.
+func TestVerify_WaitsForServerToCatchUp(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	backend.StubGraphQLOnce("RunVerification", runVerificationResponse(0))
+	backend.StubGraphQL("RunVerification", runVerificationResponse(1))
+
+	exit := verifiedRunExit(t, backend, 10)
+
+	verification := exit.GetVerification()
+	require.NotNil(t, verification)
+	assert.Empty(t, verification.GetError())
+	assert.Empty(t, verification.GetDiscrepancies())
+	assert.EqualValues(t, 1, verification.GetLocal().GetHistoryRows())
+	assert.EqualValues(t, 1, verification.GetServer().GetHistoryRows())
+	assert.Len(t, backend.GraphQLRequests("RunVerification"), 2)
+}
+
+// Data still missing from the server when the verification times out is
+// reported, without holding up the exit any longer.
+func TestVerify_ReportsDiscrepancies(t *testing.T) {
+	backend := servertest.NewFakeBackend()
+	defer backend.Close()
+	backend.StubGraphQL("UpsertBucket", validUpsertBucketResponse)
+	backend.StubCreateRunFiles()
+	backend.StubGraphQL("RunVerification", `{
+		"project": {"run": {"historyLineCount": 0, "summaryMetrics": "{}"}}
+	}`)
+
+	start := time.Now()
+	exit := verifiedRunExit(t, backend, 0.5)
+
+	assert.Less(t, time.Since(start), 10*time.Second)
+	verification := exit.GetVerification()
+	require.NotNil(t, verification)
+	assert.Empty(t, verification.GetError())
+	assert.Contains(t, verification.GetDiscrepancies(),
+		"the server has 0 history rows, expected at least 1")
+	assert.Contains(t, verification.GetDiscrepancies(),
+		`summary key "loss" is missing`)
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/wandb/wandb/core/internal/faults"
+	"github.com/wandb/wandb/core/internal/recordsink"
 	"github.com/wandb/wandb/core/pkg/observability"
 	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
 )
 
 type WriterOption func(*Writer)
@@ -24,9 +29,31 @@ func WithWriterSettings(settings *service.Settings) WriterOption {
 }
 
 type WriterParams struct {
-	Logger   *observability.CoreLogger
-	Settings *service.Settings
-	FwdChan  chan *service.Record
+	Logger        *observability.CoreLogger
+	Settings      *service.Settings
+	FwdChan       chan *service.Record
+	CrashReporter *CrashReporter
+	FaultInjector *faults.Injector
+
+	// TerminalPrinter shows the user warnings about the transaction log,
+	// if not nil.
+	TerminalPrinter *observability.Printer
+
+	// RecordSink mirrors forwarded records to an external endpoint, if
+	// not nil.
+	RecordSink *recordsink.Sink
+
+	// Checkpoints is told about the records stored, to ask the handler
+	// for checkpoints, if not nil.
+	Checkpoints *Checkpoints
+
+	// PipelineLatency is told about the history rows persisted, if not
+	// nil.
+	PipelineLatency *PipelineLatency
+
+	// ContinueLog is the transaction log of the wandb-core process that ran
+	// the run before a restart, whose records start the new log, or empty.
+	ContinueLog string
 }
 
 // Writer is responsible for writing messages to the append-only log.
@@ -49,12 +76,68 @@ type Writer struct {
 	// storeChan is the channel for messages to be stored
 	storeChan chan *service.Record
 
+	// lowLatency is whether records are forwarded before they're stored
+	lowLatency bool
+
+	// storeQueue holds the records to store in low-latency mode, or is nil
+	storeQueue *storeQueue
+
 	// store is the store for the writer
 	store *Store
 
+	// crashReporter notes the records written, for crash reports
+	crashReporter *CrashReporter
+
+	// faultInjector may delay writes or make the writer panic, for testing
+	faultInjector *faults.Injector
+
+	// terminalPrinter shows the user warnings, if not nil
+	terminalPrinter *observability.Printer
+
+	// recordSink mirrors forwarded records, if not nil
+	recordSink *recordsink.Sink
+
+	// checkpoints asks the handler for checkpoints, if not nil
+	checkpoints *Checkpoints
+
+	// latency is told about the history rows persisted, or is nil
+	latency *PipelineLatency
+
+	// continuedLog is the log of the process that ran the run before a
+	// restart, copied into the new log, or empty
+	continuedLog string
+
 	// recordNum is the running count of stored records
 	recordNum int64
 
+	// lastFlushedNum is the number of the last record flushed to the
+	// transaction log
+	lastFlushedNum atomic.Int64
+
+	// storeFailed is set once the transaction log fails, after which
+	// records are forwarded without being stored
+	storeFailed atomic.Bool
+
+	// syncMu keeps the store from being closed while it's synced to disk,
+	// and guards storeClosed
+	syncMu      sync.Mutex
+	storeClosed bool
+
+	// gap records which records are missing from the transaction log, if
+	// it failed
+	gap *logGap
+
+	// gapSaved is whether the gap was recorded next to the log
+	gapSaved bool
+
+	// digest is the final state of the transaction log, set once the log
+	// is closed if it's complete
+	digest *transactionlog.Digest
+
+	// abandoned is closed once records are no longer to be forwarded
+	abandoned     chan struct{}
+	abandonedOnce sync.Once
+
 	// wg is the wait group for the writer
 	wg sync.WaitGroup
 }
@@ -62,15 +145,32 @@ type Writer struct {
 // NewWriter returns a new Writer
 func NewWriter(ctx context.Context, params *WriterParams) *Writer {
 	w := &Writer{
-		ctx:      ctx,
-		wg:       sync.WaitGroup{},
-		logger:   params.Logger,
-		settings: params.Settings,
-		fwdChan:  params.FwdChan,
+		ctx:             ctx,
+		wg:              sync.WaitGroup{},
+		logger:          params.Logger,
+		settings:        params.Settings,
+		fwdChan:         params.FwdChan,
+		crashReporter:   params.CrashReporter,
+		faultInjector:   params.FaultInjector,
+		terminalPrinter: params.TerminalPrinter,
+		recordSink:      params.RecordSink,
+		checkpoints:     params.Checkpoints,
+		latency:         params.PipelineLatency,
+		continuedLog:    params.ContinueLog,
+		lowLatency:      params.Settings.GetXLowLatency().GetValue(),
+		abandoned:       make(chan struct{}),
 	}
 	return w
 }
 
+// Abandon makes the writer stop forwarding records, which it still stores.
+//
+// This is for closing the stream quickly, without the sender holding up
+// the records behind it.
+func (w *Writer) Abandon() {
+	w.abandonedOnce.Do(func() { close(w.abandoned) })
+}
+
 func (w *Writer) startStore() {
 	if w.settings.GetXSync().GetValue() {
 		// do not set up store if we are syncing an offline run
@@ -83,19 +183,84 @@ func (w *Writer) startStore() {
 	w.store = NewStore(w.ctx, w.settings.GetSyncFile().GetValue(), w.logger)
 	err = w.store.Open(os.O_WRONLY)
 	if err != nil {
-		w.logger.CaptureFatalAndPanic("writer: startStore: error creating store", err)
+		if w.store.db != nil {
+			_ = w.store.Close()
+		}
+		w.stopStoring(err)
+		return
+	}
+	if w.continuedLog != "" {
+		if err := w.continueLog(w.continuedLog); err != nil {
+			_ = w.store.Close()
+			w.stopStoring(err)
+			return
+		}
+	}
+
+	// records wait in memory for a slow disk, instead of holding up the
+	// ones behind them
+	if w.lowLatency {
+		w.storeQueue = newStoreQueue()
+		w.wg.Add(1)
+		go func() {
+			w.storeQueue.pump(w.storeChan)
+			w.wg.Done()
+		}()
 	}
 
 	w.wg.Add(1)
 	go func() {
+		// dropped counts the records that were queued when the log failed
+		dropped := 0
+
 		for record := range w.storeChan {
+			if w.storeFailed.Load() {
+				dropped++
+				continue
+			}
+
+			w.faultInjector.DelayWrite()
 			if err = w.store.Write(record); err != nil {
-				w.logger.Error("writer: startStore: error storing record", "error", err)
+				w.stopStoring(err)
+				dropped++
+				continue
+			}
+			w.latency.persisted(record.GetHistory())
+
+			if w.checkpoints.stored(record, w.store.LastOffset()) {
+				w.indexCheckpoint(record)
+			}
+
+			// flush once caught up, so that the sender can read the records
+			// back after a network outage
+			if len(w.storeChan) == 0 {
+				if err = w.store.Flush(); err != nil {
+					w.stopStoring(err)
+					continue
+				}
+				w.lastFlushedNum.Store(record.Num)
 			}
 		}
 
-		if err = w.store.Close(); err != nil {
+		if dropped > 0 {
+			w.logger.Warn(
+				"writer: records not saved to the transaction log",
+				"dropped", dropped,
+			)
+		}
+		w.syncMu.Lock()
+		err = w.store.Close()
+		w.storeClosed = true
+		w.syncMu.Unlock()
+		switch {
+		case w.storeFailed.Load():
+			// an incomplete log has no final state to record
+		case err != nil:
 			w.logger.CaptureError("writer: startStore: error closing store", err)
+		default:
+			digest := w.store.Digest()
+			digest.FinishedAt = time.Now()
+			w.digest = &digest
 		}
 		w.wg.Done()
 	}()
@@ -104,58 +269,203 @@ func (w *Writer) startStore() {
 // Do is the main loop of the writer to process incoming messages
 func (w *Writer) Do(inChan <-chan *service.Record) {
 	defer w.logger.Reraise()
-	w.logger.Info("writer: Do: started", "stream_id", w.settings.RunId)
+	defer w.crashReporter.ReportPanic("writer")
+	w.logger.Info("writer: Do: started")
 
 	w.startStore()
 
 	for record := range inChan {
-		w.logger.Debug("write: Do: got a message", "record", record.RecordType, "stream_id", w.settings.RunId)
+		w.crashReporter.Observe("writer", record)
+		w.faultInjector.MaybePanic(faults.Writer)
+		w.logger.Debug(
+			"write: Do: got a message",
+			observability.RecordTypeKey, recordTypeName(record),
+			"record", record.RecordType,
+		)
 		w.writeRecord(record)
 	}
 	w.Close()
 	w.wg.Wait()
+
+	// the disk may have space again to record a gap in the log
+	w.saveGap()
+	w.saveDigest()
+}
+
+// LogDigest returns the final state of the transaction log, or nil if it
+// isn't complete or isn't written.
+//
+// It's only set once the writer is done.
+func (w *Writer) LogDigest() *transactionlog.Digest {
+	return w.digest
+}
+
+// saveDigest records the final state of the transaction log next to it,
+// so that syncing the log can detect changes made to it afterwards.
+//
+// The log is hashed as it's written, so this doesn't read it back.
+func (w *Writer) saveDigest() {
+	if w.digest == nil {
+		return
+	}
+
+	path := w.settings.GetSyncFile().GetValue()
+	if err := transactionlog.WriteDigest(path, *w.digest); err != nil {
+		w.logger.Error(
+			"writer: can't record the transaction log's digest",
+			"error", err,
+			"path", path+transactionlog.DigestSuffix,
+		)
+		return
+	}
+	w.logger.Info(
+		"writer: recorded the transaction log's digest",
+		"sha256", w.digest.SHA256,
+		"records", w.digest.Records,
+		"bytes", w.digest.Bytes,
+	)
 }
 
 // Close closes the writer and all its resources
 // which includes the store
 func (w *Writer) Close() {
 	close(w.fwdChan)
-	if w.storeChan != nil {
+	switch {
+	case w.storeQueue != nil:
+		w.storeQueue.close()
+	case w.storeChan != nil:
 		close(w.storeChan)
 	}
-	w.logger.Info("writer: Close: closed", "stream_id", w.settings.RunId)
+	w.recordSink.Close()
+	w.logger.Info("writer: Close: closed")
 }
 
 // writeRecord Writing messages to the append-only log,
 // and passing them to the sender.
 // We ensure that the messages are written to the log
-// before they are sent to the server.
+// before they are sent to the server, unless in low-latency mode.
 func (w *Writer) writeRecord(record *service.Record) {
-	switch record.RecordType.(type) {
-	case *service.Record_Request:
-		w.fwdRecord(record)
-	case nil:
+	if record.RecordType == nil && !isUnknownType(record) {
 		w.logger.Error("writer: writeRecord: nil record type")
+		return
+	}
+
+	if checkpoint := record.GetCheckpoint(); checkpoint != nil {
+		// the checkpoint is sealed with the number of the record before it
+		checkpoint.LastRecordNum = w.recordNum
+		sealCheckpoint(checkpoint)
+	}
+
+	// number first: the record must not be modified once the sender has
+	// it
+	var stored *service.Record
+	switch recordPolicy(record) {
+	case skip:
+	case persistCompacted:
+		stored = w.numberRecord(record, true)
 	default:
+		stored = w.numberRecord(record, false)
+	}
+
+	// in low-latency mode, the sender doesn't wait on the disk
+	if w.lowLatency {
 		w.fwdRecord(record)
-		w.storeRecord(record)
+		w.storeRecord(stored)
+		return
 	}
+	w.storeRecord(stored)
+	w.fwdRecord(record)
 }
 
-// storeRecord stores the record in the append-only log
-func (w *Writer) storeRecord(record *service.Record) {
-	if record.GetControl().GetLocal() {
+// indexCheckpoint records where the checkpoint just stored is, once it is
+// in the file.
+//
+// Without the index, readers replay the log from its start.
+func (w *Writer) indexCheckpoint(record *service.Record) {
+	if err := w.store.Flush(); err != nil {
+		w.stopStoring(err)
 		return
 	}
+	w.lastFlushedNum.Store(record.Num)
+
+	err := transactionlog.WriteCheckpointIndex(
+		w.settings.GetSyncFile().GetValue(),
+		transactionlog.CheckpointIndex{
+			Offset:        w.store.LastOffset(),
+			LastRecordNum: record.GetCheckpoint().GetLastRecordNum(),
+		},
+	)
+	if err != nil {
+		w.logger.Warn("writer: can't index checkpoint", "error", err)
+	}
+}
+
+// numberRecord numbers the record to be stored in the append-only log,
+// and returns the record to store, compacted if requested, or nil if it
+// isn't stored.
+func (w *Writer) numberRecord(record *service.Record, compact bool) *service.Record {
+	if record.GetControl().GetLocal() {
+		return nil
+	}
+	// records that aren't stored have no number, so that the sender doesn't
+	// expect to read them back from the log
+	if w.storeFailed.Load() {
+		return nil
+	}
 	w.recordNum += 1
 	record.Num = w.recordNum
-	w.storeChan <- record
+	w.checkpoints.numbered(record)
+	if compact {
+		record = compactRecord(record)
+	}
+	return record
+}
+
+// storeRecord queues the numbered record to be stored, if not nil.
+func (w *Writer) storeRecord(record *service.Record) {
+	switch {
+	case record == nil:
+	case w.storeQueue != nil:
+		w.storeQueue.push(record)
+	default:
+		w.storeChan <- record
+	}
+}
+
+// isExportedOffline returns whether an offline run's record is still sent,
+// because the run's exported files are made from it.
+func (w *Writer) isExportedOffline(record *service.Record) bool {
+	if w.settings.GetXExportDir().GetValue() == "" {
+		return false
+	}
+
+	switch record.GetRecordType().(type) {
+	case *service.Record_Files,
+		*service.Record_Output,
+		*service.Record_OutputRaw,
+		*service.Record_Config,
+		*service.Record_Summary,
+		*service.Record_Artifact:
+		return true
+	default:
+		return false
+	}
 }
 
 func (w *Writer) fwdRecord(record *service.Record) {
 	// TODO: redo it so it only uses control
-	if w.settings.GetXOffline().GetValue() && !record.GetControl().GetAlwaysSend() {
+	if w.settings.GetXOffline().GetValue() &&
+		!record.GetControl().GetAlwaysSend() &&
+		!w.isExportedOffline(record) {
 		return
 	}
-	w.fwdChan <- record
+	// records to only persist are still sent if they couldn't be stored
+	if record.GetControl().GetPersistOnly() && record.Num > 0 {
+		return
+	}
+	w.recordSink.Mirror(record)
+	select {
+	case w.fwdChan <- record:
+	case <-w.abandoned:
+	}
 }
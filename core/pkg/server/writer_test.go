@@ -0,0 +1,140 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// writeRecords runs a writer saving to the sync file and returns the
+// records it forwarded and the messages it printed.
+func writeRecords(t *testing.T, syncFile string, n int) ([]*service.Record, []string) {
+	inChan := make(chan *service.Record, n)
+	fwdChan := make(chan *service.Record, n)
+	printer := observability.NewPrinter()
+	writer := server.NewWriter(context.Background(), &server.WriterParams{
+		Logger:          observability.NewNoOpLogger(),
+		Settings:        &service.Settings{SyncFile: &wrapperspb.StringValue{Value: syncFile}},
+		FwdChan:         fwdChan,
+		TerminalPrinter: printer,
+	})
+
+	for i := range n {
+		inChan <- makeHistoryRecord(data{
+			items: map[string]string{"loss": "0.5"},
+			step:  int64(i),
+		})
+	}
+	close(inChan)
+	writer.Do(inChan)
+
+	var forwarded []*service.Record
+	for record := range fwdChan {
+		forwarded = append(forwarded, record)
+	}
+	return forwarded, printer.Read()
+}
+
+func readGap(t *testing.T, syncFile string) map[string]any {
+	content, err := os.ReadFile(syncFile + ".gap")
+	require.NoError(t, err)
+	var gap map[string]any
+	require.NoError(t, json.Unmarshal(content, &gap))
+	return gap
+}
+
+func TestWriter_DiskFull(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("no /dev/full")
+	}
+	syncFile := filepath.Join(t.TempDir(), "run-full.wandb")
+	require.NoError(t, os.Symlink("/dev/full", syncFile))
+
+	forwarded, messages := writeRecords(t, syncFile, 3)
+
+	// Records still reach the sender, without numbers as they aren't in
+	// the log.
+	require.Len(t, forwarded, 3)
+	for _, record := range forwarded {
+		assert.Zero(t, record.Num)
+	}
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "The disk is full")
+	gap := readGap(t, syncFile)
+	assert.EqualValues(t, 1, gap["first_missing"])
+	assert.Equal(t, true, gap["no_space"])
+	assert.Contains(t, gap["error"], "no space left on device")
+}
+
+func TestWriter_IOError(t *testing.T) {
+	dir := t.TempDir()
+	syncFile := filepath.Join(dir, "missing", "run-io.wandb")
+
+	forwarded, messages := writeRecords(t, syncFile, 2)
+
+	require.Len(t, forwarded, 2)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "couldn't write to "+syncFile)
+	// The gap can't be recorded if the log's directory doesn't exist.
+	assert.NoFileExists(t, syncFile+".gap")
+}
+
+func TestWriter_NumbersStoredRecords(t *testing.T) {
+	syncFile := filepath.Join(t.TempDir(), "run-ok.wandb")
+
+	forwarded, messages := writeRecords(t, syncFile, 2)
+
+	require.Len(t, forwarded, 2)
+	assert.EqualValues(t, 1, forwarded[0].Num)
+	assert.EqualValues(t, 2, forwarded[1].Num)
+	assert.Empty(t, messages)
+	assert.NoFileExists(t, syncFile+".gap")
+}
+
+// forwardOffline runs an offline writer on a history and a files record,
+// and returns the records that it forwarded.
+func forwardOffline(t *testing.T, exportDir string) []*service.Record {
+	inChan := make(chan *service.Record, 2)
+	fwdChan := make(chan *service.Record, 2)
+	writer := server.NewWriter(context.Background(), &server.WriterParams{
+		Logger: observability.NewNoOpLogger(),
+		Settings: &service.Settings{
+			SyncFile:   wrapperspb.String(filepath.Join(t.TempDir(), "run.wandb")),
+			XOffline:   wrapperspb.Bool(true),
+			XExportDir: wrapperspb.String(exportDir),
+		},
+		FwdChan:         fwdChan,
+		TerminalPrinter: observability.NewPrinter(),
+	})
+
+	inChan <- makeHistoryRecord(data{items: map[string]string{"loss": "0.5"}})
+	inChan <- &service.Record{
+		RecordType: &service.Record_Files{Files: &service.FilesRecord{}},
+	}
+	close(inChan)
+	writer.Do(inChan)
+
+	var forwarded []*service.Record
+	for record := range fwdChan {
+		forwarded = append(forwarded, record)
+	}
+	return forwarded
+}
+
+func TestWriter_OfflineForwardsExportedRecords(t *testing.T) {
+	assert.Empty(t, forwardOffline(t, ""))
+
+	forwarded := forwardOffline(t, t.TempDir())
+	require.Len(t, forwarded, 1)
+	assert.NotNil(t, forwarded[0].GetFiles())
+}
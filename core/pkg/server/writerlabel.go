@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// writerLabel returns the label that namespaces this process's records.
+//
+// In shared-mode distributed runs several processes write to the same
+// run. A secondary process with a label has its system metrics, console
+// output and metadata namespaced so that the data of different nodes
+// can be told apart. The primary process (rank 0) is never labeled, so
+// that its records look the same as those of a run with a single writer.
+func writerLabel(settings *service.Settings) string {
+	if settings.GetXPrimary() == nil || settings.GetXPrimary().GetValue() {
+		return ""
+	}
+	return settings.GetXLabel().GetValue()
+}
+
+// labelStats prefixes the keys of a stats record with the label,
+// e.g. "gpu.0.temp" becomes "node1.gpu.0.temp".
+func labelStats(stats *service.StatsRecord, label string) {
+	for _, item := range stats.GetItem() {
+		item.Key = fmt.Sprintf("%s.%s", label, item.Key)
+	}
+}
+
+// labelLine prefixes a line of console output with the label.
+//
+// Lines that consist only of a line break are kept as they are.
+func labelLine(line string, label string) string {
+	if line == "" || line == "\n" {
+		return line
+	}
+	return fmt.Sprintf("[%s] %s", label, line)
+}
+
+// labeledMetaFileName returns the name of the metadata file of a
+// labeled writer, so that it does not overwrite the primary's.
+func labeledMetaFileName(label string) string {
+	return fmt.Sprintf("%s-%s.json", strings.TrimSuffix(MetaFileName, ".json"), safeLabel(label))
+}
+
+// writerOutputFileName returns the name of the file with the console
+// output of this process.
+//
+// Each labeled writer has its own file, e.g. "output_node1.log", so that
+// the output of different ranks isn't interleaved. The primary keeps
+// output.log, like a run with a single writer.
+func writerOutputFileName(settings *service.Settings) string {
+	label := writerLabel(settings)
+	if label == "" {
+		return OutputFileName
+	}
+	return fmt.Sprintf("%s_%s.log", strings.TrimSuffix(OutputFileName, ".log"), safeLabel(label))
+}
+
+// safeLabel replaces the characters of a label that may not be safe in
+// file names.
+func safeLabel(label string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, label)
+}
@@ -0,0 +1,146 @@
+package server_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wandb/wandb/core/pkg/observability"
+	"github.com/wandb/wandb/core/pkg/server"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+func makeStatsRecord(key string, valueJson string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_Stats{
+			Stats: &service.StatsRecord{
+				StatsType: service.StatsRecord_SYSTEM,
+				Item:      []*service.StatsItem{{Key: key, ValueJson: valueJson}},
+			},
+		},
+	}
+}
+
+func makeOutputRawRecord(line string) *service.Record {
+	return &service.Record{
+		RecordType: &service.Record_OutputRaw{
+			OutputRaw: &service.OutputRawRecord{
+				OutputType: service.OutputRawRecord_STDOUT,
+				Line:       line,
+			},
+		},
+	}
+}
+
+// startLabeledHandler starts a handler for one writer of a shared run
+// that forwards its records to fwdChan.
+func startLabeledHandler(
+	t *testing.T,
+	label string,
+	primary bool,
+	fwdChan chan *service.Record,
+) chan *service.Record {
+	settings := &service.Settings{
+		FilesDir: &wrapperspb.StringValue{Value: t.TempDir()},
+		XShared:  &wrapperspb.BoolValue{Value: true},
+		XLabel:   &wrapperspb.StringValue{Value: label},
+		XPrimary: &wrapperspb.BoolValue{Value: primary},
+	}
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        settings,
+			FwdChan:         fwdChan,
+			OutChan:         make(chan *service.Result, 10),
+			TerminalPrinter: observability.NewPrinter(),
+			RunMetadata: server.NewRunMetadata(
+				context.Background(),
+				settings,
+				observability.NewNoOpLogger(),
+			),
+		},
+	)
+
+	inChan := make(chan *service.Record, 10)
+	go h.Do(inChan)
+	return inChan
+}
+
+// Two writers of the same run forward their records to a single channel,
+// which stands in for the backend run that both of them write to.
+func TestWriterLabel_TwoStreams(t *testing.T) {
+	runChan := make(chan *service.Record, 10)
+	rank0 := startLabeledHandler(t, "node0", true, runChan)
+	rank1 := startLabeledHandler(t, "node1", false, runChan)
+
+	rank0 <- makeStatsRecord("gpu.0.temp", "40")
+	rank1 <- makeStatsRecord("gpu.0.temp", "60")
+	rank0 <- makeOutputRawRecord("epoch 1")
+	rank1 <- makeOutputRawRecord("epoch 1")
+	rank1 <- makeOutputRawRecord("\n")
+
+	var statsKeys, lines []string
+	for i := 0; i < 5; i++ {
+		record := <-runChan
+		switch x := record.GetRecordType().(type) {
+		case *service.Record_Stats:
+			for _, item := range x.Stats.GetItem() {
+				statsKeys = append(statsKeys, item.Key+"="+item.ValueJson)
+			}
+		case *service.Record_OutputRaw:
+			lines = append(lines, x.OutputRaw.Line)
+		}
+	}
+	sort.Strings(statsKeys)
+	sort.Strings(lines)
+
+	assert.Equal(t, []string{"gpu.0.temp=40", "node1.gpu.0.temp=60"}, statsKeys)
+	assert.Equal(t, []string{"\n", "[node1] epoch 1", "epoch 1"}, lines)
+}
+
+func TestWriterLabel_UnlabeledWhenPrimaryUnset(t *testing.T) {
+	runChan := make(chan *service.Record, 1)
+	settings := &service.Settings{XLabel: &wrapperspb.StringValue{Value: "node0"}}
+	h := server.NewHandler(context.Background(),
+		&server.HandlerParams{
+			Logger:          observability.NewNoOpLogger(),
+			Settings:        settings,
+			FwdChan:         runChan,
+			OutChan:         make(chan *service.Result, 1),
+			TerminalPrinter: observability.NewPrinter(),
+		},
+	)
+	inChan := make(chan *service.Record, 1)
+	go h.Do(inChan)
+
+	inChan <- makeStatsRecord("cpu", "10")
+
+	assert.Equal(t, "cpu", (<-runChan).GetStats().GetItem()[0].Key)
+}
+
+func TestWriterLabel_Metadata(t *testing.T) {
+	filesDir := t.TempDir()
+	settings := &service.Settings{
+		FilesDir: &wrapperspb.StringValue{Value: filesDir},
+		XLabel:   &wrapperspb.StringValue{Value: "node/1"},
+		XPrimary: &wrapperspb.BoolValue{Value: false},
+	}
+	runMetadata := server.NewRunMetadata(context.Background(), settings, observability.NewNoOpLogger())
+
+	records := runMetadata.Capture(&service.RunRecord{}, nil)
+
+	require.NotEmpty(t, records)
+	files := records[len(records)-1].GetFiles().GetFiles()
+	require.Len(t, files, 1)
+	assert.Equal(t, "wandb-metadata-node_1.json", files[0].GetPath())
+	_, err := os.Stat(filepath.Join(filesDir, "wandb-metadata-node_1.json"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(filesDir, server.MetaFileName))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -513,8 +513,16 @@ type ServerInformAttachResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Settings *Settings    `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
-	XInfo    *XRecordInfo `protobuf:"bytes,200,opt,name=_info,json=Info,proto3" json:"_info,omitempty"`
+	Settings *Settings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	// The run of the stream, to rebuild the client's Run object from.
+	Run *RunRecord `protobuf:"bytes,2,opt,name=run,proto3" json:"run,omitempty"`
+	// Why the stream can't be attached to, like it not existing or having
+	// finished, if it can't.
+	Error *ErrorInfo `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Whether the server hasn't yet confirmed the run, in which case it may
+	// still change.
+	Provisional bool         `protobuf:"varint,4,opt,name=provisional,proto3" json:"provisional,omitempty"`
+	XInfo       *XRecordInfo `protobuf:"bytes,200,opt,name=_info,json=Info,proto3" json:"_info,omitempty"`
 }
 
 func (x *ServerInformAttachResponse) Reset() {
@@ -556,6 +564,27 @@ func (x *ServerInformAttachResponse) GetSettings() *Settings {
 	return nil
 }
 
+func (x *ServerInformAttachResponse) GetRun() *RunRecord {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *ServerInformAttachResponse) GetError() *ErrorInfo {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *ServerInformAttachResponse) GetProvisional() bool {
+	if x != nil {
+		return x.Provisional
+	}
+	return false
+}
+
 func (x *ServerInformAttachResponse) GetXInfo() *XRecordInfo {
 	if x != nil {
 		return x.XInfo
@@ -653,8 +682,11 @@ type ServerInformTeardownRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ExitCode int32        `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	XInfo    *XRecordInfo `protobuf:"bytes,200,opt,name=_info,json=Info,proto3" json:"_info,omitempty"`
+	ExitCode int32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Whether to close the streams right away, saving their data to their
+	// transaction logs without waiting to upload it.
+	Force bool         `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XInfo *XRecordInfo `protobuf:"bytes,200,opt,name=_info,json=Info,proto3" json:"_info,omitempty"`
 }
 
 func (x *ServerInformTeardownRequest) Reset() {
@@ -696,6 +728,13 @@ func (x *ServerInformTeardownRequest) GetExitCode() int32 {
 	return 0
 }
 
+func (x *ServerInformTeardownRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
 func (x *ServerInformTeardownRequest) GetXInfo() *XRecordInfo {
 	if x != nil {
 		return x.XInfo
@@ -741,6 +780,132 @@ func (*ServerInformTeardownResponse) Descriptor() ([]byte, []int) {
 	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{15}
 }
 
+// ServerPingRequest checks that wandb-core is alive, to diagnose hangs.
+//
+// It's answered by the connection as soon as it's read, without involving
+// any stream.
+type ServerPingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	XInfo *XRecordInfo `protobuf:"bytes,200,opt,name=_info,json=Info,proto3" json:"_info,omitempty"`
+}
+
+func (x *ServerPingRequest) Reset() {
+	*x = ServerPingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wandb_proto_wandb_server_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerPingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerPingRequest) ProtoMessage() {}
+
+func (x *ServerPingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wandb_proto_wandb_server_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerPingRequest.ProtoReflect.Descriptor instead.
+func (*ServerPingRequest) Descriptor() ([]byte, []int) {
+	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ServerPingRequest) GetXInfo() *XRecordInfo {
+	if x != nil {
+		return x.XInfo
+	}
+	return nil
+}
+
+type ServerPingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version of wandb-core.
+	CoreVersion string `protobuf:"bytes,1,opt,name=core_version,json=coreVersion,proto3" json:"core_version,omitempty"`
+	// How long wandb-core has been running.
+	UptimeSeconds float64 `protobuf:"fixed64,2,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// The number of streams wandb-core is running.
+	ActiveStreams int32 `protobuf:"varint,3,opt,name=active_streams,json=activeStreams,proto3" json:"active_streams,omitempty"`
+	// The health of each running stream.
+	Streams []*StreamHealth `protobuf:"bytes,4,rep,name=streams,proto3" json:"streams,omitempty"`
+}
+
+func (x *ServerPingResponse) Reset() {
+	*x = ServerPingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wandb_proto_wandb_server_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerPingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerPingResponse) ProtoMessage() {}
+
+func (x *ServerPingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wandb_proto_wandb_server_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerPingResponse.ProtoReflect.Descriptor instead.
+func (*ServerPingResponse) Descriptor() ([]byte, []int) {
+	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ServerPingResponse) GetCoreVersion() string {
+	if x != nil {
+		return x.CoreVersion
+	}
+	return ""
+}
+
+func (x *ServerPingResponse) GetUptimeSeconds() float64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *ServerPingResponse) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *ServerPingResponse) GetStreams() []*StreamHealth {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
 type ServerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -756,13 +921,14 @@ type ServerRequest struct {
 	//	*ServerRequest_InformDetach
 	//	*ServerRequest_InformTeardown
 	//	*ServerRequest_InformStart
+	//	*ServerRequest_Ping
 	ServerRequestType isServerRequest_ServerRequestType `protobuf_oneof:"server_request_type"`
 }
 
 func (x *ServerRequest) Reset() {
 	*x = ServerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wandb_proto_wandb_server_proto_msgTypes[16]
+		mi := &file_wandb_proto_wandb_server_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -775,7 +941,7 @@ func (x *ServerRequest) String() string {
 func (*ServerRequest) ProtoMessage() {}
 
 func (x *ServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wandb_proto_wandb_server_proto_msgTypes[16]
+	mi := &file_wandb_proto_wandb_server_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +954,7 @@ func (x *ServerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerRequest.ProtoReflect.Descriptor instead.
 func (*ServerRequest) Descriptor() ([]byte, []int) {
-	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{16}
+	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{18}
 }
 
 func (m *ServerRequest) GetServerRequestType() isServerRequest_ServerRequestType {
@@ -854,6 +1020,13 @@ func (x *ServerRequest) GetInformStart() *ServerInformStartRequest {
 	return nil
 }
 
+func (x *ServerRequest) GetPing() *ServerPingRequest {
+	if x, ok := x.GetServerRequestType().(*ServerRequest_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
 type isServerRequest_ServerRequestType interface {
 	isServerRequest_ServerRequestType()
 }
@@ -890,6 +1063,10 @@ type ServerRequest_InformStart struct {
 	InformStart *ServerInformStartRequest `protobuf:"bytes,8,opt,name=inform_start,json=informStart,proto3,oneof"`
 }
 
+type ServerRequest_Ping struct {
+	Ping *ServerPingRequest `protobuf:"bytes,9,opt,name=ping,proto3,oneof"`
+}
+
 func (*ServerRequest_RecordPublish) isServerRequest_ServerRequestType() {}
 
 func (*ServerRequest_RecordCommunicate) isServerRequest_ServerRequestType() {}
@@ -906,6 +1083,8 @@ func (*ServerRequest_InformTeardown) isServerRequest_ServerRequestType() {}
 
 func (*ServerRequest_InformStart) isServerRequest_ServerRequestType() {}
 
+func (*ServerRequest_Ping) isServerRequest_ServerRequestType() {}
+
 type ServerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -920,13 +1099,14 @@ type ServerResponse struct {
 	//	*ServerResponse_InformDetachResponse
 	//	*ServerResponse_InformTeardownResponse
 	//	*ServerResponse_InformStartResponse
+	//	*ServerResponse_PingResponse
 	ServerResponseType isServerResponse_ServerResponseType `protobuf_oneof:"server_response_type"`
 }
 
 func (x *ServerResponse) Reset() {
 	*x = ServerResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wandb_proto_wandb_server_proto_msgTypes[17]
+		mi := &file_wandb_proto_wandb_server_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -939,7 +1119,7 @@ func (x *ServerResponse) String() string {
 func (*ServerResponse) ProtoMessage() {}
 
 func (x *ServerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wandb_proto_wandb_server_proto_msgTypes[17]
+	mi := &file_wandb_proto_wandb_server_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,7 +1132,7 @@ func (x *ServerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerResponse.ProtoReflect.Descriptor instead.
 func (*ServerResponse) Descriptor() ([]byte, []int) {
-	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{17}
+	return file_wandb_proto_wandb_server_proto_rawDescGZIP(), []int{19}
 }
 
 func (m *ServerResponse) GetServerResponseType() isServerResponse_ServerResponseType {
@@ -1011,6 +1191,13 @@ func (x *ServerResponse) GetInformStartResponse() *ServerInformStartResponse {
 	return nil
 }
 
+func (x *ServerResponse) GetPingResponse() *ServerPingResponse {
+	if x, ok := x.GetServerResponseType().(*ServerResponse_PingResponse); ok {
+		return x.PingResponse
+	}
+	return nil
+}
+
 type isServerResponse_ServerResponseType interface {
 	isServerResponse_ServerResponseType()
 }
@@ -1043,6 +1230,10 @@ type ServerResponse_InformStartResponse struct {
 	InformStartResponse *ServerInformStartResponse `protobuf:"bytes,8,opt,name=inform_start_response,json=informStartResponse,proto3,oneof"`
 }
 
+type ServerResponse_PingResponse struct {
+	PingResponse *ServerPingResponse `protobuf:"bytes,9,opt,name=ping_response,json=pingResponse,proto3,oneof"`
+}
+
 func (*ServerResponse_ResultCommunicate) isServerResponse_ServerResponseType() {}
 
 func (*ServerResponse_InformInitResponse) isServerResponse_ServerResponseType() {}
@@ -1057,6 +1248,8 @@ func (*ServerResponse_InformTeardownResponse) isServerResponse_ServerResponseTyp
 
 func (*ServerResponse_InformStartResponse) isServerResponse_ServerResponseType() {}
 
+func (*ServerResponse_PingResponse) isServerResponse_ServerResponseType() {}
+
 var File_wandb_proto_wandb_server_proto protoreflect.FileDescriptor
 
 var file_wandb_proto_wandb_server_proto_rawDesc = []byte{
@@ -1113,13 +1306,21 @@ var file_wandb_proto_wandb_server_proto_rawDesc = []byte{
 	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x5f, 0x69, 0x6e,
 	0x66, 0x6f, 0x18, 0xc8, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x6e, 0x64,
 	0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x5f, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x85, 0x01, 0x0a,
+	0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x85, 0x02, 0x0a,
 	0x1a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x41, 0x74, 0x74,
 	0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x73,
 	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
 	0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53,
 	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x73, 0x12, 0x31, 0x0a, 0x05, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc8, 0x01, 0x20, 0x01, 0x28,
+	0x73, 0x12, 0x2b, 0x0a, 0x03, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
+	0x52, 0x75, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x03, 0x72, 0x75, 0x6e, 0x12, 0x2f,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x12, 0x31, 0x0a, 0x05, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc8, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
 	0x61, 0x6c, 0x2e, 0x5f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
 	0x49, 0x6e, 0x66, 0x6f, 0x22, 0x4e, 0x0a, 0x19, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e,
@@ -1129,102 +1330,129 @@ var file_wandb_proto_wandb_server_proto_rawDesc = []byte{
 	0x61, 0x6c, 0x2e, 0x5f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
 	0x49, 0x6e, 0x66, 0x6f, 0x22, 0x1c, 0x0a, 0x1a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e,
 	0x66, 0x6f, 0x72, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x6d, 0x0a, 0x1b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x31,
-	0x0a, 0x05, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc8, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e,
-	0x5f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x49, 0x6e, 0x66,
-	0x6f, 0x22, 0x1e, 0x0a, 0x1c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x99, 0x05, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x61,
-	0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x73, 0x68, 0x12, 0x47, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x63,
-	0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x4a, 0x0a,
-	0x0b, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x69,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x50, 0x0a, 0x0d, 0x69, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69,
-	0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x69,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x12, 0x50, 0x0a, 0x0d, 0x69,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
-	0x0c, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x12, 0x50, 0x0a,
-	0x0d, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x0c, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x12,
-	0x56, 0x0a, 0x0f, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f,
-	0x77, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62,
-	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54,
-	0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x4d, 0x0a, 0x0c, 0x69, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
-	0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x69, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x15, 0x0a, 0x13, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0xc6, 0x05,
-	0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x47, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x75,
-	0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77,
-	0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f,
-	0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x5c, 0x0a, 0x14, 0x69, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f,
+	0x73, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x1b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x05, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc8,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x5f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x1e, 0x0a, 0x1c, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x0a, 0x11, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a,
+	0x05, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc8, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x5f,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x49, 0x6e, 0x66, 0x6f,
+	0x22, 0xbd, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x72, 0x65, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0d, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x36, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x61, 0x6e, 0x64,
+	0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73,
+	0x22, 0xd2, 0x05, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x61, 0x6e,
+	0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x12, 0x47, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x4a, 0x0a, 0x0b,
+	0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x49,
+	0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x50, 0x0a, 0x0d, 0x69, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x12, 0x50, 0x0a, 0x0d, 0x69, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x41,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c,
+	0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x12, 0x50, 0x0a, 0x0d,
+	0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0c, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x12, 0x56,
+	0x0a, 0x0f, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f,
 	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x52,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65,
+	0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x4d, 0x0a, 0x0c, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x77,
+	0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x37, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x42, 0x15,
+	0x0a, 0x13, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0x91, 0x06, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x11,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x12, 0x5c, 0x0a, 0x14, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x6e, 0x69, 0x74,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x6e, 0x69,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x69, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x62, 0x0a, 0x16, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x16, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x61, 0x74,
+	0x74, 0x61, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x16, 0x69, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f,
+	0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f,
 	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x46, 0x69, 0x6e,
-	0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x16, 0x69,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x61,
-	0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x62, 0x0a, 0x16, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2a, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x65, 0x74,
-	0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x18, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x74, 0x65,
-	0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x16, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61,
-	0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a,
-	0x15, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77,
-	0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x13, 0x69, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x16,
-	0x0a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x65, 0x74,
+	0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x18, 0x69,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
+	0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64,
+	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x16, 0x69,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x54, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x15, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x13, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0d, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x77, 0x61, 0x6e, 0x64, 0x62, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x0c, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x16, 0x0a, 0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1239,7 +1467,7 @@ func file_wandb_proto_wandb_server_proto_rawDescGZIP() []byte {
 	return file_wandb_proto_wandb_server_proto_rawDescData
 }
 
-var file_wandb_proto_wandb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_wandb_proto_wandb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_wandb_proto_wandb_server_proto_goTypes = []interface{}{
 	(*ServerShutdownRequest)(nil),        // 0: wandb_internal.ServerShutdownRequest
 	(*ServerShutdownResponse)(nil),       // 1: wandb_internal.ServerShutdownResponse
@@ -1257,46 +1485,57 @@ var file_wandb_proto_wandb_server_proto_goTypes = []interface{}{
 	(*ServerInformDetachResponse)(nil),   // 13: wandb_internal.ServerInformDetachResponse
 	(*ServerInformTeardownRequest)(nil),  // 14: wandb_internal.ServerInformTeardownRequest
 	(*ServerInformTeardownResponse)(nil), // 15: wandb_internal.ServerInformTeardownResponse
-	(*ServerRequest)(nil),                // 16: wandb_internal.ServerRequest
-	(*ServerResponse)(nil),               // 17: wandb_internal.ServerResponse
-	(*XRecordInfo)(nil),                  // 18: wandb_internal._RecordInfo
-	(*Settings)(nil),                     // 19: wandb_internal.Settings
-	(*Record)(nil),                       // 20: wandb_internal.Record
-	(*Result)(nil),                       // 21: wandb_internal.Result
+	(*ServerPingRequest)(nil),            // 16: wandb_internal.ServerPingRequest
+	(*ServerPingResponse)(nil),           // 17: wandb_internal.ServerPingResponse
+	(*ServerRequest)(nil),                // 18: wandb_internal.ServerRequest
+	(*ServerResponse)(nil),               // 19: wandb_internal.ServerResponse
+	(*XRecordInfo)(nil),                  // 20: wandb_internal._RecordInfo
+	(*Settings)(nil),                     // 21: wandb_internal.Settings
+	(*RunRecord)(nil),                    // 22: wandb_internal.RunRecord
+	(*ErrorInfo)(nil),                    // 23: wandb_internal.ErrorInfo
+	(*StreamHealth)(nil),                 // 24: wandb_internal.StreamHealth
+	(*Record)(nil),                       // 25: wandb_internal.Record
+	(*Result)(nil),                       // 26: wandb_internal.Result
 }
 var file_wandb_proto_wandb_server_proto_depIdxs = []int32{
-	18, // 0: wandb_internal.ServerShutdownRequest._info:type_name -> wandb_internal._RecordInfo
-	18, // 1: wandb_internal.ServerStatusRequest._info:type_name -> wandb_internal._RecordInfo
-	19, // 2: wandb_internal.ServerInformInitRequest.settings:type_name -> wandb_internal.Settings
-	18, // 3: wandb_internal.ServerInformInitRequest._info:type_name -> wandb_internal._RecordInfo
-	19, // 4: wandb_internal.ServerInformStartRequest.settings:type_name -> wandb_internal.Settings
-	18, // 5: wandb_internal.ServerInformStartRequest._info:type_name -> wandb_internal._RecordInfo
-	18, // 6: wandb_internal.ServerInformFinishRequest._info:type_name -> wandb_internal._RecordInfo
-	18, // 7: wandb_internal.ServerInformAttachRequest._info:type_name -> wandb_internal._RecordInfo
-	19, // 8: wandb_internal.ServerInformAttachResponse.settings:type_name -> wandb_internal.Settings
-	18, // 9: wandb_internal.ServerInformAttachResponse._info:type_name -> wandb_internal._RecordInfo
-	18, // 10: wandb_internal.ServerInformDetachRequest._info:type_name -> wandb_internal._RecordInfo
-	18, // 11: wandb_internal.ServerInformTeardownRequest._info:type_name -> wandb_internal._RecordInfo
-	20, // 12: wandb_internal.ServerRequest.record_publish:type_name -> wandb_internal.Record
-	20, // 13: wandb_internal.ServerRequest.record_communicate:type_name -> wandb_internal.Record
-	4,  // 14: wandb_internal.ServerRequest.inform_init:type_name -> wandb_internal.ServerInformInitRequest
-	8,  // 15: wandb_internal.ServerRequest.inform_finish:type_name -> wandb_internal.ServerInformFinishRequest
-	10, // 16: wandb_internal.ServerRequest.inform_attach:type_name -> wandb_internal.ServerInformAttachRequest
-	12, // 17: wandb_internal.ServerRequest.inform_detach:type_name -> wandb_internal.ServerInformDetachRequest
-	14, // 18: wandb_internal.ServerRequest.inform_teardown:type_name -> wandb_internal.ServerInformTeardownRequest
-	6,  // 19: wandb_internal.ServerRequest.inform_start:type_name -> wandb_internal.ServerInformStartRequest
-	21, // 20: wandb_internal.ServerResponse.result_communicate:type_name -> wandb_internal.Result
-	5,  // 21: wandb_internal.ServerResponse.inform_init_response:type_name -> wandb_internal.ServerInformInitResponse
-	9,  // 22: wandb_internal.ServerResponse.inform_finish_response:type_name -> wandb_internal.ServerInformFinishResponse
-	11, // 23: wandb_internal.ServerResponse.inform_attach_response:type_name -> wandb_internal.ServerInformAttachResponse
-	13, // 24: wandb_internal.ServerResponse.inform_detach_response:type_name -> wandb_internal.ServerInformDetachResponse
-	15, // 25: wandb_internal.ServerResponse.inform_teardown_response:type_name -> wandb_internal.ServerInformTeardownResponse
-	7,  // 26: wandb_internal.ServerResponse.inform_start_response:type_name -> wandb_internal.ServerInformStartResponse
-	27, // [27:27] is the sub-list for method output_type
-	27, // [27:27] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	20, // 0: wandb_internal.ServerShutdownRequest._info:type_name -> wandb_internal._RecordInfo
+	20, // 1: wandb_internal.ServerStatusRequest._info:type_name -> wandb_internal._RecordInfo
+	21, // 2: wandb_internal.ServerInformInitRequest.settings:type_name -> wandb_internal.Settings
+	20, // 3: wandb_internal.ServerInformInitRequest._info:type_name -> wandb_internal._RecordInfo
+	21, // 4: wandb_internal.ServerInformStartRequest.settings:type_name -> wandb_internal.Settings
+	20, // 5: wandb_internal.ServerInformStartRequest._info:type_name -> wandb_internal._RecordInfo
+	20, // 6: wandb_internal.ServerInformFinishRequest._info:type_name -> wandb_internal._RecordInfo
+	20, // 7: wandb_internal.ServerInformAttachRequest._info:type_name -> wandb_internal._RecordInfo
+	21, // 8: wandb_internal.ServerInformAttachResponse.settings:type_name -> wandb_internal.Settings
+	22, // 9: wandb_internal.ServerInformAttachResponse.run:type_name -> wandb_internal.RunRecord
+	23, // 10: wandb_internal.ServerInformAttachResponse.error:type_name -> wandb_internal.ErrorInfo
+	20, // 11: wandb_internal.ServerInformAttachResponse._info:type_name -> wandb_internal._RecordInfo
+	20, // 12: wandb_internal.ServerInformDetachRequest._info:type_name -> wandb_internal._RecordInfo
+	20, // 13: wandb_internal.ServerInformTeardownRequest._info:type_name -> wandb_internal._RecordInfo
+	20, // 14: wandb_internal.ServerPingRequest._info:type_name -> wandb_internal._RecordInfo
+	24, // 15: wandb_internal.ServerPingResponse.streams:type_name -> wandb_internal.StreamHealth
+	25, // 16: wandb_internal.ServerRequest.record_publish:type_name -> wandb_internal.Record
+	25, // 17: wandb_internal.ServerRequest.record_communicate:type_name -> wandb_internal.Record
+	4,  // 18: wandb_internal.ServerRequest.inform_init:type_name -> wandb_internal.ServerInformInitRequest
+	8,  // 19: wandb_internal.ServerRequest.inform_finish:type_name -> wandb_internal.ServerInformFinishRequest
+	10, // 20: wandb_internal.ServerRequest.inform_attach:type_name -> wandb_internal.ServerInformAttachRequest
+	12, // 21: wandb_internal.ServerRequest.inform_detach:type_name -> wandb_internal.ServerInformDetachRequest
+	14, // 22: wandb_internal.ServerRequest.inform_teardown:type_name -> wandb_internal.ServerInformTeardownRequest
+	6,  // 23: wandb_internal.ServerRequest.inform_start:type_name -> wandb_internal.ServerInformStartRequest
+	16, // 24: wandb_internal.ServerRequest.ping:type_name -> wandb_internal.ServerPingRequest
+	26, // 25: wandb_internal.ServerResponse.result_communicate:type_name -> wandb_internal.Result
+	5,  // 26: wandb_internal.ServerResponse.inform_init_response:type_name -> wandb_internal.ServerInformInitResponse
+	9,  // 27: wandb_internal.ServerResponse.inform_finish_response:type_name -> wandb_internal.ServerInformFinishResponse
+	11, // 28: wandb_internal.ServerResponse.inform_attach_response:type_name -> wandb_internal.ServerInformAttachResponse
+	13, // 29: wandb_internal.ServerResponse.inform_detach_response:type_name -> wandb_internal.ServerInformDetachResponse
+	15, // 30: wandb_internal.ServerResponse.inform_teardown_response:type_name -> wandb_internal.ServerInformTeardownResponse
+	7,  // 31: wandb_internal.ServerResponse.inform_start_response:type_name -> wandb_internal.ServerInformStartResponse
+	17, // 32: wandb_internal.ServerResponse.ping_response:type_name -> wandb_internal.ServerPingResponse
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_wandb_proto_wandb_server_proto_init() }
@@ -1501,7 +1740,7 @@ func file_wandb_proto_wandb_server_proto_init() {
 			}
 		}
 		file_wandb_proto_wandb_server_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerRequest); i {
+			switch v := v.(*ServerPingRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1513,6 +1752,30 @@ func file_wandb_proto_wandb_server_proto_init() {
 			}
 		}
 		file_wandb_proto_wandb_server_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerPingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wandb_proto_wandb_server_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wandb_proto_wandb_server_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerResponse); i {
 			case 0:
 				return &v.state
@@ -1525,7 +1788,7 @@ func file_wandb_proto_wandb_server_proto_init() {
 			}
 		}
 	}
-	file_wandb_proto_wandb_server_proto_msgTypes[16].OneofWrappers = []interface{}{
+	file_wandb_proto_wandb_server_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*ServerRequest_RecordPublish)(nil),
 		(*ServerRequest_RecordCommunicate)(nil),
 		(*ServerRequest_InformInit)(nil),
@@ -1534,8 +1797,9 @@ func file_wandb_proto_wandb_server_proto_init() {
 		(*ServerRequest_InformDetach)(nil),
 		(*ServerRequest_InformTeardown)(nil),
 		(*ServerRequest_InformStart)(nil),
+		(*ServerRequest_Ping)(nil),
 	}
-	file_wandb_proto_wandb_server_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_wandb_proto_wandb_server_proto_msgTypes[19].OneofWrappers = []interface{}{
 		(*ServerResponse_ResultCommunicate)(nil),
 		(*ServerResponse_InformInitResponse)(nil),
 		(*ServerResponse_InformFinishResponse)(nil),
@@ -1543,6 +1807,7 @@ func file_wandb_proto_wandb_server_proto_init() {
 		(*ServerResponse_InformDetachResponse)(nil),
 		(*ServerResponse_InformTeardownResponse)(nil),
 		(*ServerResponse_InformStartResponse)(nil),
+		(*ServerResponse_PingResponse)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1550,7 +1815,7 @@ func file_wandb_proto_wandb_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_wandb_proto_wandb_server_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
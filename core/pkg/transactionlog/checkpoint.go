@@ -0,0 +1,51 @@
+package transactionlog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+)
+
+// CheckpointSuffix is appended to the path of a transaction log to name the
+// file recording where its last checkpoint is.
+const CheckpointSuffix = ".checkpoint"
+
+// CheckpointIndex records where the last checkpoint in a transaction log
+// is, so that readers can seek to it without scanning the log.
+type CheckpointIndex struct {
+	// Offset is the offset of the checkpoint record.
+	Offset int64 `json:"offset"`
+
+	// LastRecordNum is the number of the last record before the
+	// checkpoint.
+	LastRecordNum int64 `json:"last_record_num"`
+}
+
+// WriteCheckpointIndex records where the last checkpoint in the
+// transaction log at the path is.
+//
+// The index is replaced atomically, so readers never see a partial one.
+func WriteCheckpointIndex(path string, index CheckpointIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	if err := atomicfile.Write(path+CheckpointSuffix, data, 0o644); err != nil {
+		return fmt.Errorf("transactionlog: can't write checkpoint index: %w", err)
+	}
+	return nil
+}
+
+// ReadCheckpointIndex returns where the last checkpoint in the transaction
+// log at the path is, or nil if none was recorded or the recorded one is
+// corrupt.
+func ReadCheckpointIndex(path string) (*CheckpointIndex, error) {
+	index := &CheckpointIndex{}
+	found, err := atomicfile.ReadJSON(path+CheckpointSuffix, index)
+	if err != nil || !found {
+		return nil, err
+	}
+	return index, nil
+}
@@ -0,0 +1,128 @@
+package transactionlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+)
+
+// DigestSuffix is appended to the path of a transaction log to name the
+// file recording its final state.
+const DigestSuffix = ".digest"
+
+// ErrDigestMismatch is the error of a transaction log that changed after
+// its digest was recorded.
+var ErrDigestMismatch = errors.New("transactionlog: log doesn't match its digest")
+
+// Digest records the final state of a transaction log, so that changes
+// made to it afterwards can be detected.
+type Digest struct {
+	// SHA256 is the hex-encoded SHA-256 of the whole file.
+	SHA256 string `json:"sha256"`
+
+	// Records is the number of records in the log.
+	Records int64 `json:"records"`
+
+	// Bytes is the size of the file.
+	Bytes int64 `json:"bytes"`
+
+	// FinishedAt is when the log was finished.
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// WriteDigest records the digest of the transaction log at the path.
+//
+// The digest is replaced atomically, so readers never see a partial one.
+func WriteDigest(path string, digest Digest) error {
+	data, err := json.Marshal(digest)
+	if err != nil {
+		return err
+	}
+
+	if err := atomicfile.Write(path+DigestSuffix, data, 0o644); err != nil {
+		return fmt.Errorf("transactionlog: can't write digest: %w", err)
+	}
+	return nil
+}
+
+// ReadDigest returns the digest of the transaction log at the path, or nil
+// if none was recorded or the recorded one is corrupt.
+func ReadDigest(path string) (*Digest, error) {
+	digest := &Digest{}
+	found, err := atomicfile.ReadJSON(path+DigestSuffix, digest)
+	if err != nil || !found {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// VerifyDigest checks the transaction log at the path against its
+// recorded digest, and returns the digest.
+//
+// It returns nil if no digest was recorded, as for logs of runs that
+// didn't finish, and an error wrapping ErrDigestMismatch if the log
+// changed. The log is read in a single pass.
+func VerifyDigest(path string) (*Digest, error) {
+	digest, err := ReadDigest(path)
+	if err != nil || digest == nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return digest, err
+	}
+	defer func() { _ = f.Close() }()
+
+	hasher := newDigester(io.Discard)
+	if _, err := io.Copy(hasher, f); err != nil {
+		return digest, fmt.Errorf("transactionlog: can't read log: %w", err)
+	}
+
+	if sum := hasher.sum(); sum != digest.SHA256 || hasher.bytes != digest.Bytes {
+		return digest, fmt.Errorf(
+			"%w: it has %d bytes with SHA-256 %s, but %d bytes with SHA-256"+
+				" %s were recorded when the run finished",
+			ErrDigestMismatch,
+			hasher.bytes,
+			sum,
+			digest.Bytes,
+			digest.SHA256,
+		)
+	}
+	return digest, nil
+}
+
+// digester hashes the bytes written through it.
+//
+// Only the bytes that the underlying writer accepted are hashed, so that
+// the hash matches the file even if a write fails partway.
+type digester struct {
+	w     io.Writer
+	hash  hash.Hash
+	bytes int64
+}
+
+func newDigester(w io.Writer) *digester {
+	return &digester{w: w, hash: sha256.New()}
+}
+
+func (d *digester) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	d.hash.Write(p[:n])
+	d.bytes += int64(n)
+	return n, err
+}
+
+// sum returns the hex-encoded hash of the bytes written so far.
+func (d *digester) sum() string {
+	return hex.EncodeToString(d.hash.Sum(nil))
+}
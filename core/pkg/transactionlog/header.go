@@ -0,0 +1,70 @@
+// Package transactionlog reads and writes .wandb transaction logs.
+//
+// A transaction log is a header followed by a sequence of records in the
+// LevelDB log format (see package leveldb), each of which is a serialized
+// service.Record. Offsets of records are byte offsets from the start of
+// the file, including the header, and can be used to resume reading.
+package transactionlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/wandb/wandb/core/pkg/leveldb"
+)
+
+// The checksum algorithm of the records.
+const crcAlgo = leveldb.CRCAlgoIEEE
+
+// HeaderOptions is the header at the start of a transaction log.
+type HeaderOptions struct {
+	IDENT   [4]byte
+	Magic   uint16
+	Version byte
+}
+
+const (
+	// headerMagic is the magic number for the header.
+	headerMagic = 0xBEE1
+	// headerVersion is the version of the header.
+	headerVersion = 0
+	// headerSize is the size of the header in bytes.
+	headerSize = 7
+)
+
+// headerIdent returns the header identifier.
+func headerIdent() [4]byte {
+	return [4]byte{':', 'W', '&', 'B'}
+}
+
+// NewHeader returns a new header with default values.
+func NewHeader() *HeaderOptions {
+	return &HeaderOptions{
+		IDENT:   headerIdent(),
+		Magic:   headerMagic,
+		Version: headerVersion,
+	}
+}
+
+// MarshalBinary encodes the header to binary format.
+func (o *HeaderOptions) MarshalBinary(w io.Writer) error {
+
+	if err := binary.Write(w, binary.LittleEndian, o); err != nil {
+		return fmt.Errorf("error writing binary data: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalBinary decodes binary data into the header.
+func (o *HeaderOptions) UnmarshalBinary(r io.Reader) error {
+	if err := binary.Read(r, binary.LittleEndian, o); err != nil {
+		return fmt.Errorf("error reading binary data: %w", err)
+	}
+	return nil
+}
+
+// Valid checks if the header is valid based on a reference header.
+func (o *HeaderOptions) Valid() bool {
+	return o.IDENT == headerIdent() && o.Magic == headerMagic && o.Version == headerVersion
+}
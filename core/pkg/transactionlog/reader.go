@@ -0,0 +1,153 @@
+package transactionlog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/pkg/leveldb"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// ErrInvalidHeader is returned when a file is not a transaction log.
+var ErrInvalidHeader = errors.New("transactionlog: invalid header")
+
+// Reader reads records from a transaction log.
+//
+// A log whose last record is incomplete, like the log of a run whose
+// process was killed, reads as if it ended before that record.
+type Reader struct {
+	// file is the underlying file, if the reader was created by Open.
+	file *os.File
+
+	// records reads the records after the header.
+	records *leveldb.Reader
+}
+
+// Open opens the transaction log at the path for reading.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	r.file = f
+	return r, nil
+}
+
+// NewReader returns a reader for the transaction log in r.
+//
+// The log must start at the current position of r, which is usually
+// the start of a file.
+func NewReader(r io.ReadSeeker) (*Reader, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	header := NewHeader()
+	if err := header.UnmarshalBinary(r); err != nil {
+		return nil, fmt.Errorf("transactionlog: can't read header: %v", err)
+	}
+	if !header.Valid() {
+		return nil, ErrInvalidHeader
+	}
+
+	// Blocks are aligned relative to the end of the header, so the
+	// LevelDB reader must see the end of the header as offset zero.
+	body := io.NewSectionReader(
+		readerAt{r},
+		start+headerSize,
+		math.MaxInt64-start-headerSize,
+	)
+
+	return &Reader{records: leveldb.NewReaderExt(body, crcAlgo)}, nil
+}
+
+// Next returns the next record and its offset.
+//
+// It returns io.EOF after the last complete record. For other errors, the
+// following call to Next continues from the next valid block.
+func (r *Reader) Next() (*service.Record, int64, error) {
+	chunks, err := r.records.Next()
+	if err != nil {
+		return nil, 0, r.recover(err)
+	}
+
+	offset, err := r.records.LastRecordOffset()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	buf, err := io.ReadAll(chunks)
+	if err != nil {
+		return nil, 0, r.recover(err)
+	}
+
+	record := &service.Record{}
+	if err := proto.Unmarshal(buf, record); err != nil {
+		return nil, 0, fmt.Errorf("transactionlog: can't parse record: %v", err)
+	}
+
+	return record, offset + headerSize, nil
+}
+
+// recover prepares the reader to continue after an error and returns the
+// error to report.
+func (r *Reader) recover(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return io.EOF
+	}
+
+	r.records.Recover()
+	return fmt.Errorf("transactionlog: can't read record: %v", err)
+}
+
+// SeekRecord makes the next call to Next return the record at the offset.
+//
+// The offset must be one returned by Next or by Writer.Write.
+func (r *Reader) SeekRecord(offset int64) error {
+	if offset < headerSize {
+		return fmt.Errorf("transactionlog: offset %d is inside the header", offset)
+	}
+
+	// SeekRecord fails if the previous read ended with an error.
+	r.records.Recover()
+	return r.records.SeekRecord(offset - headerSize)
+}
+
+// Close closes the underlying file, if the reader was created by Open.
+func (r *Reader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// readerAt implements io.ReaderAt on top of an io.ReadSeeker.
+//
+// It is not safe for concurrent use, which is fine because Reader isn't.
+type readerAt struct {
+	r io.ReadSeeker
+}
+
+func (r readerAt) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := r.r.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(r.r, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
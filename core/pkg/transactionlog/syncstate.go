@@ -0,0 +1,92 @@
+package transactionlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wandb/wandb/core/internal/atomicfile"
+)
+
+// SyncStateSuffix is appended to the path of a transaction log to name the
+// file recording how much of it reached the server.
+const SyncStateSuffix = ".synced"
+
+// SyncState records how much of a transaction log reached the server.
+type SyncState struct {
+	// LastAcked is the number of the last record the server acknowledged.
+	LastAcked int64 `json:"last_acked"`
+
+	// Complete is whether everything in the log, including the run's files,
+	// reached the server, so the log isn't needed to sync the run.
+	Complete bool `json:"complete"`
+
+	// UpdatedAt is when the state was recorded.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// FileStreamOffsets are the number of lines of each filestream file
+	// the server acknowledged, by file name, if known.
+	FileStreamOffsets map[string]int `json:"file_stream_offsets,omitempty"`
+
+	// PendingFiles are the run's files whose uploads hadn't finished,
+	// relative to its files directory.
+	PendingFiles []string `json:"pending_files,omitempty"`
+
+	// UploadedFiles are the contents of the run's files as of their last
+	// uploads, relative to its files directory, so that unchanged files
+	// aren't uploaded again.
+	UploadedFiles map[string]UploadedFile `json:"uploaded_files,omitempty"`
+
+	// ArtifactDrafts are the artifacts created on the server whose saving
+	// hadn't finished.
+	ArtifactDrafts []ArtifactDraft `json:"artifact_drafts,omitempty"`
+}
+
+// UploadedFile is the content of a file as of its last upload.
+type UploadedFile struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+
+	// Digest is the base64-encoded MD5 of the content.
+	Digest string `json:"digest"`
+
+	// HashedAt is when the digest was computed.
+	HashedAt time.Time `json:"hashed_at"`
+}
+
+// ArtifactDraft is an artifact created on the server but not yet saved.
+type ArtifactDraft struct {
+	// ID is the artifact's ID on the server.
+	ID string `json:"id"`
+
+	// RecordNum is the number of the record logging the artifact, or 0 if
+	// it wasn't logged by a record in the transaction log.
+	RecordNum int64 `json:"record_num,omitempty"`
+}
+
+// WriteSyncState records the sync state of the transaction log at the
+// path.
+//
+// The state is replaced atomically, so readers never see a partial one.
+func WriteSyncState(path string, state SyncState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := atomicfile.Write(path+SyncStateSuffix, data, 0o644); err != nil {
+		return fmt.Errorf("transactionlog: can't write sync state: %w", err)
+	}
+	return nil
+}
+
+// ReadSyncState returns the sync state of the transaction log at the path,
+// or nil if none was recorded or the recorded one is corrupt.
+func ReadSyncState(path string) (*SyncState, error) {
+	state := &SyncState{}
+	found, err := atomicfile.ReadJSON(path+SyncStateSuffix, state)
+	if err != nil || !found {
+		return nil, err
+	}
+	return state, nil
+}
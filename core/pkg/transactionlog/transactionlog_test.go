@@ -0,0 +1,305 @@
+package transactionlog_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/pkg/service"
+	"github.com/wandb/wandb/core/pkg/transactionlog"
+)
+
+// goldenFile is a log of goldenRecords written by the server's Store
+// before this package existed.
+const goldenFile = "testdata/golden.wandb"
+
+// goldenRecords are the records in goldenFile.
+//
+// The output record is larger than a block, so that it is split into
+// several chunks.
+func goldenRecords() []*service.Record {
+	return []*service.Record{
+		{Num: 1, RecordType: &service.Record_Run{
+			Run: &service.RunRecord{RunId: "golden", Project: "transactionlog"}}},
+		{Num: 2, RecordType: &service.Record_Output{
+			Output: &service.OutputRecord{Line: strings.Repeat("x", 40000)}}},
+		{Num: 3, RecordType: &service.Record_Exit{
+			Exit: &service.RunExitRecord{ExitCode: 1}}},
+	}
+}
+
+// goldenOffsets are the offsets of goldenRecords in goldenFile.
+var goldenOffsets = []int64{7, 43, 40067}
+
+// readAll reads the records and offsets of the log until an error.
+func readAll(r *transactionlog.Reader) ([]*service.Record, []int64, error) {
+	var records []*service.Record
+	var offsets []int64
+	for {
+		record, offset, err := r.Next()
+		if err != nil {
+			return records, offsets, err
+		}
+		records = append(records, record)
+		offsets = append(offsets, offset)
+	}
+}
+
+func assertRecords(t *testing.T, expected, actual []*service.Record) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.True(t, proto.Equal(expected[i], actual[i]), "record %d differs", i)
+	}
+}
+
+func TestWriter_MatchesGolden(t *testing.T) {
+	golden, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := transactionlog.NewWriter(&buf)
+	require.NoError(t, err)
+	var offsets []int64
+	for _, record := range goldenRecords() {
+		offset, err := w.Write(record)
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, golden, buf.Bytes())
+	assert.Equal(t, goldenOffsets, offsets)
+}
+
+func TestReader_ReadsGolden(t *testing.T) {
+	r, err := transactionlog.Open(goldenFile)
+	require.NoError(t, err)
+	defer r.Close()
+
+	records, offsets, err := readAll(r)
+
+	assert.ErrorIs(t, err, io.EOF)
+	assertRecords(t, goldenRecords(), records)
+	assert.Equal(t, goldenOffsets, offsets)
+}
+
+func TestReader_SeekRecord(t *testing.T) {
+	r, err := transactionlog.Open(goldenFile)
+	require.NoError(t, err)
+	defer r.Close()
+
+	// Reading to the end first checks that seeking clears the EOF.
+	_, _, err = readAll(r)
+	require.ErrorIs(t, err, io.EOF)
+
+	for i, offset := range goldenOffsets {
+		require.NoError(t, r.SeekRecord(offset))
+
+		records, _, err := readAll(r)
+
+		assert.ErrorIs(t, err, io.EOF)
+		assertRecords(t, goldenRecords()[i:], records)
+	}
+}
+
+func TestReader_SeekRecordInHeader(t *testing.T) {
+	r, err := transactionlog.Open(goldenFile)
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Error(t, r.SeekRecord(3))
+}
+
+func TestReader_TruncatedTail(t *testing.T) {
+	golden, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		size     int
+		nRecords int
+	}{
+		{"in chunk header", int(goldenOffsets[1]) + 3, 1},
+		{"in first chunk", int(goldenOffsets[1]) + 100, 1},
+		{"before last chunk", 7 + 32*1024, 1},
+		{"in last record", len(golden) - 1, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := transactionlog.NewReader(bytes.NewReader(golden[:tc.size]))
+			require.NoError(t, err)
+
+			records, _, err := readAll(r)
+
+			assert.ErrorIs(t, err, io.EOF)
+			assertRecords(t, goldenRecords()[:tc.nRecords], records)
+		})
+	}
+}
+
+func TestReader_Corrupt(t *testing.T) {
+	golden, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+	corrupt := bytes.Clone(golden)
+	corrupt[goldenOffsets[0]+10] ^= 0xFF
+
+	r, err := transactionlog.NewReader(bytes.NewReader(corrupt))
+	require.NoError(t, err)
+
+	_, _, err = r.Next()
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestReader_InvalidHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.wandb")
+	require.NoError(t, os.WriteFile(path, []byte("not a transaction log"), 0644))
+
+	_, err := transactionlog.Open(path)
+
+	assert.ErrorIs(t, err, transactionlog.ErrInvalidHeader)
+}
+
+func TestCreate_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	w, err := transactionlog.Create(path)
+	require.NoError(t, err)
+	for _, record := range goldenRecords() {
+		_, err := w.Write(record)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	r, err := transactionlog.Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+	records, _, err := readAll(r)
+
+	assert.ErrorIs(t, err, io.EOF)
+	assertRecords(t, goldenRecords(), records)
+}
+
+func TestWriter_FlushMakesRecordsReadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	w, err := transactionlog.Create(path)
+	require.NoError(t, err)
+	for _, record := range goldenRecords() {
+		_, err := w.Write(record)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Flush())
+
+	r, err := transactionlog.Open(path)
+	require.NoError(t, err)
+	defer r.Close()
+	records, _, err := readAll(r)
+	assert.ErrorIs(t, err, io.EOF)
+	assertRecords(t, goldenRecords(), records)
+
+	// Flushing doesn't change what is written.
+	require.NoError(t, w.Close())
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	golden, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, golden, contents)
+}
+
+func TestSyncState_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	state := transactionlog.SyncState{
+		LastAcked: 42,
+		Complete:  true,
+		UpdatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, transactionlog.WriteSyncState(path, state))
+	read, err := transactionlog.ReadSyncState(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, &state, read)
+	assert.NoFileExists(t, path+transactionlog.SyncStateSuffix+".tmp")
+}
+
+func TestSyncState_None(t *testing.T) {
+	state, err := transactionlog.ReadSyncState(filepath.Join(t.TempDir(), "run.wandb"))
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestSyncState_CorruptIsNone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	require.NoError(t, os.WriteFile(
+		path+transactionlog.SyncStateSuffix,
+		[]byte(`{"last_acked": 4`),
+		0o644,
+	))
+
+	state, err := transactionlog.ReadSyncState(path)
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestWriter_DigestMatchesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	w, err := transactionlog.Create(path)
+	require.NoError(t, err)
+	for _, record := range goldenRecords() {
+		_, err := w.Write(record)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	digest := w.Digest()
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sum := sha256.Sum256(contents)
+	assert.Equal(t, hex.EncodeToString(sum[:]), digest.SHA256)
+	assert.EqualValues(t, len(contents), digest.Bytes)
+	assert.EqualValues(t, len(goldenRecords()), digest.Records)
+}
+
+func TestVerifyDigest_DetectsChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	w, err := transactionlog.Create(path)
+	require.NoError(t, err)
+	for _, record := range goldenRecords() {
+		_, err := w.Write(record)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, transactionlog.WriteDigest(path, w.Digest()))
+
+	digest, err := transactionlog.VerifyDigest(path)
+	require.NoError(t, err)
+	assert.Equal(t, w.Digest().SHA256, digest.SHA256)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	contents[len(contents)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(path, contents, 0o644))
+	_, err = transactionlog.VerifyDigest(path)
+	assert.ErrorIs(t, err, transactionlog.ErrDigestMismatch)
+}
+
+func TestVerifyDigest_NoneRecorded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.wandb")
+	require.NoError(t, os.WriteFile(path, []byte("anything"), 0o644))
+
+	digest, err := transactionlog.VerifyDigest(path)
+
+	assert.NoError(t, err)
+	assert.Nil(t, digest)
+}
@@ -0,0 +1,136 @@
+package transactionlog
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wandb/wandb/core/pkg/leveldb"
+	"github.com/wandb/wandb/core/pkg/service"
+)
+
+// Writer writes records to a transaction log.
+type Writer struct {
+	// file is the underlying file, if the writer was created by Create.
+	file *os.File
+
+	// records writes the records after the header.
+	records *leveldb.Writer
+
+	// digester hashes everything written to the file, header included.
+	digester *digester
+
+	// count is the number of records written.
+	count int64
+}
+
+// Create creates or truncates the file at the path and starts a
+// transaction log in it.
+func Create(path string) (*Writer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewWriter(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	w.file = f
+	return w, nil
+}
+
+// NewWriter writes the header of a transaction log to w and returns a
+// writer for its records.
+//
+// Everything written is hashed as it's written, for Digest.
+func NewWriter(w io.Writer) (*Writer, error) {
+	digester := newDigester(w)
+	if err := NewHeader().MarshalBinary(digester); err != nil {
+		return nil, fmt.Errorf("transactionlog: can't write header: %w", err)
+	}
+
+	return &Writer{
+		records:  leveldb.NewWriterExt(body{digester}, crcAlgo),
+		digester: digester,
+	}, nil
+}
+
+// Write appends the record to the log and returns its offset.
+//
+// Records are buffered, and are only guaranteed to be in the file after
+// the next call to Flush or Close.
+func (w *Writer) Write(record *service.Record) (int64, error) {
+	chunks, err := w.records.Next()
+	if err != nil {
+		return 0, fmt.Errorf("transactionlog: can't write record: %w", err)
+	}
+
+	offset, err := w.records.LastRecordOffset()
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := proto.Marshal(record)
+	if err != nil {
+		return 0, fmt.Errorf("transactionlog: can't serialize record: %v", err)
+	}
+
+	if _, err := chunks.Write(out); err != nil {
+		return 0, fmt.Errorf("transactionlog: can't write record: %w", err)
+	}
+	w.count++
+
+	return offset + headerSize, nil
+}
+
+// Flush finishes the last record and writes all buffered records to the
+// underlying writer, so that readers of the file see them.
+func (w *Writer) Flush() error {
+	if err := w.records.Flush(); err != nil {
+		return fmt.Errorf("transactionlog: can't flush: %w", err)
+	}
+	return nil
+}
+
+// Close finishes the last record and closes the underlying file, if the
+// writer was created by Create.
+func (w *Writer) Close() error {
+	err := w.records.Close()
+
+	if w.file != nil {
+		if closeErr := w.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// Digest returns the digest of what was written to the underlying writer,
+// without its FinishedAt time.
+//
+// It describes the whole log only after Close, since records are
+// buffered until then.
+func (w *Writer) Digest() Digest {
+	return Digest{
+		SHA256:  w.digester.sum(),
+		Records: w.count,
+		Bytes:   w.digester.bytes,
+	}
+}
+
+// body hides the underlying writer's other methods from the LevelDB
+// writer, so that record offsets are relative to the end of the header
+// like in Reader.
+type body struct {
+	w io.Writer
+}
+
+func (b body) Write(p []byte) (int, error) {
+	return b.w.Write(p)
+}
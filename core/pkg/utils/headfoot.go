@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/wandb/wandb/core/pkg/service"
 )
@@ -73,6 +75,18 @@ func PrintFooterOnline(run *service.RunRecord, settings *service.Settings) {
 		format(url, colorBlue),
 	)
 
+	// anonymous runs are claimed by opening them with their API key
+	if settings.GetAnonymous().GetValue() == "true" {
+		claimURL := fmt.Sprintf("%v?apiKey=%v", url, settings.GetApiKey().GetValue())
+		fmt.Printf("%v: Claim this anonymous run at: %v\n",
+			format("wandb", colorBrightBlue),
+			format(claimURL, colorBlue),
+		)
+		fmt.Printf("%v: Do NOT share this link with anyone. It can be used to claim your runs.\n",
+			format("wandb", colorBrightBlue),
+		)
+	}
+
 	currentDir, err := os.Getwd()
 	if err != nil {
 		return
@@ -114,3 +128,289 @@ func PrintFooterOffline(settings *service.Settings) {
 		format(relLogDir, colorBrightMagenta),
 	)
 }
+
+// PrintFooterOfflineMessages lists the messages for the user of an offline
+// run, which may have had no terminal to show them in.
+func PrintFooterOfflineMessages(messages []*service.UserMessageRecord, dropped int) {
+	if len(messages) == 0 {
+		return
+	}
+
+	fmt.Printf("%v: Messages from the offline run:\n",
+		format("wandb", colorBrightBlue),
+	)
+	for _, message := range messages {
+		PrintUserMessage(&service.UserMessageResponse{
+			Level:   message.GetLevel(),
+			Message: message.GetMessage(),
+		})
+	}
+	if dropped > 0 {
+		fmt.Printf("%v: %d more messages weren't kept.\n",
+			format("wandb", colorBrightBlue),
+			dropped,
+		)
+	}
+}
+
+// PrintFooterDryRun prints that a dry run uploaded nothing, and where the
+// requests it would have sent are recorded.
+func PrintFooterDryRun(dir string) {
+	fmt.Printf("%v: Dry run: nothing was uploaded.\n",
+		format("wandb", colorBrightBlue),
+	)
+	fmt.Printf("%v: Find the requests it would have sent in: %v\n",
+		format("wandb", colorBrightBlue),
+		format(dir, colorBrightMagenta),
+	)
+}
+
+// PrintFooterDisabled prints the footer of a disabled run.
+func PrintFooterDisabled() {
+	fmt.Printf("%v: W&B is disabled: nothing was saved or uploaded.\n",
+		format("wandb", colorBrightBlue),
+	)
+}
+
+// PrintFooterDiscarded prints the footer of a run that was aborted.
+func PrintFooterDiscarded(runID string) {
+	fmt.Printf("%v: Run %v was aborted: it was discarded and won't be synced.\n",
+		format("wandb", colorBrightBlue),
+		format(runID, colorBrightMagenta),
+	)
+}
+
+// PrintFooterRetries prints how often the run's requests were retried,
+// if they ever were.
+func PrintFooterRetries(retries map[string]*service.RetryStats) {
+	if len(retries) == 0 {
+		return
+	}
+
+	endpoints := make([]string, 0, len(retries))
+	for endpoint := range retries {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	counts := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		byCategory := retries[endpoint].GetByCategory()
+		categories := make([]string, 0, len(byCategory))
+		for category := range byCategory {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for j, category := range categories {
+			categories[j] = fmt.Sprintf("%v: %d", category, byCategory[category])
+		}
+
+		counts[i] = fmt.Sprintf("%v %d (%v)",
+			endpoint,
+			retries[endpoint].GetTotal(),
+			strings.Join(categories, ", "),
+		)
+	}
+
+	fmt.Printf("%v: Retried requests: %v\n",
+		format("wandb", colorBrightBlue),
+		strings.Join(counts, ", "),
+	)
+}
+
+// PrintFooterCoalescedHistory prints how many history rows weren't
+// uploaded because they were over the rate limit, if any weren't.
+func PrintFooterCoalescedHistory(coalesced int64) {
+	if coalesced == 0 {
+		return
+	}
+
+	fmt.Printf("%v: %d history rows logged over the rate limit weren't"+
+		" uploaded. They're saved in the run's transaction log.\n",
+		format("wandb", colorBrightBlue),
+		coalesced,
+	)
+}
+
+// PrintFooterPipelineLatency prints how long it took for logged history
+// to be visible in the UI, if any was measured.
+func PrintFooterPipelineLatency(latency *service.PipelineLatency) {
+	acknowledged := latency.GetAcknowledged()
+	if acknowledged.GetSamples() == 0 {
+		return
+	}
+
+	fmt.Printf("%v: Logged metrics were visible in the UI after %v"+
+		" (median) and %v (95th percentile), over %d sampled rows.\n",
+		format("wandb", colorBrightBlue),
+		formatSeconds(acknowledged.GetP50Seconds()),
+		formatSeconds(acknowledged.GetP95Seconds()),
+		acknowledged.GetSamples(),
+	)
+}
+
+// formatSeconds formats a duration in seconds to the millisecond.
+func formatSeconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).
+		Round(time.Millisecond).
+		String()
+}
+
+// PrintFooterUnfinished prints what the run left undone because its exit
+// timed out, if it did.
+func PrintFooterUnfinished(unfinished *service.UnfinishedWork) {
+	if unfinished == nil {
+		return
+	}
+
+	fmt.Printf("%v: %v The run finished before all of its data was uploaded"+
+		" (%d files, %d pending uploads, %d filestream updates and %d lines"+
+		" unconfirmed). Run `wandb sync` to upload the rest.\n",
+		format("wandb", colorBrightBlue),
+		format("WARNING", colorYellow),
+		len(unfinished.GetFiles()),
+		unfinished.GetPendingUploads(),
+		unfinished.GetQueuedFilestreamUpdates(),
+		unfinished.GetUnackedFilestreamLines(),
+	)
+	for _, path := range unfinished.GetFiles() {
+		fmt.Printf("%v:   %v\n", format("wandb", colorBrightBlue), path)
+	}
+}
+
+// PrintFooterVerification reports whether the run's data was found on the
+// server, if it was verified.
+func PrintFooterVerification(verification *service.RunVerification) {
+	if verification == nil {
+		return
+	}
+
+	if verification.GetError() == "" && len(verification.GetDiscrepancies()) == 0 {
+		fmt.Printf("%v: Verified that the server has all of the run's data"+
+			" (%d history rows, %d summary keys, %d files and %d artifacts).\n",
+			format("wandb", colorBrightBlue),
+			verification.GetLocal().GetHistoryRows(),
+			verification.GetLocal().GetSummaryKeys(),
+			verification.GetLocal().GetFiles(),
+			verification.GetLocal().GetArtifacts(),
+		)
+		return
+	}
+
+	if err := verification.GetError(); err != "" {
+		fmt.Printf("%v: %v Couldn't verify the run's data on the server: %v\n",
+			format("wandb", colorBrightBlue),
+			format("WARNING", colorYellow),
+			err,
+		)
+	}
+	if len(verification.GetDiscrepancies()) > 0 {
+		fmt.Printf("%v: %v The server is missing some of the run's data."+
+			" Run `wandb sync` to upload it again.\n",
+			format("wandb", colorBrightBlue),
+			format("WARNING", colorYellow),
+		)
+	}
+	for _, discrepancy := range verification.GetDiscrepancies() {
+		fmt.Printf("%v:   %v\n", format("wandb", colorBrightBlue), discrepancy)
+	}
+}
+
+// PrintFooterShutdownTiming reports where the time went while the run
+// exited.
+func PrintFooterShutdownTiming(timing *service.ShutdownTiming) {
+	if timing == nil {
+		return
+	}
+
+	fmt.Printf("%v: The run took %.1fs to exit:\n",
+		format("wandb", colorBrightBlue),
+		timing.GetTotalSeconds(),
+	)
+	phases := []struct {
+		name    string
+		seconds float64
+	}{
+		{"processing logged data", timing.GetDrainSeconds()},
+		{"flushing the run", timing.GetWriterFlushSeconds()},
+		{"uploading the summary and config", timing.GetFinalUpsertSeconds()},
+		{"committing artifacts", timing.GetArtifactCommitSeconds()},
+		{"uploading files", timing.GetFileUploadSeconds()},
+		{"flushing the filestream", timing.GetFileStreamFlushSeconds()},
+		{"finalizing the run", timing.GetFooterSeconds()},
+	}
+	for _, phase := range phases {
+		fmt.Printf("%v:   %-33s %6.1fs\n",
+			format("wandb", colorBrightBlue),
+			phase.name,
+			phase.seconds,
+		)
+	}
+}
+
+// PrintUploadProgress reports how far an upload got, for when no client
+// is attached to draw a progress bar.
+func PrintUploadProgress(progress *service.UploadProgressResponse) {
+	percent := 100.0
+	if total := progress.GetTotalBytes(); total > 0 {
+		percent = 100 * float64(progress.GetDoneBytes()) / float64(total)
+	}
+
+	files := ""
+	if progress.GetTotalFiles() > 0 {
+		files = fmt.Sprintf(", %d of %d files",
+			progress.GetDoneFiles(), progress.GetTotalFiles())
+	}
+
+	fmt.Printf("%v: Uploading %v: %v of %v (%.0f%%%v)\n",
+		format("wandb", colorBrightBlue),
+		progress.GetName(),
+		formatBytes(progress.GetDoneBytes()),
+		formatBytes(progress.GetTotalBytes()),
+		percent,
+		files,
+	)
+}
+
+// PrintUserMessage shows a message meant for the user, for clients that
+// don't display messages themselves.
+func PrintUserMessage(message *service.UserMessageResponse) {
+	prefix := ""
+	switch message.GetLevel() {
+	case service.UserMessageResponse_WARNING:
+		prefix = format("WARNING", colorYellow) + " "
+	case service.UserMessageResponse_ERROR:
+		prefix = format("ERROR", colorBrightMagenta) + " "
+	}
+
+	fmt.Printf("%v: %v%v\n",
+		format("wandb", colorBrightBlue),
+		prefix,
+		message.GetMessage(),
+	)
+}
+
+// formatBytes formats a number of bytes for people, like "1.5 MB".
+func formatBytes(bytes int64) string {
+	const unit = 1000
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	value := float64(bytes) / unit
+	prefixes := "kMGTPE"
+	i := 0
+	for ; value >= unit && i < len(prefixes)-1; i++ {
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f %cB", value, prefixes[i])
+}
+
+// PrintFooterCrashReport points to the crash report written for a run
+// that failed.
+func PrintFooterCrashReport(path string) {
+	fmt.Printf("%v: The run failed. Please attach the crash report to a bug report: %v\n",
+		format("wandb", colorBrightBlue),
+		format(path, colorBrightMagenta),
+	)
+}
@@ -0,0 +1,13 @@
+#!/usr/bin/env bash
+#
+# Script used to run the end-to-end stream benchmarks, whose baseline is in
+# pkg/server/testdata/benchmark_stream.txt.
+#
+# Usage:
+#   scripts/run-benchmarks.sh [go test flags, such as -benchtime=5000x]
+#
+
+set -e
+BASE=$(dirname $(dirname $(readlink -f $0)))
+cd $BASE
+go test -vet=off -run '^$' -bench '^BenchmarkStream$' -benchmem ./pkg/server/ $*
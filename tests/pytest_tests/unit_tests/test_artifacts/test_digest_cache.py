@@ -0,0 +1,117 @@
+import os
+from types import SimpleNamespace
+
+import pytest
+import wandb
+from wandb.sdk.artifacts import artifact as artifact_module
+from wandb.sdk.artifacts.artifact import Artifact
+from wandb.sdk.artifacts.digest_cache import DigestCache
+from wandb.sdk.lib.hashutil import md5_file_b64
+
+
+@pytest.fixture
+def hashed_paths(monkeypatch):
+    """Records the paths of the files hashed when added to artifacts."""
+    paths = []
+
+    def md5_file_b64_spy(*args):
+        paths.extend(os.path.basename(path) for path in args)
+        return md5_file_b64(*args)
+
+    monkeypatch.setattr(artifact_module, "md5_file_b64", md5_file_b64_spy)
+    return paths
+
+
+@pytest.fixture
+def run_settings(monkeypatch, tmp_path):
+    """Settings of a fake online run that artifacts are logged to."""
+    monkeypatch.setenv("WANDB_DATA_DIR", str(tmp_path / "data"))
+    settings = SimpleNamespace(
+        _offline=False,
+        _artifact_full_rehash=False,
+        wandb_dir=str(tmp_path / "wandb"),
+    )
+    monkeypatch.setattr(wandb, "run", SimpleNamespace(settings=settings))
+    return settings
+
+
+@pytest.fixture
+def dataset_dir(tmp_path):
+    path = tmp_path / "dataset"
+    path.mkdir()
+    for i in range(20):
+        (path / f"file{i}.txt").write_text(f"content {i}")
+    return path
+
+
+def log_previous_version(monkeypatch, dataset_dir):
+    """Adds the dataset to an artifact, as the version that's logged next
+    will see as its previous one."""
+    previous = Artifact("dataset", "dataset")
+    previous.add_dir(str(dataset_dir))
+    monkeypatch.setattr(
+        Artifact, "_fetch_previous_manifest", lambda self: previous.manifest
+    )
+    return previous
+
+
+def change_file(path):
+    stat = os.stat(path)
+    path.write_text("changed")
+    os.utime(path, ns=(stat.st_atime_ns, stat.st_mtime_ns + 1_000_000_000))
+
+
+def test_only_changed_file_is_rehashed(
+    monkeypatch, hashed_paths, run_settings, dataset_dir
+):
+    previous = log_previous_version(monkeypatch, dataset_dir)
+    change_file(dataset_dir / "file7.txt")
+    hashed_paths.clear()
+
+    artifact = Artifact("dataset", "dataset")
+    artifact.add_dir(str(dataset_dir))
+
+    assert hashed_paths == ["file7.txt"]
+    changed = [
+        name
+        for name, entry in artifact.manifest.entries.items()
+        if entry.digest != previous.manifest.entries[name].digest
+    ]
+    assert changed == ["file7.txt"]
+
+
+def test_full_rehash_setting_hashes_every_file(
+    monkeypatch, hashed_paths, run_settings, dataset_dir
+):
+    log_previous_version(monkeypatch, dataset_dir)
+    run_settings._artifact_full_rehash = True
+    hashed_paths.clear()
+
+    artifact = Artifact("dataset", "dataset")
+    artifact.add_dir(str(dataset_dir))
+
+    assert len(hashed_paths) == 20
+
+
+def test_without_previous_version_every_file_is_hashed(
+    monkeypatch, hashed_paths, run_settings, dataset_dir
+):
+    Artifact("dataset", "dataset").add_dir(str(dataset_dir))
+    monkeypatch.setattr(Artifact, "_fetch_previous_manifest", lambda self: None)
+    hashed_paths.clear()
+
+    artifact = Artifact("dataset", "dataset")
+    artifact.add_dir(str(dataset_dir))
+
+    assert len(hashed_paths) == 20
+
+
+def test_digest_cache_misses_changed_file(tmp_path):
+    cache = DigestCache(tmp_path / "digests")
+    path = tmp_path / "file.txt"
+    path.write_text("content")
+    cache.put(path, md5_file_b64(path), os.stat(path))
+
+    assert cache.get(path, os.stat(path)) == md5_file_b64(path)
+    change_file(path)
+    assert cache.get(path, os.stat(path)) is None
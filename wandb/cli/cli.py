@@ -45,7 +45,7 @@ from wandb.sdk.launch.sweeps import utils as sweep_utils
 from wandb.sdk.launch.sweeps.scheduler import Scheduler
 from wandb.sdk.lib import filesystem
 from wandb.sdk.lib.wburls import wburls
-from wandb.sync import SyncManager, get_run_from_path, get_runs
+from wandb.sync import SyncManager, get_run_from_path, get_runs, is_synced
 from wandb.util import get_core_path
 
 # Send cli logs to wandb/debug-cli.<username>.log by default and fallback to a temp dir.
@@ -486,6 +486,12 @@ def beta():
 @click.option(
     "--dry-run", is_flag=True, help="Perform a dry run without uploading anything."
 )
+@click.option(
+    "--allow-modified-log",
+    is_flag=True,
+    default=False,
+    help="Sync runs whose .wandb file changed after the run finished.",
+)
 @display_error
 def sync_beta(
     ctx,
@@ -500,6 +506,7 @@ def sync_beta(
     skip_synced: bool = True,
     mark_synced: bool = True,
     dry_run: bool = False,
+    allow_modified_log: bool = False,
 ):
     import concurrent.futures
     from multiprocessing import cpu_count
@@ -534,7 +541,11 @@ def sync_beta(
     if skip_synced:
         synced_paths = set()
         for path in paths:
-            wandb_synced_files = [p for p in path.glob("*.wandb.synced") if p.is_file()]
+            wandb_synced_files = [
+                p
+                for p in path.glob("*.wandb.synced")
+                if p.is_file() and is_synced(str(p))
+            ]
             if len(wandb_synced_files) > 1:
                 print(
                     f"Multiple wandb.synced files found in directory {path}, skipping"
@@ -578,6 +589,7 @@ def sync_beta(
                 skip_console=skip_console,
                 append=append,
                 mark_synced=mark_synced,
+                allow_modified_log=allow_modified_log,
             )
             futures.append(future)
 
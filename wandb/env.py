@@ -62,6 +62,8 @@ IGNORE = "WANDB_IGNORE_GLOBS"
 ERROR_REPORTING = "WANDB_ERROR_REPORTING"
 CORE_ERROR_REPORTING = "WANDB_CORE_ERROR_REPORTING"
 CORE_DEBUG = "WANDB_CORE_DEBUG"
+CORE_MAX_CONNECTIONS = "WANDB_CORE_MAX_CONNECTIONS"
+CORE_MAX_STREAMS = "WANDB_CORE_MAX_STREAMS"
 DOCKER = "WANDB_DOCKER"
 AGENT_REPORT_INTERVAL = "WANDB_AGENT_REPORT_INTERVAL"
 AGENT_KILL_DELAY = "WANDB_AGENT_KILL_DELAY"
@@ -4,6 +4,7 @@ __all__ = [
     "AuthenticationError",
     "UsageError",
     "UnsupportedError",
+    "CapacityError",
     "WandbCoreNotAvailableError",
 ]
 
@@ -42,5 +43,9 @@ class UnsupportedError(UsageError):
     """Raised when trying to use a feature that is not supported."""
 
 
+class CapacityError(CommError):
+    """Raised when the wandb service can't take on more runs."""
+
+
 class WandbCoreNotAvailableError(Error):
     """Raised when wandb core is not available."""
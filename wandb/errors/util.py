@@ -2,7 +2,14 @@ from typing import Optional
 
 from wandb.proto import wandb_internal_pb2 as pb
 
-from . import AuthenticationError, CommError, Error, UnsupportedError, UsageError
+from . import (
+    AuthenticationError,
+    CapacityError,
+    CommError,
+    Error,
+    UnsupportedError,
+    UsageError,
+)
 
 to_exception_map = {
     pb.ErrorInfo.UNKNOWN: Error,
@@ -10,6 +17,7 @@ to_exception_map = {
     pb.ErrorInfo.AUTHENTICATION: AuthenticationError,
     pb.ErrorInfo.USAGE: UsageError,
     pb.ErrorInfo.UNSUPPORTED: UnsupportedError,
+    pb.ErrorInfo.CAPACITY: CapacityError,
 }
 
 from_exception_map = {v: k for k, v in to_exception_map.items()}
@@ -9,6 +9,7 @@ import google.protobuf.internal.containers
 import google.protobuf.internal.enum_type_wrapper
 import google.protobuf.message
 import google.protobuf.timestamp_pb2
+import google.protobuf.wrappers_pb2
 import sys
 import typing
 import wandb.proto.wandb_base_pb2
@@ -53,6 +54,12 @@ class Record(google.protobuf.message.Message):
     PREEMPTING_FIELD_NUMBER: builtins.int
     LINK_ARTIFACT_FIELD_NUMBER: builtins.int
     USE_ARTIFACT_FIELD_NUMBER: builtins.int
+    CHECKPOINT_FIELD_NUMBER: builtins.int
+    TABLE_ROWS_FIELD_NUMBER: builtins.int
+    ABORTED_FIELD_NUMBER: builtins.int
+    USER_MESSAGE_FIELD_NUMBER: builtins.int
+    CONFLICTED_FIELD_NUMBER: builtins.int
+    PROJECT_SETTINGS_FIELD_NUMBER: builtins.int
     REQUEST_FIELD_NUMBER: builtins.int
     CONTROL_FIELD_NUMBER: builtins.int
     UUID_FIELD_NUMBER: builtins.int
@@ -101,6 +108,18 @@ class Record(google.protobuf.message.Message):
     @property
     def use_artifact(self) -> global___UseArtifactRecord: ...
     @property
+    def checkpoint(self) -> global___CheckpointRecord: ...
+    @property
+    def table_rows(self) -> global___TableRowsRecord: ...
+    @property
+    def aborted(self) -> global___RunAbortedRecord: ...
+    @property
+    def user_message(self) -> global___UserMessageRecord: ...
+    @property
+    def conflicted(self) -> global___RunConflictedRecord: ...
+    @property
+    def project_settings(self) -> global___ProjectSettingsRecord: ...
+    @property
     def request(self) -> global___Request:
         """request field does not belong here longterm"""
     @property
@@ -132,14 +151,20 @@ class Record(google.protobuf.message.Message):
         preempting: global___RunPreemptingRecord | None = ...,
         link_artifact: global___LinkArtifactRecord | None = ...,
         use_artifact: global___UseArtifactRecord | None = ...,
+        checkpoint: global___CheckpointRecord | None = ...,
+        table_rows: global___TableRowsRecord | None = ...,
+        aborted: global___RunAbortedRecord | None = ...,
+        user_message: global___UserMessageRecord | None = ...,
+        conflicted: global___RunConflictedRecord | None = ...,
+        project_settings: global___ProjectSettingsRecord | None = ...,
         request: global___Request | None = ...,
         control: global___Control | None = ...,
         uuid: builtins.str = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "alert", b"alert", "artifact", b"artifact", "config", b"config", "control", b"control", "exit", b"exit", "files", b"files", "final", b"final", "footer", b"footer", "header", b"header", "history", b"history", "link_artifact", b"link_artifact", "metric", b"metric", "output", b"output", "output_raw", b"output_raw", "preempting", b"preempting", "record_type", b"record_type", "request", b"request", "run", b"run", "stats", b"stats", "summary", b"summary", "tbrecord", b"tbrecord", "telemetry", b"telemetry", "use_artifact", b"use_artifact"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "alert", b"alert", "artifact", b"artifact", "config", b"config", "control", b"control", "exit", b"exit", "files", b"files", "final", b"final", "footer", b"footer", "header", b"header", "history", b"history", "link_artifact", b"link_artifact", "metric", b"metric", "num", b"num", "output", b"output", "output_raw", b"output_raw", "preempting", b"preempting", "record_type", b"record_type", "request", b"request", "run", b"run", "stats", b"stats", "summary", b"summary", "tbrecord", b"tbrecord", "telemetry", b"telemetry", "use_artifact", b"use_artifact", "uuid", b"uuid"]) -> None: ...
-    def WhichOneof(self, oneof_group: typing_extensions.Literal["record_type", b"record_type"]) -> typing_extensions.Literal["history", "summary", "output", "config", "files", "stats", "artifact", "tbrecord", "alert", "telemetry", "metric", "output_raw", "run", "exit", "final", "header", "footer", "preempting", "link_artifact", "use_artifact", "request"] | None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "aborted", b"aborted", "alert", b"alert", "artifact", b"artifact", "checkpoint", b"checkpoint", "config", b"config", "conflicted", b"conflicted", "control", b"control", "exit", b"exit", "files", b"files", "final", b"final", "footer", b"footer", "header", b"header", "history", b"history", "link_artifact", b"link_artifact", "metric", b"metric", "output", b"output", "output_raw", b"output_raw", "preempting", b"preempting", "project_settings", b"project_settings", "record_type", b"record_type", "request", b"request", "run", b"run", "stats", b"stats", "summary", b"summary", "table_rows", b"table_rows", "tbrecord", b"tbrecord", "telemetry", b"telemetry", "use_artifact", b"use_artifact", "user_message", b"user_message"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "aborted", b"aborted", "alert", b"alert", "artifact", b"artifact", "checkpoint", b"checkpoint", "config", b"config", "conflicted", b"conflicted", "control", b"control", "exit", b"exit", "files", b"files", "final", b"final", "footer", b"footer", "header", b"header", "history", b"history", "link_artifact", b"link_artifact", "metric", b"metric", "num", b"num", "output", b"output", "output_raw", b"output_raw", "preempting", b"preempting", "project_settings", b"project_settings", "record_type", b"record_type", "request", b"request", "run", b"run", "stats", b"stats", "summary", b"summary", "table_rows", b"table_rows", "tbrecord", b"tbrecord", "telemetry", b"telemetry", "use_artifact", b"use_artifact", "user_message", b"user_message", "uuid", b"uuid"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["record_type", b"record_type"]) -> typing_extensions.Literal["history", "summary", "output", "config", "files", "stats", "artifact", "tbrecord", "alert", "telemetry", "metric", "output_raw", "run", "exit", "final", "header", "footer", "preempting", "link_artifact", "use_artifact", "checkpoint", "table_rows", "aborted", "user_message", "conflicted", "project_settings", "request"] | None: ...
 
 global___Record = Record
 
@@ -154,6 +179,7 @@ class Control(google.protobuf.message.Message):
     FLOW_CONTROL_FIELD_NUMBER: builtins.int
     END_OFFSET_FIELD_NUMBER: builtins.int
     CONNECTION_ID_FIELD_NUMBER: builtins.int
+    PERSIST_ONLY_FIELD_NUMBER: builtins.int
     req_resp: builtins.bool
     """record is expecting a result"""
     local: builtins.bool
@@ -170,6 +196,8 @@ class Control(google.protobuf.message.Message):
     """end of message offset of this written message"""
     connection_id: builtins.str
     """connection id"""
+    persist_only: builtins.bool
+    """persisted, but not sent to the server"""
     def __init__(
         self,
         *,
@@ -181,8 +209,9 @@ class Control(google.protobuf.message.Message):
         flow_control: builtins.bool = ...,
         end_offset: builtins.int = ...,
         connection_id: builtins.str = ...,
+        persist_only: builtins.bool = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["always_send", b"always_send", "connection_id", b"connection_id", "end_offset", b"end_offset", "flow_control", b"flow_control", "local", b"local", "mailbox_slot", b"mailbox_slot", "relay_id", b"relay_id", "req_resp", b"req_resp"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["always_send", b"always_send", "connection_id", b"connection_id", "end_offset", b"end_offset", "flow_control", b"flow_control", "local", b"local", "mailbox_slot", b"mailbox_slot", "persist_only", b"persist_only", "relay_id", b"relay_id", "req_resp", b"req_resp"]) -> None: ...
 
 global___Control = Control
 
@@ -202,6 +231,8 @@ class Result(google.protobuf.message.Message):
     RESPONSE_FIELD_NUMBER: builtins.int
     CONTROL_FIELD_NUMBER: builtins.int
     UUID_FIELD_NUMBER: builtins.int
+    CHUNK_FIELD_NUMBER: builtins.int
+    REJECTED_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
     def run_result(self) -> global___RunUpdateResult: ...
@@ -222,6 +253,14 @@ class Result(google.protobuf.message.Message):
     def control(self) -> global___Control: ...
     uuid: builtins.str
     @property
+    def chunk(self) -> global___ResultChunk:
+        """Set if the result is one part of a response that's sent in parts."""
+    @property
+    def rejected(self) -> global___ErrorInfo:
+        """Set if the record was rejected rather than handled, such as data
+        logged after the run's exit grace window.
+        """
+    @property
     def _info(self) -> wandb.proto.wandb_base_pb2._ResultInfo: ...
     def __init__(
         self,
@@ -235,14 +274,41 @@ class Result(google.protobuf.message.Message):
         response: global___Response | None = ...,
         control: global___Control | None = ...,
         uuid: builtins.str = ...,
+        chunk: global___ResultChunk | None = ...,
+        rejected: global___ErrorInfo | None = ...,
         _info: wandb.proto.wandb_base_pb2._ResultInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "config_result", b"config_result", "control", b"control", "exit_result", b"exit_result", "log_result", b"log_result", "output_result", b"output_result", "response", b"response", "result_type", b"result_type", "run_result", b"run_result", "summary_result", b"summary_result"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "config_result", b"config_result", "control", b"control", "exit_result", b"exit_result", "log_result", b"log_result", "output_result", b"output_result", "response", b"response", "result_type", b"result_type", "run_result", b"run_result", "summary_result", b"summary_result", "uuid", b"uuid"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "chunk", b"chunk", "config_result", b"config_result", "control", b"control", "exit_result", b"exit_result", "log_result", b"log_result", "output_result", b"output_result", "rejected", b"rejected", "response", b"response", "result_type", b"result_type", "run_result", b"run_result", "summary_result", b"summary_result"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "chunk", b"chunk", "config_result", b"config_result", "control", b"control", "exit_result", b"exit_result", "log_result", b"log_result", "output_result", b"output_result", "rejected", b"rejected", "response", b"response", "result_type", b"result_type", "run_result", b"run_result", "summary_result", b"summary_result", "uuid", b"uuid"]) -> None: ...
     def WhichOneof(self, oneof_group: typing_extensions.Literal["result_type", b"result_type"]) -> typing_extensions.Literal["run_result", "exit_result", "log_result", "summary_result", "output_result", "config_result", "response"] | None: ...
 
 global___Result = Result
 
+class ResultChunk(google.protobuf.message.Message):
+    """ResultChunk places a result among the parts of a large response.
+
+    The parts of a response have the mailbox slot of its request and are
+    sent in order. Merging them in order gives the whole response.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    INDEX_FIELD_NUMBER: builtins.int
+    FINAL_FIELD_NUMBER: builtins.int
+    index: builtins.int
+    """The position of the part, counting from 0."""
+    final: builtins.bool
+    """Whether this is the last part."""
+    def __init__(
+        self,
+        *,
+        index: builtins.int = ...,
+        final: builtins.bool = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["final", b"final", "index", b"index"]) -> None: ...
+
+global___ResultChunk = ResultChunk
+
 class FinalRecord(google.protobuf.message.Message):
     """
     FinalRecord
@@ -299,22 +365,60 @@ class HeaderRecord(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
     VERSION_INFO_FIELD_NUMBER: builtins.int
+    COMPACTION_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
     def version_info(self) -> global___VersionInfo: ...
     @property
+    def compaction(self) -> global___CompactionInfo:
+        """Set if the log was compacted from another, which older readers ignore."""
+    @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
         self,
         *,
         version_info: global___VersionInfo | None = ...,
+        compaction: global___CompactionInfo | None = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "version_info", b"version_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "version_info", b"version_info"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "compaction", b"compaction", "version_info", b"version_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "compaction", b"compaction", "version_info", b"version_info"]) -> None: ...
 
 global___HeaderRecord = HeaderRecord
 
+class CompactionInfo(google.protobuf.message.Message):
+    """
+    CompactionInfo: how a transaction log was compacted
+
+    A compacted log keeps the run's history, output and stats, and the final
+    forms of its config, summary, telemetry and metric definitions in place
+    of their updates. Syncing it gives the same run as syncing the original.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ORIGINAL_RECORDS_FIELD_NUMBER: builtins.int
+    ORIGINAL_BYTES_FIELD_NUMBER: builtins.int
+    COMPACTED_AT_FIELD_NUMBER: builtins.int
+    original_records: builtins.int
+    """The number of records in the original log."""
+    original_bytes: builtins.int
+    """The size of the original log in bytes."""
+    @property
+    def compacted_at(self) -> google.protobuf.timestamp_pb2.Timestamp:
+        """When the log was compacted."""
+    def __init__(
+        self,
+        *,
+        original_records: builtins.int = ...,
+        original_bytes: builtins.int = ...,
+        compacted_at: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["compacted_at", b"compacted_at"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["compacted_at", b"compacted_at", "original_bytes", b"original_bytes", "original_records", b"original_records"]) -> None: ...
+
+global___CompactionInfo = CompactionInfo
+
 class FooterRecord(google.protobuf.message.Message):
     """
     FooterRecord
@@ -335,6 +439,164 @@ class FooterRecord(google.protobuf.message.Message):
 
 global___FooterRecord = FooterRecord
 
+class TableRowsRecord(google.protobuf.message.Message):
+    """
+    TableRowsRecord: rows appended to a table that's logged as a run file
+
+    wandb-core keeps the table's rows and writes the whole table to
+    media/table/<key>.table.json when the run is flushed, so that the client
+    doesn't re-send the table each time it grows.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    KEY_FIELD_NUMBER: builtins.int
+    COLUMNS_FIELD_NUMBER: builtins.int
+    ROWS_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    """The table's name, which names its file."""
+    @property
+    def columns(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """The table's columns, which are the same in each record of the table."""
+    @property
+    def rows(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___TableRow]: ...
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        key: builtins.str = ...,
+        columns: collections.abc.Iterable[builtins.str] | None = ...,
+        rows: collections.abc.Iterable[global___TableRow] | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "columns", b"columns", "key", b"key", "rows", b"rows"]) -> None: ...
+
+global___TableRowsRecord = TableRowsRecord
+
+class TableRow(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    VALUES_JSON_FIELD_NUMBER: builtins.int
+    @property
+    def values_json(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """The row's JSON-encoded values, one per column."""
+    def __init__(
+        self,
+        *,
+        values_json: collections.abc.Iterable[builtins.str] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["values_json", b"values_json"]) -> None: ...
+
+global___TableRow = TableRow
+
+class CheckpointRecord(google.protobuf.message.Message):
+    """
+    CheckpointRecord: the handler's state at a point in the transaction log
+
+    Replaying a log can start from its last checkpoint instead of its
+    first record.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class MetricAggregate(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        MIN_FIELD_NUMBER: builtins.int
+        MAX_FIELD_NUMBER: builtins.int
+        SUM_FIELD_NUMBER: builtins.int
+        COUNT_FIELD_NUMBER: builtins.int
+        HAS_BEST_FIELD_NUMBER: builtins.int
+        BEST_FIELD_NUMBER: builtins.int
+        BEST_STEP_FIELD_NUMBER: builtins.int
+        BEST_GOAL_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        min: builtins.float
+        max: builtins.float
+        sum: builtins.float
+        count: builtins.int
+        has_best: builtins.bool
+        """The best value with its step, for metrics summarized by their best
+        value, as of the goal the best value is tracked for.
+        """
+        best: builtins.float
+        best_step: builtins.int
+        best_goal: global___MetricRecord.MetricGoal.ValueType
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            min: builtins.float = ...,
+            max: builtins.float = ...,
+            sum: builtins.float = ...,
+            count: builtins.int = ...,
+            has_best: builtins.bool = ...,
+            best: builtins.float = ...,
+            best_step: builtins.int = ...,
+            best_goal: global___MetricRecord.MetricGoal.ValueType = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["best", b"best", "best_goal", b"best_goal", "best_step", b"best_step", "count", b"count", "has_best", b"has_best", "key", b"key", "max", b"max", "min", b"min", "sum", b"sum"]) -> None: ...
+
+    VERSION_FIELD_NUMBER: builtins.int
+    LAST_RECORD_NUM_FIELD_NUMBER: builtins.int
+    CONFIG_FIELD_NUMBER: builtins.int
+    SUMMARY_FIELD_NUMBER: builtins.int
+    STEP_FIELD_NUMBER: builtins.int
+    METRICS_FIELD_NUMBER: builtins.int
+    CHECKSUM_FIELD_NUMBER: builtins.int
+    RUN_FIELD_NUMBER: builtins.int
+    AGGREGATES_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    version: builtins.int
+    """The version of the checkpoint's format."""
+    last_record_num: builtins.int
+    """The number of the last record stored before the checkpoint."""
+    @property
+    def config(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___ConfigItem]:
+        """The run's config."""
+    @property
+    def summary(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___SummaryItem]:
+        """The run's summary."""
+    step: builtins.int
+    """The step of the next history row."""
+    @property
+    def metrics(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___MetricRecord]:
+        """The metrics defined so far, including glob metrics."""
+    checksum: builtins.int
+    """The CRC-32C of the checkpoint serialized without its checksum."""
+    @property
+    def run(self) -> global___RunRecord:
+        """The run, as last updated."""
+    @property
+    def aggregates(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___CheckpointRecord.MetricAggregate]:
+        """What is kept of the history of metrics that are summarized by their
+        min, max, mean or best value.
+        """
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        version: builtins.int = ...,
+        last_record_num: builtins.int = ...,
+        config: collections.abc.Iterable[global___ConfigItem] | None = ...,
+        summary: collections.abc.Iterable[global___SummaryItem] | None = ...,
+        step: builtins.int = ...,
+        metrics: collections.abc.Iterable[global___MetricRecord] | None = ...,
+        checksum: builtins.int = ...,
+        run: global___RunRecord | None = ...,
+        aggregates: collections.abc.Iterable[global___CheckpointRecord.MetricAggregate] | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "run", b"run"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "aggregates", b"aggregates", "checksum", b"checksum", "config", b"config", "last_record_num", b"last_record_num", "metrics", b"metrics", "run", b"run", "step", b"step", "summary", b"summary", "version", b"version"]) -> None: ...
+
+global___CheckpointRecord = CheckpointRecord
+
 class RunRecord(google.protobuf.message.Message):
     """
     RunRecord: wandb/sdk/wandb_run/Run
@@ -476,6 +738,8 @@ class ErrorInfo(google.protobuf.message.Message):
         AUTHENTICATION: ErrorInfo._ErrorCode.ValueType  # 2
         USAGE: ErrorInfo._ErrorCode.ValueType  # 3
         UNSUPPORTED: ErrorInfo._ErrorCode.ValueType  # 4
+        CAPACITY: ErrorInfo._ErrorCode.ValueType  # 5
+        """The service already has as many connections or runs as it allows."""
 
     class ErrorCode(_ErrorCode, metaclass=_ErrorCodeEnumTypeWrapper): ...
     UNKNOWN: ErrorInfo.ErrorCode.ValueType  # 0
@@ -483,6 +747,8 @@ class ErrorInfo(google.protobuf.message.Message):
     AUTHENTICATION: ErrorInfo.ErrorCode.ValueType  # 2
     USAGE: ErrorInfo.ErrorCode.ValueType  # 3
     UNSUPPORTED: ErrorInfo.ErrorCode.ValueType  # 4
+    CAPACITY: ErrorInfo.ErrorCode.ValueType  # 5
+    """The service already has as many connections or runs as it allows."""
 
     MESSAGE_FIELD_NUMBER: builtins.int
     CODE_FIELD_NUMBER: builtins.int
@@ -507,9 +773,14 @@ class RunExitRecord(google.protobuf.message.Message):
 
     EXIT_CODE_FIELD_NUMBER: builtins.int
     RUNTIME_FIELD_NUMBER: builtins.int
+    INTERRUPTED_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     exit_code: builtins.int
     runtime: builtins.int
+    interrupted: builtins.bool
+    """Whether the run was exited by the service shutting down, rather than
+    by the process that started it.
+    """
     @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
@@ -517,157 +788,604 @@ class RunExitRecord(google.protobuf.message.Message):
         *,
         exit_code: builtins.int = ...,
         runtime: builtins.int = ...,
+        interrupted: builtins.bool = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "exit_code", b"exit_code", "runtime", b"runtime"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "exit_code", b"exit_code", "interrupted", b"interrupted", "runtime", b"runtime"]) -> None: ...
 
 global___RunExitRecord = RunExitRecord
 
 class RunExitResult(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
+    UNFINISHED_FIELD_NUMBER: builtins.int
+    VERIFICATION_FIELD_NUMBER: builtins.int
+    SHUTDOWN_TIMING_FIELD_NUMBER: builtins.int
+    QUOTA_EXCEEDED_FIELD_NUMBER: builtins.int
+    FILES_FIELD_NUMBER: builtins.int
+    DISCARDED_FIELD_NUMBER: builtins.int
+    CONFLICTED_FIELD_NUMBER: builtins.int
+    LATE_RECORDS_FIELD_NUMBER: builtins.int
+    OMITTED_SUMMARY_KEYS_FIELD_NUMBER: builtins.int
+    @property
+    def unfinished(self) -> global___UnfinishedWork:
+        """The work left undone because the exit timed out, if it did."""
+    @property
+    def verification(self) -> global___RunVerification:
+        """How the run's data compares with the server's, if it was verified."""
+    @property
+    def shutdown_timing(self) -> global___ShutdownTiming:
+        """How long each phase of the exit took."""
+    @property
+    def quota_exceeded(self) -> global___QuotaExceeded:
+        """The limit that stopped the run's uploads, if one did. The run's data
+        is kept locally to sync once the limit allows.
+        """
+    @property
+    def files(self) -> global___RunFilesReport:
+        """What became of each of the run's files."""
+    discarded: builtins.bool
+    """Whether the run was aborted by the client and discarded rather than
+    finished.
+    """
+    conflicted: builtins.bool
+    """Whether the run was finished on the server, such as from the UI, while
+    it was running, so that the rest of its data was only saved locally.
+    """
+    @property
+    def late_records(self) -> global___LateRecords:
+        """The data logged after the exit was requested."""
+    @property
+    def omitted_summary_keys(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """The top-level summary keys left out of the final summary, because the
+        exit ran short of time or their values were too large to send.
+        """
     def __init__(
         self,
-    ) -> None: ...
+        *,
+        unfinished: global___UnfinishedWork | None = ...,
+        verification: global___RunVerification | None = ...,
+        shutdown_timing: global___ShutdownTiming | None = ...,
+        quota_exceeded: global___QuotaExceeded | None = ...,
+        files: global___RunFilesReport | None = ...,
+        discarded: builtins.bool = ...,
+        conflicted: builtins.bool = ...,
+        late_records: global___LateRecords | None = ...,
+        omitted_summary_keys: collections.abc.Iterable[builtins.str] | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["files", b"files", "late_records", b"late_records", "quota_exceeded", b"quota_exceeded", "shutdown_timing", b"shutdown_timing", "unfinished", b"unfinished", "verification", b"verification"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["conflicted", b"conflicted", "discarded", b"discarded", "files", b"files", "late_records", b"late_records", "omitted_summary_keys", b"omitted_summary_keys", "quota_exceeded", b"quota_exceeded", "shutdown_timing", b"shutdown_timing", "unfinished", b"unfinished", "verification", b"verification"]) -> None: ...
 
 global___RunExitResult = RunExitResult
 
-class RunPreemptingRecord(google.protobuf.message.Message):
-    """
-    RunPreemptingRecord: run being preempted
+class LateRecords(google.protobuf.message.Message):
+    """LateRecords is the data logged after a run's exit was requested.
+
+    Data is accepted for a grace window after the exit, or until the run's
+    final summary is flushed if that's sooner, and rejected after that.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    _INFO_FIELD_NUMBER: builtins.int
+    GRACE_SECONDS_FIELD_NUMBER: builtins.int
+    ACCEPTED_FIELD_NUMBER: builtins.int
+    REJECTED_FIELD_NUMBER: builtins.int
+    grace_seconds: builtins.float
+    """How long after the exit data was accepted for."""
+    accepted: builtins.int
+    """The number of records accepted after the exit."""
+    rejected: builtins.int
+    """The number of records rejected for coming too late."""
+    def __init__(
+        self,
+        *,
+        grace_seconds: builtins.float = ...,
+        accepted: builtins.int = ...,
+        rejected: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["accepted", b"accepted", "grace_seconds", b"grace_seconds", "rejected", b"rejected"]) -> None: ...
+
+global___LateRecords = LateRecords
+
+class RunFilesReport(google.protobuf.message.Message):
+    """RunFilesReport lists what became of a run's files."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    FILES_FIELD_NUMBER: builtins.int
+    OVERFLOW_FIELD_NUMBER: builtins.int
     @property
-    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def files(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___RunFileReport]:
+        """The files, failed ones first and then by path, up to the limit set
+        by the _max_reported_files setting.
+        """
+    overflow: builtins.int
+    """The number of files left out of the list because of the limit."""
     def __init__(
         self,
         *,
-        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+        files: collections.abc.Iterable[global___RunFileReport] | None = ...,
+        overflow: builtins.int = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["files", b"files", "overflow", b"overflow"]) -> None: ...
 
-global___RunPreemptingRecord = RunPreemptingRecord
+global___RunFilesReport = RunFilesReport
+
+class RunFileReport(google.protobuf.message.Message):
+    """RunFileReport is what became of one of a run's files."""
 
-class RunPreemptingResult(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
+    class _Status:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _StatusEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[RunFileReport._Status.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        UPLOADED: RunFileReport._Status.ValueType  # 0
+        FAILED: RunFileReport._Status.ValueType  # 1
+        SKIPPED_IGNORED: RunFileReport._Status.ValueType  # 2
+        """Not uploaded because it matched the ignore_globs setting."""
+        SKIPPED_UNCHANGED: RunFileReport._Status.ValueType  # 3
+        """Not uploaded again because it hadn't changed since its last upload."""
+        PENDING: RunFileReport._Status.ValueType  # 4
+        """Not uploaded yet, such as every file of an offline run."""
+
+    class Status(_Status, metaclass=_StatusEnumTypeWrapper): ...
+    UPLOADED: RunFileReport.Status.ValueType  # 0
+    FAILED: RunFileReport.Status.ValueType  # 1
+    SKIPPED_IGNORED: RunFileReport.Status.ValueType  # 2
+    """Not uploaded because it matched the ignore_globs setting."""
+    SKIPPED_UNCHANGED: RunFileReport.Status.ValueType  # 3
+    """Not uploaded again because it hadn't changed since its last upload."""
+    PENDING: RunFileReport.Status.ValueType  # 4
+    """Not uploaded yet, such as every file of an offline run."""
+
+    PATH_FIELD_NUMBER: builtins.int
+    SIZE_FIELD_NUMBER: builtins.int
+    STATUS_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
+    RETRIES_FIELD_NUMBER: builtins.int
+    path: builtins.str
+    """The file's path, relative to the run's files directory."""
+    size: builtins.int
+    """The file's size in bytes, if known."""
+    status: global___RunFileReport.Status.ValueType
+    """What became of the file's last upload."""
+    error: builtins.str
+    """Why the file failed to upload, if it did."""
+    retries: builtins.int
+    """How many times the file's last upload was retried."""
     def __init__(
         self,
+        *,
+        path: builtins.str = ...,
+        size: builtins.int = ...,
+        status: global___RunFileReport.Status.ValueType = ...,
+        error: builtins.str = ...,
+        retries: builtins.int = ...,
     ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["error", b"error", "path", b"path", "retries", b"retries", "size", b"size", "status", b"status"]) -> None: ...
 
-global___RunPreemptingResult = RunPreemptingResult
+global___RunFileReport = RunFileReport
 
-class SettingsRecord(google.protobuf.message.Message):
+class QuotaExceeded(google.protobuf.message.Message):
+    """QuotaExceeded is a limit of the user's organization that the server
+    enforced, like its storage quota.
     """
-    SettingsRecord: wandb/sdk/wandb_settings/Settings
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    LIMIT_FIELD_NUMBER: builtins.int
+    MESSAGE_FIELD_NUMBER: builtins.int
+    limit: builtins.str
+    """The name of the limit, like "storage"."""
+    message: builtins.str
+    """The server's explanation, if it gave one."""
+    def __init__(
+        self,
+        *,
+        limit: builtins.str = ...,
+        message: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["limit", b"limit", "message", b"message"]) -> None: ...
+
+global___QuotaExceeded = QuotaExceeded
+
+class ShutdownTiming(google.protobuf.message.Message):
+    """ShutdownTiming breaks down how long a run took to exit, in seconds."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    DRAIN_SECONDS_FIELD_NUMBER: builtins.int
+    WRITER_FLUSH_SECONDS_FIELD_NUMBER: builtins.int
+    FINAL_UPSERT_SECONDS_FIELD_NUMBER: builtins.int
+    ARTIFACT_COMMIT_SECONDS_FIELD_NUMBER: builtins.int
+    FILE_UPLOAD_SECONDS_FIELD_NUMBER: builtins.int
+    FILE_STREAM_FLUSH_SECONDS_FIELD_NUMBER: builtins.int
+    FOOTER_SECONDS_FIELD_NUMBER: builtins.int
+    TOTAL_SECONDS_FIELD_NUMBER: builtins.int
+    drain_seconds: builtins.float
+    """Waiting for the records logged before the exit to be processed."""
+    writer_flush_seconds: builtins.float
+    """Flushing the run, system metrics, history and console output."""
+    final_upsert_seconds: builtins.float
+    """Uploading the final summary and config."""
+    artifact_commit_seconds: builtins.float
+    """Committing artifacts, including the job."""
+    file_upload_seconds: builtins.float
+    """Uploading the run's files."""
+    file_stream_flush_seconds: builtins.float
+    """Sending the filestream's final transmission."""
+    footer_seconds: builtins.float
+    """Finalizing the run and writing its footer."""
+    total_seconds: builtins.float
+    """From the exit being requested to the run being done."""
+    def __init__(
+        self,
+        *,
+        drain_seconds: builtins.float = ...,
+        writer_flush_seconds: builtins.float = ...,
+        final_upsert_seconds: builtins.float = ...,
+        artifact_commit_seconds: builtins.float = ...,
+        file_upload_seconds: builtins.float = ...,
+        file_stream_flush_seconds: builtins.float = ...,
+        footer_seconds: builtins.float = ...,
+        total_seconds: builtins.float = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["artifact_commit_seconds", b"artifact_commit_seconds", "drain_seconds", b"drain_seconds", "file_stream_flush_seconds", b"file_stream_flush_seconds", "file_upload_seconds", b"file_upload_seconds", "final_upsert_seconds", b"final_upsert_seconds", "footer_seconds", b"footer_seconds", "total_seconds", b"total_seconds", "writer_flush_seconds", b"writer_flush_seconds"]) -> None: ...
+
+global___ShutdownTiming = ShutdownTiming
+
+class RunVerification(google.protobuf.message.Message):
+    """RunVerification compares what a run recorded locally with what reached
+    the server.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    ITEM_FIELD_NUMBER: builtins.int
-    _INFO_FIELD_NUMBER: builtins.int
+    LOCAL_FIELD_NUMBER: builtins.int
+    SERVER_FIELD_NUMBER: builtins.int
+    DISCREPANCIES_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
     @property
-    def item(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___SettingsItem]: ...
+    def local(self) -> global___VerificationCounts: ...
     @property
-    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def server(self) -> global___VerificationCounts: ...
+    @property
+    def discrepancies(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """What was recorded locally but not found on the server."""
+    error: builtins.str
+    """Why the server couldn't be checked, if it couldn't."""
     def __init__(
         self,
         *,
-        item: collections.abc.Iterable[global___SettingsItem] | None = ...,
-        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+        local: global___VerificationCounts | None = ...,
+        server: global___VerificationCounts | None = ...,
+        discrepancies: collections.abc.Iterable[builtins.str] | None = ...,
+        error: builtins.str = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "item", b"item"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["local", b"local", "server", b"server"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["discrepancies", b"discrepancies", "error", b"error", "local", b"local", "server", b"server"]) -> None: ...
 
-global___SettingsRecord = SettingsRecord
+global___RunVerification = RunVerification
 
-class SettingsItem(google.protobuf.message.Message):
+class VerificationCounts(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    KEY_FIELD_NUMBER: builtins.int
-    VALUE_JSON_FIELD_NUMBER: builtins.int
-    key: builtins.str
-    value_json: builtins.str
+    HISTORY_ROWS_FIELD_NUMBER: builtins.int
+    SUMMARY_KEYS_FIELD_NUMBER: builtins.int
+    FILES_FIELD_NUMBER: builtins.int
+    ARTIFACTS_FIELD_NUMBER: builtins.int
+    history_rows: builtins.int
+    summary_keys: builtins.int
+    files: builtins.int
+    artifacts: builtins.int
     def __init__(
         self,
         *,
-        key: builtins.str = ...,
-        value_json: builtins.str = ...,
+        history_rows: builtins.int = ...,
+        summary_keys: builtins.int = ...,
+        files: builtins.int = ...,
+        artifacts: builtins.int = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value_json", b"value_json"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["artifacts", b"artifacts", "files", b"files", "history_rows", b"history_rows", "summary_keys", b"summary_keys"]) -> None: ...
 
-global___SettingsItem = SettingsItem
+global___VerificationCounts = VerificationCounts
 
-class HistoryStep(google.protobuf.message.Message):
-    """
-    HistoryRecord: wandb/sdk/wandb_history/History
+class UnfinishedWork(google.protobuf.message.Message):
+    """UnfinishedWork is what a run left undone when its exit timed out.
+
+    The run's transaction log has everything needed to finish it with
+    `wandb sync`.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    NUM_FIELD_NUMBER: builtins.int
-    num: builtins.int
+    FILES_FIELD_NUMBER: builtins.int
+    PENDING_UPLOADS_FIELD_NUMBER: builtins.int
+    QUEUED_FILESTREAM_UPDATES_FIELD_NUMBER: builtins.int
+    UNACKED_FILESTREAM_LINES_FIELD_NUMBER: builtins.int
+    @property
+    def files(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """The run files whose uploads hadn't finished, relative to the files
+        directory.
+        """
+    pending_uploads: builtins.int
+    """The number of file transfers and artifact operations not finished."""
+    queued_filestream_updates: builtins.int
+    """The number of filestream updates not yet sent."""
+    unacked_filestream_lines: builtins.int
+    """The number of filestream lines sent but not acknowledged."""
     def __init__(
         self,
         *,
-        num: builtins.int = ...,
+        files: collections.abc.Iterable[builtins.str] | None = ...,
+        pending_uploads: builtins.int = ...,
+        queued_filestream_updates: builtins.int = ...,
+        unacked_filestream_lines: builtins.int = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["num", b"num"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["files", b"files", "pending_uploads", b"pending_uploads", "queued_filestream_updates", b"queued_filestream_updates", "unacked_filestream_lines", b"unacked_filestream_lines"]) -> None: ...
 
-global___HistoryStep = HistoryStep
+global___UnfinishedWork = UnfinishedWork
+
+class RunPreemptingRecord(google.protobuf.message.Message):
+    """
+    RunPreemptingRecord: run being preempted
+    """
 
-class HistoryRecord(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    ITEM_FIELD_NUMBER: builtins.int
-    STEP_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
-    def item(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___HistoryItem]: ...
-    @property
-    def step(self) -> global___HistoryStep: ...
-    @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
         self,
         *,
-        item: collections.abc.Iterable[global___HistoryItem] | None = ...,
-        step: global___HistoryStep | None = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "step", b"step"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "item", b"item", "step", b"step"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
 
-global___HistoryRecord = HistoryRecord
+global___RunPreemptingRecord = RunPreemptingRecord
 
-class HistoryItem(google.protobuf.message.Message):
+class RunPreemptingResult(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    def __init__(
+        self,
+    ) -> None: ...
+
+global___RunPreemptingResult = RunPreemptingResult
+
+class RunAbortedRecord(google.protobuf.message.Message):
     """
-    HistoryItem:
+    RunAbortedRecord: run aborted by the client
 
-    key and nested_key are mutually exclusive. Only one of them should be set.
-    key is supposedly more performant than nested_key, so nested_key should be
-    only used for nested keys.
+    Marks the transaction log of a discarded run so that syncing it doesn't
+    create the run again.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    KEY_FIELD_NUMBER: builtins.int
-    NESTED_KEY_FIELD_NUMBER: builtins.int
-    VALUE_JSON_FIELD_NUMBER: builtins.int
-    key: builtins.str
+    _INFO_FIELD_NUMBER: builtins.int
     @property
-    def nested_key(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]: ...
-    value_json: builtins.str
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
         self,
         *,
-        key: builtins.str = ...,
-        nested_key: collections.abc.Iterable[builtins.str] | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___RunAbortedRecord = RunAbortedRecord
+
+class RunConflictedRecord(google.protobuf.message.Message):
+    """
+    RunConflictedRecord: run finished on the server while it was running
+
+    Marks the transaction log of a run whose uploads the server rejected
+    because the run was finished elsewhere, such as from the UI, so that
+    syncing it doesn't append to the finished run.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    MESSAGE_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    message: builtins.str
+    """The server's explanation, if it gave one."""
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        message: builtins.str = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "message", b"message"]) -> None: ...
+
+global___RunConflictedRecord = RunConflictedRecord
+
+class ProjectSettingsRecord(google.protobuf.message.Message):
+    """
+    ProjectSettingsRecord: the run's defaults from its project
+
+    The defaults that the run's project sets, such as whether code is saved,
+    are fetched once the run is created. The sender applies the ones the run
+    doesn't set itself, and saves the record with the settings in effect so
+    that syncing the run uses the same ones.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    DEFAULTS_FIELD_NUMBER: builtins.int
+    EFFECTIVE_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def defaults(self) -> global___SettingsRecord:
+        """The project's defaults, as the backend has them."""
+    @property
+    def effective(self) -> global___SettingsRecord:
+        """The settings the project has defaults for, as in effect for the run."""
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        defaults: global___SettingsRecord | None = ...,
+        effective: global___SettingsRecord | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "defaults", b"defaults", "effective", b"effective"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "defaults", b"defaults", "effective", b"effective"]) -> None: ...
+
+global___ProjectSettingsRecord = ProjectSettingsRecord
+
+class UserMessageRecord(google.protobuf.message.Message):
+    """
+    UserMessageRecord: a message for the user of an offline run
+
+    Saved to the transaction log because an offline run may have no terminal
+    to show it in. It's shown in the run's footer and, once the run is
+    synced, in its console logs.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    LEVEL_FIELD_NUMBER: builtins.int
+    MESSAGE_FIELD_NUMBER: builtins.int
+    TIMESTAMP_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    level: global___UserMessageResponse.Level.ValueType
+    message: builtins.str
+    @property
+    def timestamp(self) -> google.protobuf.timestamp_pb2.Timestamp:
+        """When the message was emitted."""
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        level: global___UserMessageResponse.Level.ValueType = ...,
+        message: builtins.str = ...,
+        timestamp: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "timestamp", b"timestamp"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "level", b"level", "message", b"message", "timestamp", b"timestamp"]) -> None: ...
+
+global___UserMessageRecord = UserMessageRecord
+
+class SettingsRecord(google.protobuf.message.Message):
+    """
+    SettingsRecord: wandb/sdk/wandb_settings/Settings
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ITEM_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def item(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___SettingsItem]: ...
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        item: collections.abc.Iterable[global___SettingsItem] | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "item", b"item"]) -> None: ...
+
+global___SettingsRecord = SettingsRecord
+
+class SettingsItem(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    KEY_FIELD_NUMBER: builtins.int
+    VALUE_JSON_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    value_json: builtins.str
+    def __init__(
+        self,
+        *,
+        key: builtins.str = ...,
+        value_json: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value_json", b"value_json"]) -> None: ...
+
+global___SettingsItem = SettingsItem
+
+class HistoryStep(google.protobuf.message.Message):
+    """
+    HistoryRecord: wandb/sdk/wandb_history/History
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    NUM_FIELD_NUMBER: builtins.int
+    num: builtins.int
+    def __init__(
+        self,
+        *,
+        num: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["num", b"num"]) -> None: ...
+
+global___HistoryStep = HistoryStep
+
+class HistoryRecord(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ITEM_FIELD_NUMBER: builtins.int
+    STEP_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def item(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___HistoryItem]: ...
+    @property
+    def step(self) -> global___HistoryStep: ...
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        item: collections.abc.Iterable[global___HistoryItem] | None = ...,
+        step: global___HistoryStep | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "step", b"step"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "item", b"item", "step", b"step"]) -> None: ...
+
+global___HistoryRecord = HistoryRecord
+
+class HistoryItem(google.protobuf.message.Message):
+    """
+    HistoryItem:
+
+    key and nested_key are mutually exclusive. Only one of them should be set.
+    key is supposedly more performant than nested_key, so nested_key should be
+    only used for nested keys.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    KEY_FIELD_NUMBER: builtins.int
+    NESTED_KEY_FIELD_NUMBER: builtins.int
+    VALUE_JSON_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    @property
+    def nested_key(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]: ...
+    value_json: builtins.str
+    def __init__(
+        self,
+        *,
+        key: builtins.str = ...,
+        nested_key: collections.abc.Iterable[builtins.str] | None = ...,
         value_json: builtins.str = ...,
     ) -> None: ...
     def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "nested_key", b"nested_key", "value_json", b"value_json"]) -> None: ...
@@ -1005,11 +1723,16 @@ class SummaryRecord(google.protobuf.message.Message):
 
     UPDATE_FIELD_NUMBER: builtins.int
     REMOVE_FIELD_NUMBER: builtins.int
+    DERIVED_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
     def update(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___SummaryItem]: ...
     @property
     def remove(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___SummaryItem]: ...
+    derived: builtins.bool
+    """Whether the values were derived from the run's history rather than
+    set by the user.
+    """
     @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
@@ -1017,10 +1740,11 @@ class SummaryRecord(google.protobuf.message.Message):
         *,
         update: collections.abc.Iterable[global___SummaryItem] | None = ...,
         remove: collections.abc.Iterable[global___SummaryItem] | None = ...,
+        derived: builtins.bool = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "remove", b"remove", "update", b"update"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "derived", b"derived", "remove", b"remove", "update", b"update"]) -> None: ...
 
 global___SummaryRecord = SummaryRecord
 
@@ -1068,19 +1792,25 @@ class FilesRecord(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
     FILES_FIELD_NUMBER: builtins.int
+    FORCE_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
     def files(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___FilesItem]: ...
+    force: builtins.bool
+    """Whether to upload the files even if they haven't changed since they
+    were last uploaded.
+    """
     @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
         self,
         *,
         files: collections.abc.Iterable[global___FilesItem] | None = ...,
+        force: builtins.bool = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "files", b"files"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "files", b"files", "force", b"force"]) -> None: ...
 
 global___FilesRecord = FilesRecord
 
@@ -1573,6 +2303,18 @@ class Request(google.protobuf.message.Message):
     GET_SYSTEM_METRICS_FIELD_NUMBER: builtins.int
     SYNC_FIELD_NUMBER: builtins.int
     JOB_INPUT_FIELD_NUMBER: builtins.int
+    CREDENTIALS_UPDATE_FIELD_NUMBER: builtins.int
+    CLEANUP_FIELD_NUMBER: builtins.int
+    SETTINGS_UPDATE_FIELD_NUMBER: builtins.int
+    RUN_UPDATED_FIELD_NUMBER: builtins.int
+    ARTIFACT_WAIT_FIELD_NUMBER: builtins.int
+    LIST_RUN_OUTPUTS_FIELD_NUMBER: builtins.int
+    ABORT_RUN_FIELD_NUMBER: builtins.int
+    BACKPRESSURE_FIELD_NUMBER: builtins.int
+    PREPARE_FOR_PREEMPTION_FIELD_NUMBER: builtins.int
+    GET_SETTINGS_FIELD_NUMBER: builtins.int
+    ARTIFACT_CANCEL_FIELD_NUMBER: builtins.int
+    STREAM_PING_FIELD_NUMBER: builtins.int
     TEST_INJECT_FIELD_NUMBER: builtins.int
     @property
     def stop_status(self) -> global___StopStatusRequest: ...
@@ -1641,6 +2383,30 @@ class Request(google.protobuf.message.Message):
     @property
     def job_input(self) -> global___JobInputRequest: ...
     @property
+    def credentials_update(self) -> global___CredentialsUpdateRequest: ...
+    @property
+    def cleanup(self) -> global___CleanupRequest: ...
+    @property
+    def settings_update(self) -> global___SettingsUpdateRequest: ...
+    @property
+    def run_updated(self) -> global___RunUpdatedRequest: ...
+    @property
+    def artifact_wait(self) -> global___ArtifactWaitRequest: ...
+    @property
+    def list_run_outputs(self) -> global___ListRunOutputsRequest: ...
+    @property
+    def abort_run(self) -> global___AbortRunRequest: ...
+    @property
+    def backpressure(self) -> global___BackpressureRequest: ...
+    @property
+    def prepare_for_preemption(self) -> global___PrepareForPreemptionRequest: ...
+    @property
+    def get_settings(self) -> global___GetSettingsRequest: ...
+    @property
+    def artifact_cancel(self) -> global___ArtifactCancelRequest: ...
+    @property
+    def stream_ping(self) -> global___StreamPingRequest: ...
+    @property
     def test_inject(self) -> global___TestInjectRequest: ...
     def __init__(
         self,
@@ -1678,11 +2444,23 @@ class Request(google.protobuf.message.Message):
         get_system_metrics: global___GetSystemMetricsRequest | None = ...,
         sync: global___SyncRequest | None = ...,
         job_input: global___JobInputRequest | None = ...,
+        credentials_update: global___CredentialsUpdateRequest | None = ...,
+        cleanup: global___CleanupRequest | None = ...,
+        settings_update: global___SettingsUpdateRequest | None = ...,
+        run_updated: global___RunUpdatedRequest | None = ...,
+        artifact_wait: global___ArtifactWaitRequest | None = ...,
+        list_run_outputs: global___ListRunOutputsRequest | None = ...,
+        abort_run: global___AbortRunRequest | None = ...,
+        backpressure: global___BackpressureRequest | None = ...,
+        prepare_for_preemption: global___PrepareForPreemptionRequest | None = ...,
+        get_settings: global___GetSettingsRequest | None = ...,
+        artifact_cancel: global___ArtifactCancelRequest | None = ...,
+        stream_ping: global___StreamPingRequest | None = ...,
         test_inject: global___TestInjectRequest | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["attach", b"attach", "cancel", b"cancel", "check_version", b"check_version", "defer", b"defer", "download_artifact", b"download_artifact", "get_summary", b"get_summary", "get_system_metrics", b"get_system_metrics", "internal_messages", b"internal_messages", "job_info", b"job_info", "job_input", b"job_input", "keepalive", b"keepalive", "log_artifact", b"log_artifact", "login", b"login", "metadata", b"metadata", "network_status", b"network_status", "partial_history", b"partial_history", "pause", b"pause", "poll_exit", b"poll_exit", "python_packages", b"python_packages", "request_type", b"request_type", "resume", b"resume", "run_start", b"run_start", "run_status", b"run_status", "sampled_history", b"sampled_history", "sender_mark", b"sender_mark", "sender_read", b"sender_read", "server_info", b"server_info", "shutdown", b"shutdown", "status", b"status", "status_report", b"status_report", "stop_status", b"stop_status", "summary_record", b"summary_record", "sync", b"sync", "telemetry_record", b"telemetry_record", "test_inject", b"test_inject"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["attach", b"attach", "cancel", b"cancel", "check_version", b"check_version", "defer", b"defer", "download_artifact", b"download_artifact", "get_summary", b"get_summary", "get_system_metrics", b"get_system_metrics", "internal_messages", b"internal_messages", "job_info", b"job_info", "job_input", b"job_input", "keepalive", b"keepalive", "log_artifact", b"log_artifact", "login", b"login", "metadata", b"metadata", "network_status", b"network_status", "partial_history", b"partial_history", "pause", b"pause", "poll_exit", b"poll_exit", "python_packages", b"python_packages", "request_type", b"request_type", "resume", b"resume", "run_start", b"run_start", "run_status", b"run_status", "sampled_history", b"sampled_history", "sender_mark", b"sender_mark", "sender_read", b"sender_read", "server_info", b"server_info", "shutdown", b"shutdown", "status", b"status", "status_report", b"status_report", "stop_status", b"stop_status", "summary_record", b"summary_record", "sync", b"sync", "telemetry_record", b"telemetry_record", "test_inject", b"test_inject"]) -> None: ...
-    def WhichOneof(self, oneof_group: typing_extensions.Literal["request_type", b"request_type"]) -> typing_extensions.Literal["stop_status", "network_status", "defer", "get_summary", "login", "pause", "resume", "poll_exit", "sampled_history", "partial_history", "run_start", "check_version", "log_artifact", "download_artifact", "keepalive", "run_status", "cancel", "metadata", "internal_messages", "python_packages", "shutdown", "attach", "status", "server_info", "sender_mark", "sender_read", "status_report", "summary_record", "telemetry_record", "job_info", "get_system_metrics", "sync", "job_input", "test_inject"] | None: ...
+    def HasField(self, field_name: typing_extensions.Literal["abort_run", b"abort_run", "artifact_cancel", b"artifact_cancel", "artifact_wait", b"artifact_wait", "attach", b"attach", "backpressure", b"backpressure", "cancel", b"cancel", "check_version", b"check_version", "cleanup", b"cleanup", "credentials_update", b"credentials_update", "defer", b"defer", "download_artifact", b"download_artifact", "get_settings", b"get_settings", "get_summary", b"get_summary", "get_system_metrics", b"get_system_metrics", "internal_messages", b"internal_messages", "job_info", b"job_info", "job_input", b"job_input", "keepalive", b"keepalive", "list_run_outputs", b"list_run_outputs", "log_artifact", b"log_artifact", "login", b"login", "metadata", b"metadata", "network_status", b"network_status", "partial_history", b"partial_history", "pause", b"pause", "poll_exit", b"poll_exit", "prepare_for_preemption", b"prepare_for_preemption", "python_packages", b"python_packages", "request_type", b"request_type", "resume", b"resume", "run_start", b"run_start", "run_status", b"run_status", "run_updated", b"run_updated", "sampled_history", b"sampled_history", "sender_mark", b"sender_mark", "sender_read", b"sender_read", "server_info", b"server_info", "settings_update", b"settings_update", "shutdown", b"shutdown", "status", b"status", "status_report", b"status_report", "stop_status", b"stop_status", "stream_ping", b"stream_ping", "summary_record", b"summary_record", "sync", b"sync", "telemetry_record", b"telemetry_record", "test_inject", b"test_inject"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["abort_run", b"abort_run", "artifact_cancel", b"artifact_cancel", "artifact_wait", b"artifact_wait", "attach", b"attach", "backpressure", b"backpressure", "cancel", b"cancel", "check_version", b"check_version", "cleanup", b"cleanup", "credentials_update", b"credentials_update", "defer", b"defer", "download_artifact", b"download_artifact", "get_settings", b"get_settings", "get_summary", b"get_summary", "get_system_metrics", b"get_system_metrics", "internal_messages", b"internal_messages", "job_info", b"job_info", "job_input", b"job_input", "keepalive", b"keepalive", "list_run_outputs", b"list_run_outputs", "log_artifact", b"log_artifact", "login", b"login", "metadata", b"metadata", "network_status", b"network_status", "partial_history", b"partial_history", "pause", b"pause", "poll_exit", b"poll_exit", "prepare_for_preemption", b"prepare_for_preemption", "python_packages", b"python_packages", "request_type", b"request_type", "resume", b"resume", "run_start", b"run_start", "run_status", b"run_status", "run_updated", b"run_updated", "sampled_history", b"sampled_history", "sender_mark", b"sender_mark", "sender_read", b"sender_read", "server_info", b"server_info", "settings_update", b"settings_update", "shutdown", b"shutdown", "status", b"status", "status_report", b"status_report", "stop_status", b"stop_status", "stream_ping", b"stream_ping", "summary_record", b"summary_record", "sync", b"sync", "telemetry_record", b"telemetry_record", "test_inject", b"test_inject"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["request_type", b"request_type"]) -> typing_extensions.Literal["stop_status", "network_status", "defer", "get_summary", "login", "pause", "resume", "poll_exit", "sampled_history", "partial_history", "run_start", "check_version", "log_artifact", "download_artifact", "keepalive", "run_status", "cancel", "metadata", "internal_messages", "python_packages", "shutdown", "attach", "status", "server_info", "sender_mark", "sender_read", "status_report", "summary_record", "telemetry_record", "job_info", "get_system_metrics", "sync", "job_input", "credentials_update", "cleanup", "settings_update", "run_updated", "artifact_wait", "list_run_outputs", "abort_run", "backpressure", "prepare_for_preemption", "get_settings", "artifact_cancel", "stream_ping", "test_inject"] | None: ...
 
 global___Request = Request
 
@@ -1714,6 +2492,17 @@ class Response(google.protobuf.message.Message):
     JOB_INFO_RESPONSE_FIELD_NUMBER: builtins.int
     GET_SYSTEM_METRICS_RESPONSE_FIELD_NUMBER: builtins.int
     SYNC_RESPONSE_FIELD_NUMBER: builtins.int
+    CLEANUP_RESPONSE_FIELD_NUMBER: builtins.int
+    UPLOAD_PROGRESS_RESPONSE_FIELD_NUMBER: builtins.int
+    ARTIFACT_WAIT_RESPONSE_FIELD_NUMBER: builtins.int
+    USER_MESSAGE_RESPONSE_FIELD_NUMBER: builtins.int
+    RUN_URL_RESPONSE_FIELD_NUMBER: builtins.int
+    LIST_RUN_OUTPUTS_RESPONSE_FIELD_NUMBER: builtins.int
+    ABORT_RUN_RESPONSE_FIELD_NUMBER: builtins.int
+    PREPARE_FOR_PREEMPTION_RESPONSE_FIELD_NUMBER: builtins.int
+    GET_SETTINGS_RESPONSE_FIELD_NUMBER: builtins.int
+    ARTIFACT_CANCEL_RESPONSE_FIELD_NUMBER: builtins.int
+    STREAM_PING_RESPONSE_FIELD_NUMBER: builtins.int
     TEST_INJECT_RESPONSE_FIELD_NUMBER: builtins.int
     @property
     def keepalive_response(self) -> global___KeepaliveResponse: ...
@@ -1758,6 +2547,28 @@ class Response(google.protobuf.message.Message):
     @property
     def sync_response(self) -> global___SyncResponse: ...
     @property
+    def cleanup_response(self) -> global___CleanupResponse: ...
+    @property
+    def upload_progress_response(self) -> global___UploadProgressResponse: ...
+    @property
+    def artifact_wait_response(self) -> global___ArtifactWaitResponse: ...
+    @property
+    def user_message_response(self) -> global___UserMessageResponse: ...
+    @property
+    def run_url_response(self) -> global___RunUrlResponse: ...
+    @property
+    def list_run_outputs_response(self) -> global___ListRunOutputsResponse: ...
+    @property
+    def abort_run_response(self) -> global___AbortRunResponse: ...
+    @property
+    def prepare_for_preemption_response(self) -> global___PrepareForPreemptionResponse: ...
+    @property
+    def get_settings_response(self) -> global___GetSettingsResponse: ...
+    @property
+    def artifact_cancel_response(self) -> global___ArtifactCancelResponse: ...
+    @property
+    def stream_ping_response(self) -> global___StreamPingResponse: ...
+    @property
     def test_inject_response(self) -> global___TestInjectResponse: ...
     def __init__(
         self,
@@ -1783,11 +2594,22 @@ class Response(google.protobuf.message.Message):
         job_info_response: global___JobInfoResponse | None = ...,
         get_system_metrics_response: global___GetSystemMetricsResponse | None = ...,
         sync_response: global___SyncResponse | None = ...,
+        cleanup_response: global___CleanupResponse | None = ...,
+        upload_progress_response: global___UploadProgressResponse | None = ...,
+        artifact_wait_response: global___ArtifactWaitResponse | None = ...,
+        user_message_response: global___UserMessageResponse | None = ...,
+        run_url_response: global___RunUrlResponse | None = ...,
+        list_run_outputs_response: global___ListRunOutputsResponse | None = ...,
+        abort_run_response: global___AbortRunResponse | None = ...,
+        prepare_for_preemption_response: global___PrepareForPreemptionResponse | None = ...,
+        get_settings_response: global___GetSettingsResponse | None = ...,
+        artifact_cancel_response: global___ArtifactCancelResponse | None = ...,
+        stream_ping_response: global___StreamPingResponse | None = ...,
         test_inject_response: global___TestInjectResponse | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["attach_response", b"attach_response", "cancel_response", b"cancel_response", "check_version_response", b"check_version_response", "download_artifact_response", b"download_artifact_response", "get_summary_response", b"get_summary_response", "get_system_metrics_response", b"get_system_metrics_response", "internal_messages_response", b"internal_messages_response", "job_info_response", b"job_info_response", "keepalive_response", b"keepalive_response", "log_artifact_response", b"log_artifact_response", "login_response", b"login_response", "network_status_response", b"network_status_response", "poll_exit_response", b"poll_exit_response", "response_type", b"response_type", "run_start_response", b"run_start_response", "run_status_response", b"run_status_response", "sampled_history_response", b"sampled_history_response", "server_info_response", b"server_info_response", "shutdown_response", b"shutdown_response", "status_response", b"status_response", "stop_status_response", b"stop_status_response", "sync_response", b"sync_response", "test_inject_response", b"test_inject_response"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["attach_response", b"attach_response", "cancel_response", b"cancel_response", "check_version_response", b"check_version_response", "download_artifact_response", b"download_artifact_response", "get_summary_response", b"get_summary_response", "get_system_metrics_response", b"get_system_metrics_response", "internal_messages_response", b"internal_messages_response", "job_info_response", b"job_info_response", "keepalive_response", b"keepalive_response", "log_artifact_response", b"log_artifact_response", "login_response", b"login_response", "network_status_response", b"network_status_response", "poll_exit_response", b"poll_exit_response", "response_type", b"response_type", "run_start_response", b"run_start_response", "run_status_response", b"run_status_response", "sampled_history_response", b"sampled_history_response", "server_info_response", b"server_info_response", "shutdown_response", b"shutdown_response", "status_response", b"status_response", "stop_status_response", b"stop_status_response", "sync_response", b"sync_response", "test_inject_response", b"test_inject_response"]) -> None: ...
-    def WhichOneof(self, oneof_group: typing_extensions.Literal["response_type", b"response_type"]) -> typing_extensions.Literal["keepalive_response", "stop_status_response", "network_status_response", "login_response", "get_summary_response", "poll_exit_response", "sampled_history_response", "run_start_response", "check_version_response", "log_artifact_response", "download_artifact_response", "run_status_response", "cancel_response", "internal_messages_response", "shutdown_response", "attach_response", "status_response", "server_info_response", "job_info_response", "get_system_metrics_response", "sync_response", "test_inject_response"] | None: ...
+    def HasField(self, field_name: typing_extensions.Literal["abort_run_response", b"abort_run_response", "artifact_cancel_response", b"artifact_cancel_response", "artifact_wait_response", b"artifact_wait_response", "attach_response", b"attach_response", "cancel_response", b"cancel_response", "check_version_response", b"check_version_response", "cleanup_response", b"cleanup_response", "download_artifact_response", b"download_artifact_response", "get_settings_response", b"get_settings_response", "get_summary_response", b"get_summary_response", "get_system_metrics_response", b"get_system_metrics_response", "internal_messages_response", b"internal_messages_response", "job_info_response", b"job_info_response", "keepalive_response", b"keepalive_response", "list_run_outputs_response", b"list_run_outputs_response", "log_artifact_response", b"log_artifact_response", "login_response", b"login_response", "network_status_response", b"network_status_response", "poll_exit_response", b"poll_exit_response", "prepare_for_preemption_response", b"prepare_for_preemption_response", "response_type", b"response_type", "run_start_response", b"run_start_response", "run_status_response", b"run_status_response", "run_url_response", b"run_url_response", "sampled_history_response", b"sampled_history_response", "server_info_response", b"server_info_response", "shutdown_response", b"shutdown_response", "status_response", b"status_response", "stop_status_response", b"stop_status_response", "stream_ping_response", b"stream_ping_response", "sync_response", b"sync_response", "test_inject_response", b"test_inject_response", "upload_progress_response", b"upload_progress_response", "user_message_response", b"user_message_response"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["abort_run_response", b"abort_run_response", "artifact_cancel_response", b"artifact_cancel_response", "artifact_wait_response", b"artifact_wait_response", "attach_response", b"attach_response", "cancel_response", b"cancel_response", "check_version_response", b"check_version_response", "cleanup_response", b"cleanup_response", "download_artifact_response", b"download_artifact_response", "get_settings_response", b"get_settings_response", "get_summary_response", b"get_summary_response", "get_system_metrics_response", b"get_system_metrics_response", "internal_messages_response", b"internal_messages_response", "job_info_response", b"job_info_response", "keepalive_response", b"keepalive_response", "list_run_outputs_response", b"list_run_outputs_response", "log_artifact_response", b"log_artifact_response", "login_response", b"login_response", "network_status_response", b"network_status_response", "poll_exit_response", b"poll_exit_response", "prepare_for_preemption_response", b"prepare_for_preemption_response", "response_type", b"response_type", "run_start_response", b"run_start_response", "run_status_response", b"run_status_response", "run_url_response", b"run_url_response", "sampled_history_response", b"sampled_history_response", "server_info_response", b"server_info_response", "shutdown_response", b"shutdown_response", "status_response", b"status_response", "stop_status_response", b"stop_status_response", "stream_ping_response", b"stream_ping_response", "sync_response", b"sync_response", "test_inject_response", b"test_inject_response", "upload_progress_response", b"upload_progress_response", "user_message_response", b"user_message_response"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["response_type", b"response_type"]) -> typing_extensions.Literal["keepalive_response", "stop_status_response", "network_status_response", "login_response", "get_summary_response", "poll_exit_response", "sampled_history_response", "run_start_response", "check_version_response", "log_artifact_response", "download_artifact_response", "run_status_response", "cancel_response", "internal_messages_response", "shutdown_response", "attach_response", "status_response", "server_info_response", "job_info_response", "get_system_metrics_response", "sync_response", "cleanup_response", "upload_progress_response", "artifact_wait_response", "user_message_response", "run_url_response", "list_run_outputs_response", "abort_run_response", "prepare_for_preemption_response", "get_settings_response", "artifact_cancel_response", "stream_ping_response", "test_inject_response"] | None: ...
 
 global___Response = Response
 
@@ -2089,64 +2911,429 @@ class StatusResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
     RUN_SHOULD_STOP_FIELD_NUMBER: builtins.int
+    EXIT_PROGRESS_FIELD_NUMBER: builtins.int
+    DIAGNOSTICS_FIELD_NUMBER: builtins.int
+    PATHS_FIELD_NUMBER: builtins.int
     run_should_stop: builtins.bool
+    @property
+    def exit_progress(self) -> global___ExitProgress: ...
+    @property
+    def diagnostics(self) -> global___StreamDiagnostics: ...
+    @property
+    def paths(self) -> global___RunPaths: ...
     def __init__(
         self,
         *,
         run_should_stop: builtins.bool = ...,
+        exit_progress: global___ExitProgress | None = ...,
+        diagnostics: global___StreamDiagnostics | None = ...,
+        paths: global___RunPaths | None = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["run_should_stop", b"run_should_stop"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["diagnostics", b"diagnostics", "exit_progress", b"exit_progress", "paths", b"paths"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["diagnostics", b"diagnostics", "exit_progress", b"exit_progress", "paths", b"paths", "run_should_stop", b"run_should_stop"]) -> None: ...
 
 global___StatusResponse = StatusResponse
 
-class StopStatusRequest(google.protobuf.message.Message):
+class RunPaths(google.protobuf.message.Message):
+    """RunPaths are where the core keeps a run's files locally."""
+
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    _INFO_FIELD_NUMBER: builtins.int
+    FILES_DIR_FIELD_NUMBER: builtins.int
+    LOG_DIR_FIELD_NUMBER: builtins.int
+    SYNC_FILE_FIELD_NUMBER: builtins.int
+    FALLBACKS_FIELD_NUMBER: builtins.int
+    DEBUG_LOG_FIELD_NUMBER: builtins.int
+    files_dir: builtins.str
+    log_dir: builtins.str
+    sync_file: builtins.str
     @property
-    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def fallbacks(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """Descriptions of the configured directories that couldn't be used and
+        were replaced by temporary ones.
+        """
+    debug_log: builtins.str
+    """Where the run's debug log is written: the configured file, a temporary
+    one if that couldn't be opened, or "stderr" if neither could.
+    """
     def __init__(
         self,
         *,
-        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+        files_dir: builtins.str = ...,
+        log_dir: builtins.str = ...,
+        sync_file: builtins.str = ...,
+        fallbacks: collections.abc.Iterable[builtins.str] | None = ...,
+        debug_log: builtins.str = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["debug_log", b"debug_log", "fallbacks", b"fallbacks", "files_dir", b"files_dir", "log_dir", b"log_dir", "sync_file", b"sync_file"]) -> None: ...
 
-global___StopStatusRequest = StopStatusRequest
-
-class StopStatusResponse(google.protobuf.message.Message):
-    DESCRIPTOR: google.protobuf.descriptor.Descriptor
-
-    RUN_SHOULD_STOP_FIELD_NUMBER: builtins.int
-    run_should_stop: builtins.bool
-    def __init__(
-        self,
-        *,
-        run_should_stop: builtins.bool = ...,
-    ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["run_should_stop", b"run_should_stop"]) -> None: ...
+global___RunPaths = RunPaths
 
-global___StopStatusResponse = StopStatusResponse
+class StreamDiagnostics(google.protobuf.message.Message):
+    """StreamDiagnostics is a snapshot of a stream's internals, to help debug
+    slowdowns and leaks.
+    """
 
-class NetworkStatusRequest(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    _INFO_FIELD_NUMBER: builtins.int
-    @property
-    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
-    def __init__(
-        self,
-        *,
-        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
-    ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+    class ChannelDepthsEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-global___NetworkStatusRequest = NetworkStatusRequest
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        value: builtins.int
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: builtins.int = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
 
-class NetworkStatusResponse(google.protobuf.message.Message):
-    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    class RetriesEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        @property
+        def value(self) -> global___RetryStats: ...
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: global___RetryStats | None = ...,
+        ) -> None: ...
+        def HasField(self, field_name: typing_extensions.Literal["value", b"value"]) -> builtins.bool: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
+    class PollsEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        @property
+        def value(self) -> global___PollStats: ...
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: global___PollStats | None = ...,
+        ) -> None: ...
+        def HasField(self, field_name: typing_extensions.Literal["value", b"value"]) -> builtins.bool: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
+    class UnknownRecordTypesEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        value: builtins.int
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: builtins.int = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
+    CHANNEL_DEPTHS_FIELD_NUMBER: builtins.int
+    GOROUTINES_FIELD_NUMBER: builtins.int
+    HEAP_IN_USE_BYTES_FIELD_NUMBER: builtins.int
+    PENDING_UPLOADS_FIELD_NUMBER: builtins.int
+    QUEUED_FILESTREAM_UPDATES_FIELD_NUMBER: builtins.int
+    UNACKED_FILESTREAM_LINES_FIELD_NUMBER: builtins.int
+    RETRIES_FIELD_NUMBER: builtins.int
+    COALESCED_HISTORY_ROWS_FIELD_NUMBER: builtins.int
+    UNTRACKED_HISTORY_VALUES_FIELD_NUMBER: builtins.int
+    PIPELINE_LATENCY_FIELD_NUMBER: builtins.int
+    POLLS_FIELD_NUMBER: builtins.int
+    HANDLER_MEMORY_FIELD_NUMBER: builtins.int
+    UNKNOWN_RECORD_TYPES_FIELD_NUMBER: builtins.int
+    @property
+    def channel_depths(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.int]:
+        """The number of records or results waiting in each channel, by name."""
+    goroutines: builtins.int
+    """The number of goroutines in the process."""
+    heap_in_use_bytes: builtins.int
+    """The bytes of heap memory in use by the process."""
+    pending_uploads: builtins.int
+    """The number of file transfers and artifact operations not yet finished."""
+    queued_filestream_updates: builtins.int
+    """The number of filestream updates not yet batched into a request."""
+    unacked_filestream_lines: builtins.int
+    """The number of filestream lines sent but not yet acknowledged."""
+    @property
+    def retries(self) -> google.protobuf.internal.containers.MessageMap[builtins.str, global___RetryStats]:
+        """Retries of requests to the backend, by endpoint class."""
+    coalesced_history_rows: builtins.int
+    """The number of history rows saved locally but not sent, because they
+    were logged faster than the history row rate limit.
+    """
+    untracked_history_values: builtins.int
+    """The number of history values sent but not summarized or sampled,
+    because their keys were beyond the limit on tracked history keys.
+    """
+    @property
+    def pipeline_latency(self) -> global___PipelineLatency:
+        """How long sampled history rows took to go through the stream."""
+    @property
+    def polls(self) -> google.protobuf.internal.containers.MessageMap[builtins.str, global___PollStats]:
+        """Status polls answered by the sender, by request type."""
+    @property
+    def handler_memory(self) -> global___HandlerMemory:
+        """The size of the handler's internal state, and what was dropped to
+        keep it within its bounds.
+        """
+    @property
+    def unknown_record_types(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.int]:
+        """The number of records and requests of types this version doesn't
+        know, sent by newer clients, by type.
+        """
+    def __init__(
+        self,
+        *,
+        channel_depths: collections.abc.Mapping[builtins.str, builtins.int] | None = ...,
+        goroutines: builtins.int = ...,
+        heap_in_use_bytes: builtins.int = ...,
+        pending_uploads: builtins.int = ...,
+        queued_filestream_updates: builtins.int = ...,
+        unacked_filestream_lines: builtins.int = ...,
+        retries: collections.abc.Mapping[builtins.str, global___RetryStats] | None = ...,
+        coalesced_history_rows: builtins.int = ...,
+        untracked_history_values: builtins.int = ...,
+        pipeline_latency: global___PipelineLatency | None = ...,
+        polls: collections.abc.Mapping[builtins.str, global___PollStats] | None = ...,
+        handler_memory: global___HandlerMemory | None = ...,
+        unknown_record_types: collections.abc.Mapping[builtins.str, builtins.int] | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["handler_memory", b"handler_memory", "pipeline_latency", b"pipeline_latency"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["channel_depths", b"channel_depths", "coalesced_history_rows", b"coalesced_history_rows", "goroutines", b"goroutines", "handler_memory", b"handler_memory", "heap_in_use_bytes", b"heap_in_use_bytes", "pending_uploads", b"pending_uploads", "pipeline_latency", b"pipeline_latency", "polls", b"polls", "queued_filestream_updates", b"queued_filestream_updates", "retries", b"retries", "unacked_filestream_lines", b"unacked_filestream_lines", "unknown_record_types", b"unknown_record_types", "untracked_history_values", b"untracked_history_values"]) -> None: ...
+
+global___StreamDiagnostics = StreamDiagnostics
+
+class HandlerMemory(google.protobuf.message.Message):
+    """HandlerMemory is the size of the state that the handler keeps about the
+    run, which is bounded by settings.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    SAMPLED_KEYS_FIELD_NUMBER: builtins.int
+    SUMMARY_KEYS_FIELD_NUMBER: builtins.int
+    PARTIAL_HISTORY_KEYS_FIELD_NUMBER: builtins.int
+    METRIC_DEFINITIONS_FIELD_NUMBER: builtins.int
+    EVICTED_SAMPLED_KEYS_FIELD_NUMBER: builtins.int
+    REFUSED_SUMMARY_VALUES_FIELD_NUMBER: builtins.int
+    REFUSED_PARTIAL_HISTORY_VALUES_FIELD_NUMBER: builtins.int
+    REFUSED_METRIC_DEFINITIONS_FIELD_NUMBER: builtins.int
+    sampled_keys: builtins.int
+    """The number of history metrics whose samples are kept."""
+    summary_keys: builtins.int
+    """The number of top-level summary keys."""
+    partial_history_keys: builtins.int
+    """The number of top-level keys in the history row being built from
+    partial history.
+    """
+    metric_definitions: builtins.int
+    """The number of defined metrics, including glob metrics."""
+    evicted_sampled_keys: builtins.int
+    """The number of metrics whose samples were dropped for ones logged more
+    recently.
+    """
+    refused_summary_values: builtins.int
+    """The number of summary updates dropped for being beyond the limit."""
+    refused_partial_history_values: builtins.int
+    """The number of partial history values dropped for being beyond the
+    limit.
+    """
+    refused_metric_definitions: builtins.int
+    """The number of metric definitions dropped for being beyond the limit."""
+    def __init__(
+        self,
+        *,
+        sampled_keys: builtins.int = ...,
+        summary_keys: builtins.int = ...,
+        partial_history_keys: builtins.int = ...,
+        metric_definitions: builtins.int = ...,
+        evicted_sampled_keys: builtins.int = ...,
+        refused_summary_values: builtins.int = ...,
+        refused_partial_history_values: builtins.int = ...,
+        refused_metric_definitions: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["evicted_sampled_keys", b"evicted_sampled_keys", "metric_definitions", b"metric_definitions", "partial_history_keys", b"partial_history_keys", "refused_metric_definitions", b"refused_metric_definitions", "refused_partial_history_values", b"refused_partial_history_values", "refused_summary_values", b"refused_summary_values", "sampled_keys", b"sampled_keys", "summary_keys", b"summary_keys"]) -> None: ...
+
+global___HandlerMemory = HandlerMemory
+
+class PollStats(google.protobuf.message.Message):
+    """PollStats counts the status polls of one request type."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    REQUESTS_FIELD_NUMBER: builtins.int
+    QUERIES_FIELD_NUMBER: builtins.int
+    requests: builtins.int
+    """The number of polls made."""
+    queries: builtins.int
+    """The number of times the backend was queried to answer them. Polls
+    made while a query is in flight, or shortly after one, share its
+    answer.
+    """
+    def __init__(
+        self,
+        *,
+        requests: builtins.int = ...,
+        queries: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["queries", b"queries", "requests", b"requests"]) -> None: ...
+
+global___PollStats = PollStats
+
+class PipelineLatency(google.protobuf.message.Message):
+    """PipelineLatency is how long sampled history rows took to go from the
+    handler to the transaction log and to the backend.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    PERSISTED_FIELD_NUMBER: builtins.int
+    ACKNOWLEDGED_FIELD_NUMBER: builtins.int
+    @property
+    def persisted(self) -> global___LatencyStats:
+        """From the handler until the row was written to the transaction log."""
+    @property
+    def acknowledged(self) -> global___LatencyStats:
+        """From the handler until the backend acknowledged the filestream request
+        with the row, which is when it's visible in the UI.
+        """
+    def __init__(
+        self,
+        *,
+        persisted: global___LatencyStats | None = ...,
+        acknowledged: global___LatencyStats | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["acknowledged", b"acknowledged", "persisted", b"persisted"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["acknowledged", b"acknowledged", "persisted", b"persisted"]) -> None: ...
+
+global___PipelineLatency = PipelineLatency
+
+class LatencyStats(google.protobuf.message.Message):
+    """LatencyStats summarizes recent samples of a latency."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    SAMPLES_FIELD_NUMBER: builtins.int
+    P50_SECONDS_FIELD_NUMBER: builtins.int
+    P95_SECONDS_FIELD_NUMBER: builtins.int
+    samples: builtins.int
+    """The number of samples summarized."""
+    p50_seconds: builtins.float
+    """The median latency, in seconds."""
+    p95_seconds: builtins.float
+    """The 95th percentile latency, in seconds."""
+    def __init__(
+        self,
+        *,
+        samples: builtins.int = ...,
+        p50_seconds: builtins.float = ...,
+        p95_seconds: builtins.float = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["p50_seconds", b"p50_seconds", "p95_seconds", b"p95_seconds", "samples", b"samples"]) -> None: ...
+
+global___LatencyStats = LatencyStats
+
+class RetryStats(google.protobuf.message.Message):
+    """RetryStats counts the retries of requests to one class of endpoint."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class ByCategoryEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        value: builtins.int
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: builtins.int = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
+    TOTAL_FIELD_NUMBER: builtins.int
+    LAST_MINUTE_FIELD_NUMBER: builtins.int
+    BY_CATEGORY_FIELD_NUMBER: builtins.int
+    total: builtins.int
+    """The number of retries since the stream started."""
+    last_minute: builtins.int
+    """The number of retries in the last minute."""
+    @property
+    def by_category(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.int]:
+        """The number of retries since the stream started, by failure category."""
+    def __init__(
+        self,
+        *,
+        total: builtins.int = ...,
+        last_minute: builtins.int = ...,
+        by_category: collections.abc.Mapping[builtins.str, builtins.int] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["by_category", b"by_category", "last_minute", b"last_minute", "total", b"total"]) -> None: ...
+
+global___RetryStats = RetryStats
+
+class StopStatusRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___StopStatusRequest = StopStatusRequest
+
+class StopStatusResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    RUN_SHOULD_STOP_FIELD_NUMBER: builtins.int
+    run_should_stop: builtins.bool
+    def __init__(
+        self,
+        *,
+        run_should_stop: builtins.bool = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["run_should_stop", b"run_should_stop"]) -> None: ...
+
+global___StopStatusResponse = StopStatusResponse
+
+class NetworkStatusRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___NetworkStatusRequest = NetworkStatusRequest
+
+class NetworkStatusResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
     NETWORK_RESPONSES_FIELD_NUMBER: builtins.int
     @property
@@ -2255,6 +3442,10 @@ class PollExitResponse(google.protobuf.message.Message):
     EXIT_RESULT_FIELD_NUMBER: builtins.int
     PUSHER_STATS_FIELD_NUMBER: builtins.int
     FILE_COUNTS_FIELD_NUMBER: builtins.int
+    EXIT_PROGRESS_FIELD_NUMBER: builtins.int
+    FILESTREAM_DONE_FIELD_NUMBER: builtins.int
+    MIRROR_PROGRESS_FIELD_NUMBER: builtins.int
+    FILES_FIELD_NUMBER: builtins.int
     done: builtins.bool
     @property
     def exit_result(self) -> global___RunExitResult: ...
@@ -2262,6 +3453,20 @@ class PollExitResponse(google.protobuf.message.Message):
     def pusher_stats(self) -> global___FilePusherStats: ...
     @property
     def file_counts(self) -> global___FileCounts: ...
+    @property
+    def exit_progress(self) -> global___ExitProgress: ...
+    filestream_done: builtins.bool
+    """Whether the run's history, summary and console output are uploaded."""
+    @property
+    def mirror_progress(self) -> global___MirrorProgress:
+        """How far mirroring the run to a second server got, if it's mirrored.
+
+        The mirror doesn't hold up the run, so it may not be done when the
+        run is.
+        """
+    @property
+    def files(self) -> global___RunFilesReport:
+        """What became of each of the run's files so far."""
     def __init__(
         self,
         *,
@@ -2269,12 +3474,89 @@ class PollExitResponse(google.protobuf.message.Message):
         exit_result: global___RunExitResult | None = ...,
         pusher_stats: global___FilePusherStats | None = ...,
         file_counts: global___FileCounts | None = ...,
+        exit_progress: global___ExitProgress | None = ...,
+        filestream_done: builtins.bool = ...,
+        mirror_progress: global___MirrorProgress | None = ...,
+        files: global___RunFilesReport | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["exit_result", b"exit_result", "file_counts", b"file_counts", "pusher_stats", b"pusher_stats"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["done", b"done", "exit_result", b"exit_result", "file_counts", b"file_counts", "pusher_stats", b"pusher_stats"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["exit_progress", b"exit_progress", "exit_result", b"exit_result", "file_counts", b"file_counts", "files", b"files", "mirror_progress", b"mirror_progress", "pusher_stats", b"pusher_stats"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["done", b"done", "exit_progress", b"exit_progress", "exit_result", b"exit_result", "file_counts", b"file_counts", "files", b"files", "filestream_done", b"filestream_done", "mirror_progress", b"mirror_progress", "pusher_stats", b"pusher_stats"]) -> None: ...
 
 global___PollExitResponse = PollExitResponse
 
+class MirrorProgress(google.protobuf.message.Message):
+    """
+    The progress of mirroring a run to a second W&B server.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    BASE_URL_FIELD_NUMBER: builtins.int
+    PUSHER_STATS_FIELD_NUMBER: builtins.int
+    EXIT_PROGRESS_FIELD_NUMBER: builtins.int
+    DONE_FIELD_NUMBER: builtins.int
+    ERROR_MESSAGE_FIELD_NUMBER: builtins.int
+    DROPPED_RECORDS_FIELD_NUMBER: builtins.int
+    base_url: builtins.str
+    """The URL of the server the run is mirrored to."""
+    @property
+    def pusher_stats(self) -> global___FilePusherStats: ...
+    @property
+    def exit_progress(self) -> global___ExitProgress: ...
+    done: builtins.bool
+    """Whether the mirror finished, or stopped after failing."""
+    error_message: builtins.str
+    """Why the mirror stopped, if it failed."""
+    dropped_records: builtins.int
+    """The number of records not mirrored because the mirror fell behind."""
+    def __init__(
+        self,
+        *,
+        base_url: builtins.str = ...,
+        pusher_stats: global___FilePusherStats | None = ...,
+        exit_progress: global___ExitProgress | None = ...,
+        done: builtins.bool = ...,
+        error_message: builtins.str = ...,
+        dropped_records: builtins.int = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["exit_progress", b"exit_progress", "pusher_stats", b"pusher_stats"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["base_url", b"base_url", "done", b"done", "dropped_records", b"dropped_records", "error_message", b"error_message", "exit_progress", b"exit_progress", "pusher_stats", b"pusher_stats"]) -> None: ...
+
+global___MirrorProgress = MirrorProgress
+
+class ExitProgress(google.protobuf.message.Message):
+    """
+    The progress of the internal process through the steps of exiting a run.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    STATE_FIELD_NUMBER: builtins.int
+    DESCRIPTION_FIELD_NUMBER: builtins.int
+    SECONDS_IN_STATE_FIELD_NUMBER: builtins.int
+    PENDING_ARTIFACTS_FIELD_NUMBER: builtins.int
+    state: global___DeferRequest.DeferState.ValueType
+    """The name of the step, like FLUSH_FS."""
+    description: builtins.str
+    """What happens in the step, like "flushing the filestream"."""
+    seconds_in_state: builtins.float
+    """How long the step has been running."""
+    pending_artifacts: builtins.int
+    """The number of artifacts still being saved, which the run waits for
+    before it's marked as finished.
+    """
+    def __init__(
+        self,
+        *,
+        state: global___DeferRequest.DeferState.ValueType = ...,
+        description: builtins.str = ...,
+        seconds_in_state: builtins.float = ...,
+        pending_artifacts: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["description", b"description", "pending_artifacts", b"pending_artifacts", "seconds_in_state", b"seconds_in_state", "state", b"state"]) -> None: ...
+
+global___ExitProgress = ExitProgress
+
 class SyncOverwrite(google.protobuf.message.Message):
     """
     Sender requests
@@ -2329,12 +3611,17 @@ class SyncRequest(google.protobuf.message.Message):
     FINAL_OFFSET_FIELD_NUMBER: builtins.int
     OVERWRITE_FIELD_NUMBER: builtins.int
     SKIP_FIELD_NUMBER: builtins.int
+    ALLOW_MODIFIED_LOG_FIELD_NUMBER: builtins.int
     start_offset: builtins.int
     final_offset: builtins.int
     @property
     def overwrite(self) -> global___SyncOverwrite: ...
     @property
     def skip(self) -> global___SyncSkip: ...
+    allow_modified_log: builtins.bool
+    """Whether to sync a transaction log that changed after its run finished,
+    which is otherwise refused.
+    """
     def __init__(
         self,
         *,
@@ -2342,9 +3629,10 @@ class SyncRequest(google.protobuf.message.Message):
         final_offset: builtins.int = ...,
         overwrite: global___SyncOverwrite | None = ...,
         skip: global___SyncSkip | None = ...,
+        allow_modified_log: builtins.bool = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["overwrite", b"overwrite", "skip", b"skip"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["final_offset", b"final_offset", "overwrite", b"overwrite", "skip", b"skip", "start_offset", b"start_offset"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["allow_modified_log", b"allow_modified_log", "final_offset", b"final_offset", "overwrite", b"overwrite", "skip", b"skip", "start_offset", b"start_offset"]) -> None: ...
 
 global___SyncRequest = SyncRequest
 
@@ -2367,52 +3655,129 @@ class SyncResponse(google.protobuf.message.Message):
 
 global___SyncResponse = SyncResponse
 
-class SenderReadRequest(google.protobuf.message.Message):
+class CleanupRequest(google.protobuf.message.Message):
+    """CleanupRequest deletes old run directories by the settings' cleanup
+    policy.
+    """
+
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    START_OFFSET_FIELD_NUMBER: builtins.int
-    FINAL_OFFSET_FIELD_NUMBER: builtins.int
-    start_offset: builtins.int
-    final_offset: builtins.int
-    """TODO: implement cancel for paused ops
-    repeated string cancel_list = 3;
+    DRY_RUN_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    dry_run: builtins.bool
+    """Only report what would be deleted, even if the settings don't ask for
+    a dry run.
     """
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
     def __init__(
         self,
         *,
-        start_offset: builtins.int = ...,
-        final_offset: builtins.int = ...,
+        dry_run: builtins.bool = ...,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["final_offset", b"final_offset", "start_offset", b"start_offset"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "dry_run", b"dry_run"]) -> None: ...
 
-global___SenderReadRequest = SenderReadRequest
+global___CleanupRequest = CleanupRequest
 
-class StatusReportRequest(google.protobuf.message.Message):
+class CleanupResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    RECORD_NUM_FIELD_NUMBER: builtins.int
-    SENT_OFFSET_FIELD_NUMBER: builtins.int
-    SYNC_TIME_FIELD_NUMBER: builtins.int
-    record_num: builtins.int
-    sent_offset: builtins.int
+    REMOVED_FIELD_NUMBER: builtins.int
+    RECLAIMED_BYTES_FIELD_NUMBER: builtins.int
+    DRY_RUN_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
     @property
-    def sync_time(self) -> google.protobuf.timestamp_pb2.Timestamp: ...
+    def removed(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___RemovedRunDir]:
+        """The run directories deleted, or that would be in a dry run."""
+    reclaimed_bytes: builtins.int
+    dry_run: builtins.bool
+    @property
+    def error(self) -> global___ErrorInfo: ...
     def __init__(
         self,
         *,
-        record_num: builtins.int = ...,
-        sent_offset: builtins.int = ...,
-        sync_time: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+        removed: collections.abc.Iterable[global___RemovedRunDir] | None = ...,
+        reclaimed_bytes: builtins.int = ...,
+        dry_run: builtins.bool = ...,
+        error: global___ErrorInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["sync_time", b"sync_time"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["record_num", b"record_num", "sent_offset", b"sent_offset", "sync_time", b"sync_time"]) -> None: ...
-
-global___StatusReportRequest = StatusReportRequest
+    def HasField(self, field_name: typing_extensions.Literal["error", b"error"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["dry_run", b"dry_run", "error", b"error", "reclaimed_bytes", b"reclaimed_bytes", "removed", b"removed"]) -> None: ...
 
-class SummaryRecordRequest(google.protobuf.message.Message):
-    """
-    Requests wrapping Records
-    """
+global___CleanupResponse = CleanupResponse
+
+class RemovedRunDir(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    RUN_ID_FIELD_NUMBER: builtins.int
+    PATH_FIELD_NUMBER: builtins.int
+    BYTES_FIELD_NUMBER: builtins.int
+    REASON_FIELD_NUMBER: builtins.int
+    run_id: builtins.str
+    path: builtins.str
+    bytes: builtins.int
+    reason: builtins.str
+    """Why the directory was deleted: "age" or "size"."""
+    def __init__(
+        self,
+        *,
+        run_id: builtins.str = ...,
+        path: builtins.str = ...,
+        bytes: builtins.int = ...,
+        reason: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["bytes", b"bytes", "path", b"path", "reason", b"reason", "run_id", b"run_id"]) -> None: ...
+
+global___RemovedRunDir = RemovedRunDir
+
+class SenderReadRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    START_OFFSET_FIELD_NUMBER: builtins.int
+    FINAL_OFFSET_FIELD_NUMBER: builtins.int
+    start_offset: builtins.int
+    final_offset: builtins.int
+    """TODO: implement cancel for paused ops
+    repeated string cancel_list = 3;
+    """
+    def __init__(
+        self,
+        *,
+        start_offset: builtins.int = ...,
+        final_offset: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["final_offset", b"final_offset", "start_offset", b"start_offset"]) -> None: ...
+
+global___SenderReadRequest = SenderReadRequest
+
+class StatusReportRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    RECORD_NUM_FIELD_NUMBER: builtins.int
+    SENT_OFFSET_FIELD_NUMBER: builtins.int
+    SYNC_TIME_FIELD_NUMBER: builtins.int
+    record_num: builtins.int
+    sent_offset: builtins.int
+    @property
+    def sync_time(self) -> google.protobuf.timestamp_pb2.Timestamp: ...
+    def __init__(
+        self,
+        *,
+        record_num: builtins.int = ...,
+        sent_offset: builtins.int = ...,
+        sync_time: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["sync_time", b"sync_time"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["record_num", b"record_num", "sent_offset", b"sent_offset", "sync_time", b"sync_time"]) -> None: ...
+
+global___StatusReportRequest = StatusReportRequest
+
+class SummaryRecordRequest(google.protobuf.message.Message):
+    """
+    Requests wrapping Records
+    """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
@@ -2470,21 +3835,76 @@ class ServerInfoResponse(google.protobuf.message.Message):
 
     LOCAL_INFO_FIELD_NUMBER: builtins.int
     SERVER_MESSAGES_FIELD_NUMBER: builtins.int
+    CAPABILITIES_FIELD_NUMBER: builtins.int
     @property
     def local_info(self) -> global___LocalInfo: ...
     @property
     def server_messages(self) -> global___ServerMessages: ...
+    @property
+    def capabilities(self) -> global___ServerCapabilities: ...
     def __init__(
         self,
         *,
         local_info: global___LocalInfo | None = ...,
         server_messages: global___ServerMessages | None = ...,
+        capabilities: global___ServerCapabilities | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["local_info", b"local_info", "server_messages", b"server_messages"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["local_info", b"local_info", "server_messages", b"server_messages"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["capabilities", b"capabilities", "local_info", b"local_info", "server_messages", b"server_messages"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["capabilities", b"capabilities", "local_info", b"local_info", "server_messages", b"server_messages"]) -> None: ...
 
 global___ServerInfoResponse = ServerInfoResponse
 
+class ServerCapabilities(google.protobuf.message.Message):
+    """
+    ServerCapabilities: what the core knows about the server it talks to.
+
+    Fields the core couldn't find out, such as when the server couldn't be
+    reached, are "unknown".
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class _Deployment:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _DeploymentEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[ServerCapabilities._Deployment.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        UNKNOWN: ServerCapabilities._Deployment.ValueType  # 0
+        SAAS: ServerCapabilities._Deployment.ValueType  # 1
+        LOCAL: ServerCapabilities._Deployment.ValueType  # 2
+
+    class Deployment(_Deployment, metaclass=_DeploymentEnumTypeWrapper): ...
+    UNKNOWN: ServerCapabilities.Deployment.ValueType  # 0
+    SAAS: ServerCapabilities.Deployment.ValueType  # 1
+    LOCAL: ServerCapabilities.Deployment.ValueType  # 2
+
+    SERVER_VERSION_FIELD_NUMBER: builtins.int
+    DEPLOYMENT_FIELD_NUMBER: builtins.int
+    MAX_FILESTREAM_REQUEST_BYTES_FIELD_NUMBER: builtins.int
+    CORE_VERSION_FIELD_NUMBER: builtins.int
+    server_version: builtins.str
+    """The version of the server, if it's a local deployment."""
+    deployment: global___ServerCapabilities.Deployment.ValueType
+    """Whether the server is W&B's cloud or a local deployment."""
+    max_filestream_request_bytes: builtins.int
+    """The most bytes the core sends in one filestream request, or 0 if
+    there's no limit.
+    """
+    core_version: builtins.str
+    """The version of the core."""
+    def __init__(
+        self,
+        *,
+        server_version: builtins.str = ...,
+        deployment: global___ServerCapabilities.Deployment.ValueType = ...,
+        max_filestream_request_bytes: builtins.int = ...,
+        core_version: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["core_version", b"core_version", "deployment", b"deployment", "max_filestream_request_bytes", b"max_filestream_request_bytes", "server_version", b"server_version"]) -> None: ...
+
+global___ServerCapabilities = ServerCapabilities
+
 class ServerMessages(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
@@ -2533,10 +3953,15 @@ class FileCounts(google.protobuf.message.Message):
     MEDIA_COUNT_FIELD_NUMBER: builtins.int
     ARTIFACT_COUNT_FIELD_NUMBER: builtins.int
     OTHER_COUNT_FIELD_NUMBER: builtins.int
+    UNCHANGED_COUNT_FIELD_NUMBER: builtins.int
     wandb_count: builtins.int
     media_count: builtins.int
     artifact_count: builtins.int
     other_count: builtins.int
+    unchanged_count: builtins.int
+    """The number of times files weren't uploaded again because they hadn't
+    changed since they were last uploaded.
+    """
     def __init__(
         self,
         *,
@@ -2544,8 +3969,9 @@ class FileCounts(google.protobuf.message.Message):
         media_count: builtins.int = ...,
         artifact_count: builtins.int = ...,
         other_count: builtins.int = ...,
+        unchanged_count: builtins.int = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["artifact_count", b"artifact_count", "media_count", b"media_count", "other_count", b"other_count", "wandb_count", b"wandb_count"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["artifact_count", b"artifact_count", "media_count", b"media_count", "other_count", b"other_count", "unchanged_count", b"unchanged_count", "wandb_count", b"wandb_count"]) -> None: ...
 
 global___FileCounts = FileCounts
 
@@ -2702,18 +4128,24 @@ class AttachResponse(google.protobuf.message.Message):
 
     RUN_FIELD_NUMBER: builtins.int
     ERROR_FIELD_NUMBER: builtins.int
+    PROVISIONAL_FIELD_NUMBER: builtins.int
     @property
     def run(self) -> global___RunRecord: ...
     @property
     def error(self) -> global___ErrorInfo: ...
+    provisional: builtins.bool
+    """Whether the server hasn't yet confirmed the run, in which case it may
+    still change, such as by being given a display name.
+    """
     def __init__(
         self,
         *,
         run: global___RunRecord | None = ...,
         error: global___ErrorInfo | None = ...,
+        provisional: builtins.bool = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["error", b"error", "run", b"run"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["error", b"error", "run", b"run"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["error", b"error", "provisional", b"provisional", "run", b"run"]) -> None: ...
 
 global___AttachResponse = AttachResponse
 
@@ -2850,16 +4282,25 @@ class SampledHistoryRequest(google.protobuf.message.Message):
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
+    KEYS_FIELD_NUMBER: builtins.int
+    SAMPLES_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
+    def keys(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """The keys of the metrics to sample, or empty for all metrics."""
+    samples: builtins.int
+    """The maximum number of samples per metric, or 0 for as many as are kept."""
+    @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
     def __init__(
         self,
         *,
+        keys: collections.abc.Iterable[builtins.str] | None = ...,
+        samples: builtins.int = ...,
         _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "keys", b"keys", "samples", b"samples"]) -> None: ...
 
 global___SampledHistoryRequest = SampledHistoryRequest
 
@@ -2874,10 +4315,29 @@ class SampledHistoryItem(google.protobuf.message.Message):
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
+    class _ValueType:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _ValueTypeEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[SampledHistoryItem._ValueType.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        NUMBER: SampledHistoryItem._ValueType.ValueType  # 0
+        """The metric's values are numbers."""
+        NON_NUMERIC: SampledHistoryItem._ValueType.ValueType  # 1
+        """The metric's values are not numbers, so none are sampled."""
+
+    class ValueType(_ValueType, metaclass=_ValueTypeEnumTypeWrapper): ...
+    NUMBER: SampledHistoryItem.ValueType.ValueType  # 0
+    """The metric's values are numbers."""
+    NON_NUMERIC: SampledHistoryItem.ValueType.ValueType  # 1
+    """The metric's values are not numbers, so none are sampled."""
+
     KEY_FIELD_NUMBER: builtins.int
     NESTED_KEY_FIELD_NUMBER: builtins.int
     VALUES_FLOAT_FIELD_NUMBER: builtins.int
     VALUES_INT_FIELD_NUMBER: builtins.int
+    STEPS_FIELD_NUMBER: builtins.int
+    VALUE_TYPE_FIELD_NUMBER: builtins.int
     key: builtins.str
     @property
     def nested_key(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]: ...
@@ -2885,6 +4345,10 @@ class SampledHistoryItem(google.protobuf.message.Message):
     def values_float(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.float]: ...
     @property
     def values_int(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.int]: ...
+    @property
+    def steps(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.int]:
+        """The steps at which the sampled values were logged, one per value."""
+    value_type: global___SampledHistoryItem.ValueType.ValueType
     def __init__(
         self,
         *,
@@ -2892,8 +4356,10 @@ class SampledHistoryItem(google.protobuf.message.Message):
         nested_key: collections.abc.Iterable[builtins.str] | None = ...,
         values_float: collections.abc.Iterable[builtins.float] | None = ...,
         values_int: collections.abc.Iterable[builtins.int] | None = ...,
+        steps: collections.abc.Iterable[builtins.int] | None = ...,
+        value_type: global___SampledHistoryItem.ValueType.ValueType = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "nested_key", b"nested_key", "values_float", b"values_float", "values_int", b"values_int"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "nested_key", b"nested_key", "steps", b"steps", "value_type", b"value_type", "values_float", b"values_float", "values_int", b"values_int"]) -> None: ...
 
 global___SampledHistoryItem = SampledHistoryItem
 
@@ -2919,124 +4385,855 @@ class RunStatusRequest(google.protobuf.message.Message):
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    _INFO_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___RunStatusRequest = RunStatusRequest
+
+class RunStatusResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    SYNC_ITEMS_TOTAL_FIELD_NUMBER: builtins.int
+    SYNC_ITEMS_PENDING_FIELD_NUMBER: builtins.int
+    SYNC_TIME_FIELD_NUMBER: builtins.int
+    sync_items_total: builtins.int
+    sync_items_pending: builtins.int
+    @property
+    def sync_time(self) -> google.protobuf.timestamp_pb2.Timestamp:
+        """TODO(flowcontrol): can we give the user an indication of step position
+        int64 sync_history_step = 3;
+        google.protobuf.Timestamp sync_history_time = 4;
+        """
+    def __init__(
+        self,
+        *,
+        sync_items_total: builtins.int = ...,
+        sync_items_pending: builtins.int = ...,
+        sync_time: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["sync_time", b"sync_time"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["sync_items_pending", b"sync_items_pending", "sync_items_total", b"sync_items_total", "sync_time", b"sync_time"]) -> None: ...
+
+global___RunStatusResponse = RunStatusResponse
+
+class RunStartRequest(google.protobuf.message.Message):
+    """
+    RunStartRequest: start the run
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    RUN_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def run(self) -> global___RunRecord: ...
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        run: global___RunRecord | None = ...,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "run", b"run"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "run", b"run"]) -> None: ...
+
+global___RunStartRequest = RunStartRequest
+
+class RunStartResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    def __init__(
+        self,
+    ) -> None: ...
+
+global___RunStartResponse = RunStartResponse
+
+class CheckVersionRequest(google.protobuf.message.Message):
+    """
+    CheckVersion:
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CURRENT_VERSION_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    current_version: builtins.str
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        current_version: builtins.str = ...,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "current_version", b"current_version"]) -> None: ...
+
+global___CheckVersionRequest = CheckVersionRequest
+
+class CheckVersionResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    UPGRADE_MESSAGE_FIELD_NUMBER: builtins.int
+    YANK_MESSAGE_FIELD_NUMBER: builtins.int
+    DELETE_MESSAGE_FIELD_NUMBER: builtins.int
+    upgrade_message: builtins.str
+    yank_message: builtins.str
+    delete_message: builtins.str
+    def __init__(
+        self,
+        *,
+        upgrade_message: builtins.str = ...,
+        yank_message: builtins.str = ...,
+        delete_message: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["delete_message", b"delete_message", "upgrade_message", b"upgrade_message", "yank_message", b"yank_message"]) -> None: ...
+
+global___CheckVersionResponse = CheckVersionResponse
+
+class JobInfoRequest(google.protobuf.message.Message):
+    """
+    JobInfo:
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___JobInfoRequest = JobInfoRequest
+
+class JobInfoResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    SEQUENCEID_FIELD_NUMBER: builtins.int
+    VERSION_FIELD_NUMBER: builtins.int
+    sequenceId: builtins.str
+    version: builtins.str
+    def __init__(
+        self,
+        *,
+        sequenceId: builtins.str = ...,
+        version: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["sequenceId", b"sequenceId", "version", b"version"]) -> None: ...
+
+global___JobInfoResponse = JobInfoResponse
+
+class LogArtifactRequest(google.protobuf.message.Message):
+    """
+    LogArtifact:
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ARTIFACT_FIELD_NUMBER: builtins.int
+    HISTORY_STEP_FIELD_NUMBER: builtins.int
+    STAGING_DIR_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def artifact(self) -> global___ArtifactRecord: ...
+    history_step: builtins.int
+    staging_dir: builtins.str
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        artifact: global___ArtifactRecord | None = ...,
+        history_step: builtins.int = ...,
+        staging_dir: builtins.str = ...,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "artifact", b"artifact"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "artifact", b"artifact", "history_step", b"history_step", "staging_dir", b"staging_dir"]) -> None: ...
+
+global___LogArtifactRequest = LogArtifactRequest
+
+class LogArtifactResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ARTIFACT_ID_FIELD_NUMBER: builtins.int
+    ERROR_MESSAGE_FIELD_NUMBER: builtins.int
+    artifact_id: builtins.str
+    error_message: builtins.str
+    def __init__(
+        self,
+        *,
+        artifact_id: builtins.str = ...,
+        error_message: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["artifact_id", b"artifact_id", "error_message", b"error_message"]) -> None: ...
+
+global___LogArtifactResponse = LogArtifactResponse
+
+class ArtifactWaitRequest(google.protobuf.message.Message):
+    """
+    ArtifactWaitRequest: wait for a logged artifact to be saved
+
+    Answered once the artifact with the client ID is committed or fails to
+    be saved, or once the timeout passes.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CLIENT_ID_FIELD_NUMBER: builtins.int
+    TIMEOUT_SECONDS_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    client_id: builtins.str
+    """The client ID of the artifact, as in its ArtifactRecord."""
+    timeout_seconds: builtins.float
+    """How long to wait in seconds. The artifact is waited for until it's
+    saved if this is 0.
+    """
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        client_id: builtins.str = ...,
+        timeout_seconds: builtins.float = ...,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "client_id", b"client_id", "timeout_seconds", b"timeout_seconds"]) -> None: ...
+
+global___ArtifactWaitRequest = ArtifactWaitRequest
+
+class ArtifactWaitResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class _State:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _StateEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[ArtifactWaitResponse._State.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        IN_PROGRESS: ArtifactWaitResponse._State.ValueType  # 0
+        """The artifact is still being saved, as the wait timed out."""
+        COMMITTED: ArtifactWaitResponse._State.ValueType  # 1
+        """The artifact is committed, or saved without committing if it's
+        not finalized.
+        """
+        FAILED: ArtifactWaitResponse._State.ValueType  # 2
+        """The artifact failed to be saved, or no artifact with the client ID
+        was logged.
+        """
+        CANCELLED: ArtifactWaitResponse._State.ValueType  # 3
+        """Saving the artifact was cancelled by an ArtifactCancelRequest."""
+
+    class State(_State, metaclass=_StateEnumTypeWrapper): ...
+    IN_PROGRESS: ArtifactWaitResponse.State.ValueType  # 0
+    """The artifact is still being saved, as the wait timed out."""
+    COMMITTED: ArtifactWaitResponse.State.ValueType  # 1
+    """The artifact is committed, or saved without committing if it's
+    not finalized.
+    """
+    FAILED: ArtifactWaitResponse.State.ValueType  # 2
+    """The artifact failed to be saved, or no artifact with the client ID
+    was logged.
+    """
+    CANCELLED: ArtifactWaitResponse.State.ValueType  # 3
+    """Saving the artifact was cancelled by an ArtifactCancelRequest."""
+
+    STATE_FIELD_NUMBER: builtins.int
+    ARTIFACT_ID_FIELD_NUMBER: builtins.int
+    VERSION_INDEX_FIELD_NUMBER: builtins.int
+    DIGEST_FIELD_NUMBER: builtins.int
+    ERROR_MESSAGE_FIELD_NUMBER: builtins.int
+    state: global___ArtifactWaitResponse.State.ValueType
+    artifact_id: builtins.str
+    """The artifact's ID on the server."""
+    @property
+    def version_index(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The artifact's version, like 3 for "v3", if the server assigned it."""
+    digest: builtins.str
+    """The digest of the artifact's manifest."""
+    error_message: builtins.str
+    def __init__(
+        self,
+        *,
+        state: global___ArtifactWaitResponse.State.ValueType = ...,
+        artifact_id: builtins.str = ...,
+        version_index: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        digest: builtins.str = ...,
+        error_message: builtins.str = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["version_index", b"version_index"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["artifact_id", b"artifact_id", "digest", b"digest", "error_message", b"error_message", "state", b"state", "version_index", b"version_index"]) -> None: ...
+
+global___ArtifactWaitResponse = ArtifactWaitResponse
+
+class ArtifactCancelRequest(google.protobuf.message.Message):
+    """
+    ArtifactCancelRequest: stop saving a logged artifact
+
+    The artifact's uploads that are left are cancelled and its draft version
+    is deleted from the server, where the server supports it. Everyone
+    waiting for the artifact is told it was cancelled. Answered once the
+    artifact stopped being saved.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CLIENT_ID_FIELD_NUMBER: builtins.int
+    _INFO_FIELD_NUMBER: builtins.int
+    client_id: builtins.str
+    """The client ID of the artifact, as in its ArtifactRecord."""
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        client_id: builtins.str = ...,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "client_id", b"client_id"]) -> None: ...
+
+global___ArtifactCancelRequest = ArtifactCancelRequest
+
+class ArtifactCancelResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class _State:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _StateEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[ArtifactCancelResponse._State.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        CANCELLED: ArtifactCancelResponse._State.ValueType  # 0
+        """Saving the artifact was stopped, by this or an earlier request."""
+        ALREADY_COMMITTED: ArtifactCancelResponse._State.ValueType  # 1
+        """The artifact was committed before it could be cancelled; committed
+        versions can't be cancelled.
+        """
+        NOT_IN_PROGRESS: ArtifactCancelResponse._State.ValueType  # 2
+        """The artifact isn't being saved: it already failed to be, or no
+        artifact with the client ID was logged.
+        """
+
+    class State(_State, metaclass=_StateEnumTypeWrapper): ...
+    CANCELLED: ArtifactCancelResponse.State.ValueType  # 0
+    """Saving the artifact was stopped, by this or an earlier request."""
+    ALREADY_COMMITTED: ArtifactCancelResponse.State.ValueType  # 1
+    """The artifact was committed before it could be cancelled; committed
+    versions can't be cancelled.
+    """
+    NOT_IN_PROGRESS: ArtifactCancelResponse.State.ValueType  # 2
+    """The artifact isn't being saved: it already failed to be, or no
+    artifact with the client ID was logged.
+    """
+
+    STATE_FIELD_NUMBER: builtins.int
+    ERROR_MESSAGE_FIELD_NUMBER: builtins.int
+    state: global___ArtifactCancelResponse.State.ValueType
+    error_message: builtins.str
+    def __init__(
+        self,
+        *,
+        state: global___ArtifactCancelResponse.State.ValueType = ...,
+        error_message: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["error_message", b"error_message", "state", b"state"]) -> None: ...
+
+global___ArtifactCancelResponse = ArtifactCancelResponse
+
+class StreamPingRequest(google.protobuf.message.Message):
+    """
+    StreamPing: check that a stream is alive, to diagnose hangs
+
+    Answered on the stream's control lane, ahead of the records queued in
+    its pipeline, so it's answered even while the pipeline is stuck.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___StreamPingRequest = StreamPingRequest
+
+class StreamPingResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class LastProcessedEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        @property
+        def value(self) -> google.protobuf.timestamp_pb2.Timestamp: ...
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+        ) -> None: ...
+        def HasField(self, field_name: typing_extensions.Literal["value", b"value"]) -> builtins.bool: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
+    HEALTH_FIELD_NUMBER: builtins.int
+    LAST_PROCESSED_FIELD_NUMBER: builtins.int
+    DIAGNOSTICS_FIELD_NUMBER: builtins.int
+    @property
+    def health(self) -> global___StreamHealth: ...
+    @property
+    def last_processed(self) -> google.protobuf.internal.containers.MessageMap[builtins.str, google.protobuf.timestamp_pb2.Timestamp]:
+        """When each of the stream's components last processed a record, by
+        component; a component that processed none is missing.
+        """
+    @property
+    def diagnostics(self) -> global___StreamDiagnostics:
+        """A snapshot of the stream's internals, such as its channel depths."""
+    def __init__(
+        self,
+        *,
+        health: global___StreamHealth | None = ...,
+        last_processed: collections.abc.Mapping[builtins.str, google.protobuf.timestamp_pb2.Timestamp] | None = ...,
+        diagnostics: global___StreamDiagnostics | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["diagnostics", b"diagnostics", "health", b"health"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["diagnostics", b"diagnostics", "health", b"health", "last_processed", b"last_processed"]) -> None: ...
+
+global___StreamPingResponse = StreamPingResponse
+
+class StreamHealth(google.protobuf.message.Message):
+    """StreamHealth is what wandb-core knows about a stream without asking its
+    pipeline.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    STREAM_ID_FIELD_NUMBER: builtins.int
+    LAST_BACKEND_CONTACT_FIELD_NUMBER: builtins.int
+    stream_id: builtins.str
+    @property
+    def last_backend_contact(self) -> google.protobuf.timestamp_pb2.Timestamp:
+        """When the stream last got a response from the W&B server that wasn't
+        a server error, or unset if it never did.
+        """
+    def __init__(
+        self,
+        *,
+        stream_id: builtins.str = ...,
+        last_backend_contact: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["last_backend_contact", b"last_backend_contact"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["last_backend_contact", b"last_backend_contact", "stream_id", b"stream_id"]) -> None: ...
+
+global___StreamHealth = StreamHealth
+
+class UploadProgressResponse(google.protobuf.message.Message):
+    """
+    UploadProgress: how far the uploads of a request got
+
+    Sent to the requesting client while a request's uploads are in progress,
+    before the request's own response.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    MAILBOX_SLOT_FIELD_NUMBER: builtins.int
+    NAME_FIELD_NUMBER: builtins.int
+    DONE_BYTES_FIELD_NUMBER: builtins.int
+    TOTAL_BYTES_FIELD_NUMBER: builtins.int
+    DONE_FILES_FIELD_NUMBER: builtins.int
+    TOTAL_FILES_FIELD_NUMBER: builtins.int
+    mailbox_slot: builtins.str
+    """The mailbox slot of the request whose uploads these are."""
+    name: builtins.str
+    """What's being uploaded, like an artifact's name."""
+    done_bytes: builtins.int
+    total_bytes: builtins.int
+    done_files: builtins.int
+    """The number of files done, and in total, or 0 if not counted by file."""
+    total_files: builtins.int
+    def __init__(
+        self,
+        *,
+        mailbox_slot: builtins.str = ...,
+        name: builtins.str = ...,
+        done_bytes: builtins.int = ...,
+        total_bytes: builtins.int = ...,
+        done_files: builtins.int = ...,
+        total_files: builtins.int = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["done_bytes", b"done_bytes", "done_files", b"done_files", "mailbox_slot", b"mailbox_slot", "name", b"name", "total_bytes", b"total_bytes", "total_files", b"total_files"]) -> None: ...
+
+global___UploadProgressResponse = UploadProgressResponse
+
+class UserMessageResponse(google.protobuf.message.Message):
+    """
+    UserMessage: something to tell the user
+
+    Sent to every attached client as problems the user should know about
+    come up, such as losing the network, rather than in response to a request.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class _Level:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _LevelEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[UserMessageResponse._Level.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        INFO: UserMessageResponse._Level.ValueType  # 0
+        WARNING: UserMessageResponse._Level.ValueType  # 1
+        ERROR: UserMessageResponse._Level.ValueType  # 2
+
+    class Level(_Level, metaclass=_LevelEnumTypeWrapper): ...
+    INFO: UserMessageResponse.Level.ValueType  # 0
+    WARNING: UserMessageResponse.Level.ValueType  # 1
+    ERROR: UserMessageResponse.Level.ValueType  # 2
+
+    LEVEL_FIELD_NUMBER: builtins.int
+    MESSAGE_FIELD_NUMBER: builtins.int
+    level: global___UserMessageResponse.Level.ValueType
+    message: builtins.str
+    def __init__(
+        self,
+        *,
+        level: global___UserMessageResponse.Level.ValueType = ...,
+        message: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["level", b"level", "message", b"message"]) -> None: ...
+
+global___UserMessageResponse = UserMessageResponse
+
+class RunUrlResponse(google.protobuf.message.Message):
+    """
+    RunUrl: where the run can be viewed
+
+    Sent to every attached client once the run is created on the server, or
+    once it starts if it's offline, rather than in response to a request.
+    The fields are those of the run-url.json file in the run's directory.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    VERSION_FIELD_NUMBER: builtins.int
+    ENTITY_FIELD_NUMBER: builtins.int
+    PROJECT_FIELD_NUMBER: builtins.int
+    RUN_ID_FIELD_NUMBER: builtins.int
+    URL_FIELD_NUMBER: builtins.int
+    LOCAL_PATH_FIELD_NUMBER: builtins.int
+    version: builtins.int
+    """The version of the file's schema."""
+    entity: builtins.str
+    project: builtins.str
+    run_id: builtins.str
+    url: builtins.str
+    """The run's URL, or empty if the run is offline."""
+    local_path: builtins.str
+    """The run's local directory."""
+    def __init__(
+        self,
+        *,
+        version: builtins.int = ...,
+        entity: builtins.str = ...,
+        project: builtins.str = ...,
+        run_id: builtins.str = ...,
+        url: builtins.str = ...,
+        local_path: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["entity", b"entity", "local_path", b"local_path", "project", b"project", "run_id", b"run_id", "url", b"url", "version", b"version"]) -> None: ...
+
+global___RunUrlResponse = RunUrlResponse
+
+class ListRunOutputsRequest(google.protobuf.message.Message):
+    """
+    ListRunOutputs: what the run produced so far
+
+    Answered from what the service knows of the run, without asking the
+    server, so it works the same offline. The response may be sent in parts.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___ListRunOutputsRequest = ListRunOutputsRequest
+
+class ListRunOutputsResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    FILES_FIELD_NUMBER: builtins.int
+    ARTIFACTS_FIELD_NUMBER: builtins.int
+    MEDIA_FIELD_NUMBER: builtins.int
+    @property
+    def files(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___RunFileReport]:
+        """The files saved to the run, by path."""
+    @property
+    def artifacts(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___RunArtifactOutput]:
+        """The artifact versions logged by the run, in the order logged."""
+    @property
+    def media(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___RunMediaOutput]:
+        """The media files referenced by the run's history, by path."""
+    def __init__(
+        self,
+        *,
+        files: collections.abc.Iterable[global___RunFileReport] | None = ...,
+        artifacts: collections.abc.Iterable[global___RunArtifactOutput] | None = ...,
+        media: collections.abc.Iterable[global___RunMediaOutput] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["artifacts", b"artifacts", "files", b"files", "media", b"media"]) -> None: ...
+
+global___ListRunOutputsResponse = ListRunOutputsResponse
+
+class RunArtifactOutput(google.protobuf.message.Message):
+    """RunArtifactOutput is an artifact version logged by a run."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    class _State:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
+
+    class _StateEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[RunArtifactOutput._State.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        PENDING: RunArtifactOutput._State.ValueType  # 0
+        """Not saved to the server yet, such as every artifact of an offline
+        run.
+        """
+        COMMITTED: RunArtifactOutput._State.ValueType  # 1
+        """Committed, or saved without committing if it's not finalized."""
+        FAILED: RunArtifactOutput._State.ValueType  # 2
+
+    class State(_State, metaclass=_StateEnumTypeWrapper): ...
+    PENDING: RunArtifactOutput.State.ValueType  # 0
+    """Not saved to the server yet, such as every artifact of an offline
+    run.
+    """
+    COMMITTED: RunArtifactOutput.State.ValueType  # 1
+    """Committed, or saved without committing if it's not finalized."""
+    FAILED: RunArtifactOutput.State.ValueType  # 2
+
+    NAME_FIELD_NUMBER: builtins.int
+    TYPE_FIELD_NUMBER: builtins.int
+    DIGEST_FIELD_NUMBER: builtins.int
+    ALIASES_FIELD_NUMBER: builtins.int
+    CLIENT_ID_FIELD_NUMBER: builtins.int
+    STATE_FIELD_NUMBER: builtins.int
+    ARTIFACT_ID_FIELD_NUMBER: builtins.int
+    VERSION_INDEX_FIELD_NUMBER: builtins.int
+    ERROR_MESSAGE_FIELD_NUMBER: builtins.int
+    name: builtins.str
+    type: builtins.str
+    digest: builtins.str
+    """The digest of the artifact's manifest."""
     @property
-    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
+    def aliases(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]: ...
+    client_id: builtins.str
+    """The artifact's client ID, as in its ArtifactRecord."""
+    state: global___RunArtifactOutput.State.ValueType
+    artifact_id: builtins.str
+    """The artifact's ID on the server, once saved."""
+    @property
+    def version_index(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The artifact's version, like 3 for "v3", if the server assigned it."""
+    error_message: builtins.str
     def __init__(
         self,
         *,
-        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
+        name: builtins.str = ...,
+        type: builtins.str = ...,
+        digest: builtins.str = ...,
+        aliases: collections.abc.Iterable[builtins.str] | None = ...,
+        client_id: builtins.str = ...,
+        state: global___RunArtifactOutput.State.ValueType = ...,
+        artifact_id: builtins.str = ...,
+        version_index: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        error_message: builtins.str = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["version_index", b"version_index"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["aliases", b"aliases", "artifact_id", b"artifact_id", "client_id", b"client_id", "digest", b"digest", "error_message", b"error_message", "name", b"name", "state", b"state", "type", b"type", "version_index", b"version_index"]) -> None: ...
 
-global___RunStatusRequest = RunStatusRequest
+global___RunArtifactOutput = RunArtifactOutput
+
+class RunMediaOutput(google.protobuf.message.Message):
+    """RunMediaOutput is a media file that a run's history refers to."""
 
-class RunStatusResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    SYNC_ITEMS_TOTAL_FIELD_NUMBER: builtins.int
-    SYNC_ITEMS_PENDING_FIELD_NUMBER: builtins.int
-    SYNC_TIME_FIELD_NUMBER: builtins.int
-    sync_items_total: builtins.int
-    sync_items_pending: builtins.int
-    @property
-    def sync_time(self) -> google.protobuf.timestamp_pb2.Timestamp:
-        """TODO(flowcontrol): can we give the user an indication of step position
-        int64 sync_history_step = 3;
-        google.protobuf.Timestamp sync_history_time = 4;
-        """
+    PATH_FIELD_NUMBER: builtins.int
+    TYPE_FIELD_NUMBER: builtins.int
+    KEY_FIELD_NUMBER: builtins.int
+    STEP_FIELD_NUMBER: builtins.int
+    SIZE_FIELD_NUMBER: builtins.int
+    SHA256_FIELD_NUMBER: builtins.int
+    path: builtins.str
+    """The file's path, relative to the run's files directory."""
+    type: builtins.str
+    """The media's type, like "image-file"."""
+    key: builtins.str
+    """The history key and the step of the first row referring to the file."""
+    step: builtins.int
+    size: builtins.int
+    """The file's size in bytes and its SHA-256 hash, if the history has them."""
+    sha256: builtins.str
     def __init__(
         self,
         *,
-        sync_items_total: builtins.int = ...,
-        sync_items_pending: builtins.int = ...,
-        sync_time: google.protobuf.timestamp_pb2.Timestamp | None = ...,
+        path: builtins.str = ...,
+        type: builtins.str = ...,
+        key: builtins.str = ...,
+        step: builtins.int = ...,
+        size: builtins.int = ...,
+        sha256: builtins.str = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["sync_time", b"sync_time"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["sync_items_pending", b"sync_items_pending", "sync_items_total", b"sync_items_total", "sync_time", b"sync_time"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "path", b"path", "sha256", b"sha256", "size", b"size", "step", b"step", "type", b"type"]) -> None: ...
 
-global___RunStatusResponse = RunStatusResponse
+global___RunMediaOutput = RunMediaOutput
 
-class RunStartRequest(google.protobuf.message.Message):
+class AbortRunRequest(google.protobuf.message.Message):
     """
-    RunStartRequest: start the run
+    AbortRun: discard the run instead of finishing it
+
+    Deletes the run from the server if it was created there, cancels the
+    run's uploads and marks its transaction log so that it isn't synced.
+    The client still sends the exit record afterwards. Aborting a run that
+    has started to exit fails.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    RUN_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
-    def run(self) -> global___RunRecord: ...
-    @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
     def __init__(
         self,
         *,
-        run: global___RunRecord | None = ...,
         _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "run", b"run"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "run", b"run"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
 
-global___RunStartRequest = RunStartRequest
+global___AbortRunRequest = AbortRunRequest
 
-class RunStartResponse(google.protobuf.message.Message):
+class AbortRunResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
+    ERROR_FIELD_NUMBER: builtins.int
+    DELETED_FIELD_NUMBER: builtins.int
+    @property
+    def error(self) -> global___ErrorInfo: ...
+    deleted: builtins.bool
+    """Whether the run had been created on the server and was deleted."""
     def __init__(
         self,
+        *,
+        error: global___ErrorInfo | None = ...,
+        deleted: builtins.bool = ...,
     ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["error", b"error"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["deleted", b"deleted", "error", b"error"]) -> None: ...
 
-global___RunStartResponse = RunStartResponse
+global___AbortRunResponse = AbortRunResponse
 
-class CheckVersionRequest(google.protobuf.message.Message):
+class PrepareForPreemptionRequest(google.protobuf.message.Message):
     """
-    CheckVersion:
+    PrepareForPreemption: get ready for the process to be killed
+
+    Sent after a preemption notice. The run is marked as preempting, its
+    transaction log is synced to disk and its buffered history, summary and
+    output are sent to the server. The response comes once that's done or
+    the timeout passes; either way, a durable log has everything needed to
+    finish the run with `wandb sync`.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    CURRENT_VERSION_FIELD_NUMBER: builtins.int
+    TIMEOUT_SECONDS_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
-    current_version: builtins.str
+    timeout_seconds: builtins.float
+    """How long to take at most, or 0 for the default of 20 seconds."""
     @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
     def __init__(
         self,
         *,
-        current_version: builtins.str = ...,
+        timeout_seconds: builtins.float = ...,
         _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "current_version", b"current_version"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "timeout_seconds", b"timeout_seconds"]) -> None: ...
 
-global___CheckVersionRequest = CheckVersionRequest
+global___PrepareForPreemptionRequest = PrepareForPreemptionRequest
 
-class CheckVersionResponse(google.protobuf.message.Message):
+class PrepareForPreemptionResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    UPGRADE_MESSAGE_FIELD_NUMBER: builtins.int
-    YANK_MESSAGE_FIELD_NUMBER: builtins.int
-    DELETE_MESSAGE_FIELD_NUMBER: builtins.int
-    upgrade_message: builtins.str
-    yank_message: builtins.str
-    delete_message: builtins.str
+    ERROR_FIELD_NUMBER: builtins.int
+    DURABLE_FIELD_NUMBER: builtins.int
+    FLUSHED_FIELD_NUMBER: builtins.int
+    UNFINISHED_FIELD_NUMBER: builtins.int
+    @property
+    def error(self) -> global___ErrorInfo:
+        """Why the transaction log isn't durable, if it isn't."""
+    durable: builtins.bool
+    """Whether the transaction log is on disk up to the request."""
+    flushed: builtins.bool
+    """Whether the server acknowledged the run's history, summary and output
+    up to the request.
+    """
+    @property
+    def unfinished(self) -> global___UnfinishedWork:
+        """What the server doesn't have yet, which syncing the run uploads."""
     def __init__(
         self,
         *,
-        upgrade_message: builtins.str = ...,
-        yank_message: builtins.str = ...,
-        delete_message: builtins.str = ...,
+        error: global___ErrorInfo | None = ...,
+        durable: builtins.bool = ...,
+        flushed: builtins.bool = ...,
+        unfinished: global___UnfinishedWork | None = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["delete_message", b"delete_message", "upgrade_message", b"upgrade_message", "yank_message", b"yank_message"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["error", b"error", "unfinished", b"unfinished"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["durable", b"durable", "error", b"error", "flushed", b"flushed", "unfinished", b"unfinished"]) -> None: ...
 
-global___CheckVersionResponse = CheckVersionResponse
+global___PrepareForPreemptionResponse = PrepareForPreemptionResponse
 
-class JobInfoRequest(google.protobuf.message.Message):
+class GetSettingsRequest(google.protobuf.message.Message):
     """
-    JobInfo:
+    GetSettings: the settings a run is using, and where they came from
+
+    Every setting is listed, with secrets like the API key redacted.
     """
 
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
@@ -3052,71 +5249,84 @@ class JobInfoRequest(google.protobuf.message.Message):
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
     def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
 
-global___JobInfoRequest = JobInfoRequest
+global___GetSettingsRequest = GetSettingsRequest
 
-class JobInfoResponse(google.protobuf.message.Message):
+class GetSettingsResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    SEQUENCEID_FIELD_NUMBER: builtins.int
-    VERSION_FIELD_NUMBER: builtins.int
-    sequenceId: builtins.str
-    version: builtins.str
+    SETTINGS_FIELD_NUMBER: builtins.int
+    DEBUG_LOG_FIELD_NUMBER: builtins.int
+    @property
+    def settings(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___EffectiveSetting]:
+        """The settings, sorted by key."""
+    debug_log: builtins.str
+    """Where the run's debug log is written, as in RunPaths."""
     def __init__(
         self,
         *,
-        sequenceId: builtins.str = ...,
-        version: builtins.str = ...,
+        settings: collections.abc.Iterable[global___EffectiveSetting] | None = ...,
+        debug_log: builtins.str = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["sequenceId", b"sequenceId", "version", b"version"]) -> None: ...
-
-global___JobInfoResponse = JobInfoResponse
+    def ClearField(self, field_name: typing_extensions.Literal["debug_log", b"debug_log", "settings", b"settings"]) -> None: ...
 
-class LogArtifactRequest(google.protobuf.message.Message):
-    """
-    LogArtifact:
-    """
+global___GetSettingsResponse = GetSettingsResponse
 
+class EffectiveSetting(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
-    ARTIFACT_FIELD_NUMBER: builtins.int
-    HISTORY_STEP_FIELD_NUMBER: builtins.int
-    STAGING_DIR_FIELD_NUMBER: builtins.int
-    _INFO_FIELD_NUMBER: builtins.int
-    @property
-    def artifact(self) -> global___ArtifactRecord: ...
-    history_step: builtins.int
-    staging_dir: builtins.str
-    @property
-    def _info(self) -> wandb.proto.wandb_base_pb2._RequestInfo: ...
-    def __init__(
-        self,
-        *,
-        artifact: global___ArtifactRecord | None = ...,
-        history_step: builtins.int = ...,
-        staging_dir: builtins.str = ...,
-        _info: wandb.proto.wandb_base_pb2._RequestInfo | None = ...,
-    ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "artifact", b"artifact"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "artifact", b"artifact", "history_step", b"history_step", "staging_dir", b"staging_dir"]) -> None: ...
-
-global___LogArtifactRequest = LogArtifactRequest
+    class _Source:
+        ValueType = typing.NewType("ValueType", builtins.int)
+        V: typing_extensions.TypeAlias = ValueType
 
-class LogArtifactResponse(google.protobuf.message.Message):
-    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+    class _SourceEnumTypeWrapper(google.protobuf.internal.enum_type_wrapper._EnumTypeWrapper[EffectiveSetting._Source.ValueType], builtins.type):  # noqa: F821
+        DESCRIPTOR: google.protobuf.descriptor.EnumDescriptor
+        DEFAULT: EffectiveSetting._Source.ValueType  # 0
+        """W&B's built-in default, or no value."""
+        PROJECT: EffectiveSetting._Source.ValueType  # 1
+        """The defaults of the run's project."""
+        CLIENT: EffectiveSetting._Source.ValueType  # 2
+        """The client, from its code, config files or environment."""
+        ENV: EffectiveSetting._Source.ValueType  # 3
+        """An environment variable of wandb-core that overrides the client."""
+        NETRC: EffectiveSetting._Source.ValueType  # 4
+        """The .netrc file."""
+        SERVER: EffectiveSetting._Source.ValueType  # 5
+        """The server, such as the run's entity once it's created."""
+
+    class Source(_Source, metaclass=_SourceEnumTypeWrapper):
+        """Where a setting's value came from."""
+
+    DEFAULT: EffectiveSetting.Source.ValueType  # 0
+    """W&B's built-in default, or no value."""
+    PROJECT: EffectiveSetting.Source.ValueType  # 1
+    """The defaults of the run's project."""
+    CLIENT: EffectiveSetting.Source.ValueType  # 2
+    """The client, from its code, config files or environment."""
+    ENV: EffectiveSetting.Source.ValueType  # 3
+    """An environment variable of wandb-core that overrides the client."""
+    NETRC: EffectiveSetting.Source.ValueType  # 4
+    """The .netrc file."""
+    SERVER: EffectiveSetting.Source.ValueType  # 5
+    """The server, such as the run's entity once it's created."""
 
-    ARTIFACT_ID_FIELD_NUMBER: builtins.int
-    ERROR_MESSAGE_FIELD_NUMBER: builtins.int
-    artifact_id: builtins.str
-    error_message: builtins.str
+    KEY_FIELD_NUMBER: builtins.int
+    VALUE_JSON_FIELD_NUMBER: builtins.int
+    SOURCE_FIELD_NUMBER: builtins.int
+    key: builtins.str
+    """The setting's proto field name."""
+    value_json: builtins.str
+    """The setting's value as JSON, or "null" if it has none."""
+    source: global___EffectiveSetting.Source.ValueType
     def __init__(
         self,
         *,
-        artifact_id: builtins.str = ...,
-        error_message: builtins.str = ...,
+        key: builtins.str = ...,
+        value_json: builtins.str = ...,
+        source: global___EffectiveSetting.Source.ValueType = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["artifact_id", b"artifact_id", "error_message", b"error_message"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "source", b"source", "value_json", b"value_json"]) -> None: ...
 
-global___LogArtifactResponse = LogArtifactResponse
+global___EffectiveSetting = EffectiveSetting
 
 class DownloadArtifactRequest(google.protobuf.message.Message):
     """
@@ -3492,20 +5702,58 @@ class GpuAppleInfo(google.protobuf.message.Message):
     GPUTYPE_FIELD_NUMBER: builtins.int
     VENDOR_FIELD_NUMBER: builtins.int
     CORES_FIELD_NUMBER: builtins.int
+    MODEL_FIELD_NUMBER: builtins.int
+    PERFORMANCE_CORES_FIELD_NUMBER: builtins.int
+    EFFICIENCY_CORES_FIELD_NUMBER: builtins.int
     gpuType: builtins.str
     vendor: builtins.str
     cores: builtins.int
+    model: builtins.str
+    performance_cores: builtins.int
+    efficiency_cores: builtins.int
     def __init__(
         self,
         *,
         gpuType: builtins.str = ...,
         vendor: builtins.str = ...,
         cores: builtins.int = ...,
+        model: builtins.str = ...,
+        performance_cores: builtins.int = ...,
+        efficiency_cores: builtins.int = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["cores", b"cores", "gpuType", b"gpuType", "vendor", b"vendor"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["cores", b"cores", "efficiency_cores", b"efficiency_cores", "gpuType", b"gpuType", "model", b"model", "performance_cores", b"performance_cores", "vendor", b"vendor"]) -> None: ...
 
 global___GpuAppleInfo = GpuAppleInfo
 
+class ContainerInfo(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    RUNTIME_FIELD_NUMBER: builtins.int
+    CONTAINER_ID_FIELD_NUMBER: builtins.int
+    IMAGE_FIELD_NUMBER: builtins.int
+    POD_NAME_FIELD_NUMBER: builtins.int
+    NAMESPACE_FIELD_NUMBER: builtins.int
+    NODE_NAME_FIELD_NUMBER: builtins.int
+    runtime: builtins.str
+    container_id: builtins.str
+    image: builtins.str
+    pod_name: builtins.str
+    namespace: builtins.str
+    node_name: builtins.str
+    def __init__(
+        self,
+        *,
+        runtime: builtins.str = ...,
+        container_id: builtins.str = ...,
+        image: builtins.str = ...,
+        pod_name: builtins.str = ...,
+        namespace: builtins.str = ...,
+        node_name: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["container_id", b"container_id", "image", b"image", "namespace", b"namespace", "node_name", b"node_name", "pod_name", b"pod_name", "runtime", b"runtime"]) -> None: ...
+
+global___ContainerInfo = ContainerInfo
+
 class GpuNvidiaInfo(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
@@ -3608,6 +5856,21 @@ class MetadataRequest(google.protobuf.message.Message):
         ) -> None: ...
         def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
 
+    class SchedulerEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        value: builtins.str
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: builtins.str = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
     OS_FIELD_NUMBER: builtins.int
     PYTHON_FIELD_NUMBER: builtins.int
     HEARTBEATAT_FIELD_NUMBER: builtins.int
@@ -3637,6 +5900,9 @@ class MetadataRequest(google.protobuf.message.Message):
     GPU_NVIDIA_FIELD_NUMBER: builtins.int
     GPU_AMD_FIELD_NUMBER: builtins.int
     SLURM_FIELD_NUMBER: builtins.int
+    SCHEDULER_FIELD_NUMBER: builtins.int
+    CONTAINER_FIELD_NUMBER: builtins.int
+    CLOCK_SKEW_SECONDS_FIELD_NUMBER: builtins.int
     os: builtins.str
     python: builtins.str
     @property
@@ -3677,6 +5943,14 @@ class MetadataRequest(google.protobuf.message.Message):
     def gpu_amd(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___GpuAmdInfo]: ...
     @property
     def slurm(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.str]: ...
+    @property
+    def scheduler(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.str]: ...
+    @property
+    def container(self) -> global___ContainerInfo: ...
+    clock_skew_seconds: builtins.float
+    """How far the local clock was behind the server's when the run started,
+    in seconds, if it was measured.
+    """
     def __init__(
         self,
         *,
@@ -3709,9 +5983,13 @@ class MetadataRequest(google.protobuf.message.Message):
         gpu_nvidia: collections.abc.Iterable[global___GpuNvidiaInfo] | None = ...,
         gpu_amd: collections.abc.Iterable[global___GpuAmdInfo] | None = ...,
         slurm: collections.abc.Mapping[builtins.str, builtins.str] | None = ...,
+        scheduler: collections.abc.Mapping[builtins.str, builtins.str] | None = ...,
+        container: global___ContainerInfo | None = ...,
+        clock_skew_seconds: builtins.float | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["cpu", b"cpu", "git", b"git", "gpu_apple", b"gpu_apple", "heartbeatAt", b"heartbeatAt", "memory", b"memory", "startedAt", b"startedAt"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["args", b"args", "code_path", b"code_path", "code_path_local", b"code_path_local", "colab", b"colab", "cpu", b"cpu", "cpu_count", b"cpu_count", "cpu_count_logical", b"cpu_count_logical", "cuda", b"cuda", "disk", b"disk", "docker", b"docker", "email", b"email", "executable", b"executable", "git", b"git", "gpu_amd", b"gpu_amd", "gpu_apple", b"gpu_apple", "gpu_count", b"gpu_count", "gpu_nvidia", b"gpu_nvidia", "gpu_type", b"gpu_type", "heartbeatAt", b"heartbeatAt", "host", b"host", "memory", b"memory", "os", b"os", "program", b"program", "python", b"python", "root", b"root", "slurm", b"slurm", "startedAt", b"startedAt", "state", b"state", "username", b"username"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_clock_skew_seconds", b"_clock_skew_seconds", "clock_skew_seconds", b"clock_skew_seconds", "container", b"container", "cpu", b"cpu", "git", b"git", "gpu_apple", b"gpu_apple", "heartbeatAt", b"heartbeatAt", "memory", b"memory", "startedAt", b"startedAt"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_clock_skew_seconds", b"_clock_skew_seconds", "args", b"args", "clock_skew_seconds", b"clock_skew_seconds", "code_path", b"code_path", "code_path_local", b"code_path_local", "colab", b"colab", "container", b"container", "cpu", b"cpu", "cpu_count", b"cpu_count", "cpu_count_logical", b"cpu_count_logical", "cuda", b"cuda", "disk", b"disk", "docker", b"docker", "email", b"email", "executable", b"executable", "git", b"git", "gpu_amd", b"gpu_amd", "gpu_apple", b"gpu_apple", "gpu_count", b"gpu_count", "gpu_nvidia", b"gpu_nvidia", "gpu_type", b"gpu_type", "heartbeatAt", b"heartbeatAt", "host", b"host", "memory", b"memory", "os", b"os", "program", b"program", "python", b"python", "root", b"root", "scheduler", b"scheduler", "slurm", b"slurm", "startedAt", b"startedAt", "state", b"state", "username", b"username"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["_clock_skew_seconds", b"_clock_skew_seconds"]) -> typing_extensions.Literal["clock_skew_seconds"] | None: ...
 
 global___MetadataRequest = MetadataRequest
 
@@ -3850,3 +6128,111 @@ class JobInputRequest(google.protobuf.message.Message):
     def ClearField(self, field_name: typing_extensions.Literal["exclude_paths", b"exclude_paths", "include_paths", b"include_paths", "input_source", b"input_source"]) -> None: ...
 
 global___JobInputRequest = JobInputRequest
+
+class CredentialsUpdateRequest(google.protobuf.message.Message):
+    """CredentialsUpdateRequest replaces the API key of an active stream.
+
+    Requests made after this is processed use the new key, while requests
+    that are already in flight finish with the old one. Like all requests,
+    it is never written to the transaction log.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    API_KEY_FIELD_NUMBER: builtins.int
+    api_key: builtins.str
+    def __init__(
+        self,
+        *,
+        api_key: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["api_key", b"api_key"]) -> None: ...
+
+global___CredentialsUpdateRequest = CredentialsUpdateRequest
+
+class SettingsUpdateRequest(google.protobuf.message.Message):
+    """SettingsUpdateRequest changes the settings of an active stream that can
+    change mid-run.
+
+    Settings that aren't set are left as they are.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    UPLOAD_BYTES_PER_SECOND_FIELD_NUMBER: builtins.int
+    FILE_STREAM_BYTES_PER_SECOND_FIELD_NUMBER: builtins.int
+    CONSOLE_CAPTURE_FIELD_NUMBER: builtins.int
+    @property
+    def upload_bytes_per_second(self) -> google.protobuf.wrappers_pb2.Int64Value:
+        """The caps on bandwidth in bytes per second, or 0 for no cap."""
+    @property
+    def file_stream_bytes_per_second(self) -> google.protobuf.wrappers_pb2.Int64Value: ...
+    @property
+    def console_capture(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """Which console streams are saved and uploaded, like the
+        _console_capture setting.
+        """
+    def __init__(
+        self,
+        *,
+        upload_bytes_per_second: google.protobuf.wrappers_pb2.Int64Value | None = ...,
+        file_stream_bytes_per_second: google.protobuf.wrappers_pb2.Int64Value | None = ...,
+        console_capture: google.protobuf.wrappers_pb2.StringValue | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["console_capture", b"console_capture", "file_stream_bytes_per_second", b"file_stream_bytes_per_second", "upload_bytes_per_second", b"upload_bytes_per_second"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["console_capture", b"console_capture", "file_stream_bytes_per_second", b"file_stream_bytes_per_second", "upload_bytes_per_second", b"upload_bytes_per_second"]) -> None: ...
+
+global___SettingsUpdateRequest = SettingsUpdateRequest
+
+class RunUpdatedRequest(google.protobuf.message.Message):
+    """RunUpdatedRequest tells the handler how the run was created or updated
+    on the server.
+
+    It's sent by the sender back to the handler, as the server may change
+    the run, for example by assigning it a display name.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    RUN_FIELD_NUMBER: builtins.int
+    @property
+    def run(self) -> global___RunRecord: ...
+    def __init__(
+        self,
+        *,
+        run: global___RunRecord | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["run", b"run"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["run", b"run"]) -> None: ...
+
+global___RunUpdatedRequest = RunUpdatedRequest
+
+class BackpressureRequest(google.protobuf.message.Message):
+    """BackpressureRequest tells the handler how far behind the sender is.
+
+    It's sent by the sender back to the handler whenever the sender's
+    backlog crosses one of the backpressure thresholds, so that the handler
+    takes in less while the backend is slow.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    PENDING_FIELD_NUMBER: builtins.int
+    SLOW_FIELD_NUMBER: builtins.int
+    CONGESTED_FIELD_NUMBER: builtins.int
+    pending: builtins.int
+    """The records and updates the sender has yet to send."""
+    slow: builtins.bool
+    """Whether the backlog is over the threshold to slow down at."""
+    congested: builtins.bool
+    """Whether the backlog is over the threshold to reject records at."""
+    def __init__(
+        self,
+        *,
+        pending: builtins.int = ...,
+        slow: builtins.bool = ...,
+        congested: builtins.bool = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["congested", b"congested", "pending", b"pending", "slow", b"slow"]) -> None: ...
+
+global___BackpressureRequest = BackpressureRequest
@@ -17,7 +17,7 @@ from wandb.proto import wandb_internal_pb2 as wandb_dot_proto_dot_wandb__interna
 from wandb.proto import wandb_settings_pb2 as wandb_dot_proto_dot_wandb__settings__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1ewandb/proto/wandb_server.proto\x12\x0ewandb_internal\x1a\x1cwandb/proto/wandb_base.proto\x1a wandb/proto/wandb_internal.proto\x1a wandb/proto/wandb_settings.proto\"D\n\x15ServerShutdownRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x18\n\x16ServerShutdownResponse\"B\n\x13ServerStatusRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x16\n\x14ServerStatusResponse\"r\n\x17ServerInformInitRequest\x12*\n\x08settings\x18\x01 \x01(\x0b\x32\x18.wandb_internal.Settings\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1a\n\x18ServerInformInitResponse\"s\n\x18ServerInformStartRequest\x12*\n\x08settings\x18\x01 \x01(\x0b\x32\x18.wandb_internal.Settings\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1b\n\x19ServerInformStartResponse\"H\n\x19ServerInformFinishRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1c\n\x1aServerInformFinishResponse\"H\n\x19ServerInformAttachRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"u\n\x1aServerInformAttachResponse\x12*\n\x08settings\x18\x01 \x01(\x0b\x32\x18.wandb_internal.Settings\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"H\n\x19ServerInformDetachRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1c\n\x1aServerInformDetachResponse\"]\n\x1bServerInformTeardownRequest\x12\x11\n\texit_code\x18\x01 \x01(\x05\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1e\n\x1cServerInformTeardownResponse\"\xa4\x04\n\rServerRequest\x12\x30\n\x0erecord_publish\x18\x01 \x01(\x0b\x32\x16.wandb_internal.RecordH\x00\x12\x34\n\x12record_communicate\x18\x02 \x01(\x0b\x32\x16.wandb_internal.RecordH\x00\x12>\n\x0binform_init\x18\x03 \x01(\x0b\x32\'.wandb_internal.ServerInformInitRequestH\x00\x12\x42\n\rinform_finish\x18\x04 \x01(\x0b\x32).wandb_internal.ServerInformFinishRequestH\x00\x12\x42\n\rinform_attach\x18\x05 \x01(\x0b\x32).wandb_internal.ServerInformAttachRequestH\x00\x12\x42\n\rinform_detach\x18\x06 \x01(\x0b\x32).wandb_internal.ServerInformDetachRequestH\x00\x12\x46\n\x0finform_teardown\x18\x07 \x01(\x0b\x32+.wandb_internal.ServerInformTeardownRequestH\x00\x12@\n\x0cinform_start\x18\x08 \x01(\x0b\x32(.wandb_internal.ServerInformStartRequestH\x00\x42\x15\n\x13server_request_type\"\xb0\x04\n\x0eServerResponse\x12\x34\n\x12result_communicate\x18\x02 \x01(\x0b\x32\x16.wandb_internal.ResultH\x00\x12H\n\x14inform_init_response\x18\x03 \x01(\x0b\x32(.wandb_internal.ServerInformInitResponseH\x00\x12L\n\x16inform_finish_response\x18\x04 \x01(\x0b\x32*.wandb_internal.ServerInformFinishResponseH\x00\x12L\n\x16inform_attach_response\x18\x05 \x01(\x0b\x32*.wandb_internal.ServerInformAttachResponseH\x00\x12L\n\x16inform_detach_response\x18\x06 \x01(\x0b\x32*.wandb_internal.ServerInformDetachResponseH\x00\x12P\n\x18inform_teardown_response\x18\x07 \x01(\x0b\x32,.wandb_internal.ServerInformTeardownResponseH\x00\x12J\n\x15inform_start_response\x18\x08 \x01(\x0b\x32).wandb_internal.ServerInformStartResponseH\x00\x42\x16\n\x14server_response_typeb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1ewandb/proto/wandb_server.proto\x12\x0ewandb_internal\x1a\x1cwandb/proto/wandb_base.proto\x1a wandb/proto/wandb_internal.proto\x1a wandb/proto/wandb_settings.proto\"D\n\x15ServerShutdownRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x18\n\x16ServerShutdownResponse\"B\n\x13ServerStatusRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x16\n\x14ServerStatusResponse\"r\n\x17ServerInformInitRequest\x12*\n\x08settings\x18\x01 \x01(\x0b\x32\x18.wandb_internal.Settings\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1a\n\x18ServerInformInitResponse\"s\n\x18ServerInformStartRequest\x12*\n\x08settings\x18\x01 \x01(\x0b\x32\x18.wandb_internal.Settings\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1b\n\x19ServerInformStartResponse\"H\n\x19ServerInformFinishRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1c\n\x1aServerInformFinishResponse\"H\n\x19ServerInformAttachRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xdc\x01\n\x1aServerInformAttachResponse\x12*\n\x08settings\x18\x01 \x01(\x0b\x32\x18.wandb_internal.Settings\x12&\n\x03run\x18\x02 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12(\n\x05\x65rror\x18\x03 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\x12\x13\n\x0bprovisional\x18\x04 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"H\n\x19ServerInformDetachRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1c\n\x1aServerInformDetachResponse\"l\n\x1bServerInformTeardownRequest\x12\x11\n\texit_code\x18\x01 \x01(\x05\x12\r\n\x05\x66orce\x18\x02 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1e\n\x1cServerInformTeardownResponse\"@\n\x11ServerPingRequest\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x89\x01\n\x12ServerPingResponse\x12\x14\n\x0c\x63ore_version\x18\x01 \x01(\t\x12\x16\n\x0euptime_seconds\x18\x02 \x01(\x01\x12\x16\n\x0e\x61\x63tive_streams\x18\x03 \x01(\x05\x12-\n\x07streams\x18\x04 \x03(\x0b\x32\x1c.wandb_internal.StreamHealth\"\xd7\x04\n\rServerRequest\x12\x30\n\x0erecord_publish\x18\x01 \x01(\x0b\x32\x16.wandb_internal.RecordH\x00\x12\x34\n\x12record_communicate\x18\x02 \x01(\x0b\x32\x16.wandb_internal.RecordH\x00\x12>\n\x0binform_init\x18\x03 \x01(\x0b\x32\'.wandb_internal.ServerInformInitRequestH\x00\x12\x42\n\rinform_finish\x18\x04 \x01(\x0b\x32).wandb_internal.ServerInformFinishRequestH\x00\x12\x42\n\rinform_attach\x18\x05 \x01(\x0b\x32).wandb_internal.ServerInformAttachRequestH\x00\x12\x42\n\rinform_detach\x18\x06 \x01(\x0b\x32).wandb_internal.ServerInformDetachRequestH\x00\x12\x46\n\x0finform_teardown\x18\x07 \x01(\x0b\x32+.wandb_internal.ServerInformTeardownRequestH\x00\x12@\n\x0cinform_start\x18\x08 \x01(\x0b\x32(.wandb_internal.ServerInformStartRequestH\x00\x12\x31\n\x04ping\x18\t \x01(\x0b\x32!.wandb_internal.ServerPingRequestH\x00\x42\x15\n\x13server_request_type\"\xed\x04\n\x0eServerResponse\x12\x34\n\x12result_communicate\x18\x02 \x01(\x0b\x32\x16.wandb_internal.ResultH\x00\x12H\n\x14inform_init_response\x18\x03 \x01(\x0b\x32(.wandb_internal.ServerInformInitResponseH\x00\x12L\n\x16inform_finish_response\x18\x04 \x01(\x0b\x32*.wandb_internal.ServerInformFinishResponseH\x00\x12L\n\x16inform_attach_response\x18\x05 \x01(\x0b\x32*.wandb_internal.ServerInformAttachResponseH\x00\x12L\n\x16inform_detach_response\x18\x06 \x01(\x0b\x32*.wandb_internal.ServerInformDetachResponseH\x00\x12P\n\x18inform_teardown_response\x18\x07 \x01(\x0b\x32,.wandb_internal.ServerInformTeardownResponseH\x00\x12J\n\x15inform_start_response\x18\x08 \x01(\x0b\x32).wandb_internal.ServerInformStartResponseH\x00\x12;\n\rping_response\x18\t \x01(\x0b\x32\".wandb_internal.ServerPingResponseH\x00\x42\x16\n\x14server_response_typeb\x06proto3')
 
 
 
@@ -37,6 +37,8 @@ _SERVERINFORMDETACHREQUEST = DESCRIPTOR.message_types_by_name['ServerInformDetac
 _SERVERINFORMDETACHRESPONSE = DESCRIPTOR.message_types_by_name['ServerInformDetachResponse']
 _SERVERINFORMTEARDOWNREQUEST = DESCRIPTOR.message_types_by_name['ServerInformTeardownRequest']
 _SERVERINFORMTEARDOWNRESPONSE = DESCRIPTOR.message_types_by_name['ServerInformTeardownResponse']
+_SERVERPINGREQUEST = DESCRIPTOR.message_types_by_name['ServerPingRequest']
+_SERVERPINGRESPONSE = DESCRIPTOR.message_types_by_name['ServerPingResponse']
 _SERVERREQUEST = DESCRIPTOR.message_types_by_name['ServerRequest']
 _SERVERRESPONSE = DESCRIPTOR.message_types_by_name['ServerResponse']
 ServerShutdownRequest = _reflection.GeneratedProtocolMessageType('ServerShutdownRequest', (_message.Message,), {
@@ -151,6 +153,20 @@ ServerInformTeardownResponse = _reflection.GeneratedProtocolMessageType('ServerI
   })
 _sym_db.RegisterMessage(ServerInformTeardownResponse)
 
+ServerPingRequest = _reflection.GeneratedProtocolMessageType('ServerPingRequest', (_message.Message,), {
+  'DESCRIPTOR' : _SERVERPINGREQUEST,
+  '__module__' : 'wandb.proto.wandb_server_pb2'
+  # @@protoc_insertion_point(class_scope:wandb_internal.ServerPingRequest)
+  })
+_sym_db.RegisterMessage(ServerPingRequest)
+
+ServerPingResponse = _reflection.GeneratedProtocolMessageType('ServerPingResponse', (_message.Message,), {
+  'DESCRIPTOR' : _SERVERPINGRESPONSE,
+  '__module__' : 'wandb.proto.wandb_server_pb2'
+  # @@protoc_insertion_point(class_scope:wandb_internal.ServerPingResponse)
+  })
+_sym_db.RegisterMessage(ServerPingResponse)
+
 ServerRequest = _reflection.GeneratedProtocolMessageType('ServerRequest', (_message.Message,), {
   'DESCRIPTOR' : _SERVERREQUEST,
   '__module__' : 'wandb.proto.wandb_server_pb2'
@@ -190,18 +206,22 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _SERVERINFORMFINISHRESPONSE._serialized_end=728
   _SERVERINFORMATTACHREQUEST._serialized_start=730
   _SERVERINFORMATTACHREQUEST._serialized_end=802
-  _SERVERINFORMATTACHRESPONSE._serialized_start=804
-  _SERVERINFORMATTACHRESPONSE._serialized_end=921
-  _SERVERINFORMDETACHREQUEST._serialized_start=923
-  _SERVERINFORMDETACHREQUEST._serialized_end=995
-  _SERVERINFORMDETACHRESPONSE._serialized_start=997
-  _SERVERINFORMDETACHRESPONSE._serialized_end=1025
-  _SERVERINFORMTEARDOWNREQUEST._serialized_start=1027
-  _SERVERINFORMTEARDOWNREQUEST._serialized_end=1120
-  _SERVERINFORMTEARDOWNRESPONSE._serialized_start=1122
-  _SERVERINFORMTEARDOWNRESPONSE._serialized_end=1152
-  _SERVERREQUEST._serialized_start=1155
-  _SERVERREQUEST._serialized_end=1703
-  _SERVERRESPONSE._serialized_start=1706
-  _SERVERRESPONSE._serialized_end=2266
+  _SERVERINFORMATTACHRESPONSE._serialized_start=805
+  _SERVERINFORMATTACHRESPONSE._serialized_end=1025
+  _SERVERINFORMDETACHREQUEST._serialized_start=1027
+  _SERVERINFORMDETACHREQUEST._serialized_end=1099
+  _SERVERINFORMDETACHRESPONSE._serialized_start=1101
+  _SERVERINFORMDETACHRESPONSE._serialized_end=1129
+  _SERVERINFORMTEARDOWNREQUEST._serialized_start=1131
+  _SERVERINFORMTEARDOWNREQUEST._serialized_end=1239
+  _SERVERINFORMTEARDOWNRESPONSE._serialized_start=1241
+  _SERVERINFORMTEARDOWNRESPONSE._serialized_end=1271
+  _SERVERPINGREQUEST._serialized_start=1273
+  _SERVERPINGREQUEST._serialized_end=1337
+  _SERVERPINGRESPONSE._serialized_start=1340
+  _SERVERPINGRESPONSE._serialized_end=1477
+  _SERVERREQUEST._serialized_start=1480
+  _SERVERREQUEST._serialized_end=2079
+  _SERVERRESPONSE._serialized_start=2082
+  _SERVERRESPONSE._serialized_end=2703
 # @@protoc_insertion_point(module_scope)
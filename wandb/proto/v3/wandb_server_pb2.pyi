@@ -3,7 +3,9 @@
 isort:skip_file
 """
 import builtins
+import collections.abc
 import google.protobuf.descriptor
+import google.protobuf.internal.containers
 import google.protobuf.message
 import sys
 import wandb.proto.wandb_base_pb2
@@ -170,19 +172,37 @@ class ServerInformAttachResponse(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
     SETTINGS_FIELD_NUMBER: builtins.int
+    RUN_FIELD_NUMBER: builtins.int
+    ERROR_FIELD_NUMBER: builtins.int
+    PROVISIONAL_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     @property
     def settings(self) -> wandb.proto.wandb_settings_pb2.Settings: ...
     @property
+    def run(self) -> wandb.proto.wandb_internal_pb2.RunRecord:
+        """The run of the stream, to rebuild the client's Run object from."""
+    @property
+    def error(self) -> wandb.proto.wandb_internal_pb2.ErrorInfo:
+        """Why the stream can't be attached to, like it not existing or having
+        finished, if it can't.
+        """
+    provisional: builtins.bool
+    """Whether the server hasn't yet confirmed the run, in which case it may
+    still change.
+    """
+    @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
         self,
         *,
         settings: wandb.proto.wandb_settings_pb2.Settings | None = ...,
+        run: wandb.proto.wandb_internal_pb2.RunRecord | None = ...,
+        error: wandb.proto.wandb_internal_pb2.ErrorInfo | None = ...,
+        provisional: builtins.bool = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "settings", b"settings"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "settings", b"settings"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info", "error", b"error", "run", b"run", "settings", b"settings"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "error", b"error", "provisional", b"provisional", "run", b"run", "settings", b"settings"]) -> None: ...
 
 global___ServerInformAttachResponse = ServerInformAttachResponse
 
@@ -215,18 +235,24 @@ class ServerInformTeardownRequest(google.protobuf.message.Message):
     DESCRIPTOR: google.protobuf.descriptor.Descriptor
 
     EXIT_CODE_FIELD_NUMBER: builtins.int
+    FORCE_FIELD_NUMBER: builtins.int
     _INFO_FIELD_NUMBER: builtins.int
     exit_code: builtins.int
+    force: builtins.bool
+    """Whether to close the streams right away, saving their data to their
+    transaction logs without waiting to upload it.
+    """
     @property
     def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
     def __init__(
         self,
         *,
         exit_code: builtins.int = ...,
+        force: builtins.bool = ...,
         _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
     ) -> None: ...
     def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "exit_code", b"exit_code"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info", "exit_code", b"exit_code", "force", b"force"]) -> None: ...
 
 global___ServerInformTeardownRequest = ServerInformTeardownRequest
 
@@ -239,6 +265,56 @@ class ServerInformTeardownResponse(google.protobuf.message.Message):
 
 global___ServerInformTeardownResponse = ServerInformTeardownResponse
 
+class ServerPingRequest(google.protobuf.message.Message):
+    """ServerPingRequest checks that wandb-core is alive, to diagnose hangs.
+
+    It's answered by the connection as soon as it's read, without involving
+    any stream.
+    """
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    _INFO_FIELD_NUMBER: builtins.int
+    @property
+    def _info(self) -> wandb.proto.wandb_base_pb2._RecordInfo: ...
+    def __init__(
+        self,
+        *,
+        _info: wandb.proto.wandb_base_pb2._RecordInfo | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_info", b"_info"]) -> None: ...
+
+global___ServerPingRequest = ServerPingRequest
+
+class ServerPingResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CORE_VERSION_FIELD_NUMBER: builtins.int
+    UPTIME_SECONDS_FIELD_NUMBER: builtins.int
+    ACTIVE_STREAMS_FIELD_NUMBER: builtins.int
+    STREAMS_FIELD_NUMBER: builtins.int
+    core_version: builtins.str
+    """The version of wandb-core."""
+    uptime_seconds: builtins.float
+    """How long wandb-core has been running."""
+    active_streams: builtins.int
+    """The number of streams wandb-core is running."""
+    @property
+    def streams(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[wandb.proto.wandb_internal_pb2.StreamHealth]:
+        """The health of each running stream."""
+    def __init__(
+        self,
+        *,
+        core_version: builtins.str = ...,
+        uptime_seconds: builtins.float = ...,
+        active_streams: builtins.int = ...,
+        streams: collections.abc.Iterable[wandb.proto.wandb_internal_pb2.StreamHealth] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["active_streams", b"active_streams", "core_version", b"core_version", "streams", b"streams", "uptime_seconds", b"uptime_seconds"]) -> None: ...
+
+global___ServerPingResponse = ServerPingResponse
+
 class ServerRequest(google.protobuf.message.Message):
     """
     ServerRequest, ServerResponse: used in sock server
@@ -254,6 +330,7 @@ class ServerRequest(google.protobuf.message.Message):
     INFORM_DETACH_FIELD_NUMBER: builtins.int
     INFORM_TEARDOWN_FIELD_NUMBER: builtins.int
     INFORM_START_FIELD_NUMBER: builtins.int
+    PING_FIELD_NUMBER: builtins.int
     @property
     def record_publish(self) -> wandb.proto.wandb_internal_pb2.Record: ...
     @property
@@ -270,6 +347,8 @@ class ServerRequest(google.protobuf.message.Message):
     def inform_teardown(self) -> global___ServerInformTeardownRequest: ...
     @property
     def inform_start(self) -> global___ServerInformStartRequest: ...
+    @property
+    def ping(self) -> global___ServerPingRequest: ...
     def __init__(
         self,
         *,
@@ -281,10 +360,11 @@ class ServerRequest(google.protobuf.message.Message):
         inform_detach: global___ServerInformDetachRequest | None = ...,
         inform_teardown: global___ServerInformTeardownRequest | None = ...,
         inform_start: global___ServerInformStartRequest | None = ...,
+        ping: global___ServerPingRequest | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["inform_attach", b"inform_attach", "inform_detach", b"inform_detach", "inform_finish", b"inform_finish", "inform_init", b"inform_init", "inform_start", b"inform_start", "inform_teardown", b"inform_teardown", "record_communicate", b"record_communicate", "record_publish", b"record_publish", "server_request_type", b"server_request_type"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["inform_attach", b"inform_attach", "inform_detach", b"inform_detach", "inform_finish", b"inform_finish", "inform_init", b"inform_init", "inform_start", b"inform_start", "inform_teardown", b"inform_teardown", "record_communicate", b"record_communicate", "record_publish", b"record_publish", "server_request_type", b"server_request_type"]) -> None: ...
-    def WhichOneof(self, oneof_group: typing_extensions.Literal["server_request_type", b"server_request_type"]) -> typing_extensions.Literal["record_publish", "record_communicate", "inform_init", "inform_finish", "inform_attach", "inform_detach", "inform_teardown", "inform_start"] | None: ...
+    def HasField(self, field_name: typing_extensions.Literal["inform_attach", b"inform_attach", "inform_detach", b"inform_detach", "inform_finish", b"inform_finish", "inform_init", b"inform_init", "inform_start", b"inform_start", "inform_teardown", b"inform_teardown", "ping", b"ping", "record_communicate", b"record_communicate", "record_publish", b"record_publish", "server_request_type", b"server_request_type"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["inform_attach", b"inform_attach", "inform_detach", b"inform_detach", "inform_finish", b"inform_finish", "inform_init", b"inform_init", "inform_start", b"inform_start", "inform_teardown", b"inform_teardown", "ping", b"ping", "record_communicate", b"record_communicate", "record_publish", b"record_publish", "server_request_type", b"server_request_type"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["server_request_type", b"server_request_type"]) -> typing_extensions.Literal["record_publish", "record_communicate", "inform_init", "inform_finish", "inform_attach", "inform_detach", "inform_teardown", "inform_start", "ping"] | None: ...
 
 global___ServerRequest = ServerRequest
 
@@ -298,6 +378,7 @@ class ServerResponse(google.protobuf.message.Message):
     INFORM_DETACH_RESPONSE_FIELD_NUMBER: builtins.int
     INFORM_TEARDOWN_RESPONSE_FIELD_NUMBER: builtins.int
     INFORM_START_RESPONSE_FIELD_NUMBER: builtins.int
+    PING_RESPONSE_FIELD_NUMBER: builtins.int
     @property
     def result_communicate(self) -> wandb.proto.wandb_internal_pb2.Result: ...
     @property
@@ -312,6 +393,8 @@ class ServerResponse(google.protobuf.message.Message):
     def inform_teardown_response(self) -> global___ServerInformTeardownResponse: ...
     @property
     def inform_start_response(self) -> global___ServerInformStartResponse: ...
+    @property
+    def ping_response(self) -> global___ServerPingResponse: ...
     def __init__(
         self,
         *,
@@ -322,9 +405,10 @@ class ServerResponse(google.protobuf.message.Message):
         inform_detach_response: global___ServerInformDetachResponse | None = ...,
         inform_teardown_response: global___ServerInformTeardownResponse | None = ...,
         inform_start_response: global___ServerInformStartResponse | None = ...,
+        ping_response: global___ServerPingResponse | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["inform_attach_response", b"inform_attach_response", "inform_detach_response", b"inform_detach_response", "inform_finish_response", b"inform_finish_response", "inform_init_response", b"inform_init_response", "inform_start_response", b"inform_start_response", "inform_teardown_response", b"inform_teardown_response", "result_communicate", b"result_communicate", "server_response_type", b"server_response_type"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["inform_attach_response", b"inform_attach_response", "inform_detach_response", b"inform_detach_response", "inform_finish_response", b"inform_finish_response", "inform_init_response", b"inform_init_response", "inform_start_response", b"inform_start_response", "inform_teardown_response", b"inform_teardown_response", "result_communicate", b"result_communicate", "server_response_type", b"server_response_type"]) -> None: ...
-    def WhichOneof(self, oneof_group: typing_extensions.Literal["server_response_type", b"server_response_type"]) -> typing_extensions.Literal["result_communicate", "inform_init_response", "inform_finish_response", "inform_attach_response", "inform_detach_response", "inform_teardown_response", "inform_start_response"] | None: ...
+    def HasField(self, field_name: typing_extensions.Literal["inform_attach_response", b"inform_attach_response", "inform_detach_response", b"inform_detach_response", "inform_finish_response", b"inform_finish_response", "inform_init_response", b"inform_init_response", "inform_start_response", b"inform_start_response", "inform_teardown_response", b"inform_teardown_response", "ping_response", b"ping_response", "result_communicate", b"result_communicate", "server_response_type", b"server_response_type"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["inform_attach_response", b"inform_attach_response", "inform_detach_response", b"inform_detach_response", "inform_finish_response", b"inform_finish_response", "inform_init_response", b"inform_init_response", "inform_start_response", b"inform_start_response", "inform_teardown_response", b"inform_teardown_response", "ping_response", b"ping_response", "result_communicate", b"result_communicate", "server_response_type", b"server_response_type"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["server_response_type", b"server_response_type"]) -> typing_extensions.Literal["result_communicate", "inform_init_response", "inform_finish_response", "inform_attach_response", "inform_detach_response", "inform_teardown_response", "inform_start_response", "ping_response"] | None: ...
 
 global___ServerResponse = ServerResponse
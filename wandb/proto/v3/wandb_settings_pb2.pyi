@@ -168,6 +168,8 @@ class Settings(google.protobuf.message.Message):
     LOG_INTERNAL_FIELD_NUMBER: builtins.int
     FILES_DIR_FIELD_NUMBER: builtins.int
     IGNORE_GLOBS_FIELD_NUMBER: builtins.int
+    CONFIG_INCLUDE_KEYS_FIELD_NUMBER: builtins.int
+    CONFIG_EXCLUDE_KEYS_FIELD_NUMBER: builtins.int
     _DISABLE_UPDATE_CHECK_FIELD_NUMBER: builtins.int
     _REQUIRE_CORE_FIELD_NUMBER: builtins.int
     _ARGS_FIELD_NUMBER: builtins.int
@@ -319,7 +321,68 @@ class Settings(google.protobuf.message.Message):
     _STATS_BUFFER_SIZE_FIELD_NUMBER: builtins.int
     _SHARED_FIELD_NUMBER: builtins.int
     _CODE_PATH_LOCAL_FIELD_NUMBER: builtins.int
+    _LABEL_FIELD_NUMBER: builtins.int
+    _PRIMARY_FIELD_NUMBER: builtins.int
+    _DISABLE_GENERATED_RUN_NAMES_FIELD_NUMBER: builtins.int
+    _STORE_REDACTED_SETTINGS_FIELD_NUMBER: builtins.int
+    _LOG_FORMAT_FIELD_NUMBER: builtins.int
+    _DIAGNOSTICS_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
+    _FAULT_INJECTION_FIELD_NUMBER: builtins.int
+    _HTTP_IDLE_CONN_TIMEOUT_SECONDS_FIELD_NUMBER: builtins.int
+    _HTTP_MAX_IDLE_CONNS_PER_HOST_FIELD_NUMBER: builtins.int
+    _HTTP_MAX_CONNS_PER_HOST_FIELD_NUMBER: builtins.int
+    _HTTP_DISABLE_HTTP2_FIELD_NUMBER: builtins.int
+    _FILE_STREAM_TRANSMIT_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
+    _FILE_STREAM_MIN_TRANSMIT_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
+    _FILE_STREAM_MAX_TRANSMIT_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
+    _FILE_STREAM_MAX_REQUEST_BYTES_FIELD_NUMBER: builtins.int
+    _NETWORK_OFFLINE_AFTER_SECONDS_FIELD_NUMBER: builtins.int
+    _NETWORK_PROBE_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
+    _RETRY_BUDGET_PER_MINUTE_FIELD_NUMBER: builtins.int
+    _SUMMARY_DEBOUNCE_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
+    _EXIT_TIMEOUT_SECONDS_FIELD_NUMBER: builtins.int
+    _CLEANUP_MAX_AGE_DAYS_FIELD_NUMBER: builtins.int
+    _CLEANUP_MAX_TOTAL_BYTES_FIELD_NUMBER: builtins.int
+    _CLEANUP_DRY_RUN_FIELD_NUMBER: builtins.int
+    _VERIFY_ON_EXIT_FIELD_NUMBER: builtins.int
+    _VERIFY_TIMEOUT_SECONDS_FIELD_NUMBER: builtins.int
+    _UPLOAD_BYTES_PER_SECOND_FIELD_NUMBER: builtins.int
+    _FILE_STREAM_BYTES_PER_SECOND_FIELD_NUMBER: builtins.int
+    _RECORD_SINK_URL_FIELD_NUMBER: builtins.int
+    _RECORD_SINK_TYPES_FIELD_NUMBER: builtins.int
+    _CONSOLE_DEDUP_THRESHOLD_FIELD_NUMBER: builtins.int
+    _CONSOLE_TIMESTAMPS_FIELD_NUMBER: builtins.int
+    _DISABLE_CLOCK_SKEW_CORRECTION_FIELD_NUMBER: builtins.int
     _PROXIES_FIELD_NUMBER: builtins.int
+    _MAX_HISTORY_ROWS_PER_SECOND_FIELD_NUMBER: builtins.int
+    _CORE_RSS_WARNING_MB_FIELD_NUMBER: builtins.int
+    _CONSOLE_CAPTURE_FIELD_NUMBER: builtins.int
+    _UPLOAD_URL_BATCH_SIZE_FIELD_NUMBER: builtins.int
+    _ARTIFACT_FULL_REHASH_FIELD_NUMBER: builtins.int
+    _EXPORT_DIR_FIELD_NUMBER: builtins.int
+    _EXPORT_HARD_LINK_FIELD_NUMBER: builtins.int
+    _MIRROR_BASE_URL_FIELD_NUMBER: builtins.int
+    _MIRROR_API_KEY_FIELD_NUMBER: builtins.int
+    _PROGRAM_OVERRIDE_FIELD_NUMBER: builtins.int
+    _ARGS_OVERRIDE_FIELD_NUMBER: builtins.int
+    _CODE_PATH_OVERRIDE_FIELD_NUMBER: builtins.int
+    _ROOT_DIR_OVERRIDE_FIELD_NUMBER: builtins.int
+    _RUN_URL_NOTIFY_URL_FIELD_NUMBER: builtins.int
+    _DRY_RUN_FIELD_NUMBER: builtins.int
+    _MAX_TRACKED_HISTORY_KEYS_FIELD_NUMBER: builtins.int
+    _MAX_REPORTED_FILES_FIELD_NUMBER: builtins.int
+    _LOW_LATENCY_FIELD_NUMBER: builtins.int
+    _SHARED_INTERNAL_LOG_FIELD_NUMBER: builtins.int
+    _MAX_SAMPLED_HISTORY_KEYS_FIELD_NUMBER: builtins.int
+    _MAX_SUMMARY_KEYS_FIELD_NUMBER: builtins.int
+    _MAX_PARTIAL_HISTORY_KEYS_FIELD_NUMBER: builtins.int
+    _MAX_METRIC_DEFINITIONS_FIELD_NUMBER: builtins.int
+    _EXIT_GRACE_SECONDS_FIELD_NUMBER: builtins.int
+    _BACKPRESSURE_SLOW_DEPTH_FIELD_NUMBER: builtins.int
+    _BACKPRESSURE_CONGESTED_DEPTH_FIELD_NUMBER: builtins.int
+    _RUN_LABELS_FIELD_NUMBER: builtins.int
+    _RUN_LABEL_KEYS_FIELD_NUMBER: builtins.int
+    _STREAM_STATE_INTERVAL_SECONDS_FIELD_NUMBER: builtins.int
     @property
     def api_key(self) -> google.protobuf.wrappers_pb2.StringValue:
         """The W&B API key.
@@ -354,6 +417,16 @@ class Settings(google.protobuf.message.Message):
     def ignore_globs(self) -> global___ListStringValue:
         """Unix glob patterns relative to `files_dir` to not upload."""
     @property
+    def config_include_keys(self) -> global___ListStringValue:
+        """Dotted config key patterns, with globs, to send to the server; if set,
+        other keys are kept local. Keys under "_wandb" are always sent.
+        """
+    @property
+    def config_exclude_keys(self) -> global___ListStringValue:
+        """Dotted config key patterns, with globs, to keep local instead of
+        sending to the server.
+        """
+    @property
     def _disable_update_check(self) -> google.protobuf.wrappers_pb2.BoolValue:
         """Disable check for latest version of wandb, from pypi."""
     @property
@@ -660,7 +733,272 @@ class Settings(google.protobuf.message.Message):
     @property
     def _code_path_local(self) -> google.protobuf.wrappers_pb2.StringValue: ...
     @property
+    def _label(self) -> google.protobuf.wrappers_pb2.StringValue: ...
+    @property
+    def _primary(self) -> google.protobuf.wrappers_pb2.BoolValue: ...
+    @property
+    def _disable_generated_run_names(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Do not generate display names for runs that don't have one."""
+    @property
+    def _store_redacted_settings(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Store the settings, with secrets redacted, in the run record of the
+        transaction log.
+        """
+    @property
+    def _log_format(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """The format of the internal log file: "text" or "json"."""
+    @property
+    def _diagnostics_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How often to log a stream's diagnostics, or 0 to never log them."""
+    @property
+    def _fault_injection(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """Faults to inject into the core, for testing; see the faults package."""
+    @property
+    def _http_idle_conn_timeout_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How long to keep idle HTTP connections open, or 0 for the default."""
+    @property
+    def _http_max_idle_conns_per_host(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The idle HTTP connections kept open per host, or 0 for the default."""
+    @property
+    def _http_max_conns_per_host(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most HTTP connections to make per host, or 0 for no limit."""
+    @property
+    def _http_disable_http2(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Use HTTP/1.1 even with servers that support HTTP/2."""
+    @property
+    def _file_stream_transmit_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How long the filestream batches updates before sending them, and the
+        bounds within which it adapts this to the backlog.
+        """
+    @property
+    def _file_stream_min_transmit_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue: ...
+    @property
+    def _file_stream_max_transmit_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue: ...
+    @property
+    def _file_stream_max_request_bytes(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most bytes to send in one filestream request, or 0 for the default."""
+    @property
+    def _network_offline_after_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How long requests must fail with network errors before the run goes
+        offline until the network is back, or 0 to keep retrying.
+        """
+    @property
+    def _network_probe_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How often to check whether the network is back while offline."""
+    @property
+    def _retry_budget_per_minute(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """How many retries to an endpoint in a minute trigger a warning, or 0
+        for the default. This never changes whether requests are retried.
+        """
+    @property
+    def _summary_debounce_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """The least time between summary updates sent to the backend."""
+    @property
+    def _exit_timeout_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How long a finishing run waits for its data to be uploaded before
+        leaving the rest to `wandb sync`, or 0 to wait until it's done.
+        """
+    @property
+    def _cleanup_max_age_days(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """Finished, fully synced run directories to delete: those older than
+        the days, and the oldest beyond the total bytes. 0 disables either.
+        """
+    @property
+    def _cleanup_max_total_bytes(self) -> google.protobuf.wrappers_pb2.Int64Value: ...
+    @property
+    def _cleanup_dry_run(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Only report which run directories the cleanup would delete."""
+    @property
+    def _verify_on_exit(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Check that the run's data reached the server as it exits, waiting at
+        most the timeout for the server to catch up.
+        """
+    @property
+    def _verify_timeout_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue: ...
+    @property
+    def _upload_bytes_per_second(self) -> google.protobuf.wrappers_pb2.Int64Value:
+        """Caps on the bandwidth of file and artifact uploads, and of filestream
+        traffic like metrics and console logs, in bytes per second. 0 is no
+        cap. Both can change mid-run through a settings update.
+        """
+    @property
+    def _file_stream_bytes_per_second(self) -> google.protobuf.wrappers_pb2.Int64Value: ...
+    @property
+    def _record_sink_url(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """An HTTP endpoint to mirror the run's records to as they're sent, and
+        the record types to mirror, like "history" and "exit". All types are
+        mirrored if none are listed.
+        """
+    @property
+    def _record_sink_types(self) -> global___ListStringValue: ...
+    @property
+    def _console_dedup_threshold(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """How many times in a row an identical console line is output before
+        its further repeats are collapsed into one "last line repeated N
+        times" line. 0 doesn't collapse them.
+        """
+    @property
+    def _console_timestamps(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Whether console lines are assembled from the chunks they're printed
+        in and prefixed in output.log with an ISO-8601 timestamp of when they
+        were printed. Streamed lines are timed the same way.
+        """
+    @property
+    def _disable_clock_skew_correction(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Whether to not correct the timestamps in history for a local clock
+        that is far off the server's, for clocks set off on purpose.
+        """
+    @property
     def _proxies(self) -> global___MapStringKeyStringValue: ...
+    @property
+    def _max_history_rows_per_second(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """The most history rows per second to send to the server. Rows logged
+        faster are saved in the transaction log but not sent. 0 is no limit.
+        """
+    @property
+    def _core_rss_warning_mb(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The memory used by the wandb-core process, in megabytes, above which
+        a warning is logged, as it likely has a backlog. 0 never warns.
+        """
+    @property
+    def _console_capture(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """Which console streams are saved and uploaded: "stdout", "stderr",
+        "both", or "off" for neither. "auto" is the same as "both".
+        """
+    @property
+    def _upload_url_batch_size(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """How many files' upload URLs are requested from the server at once,
+        for run files and artifacts. 0 uses the default of 500.
+        """
+    @property
+    def _artifact_full_rehash(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Whether to hash every file added to an artifact, instead of reusing
+        digests of files unchanged since the artifact's previous version.
+        """
+    @property
+    def _export_dir(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """A directory, such as a mounted network share, to mirror every file the
+        run uploads or would upload into, under <entity>/<project>/<run_id>.
+        Files are mirrored whether the run is online or offline.
+        """
+    @property
+    def _export_hard_link(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Whether to hard-link exported files instead of copying them, where the
+        export directory is on the same filesystem.
+        """
+    @property
+    def _mirror_base_url(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """The URL of a second W&B server to mirror the run to, such as while
+        migrating between servers. Artifacts are only logged to the primary.
+        """
+    @property
+    def _mirror_api_key(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """The API key for the server the run is mirrored to."""
+    @property
+    def _program_override(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """The program to record for the run instead of the probed one, for
+        runs started through a wrapper such as a container entrypoint.
+        """
+    @property
+    def _args_override(self) -> global___ListStringValue:
+        """The arguments to record for the run instead of the probed ones."""
+    @property
+    def _code_path_override(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """The program's path relative to the root directory, recorded as the
+        run's code path instead of the probed one.
+        """
+    @property
+    def _root_dir_override(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """The root directory to record for the run instead of the probed one."""
+    @property
+    def _run_url_notify_url(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """An endpoint to POST the run's URL to as JSON once the run is created
+        on the server, like the run-url.json file in the run's directory.
+        """
+    @property
+    def _dry_run(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Whether to record the requests meant for the backend in the run's
+        directory instead of sending them.
+        """
+    @property
+    def _max_tracked_history_keys(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most distinct history keys to track for the summary and sampled
+        history, or 0 for no limit. Values of keys beyond it are only sent.
+        """
+    @property
+    def _max_reported_files(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most files to list in the run's exit results, or 0 for no limit.
+        Failed uploads are listed first.
+        """
+    @property
+    def _low_latency(self) -> google.protobuf.wrappers_pb2.BoolValue:
+        """Whether the writer hands records to the sender before saving them, so
+        that slow disk writes don't delay what's shown in the UI.
+        """
+    @property
+    def _shared_internal_log(self) -> google.protobuf.wrappers_pb2.StringValue:
+        """A log file shared by all of the service's streams, rotated by size, to
+        write their internal logs to instead of each run's own file. Each line
+        has the stream's ID.
+        """
+    @property
+    def _max_sampled_history_keys(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """Bounds on the handler's internal state, each 0 for no limit.
+
+        The most history metrics to keep samples of, 10k by default. The
+        samples of the least recently logged metrics are dropped first.
+        """
+    @property
+    def _max_summary_keys(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most top-level summary keys, 100k by default. Updates of keys
+        beyond it are dropped.
+        """
+    @property
+    def _max_partial_history_keys(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most top-level keys in a history row built from partial history,
+        100k by default. Values of keys beyond it are dropped.
+        """
+    @property
+    def _max_metric_definitions(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """The most metrics to define, 10k by default. Definitions beyond it are
+        dropped.
+        """
+    @property
+    def _exit_grace_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How long after a run's exit to keep accepting its data, such as metrics
+        logged from atexit hooks, 10 seconds by default. Data logged later, or
+        once the run's final summary is flushed, is rejected.
+        """
+    @property
+    def _backpressure_slow_depth(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """How many records and updates the sender may have yet to send before
+        the handler slows down taking in history and console output, 32 by
+        default, or 0 to never slow down.
+        """
+    @property
+    def _backpressure_congested_depth(self) -> google.protobuf.wrappers_pb2.Int32Value:
+        """How many records and updates the sender may have yet to send before
+        the stream rejects history and console output so that the client is
+        throttled, 64 by default, or 0 to never reject them.
+        """
+    @property
+    def _run_labels(self) -> global___MapStringKeyStringValue:
+        """Labels that the infrastructure stamps on the run, such as its team or
+        cluster, added to its config under "_wandb.labels" and to its tags as
+        "key:value". WANDB_NEXUS_RUN_LABELS overrides them with "key=value"
+        pairs separated by commas.
+        """
+    @property
+    def _run_label_keys(self) -> global___ListStringValue:
+        """The keys of the labels that may be stamped on the run; other labels
+        are dropped. WANDB_NEXUS_RUN_LABEL_KEYS overrides them with keys
+        separated by commas.
+        """
+    @property
+    def _stream_state_interval_seconds(self) -> google.protobuf.wrappers_pb2.DoubleValue:
+        """How often the sender saves how much of the run reached the server, so
+        that a restarted wandb-core continues the run instead of resending it.
+        """
     def __init__(
         self,
         *,
@@ -674,6 +1012,8 @@ class Settings(google.protobuf.message.Message):
         log_internal: google.protobuf.wrappers_pb2.StringValue | None = ...,
         files_dir: google.protobuf.wrappers_pb2.StringValue | None = ...,
         ignore_globs: global___ListStringValue | None = ...,
+        config_include_keys: global___ListStringValue | None = ...,
+        config_exclude_keys: global___ListStringValue | None = ...,
         _disable_update_check: google.protobuf.wrappers_pb2.BoolValue | None = ...,
         _require_core: google.protobuf.wrappers_pb2.BoolValue | None = ...,
         _args: global___ListStringValue | None = ...,
@@ -825,9 +1165,70 @@ class Settings(google.protobuf.message.Message):
         _stats_buffer_size: google.protobuf.wrappers_pb2.Int32Value | None = ...,
         _shared: google.protobuf.wrappers_pb2.BoolValue | None = ...,
         _code_path_local: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _label: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _primary: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _disable_generated_run_names: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _store_redacted_settings: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _log_format: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _diagnostics_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _fault_injection: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _http_idle_conn_timeout_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _http_max_idle_conns_per_host: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _http_max_conns_per_host: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _http_disable_http2: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _file_stream_transmit_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _file_stream_min_transmit_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _file_stream_max_transmit_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _file_stream_max_request_bytes: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _network_offline_after_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _network_probe_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _retry_budget_per_minute: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _summary_debounce_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _exit_timeout_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _cleanup_max_age_days: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _cleanup_max_total_bytes: google.protobuf.wrappers_pb2.Int64Value | None = ...,
+        _cleanup_dry_run: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _verify_on_exit: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _verify_timeout_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _upload_bytes_per_second: google.protobuf.wrappers_pb2.Int64Value | None = ...,
+        _file_stream_bytes_per_second: google.protobuf.wrappers_pb2.Int64Value | None = ...,
+        _record_sink_url: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _record_sink_types: global___ListStringValue | None = ...,
+        _console_dedup_threshold: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _console_timestamps: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _disable_clock_skew_correction: google.protobuf.wrappers_pb2.BoolValue | None = ...,
         _proxies: global___MapStringKeyStringValue | None = ...,
+        _max_history_rows_per_second: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _core_rss_warning_mb: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _console_capture: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _upload_url_batch_size: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _artifact_full_rehash: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _export_dir: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _export_hard_link: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _mirror_base_url: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _mirror_api_key: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _program_override: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _args_override: global___ListStringValue | None = ...,
+        _code_path_override: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _root_dir_override: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _run_url_notify_url: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _dry_run: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _max_tracked_history_keys: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _max_reported_files: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _low_latency: google.protobuf.wrappers_pb2.BoolValue | None = ...,
+        _shared_internal_log: google.protobuf.wrappers_pb2.StringValue | None = ...,
+        _max_sampled_history_keys: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _max_summary_keys: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _max_partial_history_keys: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _max_metric_definitions: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _exit_grace_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
+        _backpressure_slow_depth: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _backpressure_congested_depth: google.protobuf.wrappers_pb2.Int32Value | None = ...,
+        _run_labels: global___MapStringKeyStringValue | None = ...,
+        _run_label_keys: global___ListStringValue | None = ...,
+        _stream_state_interval_seconds: google.protobuf.wrappers_pb2.DoubleValue | None = ...,
     ) -> None: ...
-    def HasField(self, field_name: typing_extensions.Literal["_args", b"_args", "_aws_lambda", b"_aws_lambda", "_cli_only_mode", b"_cli_only_mode", "_code_path_local", b"_code_path_local", "_colab", b"_colab", "_cuda", b"_cuda", "_disable_machine_info", b"_disable_machine_info", "_disable_meta", b"_disable_meta", "_disable_service", b"_disable_service", "_disable_setproctitle", b"_disable_setproctitle", "_disable_stats", b"_disable_stats", "_disable_update_check", b"_disable_update_check", "_disable_viewer", b"_disable_viewer", "_executable", b"_executable", "_extra_http_headers", b"_extra_http_headers", "_file_stream_retry_max", b"_file_stream_retry_max", "_file_stream_retry_wait_max_seconds", b"_file_stream_retry_wait_max_seconds", "_file_stream_retry_wait_min_seconds", b"_file_stream_retry_wait_min_seconds", "_file_stream_timeout_seconds", b"_file_stream_timeout_seconds", "_file_transfer_retry_max", b"_file_transfer_retry_max", "_file_transfer_retry_wait_max_seconds", b"_file_transfer_retry_wait_max_seconds", "_file_transfer_retry_wait_min_seconds", b"_file_transfer_retry_wait_min_seconds", "_file_transfer_timeout_seconds", b"_file_transfer_timeout_seconds", "_flow_control_custom", b"_flow_control_custom", "_flow_control_disabled", b"_flow_control_disabled", "_graphql_retry_max", b"_graphql_retry_max", "_graphql_retry_wait_max_seconds", b"_graphql_retry_wait_max_seconds", "_graphql_retry_wait_min_seconds", b"_graphql_retry_wait_min_seconds", "_graphql_timeout_seconds", b"_graphql_timeout_seconds", "_internal_check_process", b"_internal_check_process", "_internal_queue_timeout", b"_internal_queue_timeout", "_ipython", b"_ipython", "_jupyter", b"_jupyter", "_jupyter_name", b"_jupyter_name", "_jupyter_path", b"_jupyter_path", "_jupyter_root", b"_jupyter_root", "_kaggle", b"_kaggle", "_live_policy_rate_limit", b"_live_policy_rate_limit", "_live_policy_wait_time", b"_live_policy_wait_time", "_log_level", b"_log_level", "_network_buffer", b"_network_buffer", "_noop", b"_noop", "_notebook", b"_notebook", "_offline", b"_offline", "_os", b"_os", "_platform", b"_platform", "_proxies", b"_proxies", "_python", b"_python", "_require_core", b"_require_core", "_runqueue_item_id", b"_runqueue_item_id", "_save_requirements", b"_save_requirements", "_service_transport", b"_service_transport", "_service_wait", b"_service_wait", "_shared", b"_shared", "_start_datetime", b"_start_datetime", "_start_time", b"_start_time", "_stats_buffer_size", b"_stats_buffer_size", "_stats_disk_paths", b"_stats_disk_paths", "_stats_join_assets", b"_stats_join_assets", "_stats_neuron_monitor_config_path", b"_stats_neuron_monitor_config_path", "_stats_open_metrics_endpoints", b"_stats_open_metrics_endpoints", "_stats_open_metrics_filters", b"_stats_open_metrics_filters", "_stats_pid", b"_stats_pid", "_stats_sample_rate_seconds", b"_stats_sample_rate_seconds", "_stats_samples_to_average", b"_stats_samples_to_average", "_sync", b"_sync", "_tmp_code_dir", b"_tmp_code_dir", "_tracelog", b"_tracelog", "_unsaved_keys", b"_unsaved_keys", "_windows", b"_windows", "allow_val_change", b"allow_val_change", "anonymous", b"anonymous", "api_key", b"api_key", "azure_account_url_to_access_key", b"azure_account_url_to_access_key", "base_url", b"base_url", "code_dir", b"code_dir", "colab_url", b"colab_url", "config_paths", b"config_paths", "console", b"console", "deployment", b"deployment", "disable_code", b"disable_code", "disable_git", b"disable_git", "disable_hints", b"disable_hints", "disable_job_creation", b"disable_job_creation", "disabled", b"disabled", "docker", b"docker", "email", b"email", "entity", b"entity", "files_dir", b"files_dir", "force", b"force", "fork_from", b"fork_from", "git_commit", b"git_commit", "git_remote", b"git_remote", "git_remote_url", b"git_remote_url", "git_root", b"git_root", "heartbeat_seconds", b"heartbeat_seconds", "host", b"host", "ignore_globs", b"ignore_globs", "init_timeout", b"init_timeout", "is_local", b"is_local", "job_name", b"job_name", "job_source", b"job_source", "label_disable", b"label_disable", "launch", b"launch", "launch_config_path", b"launch_config_path", "log_dir", b"log_dir", "log_internal", b"log_internal", "log_symlink_internal", b"log_symlink_internal", "log_symlink_user", b"log_symlink_user", "log_user", b"log_user", "login_timeout", b"login_timeout", "mode", b"mode", "notebook_name", b"notebook_name", "program", b"program", "program_abspath", b"program_abspath", "program_relpath", b"program_relpath", "project", b"project", "project_url", b"project_url", "quiet", b"quiet", "reinit", b"reinit", "relogin", b"relogin", "resume", b"resume", "resume_fname", b"resume_fname", "resumed", b"resumed", "root_dir", b"root_dir", "run_group", b"run_group", "run_id", b"run_id", "run_job_type", b"run_job_type", "run_mode", b"run_mode", "run_name", b"run_name", "run_notes", b"run_notes", "run_tags", b"run_tags", "run_url", b"run_url", "sagemaker_disable", b"sagemaker_disable", "save_code", b"save_code", "settings_system", b"settings_system", "settings_workspace", b"settings_workspace", "show_colors", b"show_colors", "show_emoji", b"show_emoji", "show_errors", b"show_errors", "show_info", b"show_info", "show_warnings", b"show_warnings", "silent", b"silent", "start_method", b"start_method", "strict", b"strict", "summary_errors", b"summary_errors", "summary_timeout", b"summary_timeout", "summary_warnings", b"summary_warnings", "sweep_id", b"sweep_id", "sweep_param_path", b"sweep_param_path", "sweep_url", b"sweep_url", "symlink", b"symlink", "sync_dir", b"sync_dir", "sync_file", b"sync_file", "sync_symlink_latest", b"sync_symlink_latest", "system_sample", b"system_sample", "system_sample_seconds", b"system_sample_seconds", "table_raise_on_max_row_limit_exceeded", b"table_raise_on_max_row_limit_exceeded", "timespec", b"timespec", "tmp_dir", b"tmp_dir", "username", b"username", "wandb_dir", b"wandb_dir"]) -> builtins.bool: ...
-    def ClearField(self, field_name: typing_extensions.Literal["_args", b"_args", "_aws_lambda", b"_aws_lambda", "_cli_only_mode", b"_cli_only_mode", "_code_path_local", b"_code_path_local", "_colab", b"_colab", "_cuda", b"_cuda", "_disable_machine_info", b"_disable_machine_info", "_disable_meta", b"_disable_meta", "_disable_service", b"_disable_service", "_disable_setproctitle", b"_disable_setproctitle", "_disable_stats", b"_disable_stats", "_disable_update_check", b"_disable_update_check", "_disable_viewer", b"_disable_viewer", "_executable", b"_executable", "_extra_http_headers", b"_extra_http_headers", "_file_stream_retry_max", b"_file_stream_retry_max", "_file_stream_retry_wait_max_seconds", b"_file_stream_retry_wait_max_seconds", "_file_stream_retry_wait_min_seconds", b"_file_stream_retry_wait_min_seconds", "_file_stream_timeout_seconds", b"_file_stream_timeout_seconds", "_file_transfer_retry_max", b"_file_transfer_retry_max", "_file_transfer_retry_wait_max_seconds", b"_file_transfer_retry_wait_max_seconds", "_file_transfer_retry_wait_min_seconds", b"_file_transfer_retry_wait_min_seconds", "_file_transfer_timeout_seconds", b"_file_transfer_timeout_seconds", "_flow_control_custom", b"_flow_control_custom", "_flow_control_disabled", b"_flow_control_disabled", "_graphql_retry_max", b"_graphql_retry_max", "_graphql_retry_wait_max_seconds", b"_graphql_retry_wait_max_seconds", "_graphql_retry_wait_min_seconds", b"_graphql_retry_wait_min_seconds", "_graphql_timeout_seconds", b"_graphql_timeout_seconds", "_internal_check_process", b"_internal_check_process", "_internal_queue_timeout", b"_internal_queue_timeout", "_ipython", b"_ipython", "_jupyter", b"_jupyter", "_jupyter_name", b"_jupyter_name", "_jupyter_path", b"_jupyter_path", "_jupyter_root", b"_jupyter_root", "_kaggle", b"_kaggle", "_live_policy_rate_limit", b"_live_policy_rate_limit", "_live_policy_wait_time", b"_live_policy_wait_time", "_log_level", b"_log_level", "_network_buffer", b"_network_buffer", "_noop", b"_noop", "_notebook", b"_notebook", "_offline", b"_offline", "_os", b"_os", "_platform", b"_platform", "_proxies", b"_proxies", "_python", b"_python", "_require_core", b"_require_core", "_runqueue_item_id", b"_runqueue_item_id", "_save_requirements", b"_save_requirements", "_service_transport", b"_service_transport", "_service_wait", b"_service_wait", "_shared", b"_shared", "_start_datetime", b"_start_datetime", "_start_time", b"_start_time", "_stats_buffer_size", b"_stats_buffer_size", "_stats_disk_paths", b"_stats_disk_paths", "_stats_join_assets", b"_stats_join_assets", "_stats_neuron_monitor_config_path", b"_stats_neuron_monitor_config_path", "_stats_open_metrics_endpoints", b"_stats_open_metrics_endpoints", "_stats_open_metrics_filters", b"_stats_open_metrics_filters", "_stats_pid", b"_stats_pid", "_stats_sample_rate_seconds", b"_stats_sample_rate_seconds", "_stats_samples_to_average", b"_stats_samples_to_average", "_sync", b"_sync", "_tmp_code_dir", b"_tmp_code_dir", "_tracelog", b"_tracelog", "_unsaved_keys", b"_unsaved_keys", "_windows", b"_windows", "allow_val_change", b"allow_val_change", "anonymous", b"anonymous", "api_key", b"api_key", "azure_account_url_to_access_key", b"azure_account_url_to_access_key", "base_url", b"base_url", "code_dir", b"code_dir", "colab_url", b"colab_url", "config_paths", b"config_paths", "console", b"console", "deployment", b"deployment", "disable_code", b"disable_code", "disable_git", b"disable_git", "disable_hints", b"disable_hints", "disable_job_creation", b"disable_job_creation", "disabled", b"disabled", "docker", b"docker", "email", b"email", "entity", b"entity", "files_dir", b"files_dir", "force", b"force", "fork_from", b"fork_from", "git_commit", b"git_commit", "git_remote", b"git_remote", "git_remote_url", b"git_remote_url", "git_root", b"git_root", "heartbeat_seconds", b"heartbeat_seconds", "host", b"host", "ignore_globs", b"ignore_globs", "init_timeout", b"init_timeout", "is_local", b"is_local", "job_name", b"job_name", "job_source", b"job_source", "label_disable", b"label_disable", "launch", b"launch", "launch_config_path", b"launch_config_path", "log_dir", b"log_dir", "log_internal", b"log_internal", "log_symlink_internal", b"log_symlink_internal", "log_symlink_user", b"log_symlink_user", "log_user", b"log_user", "login_timeout", b"login_timeout", "mode", b"mode", "notebook_name", b"notebook_name", "program", b"program", "program_abspath", b"program_abspath", "program_relpath", b"program_relpath", "project", b"project", "project_url", b"project_url", "quiet", b"quiet", "reinit", b"reinit", "relogin", b"relogin", "resume", b"resume", "resume_fname", b"resume_fname", "resumed", b"resumed", "root_dir", b"root_dir", "run_group", b"run_group", "run_id", b"run_id", "run_job_type", b"run_job_type", "run_mode", b"run_mode", "run_name", b"run_name", "run_notes", b"run_notes", "run_tags", b"run_tags", "run_url", b"run_url", "sagemaker_disable", b"sagemaker_disable", "save_code", b"save_code", "settings_system", b"settings_system", "settings_workspace", b"settings_workspace", "show_colors", b"show_colors", "show_emoji", b"show_emoji", "show_errors", b"show_errors", "show_info", b"show_info", "show_warnings", b"show_warnings", "silent", b"silent", "start_method", b"start_method", "strict", b"strict", "summary_errors", b"summary_errors", "summary_timeout", b"summary_timeout", "summary_warnings", b"summary_warnings", "sweep_id", b"sweep_id", "sweep_param_path", b"sweep_param_path", "sweep_url", b"sweep_url", "symlink", b"symlink", "sync_dir", b"sync_dir", "sync_file", b"sync_file", "sync_symlink_latest", b"sync_symlink_latest", "system_sample", b"system_sample", "system_sample_seconds", b"system_sample_seconds", "table_raise_on_max_row_limit_exceeded", b"table_raise_on_max_row_limit_exceeded", "timespec", b"timespec", "tmp_dir", b"tmp_dir", "username", b"username", "wandb_dir", b"wandb_dir"]) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["_args", b"_args", "_args_override", b"_args_override", "_artifact_full_rehash", b"_artifact_full_rehash", "_aws_lambda", b"_aws_lambda", "_backpressure_congested_depth", b"_backpressure_congested_depth", "_backpressure_slow_depth", b"_backpressure_slow_depth", "_cleanup_dry_run", b"_cleanup_dry_run", "_cleanup_max_age_days", b"_cleanup_max_age_days", "_cleanup_max_total_bytes", b"_cleanup_max_total_bytes", "_cli_only_mode", b"_cli_only_mode", "_code_path_local", b"_code_path_local", "_code_path_override", b"_code_path_override", "_colab", b"_colab", "_console_capture", b"_console_capture", "_console_dedup_threshold", b"_console_dedup_threshold", "_console_timestamps", b"_console_timestamps", "_core_rss_warning_mb", b"_core_rss_warning_mb", "_cuda", b"_cuda", "_diagnostics_interval_seconds", b"_diagnostics_interval_seconds", "_disable_clock_skew_correction", b"_disable_clock_skew_correction", "_disable_generated_run_names", b"_disable_generated_run_names", "_disable_machine_info", b"_disable_machine_info", "_disable_meta", b"_disable_meta", "_disable_service", b"_disable_service", "_disable_setproctitle", b"_disable_setproctitle", "_disable_stats", b"_disable_stats", "_disable_update_check", b"_disable_update_check", "_disable_viewer", b"_disable_viewer", "_dry_run", b"_dry_run", "_executable", b"_executable", "_exit_grace_seconds", b"_exit_grace_seconds", "_exit_timeout_seconds", b"_exit_timeout_seconds", "_export_dir", b"_export_dir", "_export_hard_link", b"_export_hard_link", "_extra_http_headers", b"_extra_http_headers", "_fault_injection", b"_fault_injection", "_file_stream_bytes_per_second", b"_file_stream_bytes_per_second", "_file_stream_max_request_bytes", b"_file_stream_max_request_bytes", "_file_stream_max_transmit_interval_seconds", b"_file_stream_max_transmit_interval_seconds", "_file_stream_min_transmit_interval_seconds", b"_file_stream_min_transmit_interval_seconds", "_file_stream_retry_max", b"_file_stream_retry_max", "_file_stream_retry_wait_max_seconds", b"_file_stream_retry_wait_max_seconds", "_file_stream_retry_wait_min_seconds", b"_file_stream_retry_wait_min_seconds", "_file_stream_timeout_seconds", b"_file_stream_timeout_seconds", "_file_stream_transmit_interval_seconds", b"_file_stream_transmit_interval_seconds", "_file_transfer_retry_max", b"_file_transfer_retry_max", "_file_transfer_retry_wait_max_seconds", b"_file_transfer_retry_wait_max_seconds", "_file_transfer_retry_wait_min_seconds", b"_file_transfer_retry_wait_min_seconds", "_file_transfer_timeout_seconds", b"_file_transfer_timeout_seconds", "_flow_control_custom", b"_flow_control_custom", "_flow_control_disabled", b"_flow_control_disabled", "_graphql_retry_max", b"_graphql_retry_max", "_graphql_retry_wait_max_seconds", b"_graphql_retry_wait_max_seconds", "_graphql_retry_wait_min_seconds", b"_graphql_retry_wait_min_seconds", "_graphql_timeout_seconds", b"_graphql_timeout_seconds", "_http_disable_http2", b"_http_disable_http2", "_http_idle_conn_timeout_seconds", b"_http_idle_conn_timeout_seconds", "_http_max_conns_per_host", b"_http_max_conns_per_host", "_http_max_idle_conns_per_host", b"_http_max_idle_conns_per_host", "_internal_check_process", b"_internal_check_process", "_internal_queue_timeout", b"_internal_queue_timeout", "_ipython", b"_ipython", "_jupyter", b"_jupyter", "_jupyter_name", b"_jupyter_name", "_jupyter_path", b"_jupyter_path", "_jupyter_root", b"_jupyter_root", "_kaggle", b"_kaggle", "_label", b"_label", "_live_policy_rate_limit", b"_live_policy_rate_limit", "_live_policy_wait_time", b"_live_policy_wait_time", "_log_format", b"_log_format", "_log_level", b"_log_level", "_low_latency", b"_low_latency", "_max_history_rows_per_second", b"_max_history_rows_per_second", "_max_metric_definitions", b"_max_metric_definitions", "_max_partial_history_keys", b"_max_partial_history_keys", "_max_reported_files", b"_max_reported_files", "_max_sampled_history_keys", b"_max_sampled_history_keys", "_max_summary_keys", b"_max_summary_keys", "_max_tracked_history_keys", b"_max_tracked_history_keys", "_mirror_api_key", b"_mirror_api_key", "_mirror_base_url", b"_mirror_base_url", "_network_buffer", b"_network_buffer", "_network_offline_after_seconds", b"_network_offline_after_seconds", "_network_probe_interval_seconds", b"_network_probe_interval_seconds", "_noop", b"_noop", "_notebook", b"_notebook", "_offline", b"_offline", "_os", b"_os", "_platform", b"_platform", "_primary", b"_primary", "_program_override", b"_program_override", "_proxies", b"_proxies", "_python", b"_python", "_record_sink_types", b"_record_sink_types", "_record_sink_url", b"_record_sink_url", "_require_core", b"_require_core", "_retry_budget_per_minute", b"_retry_budget_per_minute", "_root_dir_override", b"_root_dir_override", "_run_label_keys", b"_run_label_keys", "_run_labels", b"_run_labels", "_run_url_notify_url", b"_run_url_notify_url", "_runqueue_item_id", b"_runqueue_item_id", "_save_requirements", b"_save_requirements", "_service_transport", b"_service_transport", "_service_wait", b"_service_wait", "_shared", b"_shared", "_shared_internal_log", b"_shared_internal_log", "_start_datetime", b"_start_datetime", "_start_time", b"_start_time", "_stats_buffer_size", b"_stats_buffer_size", "_stats_disk_paths", b"_stats_disk_paths", "_stats_join_assets", b"_stats_join_assets", "_stats_neuron_monitor_config_path", b"_stats_neuron_monitor_config_path", "_stats_open_metrics_endpoints", b"_stats_open_metrics_endpoints", "_stats_open_metrics_filters", b"_stats_open_metrics_filters", "_stats_pid", b"_stats_pid", "_stats_sample_rate_seconds", b"_stats_sample_rate_seconds", "_stats_samples_to_average", b"_stats_samples_to_average", "_store_redacted_settings", b"_store_redacted_settings", "_stream_state_interval_seconds", b"_stream_state_interval_seconds", "_summary_debounce_interval_seconds", b"_summary_debounce_interval_seconds", "_sync", b"_sync", "_tmp_code_dir", b"_tmp_code_dir", "_tracelog", b"_tracelog", "_unsaved_keys", b"_unsaved_keys", "_upload_bytes_per_second", b"_upload_bytes_per_second", "_upload_url_batch_size", b"_upload_url_batch_size", "_verify_on_exit", b"_verify_on_exit", "_verify_timeout_seconds", b"_verify_timeout_seconds", "_windows", b"_windows", "allow_val_change", b"allow_val_change", "anonymous", b"anonymous", "api_key", b"api_key", "azure_account_url_to_access_key", b"azure_account_url_to_access_key", "base_url", b"base_url", "code_dir", b"code_dir", "colab_url", b"colab_url", "config_exclude_keys", b"config_exclude_keys", "config_include_keys", b"config_include_keys", "config_paths", b"config_paths", "console", b"console", "deployment", b"deployment", "disable_code", b"disable_code", "disable_git", b"disable_git", "disable_hints", b"disable_hints", "disable_job_creation", b"disable_job_creation", "disabled", b"disabled", "docker", b"docker", "email", b"email", "entity", b"entity", "files_dir", b"files_dir", "force", b"force", "fork_from", b"fork_from", "git_commit", b"git_commit", "git_remote", b"git_remote", "git_remote_url", b"git_remote_url", "git_root", b"git_root", "heartbeat_seconds", b"heartbeat_seconds", "host", b"host", "ignore_globs", b"ignore_globs", "init_timeout", b"init_timeout", "is_local", b"is_local", "job_name", b"job_name", "job_source", b"job_source", "label_disable", b"label_disable", "launch", b"launch", "launch_config_path", b"launch_config_path", "log_dir", b"log_dir", "log_internal", b"log_internal", "log_symlink_internal", b"log_symlink_internal", "log_symlink_user", b"log_symlink_user", "log_user", b"log_user", "login_timeout", b"login_timeout", "mode", b"mode", "notebook_name", b"notebook_name", "program", b"program", "program_abspath", b"program_abspath", "program_relpath", b"program_relpath", "project", b"project", "project_url", b"project_url", "quiet", b"quiet", "reinit", b"reinit", "relogin", b"relogin", "resume", b"resume", "resume_fname", b"resume_fname", "resumed", b"resumed", "root_dir", b"root_dir", "run_group", b"run_group", "run_id", b"run_id", "run_job_type", b"run_job_type", "run_mode", b"run_mode", "run_name", b"run_name", "run_notes", b"run_notes", "run_tags", b"run_tags", "run_url", b"run_url", "sagemaker_disable", b"sagemaker_disable", "save_code", b"save_code", "settings_system", b"settings_system", "settings_workspace", b"settings_workspace", "show_colors", b"show_colors", "show_emoji", b"show_emoji", "show_errors", b"show_errors", "show_info", b"show_info", "show_warnings", b"show_warnings", "silent", b"silent", "start_method", b"start_method", "strict", b"strict", "summary_errors", b"summary_errors", "summary_timeout", b"summary_timeout", "summary_warnings", b"summary_warnings", "sweep_id", b"sweep_id", "sweep_param_path", b"sweep_param_path", "sweep_url", b"sweep_url", "symlink", b"symlink", "sync_dir", b"sync_dir", "sync_file", b"sync_file", "sync_symlink_latest", b"sync_symlink_latest", "system_sample", b"system_sample", "system_sample_seconds", b"system_sample_seconds", "table_raise_on_max_row_limit_exceeded", b"table_raise_on_max_row_limit_exceeded", "timespec", b"timespec", "tmp_dir", b"tmp_dir", "username", b"username", "wandb_dir", b"wandb_dir"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["_args", b"_args", "_args_override", b"_args_override", "_artifact_full_rehash", b"_artifact_full_rehash", "_aws_lambda", b"_aws_lambda", "_backpressure_congested_depth", b"_backpressure_congested_depth", "_backpressure_slow_depth", b"_backpressure_slow_depth", "_cleanup_dry_run", b"_cleanup_dry_run", "_cleanup_max_age_days", b"_cleanup_max_age_days", "_cleanup_max_total_bytes", b"_cleanup_max_total_bytes", "_cli_only_mode", b"_cli_only_mode", "_code_path_local", b"_code_path_local", "_code_path_override", b"_code_path_override", "_colab", b"_colab", "_console_capture", b"_console_capture", "_console_dedup_threshold", b"_console_dedup_threshold", "_console_timestamps", b"_console_timestamps", "_core_rss_warning_mb", b"_core_rss_warning_mb", "_cuda", b"_cuda", "_diagnostics_interval_seconds", b"_diagnostics_interval_seconds", "_disable_clock_skew_correction", b"_disable_clock_skew_correction", "_disable_generated_run_names", b"_disable_generated_run_names", "_disable_machine_info", b"_disable_machine_info", "_disable_meta", b"_disable_meta", "_disable_service", b"_disable_service", "_disable_setproctitle", b"_disable_setproctitle", "_disable_stats", b"_disable_stats", "_disable_update_check", b"_disable_update_check", "_disable_viewer", b"_disable_viewer", "_dry_run", b"_dry_run", "_executable", b"_executable", "_exit_grace_seconds", b"_exit_grace_seconds", "_exit_timeout_seconds", b"_exit_timeout_seconds", "_export_dir", b"_export_dir", "_export_hard_link", b"_export_hard_link", "_extra_http_headers", b"_extra_http_headers", "_fault_injection", b"_fault_injection", "_file_stream_bytes_per_second", b"_file_stream_bytes_per_second", "_file_stream_max_request_bytes", b"_file_stream_max_request_bytes", "_file_stream_max_transmit_interval_seconds", b"_file_stream_max_transmit_interval_seconds", "_file_stream_min_transmit_interval_seconds", b"_file_stream_min_transmit_interval_seconds", "_file_stream_retry_max", b"_file_stream_retry_max", "_file_stream_retry_wait_max_seconds", b"_file_stream_retry_wait_max_seconds", "_file_stream_retry_wait_min_seconds", b"_file_stream_retry_wait_min_seconds", "_file_stream_timeout_seconds", b"_file_stream_timeout_seconds", "_file_stream_transmit_interval_seconds", b"_file_stream_transmit_interval_seconds", "_file_transfer_retry_max", b"_file_transfer_retry_max", "_file_transfer_retry_wait_max_seconds", b"_file_transfer_retry_wait_max_seconds", "_file_transfer_retry_wait_min_seconds", b"_file_transfer_retry_wait_min_seconds", "_file_transfer_timeout_seconds", b"_file_transfer_timeout_seconds", "_flow_control_custom", b"_flow_control_custom", "_flow_control_disabled", b"_flow_control_disabled", "_graphql_retry_max", b"_graphql_retry_max", "_graphql_retry_wait_max_seconds", b"_graphql_retry_wait_max_seconds", "_graphql_retry_wait_min_seconds", b"_graphql_retry_wait_min_seconds", "_graphql_timeout_seconds", b"_graphql_timeout_seconds", "_http_disable_http2", b"_http_disable_http2", "_http_idle_conn_timeout_seconds", b"_http_idle_conn_timeout_seconds", "_http_max_conns_per_host", b"_http_max_conns_per_host", "_http_max_idle_conns_per_host", b"_http_max_idle_conns_per_host", "_internal_check_process", b"_internal_check_process", "_internal_queue_timeout", b"_internal_queue_timeout", "_ipython", b"_ipython", "_jupyter", b"_jupyter", "_jupyter_name", b"_jupyter_name", "_jupyter_path", b"_jupyter_path", "_jupyter_root", b"_jupyter_root", "_kaggle", b"_kaggle", "_label", b"_label", "_live_policy_rate_limit", b"_live_policy_rate_limit", "_live_policy_wait_time", b"_live_policy_wait_time", "_log_format", b"_log_format", "_log_level", b"_log_level", "_low_latency", b"_low_latency", "_max_history_rows_per_second", b"_max_history_rows_per_second", "_max_metric_definitions", b"_max_metric_definitions", "_max_partial_history_keys", b"_max_partial_history_keys", "_max_reported_files", b"_max_reported_files", "_max_sampled_history_keys", b"_max_sampled_history_keys", "_max_summary_keys", b"_max_summary_keys", "_max_tracked_history_keys", b"_max_tracked_history_keys", "_mirror_api_key", b"_mirror_api_key", "_mirror_base_url", b"_mirror_base_url", "_network_buffer", b"_network_buffer", "_network_offline_after_seconds", b"_network_offline_after_seconds", "_network_probe_interval_seconds", b"_network_probe_interval_seconds", "_noop", b"_noop", "_notebook", b"_notebook", "_offline", b"_offline", "_os", b"_os", "_platform", b"_platform", "_primary", b"_primary", "_program_override", b"_program_override", "_proxies", b"_proxies", "_python", b"_python", "_record_sink_types", b"_record_sink_types", "_record_sink_url", b"_record_sink_url", "_require_core", b"_require_core", "_retry_budget_per_minute", b"_retry_budget_per_minute", "_root_dir_override", b"_root_dir_override", "_run_label_keys", b"_run_label_keys", "_run_labels", b"_run_labels", "_run_url_notify_url", b"_run_url_notify_url", "_runqueue_item_id", b"_runqueue_item_id", "_save_requirements", b"_save_requirements", "_service_transport", b"_service_transport", "_service_wait", b"_service_wait", "_shared", b"_shared", "_shared_internal_log", b"_shared_internal_log", "_start_datetime", b"_start_datetime", "_start_time", b"_start_time", "_stats_buffer_size", b"_stats_buffer_size", "_stats_disk_paths", b"_stats_disk_paths", "_stats_join_assets", b"_stats_join_assets", "_stats_neuron_monitor_config_path", b"_stats_neuron_monitor_config_path", "_stats_open_metrics_endpoints", b"_stats_open_metrics_endpoints", "_stats_open_metrics_filters", b"_stats_open_metrics_filters", "_stats_pid", b"_stats_pid", "_stats_sample_rate_seconds", b"_stats_sample_rate_seconds", "_stats_samples_to_average", b"_stats_samples_to_average", "_store_redacted_settings", b"_store_redacted_settings", "_stream_state_interval_seconds", b"_stream_state_interval_seconds", "_summary_debounce_interval_seconds", b"_summary_debounce_interval_seconds", "_sync", b"_sync", "_tmp_code_dir", b"_tmp_code_dir", "_tracelog", b"_tracelog", "_unsaved_keys", b"_unsaved_keys", "_upload_bytes_per_second", b"_upload_bytes_per_second", "_upload_url_batch_size", b"_upload_url_batch_size", "_verify_on_exit", b"_verify_on_exit", "_verify_timeout_seconds", b"_verify_timeout_seconds", "_windows", b"_windows", "allow_val_change", b"allow_val_change", "anonymous", b"anonymous", "api_key", b"api_key", "azure_account_url_to_access_key", b"azure_account_url_to_access_key", "base_url", b"base_url", "code_dir", b"code_dir", "colab_url", b"colab_url", "config_exclude_keys", b"config_exclude_keys", "config_include_keys", b"config_include_keys", "config_paths", b"config_paths", "console", b"console", "deployment", b"deployment", "disable_code", b"disable_code", "disable_git", b"disable_git", "disable_hints", b"disable_hints", "disable_job_creation", b"disable_job_creation", "disabled", b"disabled", "docker", b"docker", "email", b"email", "entity", b"entity", "files_dir", b"files_dir", "force", b"force", "fork_from", b"fork_from", "git_commit", b"git_commit", "git_remote", b"git_remote", "git_remote_url", b"git_remote_url", "git_root", b"git_root", "heartbeat_seconds", b"heartbeat_seconds", "host", b"host", "ignore_globs", b"ignore_globs", "init_timeout", b"init_timeout", "is_local", b"is_local", "job_name", b"job_name", "job_source", b"job_source", "label_disable", b"label_disable", "launch", b"launch", "launch_config_path", b"launch_config_path", "log_dir", b"log_dir", "log_internal", b"log_internal", "log_symlink_internal", b"log_symlink_internal", "log_symlink_user", b"log_symlink_user", "log_user", b"log_user", "login_timeout", b"login_timeout", "mode", b"mode", "notebook_name", b"notebook_name", "program", b"program", "program_abspath", b"program_abspath", "program_relpath", b"program_relpath", "project", b"project", "project_url", b"project_url", "quiet", b"quiet", "reinit", b"reinit", "relogin", b"relogin", "resume", b"resume", "resume_fname", b"resume_fname", "resumed", b"resumed", "root_dir", b"root_dir", "run_group", b"run_group", "run_id", b"run_id", "run_job_type", b"run_job_type", "run_mode", b"run_mode", "run_name", b"run_name", "run_notes", b"run_notes", "run_tags", b"run_tags", "run_url", b"run_url", "sagemaker_disable", b"sagemaker_disable", "save_code", b"save_code", "settings_system", b"settings_system", "settings_workspace", b"settings_workspace", "show_colors", b"show_colors", "show_emoji", b"show_emoji", "show_errors", b"show_errors", "show_info", b"show_info", "show_warnings", b"show_warnings", "silent", b"silent", "start_method", b"start_method", "strict", b"strict", "summary_errors", b"summary_errors", "summary_timeout", b"summary_timeout", "summary_warnings", b"summary_warnings", "sweep_id", b"sweep_id", "sweep_param_path", b"sweep_param_path", "sweep_url", b"sweep_url", "symlink", b"symlink", "sync_dir", b"sync_dir", "sync_file", b"sync_file", "sync_symlink_latest", b"sync_symlink_latest", "system_sample", b"system_sample", "system_sample_seconds", b"system_sample_seconds", "table_raise_on_max_row_limit_exceeded", b"table_raise_on_max_row_limit_exceeded", "timespec", b"timespec", "tmp_dir", b"tmp_dir", "username", b"username", "wandb_dir", b"wandb_dir"]) -> None: ...
 
 global___Settings = Settings
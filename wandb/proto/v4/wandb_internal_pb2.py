@@ -12,11 +12,12 @@ _sym_db = _symbol_database.Default()
 
 
 from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__pb2
+from google.protobuf import wrappers_pb2 as google_dot_protobuf_dot_wrappers__pb2
 from wandb.proto import wandb_base_pb2 as wandb_dot_proto_dot_wandb__base__pb2
 from wandb.proto import wandb_telemetry_pb2 as wandb_dot_proto_dot_wandb__telemetry__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n wandb/proto/wandb_internal.proto\x12\x0ewandb_internal\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cwandb/proto/wandb_base.proto\x1a!wandb/proto/wandb_telemetry.proto\"\x9c\t\n\x06Record\x12\x0b\n\x03num\x18\x01 \x01(\x03\x12\x30\n\x07history\x18\x02 \x01(\x0b\x32\x1d.wandb_internal.HistoryRecordH\x00\x12\x30\n\x07summary\x18\x03 \x01(\x0b\x32\x1d.wandb_internal.SummaryRecordH\x00\x12.\n\x06output\x18\x04 \x01(\x0b\x32\x1c.wandb_internal.OutputRecordH\x00\x12.\n\x06\x63onfig\x18\x05 \x01(\x0b\x32\x1c.wandb_internal.ConfigRecordH\x00\x12,\n\x05\x66iles\x18\x06 \x01(\x0b\x32\x1b.wandb_internal.FilesRecordH\x00\x12,\n\x05stats\x18\x07 \x01(\x0b\x32\x1b.wandb_internal.StatsRecordH\x00\x12\x32\n\x08\x61rtifact\x18\x08 \x01(\x0b\x32\x1e.wandb_internal.ArtifactRecordH\x00\x12,\n\x08tbrecord\x18\t \x01(\x0b\x32\x18.wandb_internal.TBRecordH\x00\x12,\n\x05\x61lert\x18\n \x01(\x0b\x32\x1b.wandb_internal.AlertRecordH\x00\x12\x34\n\ttelemetry\x18\x0b \x01(\x0b\x32\x1f.wandb_internal.TelemetryRecordH\x00\x12.\n\x06metric\x18\x0c \x01(\x0b\x32\x1c.wandb_internal.MetricRecordH\x00\x12\x35\n\noutput_raw\x18\r \x01(\x0b\x32\x1f.wandb_internal.OutputRawRecordH\x00\x12(\n\x03run\x18\x11 \x01(\x0b\x32\x19.wandb_internal.RunRecordH\x00\x12-\n\x04\x65xit\x18\x12 \x01(\x0b\x32\x1d.wandb_internal.RunExitRecordH\x00\x12,\n\x05\x66inal\x18\x14 \x01(\x0b\x32\x1b.wandb_internal.FinalRecordH\x00\x12.\n\x06header\x18\x15 \x01(\x0b\x32\x1c.wandb_internal.HeaderRecordH\x00\x12.\n\x06\x66ooter\x18\x16 \x01(\x0b\x32\x1c.wandb_internal.FooterRecordH\x00\x12\x39\n\npreempting\x18\x17 \x01(\x0b\x32#.wandb_internal.RunPreemptingRecordH\x00\x12;\n\rlink_artifact\x18\x18 \x01(\x0b\x32\".wandb_internal.LinkArtifactRecordH\x00\x12\x39\n\x0cuse_artifact\x18\x19 \x01(\x0b\x32!.wandb_internal.UseArtifactRecordH\x00\x12*\n\x07request\x18\x64 \x01(\x0b\x32\x17.wandb_internal.RequestH\x00\x12(\n\x07\x63ontrol\x18\x10 \x01(\x0b\x32\x17.wandb_internal.Control\x12\x0c\n\x04uuid\x18\x13 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfoB\r\n\x0brecord_type\"\xa8\x01\n\x07\x43ontrol\x12\x10\n\x08req_resp\x18\x01 \x01(\x08\x12\r\n\x05local\x18\x02 \x01(\x08\x12\x10\n\x08relay_id\x18\x03 \x01(\t\x12\x14\n\x0cmailbox_slot\x18\x04 \x01(\t\x12\x13\n\x0b\x61lways_send\x18\x05 \x01(\x08\x12\x14\n\x0c\x66low_control\x18\x06 \x01(\x08\x12\x12\n\nend_offset\x18\x07 \x01(\x03\x12\x15\n\rconnection_id\x18\x08 \x01(\t\"\xf3\x03\n\x06Result\x12\x35\n\nrun_result\x18\x11 \x01(\x0b\x32\x1f.wandb_internal.RunUpdateResultH\x00\x12\x34\n\x0b\x65xit_result\x18\x12 \x01(\x0b\x32\x1d.wandb_internal.RunExitResultH\x00\x12\x33\n\nlog_result\x18\x14 \x01(\x0b\x32\x1d.wandb_internal.HistoryResultH\x00\x12\x37\n\x0esummary_result\x18\x15 \x01(\x0b\x32\x1d.wandb_internal.SummaryResultH\x00\x12\x35\n\routput_result\x18\x16 \x01(\x0b\x32\x1c.wandb_internal.OutputResultH\x00\x12\x35\n\rconfig_result\x18\x17 \x01(\x0b\x32\x1c.wandb_internal.ConfigResultH\x00\x12,\n\x08response\x18\x64 \x01(\x0b\x32\x18.wandb_internal.ResponseH\x00\x12(\n\x07\x63ontrol\x18\x10 \x01(\x0b\x32\x17.wandb_internal.Control\x12\x0c\n\x04uuid\x18\x18 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._ResultInfoB\r\n\x0bresult_type\":\n\x0b\x46inalRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"b\n\x0bVersionInfo\x12\x10\n\x08producer\x18\x01 \x01(\t\x12\x14\n\x0cmin_consumer\x18\x02 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"n\n\x0cHeaderRecord\x12\x31\n\x0cversion_info\x18\x01 \x01(\x0b\x32\x1b.wandb_internal.VersionInfo\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\";\n\x0c\x46ooterRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xde\x04\n\tRunRecord\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0e\n\x06\x65ntity\x18\x02 \x01(\t\x12\x0f\n\x07project\x18\x03 \x01(\t\x12,\n\x06\x63onfig\x18\x04 \x01(\x0b\x32\x1c.wandb_internal.ConfigRecord\x12.\n\x07summary\x18\x05 \x01(\x0b\x32\x1d.wandb_internal.SummaryRecord\x12\x11\n\trun_group\x18\x06 \x01(\t\x12\x10\n\x08job_type\x18\x07 \x01(\t\x12\x14\n\x0c\x64isplay_name\x18\x08 \x01(\t\x12\r\n\x05notes\x18\t \x01(\t\x12\x0c\n\x04tags\x18\n \x03(\t\x12\x30\n\x08settings\x18\x0b \x01(\x0b\x32\x1e.wandb_internal.SettingsRecord\x12\x10\n\x08sweep_id\x18\x0c \x01(\t\x12\x0c\n\x04host\x18\r \x01(\t\x12\x15\n\rstarting_step\x18\x0e \x01(\x03\x12\x12\n\nstorage_id\x18\x10 \x01(\t\x12.\n\nstart_time\x18\x11 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0f\n\x07resumed\x18\x12 \x01(\x08\x12\x32\n\ttelemetry\x18\x13 \x01(\x0b\x32\x1f.wandb_internal.TelemetryRecord\x12\x0f\n\x07runtime\x18\x14 \x01(\x05\x12*\n\x03git\x18\x15 \x01(\x0b\x32\x1d.wandb_internal.GitRepoRecord\x12\x0e\n\x06\x66orked\x18\x16 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\";\n\rGitRepoRecord\x12\x1a\n\nremote_url\x18\x01 \x01(\tR\x06remote\x12\x0e\n\x06\x63ommit\x18\x02 \x01(\t\"c\n\x0fRunUpdateResult\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12(\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\"\xac\x01\n\tErrorInfo\x12\x0f\n\x07message\x18\x01 \x01(\t\x12\x31\n\x04\x63ode\x18\x02 \x01(\x0e\x32#.wandb_internal.ErrorInfo.ErrorCode\"[\n\tErrorCode\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x11\n\rCOMMUNICATION\x10\x01\x12\x12\n\x0e\x41UTHENTICATION\x10\x02\x12\t\n\x05USAGE\x10\x03\x12\x0f\n\x0bUNSUPPORTED\x10\x04\"`\n\rRunExitRecord\x12\x11\n\texit_code\x18\x01 \x01(\x05\x12\x0f\n\x07runtime\x18\x02 \x01(\x05\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x0f\n\rRunExitResult\"B\n\x13RunPreemptingRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x15\n\x13RunPreemptingResult\"i\n\x0eSettingsRecord\x12*\n\x04item\x18\x01 \x03(\x0b\x32\x1c.wandb_internal.SettingsItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"/\n\x0cSettingsItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x1a\n\x0bHistoryStep\x12\x0b\n\x03num\x18\x01 \x01(\x03\"\x92\x01\n\rHistoryRecord\x12)\n\x04item\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.HistoryItem\x12)\n\x04step\x18\x02 \x01(\x0b\x32\x1b.wandb_internal.HistoryStep\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"B\n\x0bHistoryItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x0f\n\rHistoryResult\"\xdc\x01\n\x0cOutputRecord\x12<\n\x0boutput_type\x18\x01 \x01(\x0e\x32\'.wandb_internal.OutputRecord.OutputType\x12-\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0c\n\x04line\x18\x03 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"$\n\nOutputType\x12\n\n\x06STDERR\x10\x00\x12\n\n\x06STDOUT\x10\x01\"\x0e\n\x0cOutputResult\"\xe2\x01\n\x0fOutputRawRecord\x12?\n\x0boutput_type\x18\x01 \x01(\x0e\x32*.wandb_internal.OutputRawRecord.OutputType\x12-\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0c\n\x04line\x18\x03 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"$\n\nOutputType\x12\n\n\x06STDERR\x10\x00\x12\n\n\x06STDOUT\x10\x01\"\x11\n\x0fOutputRawResult\"\x98\x03\n\x0cMetricRecord\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x11\n\tglob_name\x18\x02 \x01(\t\x12\x13\n\x0bstep_metric\x18\x04 \x01(\t\x12\x19\n\x11step_metric_index\x18\x05 \x01(\x05\x12.\n\x07options\x18\x06 \x01(\x0b\x32\x1d.wandb_internal.MetricOptions\x12.\n\x07summary\x18\x07 \x01(\x0b\x32\x1d.wandb_internal.MetricSummary\x12\x35\n\x04goal\x18\x08 \x01(\x0e\x32\'.wandb_internal.MetricRecord.MetricGoal\x12/\n\x08_control\x18\t \x01(\x0b\x32\x1d.wandb_internal.MetricControl\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"B\n\nMetricGoal\x12\x0e\n\nGOAL_UNSET\x10\x00\x12\x11\n\rGOAL_MINIMIZE\x10\x01\x12\x11\n\rGOAL_MAXIMIZE\x10\x02\"\x0e\n\x0cMetricResult\"C\n\rMetricOptions\x12\x11\n\tstep_sync\x18\x01 \x01(\x08\x12\x0e\n\x06hidden\x18\x02 \x01(\x08\x12\x0f\n\x07\x64\x65\x66ined\x18\x03 \x01(\x08\"\"\n\rMetricControl\x12\x11\n\toverwrite\x18\x01 \x01(\x08\"o\n\rMetricSummary\x12\x0b\n\x03min\x18\x01 \x01(\x08\x12\x0b\n\x03max\x18\x02 \x01(\x08\x12\x0c\n\x04mean\x18\x03 \x01(\x08\x12\x0c\n\x04\x62\x65st\x18\x04 \x01(\x08\x12\x0c\n\x04last\x18\x05 \x01(\x08\x12\x0c\n\x04none\x18\x06 \x01(\x08\x12\x0c\n\x04\x63opy\x18\x07 \x01(\x08\"\x93\x01\n\x0c\x43onfigRecord\x12*\n\x06update\x18\x01 \x03(\x0b\x32\x1a.wandb_internal.ConfigItem\x12*\n\x06remove\x18\x02 \x03(\x0b\x32\x1a.wandb_internal.ConfigItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"A\n\nConfigItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x0e\n\x0c\x43onfigResult\"\x96\x01\n\rSummaryRecord\x12+\n\x06update\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\x12+\n\x06remove\x18\x02 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"B\n\x0bSummaryItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x0f\n\rSummaryResult\"d\n\x0b\x46ilesRecord\x12(\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x19.wandb_internal.FilesItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xec\x01\n\tFilesItem\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x34\n\x06policy\x18\x02 \x01(\x0e\x32$.wandb_internal.FilesItem.PolicyType\x12\x30\n\x04type\x18\x03 \x01(\x0e\x32\".wandb_internal.FilesItem.FileType\"(\n\nPolicyType\x12\x07\n\x03NOW\x10\x00\x12\x07\n\x03\x45ND\x10\x01\x12\x08\n\x04LIVE\x10\x02\"9\n\x08\x46ileType\x12\t\n\x05OTHER\x10\x00\x12\t\n\x05WANDB\x10\x01\x12\t\n\x05MEDIA\x10\x02\x12\x0c\n\x08\x41RTIFACT\x10\x03J\x04\x08\x10\x10\x11\"\r\n\x0b\x46ilesResult\"\xe6\x01\n\x0bStatsRecord\x12\x39\n\nstats_type\x18\x01 \x01(\x0e\x32%.wandb_internal.StatsRecord.StatsType\x12-\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\'\n\x04item\x18\x03 \x03(\x0b\x32\x19.wandb_internal.StatsItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x17\n\tStatsType\x12\n\n\x06SYSTEM\x10\x00\",\n\tStatsItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\xd9\x03\n\x0e\x41rtifactRecord\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0f\n\x07project\x18\x02 \x01(\t\x12\x0e\n\x06\x65ntity\x18\x03 \x01(\t\x12\x0c\n\x04type\x18\x04 \x01(\t\x12\x0c\n\x04name\x18\x05 \x01(\t\x12\x0e\n\x06\x64igest\x18\x06 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x07 \x01(\t\x12\x10\n\x08metadata\x18\x08 \x01(\t\x12\x14\n\x0cuser_created\x18\t \x01(\x08\x12\x18\n\x10use_after_commit\x18\n \x01(\x08\x12\x0f\n\x07\x61liases\x18\x0b \x03(\t\x12\x32\n\x08manifest\x18\x0c \x01(\x0b\x32 .wandb_internal.ArtifactManifest\x12\x16\n\x0e\x64istributed_id\x18\r \x01(\t\x12\x10\n\x08\x66inalize\x18\x0e \x01(\x08\x12\x11\n\tclient_id\x18\x0f \x01(\t\x12\x1a\n\x12sequence_client_id\x18\x10 \x01(\t\x12\x0f\n\x07\x62\x61se_id\x18\x11 \x01(\t\x12\x1c\n\x14ttl_duration_seconds\x18\x12 \x01(\x03\x12\x19\n\x11incremental_beta1\x18\x64 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xbc\x01\n\x10\x41rtifactManifest\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x16\n\x0estorage_policy\x18\x02 \x01(\t\x12\x46\n\x15storage_policy_config\x18\x03 \x03(\x0b\x32\'.wandb_internal.StoragePolicyConfigItem\x12\x37\n\x08\x63ontents\x18\x04 \x03(\x0b\x32%.wandb_internal.ArtifactManifestEntry\"\xcf\x01\n\x15\x41rtifactManifestEntry\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0e\n\x06\x64igest\x18\x02 \x01(\t\x12\x0b\n\x03ref\x18\x03 \x01(\t\x12\x0c\n\x04size\x18\x04 \x01(\x03\x12\x10\n\x08mimetype\x18\x05 \x01(\t\x12\x12\n\nlocal_path\x18\x06 \x01(\t\x12\x19\n\x11\x62irth_artifact_id\x18\x07 \x01(\t\x12\x12\n\nskip_cache\x18\x08 \x01(\x08\x12(\n\x05\x65xtra\x18\x10 \x03(\x0b\x32\x19.wandb_internal.ExtraItem\",\n\tExtraItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x02 \x01(\t\":\n\x17StoragePolicyConfigItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x02 \x01(\t\"\x10\n\x0e\x41rtifactResult\"\x14\n\x12LinkArtifactResult\"\xcf\x01\n\x12LinkArtifactRecord\x12\x11\n\tclient_id\x18\x01 \x01(\t\x12\x11\n\tserver_id\x18\x02 \x01(\t\x12\x16\n\x0eportfolio_name\x18\x03 \x01(\t\x12\x18\n\x10portfolio_entity\x18\x04 \x01(\t\x12\x19\n\x11portfolio_project\x18\x05 \x01(\t\x12\x19\n\x11portfolio_aliases\x18\x06 \x03(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"h\n\x08TBRecord\x12\x0f\n\x07log_dir\x18\x01 \x01(\t\x12\x0c\n\x04save\x18\x02 \x01(\x08\x12\x10\n\x08root_dir\x18\x03 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\n\n\x08TBResult\"}\n\x0b\x41lertRecord\x12\r\n\x05title\x18\x01 \x01(\t\x12\x0c\n\x04text\x18\x02 \x01(\t\x12\r\n\x05level\x18\x03 \x01(\t\x12\x15\n\rwait_duration\x18\x04 \x01(\x03\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\r\n\x0b\x41lertResult\"\xbe\x0f\n\x07Request\x12\x38\n\x0bstop_status\x18\x01 \x01(\x0b\x32!.wandb_internal.StopStatusRequestH\x00\x12>\n\x0enetwork_status\x18\x02 \x01(\x0b\x32$.wandb_internal.NetworkStatusRequestH\x00\x12-\n\x05\x64\x65\x66\x65r\x18\x03 \x01(\x0b\x32\x1c.wandb_internal.DeferRequestH\x00\x12\x38\n\x0bget_summary\x18\x04 \x01(\x0b\x32!.wandb_internal.GetSummaryRequestH\x00\x12-\n\x05login\x18\x05 \x01(\x0b\x32\x1c.wandb_internal.LoginRequestH\x00\x12-\n\x05pause\x18\x06 \x01(\x0b\x32\x1c.wandb_internal.PauseRequestH\x00\x12/\n\x06resume\x18\x07 \x01(\x0b\x32\x1d.wandb_internal.ResumeRequestH\x00\x12\x34\n\tpoll_exit\x18\x08 \x01(\x0b\x32\x1f.wandb_internal.PollExitRequestH\x00\x12@\n\x0fsampled_history\x18\t \x01(\x0b\x32%.wandb_internal.SampledHistoryRequestH\x00\x12@\n\x0fpartial_history\x18\n \x01(\x0b\x32%.wandb_internal.PartialHistoryRequestH\x00\x12\x34\n\trun_start\x18\x0b \x01(\x0b\x32\x1f.wandb_internal.RunStartRequestH\x00\x12<\n\rcheck_version\x18\x0c \x01(\x0b\x32#.wandb_internal.CheckVersionRequestH\x00\x12:\n\x0clog_artifact\x18\r \x01(\x0b\x32\".wandb_internal.LogArtifactRequestH\x00\x12\x44\n\x11\x64ownload_artifact\x18\x0e \x01(\x0b\x32\'.wandb_internal.DownloadArtifactRequestH\x00\x12\x35\n\tkeepalive\x18\x11 \x01(\x0b\x32 .wandb_internal.KeepaliveRequestH\x00\x12\x36\n\nrun_status\x18\x14 \x01(\x0b\x32 .wandb_internal.RunStatusRequestH\x00\x12/\n\x06\x63\x61ncel\x18\x15 \x01(\x0b\x32\x1d.wandb_internal.CancelRequestH\x00\x12\x33\n\x08metadata\x18\x16 \x01(\x0b\x32\x1f.wandb_internal.MetadataRequestH\x00\x12\x44\n\x11internal_messages\x18\x17 \x01(\x0b\x32\'.wandb_internal.InternalMessagesRequestH\x00\x12@\n\x0fpython_packages\x18\x18 \x01(\x0b\x32%.wandb_internal.PythonPackagesRequestH\x00\x12\x33\n\x08shutdown\x18@ \x01(\x0b\x32\x1f.wandb_internal.ShutdownRequestH\x00\x12/\n\x06\x61ttach\x18\x41 \x01(\x0b\x32\x1d.wandb_internal.AttachRequestH\x00\x12/\n\x06status\x18\x42 \x01(\x0b\x32\x1d.wandb_internal.StatusRequestH\x00\x12\x38\n\x0bserver_info\x18\x43 \x01(\x0b\x32!.wandb_internal.ServerInfoRequestH\x00\x12\x38\n\x0bsender_mark\x18\x44 \x01(\x0b\x32!.wandb_internal.SenderMarkRequestH\x00\x12\x38\n\x0bsender_read\x18\x45 \x01(\x0b\x32!.wandb_internal.SenderReadRequestH\x00\x12<\n\rstatus_report\x18\x46 \x01(\x0b\x32#.wandb_internal.StatusReportRequestH\x00\x12>\n\x0esummary_record\x18G \x01(\x0b\x32$.wandb_internal.SummaryRecordRequestH\x00\x12\x42\n\x10telemetry_record\x18H \x01(\x0b\x32&.wandb_internal.TelemetryRecordRequestH\x00\x12\x32\n\x08job_info\x18I \x01(\x0b\x32\x1e.wandb_internal.JobInfoRequestH\x00\x12\x45\n\x12get_system_metrics\x18J \x01(\x0b\x32\'.wandb_internal.GetSystemMetricsRequestH\x00\x12+\n\x04sync\x18L \x01(\x0b\x32\x1b.wandb_internal.SyncRequestH\x00\x12\x34\n\tjob_input\x18M \x01(\x0b\x32\x1f.wandb_internal.JobInputRequestH\x00\x12\x39\n\x0btest_inject\x18\xe8\x07 \x01(\x0b\x32!.wandb_internal.TestInjectRequestH\x00\x42\x0e\n\x0crequest_typeJ\x04\x08K\x10L\"\xe2\x0b\n\x08Response\x12?\n\x12keepalive_response\x18\x12 \x01(\x0b\x32!.wandb_internal.KeepaliveResponseH\x00\x12\x42\n\x14stop_status_response\x18\x13 \x01(\x0b\x32\".wandb_internal.StopStatusResponseH\x00\x12H\n\x17network_status_response\x18\x14 \x01(\x0b\x32%.wandb_internal.NetworkStatusResponseH\x00\x12\x37\n\x0elogin_response\x18\x18 \x01(\x0b\x32\x1d.wandb_internal.LoginResponseH\x00\x12\x42\n\x14get_summary_response\x18\x19 \x01(\x0b\x32\".wandb_internal.GetSummaryResponseH\x00\x12>\n\x12poll_exit_response\x18\x1a \x01(\x0b\x32 .wandb_internal.PollExitResponseH\x00\x12J\n\x18sampled_history_response\x18\x1b \x01(\x0b\x32&.wandb_internal.SampledHistoryResponseH\x00\x12>\n\x12run_start_response\x18\x1c \x01(\x0b\x32 .wandb_internal.RunStartResponseH\x00\x12\x46\n\x16\x63heck_version_response\x18\x1d \x01(\x0b\x32$.wandb_internal.CheckVersionResponseH\x00\x12\x44\n\x15log_artifact_response\x18\x1e \x01(\x0b\x32#.wandb_internal.LogArtifactResponseH\x00\x12N\n\x1a\x64ownload_artifact_response\x18\x1f \x01(\x0b\x32(.wandb_internal.DownloadArtifactResponseH\x00\x12@\n\x13run_status_response\x18# \x01(\x0b\x32!.wandb_internal.RunStatusResponseH\x00\x12\x39\n\x0f\x63\x61ncel_response\x18$ \x01(\x0b\x32\x1e.wandb_internal.CancelResponseH\x00\x12N\n\x1ainternal_messages_response\x18% \x01(\x0b\x32(.wandb_internal.InternalMessagesResponseH\x00\x12=\n\x11shutdown_response\x18@ \x01(\x0b\x32 .wandb_internal.ShutdownResponseH\x00\x12\x39\n\x0f\x61ttach_response\x18\x41 \x01(\x0b\x32\x1e.wandb_internal.AttachResponseH\x00\x12\x39\n\x0fstatus_response\x18\x42 \x01(\x0b\x32\x1e.wandb_internal.StatusResponseH\x00\x12\x42\n\x14server_info_response\x18\x43 \x01(\x0b\x32\".wandb_internal.ServerInfoResponseH\x00\x12<\n\x11job_info_response\x18\x44 \x01(\x0b\x32\x1f.wandb_internal.JobInfoResponseH\x00\x12O\n\x1bget_system_metrics_response\x18\x45 \x01(\x0b\x32(.wandb_internal.GetSystemMetricsResponseH\x00\x12\x35\n\rsync_response\x18\x46 \x01(\x0b\x32\x1c.wandb_internal.SyncResponseH\x00\x12\x43\n\x14test_inject_response\x18\xe8\x07 \x01(\x0b\x32\".wandb_internal.TestInjectResponseH\x00\x42\x0f\n\rresponse_type\"\xc0\x02\n\x0c\x44\x65\x66\x65rRequest\x12\x36\n\x05state\x18\x01 \x01(\x0e\x32\'.wandb_internal.DeferRequest.DeferState\"\xf7\x01\n\nDeferState\x12\t\n\x05\x42\x45GIN\x10\x00\x12\r\n\tFLUSH_RUN\x10\x01\x12\x0f\n\x0b\x46LUSH_STATS\x10\x02\x12\x19\n\x15\x46LUSH_PARTIAL_HISTORY\x10\x03\x12\x0c\n\x08\x46LUSH_TB\x10\x04\x12\r\n\tFLUSH_SUM\x10\x05\x12\x13\n\x0f\x46LUSH_DEBOUNCER\x10\x06\x12\x10\n\x0c\x46LUSH_OUTPUT\x10\x07\x12\r\n\tFLUSH_JOB\x10\x08\x12\r\n\tFLUSH_DIR\x10\t\x12\x0c\n\x08\x46LUSH_FP\x10\n\x12\x0b\n\x07JOIN_FP\x10\x0b\x12\x0c\n\x08\x46LUSH_FS\x10\x0c\x12\x0f\n\x0b\x46LUSH_FINAL\x10\r\x12\x07\n\x03\x45ND\x10\x0e\"<\n\x0cPauseRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x0f\n\rPauseResponse\"=\n\rResumeRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x10\n\x0eResumeResponse\"M\n\x0cLoginRequest\x12\x0f\n\x07\x61pi_key\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"&\n\rLoginResponse\x12\x15\n\ractive_entity\x18\x01 \x01(\t\"A\n\x11GetSummaryRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"?\n\x12GetSummaryResponse\x12)\n\x04item\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\"G\n\x17GetSystemMetricsRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"R\n\x12SystemMetricSample\x12-\n\ttimestamp\x18\x01 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\r\n\x05value\x18\x02 \x01(\x02\"I\n\x13SystemMetricsBuffer\x12\x32\n\x06record\x18\x01 \x03(\x0b\x32\".wandb_internal.SystemMetricSample\"\xca\x01\n\x18GetSystemMetricsResponse\x12S\n\x0esystem_metrics\x18\x01 \x03(\x0b\x32;.wandb_internal.GetSystemMetricsResponse.SystemMetricsEntry\x1aY\n\x12SystemMetricsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x32\n\x05value\x18\x02 \x01(\x0b\x32#.wandb_internal.SystemMetricsBuffer:\x02\x38\x01\"=\n\rStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\")\n\x0eStatusResponse\x12\x17\n\x0frun_should_stop\x18\x01 \x01(\x08\"A\n\x11StopStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"-\n\x12StopStatusResponse\x12\x17\n\x0frun_should_stop\x18\x01 \x01(\x08\"D\n\x14NetworkStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"P\n\x15NetworkStatusResponse\x12\x37\n\x11network_responses\x18\x01 \x03(\x0b\x32\x1c.wandb_internal.HttpResponse\"D\n\x0cHttpResponse\x12\x18\n\x10http_status_code\x18\x01 \x01(\x05\x12\x1a\n\x12http_response_text\x18\x02 \x01(\t\"G\n\x17InternalMessagesRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"N\n\x18InternalMessagesResponse\x12\x32\n\x08messages\x18\x01 \x01(\x0b\x32 .wandb_internal.InternalMessages\"#\n\x10InternalMessages\x12\x0f\n\x07warning\x18\x01 \x03(\t\"?\n\x0fPollExitRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xbc\x01\n\x10PollExitResponse\x12\x0c\n\x04\x64one\x18\x01 \x01(\x08\x12\x32\n\x0b\x65xit_result\x18\x02 \x01(\x0b\x32\x1d.wandb_internal.RunExitResult\x12\x35\n\x0cpusher_stats\x18\x03 \x01(\x0b\x32\x1f.wandb_internal.FilePusherStats\x12/\n\x0b\x66ile_counts\x18\x04 \x01(\x0b\x32\x1a.wandb_internal.FileCounts\"@\n\rSyncOverwrite\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0e\n\x06\x65ntity\x18\x02 \x01(\t\x12\x0f\n\x07project\x18\x03 \x01(\t\"\x1e\n\x08SyncSkip\x12\x12\n\noutput_raw\x18\x01 \x01(\x08\"\x13\n\x11SenderMarkRequest\"\x93\x01\n\x0bSyncRequest\x12\x14\n\x0cstart_offset\x18\x01 \x01(\x03\x12\x14\n\x0c\x66inal_offset\x18\x02 \x01(\x03\x12\x30\n\toverwrite\x18\x03 \x01(\x0b\x32\x1d.wandb_internal.SyncOverwrite\x12&\n\x04skip\x18\x04 \x01(\x0b\x32\x18.wandb_internal.SyncSkip\"E\n\x0cSyncResponse\x12\x0b\n\x03url\x18\x01 \x01(\t\x12(\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\"?\n\x11SenderReadRequest\x12\x14\n\x0cstart_offset\x18\x01 \x01(\x03\x12\x14\n\x0c\x66inal_offset\x18\x02 \x01(\x03\"m\n\x13StatusReportRequest\x12\x12\n\nrecord_num\x18\x01 \x01(\x03\x12\x13\n\x0bsent_offset\x18\x02 \x01(\x03\x12-\n\tsync_time\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"F\n\x14SummaryRecordRequest\x12.\n\x07summary\x18\x01 \x01(\x0b\x32\x1d.wandb_internal.SummaryRecord\"L\n\x16TelemetryRecordRequest\x12\x32\n\ttelemetry\x18\x01 \x01(\x0b\x32\x1f.wandb_internal.TelemetryRecord\"A\n\x11ServerInfoRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"|\n\x12ServerInfoResponse\x12-\n\nlocal_info\x18\x01 \x01(\x0b\x32\x19.wandb_internal.LocalInfo\x12\x37\n\x0fserver_messages\x18\x02 \x01(\x0b\x32\x1e.wandb_internal.ServerMessages\"=\n\x0eServerMessages\x12+\n\x04item\x18\x01 \x03(\x0b\x32\x1d.wandb_internal.ServerMessage\"e\n\rServerMessage\x12\x12\n\nplain_text\x18\x01 \x01(\t\x12\x10\n\x08utf_text\x18\x02 \x01(\t\x12\x11\n\thtml_text\x18\x03 \x01(\t\x12\x0c\n\x04type\x18\x04 \x01(\t\x12\r\n\x05level\x18\x05 \x01(\x05\"c\n\nFileCounts\x12\x13\n\x0bwandb_count\x18\x01 \x01(\x05\x12\x13\n\x0bmedia_count\x18\x02 \x01(\x05\x12\x16\n\x0e\x61rtifact_count\x18\x03 \x01(\x05\x12\x13\n\x0bother_count\x18\x04 \x01(\x05\"U\n\x0f\x46ilePusherStats\x12\x16\n\x0euploaded_bytes\x18\x01 \x01(\x03\x12\x13\n\x0btotal_bytes\x18\x02 \x01(\x03\x12\x15\n\rdeduped_bytes\x18\x03 \x01(\x03\"\x1e\n\rFilesUploaded\x12\r\n\x05\x66iles\x18\x01 \x03(\t\"\xf4\x01\n\x17\x46ileTransferInfoRequest\x12\x42\n\x04type\x18\x01 \x01(\x0e\x32\x34.wandb_internal.FileTransferInfoRequest.TransferType\x12\x0c\n\x04path\x18\x02 \x01(\t\x12\x0b\n\x03url\x18\x03 \x01(\t\x12\x0c\n\x04size\x18\x04 \x01(\x03\x12\x11\n\tprocessed\x18\x05 \x01(\x03\x12/\n\x0b\x66ile_counts\x18\x06 \x01(\x0b\x32\x1a.wandb_internal.FileCounts\"(\n\x0cTransferType\x12\n\n\x06Upload\x10\x00\x12\x0c\n\x08\x44ownload\x10\x01\"1\n\tLocalInfo\x12\x0f\n\x07version\x18\x01 \x01(\t\x12\x13\n\x0bout_of_date\x18\x02 \x01(\x08\"?\n\x0fShutdownRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x12\n\x10ShutdownResponse\"P\n\rAttachRequest\x12\x11\n\tattach_id\x18\x14 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"b\n\x0e\x41ttachResponse\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12(\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\"\xd5\x02\n\x11TestInjectRequest\x12\x13\n\x0bhandler_exc\x18\x01 \x01(\x08\x12\x14\n\x0chandler_exit\x18\x02 \x01(\x08\x12\x15\n\rhandler_abort\x18\x03 \x01(\x08\x12\x12\n\nsender_exc\x18\x04 \x01(\x08\x12\x13\n\x0bsender_exit\x18\x05 \x01(\x08\x12\x14\n\x0csender_abort\x18\x06 \x01(\x08\x12\x0f\n\x07req_exc\x18\x07 \x01(\x08\x12\x10\n\x08req_exit\x18\x08 \x01(\x08\x12\x11\n\treq_abort\x18\t \x01(\x08\x12\x10\n\x08resp_exc\x18\n \x01(\x08\x12\x11\n\tresp_exit\x18\x0b \x01(\x08\x12\x12\n\nresp_abort\x18\x0c \x01(\x08\x12\x10\n\x08msg_drop\x18\r \x01(\x08\x12\x10\n\x08msg_hang\x18\x0e \x01(\x08\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x14\n\x12TestInjectResponse\"\x1e\n\rHistoryAction\x12\r\n\x05\x66lush\x18\x01 \x01(\x08\"\xca\x01\n\x15PartialHistoryRequest\x12)\n\x04item\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.HistoryItem\x12)\n\x04step\x18\x02 \x01(\x0b\x32\x1b.wandb_internal.HistoryStep\x12-\n\x06\x61\x63tion\x18\x03 \x01(\x0b\x32\x1d.wandb_internal.HistoryAction\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x18\n\x16PartialHistoryResponse\"E\n\x15SampledHistoryRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"_\n\x12SampledHistoryItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x14\n\x0cvalues_float\x18\x03 \x03(\x02\x12\x12\n\nvalues_int\x18\x04 \x03(\x03\"J\n\x16SampledHistoryResponse\x12\x30\n\x04item\x18\x01 \x03(\x0b\x32\".wandb_internal.SampledHistoryItem\"@\n\x10RunStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"x\n\x11RunStatusResponse\x12\x18\n\x10sync_items_total\x18\x01 \x01(\x03\x12\x1a\n\x12sync_items_pending\x18\x02 \x01(\x03\x12-\n\tsync_time\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"g\n\x0fRunStartRequest\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x12\n\x10RunStartResponse\"\\\n\x13\x43heckVersionRequest\x12\x17\n\x0f\x63urrent_version\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"]\n\x14\x43heckVersionResponse\x12\x17\n\x0fupgrade_message\x18\x01 \x01(\t\x12\x14\n\x0cyank_message\x18\x02 \x01(\t\x12\x16\n\x0e\x64\x65lete_message\x18\x03 \x01(\t\">\n\x0eJobInfoRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"6\n\x0fJobInfoResponse\x12\x12\n\nsequenceId\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\"\x9f\x01\n\x12LogArtifactRequest\x12\x30\n\x08\x61rtifact\x18\x01 \x01(\x0b\x32\x1e.wandb_internal.ArtifactRecord\x12\x14\n\x0chistory_step\x18\x02 \x01(\x03\x12\x13\n\x0bstaging_dir\x18\x03 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"A\n\x13LogArtifactResponse\x12\x13\n\x0b\x61rtifact_id\x18\x01 \x01(\t\x12\x15\n\rerror_message\x18\x02 \x01(\t\"\xbe\x01\n\x17\x44ownloadArtifactRequest\x12\x13\n\x0b\x61rtifact_id\x18\x01 \x01(\t\x12\x15\n\rdownload_root\x18\x02 \x01(\t\x12 \n\x18\x61llow_missing_references\x18\x04 \x01(\x08\x12\x12\n\nskip_cache\x18\x05 \x01(\x08\x12\x13\n\x0bpath_prefix\x18\x06 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"1\n\x18\x44ownloadArtifactResponse\x12\x15\n\rerror_message\x18\x01 \x01(\t\"@\n\x10KeepaliveRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x13\n\x11KeepaliveResponse\"q\n\x0c\x41rtifactInfo\x12\x10\n\x08\x61rtifact\x18\x01 \x01(\t\x12\x12\n\nentrypoint\x18\x02 \x03(\t\x12\x10\n\x08notebook\x18\x03 \x01(\x08\x12\x15\n\rbuild_context\x18\x04 \x01(\t\x12\x12\n\ndockerfile\x18\x05 \x01(\t\")\n\x07GitInfo\x12\x0e\n\x06remote\x18\x01 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x02 \x01(\t\"\x87\x01\n\tGitSource\x12)\n\x08git_info\x18\x01 \x01(\x0b\x32\x17.wandb_internal.GitInfo\x12\x12\n\nentrypoint\x18\x02 \x03(\t\x12\x10\n\x08notebook\x18\x03 \x01(\x08\x12\x15\n\rbuild_context\x18\x04 \x01(\t\x12\x12\n\ndockerfile\x18\x05 \x01(\t\"\x1c\n\x0bImageSource\x12\r\n\x05image\x18\x01 \x01(\t\"\x8c\x01\n\x06Source\x12&\n\x03git\x18\x01 \x01(\x0b\x32\x19.wandb_internal.GitSource\x12.\n\x08\x61rtifact\x18\x02 \x01(\x0b\x32\x1c.wandb_internal.ArtifactInfo\x12*\n\x05image\x18\x03 \x01(\x0b\x32\x1b.wandb_internal.ImageSource\"k\n\tJobSource\x12\x10\n\x08_version\x18\x01 \x01(\t\x12\x13\n\x0bsource_type\x18\x02 \x01(\t\x12&\n\x06source\x18\x03 \x01(\x0b\x32\x16.wandb_internal.Source\x12\x0f\n\x07runtime\x18\x04 \x01(\t\"V\n\x12PartialJobArtifact\x12\x10\n\x08job_name\x18\x01 \x01(\t\x12.\n\x0bsource_info\x18\x02 \x01(\x0b\x32\x19.wandb_internal.JobSource\"\x9d\x01\n\x11UseArtifactRecord\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x33\n\x07partial\x18\x04 \x01(\x0b\x32\".wandb_internal.PartialJobArtifact\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x13\n\x11UseArtifactResult\"R\n\rCancelRequest\x12\x13\n\x0b\x63\x61ncel_slot\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x10\n\x0e\x43\x61ncelResponse\"\'\n\x08\x44iskInfo\x12\r\n\x05total\x18\x01 \x01(\x04\x12\x0c\n\x04used\x18\x02 \x01(\x04\"\x1b\n\nMemoryInfo\x12\r\n\x05total\x18\x01 \x01(\x04\"/\n\x07\x43puInfo\x12\r\n\x05\x63ount\x18\x01 \x01(\r\x12\x15\n\rcount_logical\x18\x02 \x01(\r\">\n\x0cGpuAppleInfo\x12\x0f\n\x07gpuType\x18\x01 \x01(\t\x12\x0e\n\x06vendor\x18\x02 \x01(\t\x12\r\n\x05\x63ores\x18\x03 \x01(\r\"3\n\rGpuNvidiaInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x14\n\x0cmemory_total\x18\x02 \x01(\x04\"\x89\x02\n\nGpuAmdInfo\x12\n\n\x02id\x18\x01 \x01(\t\x12\x11\n\tunique_id\x18\x02 \x01(\t\x12\x15\n\rvbios_version\x18\x03 \x01(\t\x12\x19\n\x11performance_level\x18\x04 \x01(\t\x12\x15\n\rgpu_overdrive\x18\x05 \x01(\t\x12\x1c\n\x14gpu_memory_overdrive\x18\x06 \x01(\t\x12\x11\n\tmax_power\x18\x07 \x01(\t\x12\x0e\n\x06series\x18\x08 \x01(\t\x12\r\n\x05model\x18\t \x01(\t\x12\x0e\n\x06vendor\x18\n \x01(\t\x12\x0b\n\x03sku\x18\x0b \x01(\t\x12\x12\n\nsclk_range\x18\x0c \x01(\t\x12\x12\n\nmclk_range\x18\r \x01(\t\"\x96\x08\n\x0fMetadataRequest\x12\n\n\x02os\x18\x01 \x01(\t\x12\x0e\n\x06python\x18\x02 \x01(\t\x12/\n\x0bheartbeatAt\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12-\n\tstartedAt\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06\x64ocker\x18\x05 \x01(\t\x12\x0c\n\x04\x63uda\x18\x06 \x01(\t\x12\x0c\n\x04\x61rgs\x18\x07 \x03(\t\x12\r\n\x05state\x18\x08 \x01(\t\x12\x0f\n\x07program\x18\t \x01(\t\x12\x1b\n\tcode_path\x18\n \x01(\tR\x08\x63odePath\x12*\n\x03git\x18\x0b \x01(\x0b\x32\x1d.wandb_internal.GitRepoRecord\x12\r\n\x05\x65mail\x18\x0c \x01(\t\x12\x0c\n\x04root\x18\r \x01(\t\x12\x0c\n\x04host\x18\x0e \x01(\t\x12\x10\n\x08username\x18\x0f \x01(\t\x12\x12\n\nexecutable\x18\x10 \x01(\t\x12&\n\x0f\x63ode_path_local\x18\x11 \x01(\tR\rcodePathLocal\x12\r\n\x05\x63olab\x18\x12 \x01(\t\x12\x1c\n\tcpu_count\x18\x13 \x01(\rR\tcpu_count\x12,\n\x11\x63pu_count_logical\x18\x14 \x01(\rR\x11\x63pu_count_logical\x12\x15\n\x08gpu_type\x18\x15 \x01(\tR\x03gpu\x12\x1c\n\tgpu_count\x18\x16 \x01(\rR\tgpu_count\x12\x37\n\x04\x64isk\x18\x17 \x03(\x0b\x32).wandb_internal.MetadataRequest.DiskEntry\x12*\n\x06memory\x18\x18 \x01(\x0b\x32\x1a.wandb_internal.MemoryInfo\x12$\n\x03\x63pu\x18\x19 \x01(\x0b\x32\x17.wandb_internal.CpuInfo\x12\x39\n\tgpu_apple\x18\x1a \x01(\x0b\x32\x1c.wandb_internal.GpuAppleInfoR\x08gpuapple\x12=\n\ngpu_nvidia\x18\x1b \x03(\x0b\x32\x1d.wandb_internal.GpuNvidiaInfoR\ngpu_nvidia\x12\x34\n\x07gpu_amd\x18\x1c \x03(\x0b\x32\x1a.wandb_internal.GpuAmdInfoR\x07gpu_amd\x12\x39\n\x05slurm\x18\x1d \x03(\x0b\x32*.wandb_internal.MetadataRequest.SlurmEntry\x1a\x45\n\tDiskEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\'\n\x05value\x18\x02 \x01(\x0b\x32\x18.wandb_internal.DiskInfo:\x02\x38\x01\x1a,\n\nSlurmEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\x8d\x01\n\x15PythonPackagesRequest\x12\x44\n\x07package\x18\x01 \x03(\x0b\x32\x33.wandb_internal.PythonPackagesRequest.PythonPackage\x1a.\n\rPythonPackage\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\"\x1c\n\x0cJobInputPath\x12\x0c\n\x04path\x18\x01 \x03(\t\"\xd6\x01\n\x0eJobInputSource\x12\x44\n\nrun_config\x18\x01 \x01(\x0b\x32..wandb_internal.JobInputSource.RunConfigSourceH\x00\x12?\n\x04\x66ile\x18\x02 \x01(\x0b\x32/.wandb_internal.JobInputSource.ConfigFileSourceH\x00\x1a\x11\n\x0fRunConfigSource\x1a \n\x10\x43onfigFileSource\x12\x0c\n\x04path\x18\x01 \x01(\tB\x08\n\x06source\"\xb1\x01\n\x0fJobInputRequest\x12\x34\n\x0cinput_source\x18\x01 \x01(\x0b\x32\x1e.wandb_internal.JobInputSource\x12\x33\n\rinclude_paths\x18\x02 \x03(\x0b\x32\x1c.wandb_internal.JobInputPath\x12\x33\n\rexclude_paths\x18\x03 \x03(\x0b\x32\x1c.wandb_internal.JobInputPathb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n wandb/proto/wandb_internal.proto\x12\x0ewandb_internal\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1cwandb/proto/wandb_base.proto\x1a!wandb/proto/wandb_telemetry.proto\"\xf9\x0b\n\x06Record\x12\x0b\n\x03num\x18\x01 \x01(\x03\x12\x30\n\x07history\x18\x02 \x01(\x0b\x32\x1d.wandb_internal.HistoryRecordH\x00\x12\x30\n\x07summary\x18\x03 \x01(\x0b\x32\x1d.wandb_internal.SummaryRecordH\x00\x12.\n\x06output\x18\x04 \x01(\x0b\x32\x1c.wandb_internal.OutputRecordH\x00\x12.\n\x06\x63onfig\x18\x05 \x01(\x0b\x32\x1c.wandb_internal.ConfigRecordH\x00\x12,\n\x05\x66iles\x18\x06 \x01(\x0b\x32\x1b.wandb_internal.FilesRecordH\x00\x12,\n\x05stats\x18\x07 \x01(\x0b\x32\x1b.wandb_internal.StatsRecordH\x00\x12\x32\n\x08\x61rtifact\x18\x08 \x01(\x0b\x32\x1e.wandb_internal.ArtifactRecordH\x00\x12,\n\x08tbrecord\x18\t \x01(\x0b\x32\x18.wandb_internal.TBRecordH\x00\x12,\n\x05\x61lert\x18\n \x01(\x0b\x32\x1b.wandb_internal.AlertRecordH\x00\x12\x34\n\ttelemetry\x18\x0b \x01(\x0b\x32\x1f.wandb_internal.TelemetryRecordH\x00\x12.\n\x06metric\x18\x0c \x01(\x0b\x32\x1c.wandb_internal.MetricRecordH\x00\x12\x35\n\noutput_raw\x18\r \x01(\x0b\x32\x1f.wandb_internal.OutputRawRecordH\x00\x12(\n\x03run\x18\x11 \x01(\x0b\x32\x19.wandb_internal.RunRecordH\x00\x12-\n\x04\x65xit\x18\x12 \x01(\x0b\x32\x1d.wandb_internal.RunExitRecordH\x00\x12,\n\x05\x66inal\x18\x14 \x01(\x0b\x32\x1b.wandb_internal.FinalRecordH\x00\x12.\n\x06header\x18\x15 \x01(\x0b\x32\x1c.wandb_internal.HeaderRecordH\x00\x12.\n\x06\x66ooter\x18\x16 \x01(\x0b\x32\x1c.wandb_internal.FooterRecordH\x00\x12\x39\n\npreempting\x18\x17 \x01(\x0b\x32#.wandb_internal.RunPreemptingRecordH\x00\x12;\n\rlink_artifact\x18\x18 \x01(\x0b\x32\".wandb_internal.LinkArtifactRecordH\x00\x12\x39\n\x0cuse_artifact\x18\x19 \x01(\x0b\x32!.wandb_internal.UseArtifactRecordH\x00\x12\x36\n\ncheckpoint\x18\x1a \x01(\x0b\x32 .wandb_internal.CheckpointRecordH\x00\x12\x35\n\ntable_rows\x18\x1b \x01(\x0b\x32\x1f.wandb_internal.TableRowsRecordH\x00\x12\x33\n\x07\x61\x62orted\x18\x1c \x01(\x0b\x32 .wandb_internal.RunAbortedRecordH\x00\x12\x39\n\x0cuser_message\x18\x1d \x01(\x0b\x32!.wandb_internal.UserMessageRecordH\x00\x12\x39\n\nconflicted\x18\x1e \x01(\x0b\x32#.wandb_internal.RunConflictedRecordH\x00\x12\x41\n\x10project_settings\x18\x1f \x01(\x0b\x32%.wandb_internal.ProjectSettingsRecordH\x00\x12*\n\x07request\x18\x64 \x01(\x0b\x32\x17.wandb_internal.RequestH\x00\x12(\n\x07\x63ontrol\x18\x10 \x01(\x0b\x32\x17.wandb_internal.Control\x12\x0c\n\x04uuid\x18\x13 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfoB\r\n\x0brecord_type\"\xbe\x01\n\x07\x43ontrol\x12\x10\n\x08req_resp\x18\x01 \x01(\x08\x12\r\n\x05local\x18\x02 \x01(\x08\x12\x10\n\x08relay_id\x18\x03 \x01(\t\x12\x14\n\x0cmailbox_slot\x18\x04 \x01(\t\x12\x13\n\x0b\x61lways_send\x18\x05 \x01(\x08\x12\x14\n\x0c\x66low_control\x18\x06 \x01(\x08\x12\x12\n\nend_offset\x18\x07 \x01(\x03\x12\x15\n\rconnection_id\x18\x08 \x01(\t\x12\x14\n\x0cpersist_only\x18\t \x01(\x08\"\xcc\x04\n\x06Result\x12\x35\n\nrun_result\x18\x11 \x01(\x0b\x32\x1f.wandb_internal.RunUpdateResultH\x00\x12\x34\n\x0b\x65xit_result\x18\x12 \x01(\x0b\x32\x1d.wandb_internal.RunExitResultH\x00\x12\x33\n\nlog_result\x18\x14 \x01(\x0b\x32\x1d.wandb_internal.HistoryResultH\x00\x12\x37\n\x0esummary_result\x18\x15 \x01(\x0b\x32\x1d.wandb_internal.SummaryResultH\x00\x12\x35\n\routput_result\x18\x16 \x01(\x0b\x32\x1c.wandb_internal.OutputResultH\x00\x12\x35\n\rconfig_result\x18\x17 \x01(\x0b\x32\x1c.wandb_internal.ConfigResultH\x00\x12,\n\x08response\x18\x64 \x01(\x0b\x32\x18.wandb_internal.ResponseH\x00\x12(\n\x07\x63ontrol\x18\x10 \x01(\x0b\x32\x17.wandb_internal.Control\x12\x0c\n\x04uuid\x18\x18 \x01(\t\x12*\n\x05\x63hunk\x18\x19 \x01(\x0b\x32\x1b.wandb_internal.ResultChunk\x12+\n\x08rejected\x18\x1a \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._ResultInfoB\r\n\x0bresult_type\"+\n\x0bResultChunk\x12\r\n\x05index\x18\x01 \x01(\x05\x12\r\n\x05\x66inal\x18\x02 \x01(\x08\":\n\x0b\x46inalRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"b\n\x0bVersionInfo\x12\x10\n\x08producer\x18\x01 \x01(\t\x12\x14\n\x0cmin_consumer\x18\x02 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xa2\x01\n\x0cHeaderRecord\x12\x31\n\x0cversion_info\x18\x01 \x01(\x0b\x32\x1b.wandb_internal.VersionInfo\x12\x32\n\ncompaction\x18\x02 \x01(\x0b\x32\x1e.wandb_internal.CompactionInfo\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"t\n\x0e\x43ompactionInfo\x12\x18\n\x10original_records\x18\x01 \x01(\x03\x12\x16\n\x0eoriginal_bytes\x18\x02 \x01(\x03\x12\x30\n\x0c\x63ompacted_at\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\";\n\x0c\x46ooterRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x84\x01\n\x0fTableRowsRecord\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x0f\n\x07\x63olumns\x18\x02 \x03(\t\x12&\n\x04rows\x18\x03 \x03(\x0b\x32\x18.wandb_internal.TableRow\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x1f\n\x08TableRow\x12\x13\n\x0bvalues_json\x18\x01 \x03(\t\"\xc6\x04\n\x10\x43heckpointRecord\x12\x0f\n\x07version\x18\x01 \x01(\r\x12\x17\n\x0flast_record_num\x18\x02 \x01(\x03\x12*\n\x06\x63onfig\x18\x03 \x03(\x0b\x32\x1a.wandb_internal.ConfigItem\x12,\n\x07summary\x18\x04 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\x12\x0c\n\x04step\x18\x05 \x01(\x03\x12-\n\x07metrics\x18\x06 \x03(\x0b\x32\x1c.wandb_internal.MetricRecord\x12\x10\n\x08\x63hecksum\x18\x07 \x01(\x07\x12&\n\x03run\x18\x08 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12\x44\n\naggregates\x18\t \x03(\x0b\x32\x30.wandb_internal.CheckpointRecord.MetricAggregate\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\x1a\xc3\x01\n\x0fMetricAggregate\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x0b\n\x03min\x18\x02 \x01(\x01\x12\x0b\n\x03max\x18\x03 \x01(\x01\x12\x0b\n\x03sum\x18\x04 \x01(\x01\x12\r\n\x05\x63ount\x18\x05 \x01(\x03\x12\x10\n\x08has_best\x18\x06 \x01(\x08\x12\x0c\n\x04\x62\x65st\x18\x07 \x01(\x01\x12\x11\n\tbest_step\x18\x08 \x01(\x03\x12:\n\tbest_goal\x18\t \x01(\x0e\x32\'.wandb_internal.MetricRecord.MetricGoal\"\xde\x04\n\tRunRecord\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0e\n\x06\x65ntity\x18\x02 \x01(\t\x12\x0f\n\x07project\x18\x03 \x01(\t\x12,\n\x06\x63onfig\x18\x04 \x01(\x0b\x32\x1c.wandb_internal.ConfigRecord\x12.\n\x07summary\x18\x05 \x01(\x0b\x32\x1d.wandb_internal.SummaryRecord\x12\x11\n\trun_group\x18\x06 \x01(\t\x12\x10\n\x08job_type\x18\x07 \x01(\t\x12\x14\n\x0c\x64isplay_name\x18\x08 \x01(\t\x12\r\n\x05notes\x18\t \x01(\t\x12\x0c\n\x04tags\x18\n \x03(\t\x12\x30\n\x08settings\x18\x0b \x01(\x0b\x32\x1e.wandb_internal.SettingsRecord\x12\x10\n\x08sweep_id\x18\x0c \x01(\t\x12\x0c\n\x04host\x18\r \x01(\t\x12\x15\n\rstarting_step\x18\x0e \x01(\x03\x12\x12\n\nstorage_id\x18\x10 \x01(\t\x12.\n\nstart_time\x18\x11 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0f\n\x07resumed\x18\x12 \x01(\x08\x12\x32\n\ttelemetry\x18\x13 \x01(\x0b\x32\x1f.wandb_internal.TelemetryRecord\x12\x0f\n\x07runtime\x18\x14 \x01(\x05\x12*\n\x03git\x18\x15 \x01(\x0b\x32\x1d.wandb_internal.GitRepoRecord\x12\x0e\n\x06\x66orked\x18\x16 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\";\n\rGitRepoRecord\x12\x1a\n\nremote_url\x18\x01 \x01(\tR\x06remote\x12\x0e\n\x06\x63ommit\x18\x02 \x01(\t\"c\n\x0fRunUpdateResult\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12(\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\"\xba\x01\n\tErrorInfo\x12\x0f\n\x07message\x18\x01 \x01(\t\x12\x31\n\x04\x63ode\x18\x02 \x01(\x0e\x32#.wandb_internal.ErrorInfo.ErrorCode\"i\n\tErrorCode\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x11\n\rCOMMUNICATION\x10\x01\x12\x12\n\x0e\x41UTHENTICATION\x10\x02\x12\t\n\x05USAGE\x10\x03\x12\x0f\n\x0bUNSUPPORTED\x10\x04\x12\x0c\n\x08\x43\x41PACITY\x10\x05\"u\n\rRunExitRecord\x12\x11\n\texit_code\x18\x01 \x01(\x05\x12\x0f\n\x07runtime\x18\x02 \x01(\x05\x12\x13\n\x0binterrupted\x18\x03 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x91\x03\n\rRunExitResult\x12\x32\n\nunfinished\x18\x01 \x01(\x0b\x32\x1e.wandb_internal.UnfinishedWork\x12\x35\n\x0cverification\x18\x02 \x01(\x0b\x32\x1f.wandb_internal.RunVerification\x12\x37\n\x0fshutdown_timing\x18\x03 \x01(\x0b\x32\x1e.wandb_internal.ShutdownTiming\x12\x35\n\x0equota_exceeded\x18\x04 \x01(\x0b\x32\x1d.wandb_internal.QuotaExceeded\x12-\n\x05\x66iles\x18\x05 \x01(\x0b\x32\x1e.wandb_internal.RunFilesReport\x12\x11\n\tdiscarded\x18\x06 \x01(\x08\x12\x12\n\nconflicted\x18\x07 \x01(\x08\x12\x31\n\x0clate_records\x18\x08 \x01(\x0b\x32\x1b.wandb_internal.LateRecords\x12\x1c\n\x14omitted_summary_keys\x18\t \x03(\t\"H\n\x0bLateRecords\x12\x15\n\rgrace_seconds\x18\x01 \x01(\x01\x12\x10\n\x08\x61\x63\x63\x65pted\x18\x02 \x01(\x03\x12\x10\n\x08rejected\x18\x03 \x01(\x03\"P\n\x0eRunFilesReport\x12,\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x1d.wandb_internal.RunFileReport\x12\x10\n\x08overflow\x18\x02 \x01(\x05\"\xde\x01\n\rRunFileReport\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0c\n\x04size\x18\x02 \x01(\x03\x12\x34\n\x06status\x18\x03 \x01(\x0e\x32$.wandb_internal.RunFileReport.Status\x12\r\n\x05\x65rror\x18\x04 \x01(\t\x12\x0f\n\x07retries\x18\x05 \x01(\x05\"[\n\x06Status\x12\x0c\n\x08UPLOADED\x10\x00\x12\n\n\x06\x46\x41ILED\x10\x01\x12\x13\n\x0fSKIPPED_IGNORED\x10\x02\x12\x15\n\x11SKIPPED_UNCHANGED\x10\x03\x12\x0b\n\x07PENDING\x10\x04\"/\n\rQuotaExceeded\x12\r\n\x05limit\x18\x01 \x01(\t\x12\x0f\n\x07message\x18\x02 \x01(\t\"\xf3\x01\n\x0eShutdownTiming\x12\x15\n\rdrain_seconds\x18\x01 \x01(\x01\x12\x1c\n\x14writer_flush_seconds\x18\x02 \x01(\x01\x12\x1c\n\x14\x66inal_upsert_seconds\x18\x03 \x01(\x01\x12\x1f\n\x17\x61rtifact_commit_seconds\x18\x04 \x01(\x01\x12\x1b\n\x13\x66ile_upload_seconds\x18\x05 \x01(\x01\x12!\n\x19\x66ile_stream_flush_seconds\x18\x06 \x01(\x01\x12\x16\n\x0e\x66ooter_seconds\x18\x07 \x01(\x01\x12\x15\n\rtotal_seconds\x18\x08 \x01(\x01\"\x9e\x01\n\x0fRunVerification\x12\x31\n\x05local\x18\x01 \x01(\x0b\x32\".wandb_internal.VerificationCounts\x12\x32\n\x06server\x18\x02 \x01(\x0b\x32\".wandb_internal.VerificationCounts\x12\x15\n\rdiscrepancies\x18\x03 \x03(\t\x12\r\n\x05\x65rror\x18\x04 \x01(\t\"b\n\x12VerificationCounts\x12\x14\n\x0chistory_rows\x18\x01 \x01(\x03\x12\x14\n\x0csummary_keys\x18\x02 \x01(\x05\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\x12\x11\n\tartifacts\x18\x04 \x01(\x05\"}\n\x0eUnfinishedWork\x12\r\n\x05\x66iles\x18\x01 \x03(\t\x12\x17\n\x0fpending_uploads\x18\x02 \x01(\x05\x12!\n\x19queued_filestream_updates\x18\x03 \x01(\x05\x12 \n\x18unacked_filestream_lines\x18\x04 \x01(\x05\"B\n\x13RunPreemptingRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x15\n\x13RunPreemptingResult\"?\n\x10RunAbortedRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"S\n\x13RunConflictedRecord\x12\x0f\n\x07message\x18\x01 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xa9\x01\n\x15ProjectSettingsRecord\x12\x30\n\x08\x64\x65\x66\x61ults\x18\x01 \x01(\x0b\x32\x1e.wandb_internal.SettingsRecord\x12\x31\n\teffective\x18\x02 \x01(\x0b\x32\x1e.wandb_internal.SettingsRecord\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xba\x01\n\x11UserMessageRecord\x12\x38\n\x05level\x18\x01 \x01(\x0e\x32).wandb_internal.UserMessageResponse.Level\x12\x0f\n\x07message\x18\x02 \x01(\t\x12-\n\ttimestamp\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"i\n\x0eSettingsRecord\x12*\n\x04item\x18\x01 \x03(\x0b\x32\x1c.wandb_internal.SettingsItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"/\n\x0cSettingsItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x1a\n\x0bHistoryStep\x12\x0b\n\x03num\x18\x01 \x01(\x03\"\x92\x01\n\rHistoryRecord\x12)\n\x04item\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.HistoryItem\x12)\n\x04step\x18\x02 \x01(\x0b\x32\x1b.wandb_internal.HistoryStep\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"B\n\x0bHistoryItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x0f\n\rHistoryResult\"\xdc\x01\n\x0cOutputRecord\x12<\n\x0boutput_type\x18\x01 \x01(\x0e\x32\'.wandb_internal.OutputRecord.OutputType\x12-\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0c\n\x04line\x18\x03 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"$\n\nOutputType\x12\n\n\x06STDERR\x10\x00\x12\n\n\x06STDOUT\x10\x01\"\x0e\n\x0cOutputResult\"\xe2\x01\n\x0fOutputRawRecord\x12?\n\x0boutput_type\x18\x01 \x01(\x0e\x32*.wandb_internal.OutputRawRecord.OutputType\x12-\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0c\n\x04line\x18\x03 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"$\n\nOutputType\x12\n\n\x06STDERR\x10\x00\x12\n\n\x06STDOUT\x10\x01\"\x11\n\x0fOutputRawResult\"\x98\x03\n\x0cMetricRecord\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x11\n\tglob_name\x18\x02 \x01(\t\x12\x13\n\x0bstep_metric\x18\x04 \x01(\t\x12\x19\n\x11step_metric_index\x18\x05 \x01(\x05\x12.\n\x07options\x18\x06 \x01(\x0b\x32\x1d.wandb_internal.MetricOptions\x12.\n\x07summary\x18\x07 \x01(\x0b\x32\x1d.wandb_internal.MetricSummary\x12\x35\n\x04goal\x18\x08 \x01(\x0e\x32\'.wandb_internal.MetricRecord.MetricGoal\x12/\n\x08_control\x18\t \x01(\x0b\x32\x1d.wandb_internal.MetricControl\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"B\n\nMetricGoal\x12\x0e\n\nGOAL_UNSET\x10\x00\x12\x11\n\rGOAL_MINIMIZE\x10\x01\x12\x11\n\rGOAL_MAXIMIZE\x10\x02\"\x0e\n\x0cMetricResult\"C\n\rMetricOptions\x12\x11\n\tstep_sync\x18\x01 \x01(\x08\x12\x0e\n\x06hidden\x18\x02 \x01(\x08\x12\x0f\n\x07\x64\x65\x66ined\x18\x03 \x01(\x08\"\"\n\rMetricControl\x12\x11\n\toverwrite\x18\x01 \x01(\x08\"o\n\rMetricSummary\x12\x0b\n\x03min\x18\x01 \x01(\x08\x12\x0b\n\x03max\x18\x02 \x01(\x08\x12\x0c\n\x04mean\x18\x03 \x01(\x08\x12\x0c\n\x04\x62\x65st\x18\x04 \x01(\x08\x12\x0c\n\x04last\x18\x05 \x01(\x08\x12\x0c\n\x04none\x18\x06 \x01(\x08\x12\x0c\n\x04\x63opy\x18\x07 \x01(\x08\"\x93\x01\n\x0c\x43onfigRecord\x12*\n\x06update\x18\x01 \x03(\x0b\x32\x1a.wandb_internal.ConfigItem\x12*\n\x06remove\x18\x02 \x03(\x0b\x32\x1a.wandb_internal.ConfigItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"A\n\nConfigItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x0e\n\x0c\x43onfigResult\"\xa7\x01\n\rSummaryRecord\x12+\n\x06update\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\x12+\n\x06remove\x18\x02 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\x12\x0f\n\x07\x64\x65rived\x18\x03 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"B\n\x0bSummaryItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\x0f\n\rSummaryResult\"s\n\x0b\x46ilesRecord\x12(\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x19.wandb_internal.FilesItem\x12\r\n\x05\x66orce\x18\x02 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xec\x01\n\tFilesItem\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x34\n\x06policy\x18\x02 \x01(\x0e\x32$.wandb_internal.FilesItem.PolicyType\x12\x30\n\x04type\x18\x03 \x01(\x0e\x32\".wandb_internal.FilesItem.FileType\"(\n\nPolicyType\x12\x07\n\x03NOW\x10\x00\x12\x07\n\x03\x45ND\x10\x01\x12\x08\n\x04LIVE\x10\x02\"9\n\x08\x46ileType\x12\t\n\x05OTHER\x10\x00\x12\t\n\x05WANDB\x10\x01\x12\t\n\x05MEDIA\x10\x02\x12\x0c\n\x08\x41RTIFACT\x10\x03J\x04\x08\x10\x10\x11\"\r\n\x0b\x46ilesResult\"\xe6\x01\n\x0bStatsRecord\x12\x39\n\nstats_type\x18\x01 \x01(\x0e\x32%.wandb_internal.StatsRecord.StatsType\x12-\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\'\n\x04item\x18\x03 \x03(\x0b\x32\x19.wandb_internal.StatsItem\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x17\n\tStatsType\x12\n\n\x06SYSTEM\x10\x00\",\n\tStatsItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x10 \x01(\t\"\xd9\x03\n\x0e\x41rtifactRecord\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0f\n\x07project\x18\x02 \x01(\t\x12\x0e\n\x06\x65ntity\x18\x03 \x01(\t\x12\x0c\n\x04type\x18\x04 \x01(\t\x12\x0c\n\x04name\x18\x05 \x01(\t\x12\x0e\n\x06\x64igest\x18\x06 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x07 \x01(\t\x12\x10\n\x08metadata\x18\x08 \x01(\t\x12\x14\n\x0cuser_created\x18\t \x01(\x08\x12\x18\n\x10use_after_commit\x18\n \x01(\x08\x12\x0f\n\x07\x61liases\x18\x0b \x03(\t\x12\x32\n\x08manifest\x18\x0c \x01(\x0b\x32 .wandb_internal.ArtifactManifest\x12\x16\n\x0e\x64istributed_id\x18\r \x01(\t\x12\x10\n\x08\x66inalize\x18\x0e \x01(\x08\x12\x11\n\tclient_id\x18\x0f \x01(\t\x12\x1a\n\x12sequence_client_id\x18\x10 \x01(\t\x12\x0f\n\x07\x62\x61se_id\x18\x11 \x01(\t\x12\x1c\n\x14ttl_duration_seconds\x18\x12 \x01(\x03\x12\x19\n\x11incremental_beta1\x18\x64 \x01(\x08\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\xbc\x01\n\x10\x41rtifactManifest\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x16\n\x0estorage_policy\x18\x02 \x01(\t\x12\x46\n\x15storage_policy_config\x18\x03 \x03(\x0b\x32\'.wandb_internal.StoragePolicyConfigItem\x12\x37\n\x08\x63ontents\x18\x04 \x03(\x0b\x32%.wandb_internal.ArtifactManifestEntry\"\xcf\x01\n\x15\x41rtifactManifestEntry\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0e\n\x06\x64igest\x18\x02 \x01(\t\x12\x0b\n\x03ref\x18\x03 \x01(\t\x12\x0c\n\x04size\x18\x04 \x01(\x03\x12\x10\n\x08mimetype\x18\x05 \x01(\t\x12\x12\n\nlocal_path\x18\x06 \x01(\t\x12\x19\n\x11\x62irth_artifact_id\x18\x07 \x01(\t\x12\x12\n\nskip_cache\x18\x08 \x01(\x08\x12(\n\x05\x65xtra\x18\x10 \x03(\x0b\x32\x19.wandb_internal.ExtraItem\",\n\tExtraItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x02 \x01(\t\":\n\x17StoragePolicyConfigItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x02 \x01(\t\"\x10\n\x0e\x41rtifactResult\"\x14\n\x12LinkArtifactResult\"\xcf\x01\n\x12LinkArtifactRecord\x12\x11\n\tclient_id\x18\x01 \x01(\t\x12\x11\n\tserver_id\x18\x02 \x01(\t\x12\x16\n\x0eportfolio_name\x18\x03 \x01(\t\x12\x18\n\x10portfolio_entity\x18\x04 \x01(\t\x12\x19\n\x11portfolio_project\x18\x05 \x01(\t\x12\x19\n\x11portfolio_aliases\x18\x06 \x03(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"h\n\x08TBRecord\x12\x0f\n\x07log_dir\x18\x01 \x01(\t\x12\x0c\n\x04save\x18\x02 \x01(\x08\x12\x10\n\x08root_dir\x18\x03 \x01(\t\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\n\n\x08TBResult\"}\n\x0b\x41lertRecord\x12\r\n\x05title\x18\x01 \x01(\t\x12\x0c\n\x04text\x18\x02 \x01(\t\x12\r\n\x05level\x18\x03 \x01(\t\x12\x15\n\rwait_duration\x18\x04 \x01(\x03\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\r\n\x0b\x41lertResult\"\xb0\x15\n\x07Request\x12\x38\n\x0bstop_status\x18\x01 \x01(\x0b\x32!.wandb_internal.StopStatusRequestH\x00\x12>\n\x0enetwork_status\x18\x02 \x01(\x0b\x32$.wandb_internal.NetworkStatusRequestH\x00\x12-\n\x05\x64\x65\x66\x65r\x18\x03 \x01(\x0b\x32\x1c.wandb_internal.DeferRequestH\x00\x12\x38\n\x0bget_summary\x18\x04 \x01(\x0b\x32!.wandb_internal.GetSummaryRequestH\x00\x12-\n\x05login\x18\x05 \x01(\x0b\x32\x1c.wandb_internal.LoginRequestH\x00\x12-\n\x05pause\x18\x06 \x01(\x0b\x32\x1c.wandb_internal.PauseRequestH\x00\x12/\n\x06resume\x18\x07 \x01(\x0b\x32\x1d.wandb_internal.ResumeRequestH\x00\x12\x34\n\tpoll_exit\x18\x08 \x01(\x0b\x32\x1f.wandb_internal.PollExitRequestH\x00\x12@\n\x0fsampled_history\x18\t \x01(\x0b\x32%.wandb_internal.SampledHistoryRequestH\x00\x12@\n\x0fpartial_history\x18\n \x01(\x0b\x32%.wandb_internal.PartialHistoryRequestH\x00\x12\x34\n\trun_start\x18\x0b \x01(\x0b\x32\x1f.wandb_internal.RunStartRequestH\x00\x12<\n\rcheck_version\x18\x0c \x01(\x0b\x32#.wandb_internal.CheckVersionRequestH\x00\x12:\n\x0clog_artifact\x18\r \x01(\x0b\x32\".wandb_internal.LogArtifactRequestH\x00\x12\x44\n\x11\x64ownload_artifact\x18\x0e \x01(\x0b\x32\'.wandb_internal.DownloadArtifactRequestH\x00\x12\x35\n\tkeepalive\x18\x11 \x01(\x0b\x32 .wandb_internal.KeepaliveRequestH\x00\x12\x36\n\nrun_status\x18\x14 \x01(\x0b\x32 .wandb_internal.RunStatusRequestH\x00\x12/\n\x06\x63\x61ncel\x18\x15 \x01(\x0b\x32\x1d.wandb_internal.CancelRequestH\x00\x12\x33\n\x08metadata\x18\x16 \x01(\x0b\x32\x1f.wandb_internal.MetadataRequestH\x00\x12\x44\n\x11internal_messages\x18\x17 \x01(\x0b\x32\'.wandb_internal.InternalMessagesRequestH\x00\x12@\n\x0fpython_packages\x18\x18 \x01(\x0b\x32%.wandb_internal.PythonPackagesRequestH\x00\x12\x33\n\x08shutdown\x18@ \x01(\x0b\x32\x1f.wandb_internal.ShutdownRequestH\x00\x12/\n\x06\x61ttach\x18\x41 \x01(\x0b\x32\x1d.wandb_internal.AttachRequestH\x00\x12/\n\x06status\x18\x42 \x01(\x0b\x32\x1d.wandb_internal.StatusRequestH\x00\x12\x38\n\x0bserver_info\x18\x43 \x01(\x0b\x32!.wandb_internal.ServerInfoRequestH\x00\x12\x38\n\x0bsender_mark\x18\x44 \x01(\x0b\x32!.wandb_internal.SenderMarkRequestH\x00\x12\x38\n\x0bsender_read\x18\x45 \x01(\x0b\x32!.wandb_internal.SenderReadRequestH\x00\x12<\n\rstatus_report\x18\x46 \x01(\x0b\x32#.wandb_internal.StatusReportRequestH\x00\x12>\n\x0esummary_record\x18G \x01(\x0b\x32$.wandb_internal.SummaryRecordRequestH\x00\x12\x42\n\x10telemetry_record\x18H \x01(\x0b\x32&.wandb_internal.TelemetryRecordRequestH\x00\x12\x32\n\x08job_info\x18I \x01(\x0b\x32\x1e.wandb_internal.JobInfoRequestH\x00\x12\x45\n\x12get_system_metrics\x18J \x01(\x0b\x32\'.wandb_internal.GetSystemMetricsRequestH\x00\x12+\n\x04sync\x18L \x01(\x0b\x32\x1b.wandb_internal.SyncRequestH\x00\x12\x34\n\tjob_input\x18M \x01(\x0b\x32\x1f.wandb_internal.JobInputRequestH\x00\x12\x46\n\x12\x63redentials_update\x18N \x01(\x0b\x32(.wandb_internal.CredentialsUpdateRequestH\x00\x12\x31\n\x07\x63leanup\x18O \x01(\x0b\x32\x1e.wandb_internal.CleanupRequestH\x00\x12@\n\x0fsettings_update\x18P \x01(\x0b\x32%.wandb_internal.SettingsUpdateRequestH\x00\x12\x38\n\x0brun_updated\x18Q \x01(\x0b\x32!.wandb_internal.RunUpdatedRequestH\x00\x12<\n\rartifact_wait\x18R \x01(\x0b\x32#.wandb_internal.ArtifactWaitRequestH\x00\x12\x41\n\x10list_run_outputs\x18S \x01(\x0b\x32%.wandb_internal.ListRunOutputsRequestH\x00\x12\x34\n\tabort_run\x18T \x01(\x0b\x32\x1f.wandb_internal.AbortRunRequestH\x00\x12;\n\x0c\x62\x61\x63kpressure\x18U \x01(\x0b\x32#.wandb_internal.BackpressureRequestH\x00\x12M\n\x16prepare_for_preemption\x18V \x01(\x0b\x32+.wandb_internal.PrepareForPreemptionRequestH\x00\x12:\n\x0cget_settings\x18W \x01(\x0b\x32\".wandb_internal.GetSettingsRequestH\x00\x12@\n\x0f\x61rtifact_cancel\x18X \x01(\x0b\x32%.wandb_internal.ArtifactCancelRequestH\x00\x12\x38\n\x0bstream_ping\x18Y \x01(\x0b\x32!.wandb_internal.StreamPingRequestH\x00\x12\x39\n\x0btest_inject\x18\xe8\x07 \x01(\x0b\x32!.wandb_internal.TestInjectRequestH\x00\x42\x0e\n\x0crequest_typeJ\x04\x08K\x10L\"\xf1\x11\n\x08Response\x12?\n\x12keepalive_response\x18\x12 \x01(\x0b\x32!.wandb_internal.KeepaliveResponseH\x00\x12\x42\n\x14stop_status_response\x18\x13 \x01(\x0b\x32\".wandb_internal.StopStatusResponseH\x00\x12H\n\x17network_status_response\x18\x14 \x01(\x0b\x32%.wandb_internal.NetworkStatusResponseH\x00\x12\x37\n\x0elogin_response\x18\x18 \x01(\x0b\x32\x1d.wandb_internal.LoginResponseH\x00\x12\x42\n\x14get_summary_response\x18\x19 \x01(\x0b\x32\".wandb_internal.GetSummaryResponseH\x00\x12>\n\x12poll_exit_response\x18\x1a \x01(\x0b\x32 .wandb_internal.PollExitResponseH\x00\x12J\n\x18sampled_history_response\x18\x1b \x01(\x0b\x32&.wandb_internal.SampledHistoryResponseH\x00\x12>\n\x12run_start_response\x18\x1c \x01(\x0b\x32 .wandb_internal.RunStartResponseH\x00\x12\x46\n\x16\x63heck_version_response\x18\x1d \x01(\x0b\x32$.wandb_internal.CheckVersionResponseH\x00\x12\x44\n\x15log_artifact_response\x18\x1e \x01(\x0b\x32#.wandb_internal.LogArtifactResponseH\x00\x12N\n\x1a\x64ownload_artifact_response\x18\x1f \x01(\x0b\x32(.wandb_internal.DownloadArtifactResponseH\x00\x12@\n\x13run_status_response\x18# \x01(\x0b\x32!.wandb_internal.RunStatusResponseH\x00\x12\x39\n\x0f\x63\x61ncel_response\x18$ \x01(\x0b\x32\x1e.wandb_internal.CancelResponseH\x00\x12N\n\x1ainternal_messages_response\x18% \x01(\x0b\x32(.wandb_internal.InternalMessagesResponseH\x00\x12=\n\x11shutdown_response\x18@ \x01(\x0b\x32 .wandb_internal.ShutdownResponseH\x00\x12\x39\n\x0f\x61ttach_response\x18\x41 \x01(\x0b\x32\x1e.wandb_internal.AttachResponseH\x00\x12\x39\n\x0fstatus_response\x18\x42 \x01(\x0b\x32\x1e.wandb_internal.StatusResponseH\x00\x12\x42\n\x14server_info_response\x18\x43 \x01(\x0b\x32\".wandb_internal.ServerInfoResponseH\x00\x12<\n\x11job_info_response\x18\x44 \x01(\x0b\x32\x1f.wandb_internal.JobInfoResponseH\x00\x12O\n\x1bget_system_metrics_response\x18\x45 \x01(\x0b\x32(.wandb_internal.GetSystemMetricsResponseH\x00\x12\x35\n\rsync_response\x18\x46 \x01(\x0b\x32\x1c.wandb_internal.SyncResponseH\x00\x12;\n\x10\x63leanup_response\x18G \x01(\x0b\x32\x1f.wandb_internal.CleanupResponseH\x00\x12J\n\x18upload_progress_response\x18H \x01(\x0b\x32&.wandb_internal.UploadProgressResponseH\x00\x12\x46\n\x16\x61rtifact_wait_response\x18I \x01(\x0b\x32$.wandb_internal.ArtifactWaitResponseH\x00\x12\x44\n\x15user_message_response\x18J \x01(\x0b\x32#.wandb_internal.UserMessageResponseH\x00\x12:\n\x10run_url_response\x18K \x01(\x0b\x32\x1e.wandb_internal.RunUrlResponseH\x00\x12K\n\x19list_run_outputs_response\x18L \x01(\x0b\x32&.wandb_internal.ListRunOutputsResponseH\x00\x12>\n\x12\x61\x62ort_run_response\x18M \x01(\x0b\x32 .wandb_internal.AbortRunResponseH\x00\x12W\n\x1fprepare_for_preemption_response\x18N \x01(\x0b\x32,.wandb_internal.PrepareForPreemptionResponseH\x00\x12\x44\n\x15get_settings_response\x18O \x01(\x0b\x32#.wandb_internal.GetSettingsResponseH\x00\x12J\n\x18\x61rtifact_cancel_response\x18P \x01(\x0b\x32&.wandb_internal.ArtifactCancelResponseH\x00\x12\x42\n\x14stream_ping_response\x18Q \x01(\x0b\x32\".wandb_internal.StreamPingResponseH\x00\x12\x43\n\x14test_inject_response\x18\xe8\x07 \x01(\x0b\x32\".wandb_internal.TestInjectResponseH\x00\x42\x0f\n\rresponse_type\"\xc0\x02\n\x0c\x44\x65\x66\x65rRequest\x12\x36\n\x05state\x18\x01 \x01(\x0e\x32\'.wandb_internal.DeferRequest.DeferState\"\xf7\x01\n\nDeferState\x12\t\n\x05\x42\x45GIN\x10\x00\x12\r\n\tFLUSH_RUN\x10\x01\x12\x0f\n\x0b\x46LUSH_STATS\x10\x02\x12\x19\n\x15\x46LUSH_PARTIAL_HISTORY\x10\x03\x12\x0c\n\x08\x46LUSH_TB\x10\x04\x12\r\n\tFLUSH_SUM\x10\x05\x12\x13\n\x0f\x46LUSH_DEBOUNCER\x10\x06\x12\x10\n\x0c\x46LUSH_OUTPUT\x10\x07\x12\r\n\tFLUSH_JOB\x10\x08\x12\r\n\tFLUSH_DIR\x10\t\x12\x0c\n\x08\x46LUSH_FP\x10\n\x12\x0b\n\x07JOIN_FP\x10\x0b\x12\x0c\n\x08\x46LUSH_FS\x10\x0c\x12\x0f\n\x0b\x46LUSH_FINAL\x10\r\x12\x07\n\x03\x45ND\x10\x0e\"<\n\x0cPauseRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x0f\n\rPauseResponse\"=\n\rResumeRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x10\n\x0eResumeResponse\"M\n\x0cLoginRequest\x12\x0f\n\x07\x61pi_key\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"&\n\rLoginResponse\x12\x15\n\ractive_entity\x18\x01 \x01(\t\"A\n\x11GetSummaryRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"?\n\x12GetSummaryResponse\x12)\n\x04item\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.SummaryItem\"G\n\x17GetSystemMetricsRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"R\n\x12SystemMetricSample\x12-\n\ttimestamp\x18\x01 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\r\n\x05value\x18\x02 \x01(\x02\"I\n\x13SystemMetricsBuffer\x12\x32\n\x06record\x18\x01 \x03(\x0b\x32\".wandb_internal.SystemMetricSample\"\xca\x01\n\x18GetSystemMetricsResponse\x12S\n\x0esystem_metrics\x18\x01 \x03(\x0b\x32;.wandb_internal.GetSystemMetricsResponse.SystemMetricsEntry\x1aY\n\x12SystemMetricsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x32\n\x05value\x18\x02 \x01(\x0b\x32#.wandb_internal.SystemMetricsBuffer:\x02\x38\x01\"=\n\rStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xbf\x01\n\x0eStatusResponse\x12\x17\n\x0frun_should_stop\x18\x01 \x01(\x08\x12\x33\n\rexit_progress\x18\x02 \x01(\x0b\x32\x1c.wandb_internal.ExitProgress\x12\x36\n\x0b\x64iagnostics\x18\x03 \x01(\x0b\x32!.wandb_internal.StreamDiagnostics\x12\'\n\x05paths\x18\x04 \x01(\x0b\x32\x18.wandb_internal.RunPaths\"g\n\x08RunPaths\x12\x11\n\tfiles_dir\x18\x01 \x01(\t\x12\x0f\n\x07log_dir\x18\x02 \x01(\t\x12\x11\n\tsync_file\x18\x03 \x01(\t\x12\x11\n\tfallbacks\x18\x04 \x03(\t\x12\x11\n\tdebug_log\x18\x05 \x01(\t\"\xff\x06\n\x11StreamDiagnostics\x12L\n\x0e\x63hannel_depths\x18\x01 \x03(\x0b\x32\x34.wandb_internal.StreamDiagnostics.ChannelDepthsEntry\x12\x12\n\ngoroutines\x18\x02 \x01(\x05\x12\x19\n\x11heap_in_use_bytes\x18\x03 \x01(\x04\x12\x17\n\x0fpending_uploads\x18\x04 \x01(\x05\x12!\n\x19queued_filestream_updates\x18\x05 \x01(\x05\x12 \n\x18unacked_filestream_lines\x18\x06 \x01(\x05\x12?\n\x07retries\x18\x07 \x03(\x0b\x32..wandb_internal.StreamDiagnostics.RetriesEntry\x12\x1e\n\x16\x63oalesced_history_rows\x18\x08 \x01(\x03\x12 \n\x18untracked_history_values\x18\t \x01(\x03\x12\x39\n\x10pipeline_latency\x18\n \x01(\x0b\x32\x1f.wandb_internal.PipelineLatency\x12;\n\x05polls\x18\x0b \x03(\x0b\x32,.wandb_internal.StreamDiagnostics.PollsEntry\x12\x35\n\x0ehandler_memory\x18\x0c \x01(\x0b\x32\x1d.wandb_internal.HandlerMemory\x12W\n\x14unknown_record_types\x18\r \x03(\x0b\x32\x39.wandb_internal.StreamDiagnostics.UnknownRecordTypesEntry\x1a\x34\n\x12\x43hannelDepthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\x1aJ\n\x0cRetriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.wandb_internal.RetryStats:\x02\x38\x01\x1aG\n\nPollsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12(\n\x05value\x18\x02 \x01(\x0b\x32\x19.wandb_internal.PollStats:\x02\x38\x01\x1a\x39\n\x17UnknownRecordTypesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\xff\x01\n\rHandlerMemory\x12\x14\n\x0csampled_keys\x18\x01 \x01(\x05\x12\x14\n\x0csummary_keys\x18\x02 \x01(\x05\x12\x1c\n\x14partial_history_keys\x18\x03 \x01(\x05\x12\x1a\n\x12metric_definitions\x18\x04 \x01(\x05\x12\x1c\n\x14\x65victed_sampled_keys\x18\x05 \x01(\x03\x12\x1e\n\x16refused_summary_values\x18\x06 \x01(\x03\x12&\n\x1erefused_partial_history_values\x18\x07 \x01(\x03\x12\"\n\x1arefused_metric_definitions\x18\x08 \x01(\x03\".\n\tPollStats\x12\x10\n\x08requests\x18\x01 \x01(\x05\x12\x0f\n\x07queries\x18\x02 \x01(\x05\"v\n\x0fPipelineLatency\x12/\n\tpersisted\x18\x01 \x01(\x0b\x32\x1c.wandb_internal.LatencyStats\x12\x32\n\x0c\x61\x63knowledged\x18\x02 \x01(\x0b\x32\x1c.wandb_internal.LatencyStats\"I\n\x0cLatencyStats\x12\x0f\n\x07samples\x18\x01 \x01(\x05\x12\x13\n\x0bp50_seconds\x18\x02 \x01(\x01\x12\x13\n\x0bp95_seconds\x18\x03 \x01(\x01\"\xa4\x01\n\nRetryStats\x12\r\n\x05total\x18\x01 \x01(\x05\x12\x13\n\x0blast_minute\x18\x02 \x01(\x05\x12?\n\x0b\x62y_category\x18\x03 \x03(\x0b\x32*.wandb_internal.RetryStats.ByCategoryEntry\x1a\x31\n\x0f\x42yCategoryEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"A\n\x11StopStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"-\n\x12StopStatusResponse\x12\x17\n\x0frun_should_stop\x18\x01 \x01(\x08\"D\n\x14NetworkStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"P\n\x15NetworkStatusResponse\x12\x37\n\x11network_responses\x18\x01 \x03(\x0b\x32\x1c.wandb_internal.HttpResponse\"D\n\x0cHttpResponse\x12\x18\n\x10http_status_code\x18\x01 \x01(\x05\x12\x1a\n\x12http_response_text\x18\x02 \x01(\t\"G\n\x17InternalMessagesRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"N\n\x18InternalMessagesResponse\x12\x32\n\x08messages\x18\x01 \x01(\x0b\x32 .wandb_internal.InternalMessages\"#\n\x10InternalMessages\x12\x0f\n\x07warning\x18\x01 \x03(\t\"?\n\x0fPollExitRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xf2\x02\n\x10PollExitResponse\x12\x0c\n\x04\x64one\x18\x01 \x01(\x08\x12\x32\n\x0b\x65xit_result\x18\x02 \x01(\x0b\x32\x1d.wandb_internal.RunExitResult\x12\x35\n\x0cpusher_stats\x18\x03 \x01(\x0b\x32\x1f.wandb_internal.FilePusherStats\x12/\n\x0b\x66ile_counts\x18\x04 \x01(\x0b\x32\x1a.wandb_internal.FileCounts\x12\x33\n\rexit_progress\x18\x05 \x01(\x0b\x32\x1c.wandb_internal.ExitProgress\x12\x17\n\x0f\x66ilestream_done\x18\x06 \x01(\x08\x12\x37\n\x0fmirror_progress\x18\x07 \x01(\x0b\x32\x1e.wandb_internal.MirrorProgress\x12-\n\x05\x66iles\x18\x08 \x01(\x0b\x32\x1e.wandb_internal.RunFilesReport\"\xcc\x01\n\x0eMirrorProgress\x12\x10\n\x08\x62\x61se_url\x18\x01 \x01(\t\x12\x35\n\x0cpusher_stats\x18\x02 \x01(\x0b\x32\x1f.wandb_internal.FilePusherStats\x12\x33\n\rexit_progress\x18\x03 \x01(\x0b\x32\x1c.wandb_internal.ExitProgress\x12\x0c\n\x04\x64one\x18\x04 \x01(\x08\x12\x15\n\rerror_message\x18\x05 \x01(\t\x12\x17\n\x0f\x64ropped_records\x18\x06 \x01(\x03\"\x90\x01\n\x0c\x45xitProgress\x12\x36\n\x05state\x18\x01 \x01(\x0e\x32\'.wandb_internal.DeferRequest.DeferState\x12\x13\n\x0b\x64\x65scription\x18\x02 \x01(\t\x12\x18\n\x10seconds_in_state\x18\x03 \x01(\x01\x12\x19\n\x11pending_artifacts\x18\x04 \x01(\x05\"@\n\rSyncOverwrite\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0e\n\x06\x65ntity\x18\x02 \x01(\t\x12\x0f\n\x07project\x18\x03 \x01(\t\"\x1e\n\x08SyncSkip\x12\x12\n\noutput_raw\x18\x01 \x01(\x08\"\x13\n\x11SenderMarkRequest\"\xaf\x01\n\x0bSyncRequest\x12\x14\n\x0cstart_offset\x18\x01 \x01(\x03\x12\x14\n\x0c\x66inal_offset\x18\x02 \x01(\x03\x12\x30\n\toverwrite\x18\x03 \x01(\x0b\x32\x1d.wandb_internal.SyncOverwrite\x12&\n\x04skip\x18\x04 \x01(\x0b\x32\x18.wandb_internal.SyncSkip\x12\x1a\n\x12\x61llow_modified_log\x18\x05 \x01(\x08\"E\n\x0cSyncResponse\x12\x0b\n\x03url\x18\x01 \x01(\t\x12(\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\"O\n\x0e\x43leanupRequest\x12\x0f\n\x07\x64ry_run\x18\x01 \x01(\x08\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x95\x01\n\x0f\x43leanupResponse\x12.\n\x07removed\x18\x01 \x03(\x0b\x32\x1d.wandb_internal.RemovedRunDir\x12\x17\n\x0freclaimed_bytes\x18\x02 \x01(\x03\x12\x0f\n\x07\x64ry_run\x18\x03 \x01(\x08\x12(\n\x05\x65rror\x18\x04 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\"L\n\rRemovedRunDir\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x0c\n\x04path\x18\x02 \x01(\t\x12\r\n\x05\x62ytes\x18\x03 \x01(\x03\x12\x0e\n\x06reason\x18\x04 \x01(\t\"?\n\x11SenderReadRequest\x12\x14\n\x0cstart_offset\x18\x01 \x01(\x03\x12\x14\n\x0c\x66inal_offset\x18\x02 \x01(\x03\"m\n\x13StatusReportRequest\x12\x12\n\nrecord_num\x18\x01 \x01(\x03\x12\x13\n\x0bsent_offset\x18\x02 \x01(\x03\x12-\n\tsync_time\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"F\n\x14SummaryRecordRequest\x12.\n\x07summary\x18\x01 \x01(\x0b\x32\x1d.wandb_internal.SummaryRecord\"L\n\x16TelemetryRecordRequest\x12\x32\n\ttelemetry\x18\x01 \x01(\x0b\x32\x1f.wandb_internal.TelemetryRecord\"A\n\x11ServerInfoRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xb6\x01\n\x12ServerInfoResponse\x12-\n\nlocal_info\x18\x01 \x01(\x0b\x32\x19.wandb_internal.LocalInfo\x12\x37\n\x0fserver_messages\x18\x02 \x01(\x0b\x32\x1e.wandb_internal.ServerMessages\x12\x38\n\x0c\x63\x61pabilities\x18\x03 \x01(\x0b\x32\".wandb_internal.ServerCapabilities\"\xdb\x01\n\x12ServerCapabilities\x12\x16\n\x0eserver_version\x18\x01 \x01(\t\x12\x41\n\ndeployment\x18\x02 \x01(\x0e\x32-.wandb_internal.ServerCapabilities.Deployment\x12$\n\x1cmax_filestream_request_bytes\x18\x03 \x01(\x05\x12\x14\n\x0c\x63ore_version\x18\x04 \x01(\t\".\n\nDeployment\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x08\n\x04SAAS\x10\x01\x12\t\n\x05LOCAL\x10\x02\"=\n\x0eServerMessages\x12+\n\x04item\x18\x01 \x03(\x0b\x32\x1d.wandb_internal.ServerMessage\"e\n\rServerMessage\x12\x12\n\nplain_text\x18\x01 \x01(\t\x12\x10\n\x08utf_text\x18\x02 \x01(\t\x12\x11\n\thtml_text\x18\x03 \x01(\t\x12\x0c\n\x04type\x18\x04 \x01(\t\x12\r\n\x05level\x18\x05 \x01(\x05\"|\n\nFileCounts\x12\x13\n\x0bwandb_count\x18\x01 \x01(\x05\x12\x13\n\x0bmedia_count\x18\x02 \x01(\x05\x12\x16\n\x0e\x61rtifact_count\x18\x03 \x01(\x05\x12\x13\n\x0bother_count\x18\x04 \x01(\x05\x12\x17\n\x0funchanged_count\x18\x05 \x01(\x05\"U\n\x0f\x46ilePusherStats\x12\x16\n\x0euploaded_bytes\x18\x01 \x01(\x03\x12\x13\n\x0btotal_bytes\x18\x02 \x01(\x03\x12\x15\n\rdeduped_bytes\x18\x03 \x01(\x03\"\x1e\n\rFilesUploaded\x12\r\n\x05\x66iles\x18\x01 \x03(\t\"\xf4\x01\n\x17\x46ileTransferInfoRequest\x12\x42\n\x04type\x18\x01 \x01(\x0e\x32\x34.wandb_internal.FileTransferInfoRequest.TransferType\x12\x0c\n\x04path\x18\x02 \x01(\t\x12\x0b\n\x03url\x18\x03 \x01(\t\x12\x0c\n\x04size\x18\x04 \x01(\x03\x12\x11\n\tprocessed\x18\x05 \x01(\x03\x12/\n\x0b\x66ile_counts\x18\x06 \x01(\x0b\x32\x1a.wandb_internal.FileCounts\"(\n\x0cTransferType\x12\n\n\x06Upload\x10\x00\x12\x0c\n\x08\x44ownload\x10\x01\"1\n\tLocalInfo\x12\x0f\n\x07version\x18\x01 \x01(\t\x12\x13\n\x0bout_of_date\x18\x02 \x01(\x08\"?\n\x0fShutdownRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x12\n\x10ShutdownResponse\"P\n\rAttachRequest\x12\x11\n\tattach_id\x18\x14 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"w\n\x0e\x41ttachResponse\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12(\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\x12\x13\n\x0bprovisional\x18\x03 \x01(\x08\"\xd5\x02\n\x11TestInjectRequest\x12\x13\n\x0bhandler_exc\x18\x01 \x01(\x08\x12\x14\n\x0chandler_exit\x18\x02 \x01(\x08\x12\x15\n\rhandler_abort\x18\x03 \x01(\x08\x12\x12\n\nsender_exc\x18\x04 \x01(\x08\x12\x13\n\x0bsender_exit\x18\x05 \x01(\x08\x12\x14\n\x0csender_abort\x18\x06 \x01(\x08\x12\x0f\n\x07req_exc\x18\x07 \x01(\x08\x12\x10\n\x08req_exit\x18\x08 \x01(\x08\x12\x11\n\treq_abort\x18\t \x01(\x08\x12\x10\n\x08resp_exc\x18\n \x01(\x08\x12\x11\n\tresp_exit\x18\x0b \x01(\x08\x12\x12\n\nresp_abort\x18\x0c \x01(\x08\x12\x10\n\x08msg_drop\x18\r \x01(\x08\x12\x10\n\x08msg_hang\x18\x0e \x01(\x08\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x14\n\x12TestInjectResponse\"\x1e\n\rHistoryAction\x12\r\n\x05\x66lush\x18\x01 \x01(\x08\"\xca\x01\n\x15PartialHistoryRequest\x12)\n\x04item\x18\x01 \x03(\x0b\x32\x1b.wandb_internal.HistoryItem\x12)\n\x04step\x18\x02 \x01(\x0b\x32\x1b.wandb_internal.HistoryStep\x12-\n\x06\x61\x63tion\x18\x03 \x01(\x0b\x32\x1d.wandb_internal.HistoryAction\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x18\n\x16PartialHistoryResponse\"d\n\x15SampledHistoryRequest\x12\x0c\n\x04keys\x18\x01 \x03(\t\x12\x0f\n\x07samples\x18\x02 \x01(\x05\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xda\x01\n\x12SampledHistoryItem\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nnested_key\x18\x02 \x03(\t\x12\x14\n\x0cvalues_float\x18\x03 \x03(\x02\x12\x12\n\nvalues_int\x18\x04 \x03(\x03\x12\r\n\x05steps\x18\x05 \x03(\x03\x12@\n\nvalue_type\x18\x06 \x01(\x0e\x32,.wandb_internal.SampledHistoryItem.ValueType\"(\n\tValueType\x12\n\n\x06NUMBER\x10\x00\x12\x0f\n\x0bNON_NUMERIC\x10\x01\"J\n\x16SampledHistoryResponse\x12\x30\n\x04item\x18\x01 \x03(\x0b\x32\".wandb_internal.SampledHistoryItem\"@\n\x10RunStatusRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"x\n\x11RunStatusResponse\x12\x18\n\x10sync_items_total\x18\x01 \x01(\x03\x12\x1a\n\x12sync_items_pending\x18\x02 \x01(\x03\x12-\n\tsync_time\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"g\n\x0fRunStartRequest\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x12\n\x10RunStartResponse\"\\\n\x13\x43heckVersionRequest\x12\x17\n\x0f\x63urrent_version\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"]\n\x14\x43heckVersionResponse\x12\x17\n\x0fupgrade_message\x18\x01 \x01(\t\x12\x14\n\x0cyank_message\x18\x02 \x01(\t\x12\x16\n\x0e\x64\x65lete_message\x18\x03 \x01(\t\">\n\x0eJobInfoRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"6\n\x0fJobInfoResponse\x12\x12\n\nsequenceId\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\"\x9f\x01\n\x12LogArtifactRequest\x12\x30\n\x08\x61rtifact\x18\x01 \x01(\x0b\x32\x1e.wandb_internal.ArtifactRecord\x12\x14\n\x0chistory_step\x18\x02 \x01(\x03\x12\x13\n\x0bstaging_dir\x18\x03 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"A\n\x13LogArtifactResponse\x12\x13\n\x0b\x61rtifact_id\x18\x01 \x01(\t\x12\x15\n\rerror_message\x18\x02 \x01(\t\"o\n\x13\x41rtifactWaitRequest\x12\x11\n\tclient_id\x18\x01 \x01(\t\x12\x17\n\x0ftimeout_seconds\x18\x02 \x01(\x01\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x85\x02\n\x14\x41rtifactWaitResponse\x12\x39\n\x05state\x18\x01 \x01(\x0e\x32*.wandb_internal.ArtifactWaitResponse.State\x12\x13\n\x0b\x61rtifact_id\x18\x02 \x01(\t\x12\x32\n\rversion_index\x18\x03 \x01(\x0b\x32\x1b.google.protobuf.Int32Value\x12\x0e\n\x06\x64igest\x18\x04 \x01(\t\x12\x15\n\rerror_message\x18\x05 \x01(\t\"B\n\x05State\x12\x0f\n\x0bIN_PROGRESS\x10\x00\x12\r\n\tCOMMITTED\x10\x01\x12\n\n\x06\x46\x41ILED\x10\x02\x12\r\n\tCANCELLED\x10\x03\"X\n\x15\x41rtifactCancelRequest\x12\x11\n\tclient_id\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xb0\x01\n\x16\x41rtifactCancelResponse\x12;\n\x05state\x18\x01 \x01(\x0e\x32,.wandb_internal.ArtifactCancelResponse.State\x12\x15\n\rerror_message\x18\x02 \x01(\t\"B\n\x05State\x12\r\n\tCANCELLED\x10\x00\x12\x15\n\x11\x41LREADY_COMMITTED\x10\x01\x12\x13\n\x0fNOT_IN_PROGRESS\x10\x02\"A\n\x11StreamPingRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x9b\x02\n\x12StreamPingResponse\x12,\n\x06health\x18\x01 \x01(\x0b\x32\x1c.wandb_internal.StreamHealth\x12M\n\x0elast_processed\x18\x02 \x03(\x0b\x32\x35.wandb_internal.StreamPingResponse.LastProcessedEntry\x12\x36\n\x0b\x64iagnostics\x18\x03 \x01(\x0b\x32!.wandb_internal.StreamDiagnostics\x1aP\n\x12LastProcessedEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp:\x02\x38\x01\"[\n\x0cStreamHealth\x12\x11\n\tstream_id\x18\x01 \x01(\t\x12\x38\n\x14last_backend_contact\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"\x8e\x01\n\x16UploadProgressResponse\x12\x14\n\x0cmailbox_slot\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x12\n\ndone_bytes\x18\x03 \x01(\x03\x12\x13\n\x0btotal_bytes\x18\x04 \x01(\x03\x12\x12\n\ndone_files\x18\x05 \x01(\x05\x12\x13\n\x0btotal_files\x18\x06 \x01(\x05\"\x8b\x01\n\x13UserMessageResponse\x12\x38\n\x05level\x18\x01 \x01(\x0e\x32).wandb_internal.UserMessageResponse.Level\x12\x0f\n\x07message\x18\x02 \x01(\t\")\n\x05Level\x12\x08\n\x04INFO\x10\x00\x12\x0b\n\x07WARNING\x10\x01\x12\t\n\x05\x45RROR\x10\x02\"s\n\x0eRunUrlResponse\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0e\n\x06\x65ntity\x18\x02 \x01(\t\x12\x0f\n\x07project\x18\x03 \x01(\t\x12\x0e\n\x06run_id\x18\x04 \x01(\t\x12\x0b\n\x03url\x18\x05 \x01(\t\x12\x12\n\nlocal_path\x18\x06 \x01(\t\"E\n\x15ListRunOutputsRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\xab\x01\n\x16ListRunOutputsResponse\x12,\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x1d.wandb_internal.RunFileReport\x12\x34\n\tartifacts\x18\x02 \x03(\x0b\x32!.wandb_internal.RunArtifactOutput\x12-\n\x05media\x18\x03 \x03(\x0b\x32\x1e.wandb_internal.RunMediaOutput\"\xac\x02\n\x11RunArtifactOutput\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0e\n\x06\x64igest\x18\x03 \x01(\t\x12\x0f\n\x07\x61liases\x18\x04 \x03(\t\x12\x11\n\tclient_id\x18\x05 \x01(\t\x12\x36\n\x05state\x18\x06 \x01(\x0e\x32\'.wandb_internal.RunArtifactOutput.State\x12\x13\n\x0b\x61rtifact_id\x18\x07 \x01(\t\x12\x32\n\rversion_index\x18\x08 \x01(\x0b\x32\x1b.google.protobuf.Int32Value\x12\x15\n\rerror_message\x18\t \x01(\t\"/\n\x05State\x12\x0b\n\x07PENDING\x10\x00\x12\r\n\tCOMMITTED\x10\x01\x12\n\n\x06\x46\x41ILED\x10\x02\"e\n\x0eRunMediaOutput\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0b\n\x03key\x18\x03 \x01(\t\x12\x0c\n\x04step\x18\x04 \x01(\x03\x12\x0c\n\x04size\x18\x05 \x01(\x03\x12\x0e\n\x06sha256\x18\x06 \x01(\t\"?\n\x0f\x41\x62ortRunRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"M\n\x10\x41\x62ortRunResponse\x12(\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\x12\x0f\n\x07\x64\x65leted\x18\x02 \x01(\x08\"d\n\x1bPrepareForPreemptionRequest\x12\x17\n\x0ftimeout_seconds\x18\x01 \x01(\x01\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x9e\x01\n\x1cPrepareForPreemptionResponse\x12(\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x19.wandb_internal.ErrorInfo\x12\x0f\n\x07\x64urable\x18\x02 \x01(\x08\x12\x0f\n\x07\x66lushed\x18\x03 \x01(\x08\x12\x32\n\nunfinished\x18\x04 \x01(\x0b\x32\x1e.wandb_internal.UnfinishedWork\"B\n\x12GetSettingsRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\\\n\x13GetSettingsResponse\x12\x32\n\x08settings\x18\x01 \x03(\x0b\x32 .wandb_internal.EffectiveSetting\x12\x11\n\tdebug_log\x18\x02 \x01(\t\"\xbc\x01\n\x10\x45\x66\x66\x65\x63tiveSetting\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x12\n\nvalue_json\x18\x02 \x01(\t\x12\x37\n\x06source\x18\x03 \x01(\x0e\x32\'.wandb_internal.EffectiveSetting.Source\"N\n\x06Source\x12\x0b\n\x07\x44\x45\x46\x41ULT\x10\x00\x12\x0b\n\x07PROJECT\x10\x01\x12\n\n\x06\x43LIENT\x10\x02\x12\x07\n\x03\x45NV\x10\x03\x12\t\n\x05NETRC\x10\x04\x12\n\n\x06SERVER\x10\x05\"\xbe\x01\n\x17\x44ownloadArtifactRequest\x12\x13\n\x0b\x61rtifact_id\x18\x01 \x01(\t\x12\x15\n\rdownload_root\x18\x02 \x01(\t\x12 \n\x18\x61llow_missing_references\x18\x04 \x01(\x08\x12\x12\n\nskip_cache\x18\x05 \x01(\x08\x12\x13\n\x0bpath_prefix\x18\x06 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"1\n\x18\x44ownloadArtifactResponse\x12\x15\n\rerror_message\x18\x01 \x01(\t\"@\n\x10KeepaliveRequest\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x13\n\x11KeepaliveResponse\"q\n\x0c\x41rtifactInfo\x12\x10\n\x08\x61rtifact\x18\x01 \x01(\t\x12\x12\n\nentrypoint\x18\x02 \x03(\t\x12\x10\n\x08notebook\x18\x03 \x01(\x08\x12\x15\n\rbuild_context\x18\x04 \x01(\t\x12\x12\n\ndockerfile\x18\x05 \x01(\t\")\n\x07GitInfo\x12\x0e\n\x06remote\x18\x01 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x02 \x01(\t\"\x87\x01\n\tGitSource\x12)\n\x08git_info\x18\x01 \x01(\x0b\x32\x17.wandb_internal.GitInfo\x12\x12\n\nentrypoint\x18\x02 \x03(\t\x12\x10\n\x08notebook\x18\x03 \x01(\x08\x12\x15\n\rbuild_context\x18\x04 \x01(\t\x12\x12\n\ndockerfile\x18\x05 \x01(\t\"\x1c\n\x0bImageSource\x12\r\n\x05image\x18\x01 \x01(\t\"\x8c\x01\n\x06Source\x12&\n\x03git\x18\x01 \x01(\x0b\x32\x19.wandb_internal.GitSource\x12.\n\x08\x61rtifact\x18\x02 \x01(\x0b\x32\x1c.wandb_internal.ArtifactInfo\x12*\n\x05image\x18\x03 \x01(\x0b\x32\x1b.wandb_internal.ImageSource\"k\n\tJobSource\x12\x10\n\x08_version\x18\x01 \x01(\t\x12\x13\n\x0bsource_type\x18\x02 \x01(\t\x12&\n\x06source\x18\x03 \x01(\x0b\x32\x16.wandb_internal.Source\x12\x0f\n\x07runtime\x18\x04 \x01(\t\"V\n\x12PartialJobArtifact\x12\x10\n\x08job_name\x18\x01 \x01(\t\x12.\n\x0bsource_info\x18\x02 \x01(\x0b\x32\x19.wandb_internal.JobSource\"\x9d\x01\n\x11UseArtifactRecord\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x33\n\x07partial\x18\x04 \x01(\x0b\x32\".wandb_internal.PartialJobArtifact\x12+\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1b.wandb_internal._RecordInfo\"\x13\n\x11UseArtifactResult\"R\n\rCancelRequest\x12\x13\n\x0b\x63\x61ncel_slot\x18\x01 \x01(\t\x12,\n\x05_info\x18\xc8\x01 \x01(\x0b\x32\x1c.wandb_internal._RequestInfo\"\x10\n\x0e\x43\x61ncelResponse\"\'\n\x08\x44iskInfo\x12\r\n\x05total\x18\x01 \x01(\x04\x12\x0c\n\x04used\x18\x02 \x01(\x04\"\x1b\n\nMemoryInfo\x12\r\n\x05total\x18\x01 \x01(\x04\"/\n\x07\x43puInfo\x12\r\n\x05\x63ount\x18\x01 \x01(\r\x12\x15\n\rcount_logical\x18\x02 \x01(\r\"\x82\x01\n\x0cGpuAppleInfo\x12\x0f\n\x07gpuType\x18\x01 \x01(\t\x12\x0e\n\x06vendor\x18\x02 \x01(\t\x12\r\n\x05\x63ores\x18\x03 \x01(\r\x12\r\n\x05model\x18\x04 \x01(\t\x12\x19\n\x11performance_cores\x18\x05 \x01(\r\x12\x18\n\x10\x65\x66\x66iciency_cores\x18\x06 \x01(\r\"}\n\rContainerInfo\x12\x0f\n\x07runtime\x18\x01 \x01(\t\x12\x14\n\x0c\x63ontainer_id\x18\x02 \x01(\t\x12\r\n\x05image\x18\x03 \x01(\t\x12\x10\n\x08pod_name\x18\x04 \x01(\t\x12\x11\n\tnamespace\x18\x05 \x01(\t\x12\x11\n\tnode_name\x18\x06 \x01(\t\"3\n\rGpuNvidiaInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x14\n\x0cmemory_total\x18\x02 \x01(\x04\"\x89\x02\n\nGpuAmdInfo\x12\n\n\x02id\x18\x01 \x01(\t\x12\x11\n\tunique_id\x18\x02 \x01(\t\x12\x15\n\rvbios_version\x18\x03 \x01(\t\x12\x19\n\x11performance_level\x18\x04 \x01(\t\x12\x15\n\rgpu_overdrive\x18\x05 \x01(\t\x12\x1c\n\x14gpu_memory_overdrive\x18\x06 \x01(\t\x12\x11\n\tmax_power\x18\x07 \x01(\t\x12\x0e\n\x06series\x18\x08 \x01(\t\x12\r\n\x05model\x18\t \x01(\t\x12\x0e\n\x06vendor\x18\n \x01(\t\x12\x0b\n\x03sku\x18\x0b \x01(\t\x12\x12\n\nsclk_range\x18\x0c \x01(\t\x12\x12\n\nmclk_range\x18\r \x01(\t\"\xf5\t\n\x0fMetadataRequest\x12\n\n\x02os\x18\x01 \x01(\t\x12\x0e\n\x06python\x18\x02 \x01(\t\x12/\n\x0bheartbeatAt\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12-\n\tstartedAt\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06\x64ocker\x18\x05 \x01(\t\x12\x0c\n\x04\x63uda\x18\x06 \x01(\t\x12\x0c\n\x04\x61rgs\x18\x07 \x03(\t\x12\r\n\x05state\x18\x08 \x01(\t\x12\x0f\n\x07program\x18\t \x01(\t\x12\x1b\n\tcode_path\x18\n \x01(\tR\x08\x63odePath\x12*\n\x03git\x18\x0b \x01(\x0b\x32\x1d.wandb_internal.GitRepoRecord\x12\r\n\x05\x65mail\x18\x0c \x01(\t\x12\x0c\n\x04root\x18\r \x01(\t\x12\x0c\n\x04host\x18\x0e \x01(\t\x12\x10\n\x08username\x18\x0f \x01(\t\x12\x12\n\nexecutable\x18\x10 \x01(\t\x12&\n\x0f\x63ode_path_local\x18\x11 \x01(\tR\rcodePathLocal\x12\r\n\x05\x63olab\x18\x12 \x01(\t\x12\x1c\n\tcpu_count\x18\x13 \x01(\rR\tcpu_count\x12,\n\x11\x63pu_count_logical\x18\x14 \x01(\rR\x11\x63pu_count_logical\x12\x15\n\x08gpu_type\x18\x15 \x01(\tR\x03gpu\x12\x1c\n\tgpu_count\x18\x16 \x01(\rR\tgpu_count\x12\x37\n\x04\x64isk\x18\x17 \x03(\x0b\x32).wandb_internal.MetadataRequest.DiskEntry\x12*\n\x06memory\x18\x18 \x01(\x0b\x32\x1a.wandb_internal.MemoryInfo\x12$\n\x03\x63pu\x18\x19 \x01(\x0b\x32\x17.wandb_internal.CpuInfo\x12\x39\n\tgpu_apple\x18\x1a \x01(\x0b\x32\x1c.wandb_internal.GpuAppleInfoR\x08gpuapple\x12=\n\ngpu_nvidia\x18\x1b \x03(\x0b\x32\x1d.wandb_internal.GpuNvidiaInfoR\ngpu_nvidia\x12\x34\n\x07gpu_amd\x18\x1c \x03(\x0b\x32\x1a.wandb_internal.GpuAmdInfoR\x07gpu_amd\x12\x39\n\x05slurm\x18\x1d \x03(\x0b\x32*.wandb_internal.MetadataRequest.SlurmEntry\x12\x41\n\tscheduler\x18\x1e \x03(\x0b\x32..wandb_internal.MetadataRequest.SchedulerEntry\x12\x30\n\tcontainer\x18\x1f \x01(\x0b\x32\x1d.wandb_internal.ContainerInfo\x12\x1f\n\x12\x63lock_skew_seconds\x18  \x01(\x01H\x00\x88\x01\x01\x1a\x45\n\tDiskEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\'\n\x05value\x18\x02 \x01(\x0b\x32\x18.wandb_internal.DiskInfo:\x02\x38\x01\x1a,\n\nSlurmEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x30\n\x0eSchedulerEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x42\x15\n\x13_clock_skew_seconds\"\x8d\x01\n\x15PythonPackagesRequest\x12\x44\n\x07package\x18\x01 \x03(\x0b\x32\x33.wandb_internal.PythonPackagesRequest.PythonPackage\x1a.\n\rPythonPackage\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\"\x1c\n\x0cJobInputPath\x12\x0c\n\x04path\x18\x01 \x03(\t\"\xd6\x01\n\x0eJobInputSource\x12\x44\n\nrun_config\x18\x01 \x01(\x0b\x32..wandb_internal.JobInputSource.RunConfigSourceH\x00\x12?\n\x04\x66ile\x18\x02 \x01(\x0b\x32/.wandb_internal.JobInputSource.ConfigFileSourceH\x00\x1a\x11\n\x0fRunConfigSource\x1a \n\x10\x43onfigFileSource\x12\x0c\n\x04path\x18\x01 \x01(\tB\x08\n\x06source\"\xb1\x01\n\x0fJobInputRequest\x12\x34\n\x0cinput_source\x18\x01 \x01(\x0b\x32\x1e.wandb_internal.JobInputSource\x12\x33\n\rinclude_paths\x18\x02 \x03(\x0b\x32\x1c.wandb_internal.JobInputPath\x12\x33\n\rexclude_paths\x18\x03 \x03(\x0b\x32\x1c.wandb_internal.JobInputPath\"+\n\x18\x43redentialsUpdateRequest\x12\x0f\n\x07\x61pi_key\x18\x01 \x01(\t\"\xcf\x01\n\x15SettingsUpdateRequest\x12<\n\x17upload_bytes_per_second\x18\x01 \x01(\x0b\x32\x1b.google.protobuf.Int64Value\x12\x41\n\x1c\x66ile_stream_bytes_per_second\x18\x02 \x01(\x0b\x32\x1b.google.protobuf.Int64Value\x12\x35\n\x0f\x63onsole_capture\x18\x03 \x01(\x0b\x32\x1c.google.protobuf.StringValue\";\n\x11RunUpdatedRequest\x12&\n\x03run\x18\x01 \x01(\x0b\x32\x19.wandb_internal.RunRecord\"G\n\x13\x42\x61\x63kpressureRequest\x12\x0f\n\x07pending\x18\x01 \x01(\x05\x12\x0c\n\x04slow\x18\x02 \x01(\x08\x12\x11\n\tcongested\x18\x03 \x01(\x08\x62\x06proto3')
 
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, globals())
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'wandb.proto.wandb_internal_pb2', globals())
@@ -25,330 +26,488 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   DESCRIPTOR._options = None
   _GETSYSTEMMETRICSRESPONSE_SYSTEMMETRICSENTRY._options = None
   _GETSYSTEMMETRICSRESPONSE_SYSTEMMETRICSENTRY._serialized_options = b'8\001'
+  _STREAMDIAGNOSTICS_CHANNELDEPTHSENTRY._options = None
+  _STREAMDIAGNOSTICS_CHANNELDEPTHSENTRY._serialized_options = b'8\001'
+  _STREAMDIAGNOSTICS_RETRIESENTRY._options = None
+  _STREAMDIAGNOSTICS_RETRIESENTRY._serialized_options = b'8\001'
+  _STREAMDIAGNOSTICS_POLLSENTRY._options = None
+  _STREAMDIAGNOSTICS_POLLSENTRY._serialized_options = b'8\001'
+  _STREAMDIAGNOSTICS_UNKNOWNRECORDTYPESENTRY._options = None
+  _STREAMDIAGNOSTICS_UNKNOWNRECORDTYPESENTRY._serialized_options = b'8\001'
+  _RETRYSTATS_BYCATEGORYENTRY._options = None
+  _RETRYSTATS_BYCATEGORYENTRY._serialized_options = b'8\001'
+  _STREAMPINGRESPONSE_LASTPROCESSEDENTRY._options = None
+  _STREAMPINGRESPONSE_LASTPROCESSEDENTRY._serialized_options = b'8\001'
   _METADATAREQUEST_DISKENTRY._options = None
   _METADATAREQUEST_DISKENTRY._serialized_options = b'8\001'
   _METADATAREQUEST_SLURMENTRY._options = None
   _METADATAREQUEST_SLURMENTRY._serialized_options = b'8\001'
-  _RECORD._serialized_start=151
-  _RECORD._serialized_end=1331
-  _CONTROL._serialized_start=1334
-  _CONTROL._serialized_end=1502
-  _RESULT._serialized_start=1505
-  _RESULT._serialized_end=2004
-  _FINALRECORD._serialized_start=2006
-  _FINALRECORD._serialized_end=2064
-  _VERSIONINFO._serialized_start=2066
-  _VERSIONINFO._serialized_end=2164
-  _HEADERRECORD._serialized_start=2166
-  _HEADERRECORD._serialized_end=2276
-  _FOOTERRECORD._serialized_start=2278
-  _FOOTERRECORD._serialized_end=2337
-  _RUNRECORD._serialized_start=2340
-  _RUNRECORD._serialized_end=2946
-  _GITREPORECORD._serialized_start=2948
-  _GITREPORECORD._serialized_end=3007
-  _RUNUPDATERESULT._serialized_start=3009
-  _RUNUPDATERESULT._serialized_end=3108
-  _ERRORINFO._serialized_start=3111
-  _ERRORINFO._serialized_end=3283
-  _ERRORINFO_ERRORCODE._serialized_start=3192
-  _ERRORINFO_ERRORCODE._serialized_end=3283
-  _RUNEXITRECORD._serialized_start=3285
-  _RUNEXITRECORD._serialized_end=3381
-  _RUNEXITRESULT._serialized_start=3383
-  _RUNEXITRESULT._serialized_end=3398
-  _RUNPREEMPTINGRECORD._serialized_start=3400
-  _RUNPREEMPTINGRECORD._serialized_end=3466
-  _RUNPREEMPTINGRESULT._serialized_start=3468
-  _RUNPREEMPTINGRESULT._serialized_end=3489
-  _SETTINGSRECORD._serialized_start=3491
-  _SETTINGSRECORD._serialized_end=3596
-  _SETTINGSITEM._serialized_start=3598
-  _SETTINGSITEM._serialized_end=3645
-  _HISTORYSTEP._serialized_start=3647
-  _HISTORYSTEP._serialized_end=3673
-  _HISTORYRECORD._serialized_start=3676
-  _HISTORYRECORD._serialized_end=3822
-  _HISTORYITEM._serialized_start=3824
-  _HISTORYITEM._serialized_end=3890
-  _HISTORYRESULT._serialized_start=3892
-  _HISTORYRESULT._serialized_end=3907
-  _OUTPUTRECORD._serialized_start=3910
-  _OUTPUTRECORD._serialized_end=4130
-  _OUTPUTRECORD_OUTPUTTYPE._serialized_start=4094
-  _OUTPUTRECORD_OUTPUTTYPE._serialized_end=4130
-  _OUTPUTRESULT._serialized_start=4132
-  _OUTPUTRESULT._serialized_end=4146
-  _OUTPUTRAWRECORD._serialized_start=4149
-  _OUTPUTRAWRECORD._serialized_end=4375
-  _OUTPUTRAWRECORD_OUTPUTTYPE._serialized_start=4094
-  _OUTPUTRAWRECORD_OUTPUTTYPE._serialized_end=4130
-  _OUTPUTRAWRESULT._serialized_start=4377
-  _OUTPUTRAWRESULT._serialized_end=4394
-  _METRICRECORD._serialized_start=4397
-  _METRICRECORD._serialized_end=4805
-  _METRICRECORD_METRICGOAL._serialized_start=4739
-  _METRICRECORD_METRICGOAL._serialized_end=4805
-  _METRICRESULT._serialized_start=4807
-  _METRICRESULT._serialized_end=4821
-  _METRICOPTIONS._serialized_start=4823
-  _METRICOPTIONS._serialized_end=4890
-  _METRICCONTROL._serialized_start=4892
-  _METRICCONTROL._serialized_end=4926
-  _METRICSUMMARY._serialized_start=4928
-  _METRICSUMMARY._serialized_end=5039
-  _CONFIGRECORD._serialized_start=5042
-  _CONFIGRECORD._serialized_end=5189
-  _CONFIGITEM._serialized_start=5191
-  _CONFIGITEM._serialized_end=5256
-  _CONFIGRESULT._serialized_start=5258
-  _CONFIGRESULT._serialized_end=5272
-  _SUMMARYRECORD._serialized_start=5275
-  _SUMMARYRECORD._serialized_end=5425
-  _SUMMARYITEM._serialized_start=5427
-  _SUMMARYITEM._serialized_end=5493
-  _SUMMARYRESULT._serialized_start=5495
-  _SUMMARYRESULT._serialized_end=5510
-  _FILESRECORD._serialized_start=5512
-  _FILESRECORD._serialized_end=5612
-  _FILESITEM._serialized_start=5615
-  _FILESITEM._serialized_end=5851
-  _FILESITEM_POLICYTYPE._serialized_start=5746
-  _FILESITEM_POLICYTYPE._serialized_end=5786
-  _FILESITEM_FILETYPE._serialized_start=5788
-  _FILESITEM_FILETYPE._serialized_end=5845
-  _FILESRESULT._serialized_start=5853
-  _FILESRESULT._serialized_end=5866
-  _STATSRECORD._serialized_start=5869
-  _STATSRECORD._serialized_end=6099
-  _STATSRECORD_STATSTYPE._serialized_start=6076
-  _STATSRECORD_STATSTYPE._serialized_end=6099
-  _STATSITEM._serialized_start=6101
-  _STATSITEM._serialized_end=6145
-  _ARTIFACTRECORD._serialized_start=6148
-  _ARTIFACTRECORD._serialized_end=6621
-  _ARTIFACTMANIFEST._serialized_start=6624
-  _ARTIFACTMANIFEST._serialized_end=6812
-  _ARTIFACTMANIFESTENTRY._serialized_start=6815
-  _ARTIFACTMANIFESTENTRY._serialized_end=7022
-  _EXTRAITEM._serialized_start=7024
-  _EXTRAITEM._serialized_end=7068
-  _STORAGEPOLICYCONFIGITEM._serialized_start=7070
-  _STORAGEPOLICYCONFIGITEM._serialized_end=7128
-  _ARTIFACTRESULT._serialized_start=7130
-  _ARTIFACTRESULT._serialized_end=7146
-  _LINKARTIFACTRESULT._serialized_start=7148
-  _LINKARTIFACTRESULT._serialized_end=7168
-  _LINKARTIFACTRECORD._serialized_start=7171
-  _LINKARTIFACTRECORD._serialized_end=7378
-  _TBRECORD._serialized_start=7380
-  _TBRECORD._serialized_end=7484
-  _TBRESULT._serialized_start=7486
-  _TBRESULT._serialized_end=7496
-  _ALERTRECORD._serialized_start=7498
-  _ALERTRECORD._serialized_end=7623
-  _ALERTRESULT._serialized_start=7625
-  _ALERTRESULT._serialized_end=7638
-  _REQUEST._serialized_start=7641
-  _REQUEST._serialized_end=9623
-  _RESPONSE._serialized_start=9626
-  _RESPONSE._serialized_end=11132
-  _DEFERREQUEST._serialized_start=11135
-  _DEFERREQUEST._serialized_end=11455
-  _DEFERREQUEST_DEFERSTATE._serialized_start=11208
-  _DEFERREQUEST_DEFERSTATE._serialized_end=11455
-  _PAUSEREQUEST._serialized_start=11457
-  _PAUSEREQUEST._serialized_end=11517
-  _PAUSERESPONSE._serialized_start=11519
-  _PAUSERESPONSE._serialized_end=11534
-  _RESUMEREQUEST._serialized_start=11536
-  _RESUMEREQUEST._serialized_end=11597
-  _RESUMERESPONSE._serialized_start=11599
-  _RESUMERESPONSE._serialized_end=11615
-  _LOGINREQUEST._serialized_start=11617
-  _LOGINREQUEST._serialized_end=11694
-  _LOGINRESPONSE._serialized_start=11696
-  _LOGINRESPONSE._serialized_end=11734
-  _GETSUMMARYREQUEST._serialized_start=11736
-  _GETSUMMARYREQUEST._serialized_end=11801
-  _GETSUMMARYRESPONSE._serialized_start=11803
-  _GETSUMMARYRESPONSE._serialized_end=11866
-  _GETSYSTEMMETRICSREQUEST._serialized_start=11868
-  _GETSYSTEMMETRICSREQUEST._serialized_end=11939
-  _SYSTEMMETRICSAMPLE._serialized_start=11941
-  _SYSTEMMETRICSAMPLE._serialized_end=12023
-  _SYSTEMMETRICSBUFFER._serialized_start=12025
-  _SYSTEMMETRICSBUFFER._serialized_end=12098
-  _GETSYSTEMMETRICSRESPONSE._serialized_start=12101
-  _GETSYSTEMMETRICSRESPONSE._serialized_end=12303
-  _GETSYSTEMMETRICSRESPONSE_SYSTEMMETRICSENTRY._serialized_start=12214
-  _GETSYSTEMMETRICSRESPONSE_SYSTEMMETRICSENTRY._serialized_end=12303
-  _STATUSREQUEST._serialized_start=12305
-  _STATUSREQUEST._serialized_end=12366
-  _STATUSRESPONSE._serialized_start=12368
-  _STATUSRESPONSE._serialized_end=12409
-  _STOPSTATUSREQUEST._serialized_start=12411
-  _STOPSTATUSREQUEST._serialized_end=12476
-  _STOPSTATUSRESPONSE._serialized_start=12478
-  _STOPSTATUSRESPONSE._serialized_end=12523
-  _NETWORKSTATUSREQUEST._serialized_start=12525
-  _NETWORKSTATUSREQUEST._serialized_end=12593
-  _NETWORKSTATUSRESPONSE._serialized_start=12595
-  _NETWORKSTATUSRESPONSE._serialized_end=12675
-  _HTTPRESPONSE._serialized_start=12677
-  _HTTPRESPONSE._serialized_end=12745
-  _INTERNALMESSAGESREQUEST._serialized_start=12747
-  _INTERNALMESSAGESREQUEST._serialized_end=12818
-  _INTERNALMESSAGESRESPONSE._serialized_start=12820
-  _INTERNALMESSAGESRESPONSE._serialized_end=12898
-  _INTERNALMESSAGES._serialized_start=12900
-  _INTERNALMESSAGES._serialized_end=12935
-  _POLLEXITREQUEST._serialized_start=12937
-  _POLLEXITREQUEST._serialized_end=13000
-  _POLLEXITRESPONSE._serialized_start=13003
-  _POLLEXITRESPONSE._serialized_end=13191
-  _SYNCOVERWRITE._serialized_start=13193
-  _SYNCOVERWRITE._serialized_end=13257
-  _SYNCSKIP._serialized_start=13259
-  _SYNCSKIP._serialized_end=13289
-  _SENDERMARKREQUEST._serialized_start=13291
-  _SENDERMARKREQUEST._serialized_end=13310
-  _SYNCREQUEST._serialized_start=13313
-  _SYNCREQUEST._serialized_end=13460
-  _SYNCRESPONSE._serialized_start=13462
-  _SYNCRESPONSE._serialized_end=13531
-  _SENDERREADREQUEST._serialized_start=13533
-  _SENDERREADREQUEST._serialized_end=13596
-  _STATUSREPORTREQUEST._serialized_start=13598
-  _STATUSREPORTREQUEST._serialized_end=13707
-  _SUMMARYRECORDREQUEST._serialized_start=13709
-  _SUMMARYRECORDREQUEST._serialized_end=13779
-  _TELEMETRYRECORDREQUEST._serialized_start=13781
-  _TELEMETRYRECORDREQUEST._serialized_end=13857
-  _SERVERINFOREQUEST._serialized_start=13859
-  _SERVERINFOREQUEST._serialized_end=13924
-  _SERVERINFORESPONSE._serialized_start=13926
-  _SERVERINFORESPONSE._serialized_end=14050
-  _SERVERMESSAGES._serialized_start=14052
-  _SERVERMESSAGES._serialized_end=14113
-  _SERVERMESSAGE._serialized_start=14115
-  _SERVERMESSAGE._serialized_end=14216
-  _FILECOUNTS._serialized_start=14218
-  _FILECOUNTS._serialized_end=14317
-  _FILEPUSHERSTATS._serialized_start=14319
-  _FILEPUSHERSTATS._serialized_end=14404
-  _FILESUPLOADED._serialized_start=14406
-  _FILESUPLOADED._serialized_end=14436
-  _FILETRANSFERINFOREQUEST._serialized_start=14439
-  _FILETRANSFERINFOREQUEST._serialized_end=14683
-  _FILETRANSFERINFOREQUEST_TRANSFERTYPE._serialized_start=14643
-  _FILETRANSFERINFOREQUEST_TRANSFERTYPE._serialized_end=14683
-  _LOCALINFO._serialized_start=14685
-  _LOCALINFO._serialized_end=14734
-  _SHUTDOWNREQUEST._serialized_start=14736
-  _SHUTDOWNREQUEST._serialized_end=14799
-  _SHUTDOWNRESPONSE._serialized_start=14801
-  _SHUTDOWNRESPONSE._serialized_end=14819
-  _ATTACHREQUEST._serialized_start=14821
-  _ATTACHREQUEST._serialized_end=14901
-  _ATTACHRESPONSE._serialized_start=14903
-  _ATTACHRESPONSE._serialized_end=15001
-  _TESTINJECTREQUEST._serialized_start=15004
-  _TESTINJECTREQUEST._serialized_end=15345
-  _TESTINJECTRESPONSE._serialized_start=15347
-  _TESTINJECTRESPONSE._serialized_end=15367
-  _HISTORYACTION._serialized_start=15369
-  _HISTORYACTION._serialized_end=15399
-  _PARTIALHISTORYREQUEST._serialized_start=15402
-  _PARTIALHISTORYREQUEST._serialized_end=15604
-  _PARTIALHISTORYRESPONSE._serialized_start=15606
-  _PARTIALHISTORYRESPONSE._serialized_end=15630
-  _SAMPLEDHISTORYREQUEST._serialized_start=15632
-  _SAMPLEDHISTORYREQUEST._serialized_end=15701
-  _SAMPLEDHISTORYITEM._serialized_start=15703
-  _SAMPLEDHISTORYITEM._serialized_end=15798
-  _SAMPLEDHISTORYRESPONSE._serialized_start=15800
-  _SAMPLEDHISTORYRESPONSE._serialized_end=15874
-  _RUNSTATUSREQUEST._serialized_start=15876
-  _RUNSTATUSREQUEST._serialized_end=15940
-  _RUNSTATUSRESPONSE._serialized_start=15942
-  _RUNSTATUSRESPONSE._serialized_end=16062
-  _RUNSTARTREQUEST._serialized_start=16064
-  _RUNSTARTREQUEST._serialized_end=16167
-  _RUNSTARTRESPONSE._serialized_start=16169
-  _RUNSTARTRESPONSE._serialized_end=16187
-  _CHECKVERSIONREQUEST._serialized_start=16189
-  _CHECKVERSIONREQUEST._serialized_end=16281
-  _CHECKVERSIONRESPONSE._serialized_start=16283
-  _CHECKVERSIONRESPONSE._serialized_end=16376
-  _JOBINFOREQUEST._serialized_start=16378
-  _JOBINFOREQUEST._serialized_end=16440
-  _JOBINFORESPONSE._serialized_start=16442
-  _JOBINFORESPONSE._serialized_end=16496
-  _LOGARTIFACTREQUEST._serialized_start=16499
-  _LOGARTIFACTREQUEST._serialized_end=16658
-  _LOGARTIFACTRESPONSE._serialized_start=16660
-  _LOGARTIFACTRESPONSE._serialized_end=16725
-  _DOWNLOADARTIFACTREQUEST._serialized_start=16728
-  _DOWNLOADARTIFACTREQUEST._serialized_end=16918
-  _DOWNLOADARTIFACTRESPONSE._serialized_start=16920
-  _DOWNLOADARTIFACTRESPONSE._serialized_end=16969
-  _KEEPALIVEREQUEST._serialized_start=16971
-  _KEEPALIVEREQUEST._serialized_end=17035
-  _KEEPALIVERESPONSE._serialized_start=17037
-  _KEEPALIVERESPONSE._serialized_end=17056
-  _ARTIFACTINFO._serialized_start=17058
-  _ARTIFACTINFO._serialized_end=17171
-  _GITINFO._serialized_start=17173
-  _GITINFO._serialized_end=17214
-  _GITSOURCE._serialized_start=17217
-  _GITSOURCE._serialized_end=17352
-  _IMAGESOURCE._serialized_start=17354
-  _IMAGESOURCE._serialized_end=17382
-  _SOURCE._serialized_start=17385
-  _SOURCE._serialized_end=17525
-  _JOBSOURCE._serialized_start=17527
-  _JOBSOURCE._serialized_end=17634
-  _PARTIALJOBARTIFACT._serialized_start=17636
-  _PARTIALJOBARTIFACT._serialized_end=17722
-  _USEARTIFACTRECORD._serialized_start=17725
-  _USEARTIFACTRECORD._serialized_end=17882
-  _USEARTIFACTRESULT._serialized_start=17884
-  _USEARTIFACTRESULT._serialized_end=17903
-  _CANCELREQUEST._serialized_start=17905
-  _CANCELREQUEST._serialized_end=17987
-  _CANCELRESPONSE._serialized_start=17989
-  _CANCELRESPONSE._serialized_end=18005
-  _DISKINFO._serialized_start=18007
-  _DISKINFO._serialized_end=18046
-  _MEMORYINFO._serialized_start=18048
-  _MEMORYINFO._serialized_end=18075
-  _CPUINFO._serialized_start=18077
-  _CPUINFO._serialized_end=18124
-  _GPUAPPLEINFO._serialized_start=18126
-  _GPUAPPLEINFO._serialized_end=18188
-  _GPUNVIDIAINFO._serialized_start=18190
-  _GPUNVIDIAINFO._serialized_end=18241
-  _GPUAMDINFO._serialized_start=18244
-  _GPUAMDINFO._serialized_end=18509
-  _METADATAREQUEST._serialized_start=18512
-  _METADATAREQUEST._serialized_end=19558
-  _METADATAREQUEST_DISKENTRY._serialized_start=19443
-  _METADATAREQUEST_DISKENTRY._serialized_end=19512
-  _METADATAREQUEST_SLURMENTRY._serialized_start=19514
-  _METADATAREQUEST_SLURMENTRY._serialized_end=19558
-  _PYTHONPACKAGESREQUEST._serialized_start=19561
-  _PYTHONPACKAGESREQUEST._serialized_end=19702
-  _PYTHONPACKAGESREQUEST_PYTHONPACKAGE._serialized_start=19656
-  _PYTHONPACKAGESREQUEST_PYTHONPACKAGE._serialized_end=19702
-  _JOBINPUTPATH._serialized_start=19704
-  _JOBINPUTPATH._serialized_end=19732
-  _JOBINPUTSOURCE._serialized_start=19735
-  _JOBINPUTSOURCE._serialized_end=19949
-  _JOBINPUTSOURCE_RUNCONFIGSOURCE._serialized_start=19888
-  _JOBINPUTSOURCE_RUNCONFIGSOURCE._serialized_end=19905
-  _JOBINPUTSOURCE_CONFIGFILESOURCE._serialized_start=19907
-  _JOBINPUTSOURCE_CONFIGFILESOURCE._serialized_end=19939
-  _JOBINPUTREQUEST._serialized_start=19952
-  _JOBINPUTREQUEST._serialized_end=20129
+  _METADATAREQUEST_SCHEDULERENTRY._options = None
+  _METADATAREQUEST_SCHEDULERENTRY._serialized_options = b'8\001'
+  _RECORD._serialized_start=183
+  _RECORD._serialized_end=1712
+  _CONTROL._serialized_start=1715
+  _CONTROL._serialized_end=1905
+  _RESULT._serialized_start=1908
+  _RESULT._serialized_end=2496
+  _RESULTCHUNK._serialized_start=2498
+  _RESULTCHUNK._serialized_end=2541
+  _FINALRECORD._serialized_start=2543
+  _FINALRECORD._serialized_end=2601
+  _VERSIONINFO._serialized_start=2603
+  _VERSIONINFO._serialized_end=2701
+  _HEADERRECORD._serialized_start=2704
+  _HEADERRECORD._serialized_end=2866
+  _COMPACTIONINFO._serialized_start=2868
+  _COMPACTIONINFO._serialized_end=2984
+  _FOOTERRECORD._serialized_start=2986
+  _FOOTERRECORD._serialized_end=3045
+  _TABLEROWSRECORD._serialized_start=3048
+  _TABLEROWSRECORD._serialized_end=3180
+  _TABLEROW._serialized_start=3182
+  _TABLEROW._serialized_end=3213
+  _CHECKPOINTRECORD._serialized_start=3216
+  _CHECKPOINTRECORD._serialized_end=3798
+  _CHECKPOINTRECORD_METRICAGGREGATE._serialized_start=3603
+  _CHECKPOINTRECORD_METRICAGGREGATE._serialized_end=3798
+  _RUNRECORD._serialized_start=3801
+  _RUNRECORD._serialized_end=4407
+  _GITREPORECORD._serialized_start=4409
+  _GITREPORECORD._serialized_end=4468
+  _RUNUPDATERESULT._serialized_start=4470
+  _RUNUPDATERESULT._serialized_end=4569
+  _ERRORINFO._serialized_start=4572
+  _ERRORINFO._serialized_end=4758
+  _ERRORINFO_ERRORCODE._serialized_start=4653
+  _ERRORINFO_ERRORCODE._serialized_end=4758
+  _RUNEXITRECORD._serialized_start=4760
+  _RUNEXITRECORD._serialized_end=4877
+  _RUNEXITRESULT._serialized_start=4880
+  _RUNEXITRESULT._serialized_end=5281
+  _LATERECORDS._serialized_start=5283
+  _LATERECORDS._serialized_end=5355
+  _RUNFILESREPORT._serialized_start=5357
+  _RUNFILESREPORT._serialized_end=5437
+  _RUNFILEREPORT._serialized_start=5440
+  _RUNFILEREPORT._serialized_end=5662
+  _RUNFILEREPORT_STATUS._serialized_start=5571
+  _RUNFILEREPORT_STATUS._serialized_end=5662
+  _QUOTAEXCEEDED._serialized_start=5664
+  _QUOTAEXCEEDED._serialized_end=5711
+  _SHUTDOWNTIMING._serialized_start=5714
+  _SHUTDOWNTIMING._serialized_end=5957
+  _RUNVERIFICATION._serialized_start=5960
+  _RUNVERIFICATION._serialized_end=6118
+  _VERIFICATIONCOUNTS._serialized_start=6120
+  _VERIFICATIONCOUNTS._serialized_end=6218
+  _UNFINISHEDWORK._serialized_start=6220
+  _UNFINISHEDWORK._serialized_end=6345
+  _RUNPREEMPTINGRECORD._serialized_start=6347
+  _RUNPREEMPTINGRECORD._serialized_end=6413
+  _RUNPREEMPTINGRESULT._serialized_start=6415
+  _RUNPREEMPTINGRESULT._serialized_end=6436
+  _RUNABORTEDRECORD._serialized_start=6438
+  _RUNABORTEDRECORD._serialized_end=6501
+  _RUNCONFLICTEDRECORD._serialized_start=6503
+  _RUNCONFLICTEDRECORD._serialized_end=6586
+  _PROJECTSETTINGSRECORD._serialized_start=6589
+  _PROJECTSETTINGSRECORD._serialized_end=6758
+  _USERMESSAGERECORD._serialized_start=6761
+  _USERMESSAGERECORD._serialized_end=6947
+  _SETTINGSRECORD._serialized_start=6949
+  _SETTINGSRECORD._serialized_end=7054
+  _SETTINGSITEM._serialized_start=7056
+  _SETTINGSITEM._serialized_end=7103
+  _HISTORYSTEP._serialized_start=7105
+  _HISTORYSTEP._serialized_end=7131
+  _HISTORYRECORD._serialized_start=7134
+  _HISTORYRECORD._serialized_end=7280
+  _HISTORYITEM._serialized_start=7282
+  _HISTORYITEM._serialized_end=7348
+  _HISTORYRESULT._serialized_start=7350
+  _HISTORYRESULT._serialized_end=7365
+  _OUTPUTRECORD._serialized_start=7368
+  _OUTPUTRECORD._serialized_end=7588
+  _OUTPUTRECORD_OUTPUTTYPE._serialized_start=7552
+  _OUTPUTRECORD_OUTPUTTYPE._serialized_end=7588
+  _OUTPUTRESULT._serialized_start=7590
+  _OUTPUTRESULT._serialized_end=7604
+  _OUTPUTRAWRECORD._serialized_start=7607
+  _OUTPUTRAWRECORD._serialized_end=7833
+  _OUTPUTRAWRECORD_OUTPUTTYPE._serialized_start=7552
+  _OUTPUTRAWRECORD_OUTPUTTYPE._serialized_end=7588
+  _OUTPUTRAWRESULT._serialized_start=7835
+  _OUTPUTRAWRESULT._serialized_end=7852
+  _METRICRECORD._serialized_start=7855
+  _METRICRECORD._serialized_end=8263
+  _METRICRECORD_METRICGOAL._serialized_start=8197
+  _METRICRECORD_METRICGOAL._serialized_end=8263
+  _METRICRESULT._serialized_start=8265
+  _METRICRESULT._serialized_end=8279
+  _METRICOPTIONS._serialized_start=8281
+  _METRICOPTIONS._serialized_end=8348
+  _METRICCONTROL._serialized_start=8350
+  _METRICCONTROL._serialized_end=8384
+  _METRICSUMMARY._serialized_start=8386
+  _METRICSUMMARY._serialized_end=8497
+  _CONFIGRECORD._serialized_start=8500
+  _CONFIGRECORD._serialized_end=8647
+  _CONFIGITEM._serialized_start=8649
+  _CONFIGITEM._serialized_end=8714
+  _CONFIGRESULT._serialized_start=8716
+  _CONFIGRESULT._serialized_end=8730
+  _SUMMARYRECORD._serialized_start=8733
+  _SUMMARYRECORD._serialized_end=8900
+  _SUMMARYITEM._serialized_start=8902
+  _SUMMARYITEM._serialized_end=8968
+  _SUMMARYRESULT._serialized_start=8970
+  _SUMMARYRESULT._serialized_end=8985
+  _FILESRECORD._serialized_start=8987
+  _FILESRECORD._serialized_end=9102
+  _FILESITEM._serialized_start=9105
+  _FILESITEM._serialized_end=9341
+  _FILESITEM_POLICYTYPE._serialized_start=9236
+  _FILESITEM_POLICYTYPE._serialized_end=9276
+  _FILESITEM_FILETYPE._serialized_start=9278
+  _FILESITEM_FILETYPE._serialized_end=9335
+  _FILESRESULT._serialized_start=9343
+  _FILESRESULT._serialized_end=9356
+  _STATSRECORD._serialized_start=9359
+  _STATSRECORD._serialized_end=9589
+  _STATSRECORD_STATSTYPE._serialized_start=9566
+  _STATSRECORD_STATSTYPE._serialized_end=9589
+  _STATSITEM._serialized_start=9591
+  _STATSITEM._serialized_end=9635
+  _ARTIFACTRECORD._serialized_start=9638
+  _ARTIFACTRECORD._serialized_end=10111
+  _ARTIFACTMANIFEST._serialized_start=10114
+  _ARTIFACTMANIFEST._serialized_end=10302
+  _ARTIFACTMANIFESTENTRY._serialized_start=10305
+  _ARTIFACTMANIFESTENTRY._serialized_end=10512
+  _EXTRAITEM._serialized_start=10514
+  _EXTRAITEM._serialized_end=10558
+  _STORAGEPOLICYCONFIGITEM._serialized_start=10560
+  _STORAGEPOLICYCONFIGITEM._serialized_end=10618
+  _ARTIFACTRESULT._serialized_start=10620
+  _ARTIFACTRESULT._serialized_end=10636
+  _LINKARTIFACTRESULT._serialized_start=10638
+  _LINKARTIFACTRESULT._serialized_end=10658
+  _LINKARTIFACTRECORD._serialized_start=10661
+  _LINKARTIFACTRECORD._serialized_end=10868
+  _TBRECORD._serialized_start=10870
+  _TBRECORD._serialized_end=10974
+  _TBRESULT._serialized_start=10976
+  _TBRESULT._serialized_end=10986
+  _ALERTRECORD._serialized_start=10988
+  _ALERTRECORD._serialized_end=11113
+  _ALERTRESULT._serialized_start=11115
+  _ALERTRESULT._serialized_end=11128
+  _REQUEST._serialized_start=11131
+  _REQUEST._serialized_end=13867
+  _RESPONSE._serialized_start=13870
+  _RESPONSE._serialized_end=16159
+  _DEFERREQUEST._serialized_start=16162
+  _DEFERREQUEST._serialized_end=16482
+  _DEFERREQUEST_DEFERSTATE._serialized_start=16235
+  _DEFERREQUEST_DEFERSTATE._serialized_end=16482
+  _PAUSEREQUEST._serialized_start=16484
+  _PAUSEREQUEST._serialized_end=16544
+  _PAUSERESPONSE._serialized_start=16546
+  _PAUSERESPONSE._serialized_end=16561
+  _RESUMEREQUEST._serialized_start=16563
+  _RESUMEREQUEST._serialized_end=16624
+  _RESUMERESPONSE._serialized_start=16626
+  _RESUMERESPONSE._serialized_end=16642
+  _LOGINREQUEST._serialized_start=16644
+  _LOGINREQUEST._serialized_end=16721
+  _LOGINRESPONSE._serialized_start=16723
+  _LOGINRESPONSE._serialized_end=16761
+  _GETSUMMARYREQUEST._serialized_start=16763
+  _GETSUMMARYREQUEST._serialized_end=16828
+  _GETSUMMARYRESPONSE._serialized_start=16830
+  _GETSUMMARYRESPONSE._serialized_end=16893
+  _GETSYSTEMMETRICSREQUEST._serialized_start=16895
+  _GETSYSTEMMETRICSREQUEST._serialized_end=16966
+  _SYSTEMMETRICSAMPLE._serialized_start=16968
+  _SYSTEMMETRICSAMPLE._serialized_end=17050
+  _SYSTEMMETRICSBUFFER._serialized_start=17052
+  _SYSTEMMETRICSBUFFER._serialized_end=17125
+  _GETSYSTEMMETRICSRESPONSE._serialized_start=17128
+  _GETSYSTEMMETRICSRESPONSE._serialized_end=17330
+  _GETSYSTEMMETRICSRESPONSE_SYSTEMMETRICSENTRY._serialized_start=17241
+  _GETSYSTEMMETRICSRESPONSE_SYSTEMMETRICSENTRY._serialized_end=17330
+  _STATUSREQUEST._serialized_start=17332
+  _STATUSREQUEST._serialized_end=17393
+  _STATUSRESPONSE._serialized_start=17396
+  _STATUSRESPONSE._serialized_end=17587
+  _RUNPATHS._serialized_start=17589
+  _RUNPATHS._serialized_end=17692
+  _STREAMDIAGNOSTICS._serialized_start=17695
+  _STREAMDIAGNOSTICS._serialized_end=18590
+  _STREAMDIAGNOSTICS_CHANNELDEPTHSENTRY._serialized_start=18330
+  _STREAMDIAGNOSTICS_CHANNELDEPTHSENTRY._serialized_end=18382
+  _STREAMDIAGNOSTICS_RETRIESENTRY._serialized_start=18384
+  _STREAMDIAGNOSTICS_RETRIESENTRY._serialized_end=18458
+  _STREAMDIAGNOSTICS_POLLSENTRY._serialized_start=18460
+  _STREAMDIAGNOSTICS_POLLSENTRY._serialized_end=18531
+  _STREAMDIAGNOSTICS_UNKNOWNRECORDTYPESENTRY._serialized_start=18533
+  _STREAMDIAGNOSTICS_UNKNOWNRECORDTYPESENTRY._serialized_end=18590
+  _HANDLERMEMORY._serialized_start=18593
+  _HANDLERMEMORY._serialized_end=18848
+  _POLLSTATS._serialized_start=18850
+  _POLLSTATS._serialized_end=18896
+  _PIPELINELATENCY._serialized_start=18898
+  _PIPELINELATENCY._serialized_end=19016
+  _LATENCYSTATS._serialized_start=19018
+  _LATENCYSTATS._serialized_end=19091
+  _RETRYSTATS._serialized_start=19094
+  _RETRYSTATS._serialized_end=19258
+  _RETRYSTATS_BYCATEGORYENTRY._serialized_start=19209
+  _RETRYSTATS_BYCATEGORYENTRY._serialized_end=19258
+  _STOPSTATUSREQUEST._serialized_start=19260
+  _STOPSTATUSREQUEST._serialized_end=19325
+  _STOPSTATUSRESPONSE._serialized_start=19327
+  _STOPSTATUSRESPONSE._serialized_end=19372
+  _NETWORKSTATUSREQUEST._serialized_start=19374
+  _NETWORKSTATUSREQUEST._serialized_end=19442
+  _NETWORKSTATUSRESPONSE._serialized_start=19444
+  _NETWORKSTATUSRESPONSE._serialized_end=19524
+  _HTTPRESPONSE._serialized_start=19526
+  _HTTPRESPONSE._serialized_end=19594
+  _INTERNALMESSAGESREQUEST._serialized_start=19596
+  _INTERNALMESSAGESREQUEST._serialized_end=19667
+  _INTERNALMESSAGESRESPONSE._serialized_start=19669
+  _INTERNALMESSAGESRESPONSE._serialized_end=19747
+  _INTERNALMESSAGES._serialized_start=19749
+  _INTERNALMESSAGES._serialized_end=19784
+  _POLLEXITREQUEST._serialized_start=19786
+  _POLLEXITREQUEST._serialized_end=19849
+  _POLLEXITRESPONSE._serialized_start=19852
+  _POLLEXITRESPONSE._serialized_end=20222
+  _MIRRORPROGRESS._serialized_start=20225
+  _MIRRORPROGRESS._serialized_end=20429
+  _EXITPROGRESS._serialized_start=20432
+  _EXITPROGRESS._serialized_end=20576
+  _SYNCOVERWRITE._serialized_start=20578
+  _SYNCOVERWRITE._serialized_end=20642
+  _SYNCSKIP._serialized_start=20644
+  _SYNCSKIP._serialized_end=20674
+  _SENDERMARKREQUEST._serialized_start=20676
+  _SENDERMARKREQUEST._serialized_end=20695
+  _SYNCREQUEST._serialized_start=20698
+  _SYNCREQUEST._serialized_end=20873
+  _SYNCRESPONSE._serialized_start=20875
+  _SYNCRESPONSE._serialized_end=20944
+  _CLEANUPREQUEST._serialized_start=20946
+  _CLEANUPREQUEST._serialized_end=21025
+  _CLEANUPRESPONSE._serialized_start=21028
+  _CLEANUPRESPONSE._serialized_end=21177
+  _REMOVEDRUNDIR._serialized_start=21179
+  _REMOVEDRUNDIR._serialized_end=21255
+  _SENDERREADREQUEST._serialized_start=21257
+  _SENDERREADREQUEST._serialized_end=21320
+  _STATUSREPORTREQUEST._serialized_start=21322
+  _STATUSREPORTREQUEST._serialized_end=21431
+  _SUMMARYRECORDREQUEST._serialized_start=21433
+  _SUMMARYRECORDREQUEST._serialized_end=21503
+  _TELEMETRYRECORDREQUEST._serialized_start=21505
+  _TELEMETRYRECORDREQUEST._serialized_end=21581
+  _SERVERINFOREQUEST._serialized_start=21583
+  _SERVERINFOREQUEST._serialized_end=21648
+  _SERVERINFORESPONSE._serialized_start=21651
+  _SERVERINFORESPONSE._serialized_end=21833
+  _SERVERCAPABILITIES._serialized_start=21836
+  _SERVERCAPABILITIES._serialized_end=22055
+  _SERVERCAPABILITIES_DEPLOYMENT._serialized_start=22009
+  _SERVERCAPABILITIES_DEPLOYMENT._serialized_end=22055
+  _SERVERMESSAGES._serialized_start=22057
+  _SERVERMESSAGES._serialized_end=22118
+  _SERVERMESSAGE._serialized_start=22120
+  _SERVERMESSAGE._serialized_end=22221
+  _FILECOUNTS._serialized_start=22223
+  _FILECOUNTS._serialized_end=22347
+  _FILEPUSHERSTATS._serialized_start=22349
+  _FILEPUSHERSTATS._serialized_end=22434
+  _FILESUPLOADED._serialized_start=22436
+  _FILESUPLOADED._serialized_end=22466
+  _FILETRANSFERINFOREQUEST._serialized_start=22469
+  _FILETRANSFERINFOREQUEST._serialized_end=22713
+  _FILETRANSFERINFOREQUEST_TRANSFERTYPE._serialized_start=22673
+  _FILETRANSFERINFOREQUEST_TRANSFERTYPE._serialized_end=22713
+  _LOCALINFO._serialized_start=22715
+  _LOCALINFO._serialized_end=22764
+  _SHUTDOWNREQUEST._serialized_start=22766
+  _SHUTDOWNREQUEST._serialized_end=22829
+  _SHUTDOWNRESPONSE._serialized_start=22831
+  _SHUTDOWNRESPONSE._serialized_end=22849
+  _ATTACHREQUEST._serialized_start=22851
+  _ATTACHREQUEST._serialized_end=22931
+  _ATTACHRESPONSE._serialized_start=22933
+  _ATTACHRESPONSE._serialized_end=23052
+  _TESTINJECTREQUEST._serialized_start=23055
+  _TESTINJECTREQUEST._serialized_end=23396
+  _TESTINJECTRESPONSE._serialized_start=23398
+  _TESTINJECTRESPONSE._serialized_end=23418
+  _HISTORYACTION._serialized_start=23420
+  _HISTORYACTION._serialized_end=23450
+  _PARTIALHISTORYREQUEST._serialized_start=23453
+  _PARTIALHISTORYREQUEST._serialized_end=23655
+  _PARTIALHISTORYRESPONSE._serialized_start=23657
+  _PARTIALHISTORYRESPONSE._serialized_end=23681
+  _SAMPLEDHISTORYREQUEST._serialized_start=23683
+  _SAMPLEDHISTORYREQUEST._serialized_end=23783
+  _SAMPLEDHISTORYITEM._serialized_start=23786
+  _SAMPLEDHISTORYITEM._serialized_end=24004
+  _SAMPLEDHISTORYITEM_VALUETYPE._serialized_start=23964
+  _SAMPLEDHISTORYITEM_VALUETYPE._serialized_end=24004
+  _SAMPLEDHISTORYRESPONSE._serialized_start=24006
+  _SAMPLEDHISTORYRESPONSE._serialized_end=24080
+  _RUNSTATUSREQUEST._serialized_start=24082
+  _RUNSTATUSREQUEST._serialized_end=24146
+  _RUNSTATUSRESPONSE._serialized_start=24148
+  _RUNSTATUSRESPONSE._serialized_end=24268
+  _RUNSTARTREQUEST._serialized_start=24270
+  _RUNSTARTREQUEST._serialized_end=24373
+  _RUNSTARTRESPONSE._serialized_start=24375
+  _RUNSTARTRESPONSE._serialized_end=24393
+  _CHECKVERSIONREQUEST._serialized_start=24395
+  _CHECKVERSIONREQUEST._serialized_end=24487
+  _CHECKVERSIONRESPONSE._serialized_start=24489
+  _CHECKVERSIONRESPONSE._serialized_end=24582
+  _JOBINFOREQUEST._serialized_start=24584
+  _JOBINFOREQUEST._serialized_end=24646
+  _JOBINFORESPONSE._serialized_start=24648
+  _JOBINFORESPONSE._serialized_end=24702
+  _LOGARTIFACTREQUEST._serialized_start=24705
+  _LOGARTIFACTREQUEST._serialized_end=24864
+  _LOGARTIFACTRESPONSE._serialized_start=24866
+  _LOGARTIFACTRESPONSE._serialized_end=24931
+  _ARTIFACTWAITREQUEST._serialized_start=24933
+  _ARTIFACTWAITREQUEST._serialized_end=25044
+  _ARTIFACTWAITRESPONSE._serialized_start=25047
+  _ARTIFACTWAITRESPONSE._serialized_end=25308
+  _ARTIFACTWAITRESPONSE_STATE._serialized_start=25242
+  _ARTIFACTWAITRESPONSE_STATE._serialized_end=25308
+  _ARTIFACTCANCELREQUEST._serialized_start=25310
+  _ARTIFACTCANCELREQUEST._serialized_end=25398
+  _ARTIFACTCANCELRESPONSE._serialized_start=25401
+  _ARTIFACTCANCELRESPONSE._serialized_end=25577
+  _ARTIFACTCANCELRESPONSE_STATE._serialized_start=25511
+  _ARTIFACTCANCELRESPONSE_STATE._serialized_end=25577
+  _STREAMPINGREQUEST._serialized_start=25579
+  _STREAMPINGREQUEST._serialized_end=25644
+  _STREAMPINGRESPONSE._serialized_start=25647
+  _STREAMPINGRESPONSE._serialized_end=25930
+  _STREAMPINGRESPONSE_LASTPROCESSEDENTRY._serialized_start=25850
+  _STREAMPINGRESPONSE_LASTPROCESSEDENTRY._serialized_end=25930
+  _STREAMHEALTH._serialized_start=25932
+  _STREAMHEALTH._serialized_end=26023
+  _UPLOADPROGRESSRESPONSE._serialized_start=26026
+  _UPLOADPROGRESSRESPONSE._serialized_end=26168
+  _USERMESSAGERESPONSE._serialized_start=26171
+  _USERMESSAGERESPONSE._serialized_end=26310
+  _USERMESSAGERESPONSE_LEVEL._serialized_start=26269
+  _USERMESSAGERESPONSE_LEVEL._serialized_end=26310
+  _RUNURLRESPONSE._serialized_start=26312
+  _RUNURLRESPONSE._serialized_end=26427
+  _LISTRUNOUTPUTSREQUEST._serialized_start=26429
+  _LISTRUNOUTPUTSREQUEST._serialized_end=26498
+  _LISTRUNOUTPUTSRESPONSE._serialized_start=26501
+  _LISTRUNOUTPUTSRESPONSE._serialized_end=26672
+  _RUNARTIFACTOUTPUT._serialized_start=26675
+  _RUNARTIFACTOUTPUT._serialized_end=26975
+  _RUNARTIFACTOUTPUT_STATE._serialized_start=26928
+  _RUNARTIFACTOUTPUT_STATE._serialized_end=26975
+  _RUNMEDIAOUTPUT._serialized_start=26977
+  _RUNMEDIAOUTPUT._serialized_end=27078
+  _ABORTRUNREQUEST._serialized_start=27080
+  _ABORTRUNREQUEST._serialized_end=27143
+  _ABORTRUNRESPONSE._serialized_start=27145
+  _ABORTRUNRESPONSE._serialized_end=27222
+  _PREPAREFORPREEMPTIONREQUEST._serialized_start=27224
+  _PREPAREFORPREEMPTIONREQUEST._serialized_end=27324
+  _PREPAREFORPREEMPTIONRESPONSE._serialized_start=27327
+  _PREPAREFORPREEMPTIONRESPONSE._serialized_end=27485
+  _GETSETTINGSREQUEST._serialized_start=27487
+  _GETSETTINGSREQUEST._serialized_end=27553
+  _GETSETTINGSRESPONSE._serialized_start=27555
+  _GETSETTINGSRESPONSE._serialized_end=27647
+  _EFFECTIVESETTING._serialized_start=27650
+  _EFFECTIVESETTING._serialized_end=27838
+  _EFFECTIVESETTING_SOURCE._serialized_start=27760
+  _EFFECTIVESETTING_SOURCE._serialized_end=27838
+  _DOWNLOADARTIFACTREQUEST._serialized_start=27841
+  _DOWNLOADARTIFACTREQUEST._serialized_end=28031
+  _DOWNLOADARTIFACTRESPONSE._serialized_start=28033
+  _DOWNLOADARTIFACTRESPONSE._serialized_end=28082
+  _KEEPALIVEREQUEST._serialized_start=28084
+  _KEEPALIVEREQUEST._serialized_end=28148
+  _KEEPALIVERESPONSE._serialized_start=28150
+  _KEEPALIVERESPONSE._serialized_end=28169
+  _ARTIFACTINFO._serialized_start=28171
+  _ARTIFACTINFO._serialized_end=28284
+  _GITINFO._serialized_start=28286
+  _GITINFO._serialized_end=28327
+  _GITSOURCE._serialized_start=28330
+  _GITSOURCE._serialized_end=28465
+  _IMAGESOURCE._serialized_start=28467
+  _IMAGESOURCE._serialized_end=28495
+  _SOURCE._serialized_start=28498
+  _SOURCE._serialized_end=28638
+  _JOBSOURCE._serialized_start=28640
+  _JOBSOURCE._serialized_end=28747
+  _PARTIALJOBARTIFACT._serialized_start=28749
+  _PARTIALJOBARTIFACT._serialized_end=28835
+  _USEARTIFACTRECORD._serialized_start=28838
+  _USEARTIFACTRECORD._serialized_end=28995
+  _USEARTIFACTRESULT._serialized_start=28997
+  _USEARTIFACTRESULT._serialized_end=29016
+  _CANCELREQUEST._serialized_start=29018
+  _CANCELREQUEST._serialized_end=29100
+  _CANCELRESPONSE._serialized_start=29102
+  _CANCELRESPONSE._serialized_end=29118
+  _DISKINFO._serialized_start=29120
+  _DISKINFO._serialized_end=29159
+  _MEMORYINFO._serialized_start=29161
+  _MEMORYINFO._serialized_end=29188
+  _CPUINFO._serialized_start=29190
+  _CPUINFO._serialized_end=29237
+  _GPUAPPLEINFO._serialized_start=29240
+  _GPUAPPLEINFO._serialized_end=29370
+  _CONTAINERINFO._serialized_start=29372
+  _CONTAINERINFO._serialized_end=29497
+  _GPUNVIDIAINFO._serialized_start=29499
+  _GPUNVIDIAINFO._serialized_end=29550
+  _GPUAMDINFO._serialized_start=29553
+  _GPUAMDINFO._serialized_end=29818
+  _METADATAREQUEST._serialized_start=29821
+  _METADATAREQUEST._serialized_end=31090
+  _METADATAREQUEST_DISKENTRY._serialized_start=30902
+  _METADATAREQUEST_DISKENTRY._serialized_end=30971
+  _METADATAREQUEST_SLURMENTRY._serialized_start=30973
+  _METADATAREQUEST_SLURMENTRY._serialized_end=31017
+  _METADATAREQUEST_SCHEDULERENTRY._serialized_start=31019
+  _METADATAREQUEST_SCHEDULERENTRY._serialized_end=31067
+  _PYTHONPACKAGESREQUEST._serialized_start=31093
+  _PYTHONPACKAGESREQUEST._serialized_end=31234
+  _PYTHONPACKAGESREQUEST_PYTHONPACKAGE._serialized_start=31188
+  _PYTHONPACKAGESREQUEST_PYTHONPACKAGE._serialized_end=31234
+  _JOBINPUTPATH._serialized_start=31236
+  _JOBINPUTPATH._serialized_end=31264
+  _JOBINPUTSOURCE._serialized_start=31267
+  _JOBINPUTSOURCE._serialized_end=31481
+  _JOBINPUTSOURCE_RUNCONFIGSOURCE._serialized_start=31420
+  _JOBINPUTSOURCE_RUNCONFIGSOURCE._serialized_end=31437
+  _JOBINPUTSOURCE_CONFIGFILESOURCE._serialized_start=31439
+  _JOBINPUTSOURCE_CONFIGFILESOURCE._serialized_end=31471
+  _JOBINPUTREQUEST._serialized_start=31484
+  _JOBINPUTREQUEST._serialized_end=31661
+  _CREDENTIALSUPDATEREQUEST._serialized_start=31663
+  _CREDENTIALSUPDATEREQUEST._serialized_end=31706
+  _SETTINGSUPDATEREQUEST._serialized_start=31709
+  _SETTINGSUPDATEREQUEST._serialized_end=31916
+  _RUNUPDATEDREQUEST._serialized_start=31918
+  _RUNUPDATEDREQUEST._serialized_end=31977
+  _BACKPRESSUREREQUEST._serialized_start=31979
+  _BACKPRESSUREREQUEST._serialized_end=32050
 # @@protoc_insertion_point(module_scope)
@@ -705,6 +705,8 @@ class Env(google.protobuf.message.Message):
     IPYTHON_FIELD_NUMBER: builtins.int
     AWS_LAMBDA_FIELD_NUMBER: builtins.int
     AMD_GPU_FIELD_NUMBER: builtins.int
+    CONTAINER_FIELD_NUMBER: builtins.int
+    KUBERNETES_FIELD_NUMBER: builtins.int
     jupyter: builtins.bool
     """jupyter env detected"""
     kaggle: builtins.bool
@@ -735,6 +737,10 @@ class Env(google.protobuf.message.Message):
     """running in AWS Lambda"""
     amd_gpu: builtins.bool
     """AMD GPU detected"""
+    container: builtins.bool
+    """running in a container"""
+    kubernetes: builtins.bool
+    """running in a Kubernetes pod"""
     def __init__(
         self,
         *,
@@ -753,8 +759,10 @@ class Env(google.protobuf.message.Message):
         ipython: builtins.bool = ...,
         aws_lambda: builtins.bool = ...,
         amd_gpu: builtins.bool = ...,
+        container: builtins.bool = ...,
+        kubernetes: builtins.bool = ...,
     ) -> None: ...
-    def ClearField(self, field_name: typing_extensions.Literal["amd_gpu", b"amd_gpu", "aws_lambda", b"aws_lambda", "colab", b"colab", "ipython", b"ipython", "jupyter", b"jupyter", "kaggle", b"kaggle", "m1_gpu", b"m1_gpu", "maybe_mp", b"maybe_mp", "pex", b"pex", "start_fork", b"start_fork", "start_forkserver", b"start_forkserver", "start_spawn", b"start_spawn", "start_thread", b"start_thread", "trainium", b"trainium", "windows", b"windows"]) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["amd_gpu", b"amd_gpu", "aws_lambda", b"aws_lambda", "colab", b"colab", "container", b"container", "ipython", b"ipython", "jupyter", b"jupyter", "kaggle", b"kaggle", "kubernetes", b"kubernetes", "m1_gpu", b"m1_gpu", "maybe_mp", b"maybe_mp", "pex", b"pex", "start_fork", b"start_fork", "start_forkserver", b"start_forkserver", "start_spawn", b"start_spawn", "start_thread", b"start_thread", "trainium", b"trainium", "windows", b"windows"]) -> None: ...
 
 global___Env = Env
 
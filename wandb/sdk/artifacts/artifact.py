@@ -11,6 +11,7 @@ import shutil
 import stat
 import sys
 import tempfile
+import threading
 import time
 from copy import copy
 from datetime import datetime, timedelta
@@ -56,6 +57,7 @@ from wandb.sdk.artifacts.artifact_manifests.artifact_manifest_v1 import (
 )
 from wandb.sdk.artifacts.artifact_state import ArtifactState
 from wandb.sdk.artifacts.artifact_ttl import ArtifactTTL
+from wandb.sdk.artifacts.digest_cache import get_digest_cache
 from wandb.sdk.artifacts.exceptions import (
     ArtifactFinalizedError,
     ArtifactNotLoggedError,
@@ -159,6 +161,10 @@ class Artifact:
         self._download_roots: Set[str] = set()
         # Set by new_draft(), otherwise the latest artifact will be used as the base.
         self._base_id: Optional[str] = None
+        # The latest version's manifest, fetched to reuse digests of unchanged files.
+        self._previous_manifest_lock = threading.Lock()
+        self._previous_manifest_fetched: bool = False
+        self._previous_manifest: Optional[ArtifactManifest] = None
         # Properties.
         self._id: Optional[str] = None
         self._client_id: str = runid.generate_id(128)
@@ -1185,7 +1191,7 @@ class Artifact:
             raise ValueError("Path is not a file: %s" % local_path)
 
         name = LogicalPath(name or os.path.basename(local_path))
-        digest = md5_file_b64(local_path)
+        digest = self._local_file_digest(local_path, name)
 
         if is_tmp:
             file_path, file_name = os.path.split(name)
@@ -1246,6 +1252,7 @@ class Artifact:
             self._add_local_file(
                 name=logical_path,
                 path=physical_path,
+                digest=self._local_file_digest(physical_path, logical_path),
                 skip_cache=skip_cache,
                 policy=policy,
             )
@@ -1472,6 +1479,58 @@ class Artifact:
         self._added_local_paths[os.fspath(path)] = entry
         return entry
 
+    def _local_file_digest(self, path: StrPath, name: StrPath) -> B64MD5:
+        """Return the digest of a local file added to the artifact as `name`.
+
+        If the file hasn't changed since it was last hashed, and the previous
+        version of the artifact has it with that digest and size, it's not hashed
+        again. On any doubt, such as without an online run or with the
+        `_artifact_full_rehash` setting, the file is hashed.
+        """
+        run = wandb.run
+        if run is None or run.settings._offline or run.settings._artifact_full_rehash:
+            return md5_file_b64(path)
+
+        cache = get_digest_cache(run.settings.wandb_dir)
+        stat = os.stat(path)
+        cached = cache.get(path, stat)
+        if cached is not None:
+            previous = self._fetch_previous_manifest()
+            entry = previous.get_entry_by_path(name) if previous else None
+            if entry and entry.digest == cached and entry.size == stat.st_size:
+                return cached
+
+        digest = md5_file_b64(path)
+        cache.put(path, digest, stat)
+        return digest
+
+    def _fetch_previous_manifest(self) -> Optional[ArtifactManifest]:
+        """Return the manifest of the artifact's latest version in the run's project.
+
+        It's fetched once; None means there's no such version or it couldn't be
+        fetched.
+        """
+        with self._previous_manifest_lock:
+            if self._previous_manifest_fetched:
+                return self._previous_manifest
+            self._previous_manifest_fetched = True
+
+            run = wandb.run
+            if run is None:
+                return None
+            try:
+                previous = self._from_name(
+                    run.entity,
+                    run.project,
+                    f"{self._name}:latest",
+                    run._public_api().client,
+                )
+                self._previous_manifest = previous.manifest
+            except Exception:
+                # Not logged before, or the server can't be reached.
+                self._previous_manifest = None
+            return self._previous_manifest
+
     def remove(self, item: Union[StrPath, "ArtifactManifestEntry"]) -> None:
         """Remove an item from the artifact.
 
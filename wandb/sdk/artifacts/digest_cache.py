@@ -0,0 +1,63 @@
+"""Artifact file digest cache.
+
+Records the digests of local files added to artifacts, so that logging a
+new version of a mostly unchanged artifact doesn't hash every file again.
+"""
+
+import hashlib
+import os
+from pathlib import Path
+from tempfile import NamedTemporaryFile
+from typing import Optional
+
+from wandb.sdk.lib.hashutil import B64MD5
+from wandb.sdk.lib.paths import StrPath
+
+
+class DigestCache:
+    """Maps a local file's path, size and modification time to its digest.
+
+    A record only matches while the file has the same size and modification
+    time as when it was hashed, so changing a file invalidates its record.
+    """
+
+    def __init__(self, cache_dir: StrPath) -> None:
+        self._cache_dir = Path(cache_dir)
+
+    def get(self, path: StrPath, stat: os.stat_result) -> Optional[B64MD5]:
+        """Return the recorded digest of the file, if it had the given stat."""
+        try:
+            record = self._record_path(path, stat).read_text().strip()
+        except OSError:
+            return None
+        return B64MD5(record) if record else None
+
+    def put(self, path: StrPath, digest: B64MD5, stat: os.stat_result) -> None:
+        """Record the digest of the file, hashed when it had the given stat.
+
+        Nothing is recorded if the file changed since. Failing to write the
+        record isn't an error; the file is hashed again next time.
+        """
+        try:
+            current = os.stat(path)
+            if (current.st_size, current.st_mtime_ns) != (
+                stat.st_size,
+                stat.st_mtime_ns,
+            ):
+                return
+            record_path = self._record_path(path, stat)
+            record_path.parent.mkdir(parents=True, exist_ok=True)
+            with NamedTemporaryFile("w", dir=record_path.parent, delete=False) as f:
+                f.write(digest)
+            os.replace(f.name, record_path)
+        except OSError:
+            pass
+
+    def _record_path(self, path: StrPath, stat: os.stat_result) -> Path:
+        key = f"{os.path.abspath(path)}\0{stat.st_size}\0{stat.st_mtime_ns}"
+        name = hashlib.sha256(key.encode("utf-8")).hexdigest()
+        return self._cache_dir / name[:2] / name[2:]
+
+
+def get_digest_cache(wandb_dir: StrPath) -> DigestCache:
+    return DigestCache(os.path.join(wandb_dir, "artifacts", "digests"))
@@ -302,6 +302,42 @@ class InterfaceBase:
     ) -> None:
         raise NotImplementedError
 
+    def publish_credentials_update(self, api_key: str) -> None:
+        """Replaces the API key used by the internal process to sync the run."""
+        credentials_update = pb.CredentialsUpdateRequest(api_key=api_key)
+        self._publish_credentials_update(credentials_update)
+
+    @abstractmethod
+    def _publish_credentials_update(
+        self, credentials_update: pb.CredentialsUpdateRequest
+    ) -> None:
+        raise NotImplementedError
+
+    def publish_settings_update(
+        self,
+        *,
+        upload_bytes_per_second: Optional[int] = None,
+        file_stream_bytes_per_second: Optional[int] = None,
+    ) -> None:
+        """Changes the bandwidth caps of the run's uploads and filestream.
+
+        A cap of 0 removes it, and caps that aren't given are left as they are.
+        """
+        settings_update = pb.SettingsUpdateRequest()
+        if upload_bytes_per_second is not None:
+            settings_update.upload_bytes_per_second.value = upload_bytes_per_second
+        if file_stream_bytes_per_second is not None:
+            settings_update.file_stream_bytes_per_second.value = (
+                file_stream_bytes_per_second
+            )
+        self._publish_settings_update(settings_update)
+
+    @abstractmethod
+    def _publish_settings_update(
+        self, settings_update: pb.SettingsUpdateRequest
+    ) -> None:
+        raise NotImplementedError
+
     def _make_artifact(self, artifact: "Artifact") -> pb.ArtifactRecord:
         proto_artifact = pb.ArtifactRecord()
         proto_artifact.type = artifact.type
@@ -546,6 +582,42 @@ class InterfaceBase:
     ) -> MailboxHandle:
         raise NotImplementedError
 
+    def deliver_artifact_wait(
+        self,
+        client_id: str,
+        timeout: Optional[float] = None,
+    ) -> MailboxHandle:
+        """Waits for the logged artifact with the client ID to be saved.
+
+        The response's state is IN_PROGRESS if it isn't saved by the timeout.
+        """
+        artifact_wait = pb.ArtifactWaitRequest(
+            client_id=client_id,
+            timeout_seconds=timeout or 0,
+        )
+        return self._deliver_artifact_wait(artifact_wait)
+
+    @abstractmethod
+    def _deliver_artifact_wait(
+        self, artifact_wait: pb.ArtifactWaitRequest
+    ) -> MailboxHandle:
+        raise NotImplementedError
+
+    def deliver_artifact_cancel(self, client_id: str) -> MailboxHandle:
+        """Cancels saving the logged artifact with the client ID.
+
+        The response's state is ALREADY_COMMITTED if the artifact was
+        committed first, since committed versions can't be cancelled.
+        """
+        artifact_cancel = pb.ArtifactCancelRequest(client_id=client_id)
+        return self._deliver_artifact_cancel(artifact_cancel)
+
+    @abstractmethod
+    def _deliver_artifact_cancel(
+        self, artifact_cancel: pb.ArtifactCancelRequest
+    ) -> MailboxHandle:
+        raise NotImplementedError
+
     def publish_artifact(
         self,
         run: "Run",
@@ -653,6 +725,29 @@ class InterfaceBase:
     def _publish_preempting(self, preempt_rec: pb.RunPreemptingRecord) -> None:
         raise NotImplementedError
 
+    def publish_table_rows(
+        self,
+        key: str,
+        columns: List[str],
+        rows: Iterable[Iterable[Any]],
+    ) -> None:
+        """Appends rows to a table that wandb-core uploads as the run's file.
+
+        The whole table is written to media/table/<key>.table.json when the
+        run is flushed, so only the new rows are sent each time.
+        """
+        table_rows = pb.TableRowsRecord(key=key, columns=columns)
+        for row in rows:
+            table_row = table_rows.rows.add()
+            table_row.values_json.extend(
+                json_dumps_safer_history(value) for value in row
+            )
+        self._publish_table_rows(table_rows)
+
+    @abstractmethod
+    def _publish_table_rows(self, table_rows: pb.TableRowsRecord) -> None:
+        raise NotImplementedError
+
     def publish_output(self, name: str, data: str) -> None:
         # from vendor.protobuf import google3.protobuf.timestamp
         # ts = timestamp.Timestamp()
@@ -817,6 +912,7 @@ class InterfaceBase:
         project: Optional[str] = None,
         run_id: Optional[str] = None,
         skip_output_raw: Optional[bool] = None,
+        allow_modified_log: Optional[bool] = None,
     ) -> MailboxHandle:
         sync = pb.SyncRequest(
             start_offset=start_offset,
@@ -830,6 +926,8 @@ class InterfaceBase:
             sync.overwrite.run_id = run_id
         if skip_output_raw:
             sync.skip.output_raw = skip_output_raw
+        if allow_modified_log:
+            sync.allow_modified_log = allow_modified_log
         return self._deliver_sync(sync)
 
     @abstractmethod
@@ -941,8 +1039,33 @@ class InterfaceBase:
     ) -> MailboxHandle:
         raise NotImplementedError
 
-    def deliver_request_sampled_history(self) -> MailboxHandle:
-        sampled_history = pb.SampledHistoryRequest()
+    def deliver_stream_ping(self) -> MailboxHandle:
+        """Checks that the stream is alive, without waiting on its pipeline.
+
+        The response tells when each part of the pipeline last made
+        progress, to tell a slow stream from a stuck one.
+        """
+        stream_ping = pb.StreamPingRequest()
+        return self._deliver_stream_ping(stream_ping)
+
+    @abstractmethod
+    def _deliver_stream_ping(self, stream_ping: pb.StreamPingRequest) -> MailboxHandle:
+        raise NotImplementedError
+
+    def deliver_request_cleanup(self, dry_run: bool = False) -> MailboxHandle:
+        cleanup = pb.CleanupRequest(dry_run=dry_run)
+        return self._deliver_request_cleanup(cleanup)
+
+    @abstractmethod
+    def _deliver_request_cleanup(self, cleanup: pb.CleanupRequest) -> MailboxHandle:
+        raise NotImplementedError
+
+    def deliver_request_sampled_history(
+        self,
+        keys: Optional[List[str]] = None,
+        samples: int = 0,
+    ) -> MailboxHandle:
+        sampled_history = pb.SampledHistoryRequest(keys=keys, samples=samples)
         return self._deliver_request_sampled_history(sampled_history)
 
     @abstractmethod
@@ -951,6 +1074,67 @@ class InterfaceBase:
     ) -> MailboxHandle:
         raise NotImplementedError
 
+    def deliver_request_list_run_outputs(self) -> MailboxHandle:
+        """Lists the run's saved files, logged artifacts and media files."""
+        list_run_outputs = pb.ListRunOutputsRequest()
+        return self._deliver_request_list_run_outputs(list_run_outputs)
+
+    @abstractmethod
+    def _deliver_request_list_run_outputs(
+        self, list_run_outputs: pb.ListRunOutputsRequest
+    ) -> MailboxHandle:
+        raise NotImplementedError
+
+    def deliver_abort_run(self) -> MailboxHandle:
+        """Aborts the run, deleting it from the server if it was created.
+
+        The run is discarded rather than finished: its uploads are cancelled
+        and it won't be synced. The exit must still be delivered afterwards.
+        """
+        abort_run = pb.AbortRunRequest()
+        return self._deliver_abort_run(abort_run)
+
+    @abstractmethod
+    def _deliver_abort_run(self, abort_run: pb.AbortRunRequest) -> MailboxHandle:
+        raise NotImplementedError
+
+    def deliver_prepare_for_preemption(
+        self, timeout_seconds: float = 0
+    ) -> MailboxHandle:
+        """Gets the run ready for the process to be killed.
+
+        The run is marked as preempting, its transaction log is synced to
+        disk and its buffered data is sent, within the timeout. Once the
+        response says the log is durable, the process can be killed and the
+        run finished later with `wandb sync`.
+        """
+        prepare_for_preemption = pb.PrepareForPreemptionRequest(
+            timeout_seconds=timeout_seconds
+        )
+        return self._deliver_prepare_for_preemption(prepare_for_preemption)
+
+    @abstractmethod
+    def _deliver_prepare_for_preemption(
+        self, prepare_for_preemption: pb.PrepareForPreemptionRequest
+    ) -> MailboxHandle:
+        raise NotImplementedError
+
+    def deliver_get_settings(self) -> MailboxHandle:
+        """Gets the settings the run is using, and where they came from.
+
+        Every setting is listed with secrets redacted, and tagged with its
+        source: a built-in default, the project's defaults, the client, an
+        environment variable of wandb-core, .netrc or the server.
+        """
+        get_settings = pb.GetSettingsRequest()
+        return self._deliver_get_settings(get_settings)
+
+    @abstractmethod
+    def _deliver_get_settings(
+        self, get_settings: pb.GetSettingsRequest
+    ) -> MailboxHandle:
+        raise NotImplementedError
+
     def deliver_request_run_status(self) -> MailboxHandle:
         run_status = pb.RunStatusRequest()
         return self._deliver_request_run_status(run_status)
@@ -96,6 +96,10 @@ class InterfaceShared(InterfaceBase):
         rec = self._make_record(preempting=preempt_rec)
         self._publish(rec)
 
+    def _publish_table_rows(self, table_rows: pb.TableRowsRecord) -> None:
+        rec = self._make_record(table_rows=table_rows)
+        self._publish(rec)
+
     def _publish_telemetry(self, telem: tpb.TelemetryRecord) -> None:
         rec = self._make_record(telemetry=telem)
         self._publish(rec)
@@ -152,6 +156,16 @@ class InterfaceShared(InterfaceBase):
         get_system_metrics: Optional[pb.GetSystemMetricsRequest] = None,
         python_packages: Optional[pb.PythonPackagesRequest] = None,
         job_input: Optional[pb.JobInputRequest] = None,
+        credentials_update: Optional[pb.CredentialsUpdateRequest] = None,
+        cleanup: Optional[pb.CleanupRequest] = None,
+        settings_update: Optional[pb.SettingsUpdateRequest] = None,
+        artifact_wait: Optional[pb.ArtifactWaitRequest] = None,
+        list_run_outputs: Optional[pb.ListRunOutputsRequest] = None,
+        abort_run: Optional[pb.AbortRunRequest] = None,
+        prepare_for_preemption: Optional[pb.PrepareForPreemptionRequest] = None,
+        get_settings: Optional[pb.GetSettingsRequest] = None,
+        artifact_cancel: Optional[pb.ArtifactCancelRequest] = None,
+        stream_ping: Optional[pb.StreamPingRequest] = None,
     ) -> pb.Record:
         request = pb.Request()
         if login:
@@ -214,6 +228,26 @@ class InterfaceShared(InterfaceBase):
             request.python_packages.CopyFrom(python_packages)
         elif job_input:
             request.job_input.CopyFrom(job_input)
+        elif credentials_update:
+            request.credentials_update.CopyFrom(credentials_update)
+        elif cleanup:
+            request.cleanup.CopyFrom(cleanup)
+        elif settings_update:
+            request.settings_update.CopyFrom(settings_update)
+        elif artifact_wait:
+            request.artifact_wait.CopyFrom(artifact_wait)
+        elif list_run_outputs:
+            request.list_run_outputs.CopyFrom(list_run_outputs)
+        elif abort_run:
+            request.abort_run.CopyFrom(abort_run)
+        elif prepare_for_preemption:
+            request.prepare_for_preemption.CopyFrom(prepare_for_preemption)
+        elif get_settings:
+            request.get_settings.CopyFrom(get_settings)
+        elif artifact_cancel:
+            request.artifact_cancel.CopyFrom(artifact_cancel)
+        elif stream_ping:
+            request.stream_ping.CopyFrom(stream_ping)
         else:
             raise Exception("Invalid request")
         record = self._make_record(request=request)
@@ -246,6 +280,7 @@ class InterfaceShared(InterfaceBase):
         use_artifact: Optional[pb.UseArtifactRecord] = None,
         output: Optional[pb.OutputRecord] = None,
         output_raw: Optional[pb.OutputRawRecord] = None,
+        table_rows: Optional[pb.TableRowsRecord] = None,
     ) -> pb.Record:
         record = pb.Record()
         if run:
@@ -290,6 +325,8 @@ class InterfaceShared(InterfaceBase):
             record.output.CopyFrom(output)
         elif output_raw:
             record.output_raw.CopyFrom(output_raw)
+        elif table_rows:
+            record.table_rows.CopyFrom(table_rows)
         else:
             raise Exception("Invalid record")
         return record
@@ -389,6 +426,18 @@ class InterfaceShared(InterfaceBase):
         rec = self._make_request(python_packages=python_packages)
         self._publish(rec)
 
+    def _publish_credentials_update(
+        self, credentials_update: pb.CredentialsUpdateRequest
+    ) -> None:
+        rec = self._make_request(credentials_update=credentials_update)
+        self._publish(rec)
+
+    def _publish_settings_update(
+        self, settings_update: pb.SettingsUpdateRequest
+    ) -> None:
+        rec = self._make_request(settings_update=settings_update)
+        self._publish(rec)
+
     def _publish_files(self, files: pb.FilesRecord) -> None:
         rec = self._make_record(files=files)
         self._publish(rec)
@@ -411,6 +460,18 @@ class InterfaceShared(InterfaceBase):
         rec = self._make_request(download_artifact=download_artifact)
         return self._deliver_record(rec)
 
+    def _deliver_artifact_wait(
+        self, artifact_wait: pb.ArtifactWaitRequest
+    ) -> MailboxHandle:
+        rec = self._make_request(artifact_wait=artifact_wait)
+        return self._deliver_record(rec)
+
+    def _deliver_artifact_cancel(
+        self, artifact_cancel: pb.ArtifactCancelRequest
+    ) -> MailboxHandle:
+        rec = self._make_request(artifact_cancel=artifact_cancel)
+        return self._deliver_record(rec)
+
     def _publish_artifact(self, proto_artifact: pb.ArtifactRecord) -> None:
         rec = self._make_record(artifact=proto_artifact)
         self._publish(rec)
@@ -515,18 +576,48 @@ class InterfaceShared(InterfaceBase):
         record = self._make_request(server_info=server_info)
         return self._deliver_record(record)
 
+    def _deliver_stream_ping(self, stream_ping: pb.StreamPingRequest) -> MailboxHandle:
+        record = self._make_request(stream_ping=stream_ping)
+        return self._deliver_record(record)
+
     def _deliver_request_sampled_history(
         self, sampled_history: pb.SampledHistoryRequest
     ) -> MailboxHandle:
         record = self._make_request(sampled_history=sampled_history)
         return self._deliver_record(record)
 
+    def _deliver_request_list_run_outputs(
+        self, list_run_outputs: pb.ListRunOutputsRequest
+    ) -> MailboxHandle:
+        record = self._make_request(list_run_outputs=list_run_outputs)
+        return self._deliver_record(record)
+
+    def _deliver_abort_run(self, abort_run: pb.AbortRunRequest) -> MailboxHandle:
+        record = self._make_request(abort_run=abort_run)
+        return self._deliver_record(record)
+
+    def _deliver_prepare_for_preemption(
+        self, prepare_for_preemption: pb.PrepareForPreemptionRequest
+    ) -> MailboxHandle:
+        record = self._make_request(prepare_for_preemption=prepare_for_preemption)
+        return self._deliver_record(record)
+
+    def _deliver_get_settings(
+        self, get_settings: pb.GetSettingsRequest
+    ) -> MailboxHandle:
+        record = self._make_request(get_settings=get_settings)
+        return self._deliver_record(record)
+
     def _deliver_request_run_status(
         self, run_status: pb.RunStatusRequest
     ) -> MailboxHandle:
         record = self._make_request(run_status=run_status)
         return self._deliver_record(record)
 
+    def _deliver_request_cleanup(self, cleanup: pb.CleanupRequest) -> MailboxHandle:
+        record = self._make_request(cleanup=cleanup)
+        return self._deliver_record(record)
+
     def _transport_keepalive_failed(self, keepalive_interval: int = 5) -> bool:
         if self._transport_failed:
             return True
@@ -10,14 +10,15 @@ import uuid
 from abc import abstractmethod
 from typing import TYPE_CHECKING, Dict, Optional
 
+from wandb.errors import term
+from wandb.proto import wandb_internal_pb2 as pb
+
 from ..lib import mailbox, tracelog
 from .message_future import MessageFuture
 
 if TYPE_CHECKING:
     from queue import Queue
 
-    from wandb.proto import wandb_internal_pb2 as pb
-
 
 logger = logging.getLogger("wandb")
 
@@ -44,10 +45,12 @@ class MessageRouter:
     _request_queue: "Queue[pb.Record]"
     _response_queue: "Queue[pb.Result]"
     _mailbox: Optional[mailbox.Mailbox]
+    _partial_results: Dict[str, "pb.Result"]
 
     def __init__(self, mailbox: Optional[mailbox.Mailbox] = None) -> None:
         self._mailbox = mailbox
         self._pending_reqs = {}
+        self._partial_results = {}
         self._lock = threading.Lock()
 
         self._join_event = threading.Event()
@@ -100,8 +103,25 @@ class MessageRouter:
         self._thread.join()
 
     def _handle_msg_rcv(self, msg: "pb.Result") -> None:
+        # large responses may arrive in parts
+        if msg.HasField("chunk"):
+            whole = self._assemble(msg)
+            if whole is None:
+                return
+            msg = whole
+        # messages for the user aren't responses to any request
+        if msg.response.HasField("user_message_response"):
+            _display_user_message(msg.response.user_message_response)
+            return
+        # the run's URL is announced for other clients, like orchestrators
+        if msg.response.HasField("run_url_response"):
+            logger.debug("run URL: %s", msg.response.run_url_response.url)
+            return
         # deliver mailbox addressed messages to mailbox
-        if self._mailbox and msg.control.mailbox_slot:
+        if self._mailbox and (
+            msg.control.mailbox_slot
+            or msg.response.HasField("upload_progress_response")
+        ):
             self._mailbox.deliver(msg)
             return
         with self._lock:
@@ -116,3 +136,40 @@ class MessageRouter:
                 )
             return
         future._set_object(msg)
+
+
+    def _assemble(self, part: "pb.Result") -> Optional["pb.Result"]:
+        """Add a part of a response, returning the response once it's whole.
+
+        The parts of a response arrive in order, with the mailbox slot of
+        its request. Merging them in order gives the whole response.
+        """
+        slot = part.control.mailbox_slot
+        whole = self._partial_results.pop(slot, None)
+        if whole is None and part.chunk.index == 0:
+            whole = part
+        elif whole is not None and part.chunk.index == whole.chunk.index + 1:
+            whole.MergeFrom(part)
+        else:
+            logger.warning(
+                "Dropping response for slot %s: part %d is out of order",
+                slot,
+                part.chunk.index,
+            )
+            return None
+
+        if not part.chunk.final:
+            self._partial_results[slot] = whole
+            return None
+        whole.ClearField("chunk")
+        return whole
+
+
+def _display_user_message(message: "pb.UserMessageResponse") -> None:
+    """Show a message that the service sent for the user."""
+    if message.level == pb.UserMessageResponse.ERROR:
+        term.termerror(message.message)
+    elif message.level == pb.UserMessageResponse.WARNING:
+        term.termwarn(message.message)
+    else:
+        term.termlog(message.message)
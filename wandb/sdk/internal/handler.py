@@ -653,6 +653,13 @@ class HandleManager:
     def handle_preempting(self, record: Record) -> None:
         self._dispatch_record(record)
 
+    def handle_table_rows(self, record: Record) -> None:
+        # tables logged a few rows at a time are only kept by wandb-core
+        logger.warning(
+            "dropping rows of table %s, which require wandb-core",
+            record.table_rows.key,
+        )
+
     def handle_header(self, record: Record) -> None:
         self._dispatch_record(record)
 
@@ -1404,6 +1404,12 @@ class SendManager:
     def send_footer(self, record: "Record") -> None:
         pass
 
+    def send_checkpoint(self, record: "Record") -> None:
+        pass
+
+    def send_table_rows(self, record: "Record") -> None:
+        pass
+
     def send_tbrecord(self, record: "Record") -> None:
         # tbrecord watching threads are handled by handler.py
         pass
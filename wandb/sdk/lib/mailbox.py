@@ -106,9 +106,11 @@ class _MailboxSlot:
     _wait_all: Optional[_MailboxWaitAll]
     _address: str
     _abandoned: bool
+    _upload_progress: Optional[pb.UploadProgressResponse]
 
     def __init__(self, address: str) -> None:
         self._result = None
+        self._upload_progress = None
         self._event = threading.Event()
         self._lock = threading.Lock()
         self._address = address
@@ -142,6 +144,10 @@ class _MailboxSlot:
         if self._wait_all:
             self._wait_all.notify()
 
+    def _deliver_upload_progress(self, progress: pb.UploadProgressResponse) -> None:
+        with self._lock:
+            self._upload_progress = progress
+
     def _notify_abandon(self) -> None:
         self._abandoned = True
         with self._lock:
@@ -329,6 +335,12 @@ class MailboxHandle:
     def _mark_failed(self) -> None:
         self._failed = True
 
+    @property
+    def upload_progress(self) -> Optional[pb.UploadProgressResponse]:
+        """The latest progress of the request's uploads, if any was reported."""
+        with self._slot._lock:
+            return self._slot._upload_progress
+
     @property
     def address(self) -> str:
         return self._slot._address
@@ -424,6 +436,13 @@ class Mailbox:
         return wait_all.active_handles_count == 0
 
     def deliver(self, result: pb.Result) -> None:
+        if result.response.HasField("upload_progress_response"):
+            progress = result.response.upload_progress_response
+            slot = self._slots.get(progress.mailbox_slot)
+            if slot:
+                slot._deliver_upload_progress(progress)
+            return
+
         mailbox = result.control.mailbox_slot
         slot = self._slots.get(mailbox)
         if not slot:
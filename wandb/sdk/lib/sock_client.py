@@ -194,6 +194,7 @@ class SockClient:
         inform_attach: Optional[spb.ServerInformAttachRequest] = None,
         inform_finish: Optional[spb.ServerInformFinishRequest] = None,
         inform_teardown: Optional[spb.ServerInformTeardownRequest] = None,
+        ping: Optional[spb.ServerPingRequest] = None,
     ) -> None:
         server_req = spb.ServerRequest()
         if inform_init:
@@ -206,6 +207,8 @@ class SockClient:
             server_req.inform_finish.CopyFrom(inform_finish)
         elif inform_teardown:
             server_req.inform_teardown.CopyFrom(inform_teardown)
+        elif ping:
+            server_req.ping.CopyFrom(ping)
         else:
             raise Exception("unmatched")
         self.send_server_request(server_req)
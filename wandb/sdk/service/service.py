@@ -15,7 +15,13 @@ import time
 from typing import TYPE_CHECKING, Any, Dict, Optional
 
 from wandb import _sentry, termlog
-from wandb.env import core_debug, core_error_reporting_enabled, is_require_core
+from wandb.env import (
+    CORE_MAX_CONNECTIONS,
+    CORE_MAX_STREAMS,
+    core_debug,
+    core_error_reporting_enabled,
+    is_require_core,
+)
 from wandb.errors import Error, WandbCoreNotAvailableError
 from wandb.sdk.lib.wburls import wburls
 from wandb.util import get_core_path, get_module
@@ -182,6 +188,14 @@ class _Service:
                 if trace_filename is not None:
                     service_args.extend(["--trace", trace_filename])
 
+                max_connections = os.environ.get(CORE_MAX_CONNECTIONS)
+                if max_connections is not None:
+                    service_args.extend(["--max-connections", max_connections])
+
+                max_streams = os.environ.get(CORE_MAX_STREAMS)
+                if max_streams is not None:
+                    service_args.extend(["--max-streams", max_streams])
+
                 exec_cmd_list = []
                 termlog(
                     "Using wandb-core as the SDK backend."
@@ -42,7 +42,11 @@ class ServiceInterface:
         raise NotImplementedError
 
     @abstractmethod
-    def _svc_inform_teardown(self, exit_code: int) -> None:
+    def _svc_inform_teardown(self, exit_code: int, force: bool = False) -> None:
+        raise NotImplementedError
+
+    @abstractmethod
+    def _svc_ping(self, timeout: int) -> Optional[spb.ServerPingResponse]:
         raise NotImplementedError
 
     @abstractmethod
@@ -16,6 +16,7 @@ if TYPE_CHECKING:
 
 class ServiceSockInterface(ServiceInterface):
     _sock_client: SockClient
+    _port: Optional[int] = None
 
     def __init__(self) -> None:
         self._sock_client = SockClient()
@@ -28,6 +29,7 @@ class ServiceSockInterface(ServiceInterface):
 
     def _svc_connect(self, port: int) -> None:
         self._sock_client.connect(port=port)
+        self._port = port
 
     def _svc_inform_init(
         self, settings: "wandb_settings_pb2.Settings", run_id: str
@@ -63,8 +65,34 @@ class ServiceSockInterface(ServiceInterface):
         response = self._sock_client.send_and_recv(inform_attach=inform_attach)
         return response.inform_attach_response
 
-    def _svc_inform_teardown(self, exit_code: int) -> None:
-        inform_teardown = spb.ServerInformTeardownRequest(exit_code=exit_code)
+    def _svc_inform_teardown(self, exit_code: int, force: bool = False) -> None:
+        inform_teardown = spb.ServerInformTeardownRequest(
+            exit_code=exit_code,
+            force=force,
+        )
 
         assert self._sock_client
         self._sock_client.send(inform_teardown=inform_teardown)
+
+    def _svc_ping(self, timeout: int) -> Optional[spb.ServerPingResponse]:
+        """Checks that wandb-core is alive, to diagnose hangs.
+
+        The ping is sent on a connection of its own, so that it's answered
+        even while the requests on the usual connection are stuck.
+
+        Returns:
+            The response, or None if it wasn't answered within the timeout.
+        """
+        assert self._port
+
+        client = SockClient()
+        client.connect(port=self._port)
+        try:
+            client.send(ping=spb.ServerPingRequest())
+            response = client.read_server_response(timeout=timeout)
+        finally:
+            client.close()
+
+        if response is None or not response.HasField("ping_response"):
+            return None
+        return response.ping_response
@@ -15,6 +15,7 @@ import os
 import platform
 import sys
 import tempfile
+import time
 from typing import TYPE_CHECKING, Any, Dict, List, Optional, Sequence, Union
 
 import wandb
@@ -52,6 +53,10 @@ if TYPE_CHECKING:
 
 logger: Optional[logging.Logger] = None  # logger configured during wandb.init()
 
+# How often wandb-core is pinged while wandb.init() waits on it, so that the
+# debug log shows whether a slow init is a hang.
+_INIT_PING_INTERVAL = 10
+
 
 def _set_logger(log_object: logging.Logger) -> None:
     """Configure module logger."""
@@ -131,6 +136,7 @@ class _WandbInit:
         self._reporter: Optional[wandb.sdk.lib.reporting.Reporter] = None
         self.notebook: Optional[wandb.jupyter.Notebook] = None  # type: ignore
         self.printer: Optional[Printer] = None
+        self._next_init_ping: Optional[float] = None
 
         self._init_telemetry_obj = telemetry.TelemetryRecord()
 
@@ -553,6 +559,37 @@ class _WandbInit:
         line = "Waiting for wandb.init()...\r"
         percent_done = handle.percent_done
         self.printer.progress_update(line, percent_done=percent_done)
+        self._ping_while_waiting()
+
+    def _ping_while_waiting(self) -> None:
+        """Logs whether wandb-core is alive, every so often during init."""
+        now = time.monotonic()
+        if self._next_init_ping is None:
+            self._next_init_ping = now + _INIT_PING_INTERVAL
+        if now < self._next_init_ping:
+            return
+        self._next_init_ping = now + _INIT_PING_INTERVAL
+
+        manager = self._wl and self._wl._get_manager()
+        if not manager or not logger:
+            return
+
+        ping = manager._ping(timeout=1)
+        if ping is None:
+            logger.warning("wandb-core didn't answer a ping during wandb.init()")
+            return
+        logger.info(
+            f"wandb-core {ping.core_version} is alive after "
+            f"{ping.uptime_seconds:.0f}s, with {ping.active_streams} streams"
+        )
+        for stream in ping.streams:
+            if stream.HasField("last_backend_contact"):
+                contact = stream.last_backend_contact.ToDatetime().isoformat()
+            else:
+                contact = "never"
+            logger.info(
+                f"stream {stream.stream_id} last contacted the backend: {contact}"
+            )
 
     def init(self) -> Union[Run, RunDisabled]:  # noqa: C901
         if logger is None:
@@ -11,12 +11,12 @@ import psutil
 
 import wandb
 from wandb import env, trigger
-from wandb.errors import Error
+from wandb.errors import Error, UsageError
 from wandb.sdk.lib.exit_hooks import ExitHooks
 from wandb.sdk.lib.import_hooks import unregister_all_post_import_hooks
 
 if TYPE_CHECKING:
-    from wandb.proto import wandb_settings_pb2
+    from wandb.proto import wandb_server_pb2, wandb_settings_pb2
     from wandb.sdk.service import service
     from wandb.sdk.service.service_base import ServiceInterface
     from wandb.sdk.wandb_settings import Settings
@@ -211,12 +211,22 @@ class _Manager:
             response = svc_iface._svc_inform_attach(attach_id=attach_id)
         except Exception:
             return None
+        if response.HasField("error"):
+            raise UsageError(response.error.message)
         return response.settings
 
     def _inform_finish(self, run_id: Optional[str] = None) -> None:
         svc_iface = self._get_service_interface()
         svc_iface._svc_inform_finish(run_id=run_id)
 
+    def _ping(self, timeout: int) -> Optional["wandb_server_pb2.ServerPingResponse"]:
+        """Checks that the service is alive, or returns None if it isn't."""
+        svc_iface = self._get_service_interface()
+        try:
+            return svc_iface._svc_ping(timeout=timeout)
+        except Exception:
+            return None
+
     def _inform_teardown(self, exit_code: int) -> None:
         svc_iface = self._get_service_interface()
         svc_iface._svc_inform_teardown(exit_code)
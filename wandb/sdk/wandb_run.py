@@ -43,10 +43,12 @@ from wandb.apis import internal, public
 from wandb.apis.internal import Api
 from wandb.apis.public import Api as PublicApi
 from wandb.proto.wandb_internal_pb2 import (
+    DeferRequest,
     MetricRecord,
     PollExitResponse,
     Result,
     RunRecord,
+    SampledHistoryItem,
     ServerInfoResponse,
 )
 from wandb.sdk.artifacts.artifact import Artifact
@@ -105,6 +107,7 @@ if TYPE_CHECKING:
         CheckVersionResponse,
         GetSummaryResponse,
         InternalMessagesResponse,
+        RunExitResult,
         SampledHistoryResponse,
     )
 
@@ -564,6 +567,7 @@ class Run:
     _poll_exit_response: Optional[PollExitResponse]
     _server_info_response: Optional[ServerInfoResponse]
     _internal_messages_response: Optional["InternalMessagesResponse"]
+    _exit_result: Optional["RunExitResult"]
 
     _stdout_slave_fd: Optional[int]
     _stderr_slave_fd: Optional[int]
@@ -2605,7 +2609,9 @@ class Run:
         #     self._exit_code, settings=self._settings, printer=self._printer
         # )
 
-        _ = exit_handle.wait(timeout=-1, on_progress=self._on_progress_exit)
+        result = exit_handle.wait(timeout=-1, on_progress=self._on_progress_exit)
+        if result:
+            self._exit_result = result.exit_result
 
         poll_exit_handle = self._backend.interface.deliver_poll_exit()
         # wait for them, it's ok to do this serially but this can be improved
@@ -2664,6 +2670,7 @@ class Run:
             internal_messages_response=self._internal_messages_response,
             reporter=self._reporter,
             quiet=self._quiet,
+            discarded=bool(self._exit_result and self._exit_result.discarded),
             settings=self._settings,
             printer=self._printer,
         )
@@ -3725,10 +3732,16 @@ class Run:
         internal_messages_response: Optional["InternalMessagesResponse"] = None,
         reporter: Optional[Reporter] = None,
         quiet: Optional[bool] = None,
+        discarded: bool = False,
         *,
         settings: "Settings",
         printer: Union["PrinterTerm", "PrinterJupyter"],
     ) -> None:
+        if discarded:
+            Run._footer_discarded_info(quiet=quiet, settings=settings, printer=printer)
+            Run._footer_log_dir_info(quiet=quiet, settings=settings, printer=printer)
+            return
+
         Run._footer_history_summary_info(
             history=sampled_history,
             summary=final_summary,
@@ -3845,9 +3858,31 @@ class Run:
         megabyte = wandb.util.POW_2_BYTES[2][1]
         line = f"{progress.uploaded_bytes / megabyte :.3f} MB of {progress.total_bytes / megabyte:.3f} MB uploaded"
         if progress.deduped_bytes > 0:
-            line += f" ({progress.deduped_bytes / megabyte:.3f} MB deduped)\r"
-        else:
-            line += "\r"
+            line += f" ({progress.deduped_bytes / megabyte:.3f} MB deduped)"
+
+        # name the step the exit is stuck on, if any
+        exit_progress = poll_exit_response.exit_progress
+        if not done and exit_progress.seconds_in_state >= EXIT_TIMEOUT:
+            state = DeferRequest.DeferState.Name(exit_progress.state)
+            line += (
+                f"; still {exit_progress.description} ({state})"
+                f" after {int(exit_progress.seconds_in_state)}s"
+            )
+        if not done and exit_progress.pending_artifacts > 0:
+            line += f"; committing {exit_progress.pending_artifacts} artifact(s)"
+
+        # the mirror doesn't hold up the run, so it's only reported
+        if poll_exit_response.HasField("mirror_progress"):
+            mirror = poll_exit_response.mirror_progress
+            mirror_stats = mirror.pusher_stats
+            if mirror.error_message:
+                line += f"; mirror failed: {mirror.error_message}"
+            else:
+                line += (
+                    f"; mirror {mirror_stats.uploaded_bytes / megabyte:.3f} MB"
+                    f" of {mirror_stats.total_bytes / megabyte:.3f} MB"
+                )
+        line += "\r"
 
         percent_done = (
             1.0
@@ -3956,8 +3991,28 @@ class Run:
                     f"Synced {file_counts.wandb_count} W&B file(s), {file_counts.media_count} media file(s), "
                     f"{file_counts.artifact_count} artifact file(s) and {file_counts.other_count} other file(s)",
                 )
+                if file_counts.unchanged_count:
+                    info.append(
+                        f"Skipped re-uploading {file_counts.unchanged_count} unchanged file(s)"
+                    )
             printer.display(info)
 
+    @staticmethod
+    def _footer_discarded_info(
+        quiet: Optional[bool] = None,
+        *,
+        settings: "Settings",
+        printer: Union["PrinterTerm", "PrinterJupyter"],
+    ) -> None:
+        if settings.silent:
+            return
+
+        name = settings.run_name or settings.run_id
+        printer.display(
+            f"Run {printer.name(name)} was aborted: it was discarded and won't be synced.",
+            off=(quiet or settings.quiet),
+        )
+
     @staticmethod
     def _footer_log_dir_info(
         quiet: Optional[bool] = None,
@@ -3975,6 +4030,14 @@ class Run:
                 f"Find logs at: {printer.files(log_dir)}",
             )
 
+        # the core's lines for this run are in the shared log, by run ID
+        if settings._shared_internal_log:
+            shared_log = settings._shared_internal_log.replace(os.getcwd(), ".")
+            printer.display(
+                f"Find internal logs for run {settings.run_id} at:"
+                f" {printer.files(shared_log)}",
+            )
+
     @staticmethod
     def _footer_history_summary_info(
         history: Optional["SampledHistoryResponse"] = None,
@@ -3999,6 +4062,7 @@ class Run:
                 )
                 for item in history.item
                 if not item.key.startswith("_")
+                and item.value_type == SampledHistoryItem.NUMBER
             }
 
             history_rows = []
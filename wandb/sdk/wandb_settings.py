@@ -8,6 +8,7 @@ import multiprocessing
 import os
 import platform
 import re
+import shlex
 import shutil
 import socket
 import sys
@@ -126,6 +127,28 @@ def _str_as_tuple(val: Union[str, Sequence[str]]) -> Tuple[str, ...]:
     return tuple(val)
 
 
+def _str_as_labels(val: Union[str, Dict[str, str]]) -> Dict[str, str]:
+    """Parse a string of comma-separated key=value pairs as a dict."""
+    if not isinstance(val, str):
+        return dict(val)
+    labels = {}
+    for pair in val.split(","):
+        if not pair.strip():
+            continue
+        key, sep, value = pair.partition("=")
+        if not sep:
+            raise UsageError(f"Could not parse label {pair!r} as key=value.")
+        labels[key.strip()] = value.strip()
+    return labels
+
+
+def _str_as_args(val: Union[str, Sequence[str]]) -> Tuple[str, ...]:
+    """Parse a shell-quoted string of program arguments as a tuple."""
+    if isinstance(val, str):
+        return tuple(shlex.split(val))
+    return tuple(val)
+
+
 def _datetime_as_str(val: Union[datetime, str]) -> str:
     """Parse a datetime object as a string."""
     if isinstance(val, datetime):
@@ -135,7 +158,9 @@ def _datetime_as_str(val: Union[datetime, str]) -> str:
 
 def _redact_dict(
     d: Dict[str, Any],
-    unsafe_keys: Union[Set[str], FrozenSet[str]] = frozenset({"api_key"}),
+    unsafe_keys: Union[Set[str], FrozenSet[str]] = frozenset(
+        {"api_key", "_mirror_api_key"}
+    ),
     redact_str: str = "***REDACTED***",
 ) -> Dict[str, Any]:
     """Redact a dict of unsafe values specified by their key."""
@@ -302,7 +327,9 @@ class SettingsData:
     _code_path_local: str
     _colab: bool
     # _config_dict: Config
+    _core_rss_warning_mb: int  # wandb-core memory use to warn above, 0 never warns
     _cuda: str
+    _diagnostics_interval_seconds: float  # how often to log stream diagnostics
     _disable_meta: bool  # Do not collect system metadata
     _disable_service: (
         bool  # Disable wandb-service, spin up internal process the old way
@@ -310,15 +337,69 @@ class SettingsData:
     _disable_setproctitle: bool  # Do not use setproctitle on internal process
     _disable_stats: bool  # Do not collect system metrics
     _disable_update_check: bool  # Disable version check
+    _disable_generated_run_names: bool  # Do not generate missing run names
     _disable_viewer: bool  # Prevent early viewer query
     _disable_machine_info: bool  # Disable automatic machine info collection
     _executable: str
     _extra_http_headers: Mapping[str, str]
+    _fault_injection: str  # faults to inject into wandb-core, for testing
     # file stream retry client configuration
     _file_stream_retry_max: int  # max number of retries
     _file_stream_retry_wait_min_seconds: float  # min wait time between retries
     _file_stream_retry_wait_max_seconds: float  # max wait time between retries
     _file_stream_timeout_seconds: float  # timeout for individual HTTP requests
+    # how long to batch updates, and the bounds within which it adapts to the backlog
+    _file_stream_transmit_interval_seconds: float
+    _file_stream_min_transmit_interval_seconds: float
+    _file_stream_max_transmit_interval_seconds: float
+    _file_stream_max_request_bytes: int  # max request size; larger batches are split
+    # how long network errors last before going offline, and how often to probe
+    _network_offline_after_seconds: float
+    _network_probe_interval_seconds: float
+    _retry_budget_per_minute: int  # retries a minute per endpoint before warning
+    _summary_debounce_interval_seconds: float
+    _stream_state_interval_seconds: float  # how often to save the sync state
+    _exit_timeout_seconds: float  # 0 waits for all uploads at exit
+    _exit_grace_seconds: float  # data logged after exit is accepted for this long
+    _backpressure_slow_depth: int  # sender backlog at which intake slows, 0 never
+    _backpressure_congested_depth: int  # sender backlog at which records are rejected, 0 never
+    _run_labels: Dict[str, str]  # labels the infrastructure stamps on the run
+    _run_label_keys: Tuple[str]  # the label keys that may be stamped
+    _cleanup_max_age_days: int  # 0 keeps synced run dirs of any age
+    _cleanup_max_total_bytes: int  # 0 keeps synced run dirs of any size
+    _cleanup_dry_run: bool
+    _verify_on_exit: bool
+    _verify_timeout_seconds: float
+    _upload_bytes_per_second: int  # 0 doesn't cap upload bandwidth
+    _file_stream_bytes_per_second: int  # 0 doesn't cap filestream bandwidth
+    _record_sink_url: str  # an HTTP endpoint to mirror records to
+    _record_sink_types: Tuple[str]  # record types to mirror, or all if empty
+    _console_dedup_threshold: int  # 0 doesn't collapse repeated console lines
+    _console_timestamps: bool  # prefix console lines with when they were printed
+    _console_capture: str  # console streams to save: off, auto, stdout, stderr, both
+    _upload_url_batch_size: int  # files per upload URL request, 0 for the default
+    _artifact_full_rehash: bool  # hash every artifact file, without the digest cache
+    _export_dir: str  # a directory to mirror the run's files into
+    _export_hard_link: bool  # hard-link exported files instead of copying them
+    _mirror_base_url: str  # a second W&B server to mirror the run to
+    _mirror_api_key: str  # the API key for the mirror server
+    _program_override: str  # the program to record instead of the probed one
+    _args_override: Sequence[str]  # the arguments to record instead of the probed ones
+    _code_path_override: str  # the code path to record instead of the probed one
+    _root_dir_override: str  # the root directory to record instead of the probed one
+    _run_url_notify_url: str  # an endpoint to post the run's URL to
+    _dry_run: bool  # record the requests for the backend instead of sending them
+    _max_tracked_history_keys: int  # 0 summarizes and samples every history key
+    _max_reported_files: int  # 0 lists every file in the exit results
+    _low_latency: bool  # send records before saving them to the transaction log
+    _shared_internal_log: str  # one rotated internal log for all of the service's runs
+    # bounds on the service's per-run state, each 0 for no limit
+    _max_sampled_history_keys: int  # 10k by default, least recently logged dropped first
+    _max_summary_keys: int  # 100k by default
+    _max_partial_history_keys: int  # 100k by default, per history row
+    _max_metric_definitions: int  # 10k by default
+    _disable_clock_skew_correction: bool  # keep timestamps from a skewed clock
+    _max_history_rows_per_second: float  # 0 sends every history row
     # file transfer retry client configuration
     _file_transfer_retry_max: int
     _file_transfer_retry_wait_min_seconds: float
@@ -331,6 +412,11 @@ class SettingsData:
     _graphql_retry_wait_min_seconds: float
     _graphql_retry_wait_max_seconds: float
     _graphql_timeout_seconds: float
+    # HTTP connection pool tuning, for networks that drop idle connections
+    _http_disable_http2: bool
+    _http_idle_conn_timeout_seconds: float
+    _http_max_conns_per_host: int
+    _http_max_idle_conns_per_host: int
     _internal_check_process: float
     _internal_queue_timeout: float
     _ipython: bool
@@ -339,8 +425,10 @@ class SettingsData:
     _jupyter_path: str
     _jupyter_root: str
     _kaggle: bool
+    _label: str  # label of this process's records in shared-mode runs
     _live_policy_rate_limit: int
     _live_policy_wait_time: int
+    _log_format: str  # format of the core's internal log: "json" or "text"
     _log_level: int
     _network_buffer: int
     _noop: bool
@@ -349,6 +437,7 @@ class SettingsData:
     _sync: bool
     _os: str
     _platform: str
+    _primary: bool  # whether this is the primary process of a shared-mode run
     _proxies: Mapping[str, str]  # dedicated global proxy servers [scheme -> url]
     _python: str
     _runqueue_item_id: str
@@ -375,6 +464,7 @@ class SettingsData:
     _stats_open_metrics_filters: Union[Sequence[str], Mapping[str, Mapping[str, str]]]
     _stats_disk_paths: Sequence[str]  # paths to monitor disk usage
     _stats_buffer_size: int  # number of consolidated samples to buffer before flushing, available in run obj
+    _store_redacted_settings: bool  # Store settings without secrets in the .wandb file
     _tmp_code_dir: str
     _tracelog: str
     _unsaved_keys: Sequence[str]
@@ -386,6 +476,8 @@ class SettingsData:
     base_url: str  # The base url for the wandb api
     code_dir: str
     colab_url: str
+    config_exclude_keys: Tuple[str]  # Config keys not to send to the server
+    config_include_keys: Tuple[str]  # Config keys to send to the server
     config_paths: Sequence[str]
     console: str
     deployment: str
@@ -630,6 +722,8 @@ class Settings(SettingsData):
                 "hook": lambda _: "google.colab" in sys.modules,
                 "auto_hook": True,
             },
+            _core_rss_warning_mb={"value": 2048, "preprocessor": int},
+            _diagnostics_interval_seconds={"value": 0, "preprocessor": float},
             _disable_machine_info={
                 "value": False,
                 "preprocessor": _str_as_bool,
@@ -651,6 +745,7 @@ class Settings(SettingsData):
                 "hook": lambda x: self._disable_machine_info or x,
             },
             _disable_update_check={"preprocessor": _str_as_bool},
+            _disable_generated_run_names={"preprocessor": _str_as_bool},
             _disable_viewer={"preprocessor": _str_as_bool},
             _extra_http_headers={"preprocessor": _str_as_json},
             # Retry filestream requests for 2 hours before dropping chunk (how do we recover?)
@@ -662,6 +757,75 @@ class Settings(SettingsData):
             _file_stream_retry_wait_max_seconds={"value": 60, "preprocessor": float},
             # A 3 minute timeout for all filestream post requests
             _file_stream_timeout_seconds={"value": 180, "preprocessor": float},
+            _file_stream_max_request_bytes={"preprocessor": int},
+            _network_offline_after_seconds={"value": 120, "preprocessor": float},
+            _network_probe_interval_seconds={"value": 10, "preprocessor": float},
+            _retry_budget_per_minute={"preprocessor": int},
+            _summary_debounce_interval_seconds={"value": 30, "preprocessor": float},
+            _stream_state_interval_seconds={"value": 15, "preprocessor": float},
+            _exit_timeout_seconds={"preprocessor": float},
+            _exit_grace_seconds={"preprocessor": float},
+            _backpressure_slow_depth={"preprocessor": int},
+            _backpressure_congested_depth={"preprocessor": int},
+            _run_labels={"preprocessor": _str_as_labels},
+            _run_label_keys={"preprocessor": _str_as_tuple},
+            _cleanup_max_age_days={"preprocessor": int},
+            _cleanup_max_total_bytes={"preprocessor": int},
+            _cleanup_dry_run={"value": False, "preprocessor": _str_as_bool},
+            _verify_on_exit={"value": False, "preprocessor": _str_as_bool},
+            _verify_timeout_seconds={"value": 30, "preprocessor": float},
+            _upload_bytes_per_second={"preprocessor": int},
+            _file_stream_bytes_per_second={"preprocessor": int},
+            _record_sink_types={"value": tuple(), "preprocessor": _str_as_tuple},
+            _console_dedup_threshold={"preprocessor": int},
+            _console_timestamps={
+                "value": False,
+                "preprocessor": _str_as_bool,
+            },
+            _console_capture={
+                "value": "auto",
+                "validator": self._validate__console_capture,
+            },
+            _upload_url_batch_size={"preprocessor": int},
+            _artifact_full_rehash={"value": False, "preprocessor": _str_as_bool},
+            _export_dir={
+                "preprocessor": str,
+                "hook": lambda x: self._path_convert(x),
+            },
+            _export_hard_link={"value": False, "preprocessor": _str_as_bool},
+            _mirror_base_url={"preprocessor": lambda x: str(x).strip().rstrip("/")},
+            _mirror_api_key={"preprocessor": str},
+            _program_override={"preprocessor": str},
+            _args_override={"preprocessor": _str_as_args},
+            _code_path_override={"preprocessor": str},
+            _root_dir_override={"preprocessor": str},
+            _run_url_notify_url={"preprocessor": str},
+            _dry_run={"value": False, "preprocessor": _str_as_bool},
+            _max_tracked_history_keys={"preprocessor": int},
+            _max_reported_files={"preprocessor": int},
+            _low_latency={"value": False, "preprocessor": _str_as_bool},
+            _shared_internal_log={"preprocessor": str},
+            _max_sampled_history_keys={"preprocessor": int},
+            _max_summary_keys={"preprocessor": int},
+            _max_partial_history_keys={"preprocessor": int},
+            _max_metric_definitions={"preprocessor": int},
+            _disable_clock_skew_correction={
+                "value": False,
+                "preprocessor": _str_as_bool,
+            },
+            _max_history_rows_per_second={"preprocessor": float},
+            _file_stream_transmit_interval_seconds={
+                "value": 0.5,
+                "preprocessor": float,
+            },
+            _file_stream_min_transmit_interval_seconds={
+                "value": 0.02,
+                "preprocessor": float,
+            },
+            _file_stream_max_transmit_interval_seconds={
+                "value": 5,
+                "preprocessor": float,
+            },
             _file_transfer_retry_max={"value": 20, "preprocessor": int},
             _file_transfer_retry_wait_min_seconds={"value": 2, "preprocessor": float},
             _file_transfer_retry_wait_max_seconds={"value": 60, "preprocessor": float},
@@ -678,6 +842,10 @@ class Settings(SettingsData):
             _graphql_retry_wait_min_seconds={"value": 2, "preprocessor": float},
             _graphql_retry_wait_max_seconds={"value": 60, "preprocessor": float},
             _graphql_timeout_seconds={"value": 30.0, "preprocessor": float},
+            _http_disable_http2={"preprocessor": _str_as_bool},
+            _http_idle_conn_timeout_seconds={"preprocessor": float},
+            _http_max_conns_per_host={"preprocessor": int},
+            _http_max_idle_conns_per_host={"preprocessor": int},
             _internal_check_process={"value": 8, "preprocessor": float},
             _internal_queue_timeout={"value": 2, "preprocessor": float},
             _ipython={
@@ -689,6 +857,7 @@ class Settings(SettingsData):
                 "auto_hook": True,
             },
             _kaggle={"hook": lambda _: util._is_likely_kaggle(), "auto_hook": True},
+            _log_format={"validator": self._validate__log_format},
             _log_level={"value": logging.DEBUG},
             _network_buffer={"preprocessor": int},
             _noop={"hook": lambda _: self.mode == "disabled", "auto_hook": True},
@@ -708,6 +877,7 @@ class Settings(SettingsData):
                 "auto_hook": True,
             },
             _platform={"value": util.get_platform_name()},
+            _primary={"value": True, "preprocessor": _str_as_bool},
             _proxies={
                 "preprocessor": _str_as_json,
             },
@@ -753,6 +923,7 @@ class Settings(SettingsData):
                 "value": 0,
                 "preprocessor": int,
             },
+            _store_redacted_settings={"preprocessor": _str_as_bool},
             _sync={"value": False},
             _tmp_code_dir={
                 "value": "code",
@@ -773,6 +944,8 @@ class Settings(SettingsData):
                 "hook": lambda _: self._get_colab_url(),
                 "auto_hook": True,
             },
+            config_exclude_keys={"value": tuple(), "preprocessor": _str_as_tuple},
+            config_include_keys={"value": tuple(), "preprocessor": _str_as_tuple},
             config_paths={"preprocessor": _str_as_tuple},
             console={
                 "value": "auto",
@@ -920,8 +1093,8 @@ class Settings(SettingsData):
                 "auto_hook": True,
             },
             sync_file={
-                "hook": lambda _: self._path_convert(
-                    self.sync_dir, f"run-{self.run_id}.wandb"
+                "hook": lambda x: self._path_convert(
+                    self.sync_dir, x or f"run-{self.run_id}.wandb"
                 ),
                 "auto_hook": True,
             },
@@ -1146,6 +1319,22 @@ class Settings(SettingsData):
 
         return True
 
+    @staticmethod
+    def _validate__log_format(value: str) -> bool:
+        choices: Set[str] = {"json", "text"}
+        if value not in choices:
+            raise UsageError(f"Settings field `_log_format`: {value!r} not in {choices}")
+        return True
+
+    @staticmethod
+    def _validate__console_capture(value: str) -> bool:
+        choices: Set[str] = {"off", "auto", "stdout", "stderr", "both"}
+        if value not in choices:
+            raise UsageError(
+                f"Settings field `_console_capture`: {value!r} not in {choices}"
+            )
+        return True
+
     @staticmethod
     def _validate__service_wait(value: float) -> bool:
         if value <= 0:
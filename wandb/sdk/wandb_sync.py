@@ -19,6 +19,7 @@ def _sync(
     mark_synced: Optional[bool] = None,
     append: Optional[bool] = None,
     skip_console: Optional[bool] = None,
+    allow_modified_log: Optional[bool] = None,
 ) -> "wandb_internal_pb2.SyncResponse":
     p = pathlib.Path(path)
 
@@ -57,6 +58,7 @@ def _sync(
         project=project,
         run_id=run_id,
         skip_output_raw=skip_console,
+        allow_modified_log=allow_modified_log,
     )
     result = handle.wait(timeout=-1)
     assert result and result.response
@@ -1,3 +1,3 @@
 """module sync."""
 
-from .sync import SyncManager, get_run_from_path, get_runs  # noqa: F401
+from .sync import SyncManager, get_run_from_path, get_runs, is_synced  # noqa: F401
@@ -3,6 +3,7 @@
 import atexit
 import datetime
 import fnmatch
+import json
 import os
 import queue
 import sys
@@ -25,6 +26,27 @@ SYNCED_SUFFIX = ".synced"
 TFEVENT_SUBSTRING = ".tfevents."
 
 
+def is_synced(synced_file: str) -> bool:
+    """Return whether a run's synced marker says the run was fully synced.
+
+    Markers written by `wandb sync` are empty. wandb-core records how much
+    of a run reached the server while it runs, which only counts once the
+    whole run did.
+    """
+    try:
+        with open(synced_file) as f:
+            content = f.read()
+    except OSError:
+        return False
+    if not content.strip():
+        return True
+    try:
+        state = json.loads(content)
+    except ValueError:
+        return True
+    return not isinstance(state, dict) or bool(state.get("complete", True))
+
+
 class _LocalRun:
     def __init__(self, path, synced=None):
         self.path = path
@@ -428,7 +450,7 @@ def get_runs(
     for f in fnames:
         dname = os.path.dirname(f)
         # TODO(frz): online runs are assumed to be synced, verify from binary log.
-        if os.path.exists(f"{f}{SYNCED_SUFFIX}") or os.path.basename(dname).startswith(
+        if is_synced(f"{f}{SYNCED_SUFFIX}") or os.path.basename(dname).startswith(
             "run-"
         ):
             if include_synced: